@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/spf13/cobra"
+
+	"github.com/qinzj/claude-demo/internal/config"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+var adminInput domain.CreateUserInput
+
+var createAdminCmd = &cobra.Command{
+	Use:   "create-admin",
+	Short: "Create an administrator or grant the admin role to an existing user",
+	Long: `Bootstrap the first administrator. If the user already exists it is granted
+the built-in admin role; otherwise it is created with the given details.`,
+	RunE: runCreateAdmin,
+}
+
+func init() {
+	createAdminCmd.Flags().StringVar(&adminInput.Username, "username", "admin", "administrator username")
+	createAdminCmd.Flags().StringVar(&adminInput.Password, "password", "", "password for a new administrator")
+	createAdminCmd.Flags().StringVar(&adminInput.Email, "email", "", "email for a new administrator")
+	createAdminCmd.Flags().StringVar(&adminInput.DisplayName, "display-name", "Administrator", "display name for a new administrator")
+	rootCmd.AddCommand(createAdminCmd)
+}
+
+func runCreateAdmin(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	hasher, err := cfg.PasswordHash.Hasher()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	d, closeDB, err := openDAO(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	userSvc := service.NewUserService(d, cfg.Lockout.Policy(), hasher)
+	roleSvc := service.NewRoleService(d)
+	if _, err := service.NewPasswordPolicyService(d).EnsureDefaultPolicy(ctx); err != nil {
+		return fmt.Errorf("creating default password policy: %w", err)
+	}
+
+	u, err := userSvc.GetUserByUsername(ctx, adminInput.Username)
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			return fmt.Errorf("looking up user: %w", err)
+		}
+		if adminInput.Password == "" || adminInput.Email == "" {
+			return fmt.Errorf("user %q does not exist: --password and --email are required to create it", adminInput.Username)
+		}
+		if u, err = userSvc.CreateUser(ctx, adminInput); err != nil {
+			return fmt.Errorf("creating user: %w", err)
+		}
+	}
+
+	if err := roleSvc.GrantAdmin(ctx, u.ID); err != nil {
+		return fmt.Errorf("granting admin role: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "user %q now has the %q role\n", u.Username, domain.AdminRoleName)
+	return nil
+}
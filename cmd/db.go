@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/qinzj/claude-demo/internal/config"
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/ent"
+)
+
+// openDAO connects to the configured database and runs migrations. The
+// returned function closes the connection.
+func openDAO(ctx context.Context, cfg *config.Config) (*dao.DAO, func(), error) {
+	if err := cfg.Database.EnsureDataDir(); err != nil {
+		return nil, nil, fmt.Errorf("creating data directory: %w", err)
+	}
+
+	driver := cfg.Database.Driver
+	if driver == "" {
+		driver = "sqlite3"
+	}
+	entClient, err := ent.Open(driver, cfg.Database.DSN())
+	if err != nil {
+		return nil, nil, fmt.Errorf("connecting to database: %w", err)
+	}
+	closeFn := func() { _ = entClient.Close() }
+
+	d := dao.New(entClient)
+	if err := d.AutoMigrate(ctx); err != nil {
+		closeFn()
+		return nil, nil, fmt.Errorf("running migrations: %w", err)
+	}
+	return d, closeFn, nil
+}
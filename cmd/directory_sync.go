@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/spf13/cobra"
+
+	"github.com/qinzj/claude-demo/internal/config"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+var (
+	directorySyncDryRun bool
+	directorySyncJSON   bool
+)
+
+var directorySyncCmd = &cobra.Command{
+	Use:   "directory-sync [source...]",
+	Short: "Synchronize users and groups from upstream LDAP directories",
+	Long: `Synchronize the named sources configured under directory_sync, or all of
+them, once and print what was done. With --dry-run nothing is changed and the
+changes synchronizing would make are printed instead.`,
+	RunE: runDirectorySync,
+}
+
+func init() {
+	directorySyncCmd.Flags().BoolVar(&directorySyncDryRun, "dry-run", false, "only print the changes")
+	directorySyncCmd.Flags().BoolVar(&directorySyncJSON, "json", false, "print the reports as JSON")
+	rootCmd.AddCommand(directorySyncCmd)
+}
+
+func runDirectorySync(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	sources, err := cfg.DirectorySources()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		for _, src := range sources {
+			args = append(args, src.Name)
+		}
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("no directory sources configured")
+	}
+
+	hasher, err := cfg.PasswordHash.Hasher()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	d, closeDB, err := openDAO(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	if _, err := service.NewPasswordPolicyService(d).EnsureDefaultPolicy(ctx); err != nil {
+		return fmt.Errorf("creating default password policy: %w", err)
+	}
+	userSvc := service.NewUserService(d, cfg.Lockout.Policy(), hasher)
+	svc := service.NewDirectorySyncService(d, userSvc, service.NewGroupService(d), sources)
+
+	var reports []*domain.DirectorySyncReport
+	var failed []string
+	for _, name := range args {
+		report, err := svc.Sync(ctx, name, directorySyncDryRun)
+		if report == nil {
+			return err
+		}
+		reports = append(reports, report)
+		if err != nil || report.Users.Failed > 0 || report.Groups.Failed > 0 {
+			failed = append(failed, name)
+		}
+		if !directorySyncJSON {
+			printDirectorySyncReport(cmd.OutOrStdout(), report)
+		}
+	}
+	if directorySyncJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(reports); err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("synchronizing %s failed", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func printDirectorySyncReport(w io.Writer, r *domain.DirectorySyncReport) {
+	mode := ""
+	if r.DryRun {
+		mode = " (dry run)"
+	}
+	fmt.Fprintf(w, "source %s%s\n", r.Source, mode)
+	if r.Error != "" {
+		fmt.Fprintf(w, "  error: %s\n", r.Error)
+		return
+	}
+	for _, kind := range []struct {
+		name   string
+		counts domain.DirectorySyncCounts
+	}{{"users", r.Users}, {"groups", r.Groups}} {
+		c := kind.counts
+		fmt.Fprintf(w, "  %s: %d created, %d updated, %d disabled, %d deleted, %d unchanged, %d conflicts, %d failed\n",
+			kind.name, c.Created, c.Updated, c.Disabled, c.Deleted, c.Unchanged, c.Conflicts, c.Failed)
+	}
+	for _, c := range r.Changes {
+		line := fmt.Sprintf("  %-8s %-5s %s", c.Action, c.ResourceType, c.Name)
+		if len(c.Fields) > 0 {
+			line += " [" + strings.Join(c.Fields, ", ") + "]"
+		}
+		if c.Error != "" {
+			line += ": " + c.Error
+		}
+		fmt.Fprintln(w, line)
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/spf13/cobra"
+
+	"github.com/qinzj/claude-demo/internal/config"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+var rotateKeysCmd = &cobra.Command{
+	Use:   "rotate-keys",
+	Short: "Generate a new JWT signing key and retire the current one",
+	Long: `Rotate the JWT signing key immediately, e.g. after a suspected compromise.
+The retired key keeps verifying tokens for jwt.key_grace_hours; running servers
+pick up the new key within a minute.`,
+	RunE: runRotateKeys,
+}
+
+func init() {
+	rootCmd.AddCommand(rotateKeysCmd)
+}
+
+func runRotateKeys(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	ctx := cmd.Context()
+	d, closeDB, err := openDAO(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	keySvc := service.NewKeyService(d, cfg.JWT.SigningAlgorithm(), cfg.JWT.KeyRotation(), cfg.JWT.KeyGrace())
+	k, err := keySvc.Rotate(ctx)
+	if err != nil {
+		return fmt.Errorf("rotating signing key: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "new %s signing key %s is now active\n", k.Algorithm, k.ID)
+	return nil
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/qinzj/claude-demo/internal/config"
+	"github.com/qinzj/claude-demo/internal/domain"
+	ldaphandler "github.com/qinzj/claude-demo/internal/handler/ldap"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+var (
+	ldifDryRun bool
+	ldifJSON   bool
+)
+
+var ldifCmd = &cobra.Command{
+	Use:   "ldif",
+	Short: "Export and import users and groups as LDIF",
+}
+
+var ldifExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write all users and groups as an LDIF file",
+	Long: `Write all users and then all groups to the file, or to standard output, as
+LDIF (RFC 2849), with the DNs and attributes the LDAP server returns for them
+in the configured ldap.mode.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLDIFExport,
+}
+
+var ldifImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Apply an LDIF file to users and groups",
+	Long: `Apply the content and change records of the LDIF file, or of standard input,
+to users and groups and print what was done with each record. Entries are
+named as the LDAP server names them in the configured ldap.mode. With --dry-run
+nothing is changed and what importing would do is printed instead.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLDIFImport,
+}
+
+func init() {
+	ldifImportCmd.Flags().BoolVar(&ldifDryRun, "dry-run", false, "only print the changes")
+	ldifImportCmd.Flags().BoolVar(&ldifJSON, "json", false, "print the report as JSON")
+	ldifCmd.AddCommand(ldifExportCmd, ldifImportCmd)
+	rootCmd.AddCommand(ldifCmd)
+}
+
+func runLDIFExport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	hasher, err := cfg.PasswordHash.Hasher()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	d, closeDB, err := openDAO(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	h := ldaphandler.New(service.NewUserService(d, cfg.Lockout.Policy(), hasher), service.NewGroupService(d),
+		service.NewAttributeService(d), nil, nil, &cfg.LDAP, zap.NewNop())
+	if len(args) == 0 {
+		if err := h.ExportLDIF(ctx, cmd.OutOrStdout()); err != nil {
+			return fmt.Errorf("exporting LDIF: %w", err)
+		}
+		return nil
+	}
+	f, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	if err := h.ExportLDIF(ctx, f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("exporting LDIF: %w", err)
+	}
+	return f.Close()
+}
+
+func runLDIFImport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	hasher, err := cfg.PasswordHash.Hasher()
+	if err != nil {
+		return err
+	}
+
+	r := cmd.InOrStdin()
+	if len(args) == 1 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	ctx := cmd.Context()
+	d, closeDB, err := openDAO(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	if _, err := service.NewPasswordPolicyService(d).EnsureDefaultPolicy(ctx); err != nil {
+		return fmt.Errorf("creating default password policy: %w", err)
+	}
+	userSvc := service.NewUserService(d, cfg.Lockout.Policy(), hasher)
+	svc := service.NewLDIFService(d, userSvc, service.NewGroupService(d))
+	report, err := svc.Import(ctx, r, cfg.LDAP.Mode, cfg.LDAP.BaseDN, ldifDryRun)
+	if err != nil {
+		return err
+	}
+
+	if ldifJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printLDIFImportReport(cmd.OutOrStdout(), report)
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d records failed", report.Failed, len(report.Entries))
+	}
+	return nil
+}
+
+func printLDIFImportReport(w io.Writer, r *domain.LDIFImportReport) {
+	mode := ""
+	if r.DryRun {
+		mode = " (dry run)"
+	}
+	fmt.Fprintf(w, "%d created, %d updated, %d renamed, %d deleted, %d unchanged, %d skipped, %d failed%s\n",
+		r.Created, r.Updated, r.Renamed, r.Deleted, r.Unchanged, r.Skipped, r.Failed, mode)
+	for _, e := range r.Entries {
+		if e.Action == domain.LDIFImportUnchanged || e.Action == domain.LDIFImportSkip {
+			continue
+		}
+		line := fmt.Sprintf("  line %-5d %-9s %s", e.Line, e.Action, e.DN)
+		if len(e.Fields) > 0 {
+			line += " [" + strings.Join(e.Fields, ", ") + "]"
+		}
+		if e.Error != "" {
+			line += ": " + e.Error
+		}
+		fmt.Fprintln(w, line)
+	}
+	for _, e := range r.Entries {
+		if len(e.Ignored) > 0 {
+			fmt.Fprintf(w, "  line %-5d ignored %s\n", e.Line, strings.Join(e.Ignored, ", "))
+		}
+	}
+}
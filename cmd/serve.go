@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/jimlambrt/gldap"
 	_ "github.com/lib/pq"
@@ -70,12 +72,63 @@ func runServe(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Init services
-	userSvc := service.NewUserService(d)
+	hasher, err := cfg.PasswordHash.Hasher()
+	if err != nil {
+		logger.Fatal("invalid password hash configuration", zap.Error(err))
+	}
+	userSvc := service.NewUserService(d, cfg.Lockout.Policy(), hasher)
 	groupSvc := service.NewGroupService(d)
-	authSvc := service.NewAuthService(userSvc, cfg.JWT.Secret, cfg.JWT.ExpireHours)
+	attrSvc := service.NewAttributeService(d)
+	roleSvc := service.NewRoleService(d)
+	keySvc := service.NewKeyService(d, cfg.JWT.SigningAlgorithm(), cfg.JWT.KeyRotation(), cfg.JWT.KeyGrace())
+	mfaSvc := service.NewMFAService(d, userSvc, cfg.MFA.IssuerName())
+	authSvc := service.NewAuthService(d, userSvc, mfaSvc, keySvc, cfg.JWT.AccessTTL(), cfg.JWT.SessionTTL())
+	oidcSvc := service.NewOIDCService(d, authSvc, cfg.OIDCIssuer())
+	saSvc := service.NewServiceAccountService(d, keySvc, cfg.JWT.AccessTTL())
+	policySvc := service.NewPasswordPolicyService(d)
+	auditSvc := service.NewAuditService(d)
+	mailSender, err := cfg.Mail.Sender()
+	if err != nil {
+		logger.Fatal("invalid mail configuration", zap.Error(err))
+	}
+	if mailSender == nil {
+		logger.Info("no mail server configured, password reset links and invitations are disabled")
+	}
+	mailTemplates, err := cfg.Mail.Templates()
+	if err != nil {
+		logger.Fatal("failed to load mail templates", zap.Error(err))
+	}
+	resetSvc := service.NewPasswordResetService(d, userSvc, mailSender, mailTemplates, cfg.PasswordResetPolicy())
+	resetSvc.OnRequestError(func(err error) {
+		logger.Error("forgot-password request failed", zap.Error(err))
+	})
+	invitationSvc := service.NewInvitationService(d, userSvc, mfaSvc, mailSender, mailTemplates, cfg.InvitationPolicy())
+	webhookSvc := service.NewWebhookService(d, cfg.WebhookPolicy())
+	provisioningSvc := service.NewProvisioningService(d, cfg.ProvisioningPolicy())
+	directorySources, err := cfg.DirectorySources()
+	if err != nil {
+		logger.Fatal("invalid directory sync configuration", zap.Error(err))
+	}
+	directorySyncSvc := service.NewDirectorySyncService(d, userSvc, groupSvc, directorySources)
+	userSvc.UsePassThrough(service.NewPassThroughService(d, directorySources))
+	ldifSvc := service.NewLDIFService(d, userSvc, groupSvc)
+	csvSvc := service.NewCSVService(d, userSvc, groupSvc, invitationSvc)
+
+	if _, err := keySvc.RotateIfDue(cmd.Context()); err != nil {
+		logger.Fatal("failed to initialize signing keys", zap.Error(err))
+	}
+
+	if _, err := roleSvc.EnsureBuiltinRoles(cmd.Context()); err != nil {
+		logger.Fatal("failed to create built-in roles", zap.Error(err))
+	}
+	if _, err := policySvc.EnsureDefaultPolicy(cmd.Context()); err != nil {
+		logger.Fatal("failed to create default password policy", zap.Error(err))
+	}
+
+	ldapHandler := ldaphandler.New(userSvc, groupSvc, attrSvc, saSvc, mfaSvc, &cfg.LDAP, logger)
 
 	// Setup HTTP server
-	router := httphandler.SetupRouter(userSvc, groupSvc, authSvc, &cfg.LDAP, logger)
+	router := httphandler.SetupRouter(userSvc, groupSvc, authSvc, attrSvc, roleSvc, oidcSvc, saSvc, mfaSvc, policySvc, resetSvc, invitationSvc, auditSvc, webhookSvc, provisioningSvc, directorySyncSvc, ldifSvc, ldapHandler, csvSvc, &cfg.Server, &cfg.LDAP, &cfg.SelfService, logger)
 	httpAddr := fmt.Sprintf(":%d", cfg.Server.HTTPPort)
 	httpServer := &http.Server{
 		Addr:    httpAddr,
@@ -83,8 +136,7 @@ func runServe(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Setup LDAP server
-	ldapHandler := ldaphandler.New(userSvc, groupSvc, &cfg.LDAP, logger)
-	ldapServer, err := gldap.NewServer()
+	ldapServer, err := gldap.NewServer(gldap.WithOnClose(ldapHandler.ConnectionClosed))
 	if err != nil {
 		logger.Fatal("failed to create LDAP server", zap.Error(err))
 	}
@@ -96,7 +148,10 @@ func runServe(cmd *cobra.Command, _ []string) error {
 	ldapHandler.RegisterRoutes(mux)
 	ldapServer.Router(mux)
 
-	ldapAddr := fmt.Sprintf(":%d", cfg.LDAP.Port)
+	ldapListener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.LDAP.Port))
+	if err != nil {
+		logger.Fatal("failed to listen for LDAP", zap.Error(err))
+	}
 
 	// Error channel for server startup failures
 	errCh := make(chan error, 2)
@@ -112,11 +167,63 @@ func runServe(cmd *cobra.Command, _ []string) error {
 	// Start LDAP server
 	go func() {
 		logger.Info("LDAP server started", zap.Int("port", cfg.LDAP.Port))
-		if err := ldapServer.Run(ldapAddr); err != nil {
+		if err := ldapHandler.Serve(ldapServer, ldapListener); err != nil {
 			errCh <- fmt.Errorf("LDAP server error: %w", err)
 		}
 	}()
 
+	// Rotate signing keys on schedule; intervals are measured in days, so an
+	// hourly check is precise enough.
+	rotateCtx, stopRotation := context.WithCancel(cmd.Context())
+	defer stopRotation()
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-rotateCtx.Done():
+				return
+			case <-ticker.C:
+				rotated, err := keySvc.RotateIfDue(rotateCtx)
+				if err != nil {
+					logger.Error("signing key rotation failed", zap.Error(err))
+				} else if rotated {
+					logger.Info("rotated JWT signing key")
+				}
+			}
+		}
+	}()
+
+	// Deliver webhooks for the events the DAO writes to the outbox.
+	webhookCtx, stopWebhooks := context.WithCancel(cmd.Context())
+	defer stopWebhooks()
+	go webhookSvc.Run(webhookCtx, func(err error) {
+		logger.Error("webhook dispatch failed", zap.Error(err))
+	})
+
+	// Synchronize provisioning connectors with their applications.
+	provisioningCtx, stopProvisioning := context.WithCancel(cmd.Context())
+	defer stopProvisioning()
+	go provisioningSvc.Run(provisioningCtx, func(err error) {
+		logger.Error("provisioning sync failed", zap.Error(err))
+	})
+
+	// Synchronize users and groups from upstream directories.
+	directorySyncCtx, stopDirectorySync := context.WithCancel(cmd.Context())
+	defer stopDirectorySync()
+	go directorySyncSvc.Run(directorySyncCtx, func(err error) {
+		logger.Error("directory sync failed", zap.Error(err))
+	})
+
+	// Send the reset links requested through forgot-password.
+	resetCtx, stopResets := context.WithCancel(context.Background())
+	defer stopResets()
+	resetsDone := make(chan struct{})
+	go func() {
+		resetSvc.Run(resetCtx)
+		close(resetsDone)
+	}()
+
 	// Wait for interrupt signal or server error
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -141,6 +248,14 @@ func runServe(cmd *cobra.Command, _ []string) error {
 		logger.Error("LDAP server shutdown error", zap.Error(err))
 	}
 
+	// No more links can be requested; send the queued ones.
+	stopResets()
+	select {
+	case <-resetsDone:
+	case <-ctx.Done():
+		logger.Error("password reset links still queued at shutdown")
+	}
+
 	logger.Info("Servers stopped")
 	return nil
 }
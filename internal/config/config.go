@@ -4,24 +4,46 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ldap/attrs"
+	"github.com/qinzj/claude-demo/internal/ldap/upstream"
+	"github.com/qinzj/claude-demo/internal/mail"
+	"github.com/qinzj/claude-demo/internal/password"
 	"github.com/qinzj/claude-demo/pkg/logs"
 )
 
 // Config holds all configuration for the application.
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	LDAP     LDAPConfig     `mapstructure:"ldap"`
-	Log      LogConfig      `mapstructure:"log"`
-	JWT      JWTConfig      `mapstructure:"jwt"`
+	Server        ServerConfig        `mapstructure:"server"`
+	Database      DatabaseConfig      `mapstructure:"database"`
+	LDAP          LDAPConfig          `mapstructure:"ldap"`
+	Log           LogConfig           `mapstructure:"log"`
+	JWT           JWTConfig           `mapstructure:"jwt"`
+	SelfService   SelfServiceConfig   `mapstructure:"self_service"`
+	OIDC          OIDCConfig          `mapstructure:"oidc"`
+	MFA           MFAConfig           `mapstructure:"mfa"`
+	Lockout       LockoutConfig       `mapstructure:"lockout"`
+	PasswordHash  PasswordHashConfig  `mapstructure:"password_hash"`
+	Mail          MailConfig          `mapstructure:"mail"`
+	PasswordReset PasswordResetConfig `mapstructure:"password_reset"`
+	Invitation    InvitationConfig    `mapstructure:"invitation"`
+	Webhook       WebhookConfig       `mapstructure:"webhook"`
+	Provisioning  ProvisioningConfig  `mapstructure:"provisioning"`
+	DirectorySync DirectorySyncConfig `mapstructure:"directory_sync"`
 }
 
 // ServerConfig holds HTTP server configuration.
 type ServerConfig struct {
 	HTTPPort int `mapstructure:"http_port"`
+	// TrustedProxies lists the addresses or CIDR ranges of the reverse proxies
+	// whose X-Forwarded-For header is honoured. Empty trusts no proxy, so the
+	// client IP is always the address of the connection.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 // DatabaseConfig holds database connection configuration.
@@ -61,6 +83,7 @@ type LDAPConfig struct {
 	Port   int    `mapstructure:"port"`    // LDAP server port
 	BaseDN string `mapstructure:"base_dn"` // Base DN, e.g. "dc=example,dc=com"
 	Mode   string `mapstructure:"mode"`    // "openldap" | "activedirectory"
+	OTP    bool   `mapstructure:"otp"`     // users with MFA enabled bind with password followed by a TOTP code
 }
 
 // LogConfig holds logging configuration.
@@ -104,8 +127,635 @@ func (l LogConfig) ToLogsConfig() logs.Config {
 
 // JWTConfig holds JWT authentication configuration.
 type JWTConfig struct {
-	Secret      string `mapstructure:"secret"`       // JWT signing secret, change in production
-	ExpireHours int    `mapstructure:"expire_hours"` // token expiration in hours
+	Algorithm           string `mapstructure:"algorithm"`             // signing algorithm: "RS256" | "ES256" | "EdDSA"
+	KeyRotationDays     int    `mapstructure:"key_rotation_days"`     // generate a new signing key after this many days
+	KeyGraceHours       int    `mapstructure:"key_grace_hours"`       // keep verifying with retired keys for this many hours
+	ExpireHours         int    `mapstructure:"expire_hours"`          // session (refresh token) lifetime in hours
+	AccessExpireMinutes int    `mapstructure:"access_expire_minutes"` // access token lifetime in minutes
+}
+
+// Defaults used when the JWT configuration leaves a value unset.
+const (
+	DefaultSigningAlgorithm = "RS256"
+	DefaultKeyRotation      = 30 * 24 * time.Hour
+	DefaultKeyGrace         = 24 * time.Hour
+	DefaultSessionTTL       = 24 * time.Hour
+	DefaultAccessTTL        = 15 * time.Minute
+)
+
+// SigningAlgorithm returns the algorithm used for newly generated signing keys.
+func (j JWTConfig) SigningAlgorithm() string {
+	if j.Algorithm == "" {
+		return DefaultSigningAlgorithm
+	}
+	return j.Algorithm
+}
+
+// KeyRotation returns how long a signing key is used before it is rotated.
+func (j JWTConfig) KeyRotation() time.Duration {
+	if j.KeyRotationDays <= 0 {
+		return DefaultKeyRotation
+	}
+	return time.Duration(j.KeyRotationDays) * 24 * time.Hour
+}
+
+// KeyGrace returns how long a retired signing key still verifies tokens. It
+// is never shorter than the access token lifetime, so tokens signed just
+// before a rotation stay valid until they expire.
+func (j JWTConfig) KeyGrace() time.Duration {
+	grace := DefaultKeyGrace
+	if j.KeyGraceHours > 0 {
+		grace = time.Duration(j.KeyGraceHours) * time.Hour
+	}
+	return max(grace, j.AccessTTL())
+}
+
+// SessionTTL returns how long a login session and its refresh token stay valid.
+func (j JWTConfig) SessionTTL() time.Duration {
+	if j.ExpireHours <= 0 {
+		return DefaultSessionTTL
+	}
+	return time.Duration(j.ExpireHours) * time.Hour
+}
+
+// AccessTTL returns how long an access token stays valid.
+func (j JWTConfig) AccessTTL() time.Duration {
+	if j.AccessExpireMinutes <= 0 {
+		return DefaultAccessTTL
+	}
+	return time.Duration(j.AccessExpireMinutes) * time.Minute
+}
+
+// SelfServiceConfig holds configuration for the /me endpoints.
+type SelfServiceConfig struct {
+	EditableFields []string `mapstructure:"editable_fields"` // profile fields users may edit: "display_name" | "email" | "phone"
+}
+
+// DefaultEditableFields are the profile fields users may edit when none are configured.
+var DefaultEditableFields = []string{"display_name", "phone"}
+
+// IsEditable reports whether users may edit the given profile field themselves.
+func (s SelfServiceConfig) IsEditable(field string) bool {
+	fields := s.EditableFields
+	if len(fields) == 0 {
+		fields = DefaultEditableFields
+	}
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// OIDCConfig holds configuration for the built-in OpenID Connect provider.
+type OIDCConfig struct {
+	Issuer string `mapstructure:"issuer"` // external base URL, e.g. "https://sso.example.com"
+}
+
+// OIDCIssuer returns the configured issuer URL without a trailing slash,
+// defaulting to the local HTTP address.
+func (c Config) OIDCIssuer() string {
+	if c.OIDC.Issuer == "" {
+		return fmt.Sprintf("http://localhost:%d", c.Server.HTTPPort)
+	}
+	return strings.TrimRight(c.OIDC.Issuer, "/")
+}
+
+// MFAConfig holds multi-factor authentication configuration.
+type MFAConfig struct {
+	Issuer string `mapstructure:"issuer"` // account issuer shown in authenticator apps
+}
+
+// DefaultMFAIssuer is the authenticator app issuer used when none is configured.
+const DefaultMFAIssuer = "User Manager"
+
+// IssuerName returns the issuer shown in authenticator apps.
+func (m MFAConfig) IssuerName() string {
+	if m.Issuer == "" {
+		return DefaultMFAIssuer
+	}
+	return m.Issuer
+}
+
+// LockoutConfig holds account lockout and brute-force protection
+// configuration. Unset values take the defaults below; a negative threshold
+// disables the corresponding protection.
+type LockoutConfig struct {
+	Threshold          int `mapstructure:"threshold"`            // failed logins that lock an account
+	DurationMinutes    int `mapstructure:"duration_minutes"`     // first lockout, doubled for each further lockout
+	MaxDurationMinutes int `mapstructure:"max_duration_minutes"` // longest lockout
+	IPThreshold        int `mapstructure:"ip_threshold"`         // failed logins that block a client IP address
+	WindowMinutes      int `mapstructure:"window_minutes"`       // how long a failed login counts
+}
+
+// Defaults used when the lockout configuration leaves a value unset.
+const (
+	DefaultLockoutThreshold   = 5
+	DefaultLockoutDuration    = 15 * time.Minute
+	DefaultLockoutMaxDuration = 24 * time.Hour
+	DefaultLockoutIPThreshold = 50
+	DefaultLockoutWindow      = 15 * time.Minute
+)
+
+// Policy returns the lockout policy with defaults applied.
+func (l LockoutConfig) Policy() domain.LockoutPolicy {
+	p := domain.LockoutPolicy{
+		Threshold:   limit(l.Threshold, DefaultLockoutThreshold),
+		Duration:    DefaultLockoutDuration,
+		MaxDuration: DefaultLockoutMaxDuration,
+		IPThreshold: limit(l.IPThreshold, DefaultLockoutIPThreshold),
+		Window:      DefaultLockoutWindow,
+	}
+	if l.DurationMinutes > 0 {
+		p.Duration = time.Duration(l.DurationMinutes) * time.Minute
+	}
+	if l.MaxDurationMinutes > 0 {
+		p.MaxDuration = time.Duration(l.MaxDurationMinutes) * time.Minute
+	}
+	p.MaxDuration = max(p.MaxDuration, p.Duration)
+	if l.WindowMinutes > 0 {
+		p.Window = time.Duration(l.WindowMinutes) * time.Minute
+	}
+	return p
+}
+
+// limit applies the default to an unset threshold or limit and maps a
+// negative one to zero, which disables the protection.
+func limit(v, def int) int {
+	switch {
+	case v == 0:
+		return def
+	case v < 0:
+		return 0
+	}
+	return v
+}
+
+// PasswordHashConfig holds how new passwords are hashed. Existing hashes in
+// other schemes, or with other parameters, are rehashed at the next login.
+type PasswordHashConfig struct {
+	Algorithm         string `mapstructure:"algorithm"`          // "bcrypt" | "argon2id"
+	BcryptCost        int    `mapstructure:"bcrypt_cost"`        // bcrypt work factor, 4-31
+	Argon2MemoryKiB   uint32 `mapstructure:"argon2_memory_kib"`  // argon2id memory in KiB
+	Argon2Iterations  uint32 `mapstructure:"argon2_iterations"`  // argon2id passes over memory
+	Argon2Parallelism uint8  `mapstructure:"argon2_parallelism"` // argon2id threads
+}
+
+// Hasher returns the password hasher, with defaults applied to unset
+// values, or an error if the algorithm or its parameters are invalid.
+func (p PasswordHashConfig) Hasher() (password.Hasher, error) {
+	h := password.Hasher{
+		Algorithm:         p.Algorithm,
+		BcryptCost:        p.BcryptCost,
+		Argon2Memory:      p.Argon2MemoryKiB,
+		Argon2Iterations:  p.Argon2Iterations,
+		Argon2Parallelism: p.Argon2Parallelism,
+	}
+	if err := h.Validate(); err != nil {
+		return password.Hasher{}, fmt.Errorf("password_hash: %w", err)
+	}
+	return h, nil
+}
+
+// MailConfig holds the SMTP server notification emails are sent through.
+// Without a host no emails are sent, and password reset links and
+// invitations are off.
+type MailConfig struct {
+	Host        string `mapstructure:"host"`         // SMTP server host
+	Port        int    `mapstructure:"port"`         // defaults to 587, 465 or 25 by TLS mode
+	Username    string `mapstructure:"username"`     // PLAIN authentication, if set
+	Password    string `mapstructure:"password"`     // PLAIN authentication password
+	From        string `mapstructure:"from"`         // sender, e.g. "User Manager <noreply@example.com>"
+	TLS         string `mapstructure:"tls"`          // "starttls" | "tls" | "none"
+	TemplateDir string `mapstructure:"template_dir"` // directory of <name>.tmpl files replacing the built-in templates
+}
+
+// Sender returns the SMTP sender, nil if no host is configured, or an
+// error if the configuration is incomplete.
+func (m MailConfig) Sender() (mail.Sender, error) {
+	if m.Host == "" {
+		return nil, nil
+	}
+	s := &mail.SMTP{
+		Host:     m.Host,
+		Port:     m.Port,
+		Username: m.Username,
+		Password: m.Password,
+		From:     m.From,
+		TLS:      m.TLS,
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("mail: %w", err)
+	}
+	return s, nil
+}
+
+// Templates loads the email templates, with those in TemplateDir replacing
+// the built-in ones.
+func (m MailConfig) Templates() (*mail.Templates, error) {
+	t, err := mail.LoadTemplates(m.TemplateDir)
+	if err != nil {
+		return nil, fmt.Errorf("mail: %w", err)
+	}
+	return t, nil
+}
+
+// PasswordResetConfig holds configuration for emailed password reset links.
+// Unset values take the defaults below; a negative limit disables it.
+type PasswordResetConfig struct {
+	URL             string `mapstructure:"url"`               // reset page of the web UI, default "<oidc issuer>/reset-password"
+	TokenTTLMinutes int    `mapstructure:"token_ttl_minutes"` // how long a link stays valid
+	UserLimit       int    `mapstructure:"user_limit"`        // links sent to one user per window
+	IPLimit         int    `mapstructure:"ip_limit"`          // forgot-password requests from one IP address per window
+	WindowMinutes   int    `mapstructure:"window_minutes"`    // rate limit window
+}
+
+// Defaults used when the password reset configuration leaves a value unset.
+const (
+	DefaultPasswordResetTTL       = 30 * time.Minute
+	DefaultPasswordResetUserLimit = 3
+	DefaultPasswordResetIPLimit   = 20
+	DefaultPasswordResetWindow    = time.Hour
+)
+
+// PasswordResetPolicy returns the password reset policy with defaults
+// applied.
+func (c Config) PasswordResetPolicy() domain.PasswordResetPolicy {
+	r := c.PasswordReset
+	p := domain.PasswordResetPolicy{
+		URL:       r.URL,
+		TokenTTL:  DefaultPasswordResetTTL,
+		UserLimit: limit(r.UserLimit, DefaultPasswordResetUserLimit),
+		IPLimit:   limit(r.IPLimit, DefaultPasswordResetIPLimit),
+		Window:    DefaultPasswordResetWindow,
+	}
+	if p.URL == "" {
+		p.URL = c.OIDCIssuer() + "/reset-password"
+	}
+	if r.TokenTTLMinutes > 0 {
+		p.TokenTTL = time.Duration(r.TokenTTLMinutes) * time.Minute
+	}
+	if r.WindowMinutes > 0 {
+		p.Window = time.Duration(r.WindowMinutes) * time.Minute
+	}
+	return p
+}
+
+// InvitationConfig holds configuration for emailed invitations of new users.
+type InvitationConfig struct {
+	URL           string `mapstructure:"url"`             // invitation page of the web UI, default "<oidc issuer>/accept-invitation"
+	TokenTTLHours int    `mapstructure:"token_ttl_hours"` // how long an invitation stays valid
+}
+
+// DefaultInvitationTTL is how long an invitation stays valid unless
+// configured otherwise.
+const DefaultInvitationTTL = 72 * time.Hour
+
+// InvitationPolicy returns the invitation policy with defaults applied.
+func (c Config) InvitationPolicy() domain.InvitationPolicy {
+	p := domain.InvitationPolicy{URL: c.Invitation.URL, TokenTTL: DefaultInvitationTTL}
+	if p.URL == "" {
+		p.URL = c.OIDCIssuer() + "/accept-invitation"
+	}
+	if c.Invitation.TokenTTLHours > 0 {
+		p.TokenTTL = time.Duration(c.Invitation.TokenTTLHours) * time.Hour
+	}
+	return p
+}
+
+// WebhookConfig holds configuration for delivering webhooks.
+type WebhookConfig struct {
+	MaxAttempts         int `mapstructure:"max_attempts"`          // attempts before a delivery is dead-lettered
+	TimeoutSeconds      int `mapstructure:"timeout_seconds"`       // timeout of each attempt
+	RetryBackoffSeconds int `mapstructure:"retry_backoff_seconds"` // delay before the first retry, doubling with each further retry
+	MaxBackoffMinutes   int `mapstructure:"max_backoff_minutes"`   // longest delay between retries
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"` // how often new events and due retries are looked for
+	RetentionDays       int `mapstructure:"retention_days"`        // how long dispatched events and finished deliveries are kept
+}
+
+// Webhook delivery defaults.
+const (
+	DefaultWebhookMaxAttempts  = 8
+	DefaultWebhookTimeout      = 10 * time.Second
+	DefaultWebhookRetryBackoff = 30 * time.Second
+	DefaultWebhookMaxBackoff   = time.Hour
+	DefaultWebhookPollInterval = 5 * time.Second
+	DefaultWebhookRetention    = 30 * 24 * time.Hour
+)
+
+// WebhookPolicy returns the webhook delivery policy with defaults applied.
+func (c Config) WebhookPolicy() domain.WebhookPolicy {
+	w := c.Webhook
+	p := domain.WebhookPolicy{
+		MaxAttempts:  DefaultWebhookMaxAttempts,
+		Timeout:      DefaultWebhookTimeout,
+		RetryBackoff: DefaultWebhookRetryBackoff,
+		MaxBackoff:   DefaultWebhookMaxBackoff,
+		PollInterval: DefaultWebhookPollInterval,
+		Retention:    DefaultWebhookRetention,
+	}
+	if w.MaxAttempts > 0 {
+		p.MaxAttempts = w.MaxAttempts
+	}
+	if w.TimeoutSeconds > 0 {
+		p.Timeout = time.Duration(w.TimeoutSeconds) * time.Second
+	}
+	if w.RetryBackoffSeconds > 0 {
+		p.RetryBackoff = time.Duration(w.RetryBackoffSeconds) * time.Second
+	}
+	if w.MaxBackoffMinutes > 0 {
+		p.MaxBackoff = time.Duration(w.MaxBackoffMinutes) * time.Minute
+	}
+	if w.PollIntervalSeconds > 0 {
+		p.PollInterval = time.Duration(w.PollIntervalSeconds) * time.Second
+	}
+	if w.RetentionDays > 0 {
+		p.Retention = time.Duration(w.RetentionDays) * 24 * time.Hour
+	}
+	return p
+}
+
+// ProvisioningConfig holds configuration for synchronizing provisioning
+// connectors.
+type ProvisioningConfig struct {
+	TimeoutSeconds           int `mapstructure:"timeout_seconds"`            // timeout of each request to an application
+	PollIntervalSeconds      int `mapstructure:"poll_interval_seconds"`      // how often changes are looked for
+	ReconcileIntervalMinutes int `mapstructure:"reconcile_interval_minutes"` // how often each connector is fully synchronized
+}
+
+// Provisioning defaults.
+const (
+	DefaultProvisioningTimeout           = 10 * time.Second
+	DefaultProvisioningPollInterval      = 10 * time.Second
+	DefaultProvisioningReconcileInterval = time.Hour
+)
+
+// ProvisioningPolicy returns the provisioning policy with defaults applied.
+func (c Config) ProvisioningPolicy() domain.ProvisioningPolicy {
+	pc := c.Provisioning
+	p := domain.ProvisioningPolicy{
+		Timeout:           DefaultProvisioningTimeout,
+		PollInterval:      DefaultProvisioningPollInterval,
+		ReconcileInterval: DefaultProvisioningReconcileInterval,
+	}
+	if pc.TimeoutSeconds > 0 {
+		p.Timeout = time.Duration(pc.TimeoutSeconds) * time.Second
+	}
+	if pc.PollIntervalSeconds > 0 {
+		p.PollInterval = time.Duration(pc.PollIntervalSeconds) * time.Second
+	}
+	if pc.ReconcileIntervalMinutes > 0 {
+		p.ReconcileInterval = time.Duration(pc.ReconcileIntervalMinutes) * time.Minute
+	}
+	return p
+}
+
+// DirectorySyncConfig holds the upstream LDAP directories users and groups
+// are synchronized from.
+type DirectorySyncConfig struct {
+	Sources []DirectorySourceConfig `mapstructure:"sources"`
+}
+
+// DirectorySourceConfig holds an upstream LDAP directory. Filters and
+// attributes left unset take the defaults of its type.
+type DirectorySourceConfig struct {
+	Name               string                     `mapstructure:"name"`                 // identifies the source in reports and the API
+	Type               string                     `mapstructure:"type"`                 // "openldap" | "activedirectory"
+	URL                string                     `mapstructure:"url"`                  // ldap://host:389 or ldaps://host:636
+	BindDN             string                     `mapstructure:"bind_dn"`              // anonymous if empty
+	BindPassword       string                     `mapstructure:"bind_password"`        // bind password
+	StartTLS           bool                       `mapstructure:"start_tls"`            // upgrade ldap:// connections with StartTLS
+	InsecureSkipVerify bool                       `mapstructure:"insecure_skip_verify"` // do not verify the server certificate
+	BaseDN             string                     `mapstructure:"base_dn"`              // base of user and group searches
+	TimeoutSeconds     int                        `mapstructure:"timeout_seconds"`      // timeout of each request to the directory
+	IntervalMinutes    int                        `mapstructure:"interval_minutes"`     // how often the source is synchronized; 0 only on demand
+	Conflict           string                     `mapstructure:"conflict"`             // taken usernames and group names: "skip" | "adopt"
+	Delete             string                     `mapstructure:"delete"`               // users gone upstream: "disable" | "delete" | "keep"
+	SyncGroups         *bool                      `mapstructure:"sync_groups"`          // synchronize groups and memberships, default true
+	Users              DirectoryUserConfig        `mapstructure:"users"`                // user search and attributes
+	Groups             DirectoryGroupConfig       `mapstructure:"groups"`               // group search and attributes
+	PassThrough        DirectoryPassThroughConfig `mapstructure:"pass_through"`         // users authenticated by the directory
+}
+
+// DirectoryPassThroughConfig holds the users whose passwords are verified
+// by binding to an upstream directory as them, instead of against the
+// stored hash.
+type DirectoryPassThroughConfig struct {
+	Users             []string `mapstructure:"users"`              // usernames
+	Groups            []string `mapstructure:"groups"`             // names of groups whose direct members are included
+	SynchronizedUsers bool     `mapstructure:"synchronized_users"` // include all users synchronized from the source
+	CapturePassword   bool     `mapstructure:"capture_password"`   // store verified passwords, hashed, for when pass-through is turned off
+}
+
+// DirectoryUserConfig holds where users are found in an upstream directory
+// and which attributes their fields are read from.
+type DirectoryUserConfig struct {
+	BaseDN         string            `mapstructure:"base_dn"`         // defaults to the source's base_dn
+	Filter         string            `mapstructure:"filter"`          // entries synchronized as users
+	DisabledFilter string            `mapstructure:"disabled_filter"` // users synchronized as disabled
+	IDAttribute    string            `mapstructure:"id_attribute"`    // immutable identifier, e.g. entryUUID or objectGUID
+	Username       string            `mapstructure:"username"`
+	DisplayName    string            `mapstructure:"display_name"`
+	Email          string            `mapstructure:"email"`
+	Phone          string            `mapstructure:"phone"`
+	Password       string            `mapstructure:"password"`   // password hashes such as {SSHA}, if readable
+	Attributes     map[string]string `mapstructure:"attributes"` // upstream attribute: user extension attribute
+}
+
+// DirectoryGroupConfig holds where groups are found in an upstream
+// directory and which attributes their fields are read from.
+type DirectoryGroupConfig struct {
+	BaseDN      string            `mapstructure:"base_dn"`      // defaults to the source's base_dn
+	Filter      string            `mapstructure:"filter"`       // entries synchronized as groups
+	IDAttribute string            `mapstructure:"id_attribute"` // immutable identifier
+	Name        string            `mapstructure:"name"`
+	Description string            `mapstructure:"description"`
+	Member      string            `mapstructure:"member"`     // DNs or usernames of the members
+	Attributes  map[string]string `mapstructure:"attributes"` // upstream attribute: group extension attribute
+}
+
+// DefaultDirectoryTimeout is the timeout of requests to an upstream
+// directory unless configured otherwise.
+const DefaultDirectoryTimeout = 10 * time.Second
+
+// directoryDefaults are the filters and attributes of each type of
+// upstream directory.
+var directoryDefaults = map[string]struct {
+	users  domain.DirectoryUserMapping
+	groups domain.DirectoryGroupMapping
+}{
+	attrs.ModeOpenLDAP: {
+		users: domain.DirectoryUserMapping{
+			Filter:      "(objectClass=inetOrgPerson)",
+			IDAttribute: "entryUUID",
+			Username:    "uid",
+			DisplayName: "cn",
+			Email:       "mail",
+			Phone:       "telephoneNumber",
+		},
+		groups: domain.DirectoryGroupMapping{
+			Filter:      "(objectClass=groupOfNames)",
+			IDAttribute: "entryUUID",
+			Name:        "cn",
+			Description: "description",
+			Member:      "member",
+		},
+	},
+	attrs.ModeActiveDirectory: {
+		users: domain.DirectoryUserMapping{
+			Filter:         "(&(objectCategory=person)(objectClass=user))",
+			DisabledFilter: "(userAccountControl:1.2.840.113556.1.4.803:=2)",
+			IDAttribute:    "objectGUID",
+			Username:       "sAMAccountName",
+			DisplayName:    "displayName",
+			Email:          "mail",
+			Phone:          "telephoneNumber",
+		},
+		groups: domain.DirectoryGroupMapping{
+			Filter:      "(objectClass=group)",
+			IDAttribute: "objectGUID",
+			Name:        "cn",
+			Description: "description",
+			Member:      "member",
+		},
+	},
+}
+
+// DirectorySources returns the upstream directories with defaults applied,
+// or an error if one is invalid.
+func (c Config) DirectorySources() ([]domain.DirectorySource, error) {
+	sources := make([]domain.DirectorySource, 0, len(c.DirectorySync.Sources))
+	names := make(map[string]bool)
+	for i, sc := range c.DirectorySync.Sources {
+		src, err := sc.source()
+		if err != nil {
+			name := sc.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			return nil, fmt.Errorf("directory_sync: source %s: %w", name, err)
+		}
+		if names[src.Name] {
+			return nil, fmt.Errorf("directory_sync: duplicate source %s", src.Name)
+		}
+		names[src.Name] = true
+		sources = append(sources, src)
+	}
+	return sources, nil
+}
+
+func (sc DirectorySourceConfig) source() (domain.DirectorySource, error) {
+	if sc.Name == "" {
+		return domain.DirectorySource{}, fmt.Errorf("name is required")
+	}
+	if sc.Type == "" {
+		sc.Type = attrs.ModeOpenLDAP
+	}
+	defaults, ok := directoryDefaults[sc.Type]
+	if !ok {
+		return domain.DirectorySource{}, fmt.Errorf("unknown type %q", sc.Type)
+	}
+
+	src := domain.DirectorySource{
+		Name:               sc.Name,
+		URL:                sc.URL,
+		BindDN:             sc.BindDN,
+		BindPassword:       sc.BindPassword,
+		StartTLS:           sc.StartTLS,
+		InsecureSkipVerify: sc.InsecureSkipVerify,
+		Timeout:            DefaultDirectoryTimeout,
+		Interval:           time.Duration(sc.IntervalMinutes) * time.Minute,
+		Conflict:           domain.DirectoryConflictPolicy(sc.Conflict),
+		Delete:             domain.DirectoryDeletePolicy(sc.Delete),
+		SyncGroups:         sc.SyncGroups == nil || *sc.SyncGroups,
+		Users:              defaults.users,
+		Groups:             defaults.groups,
+		PassThrough: domain.DirectoryPassThrough{
+			Users:             sc.PassThrough.Users,
+			Groups:            sc.PassThrough.Groups,
+			SynchronizedUsers: sc.PassThrough.SynchronizedUsers,
+			CapturePassword:   sc.PassThrough.CapturePassword,
+		},
+	}
+	cfg := upstream.Config{URL: src.URL, StartTLS: src.StartTLS}
+	if err := cfg.Validate(); err != nil {
+		return domain.DirectorySource{}, err
+	}
+	if sc.TimeoutSeconds > 0 {
+		src.Timeout = time.Duration(sc.TimeoutSeconds) * time.Second
+	}
+	switch src.Conflict {
+	case "":
+		src.Conflict = domain.DirectoryConflictSkip
+	case domain.DirectoryConflictSkip, domain.DirectoryConflictAdopt:
+	default:
+		return domain.DirectorySource{}, fmt.Errorf("unknown conflict policy %q", sc.Conflict)
+	}
+	switch src.Delete {
+	case "":
+		src.Delete = domain.DirectoryDeleteDisable
+	case domain.DirectoryDeleteDisable, domain.DirectoryDeleteDelete, domain.DirectoryDeleteKeep:
+	default:
+		return domain.DirectorySource{}, fmt.Errorf("unknown delete policy %q", sc.Delete)
+	}
+
+	u, g := sc.Users, sc.Groups
+	override(&src.Users.BaseDN, u.BaseDN, sc.BaseDN)
+	override(&src.Users.Filter, u.Filter)
+	override(&src.Users.DisabledFilter, u.DisabledFilter)
+	override(&src.Users.IDAttribute, u.IDAttribute)
+	override(&src.Users.Username, u.Username)
+	override(&src.Users.DisplayName, u.DisplayName)
+	override(&src.Users.Email, u.Email)
+	override(&src.Users.Phone, u.Phone)
+	override(&src.Users.Password, u.Password)
+	override(&src.Groups.BaseDN, g.BaseDN, sc.BaseDN)
+	override(&src.Groups.Filter, g.Filter)
+	override(&src.Groups.IDAttribute, g.IDAttribute)
+	override(&src.Groups.Name, g.Name)
+	override(&src.Groups.Description, g.Description)
+	override(&src.Groups.Member, g.Member)
+
+	var err error
+	if src.Users.Attributes, err = invertAttributes(u.Attributes); err != nil {
+		return domain.DirectorySource{}, fmt.Errorf("users: %w", err)
+	}
+	if src.Groups.Attributes, err = invertAttributes(g.Attributes); err != nil {
+		return domain.DirectorySource{}, fmt.Errorf("groups: %w", err)
+	}
+	if src.Users.BaseDN == "" || src.SyncGroups && src.Groups.BaseDN == "" {
+		return domain.DirectorySource{}, fmt.Errorf("base_dn is required")
+	}
+	return src, nil
+}
+
+// override sets *dst to the first non-empty value, if any.
+func override(dst *string, values ...string) {
+	for _, v := range values {
+		if v != "" {
+			*dst = v
+			return
+		}
+	}
+}
+
+// invertAttributes turns a mapping of upstream attributes to extension
+// attributes around. It is configured that way round because configuration
+// keys are case-insensitive, as LDAP attribute names are but extension
+// attribute names are not.
+func invertAttributes(m map[string]string) (map[string]string, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	inverted := make(map[string]string, len(m))
+	for from, to := range m {
+		if _, ok := inverted[to]; ok {
+			return nil, fmt.Errorf("attribute %s is mapped more than once", to)
+		}
+		inverted[to] = from
+	}
+	return inverted, nil
 }
 
 // Load reads configuration from the specified YAML file.
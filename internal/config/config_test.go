@@ -4,6 +4,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/password"
 )
 
 func TestLoad(t *testing.T) {
@@ -22,6 +26,7 @@ ldap:
   port: 10389
   base_dn: "dc=test,dc=com"
   mode: "activedirectory"
+  otp: true
 log:
   console: true
   add_source: true
@@ -36,8 +41,21 @@ log:
     compress: true
     local_time: true
 jwt:
-  secret: "testsecret"
+  algorithm: "ES256"
+  key_rotation_days: 7
+  key_grace_hours: 2
   expire_hours: 12
+  access_expire_minutes: 5
+oidc:
+  issuer: "https://sso.test.com/"
+mfa:
+  issuer: "Test Corp"
+lockout:
+  threshold: 3
+  duration_minutes: 10
+  max_duration_minutes: 120
+  ip_threshold: -1
+  window_minutes: 30
 `
 	dir := t.TempDir()
 	cfgPath := filepath.Join(dir, "config.yaml")
@@ -66,6 +84,7 @@ jwt:
 		{"LDAPPort", cfg.LDAP.Port, 10389},
 		{"LDAPBaseDN", cfg.LDAP.BaseDN, "dc=test,dc=com"},
 		{"LDAPMode", cfg.LDAP.Mode, "activedirectory"},
+		{"LDAPOTP", cfg.LDAP.OTP, true},
 		{"LogConsole", cfg.Log.Console, true},
 		{"LogAddSource", cfg.Log.AddSource, true},
 		{"LogLevel", cfg.Log.Level, "debug"},
@@ -77,8 +96,21 @@ jwt:
 		{"LogRotateMaxBackups", cfg.Log.Rotate.MaxBackups, 5},
 		{"LogRotateCompress", cfg.Log.Rotate.Compress, true},
 		{"LogRotateLocalTime", cfg.Log.Rotate.LocalTime, true},
-		{"JWTSecret", cfg.JWT.Secret, "testsecret"},
+		{"JWTAlgorithm", cfg.JWT.SigningAlgorithm(), "ES256"},
+		{"JWTKeyRotation", cfg.JWT.KeyRotation(), 7 * 24 * time.Hour},
+		{"JWTKeyGrace", cfg.JWT.KeyGrace(), 2 * time.Hour},
 		{"JWTExpireHours", cfg.JWT.ExpireHours, 12},
+		{"JWTSessionTTL", cfg.JWT.SessionTTL(), 12 * time.Hour},
+		{"JWTAccessTTL", cfg.JWT.AccessTTL(), 5 * time.Minute},
+		{"OIDCIssuer", cfg.OIDCIssuer(), "https://sso.test.com"},
+		{"MFAIssuer", cfg.MFA.IssuerName(), "Test Corp"},
+		{"LockoutPolicy", cfg.Lockout.Policy(), domain.LockoutPolicy{
+			Threshold:   3,
+			Duration:    10 * time.Minute,
+			MaxDuration: 2 * time.Hour,
+			IPThreshold: 0,
+			Window:      30 * time.Minute,
+		}},
 	}
 
 	for _, tt := range tests {
@@ -221,3 +253,228 @@ func TestLoadMissingFile(t *testing.T) {
 		t.Fatal("Load() expected error for missing file, got nil")
 	}
 }
+
+func TestSelfServiceIsEditable(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    SelfServiceConfig
+		field  string
+		expect bool
+	}{
+		{"default display_name", SelfServiceConfig{}, "display_name", true},
+		{"default email", SelfServiceConfig{}, "email", false},
+		{"configured email", SelfServiceConfig{EditableFields: []string{"email"}}, "email", true},
+		{"configured excludes phone", SelfServiceConfig{EditableFields: []string{"email"}}, "phone", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.IsEditable(tt.field); got != tt.expect {
+				t.Errorf("IsEditable(%q) = %v, want %v", tt.field, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestJWTConfigDefaults(t *testing.T) {
+	var cfg JWTConfig
+	if got := cfg.SessionTTL(); got != DefaultSessionTTL {
+		t.Errorf("SessionTTL() = %v, want %v", got, DefaultSessionTTL)
+	}
+	if got := cfg.AccessTTL(); got != DefaultAccessTTL {
+		t.Errorf("AccessTTL() = %v, want %v", got, DefaultAccessTTL)
+	}
+	if got := cfg.SigningAlgorithm(); got != DefaultSigningAlgorithm {
+		t.Errorf("SigningAlgorithm() = %q, want %q", got, DefaultSigningAlgorithm)
+	}
+	if got := cfg.KeyRotation(); got != DefaultKeyRotation {
+		t.Errorf("KeyRotation() = %v, want %v", got, DefaultKeyRotation)
+	}
+
+	// The grace period never ends before tokens signed with the old key expire.
+	cfg = JWTConfig{KeyGraceHours: 1, AccessExpireMinutes: 90}
+	if got := cfg.KeyGrace(); got != 90*time.Minute {
+		t.Errorf("KeyGrace() = %v, want %v", got, 90*time.Minute)
+	}
+}
+
+func TestOIDCIssuerDefault(t *testing.T) {
+	cfg := Config{Server: ServerConfig{HTTPPort: 8080}}
+	if got := cfg.OIDCIssuer(); got != "http://localhost:8080" {
+		t.Errorf("OIDCIssuer() = %q, want %q", got, "http://localhost:8080")
+	}
+}
+
+func TestMFAIssuerDefault(t *testing.T) {
+	var cfg MFAConfig
+	if got := cfg.IssuerName(); got != DefaultMFAIssuer {
+		t.Errorf("IssuerName() = %q, want %q", got, DefaultMFAIssuer)
+	}
+}
+
+func TestLockoutPolicyDefaults(t *testing.T) {
+	var cfg LockoutConfig
+	want := domain.LockoutPolicy{
+		Threshold:   DefaultLockoutThreshold,
+		Duration:    DefaultLockoutDuration,
+		MaxDuration: DefaultLockoutMaxDuration,
+		IPThreshold: DefaultLockoutIPThreshold,
+		Window:      DefaultLockoutWindow,
+	}
+	if got := cfg.Policy(); got != want {
+		t.Errorf("Policy() = %+v, want %+v", got, want)
+	}
+
+	// The longest lockout is never shorter than the first.
+	cfg = LockoutConfig{DurationMinutes: 48 * 60}
+	if got := cfg.Policy().MaxDuration; got != 48*time.Hour {
+		t.Errorf("MaxDuration = %v, want %v", got, 48*time.Hour)
+	}
+}
+
+func TestPasswordHashConfig(t *testing.T) {
+	cfg := PasswordHashConfig{Algorithm: "argon2id", Argon2MemoryKiB: 32768}
+	h, err := cfg.Hasher()
+	if err != nil {
+		t.Fatalf("Hasher: %v", err)
+	}
+	if h.Algorithm != password.Argon2id || h.Argon2Memory != 32768 {
+		t.Errorf("Hasher() = %+v", h)
+	}
+
+	for _, cfg := range []PasswordHashConfig{{Algorithm: "md5"}, {BcryptCost: 99}} {
+		if _, err := cfg.Hasher(); err == nil {
+			t.Errorf("%+v: expected error", cfg)
+		}
+	}
+}
+
+func TestPasswordResetPolicyDefaults(t *testing.T) {
+	cfg := Config{Server: ServerConfig{HTTPPort: 8080}}
+	want := domain.PasswordResetPolicy{
+		URL:       "http://localhost:8080/reset-password",
+		TokenTTL:  DefaultPasswordResetTTL,
+		UserLimit: DefaultPasswordResetUserLimit,
+		IPLimit:   DefaultPasswordResetIPLimit,
+		Window:    DefaultPasswordResetWindow,
+	}
+	if got := cfg.PasswordResetPolicy(); got != want {
+		t.Errorf("PasswordResetPolicy() = %+v, want %+v", got, want)
+	}
+
+	cfg.PasswordReset = PasswordResetConfig{URL: "https://sso.example.com/reset", UserLimit: -1, TokenTTLMinutes: 10}
+	got := cfg.PasswordResetPolicy()
+	if got.URL != "https://sso.example.com/reset" || got.UserLimit != 0 || got.TokenTTL != 10*time.Minute {
+		t.Errorf("PasswordResetPolicy() = %+v", got)
+	}
+}
+
+func TestInvitationPolicyDefaults(t *testing.T) {
+	cfg := Config{OIDC: OIDCConfig{Issuer: "https://sso.example.com/"}}
+	want := domain.InvitationPolicy{URL: "https://sso.example.com/accept-invitation", TokenTTL: DefaultInvitationTTL}
+	if got := cfg.InvitationPolicy(); got != want {
+		t.Errorf("InvitationPolicy() = %+v, want %+v", got, want)
+	}
+
+	cfg.Invitation = InvitationConfig{TokenTTLHours: 24}
+	if got := cfg.InvitationPolicy(); got.TokenTTL != 24*time.Hour {
+		t.Errorf("InvitationPolicy().TokenTTL = %v, want 24h", got.TokenTTL)
+	}
+}
+
+func TestMailSender(t *testing.T) {
+	if s, err := (MailConfig{}).Sender(); s != nil || err != nil {
+		t.Errorf("Sender() without host = %v, %v; want nil, nil", s, err)
+	}
+	if _, err := (MailConfig{Host: "smtp.example.com"}).Sender(); err == nil {
+		t.Error("Sender() without from address: expected error")
+	}
+	s, err := MailConfig{Host: "smtp.example.com", From: "noreply@example.com"}.Sender()
+	if err != nil || s == nil {
+		t.Errorf("Sender() = %v, %v", s, err)
+	}
+}
+
+func TestDirectorySources(t *testing.T) {
+	content := `
+directory_sync:
+  sources:
+    - name: legacy
+      url: ldap://ldap.example.com
+      base_dn: "dc=example,dc=com"
+      users:
+        email: mailPrimaryAddress
+        attributes:
+          departmentNumber: Department
+    - name: corp
+      type: activedirectory
+      url: ldaps://dc1.corp.example.com
+      interval_minutes: 30
+      conflict: adopt
+      delete: delete
+      sync_groups: false
+      users:
+        base_dn: "ou=Staff,dc=corp,dc=example,dc=com"
+`
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(cfgPath, []byte(content), 0644); err != nil {
+		t.Fatalf("writing temp config: %v", err)
+	}
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	sources, err := cfg.DirectorySources()
+	if err != nil {
+		t.Fatalf("DirectorySources() error: %v", err)
+	}
+	if len(sources) != 2 {
+		t.Fatalf("got %d sources, want 2", len(sources))
+	}
+	legacy, corp := sources[0], sources[1]
+	if legacy.Conflict != domain.DirectoryConflictSkip || legacy.Delete != domain.DirectoryDeleteDisable ||
+		!legacy.SyncGroups || legacy.Interval != 0 || legacy.Timeout != DefaultDirectoryTimeout {
+		t.Errorf("legacy policies = %+v, want the defaults", legacy)
+	}
+	if u := legacy.Users; u.BaseDN != "dc=example,dc=com" || u.Filter != "(objectClass=inetOrgPerson)" ||
+		u.IDAttribute != "entryUUID" || u.Username != "uid" || u.Email != "mailPrimaryAddress" {
+		t.Errorf("legacy users = %+v, want OpenLDAP defaults with the email overridden", u)
+	}
+	// Configuration keys are case-insensitive, so extension attributes are
+	// the values of the mapping.
+	if got := legacy.Users.Attributes["Department"]; got != "departmentnumber" {
+		t.Errorf("legacy Department attribute = %q, want departmentnumber", got)
+	}
+	if legacy.Groups.BaseDN != "dc=example,dc=com" || legacy.Groups.Member != "member" {
+		t.Errorf("legacy groups = %+v, want OpenLDAP defaults", legacy.Groups)
+	}
+
+	if corp.Conflict != domain.DirectoryConflictAdopt || corp.Delete != domain.DirectoryDeleteDelete ||
+		corp.SyncGroups || corp.Interval != 30*time.Minute {
+		t.Errorf("corp policies = %+v", corp)
+	}
+	if u := corp.Users; u.BaseDN != "ou=Staff,dc=corp,dc=example,dc=com" || u.IDAttribute != "objectGUID" ||
+		u.Username != "sAMAccountName" || u.DisabledFilter == "" {
+		t.Errorf("corp users = %+v, want Active Directory defaults", u)
+	}
+
+	for _, bad := range []DirectorySourceConfig{
+		{URL: "ldap://ldap.example.com", BaseDN: "dc=example,dc=com"},
+		{Name: "a", URL: "https://ldap.example.com", BaseDN: "dc=example,dc=com"},
+		{Name: "a", URL: "ldap://ldap.example.com"},
+		{Name: "a", Type: "novell", URL: "ldap://ldap.example.com", BaseDN: "dc=example,dc=com"},
+		{Name: "a", URL: "ldap://ldap.example.com", BaseDN: "dc=example,dc=com", Conflict: "rename"},
+		{Name: "a", URL: "ldap://ldap.example.com", BaseDN: "dc=example,dc=com", Delete: "archive"},
+	} {
+		cfg := Config{DirectorySync: DirectorySyncConfig{Sources: []DirectorySourceConfig{bad}}}
+		if _, err := cfg.DirectorySources(); err == nil {
+			t.Errorf("DirectorySources(%+v): expected error", bad)
+		}
+	}
+	dup := DirectorySourceConfig{Name: "a", URL: "ldap://ldap.example.com", BaseDN: "dc=example,dc=com"}
+	cfg = &Config{DirectorySync: DirectorySyncConfig{Sources: []DirectorySourceConfig{dup, dup}}}
+	if _, err := cfg.DirectorySources(); err == nil {
+		t.Error("DirectorySources() with duplicate names: expected error")
+	}
+}
@@ -0,0 +1,250 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/attributedefinition"
+	"github.com/qinzj/claude-demo/internal/ent/attributevalue"
+	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// CreateAttributeDefinition declares a new extension attribute.
+func (d *DAO) CreateAttributeDefinition(ctx context.Context, input domain.CreateAttributeDefinitionInput) (*domain.AttributeDefinition, error) {
+	def, err := d.client.AttributeDefinition.Create().
+		SetName(input.Name).
+		SetTarget(attributedefinition.Target(input.Target)).
+		SetType(attributedefinition.Type(input.Type)).
+		SetMultiValued(input.MultiValued).
+		SetLdapName(input.LDAPName).
+		SetDescription(input.Description).
+		SetRequired(input.Required).
+		SetIndexed(input.Indexed).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating attribute definition: %w", err)
+	}
+	return entAttributeDefinitionToDomain(def), nil
+}
+
+// GetAttributeDefinition retrieves an extension attribute definition by ID.
+func (d *DAO) GetAttributeDefinition(ctx context.Context, id uuid.UUID) (*domain.AttributeDefinition, error) {
+	def, err := d.client.AttributeDefinition.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("querying attribute definition: %w", err)
+	}
+	return entAttributeDefinitionToDomain(def), nil
+}
+
+// ListAttributeDefinitions returns extension attribute definitions,
+// optionally restricted to a single target. An empty target returns all.
+func (d *DAO) ListAttributeDefinitions(ctx context.Context, target domain.AttributeTarget) ([]*domain.AttributeDefinition, error) {
+	query := d.client.AttributeDefinition.Query()
+	if target != "" {
+		query = query.Where(attributedefinition.TargetEQ(attributedefinition.Target(target)))
+	}
+
+	defs, err := query.
+		Order(ent.Asc(attributedefinition.FieldTarget), ent.Asc(attributedefinition.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing attribute definitions: %w", err)
+	}
+
+	items := make([]*domain.AttributeDefinition, len(defs))
+	for i, def := range defs {
+		items[i] = entAttributeDefinitionToDomain(def)
+	}
+	return items, nil
+}
+
+// UpdateAttributeDefinition updates the mutable fields of an attribute definition.
+func (d *DAO) UpdateAttributeDefinition(ctx context.Context, id uuid.UUID, input domain.UpdateAttributeDefinitionInput) (*domain.AttributeDefinition, error) {
+	update := d.client.AttributeDefinition.UpdateOneID(id)
+	if input.LDAPName != nil {
+		update = update.SetLdapName(*input.LDAPName)
+	}
+	if input.Description != nil {
+		update = update.SetDescription(*input.Description)
+	}
+	if input.Required != nil {
+		update = update.SetRequired(*input.Required)
+	}
+	if input.Indexed != nil {
+		update = update.SetIndexed(*input.Indexed)
+	}
+
+	def, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("updating attribute definition: %w", err)
+	}
+	return entAttributeDefinitionToDomain(def), nil
+}
+
+// DeleteAttributeDefinition deletes a definition together with all stored values.
+func (d *DAO) DeleteAttributeDefinition(ctx context.Context, id uuid.UUID) error {
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		def, err := tx.AttributeDefinition.Get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("querying attribute definition: %w", err)
+		}
+
+		// The users or groups that lose values get new versions.
+		c := tx.Client()
+		owner := attributevalue.HasUser()
+		record := recordUserChanges
+		owners, err := c.User.Query().
+			Where(user.HasAttributeValuesWith(attributevalue.NameEQ(def.Name))).
+			IDs(ctx)
+		if def.Target == attributedefinition.TargetGroup {
+			owner = attributevalue.HasGroup()
+			record = recordGroupChanges
+			owners, err = c.Group.Query().
+				Where(group.HasAttributeValuesWith(attributevalue.NameEQ(def.Name))).
+				IDs(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("querying attribute owners: %w", err)
+		}
+		if _, err := tx.AttributeValue.Delete().
+			Where(attributevalue.NameEQ(def.Name), owner).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("deleting attribute values: %w", err)
+		}
+		if err := record(ctx, c, owners); err != nil {
+			return err
+		}
+
+		if err := tx.AttributeDefinition.DeleteOneID(id).Exec(ctx); err != nil {
+			return fmt.Errorf("deleting attribute definition: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetUserAttributes replaces the values of the given extension attributes on a user,
+// recording a new version of the user if they changed.
+// Attributes not present in the map are left untouched.
+func (d *DAO) SetUserAttributes(ctx context.Context, userID uuid.UUID, attrs map[string][]string) error {
+	if len(attrs) == 0 {
+		return nil
+	}
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		if err := replaceUserAttributes(ctx, tx, userID, attrs); err != nil {
+			return err
+		}
+		return recordUserChanges(ctx, tx.Client(), []uuid.UUID{userID})
+	})
+}
+
+// SetGroupAttributes replaces the values of the given extension attributes on a group,
+// recording a new version of the group if they changed.
+// Attributes not present in the map are left untouched.
+func (d *DAO) SetGroupAttributes(ctx context.Context, groupID uuid.UUID, attrs map[string][]string) error {
+	if len(attrs) == 0 {
+		return nil
+	}
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		if err := replaceGroupAttributes(ctx, tx, groupID, attrs); err != nil {
+			return err
+		}
+		return recordGroupChanges(ctx, tx.Client(), []uuid.UUID{groupID})
+	})
+}
+
+func replaceUserAttributes(ctx context.Context, tx *ent.Tx, userID uuid.UUID, attrs map[string][]string) error {
+	names := attributeNames(attrs)
+	if _, err := tx.AttributeValue.Delete().
+		Where(
+			attributevalue.NameIn(names...),
+			attributevalue.HasUserWith(user.ID(userID)),
+		).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("clearing user attributes: %w", err)
+	}
+
+	builders := make([]*ent.AttributeValueCreate, 0, len(attrs))
+	for _, name := range names {
+		for _, v := range attrs[name] {
+			builders = append(builders, tx.AttributeValue.Create().
+				SetName(name).
+				SetValue(v).
+				SetUserID(userID))
+		}
+	}
+	if err := tx.AttributeValue.CreateBulk(builders...).Exec(ctx); err != nil {
+		return fmt.Errorf("setting user attributes: %w", err)
+	}
+	return nil
+}
+
+func replaceGroupAttributes(ctx context.Context, tx *ent.Tx, groupID uuid.UUID, attrs map[string][]string) error {
+	names := attributeNames(attrs)
+	if _, err := tx.AttributeValue.Delete().
+		Where(
+			attributevalue.NameIn(names...),
+			attributevalue.HasGroupWith(group.ID(groupID)),
+		).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("clearing group attributes: %w", err)
+	}
+
+	builders := make([]*ent.AttributeValueCreate, 0, len(attrs))
+	for _, name := range names {
+		for _, v := range attrs[name] {
+			builders = append(builders, tx.AttributeValue.Create().
+				SetName(name).
+				SetValue(v).
+				SetGroupID(groupID))
+		}
+	}
+	if err := tx.AttributeValue.CreateBulk(builders...).Exec(ctx); err != nil {
+		return fmt.Errorf("setting group attributes: %w", err)
+	}
+	return nil
+}
+
+func attributeNames(attrs map[string][]string) []string {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	return names
+}
+
+// orderAttributeValues keeps multi-valued attributes in a stable order when eager loading.
+func orderAttributeValues(q *ent.AttributeValueQuery) {
+	q.Order(ent.Asc(attributevalue.FieldName), ent.Asc(attributevalue.FieldValue))
+}
+
+func entAttributeValuesToMap(values []*ent.AttributeValue) map[string][]string {
+	if len(values) == 0 {
+		return nil
+	}
+	m := make(map[string][]string)
+	for _, v := range values {
+		m[v.Name] = append(m[v.Name], v.Value)
+	}
+	return m
+}
+
+func entAttributeDefinitionToDomain(def *ent.AttributeDefinition) *domain.AttributeDefinition {
+	return &domain.AttributeDefinition{
+		ID:          def.ID,
+		Name:        def.Name,
+		Target:      domain.AttributeTarget(def.Target),
+		Type:        domain.AttributeType(def.Type),
+		MultiValued: def.MultiValued,
+		LDAPName:    def.LdapName,
+		Description: def.Description,
+		Required:    def.Required,
+		Indexed:     def.Indexed,
+		CreatedAt:   def.CreatedAt,
+		UpdatedAt:   def.UpdatedAt,
+	}
+}
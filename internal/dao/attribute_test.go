@@ -0,0 +1,63 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+func TestSetUserAttributes(t *testing.T) {
+	d, ctx := setupTestDAO(t)
+
+	u, _ := d.CreateUser(ctx, "john", "John Doe", "john@example.com", "hashedpw", "")
+	if err := d.SetUserAttributes(ctx, u.ID, map[string][]string{
+		"department": {"Engineering"},
+		"skills":     {"ldap", "go"},
+	}); err != nil {
+		t.Fatalf("SetUserAttributes: %v", err)
+	}
+
+	got, err := d.GetUserByID(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if skills := got.Attributes["skills"]; len(skills) != 2 || skills[0] != "go" {
+		t.Errorf("skills = %v, want [go ldap]", skills)
+	}
+
+	result, err := d.ListUsers(ctx, 1, 10, "", map[string]string{"department": "Engineering"})
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if result.Total != 1 {
+		t.Errorf("Total = %d, want 1", result.Total)
+	}
+
+	if err := d.DeleteUser(ctx, u.ID); err != nil {
+		t.Fatalf("DeleteUser with attributes: %v", err)
+	}
+}
+
+func TestDeleteAttributeDefinitionRemovesValues(t *testing.T) {
+	d, ctx := setupTestDAO(t)
+
+	def, err := d.CreateAttributeDefinition(ctx, domain.CreateAttributeDefinitionInput{
+		Name:     "department",
+		Target:   domain.AttributeTargetUser,
+		Type:     domain.AttributeTypeString,
+		LDAPName: "department",
+	})
+	if err != nil {
+		t.Fatalf("CreateAttributeDefinition: %v", err)
+	}
+	u, _ := d.CreateUser(ctx, "john", "John Doe", "john@example.com", "hashedpw", "")
+	_ = d.SetUserAttributes(ctx, u.ID, map[string][]string{"department": {"Engineering"}})
+
+	if err := d.DeleteAttributeDefinition(ctx, def.ID); err != nil {
+		t.Fatalf("DeleteAttributeDefinition: %v", err)
+	}
+	got, _ := d.GetUserByID(ctx, u.ID)
+	if len(got.Attributes) != 0 {
+		t.Errorf("Attributes = %v, want none", got.Attributes)
+	}
+}
@@ -0,0 +1,174 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/auditevent"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// auditBatchSize is how many audit events EachAuditEvent loads at a time.
+const auditBatchSize = 500
+
+// CreateAuditEvent appends an event to the audit trail. Events are never
+// updated or deleted.
+func (d *DAO) CreateAuditEvent(ctx context.Context, e *domain.AuditEvent) error {
+	var changes string
+	if len(e.Changes) > 0 {
+		b, err := json.Marshal(e.Changes)
+		if err != nil {
+			return fmt.Errorf("encoding audit changes: %w", err)
+		}
+		changes = string(b)
+	}
+	if err := d.client.AuditEvent.Create().
+		SetAction(e.Action).
+		SetOutcome(auditevent.Outcome(e.Outcome)).
+		SetReason(e.Reason).
+		SetActorType(e.ActorType).
+		SetNillableActorID(e.ActorID).
+		SetActorName(e.ActorName).
+		SetTargetType(e.TargetType).
+		SetTargetID(e.TargetID).
+		SetTargetName(e.TargetName).
+		SetProtocol(e.Protocol).
+		SetIPAddress(e.IPAddress).
+		SetRequestID(e.RequestID).
+		SetChanges(changes).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("creating audit event: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEvents returns a page of the audit events matching the filter,
+// newest first.
+func (d *DAO) ListAuditEvents(ctx context.Context, filter domain.AuditFilter, page, pageSize int) (*domain.ListResult[domain.AuditEvent], error) {
+	query := d.client.AuditEvent.Query().Where(auditPredicates(filter)...)
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("counting audit events: %w", err)
+	}
+
+	events, err := query.
+		Order(ent.Desc(auditevent.FieldCreatedAt), ent.Desc(auditevent.FieldID)).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing audit events: %w", err)
+	}
+
+	items := make([]*domain.AuditEvent, len(events))
+	for i, e := range events {
+		if items[i], err = entAuditEventToDomain(e); err != nil {
+			return nil, err
+		}
+	}
+	return &domain.ListResult[domain.AuditEvent]{
+		Items:    items,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// EachAuditEvent calls fn for every audit event matching the filter, oldest
+// first, loading them in batches. It stops at the first error fn returns.
+func (d *DAO) EachAuditEvent(ctx context.Context, filter domain.AuditFilter, fn func(*domain.AuditEvent) error) error {
+	for offset := 0; ; offset += auditBatchSize {
+		events, err := d.client.AuditEvent.Query().
+			Where(auditPredicates(filter)...).
+			Order(ent.Asc(auditevent.FieldCreatedAt), ent.Asc(auditevent.FieldID)).
+			Offset(offset).
+			Limit(auditBatchSize).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("listing audit events: %w", err)
+		}
+		for _, e := range events {
+			de, err := entAuditEventToDomain(e)
+			if err != nil {
+				return err
+			}
+			if err := fn(de); err != nil {
+				return err
+			}
+		}
+		if len(events) < auditBatchSize {
+			return nil
+		}
+	}
+}
+
+// auditPredicates translates an audit filter into query predicates.
+func auditPredicates(f domain.AuditFilter) []predicate.AuditEvent {
+	var ps []predicate.AuditEvent
+	if f.Actor != "" {
+		if id, err := uuid.Parse(f.Actor); err == nil {
+			ps = append(ps, auditevent.ActorID(id))
+		} else {
+			ps = append(ps, auditevent.ActorName(f.Actor))
+		}
+	}
+	if prefix, ok := strings.CutSuffix(f.Action, "*"); ok {
+		ps = append(ps, auditevent.ActionHasPrefix(prefix))
+	} else if f.Action != "" {
+		ps = append(ps, auditevent.Action(f.Action))
+	}
+	if f.TargetType != "" {
+		ps = append(ps, auditevent.TargetType(f.TargetType))
+	}
+	if f.TargetID != "" {
+		ps = append(ps, auditevent.TargetID(f.TargetID))
+	}
+	if f.Outcome != "" {
+		ps = append(ps, auditevent.OutcomeEQ(auditevent.Outcome(f.Outcome)))
+	}
+	if f.Protocol != "" {
+		ps = append(ps, auditevent.Protocol(f.Protocol))
+	}
+	if f.IPAddress != "" {
+		ps = append(ps, auditevent.IPAddress(f.IPAddress))
+	}
+	if f.From != nil {
+		ps = append(ps, auditevent.CreatedAtGTE(*f.From))
+	}
+	if f.To != nil {
+		ps = append(ps, auditevent.CreatedAtLT(*f.To))
+	}
+	return ps
+}
+
+func entAuditEventToDomain(e *ent.AuditEvent) (*domain.AuditEvent, error) {
+	de := &domain.AuditEvent{
+		ID:         e.ID,
+		CreatedAt:  e.CreatedAt,
+		Action:     e.Action,
+		Outcome:    domain.AuditOutcome(e.Outcome),
+		Reason:     e.Reason,
+		ActorType:  e.ActorType,
+		ActorID:    e.ActorID,
+		ActorName:  e.ActorName,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		TargetName: e.TargetName,
+		Protocol:   e.Protocol,
+		IPAddress:  e.IPAddress,
+		RequestID:  e.RequestID,
+	}
+	if e.Changes != "" {
+		if err := json.Unmarshal([]byte(e.Changes), &de.Changes); err != nil {
+			return nil, fmt.Errorf("decoding audit changes: %w", err)
+		}
+	}
+	return de, nil
+}
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/qinzj/claude-demo/internal/ent"
 )
@@ -9,10 +10,15 @@ import (
 // DAO provides data access operations.
 type DAO struct {
 	client *ent.Client
+	// tx is the transaction a DAO returned by WithTx works in.
+	tx *ent.Tx
 }
 
-// New creates a new DAO instance.
+// New creates a new DAO instance and installs the hooks that record the
+// history of users and groups on the client. Changes to them must be made
+// in a transaction, so that the history is saved with them.
 func New(client *ent.Client) *DAO {
+	registerHistoryHooks(client)
 	return &DAO{client: client}
 }
 
@@ -21,7 +27,45 @@ func (d *DAO) Client() *ent.Client {
 	return d.client
 }
 
-// AutoMigrate runs database schema migration.
+// AutoMigrate runs database schema migration and records the baseline
+// history of users and groups created before history was kept.
 func (d *DAO) AutoMigrate(ctx context.Context) error {
-	return d.client.Schema.Create(ctx)
+	if err := d.client.Schema.Create(ctx); err != nil {
+		return err
+	}
+	return d.BackfillHistory(ctx)
+}
+
+// WithTx runs fn with a DAO whose operations all take place in one
+// transaction, which is rolled back if fn returns an error and committed
+// otherwise. A DAO already in a transaction passes itself to fn.
+func (d *DAO) WithTx(ctx context.Context, fn func(d *DAO) error) error {
+	if d.tx != nil {
+		return fn(d)
+	}
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		return fn(&DAO{client: tx.Client(), tx: tx})
+	})
+}
+
+// withTx runs fn inside a transaction, rolling back if fn returns an error.
+// In a DAO returned by WithTx, fn runs in its transaction.
+func (d *DAO) withTx(ctx context.Context, fn func(tx *ent.Tx) error) error {
+	if d.tx != nil {
+		return fn(d.tx)
+	}
+	tx, err := d.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/directoryobject"
+)
+
+// DirectoryObjects returns the links of all sources between upstream
+// entries and the users and groups synchronized from them.
+func (d *DAO) DirectoryObjects(ctx context.Context) ([]*domain.DirectoryObject, error) {
+	objects, err := d.client.DirectoryObject.Query().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying directory objects: %w", err)
+	}
+	items := make([]*domain.DirectoryObject, len(objects))
+	for i, o := range objects {
+		items[i] = entDirectoryObjectToDomain(o)
+	}
+	return items, nil
+}
+
+// DirectoryObjectByLocalID returns the link of the user or group with the
+// given ID.
+func (d *DAO) DirectoryObjectByLocalID(ctx context.Context, resourceType domain.DirectoryResourceType, localID uuid.UUID) (*domain.DirectoryObject, error) {
+	o, err := d.client.DirectoryObject.Query().
+		Where(
+			directoryobject.ResourceTypeEQ(directoryobject.ResourceType(resourceType)),
+			directoryobject.LocalID(localID),
+		).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying directory object: %w", err)
+	}
+	return entDirectoryObjectToDomain(o), nil
+}
+
+// SaveDirectoryObject creates a link, if its ID is nil, or updates its
+// local ID, DN and password digest.
+func (d *DAO) SaveDirectoryObject(ctx context.Context, o *domain.DirectoryObject) (*domain.DirectoryObject, error) {
+	var saved *ent.DirectoryObject
+	var err error
+	if o.ID == uuid.Nil {
+		saved, err = d.client.DirectoryObject.Create().
+			SetSource(o.Source).
+			SetResourceType(directoryobject.ResourceType(o.ResourceType)).
+			SetExternalID(o.ExternalID).
+			SetLocalID(o.LocalID).
+			SetDn(o.DN).
+			SetPasswordDigest(o.PasswordDigest).
+			Save(ctx)
+	} else {
+		saved, err = d.client.DirectoryObject.UpdateOneID(o.ID).
+			SetLocalID(o.LocalID).
+			SetDn(o.DN).
+			SetPasswordDigest(o.PasswordDigest).
+			Save(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("saving directory object: %w", err)
+	}
+	return entDirectoryObjectToDomain(saved), nil
+}
+
+// DeleteDirectoryObject deletes a link.
+func (d *DAO) DeleteDirectoryObject(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.DirectoryObject.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting directory object: %w", err)
+	}
+	return nil
+}
+
+func entDirectoryObjectToDomain(o *ent.DirectoryObject) *domain.DirectoryObject {
+	return &domain.DirectoryObject{
+		ID:             o.ID,
+		Source:         o.Source,
+		ResourceType:   domain.DirectoryResourceType(o.ResourceType),
+		ExternalID:     o.ExternalID,
+		LocalID:        o.LocalID,
+		DN:             o.Dn,
+		PasswordDigest: o.PasswordDigest,
+		CreatedAt:      o.CreatedAt,
+		UpdatedAt:      o.UpdatedAt,
+	}
+}
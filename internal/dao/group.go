@@ -9,18 +9,24 @@ import (
 	"github.com/qinzj/claude-demo/internal/domain"
 	"github.com/qinzj/claude-demo/internal/ent"
 	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/user"
 )
 
 // CreateGroup creates a new group.
 func (d *DAO) CreateGroup(ctx context.Context, name, description string, parentID *uuid.UUID) (*domain.Group, error) {
-	create := d.client.Group.Create().
-		SetName(name).
-		SetDescription(description)
-	if parentID != nil {
-		create = create.SetParentID(*parentID)
-	}
+	var g *ent.Group
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		create := tx.Group.Create().
+			SetName(name).
+			SetDescription(description)
+		if parentID != nil {
+			create = create.SetParentID(*parentID)
+		}
 
-	g, err := create.Save(ctx)
+		var err error
+		g, err = create.Save(ctx)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("creating group: %w", err)
 	}
@@ -33,6 +39,9 @@ func (d *DAO) GetGroupByID(ctx context.Context, id uuid.UUID) (*domain.Group, er
 		Where(group.ID(id)).
 		WithUsers().
 		WithChildren().
+		WithOwnerUsers().
+		WithOwnerGroups().
+		WithAttributeValues(orderAttributeValues).
 		Only(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("querying group by id: %w", err)
@@ -44,6 +53,7 @@ func (d *DAO) GetGroupByID(ctx context.Context, id uuid.UUID) (*domain.Group, er
 func (d *DAO) ListGroups(ctx context.Context) ([]*domain.Group, error) {
 	groups, err := d.client.Group.Query().
 		WithChildren().
+		WithAttributeValues(orderAttributeValues).
 		Order(ent.Asc(group.FieldName)).
 		All(ctx)
 	if err != nil {
@@ -59,18 +69,36 @@ func (d *DAO) ListGroups(ctx context.Context) ([]*domain.Group, error) {
 
 // UpdateGroup updates group fields.
 func (d *DAO) UpdateGroup(ctx context.Context, id uuid.UUID, input domain.UpdateGroupInput) (*domain.Group, error) {
-	update := d.client.Group.UpdateOneID(id)
-	if input.Name != nil {
-		update = update.SetName(*input.Name)
-	}
-	if input.Description != nil {
-		update = update.SetDescription(*input.Description)
-	}
-	if input.ParentID != nil {
-		update = update.SetParentID(*input.ParentID)
-	}
+	var g *ent.Group
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		if len(input.Attributes) > 0 {
+			if err := replaceGroupAttributes(ctx, tx, id, input.Attributes); err != nil {
+				return err
+			}
+		}
+		update := tx.Group.UpdateOneID(id)
+		if input.Name != nil {
+			update = update.SetName(*input.Name)
+		}
+		if input.Description != nil {
+			update = update.SetDescription(*input.Description)
+		}
+		if input.ParentID != nil {
+			update = update.SetParentID(*input.ParentID)
+		}
+		if input.RequireMFA != nil {
+			update = update.SetRequireMfa(*input.RequireMFA)
+		}
 
-	g, err := update.Save(ctx)
+		var err error
+		if g, err = update.Save(ctx); err != nil {
+			return err
+		}
+		if len(input.Attributes) > 0 {
+			return recordGroupChanges(ctx, tx.Client(), []uuid.UUID{id})
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("updating group: %w", err)
 	}
@@ -79,7 +107,10 @@ func (d *DAO) UpdateGroup(ctx context.Context, id uuid.UUID, input domain.Update
 
 // DeleteGroup deletes a group by ID.
 func (d *DAO) DeleteGroup(ctx context.Context, id uuid.UUID) error {
-	if err := d.client.Group.DeleteOneID(id).Exec(ctx); err != nil {
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		return tx.Group.DeleteOneID(id).Exec(ctx)
+	})
+	if err != nil {
 		return fmt.Errorf("deleting group: %w", err)
 	}
 	return nil
@@ -87,9 +118,9 @@ func (d *DAO) DeleteGroup(ctx context.Context, id uuid.UUID) error {
 
 // AddMembers adds users to a group.
 func (d *DAO) AddMembers(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID) error {
-	_, err := d.client.Group.UpdateOneID(groupID).
-		AddUserIDs(userIDs...).
-		Save(ctx)
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		return tx.Group.UpdateOneID(groupID).AddUserIDs(userIDs...).Exec(ctx)
+	})
 	if err != nil {
 		return fmt.Errorf("adding members to group: %w", err)
 	}
@@ -98,9 +129,9 @@ func (d *DAO) AddMembers(ctx context.Context, groupID uuid.UUID, userIDs []uuid.
 
 // RemoveMember removes a user from a group.
 func (d *DAO) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
-	_, err := d.client.Group.UpdateOneID(groupID).
-		RemoveUserIDs(userID).
-		Save(ctx)
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		return tx.Group.UpdateOneID(groupID).RemoveUserIDs(userID).Exec(ctx)
+	})
 	if err != nil {
 		return fmt.Errorf("removing member from group: %w", err)
 	}
@@ -124,10 +155,65 @@ func (d *DAO) GetGroupMembers(ctx context.Context, groupID uuid.UUID) ([]*domain
 	return users, nil
 }
 
+// AddGroupOwners adds owning users and groups to a group.
+func (d *DAO) AddGroupOwners(ctx context.Context, groupID uuid.UUID, userIDs, groupIDs []uuid.UUID) error {
+	_, err := d.client.Group.UpdateOneID(groupID).
+		AddOwnerUserIDs(userIDs...).
+		AddOwnerGroupIDs(groupIDs...).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("adding owners to group: %w", err)
+	}
+	return nil
+}
+
+// RemoveGroupOwnerUser removes an owning user from a group.
+func (d *DAO) RemoveGroupOwnerUser(ctx context.Context, groupID, userID uuid.UUID) error {
+	_, err := d.client.Group.UpdateOneID(groupID).
+		RemoveOwnerUserIDs(userID).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("removing owner user from group: %w", err)
+	}
+	return nil
+}
+
+// RemoveGroupOwnerGroup removes an owning group from a group.
+func (d *DAO) RemoveGroupOwnerGroup(ctx context.Context, groupID, ownerGroupID uuid.UUID) error {
+	_, err := d.client.Group.UpdateOneID(groupID).
+		RemoveOwnerGroupIDs(ownerGroupID).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("removing owner group from group: %w", err)
+	}
+	return nil
+}
+
+// IsGroupOwner reports whether a user owns a group, either directly or
+// through membership in one of its owning groups.
+func (d *DAO) IsGroupOwner(ctx context.Context, groupID, userID uuid.UUID) (bool, error) {
+	ok, err := d.client.Group.Query().
+		Where(
+			group.ID(groupID),
+			group.Or(
+				group.HasOwnerUsersWith(user.ID(userID)),
+				group.HasOwnerGroupsWith(group.HasUsersWith(user.ID(userID))),
+			),
+		).
+		Exist(ctx)
+	if err != nil {
+		return false, fmt.Errorf("checking group ownership: %w", err)
+	}
+	return ok, nil
+}
+
 // AllGroups returns all groups (for LDAP search).
 func (d *DAO) AllGroups(ctx context.Context) ([]*domain.Group, error) {
 	groups, err := d.client.Group.Query().
 		WithUsers().
+		WithOwnerUsers().
+		WithOwnerGroups().
+		WithAttributeValues(orderAttributeValues).
 		All(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("querying all groups: %w", err)
@@ -145,8 +231,10 @@ func entGroupToDomain(g *ent.Group) *domain.Group {
 		Name:        g.Name,
 		Description: g.Description,
 		ParentID:    g.ParentID,
+		RequireMFA:  g.RequireMfa,
 		CreatedAt:   g.CreatedAt,
 		UpdatedAt:   g.UpdatedAt,
+		Attributes:  entAttributeValuesToMap(g.Edges.AttributeValues),
 	}
 	return dg
 }
@@ -165,5 +253,17 @@ func entGroupToDomainWithEdges(g *ent.Group) *domain.Group {
 			dg.Children[i] = entGroupToDomain(c)
 		}
 	}
+	if g.Edges.OwnerUsers != nil {
+		dg.OwnerUsers = make([]*domain.User, len(g.Edges.OwnerUsers))
+		for i, u := range g.Edges.OwnerUsers {
+			dg.OwnerUsers[i] = entUserToDomain(u)
+		}
+	}
+	if g.Edges.OwnerGroups != nil {
+		dg.OwnerGroups = make([]*domain.Group, len(g.Edges.OwnerGroups))
+		for i, o := range g.Edges.OwnerGroups {
+			dg.OwnerGroups[i] = entGroupToDomain(o)
+		}
+	}
 	return dg
 }
@@ -135,3 +135,48 @@ func TestGetUserGroups(t *testing.T) {
 		t.Errorf("len(groups) = %d, want 2", len(groups))
 	}
 }
+
+func TestGroupOwners(t *testing.T) {
+	d, ctx := setupGroupTestDAO(t)
+
+	g, _ := d.CreateGroup(ctx, "devs", "Developers", nil)
+	leads, _ := d.CreateGroup(ctx, "leads", "Team leads", nil)
+	owner, _ := d.CreateUser(ctx, "owner", "Owner", "owner@example.com", "hash", "")
+	lead, _ := d.CreateUser(ctx, "lead", "Lead", "lead@example.com", "hash", "")
+	other, _ := d.CreateUser(ctx, "other", "Other", "other@example.com", "hash", "")
+	d.AddMembers(ctx, leads.ID, []uuid.UUID{lead.ID})
+
+	if err := d.AddGroupOwners(ctx, g.ID, []uuid.UUID{owner.ID}, []uuid.UUID{leads.ID}); err != nil {
+		t.Fatalf("AddGroupOwners: %v", err)
+	}
+
+	got, _ := d.GetGroupByID(ctx, g.ID)
+	if len(got.OwnerUsers) != 1 || len(got.OwnerGroups) != 1 {
+		t.Fatalf("owners = %d users, %d groups, want 1 and 1", len(got.OwnerUsers), len(got.OwnerGroups))
+	}
+
+	for _, tt := range []struct {
+		name string
+		id   uuid.UUID
+		want bool
+	}{
+		{"direct owner", owner.ID, true},
+		{"member of owner group", lead.ID, true},
+		{"unrelated user", other.ID, false},
+	} {
+		ok, err := d.IsGroupOwner(ctx, g.ID, tt.id)
+		if err != nil {
+			t.Fatalf("IsGroupOwner: %v", err)
+		}
+		if ok != tt.want {
+			t.Errorf("%s: IsGroupOwner = %v, want %v", tt.name, ok, tt.want)
+		}
+	}
+
+	if err := d.RemoveGroupOwnerGroup(ctx, g.ID, leads.ID); err != nil {
+		t.Fatalf("RemoveGroupOwnerGroup: %v", err)
+	}
+	if ok, _ := d.IsGroupOwner(ctx, g.ID, lead.ID); ok {
+		t.Error("lead should no longer own the group")
+	}
+}
@@ -0,0 +1,734 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/groupmembershipchange"
+	"github.com/qinzj/claude-demo/internal/ent/groupversion"
+	"github.com/qinzj/claude-demo/internal/ent/hook"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+	"github.com/qinzj/claude-demo/internal/ent/userversion"
+)
+
+// ErrHistoryNeedsTx is returned by the history hooks for a change to a
+// versioned user or group outside a transaction, where the change could be
+// saved without its history.
+var ErrHistoryNeedsTx = errors.New("versioned change outside a transaction")
+
+// versionedUserFields and versionedGroupFields are the fields whose changes
+// are versioned. Other fields, such as password hashes and lockout state,
+// change without a new version. Extension attributes are versioned too, by
+// the DAO methods that set them.
+var (
+	versionedUserFields = []string{
+		user.FieldUsername, user.FieldDisplayName, user.FieldEmail, user.FieldPhone,
+		user.FieldStatus, user.FieldManagerID, user.FieldAccountExpiresAt,
+	}
+	versionedGroupFields = []string{
+		group.FieldName, group.FieldDescription, group.FieldParentID, group.FieldRequireMfa,
+	}
+)
+
+// registerHistoryHooks installs the hooks that version users, groups and
+// group memberships in the transaction that changes them, and write the
+// outbox events for webhooks alongside the versions.
+func registerHistoryHooks(client *ent.Client) {
+	client.User.Use(userHistoryHook)
+	client.Group.Use(groupHistoryHook)
+}
+
+// userHistoryHook versions the users a mutation creates, changes or
+// deletes, and records their group memberships changing. Deleting a user
+// also removes them from their groups and as manager of their reports,
+// which are recorded too.
+func userHistoryHook(next ent.Mutator) ent.Mutator {
+	return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+		memberships := len(m.GroupsIDs()) > 0 || len(m.RemovedGroupsIDs()) > 0 || m.GroupsCleared()
+		if !versioned(m, versionedUserFields) && !memberships {
+			return next.Mutate(ctx, m)
+		}
+		if _, err := m.Tx(); err != nil {
+			return nil, ErrHistoryNeedsTx
+		}
+		c := m.Client()
+
+		var ids, reports []uuid.UUID
+		if !m.Op().Is(ent.OpCreate) {
+			var err error
+			if ids, err = m.IDs(ctx); err != nil {
+				return nil, fmt.Errorf("querying changed users: %w", err)
+			}
+		}
+		before, err := loadUsersWithGroups(ctx, c, ids)
+		if err != nil {
+			return nil, err
+		}
+		if m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+			if reports, err = c.User.Query().Where(user.ManagerIDIn(ids...)).IDs(ctx); err != nil {
+				return nil, fmt.Errorf("querying reports: %w", err)
+			}
+		}
+
+		v, err := next.Mutate(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		if u, ok := v.(*ent.User); ok && m.Op().Is(ent.OpCreate) {
+			ids = []uuid.UUID{u.ID}
+		}
+		after, err := loadUsersWithGroups(ctx, c, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			b, a := before[id], after[id]
+			if err := recordUserVersion(ctx, c, id, b, a); err != nil {
+				return nil, err
+			}
+			for _, gid := range groupIDsOf(b) {
+				if !slices.Contains(groupIDsOf(a), gid) {
+					if err := recordMembership(ctx, c, gid, id, domain.MembershipRemove); err != nil {
+						return nil, err
+					}
+				}
+			}
+			for _, gid := range groupIDsOf(a) {
+				if !slices.Contains(groupIDsOf(b), gid) {
+					if err := recordMembership(ctx, c, gid, id, domain.MembershipAdd); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+		if err := recordUserChanges(ctx, c, reports); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
+// groupHistoryHook versions the groups a mutation creates, changes or
+// deletes, and records users joining or leaving them. Deleting a group also
+// detaches its child groups, whose new versions are recorded too.
+func groupHistoryHook(next ent.Mutator) ent.Mutator {
+	return hook.GroupFunc(func(ctx context.Context, m *ent.GroupMutation) (ent.Value, error) {
+		memberships := len(m.UsersIDs()) > 0 || len(m.RemovedUsersIDs()) > 0 || m.UsersCleared()
+		if !versioned(m, versionedGroupFields) && !memberships {
+			return next.Mutate(ctx, m)
+		}
+		if _, err := m.Tx(); err != nil {
+			return nil, ErrHistoryNeedsTx
+		}
+		c := m.Client()
+
+		var ids, children []uuid.UUID
+		if !m.Op().Is(ent.OpCreate) {
+			var err error
+			if ids, err = m.IDs(ctx); err != nil {
+				return nil, fmt.Errorf("querying changed groups: %w", err)
+			}
+		}
+		before, err := loadGroupsWithUsers(ctx, c, ids)
+		if err != nil {
+			return nil, err
+		}
+		if m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+			if children, err = c.Group.Query().Where(group.ParentIDIn(ids...)).IDs(ctx); err != nil {
+				return nil, fmt.Errorf("querying child groups: %w", err)
+			}
+		}
+
+		v, err := next.Mutate(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		if g, ok := v.(*ent.Group); ok && m.Op().Is(ent.OpCreate) {
+			ids = []uuid.UUID{g.ID}
+		}
+		after, err := loadGroupsWithUsers(ctx, c, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			b, a := before[id], after[id]
+			if err := recordGroupVersion(ctx, c, id, b, a); err != nil {
+				return nil, err
+			}
+			for _, uid := range userIDsOf(b) {
+				if !slices.Contains(userIDsOf(a), uid) {
+					if err := recordMembership(ctx, c, id, uid, domain.MembershipRemove); err != nil {
+						return nil, err
+					}
+				}
+			}
+			for _, uid := range userIDsOf(a) {
+				if !slices.Contains(userIDsOf(b), uid) {
+					if err := recordMembership(ctx, c, id, uid, domain.MembershipAdd); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+		if err := recordGroupChanges(ctx, c, children); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
+// versioned reports whether a mutation creates or deletes entities or
+// changes one of the given fields.
+func versioned(m ent.Mutation, fields []string) bool {
+	if m.Op().Is(ent.OpCreate | ent.OpDelete | ent.OpDeleteOne) {
+		return true
+	}
+	for _, f := range fields {
+		if _, ok := m.Field(f); ok || m.FieldCleared(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// historyActor returns the actor recorded with a change, if any.
+func historyActor(ctx context.Context) (*uuid.UUID, string) {
+	actor, ok := ctx.Value(domain.ActorContextKey{}).(*domain.Actor)
+	if !ok || actor == nil {
+		return nil, ""
+	}
+	id := actor.UserID
+	if actor.IsServiceAccount() {
+		id = actor.ServiceAccountID
+	}
+	return &id, actor.Name
+}
+
+func loadUsersWithGroups(ctx context.Context, c *ent.Client, ids []uuid.UUID) (map[uuid.UUID]*ent.User, error) {
+	users, err := c.User.Query().
+		Where(user.IDIn(ids...)).
+		WithGroups(func(q *ent.GroupQuery) { q.Select(group.FieldID) }).
+		WithAttributeValues(orderAttributeValues).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying users: %w", err)
+	}
+	m := make(map[uuid.UUID]*ent.User, len(users))
+	for _, u := range users {
+		m[u.ID] = u
+	}
+	return m, nil
+}
+
+func loadGroupsWithUsers(ctx context.Context, c *ent.Client, ids []uuid.UUID) (map[uuid.UUID]*ent.Group, error) {
+	groups, err := c.Group.Query().
+		Where(group.IDIn(ids...)).
+		WithUsers(func(q *ent.UserQuery) { q.Select(user.FieldID) }).
+		WithAttributeValues(orderAttributeValues).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying groups: %w", err)
+	}
+	m := make(map[uuid.UUID]*ent.Group, len(groups))
+	for _, g := range groups {
+		m[g.ID] = g
+	}
+	return m, nil
+}
+
+func groupIDsOf(u *ent.User) []uuid.UUID {
+	if u == nil {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(u.Edges.Groups))
+	for i, g := range u.Edges.Groups {
+		ids[i] = g.ID
+	}
+	return ids
+}
+
+func userIDsOf(g *ent.Group) []uuid.UUID {
+	if g == nil {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(g.Edges.Users))
+	for i, u := range g.Edges.Users {
+		ids[i] = u.ID
+	}
+	return ids
+}
+
+// recordUserChanges records new versions of users changed as a side effect
+// of another change, such as their manager being deleted, or by a change
+// the hooks do not see, such as to their extension attributes.
+func recordUserChanges(ctx context.Context, c *ent.Client, ids []uuid.UUID) error {
+	for _, id := range ids {
+		u, err := c.User.Query().
+			Where(user.ID(id)).
+			WithAttributeValues(orderAttributeValues).
+			Only(ctx)
+		if err != nil {
+			return fmt.Errorf("querying user: %w", err)
+		}
+		if err := recordUserVersion(ctx, c, id, u, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordGroupChanges is recordUserChanges for groups.
+func recordGroupChanges(ctx context.Context, c *ent.Client, ids []uuid.UUID) error {
+	for _, id := range ids {
+		g, err := c.Group.Query().
+			Where(group.ID(id)).
+			WithAttributeValues(orderAttributeValues).
+			Only(ctx)
+		if err != nil {
+			return fmt.Errorf("querying group: %w", err)
+		}
+		if err := recordGroupVersion(ctx, c, id, g, g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordUserVersion records a version of a user given its state before and
+// after a change: a delete version if after is nil, a create version if
+// before is nil, and otherwise an update version unless the versioned
+// fields equal those of the latest version.
+func recordUserVersion(ctx context.Context, c *ent.Client, id uuid.UUID, before, after *ent.User) error {
+	latest, err := c.UserVersion.Query().
+		Where(userversion.UserID(id)).
+		Order(ent.Desc(userversion.FieldVersion)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return fmt.Errorf("querying latest user version: %w", err)
+	}
+	number := 1
+	if latest != nil {
+		number = latest.Version + 1
+	}
+
+	op, u := domain.VersionUpdate, after
+	switch {
+	case after == nil && before == nil:
+		return nil
+	case after == nil:
+		op, u = domain.VersionDelete, before
+	case before == nil:
+		op = domain.VersionCreate
+	case latest != nil && sameUserVersion(entUserVersionToDomain(latest), u):
+		return nil
+	}
+
+	v, err := createUserVersion(ctx, c, number, op, u)
+	if err != nil {
+		return err
+	}
+	var previous *domain.UserVersion
+	if latest != nil {
+		previous = entUserVersionToDomain(latest)
+	}
+	return enqueueUserEvents(ctx, c, previous, entUserVersionToDomain(v))
+}
+
+func createUserVersion(ctx context.Context, c *ent.Client, number int, op domain.VersionOperation, u *ent.User) (*ent.UserVersion, error) {
+	actorID, actorName := historyActor(ctx)
+	v, err := c.UserVersion.Create().
+		SetUserID(u.ID).
+		SetVersion(number).
+		SetOperation(userversion.Operation(op)).
+		SetUsername(u.Username).
+		SetDisplayName(u.DisplayName).
+		SetEmail(u.Email).
+		SetPhone(u.Phone).
+		SetStatus(string(u.Status)).
+		SetNillableManagerID(u.ManagerID).
+		SetNillableAccountExpiresAt(u.AccountExpiresAt).
+		SetAttributes(entAttributeValuesToMap(u.Edges.AttributeValues)).
+		SetNillableActorID(actorID).
+		SetActorName(actorName).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating user version: %w", err)
+	}
+	return v, nil
+}
+
+// recordGroupVersion is recordUserVersion for groups.
+func recordGroupVersion(ctx context.Context, c *ent.Client, id uuid.UUID, before, after *ent.Group) error {
+	latest, err := c.GroupVersion.Query().
+		Where(groupversion.GroupID(id)).
+		Order(ent.Desc(groupversion.FieldVersion)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return fmt.Errorf("querying latest group version: %w", err)
+	}
+	number := 1
+	if latest != nil {
+		number = latest.Version + 1
+	}
+
+	op, g := domain.VersionUpdate, after
+	switch {
+	case after == nil && before == nil:
+		return nil
+	case after == nil:
+		op, g = domain.VersionDelete, before
+	case before == nil:
+		op = domain.VersionCreate
+	case latest != nil && sameGroupVersion(entGroupVersionToDomain(latest), g):
+		return nil
+	}
+
+	v, err := createGroupVersion(ctx, c, number, op, g)
+	if err != nil {
+		return err
+	}
+	var previous *domain.GroupVersion
+	if latest != nil {
+		previous = entGroupVersionToDomain(latest)
+	}
+	return enqueueGroupEvent(ctx, c, previous, entGroupVersionToDomain(v))
+}
+
+func createGroupVersion(ctx context.Context, c *ent.Client, number int, op domain.VersionOperation, g *ent.Group) (*ent.GroupVersion, error) {
+	actorID, actorName := historyActor(ctx)
+	v, err := c.GroupVersion.Create().
+		SetGroupID(g.ID).
+		SetVersion(number).
+		SetOperation(groupversion.Operation(op)).
+		SetName(g.Name).
+		SetDescription(g.Description).
+		SetNillableParentID(g.ParentID).
+		SetRequireMfa(g.RequireMfa).
+		SetAttributes(entAttributeValuesToMap(g.Edges.AttributeValues)).
+		SetNillableActorID(actorID).
+		SetActorName(actorName).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating group version: %w", err)
+	}
+	return v, nil
+}
+
+// recordMembership records a user joining or leaving a group.
+func recordMembership(ctx context.Context, c *ent.Client, groupID, userID uuid.UUID, op domain.MembershipOperation) error {
+	if err := createMembershipChange(ctx, c, groupID, userID, op); err != nil {
+		return err
+	}
+	return enqueueMembershipEvent(ctx, c, groupID, userID, op)
+}
+
+func createMembershipChange(ctx context.Context, c *ent.Client, groupID, userID uuid.UUID, op domain.MembershipOperation) error {
+	actorID, actorName := historyActor(ctx)
+	if err := c.GroupMembershipChange.Create().
+		SetGroupID(groupID).
+		SetUserID(userID).
+		SetOperation(groupmembershipchange.Operation(op)).
+		SetNillableActorID(actorID).
+		SetActorName(actorName).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("creating membership change: %w", err)
+	}
+	return nil
+}
+
+func sameUserVersion(v *domain.UserVersion, u *ent.User) bool {
+	return v.Operation != domain.VersionDelete &&
+		v.Username == u.Username &&
+		v.DisplayName == u.DisplayName &&
+		v.Email == u.Email &&
+		v.Phone == u.Phone &&
+		v.Status == domain.UserStatus(u.Status) &&
+		equalPtr(v.ManagerID, u.ManagerID) &&
+		equalTimePtr(v.AccountExpiresAt, u.AccountExpiresAt) &&
+		equalAttributes(v.Attributes, entAttributeValuesToMap(u.Edges.AttributeValues))
+}
+
+func sameGroupVersion(v *domain.GroupVersion, g *ent.Group) bool {
+	return v.Operation != domain.VersionDelete &&
+		v.Name == g.Name &&
+		v.Description == g.Description &&
+		equalPtr(v.ParentID, g.ParentID) &&
+		v.RequireMFA == g.RequireMfa &&
+		equalAttributes(v.Attributes, entAttributeValuesToMap(g.Edges.AttributeValues))
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func equalAttributes(a, b map[string][]string) bool {
+	return maps.EqualFunc(a, b, slices.Equal)
+}
+
+// BackfillHistory records baseline versions of the users and groups that
+// have none, with the current members of those groups, so that history is
+// complete from the first start after it was introduced. Once every user
+// and group has a version it does nothing. Baselines are not changes, so
+// no webhook events are sent for them.
+func (d *DAO) BackfillHistory(ctx context.Context) error {
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		c := tx.Client()
+		users, err := c.User.Query().
+			Where(func(s *sql.Selector) {
+				t := sql.Table(userversion.Table)
+				s.Where(sql.NotExists(sql.Select(t.C(userversion.FieldID)).From(t).
+					Where(sql.ColumnsEQ(t.C(userversion.FieldUserID), s.C(user.FieldID)))))
+			}).
+			WithAttributeValues(orderAttributeValues).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("querying users without history: %w", err)
+		}
+		for _, u := range users {
+			if _, err := createUserVersion(ctx, c, 1, domain.VersionBaseline, u); err != nil {
+				return err
+			}
+		}
+
+		groups, err := c.Group.Query().
+			Where(func(s *sql.Selector) {
+				t := sql.Table(groupversion.Table)
+				s.Where(sql.NotExists(sql.Select(t.C(groupversion.FieldID)).From(t).
+					Where(sql.ColumnsEQ(t.C(groupversion.FieldGroupID), s.C(group.FieldID)))))
+			}).
+			WithUsers(func(q *ent.UserQuery) { q.Select(user.FieldID) }).
+			WithAttributeValues(orderAttributeValues).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("querying groups without history: %w", err)
+		}
+		for _, g := range groups {
+			if _, err := createGroupVersion(ctx, c, 1, domain.VersionBaseline, g); err != nil {
+				return err
+			}
+			for _, uid := range userIDsOf(g) {
+				if err := createMembershipChange(ctx, c, g.ID, uid, domain.MembershipAdd); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// UserHistory returns every version of a user and every change to their
+// group memberships, oldest first. Deleted users keep their history.
+func (d *DAO) UserHistory(ctx context.Context, userID uuid.UUID) (*domain.UserHistory, error) {
+	versions, err := d.client.UserVersion.Query().
+		Where(userversion.UserID(userID)).
+		Order(ent.Asc(userversion.FieldVersion)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying user versions: %w", err)
+	}
+	changes, err := d.client.GroupMembershipChange.Query().
+		Where(groupmembershipchange.UserID(userID)).
+		Order(ent.Asc(groupmembershipchange.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying membership changes: %w", err)
+	}
+
+	h := &domain.UserHistory{
+		Versions:    make([]*domain.UserVersion, len(versions)),
+		Memberships: entMembershipChangesToDomain(changes),
+	}
+	for i, v := range versions {
+		h.Versions[i] = entUserVersionToDomain(v)
+	}
+	return h, nil
+}
+
+// GroupHistory returns every version of a group and every change to its
+// members, oldest first. Deleted groups keep their history.
+func (d *DAO) GroupHistory(ctx context.Context, groupID uuid.UUID) (*domain.GroupHistory, error) {
+	versions, err := d.client.GroupVersion.Query().
+		Where(groupversion.GroupID(groupID)).
+		Order(ent.Asc(groupversion.FieldVersion)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying group versions: %w", err)
+	}
+	changes, err := d.client.GroupMembershipChange.Query().
+		Where(groupmembershipchange.GroupID(groupID)).
+		Order(ent.Asc(groupmembershipchange.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying membership changes: %w", err)
+	}
+
+	h := &domain.GroupHistory{
+		Versions:    make([]*domain.GroupVersion, len(versions)),
+		Memberships: entMembershipChangesToDomain(changes),
+	}
+	for i, v := range versions {
+		h.Versions[i] = entGroupVersionToDomain(v)
+	}
+	return h, nil
+}
+
+// GetUserVersion returns a version of a user by number.
+func (d *DAO) GetUserVersion(ctx context.Context, userID uuid.UUID, version int) (*domain.UserVersion, error) {
+	v, err := d.client.UserVersion.Query().
+		Where(userversion.UserID(userID), userversion.Version(version)).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying user version: %w", err)
+	}
+	return entUserVersionToDomain(v), nil
+}
+
+// UserVersionAt returns the version of a user in effect at a point in time,
+// or nil if the user did not exist then or no history was recorded yet.
+func (d *DAO) UserVersionAt(ctx context.Context, userID uuid.UUID, at time.Time) (*domain.UserVersion, error) {
+	v, err := d.client.UserVersion.Query().
+		Where(userversion.UserID(userID), userversion.CreatedAtLTE(at)).
+		Order(ent.Desc(userversion.FieldVersion)).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying user version: %w", err)
+	}
+	if v.Operation == userversion.OperationDelete {
+		return nil, nil
+	}
+	return entUserVersionToDomain(v), nil
+}
+
+// GroupVersionAt is UserVersionAt for groups.
+func (d *DAO) GroupVersionAt(ctx context.Context, groupID uuid.UUID, at time.Time) (*domain.GroupVersion, error) {
+	v, err := d.client.GroupVersion.Query().
+		Where(groupversion.GroupID(groupID), groupversion.CreatedAtLTE(at)).
+		Order(ent.Desc(groupversion.FieldVersion)).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying group version: %w", err)
+	}
+	if v.Operation == groupversion.OperationDelete {
+		return nil, nil
+	}
+	return entGroupVersionToDomain(v), nil
+}
+
+// GroupMembersAt returns the IDs of the users who belonged to a group at a
+// point in time, replaying the membership changes up to then.
+func (d *DAO) GroupMembersAt(ctx context.Context, groupID uuid.UUID, at time.Time) ([]uuid.UUID, error) {
+	changes, err := d.client.GroupMembershipChange.Query().
+		Where(groupmembershipchange.GroupID(groupID), groupmembershipchange.CreatedAtLTE(at)).
+		Order(ent.Asc(groupmembershipchange.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying membership changes: %w", err)
+	}
+	return replayMemberships(changes, func(c *ent.GroupMembershipChange) uuid.UUID { return c.UserID }), nil
+}
+
+// UserGroupsAt returns the IDs of the groups a user belonged to at a point
+// in time.
+func (d *DAO) UserGroupsAt(ctx context.Context, userID uuid.UUID, at time.Time) ([]uuid.UUID, error) {
+	changes, err := d.client.GroupMembershipChange.Query().
+		Where(groupmembershipchange.UserID(userID), groupmembershipchange.CreatedAtLTE(at)).
+		Order(ent.Asc(groupmembershipchange.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying membership changes: %w", err)
+	}
+	return replayMemberships(changes, func(c *ent.GroupMembershipChange) uuid.UUID { return c.GroupID }), nil
+}
+
+// replayMemberships applies membership changes in order and returns the
+// IDs, as picked by key, that are members at the end, in the order they
+// joined.
+func replayMemberships(changes []*ent.GroupMembershipChange, key func(*ent.GroupMembershipChange) uuid.UUID) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, c := range changes {
+		id := key(c)
+		ids = slices.DeleteFunc(ids, func(x uuid.UUID) bool { return x == id })
+		if c.Operation == groupmembershipchange.OperationAdd {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func entUserVersionToDomain(v *ent.UserVersion) *domain.UserVersion {
+	return &domain.UserVersion{
+		UserID:           v.UserID,
+		Version:          v.Version,
+		Operation:        domain.VersionOperation(v.Operation),
+		Username:         v.Username,
+		DisplayName:      v.DisplayName,
+		Email:            v.Email,
+		Phone:            v.Phone,
+		Status:           domain.UserStatus(v.Status),
+		ManagerID:        v.ManagerID,
+		AccountExpiresAt: v.AccountExpiresAt,
+		Attributes:       v.Attributes,
+		ActorID:          v.ActorID,
+		ActorName:        v.ActorName,
+		CreatedAt:        v.CreatedAt,
+	}
+}
+
+func entGroupVersionToDomain(v *ent.GroupVersion) *domain.GroupVersion {
+	return &domain.GroupVersion{
+		GroupID:     v.GroupID,
+		Version:     v.Version,
+		Operation:   domain.VersionOperation(v.Operation),
+		Name:        v.Name,
+		Description: v.Description,
+		ParentID:    v.ParentID,
+		RequireMFA:  v.RequireMfa,
+		Attributes:  v.Attributes,
+		ActorID:     v.ActorID,
+		ActorName:   v.ActorName,
+		CreatedAt:   v.CreatedAt,
+	}
+}
+
+func entMembershipChangesToDomain(changes []*ent.GroupMembershipChange) []*domain.MembershipChange {
+	items := make([]*domain.MembershipChange, len(changes))
+	for i, c := range changes {
+		items[i] = &domain.MembershipChange{
+			GroupID:   c.GroupID,
+			UserID:    c.UserID,
+			Operation: domain.MembershipOperation(c.Operation),
+			ActorID:   c.ActorID,
+			ActorName: c.ActorName,
+			CreatedAt: c.CreatedAt,
+		}
+	}
+	return items
+}
@@ -0,0 +1,210 @@
+package dao
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent/outboxevent"
+)
+
+func TestHistoryRecordsVersionsAndMemberships(t *testing.T) {
+	d, ctx := setupGroupTestDAO(t)
+
+	alice, err := d.CreateUser(ctx, "alice", "Alice", "alice@example.com", "hash", "")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	bob, _ := d.CreateUser(ctx, "bob", "Bob", "bob@example.com", "hash", "")
+	g, err := d.CreateGroup(ctx, "finance-admins", "Finance", nil)
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if err := d.AddMembers(ctx, g.ID, []uuid.UUID{alice.ID, bob.ID}); err != nil {
+		t.Fatalf("AddMembers: %v", err)
+	}
+	both := time.Now()
+
+	name := "Alice Smith"
+	if _, err := d.UpdateUser(ctx, alice.ID, domain.UpdateUserInput{DisplayName: &name}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if err := d.RemoveMember(ctx, g.ID, bob.ID); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+	if err := d.DeleteUser(ctx, alice.ID); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	end := time.Now()
+
+	h, err := d.UserHistory(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("UserHistory: %v", err)
+	}
+	var ops []domain.VersionOperation
+	for _, v := range h.Versions {
+		ops = append(ops, v.Operation)
+	}
+	if want := []domain.VersionOperation{domain.VersionCreate, domain.VersionUpdate, domain.VersionDelete}; !slices.Equal(ops, want) {
+		t.Fatalf("versions = %v, want %v", ops, want)
+	}
+	if h.Versions[1].DisplayName != "Alice Smith" || h.Versions[1].Version != 2 {
+		t.Errorf("version 2 = %+v", h.Versions[1])
+	}
+	if len(h.Memberships) != 2 || h.Memberships[1].Operation != domain.MembershipRemove {
+		t.Errorf("memberships = %+v, want an add and the removal on deletion", h.Memberships)
+	}
+
+	members, err := d.GroupMembersAt(ctx, g.ID, both)
+	if err != nil {
+		t.Fatalf("GroupMembersAt: %v", err)
+	}
+	if !slices.Equal(members, []uuid.UUID{alice.ID, bob.ID}) && !slices.Equal(members, []uuid.UUID{bob.ID, alice.ID}) {
+		t.Errorf("members then = %v, want alice and bob", members)
+	}
+	if members, _ := d.GroupMembersAt(ctx, g.ID, end); len(members) != 0 {
+		t.Errorf("members now = %v, want none", members)
+	}
+
+	v, err := d.UserVersionAt(ctx, alice.ID, both)
+	if err != nil || v == nil || v.DisplayName != "Alice" {
+		t.Errorf("UserVersionAt(then) = %+v, %v, want Alice", v, err)
+	}
+	if v, _ := d.UserVersionAt(ctx, alice.ID, end); v != nil {
+		t.Errorf("UserVersionAt(after deletion) = %+v, want nil", v)
+	}
+}
+
+func TestHistorySkipsUnversionedChanges(t *testing.T) {
+	d, ctx := setupGroupTestDAO(t)
+
+	u, _ := d.CreateUser(ctx, "carol", "Carol", "carol@example.com", "hash", "")
+	if err := d.UpdatePasswordHash(ctx, u.ID, "other"); err != nil {
+		t.Fatalf("UpdatePasswordHash: %v", err)
+	}
+	if err := d.UpdateUserStatus(ctx, u.ID, "enabled"); err != nil {
+		t.Fatalf("UpdateUserStatus: %v", err)
+	}
+	h, _ := d.UserHistory(ctx, u.ID)
+	if len(h.Versions) != 1 {
+		t.Errorf("got %d versions, want only the creation", len(h.Versions))
+	}
+
+	err := d.Client().User.UpdateOneID(u.ID).SetDisplayName("Caroline").Exec(ctx)
+	if !errors.Is(err, ErrHistoryNeedsTx) {
+		t.Errorf("versioned change outside a transaction: err = %v, want ErrHistoryNeedsTx", err)
+	}
+}
+
+func TestHistoryVersionsAttributes(t *testing.T) {
+	d, ctx := setupGroupTestDAO(t)
+
+	def, err := d.CreateAttributeDefinition(ctx, domain.CreateAttributeDefinitionInput{
+		Name: "costCenter", Target: domain.AttributeTargetUser, Type: domain.AttributeTypeString, MultiValued: true, LDAPName: "costCenter",
+	})
+	if err != nil {
+		t.Fatalf("CreateAttributeDefinition: %v", err)
+	}
+	u, _ := d.CreateUser(ctx, "dave", "Dave", "dave@example.com", "hash", "")
+	if err := d.SetUserAttributes(ctx, u.ID, map[string][]string{"costCenter": {"CC-2", "CC-1"}}); err != nil {
+		t.Fatalf("SetUserAttributes: %v", err)
+	}
+	set := time.Now()
+	// Setting the same values again is not a change.
+	if err := d.SetUserAttributes(ctx, u.ID, map[string][]string{"costCenter": {"CC-1", "CC-2"}}); err != nil {
+		t.Fatalf("SetUserAttributes: %v", err)
+	}
+	if err := d.DeleteAttributeDefinition(ctx, def.ID); err != nil {
+		t.Fatalf("DeleteAttributeDefinition: %v", err)
+	}
+
+	h, _ := d.UserHistory(ctx, u.ID)
+	if len(h.Versions) != 3 || h.Versions[2].Attributes != nil {
+		t.Fatalf("versions = %+v, want creation, attributes set and attributes removed", h.Versions)
+	}
+	v, err := d.UserVersionAt(ctx, u.ID, set)
+	if err != nil || v == nil || !slices.Equal(v.Attributes["costCenter"], []string{"CC-1", "CC-2"}) {
+		t.Errorf("UserVersionAt(set) = %+v, %v, want the cost centers", v, err)
+	}
+
+	if _, err := d.CreateAttributeDefinition(ctx, domain.CreateAttributeDefinitionInput{
+		Name: "mailList", Target: domain.AttributeTargetGroup, Type: domain.AttributeTypeString, LDAPName: "mailList",
+	}); err != nil {
+		t.Fatalf("CreateAttributeDefinition: %v", err)
+	}
+	g, _ := d.CreateGroup(ctx, "ops", "", nil)
+	if err := d.SetGroupAttributes(ctx, g.ID, map[string][]string{"mailList": {"ops@example.com"}}); err != nil {
+		t.Fatalf("SetGroupAttributes: %v", err)
+	}
+	gh, _ := d.GroupHistory(ctx, g.ID)
+	if len(gh.Versions) != 2 || gh.Versions[1].Attributes["mailList"][0] != "ops@example.com" {
+		t.Errorf("group versions = %+v, want the mail list in version 2", gh.Versions)
+	}
+}
+
+func TestUpdateUserWithAttributesRecordsOneVersion(t *testing.T) {
+	d, ctx := setupGroupTestDAO(t)
+
+	if _, err := d.CreateAttributeDefinition(ctx, domain.CreateAttributeDefinitionInput{
+		Name: "costCenter", Target: domain.AttributeTargetUser, Type: domain.AttributeTypeString, LDAPName: "costCenter",
+	}); err != nil {
+		t.Fatalf("CreateAttributeDefinition: %v", err)
+	}
+	u, _ := d.CreateUser(ctx, "erin", "Erin", "erin@example.com", "hash", "")
+	name := "Erin Smith"
+	if _, err := d.UpdateUser(ctx, u.ID, domain.UpdateUserInput{
+		DisplayName: &name,
+		Attributes:  map[string][]string{"costCenter": {"CC-1"}},
+	}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	// An update of the attributes alone is versioned too.
+	if _, err := d.UpdateUser(ctx, u.ID, domain.UpdateUserInput{
+		Attributes: map[string][]string{"costCenter": {"CC-2"}},
+	}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+
+	h, _ := d.UserHistory(ctx, u.ID)
+	if len(h.Versions) != 3 {
+		t.Fatalf("versions = %+v, want creation and one per update", h.Versions)
+	}
+	if v := h.Versions[1]; v.DisplayName != name || v.Attributes["costCenter"][0] != "CC-1" {
+		t.Errorf("version 2 = %+v, want the new name and cost center", v)
+	}
+	events, _ := d.Client().OutboxEvent.Query().Where(outboxevent.EventType(domain.EventUserUpdated)).Count(ctx)
+	if events != 2 {
+		t.Errorf("user.updated events = %d, want 2", events)
+	}
+}
+
+func TestBackfillHistory(t *testing.T) {
+	d, ctx := setupGroupTestDAO(t)
+
+	u, _ := d.CreateUser(ctx, "dave", "Dave", "dave@example.com", "hash", "")
+	g, _ := d.CreateGroup(ctx, "ops", "", nil)
+	_ = d.AddMembers(ctx, g.ID, []uuid.UUID{u.ID})
+	// Forget the history, as for data from before it was recorded.
+	_, _ = d.Client().UserVersion.Delete().Exec(ctx)
+	_, _ = d.Client().GroupVersion.Delete().Exec(ctx)
+	_, _ = d.Client().GroupMembershipChange.Delete().Exec(ctx)
+
+	for range 2 {
+		if err := d.BackfillHistory(ctx); err != nil {
+			t.Fatalf("BackfillHistory: %v", err)
+		}
+	}
+
+	uh, _ := d.UserHistory(ctx, u.ID)
+	if len(uh.Versions) != 1 || uh.Versions[0].Operation != domain.VersionBaseline {
+		t.Errorf("user versions = %+v, want one baseline", uh.Versions)
+	}
+	gh, _ := d.GroupHistory(ctx, g.ID)
+	if len(gh.Versions) != 1 || len(gh.Memberships) != 1 || gh.Memberships[0].UserID != u.ID {
+		t.Errorf("group history = %+v, want a baseline with dave as member", gh)
+	}
+}
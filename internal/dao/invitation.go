@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/invitation"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// CreateInvitation records an invitation identified by the hash of its
+// token. Earlier invitations of the user that were not accepted are
+// deleted, so only the newest link works.
+func (d *DAO) CreateInvitation(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error {
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		if _, err := tx.Invitation.Delete().
+			Where(
+				invitation.HasUserWith(user.ID(userID)),
+				invitation.AcceptedAtIsNil(),
+			).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("deleting old invitations: %w", err)
+		}
+		if err := tx.Invitation.Create().
+			SetUserID(userID).
+			SetTokenHash(tokenHash).
+			SetExpiresAt(expiresAt).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("creating invitation: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetInvitation finds the invitation whose token has the given hash.
+func (d *DAO) GetInvitation(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	i, err := d.client.Invitation.Query().
+		Where(invitation.TokenHash(tokenHash)).
+		WithUser(func(q *ent.UserQuery) { q.Select(user.FieldID) }).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying invitation: %w", err)
+	}
+	return &domain.Invitation{
+		ID:         i.ID,
+		UserID:     i.Edges.User.ID,
+		CreatedAt:  i.CreatedAt,
+		ExpiresAt:  i.ExpiresAt,
+		AcceptedAt: i.AcceptedAt,
+	}, nil
+}
+
+// AcceptInvitation marks an invitation as accepted if it is still open and
+// unexpired. It reports false when it cannot be accepted, for example
+// because a concurrent request accepted it first.
+func (d *DAO) AcceptInvitation(ctx context.Context, id uuid.UUID) (bool, error) {
+	now := time.Now()
+	n, err := d.client.Invitation.Update().
+		Where(
+			invitation.ID(id),
+			invitation.AcceptedAtIsNil(),
+			invitation.ExpiresAtGT(now),
+		).
+		SetAcceptedAt(now).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("accepting invitation: %w", err)
+	}
+	return n == 1, nil
+}
+
+// ReleaseInvitation reopens an invitation after the password or MFA code
+// it was accepted with was refused.
+func (d *DAO) ReleaseInvitation(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.Invitation.UpdateOneID(id).ClearAcceptedAt().Exec(ctx); err != nil {
+		return fmt.Errorf("releasing invitation: %w", err)
+	}
+	return nil
+}
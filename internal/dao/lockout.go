@@ -0,0 +1,113 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/loginevent"
+)
+
+// RecordFailedLogin counts a failed login for a user and returns the number
+// of failures since windowStart. Failures before windowStart are forgotten.
+func (d *DAO) RecordFailedLogin(ctx context.Context, id uuid.UUID, windowStart time.Time) (int, error) {
+	var count int
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		u, err := tx.User.Get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("querying user: %w", err)
+		}
+		count = 1
+		if u.LastFailedLoginAt != nil && !u.LastFailedLoginAt.Before(windowStart) {
+			count = u.FailedLoginCount + 1
+		}
+		if err := tx.User.UpdateOneID(id).
+			SetFailedLoginCount(count).
+			SetLastFailedLoginAt(time.Now()).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("recording failed login: %w", err)
+		}
+		return nil
+	})
+	return count, err
+}
+
+// LockUser locks a user out until the given time and starts counting
+// failed logins afresh.
+func (d *DAO) LockUser(ctx context.Context, id uuid.UUID, until time.Time) error {
+	if err := d.client.User.UpdateOneID(id).
+		SetLockedAt(time.Now()).
+		SetLockedUntil(until).
+		AddLockoutCount(1).
+		SetFailedLoginCount(0).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("locking user: %w", err)
+	}
+	return nil
+}
+
+// ResetLockout clears a user's failed login count and lockout, after a
+// successful login or when an administrator unlocks the account.
+func (d *DAO) ResetLockout(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.User.UpdateOneID(id).
+		SetFailedLoginCount(0).
+		ClearLastFailedLoginAt().
+		SetLockoutCount(0).
+		ClearLockedAt().
+		ClearLockedUntil().
+		Exec(ctx); err != nil {
+		return fmt.Errorf("resetting lockout: %w", err)
+	}
+	return nil
+}
+
+// CreateLoginEvent appends an event to the login audit trail.
+func (d *DAO) CreateLoginEvent(ctx context.Context, e *domain.LoginEvent) error {
+	if err := d.client.LoginEvent.Create().
+		SetNillableUserID(e.UserID).
+		SetUsername(e.Username).
+		SetType(loginevent.Type(e.Type)).
+		SetReason(e.Reason).
+		SetProtocol(e.Protocol).
+		SetIPAddress(e.IPAddress).
+		SetNillableActorID(e.ActorID).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("creating login event: %w", err)
+	}
+	return nil
+}
+
+// ListLoginEvents returns a user's most recent login events, newest first.
+func (d *DAO) ListLoginEvents(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.LoginEvent, error) {
+	events, err := d.client.LoginEvent.Query().
+		Where(loginevent.UserID(userID)).
+		Order(ent.Desc(loginevent.FieldCreatedAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing login events: %w", err)
+	}
+	result := make([]*domain.LoginEvent, len(events))
+	for i, e := range events {
+		result[i] = entLoginEventToDomain(e)
+	}
+	return result, nil
+}
+
+func entLoginEventToDomain(e *ent.LoginEvent) *domain.LoginEvent {
+	return &domain.LoginEvent{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Username:  e.Username,
+		Type:      domain.LoginEventType(e.Type),
+		Reason:    e.Reason,
+		Protocol:  e.Protocol,
+		IPAddress: e.IPAddress,
+		ActorID:   e.ActorID,
+		CreatedAt: e.CreatedAt,
+	}
+}
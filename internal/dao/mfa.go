@@ -0,0 +1,138 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/recoverycode"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// SetPendingTOTPSecret stores a TOTP secret that has not been confirmed yet.
+// It fails if the user already has MFA enabled.
+func (d *DAO) SetPendingTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	n, err := d.client.User.Update().
+		Where(user.ID(userID), user.MfaEnabled(false)).
+		SetTotpSecret(secret).
+		SetTotpLastStep(0).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("storing totp secret: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("user %s not found or mfa already enabled", userID)
+	}
+	return nil
+}
+
+// EnableMFA marks the pending TOTP secret as confirmed at the given time
+// step and replaces the user's recovery codes.
+func (d *DAO) EnableMFA(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error {
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		n, err := tx.User.Update().
+			Where(user.ID(userID), user.MfaEnabled(false), user.TotpSecretNEQ("")).
+			SetMfaEnabled(true).
+			SetTotpLastStep(step).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("enabling mfa: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("user %s has no pending totp secret", userID)
+		}
+		return replaceRecoveryCodes(ctx, tx, userID, codeHashes)
+	})
+}
+
+// DisableMFA removes a user's TOTP secret and recovery codes.
+func (d *DAO) DisableMFA(ctx context.Context, userID uuid.UUID) error {
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		if err := tx.User.UpdateOneID(userID).
+			ClearTotpSecret().
+			SetMfaEnabled(false).
+			SetTotpLastStep(0).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("disabling mfa: %w", err)
+		}
+		return replaceRecoveryCodes(ctx, tx, userID, nil)
+	})
+}
+
+// UseTOTPStep records step as the last accepted TOTP time step. It returns
+// false if a code from the same or a later step was already used, so each
+// code is accepted at most once.
+func (d *DAO) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	n, err := d.client.User.Update().
+		Where(user.ID(userID), user.MfaEnabled(true), user.TotpLastStepLT(step)).
+		SetTotpLastStep(step).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("recording totp step: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ReplaceRecoveryCodes replaces all of a user's recovery codes.
+func (d *DAO) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		return replaceRecoveryCodes(ctx, tx, userID, codeHashes)
+	})
+}
+
+// UseRecoveryCode consumes the recovery code with the given hash. It returns
+// false if the user has no such code.
+func (d *DAO) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	n, err := d.client.RecoveryCode.Delete().
+		Where(recoverycode.CodeHash(codeHash), recoverycode.HasUserWith(user.ID(userID))).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("using recovery code: %w", err)
+	}
+	return n > 0, nil
+}
+
+// CountRecoveryCodes returns how many unused recovery codes a user has.
+func (d *DAO) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	n, err := d.client.RecoveryCode.Query().
+		Where(recoverycode.HasUserWith(user.ID(userID))).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("counting recovery codes: %w", err)
+	}
+	return n, nil
+}
+
+// UserRequiresMFA reports whether the user belongs to a group that
+// requires multi-factor authentication.
+func (d *DAO) UserRequiresMFA(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ok, err := d.client.Group.Query().
+		Where(group.RequireMfa(true), group.HasUsersWith(user.ID(userID))).
+		Exist(ctx)
+	if err != nil {
+		return false, fmt.Errorf("checking mfa policy: %w", err)
+	}
+	return ok, nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *ent.Tx, userID uuid.UUID, codeHashes []string) error {
+	if _, err := tx.RecoveryCode.Delete().
+		Where(recoverycode.HasUserWith(user.ID(userID))).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("deleting recovery codes: %w", err)
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+	builders := make([]*ent.RecoveryCodeCreate, len(codeHashes))
+	for i, h := range codeHashes {
+		builders[i] = tx.RecoveryCode.Create().SetCodeHash(h).SetUserID(userID)
+	}
+	if _, err := tx.RecoveryCode.CreateBulk(builders...).Save(ctx); err != nil {
+		return fmt.Errorf("creating recovery codes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/authorizationcode"
+	"github.com/qinzj/claude-demo/internal/ent/oidcclient"
+	"github.com/qinzj/claude-demo/internal/ent/oidcconsent"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// CreateOIDCClient registers an OIDC client. secretHash is empty for public clients.
+func (d *DAO) CreateOIDCClient(ctx context.Context, input domain.CreateOIDCClientInput, secretHash string) (*domain.OIDCClient, error) {
+	c, err := d.client.OIDCClient.Create().
+		SetName(input.Name).
+		SetRedirectUris(input.RedirectURIs).
+		SetPublic(input.Public).
+		SetSecretHash(secretHash).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating oidc client: %w", err)
+	}
+	return entOIDCClientToDomain(c), nil
+}
+
+// GetOIDCClient retrieves an OIDC client by ID.
+func (d *DAO) GetOIDCClient(ctx context.Context, id uuid.UUID) (*domain.OIDCClient, error) {
+	c, err := d.client.OIDCClient.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("querying oidc client: %w", err)
+	}
+	return entOIDCClientToDomain(c), nil
+}
+
+// ListOIDCClients returns all OIDC clients ordered by name.
+func (d *DAO) ListOIDCClients(ctx context.Context) ([]*domain.OIDCClient, error) {
+	clients, err := d.client.OIDCClient.Query().
+		Order(ent.Asc(oidcclient.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing oidc clients: %w", err)
+	}
+
+	items := make([]*domain.OIDCClient, len(clients))
+	for i, c := range clients {
+		items[i] = entOIDCClientToDomain(c)
+	}
+	return items, nil
+}
+
+// UpdateOIDCClient updates an OIDC client's name and redirect URIs.
+func (d *DAO) UpdateOIDCClient(ctx context.Context, id uuid.UUID, input domain.UpdateOIDCClientInput) (*domain.OIDCClient, error) {
+	update := d.client.OIDCClient.UpdateOneID(id)
+	if input.Name != nil {
+		update = update.SetName(*input.Name)
+	}
+	if input.RedirectURIs != nil {
+		update = update.SetRedirectUris(input.RedirectURIs)
+	}
+
+	c, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("updating oidc client: %w", err)
+	}
+	return entOIDCClientToDomain(c), nil
+}
+
+// UpdateOIDCClientSecret replaces a confidential client's secret hash.
+func (d *DAO) UpdateOIDCClientSecret(ctx context.Context, id uuid.UUID, secretHash string) error {
+	if err := d.client.OIDCClient.UpdateOneID(id).SetSecretHash(secretHash).Exec(ctx); err != nil {
+		return fmt.Errorf("updating oidc client secret: %w", err)
+	}
+	return nil
+}
+
+// DeleteOIDCClient deletes an OIDC client with its codes and consents.
+func (d *DAO) DeleteOIDCClient(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.OIDCClient.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting oidc client: %w", err)
+	}
+	return nil
+}
+
+// CreateAuthorizationCode stores an authorization code under its hash.
+func (d *DAO) CreateAuthorizationCode(ctx context.Context, codeHash string, code domain.AuthorizationCode) error {
+	err := d.client.AuthorizationCode.Create().
+		SetCodeHash(codeHash).
+		SetClientID(code.ClientID).
+		SetUserID(code.UserID).
+		SetRedirectURI(code.RedirectURI).
+		SetScopes(code.Scopes).
+		SetNonce(code.Nonce).
+		SetCodeChallenge(code.CodeChallenge).
+		SetAuthTime(code.AuthTime).
+		SetExpiresAt(code.ExpiresAt).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("creating authorization code: %w", err)
+	}
+	return nil
+}
+
+// ConsumeAuthorizationCode looks up and deletes the code with the given hash,
+// so that each code can be exchanged at most once, even concurrently.
+func (d *DAO) ConsumeAuthorizationCode(ctx context.Context, codeHash string) (*domain.AuthorizationCode, error) {
+	var code *domain.AuthorizationCode
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		c, err := tx.AuthorizationCode.Query().
+			Where(authorizationcode.CodeHash(codeHash)).
+			WithClient(func(q *ent.OIDCClientQuery) { q.Select(oidcclient.FieldID) }).
+			WithUser(func(q *ent.UserQuery) { q.Select(user.FieldID) }).
+			Only(ctx)
+		if err != nil {
+			return fmt.Errorf("querying authorization code: %w", err)
+		}
+		n, err := tx.AuthorizationCode.Delete().Where(authorizationcode.ID(c.ID)).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("deleting authorization code: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("authorization code already used")
+		}
+		code = &domain.AuthorizationCode{
+			ClientID:      c.Edges.Client.ID,
+			UserID:        c.Edges.User.ID,
+			RedirectURI:   c.RedirectURI,
+			Scopes:        c.Scopes,
+			Nonce:         c.Nonce,
+			CodeChallenge: c.CodeChallenge,
+			AuthTime:      c.AuthTime,
+			ExpiresAt:     c.ExpiresAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// GetOIDCConsent returns the scopes a user has granted to a client, or nil
+// if the user has not consented yet.
+func (d *DAO) GetOIDCConsent(ctx context.Context, userID, clientID uuid.UUID) ([]string, error) {
+	c, err := d.client.OIDCConsent.Query().
+		Where(
+			oidcconsent.HasUserWith(user.ID(userID)),
+			oidcconsent.HasClientWith(oidcclient.ID(clientID)),
+		).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying oidc consent: %w", err)
+	}
+	return c.Scopes, nil
+}
+
+// SaveOIDCConsent records the scopes a user has granted to a client,
+// replacing any earlier consent.
+func (d *DAO) SaveOIDCConsent(ctx context.Context, userID, clientID uuid.UUID, scopes []string) error {
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		n, err := tx.OIDCConsent.Update().
+			Where(
+				oidcconsent.HasUserWith(user.ID(userID)),
+				oidcconsent.HasClientWith(oidcclient.ID(clientID)),
+			).
+			SetScopes(scopes).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("updating oidc consent: %w", err)
+		}
+		if n > 0 {
+			return nil
+		}
+		if err := tx.OIDCConsent.Create().
+			SetUserID(userID).
+			SetClientID(clientID).
+			SetScopes(scopes).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("creating oidc consent: %w", err)
+		}
+		return nil
+	})
+}
+
+func entOIDCClientToDomain(c *ent.OIDCClient) *domain.OIDCClient {
+	return &domain.OIDCClient{
+		ID:           c.ID,
+		Name:         c.Name,
+		RedirectURIs: c.RedirectUris,
+		Public:       c.Public,
+		SecretHash:   c.SecretHash,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+	}
+}
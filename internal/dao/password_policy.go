@@ -0,0 +1,263 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/passwordhistory"
+	"github.com/qinzj/claude-demo/internal/ent/passwordpolicy"
+	"github.com/qinzj/claude-demo/internal/ent/passwordresettoken"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// CreatePasswordPolicy creates a new password policy.
+func (d *DAO) CreatePasswordPolicy(ctx context.Context, input domain.CreatePasswordPolicyInput, builtin bool) (*domain.PasswordPolicy, error) {
+	r := input.Rules
+	p, err := d.client.PasswordPolicy.Create().
+		SetName(input.Name).
+		SetDescription(input.Description).
+		SetBuiltin(builtin).
+		SetMinLength(r.MinLength).
+		SetRequireUppercase(r.RequireUppercase).
+		SetRequireLowercase(r.RequireLowercase).
+		SetRequireDigit(r.RequireDigit).
+		SetRequireSymbol(r.RequireSymbol).
+		SetCheckDictionary(r.CheckDictionary).
+		SetCheckUsername(r.CheckUsername).
+		SetHistoryCount(r.HistoryCount).
+		SetMinAgeHours(r.MinAgeHours).
+		SetMaxAgeDays(r.MaxAgeDays).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating password policy: %w", err)
+	}
+	return entPasswordPolicyToDomain(p), nil
+}
+
+// GetPasswordPolicyByID retrieves a password policy by ID with its groups eagerly loaded.
+func (d *DAO) GetPasswordPolicyByID(ctx context.Context, id uuid.UUID) (*domain.PasswordPolicy, error) {
+	p, err := d.client.PasswordPolicy.Query().
+		Where(passwordpolicy.ID(id)).
+		WithGroups().
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying password policy by id: %w", err)
+	}
+	return entPasswordPolicyToDomain(p), nil
+}
+
+// GetPasswordPolicyByName retrieves a password policy by name.
+func (d *DAO) GetPasswordPolicyByName(ctx context.Context, name string) (*domain.PasswordPolicy, error) {
+	p, err := d.client.PasswordPolicy.Query().
+		Where(passwordpolicy.Name(name)).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying password policy by name: %w", err)
+	}
+	return entPasswordPolicyToDomain(p), nil
+}
+
+// ListPasswordPolicies returns all password policies ordered by name.
+func (d *DAO) ListPasswordPolicies(ctx context.Context) ([]*domain.PasswordPolicy, error) {
+	policies, err := d.client.PasswordPolicy.Query().
+		Order(ent.Asc(passwordpolicy.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing password policies: %w", err)
+	}
+
+	items := make([]*domain.PasswordPolicy, len(policies))
+	for i, p := range policies {
+		items[i] = entPasswordPolicyToDomain(p)
+	}
+	return items, nil
+}
+
+// UpdatePasswordPolicy updates password policy fields.
+func (d *DAO) UpdatePasswordPolicy(ctx context.Context, id uuid.UUID, input domain.UpdatePasswordPolicyInput) (*domain.PasswordPolicy, error) {
+	p, err := d.client.PasswordPolicy.UpdateOneID(id).
+		SetNillableName(input.Name).
+		SetNillableDescription(input.Description).
+		SetNillableMinLength(input.MinLength).
+		SetNillableRequireUppercase(input.RequireUppercase).
+		SetNillableRequireLowercase(input.RequireLowercase).
+		SetNillableRequireDigit(input.RequireDigit).
+		SetNillableRequireSymbol(input.RequireSymbol).
+		SetNillableCheckDictionary(input.CheckDictionary).
+		SetNillableCheckUsername(input.CheckUsername).
+		SetNillableHistoryCount(input.HistoryCount).
+		SetNillableMinAgeHours(input.MinAgeHours).
+		SetNillableMaxAgeDays(input.MaxAgeDays).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("updating password policy: %w", err)
+	}
+	return entPasswordPolicyToDomain(p), nil
+}
+
+// DeletePasswordPolicy deletes a password policy by ID.
+func (d *DAO) DeletePasswordPolicy(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.PasswordPolicy.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting password policy: %w", err)
+	}
+	return nil
+}
+
+// AddPasswordPolicyGroups applies a password policy to the members of groups.
+func (d *DAO) AddPasswordPolicyGroups(ctx context.Context, policyID uuid.UUID, groupIDs []uuid.UUID) error {
+	if _, err := d.client.PasswordPolicy.UpdateOneID(policyID).AddGroupIDs(groupIDs...).Save(ctx); err != nil {
+		return fmt.Errorf("assigning password policy to groups: %w", err)
+	}
+	return nil
+}
+
+// RemovePasswordPolicyGroup stops applying a password policy to a group.
+func (d *DAO) RemovePasswordPolicyGroup(ctx context.Context, policyID, groupID uuid.UUID) error {
+	if _, err := d.client.PasswordPolicy.UpdateOneID(policyID).RemoveGroupIDs(groupID).Save(ctx); err != nil {
+		return fmt.Errorf("unassigning password policy from group: %w", err)
+	}
+	return nil
+}
+
+// UserPasswordPolicies returns the built-in policies together with the
+// policies assigned to groups the user directly belongs to.
+func (d *DAO) UserPasswordPolicies(ctx context.Context, userID uuid.UUID) ([]*domain.PasswordPolicy, error) {
+	policies, err := d.client.PasswordPolicy.Query().
+		Where(passwordpolicy.Or(
+			passwordpolicy.Builtin(true),
+			passwordpolicy.HasGroupsWith(group.HasUsersWith(user.ID(userID))),
+		)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying user password policies: %w", err)
+	}
+
+	items := make([]*domain.PasswordPolicy, len(policies))
+	for i, p := range policies {
+		items[i] = entPasswordPolicyToDomain(p)
+	}
+	return items, nil
+}
+
+// PasswordHistory returns the hashes of a user's previous passwords,
+// newest first, at most limit of them.
+func (d *DAO) PasswordHistory(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	hashes, err := d.client.PasswordHistory.Query().
+		Where(passwordhistory.HasUserWith(user.ID(userID))).
+		Order(ent.Desc(passwordhistory.FieldCreatedAt)).
+		Limit(limit).
+		Select(passwordhistory.FieldPasswordHash).
+		Strings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying password history: %w", err)
+	}
+	return hashes, nil
+}
+
+// UpdateUserPassword replaces a user's password hash and records when it
+// was changed. The replaced hash is added to the user's password history,
+// which is trimmed to the newest keepHistory entries. Unused password reset
+// links are invalidated.
+func (d *DAO) UpdateUserPassword(ctx context.Context, id uuid.UUID, passwordHash string, mustChange bool, keepHistory int) error {
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		old, err := tx.User.Query().
+			Where(user.ID(id)).
+			Select(user.FieldPasswordHash).
+			String(ctx)
+		if err != nil {
+			return fmt.Errorf("querying current password: %w", err)
+		}
+		if err := tx.User.UpdateOneID(id).
+			SetPasswordHash(passwordHash).
+			SetPasswordChangedAt(time.Now()).
+			SetMustChangePassword(mustChange).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("updating user password: %w", err)
+		}
+
+		if keepHistory > 0 && old != "" {
+			if err := tx.PasswordHistory.Create().
+				SetPasswordHash(old).
+				SetUserID(id).
+				Exec(ctx); err != nil {
+				return fmt.Errorf("recording password history: %w", err)
+			}
+		}
+		keep, err := tx.PasswordHistory.Query().
+			Where(passwordhistory.HasUserWith(user.ID(id))).
+			Order(ent.Desc(passwordhistory.FieldCreatedAt)).
+			Limit(keepHistory).
+			IDs(ctx)
+		if err != nil {
+			return fmt.Errorf("querying password history: %w", err)
+		}
+		if _, err := tx.PasswordHistory.Delete().
+			Where(passwordhistory.HasUserWith(user.ID(id)), passwordhistory.IDNotIn(keep...)).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("trimming password history: %w", err)
+		}
+
+		// Reset links sent before the change must not undo it.
+		if err := tx.PasswordResetToken.Update().
+			Where(passwordresettoken.HasUserWith(user.ID(id)), passwordresettoken.UsedAtIsNil()).
+			SetUsedAt(time.Now()).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("invalidating password reset tokens: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdatePasswordHash replaces the hash of a user's unchanged password, when
+// it is rehashed in another scheme.
+func (d *DAO) UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	if err := d.client.User.UpdateOneID(id).SetPasswordHash(passwordHash).Exec(ctx); err != nil {
+		return fmt.Errorf("updating password hash: %w", err)
+	}
+	return nil
+}
+
+// SetMustChangePassword sets whether a user has to change their password
+// at the next login.
+func (d *DAO) SetMustChangePassword(ctx context.Context, id uuid.UUID, mustChange bool) error {
+	if err := d.client.User.UpdateOneID(id).SetMustChangePassword(mustChange).Exec(ctx); err != nil {
+		return fmt.Errorf("updating must change password: %w", err)
+	}
+	return nil
+}
+
+func entPasswordPolicyToDomain(p *ent.PasswordPolicy) *domain.PasswordPolicy {
+	dp := &domain.PasswordPolicy{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Builtin:     p.Builtin,
+		PasswordRules: domain.PasswordRules{
+			MinLength:        p.MinLength,
+			RequireUppercase: p.RequireUppercase,
+			RequireLowercase: p.RequireLowercase,
+			RequireDigit:     p.RequireDigit,
+			RequireSymbol:    p.RequireSymbol,
+			CheckDictionary:  p.CheckDictionary,
+			CheckUsername:    p.CheckUsername,
+			HistoryCount:     p.HistoryCount,
+			MinAgeHours:      p.MinAgeHours,
+			MaxAgeDays:       p.MaxAgeDays,
+		},
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+	if p.Edges.Groups != nil {
+		dp.Groups = make([]*domain.Group, len(p.Edges.Groups))
+		for i, g := range p.Edges.Groups {
+			dp.Groups[i] = entGroupToDomain(g)
+		}
+	}
+	return dp
+}
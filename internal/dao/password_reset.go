@@ -0,0 +1,108 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/passwordresettoken"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// CreatePasswordResetToken records a password reset link identified by the
+// hash of its token.
+func (d *DAO) CreatePasswordResetToken(ctx context.Context, userID uuid.UUID, tokenHash, ipAddress string, expiresAt time.Time) error {
+	if err := d.client.PasswordResetToken.Create().
+		SetUserID(userID).
+		SetTokenHash(tokenHash).
+		SetIPAddress(ipAddress).
+		SetExpiresAt(expiresAt).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("creating password reset token: %w", err)
+	}
+	return nil
+}
+
+// CreateLimitedPasswordResetToken stores a new reset link as
+// CreatePasswordResetToken does, unless limit links were already created
+// for the user since the given time, and reports whether it did. The links
+// are counted in the same transaction.
+func (d *DAO) CreateLimitedPasswordResetToken(ctx context.Context, userID uuid.UUID, tokenHash, ipAddress string, expiresAt, since time.Time, limit int) (bool, error) {
+	created := false
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		n, err := tx.PasswordResetToken.Query().
+			Where(
+				passwordresettoken.HasUserWith(user.ID(userID)),
+				passwordresettoken.CreatedAtGTE(since),
+			).
+			Count(ctx)
+		if err != nil {
+			return fmt.Errorf("counting password reset tokens: %w", err)
+		}
+		if n >= limit {
+			return nil
+		}
+		if err := tx.PasswordResetToken.Create().
+			SetUserID(userID).
+			SetTokenHash(tokenHash).
+			SetIPAddress(ipAddress).
+			SetExpiresAt(expiresAt).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("creating password reset token: %w", err)
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// GetPasswordResetToken finds the reset link whose token has the given hash.
+func (d *DAO) GetPasswordResetToken(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	t, err := d.client.PasswordResetToken.Query().
+		Where(passwordresettoken.TokenHash(tokenHash)).
+		WithUser(func(q *ent.UserQuery) { q.Select(user.FieldID) }).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying password reset token: %w", err)
+	}
+	return &domain.PasswordResetToken{
+		ID:        t.ID,
+		UserID:    t.Edges.User.ID,
+		IPAddress: t.IPAddress,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.ExpiresAt,
+		UsedAt:    t.UsedAt,
+	}, nil
+}
+
+// UsePasswordResetToken marks a reset link as used if it is still unused
+// and unexpired. It reports false when the link cannot be used, for example
+// because a concurrent request used it first.
+func (d *DAO) UsePasswordResetToken(ctx context.Context, id uuid.UUID) (bool, error) {
+	now := time.Now()
+	n, err := d.client.PasswordResetToken.Update().
+		Where(
+			passwordresettoken.ID(id),
+			passwordresettoken.UsedAtIsNil(),
+			passwordresettoken.ExpiresAtGT(now),
+		).
+		SetUsedAt(now).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("using password reset token: %w", err)
+	}
+	return n == 1, nil
+}
+
+// ReleasePasswordResetToken makes a reset link usable again after the
+// password it was used for was refused.
+func (d *DAO) ReleasePasswordResetToken(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.PasswordResetToken.UpdateOneID(id).ClearUsedAt().Exec(ctx); err != nil {
+		return fmt.Errorf("releasing password reset token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/outboxevent"
+	"github.com/qinzj/claude-demo/internal/ent/provisionedobject"
+	"github.com/qinzj/claude-demo/internal/ent/provisioningconnector"
+)
+
+// CreateConnector creates a provisioning connector.
+func (d *DAO) CreateConnector(ctx context.Context, input domain.CreateConnectorInput) (*domain.ProvisioningConnector, error) {
+	c, err := d.client.ProvisioningConnector.Create().
+		SetName(input.Name).
+		SetURL(input.URL).
+		SetToken(input.Token).
+		SetNillableScopeGroupID(input.ScopeGroupID).
+		SetSyncGroups(input.SyncGroups).
+		SetAttributeMapping(input.AttributeMapping).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating connector: %w", err)
+	}
+	return entConnectorToDomain(c), nil
+}
+
+// GetConnector retrieves a provisioning connector by ID, with its token
+// and status.
+func (d *DAO) GetConnector(ctx context.Context, id uuid.UUID) (*domain.ProvisioningConnector, error) {
+	c, err := d.client.ProvisioningConnector.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("querying connector: %w", err)
+	}
+	conn := entConnectorToDomain(c)
+	if err := d.countProvisionedObjects(ctx, conn); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// ListConnectors returns all provisioning connectors ordered by name, with
+// their status.
+func (d *DAO) ListConnectors(ctx context.Context) ([]*domain.ProvisioningConnector, error) {
+	conns, err := d.client.ProvisioningConnector.Query().
+		Order(ent.Asc(provisioningconnector.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing connectors: %w", err)
+	}
+
+	items := make([]*domain.ProvisioningConnector, len(conns))
+	for i, c := range conns {
+		items[i] = entConnectorToDomain(c)
+		if err := d.countProvisionedObjects(ctx, items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// countProvisionedObjects sets the counts of a connector's status: the
+// users and groups provisioned and the objects failing.
+func (d *DAO) countProvisionedObjects(ctx context.Context, conn *domain.ProvisioningConnector) error {
+	query := d.client.ProvisionedObject.Query().
+		Where(provisionedobject.HasConnectorWith(provisioningconnector.ID(conn.ID)))
+	provisioned := func(t provisionedobject.ResourceType) (int, error) {
+		return query.Clone().
+			Where(provisionedobject.ResourceTypeEQ(t), provisionedobject.RemoteIDNEQ("")).
+			Count(ctx)
+	}
+
+	var err error
+	if conn.Status.Users, err = provisioned(provisionedobject.ResourceTypeUser); err != nil {
+		return fmt.Errorf("counting provisioned users: %w", err)
+	}
+	if conn.Status.Groups, err = provisioned(provisionedobject.ResourceTypeGroup); err != nil {
+		return fmt.Errorf("counting provisioned groups: %w", err)
+	}
+	if conn.Status.Failed, err = query.Clone().Where(provisionedobject.LastErrorNEQ("")).Count(ctx); err != nil {
+		return fmt.Errorf("counting failed objects: %w", err)
+	}
+	return nil
+}
+
+// UpdateConnector updates provisioning connector fields.
+func (d *DAO) UpdateConnector(ctx context.Context, id uuid.UUID, input domain.UpdateConnectorInput) (*domain.ProvisioningConnector, error) {
+	update := d.client.ProvisioningConnector.UpdateOneID(id)
+	if input.Name != nil {
+		update = update.SetName(*input.Name)
+	}
+	if input.URL != nil {
+		update = update.SetURL(*input.URL)
+	}
+	if input.Token != nil {
+		update = update.SetToken(*input.Token)
+	}
+	if input.ClearScope {
+		update = update.ClearScopeGroupID()
+	} else if input.ScopeGroupID != nil {
+		update = update.SetScopeGroupID(*input.ScopeGroupID)
+	}
+	if input.SyncGroups != nil {
+		update = update.SetSyncGroups(*input.SyncGroups)
+	}
+	if input.AttributeMapping != nil {
+		update = update.SetAttributeMapping(input.AttributeMapping)
+	}
+	if input.Enabled != nil {
+		update = update.SetEnabled(*input.Enabled)
+	}
+	c, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("updating connector: %w", err)
+	}
+	return entConnectorToDomain(c), nil
+}
+
+// ResetConnectorSync makes the next synchronization of a connector a full
+// one. With forget, the connector's objects are deleted too, so that every
+// resource is looked up in the application again.
+func (d *DAO) ResetConnectorSync(ctx context.Context, id uuid.UUID, forget bool) error {
+	return d.withTx(ctx, func(tx *ent.Tx) error {
+		if err := tx.ProvisioningConnector.UpdateOneID(id).ClearLastFullSyncAt().Exec(ctx); err != nil {
+			return fmt.Errorf("resetting connector: %w", err)
+		}
+		if !forget {
+			return nil
+		}
+		if _, err := tx.ProvisionedObject.Delete().
+			Where(provisionedobject.HasConnectorWith(provisioningconnector.ID(id))).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("deleting provisioned objects: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteConnector deletes a provisioning connector and its objects.
+func (d *DAO) DeleteConnector(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.ProvisioningConnector.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting connector: %w", err)
+	}
+	return nil
+}
+
+// EnabledConnectors returns the enabled provisioning connectors, with
+// their tokens but without counts.
+func (d *DAO) EnabledConnectors(ctx context.Context) ([]*domain.ProvisioningConnector, error) {
+	conns, err := d.client.ProvisioningConnector.Query().
+		Where(provisioningconnector.Enabled(true)).
+		Order(ent.Asc(provisioningconnector.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying enabled connectors: %w", err)
+	}
+	items := make([]*domain.ProvisioningConnector, len(conns))
+	for i, c := range conns {
+		items[i] = entConnectorToDomain(c)
+	}
+	return items, nil
+}
+
+// RecordConnectorSync records a synchronization of a connector that took
+// into account the changes up to cursor and ended with lastError, if any.
+func (d *DAO) RecordConnectorSync(ctx context.Context, id uuid.UUID, full bool, cursor *time.Time, lastError string) error {
+	now := time.Now()
+	update := d.client.ProvisioningConnector.UpdateOneID(id).
+		SetLastSyncAt(now).
+		SetNillableEventCursor(cursor).
+		SetLastError(lastError)
+	if full {
+		update = update.SetLastFullSyncAt(now)
+	}
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("recording connector sync: %w", err)
+	}
+	return nil
+}
+
+// LatestEventTime returns the creation time of the newest outbox event, or
+// nil if there are none. Events are purged after the webhook retention
+// period, so nil also means nothing changed within it.
+func (d *DAO) LatestEventTime(ctx context.Context) (*time.Time, error) {
+	e, err := d.client.OutboxEvent.Query().
+		Order(ent.Desc(outboxevent.FieldCreatedAt)).
+		Select(outboxevent.FieldCreatedAt).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying latest outbox event: %w", err)
+	}
+	return &e.CreatedAt, nil
+}
+
+// ProvisionedObjects returns all objects of a connector.
+func (d *DAO) ProvisionedObjects(ctx context.Context, connectorID uuid.UUID) ([]*domain.ProvisionedObject, error) {
+	objects, err := d.client.ProvisionedObject.Query().
+		Where(provisionedobject.HasConnectorWith(provisioningconnector.ID(connectorID))).
+		WithConnector(func(q *ent.ProvisioningConnectorQuery) { q.Select(provisioningconnector.FieldID) }).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying provisioned objects: %w", err)
+	}
+	items := make([]*domain.ProvisionedObject, len(objects))
+	for i, o := range objects {
+		items[i] = entProvisionedObjectToDomain(o)
+	}
+	return items, nil
+}
+
+// SaveProvisionedObject creates an object of a connector, if its ID is
+// nil, or updates its remote ID, hash, error and synchronization time.
+func (d *DAO) SaveProvisionedObject(ctx context.Context, o *domain.ProvisionedObject) (*domain.ProvisionedObject, error) {
+	var saved *ent.ProvisionedObject
+	var err error
+	if o.ID == uuid.Nil {
+		saved, err = d.client.ProvisionedObject.Create().
+			SetConnectorID(o.ConnectorID).
+			SetResourceType(provisionedobject.ResourceType(o.ResourceType)).
+			SetLocalID(o.LocalID).
+			SetRemoteID(o.RemoteID).
+			SetHash(o.Hash).
+			SetLastError(o.LastError).
+			SetNillableSyncedAt(o.SyncedAt).
+			Save(ctx)
+	} else {
+		saved, err = d.client.ProvisionedObject.UpdateOneID(o.ID).
+			SetRemoteID(o.RemoteID).
+			SetHash(o.Hash).
+			SetLastError(o.LastError).
+			SetNillableSyncedAt(o.SyncedAt).
+			Save(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("saving provisioned object: %w", err)
+	}
+	p := entProvisionedObjectToDomain(saved)
+	p.ConnectorID = o.ConnectorID
+	return p, nil
+}
+
+// DeleteProvisionedObject deletes an object of a connector.
+func (d *DAO) DeleteProvisionedObject(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.ProvisionedObject.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting provisioned object: %w", err)
+	}
+	return nil
+}
+
+// ListProvisionedObjects returns a page of the objects of a connector,
+// failing ones first and then most recently updated first.
+func (d *DAO) ListProvisionedObjects(ctx context.Context, connectorID uuid.UUID, input domain.ListProvisionedObjectsInput) (*domain.ListResult[domain.ProvisionedObject], error) {
+	query := d.client.ProvisionedObject.Query().
+		Where(provisionedobject.HasConnectorWith(provisioningconnector.ID(connectorID)))
+	if input.ResourceType != "" {
+		query = query.Where(provisionedobject.ResourceTypeEQ(provisionedobject.ResourceType(input.ResourceType)))
+	}
+	if input.FailedOnly {
+		query = query.Where(provisionedobject.LastErrorNEQ(""))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("counting provisioned objects: %w", err)
+	}
+	objects, err := query.
+		WithConnector(func(q *ent.ProvisioningConnectorQuery) { q.Select(provisioningconnector.FieldID) }).
+		Order(ent.Desc(provisionedobject.FieldLastError), ent.Desc(provisionedobject.FieldUpdatedAt)).
+		Offset((input.Page - 1) * input.PageSize).
+		Limit(input.PageSize).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing provisioned objects: %w", err)
+	}
+
+	items := make([]*domain.ProvisionedObject, len(objects))
+	for i, o := range objects {
+		items[i] = entProvisionedObjectToDomain(o)
+	}
+	return &domain.ListResult[domain.ProvisionedObject]{
+		Items:    items,
+		Total:    total,
+		Page:     input.Page,
+		PageSize: input.PageSize,
+	}, nil
+}
+
+func entConnectorToDomain(c *ent.ProvisioningConnector) *domain.ProvisioningConnector {
+	return &domain.ProvisioningConnector{
+		ID:               c.ID,
+		Name:             c.Name,
+		URL:              c.URL,
+		Token:            c.Token,
+		ScopeGroupID:     c.ScopeGroupID,
+		SyncGroups:       c.SyncGroups,
+		AttributeMapping: c.AttributeMapping,
+		Enabled:          c.Enabled,
+		Status: domain.ProvisioningStatus{
+			LastSyncAt:     c.LastSyncAt,
+			LastFullSyncAt: c.LastFullSyncAt,
+			LastError:      c.LastError,
+		},
+		EventCursor: c.EventCursor,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+func entProvisionedObjectToDomain(o *ent.ProvisionedObject) *domain.ProvisionedObject {
+	p := &domain.ProvisionedObject{
+		ID:           o.ID,
+		ResourceType: domain.ProvisionedResourceType(o.ResourceType),
+		LocalID:      o.LocalID,
+		RemoteID:     o.RemoteID,
+		Hash:         o.Hash,
+		LastError:    o.LastError,
+		SyncedAt:     o.SyncedAt,
+		UpdatedAt:    o.UpdatedAt,
+	}
+	if c := o.Edges.Connector; c != nil {
+		p.ConnectorID = c.ID
+	}
+	return p
+}
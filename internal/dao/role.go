@@ -0,0 +1,193 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/role"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// CreateRole creates a new role.
+func (d *DAO) CreateRole(ctx context.Context, input domain.CreateRoleInput, builtin bool) (*domain.Role, error) {
+	r, err := d.client.Role.Create().
+		SetName(input.Name).
+		SetDescription(input.Description).
+		SetPermissions(input.Permissions).
+		SetBuiltin(builtin).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating role: %w", err)
+	}
+	return entRoleToDomain(r), nil
+}
+
+// GetRoleByID retrieves a role by ID with assigned users and groups eagerly loaded.
+func (d *DAO) GetRoleByID(ctx context.Context, id uuid.UUID) (*domain.Role, error) {
+	r, err := d.client.Role.Query().
+		Where(role.ID(id)).
+		WithUsers().
+		WithGroups().
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying role by id: %w", err)
+	}
+	return entRoleToDomain(r), nil
+}
+
+// GetRoleByName retrieves a role by name.
+func (d *DAO) GetRoleByName(ctx context.Context, name string) (*domain.Role, error) {
+	r, err := d.client.Role.Query().
+		Where(role.Name(name)).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying role by name: %w", err)
+	}
+	return entRoleToDomain(r), nil
+}
+
+// ListRoles returns all roles ordered by name.
+func (d *DAO) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	roles, err := d.client.Role.Query().
+		Order(ent.Asc(role.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing roles: %w", err)
+	}
+
+	items := make([]*domain.Role, len(roles))
+	for i, r := range roles {
+		items[i] = entRoleToDomain(r)
+	}
+	return items, nil
+}
+
+// UpdateRole updates role fields.
+func (d *DAO) UpdateRole(ctx context.Context, id uuid.UUID, input domain.UpdateRoleInput) (*domain.Role, error) {
+	update := d.client.Role.UpdateOneID(id)
+	if input.Name != nil {
+		update = update.SetName(*input.Name)
+	}
+	if input.Description != nil {
+		update = update.SetDescription(*input.Description)
+	}
+	if input.Permissions != nil {
+		update = update.SetPermissions(input.Permissions)
+	}
+
+	r, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("updating role: %w", err)
+	}
+	return entRoleToDomain(r), nil
+}
+
+// DeleteRole deletes a role by ID.
+func (d *DAO) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.Role.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting role: %w", err)
+	}
+	return nil
+}
+
+// AddRoleUsers assigns a role to users.
+func (d *DAO) AddRoleUsers(ctx context.Context, roleID uuid.UUID, userIDs []uuid.UUID) error {
+	if _, err := d.client.Role.UpdateOneID(roleID).AddUserIDs(userIDs...).Save(ctx); err != nil {
+		return fmt.Errorf("assigning role to users: %w", err)
+	}
+	return nil
+}
+
+// RemoveRoleUser unassigns a role from a user.
+func (d *DAO) RemoveRoleUser(ctx context.Context, roleID, userID uuid.UUID) error {
+	if _, err := d.client.Role.UpdateOneID(roleID).RemoveUserIDs(userID).Save(ctx); err != nil {
+		return fmt.Errorf("unassigning role from user: %w", err)
+	}
+	return nil
+}
+
+// AddRoleGroups assigns a role to groups; every member of the groups inherits it.
+func (d *DAO) AddRoleGroups(ctx context.Context, roleID uuid.UUID, groupIDs []uuid.UUID) error {
+	if _, err := d.client.Role.UpdateOneID(roleID).AddGroupIDs(groupIDs...).Save(ctx); err != nil {
+		return fmt.Errorf("assigning role to groups: %w", err)
+	}
+	return nil
+}
+
+// RemoveRoleGroup unassigns a role from a group.
+func (d *DAO) RemoveRoleGroup(ctx context.Context, roleID, groupID uuid.UUID) error {
+	if _, err := d.client.Role.UpdateOneID(roleID).RemoveGroupIDs(groupID).Save(ctx); err != nil {
+		return fmt.Errorf("unassigning role from group: %w", err)
+	}
+	return nil
+}
+
+// GroupHasRoles reports whether any role is assigned to a group.
+func (d *DAO) GroupHasRoles(ctx context.Context, groupID uuid.UUID) (bool, error) {
+	ok, err := d.client.Role.Query().Where(role.HasGroupsWith(group.ID(groupID))).Exist(ctx)
+	if err != nil {
+		return false, fmt.Errorf("checking group roles: %w", err)
+	}
+	return ok, nil
+}
+
+// UserPermissions returns the sorted union of permissions granted to a user
+// by roles assigned directly or through the groups they belong to.
+func (d *DAO) UserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	roles, err := d.client.Role.Query().
+		Where(role.Or(
+			role.HasUsersWith(user.ID(userID)),
+			role.HasGroupsWith(group.HasUsersWith(user.ID(userID))),
+		)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying user roles: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	perms := []string{}
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			if !seen[p] {
+				seen[p] = true
+				perms = append(perms, p)
+			}
+		}
+	}
+	sort.Strings(perms)
+	return perms, nil
+}
+
+func entRoleToDomain(r *ent.Role) *domain.Role {
+	dr := &domain.Role{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: r.Permissions,
+		Builtin:     r.Builtin,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if dr.Permissions == nil {
+		dr.Permissions = []string{}
+	}
+	if r.Edges.Users != nil {
+		dr.Users = make([]*domain.User, len(r.Edges.Users))
+		for i, u := range r.Edges.Users {
+			dr.Users[i] = entUserToDomain(u)
+		}
+	}
+	if r.Edges.Groups != nil {
+		dr.Groups = make([]*domain.Group, len(r.Edges.Groups))
+		for i, g := range r.Edges.Groups {
+			dr.Groups[i] = entGroupToDomain(g)
+		}
+	}
+	return dr
+}
@@ -0,0 +1,206 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/apikey"
+	"github.com/qinzj/claude-demo/internal/ent/serviceaccount"
+)
+
+// CreateServiceAccount creates a new service account.
+func (d *DAO) CreateServiceAccount(ctx context.Context, input domain.CreateServiceAccountInput) (*domain.ServiceAccount, error) {
+	sa, err := d.client.ServiceAccount.Create().
+		SetName(input.Name).
+		SetDescription(input.Description).
+		SetPermissions(input.Permissions).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating service account: %w", err)
+	}
+	return entServiceAccountToDomain(sa), nil
+}
+
+// GetServiceAccount retrieves a service account by ID with its API keys.
+func (d *DAO) GetServiceAccount(ctx context.Context, id uuid.UUID) (*domain.ServiceAccount, error) {
+	sa, err := d.client.ServiceAccount.Query().
+		Where(serviceaccount.ID(id)).
+		WithAPIKeys(func(q *ent.APIKeyQuery) {
+			q.Order(ent.Asc(apikey.FieldCreatedAt))
+		}).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying service account: %w", err)
+	}
+	return entServiceAccountToDomain(sa), nil
+}
+
+// ListServiceAccounts returns all service accounts ordered by name.
+func (d *DAO) ListServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error) {
+	accounts, err := d.client.ServiceAccount.Query().
+		Order(ent.Asc(serviceaccount.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing service accounts: %w", err)
+	}
+
+	items := make([]*domain.ServiceAccount, len(accounts))
+	for i, sa := range accounts {
+		items[i] = entServiceAccountToDomain(sa)
+	}
+	return items, nil
+}
+
+// UpdateServiceAccount updates service account fields.
+func (d *DAO) UpdateServiceAccount(ctx context.Context, id uuid.UUID, input domain.UpdateServiceAccountInput) (*domain.ServiceAccount, error) {
+	update := d.client.ServiceAccount.UpdateOneID(id)
+	if input.Description != nil {
+		update = update.SetDescription(*input.Description)
+	}
+	if input.Permissions != nil {
+		update = update.SetPermissions(input.Permissions)
+	}
+	if input.Disabled != nil {
+		update = update.SetDisabled(*input.Disabled)
+	}
+	sa, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("updating service account: %w", err)
+	}
+	return entServiceAccountToDomain(sa), nil
+}
+
+// DeleteServiceAccount deletes a service account and its API keys.
+func (d *DAO) DeleteServiceAccount(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.ServiceAccount.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting service account: %w", err)
+	}
+	return nil
+}
+
+// CreateAPIKey stores a new API key for a service account under its hash.
+func (d *DAO) CreateAPIKey(ctx context.Context, serviceAccountID uuid.UUID, input domain.CreateAPIKeyInput, prefix, keyHash string) (*domain.APIKey, error) {
+	k, err := d.client.APIKey.Create().
+		SetServiceAccountID(serviceAccountID).
+		SetName(input.Name).
+		SetPrefix(prefix).
+		SetKeyHash(keyHash).
+		SetNillableExpiresAt(input.ExpiresAt).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating api key: %w", err)
+	}
+	return entAPIKeyToDomain(k), nil
+}
+
+// GetAPIKey retrieves an API key by ID.
+func (d *DAO) GetAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	k, err := d.client.APIKey.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("querying api key: %w", err)
+	}
+	return entAPIKeyToDomain(k), nil
+}
+
+// GetAPIKeyByHash retrieves an API key by the hash of its value, together
+// with the service account it belongs to.
+func (d *DAO) GetAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, *domain.ServiceAccount, error) {
+	k, err := d.client.APIKey.Query().
+		Where(apikey.KeyHash(keyHash)).
+		WithServiceAccount().
+		Only(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("querying api key: %w", err)
+	}
+	return entAPIKeyToDomain(k), entServiceAccountToDomain(k.Edges.ServiceAccount), nil
+}
+
+// RotateAPIKey replaces one of a service account's API keys with a key of
+// the same name and expiry but a new ID and value. Access tokens name the
+// key they were issued with by ID, so those of the old key stop working.
+func (d *DAO) RotateAPIKey(ctx context.Context, serviceAccountID, keyID uuid.UUID, prefix, keyHash string) (*domain.APIKey, error) {
+	var k *ent.APIKey
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		old, err := tx.APIKey.Query().
+			Where(apikey.ID(keyID), apikey.HasServiceAccountWith(serviceaccount.ID(serviceAccountID))).
+			Only(ctx)
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("api key %s not found", keyID)
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.APIKey.DeleteOne(old).Exec(ctx); err != nil {
+			return err
+		}
+		k, err = tx.APIKey.Create().
+			SetServiceAccountID(serviceAccountID).
+			SetName(old.Name).
+			SetPrefix(prefix).
+			SetKeyHash(keyHash).
+			SetNillableExpiresAt(old.ExpiresAt).
+			Save(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rotating api key: %w", err)
+	}
+	return entAPIKeyToDomain(k), nil
+}
+
+// TouchAPIKey records that an API key was used.
+func (d *DAO) TouchAPIKey(ctx context.Context, keyID uuid.UUID, at time.Time) error {
+	if err := d.client.APIKey.UpdateOneID(keyID).SetLastUsedAt(at).Exec(ctx); err != nil {
+		return fmt.Errorf("updating api key last use: %w", err)
+	}
+	return nil
+}
+
+// DeleteAPIKey deletes one of a service account's API keys.
+func (d *DAO) DeleteAPIKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error {
+	n, err := d.client.APIKey.Delete().
+		Where(apikey.ID(keyID), apikey.HasServiceAccountWith(serviceaccount.ID(serviceAccountID))).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting api key: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("api key %s not found", keyID)
+	}
+	return nil
+}
+
+func entServiceAccountToDomain(sa *ent.ServiceAccount) *domain.ServiceAccount {
+	d := &domain.ServiceAccount{
+		ID:          sa.ID,
+		Name:        sa.Name,
+		Description: sa.Description,
+		Permissions: sa.Permissions,
+		Disabled:    sa.Disabled,
+		CreatedAt:   sa.CreatedAt,
+		UpdatedAt:   sa.UpdatedAt,
+	}
+	if d.Permissions == nil {
+		d.Permissions = []string{}
+	}
+	for _, k := range sa.Edges.APIKeys {
+		d.APIKeys = append(d.APIKeys, entAPIKeyToDomain(k))
+	}
+	return d
+}
+
+func entAPIKeyToDomain(k *ent.APIKey) *domain.APIKey {
+	return &domain.APIKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
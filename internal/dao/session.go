@@ -0,0 +1,143 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/session"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// CreateSession records a new login session identified by its refresh token hash.
+func (d *DAO) CreateSession(ctx context.Context, userID uuid.UUID, tokenHash string, info domain.RequestInfo, expiresAt time.Time) (*domain.Session, error) {
+	s, err := d.client.Session.Create().
+		SetUserID(userID).
+		SetRefreshTokenHash(tokenHash).
+		SetUserAgent(info.UserAgent).
+		SetIPAddress(info.IPAddress).
+		SetExpiresAt(expiresAt).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating session: %w", err)
+	}
+	return entSessionToDomain(s, userID), nil
+}
+
+// GetSession retrieves a session by ID.
+func (d *DAO) GetSession(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	s, err := d.client.Session.Query().
+		Where(session.ID(id)).
+		WithUser(func(q *ent.UserQuery) { q.Select(user.FieldID) }).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying session: %w", err)
+	}
+	return entSessionToDomain(s, s.Edges.User.ID), nil
+}
+
+// GetSessionByTokenHash finds the session whose current refresh token has the
+// given hash. If the hash matches a rotated-out token instead, the session is
+// returned with reused set to true.
+func (d *DAO) GetSessionByTokenHash(ctx context.Context, tokenHash string) (s *domain.Session, reused bool, err error) {
+	es, err := d.client.Session.Query().
+		Where(session.Or(
+			session.RefreshTokenHash(tokenHash),
+			session.PreviousTokenHash(tokenHash),
+		)).
+		WithUser(func(q *ent.UserQuery) { q.Select(user.FieldID) }).
+		First(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("querying session: %w", err)
+	}
+	return entSessionToDomain(es, es.Edges.User.ID), es.RefreshTokenHash != tokenHash, nil
+}
+
+// RotateSessionToken replaces a session's refresh token hash if it still
+// equals oldHash. It reports false when another request rotated it first.
+func (d *DAO) RotateSessionToken(ctx context.Context, id uuid.UUID, oldHash, newHash string, info domain.RequestInfo) (bool, error) {
+	n, err := d.client.Session.Update().
+		Where(session.ID(id), session.RefreshTokenHash(oldHash)).
+		SetRefreshTokenHash(newHash).
+		SetPreviousTokenHash(oldHash).
+		SetUserAgent(info.UserAgent).
+		SetIPAddress(info.IPAddress).
+		SetLastUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("rotating session token: %w", err)
+	}
+	return n == 1, nil
+}
+
+// ListActiveSessions returns a user's sessions that are neither revoked nor
+// expired, most recently used first.
+func (d *DAO) ListActiveSessions(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	sessions, err := d.client.Session.Query().
+		Where(
+			session.HasUserWith(user.ID(userID)),
+			session.RevokedAtIsNil(),
+			session.ExpiresAtGT(time.Now()),
+		).
+		Order(ent.Desc(session.FieldLastUsedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+
+	items := make([]*domain.Session, len(sessions))
+	for i, s := range sessions {
+		items[i] = entSessionToDomain(s, userID)
+	}
+	return items, nil
+}
+
+// RevokeSession revokes one of a user's sessions. Sessions belonging to
+// other users or already revoked are treated as missing.
+func (d *DAO) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	n, err := d.client.Session.Update().
+		Where(
+			session.ID(sessionID),
+			session.HasUserWith(user.ID(userID)),
+			session.RevokedAtIsNil(),
+		).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("revoking session: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("session %s not found", sessionID)
+	}
+	return nil
+}
+
+// RevokeUserSessions revokes every active session of a user and returns how
+// many were revoked.
+func (d *DAO) RevokeUserSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	n, err := d.client.Session.Update().
+		Where(session.HasUserWith(user.ID(userID)), session.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("revoking user sessions: %w", err)
+	}
+	return n, nil
+}
+
+func entSessionToDomain(s *ent.Session, userID uuid.UUID) *domain.Session {
+	return &domain.Session{
+		ID:         s.ID,
+		UserID:     userID,
+		UserAgent:  s.UserAgent,
+		IPAddress:  s.IPAddress,
+		CreatedAt:  s.CreatedAt,
+		LastUsedAt: s.LastUsedAt,
+		ExpiresAt:  s.ExpiresAt,
+		RevokedAt:  s.RevokedAt,
+	}
+}
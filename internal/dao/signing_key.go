@@ -0,0 +1,82 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/signingkey"
+)
+
+// CreateSigningKey stores a new signing key and retires every other active
+// key, making the new key the one used for signing.
+func (d *DAO) CreateSigningKey(ctx context.Context, algorithm, privateKey, publicKey string) (*domain.SigningKey, error) {
+	var created *ent.SigningKey
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		k, err := tx.SigningKey.Create().
+			SetAlgorithm(signingkey.Algorithm(algorithm)).
+			SetPrivateKey(privateKey).
+			SetPublicKey(publicKey).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("creating signing key: %w", err)
+		}
+		if _, err := tx.SigningKey.Update().
+			Where(signingkey.IDNEQ(k.ID), signingkey.RetiredAtIsNil()).
+			SetRetiredAt(k.CreatedAt).
+			Save(ctx); err != nil {
+			return fmt.Errorf("retiring signing keys: %w", err)
+		}
+		created = k
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entSigningKeyToDomain(created), nil
+}
+
+// ListSigningKeys returns the active signing key and every key retired after
+// the given time, newest first.
+func (d *DAO) ListSigningKeys(ctx context.Context, retiredAfter time.Time) ([]*domain.SigningKey, error) {
+	keys, err := d.client.SigningKey.Query().
+		Where(signingkey.Or(
+			signingkey.RetiredAtIsNil(),
+			signingkey.RetiredAtGT(retiredAfter),
+		)).
+		Order(ent.Desc(signingkey.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing signing keys: %w", err)
+	}
+
+	items := make([]*domain.SigningKey, len(keys))
+	for i, k := range keys {
+		items[i] = entSigningKeyToDomain(k)
+	}
+	return items, nil
+}
+
+// DeleteSigningKeysRetiredBefore removes keys retired before the given time.
+func (d *DAO) DeleteSigningKeysRetiredBefore(ctx context.Context, before time.Time) (int, error) {
+	n, err := d.client.SigningKey.Delete().
+		Where(signingkey.RetiredAtLT(before)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("deleting signing keys: %w", err)
+	}
+	return n, nil
+}
+
+func entSigningKeyToDomain(k *ent.SigningKey) *domain.SigningKey {
+	return &domain.SigningKey{
+		ID:         k.ID,
+		Algorithm:  string(k.Algorithm),
+		PrivateKey: k.PrivateKey,
+		PublicKey:  k.PublicKey,
+		CreatedAt:  k.CreatedAt,
+		RetiredAt:  k.RetiredAt,
+	}
+}
@@ -0,0 +1,69 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/sshkey"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// CreateSSHKey registers an SSH public key for a user.
+func (d *DAO) CreateSSHKey(ctx context.Context, userID uuid.UUID, name, publicKey, fingerprint string) (*domain.SSHKey, error) {
+	k, err := d.client.SSHKey.Create().
+		SetName(name).
+		SetPublicKey(publicKey).
+		SetFingerprint(fingerprint).
+		SetUserID(userID).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating ssh key: %w", err)
+	}
+	return entSSHKeyToDomain(k), nil
+}
+
+// ListSSHKeys returns a user's SSH public keys, oldest first.
+func (d *DAO) ListSSHKeys(ctx context.Context, userID uuid.UUID) ([]*domain.SSHKey, error) {
+	keys, err := d.client.SSHKey.Query().
+		Where(sshkey.HasUserWith(user.ID(userID))).
+		Order(ent.Asc(sshkey.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing ssh keys: %w", err)
+	}
+
+	items := make([]*domain.SSHKey, len(keys))
+	for i, k := range keys {
+		items[i] = entSSHKeyToDomain(k)
+	}
+	return items, nil
+}
+
+// DeleteSSHKey deletes one of a user's SSH public keys. Keys belonging to
+// other users are treated as missing.
+func (d *DAO) DeleteSSHKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	n, err := d.client.SSHKey.Delete().
+		Where(sshkey.ID(keyID), sshkey.HasUserWith(user.ID(userID))).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting ssh key: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("ssh key %s not found", keyID)
+	}
+	return nil
+}
+
+func entSSHKeyToDomain(k *ent.SSHKey) *domain.SSHKey {
+	return &domain.SSHKey{
+		ID:          k.ID,
+		Name:        k.Name,
+		PublicKey:   k.PublicKey,
+		Fingerprint: k.Fingerprint,
+		CreatedAt:   k.CreatedAt,
+	}
+}
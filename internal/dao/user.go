@@ -8,18 +8,46 @@ import (
 
 	"github.com/qinzj/claude-demo/internal/domain"
 	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/attributevalue"
 	"github.com/qinzj/claude-demo/internal/ent/user"
 )
 
 // CreateUser creates a new user in the database.
 func (d *DAO) CreateUser(ctx context.Context, username, displayName, email, passwordHash, phone string) (*domain.User, error) {
-	u, err := d.client.User.Create().
-		SetUsername(username).
-		SetDisplayName(displayName).
-		SetEmail(email).
-		SetPasswordHash(passwordHash).
-		SetPhone(phone).
-		Save(ctx)
+	var u *ent.User
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		var err error
+		u, err = tx.User.Create().
+			SetUsername(username).
+			SetDisplayName(displayName).
+			SetEmail(email).
+			SetPasswordHash(passwordHash).
+			SetPhone(phone).
+			Save(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating user: %w", err)
+	}
+	return entUserToDomain(u), nil
+}
+
+// CreatePendingUser creates an invited user who has no password yet and
+// cannot log in until the invitation is accepted.
+func (d *DAO) CreatePendingUser(ctx context.Context, username, displayName, email, phone string) (*domain.User, error) {
+	var u *ent.User
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		var err error
+		u, err = tx.User.Create().
+			SetUsername(username).
+			SetDisplayName(displayName).
+			SetEmail(email).
+			SetPasswordHash("").
+			SetPhone(phone).
+			SetStatus(user.StatusPending).
+			Save(ctx)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("creating user: %w", err)
 	}
@@ -31,6 +59,7 @@ func (d *DAO) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, erro
 	u, err := d.client.User.Query().
 		Where(user.ID(id)).
 		WithGroups().
+		WithAttributeValues(orderAttributeValues).
 		Only(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("querying user by id: %w", err)
@@ -42,6 +71,7 @@ func (d *DAO) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, erro
 func (d *DAO) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
 	u, err := d.client.User.Query().
 		Where(user.UsernameEQ(username)).
+		WithAttributeValues(orderAttributeValues).
 		Only(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("querying user by username: %w", err)
@@ -49,19 +79,22 @@ func (d *DAO) GetUserByUsername(ctx context.Context, username string) (*domain.U
 	return entUserToDomain(u), nil
 }
 
-// ListUsers returns a paginated list of users, optionally filtered by search.
-func (d *DAO) ListUsers(ctx context.Context, page, pageSize int, search string) (*domain.ListResult[domain.User], error) {
-	query := d.client.User.Query()
-
-	if search != "" {
-		query = query.Where(
-			user.Or(
-				user.UsernameContains(search),
-				user.DisplayNameContains(search),
-				user.EmailContains(search),
-			),
-		)
+// GetUserByEmail retrieves a user by email address, ignoring case.
+func (d *DAO) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	u, err := d.client.User.Query().
+		Where(user.EmailEqualFold(email)).
+		WithAttributeValues(orderAttributeValues).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying user by email: %w", err)
 	}
+	return entUserToDomain(u), nil
+}
+
+// ListUsers returns a paginated list of users, optionally filtered by search
+// and by exact extension attribute values.
+func (d *DAO) ListUsers(ctx context.Context, page, pageSize int, search string, attrs map[string]string) (*domain.ListResult[domain.User], error) {
+	query := d.searchUsersQuery(search, attrs)
 
 	total, err := query.Clone().Count(ctx)
 	if err != nil {
@@ -73,6 +106,7 @@ func (d *DAO) ListUsers(ctx context.Context, page, pageSize int, search string)
 		Offset(offset).
 		Limit(pageSize).
 		Order(ent.Asc(user.FieldCreatedAt)).
+		WithAttributeValues(orderAttributeValues).
 		All(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("listing users: %w", err)
@@ -91,51 +125,154 @@ func (d *DAO) ListUsers(ctx context.Context, page, pageSize int, search string)
 	}, nil
 }
 
-// UpdateUser updates user fields.
-func (d *DAO) UpdateUser(ctx context.Context, id uuid.UUID, input domain.UpdateUserInput) (*domain.User, error) {
-	update := d.client.User.UpdateOneID(id)
-	if input.DisplayName != nil {
-		update = update.SetDisplayName(*input.DisplayName)
+// SearchUsers returns all users matching the filters of ListUsers, with
+// their groups, oldest first.
+func (d *DAO) SearchUsers(ctx context.Context, search string, attrs map[string]string) ([]*domain.User, error) {
+	users, err := d.searchUsersQuery(search, attrs).
+		Order(ent.Asc(user.FieldCreatedAt)).
+		WithGroups().
+		WithAttributeValues(orderAttributeValues).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("searching users: %w", err)
 	}
-	if input.Email != nil {
-		update = update.SetEmail(*input.Email)
+	items := make([]*domain.User, len(users))
+	for i, u := range users {
+		items[i] = entUserToDomainWithGroups(u)
+	}
+	return items, nil
+}
+
+// searchUsersQuery queries the users whose username, display name or email
+// contains search and that have the given extension attribute values.
+func (d *DAO) searchUsersQuery(search string, attrs map[string]string) *ent.UserQuery {
+	query := d.client.User.Query()
+	if search != "" {
+		query = query.Where(
+			user.Or(
+				user.UsernameContains(search),
+				user.DisplayNameContains(search),
+				user.EmailContains(search),
+			),
+		)
 	}
-	if input.Phone != nil {
-		update = update.SetPhone(*input.Phone)
+	for name, value := range attrs {
+		query = query.Where(user.HasAttributeValuesWith(
+			attributevalue.NameEQ(name),
+			attributevalue.ValueEQ(value),
+		))
 	}
+	return query
+}
 
-	u, err := update.Save(ctx)
+// UpdateUser updates user fields and replaces the extension attributes in
+// input.Attributes as SetUserAttributes does, in one transaction that
+// records one version.
+func (d *DAO) UpdateUser(ctx context.Context, id uuid.UUID, input domain.UpdateUserInput) (*domain.User, error) {
+	var u *ent.User
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		// The attributes change first, so that the version the history hook
+		// records for the update includes them.
+		if len(input.Attributes) > 0 {
+			if err := replaceUserAttributes(ctx, tx, id, input.Attributes); err != nil {
+				return err
+			}
+		}
+		update := tx.User.UpdateOneID(id)
+		if input.DisplayName != nil {
+			update = update.SetDisplayName(*input.DisplayName)
+		}
+		if input.Email != nil {
+			update = update.SetEmail(*input.Email)
+		}
+		if input.Phone != nil {
+			update = update.SetPhone(*input.Phone)
+		}
+		if input.ClearManager {
+			update = update.ClearManagerID()
+		} else if input.ManagerID != nil {
+			update = update.SetManagerID(*input.ManagerID)
+		}
+		if input.ClearAccountExpiry {
+			update = update.ClearAccountExpiresAt()
+		} else if input.AccountExpiresAt != nil {
+			update = update.SetAccountExpiresAt(*input.AccountExpiresAt)
+		}
+
+		var err error
+		if u, err = update.Save(ctx); err != nil {
+			return err
+		}
+		// Without a versioned field changing, the hook recorded nothing.
+		if len(input.Attributes) > 0 {
+			return recordUserChanges(ctx, tx.Client(), []uuid.UUID{id})
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("updating user: %w", err)
 	}
 	return entUserToDomain(u), nil
 }
 
-// UpdateUserPassword updates a user's password hash.
-func (d *DAO) UpdateUserPassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
-	_, err := d.client.User.UpdateOneID(id).
-		SetPasswordHash(passwordHash).
-		Save(ctx)
+// UpdateUserStatus updates a user's status.
+func (d *DAO) UpdateUserStatus(ctx context.Context, id uuid.UUID, status user.Status) error {
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		return tx.User.UpdateOneID(id).SetStatus(status).Exec(ctx)
+	})
 	if err != nil {
-		return fmt.Errorf("updating user password: %w", err)
+		return fmt.Errorf("updating user status: %w", err)
 	}
 	return nil
 }
 
-// UpdateUserStatus updates a user's status.
-func (d *DAO) UpdateUserStatus(ctx context.Context, id uuid.UUID, status user.Status) error {
-	_, err := d.client.User.UpdateOneID(id).
-		SetStatus(status).
-		Save(ctx)
+// RestoreUserVersion sets a user's versioned fields to those of an earlier
+// version, which records a new version. The extension attributes in
+// v.Attributes replace the user's as in SetUserAttributes, in the same
+// version.
+func (d *DAO) RestoreUserVersion(ctx context.Context, id uuid.UUID, v *domain.UserVersion) (*domain.User, error) {
+	var u *ent.User
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		// The attributes change first, so that the version the history hook
+		// records for the update includes them.
+		if len(v.Attributes) > 0 {
+			if err := replaceUserAttributes(ctx, tx, id, v.Attributes); err != nil {
+				return err
+			}
+		}
+		update := tx.User.UpdateOneID(id).
+			SetUsername(v.Username).
+			SetDisplayName(v.DisplayName).
+			SetEmail(v.Email).
+			SetPhone(v.Phone).
+			SetStatus(user.Status(v.Status))
+		if v.ManagerID != nil {
+			update = update.SetManagerID(*v.ManagerID)
+		} else {
+			update = update.ClearManagerID()
+		}
+		if v.AccountExpiresAt != nil {
+			update = update.SetAccountExpiresAt(*v.AccountExpiresAt)
+		} else {
+			update = update.ClearAccountExpiresAt()
+		}
+
+		var err error
+		u, err = update.Save(ctx)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("updating user status: %w", err)
+		return nil, fmt.Errorf("restoring user version: %w", err)
 	}
-	return nil
+	return entUserToDomain(u), nil
 }
 
 // DeleteUser deletes a user by ID.
 func (d *DAO) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	if err := d.client.User.DeleteOneID(id).Exec(ctx); err != nil {
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		return tx.User.DeleteOneID(id).Exec(ctx)
+	})
+	if err != nil {
 		return fmt.Errorf("deleting user: %w", err)
 	}
 	return nil
@@ -158,9 +295,52 @@ func (d *DAO) GetUserGroups(ctx context.Context, userID uuid.UUID) ([]*domain.Gr
 	return groups, nil
 }
 
+// FindUsers returns the users matching f with their groups, ordered by
+// username, skipping offset and returning at most limit of them (all if
+// limit is negative), together with the number of matching users.
+func (d *DAO) FindUsers(ctx context.Context, f domain.UserFilter, offset, limit int) ([]*domain.User, int, error) {
+	query := d.client.User.Query()
+	if len(f.IDs) > 0 {
+		query = query.Where(user.IDIn(f.IDs...))
+	}
+	if f.Username != "" {
+		query = query.Where(user.UsernameEqualFold(f.Username))
+	}
+	if f.DisplayName != "" {
+		query = query.Where(user.DisplayNameEqualFold(f.DisplayName))
+	}
+	if f.Email != "" {
+		query = query.Where(user.EmailEqualFold(f.Email))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting users: %w", err)
+	}
+	query = query.Offset(offset)
+	if limit >= 0 {
+		query = query.Limit(limit)
+	}
+	users, err := query.
+		Order(ent.Asc(user.FieldUsername)).
+		WithGroups().
+		WithAttributeValues(orderAttributeValues).
+		All(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("finding users: %w", err)
+	}
+	items := make([]*domain.User, len(users))
+	for i, u := range users {
+		items[i] = entUserToDomainWithGroups(u)
+	}
+	return items, total, nil
+}
+
 // AllUsers returns all users (for LDAP search).
 func (d *DAO) AllUsers(ctx context.Context) ([]*domain.User, error) {
-	users, err := d.client.User.Query().All(ctx)
+	users, err := d.client.User.Query().
+		WithAttributeValues(orderAttributeValues).
+		All(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("querying all users: %w", err)
 	}
@@ -173,15 +353,28 @@ func (d *DAO) AllUsers(ctx context.Context) ([]*domain.User, error) {
 
 func entUserToDomain(u *ent.User) *domain.User {
 	return &domain.User{
-		ID:           u.ID,
-		Username:     u.Username,
-		DisplayName:  u.DisplayName,
-		Email:        u.Email,
-		PasswordHash: u.PasswordHash,
-		Phone:        u.Phone,
-		Status:       domain.UserStatus(u.Status),
-		CreatedAt:    u.CreatedAt,
-		UpdatedAt:    u.UpdatedAt,
+		ID:                 u.ID,
+		Username:           u.Username,
+		DisplayName:        u.DisplayName,
+		Email:              u.Email,
+		PasswordHash:       u.PasswordHash,
+		PasswordChangedAt:  u.PasswordChangedAt,
+		MustChangePassword: u.MustChangePassword,
+		AccountExpiresAt:   u.AccountExpiresAt,
+		Phone:              u.Phone,
+		Status:             domain.UserStatus(u.Status),
+		ManagerID:          u.ManagerID,
+		MFAEnabled:         u.MfaEnabled,
+		TOTPSecret:         u.TotpSecret,
+		TOTPLastStep:       u.TotpLastStep,
+		FailedLoginCount:   u.FailedLoginCount,
+		LastFailedLoginAt:  u.LastFailedLoginAt,
+		LockoutCount:       u.LockoutCount,
+		LockedAt:           u.LockedAt,
+		LockedUntil:        u.LockedUntil,
+		CreatedAt:          u.CreatedAt,
+		UpdatedAt:          u.UpdatedAt,
+		Attributes:         entAttributeValuesToMap(u.Edges.AttributeValues),
 	}
 }
 
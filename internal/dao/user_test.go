@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/uuid"
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/qinzj/claude-demo/internal/domain"
@@ -74,7 +75,7 @@ func TestListUsers(t *testing.T) {
 		d.CreateUser(ctx, name, "User "+name, name+"@example.com", "hashedpw", "")
 	}
 
-	result, err := d.ListUsers(ctx, 1, 3, "")
+	result, err := d.ListUsers(ctx, 1, 3, "", nil)
 	if err != nil {
 		t.Fatalf("ListUsers: %v", err)
 	}
@@ -92,7 +93,7 @@ func TestListUsersWithSearch(t *testing.T) {
 	d.CreateUser(ctx, "alice", "Alice Smith", "alice@example.com", "hashedpw", "")
 	d.CreateUser(ctx, "bob", "Bob Jones", "bob@example.com", "hashedpw", "")
 
-	result, err := d.ListUsers(ctx, 1, 10, "alice")
+	result, err := d.ListUsers(ctx, 1, 10, "alice", nil)
 	if err != nil {
 		t.Fatalf("ListUsers: %v", err)
 	}
@@ -101,6 +102,35 @@ func TestListUsersWithSearch(t *testing.T) {
 	}
 }
 
+func TestFindUsers(t *testing.T) {
+	d, ctx := setupTestDAO(t)
+
+	for _, name := range []string{"carol", "alice", "bob"} {
+		d.CreateUser(ctx, name, "User "+name, name+"@example.com", "hashedpw", "")
+	}
+
+	users, total, err := d.FindUsers(ctx, domain.UserFilter{}, 1, 1)
+	if err != nil {
+		t.Fatalf("FindUsers: %v", err)
+	}
+	if total != 3 || len(users) != 1 || users[0].Username != "bob" {
+		t.Errorf("page = %v, total %d, want bob of 3", users, total)
+	}
+
+	users, total, err = d.FindUsers(ctx, domain.UserFilter{Email: "ALICE@example.com"}, 0, -1)
+	if err != nil {
+		t.Fatalf("FindUsers: %v", err)
+	}
+	if total != 1 || len(users) != 1 || users[0].Username != "alice" {
+		t.Errorf("by email = %v, total %d, want alice", users, total)
+	}
+
+	users, _, err = d.FindUsers(ctx, domain.UserFilter{IDs: []uuid.UUID{users[0].ID}, Username: "bob"}, 0, -1)
+	if err != nil || len(users) != 0 {
+		t.Errorf("contradictory filter = %v, %v, want none", users, err)
+	}
+}
+
 func TestUpdateUser(t *testing.T) {
 	d, ctx := setupTestDAO(t)
 
@@ -140,3 +170,26 @@ func TestUpdateUserStatus(t *testing.T) {
 		t.Errorf("Status = %q, want %q", got.Status, domain.UserStatusDisabled)
 	}
 }
+
+func TestUpdateUserManager(t *testing.T) {
+	d, ctx := setupTestDAO(t)
+
+	boss, _ := d.CreateUser(ctx, "boss", "Boss", "boss@example.com", "hashedpw", "")
+	john, _ := d.CreateUser(ctx, "john", "John Doe", "john@example.com", "hashedpw", "")
+
+	updated, err := d.UpdateUser(ctx, john.ID, domain.UpdateUserInput{ManagerID: &boss.ID})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.ManagerID == nil || *updated.ManagerID != boss.ID {
+		t.Errorf("ManagerID = %v, want %v", updated.ManagerID, boss.ID)
+	}
+
+	updated, err = d.UpdateUser(ctx, john.ID, domain.UpdateUserInput{ClearManager: true})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.ManagerID != nil {
+		t.Errorf("ManagerID = %v, want nil", updated.ManagerID)
+	}
+}
@@ -0,0 +1,429 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/groupversion"
+	"github.com/qinzj/claude-demo/internal/ent/outboxevent"
+	"github.com/qinzj/claude-demo/internal/ent/userversion"
+	"github.com/qinzj/claude-demo/internal/ent/webhookdelivery"
+	"github.com/qinzj/claude-demo/internal/ent/webhooksubscription"
+)
+
+// enqueueEvent writes an outbox event. It is called by the history hooks,
+// in the transaction of the change the event describes.
+func enqueueEvent(ctx context.Context, c *ent.Client, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("encoding event data: %w", err)
+	}
+	if err := c.OutboxEvent.Create().
+		SetEventType(eventType).
+		SetPayload(string(payload)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("creating outbox event: %w", err)
+	}
+	return nil
+}
+
+// enqueueUserEvents writes the events for a new version of a user.
+func enqueueUserEvents(ctx context.Context, c *ent.Client, previous, current *domain.UserVersion) error {
+	switch current.Operation {
+	case domain.VersionCreate:
+		return enqueueEvent(ctx, c, domain.EventUserCreated, domain.UserEventData{User: current})
+	case domain.VersionDelete:
+		return enqueueEvent(ctx, c, domain.EventUserDeleted, domain.UserEventData{User: current})
+	}
+	data := domain.UserEventData{User: current, Previous: previous}
+	if err := enqueueEvent(ctx, c, domain.EventUserUpdated, data); err != nil {
+		return err
+	}
+	if previous == nil || previous.Status == current.Status {
+		return nil
+	}
+	switch current.Status {
+	case domain.UserStatusEnabled:
+		return enqueueEvent(ctx, c, domain.EventUserEnabled, data)
+	case domain.UserStatusDisabled:
+		return enqueueEvent(ctx, c, domain.EventUserDisabled, data)
+	}
+	return nil
+}
+
+// enqueueGroupEvent writes the event for a new version of a group.
+func enqueueGroupEvent(ctx context.Context, c *ent.Client, previous, current *domain.GroupVersion) error {
+	switch current.Operation {
+	case domain.VersionCreate:
+		return enqueueEvent(ctx, c, domain.EventGroupCreated, domain.GroupEventData{Group: current})
+	case domain.VersionDelete:
+		return enqueueEvent(ctx, c, domain.EventGroupDeleted, domain.GroupEventData{Group: current})
+	}
+	return enqueueEvent(ctx, c, domain.EventGroupUpdated, domain.GroupEventData{Group: current, Previous: previous})
+}
+
+// enqueueMembershipEvent writes the event for a user joining or leaving a
+// group. Names are taken from the latest versions, which the hooks record
+// before the membership changes, so they are known even when the user or
+// group is being deleted.
+func enqueueMembershipEvent(ctx context.Context, c *ent.Client, groupID, userID uuid.UUID, op domain.MembershipOperation) error {
+	data := domain.MembershipEventData{GroupID: groupID, UserID: userID}
+	data.ActorID, data.ActorName = historyActor(ctx)
+
+	uv, err := c.UserVersion.Query().
+		Where(userversion.UserID(userID)).
+		Order(ent.Desc(userversion.FieldVersion)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return fmt.Errorf("querying latest user version: %w", err)
+	}
+	if uv != nil {
+		data.Username = uv.Username
+	}
+	gv, err := c.GroupVersion.Query().
+		Where(groupversion.GroupID(groupID)).
+		Order(ent.Desc(groupversion.FieldVersion)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return fmt.Errorf("querying latest group version: %w", err)
+	}
+	if gv != nil {
+		data.GroupName = gv.Name
+	}
+
+	eventType := domain.EventGroupMemberAdded
+	if op == domain.MembershipRemove {
+		eventType = domain.EventGroupMemberRemoved
+	}
+	return enqueueEvent(ctx, c, eventType, data)
+}
+
+// CreateWebhook creates a webhook subscription.
+func (d *DAO) CreateWebhook(ctx context.Context, input domain.CreateWebhookInput) (*domain.WebhookSubscription, error) {
+	s, err := d.client.WebhookSubscription.Create().
+		SetName(input.Name).
+		SetURL(input.URL).
+		SetSecret(input.Secret).
+		SetEventTypes(input.EventTypes).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating webhook: %w", err)
+	}
+	return entWebhookToDomain(s), nil
+}
+
+// GetWebhook retrieves a webhook subscription by ID, without its secret.
+func (d *DAO) GetWebhook(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	s, err := d.client.WebhookSubscription.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("querying webhook: %w", err)
+	}
+	return entWebhookToDomain(s), nil
+}
+
+// ListWebhooks returns all webhook subscriptions ordered by name.
+func (d *DAO) ListWebhooks(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	subs, err := d.client.WebhookSubscription.Query().
+		Order(ent.Asc(webhooksubscription.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing webhooks: %w", err)
+	}
+
+	items := make([]*domain.WebhookSubscription, len(subs))
+	for i, s := range subs {
+		items[i] = entWebhookToDomain(s)
+	}
+	return items, nil
+}
+
+// UpdateWebhook updates webhook subscription fields.
+func (d *DAO) UpdateWebhook(ctx context.Context, id uuid.UUID, input domain.UpdateWebhookInput) (*domain.WebhookSubscription, error) {
+	update := d.client.WebhookSubscription.UpdateOneID(id)
+	if input.Name != nil {
+		update = update.SetName(*input.Name)
+	}
+	if input.URL != nil {
+		update = update.SetURL(*input.URL)
+	}
+	if input.Secret != nil {
+		update = update.SetSecret(*input.Secret)
+	}
+	if input.EventTypes != nil {
+		update = update.SetEventTypes(input.EventTypes)
+	}
+	if input.Enabled != nil {
+		update = update.SetEnabled(*input.Enabled)
+	}
+	s, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("updating webhook: %w", err)
+	}
+	return entWebhookToDomain(s), nil
+}
+
+// DeleteWebhook deletes a webhook subscription and its deliveries.
+func (d *DAO) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.WebhookSubscription.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting webhook: %w", err)
+	}
+	return nil
+}
+
+// FanOutEvents creates a delivery of each of up to limit outbox events not
+// yet fanned out, oldest first, for every enabled subscription matching
+// it, and marks the events fanned out. Events are claimed one at a time so
+// that concurrent dispatchers fan each out once. It returns the number of
+// events fanned out.
+func (d *DAO) FanOutEvents(ctx context.Context, limit int) (int, error) {
+	var n int
+	err := d.withTx(ctx, func(tx *ent.Tx) error {
+		c := tx.Client()
+		events, err := c.OutboxEvent.Query().
+			Where(outboxevent.DispatchedAtIsNil()).
+			Order(ent.Asc(outboxevent.FieldCreatedAt)).
+			Limit(limit).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("querying outbox events: %w", err)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		subs, err := c.WebhookSubscription.Query().
+			Where(webhooksubscription.Enabled(true)).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("querying webhooks: %w", err)
+		}
+
+		now := time.Now()
+		for _, e := range events {
+			claimed, err := c.OutboxEvent.Update().
+				Where(outboxevent.ID(e.ID), outboxevent.DispatchedAtIsNil()).
+				SetDispatchedAt(now).
+				Save(ctx)
+			if err != nil {
+				return fmt.Errorf("claiming outbox event: %w", err)
+			}
+			if claimed == 0 {
+				continue
+			}
+			for _, s := range subs {
+				if !entWebhookToDomain(s).Matches(e.EventType) {
+					continue
+				}
+				if err := c.WebhookDelivery.Create().
+					SetSubscriptionID(s.ID).
+					SetEventID(e.ID).
+					SetNextAttemptAt(now).
+					Exec(ctx); err != nil {
+					return fmt.Errorf("creating webhook delivery: %w", err)
+				}
+			}
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
+// ClaimWebhookDeliveries returns up to limit pending deliveries due at now,
+// oldest first, and postpones their next attempt to lease so that
+// concurrent dispatchers do not attempt them too. Deliveries of disabled
+// subscriptions wait until the subscription is enabled again.
+func (d *DAO) ClaimWebhookDeliveries(ctx context.Context, now, lease time.Time, limit int) ([]*domain.DueWebhook, error) {
+	deliveries, err := d.client.WebhookDelivery.Query().
+		Where(
+			webhookdelivery.StatusEQ(webhookdelivery.StatusPending),
+			webhookdelivery.NextAttemptAtLTE(now),
+			webhookdelivery.HasSubscriptionWith(webhooksubscription.Enabled(true)),
+		).
+		WithSubscription().
+		WithEvent().
+		Order(ent.Asc(webhookdelivery.FieldNextAttemptAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying due webhook deliveries: %w", err)
+	}
+
+	var due []*domain.DueWebhook
+	for _, dl := range deliveries {
+		claimed, err := d.client.WebhookDelivery.Update().
+			Where(
+				webhookdelivery.ID(dl.ID),
+				webhookdelivery.StatusEQ(webhookdelivery.StatusPending),
+				webhookdelivery.NextAttemptAt(dl.NextAttemptAt),
+			).
+			SetNextAttemptAt(lease).
+			Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("claiming webhook delivery: %w", err)
+		}
+		if claimed == 0 {
+			continue
+		}
+		sub := entWebhookToDomain(dl.Edges.Subscription)
+		sub.Secret = dl.Edges.Subscription.Secret
+		e := dl.Edges.Event
+		due = append(due, &domain.DueWebhook{
+			Delivery:     entWebhookDeliveryToDomain(dl),
+			Subscription: sub,
+			Event: &domain.WebhookEvent{
+				ID:        e.ID,
+				Type:      e.EventType,
+				CreatedAt: e.CreatedAt,
+				Data:      json.RawMessage(e.Payload),
+			},
+		})
+	}
+	return due, nil
+}
+
+// RecordWebhookAttempt records the outcome of an attempt to deliver.
+func (d *DAO) RecordWebhookAttempt(ctx context.Context, id uuid.UUID, attempt domain.WebhookAttempt) error {
+	update := d.client.WebhookDelivery.UpdateOneID(id).
+		AddAttempts(1).
+		SetStatus(webhookdelivery.Status(attempt.Status)).
+		SetLastStatusCode(attempt.StatusCode).
+		SetLastError(attempt.Error).
+		SetNextAttemptAt(attempt.NextAttemptAt)
+	if attempt.Status == domain.WebhookDelivered {
+		update = update.SetDeliveredAt(time.Now())
+	}
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("updating webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// GetWebhookDelivery retrieves a delivery of a subscription by ID.
+func (d *DAO) GetWebhookDelivery(ctx context.Context, subscriptionID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	dl, err := d.client.WebhookDelivery.Query().
+		Where(
+			webhookdelivery.ID(id),
+			webhookdelivery.HasSubscriptionWith(webhooksubscription.ID(subscriptionID)),
+		).
+		WithSubscription(func(q *ent.WebhookSubscriptionQuery) { q.Select(webhooksubscription.FieldID) }).
+		WithEvent(func(q *ent.OutboxEventQuery) { q.Select(outboxevent.FieldID, outboxevent.FieldEventType) }).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying webhook delivery: %w", err)
+	}
+	return entWebhookDeliveryToDomain(dl), nil
+}
+
+// ListWebhookDeliveries returns the deliveries of a subscription, newest
+// first, optionally only those with the given status.
+func (d *DAO) ListWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, status domain.WebhookDeliveryStatus, page, pageSize int) (*domain.ListResult[domain.WebhookDelivery], error) {
+	query := d.client.WebhookDelivery.Query().
+		Where(webhookdelivery.HasSubscriptionWith(webhooksubscription.ID(subscriptionID)))
+	if status != "" {
+		query = query.Where(webhookdelivery.StatusEQ(webhookdelivery.Status(status)))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("counting webhook deliveries: %w", err)
+	}
+	deliveries, err := query.
+		WithSubscription(func(q *ent.WebhookSubscriptionQuery) { q.Select(webhooksubscription.FieldID) }).
+		WithEvent(func(q *ent.OutboxEventQuery) { q.Select(outboxevent.FieldID, outboxevent.FieldEventType) }).
+		Order(ent.Desc(webhookdelivery.FieldCreatedAt)).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing webhook deliveries: %w", err)
+	}
+
+	items := make([]*domain.WebhookDelivery, len(deliveries))
+	for i, dl := range deliveries {
+		items[i] = entWebhookDeliveryToDomain(dl)
+	}
+	return &domain.ListResult[domain.WebhookDelivery]{
+		Items:    items,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// RequeueWebhookDelivery makes a delivery pending again, due now, with its
+// attempts reset.
+func (d *DAO) RequeueWebhookDelivery(ctx context.Context, id uuid.UUID) error {
+	if err := d.client.WebhookDelivery.UpdateOneID(id).
+		SetStatus(webhookdelivery.StatusPending).
+		SetAttempts(0).
+		SetNextAttemptAt(time.Now()).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("requeueing webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// PurgeWebhookHistory deletes the deliveries that were delivered or
+// dead-lettered before the given time, and then the events dispatched
+// before it that have no deliveries left. Events with pending deliveries
+// are kept. It returns how many deliveries and events were deleted.
+func (d *DAO) PurgeWebhookHistory(ctx context.Context, before time.Time) (deliveries, events int, err error) {
+	deliveries, err = d.client.WebhookDelivery.Delete().
+		Where(
+			webhookdelivery.StatusIn(webhookdelivery.StatusDelivered, webhookdelivery.StatusDead),
+			webhookdelivery.UpdatedAtLT(before),
+		).
+		Exec(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("purging webhook deliveries: %w", err)
+	}
+	events, err = d.client.OutboxEvent.Delete().
+		Where(
+			outboxevent.DispatchedAtLT(before),
+			outboxevent.Not(outboxevent.HasDeliveries()),
+		).
+		Exec(ctx)
+	if err != nil {
+		return deliveries, 0, fmt.Errorf("purging outbox events: %w", err)
+	}
+	return deliveries, events, nil
+}
+
+func entWebhookToDomain(s *ent.WebhookSubscription) *domain.WebhookSubscription {
+	return &domain.WebhookSubscription{
+		ID:         s.ID,
+		Name:       s.Name,
+		URL:        s.URL,
+		EventTypes: s.EventTypes,
+		Enabled:    s.Enabled,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+func entWebhookDeliveryToDomain(dl *ent.WebhookDelivery) *domain.WebhookDelivery {
+	w := &domain.WebhookDelivery{
+		ID:             dl.ID,
+		Status:         domain.WebhookDeliveryStatus(dl.Status),
+		Attempts:       dl.Attempts,
+		NextAttemptAt:  dl.NextAttemptAt,
+		LastStatusCode: dl.LastStatusCode,
+		LastError:      dl.LastError,
+		DeliveredAt:    dl.DeliveredAt,
+		CreatedAt:      dl.CreatedAt,
+	}
+	if s := dl.Edges.Subscription; s != nil {
+		w.SubscriptionID = s.ID
+	}
+	if e := dl.Edges.Event; e != nil {
+		w.EventID = e.ID
+		w.EventType = e.EventType
+	}
+	return w
+}
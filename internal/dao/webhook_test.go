@@ -0,0 +1,151 @@
+package dao
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/outboxevent"
+)
+
+func TestOutboxWrittenWithChanges(t *testing.T) {
+	d, ctx := setupGroupTestDAO(t)
+
+	u, err := d.CreateUser(ctx, "alice", "Alice", "alice@example.com", "hash", "")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if err := d.UpdateUserStatus(ctx, u.ID, "disabled"); err != nil {
+		t.Fatalf("UpdateUserStatus: %v", err)
+	}
+	g, _ := d.CreateGroup(ctx, "ops", "", nil)
+	if err := d.AddMembers(ctx, g.ID, []uuid.UUID{u.ID}); err != nil {
+		t.Fatalf("AddMembers: %v", err)
+	}
+	// A change that fails leaves no event behind.
+	if _, err := d.CreateUser(ctx, "alice", "Other", "other@example.com", "hash", ""); err == nil {
+		t.Fatal("CreateUser with a taken username succeeded")
+	}
+	if err := d.UpdatePasswordHash(ctx, u.ID, "other"); err != nil {
+		t.Fatalf("UpdatePasswordHash: %v", err)
+	}
+
+	events, err := d.Client().OutboxEvent.Query().Order(ent.Asc(outboxevent.FieldCreatedAt)).All(ctx)
+	if err != nil {
+		t.Fatalf("query outbox: %v", err)
+	}
+	var types []string
+	for _, e := range events {
+		types = append(types, e.EventType)
+	}
+	want := []string{
+		domain.EventUserCreated, domain.EventUserUpdated, domain.EventUserDisabled,
+		domain.EventGroupCreated, domain.EventGroupMemberAdded,
+	}
+	if !slices.Equal(types, want) {
+		t.Fatalf("events = %v, want %v", types, want)
+	}
+
+	var disabled domain.UserEventData
+	if err := json.Unmarshal([]byte(events[2].Payload), &disabled); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if disabled.User.Status != domain.UserStatusDisabled || disabled.Previous == nil || disabled.Previous.Status != domain.UserStatusEnabled {
+		t.Errorf("user.disabled data = %+v, want the status change", disabled)
+	}
+	var added domain.MembershipEventData
+	if err := json.Unmarshal([]byte(events[4].Payload), &added); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if added.Username != "alice" || added.GroupName != "ops" {
+		t.Errorf("group.member.added data = %+v, want alice and ops", added)
+	}
+}
+
+func TestFanOutEvents(t *testing.T) {
+	d, ctx := setupGroupTestDAO(t)
+
+	users, _ := d.CreateWebhook(ctx, domain.CreateWebhookInput{Name: "users", URL: "http://example.com", Secret: "s", EventTypes: []string{"user.*"}})
+	_, _ = d.CreateWebhook(ctx, domain.CreateWebhookInput{Name: "groups", URL: "http://example.com", Secret: "s", EventTypes: []string{domain.EventGroupCreated}})
+	off, _ := d.CreateWebhook(ctx, domain.CreateWebhookInput{Name: "off", URL: "http://example.com", Secret: "s", EventTypes: []string{"*"}})
+	enabled := false
+	if _, err := d.UpdateWebhook(ctx, off.ID, domain.UpdateWebhookInput{Enabled: &enabled}); err != nil {
+		t.Fatalf("UpdateWebhook: %v", err)
+	}
+
+	_, _ = d.CreateUser(ctx, "bob", "Bob", "bob@example.com", "hash", "")
+	_, _ = d.CreateGroup(ctx, "ops", "", nil)
+
+	for range 2 {
+		if _, err := d.FanOutEvents(ctx, 10); err != nil {
+			t.Fatalf("FanOutEvents: %v", err)
+		}
+	}
+	if n, _ := d.Client().WebhookDelivery.Query().Count(ctx); n != 2 {
+		t.Errorf("got %d deliveries, want one per matching enabled subscription", n)
+	}
+
+	now := time.Now()
+	due, err := d.ClaimWebhookDeliveries(ctx, now, now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ClaimWebhookDeliveries: %v", err)
+	}
+	if len(due) != 2 {
+		t.Fatalf("claimed %d deliveries, want 2", len(due))
+	}
+	for _, w := range due {
+		if w.Subscription.Secret != "s" || w.Event.Type == "" {
+			t.Errorf("due webhook = %+v, want the secret and event", w)
+		}
+		if w.Subscription.ID == users.ID && w.Event.Type != domain.EventUserCreated {
+			t.Errorf("users subscription got %s", w.Event.Type)
+		}
+	}
+	if again, _ := d.ClaimWebhookDeliveries(ctx, now, now.Add(time.Minute), 10); len(again) != 0 {
+		t.Errorf("claimed %d deliveries twice", len(again))
+	}
+}
+
+func TestPurgeWebhookHistory(t *testing.T) {
+	d, ctx := setupGroupTestDAO(t)
+
+	_, _ = d.CreateWebhook(ctx, domain.CreateWebhookInput{Name: "users", URL: "http://example.com", Secret: "s", EventTypes: []string{domain.EventUserCreated}})
+	_, _ = d.CreateUser(ctx, "bob", "Bob", "bob@example.com", "hash", "")
+	_, _ = d.CreateGroup(ctx, "ops", "", nil)
+	if _, err := d.FanOutEvents(ctx, 10); err != nil {
+		t.Fatalf("FanOutEvents: %v", err)
+	}
+	now := time.Now()
+	due, _ := d.ClaimWebhookDeliveries(ctx, now, now.Add(time.Minute), 10)
+	if len(due) != 1 {
+		t.Fatalf("claimed %d deliveries, want 1", len(due))
+	}
+	if err := d.RecordWebhookAttempt(ctx, due[0].Delivery.ID, domain.WebhookAttempt{Status: domain.WebhookDelivered, StatusCode: 200}); err != nil {
+		t.Fatalf("RecordWebhookAttempt: %v", err)
+	}
+	// A pending delivery keeps its event.
+	_, _ = d.CreateUser(ctx, "carl", "Carl", "carl@example.com", "hash", "")
+	if _, err := d.FanOutEvents(ctx, 10); err != nil {
+		t.Fatalf("FanOutEvents: %v", err)
+	}
+
+	if deliveries, events, err := d.PurgeWebhookHistory(ctx, now.Add(-time.Hour)); err != nil || deliveries+events != 0 {
+		t.Errorf("purge before the events = %d, %d, %v, want nothing purged", deliveries, events, err)
+	}
+	deliveries, events, err := d.PurgeWebhookHistory(ctx, time.Now().Add(time.Minute))
+	if err != nil || deliveries != 1 || events != 2 {
+		t.Fatalf("PurgeWebhookHistory = %d, %d, %v, want the delivery and the events of bob and ops", deliveries, events, err)
+	}
+	left, _ := d.Client().OutboxEvent.Query().All(ctx)
+	if len(left) != 1 {
+		t.Fatalf("%d events left, want carl's", len(left))
+	}
+	if latest, err := d.LatestEventTime(ctx); err != nil || latest == nil || !latest.Equal(left[0].CreatedAt) {
+		t.Errorf("LatestEventTime = %v, %v, want %v", latest, err, left[0].CreatedAt)
+	}
+}
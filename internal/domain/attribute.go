@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttributeTarget identifies which entity kind an extension attribute applies to.
+type AttributeTarget string
+
+const (
+	AttributeTargetUser  AttributeTarget = "user"
+	AttributeTargetGroup AttributeTarget = "group"
+)
+
+// AttributeType represents the value type of an extension attribute.
+type AttributeType string
+
+const (
+	AttributeTypeString  AttributeType = "string"
+	AttributeTypeInteger AttributeType = "integer"
+	AttributeTypeBoolean AttributeType = "boolean"
+)
+
+// AttributeDefinition declares an admin-defined extension attribute.
+type AttributeDefinition struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Target      AttributeTarget `json:"target"`
+	Type        AttributeType   `json:"type"`
+	MultiValued bool            `json:"multi_valued"`
+	LDAPName    string          `json:"ldap_name"`
+	Description string          `json:"description"`
+	Required    bool            `json:"required"`
+	Indexed     bool            `json:"indexed"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// CreateAttributeDefinitionInput holds input for declaring a new extension attribute.
+type CreateAttributeDefinitionInput struct {
+	Name        string
+	Target      AttributeTarget
+	Type        AttributeType
+	MultiValued bool
+	LDAPName    string
+	Description string
+	Required    bool
+	Indexed     bool
+}
+
+// UpdateAttributeDefinitionInput holds input for updating an extension attribute.
+// Name, target, type and cardinality are immutable once values may exist.
+type UpdateAttributeDefinitionInput struct {
+	LDAPName    *string
+	Description *string
+	Required    *bool
+	Indexed     *bool
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditOutcome tells whether an audited operation succeeded.
+type AuditOutcome string
+
+const (
+	// AuditSuccess is an operation that took effect, or a login that
+	// succeeded.
+	AuditSuccess AuditOutcome = "success"
+	// AuditFailure is a login or bind that failed or was refused.
+	AuditFailure AuditOutcome = "failure"
+)
+
+// Actor types recorded with audit events. Operations without an actor,
+// such as lockouts, are recorded with an empty actor type.
+const (
+	AuditActorUser           = "user"
+	AuditActorServiceAccount = "service_account"
+)
+
+// Target types recorded with audit events.
+const (
+	AuditTargetUser           = "user"
+	AuditTargetGroup          = "group"
+	AuditTargetRole           = "role"
+	AuditTargetAttribute      = "attribute"
+	AuditTargetPasswordPolicy = "password_policy"
+	AuditTargetOIDCClient     = "oidc_client"
+	AuditTargetServiceAccount = "service_account"
+	AuditTargetLDAPConfig     = "ldap_config"
+	AuditTargetWebhook        = "webhook"
+	AuditTargetConnector      = "provisioning_connector"
+	AuditTargetDirectory      = "directory_source"
+)
+
+// Actions recorded with audit events.
+const (
+	AuditLogin = "auth.login"
+	AuditBind  = "auth.bind"
+
+	AuditUserCreate         = "user.create"
+	AuditUserInvite         = "user.invite"
+	AuditUserUpdate         = "user.update"
+	AuditUserDelete         = "user.delete"
+	AuditUserStatus         = "user.status"
+	AuditUserPasswordChange = "user.password.change"
+	AuditUserPasswordReset  = "user.password.reset"
+	AuditUserLock           = "user.lock"
+	AuditUserUnlock         = "user.unlock"
+	AuditUserMFAEnable      = "user.mfa.enable"
+	AuditUserMFADisable     = "user.mfa.disable"
+	AuditUserMFAReset       = "user.mfa.reset"
+	AuditUserSSHKeyAdd      = "user.ssh_key.add"
+	AuditUserSSHKeyDelete   = "user.ssh_key.delete"
+	AuditUserRevert         = "user.revert"
+
+	AuditGroupCreate       = "group.create"
+	AuditGroupUpdate       = "group.update"
+	AuditGroupDelete       = "group.delete"
+	AuditGroupMemberAdd    = "group.member.add"
+	AuditGroupMemberRemove = "group.member.remove"
+	AuditGroupOwnerAdd     = "group.owner.add"
+	AuditGroupOwnerRemove  = "group.owner.remove"
+
+	AuditRoleCreate   = "role.create"
+	AuditRoleUpdate   = "role.update"
+	AuditRoleDelete   = "role.delete"
+	AuditRoleAssign   = "role.assign"
+	AuditRoleUnassign = "role.unassign"
+
+	AuditAttributeCreate = "attribute.create"
+	AuditAttributeUpdate = "attribute.update"
+	AuditAttributeDelete = "attribute.delete"
+
+	AuditPasswordPolicyCreate   = "password_policy.create"
+	AuditPasswordPolicyUpdate   = "password_policy.update"
+	AuditPasswordPolicyDelete   = "password_policy.delete"
+	AuditPasswordPolicyAssign   = "password_policy.assign"
+	AuditPasswordPolicyUnassign = "password_policy.unassign"
+
+	AuditOIDCClientCreate = "oidc_client.create"
+	AuditOIDCClientUpdate = "oidc_client.update"
+	AuditOIDCClientDelete = "oidc_client.delete"
+	AuditOIDCClientSecret = "oidc_client.secret.rotate"
+
+	AuditServiceAccountCreate = "service_account.create"
+	AuditServiceAccountUpdate = "service_account.update"
+	AuditServiceAccountDelete = "service_account.delete"
+	AuditAPIKeyCreate         = "service_account.key.create"
+	AuditAPIKeyRotate         = "service_account.key.rotate"
+	AuditAPIKeyDelete         = "service_account.key.delete"
+
+	AuditLDAPConfigUpdate = "ldap_config.update"
+
+	AuditWebhookCreate = "webhook.create"
+	AuditWebhookUpdate = "webhook.update"
+	AuditWebhookDelete = "webhook.delete"
+
+	AuditConnectorCreate = "provisioning_connector.create"
+	AuditConnectorUpdate = "provisioning_connector.update"
+	AuditConnectorDelete = "provisioning_connector.delete"
+	AuditConnectorSync   = "provisioning_connector.sync"
+
+	AuditDirectorySync = "directory_source.sync"
+)
+
+// AuditChange is a field changed by an audited operation. Secrets such as
+// passwords are recorded as Redacted, without their values.
+type AuditChange struct {
+	Field    string `json:"field"`
+	Old      any    `json:"old,omitempty"`
+	New      any    `json:"new,omitempty"`
+	Redacted bool   `json:"redacted,omitempty"`
+}
+
+// AuditEvent is an entry in the audit trail. The actor is the user or
+// service account that performed the operation, or for logins the user
+// logging in; ActorID is nil for logins with unknown usernames and for
+// operations the system performs itself, such as lockouts.
+type AuditEvent struct {
+	ID         uuid.UUID     `json:"id"`
+	CreatedAt  time.Time     `json:"created_at"`
+	Action     string        `json:"action"`
+	Outcome    AuditOutcome  `json:"outcome"`
+	Reason     string        `json:"reason,omitempty"`
+	ActorType  string        `json:"actor_type,omitempty"`
+	ActorID    *uuid.UUID    `json:"actor_id,omitempty"`
+	ActorName  string        `json:"actor_name,omitempty"`
+	TargetType string        `json:"target_type,omitempty"`
+	TargetID   string        `json:"target_id,omitempty"`
+	TargetName string        `json:"target_name,omitempty"`
+	Protocol   string        `json:"protocol,omitempty"`
+	IPAddress  string        `json:"ip_address,omitempty"`
+	RequestID  string        `json:"request_id,omitempty"`
+	Changes    []AuditChange `json:"changes,omitempty"`
+}
+
+// AuditFilter selects audit events. Zero fields match everything. Actor
+// matches the actor's name or ID, an Action ending in "*" matches every
+// action with that prefix, e.g. "group.*", and From and To bound the time
+// range, To exclusive.
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   string
+	Outcome    AuditOutcome
+	Protocol   string
+	IPAddress  string
+	From       *time.Time
+	To         *time.Time
+}
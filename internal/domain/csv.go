@@ -0,0 +1,97 @@
+package domain
+
+import "github.com/google/uuid"
+
+// CSVImportMode decides whether the valid rows of a CSV import are applied
+// when other rows are invalid.
+type CSVImportMode string
+
+const (
+	// CSVImportAllOrNothing applies the rows only if all of them are valid,
+	// in one transaction that is rolled back if any row fails to apply.
+	CSVImportAllOrNothing CSVImportMode = "all_or_nothing"
+	// CSVImportBestEffort applies the valid rows and reports the others.
+	CSVImportBestEffort CSVImportMode = "best_effort"
+)
+
+// CSVCredentials is how users created by a CSV import get their first
+// password when their row does not have one.
+type CSVCredentials string
+
+const (
+	// CSVCredentialsGenerate generates a password, returned in the report,
+	// which must be changed at the first login.
+	CSVCredentialsGenerate CSVCredentials = "generate"
+	// CSVCredentialsInvite creates pending users and emails them an
+	// invitation to choose their password.
+	CSVCredentialsInvite CSVCredentials = "invite"
+	// CSVCredentialsNone creates users without a password, who can set one
+	// by resetting it.
+	CSVCredentialsNone CSVCredentials = "none"
+)
+
+// CSVImportOptions holds the options of a CSV import of users. Columns maps
+// fields to the header of the column holding them, for columns not named
+// after their field; see CSVUserFields. With ReplaceGroups users are also
+// removed from the groups their row does not list.
+type CSVImportOptions struct {
+	Columns       map[string]string
+	Mode          CSVImportMode
+	Credentials   CSVCredentials
+	ReplaceGroups bool
+	DryRun        bool
+}
+
+// CSVUserFields lists the fields of users in CSV files, in the order they
+// are exported. Extension attributes are the fields attr.<name>.
+var CSVUserFields = []string{
+	"username", "display_name", "email", "phone", "status", "manager", "account_expires_at", "groups", "password",
+}
+
+// CSVImportAction is what a CSV import did, or would do, with a row.
+type CSVImportAction string
+
+const (
+	CSVImportCreate    CSVImportAction = "create"
+	CSVImportUpdate    CSVImportAction = "update"
+	CSVImportUnchanged CSVImportAction = "unchanged"
+	CSVImportFail      CSVImportAction = "fail"
+	// CSVImportSkip marks the rows not applied because applying another
+	// one failed in an all-or-nothing import.
+	CSVImportSkip CSVImportAction = "skip"
+)
+
+// CSVImportRow is the outcome of importing one row of a CSV file. Values
+// are the cells of the row. Fields lists the fields changed and Errors the
+// reasons the row is invalid or failed. Password is the generated password
+// of a created user.
+type CSVImportRow struct {
+	Row      int             `json:"row"`
+	Username string          `json:"username"`
+	Action   CSVImportAction `json:"action"`
+	UserID   *uuid.UUID      `json:"user_id,omitempty"`
+	Fields   []string        `json:"fields,omitempty"`
+	Invited  bool            `json:"invited,omitempty"`
+	Password string          `json:"password,omitempty"`
+	Errors   []string        `json:"errors,omitempty"`
+	Values   []string        `json:"values"`
+}
+
+// CSVImportReport is the outcome of a CSV import. Applied reports whether
+// any row was applied: not in a dry run, nor in an all-or-nothing import
+// with a row that is invalid or fails to apply. Columns maps the fields imported to their column
+// headers, and Ignored lists the columns that were not imported.
+type CSVImportReport struct {
+	DryRun    bool              `json:"dry_run"`
+	Mode      CSVImportMode     `json:"mode"`
+	Applied   bool              `json:"applied"`
+	Header    []string          `json:"header"`
+	Columns   map[string]string `json:"columns"`
+	Ignored   []string          `json:"ignored,omitempty"`
+	Created   int               `json:"created"`
+	Updated   int               `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+	Failed    int               `json:"failed"`
+	Skipped   int               `json:"skipped"`
+	Rows      []*CSVImportRow   `json:"rows"`
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DirectoryConflictPolicy is what synchronization does with an upstream
+// entry whose username or group name is taken by a local user or group
+// that is not synchronized from it.
+type DirectoryConflictPolicy string
+
+const (
+	// DirectoryConflictSkip reports the entry and leaves the local object alone.
+	DirectoryConflictSkip DirectoryConflictPolicy = "skip"
+	// DirectoryConflictAdopt links the local object to the entry and
+	// overwrites it from then on.
+	DirectoryConflictAdopt DirectoryConflictPolicy = "adopt"
+)
+
+// DirectoryDeletePolicy is what synchronization does with users whose
+// entries are gone from the upstream directory. Groups are deleted unless
+// the policy is keep.
+type DirectoryDeletePolicy string
+
+const (
+	DirectoryDeleteDisable DirectoryDeletePolicy = "disable"
+	DirectoryDeleteDelete  DirectoryDeletePolicy = "delete"
+	DirectoryDeleteKeep    DirectoryDeletePolicy = "keep"
+)
+
+// DirectorySource is an upstream LDAP directory users and groups are
+// synchronized from. Interval is zero for sources that are synchronized
+// only on demand. The bind password is never returned.
+type DirectorySource struct {
+	Name               string                  `json:"name"`
+	URL                string                  `json:"url"`
+	BindDN             string                  `json:"bind_dn,omitempty"`
+	BindPassword       string                  `json:"-"`
+	StartTLS           bool                    `json:"start_tls"`
+	InsecureSkipVerify bool                    `json:"insecure_skip_verify"`
+	Timeout            time.Duration           `json:"-"`
+	Interval           time.Duration           `json:"-"`
+	Conflict           DirectoryConflictPolicy `json:"conflict"`
+	Delete             DirectoryDeletePolicy   `json:"delete"`
+	Users              DirectoryUserMapping    `json:"users"`
+	SyncGroups         bool                    `json:"sync_groups"`
+	Groups             DirectoryGroupMapping   `json:"groups"`
+	PassThrough        DirectoryPassThrough    `json:"pass_through"`
+}
+
+// DirectoryUserMapping is where users are found in an upstream directory
+// and which of its attributes their fields are read from. Entries matching
+// DisabledFilter too are synchronized as disabled users. Password, if set,
+// is an attribute holding password hashes such as {SSHA}..., imported when
+// their scheme is supported. Attributes maps user extension attributes to
+// upstream attributes.
+type DirectoryUserMapping struct {
+	BaseDN         string            `json:"base_dn"`
+	Filter         string            `json:"filter"`
+	DisabledFilter string            `json:"disabled_filter,omitempty"`
+	IDAttribute    string            `json:"id_attribute"`
+	Username       string            `json:"username"`
+	DisplayName    string            `json:"display_name"`
+	Email          string            `json:"email"`
+	Phone          string            `json:"phone"`
+	Password       string            `json:"password,omitempty"`
+	Attributes     map[string]string `json:"attributes,omitempty"`
+}
+
+// DirectoryGroupMapping is where groups are found in an upstream directory
+// and which of its attributes their fields are read from. Member lists the
+// DNs, or usernames, of the members. Attributes maps group extension
+// attributes to upstream attributes.
+type DirectoryGroupMapping struct {
+	BaseDN      string            `json:"base_dn"`
+	Filter      string            `json:"filter"`
+	IDAttribute string            `json:"id_attribute"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Member      string            `json:"member"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+}
+
+// DirectoryPassThrough is which users authenticate against an upstream
+// directory: their passwords are verified by binding to it as them rather
+// than against the stored hash, for users whose passwords are only known
+// there. Groups are names of groups whose direct members are included;
+// SynchronizedUsers includes all users synchronized from the directory.
+// With CapturePassword verified passwords are also stored, hashed, so that
+// the users can keep them once pass-through is turned off.
+type DirectoryPassThrough struct {
+	Users             []string `json:"users,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+	SynchronizedUsers bool     `json:"synchronized_users"`
+	CapturePassword   bool     `json:"capture_password"`
+}
+
+// Enabled reports whether any users authenticate against the directory.
+func (p DirectoryPassThrough) Enabled() bool {
+	return len(p.Users) > 0 || len(p.Groups) > 0 || p.SynchronizedUsers
+}
+
+// DirectoryResourceType is the type of a synchronized object.
+type DirectoryResourceType string
+
+const (
+	DirectoryUser  DirectoryResourceType = "user"
+	DirectoryGroup DirectoryResourceType = "group"
+)
+
+// DirectoryObject links an upstream entry, by its immutable identifier, to
+// the user or group synchronized from it.
+type DirectoryObject struct {
+	ID             uuid.UUID             `json:"id"`
+	Source         string                `json:"source"`
+	ResourceType   DirectoryResourceType `json:"resource_type"`
+	ExternalID     string                `json:"external_id"`
+	LocalID        uuid.UUID             `json:"local_id"`
+	DN             string                `json:"dn"`
+	PasswordDigest string                `json:"-"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// DirectorySyncAction is what synchronization did, or would do in a dry
+// run, with an entry.
+type DirectorySyncAction string
+
+const (
+	DirectorySyncCreate   DirectorySyncAction = "create"
+	DirectorySyncUpdate   DirectorySyncAction = "update"
+	DirectorySyncAdopt    DirectorySyncAction = "adopt"
+	DirectorySyncDisable  DirectorySyncAction = "disable"
+	DirectorySyncDelete   DirectorySyncAction = "delete"
+	DirectorySyncConflict DirectorySyncAction = "conflict"
+	DirectorySyncFail     DirectorySyncAction = "fail"
+)
+
+// DirectorySyncChange is a change made to, or in a dry run planned for, a
+// user or group. Fields lists the fields changed; Error explains conflicts
+// and failures.
+type DirectorySyncChange struct {
+	ResourceType DirectoryResourceType `json:"resource_type"`
+	Action       DirectorySyncAction   `json:"action"`
+	Name         string                `json:"name"`
+	DN           string                `json:"dn,omitempty"`
+	LocalID      *uuid.UUID            `json:"local_id,omitempty"`
+	Fields       []string              `json:"fields,omitempty"`
+	Error        string                `json:"error,omitempty"`
+}
+
+// DirectorySyncCounts counts the users or groups of a synchronization by
+// what was done with them.
+type DirectorySyncCounts struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Disabled  int `json:"disabled"`
+	Deleted   int `json:"deleted"`
+	Unchanged int `json:"unchanged"`
+	Conflicts int `json:"conflicts"`
+	Failed    int `json:"failed"`
+}
+
+// DirectorySyncReport is the outcome of synchronizing a source, or in a
+// dry run what synchronizing it would do. Error is set if the source could
+// not be read, in which case nothing was changed.
+type DirectorySyncReport struct {
+	Source     string                 `json:"source"`
+	DryRun     bool                   `json:"dry_run"`
+	StartedAt  time.Time              `json:"started_at"`
+	FinishedAt time.Time              `json:"finished_at"`
+	Users      DirectorySyncCounts    `json:"users"`
+	Groups     DirectorySyncCounts    `json:"groups"`
+	Changes    []*DirectorySyncChange `json:"changes"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// DirectorySourceStatus is a source with the report of its last
+// synchronization, if any since the server started.
+type DirectorySourceStatus struct {
+	Source     *DirectorySource     `json:"source"`
+	LastReport *DirectorySyncReport `json:"last_report,omitempty"`
+}
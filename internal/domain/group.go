@@ -8,26 +8,38 @@ import (
 
 // Group represents a user group in the system.
 type Group struct {
-	ID          uuid.UUID  `json:"id"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	Children    []*Group   `json:"children,omitempty"`
-	Users       []*User    `json:"users,omitempty"`
+	ID          uuid.UUID           `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	ParentID    *uuid.UUID          `json:"parent_id,omitempty"`
+	RequireMFA  bool                `json:"require_mfa"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Children    []*Group            `json:"children,omitempty"`
+	Users       []*User             `json:"users,omitempty"`
+	OwnerUsers  []*User             `json:"owner_users,omitempty"`
+	OwnerGroups []*Group            `json:"owner_groups,omitempty"`
+	Attributes  map[string][]string `json:"attributes,omitempty"`
 }
 
 // CreateGroupInput holds input for creating a new group.
+// When no owners are given, the acting user becomes the owner.
 type CreateGroupInput struct {
-	Name        string
-	Description string
-	ParentID    *uuid.UUID
+	Name          string
+	Description   string
+	ParentID      *uuid.UUID
+	OwnerUserIDs  []uuid.UUID
+	OwnerGroupIDs []uuid.UUID
+	Attributes    map[string][]string
 }
 
 // UpdateGroupInput holds input for updating an existing group.
+// Attributes follows the same replace semantics as UpdateUserInput.
+// RequireMFA makes multi-factor authentication mandatory for direct members.
 type UpdateGroupInput struct {
 	Name        *string
 	Description *string
 	ParentID    *uuid.UUID
+	RequireMFA  *bool
+	Attributes  map[string][]string
 }
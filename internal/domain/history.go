@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VersionOperation is what produced a version of a user or group. Baseline
+// versions are taken of users and groups that existed before history was
+// recorded; their state before then is unknown.
+type VersionOperation string
+
+const (
+	VersionCreate   VersionOperation = "create"
+	VersionUpdate   VersionOperation = "update"
+	VersionDelete   VersionOperation = "delete"
+	VersionBaseline VersionOperation = "baseline"
+)
+
+// MembershipOperation is whether a user joined or left a group.
+type MembershipOperation string
+
+const (
+	MembershipAdd    MembershipOperation = "add"
+	MembershipRemove MembershipOperation = "remove"
+)
+
+// UserVersion is a user's profile and extension attributes as of a change.
+// Versions are numbered from 1 for each user; a delete version holds the
+// profile the user had when deleted. Secrets, MFA and lockout state are not
+// versioned.
+type UserVersion struct {
+	UserID           uuid.UUID           `json:"user_id"`
+	Version          int                 `json:"version"`
+	Operation        VersionOperation    `json:"operation"`
+	Username         string              `json:"username"`
+	DisplayName      string              `json:"display_name"`
+	Email            string              `json:"email"`
+	Phone            string              `json:"phone"`
+	Status           UserStatus          `json:"status"`
+	ManagerID        *uuid.UUID          `json:"manager_id,omitempty"`
+	AccountExpiresAt *time.Time          `json:"account_expires_at,omitempty"`
+	Attributes       map[string][]string `json:"attributes,omitempty"`
+	ActorID          *uuid.UUID          `json:"actor_id,omitempty"`
+	ActorName        string              `json:"actor_name,omitempty"`
+	CreatedAt        time.Time           `json:"created_at"`
+}
+
+// GroupVersion is a group's settings and extension attributes as of a
+// change, numbered like UserVersion. Members are versioned separately as
+// MembershipChanges.
+type GroupVersion struct {
+	GroupID     uuid.UUID           `json:"group_id"`
+	Version     int                 `json:"version"`
+	Operation   VersionOperation    `json:"operation"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	ParentID    *uuid.UUID          `json:"parent_id,omitempty"`
+	RequireMFA  bool                `json:"require_mfa"`
+	Attributes  map[string][]string `json:"attributes,omitempty"`
+	ActorID     *uuid.UUID          `json:"actor_id,omitempty"`
+	ActorName   string              `json:"actor_name,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// MembershipChange records a user joining or leaving a group, including
+// when either of them was deleted.
+type MembershipChange struct {
+	GroupID   uuid.UUID           `json:"group_id"`
+	UserID    uuid.UUID           `json:"user_id"`
+	Operation MembershipOperation `json:"operation"`
+	ActorID   *uuid.UUID          `json:"actor_id,omitempty"`
+	ActorName string              `json:"actor_name,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+// UserHistory is every version of a user and every change to their group
+// memberships, oldest first.
+type UserHistory struct {
+	Versions    []*UserVersion      `json:"versions"`
+	Memberships []*MembershipChange `json:"memberships"`
+}
+
+// GroupHistory is every version of a group and every change to its
+// members, oldest first.
+type GroupHistory struct {
+	Versions    []*GroupVersion     `json:"versions"`
+	Memberships []*MembershipChange `json:"memberships"`
+}
+
+// UserSnapshot is a user as of a point in time, with the groups they
+// belonged to then.
+type UserSnapshot struct {
+	At     time.Time       `json:"at"`
+	User   *UserVersion    `json:"user"`
+	Groups []*GroupVersion `json:"groups"`
+}
+
+// GroupSnapshot is a group as of a point in time, with the users who
+// belonged to it then.
+type GroupSnapshot struct {
+	At      time.Time      `json:"at"`
+	Group   *GroupVersion  `json:"group"`
+	Members []*UserVersion `json:"members"`
+}
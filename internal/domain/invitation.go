@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvitationPolicy controls the emailed invitations of pending users.
+type InvitationPolicy struct {
+	// URL is the page invitation links lead to; the token is added as its
+	// "token" query parameter.
+	URL string
+	// TokenTTL is how long an invitation stays valid.
+	TokenTTL time.Duration
+}
+
+// Invitation is an emailed link with which a pending user activates their
+// account. Only the hash of its token is stored.
+type Invitation struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+}
+
+// Valid reports whether the invitation can still be accepted at now.
+func (i *Invitation) Valid(now time.Time) bool {
+	return i.AcceptedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// InviteUserInput holds input for inviting a new user, who chooses their
+// own password when accepting the invitation.
+type InviteUserInput struct {
+	Username         string
+	DisplayName      string
+	Email            string
+	Phone            string
+	Attributes       map[string][]string
+	AccountExpiresAt *time.Time
+}
+
+// AcceptInvitationInput holds the password an invited user chooses and,
+// when they enroll MFA while accepting, a code for their new TOTP secret.
+type AcceptInvitationInput struct {
+	Password string
+	TOTPCode string
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "github.com/google/uuid"
+
+// LDIFImportAction is what an LDIF import did, or would do in a dry run,
+// with an entry.
+type LDIFImportAction string
+
+const (
+	LDIFImportCreate    LDIFImportAction = "create"
+	LDIFImportUpdate    LDIFImportAction = "update"
+	LDIFImportRename    LDIFImportAction = "rename"
+	LDIFImportDelete    LDIFImportAction = "delete"
+	LDIFImportUnchanged LDIFImportAction = "unchanged"
+	LDIFImportSkip      LDIFImportAction = "skip"
+	LDIFImportFail      LDIFImportAction = "fail"
+)
+
+// LDIFImportResult is the outcome of importing one record of an LDIF file.
+// ChangeType is empty for content records. Entries that are not users or
+// groups, such as the base DN and the containers of users and groups, are
+// skipped. Fields lists the fields changed, Ignored the attributes of the
+// record that were not imported, and Error explains failures.
+type LDIFImportResult struct {
+	Line       int              `json:"line"`
+	DN         string           `json:"dn"`
+	ChangeType string           `json:"changetype,omitempty"`
+	Type       AttributeTarget  `json:"type,omitempty"`
+	Action     LDIFImportAction `json:"action"`
+	LocalID    *uuid.UUID       `json:"local_id,omitempty"`
+	Fields     []string         `json:"fields,omitempty"`
+	Ignored    []string         `json:"ignored,omitempty"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// LDIFImportReport is the outcome of importing an LDIF file, or in a dry
+// run what importing it would do, with a result per record.
+type LDIFImportReport struct {
+	DryRun    bool                `json:"dry_run"`
+	Created   int                 `json:"created"`
+	Updated   int                 `json:"updated"`
+	Renamed   int                 `json:"renamed"`
+	Deleted   int                 `json:"deleted"`
+	Unchanged int                 `json:"unchanged"`
+	Skipped   int                 `json:"skipped"`
+	Failed    int                 `json:"failed"`
+	Entries   []*LDIFImportResult `json:"entries"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+// MFAStatus describes a user's multi-factor authentication setup.
+// Required is set when a group the user belongs to demands MFA.
+type MFAStatus struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// TOTPEnrollment is a pending TOTP secret together with the otpauth:// URI
+// that authenticator apps scan as a QR code.
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultPasswordPolicyName is the name of the built-in policy that applies
+// to every user.
+const DefaultPasswordPolicyName = "default"
+
+// Password policy violation codes.
+const (
+	PasswordTooShort         = "too_short"
+	PasswordMissingUppercase = "missing_uppercase"
+	PasswordMissingLowercase = "missing_lowercase"
+	PasswordMissingDigit     = "missing_digit"
+	PasswordMissingSymbol    = "missing_symbol"
+	PasswordInDictionary     = "in_dictionary"
+	PasswordContainsUsername = "contains_username"
+	PasswordReused           = "reused"
+	PasswordTooRecent        = "too_recent"
+)
+
+// PasswordRules are the requirements a password policy places on passwords.
+// Zero values disable a rule. HistoryCount previous passwords may not be
+// reused; a password may not be changed again within MinAgeHours and must
+// be changed after MaxAgeDays.
+type PasswordRules struct {
+	MinLength        int  `json:"min_length"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit"`
+	RequireSymbol    bool `json:"require_symbol"`
+	CheckDictionary  bool `json:"check_dictionary"`
+	CheckUsername    bool `json:"check_username"`
+	HistoryCount     int  `json:"history_count"`
+	MinAgeHours      int  `json:"min_age_hours"`
+	MaxAgeDays       int  `json:"max_age_days"`
+}
+
+// Merge returns the strictest combination of both rule sets.
+func (r PasswordRules) Merge(o PasswordRules) PasswordRules {
+	r.MinLength = max(r.MinLength, o.MinLength)
+	r.RequireUppercase = r.RequireUppercase || o.RequireUppercase
+	r.RequireLowercase = r.RequireLowercase || o.RequireLowercase
+	r.RequireDigit = r.RequireDigit || o.RequireDigit
+	r.RequireSymbol = r.RequireSymbol || o.RequireSymbol
+	r.CheckDictionary = r.CheckDictionary || o.CheckDictionary
+	r.CheckUsername = r.CheckUsername || o.CheckUsername
+	r.HistoryCount = max(r.HistoryCount, o.HistoryCount)
+	r.MinAgeHours = max(r.MinAgeHours, o.MinAgeHours)
+	if r.MaxAgeDays == 0 || (o.MaxAgeDays > 0 && o.MaxAgeDays < r.MaxAgeDays) {
+		r.MaxAgeDays = o.MaxAgeDays
+	}
+	return r
+}
+
+// ExpiresAt returns when a password changed at the given time expires, or
+// nil if passwords do not expire.
+func (r PasswordRules) ExpiresAt(changedAt time.Time) *time.Time {
+	if r.MaxAgeDays <= 0 {
+		return nil
+	}
+	t := changedAt.AddDate(0, 0, r.MaxAgeDays)
+	return &t
+}
+
+// PasswordPolicy is a named set of password rules. The built-in default
+// policy applies to every user; other policies apply to the direct members
+// of their groups.
+type PasswordPolicy struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Builtin     bool      `json:"builtin"`
+	PasswordRules
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Groups    []*Group  `json:"groups,omitempty"`
+}
+
+// CreatePasswordPolicyInput holds input for creating a password policy.
+type CreatePasswordPolicyInput struct {
+	Name        string
+	Description string
+	Rules       PasswordRules
+}
+
+// UpdatePasswordPolicyInput holds input for updating a password policy.
+// Nil fields are left unchanged.
+type UpdatePasswordPolicyInput struct {
+	Name             *string
+	Description      *string
+	MinLength        *int
+	RequireUppercase *bool
+	RequireLowercase *bool
+	RequireDigit     *bool
+	RequireSymbol    *bool
+	CheckDictionary  *bool
+	CheckUsername    *bool
+	HistoryCount     *int
+	MinAgeHours      *int
+	MaxAgeDays       *int
+}
+
+// Apply returns the rules with the input's rule changes applied.
+func (in UpdatePasswordPolicyInput) Apply(r PasswordRules) PasswordRules {
+	setInt := func(dst *int, v *int) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	setBool := func(dst *bool, v *bool) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	setInt(&r.MinLength, in.MinLength)
+	setBool(&r.RequireUppercase, in.RequireUppercase)
+	setBool(&r.RequireLowercase, in.RequireLowercase)
+	setBool(&r.RequireDigit, in.RequireDigit)
+	setBool(&r.RequireSymbol, in.RequireSymbol)
+	setBool(&r.CheckDictionary, in.CheckDictionary)
+	setBool(&r.CheckUsername, in.CheckUsername)
+	setInt(&r.HistoryCount, in.HistoryCount)
+	setInt(&r.MinAgeHours, in.MinAgeHours)
+	setInt(&r.MaxAgeDays, in.MaxAgeDays)
+	return r
+}
+
+// PasswordViolation describes one way a password fails its policy.
+type PasswordViolation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PasswordStatus describes a user's password with respect to the policies
+// that apply to them. PassThrough names the upstream directory that
+// verifies the password, if any, in which case it does not expire here.
+type PasswordStatus struct {
+	Rules       PasswordRules `json:"rules"`
+	ChangedAt   time.Time     `json:"changed_at"`
+	ExpiresAt   *time.Time    `json:"expires_at,omitempty"`
+	MustChange  bool          `json:"must_change"`
+	Expired     bool          `json:"expired"`
+	PassThrough string        `json:"pass_through,omitempty"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetPolicy controls the emailed password reset links.
+type PasswordResetPolicy struct {
+	// URL is the page reset links lead to; the token is added as its
+	// "token" query parameter.
+	URL string
+	// TokenTTL is how long a link stays valid.
+	TokenTTL time.Duration
+	// UserLimit is the number of links sent to one user within Window.
+	// Further forgot-password requests for the user are dropped silently;
+	// zero means no limit.
+	UserLimit int
+	// IPLimit is the number of forgot-password requests accepted from one
+	// IP address within Window; zero means no limit.
+	IPLimit int
+	Window  time.Duration
+}
+
+// PasswordResetToken is an emailed password reset link. Only the hash of
+// its token is stored.
+type PasswordResetToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	IPAddress string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// Valid reports whether the link can still be used at now.
+func (t *PasswordResetToken) Valid(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProvisioningConnector provisions users and groups to an application's
+// SCIM endpoint. Users are provisioned unless pending, or only the direct
+// members of ScopeGroupID; groups, if SyncGroups, with the provisioned
+// users among their members. AttributeMapping maps the names of user
+// extension attributes to the SCIM attribute paths they are sent as.
+// The token authenticating to the application is never returned.
+type ProvisioningConnector struct {
+	ID               uuid.UUID          `json:"id"`
+	Name             string             `json:"name"`
+	URL              string             `json:"url"`
+	Token            string             `json:"-"`
+	ScopeGroupID     *uuid.UUID         `json:"scope_group_id,omitempty"`
+	SyncGroups       bool               `json:"sync_groups"`
+	AttributeMapping map[string]string  `json:"attribute_mapping,omitempty"`
+	Enabled          bool               `json:"enabled"`
+	Status           ProvisioningStatus `json:"status"`
+	// EventCursor is the time of the latest change synchronized.
+	EventCursor *time.Time `json:"-"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ProvisioningStatus is how a connector's synchronization stands.
+// LastError is the error that stopped the last synchronization, or how
+// many objects failed in it; see the objects for their errors.
+type ProvisioningStatus struct {
+	LastSyncAt     *time.Time `json:"last_sync_at,omitempty"`
+	LastFullSyncAt *time.Time `json:"last_full_sync_at,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	Users          int        `json:"users"`
+	Groups         int        `json:"groups"`
+	Failed         int        `json:"failed"`
+}
+
+// CreateConnectorInput holds input for creating a provisioning connector.
+type CreateConnectorInput struct {
+	Name             string
+	URL              string
+	Token            string
+	ScopeGroupID     *uuid.UUID
+	SyncGroups       bool
+	AttributeMapping map[string]string
+}
+
+// UpdateConnectorInput holds input for updating a provisioning connector.
+// Nil fields are left unchanged; ClearScope provisions every user again.
+type UpdateConnectorInput struct {
+	Name             *string
+	URL              *string
+	Token            *string
+	ScopeGroupID     *uuid.UUID
+	ClearScope       bool
+	SyncGroups       *bool
+	AttributeMapping map[string]string
+	Enabled          *bool
+}
+
+// ProvisionedResourceType is the type of a provisioned object.
+type ProvisionedResourceType string
+
+const (
+	ProvisionedUser  ProvisionedResourceType = "user"
+	ProvisionedGroup ProvisionedResourceType = "group"
+)
+
+// ProvisionedObject links a user or group to the resource a connector
+// provisioned for it. RemoteID is empty until the resource is created, and
+// LastError is set while the object fails to synchronize.
+type ProvisionedObject struct {
+	ID           uuid.UUID               `json:"id"`
+	ConnectorID  uuid.UUID               `json:"connector_id"`
+	ResourceType ProvisionedResourceType `json:"resource_type"`
+	LocalID      uuid.UUID               `json:"local_id"`
+	RemoteID     string                  `json:"remote_id,omitempty"`
+	Hash         string                  `json:"-"`
+	LastError    string                  `json:"last_error,omitempty"`
+	SyncedAt     *time.Time              `json:"synced_at,omitempty"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+}
+
+// ListProvisionedObjectsInput holds input for listing the objects of a
+// connector, optionally of one resource type or only those failing.
+type ListProvisionedObjectsInput struct {
+	ResourceType ProvisionedResourceType
+	FailedOnly   bool
+	Page         int
+	PageSize     int
+}
+
+// ProvisioningSyncResult counts what a synchronization did. Full
+// synchronizations compare every resource with the application's.
+type ProvisioningSyncResult struct {
+	Full      bool `json:"full"`
+	Created   int  `json:"created"`
+	Updated   int  `json:"updated"`
+	Deleted   int  `json:"deleted"`
+	Unchanged int  `json:"unchanged"`
+	Failed    int  `json:"failed"`
+}
+
+// ProvisioningPolicy controls the synchronization of connectors.
+type ProvisioningPolicy struct {
+	// Timeout bounds each request to an application.
+	Timeout time.Duration
+	// PollInterval is how often changes are looked for.
+	PollInterval time.Duration
+	// ReconcileInterval is how often each connector is fully synchronized,
+	// correcting changes made in the application and retrying failures.
+	ReconcileInterval time.Duration
+}
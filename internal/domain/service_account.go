@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceAccount is a machine identity used by automation such as HR sync or
+// CI jobs. It authenticates with API keys and holds permissions directly.
+type ServiceAccount struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	Disabled    bool      `json:"disabled"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	APIKeys     []*APIKey `json:"api_keys,omitempty"`
+}
+
+// APIKey is a credential of a service account. The key itself is only shown
+// when it is created or rotated; Prefix identifies it afterwards.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Expired reports whether the key has expired at the given time.
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// CreateServiceAccountInput holds input for creating a service account.
+type CreateServiceAccountInput struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// UpdateServiceAccountInput holds input for updating a service account.
+// A nil Permissions leaves the permissions unchanged.
+type UpdateServiceAccountInput struct {
+	Description *string
+	Permissions []string
+	Disabled    *bool
+}
+
+// CreateAPIKeyInput holds input for creating an API key.
+// A nil ExpiresAt creates a key that does not expire.
+type CreateAPIKeyInput struct {
+	Name      string
+	ExpiresAt *time.Time
+}
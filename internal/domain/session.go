@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a login session backing a refresh token. Access tokens carry the
+// session ID so revoking the session invalidates them immediately.
+type Session struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Active reports whether the session can still be used at the given time.
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// Protocols recorded in RequestInfo.
+const (
+	ProtocolHTTP = "http"
+	ProtocolLDAP = "ldap"
+)
+
+// RequestInfo describes the client that issued a request. RequestID
+// identifies the request in the logs and the audit trail.
+type RequestInfo struct {
+	Protocol  string
+	IPAddress string
+	UserAgent string
+	RequestID string
+}
@@ -6,49 +6,104 @@ import (
 	"github.com/google/uuid"
 )
 
-// UserStatus represents the account status of a user.
+// UserStatus represents the account status of a user. Pending users were
+// invited and cannot log in until they accept the invitation.
 type UserStatus string
 
 const (
 	UserStatusEnabled  UserStatus = "enabled"
 	UserStatusDisabled UserStatus = "disabled"
+	UserStatusPending  UserStatus = "pending"
 )
 
 // User represents a user in the system.
 type User struct {
-	ID           uuid.UUID  `json:"id"`
-	Username     string     `json:"username"`
-	DisplayName  string     `json:"display_name"`
-	Email        string     `json:"email"`
-	PasswordHash string     `json:"-"`
-	Phone        string     `json:"phone"`
-	Status       UserStatus `json:"status"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
-	Groups       []*Group   `json:"groups,omitempty"`
-}
-
-// CreateUserInput holds input for creating a new user.
+	ID                 uuid.UUID           `json:"id"`
+	Username           string              `json:"username"`
+	DisplayName        string              `json:"display_name"`
+	Email              string              `json:"email"`
+	PasswordHash       string              `json:"-"`
+	PasswordChangedAt  time.Time           `json:"password_changed_at"`
+	MustChangePassword bool                `json:"must_change_password"`
+	AccountExpiresAt   *time.Time          `json:"account_expires_at,omitempty"`
+	Phone              string              `json:"phone"`
+	Status             UserStatus          `json:"status"`
+	ManagerID          *uuid.UUID          `json:"manager_id,omitempty"`
+	MFAEnabled         bool                `json:"mfa_enabled"`
+	TOTPSecret         string              `json:"-"`
+	TOTPLastStep       int64               `json:"-"`
+	FailedLoginCount   int                 `json:"failed_login_count"`
+	LastFailedLoginAt  *time.Time          `json:"last_failed_login_at,omitempty"`
+	LockoutCount       int                 `json:"-"`
+	LockedAt           *time.Time          `json:"locked_at,omitempty"`
+	LockedUntil        *time.Time          `json:"locked_until,omitempty"`
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+	Groups             []*Group            `json:"groups,omitempty"`
+	Attributes         map[string][]string `json:"attributes,omitempty"`
+}
+
+// Locked reports whether the account is locked out at the given time.
+func (u *User) Locked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// Expired reports whether the account has expired at the given time.
+func (u *User) Expired(now time.Time) bool {
+	return u.AccountExpiresAt != nil && !now.Before(*u.AccountExpiresAt)
+}
+
+// CreateUserInput holds input for creating a new user. PasswordHash imports
+// an existing hash, such as {SSHA}..., instead of setting Password.
+// MustChangePassword makes the user choose a new password at first login;
+// after AccountExpiresAt, if set, the user can no longer log in. NoPassword
+// creates a user without any password, such as one provisioned by an
+// identity provider, who can log in only after a password reset.
 type CreateUserInput struct {
-	Username    string
-	DisplayName string
-	Email       string
-	Password    string
-	Phone       string
+	Username           string
+	DisplayName        string
+	Email              string
+	Password           string
+	PasswordHash       string
+	NoPassword         bool
+	Phone              string
+	Attributes         map[string][]string
+	MustChangePassword bool
+	AccountExpiresAt   *time.Time
 }
 
 // UpdateUserInput holds input for updating an existing user.
+// Attributes replaces the values of each listed extension attribute;
+// an empty slice removes the attribute. ClearManager removes the manager
+// and takes precedence over ManagerID; ClearAccountExpiry likewise makes the
+// account never expire.
 type UpdateUserInput struct {
-	DisplayName *string
-	Email       *string
-	Phone       *string
+	DisplayName        *string
+	Email              *string
+	Phone              *string
+	ManagerID          *uuid.UUID
+	ClearManager       bool
+	AccountExpiresAt   *time.Time
+	ClearAccountExpiry bool
+	Attributes         map[string][]string
 }
 
 // ListUsersInput holds parameters for listing users.
+// Attributes filters by exact value of indexed extension attributes.
 type ListUsersInput struct {
-	Page     int
-	PageSize int
-	Search   string
+	Page       int
+	PageSize   int
+	Search     string
+	Attributes map[string]string
+}
+
+// UserFilter selects the users with one of IDs, if any, whose other fields
+// equal the set ones, ignoring case. A zero UserFilter selects all users.
+type UserFilter struct {
+	IDs         []uuid.UUID
+	Username    string
+	DisplayName string
+	Email       string
 }
 
 // ListResult holds a paginated list of items.
@@ -58,3 +113,17 @@ type ListResult[T any] struct {
 	Page     int  `json:"page"`
 	PageSize int  `json:"page_size"`
 }
+
+// OrgNode is a user together with their direct reports, recursively.
+type OrgNode struct {
+	User    *User      `json:"user"`
+	Reports []*OrgNode `json:"reports,omitempty"`
+}
+
+// OrgChart describes a user's position in the reporting hierarchy.
+// Chain lists managers from the direct manager up to the top.
+type OrgChart struct {
+	User    *User      `json:"user"`
+	Chain   []*User    `json:"chain"`
+	Reports []*OrgNode `json:"reports"`
+}
@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types delivered to webhook subscribers. Every change to a user
+// produces user.updated; a change of status also produces user.enabled or
+// user.disabled.
+const (
+	EventUserCreated        = "user.created"
+	EventUserUpdated        = "user.updated"
+	EventUserEnabled        = "user.enabled"
+	EventUserDisabled       = "user.disabled"
+	EventUserDeleted        = "user.deleted"
+	EventGroupCreated       = "group.created"
+	EventGroupUpdated       = "group.updated"
+	EventGroupDeleted       = "group.deleted"
+	EventGroupMemberAdded   = "group.member.added"
+	EventGroupMemberRemoved = "group.member.removed"
+)
+
+// KnownEventTypes lists every event type a subscription may filter on.
+var KnownEventTypes = []string{
+	EventUserCreated,
+	EventUserUpdated,
+	EventUserEnabled,
+	EventUserDisabled,
+	EventUserDeleted,
+	EventGroupCreated,
+	EventGroupUpdated,
+	EventGroupDeleted,
+	EventGroupMemberAdded,
+	EventGroupMemberRemoved,
+}
+
+// UserEventData is the data of user events: the user as of the change and,
+// for updates, as they were before it.
+type UserEventData struct {
+	User     *UserVersion `json:"user"`
+	Previous *UserVersion `json:"previous,omitempty"`
+}
+
+// GroupEventData is UserEventData for groups.
+type GroupEventData struct {
+	Group    *GroupVersion `json:"group"`
+	Previous *GroupVersion `json:"previous,omitempty"`
+}
+
+// MembershipEventData is the data of group member events.
+type MembershipEventData struct {
+	GroupID   uuid.UUID  `json:"group_id"`
+	GroupName string     `json:"group_name"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Username  string     `json:"username"`
+	ActorID   *uuid.UUID `json:"actor_id,omitempty"`
+	ActorName string     `json:"actor_name,omitempty"`
+}
+
+// WebhookEvent is the JSON body of a webhook request. ID identifies the
+// event, so that receivers can ignore redeliveries.
+type WebhookEvent struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+// WebhookSubscription receives the events whose types match its filters.
+// The secret signs the requests; it is only shown when the subscription is
+// created.
+type WebhookSubscription struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Enabled    bool      `json:"enabled"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Matches reports whether the subscription filters select an event type.
+// A filter is an event type, a prefix ending in ".*" such as "user.*", or
+// "*" for every event.
+func (s *WebhookSubscription) Matches(eventType string) bool {
+	for _, f := range s.EventTypes {
+		if f == "*" || f == eventType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(f, "*"); ok && strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateWebhookInput holds input for creating a webhook subscription.
+// An empty Secret has one generated.
+type CreateWebhookInput struct {
+	Name       string
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+// UpdateWebhookInput holds input for updating a webhook subscription.
+// Nil fields are left unchanged.
+type UpdateWebhookInput struct {
+	Name       *string
+	URL        *string
+	Secret     *string
+	EventTypes []string
+	Enabled    *bool
+}
+
+// WebhookDeliveryStatus is where a delivery stands. Pending deliveries are
+// retried with backoff until delivered or, after the last attempt, dead.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookPending   WebhookDeliveryStatus = "pending"
+	WebhookDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDead      WebhookDeliveryStatus = "dead"
+)
+
+// WebhookDelivery is one event sent, or to be sent, to one subscription.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	SubscriptionID uuid.UUID             `json:"subscription_id"`
+	EventID        uuid.UUID             `json:"event_id"`
+	EventType      string                `json:"event_type"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	LastStatusCode int                   `json:"last_status_code,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+// WebhookAttempt is the outcome of a delivery attempt, which sets the
+// delivery's status and when it is next attempted.
+type WebhookAttempt struct {
+	Status        WebhookDeliveryStatus
+	StatusCode    int
+	Error         string
+	NextAttemptAt time.Time
+}
+
+// WebhookPolicy controls the delivery of webhooks.
+type WebhookPolicy struct {
+	// MaxAttempts is how many times a delivery is attempted before it is
+	// dead-lettered.
+	MaxAttempts int
+	// Timeout bounds each attempt.
+	Timeout time.Duration
+	// RetryBackoff is the delay before the first retry; it doubles with
+	// each further retry up to MaxBackoff.
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+	// PollInterval is how often the dispatcher looks for new events and
+	// due retries.
+	PollInterval time.Duration
+	// Retention is how long dispatched events and delivered or
+	// dead-lettered deliveries are kept.
+	Retention time.Duration
+}
+
+// Backoff returns the delay before retrying a delivery that has failed the
+// given number of attempts.
+func (p WebhookPolicy) Backoff(attempts int) time.Duration {
+	d := p.RetryBackoff
+	for i := 1; i < attempts && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, p.MaxBackoff)
+}
+
+// DueWebhook is a delivery claimed for an attempt, with what is needed to
+// make it: the subscription, including its secret, and the event.
+type DueWebhook struct {
+	Delivery     *WebhookDelivery
+	Subscription *WebhookSubscription
+	Event        *WebhookEvent
+}
@@ -0,0 +1,208 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/attributedefinition"
+)
+
+// AttributeDefinition is the model entity for the AttributeDefinition schema.
+type AttributeDefinition struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Target holds the value of the "target" field.
+	Target attributedefinition.Target `json:"target,omitempty"`
+	// Type holds the value of the "type" field.
+	Type attributedefinition.Type `json:"type,omitempty"`
+	// MultiValued holds the value of the "multi_valued" field.
+	MultiValued bool `json:"multi_valued,omitempty"`
+	// LdapName holds the value of the "ldap_name" field.
+	LdapName string `json:"ldap_name,omitempty"`
+	// Description holds the value of the "description" field.
+	Description string `json:"description,omitempty"`
+	// Required holds the value of the "required" field.
+	Required bool `json:"required,omitempty"`
+	// Indexed holds the value of the "indexed" field.
+	Indexed bool `json:"indexed,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*AttributeDefinition) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case attributedefinition.FieldMultiValued, attributedefinition.FieldRequired, attributedefinition.FieldIndexed:
+			values[i] = new(sql.NullBool)
+		case attributedefinition.FieldName, attributedefinition.FieldTarget, attributedefinition.FieldType, attributedefinition.FieldLdapName, attributedefinition.FieldDescription:
+			values[i] = new(sql.NullString)
+		case attributedefinition.FieldCreatedAt, attributedefinition.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case attributedefinition.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the AttributeDefinition fields.
+func (_m *AttributeDefinition) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case attributedefinition.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case attributedefinition.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case attributedefinition.FieldTarget:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field target", values[i])
+			} else if value.Valid {
+				_m.Target = attributedefinition.Target(value.String)
+			}
+		case attributedefinition.FieldType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field type", values[i])
+			} else if value.Valid {
+				_m.Type = attributedefinition.Type(value.String)
+			}
+		case attributedefinition.FieldMultiValued:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field multi_valued", values[i])
+			} else if value.Valid {
+				_m.MultiValued = value.Bool
+			}
+		case attributedefinition.FieldLdapName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field ldap_name", values[i])
+			} else if value.Valid {
+				_m.LdapName = value.String
+			}
+		case attributedefinition.FieldDescription:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field description", values[i])
+			} else if value.Valid {
+				_m.Description = value.String
+			}
+		case attributedefinition.FieldRequired:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field required", values[i])
+			} else if value.Valid {
+				_m.Required = value.Bool
+			}
+		case attributedefinition.FieldIndexed:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field indexed", values[i])
+			} else if value.Valid {
+				_m.Indexed = value.Bool
+			}
+		case attributedefinition.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case attributedefinition.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the AttributeDefinition.
+// This includes values selected through modifiers, order, etc.
+func (_m *AttributeDefinition) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this AttributeDefinition.
+// Note that you need to call AttributeDefinition.Unwrap() before calling this method if this AttributeDefinition
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *AttributeDefinition) Update() *AttributeDefinitionUpdateOne {
+	return NewAttributeDefinitionClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the AttributeDefinition entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *AttributeDefinition) Unwrap() *AttributeDefinition {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: AttributeDefinition is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *AttributeDefinition) String() string {
+	var builder strings.Builder
+	builder.WriteString("AttributeDefinition(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("target=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Target))
+	builder.WriteString(", ")
+	builder.WriteString("type=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Type))
+	builder.WriteString(", ")
+	builder.WriteString("multi_valued=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MultiValued))
+	builder.WriteString(", ")
+	builder.WriteString("ldap_name=")
+	builder.WriteString(_m.LdapName)
+	builder.WriteString(", ")
+	builder.WriteString("description=")
+	builder.WriteString(_m.Description)
+	builder.WriteString(", ")
+	builder.WriteString("required=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Required))
+	builder.WriteString(", ")
+	builder.WriteString("indexed=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Indexed))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// AttributeDefinitions is a parsable slice of AttributeDefinition.
+type AttributeDefinitions []*AttributeDefinition
@@ -0,0 +1,196 @@
+// Code generated by ent, DO NOT EDIT.
+
+package attributedefinition
+
+import (
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the attributedefinition type in the database.
+	Label = "attribute_definition"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldTarget holds the string denoting the target field in the database.
+	FieldTarget = "target"
+	// FieldType holds the string denoting the type field in the database.
+	FieldType = "type"
+	// FieldMultiValued holds the string denoting the multi_valued field in the database.
+	FieldMultiValued = "multi_valued"
+	// FieldLdapName holds the string denoting the ldap_name field in the database.
+	FieldLdapName = "ldap_name"
+	// FieldDescription holds the string denoting the description field in the database.
+	FieldDescription = "description"
+	// FieldRequired holds the string denoting the required field in the database.
+	FieldRequired = "required"
+	// FieldIndexed holds the string denoting the indexed field in the database.
+	FieldIndexed = "indexed"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// Table holds the table name of the attributedefinition in the database.
+	Table = "attribute_definitions"
+)
+
+// Columns holds all SQL columns for attributedefinition fields.
+var Columns = []string{
+	FieldID,
+	FieldName,
+	FieldTarget,
+	FieldType,
+	FieldMultiValued,
+	FieldLdapName,
+	FieldDescription,
+	FieldRequired,
+	FieldIndexed,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// NameValidator is a validator for the "name" field. It is called by the builders before save.
+	NameValidator func(string) error
+	// DefaultMultiValued holds the default value on creation for the "multi_valued" field.
+	DefaultMultiValued bool
+	// LdapNameValidator is a validator for the "ldap_name" field. It is called by the builders before save.
+	LdapNameValidator func(string) error
+	// DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
+	DescriptionValidator func(string) error
+	// DefaultRequired holds the default value on creation for the "required" field.
+	DefaultRequired bool
+	// DefaultIndexed holds the default value on creation for the "indexed" field.
+	DefaultIndexed bool
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// Target defines the type for the "target" enum field.
+type Target string
+
+// Target values.
+const (
+	TargetUser  Target = "user"
+	TargetGroup Target = "group"
+)
+
+func (t Target) String() string {
+	return string(t)
+}
+
+// TargetValidator is a validator for the "target" field enum values. It is called by the builders before save.
+func TargetValidator(t Target) error {
+	switch t {
+	case TargetUser, TargetGroup:
+		return nil
+	default:
+		return fmt.Errorf("attributedefinition: invalid enum value for target field: %q", t)
+	}
+}
+
+// Type defines the type for the "type" enum field.
+type Type string
+
+// TypeString is the default value of the Type enum.
+const DefaultType = TypeString
+
+// Type values.
+const (
+	TypeString  Type = "string"
+	TypeInteger Type = "integer"
+	TypeBoolean Type = "boolean"
+)
+
+func (_type Type) String() string {
+	return string(_type)
+}
+
+// TypeValidator is a validator for the "type" field enum values. It is called by the builders before save.
+func TypeValidator(_type Type) error {
+	switch _type {
+	case TypeString, TypeInteger, TypeBoolean:
+		return nil
+	default:
+		return fmt.Errorf("attributedefinition: invalid enum value for type field: %q", _type)
+	}
+}
+
+// OrderOption defines the ordering options for the AttributeDefinition queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByName orders the results by the name field.
+func ByName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldName, opts...).ToFunc()
+}
+
+// ByTarget orders the results by the target field.
+func ByTarget(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTarget, opts...).ToFunc()
+}
+
+// ByType orders the results by the type field.
+func ByType(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldType, opts...).ToFunc()
+}
+
+// ByMultiValued orders the results by the multi_valued field.
+func ByMultiValued(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMultiValued, opts...).ToFunc()
+}
+
+// ByLdapName orders the results by the ldap_name field.
+func ByLdapName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLdapName, opts...).ToFunc()
+}
+
+// ByDescription orders the results by the description field.
+func ByDescription(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDescription, opts...).ToFunc()
+}
+
+// ByRequired orders the results by the required field.
+func ByRequired(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRequired, opts...).ToFunc()
+}
+
+// ByIndexed orders the results by the indexed field.
+func ByIndexed(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldIndexed, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
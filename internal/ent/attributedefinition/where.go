@@ -0,0 +1,466 @@
+// Code generated by ent, DO NOT EDIT.
+
+package attributedefinition
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLTE(FieldID, id))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldName, v))
+}
+
+// MultiValued applies equality check predicate on the "multi_valued" field. It's identical to MultiValuedEQ.
+func MultiValued(v bool) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldMultiValued, v))
+}
+
+// LdapName applies equality check predicate on the "ldap_name" field. It's identical to LdapNameEQ.
+func LdapName(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldLdapName, v))
+}
+
+// Description applies equality check predicate on the "description" field. It's identical to DescriptionEQ.
+func Description(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldDescription, v))
+}
+
+// Required applies equality check predicate on the "required" field. It's identical to RequiredEQ.
+func Required(v bool) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldRequired, v))
+}
+
+// Indexed applies equality check predicate on the "indexed" field. It's identical to IndexedEQ.
+func Indexed(v bool) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldIndexed, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldContainsFold(FieldName, v))
+}
+
+// TargetEQ applies the EQ predicate on the "target" field.
+func TargetEQ(v Target) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldTarget, v))
+}
+
+// TargetNEQ applies the NEQ predicate on the "target" field.
+func TargetNEQ(v Target) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNEQ(FieldTarget, v))
+}
+
+// TargetIn applies the In predicate on the "target" field.
+func TargetIn(vs ...Target) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldIn(FieldTarget, vs...))
+}
+
+// TargetNotIn applies the NotIn predicate on the "target" field.
+func TargetNotIn(vs ...Target) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNotIn(FieldTarget, vs...))
+}
+
+// TypeEQ applies the EQ predicate on the "type" field.
+func TypeEQ(v Type) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldType, v))
+}
+
+// TypeNEQ applies the NEQ predicate on the "type" field.
+func TypeNEQ(v Type) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNEQ(FieldType, v))
+}
+
+// TypeIn applies the In predicate on the "type" field.
+func TypeIn(vs ...Type) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldIn(FieldType, vs...))
+}
+
+// TypeNotIn applies the NotIn predicate on the "type" field.
+func TypeNotIn(vs ...Type) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNotIn(FieldType, vs...))
+}
+
+// MultiValuedEQ applies the EQ predicate on the "multi_valued" field.
+func MultiValuedEQ(v bool) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldMultiValued, v))
+}
+
+// MultiValuedNEQ applies the NEQ predicate on the "multi_valued" field.
+func MultiValuedNEQ(v bool) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNEQ(FieldMultiValued, v))
+}
+
+// LdapNameEQ applies the EQ predicate on the "ldap_name" field.
+func LdapNameEQ(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldLdapName, v))
+}
+
+// LdapNameNEQ applies the NEQ predicate on the "ldap_name" field.
+func LdapNameNEQ(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNEQ(FieldLdapName, v))
+}
+
+// LdapNameIn applies the In predicate on the "ldap_name" field.
+func LdapNameIn(vs ...string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldIn(FieldLdapName, vs...))
+}
+
+// LdapNameNotIn applies the NotIn predicate on the "ldap_name" field.
+func LdapNameNotIn(vs ...string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNotIn(FieldLdapName, vs...))
+}
+
+// LdapNameGT applies the GT predicate on the "ldap_name" field.
+func LdapNameGT(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGT(FieldLdapName, v))
+}
+
+// LdapNameGTE applies the GTE predicate on the "ldap_name" field.
+func LdapNameGTE(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGTE(FieldLdapName, v))
+}
+
+// LdapNameLT applies the LT predicate on the "ldap_name" field.
+func LdapNameLT(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLT(FieldLdapName, v))
+}
+
+// LdapNameLTE applies the LTE predicate on the "ldap_name" field.
+func LdapNameLTE(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLTE(FieldLdapName, v))
+}
+
+// LdapNameContains applies the Contains predicate on the "ldap_name" field.
+func LdapNameContains(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldContains(FieldLdapName, v))
+}
+
+// LdapNameHasPrefix applies the HasPrefix predicate on the "ldap_name" field.
+func LdapNameHasPrefix(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldHasPrefix(FieldLdapName, v))
+}
+
+// LdapNameHasSuffix applies the HasSuffix predicate on the "ldap_name" field.
+func LdapNameHasSuffix(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldHasSuffix(FieldLdapName, v))
+}
+
+// LdapNameEqualFold applies the EqualFold predicate on the "ldap_name" field.
+func LdapNameEqualFold(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEqualFold(FieldLdapName, v))
+}
+
+// LdapNameContainsFold applies the ContainsFold predicate on the "ldap_name" field.
+func LdapNameContainsFold(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldContainsFold(FieldLdapName, v))
+}
+
+// DescriptionEQ applies the EQ predicate on the "description" field.
+func DescriptionEQ(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldDescription, v))
+}
+
+// DescriptionNEQ applies the NEQ predicate on the "description" field.
+func DescriptionNEQ(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNEQ(FieldDescription, v))
+}
+
+// DescriptionIn applies the In predicate on the "description" field.
+func DescriptionIn(vs ...string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldIn(FieldDescription, vs...))
+}
+
+// DescriptionNotIn applies the NotIn predicate on the "description" field.
+func DescriptionNotIn(vs ...string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNotIn(FieldDescription, vs...))
+}
+
+// DescriptionGT applies the GT predicate on the "description" field.
+func DescriptionGT(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGT(FieldDescription, v))
+}
+
+// DescriptionGTE applies the GTE predicate on the "description" field.
+func DescriptionGTE(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGTE(FieldDescription, v))
+}
+
+// DescriptionLT applies the LT predicate on the "description" field.
+func DescriptionLT(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLT(FieldDescription, v))
+}
+
+// DescriptionLTE applies the LTE predicate on the "description" field.
+func DescriptionLTE(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLTE(FieldDescription, v))
+}
+
+// DescriptionContains applies the Contains predicate on the "description" field.
+func DescriptionContains(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldContains(FieldDescription, v))
+}
+
+// DescriptionHasPrefix applies the HasPrefix predicate on the "description" field.
+func DescriptionHasPrefix(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldHasPrefix(FieldDescription, v))
+}
+
+// DescriptionHasSuffix applies the HasSuffix predicate on the "description" field.
+func DescriptionHasSuffix(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldHasSuffix(FieldDescription, v))
+}
+
+// DescriptionIsNil applies the IsNil predicate on the "description" field.
+func DescriptionIsNil() predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldIsNull(FieldDescription))
+}
+
+// DescriptionNotNil applies the NotNil predicate on the "description" field.
+func DescriptionNotNil() predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNotNull(FieldDescription))
+}
+
+// DescriptionEqualFold applies the EqualFold predicate on the "description" field.
+func DescriptionEqualFold(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEqualFold(FieldDescription, v))
+}
+
+// DescriptionContainsFold applies the ContainsFold predicate on the "description" field.
+func DescriptionContainsFold(v string) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldContainsFold(FieldDescription, v))
+}
+
+// RequiredEQ applies the EQ predicate on the "required" field.
+func RequiredEQ(v bool) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldRequired, v))
+}
+
+// RequiredNEQ applies the NEQ predicate on the "required" field.
+func RequiredNEQ(v bool) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNEQ(FieldRequired, v))
+}
+
+// IndexedEQ applies the EQ predicate on the "indexed" field.
+func IndexedEQ(v bool) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldIndexed, v))
+}
+
+// IndexedNEQ applies the NEQ predicate on the "indexed" field.
+func IndexedNEQ(v bool) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNEQ(FieldIndexed, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.AttributeDefinition) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.AttributeDefinition) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.AttributeDefinition) predicate.AttributeDefinition {
+	return predicate.AttributeDefinition(sql.NotPredicates(p))
+}
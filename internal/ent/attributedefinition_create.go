@@ -0,0 +1,433 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/attributedefinition"
+)
+
+// AttributeDefinitionCreate is the builder for creating a AttributeDefinition entity.
+type AttributeDefinitionCreate struct {
+	config
+	mutation *AttributeDefinitionMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (_c *AttributeDefinitionCreate) SetName(v string) *AttributeDefinitionCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetTarget sets the "target" field.
+func (_c *AttributeDefinitionCreate) SetTarget(v attributedefinition.Target) *AttributeDefinitionCreate {
+	_c.mutation.SetTarget(v)
+	return _c
+}
+
+// SetType sets the "type" field.
+func (_c *AttributeDefinitionCreate) SetType(v attributedefinition.Type) *AttributeDefinitionCreate {
+	_c.mutation.SetType(v)
+	return _c
+}
+
+// SetNillableType sets the "type" field if the given value is not nil.
+func (_c *AttributeDefinitionCreate) SetNillableType(v *attributedefinition.Type) *AttributeDefinitionCreate {
+	if v != nil {
+		_c.SetType(*v)
+	}
+	return _c
+}
+
+// SetMultiValued sets the "multi_valued" field.
+func (_c *AttributeDefinitionCreate) SetMultiValued(v bool) *AttributeDefinitionCreate {
+	_c.mutation.SetMultiValued(v)
+	return _c
+}
+
+// SetNillableMultiValued sets the "multi_valued" field if the given value is not nil.
+func (_c *AttributeDefinitionCreate) SetNillableMultiValued(v *bool) *AttributeDefinitionCreate {
+	if v != nil {
+		_c.SetMultiValued(*v)
+	}
+	return _c
+}
+
+// SetLdapName sets the "ldap_name" field.
+func (_c *AttributeDefinitionCreate) SetLdapName(v string) *AttributeDefinitionCreate {
+	_c.mutation.SetLdapName(v)
+	return _c
+}
+
+// SetDescription sets the "description" field.
+func (_c *AttributeDefinitionCreate) SetDescription(v string) *AttributeDefinitionCreate {
+	_c.mutation.SetDescription(v)
+	return _c
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_c *AttributeDefinitionCreate) SetNillableDescription(v *string) *AttributeDefinitionCreate {
+	if v != nil {
+		_c.SetDescription(*v)
+	}
+	return _c
+}
+
+// SetRequired sets the "required" field.
+func (_c *AttributeDefinitionCreate) SetRequired(v bool) *AttributeDefinitionCreate {
+	_c.mutation.SetRequired(v)
+	return _c
+}
+
+// SetNillableRequired sets the "required" field if the given value is not nil.
+func (_c *AttributeDefinitionCreate) SetNillableRequired(v *bool) *AttributeDefinitionCreate {
+	if v != nil {
+		_c.SetRequired(*v)
+	}
+	return _c
+}
+
+// SetIndexed sets the "indexed" field.
+func (_c *AttributeDefinitionCreate) SetIndexed(v bool) *AttributeDefinitionCreate {
+	_c.mutation.SetIndexed(v)
+	return _c
+}
+
+// SetNillableIndexed sets the "indexed" field if the given value is not nil.
+func (_c *AttributeDefinitionCreate) SetNillableIndexed(v *bool) *AttributeDefinitionCreate {
+	if v != nil {
+		_c.SetIndexed(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *AttributeDefinitionCreate) SetCreatedAt(v time.Time) *AttributeDefinitionCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *AttributeDefinitionCreate) SetNillableCreatedAt(v *time.Time) *AttributeDefinitionCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *AttributeDefinitionCreate) SetUpdatedAt(v time.Time) *AttributeDefinitionCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *AttributeDefinitionCreate) SetNillableUpdatedAt(v *time.Time) *AttributeDefinitionCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *AttributeDefinitionCreate) SetID(v uuid.UUID) *AttributeDefinitionCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *AttributeDefinitionCreate) SetNillableID(v *uuid.UUID) *AttributeDefinitionCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the AttributeDefinitionMutation object of the builder.
+func (_c *AttributeDefinitionCreate) Mutation() *AttributeDefinitionMutation {
+	return _c.mutation
+}
+
+// Save creates the AttributeDefinition in the database.
+func (_c *AttributeDefinitionCreate) Save(ctx context.Context) (*AttributeDefinition, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *AttributeDefinitionCreate) SaveX(ctx context.Context) *AttributeDefinition {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AttributeDefinitionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AttributeDefinitionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *AttributeDefinitionCreate) defaults() {
+	if _, ok := _c.mutation.GetType(); !ok {
+		v := attributedefinition.DefaultType
+		_c.mutation.SetType(v)
+	}
+	if _, ok := _c.mutation.MultiValued(); !ok {
+		v := attributedefinition.DefaultMultiValued
+		_c.mutation.SetMultiValued(v)
+	}
+	if _, ok := _c.mutation.Required(); !ok {
+		v := attributedefinition.DefaultRequired
+		_c.mutation.SetRequired(v)
+	}
+	if _, ok := _c.mutation.Indexed(); !ok {
+		v := attributedefinition.DefaultIndexed
+		_c.mutation.SetIndexed(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := attributedefinition.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := attributedefinition.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := attributedefinition.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *AttributeDefinitionCreate) check() error {
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "AttributeDefinition.name"`)}
+	}
+	if v, ok := _c.mutation.Name(); ok {
+		if err := attributedefinition.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "AttributeDefinition.name": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Target(); !ok {
+		return &ValidationError{Name: "target", err: errors.New(`ent: missing required field "AttributeDefinition.target"`)}
+	}
+	if v, ok := _c.mutation.Target(); ok {
+		if err := attributedefinition.TargetValidator(v); err != nil {
+			return &ValidationError{Name: "target", err: fmt.Errorf(`ent: validator failed for field "AttributeDefinition.target": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.GetType(); !ok {
+		return &ValidationError{Name: "type", err: errors.New(`ent: missing required field "AttributeDefinition.type"`)}
+	}
+	if v, ok := _c.mutation.GetType(); ok {
+		if err := attributedefinition.TypeValidator(v); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "AttributeDefinition.type": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.MultiValued(); !ok {
+		return &ValidationError{Name: "multi_valued", err: errors.New(`ent: missing required field "AttributeDefinition.multi_valued"`)}
+	}
+	if _, ok := _c.mutation.LdapName(); !ok {
+		return &ValidationError{Name: "ldap_name", err: errors.New(`ent: missing required field "AttributeDefinition.ldap_name"`)}
+	}
+	if v, ok := _c.mutation.LdapName(); ok {
+		if err := attributedefinition.LdapNameValidator(v); err != nil {
+			return &ValidationError{Name: "ldap_name", err: fmt.Errorf(`ent: validator failed for field "AttributeDefinition.ldap_name": %w`, err)}
+		}
+	}
+	if v, ok := _c.mutation.Description(); ok {
+		if err := attributedefinition.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf(`ent: validator failed for field "AttributeDefinition.description": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Required(); !ok {
+		return &ValidationError{Name: "required", err: errors.New(`ent: missing required field "AttributeDefinition.required"`)}
+	}
+	if _, ok := _c.mutation.Indexed(); !ok {
+		return &ValidationError{Name: "indexed", err: errors.New(`ent: missing required field "AttributeDefinition.indexed"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "AttributeDefinition.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "AttributeDefinition.updated_at"`)}
+	}
+	return nil
+}
+
+func (_c *AttributeDefinitionCreate) sqlSave(ctx context.Context) (*AttributeDefinition, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *AttributeDefinitionCreate) createSpec() (*AttributeDefinition, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AttributeDefinition{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(attributedefinition.Table, sqlgraph.NewFieldSpec(attributedefinition.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(attributedefinition.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.Target(); ok {
+		_spec.SetField(attributedefinition.FieldTarget, field.TypeEnum, value)
+		_node.Target = value
+	}
+	if value, ok := _c.mutation.GetType(); ok {
+		_spec.SetField(attributedefinition.FieldType, field.TypeEnum, value)
+		_node.Type = value
+	}
+	if value, ok := _c.mutation.MultiValued(); ok {
+		_spec.SetField(attributedefinition.FieldMultiValued, field.TypeBool, value)
+		_node.MultiValued = value
+	}
+	if value, ok := _c.mutation.LdapName(); ok {
+		_spec.SetField(attributedefinition.FieldLdapName, field.TypeString, value)
+		_node.LdapName = value
+	}
+	if value, ok := _c.mutation.Description(); ok {
+		_spec.SetField(attributedefinition.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
+	if value, ok := _c.mutation.Required(); ok {
+		_spec.SetField(attributedefinition.FieldRequired, field.TypeBool, value)
+		_node.Required = value
+	}
+	if value, ok := _c.mutation.Indexed(); ok {
+		_spec.SetField(attributedefinition.FieldIndexed, field.TypeBool, value)
+		_node.Indexed = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(attributedefinition.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(attributedefinition.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// AttributeDefinitionCreateBulk is the builder for creating many AttributeDefinition entities in bulk.
+type AttributeDefinitionCreateBulk struct {
+	config
+	err      error
+	builders []*AttributeDefinitionCreate
+}
+
+// Save creates the AttributeDefinition entities in the database.
+func (_c *AttributeDefinitionCreateBulk) Save(ctx context.Context) ([]*AttributeDefinition, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*AttributeDefinition, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AttributeDefinitionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *AttributeDefinitionCreateBulk) SaveX(ctx context.Context) []*AttributeDefinition {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AttributeDefinitionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AttributeDefinitionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,402 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/qinzj/claude-demo/internal/ent/attributedefinition"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// AttributeDefinitionUpdate is the builder for updating AttributeDefinition entities.
+type AttributeDefinitionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AttributeDefinitionMutation
+}
+
+// Where appends a list predicates to the AttributeDefinitionUpdate builder.
+func (_u *AttributeDefinitionUpdate) Where(ps ...predicate.AttributeDefinition) *AttributeDefinitionUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetLdapName sets the "ldap_name" field.
+func (_u *AttributeDefinitionUpdate) SetLdapName(v string) *AttributeDefinitionUpdate {
+	_u.mutation.SetLdapName(v)
+	return _u
+}
+
+// SetNillableLdapName sets the "ldap_name" field if the given value is not nil.
+func (_u *AttributeDefinitionUpdate) SetNillableLdapName(v *string) *AttributeDefinitionUpdate {
+	if v != nil {
+		_u.SetLdapName(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *AttributeDefinitionUpdate) SetDescription(v string) *AttributeDefinitionUpdate {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *AttributeDefinitionUpdate) SetNillableDescription(v *string) *AttributeDefinitionUpdate {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *AttributeDefinitionUpdate) ClearDescription() *AttributeDefinitionUpdate {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetRequired sets the "required" field.
+func (_u *AttributeDefinitionUpdate) SetRequired(v bool) *AttributeDefinitionUpdate {
+	_u.mutation.SetRequired(v)
+	return _u
+}
+
+// SetNillableRequired sets the "required" field if the given value is not nil.
+func (_u *AttributeDefinitionUpdate) SetNillableRequired(v *bool) *AttributeDefinitionUpdate {
+	if v != nil {
+		_u.SetRequired(*v)
+	}
+	return _u
+}
+
+// SetIndexed sets the "indexed" field.
+func (_u *AttributeDefinitionUpdate) SetIndexed(v bool) *AttributeDefinitionUpdate {
+	_u.mutation.SetIndexed(v)
+	return _u
+}
+
+// SetNillableIndexed sets the "indexed" field if the given value is not nil.
+func (_u *AttributeDefinitionUpdate) SetNillableIndexed(v *bool) *AttributeDefinitionUpdate {
+	if v != nil {
+		_u.SetIndexed(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *AttributeDefinitionUpdate) SetUpdatedAt(v time.Time) *AttributeDefinitionUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// Mutation returns the AttributeDefinitionMutation object of the builder.
+func (_u *AttributeDefinitionUpdate) Mutation() *AttributeDefinitionMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *AttributeDefinitionUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AttributeDefinitionUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *AttributeDefinitionUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AttributeDefinitionUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *AttributeDefinitionUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := attributedefinition.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *AttributeDefinitionUpdate) check() error {
+	if v, ok := _u.mutation.LdapName(); ok {
+		if err := attributedefinition.LdapNameValidator(v); err != nil {
+			return &ValidationError{Name: "ldap_name", err: fmt.Errorf(`ent: validator failed for field "AttributeDefinition.ldap_name": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Description(); ok {
+		if err := attributedefinition.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf(`ent: validator failed for field "AttributeDefinition.description": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *AttributeDefinitionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(attributedefinition.Table, attributedefinition.Columns, sqlgraph.NewFieldSpec(attributedefinition.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.LdapName(); ok {
+		_spec.SetField(attributedefinition.FieldLdapName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(attributedefinition.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(attributedefinition.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.Required(); ok {
+		_spec.SetField(attributedefinition.FieldRequired, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Indexed(); ok {
+		_spec.SetField(attributedefinition.FieldIndexed, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(attributedefinition.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{attributedefinition.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// AttributeDefinitionUpdateOne is the builder for updating a single AttributeDefinition entity.
+type AttributeDefinitionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AttributeDefinitionMutation
+}
+
+// SetLdapName sets the "ldap_name" field.
+func (_u *AttributeDefinitionUpdateOne) SetLdapName(v string) *AttributeDefinitionUpdateOne {
+	_u.mutation.SetLdapName(v)
+	return _u
+}
+
+// SetNillableLdapName sets the "ldap_name" field if the given value is not nil.
+func (_u *AttributeDefinitionUpdateOne) SetNillableLdapName(v *string) *AttributeDefinitionUpdateOne {
+	if v != nil {
+		_u.SetLdapName(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *AttributeDefinitionUpdateOne) SetDescription(v string) *AttributeDefinitionUpdateOne {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *AttributeDefinitionUpdateOne) SetNillableDescription(v *string) *AttributeDefinitionUpdateOne {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *AttributeDefinitionUpdateOne) ClearDescription() *AttributeDefinitionUpdateOne {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetRequired sets the "required" field.
+func (_u *AttributeDefinitionUpdateOne) SetRequired(v bool) *AttributeDefinitionUpdateOne {
+	_u.mutation.SetRequired(v)
+	return _u
+}
+
+// SetNillableRequired sets the "required" field if the given value is not nil.
+func (_u *AttributeDefinitionUpdateOne) SetNillableRequired(v *bool) *AttributeDefinitionUpdateOne {
+	if v != nil {
+		_u.SetRequired(*v)
+	}
+	return _u
+}
+
+// SetIndexed sets the "indexed" field.
+func (_u *AttributeDefinitionUpdateOne) SetIndexed(v bool) *AttributeDefinitionUpdateOne {
+	_u.mutation.SetIndexed(v)
+	return _u
+}
+
+// SetNillableIndexed sets the "indexed" field if the given value is not nil.
+func (_u *AttributeDefinitionUpdateOne) SetNillableIndexed(v *bool) *AttributeDefinitionUpdateOne {
+	if v != nil {
+		_u.SetIndexed(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *AttributeDefinitionUpdateOne) SetUpdatedAt(v time.Time) *AttributeDefinitionUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// Mutation returns the AttributeDefinitionMutation object of the builder.
+func (_u *AttributeDefinitionUpdateOne) Mutation() *AttributeDefinitionMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the AttributeDefinitionUpdate builder.
+func (_u *AttributeDefinitionUpdateOne) Where(ps ...predicate.AttributeDefinition) *AttributeDefinitionUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *AttributeDefinitionUpdateOne) Select(field string, fields ...string) *AttributeDefinitionUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated AttributeDefinition entity.
+func (_u *AttributeDefinitionUpdateOne) Save(ctx context.Context) (*AttributeDefinition, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AttributeDefinitionUpdateOne) SaveX(ctx context.Context) *AttributeDefinition {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *AttributeDefinitionUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AttributeDefinitionUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *AttributeDefinitionUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := attributedefinition.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *AttributeDefinitionUpdateOne) check() error {
+	if v, ok := _u.mutation.LdapName(); ok {
+		if err := attributedefinition.LdapNameValidator(v); err != nil {
+			return &ValidationError{Name: "ldap_name", err: fmt.Errorf(`ent: validator failed for field "AttributeDefinition.ldap_name": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Description(); ok {
+		if err := attributedefinition.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf(`ent: validator failed for field "AttributeDefinition.description": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *AttributeDefinitionUpdateOne) sqlSave(ctx context.Context) (_node *AttributeDefinition, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(attributedefinition.Table, attributedefinition.Columns, sqlgraph.NewFieldSpec(attributedefinition.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "AttributeDefinition.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, attributedefinition.FieldID)
+		for _, f := range fields {
+			if !attributedefinition.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != attributedefinition.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.LdapName(); ok {
+		_spec.SetField(attributedefinition.FieldLdapName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(attributedefinition.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(attributedefinition.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.Required(); ok {
+		_spec.SetField(attributedefinition.FieldRequired, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Indexed(); ok {
+		_spec.SetField(attributedefinition.FieldIndexed, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(attributedefinition.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &AttributeDefinition{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{attributedefinition.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,183 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/attributevalue"
+	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// AttributeValue is the model entity for the AttributeValue schema.
+type AttributeValue struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Value holds the value of the "value" field.
+	Value string `json:"value,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the AttributeValueQuery when eager-loading is set.
+	Edges                  AttributeValueEdges `json:"edges"`
+	group_attribute_values *uuid.UUID
+	user_attribute_values  *uuid.UUID
+	selectValues           sql.SelectValues
+}
+
+// AttributeValueEdges holds the relations/edges for other nodes in the graph.
+type AttributeValueEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// Group holds the value of the group edge.
+	Group *Group `json:"group,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e AttributeValueEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// GroupOrErr returns the Group value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e AttributeValueEdges) GroupOrErr() (*Group, error) {
+	if e.Group != nil {
+		return e.Group, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: group.Label}
+	}
+	return nil, &NotLoadedError{edge: "group"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*AttributeValue) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case attributevalue.FieldName, attributevalue.FieldValue:
+			values[i] = new(sql.NullString)
+		case attributevalue.FieldID:
+			values[i] = new(uuid.UUID)
+		case attributevalue.ForeignKeys[0]: // group_attribute_values
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		case attributevalue.ForeignKeys[1]: // user_attribute_values
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the AttributeValue fields.
+func (_m *AttributeValue) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case attributevalue.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case attributevalue.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case attributevalue.FieldValue:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field value", values[i])
+			} else if value.Valid {
+				_m.Value = value.String
+			}
+		case attributevalue.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field group_attribute_values", values[i])
+			} else if value.Valid {
+				_m.group_attribute_values = new(uuid.UUID)
+				*_m.group_attribute_values = *value.S.(*uuid.UUID)
+			}
+		case attributevalue.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field user_attribute_values", values[i])
+			} else if value.Valid {
+				_m.user_attribute_values = new(uuid.UUID)
+				*_m.user_attribute_values = *value.S.(*uuid.UUID)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// GetValue returns the ent.Value that was dynamically selected and assigned to the AttributeValue.
+// This includes values selected through modifiers, order, etc.
+func (_m *AttributeValue) GetValue(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the AttributeValue entity.
+func (_m *AttributeValue) QueryUser() *UserQuery {
+	return NewAttributeValueClient(_m.config).QueryUser(_m)
+}
+
+// QueryGroup queries the "group" edge of the AttributeValue entity.
+func (_m *AttributeValue) QueryGroup() *GroupQuery {
+	return NewAttributeValueClient(_m.config).QueryGroup(_m)
+}
+
+// Update returns a builder for updating this AttributeValue.
+// Note that you need to call AttributeValue.Unwrap() before calling this method if this AttributeValue
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *AttributeValue) Update() *AttributeValueUpdateOne {
+	return NewAttributeValueClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the AttributeValue entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *AttributeValue) Unwrap() *AttributeValue {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: AttributeValue is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *AttributeValue) String() string {
+	var builder strings.Builder
+	builder.WriteString("AttributeValue(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("value=")
+	builder.WriteString(_m.Value)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// AttributeValues is a parsable slice of AttributeValue.
+type AttributeValues []*AttributeValue
@@ -0,0 +1,245 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/authorizationcode"
+	"github.com/qinzj/claude-demo/internal/ent/oidcclient"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// AuthorizationCode is the model entity for the AuthorizationCode schema.
+type AuthorizationCode struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// CodeHash holds the value of the "code_hash" field.
+	CodeHash string `json:"-"`
+	// RedirectURI holds the value of the "redirect_uri" field.
+	RedirectURI string `json:"redirect_uri,omitempty"`
+	// Scopes holds the value of the "scopes" field.
+	Scopes []string `json:"scopes,omitempty"`
+	// Nonce holds the value of the "nonce" field.
+	Nonce string `json:"nonce,omitempty"`
+	// CodeChallenge holds the value of the "code_challenge" field.
+	CodeChallenge string `json:"code_challenge,omitempty"`
+	// AuthTime holds the value of the "auth_time" field.
+	AuthTime time.Time `json:"auth_time,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the AuthorizationCodeQuery when eager-loading is set.
+	Edges                    AuthorizationCodeEdges `json:"edges"`
+	oidc_client_codes        *uuid.UUID
+	user_authorization_codes *uuid.UUID
+	selectValues             sql.SelectValues
+}
+
+// AuthorizationCodeEdges holds the relations/edges for other nodes in the graph.
+type AuthorizationCodeEdges struct {
+	// Client holds the value of the client edge.
+	Client *OIDCClient `json:"client,omitempty"`
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// ClientOrErr returns the Client value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e AuthorizationCodeEdges) ClientOrErr() (*OIDCClient, error) {
+	if e.Client != nil {
+		return e.Client, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: oidcclient.Label}
+	}
+	return nil, &NotLoadedError{edge: "client"}
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e AuthorizationCodeEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*AuthorizationCode) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case authorizationcode.FieldScopes:
+			values[i] = new([]byte)
+		case authorizationcode.FieldCodeHash, authorizationcode.FieldRedirectURI, authorizationcode.FieldNonce, authorizationcode.FieldCodeChallenge:
+			values[i] = new(sql.NullString)
+		case authorizationcode.FieldAuthTime, authorizationcode.FieldExpiresAt:
+			values[i] = new(sql.NullTime)
+		case authorizationcode.FieldID:
+			values[i] = new(uuid.UUID)
+		case authorizationcode.ForeignKeys[0]: // oidc_client_codes
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		case authorizationcode.ForeignKeys[1]: // user_authorization_codes
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the AuthorizationCode fields.
+func (_m *AuthorizationCode) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case authorizationcode.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case authorizationcode.FieldCodeHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field code_hash", values[i])
+			} else if value.Valid {
+				_m.CodeHash = value.String
+			}
+		case authorizationcode.FieldRedirectURI:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field redirect_uri", values[i])
+			} else if value.Valid {
+				_m.RedirectURI = value.String
+			}
+		case authorizationcode.FieldScopes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field scopes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Scopes); err != nil {
+					return fmt.Errorf("unmarshal field scopes: %w", err)
+				}
+			}
+		case authorizationcode.FieldNonce:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field nonce", values[i])
+			} else if value.Valid {
+				_m.Nonce = value.String
+			}
+		case authorizationcode.FieldCodeChallenge:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field code_challenge", values[i])
+			} else if value.Valid {
+				_m.CodeChallenge = value.String
+			}
+		case authorizationcode.FieldAuthTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field auth_time", values[i])
+			} else if value.Valid {
+				_m.AuthTime = value.Time
+			}
+		case authorizationcode.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				_m.ExpiresAt = value.Time
+			}
+		case authorizationcode.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field oidc_client_codes", values[i])
+			} else if value.Valid {
+				_m.oidc_client_codes = new(uuid.UUID)
+				*_m.oidc_client_codes = *value.S.(*uuid.UUID)
+			}
+		case authorizationcode.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field user_authorization_codes", values[i])
+			} else if value.Valid {
+				_m.user_authorization_codes = new(uuid.UUID)
+				*_m.user_authorization_codes = *value.S.(*uuid.UUID)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the AuthorizationCode.
+// This includes values selected through modifiers, order, etc.
+func (_m *AuthorizationCode) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryClient queries the "client" edge of the AuthorizationCode entity.
+func (_m *AuthorizationCode) QueryClient() *OIDCClientQuery {
+	return NewAuthorizationCodeClient(_m.config).QueryClient(_m)
+}
+
+// QueryUser queries the "user" edge of the AuthorizationCode entity.
+func (_m *AuthorizationCode) QueryUser() *UserQuery {
+	return NewAuthorizationCodeClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this AuthorizationCode.
+// Note that you need to call AuthorizationCode.Unwrap() before calling this method if this AuthorizationCode
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *AuthorizationCode) Update() *AuthorizationCodeUpdateOne {
+	return NewAuthorizationCodeClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the AuthorizationCode entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *AuthorizationCode) Unwrap() *AuthorizationCode {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: AuthorizationCode is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *AuthorizationCode) String() string {
+	var builder strings.Builder
+	builder.WriteString("AuthorizationCode(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("code_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("redirect_uri=")
+	builder.WriteString(_m.RedirectURI)
+	builder.WriteString(", ")
+	builder.WriteString("scopes=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Scopes))
+	builder.WriteString(", ")
+	builder.WriteString("nonce=")
+	builder.WriteString(_m.Nonce)
+	builder.WriteString(", ")
+	builder.WriteString("code_challenge=")
+	builder.WriteString(_m.CodeChallenge)
+	builder.WriteString(", ")
+	builder.WriteString("auth_time=")
+	builder.WriteString(_m.AuthTime.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(_m.ExpiresAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// AuthorizationCodes is a parsable slice of AuthorizationCode.
+type AuthorizationCodes []*AuthorizationCode
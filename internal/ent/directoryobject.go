@@ -0,0 +1,183 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/directoryobject"
+)
+
+// DirectoryObject is the model entity for the DirectoryObject schema.
+type DirectoryObject struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Source holds the value of the "source" field.
+	Source string `json:"source,omitempty"`
+	// ResourceType holds the value of the "resource_type" field.
+	ResourceType directoryobject.ResourceType `json:"resource_type,omitempty"`
+	// ExternalID holds the value of the "external_id" field.
+	ExternalID string `json:"external_id,omitempty"`
+	// LocalID holds the value of the "local_id" field.
+	LocalID uuid.UUID `json:"local_id,omitempty"`
+	// Dn holds the value of the "dn" field.
+	Dn string `json:"dn,omitempty"`
+	// PasswordDigest holds the value of the "password_digest" field.
+	PasswordDigest string `json:"-"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*DirectoryObject) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case directoryobject.FieldSource, directoryobject.FieldResourceType, directoryobject.FieldExternalID, directoryobject.FieldDn, directoryobject.FieldPasswordDigest:
+			values[i] = new(sql.NullString)
+		case directoryobject.FieldCreatedAt, directoryobject.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case directoryobject.FieldID, directoryobject.FieldLocalID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the DirectoryObject fields.
+func (_m *DirectoryObject) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case directoryobject.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case directoryobject.FieldSource:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field source", values[i])
+			} else if value.Valid {
+				_m.Source = value.String
+			}
+		case directoryobject.FieldResourceType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field resource_type", values[i])
+			} else if value.Valid {
+				_m.ResourceType = directoryobject.ResourceType(value.String)
+			}
+		case directoryobject.FieldExternalID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field external_id", values[i])
+			} else if value.Valid {
+				_m.ExternalID = value.String
+			}
+		case directoryobject.FieldLocalID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field local_id", values[i])
+			} else if value != nil {
+				_m.LocalID = *value
+			}
+		case directoryobject.FieldDn:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field dn", values[i])
+			} else if value.Valid {
+				_m.Dn = value.String
+			}
+		case directoryobject.FieldPasswordDigest:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field password_digest", values[i])
+			} else if value.Valid {
+				_m.PasswordDigest = value.String
+			}
+		case directoryobject.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case directoryobject.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the DirectoryObject.
+// This includes values selected through modifiers, order, etc.
+func (_m *DirectoryObject) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this DirectoryObject.
+// Note that you need to call DirectoryObject.Unwrap() before calling this method if this DirectoryObject
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *DirectoryObject) Update() *DirectoryObjectUpdateOne {
+	return NewDirectoryObjectClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the DirectoryObject entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *DirectoryObject) Unwrap() *DirectoryObject {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: DirectoryObject is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *DirectoryObject) String() string {
+	var builder strings.Builder
+	builder.WriteString("DirectoryObject(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("source=")
+	builder.WriteString(_m.Source)
+	builder.WriteString(", ")
+	builder.WriteString("resource_type=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ResourceType))
+	builder.WriteString(", ")
+	builder.WriteString("external_id=")
+	builder.WriteString(_m.ExternalID)
+	builder.WriteString(", ")
+	builder.WriteString("local_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LocalID))
+	builder.WriteString(", ")
+	builder.WriteString("dn=")
+	builder.WriteString(_m.Dn)
+	builder.WriteString(", ")
+	builder.WriteString("password_digest=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// DirectoryObjects is a parsable slice of DirectoryObject.
+type DirectoryObjects []*DirectoryObject
@@ -0,0 +1,149 @@
+// Code generated by ent, DO NOT EDIT.
+
+package directoryobject
+
+import (
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the directoryobject type in the database.
+	Label = "directory_object"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldSource holds the string denoting the source field in the database.
+	FieldSource = "source"
+	// FieldResourceType holds the string denoting the resource_type field in the database.
+	FieldResourceType = "resource_type"
+	// FieldExternalID holds the string denoting the external_id field in the database.
+	FieldExternalID = "external_id"
+	// FieldLocalID holds the string denoting the local_id field in the database.
+	FieldLocalID = "local_id"
+	// FieldDn holds the string denoting the dn field in the database.
+	FieldDn = "dn"
+	// FieldPasswordDigest holds the string denoting the password_digest field in the database.
+	FieldPasswordDigest = "password_digest"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// Table holds the table name of the directoryobject in the database.
+	Table = "directory_objects"
+)
+
+// Columns holds all SQL columns for directoryobject fields.
+var Columns = []string{
+	FieldID,
+	FieldSource,
+	FieldResourceType,
+	FieldExternalID,
+	FieldLocalID,
+	FieldDn,
+	FieldPasswordDigest,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// SourceValidator is a validator for the "source" field. It is called by the builders before save.
+	SourceValidator func(string) error
+	// ExternalIDValidator is a validator for the "external_id" field. It is called by the builders before save.
+	ExternalIDValidator func(string) error
+	// DefaultDn holds the default value on creation for the "dn" field.
+	DefaultDn string
+	// DefaultPasswordDigest holds the default value on creation for the "password_digest" field.
+	DefaultPasswordDigest string
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// ResourceType defines the type for the "resource_type" enum field.
+type ResourceType string
+
+// ResourceType values.
+const (
+	ResourceTypeUser  ResourceType = "user"
+	ResourceTypeGroup ResourceType = "group"
+)
+
+func (rt ResourceType) String() string {
+	return string(rt)
+}
+
+// ResourceTypeValidator is a validator for the "resource_type" field enum values. It is called by the builders before save.
+func ResourceTypeValidator(rt ResourceType) error {
+	switch rt {
+	case ResourceTypeUser, ResourceTypeGroup:
+		return nil
+	default:
+		return fmt.Errorf("directoryobject: invalid enum value for resource_type field: %q", rt)
+	}
+}
+
+// OrderOption defines the ordering options for the DirectoryObject queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// BySource orders the results by the source field.
+func BySource(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSource, opts...).ToFunc()
+}
+
+// ByResourceType orders the results by the resource_type field.
+func ByResourceType(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResourceType, opts...).ToFunc()
+}
+
+// ByExternalID orders the results by the external_id field.
+func ByExternalID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldExternalID, opts...).ToFunc()
+}
+
+// ByLocalID orders the results by the local_id field.
+func ByLocalID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLocalID, opts...).ToFunc()
+}
+
+// ByDn orders the results by the dn field.
+func ByDn(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDn, opts...).ToFunc()
+}
+
+// ByPasswordDigest orders the results by the password_digest field.
+func ByPasswordDigest(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPasswordDigest, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
@@ -0,0 +1,506 @@
+// Code generated by ent, DO NOT EDIT.
+
+package directoryobject
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLTE(FieldID, id))
+}
+
+// Source applies equality check predicate on the "source" field. It's identical to SourceEQ.
+func Source(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldSource, v))
+}
+
+// ExternalID applies equality check predicate on the "external_id" field. It's identical to ExternalIDEQ.
+func ExternalID(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldExternalID, v))
+}
+
+// LocalID applies equality check predicate on the "local_id" field. It's identical to LocalIDEQ.
+func LocalID(v uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldLocalID, v))
+}
+
+// Dn applies equality check predicate on the "dn" field. It's identical to DnEQ.
+func Dn(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldDn, v))
+}
+
+// PasswordDigest applies equality check predicate on the "password_digest" field. It's identical to PasswordDigestEQ.
+func PasswordDigest(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldPasswordDigest, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// SourceEQ applies the EQ predicate on the "source" field.
+func SourceEQ(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldSource, v))
+}
+
+// SourceNEQ applies the NEQ predicate on the "source" field.
+func SourceNEQ(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNEQ(FieldSource, v))
+}
+
+// SourceIn applies the In predicate on the "source" field.
+func SourceIn(vs ...string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldIn(FieldSource, vs...))
+}
+
+// SourceNotIn applies the NotIn predicate on the "source" field.
+func SourceNotIn(vs ...string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNotIn(FieldSource, vs...))
+}
+
+// SourceGT applies the GT predicate on the "source" field.
+func SourceGT(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGT(FieldSource, v))
+}
+
+// SourceGTE applies the GTE predicate on the "source" field.
+func SourceGTE(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGTE(FieldSource, v))
+}
+
+// SourceLT applies the LT predicate on the "source" field.
+func SourceLT(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLT(FieldSource, v))
+}
+
+// SourceLTE applies the LTE predicate on the "source" field.
+func SourceLTE(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLTE(FieldSource, v))
+}
+
+// SourceContains applies the Contains predicate on the "source" field.
+func SourceContains(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldContains(FieldSource, v))
+}
+
+// SourceHasPrefix applies the HasPrefix predicate on the "source" field.
+func SourceHasPrefix(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldHasPrefix(FieldSource, v))
+}
+
+// SourceHasSuffix applies the HasSuffix predicate on the "source" field.
+func SourceHasSuffix(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldHasSuffix(FieldSource, v))
+}
+
+// SourceEqualFold applies the EqualFold predicate on the "source" field.
+func SourceEqualFold(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEqualFold(FieldSource, v))
+}
+
+// SourceContainsFold applies the ContainsFold predicate on the "source" field.
+func SourceContainsFold(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldContainsFold(FieldSource, v))
+}
+
+// ResourceTypeEQ applies the EQ predicate on the "resource_type" field.
+func ResourceTypeEQ(v ResourceType) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldResourceType, v))
+}
+
+// ResourceTypeNEQ applies the NEQ predicate on the "resource_type" field.
+func ResourceTypeNEQ(v ResourceType) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNEQ(FieldResourceType, v))
+}
+
+// ResourceTypeIn applies the In predicate on the "resource_type" field.
+func ResourceTypeIn(vs ...ResourceType) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldIn(FieldResourceType, vs...))
+}
+
+// ResourceTypeNotIn applies the NotIn predicate on the "resource_type" field.
+func ResourceTypeNotIn(vs ...ResourceType) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNotIn(FieldResourceType, vs...))
+}
+
+// ExternalIDEQ applies the EQ predicate on the "external_id" field.
+func ExternalIDEQ(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldExternalID, v))
+}
+
+// ExternalIDNEQ applies the NEQ predicate on the "external_id" field.
+func ExternalIDNEQ(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNEQ(FieldExternalID, v))
+}
+
+// ExternalIDIn applies the In predicate on the "external_id" field.
+func ExternalIDIn(vs ...string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldIn(FieldExternalID, vs...))
+}
+
+// ExternalIDNotIn applies the NotIn predicate on the "external_id" field.
+func ExternalIDNotIn(vs ...string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNotIn(FieldExternalID, vs...))
+}
+
+// ExternalIDGT applies the GT predicate on the "external_id" field.
+func ExternalIDGT(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGT(FieldExternalID, v))
+}
+
+// ExternalIDGTE applies the GTE predicate on the "external_id" field.
+func ExternalIDGTE(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGTE(FieldExternalID, v))
+}
+
+// ExternalIDLT applies the LT predicate on the "external_id" field.
+func ExternalIDLT(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLT(FieldExternalID, v))
+}
+
+// ExternalIDLTE applies the LTE predicate on the "external_id" field.
+func ExternalIDLTE(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLTE(FieldExternalID, v))
+}
+
+// ExternalIDContains applies the Contains predicate on the "external_id" field.
+func ExternalIDContains(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldContains(FieldExternalID, v))
+}
+
+// ExternalIDHasPrefix applies the HasPrefix predicate on the "external_id" field.
+func ExternalIDHasPrefix(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldHasPrefix(FieldExternalID, v))
+}
+
+// ExternalIDHasSuffix applies the HasSuffix predicate on the "external_id" field.
+func ExternalIDHasSuffix(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldHasSuffix(FieldExternalID, v))
+}
+
+// ExternalIDEqualFold applies the EqualFold predicate on the "external_id" field.
+func ExternalIDEqualFold(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEqualFold(FieldExternalID, v))
+}
+
+// ExternalIDContainsFold applies the ContainsFold predicate on the "external_id" field.
+func ExternalIDContainsFold(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldContainsFold(FieldExternalID, v))
+}
+
+// LocalIDEQ applies the EQ predicate on the "local_id" field.
+func LocalIDEQ(v uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldLocalID, v))
+}
+
+// LocalIDNEQ applies the NEQ predicate on the "local_id" field.
+func LocalIDNEQ(v uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNEQ(FieldLocalID, v))
+}
+
+// LocalIDIn applies the In predicate on the "local_id" field.
+func LocalIDIn(vs ...uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldIn(FieldLocalID, vs...))
+}
+
+// LocalIDNotIn applies the NotIn predicate on the "local_id" field.
+func LocalIDNotIn(vs ...uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNotIn(FieldLocalID, vs...))
+}
+
+// LocalIDGT applies the GT predicate on the "local_id" field.
+func LocalIDGT(v uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGT(FieldLocalID, v))
+}
+
+// LocalIDGTE applies the GTE predicate on the "local_id" field.
+func LocalIDGTE(v uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGTE(FieldLocalID, v))
+}
+
+// LocalIDLT applies the LT predicate on the "local_id" field.
+func LocalIDLT(v uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLT(FieldLocalID, v))
+}
+
+// LocalIDLTE applies the LTE predicate on the "local_id" field.
+func LocalIDLTE(v uuid.UUID) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLTE(FieldLocalID, v))
+}
+
+// DnEQ applies the EQ predicate on the "dn" field.
+func DnEQ(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldDn, v))
+}
+
+// DnNEQ applies the NEQ predicate on the "dn" field.
+func DnNEQ(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNEQ(FieldDn, v))
+}
+
+// DnIn applies the In predicate on the "dn" field.
+func DnIn(vs ...string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldIn(FieldDn, vs...))
+}
+
+// DnNotIn applies the NotIn predicate on the "dn" field.
+func DnNotIn(vs ...string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNotIn(FieldDn, vs...))
+}
+
+// DnGT applies the GT predicate on the "dn" field.
+func DnGT(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGT(FieldDn, v))
+}
+
+// DnGTE applies the GTE predicate on the "dn" field.
+func DnGTE(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGTE(FieldDn, v))
+}
+
+// DnLT applies the LT predicate on the "dn" field.
+func DnLT(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLT(FieldDn, v))
+}
+
+// DnLTE applies the LTE predicate on the "dn" field.
+func DnLTE(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLTE(FieldDn, v))
+}
+
+// DnContains applies the Contains predicate on the "dn" field.
+func DnContains(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldContains(FieldDn, v))
+}
+
+// DnHasPrefix applies the HasPrefix predicate on the "dn" field.
+func DnHasPrefix(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldHasPrefix(FieldDn, v))
+}
+
+// DnHasSuffix applies the HasSuffix predicate on the "dn" field.
+func DnHasSuffix(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldHasSuffix(FieldDn, v))
+}
+
+// DnEqualFold applies the EqualFold predicate on the "dn" field.
+func DnEqualFold(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEqualFold(FieldDn, v))
+}
+
+// DnContainsFold applies the ContainsFold predicate on the "dn" field.
+func DnContainsFold(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldContainsFold(FieldDn, v))
+}
+
+// PasswordDigestEQ applies the EQ predicate on the "password_digest" field.
+func PasswordDigestEQ(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldPasswordDigest, v))
+}
+
+// PasswordDigestNEQ applies the NEQ predicate on the "password_digest" field.
+func PasswordDigestNEQ(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNEQ(FieldPasswordDigest, v))
+}
+
+// PasswordDigestIn applies the In predicate on the "password_digest" field.
+func PasswordDigestIn(vs ...string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldIn(FieldPasswordDigest, vs...))
+}
+
+// PasswordDigestNotIn applies the NotIn predicate on the "password_digest" field.
+func PasswordDigestNotIn(vs ...string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNotIn(FieldPasswordDigest, vs...))
+}
+
+// PasswordDigestGT applies the GT predicate on the "password_digest" field.
+func PasswordDigestGT(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGT(FieldPasswordDigest, v))
+}
+
+// PasswordDigestGTE applies the GTE predicate on the "password_digest" field.
+func PasswordDigestGTE(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGTE(FieldPasswordDigest, v))
+}
+
+// PasswordDigestLT applies the LT predicate on the "password_digest" field.
+func PasswordDigestLT(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLT(FieldPasswordDigest, v))
+}
+
+// PasswordDigestLTE applies the LTE predicate on the "password_digest" field.
+func PasswordDigestLTE(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLTE(FieldPasswordDigest, v))
+}
+
+// PasswordDigestContains applies the Contains predicate on the "password_digest" field.
+func PasswordDigestContains(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldContains(FieldPasswordDigest, v))
+}
+
+// PasswordDigestHasPrefix applies the HasPrefix predicate on the "password_digest" field.
+func PasswordDigestHasPrefix(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldHasPrefix(FieldPasswordDigest, v))
+}
+
+// PasswordDigestHasSuffix applies the HasSuffix predicate on the "password_digest" field.
+func PasswordDigestHasSuffix(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldHasSuffix(FieldPasswordDigest, v))
+}
+
+// PasswordDigestEqualFold applies the EqualFold predicate on the "password_digest" field.
+func PasswordDigestEqualFold(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEqualFold(FieldPasswordDigest, v))
+}
+
+// PasswordDigestContainsFold applies the ContainsFold predicate on the "password_digest" field.
+func PasswordDigestContainsFold(v string) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldContainsFold(FieldPasswordDigest, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.DirectoryObject) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.DirectoryObject) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.DirectoryObject) predicate.DirectoryObject {
+	return predicate.DirectoryObject(sql.NotPredicates(p))
+}
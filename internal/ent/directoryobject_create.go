@@ -0,0 +1,368 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/directoryobject"
+)
+
+// DirectoryObjectCreate is the builder for creating a DirectoryObject entity.
+type DirectoryObjectCreate struct {
+	config
+	mutation *DirectoryObjectMutation
+	hooks    []Hook
+}
+
+// SetSource sets the "source" field.
+func (_c *DirectoryObjectCreate) SetSource(v string) *DirectoryObjectCreate {
+	_c.mutation.SetSource(v)
+	return _c
+}
+
+// SetResourceType sets the "resource_type" field.
+func (_c *DirectoryObjectCreate) SetResourceType(v directoryobject.ResourceType) *DirectoryObjectCreate {
+	_c.mutation.SetResourceType(v)
+	return _c
+}
+
+// SetExternalID sets the "external_id" field.
+func (_c *DirectoryObjectCreate) SetExternalID(v string) *DirectoryObjectCreate {
+	_c.mutation.SetExternalID(v)
+	return _c
+}
+
+// SetLocalID sets the "local_id" field.
+func (_c *DirectoryObjectCreate) SetLocalID(v uuid.UUID) *DirectoryObjectCreate {
+	_c.mutation.SetLocalID(v)
+	return _c
+}
+
+// SetDn sets the "dn" field.
+func (_c *DirectoryObjectCreate) SetDn(v string) *DirectoryObjectCreate {
+	_c.mutation.SetDn(v)
+	return _c
+}
+
+// SetNillableDn sets the "dn" field if the given value is not nil.
+func (_c *DirectoryObjectCreate) SetNillableDn(v *string) *DirectoryObjectCreate {
+	if v != nil {
+		_c.SetDn(*v)
+	}
+	return _c
+}
+
+// SetPasswordDigest sets the "password_digest" field.
+func (_c *DirectoryObjectCreate) SetPasswordDigest(v string) *DirectoryObjectCreate {
+	_c.mutation.SetPasswordDigest(v)
+	return _c
+}
+
+// SetNillablePasswordDigest sets the "password_digest" field if the given value is not nil.
+func (_c *DirectoryObjectCreate) SetNillablePasswordDigest(v *string) *DirectoryObjectCreate {
+	if v != nil {
+		_c.SetPasswordDigest(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *DirectoryObjectCreate) SetCreatedAt(v time.Time) *DirectoryObjectCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *DirectoryObjectCreate) SetNillableCreatedAt(v *time.Time) *DirectoryObjectCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *DirectoryObjectCreate) SetUpdatedAt(v time.Time) *DirectoryObjectCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *DirectoryObjectCreate) SetNillableUpdatedAt(v *time.Time) *DirectoryObjectCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *DirectoryObjectCreate) SetID(v uuid.UUID) *DirectoryObjectCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *DirectoryObjectCreate) SetNillableID(v *uuid.UUID) *DirectoryObjectCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the DirectoryObjectMutation object of the builder.
+func (_c *DirectoryObjectCreate) Mutation() *DirectoryObjectMutation {
+	return _c.mutation
+}
+
+// Save creates the DirectoryObject in the database.
+func (_c *DirectoryObjectCreate) Save(ctx context.Context) (*DirectoryObject, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *DirectoryObjectCreate) SaveX(ctx context.Context) *DirectoryObject {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DirectoryObjectCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DirectoryObjectCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *DirectoryObjectCreate) defaults() {
+	if _, ok := _c.mutation.Dn(); !ok {
+		v := directoryobject.DefaultDn
+		_c.mutation.SetDn(v)
+	}
+	if _, ok := _c.mutation.PasswordDigest(); !ok {
+		v := directoryobject.DefaultPasswordDigest
+		_c.mutation.SetPasswordDigest(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := directoryobject.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := directoryobject.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := directoryobject.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *DirectoryObjectCreate) check() error {
+	if _, ok := _c.mutation.Source(); !ok {
+		return &ValidationError{Name: "source", err: errors.New(`ent: missing required field "DirectoryObject.source"`)}
+	}
+	if v, ok := _c.mutation.Source(); ok {
+		if err := directoryobject.SourceValidator(v); err != nil {
+			return &ValidationError{Name: "source", err: fmt.Errorf(`ent: validator failed for field "DirectoryObject.source": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ResourceType(); !ok {
+		return &ValidationError{Name: "resource_type", err: errors.New(`ent: missing required field "DirectoryObject.resource_type"`)}
+	}
+	if v, ok := _c.mutation.ResourceType(); ok {
+		if err := directoryobject.ResourceTypeValidator(v); err != nil {
+			return &ValidationError{Name: "resource_type", err: fmt.Errorf(`ent: validator failed for field "DirectoryObject.resource_type": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ExternalID(); !ok {
+		return &ValidationError{Name: "external_id", err: errors.New(`ent: missing required field "DirectoryObject.external_id"`)}
+	}
+	if v, ok := _c.mutation.ExternalID(); ok {
+		if err := directoryobject.ExternalIDValidator(v); err != nil {
+			return &ValidationError{Name: "external_id", err: fmt.Errorf(`ent: validator failed for field "DirectoryObject.external_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.LocalID(); !ok {
+		return &ValidationError{Name: "local_id", err: errors.New(`ent: missing required field "DirectoryObject.local_id"`)}
+	}
+	if _, ok := _c.mutation.Dn(); !ok {
+		return &ValidationError{Name: "dn", err: errors.New(`ent: missing required field "DirectoryObject.dn"`)}
+	}
+	if _, ok := _c.mutation.PasswordDigest(); !ok {
+		return &ValidationError{Name: "password_digest", err: errors.New(`ent: missing required field "DirectoryObject.password_digest"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "DirectoryObject.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "DirectoryObject.updated_at"`)}
+	}
+	return nil
+}
+
+func (_c *DirectoryObjectCreate) sqlSave(ctx context.Context) (*DirectoryObject, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *DirectoryObjectCreate) createSpec() (*DirectoryObject, *sqlgraph.CreateSpec) {
+	var (
+		_node = &DirectoryObject{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(directoryobject.Table, sqlgraph.NewFieldSpec(directoryobject.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Source(); ok {
+		_spec.SetField(directoryobject.FieldSource, field.TypeString, value)
+		_node.Source = value
+	}
+	if value, ok := _c.mutation.ResourceType(); ok {
+		_spec.SetField(directoryobject.FieldResourceType, field.TypeEnum, value)
+		_node.ResourceType = value
+	}
+	if value, ok := _c.mutation.ExternalID(); ok {
+		_spec.SetField(directoryobject.FieldExternalID, field.TypeString, value)
+		_node.ExternalID = value
+	}
+	if value, ok := _c.mutation.LocalID(); ok {
+		_spec.SetField(directoryobject.FieldLocalID, field.TypeUUID, value)
+		_node.LocalID = value
+	}
+	if value, ok := _c.mutation.Dn(); ok {
+		_spec.SetField(directoryobject.FieldDn, field.TypeString, value)
+		_node.Dn = value
+	}
+	if value, ok := _c.mutation.PasswordDigest(); ok {
+		_spec.SetField(directoryobject.FieldPasswordDigest, field.TypeString, value)
+		_node.PasswordDigest = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(directoryobject.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(directoryobject.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// DirectoryObjectCreateBulk is the builder for creating many DirectoryObject entities in bulk.
+type DirectoryObjectCreateBulk struct {
+	config
+	err      error
+	builders []*DirectoryObjectCreate
+}
+
+// Save creates the DirectoryObject entities in the database.
+func (_c *DirectoryObjectCreateBulk) Save(ctx context.Context) ([]*DirectoryObject, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*DirectoryObject, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*DirectoryObjectMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *DirectoryObjectCreateBulk) SaveX(ctx context.Context) []*DirectoryObject {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DirectoryObjectCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DirectoryObjectCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/qinzj/claude-demo/internal/ent/directoryobject"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// DirectoryObjectDelete is the builder for deleting a DirectoryObject entity.
+type DirectoryObjectDelete struct {
+	config
+	hooks    []Hook
+	mutation *DirectoryObjectMutation
+}
+
+// Where appends a list predicates to the DirectoryObjectDelete builder.
+func (_d *DirectoryObjectDelete) Where(ps ...predicate.DirectoryObject) *DirectoryObjectDelete {
+	_d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (_d *DirectoryObjectDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, _d.sqlExec, _d.mutation, _d.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *DirectoryObjectDelete) ExecX(ctx context.Context) int {
+	n, err := _d.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (_d *DirectoryObjectDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(directoryobject.Table, sqlgraph.NewFieldSpec(directoryobject.FieldID, field.TypeUUID))
+	if ps := _d.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, _d.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	_d.mutation.done = true
+	return affected, err
+}
+
+// DirectoryObjectDeleteOne is the builder for deleting a single DirectoryObject entity.
+type DirectoryObjectDeleteOne struct {
+	_d *DirectoryObjectDelete
+}
+
+// Where appends a list predicates to the DirectoryObjectDelete builder.
+func (_d *DirectoryObjectDeleteOne) Where(ps ...predicate.DirectoryObject) *DirectoryObjectDeleteOne {
+	_d._d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query.
+func (_d *DirectoryObjectDeleteOne) Exec(ctx context.Context) error {
+	n, err := _d._d.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{directoryobject.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *DirectoryObjectDeleteOne) ExecX(ctx context.Context) {
+	if err := _d.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,315 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/directoryobject"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// DirectoryObjectUpdate is the builder for updating DirectoryObject entities.
+type DirectoryObjectUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DirectoryObjectMutation
+}
+
+// Where appends a list predicates to the DirectoryObjectUpdate builder.
+func (_u *DirectoryObjectUpdate) Where(ps ...predicate.DirectoryObject) *DirectoryObjectUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetLocalID sets the "local_id" field.
+func (_u *DirectoryObjectUpdate) SetLocalID(v uuid.UUID) *DirectoryObjectUpdate {
+	_u.mutation.SetLocalID(v)
+	return _u
+}
+
+// SetNillableLocalID sets the "local_id" field if the given value is not nil.
+func (_u *DirectoryObjectUpdate) SetNillableLocalID(v *uuid.UUID) *DirectoryObjectUpdate {
+	if v != nil {
+		_u.SetLocalID(*v)
+	}
+	return _u
+}
+
+// SetDn sets the "dn" field.
+func (_u *DirectoryObjectUpdate) SetDn(v string) *DirectoryObjectUpdate {
+	_u.mutation.SetDn(v)
+	return _u
+}
+
+// SetNillableDn sets the "dn" field if the given value is not nil.
+func (_u *DirectoryObjectUpdate) SetNillableDn(v *string) *DirectoryObjectUpdate {
+	if v != nil {
+		_u.SetDn(*v)
+	}
+	return _u
+}
+
+// SetPasswordDigest sets the "password_digest" field.
+func (_u *DirectoryObjectUpdate) SetPasswordDigest(v string) *DirectoryObjectUpdate {
+	_u.mutation.SetPasswordDigest(v)
+	return _u
+}
+
+// SetNillablePasswordDigest sets the "password_digest" field if the given value is not nil.
+func (_u *DirectoryObjectUpdate) SetNillablePasswordDigest(v *string) *DirectoryObjectUpdate {
+	if v != nil {
+		_u.SetPasswordDigest(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *DirectoryObjectUpdate) SetUpdatedAt(v time.Time) *DirectoryObjectUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// Mutation returns the DirectoryObjectMutation object of the builder.
+func (_u *DirectoryObjectUpdate) Mutation() *DirectoryObjectMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *DirectoryObjectUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DirectoryObjectUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *DirectoryObjectUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DirectoryObjectUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *DirectoryObjectUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := directoryobject.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *DirectoryObjectUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(directoryobject.Table, directoryobject.Columns, sqlgraph.NewFieldSpec(directoryobject.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.LocalID(); ok {
+		_spec.SetField(directoryobject.FieldLocalID, field.TypeUUID, value)
+	}
+	if value, ok := _u.mutation.Dn(); ok {
+		_spec.SetField(directoryobject.FieldDn, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.PasswordDigest(); ok {
+		_spec.SetField(directoryobject.FieldPasswordDigest, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(directoryobject.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{directoryobject.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// DirectoryObjectUpdateOne is the builder for updating a single DirectoryObject entity.
+type DirectoryObjectUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *DirectoryObjectMutation
+}
+
+// SetLocalID sets the "local_id" field.
+func (_u *DirectoryObjectUpdateOne) SetLocalID(v uuid.UUID) *DirectoryObjectUpdateOne {
+	_u.mutation.SetLocalID(v)
+	return _u
+}
+
+// SetNillableLocalID sets the "local_id" field if the given value is not nil.
+func (_u *DirectoryObjectUpdateOne) SetNillableLocalID(v *uuid.UUID) *DirectoryObjectUpdateOne {
+	if v != nil {
+		_u.SetLocalID(*v)
+	}
+	return _u
+}
+
+// SetDn sets the "dn" field.
+func (_u *DirectoryObjectUpdateOne) SetDn(v string) *DirectoryObjectUpdateOne {
+	_u.mutation.SetDn(v)
+	return _u
+}
+
+// SetNillableDn sets the "dn" field if the given value is not nil.
+func (_u *DirectoryObjectUpdateOne) SetNillableDn(v *string) *DirectoryObjectUpdateOne {
+	if v != nil {
+		_u.SetDn(*v)
+	}
+	return _u
+}
+
+// SetPasswordDigest sets the "password_digest" field.
+func (_u *DirectoryObjectUpdateOne) SetPasswordDigest(v string) *DirectoryObjectUpdateOne {
+	_u.mutation.SetPasswordDigest(v)
+	return _u
+}
+
+// SetNillablePasswordDigest sets the "password_digest" field if the given value is not nil.
+func (_u *DirectoryObjectUpdateOne) SetNillablePasswordDigest(v *string) *DirectoryObjectUpdateOne {
+	if v != nil {
+		_u.SetPasswordDigest(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *DirectoryObjectUpdateOne) SetUpdatedAt(v time.Time) *DirectoryObjectUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// Mutation returns the DirectoryObjectMutation object of the builder.
+func (_u *DirectoryObjectUpdateOne) Mutation() *DirectoryObjectMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the DirectoryObjectUpdate builder.
+func (_u *DirectoryObjectUpdateOne) Where(ps ...predicate.DirectoryObject) *DirectoryObjectUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *DirectoryObjectUpdateOne) Select(field string, fields ...string) *DirectoryObjectUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated DirectoryObject entity.
+func (_u *DirectoryObjectUpdateOne) Save(ctx context.Context) (*DirectoryObject, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DirectoryObjectUpdateOne) SaveX(ctx context.Context) *DirectoryObject {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *DirectoryObjectUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DirectoryObjectUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *DirectoryObjectUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := directoryobject.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *DirectoryObjectUpdateOne) sqlSave(ctx context.Context) (_node *DirectoryObject, err error) {
+	_spec := sqlgraph.NewUpdateSpec(directoryobject.Table, directoryobject.Columns, sqlgraph.NewFieldSpec(directoryobject.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "DirectoryObject.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, directoryobject.FieldID)
+		for _, f := range fields {
+			if !directoryobject.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != directoryobject.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.LocalID(); ok {
+		_spec.SetField(directoryobject.FieldLocalID, field.TypeUUID, value)
+	}
+	if value, ok := _u.mutation.Dn(); ok {
+		_spec.SetField(directoryobject.FieldDn, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.PasswordDigest(); ok {
+		_spec.SetField(directoryobject.FieldPasswordDigest, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(directoryobject.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &DirectoryObject{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{directoryobject.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -12,8 +12,35 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/qinzj/claude-demo/internal/ent/apikey"
+	"github.com/qinzj/claude-demo/internal/ent/attributedefinition"
+	"github.com/qinzj/claude-demo/internal/ent/attributevalue"
+	"github.com/qinzj/claude-demo/internal/ent/auditevent"
+	"github.com/qinzj/claude-demo/internal/ent/authorizationcode"
+	"github.com/qinzj/claude-demo/internal/ent/directoryobject"
 	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/groupmembershipchange"
+	"github.com/qinzj/claude-demo/internal/ent/groupversion"
+	"github.com/qinzj/claude-demo/internal/ent/invitation"
+	"github.com/qinzj/claude-demo/internal/ent/loginevent"
+	"github.com/qinzj/claude-demo/internal/ent/oidcclient"
+	"github.com/qinzj/claude-demo/internal/ent/oidcconsent"
+	"github.com/qinzj/claude-demo/internal/ent/outboxevent"
+	"github.com/qinzj/claude-demo/internal/ent/passwordhistory"
+	"github.com/qinzj/claude-demo/internal/ent/passwordpolicy"
+	"github.com/qinzj/claude-demo/internal/ent/passwordresettoken"
+	"github.com/qinzj/claude-demo/internal/ent/provisionedobject"
+	"github.com/qinzj/claude-demo/internal/ent/provisioningconnector"
+	"github.com/qinzj/claude-demo/internal/ent/recoverycode"
+	"github.com/qinzj/claude-demo/internal/ent/role"
+	"github.com/qinzj/claude-demo/internal/ent/serviceaccount"
+	"github.com/qinzj/claude-demo/internal/ent/session"
+	"github.com/qinzj/claude-demo/internal/ent/signingkey"
+	"github.com/qinzj/claude-demo/internal/ent/sshkey"
 	"github.com/qinzj/claude-demo/internal/ent/user"
+	"github.com/qinzj/claude-demo/internal/ent/userversion"
+	"github.com/qinzj/claude-demo/internal/ent/webhookdelivery"
+	"github.com/qinzj/claude-demo/internal/ent/webhooksubscription"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -74,8 +101,35 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			group.Table: group.ValidColumn,
-			user.Table:  user.ValidColumn,
+			apikey.Table:                apikey.ValidColumn,
+			attributedefinition.Table:   attributedefinition.ValidColumn,
+			attributevalue.Table:        attributevalue.ValidColumn,
+			auditevent.Table:            auditevent.ValidColumn,
+			authorizationcode.Table:     authorizationcode.ValidColumn,
+			directoryobject.Table:       directoryobject.ValidColumn,
+			group.Table:                 group.ValidColumn,
+			groupmembershipchange.Table: groupmembershipchange.ValidColumn,
+			groupversion.Table:          groupversion.ValidColumn,
+			invitation.Table:            invitation.ValidColumn,
+			loginevent.Table:            loginevent.ValidColumn,
+			oidcclient.Table:            oidcclient.ValidColumn,
+			oidcconsent.Table:           oidcconsent.ValidColumn,
+			outboxevent.Table:           outboxevent.ValidColumn,
+			passwordhistory.Table:       passwordhistory.ValidColumn,
+			passwordpolicy.Table:        passwordpolicy.ValidColumn,
+			passwordresettoken.Table:    passwordresettoken.ValidColumn,
+			provisionedobject.Table:     provisionedobject.ValidColumn,
+			provisioningconnector.Table: provisioningconnector.ValidColumn,
+			recoverycode.Table:          recoverycode.ValidColumn,
+			role.Table:                  role.ValidColumn,
+			sshkey.Table:                sshkey.ValidColumn,
+			serviceaccount.Table:        serviceaccount.ValidColumn,
+			session.Table:               session.ValidColumn,
+			signingkey.Table:            signingkey.ValidColumn,
+			user.Table:                  user.ValidColumn,
+			userversion.Table:           userversion.ValidColumn,
+			webhookdelivery.Table:       webhookdelivery.ValidColumn,
+			webhooksubscription.Table:   webhooksubscription.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -24,6 +24,8 @@ type Group struct {
 	Description string `json:"description,omitempty"`
 	// ParentID holds the value of the "parent_id" field.
 	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	// RequireMfa holds the value of the "require_mfa" field.
+	RequireMfa bool `json:"require_mfa,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
@@ -42,9 +44,21 @@ type GroupEdges struct {
 	Parent *Group `json:"parent,omitempty"`
 	// Children holds the value of the children edge.
 	Children []*Group `json:"children,omitempty"`
+	// OwnerUsers holds the value of the owner_users edge.
+	OwnerUsers []*User `json:"owner_users,omitempty"`
+	// OwnedGroups holds the value of the owned_groups edge.
+	OwnedGroups []*Group `json:"owned_groups,omitempty"`
+	// OwnerGroups holds the value of the owner_groups edge.
+	OwnerGroups []*Group `json:"owner_groups,omitempty"`
+	// Roles holds the value of the roles edge.
+	Roles []*Role `json:"roles,omitempty"`
+	// PasswordPolicies holds the value of the password_policies edge.
+	PasswordPolicies []*PasswordPolicy `json:"password_policies,omitempty"`
+	// AttributeValues holds the value of the attribute_values edge.
+	AttributeValues []*AttributeValue `json:"attribute_values,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [3]bool
+	loadedTypes [9]bool
 }
 
 // UsersOrErr returns the Users value or an error if the edge
@@ -76,6 +90,60 @@ func (e GroupEdges) ChildrenOrErr() ([]*Group, error) {
 	return nil, &NotLoadedError{edge: "children"}
 }
 
+// OwnerUsersOrErr returns the OwnerUsers value or an error if the edge
+// was not loaded in eager-loading.
+func (e GroupEdges) OwnerUsersOrErr() ([]*User, error) {
+	if e.loadedTypes[3] {
+		return e.OwnerUsers, nil
+	}
+	return nil, &NotLoadedError{edge: "owner_users"}
+}
+
+// OwnedGroupsOrErr returns the OwnedGroups value or an error if the edge
+// was not loaded in eager-loading.
+func (e GroupEdges) OwnedGroupsOrErr() ([]*Group, error) {
+	if e.loadedTypes[4] {
+		return e.OwnedGroups, nil
+	}
+	return nil, &NotLoadedError{edge: "owned_groups"}
+}
+
+// OwnerGroupsOrErr returns the OwnerGroups value or an error if the edge
+// was not loaded in eager-loading.
+func (e GroupEdges) OwnerGroupsOrErr() ([]*Group, error) {
+	if e.loadedTypes[5] {
+		return e.OwnerGroups, nil
+	}
+	return nil, &NotLoadedError{edge: "owner_groups"}
+}
+
+// RolesOrErr returns the Roles value or an error if the edge
+// was not loaded in eager-loading.
+func (e GroupEdges) RolesOrErr() ([]*Role, error) {
+	if e.loadedTypes[6] {
+		return e.Roles, nil
+	}
+	return nil, &NotLoadedError{edge: "roles"}
+}
+
+// PasswordPoliciesOrErr returns the PasswordPolicies value or an error if the edge
+// was not loaded in eager-loading.
+func (e GroupEdges) PasswordPoliciesOrErr() ([]*PasswordPolicy, error) {
+	if e.loadedTypes[7] {
+		return e.PasswordPolicies, nil
+	}
+	return nil, &NotLoadedError{edge: "password_policies"}
+}
+
+// AttributeValuesOrErr returns the AttributeValues value or an error if the edge
+// was not loaded in eager-loading.
+func (e GroupEdges) AttributeValuesOrErr() ([]*AttributeValue, error) {
+	if e.loadedTypes[8] {
+		return e.AttributeValues, nil
+	}
+	return nil, &NotLoadedError{edge: "attribute_values"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Group) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -83,6 +151,8 @@ func (*Group) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case group.FieldParentID:
 			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		case group.FieldRequireMfa:
+			values[i] = new(sql.NullBool)
 		case group.FieldName, group.FieldDescription:
 			values[i] = new(sql.NullString)
 		case group.FieldCreatedAt, group.FieldUpdatedAt:
@@ -129,6 +199,12 @@ func (_m *Group) assignValues(columns []string, values []any) error {
 				_m.ParentID = new(uuid.UUID)
 				*_m.ParentID = *value.S.(*uuid.UUID)
 			}
+		case group.FieldRequireMfa:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field require_mfa", values[i])
+			} else if value.Valid {
+				_m.RequireMfa = value.Bool
+			}
 		case group.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -169,6 +245,36 @@ func (_m *Group) QueryChildren() *GroupQuery {
 	return NewGroupClient(_m.config).QueryChildren(_m)
 }
 
+// QueryOwnerUsers queries the "owner_users" edge of the Group entity.
+func (_m *Group) QueryOwnerUsers() *UserQuery {
+	return NewGroupClient(_m.config).QueryOwnerUsers(_m)
+}
+
+// QueryOwnedGroups queries the "owned_groups" edge of the Group entity.
+func (_m *Group) QueryOwnedGroups() *GroupQuery {
+	return NewGroupClient(_m.config).QueryOwnedGroups(_m)
+}
+
+// QueryOwnerGroups queries the "owner_groups" edge of the Group entity.
+func (_m *Group) QueryOwnerGroups() *GroupQuery {
+	return NewGroupClient(_m.config).QueryOwnerGroups(_m)
+}
+
+// QueryRoles queries the "roles" edge of the Group entity.
+func (_m *Group) QueryRoles() *RoleQuery {
+	return NewGroupClient(_m.config).QueryRoles(_m)
+}
+
+// QueryPasswordPolicies queries the "password_policies" edge of the Group entity.
+func (_m *Group) QueryPasswordPolicies() *PasswordPolicyQuery {
+	return NewGroupClient(_m.config).QueryPasswordPolicies(_m)
+}
+
+// QueryAttributeValues queries the "attribute_values" edge of the Group entity.
+func (_m *Group) QueryAttributeValues() *AttributeValueQuery {
+	return NewGroupClient(_m.config).QueryAttributeValues(_m)
+}
+
 // Update returns a builder for updating this Group.
 // Note that you need to call Group.Unwrap() before calling this method if this Group
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -203,6 +309,9 @@ func (_m *Group) String() string {
 		builder.WriteString(fmt.Sprintf("%v", *v))
 	}
 	builder.WriteString(", ")
+	builder.WriteString("require_mfa=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RequireMfa))
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
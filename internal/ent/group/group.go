@@ -21,6 +21,8 @@ const (
 	FieldDescription = "description"
 	// FieldParentID holds the string denoting the parent_id field in the database.
 	FieldParentID = "parent_id"
+	// FieldRequireMfa holds the string denoting the require_mfa field in the database.
+	FieldRequireMfa = "require_mfa"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
@@ -31,6 +33,18 @@ const (
 	EdgeParent = "parent"
 	// EdgeChildren holds the string denoting the children edge name in mutations.
 	EdgeChildren = "children"
+	// EdgeOwnerUsers holds the string denoting the owner_users edge name in mutations.
+	EdgeOwnerUsers = "owner_users"
+	// EdgeOwnedGroups holds the string denoting the owned_groups edge name in mutations.
+	EdgeOwnedGroups = "owned_groups"
+	// EdgeOwnerGroups holds the string denoting the owner_groups edge name in mutations.
+	EdgeOwnerGroups = "owner_groups"
+	// EdgeRoles holds the string denoting the roles edge name in mutations.
+	EdgeRoles = "roles"
+	// EdgePasswordPolicies holds the string denoting the password_policies edge name in mutations.
+	EdgePasswordPolicies = "password_policies"
+	// EdgeAttributeValues holds the string denoting the attribute_values edge name in mutations.
+	EdgeAttributeValues = "attribute_values"
 	// Table holds the table name of the group in the database.
 	Table = "groups"
 	// UsersTable is the table that holds the users relation/edge. The primary key declared below.
@@ -46,6 +60,32 @@ const (
 	ChildrenTable = "groups"
 	// ChildrenColumn is the table column denoting the children relation/edge.
 	ChildrenColumn = "parent_id"
+	// OwnerUsersTable is the table that holds the owner_users relation/edge. The primary key declared below.
+	OwnerUsersTable = "group_owner_users"
+	// OwnerUsersInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	OwnerUsersInverseTable = "users"
+	// OwnedGroupsTable is the table that holds the owned_groups relation/edge. The primary key declared below.
+	OwnedGroupsTable = "group_owner_groups"
+	// OwnerGroupsTable is the table that holds the owner_groups relation/edge. The primary key declared below.
+	OwnerGroupsTable = "group_owner_groups"
+	// RolesTable is the table that holds the roles relation/edge. The primary key declared below.
+	RolesTable = "role_groups"
+	// RolesInverseTable is the table name for the Role entity.
+	// It exists in this package in order to avoid circular dependency with the "role" package.
+	RolesInverseTable = "roles"
+	// PasswordPoliciesTable is the table that holds the password_policies relation/edge. The primary key declared below.
+	PasswordPoliciesTable = "password_policy_groups"
+	// PasswordPoliciesInverseTable is the table name for the PasswordPolicy entity.
+	// It exists in this package in order to avoid circular dependency with the "passwordpolicy" package.
+	PasswordPoliciesInverseTable = "password_policies"
+	// AttributeValuesTable is the table that holds the attribute_values relation/edge.
+	AttributeValuesTable = "attribute_values"
+	// AttributeValuesInverseTable is the table name for the AttributeValue entity.
+	// It exists in this package in order to avoid circular dependency with the "attributevalue" package.
+	AttributeValuesInverseTable = "attribute_values"
+	// AttributeValuesColumn is the table column denoting the attribute_values relation/edge.
+	AttributeValuesColumn = "group_attribute_values"
 )
 
 // Columns holds all SQL columns for group fields.
@@ -54,6 +94,7 @@ var Columns = []string{
 	FieldName,
 	FieldDescription,
 	FieldParentID,
+	FieldRequireMfa,
 	FieldCreatedAt,
 	FieldUpdatedAt,
 }
@@ -62,6 +103,21 @@ var (
 	// UsersPrimaryKey and UsersColumn2 are the table columns denoting the
 	// primary key for the users relation (M2M).
 	UsersPrimaryKey = []string{"group_id", "user_id"}
+	// OwnerUsersPrimaryKey and OwnerUsersColumn2 are the table columns denoting the
+	// primary key for the owner_users relation (M2M).
+	OwnerUsersPrimaryKey = []string{"group_id", "user_id"}
+	// OwnedGroupsPrimaryKey and OwnedGroupsColumn2 are the table columns denoting the
+	// primary key for the owned_groups relation (M2M).
+	OwnedGroupsPrimaryKey = []string{"group_id", "owned_group_id"}
+	// OwnerGroupsPrimaryKey and OwnerGroupsColumn2 are the table columns denoting the
+	// primary key for the owner_groups relation (M2M).
+	OwnerGroupsPrimaryKey = []string{"group_id", "owned_group_id"}
+	// RolesPrimaryKey and RolesColumn2 are the table columns denoting the
+	// primary key for the roles relation (M2M).
+	RolesPrimaryKey = []string{"role_id", "group_id"}
+	// PasswordPoliciesPrimaryKey and PasswordPoliciesColumn2 are the table columns denoting the
+	// primary key for the password_policies relation (M2M).
+	PasswordPoliciesPrimaryKey = []string{"password_policy_id", "group_id"}
 )
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -79,6 +135,8 @@ var (
 	NameValidator func(string) error
 	// DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
 	DescriptionValidator func(string) error
+	// DefaultRequireMfa holds the default value on creation for the "require_mfa" field.
+	DefaultRequireMfa bool
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
 	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
@@ -112,6 +170,11 @@ func ByParentID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldParentID, opts...).ToFunc()
 }
 
+// ByRequireMfa orders the results by the require_mfa field.
+func ByRequireMfa(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRequireMfa, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -156,6 +219,90 @@ func ByChildren(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newChildrenStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByOwnerUsersCount orders the results by owner_users count.
+func ByOwnerUsersCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newOwnerUsersStep(), opts...)
+	}
+}
+
+// ByOwnerUsers orders the results by owner_users terms.
+func ByOwnerUsers(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newOwnerUsersStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByOwnedGroupsCount orders the results by owned_groups count.
+func ByOwnedGroupsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newOwnedGroupsStep(), opts...)
+	}
+}
+
+// ByOwnedGroups orders the results by owned_groups terms.
+func ByOwnedGroups(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newOwnedGroupsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByOwnerGroupsCount orders the results by owner_groups count.
+func ByOwnerGroupsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newOwnerGroupsStep(), opts...)
+	}
+}
+
+// ByOwnerGroups orders the results by owner_groups terms.
+func ByOwnerGroups(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newOwnerGroupsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByRolesCount orders the results by roles count.
+func ByRolesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newRolesStep(), opts...)
+	}
+}
+
+// ByRoles orders the results by roles terms.
+func ByRoles(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newRolesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByPasswordPoliciesCount orders the results by password_policies count.
+func ByPasswordPoliciesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newPasswordPoliciesStep(), opts...)
+	}
+}
+
+// ByPasswordPolicies orders the results by password_policies terms.
+func ByPasswordPolicies(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newPasswordPoliciesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByAttributeValuesCount orders the results by attribute_values count.
+func ByAttributeValuesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newAttributeValuesStep(), opts...)
+	}
+}
+
+// ByAttributeValues orders the results by attribute_values terms.
+func ByAttributeValues(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newAttributeValuesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newUsersStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -177,3 +324,45 @@ func newChildrenStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, ChildrenTable, ChildrenColumn),
 	)
 }
+func newOwnerUsersStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(OwnerUsersInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, false, OwnerUsersTable, OwnerUsersPrimaryKey...),
+	)
+}
+func newOwnedGroupsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(Table, FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, true, OwnedGroupsTable, OwnedGroupsPrimaryKey...),
+	)
+}
+func newOwnerGroupsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(Table, FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, false, OwnerGroupsTable, OwnerGroupsPrimaryKey...),
+	)
+}
+func newRolesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(RolesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, true, RolesTable, RolesPrimaryKey...),
+	)
+}
+func newPasswordPoliciesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(PasswordPoliciesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, true, PasswordPoliciesTable, PasswordPoliciesPrimaryKey...),
+	)
+}
+func newAttributeValuesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(AttributeValuesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, AttributeValuesTable, AttributeValuesColumn),
+	)
+}
@@ -11,7 +11,10 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/attributevalue"
 	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/passwordpolicy"
+	"github.com/qinzj/claude-demo/internal/ent/role"
 	"github.com/qinzj/claude-demo/internal/ent/user"
 )
 
@@ -56,6 +59,20 @@ func (_c *GroupCreate) SetNillableParentID(v *uuid.UUID) *GroupCreate {
 	return _c
 }
 
+// SetRequireMfa sets the "require_mfa" field.
+func (_c *GroupCreate) SetRequireMfa(v bool) *GroupCreate {
+	_c.mutation.SetRequireMfa(v)
+	return _c
+}
+
+// SetNillableRequireMfa sets the "require_mfa" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableRequireMfa(v *bool) *GroupCreate {
+	if v != nil {
+		_c.SetRequireMfa(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *GroupCreate) SetCreatedAt(v time.Time) *GroupCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -133,6 +150,96 @@ func (_c *GroupCreate) AddChildren(v ...*Group) *GroupCreate {
 	return _c.AddChildIDs(ids...)
 }
 
+// AddOwnerUserIDs adds the "owner_users" edge to the User entity by IDs.
+func (_c *GroupCreate) AddOwnerUserIDs(ids ...uuid.UUID) *GroupCreate {
+	_c.mutation.AddOwnerUserIDs(ids...)
+	return _c
+}
+
+// AddOwnerUsers adds the "owner_users" edges to the User entity.
+func (_c *GroupCreate) AddOwnerUsers(v ...*User) *GroupCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddOwnerUserIDs(ids...)
+}
+
+// AddOwnedGroupIDs adds the "owned_groups" edge to the Group entity by IDs.
+func (_c *GroupCreate) AddOwnedGroupIDs(ids ...uuid.UUID) *GroupCreate {
+	_c.mutation.AddOwnedGroupIDs(ids...)
+	return _c
+}
+
+// AddOwnedGroups adds the "owned_groups" edges to the Group entity.
+func (_c *GroupCreate) AddOwnedGroups(v ...*Group) *GroupCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddOwnedGroupIDs(ids...)
+}
+
+// AddOwnerGroupIDs adds the "owner_groups" edge to the Group entity by IDs.
+func (_c *GroupCreate) AddOwnerGroupIDs(ids ...uuid.UUID) *GroupCreate {
+	_c.mutation.AddOwnerGroupIDs(ids...)
+	return _c
+}
+
+// AddOwnerGroups adds the "owner_groups" edges to the Group entity.
+func (_c *GroupCreate) AddOwnerGroups(v ...*Group) *GroupCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddOwnerGroupIDs(ids...)
+}
+
+// AddRoleIDs adds the "roles" edge to the Role entity by IDs.
+func (_c *GroupCreate) AddRoleIDs(ids ...uuid.UUID) *GroupCreate {
+	_c.mutation.AddRoleIDs(ids...)
+	return _c
+}
+
+// AddRoles adds the "roles" edges to the Role entity.
+func (_c *GroupCreate) AddRoles(v ...*Role) *GroupCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddRoleIDs(ids...)
+}
+
+// AddPasswordPolicyIDs adds the "password_policies" edge to the PasswordPolicy entity by IDs.
+func (_c *GroupCreate) AddPasswordPolicyIDs(ids ...uuid.UUID) *GroupCreate {
+	_c.mutation.AddPasswordPolicyIDs(ids...)
+	return _c
+}
+
+// AddPasswordPolicies adds the "password_policies" edges to the PasswordPolicy entity.
+func (_c *GroupCreate) AddPasswordPolicies(v ...*PasswordPolicy) *GroupCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddPasswordPolicyIDs(ids...)
+}
+
+// AddAttributeValueIDs adds the "attribute_values" edge to the AttributeValue entity by IDs.
+func (_c *GroupCreate) AddAttributeValueIDs(ids ...uuid.UUID) *GroupCreate {
+	_c.mutation.AddAttributeValueIDs(ids...)
+	return _c
+}
+
+// AddAttributeValues adds the "attribute_values" edges to the AttributeValue entity.
+func (_c *GroupCreate) AddAttributeValues(v ...*AttributeValue) *GroupCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddAttributeValueIDs(ids...)
+}
+
 // Mutation returns the GroupMutation object of the builder.
 func (_c *GroupCreate) Mutation() *GroupMutation {
 	return _c.mutation
@@ -168,6 +275,10 @@ func (_c *GroupCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (_c *GroupCreate) defaults() {
+	if _, ok := _c.mutation.RequireMfa(); !ok {
+		v := group.DefaultRequireMfa
+		_c.mutation.SetRequireMfa(v)
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		v := group.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
@@ -197,6 +308,9 @@ func (_c *GroupCreate) check() error {
 			return &ValidationError{Name: "description", err: fmt.Errorf(`ent: validator failed for field "Group.description": %w`, err)}
 		}
 	}
+	if _, ok := _c.mutation.RequireMfa(); !ok {
+		return &ValidationError{Name: "require_mfa", err: errors.New(`ent: missing required field "Group.require_mfa"`)}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Group.created_at"`)}
 	}
@@ -246,6 +360,10 @@ func (_c *GroupCreate) createSpec() (*Group, *sqlgraph.CreateSpec) {
 		_spec.SetField(group.FieldDescription, field.TypeString, value)
 		_node.Description = value
 	}
+	if value, ok := _c.mutation.RequireMfa(); ok {
+		_spec.SetField(group.FieldRequireMfa, field.TypeBool, value)
+		_node.RequireMfa = value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(group.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -303,6 +421,102 @@ func (_c *GroupCreate) createSpec() (*Group, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.OwnerUsersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerUsersTable,
+			Columns: group.OwnerUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.OwnedGroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.OwnedGroupsTable,
+			Columns: group.OwnedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.OwnerGroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerGroupsTable,
+			Columns: group.OwnerGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.RolesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.RolesTable,
+			Columns: group.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.PasswordPoliciesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.PasswordPoliciesTable,
+			Columns: group.PasswordPoliciesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(passwordpolicy.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.AttributeValuesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   group.AttributeValuesTable,
+			Columns: []string{group.AttributeValuesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attributevalue.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -12,8 +12,11 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/attributevalue"
 	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/passwordpolicy"
 	"github.com/qinzj/claude-demo/internal/ent/predicate"
+	"github.com/qinzj/claude-demo/internal/ent/role"
 	"github.com/qinzj/claude-demo/internal/ent/user"
 )
 
@@ -84,6 +87,20 @@ func (_u *GroupUpdate) ClearParentID() *GroupUpdate {
 	return _u
 }
 
+// SetRequireMfa sets the "require_mfa" field.
+func (_u *GroupUpdate) SetRequireMfa(v bool) *GroupUpdate {
+	_u.mutation.SetRequireMfa(v)
+	return _u
+}
+
+// SetNillableRequireMfa sets the "require_mfa" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableRequireMfa(v *bool) *GroupUpdate {
+	if v != nil {
+		_u.SetRequireMfa(*v)
+	}
+	return _u
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (_u *GroupUpdate) SetUpdatedAt(v time.Time) *GroupUpdate {
 	_u.mutation.SetUpdatedAt(v)
@@ -125,6 +142,96 @@ func (_u *GroupUpdate) AddChildren(v ...*Group) *GroupUpdate {
 	return _u.AddChildIDs(ids...)
 }
 
+// AddOwnerUserIDs adds the "owner_users" edge to the User entity by IDs.
+func (_u *GroupUpdate) AddOwnerUserIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.AddOwnerUserIDs(ids...)
+	return _u
+}
+
+// AddOwnerUsers adds the "owner_users" edges to the User entity.
+func (_u *GroupUpdate) AddOwnerUsers(v ...*User) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOwnerUserIDs(ids...)
+}
+
+// AddOwnedGroupIDs adds the "owned_groups" edge to the Group entity by IDs.
+func (_u *GroupUpdate) AddOwnedGroupIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.AddOwnedGroupIDs(ids...)
+	return _u
+}
+
+// AddOwnedGroups adds the "owned_groups" edges to the Group entity.
+func (_u *GroupUpdate) AddOwnedGroups(v ...*Group) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOwnedGroupIDs(ids...)
+}
+
+// AddOwnerGroupIDs adds the "owner_groups" edge to the Group entity by IDs.
+func (_u *GroupUpdate) AddOwnerGroupIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.AddOwnerGroupIDs(ids...)
+	return _u
+}
+
+// AddOwnerGroups adds the "owner_groups" edges to the Group entity.
+func (_u *GroupUpdate) AddOwnerGroups(v ...*Group) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOwnerGroupIDs(ids...)
+}
+
+// AddRoleIDs adds the "roles" edge to the Role entity by IDs.
+func (_u *GroupUpdate) AddRoleIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.AddRoleIDs(ids...)
+	return _u
+}
+
+// AddRoles adds the "roles" edges to the Role entity.
+func (_u *GroupUpdate) AddRoles(v ...*Role) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddRoleIDs(ids...)
+}
+
+// AddPasswordPolicyIDs adds the "password_policies" edge to the PasswordPolicy entity by IDs.
+func (_u *GroupUpdate) AddPasswordPolicyIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.AddPasswordPolicyIDs(ids...)
+	return _u
+}
+
+// AddPasswordPolicies adds the "password_policies" edges to the PasswordPolicy entity.
+func (_u *GroupUpdate) AddPasswordPolicies(v ...*PasswordPolicy) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddPasswordPolicyIDs(ids...)
+}
+
+// AddAttributeValueIDs adds the "attribute_values" edge to the AttributeValue entity by IDs.
+func (_u *GroupUpdate) AddAttributeValueIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.AddAttributeValueIDs(ids...)
+	return _u
+}
+
+// AddAttributeValues adds the "attribute_values" edges to the AttributeValue entity.
+func (_u *GroupUpdate) AddAttributeValues(v ...*AttributeValue) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddAttributeValueIDs(ids...)
+}
+
 // Mutation returns the GroupMutation object of the builder.
 func (_u *GroupUpdate) Mutation() *GroupMutation {
 	return _u.mutation
@@ -178,6 +285,132 @@ func (_u *GroupUpdate) RemoveChildren(v ...*Group) *GroupUpdate {
 	return _u.RemoveChildIDs(ids...)
 }
 
+// ClearOwnerUsers clears all "owner_users" edges to the User entity.
+func (_u *GroupUpdate) ClearOwnerUsers() *GroupUpdate {
+	_u.mutation.ClearOwnerUsers()
+	return _u
+}
+
+// RemoveOwnerUserIDs removes the "owner_users" edge to User entities by IDs.
+func (_u *GroupUpdate) RemoveOwnerUserIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.RemoveOwnerUserIDs(ids...)
+	return _u
+}
+
+// RemoveOwnerUsers removes "owner_users" edges to User entities.
+func (_u *GroupUpdate) RemoveOwnerUsers(v ...*User) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveOwnerUserIDs(ids...)
+}
+
+// ClearOwnedGroups clears all "owned_groups" edges to the Group entity.
+func (_u *GroupUpdate) ClearOwnedGroups() *GroupUpdate {
+	_u.mutation.ClearOwnedGroups()
+	return _u
+}
+
+// RemoveOwnedGroupIDs removes the "owned_groups" edge to Group entities by IDs.
+func (_u *GroupUpdate) RemoveOwnedGroupIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.RemoveOwnedGroupIDs(ids...)
+	return _u
+}
+
+// RemoveOwnedGroups removes "owned_groups" edges to Group entities.
+func (_u *GroupUpdate) RemoveOwnedGroups(v ...*Group) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveOwnedGroupIDs(ids...)
+}
+
+// ClearOwnerGroups clears all "owner_groups" edges to the Group entity.
+func (_u *GroupUpdate) ClearOwnerGroups() *GroupUpdate {
+	_u.mutation.ClearOwnerGroups()
+	return _u
+}
+
+// RemoveOwnerGroupIDs removes the "owner_groups" edge to Group entities by IDs.
+func (_u *GroupUpdate) RemoveOwnerGroupIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.RemoveOwnerGroupIDs(ids...)
+	return _u
+}
+
+// RemoveOwnerGroups removes "owner_groups" edges to Group entities.
+func (_u *GroupUpdate) RemoveOwnerGroups(v ...*Group) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveOwnerGroupIDs(ids...)
+}
+
+// ClearRoles clears all "roles" edges to the Role entity.
+func (_u *GroupUpdate) ClearRoles() *GroupUpdate {
+	_u.mutation.ClearRoles()
+	return _u
+}
+
+// RemoveRoleIDs removes the "roles" edge to Role entities by IDs.
+func (_u *GroupUpdate) RemoveRoleIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.RemoveRoleIDs(ids...)
+	return _u
+}
+
+// RemoveRoles removes "roles" edges to Role entities.
+func (_u *GroupUpdate) RemoveRoles(v ...*Role) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveRoleIDs(ids...)
+}
+
+// ClearPasswordPolicies clears all "password_policies" edges to the PasswordPolicy entity.
+func (_u *GroupUpdate) ClearPasswordPolicies() *GroupUpdate {
+	_u.mutation.ClearPasswordPolicies()
+	return _u
+}
+
+// RemovePasswordPolicyIDs removes the "password_policies" edge to PasswordPolicy entities by IDs.
+func (_u *GroupUpdate) RemovePasswordPolicyIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.RemovePasswordPolicyIDs(ids...)
+	return _u
+}
+
+// RemovePasswordPolicies removes "password_policies" edges to PasswordPolicy entities.
+func (_u *GroupUpdate) RemovePasswordPolicies(v ...*PasswordPolicy) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemovePasswordPolicyIDs(ids...)
+}
+
+// ClearAttributeValues clears all "attribute_values" edges to the AttributeValue entity.
+func (_u *GroupUpdate) ClearAttributeValues() *GroupUpdate {
+	_u.mutation.ClearAttributeValues()
+	return _u
+}
+
+// RemoveAttributeValueIDs removes the "attribute_values" edge to AttributeValue entities by IDs.
+func (_u *GroupUpdate) RemoveAttributeValueIDs(ids ...uuid.UUID) *GroupUpdate {
+	_u.mutation.RemoveAttributeValueIDs(ids...)
+	return _u
+}
+
+// RemoveAttributeValues removes "attribute_values" edges to AttributeValue entities.
+func (_u *GroupUpdate) RemoveAttributeValues(v ...*AttributeValue) *GroupUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveAttributeValueIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *GroupUpdate) Save(ctx context.Context) (int, error) {
 	_u.defaults()
@@ -250,6 +483,9 @@ func (_u *GroupUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.DescriptionCleared() {
 		_spec.ClearField(group.FieldDescription, field.TypeString)
 	}
+	if value, ok := _u.mutation.RequireMfa(); ok {
+		_spec.SetField(group.FieldRequireMfa, field.TypeBool, value)
+	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(group.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -372,172 +608,672 @@ func (_u *GroupUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
-		if _, ok := err.(*sqlgraph.NotFoundError); ok {
-			err = &NotFoundError{group.Label}
-		} else if sqlgraph.IsConstraintError(err) {
-			err = &ConstraintError{msg: err.Error(), wrap: err}
+	if _u.mutation.OwnerUsersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerUsersTable,
+			Columns: group.OwnerUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
 		}
-		return 0, err
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	_u.mutation.done = true
-	return _node, nil
-}
-
-// GroupUpdateOne is the builder for updating a single Group entity.
-type GroupUpdateOne struct {
-	config
-	fields   []string
-	hooks    []Hook
-	mutation *GroupMutation
-}
-
-// SetName sets the "name" field.
-func (_u *GroupUpdateOne) SetName(v string) *GroupUpdateOne {
-	_u.mutation.SetName(v)
-	return _u
-}
-
-// SetNillableName sets the "name" field if the given value is not nil.
-func (_u *GroupUpdateOne) SetNillableName(v *string) *GroupUpdateOne {
-	if v != nil {
-		_u.SetName(*v)
+	if nodes := _u.mutation.RemovedOwnerUsersIDs(); len(nodes) > 0 && !_u.mutation.OwnerUsersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerUsersTable,
+			Columns: group.OwnerUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	return _u
-}
-
-// SetDescription sets the "description" field.
-func (_u *GroupUpdateOne) SetDescription(v string) *GroupUpdateOne {
-	_u.mutation.SetDescription(v)
-	return _u
-}
-
-// SetNillableDescription sets the "description" field if the given value is not nil.
-func (_u *GroupUpdateOne) SetNillableDescription(v *string) *GroupUpdateOne {
-	if v != nil {
-		_u.SetDescription(*v)
+	if nodes := _u.mutation.OwnerUsersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerUsersTable,
+			Columns: group.OwnerUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	return _u
-}
-
-// ClearDescription clears the value of the "description" field.
-func (_u *GroupUpdateOne) ClearDescription() *GroupUpdateOne {
-	_u.mutation.ClearDescription()
-	return _u
-}
-
-// SetParentID sets the "parent_id" field.
-func (_u *GroupUpdateOne) SetParentID(v uuid.UUID) *GroupUpdateOne {
-	_u.mutation.SetParentID(v)
-	return _u
-}
-
-// SetNillableParentID sets the "parent_id" field if the given value is not nil.
-func (_u *GroupUpdateOne) SetNillableParentID(v *uuid.UUID) *GroupUpdateOne {
-	if v != nil {
-		_u.SetParentID(*v)
+	if _u.mutation.OwnedGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.OwnedGroupsTable,
+			Columns: group.OwnedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	return _u
-}
-
-// ClearParentID clears the value of the "parent_id" field.
-func (_u *GroupUpdateOne) ClearParentID() *GroupUpdateOne {
-	_u.mutation.ClearParentID()
-	return _u
-}
-
-// SetUpdatedAt sets the "updated_at" field.
-func (_u *GroupUpdateOne) SetUpdatedAt(v time.Time) *GroupUpdateOne {
-	_u.mutation.SetUpdatedAt(v)
-	return _u
-}
-
-// AddUserIDs adds the "users" edge to the User entity by IDs.
-func (_u *GroupUpdateOne) AddUserIDs(ids ...uuid.UUID) *GroupUpdateOne {
-	_u.mutation.AddUserIDs(ids...)
-	return _u
-}
+	if nodes := _u.mutation.RemovedOwnedGroupsIDs(); len(nodes) > 0 && !_u.mutation.OwnedGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.OwnedGroupsTable,
+			Columns: group.OwnedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnedGroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.OwnedGroupsTable,
+			Columns: group.OwnedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OwnerGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerGroupsTable,
+			Columns: group.OwnerGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedOwnerGroupsIDs(); len(nodes) > 0 && !_u.mutation.OwnerGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerGroupsTable,
+			Columns: group.OwnerGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerGroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerGroupsTable,
+			Columns: group.OwnerGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.RolesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.RolesTable,
+			Columns: group.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedRolesIDs(); len(nodes) > 0 && !_u.mutation.RolesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.RolesTable,
+			Columns: group.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RolesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.RolesTable,
+			Columns: group.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.PasswordPoliciesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.PasswordPoliciesTable,
+			Columns: group.PasswordPoliciesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(passwordpolicy.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedPasswordPoliciesIDs(); len(nodes) > 0 && !_u.mutation.PasswordPoliciesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.PasswordPoliciesTable,
+			Columns: group.PasswordPoliciesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(passwordpolicy.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PasswordPoliciesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.PasswordPoliciesTable,
+			Columns: group.PasswordPoliciesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(passwordpolicy.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.AttributeValuesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   group.AttributeValuesTable,
+			Columns: []string{group.AttributeValuesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attributevalue.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedAttributeValuesIDs(); len(nodes) > 0 && !_u.mutation.AttributeValuesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   group.AttributeValuesTable,
+			Columns: []string{group.AttributeValuesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attributevalue.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.AttributeValuesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   group.AttributeValuesTable,
+			Columns: []string{group.AttributeValuesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attributevalue.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{group.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// GroupUpdateOne is the builder for updating a single Group entity.
+type GroupUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *GroupMutation
+}
+
+// SetName sets the "name" field.
+func (_u *GroupUpdateOne) SetName(v string) *GroupUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableName(v *string) *GroupUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *GroupUpdateOne) SetDescription(v string) *GroupUpdateOne {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableDescription(v *string) *GroupUpdateOne {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *GroupUpdateOne) ClearDescription() *GroupUpdateOne {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetParentID sets the "parent_id" field.
+func (_u *GroupUpdateOne) SetParentID(v uuid.UUID) *GroupUpdateOne {
+	_u.mutation.SetParentID(v)
+	return _u
+}
+
+// SetNillableParentID sets the "parent_id" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableParentID(v *uuid.UUID) *GroupUpdateOne {
+	if v != nil {
+		_u.SetParentID(*v)
+	}
+	return _u
+}
+
+// ClearParentID clears the value of the "parent_id" field.
+func (_u *GroupUpdateOne) ClearParentID() *GroupUpdateOne {
+	_u.mutation.ClearParentID()
+	return _u
+}
+
+// SetRequireMfa sets the "require_mfa" field.
+func (_u *GroupUpdateOne) SetRequireMfa(v bool) *GroupUpdateOne {
+	_u.mutation.SetRequireMfa(v)
+	return _u
+}
+
+// SetNillableRequireMfa sets the "require_mfa" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableRequireMfa(v *bool) *GroupUpdateOne {
+	if v != nil {
+		_u.SetRequireMfa(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *GroupUpdateOne) SetUpdatedAt(v time.Time) *GroupUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddUserIDs adds the "users" edge to the User entity by IDs.
+func (_u *GroupUpdateOne) AddUserIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.AddUserIDs(ids...)
+	return _u
+}
+
+// AddUsers adds the "users" edges to the User entity.
+func (_u *GroupUpdateOne) AddUsers(v ...*User) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddUserIDs(ids...)
+}
+
+// SetParent sets the "parent" edge to the Group entity.
+func (_u *GroupUpdateOne) SetParent(v *Group) *GroupUpdateOne {
+	return _u.SetParentID(v.ID)
+}
+
+// AddChildIDs adds the "children" edge to the Group entity by IDs.
+func (_u *GroupUpdateOne) AddChildIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.AddChildIDs(ids...)
+	return _u
+}
+
+// AddChildren adds the "children" edges to the Group entity.
+func (_u *GroupUpdateOne) AddChildren(v ...*Group) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddChildIDs(ids...)
+}
+
+// AddOwnerUserIDs adds the "owner_users" edge to the User entity by IDs.
+func (_u *GroupUpdateOne) AddOwnerUserIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.AddOwnerUserIDs(ids...)
+	return _u
+}
+
+// AddOwnerUsers adds the "owner_users" edges to the User entity.
+func (_u *GroupUpdateOne) AddOwnerUsers(v ...*User) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOwnerUserIDs(ids...)
+}
+
+// AddOwnedGroupIDs adds the "owned_groups" edge to the Group entity by IDs.
+func (_u *GroupUpdateOne) AddOwnedGroupIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.AddOwnedGroupIDs(ids...)
+	return _u
+}
+
+// AddOwnedGroups adds the "owned_groups" edges to the Group entity.
+func (_u *GroupUpdateOne) AddOwnedGroups(v ...*Group) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOwnedGroupIDs(ids...)
+}
+
+// AddOwnerGroupIDs adds the "owner_groups" edge to the Group entity by IDs.
+func (_u *GroupUpdateOne) AddOwnerGroupIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.AddOwnerGroupIDs(ids...)
+	return _u
+}
+
+// AddOwnerGroups adds the "owner_groups" edges to the Group entity.
+func (_u *GroupUpdateOne) AddOwnerGroups(v ...*Group) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOwnerGroupIDs(ids...)
+}
+
+// AddRoleIDs adds the "roles" edge to the Role entity by IDs.
+func (_u *GroupUpdateOne) AddRoleIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.AddRoleIDs(ids...)
+	return _u
+}
+
+// AddRoles adds the "roles" edges to the Role entity.
+func (_u *GroupUpdateOne) AddRoles(v ...*Role) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddRoleIDs(ids...)
+}
+
+// AddPasswordPolicyIDs adds the "password_policies" edge to the PasswordPolicy entity by IDs.
+func (_u *GroupUpdateOne) AddPasswordPolicyIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.AddPasswordPolicyIDs(ids...)
+	return _u
+}
+
+// AddPasswordPolicies adds the "password_policies" edges to the PasswordPolicy entity.
+func (_u *GroupUpdateOne) AddPasswordPolicies(v ...*PasswordPolicy) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddPasswordPolicyIDs(ids...)
+}
+
+// AddAttributeValueIDs adds the "attribute_values" edge to the AttributeValue entity by IDs.
+func (_u *GroupUpdateOne) AddAttributeValueIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.AddAttributeValueIDs(ids...)
+	return _u
+}
+
+// AddAttributeValues adds the "attribute_values" edges to the AttributeValue entity.
+func (_u *GroupUpdateOne) AddAttributeValues(v ...*AttributeValue) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddAttributeValueIDs(ids...)
+}
+
+// Mutation returns the GroupMutation object of the builder.
+func (_u *GroupUpdateOne) Mutation() *GroupMutation {
+	return _u.mutation
+}
+
+// ClearUsers clears all "users" edges to the User entity.
+func (_u *GroupUpdateOne) ClearUsers() *GroupUpdateOne {
+	_u.mutation.ClearUsers()
+	return _u
+}
+
+// RemoveUserIDs removes the "users" edge to User entities by IDs.
+func (_u *GroupUpdateOne) RemoveUserIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.RemoveUserIDs(ids...)
+	return _u
+}
+
+// RemoveUsers removes "users" edges to User entities.
+func (_u *GroupUpdateOne) RemoveUsers(v ...*User) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveUserIDs(ids...)
+}
+
+// ClearParent clears the "parent" edge to the Group entity.
+func (_u *GroupUpdateOne) ClearParent() *GroupUpdateOne {
+	_u.mutation.ClearParent()
+	return _u
+}
+
+// ClearChildren clears all "children" edges to the Group entity.
+func (_u *GroupUpdateOne) ClearChildren() *GroupUpdateOne {
+	_u.mutation.ClearChildren()
+	return _u
+}
+
+// RemoveChildIDs removes the "children" edge to Group entities by IDs.
+func (_u *GroupUpdateOne) RemoveChildIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.RemoveChildIDs(ids...)
+	return _u
+}
+
+// RemoveChildren removes "children" edges to Group entities.
+func (_u *GroupUpdateOne) RemoveChildren(v ...*Group) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveChildIDs(ids...)
+}
+
+// ClearOwnerUsers clears all "owner_users" edges to the User entity.
+func (_u *GroupUpdateOne) ClearOwnerUsers() *GroupUpdateOne {
+	_u.mutation.ClearOwnerUsers()
+	return _u
+}
+
+// RemoveOwnerUserIDs removes the "owner_users" edge to User entities by IDs.
+func (_u *GroupUpdateOne) RemoveOwnerUserIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.RemoveOwnerUserIDs(ids...)
+	return _u
+}
+
+// RemoveOwnerUsers removes "owner_users" edges to User entities.
+func (_u *GroupUpdateOne) RemoveOwnerUsers(v ...*User) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveOwnerUserIDs(ids...)
+}
+
+// ClearOwnedGroups clears all "owned_groups" edges to the Group entity.
+func (_u *GroupUpdateOne) ClearOwnedGroups() *GroupUpdateOne {
+	_u.mutation.ClearOwnedGroups()
+	return _u
+}
+
+// RemoveOwnedGroupIDs removes the "owned_groups" edge to Group entities by IDs.
+func (_u *GroupUpdateOne) RemoveOwnedGroupIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.RemoveOwnedGroupIDs(ids...)
+	return _u
+}
 
-// AddUsers adds the "users" edges to the User entity.
-func (_u *GroupUpdateOne) AddUsers(v ...*User) *GroupUpdateOne {
+// RemoveOwnedGroups removes "owned_groups" edges to Group entities.
+func (_u *GroupUpdateOne) RemoveOwnedGroups(v ...*Group) *GroupUpdateOne {
 	ids := make([]uuid.UUID, len(v))
 	for i := range v {
 		ids[i] = v[i].ID
 	}
-	return _u.AddUserIDs(ids...)
+	return _u.RemoveOwnedGroupIDs(ids...)
 }
 
-// SetParent sets the "parent" edge to the Group entity.
-func (_u *GroupUpdateOne) SetParent(v *Group) *GroupUpdateOne {
-	return _u.SetParentID(v.ID)
+// ClearOwnerGroups clears all "owner_groups" edges to the Group entity.
+func (_u *GroupUpdateOne) ClearOwnerGroups() *GroupUpdateOne {
+	_u.mutation.ClearOwnerGroups()
+	return _u
 }
 
-// AddChildIDs adds the "children" edge to the Group entity by IDs.
-func (_u *GroupUpdateOne) AddChildIDs(ids ...uuid.UUID) *GroupUpdateOne {
-	_u.mutation.AddChildIDs(ids...)
+// RemoveOwnerGroupIDs removes the "owner_groups" edge to Group entities by IDs.
+func (_u *GroupUpdateOne) RemoveOwnerGroupIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.RemoveOwnerGroupIDs(ids...)
 	return _u
 }
 
-// AddChildren adds the "children" edges to the Group entity.
-func (_u *GroupUpdateOne) AddChildren(v ...*Group) *GroupUpdateOne {
+// RemoveOwnerGroups removes "owner_groups" edges to Group entities.
+func (_u *GroupUpdateOne) RemoveOwnerGroups(v ...*Group) *GroupUpdateOne {
 	ids := make([]uuid.UUID, len(v))
 	for i := range v {
 		ids[i] = v[i].ID
 	}
-	return _u.AddChildIDs(ids...)
-}
-
-// Mutation returns the GroupMutation object of the builder.
-func (_u *GroupUpdateOne) Mutation() *GroupMutation {
-	return _u.mutation
+	return _u.RemoveOwnerGroupIDs(ids...)
 }
 
-// ClearUsers clears all "users" edges to the User entity.
-func (_u *GroupUpdateOne) ClearUsers() *GroupUpdateOne {
-	_u.mutation.ClearUsers()
+// ClearRoles clears all "roles" edges to the Role entity.
+func (_u *GroupUpdateOne) ClearRoles() *GroupUpdateOne {
+	_u.mutation.ClearRoles()
 	return _u
 }
 
-// RemoveUserIDs removes the "users" edge to User entities by IDs.
-func (_u *GroupUpdateOne) RemoveUserIDs(ids ...uuid.UUID) *GroupUpdateOne {
-	_u.mutation.RemoveUserIDs(ids...)
+// RemoveRoleIDs removes the "roles" edge to Role entities by IDs.
+func (_u *GroupUpdateOne) RemoveRoleIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.RemoveRoleIDs(ids...)
 	return _u
 }
 
-// RemoveUsers removes "users" edges to User entities.
-func (_u *GroupUpdateOne) RemoveUsers(v ...*User) *GroupUpdateOne {
+// RemoveRoles removes "roles" edges to Role entities.
+func (_u *GroupUpdateOne) RemoveRoles(v ...*Role) *GroupUpdateOne {
 	ids := make([]uuid.UUID, len(v))
 	for i := range v {
 		ids[i] = v[i].ID
 	}
-	return _u.RemoveUserIDs(ids...)
+	return _u.RemoveRoleIDs(ids...)
 }
 
-// ClearParent clears the "parent" edge to the Group entity.
-func (_u *GroupUpdateOne) ClearParent() *GroupUpdateOne {
-	_u.mutation.ClearParent()
+// ClearPasswordPolicies clears all "password_policies" edges to the PasswordPolicy entity.
+func (_u *GroupUpdateOne) ClearPasswordPolicies() *GroupUpdateOne {
+	_u.mutation.ClearPasswordPolicies()
 	return _u
 }
 
-// ClearChildren clears all "children" edges to the Group entity.
-func (_u *GroupUpdateOne) ClearChildren() *GroupUpdateOne {
-	_u.mutation.ClearChildren()
+// RemovePasswordPolicyIDs removes the "password_policies" edge to PasswordPolicy entities by IDs.
+func (_u *GroupUpdateOne) RemovePasswordPolicyIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.RemovePasswordPolicyIDs(ids...)
 	return _u
 }
 
-// RemoveChildIDs removes the "children" edge to Group entities by IDs.
-func (_u *GroupUpdateOne) RemoveChildIDs(ids ...uuid.UUID) *GroupUpdateOne {
-	_u.mutation.RemoveChildIDs(ids...)
+// RemovePasswordPolicies removes "password_policies" edges to PasswordPolicy entities.
+func (_u *GroupUpdateOne) RemovePasswordPolicies(v ...*PasswordPolicy) *GroupUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemovePasswordPolicyIDs(ids...)
+}
+
+// ClearAttributeValues clears all "attribute_values" edges to the AttributeValue entity.
+func (_u *GroupUpdateOne) ClearAttributeValues() *GroupUpdateOne {
+	_u.mutation.ClearAttributeValues()
 	return _u
 }
 
-// RemoveChildren removes "children" edges to Group entities.
-func (_u *GroupUpdateOne) RemoveChildren(v ...*Group) *GroupUpdateOne {
+// RemoveAttributeValueIDs removes the "attribute_values" edge to AttributeValue entities by IDs.
+func (_u *GroupUpdateOne) RemoveAttributeValueIDs(ids ...uuid.UUID) *GroupUpdateOne {
+	_u.mutation.RemoveAttributeValueIDs(ids...)
+	return _u
+}
+
+// RemoveAttributeValues removes "attribute_values" edges to AttributeValue entities.
+func (_u *GroupUpdateOne) RemoveAttributeValues(v ...*AttributeValue) *GroupUpdateOne {
 	ids := make([]uuid.UUID, len(v))
 	for i := range v {
 		ids[i] = v[i].ID
 	}
-	return _u.RemoveChildIDs(ids...)
+	return _u.RemoveAttributeValueIDs(ids...)
 }
 
 // Where appends a list predicates to the GroupUpdate builder.
@@ -642,6 +1378,9 @@ func (_u *GroupUpdateOne) sqlSave(ctx context.Context) (_node *Group, err error)
 	if _u.mutation.DescriptionCleared() {
 		_spec.ClearField(group.FieldDescription, field.TypeString)
 	}
+	if value, ok := _u.mutation.RequireMfa(); ok {
+		_spec.SetField(group.FieldRequireMfa, field.TypeBool, value)
+	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(group.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -764,6 +1503,276 @@ func (_u *GroupUpdateOne) sqlSave(ctx context.Context) (_node *Group, err error)
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.OwnerUsersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerUsersTable,
+			Columns: group.OwnerUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedOwnerUsersIDs(); len(nodes) > 0 && !_u.mutation.OwnerUsersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerUsersTable,
+			Columns: group.OwnerUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerUsersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerUsersTable,
+			Columns: group.OwnerUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OwnedGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.OwnedGroupsTable,
+			Columns: group.OwnedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedOwnedGroupsIDs(); len(nodes) > 0 && !_u.mutation.OwnedGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.OwnedGroupsTable,
+			Columns: group.OwnedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnedGroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.OwnedGroupsTable,
+			Columns: group.OwnedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OwnerGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerGroupsTable,
+			Columns: group.OwnerGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedOwnerGroupsIDs(); len(nodes) > 0 && !_u.mutation.OwnerGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerGroupsTable,
+			Columns: group.OwnerGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerGroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   group.OwnerGroupsTable,
+			Columns: group.OwnerGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.RolesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.RolesTable,
+			Columns: group.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedRolesIDs(); len(nodes) > 0 && !_u.mutation.RolesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.RolesTable,
+			Columns: group.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RolesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.RolesTable,
+			Columns: group.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.PasswordPoliciesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.PasswordPoliciesTable,
+			Columns: group.PasswordPoliciesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(passwordpolicy.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedPasswordPoliciesIDs(); len(nodes) > 0 && !_u.mutation.PasswordPoliciesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.PasswordPoliciesTable,
+			Columns: group.PasswordPoliciesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(passwordpolicy.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PasswordPoliciesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   group.PasswordPoliciesTable,
+			Columns: group.PasswordPoliciesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(passwordpolicy.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.AttributeValuesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   group.AttributeValuesTable,
+			Columns: []string{group.AttributeValuesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attributevalue.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedAttributeValuesIDs(); len(nodes) > 0 && !_u.mutation.AttributeValuesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   group.AttributeValuesTable,
+			Columns: []string{group.AttributeValuesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attributevalue.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.AttributeValuesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   group.AttributeValuesTable,
+			Columns: []string{group.AttributeValuesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attributevalue.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Group{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,313 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/groupmembershipchange"
+)
+
+// GroupMembershipChangeCreate is the builder for creating a GroupMembershipChange entity.
+type GroupMembershipChangeCreate struct {
+	config
+	mutation *GroupMembershipChangeMutation
+	hooks    []Hook
+}
+
+// SetGroupID sets the "group_id" field.
+func (_c *GroupMembershipChangeCreate) SetGroupID(v uuid.UUID) *GroupMembershipChangeCreate {
+	_c.mutation.SetGroupID(v)
+	return _c
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *GroupMembershipChangeCreate) SetUserID(v uuid.UUID) *GroupMembershipChangeCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetOperation sets the "operation" field.
+func (_c *GroupMembershipChangeCreate) SetOperation(v groupmembershipchange.Operation) *GroupMembershipChangeCreate {
+	_c.mutation.SetOperation(v)
+	return _c
+}
+
+// SetActorID sets the "actor_id" field.
+func (_c *GroupMembershipChangeCreate) SetActorID(v uuid.UUID) *GroupMembershipChangeCreate {
+	_c.mutation.SetActorID(v)
+	return _c
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (_c *GroupMembershipChangeCreate) SetNillableActorID(v *uuid.UUID) *GroupMembershipChangeCreate {
+	if v != nil {
+		_c.SetActorID(*v)
+	}
+	return _c
+}
+
+// SetActorName sets the "actor_name" field.
+func (_c *GroupMembershipChangeCreate) SetActorName(v string) *GroupMembershipChangeCreate {
+	_c.mutation.SetActorName(v)
+	return _c
+}
+
+// SetNillableActorName sets the "actor_name" field if the given value is not nil.
+func (_c *GroupMembershipChangeCreate) SetNillableActorName(v *string) *GroupMembershipChangeCreate {
+	if v != nil {
+		_c.SetActorName(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *GroupMembershipChangeCreate) SetCreatedAt(v time.Time) *GroupMembershipChangeCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *GroupMembershipChangeCreate) SetNillableCreatedAt(v *time.Time) *GroupMembershipChangeCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *GroupMembershipChangeCreate) SetID(v uuid.UUID) *GroupMembershipChangeCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *GroupMembershipChangeCreate) SetNillableID(v *uuid.UUID) *GroupMembershipChangeCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the GroupMembershipChangeMutation object of the builder.
+func (_c *GroupMembershipChangeCreate) Mutation() *GroupMembershipChangeMutation {
+	return _c.mutation
+}
+
+// Save creates the GroupMembershipChange in the database.
+func (_c *GroupMembershipChangeCreate) Save(ctx context.Context) (*GroupMembershipChange, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *GroupMembershipChangeCreate) SaveX(ctx context.Context) *GroupMembershipChange {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *GroupMembershipChangeCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *GroupMembershipChangeCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *GroupMembershipChangeCreate) defaults() {
+	if _, ok := _c.mutation.ActorName(); !ok {
+		v := groupmembershipchange.DefaultActorName
+		_c.mutation.SetActorName(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := groupmembershipchange.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := groupmembershipchange.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *GroupMembershipChangeCreate) check() error {
+	if _, ok := _c.mutation.GroupID(); !ok {
+		return &ValidationError{Name: "group_id", err: errors.New(`ent: missing required field "GroupMembershipChange.group_id"`)}
+	}
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "GroupMembershipChange.user_id"`)}
+	}
+	if _, ok := _c.mutation.Operation(); !ok {
+		return &ValidationError{Name: "operation", err: errors.New(`ent: missing required field "GroupMembershipChange.operation"`)}
+	}
+	if v, ok := _c.mutation.Operation(); ok {
+		if err := groupmembershipchange.OperationValidator(v); err != nil {
+			return &ValidationError{Name: "operation", err: fmt.Errorf(`ent: validator failed for field "GroupMembershipChange.operation": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ActorName(); !ok {
+		return &ValidationError{Name: "actor_name", err: errors.New(`ent: missing required field "GroupMembershipChange.actor_name"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "GroupMembershipChange.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *GroupMembershipChangeCreate) sqlSave(ctx context.Context) (*GroupMembershipChange, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *GroupMembershipChangeCreate) createSpec() (*GroupMembershipChange, *sqlgraph.CreateSpec) {
+	var (
+		_node = &GroupMembershipChange{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(groupmembershipchange.Table, sqlgraph.NewFieldSpec(groupmembershipchange.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.GroupID(); ok {
+		_spec.SetField(groupmembershipchange.FieldGroupID, field.TypeUUID, value)
+		_node.GroupID = value
+	}
+	if value, ok := _c.mutation.UserID(); ok {
+		_spec.SetField(groupmembershipchange.FieldUserID, field.TypeUUID, value)
+		_node.UserID = value
+	}
+	if value, ok := _c.mutation.Operation(); ok {
+		_spec.SetField(groupmembershipchange.FieldOperation, field.TypeEnum, value)
+		_node.Operation = value
+	}
+	if value, ok := _c.mutation.ActorID(); ok {
+		_spec.SetField(groupmembershipchange.FieldActorID, field.TypeUUID, value)
+		_node.ActorID = &value
+	}
+	if value, ok := _c.mutation.ActorName(); ok {
+		_spec.SetField(groupmembershipchange.FieldActorName, field.TypeString, value)
+		_node.ActorName = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(groupmembershipchange.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// GroupMembershipChangeCreateBulk is the builder for creating many GroupMembershipChange entities in bulk.
+type GroupMembershipChangeCreateBulk struct {
+	config
+	err      error
+	builders []*GroupMembershipChangeCreate
+}
+
+// Save creates the GroupMembershipChange entities in the database.
+func (_c *GroupMembershipChangeCreateBulk) Save(ctx context.Context) ([]*GroupMembershipChange, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*GroupMembershipChange, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*GroupMembershipChangeMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *GroupMembershipChangeCreateBulk) SaveX(ctx context.Context) []*GroupMembershipChange {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *GroupMembershipChangeCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *GroupMembershipChangeCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/qinzj/claude-demo/internal/ent/groupmembershipchange"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// GroupMembershipChangeDelete is the builder for deleting a GroupMembershipChange entity.
+type GroupMembershipChangeDelete struct {
+	config
+	hooks    []Hook
+	mutation *GroupMembershipChangeMutation
+}
+
+// Where appends a list predicates to the GroupMembershipChangeDelete builder.
+func (_d *GroupMembershipChangeDelete) Where(ps ...predicate.GroupMembershipChange) *GroupMembershipChangeDelete {
+	_d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (_d *GroupMembershipChangeDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, _d.sqlExec, _d.mutation, _d.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *GroupMembershipChangeDelete) ExecX(ctx context.Context) int {
+	n, err := _d.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (_d *GroupMembershipChangeDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(groupmembershipchange.Table, sqlgraph.NewFieldSpec(groupmembershipchange.FieldID, field.TypeUUID))
+	if ps := _d.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, _d.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	_d.mutation.done = true
+	return affected, err
+}
+
+// GroupMembershipChangeDeleteOne is the builder for deleting a single GroupMembershipChange entity.
+type GroupMembershipChangeDeleteOne struct {
+	_d *GroupMembershipChangeDelete
+}
+
+// Where appends a list predicates to the GroupMembershipChangeDelete builder.
+func (_d *GroupMembershipChangeDeleteOne) Where(ps ...predicate.GroupMembershipChange) *GroupMembershipChangeDeleteOne {
+	_d._d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query.
+func (_d *GroupMembershipChangeDeleteOne) Exec(ctx context.Context) error {
+	n, err := _d._d.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{groupmembershipchange.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *GroupMembershipChangeDeleteOne) ExecX(ctx context.Context) {
+	if err := _d.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,181 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/qinzj/claude-demo/internal/ent/groupmembershipchange"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// GroupMembershipChangeUpdate is the builder for updating GroupMembershipChange entities.
+type GroupMembershipChangeUpdate struct {
+	config
+	hooks    []Hook
+	mutation *GroupMembershipChangeMutation
+}
+
+// Where appends a list predicates to the GroupMembershipChangeUpdate builder.
+func (_u *GroupMembershipChangeUpdate) Where(ps ...predicate.GroupMembershipChange) *GroupMembershipChangeUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Mutation returns the GroupMembershipChangeMutation object of the builder.
+func (_u *GroupMembershipChangeUpdate) Mutation() *GroupMembershipChangeMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *GroupMembershipChangeUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *GroupMembershipChangeUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *GroupMembershipChangeUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *GroupMembershipChangeUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *GroupMembershipChangeUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(groupmembershipchange.Table, groupmembershipchange.Columns, sqlgraph.NewFieldSpec(groupmembershipchange.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.ActorIDCleared() {
+		_spec.ClearField(groupmembershipchange.FieldActorID, field.TypeUUID)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{groupmembershipchange.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// GroupMembershipChangeUpdateOne is the builder for updating a single GroupMembershipChange entity.
+type GroupMembershipChangeUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *GroupMembershipChangeMutation
+}
+
+// Mutation returns the GroupMembershipChangeMutation object of the builder.
+func (_u *GroupMembershipChangeUpdateOne) Mutation() *GroupMembershipChangeMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the GroupMembershipChangeUpdate builder.
+func (_u *GroupMembershipChangeUpdateOne) Where(ps ...predicate.GroupMembershipChange) *GroupMembershipChangeUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *GroupMembershipChangeUpdateOne) Select(field string, fields ...string) *GroupMembershipChangeUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated GroupMembershipChange entity.
+func (_u *GroupMembershipChangeUpdateOne) Save(ctx context.Context) (*GroupMembershipChange, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *GroupMembershipChangeUpdateOne) SaveX(ctx context.Context) *GroupMembershipChange {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *GroupMembershipChangeUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *GroupMembershipChangeUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *GroupMembershipChangeUpdateOne) sqlSave(ctx context.Context) (_node *GroupMembershipChange, err error) {
+	_spec := sqlgraph.NewUpdateSpec(groupmembershipchange.Table, groupmembershipchange.Columns, sqlgraph.NewFieldSpec(groupmembershipchange.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "GroupMembershipChange.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, groupmembershipchange.FieldID)
+		for _, f := range fields {
+			if !groupmembershipchange.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != groupmembershipchange.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.ActorIDCleared() {
+		_spec.ClearField(groupmembershipchange.FieldActorID, field.TypeUUID)
+	}
+	_node = &GroupMembershipChange{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{groupmembershipchange.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,409 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/groupversion"
+)
+
+// GroupVersionCreate is the builder for creating a GroupVersion entity.
+type GroupVersionCreate struct {
+	config
+	mutation *GroupVersionMutation
+	hooks    []Hook
+}
+
+// SetGroupID sets the "group_id" field.
+func (_c *GroupVersionCreate) SetGroupID(v uuid.UUID) *GroupVersionCreate {
+	_c.mutation.SetGroupID(v)
+	return _c
+}
+
+// SetVersion sets the "version" field.
+func (_c *GroupVersionCreate) SetVersion(v int) *GroupVersionCreate {
+	_c.mutation.SetVersion(v)
+	return _c
+}
+
+// SetOperation sets the "operation" field.
+func (_c *GroupVersionCreate) SetOperation(v groupversion.Operation) *GroupVersionCreate {
+	_c.mutation.SetOperation(v)
+	return _c
+}
+
+// SetName sets the "name" field.
+func (_c *GroupVersionCreate) SetName(v string) *GroupVersionCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetDescription sets the "description" field.
+func (_c *GroupVersionCreate) SetDescription(v string) *GroupVersionCreate {
+	_c.mutation.SetDescription(v)
+	return _c
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_c *GroupVersionCreate) SetNillableDescription(v *string) *GroupVersionCreate {
+	if v != nil {
+		_c.SetDescription(*v)
+	}
+	return _c
+}
+
+// SetParentID sets the "parent_id" field.
+func (_c *GroupVersionCreate) SetParentID(v uuid.UUID) *GroupVersionCreate {
+	_c.mutation.SetParentID(v)
+	return _c
+}
+
+// SetNillableParentID sets the "parent_id" field if the given value is not nil.
+func (_c *GroupVersionCreate) SetNillableParentID(v *uuid.UUID) *GroupVersionCreate {
+	if v != nil {
+		_c.SetParentID(*v)
+	}
+	return _c
+}
+
+// SetRequireMfa sets the "require_mfa" field.
+func (_c *GroupVersionCreate) SetRequireMfa(v bool) *GroupVersionCreate {
+	_c.mutation.SetRequireMfa(v)
+	return _c
+}
+
+// SetNillableRequireMfa sets the "require_mfa" field if the given value is not nil.
+func (_c *GroupVersionCreate) SetNillableRequireMfa(v *bool) *GroupVersionCreate {
+	if v != nil {
+		_c.SetRequireMfa(*v)
+	}
+	return _c
+}
+
+// SetAttributes sets the "attributes" field.
+func (_c *GroupVersionCreate) SetAttributes(v map[string][]string) *GroupVersionCreate {
+	_c.mutation.SetAttributes(v)
+	return _c
+}
+
+// SetActorID sets the "actor_id" field.
+func (_c *GroupVersionCreate) SetActorID(v uuid.UUID) *GroupVersionCreate {
+	_c.mutation.SetActorID(v)
+	return _c
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (_c *GroupVersionCreate) SetNillableActorID(v *uuid.UUID) *GroupVersionCreate {
+	if v != nil {
+		_c.SetActorID(*v)
+	}
+	return _c
+}
+
+// SetActorName sets the "actor_name" field.
+func (_c *GroupVersionCreate) SetActorName(v string) *GroupVersionCreate {
+	_c.mutation.SetActorName(v)
+	return _c
+}
+
+// SetNillableActorName sets the "actor_name" field if the given value is not nil.
+func (_c *GroupVersionCreate) SetNillableActorName(v *string) *GroupVersionCreate {
+	if v != nil {
+		_c.SetActorName(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *GroupVersionCreate) SetCreatedAt(v time.Time) *GroupVersionCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *GroupVersionCreate) SetNillableCreatedAt(v *time.Time) *GroupVersionCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *GroupVersionCreate) SetID(v uuid.UUID) *GroupVersionCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *GroupVersionCreate) SetNillableID(v *uuid.UUID) *GroupVersionCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the GroupVersionMutation object of the builder.
+func (_c *GroupVersionCreate) Mutation() *GroupVersionMutation {
+	return _c.mutation
+}
+
+// Save creates the GroupVersion in the database.
+func (_c *GroupVersionCreate) Save(ctx context.Context) (*GroupVersion, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *GroupVersionCreate) SaveX(ctx context.Context) *GroupVersion {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *GroupVersionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *GroupVersionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *GroupVersionCreate) defaults() {
+	if _, ok := _c.mutation.Description(); !ok {
+		v := groupversion.DefaultDescription
+		_c.mutation.SetDescription(v)
+	}
+	if _, ok := _c.mutation.RequireMfa(); !ok {
+		v := groupversion.DefaultRequireMfa
+		_c.mutation.SetRequireMfa(v)
+	}
+	if _, ok := _c.mutation.ActorName(); !ok {
+		v := groupversion.DefaultActorName
+		_c.mutation.SetActorName(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := groupversion.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := groupversion.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *GroupVersionCreate) check() error {
+	if _, ok := _c.mutation.GroupID(); !ok {
+		return &ValidationError{Name: "group_id", err: errors.New(`ent: missing required field "GroupVersion.group_id"`)}
+	}
+	if _, ok := _c.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "GroupVersion.version"`)}
+	}
+	if v, ok := _c.mutation.Version(); ok {
+		if err := groupversion.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "GroupVersion.version": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Operation(); !ok {
+		return &ValidationError{Name: "operation", err: errors.New(`ent: missing required field "GroupVersion.operation"`)}
+	}
+	if v, ok := _c.mutation.Operation(); ok {
+		if err := groupversion.OperationValidator(v); err != nil {
+			return &ValidationError{Name: "operation", err: fmt.Errorf(`ent: validator failed for field "GroupVersion.operation": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "GroupVersion.name"`)}
+	}
+	if _, ok := _c.mutation.Description(); !ok {
+		return &ValidationError{Name: "description", err: errors.New(`ent: missing required field "GroupVersion.description"`)}
+	}
+	if _, ok := _c.mutation.RequireMfa(); !ok {
+		return &ValidationError{Name: "require_mfa", err: errors.New(`ent: missing required field "GroupVersion.require_mfa"`)}
+	}
+	if _, ok := _c.mutation.ActorName(); !ok {
+		return &ValidationError{Name: "actor_name", err: errors.New(`ent: missing required field "GroupVersion.actor_name"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "GroupVersion.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *GroupVersionCreate) sqlSave(ctx context.Context) (*GroupVersion, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *GroupVersionCreate) createSpec() (*GroupVersion, *sqlgraph.CreateSpec) {
+	var (
+		_node = &GroupVersion{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(groupversion.Table, sqlgraph.NewFieldSpec(groupversion.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.GroupID(); ok {
+		_spec.SetField(groupversion.FieldGroupID, field.TypeUUID, value)
+		_node.GroupID = value
+	}
+	if value, ok := _c.mutation.Version(); ok {
+		_spec.SetField(groupversion.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if value, ok := _c.mutation.Operation(); ok {
+		_spec.SetField(groupversion.FieldOperation, field.TypeEnum, value)
+		_node.Operation = value
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(groupversion.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.Description(); ok {
+		_spec.SetField(groupversion.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
+	if value, ok := _c.mutation.ParentID(); ok {
+		_spec.SetField(groupversion.FieldParentID, field.TypeUUID, value)
+		_node.ParentID = &value
+	}
+	if value, ok := _c.mutation.RequireMfa(); ok {
+		_spec.SetField(groupversion.FieldRequireMfa, field.TypeBool, value)
+		_node.RequireMfa = value
+	}
+	if value, ok := _c.mutation.Attributes(); ok {
+		_spec.SetField(groupversion.FieldAttributes, field.TypeJSON, value)
+		_node.Attributes = value
+	}
+	if value, ok := _c.mutation.ActorID(); ok {
+		_spec.SetField(groupversion.FieldActorID, field.TypeUUID, value)
+		_node.ActorID = &value
+	}
+	if value, ok := _c.mutation.ActorName(); ok {
+		_spec.SetField(groupversion.FieldActorName, field.TypeString, value)
+		_node.ActorName = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(groupversion.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// GroupVersionCreateBulk is the builder for creating many GroupVersion entities in bulk.
+type GroupVersionCreateBulk struct {
+	config
+	err      error
+	builders []*GroupVersionCreate
+}
+
+// Save creates the GroupVersion entities in the database.
+func (_c *GroupVersionCreateBulk) Save(ctx context.Context) ([]*GroupVersion, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*GroupVersion, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*GroupVersionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *GroupVersionCreateBulk) SaveX(ctx context.Context) []*GroupVersion {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *GroupVersionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *GroupVersionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
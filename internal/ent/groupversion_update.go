@@ -0,0 +1,193 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/qinzj/claude-demo/internal/ent/groupversion"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// GroupVersionUpdate is the builder for updating GroupVersion entities.
+type GroupVersionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *GroupVersionMutation
+}
+
+// Where appends a list predicates to the GroupVersionUpdate builder.
+func (_u *GroupVersionUpdate) Where(ps ...predicate.GroupVersion) *GroupVersionUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Mutation returns the GroupVersionMutation object of the builder.
+func (_u *GroupVersionUpdate) Mutation() *GroupVersionMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *GroupVersionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *GroupVersionUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *GroupVersionUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *GroupVersionUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *GroupVersionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(groupversion.Table, groupversion.Columns, sqlgraph.NewFieldSpec(groupversion.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.ParentIDCleared() {
+		_spec.ClearField(groupversion.FieldParentID, field.TypeUUID)
+	}
+	if _u.mutation.AttributesCleared() {
+		_spec.ClearField(groupversion.FieldAttributes, field.TypeJSON)
+	}
+	if _u.mutation.ActorIDCleared() {
+		_spec.ClearField(groupversion.FieldActorID, field.TypeUUID)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{groupversion.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// GroupVersionUpdateOne is the builder for updating a single GroupVersion entity.
+type GroupVersionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *GroupVersionMutation
+}
+
+// Mutation returns the GroupVersionMutation object of the builder.
+func (_u *GroupVersionUpdateOne) Mutation() *GroupVersionMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the GroupVersionUpdate builder.
+func (_u *GroupVersionUpdateOne) Where(ps ...predicate.GroupVersion) *GroupVersionUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *GroupVersionUpdateOne) Select(field string, fields ...string) *GroupVersionUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated GroupVersion entity.
+func (_u *GroupVersionUpdateOne) Save(ctx context.Context) (*GroupVersion, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *GroupVersionUpdateOne) SaveX(ctx context.Context) *GroupVersion {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *GroupVersionUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *GroupVersionUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *GroupVersionUpdateOne) sqlSave(ctx context.Context) (_node *GroupVersion, err error) {
+	_spec := sqlgraph.NewUpdateSpec(groupversion.Table, groupversion.Columns, sqlgraph.NewFieldSpec(groupversion.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "GroupVersion.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, groupversion.FieldID)
+		for _, f := range fields {
+			if !groupversion.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != groupversion.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.ParentIDCleared() {
+		_spec.ClearField(groupversion.FieldParentID, field.TypeUUID)
+	}
+	if _u.mutation.AttributesCleared() {
+		_spec.ClearField(groupversion.FieldAttributes, field.TypeJSON)
+	}
+	if _u.mutation.ActorIDCleared() {
+		_spec.ClearField(groupversion.FieldActorID, field.TypeUUID)
+	}
+	_node = &GroupVersion{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{groupversion.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -8,11 +8,208 @@ import (
 )
 
 var (
+	// APIKeysColumns holds the columns for the "api_keys" table.
+	APIKeysColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Size: 64},
+		{Name: "prefix", Type: field.TypeString},
+		{Name: "key_hash", Type: field.TypeString, Unique: true},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "last_used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "service_account_api_keys", Type: field.TypeUUID},
+	}
+	// APIKeysTable holds the schema information for the "api_keys" table.
+	APIKeysTable = &schema.Table{
+		Name:       "api_keys",
+		Columns:    APIKeysColumns,
+		PrimaryKey: []*schema.Column{APIKeysColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "api_keys_service_accounts_api_keys",
+				Columns:    []*schema.Column{APIKeysColumns[7]},
+				RefColumns: []*schema.Column{ServiceAccountsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// AttributeDefinitionsColumns holds the columns for the "attribute_definitions" table.
+	AttributeDefinitionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Size: 64},
+		{Name: "target", Type: field.TypeEnum, Enums: []string{"user", "group"}},
+		{Name: "type", Type: field.TypeEnum, Enums: []string{"string", "integer", "boolean"}, Default: "string"},
+		{Name: "multi_valued", Type: field.TypeBool, Default: false},
+		{Name: "ldap_name", Type: field.TypeString, Size: 64},
+		{Name: "description", Type: field.TypeString, Nullable: true, Size: 255},
+		{Name: "required", Type: field.TypeBool, Default: false},
+		{Name: "indexed", Type: field.TypeBool, Default: false},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// AttributeDefinitionsTable holds the schema information for the "attribute_definitions" table.
+	AttributeDefinitionsTable = &schema.Table{
+		Name:       "attribute_definitions",
+		Columns:    AttributeDefinitionsColumns,
+		PrimaryKey: []*schema.Column{AttributeDefinitionsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "attributedefinition_target_name",
+				Unique:  true,
+				Columns: []*schema.Column{AttributeDefinitionsColumns[2], AttributeDefinitionsColumns[1]},
+			},
+			{
+				Name:    "attributedefinition_target_ldap_name",
+				Unique:  true,
+				Columns: []*schema.Column{AttributeDefinitionsColumns[2], AttributeDefinitionsColumns[5]},
+			},
+		},
+	}
+	// AttributeValuesColumns holds the columns for the "attribute_values" table.
+	AttributeValuesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Size: 64},
+		{Name: "value", Type: field.TypeString, Size: 1024},
+		{Name: "group_attribute_values", Type: field.TypeUUID, Nullable: true},
+		{Name: "user_attribute_values", Type: field.TypeUUID, Nullable: true},
+	}
+	// AttributeValuesTable holds the schema information for the "attribute_values" table.
+	AttributeValuesTable = &schema.Table{
+		Name:       "attribute_values",
+		Columns:    AttributeValuesColumns,
+		PrimaryKey: []*schema.Column{AttributeValuesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "attribute_values_groups_attribute_values",
+				Columns:    []*schema.Column{AttributeValuesColumns[3]},
+				RefColumns: []*schema.Column{GroupsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "attribute_values_users_attribute_values",
+				Columns:    []*schema.Column{AttributeValuesColumns[4]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "attributevalue_name_value",
+				Unique:  false,
+				Columns: []*schema.Column{AttributeValuesColumns[1], AttributeValuesColumns[2]},
+			},
+		},
+	}
+	// AuditEventsColumns holds the columns for the "audit_events" table.
+	AuditEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "action", Type: field.TypeString},
+		{Name: "outcome", Type: field.TypeEnum, Enums: []string{"success", "failure"}},
+		{Name: "reason", Type: field.TypeString, Default: ""},
+		{Name: "actor_type", Type: field.TypeString, Default: ""},
+		{Name: "actor_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "actor_name", Type: field.TypeString, Default: ""},
+		{Name: "target_type", Type: field.TypeString, Default: ""},
+		{Name: "target_id", Type: field.TypeString, Default: ""},
+		{Name: "target_name", Type: field.TypeString, Default: ""},
+		{Name: "protocol", Type: field.TypeString, Default: ""},
+		{Name: "ip_address", Type: field.TypeString, Default: ""},
+		{Name: "request_id", Type: field.TypeString, Default: ""},
+		{Name: "changes", Type: field.TypeString, Size: 2147483647, Default: ""},
+	}
+	// AuditEventsTable holds the schema information for the "audit_events" table.
+	AuditEventsTable = &schema.Table{
+		Name:       "audit_events",
+		Columns:    AuditEventsColumns,
+		PrimaryKey: []*schema.Column{AuditEventsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "auditevent_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEventsColumns[1]},
+			},
+			{
+				Name:    "auditevent_target_type_target_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEventsColumns[8], AuditEventsColumns[9], AuditEventsColumns[1]},
+			},
+			{
+				Name:    "auditevent_actor_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEventsColumns[6], AuditEventsColumns[1]},
+			},
+		},
+	}
+	// AuthorizationCodesColumns holds the columns for the "authorization_codes" table.
+	AuthorizationCodesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "code_hash", Type: field.TypeString, Unique: true},
+		{Name: "redirect_uri", Type: field.TypeString},
+		{Name: "scopes", Type: field.TypeJSON},
+		{Name: "nonce", Type: field.TypeString, Default: ""},
+		{Name: "code_challenge", Type: field.TypeString},
+		{Name: "auth_time", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "oidc_client_codes", Type: field.TypeUUID},
+		{Name: "user_authorization_codes", Type: field.TypeUUID},
+	}
+	// AuthorizationCodesTable holds the schema information for the "authorization_codes" table.
+	AuthorizationCodesTable = &schema.Table{
+		Name:       "authorization_codes",
+		Columns:    AuthorizationCodesColumns,
+		PrimaryKey: []*schema.Column{AuthorizationCodesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "authorization_codes_oidc_clients_codes",
+				Columns:    []*schema.Column{AuthorizationCodesColumns[8]},
+				RefColumns: []*schema.Column{OidcClientsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "authorization_codes_users_authorization_codes",
+				Columns:    []*schema.Column{AuthorizationCodesColumns[9]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// DirectoryObjectsColumns holds the columns for the "directory_objects" table.
+	DirectoryObjectsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "source", Type: field.TypeString},
+		{Name: "resource_type", Type: field.TypeEnum, Enums: []string{"user", "group"}},
+		{Name: "external_id", Type: field.TypeString},
+		{Name: "local_id", Type: field.TypeUUID},
+		{Name: "dn", Type: field.TypeString, Default: ""},
+		{Name: "password_digest", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// DirectoryObjectsTable holds the schema information for the "directory_objects" table.
+	DirectoryObjectsTable = &schema.Table{
+		Name:       "directory_objects",
+		Columns:    DirectoryObjectsColumns,
+		PrimaryKey: []*schema.Column{DirectoryObjectsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "directoryobject_source_resource_type_external_id",
+				Unique:  true,
+				Columns: []*schema.Column{DirectoryObjectsColumns[1], DirectoryObjectsColumns[2], DirectoryObjectsColumns[3]},
+			},
+			{
+				Name:    "directoryobject_resource_type_local_id",
+				Unique:  true,
+				Columns: []*schema.Column{DirectoryObjectsColumns[2], DirectoryObjectsColumns[4]},
+			},
+		},
+	}
 	// GroupsColumns holds the columns for the "groups" table.
 	GroupsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
 		{Name: "name", Type: field.TypeString, Unique: true, Size: 64},
 		{Name: "description", Type: field.TypeString, Nullable: true, Size: 255},
+		{Name: "require_mfa", Type: field.TypeBool, Default: false},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 		{Name: "parent_id", Type: field.TypeUUID, Nullable: true},
@@ -25,12 +222,465 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "groups_groups_children",
-				Columns:    []*schema.Column{GroupsColumns[5]},
+				Columns:    []*schema.Column{GroupsColumns[6]},
 				RefColumns: []*schema.Column{GroupsColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
 		},
 	}
+	// GroupMembershipChangesColumns holds the columns for the "group_membership_changes" table.
+	GroupMembershipChangesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "group_id", Type: field.TypeUUID},
+		{Name: "user_id", Type: field.TypeUUID},
+		{Name: "operation", Type: field.TypeEnum, Enums: []string{"add", "remove"}},
+		{Name: "actor_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "actor_name", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// GroupMembershipChangesTable holds the schema information for the "group_membership_changes" table.
+	GroupMembershipChangesTable = &schema.Table{
+		Name:       "group_membership_changes",
+		Columns:    GroupMembershipChangesColumns,
+		PrimaryKey: []*schema.Column{GroupMembershipChangesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "groupmembershipchange_group_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{GroupMembershipChangesColumns[1], GroupMembershipChangesColumns[6]},
+			},
+			{
+				Name:    "groupmembershipchange_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{GroupMembershipChangesColumns[2], GroupMembershipChangesColumns[6]},
+			},
+		},
+	}
+	// GroupVersionsColumns holds the columns for the "group_versions" table.
+	GroupVersionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "group_id", Type: field.TypeUUID},
+		{Name: "version", Type: field.TypeInt},
+		{Name: "operation", Type: field.TypeEnum, Enums: []string{"create", "update", "delete", "baseline"}},
+		{Name: "name", Type: field.TypeString},
+		{Name: "description", Type: field.TypeString, Default: ""},
+		{Name: "parent_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "require_mfa", Type: field.TypeBool, Default: false},
+		{Name: "attributes", Type: field.TypeJSON, Nullable: true},
+		{Name: "actor_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "actor_name", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// GroupVersionsTable holds the schema information for the "group_versions" table.
+	GroupVersionsTable = &schema.Table{
+		Name:       "group_versions",
+		Columns:    GroupVersionsColumns,
+		PrimaryKey: []*schema.Column{GroupVersionsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "groupversion_group_id_version",
+				Unique:  true,
+				Columns: []*schema.Column{GroupVersionsColumns[1], GroupVersionsColumns[2]},
+			},
+			{
+				Name:    "groupversion_group_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{GroupVersionsColumns[1], GroupVersionsColumns[11]},
+			},
+		},
+	}
+	// InvitationsColumns holds the columns for the "invitations" table.
+	InvitationsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "token_hash", Type: field.TypeString, Unique: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "accepted_at", Type: field.TypeTime, Nullable: true},
+		{Name: "user_invitations", Type: field.TypeUUID},
+	}
+	// InvitationsTable holds the schema information for the "invitations" table.
+	InvitationsTable = &schema.Table{
+		Name:       "invitations",
+		Columns:    InvitationsColumns,
+		PrimaryKey: []*schema.Column{InvitationsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "invitations_users_invitations",
+				Columns:    []*schema.Column{InvitationsColumns[5]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// LoginEventsColumns holds the columns for the "login_events" table.
+	LoginEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "user_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "username", Type: field.TypeString},
+		{Name: "type", Type: field.TypeEnum, Enums: []string{"failure", "rejected", "locked", "unlocked"}},
+		{Name: "reason", Type: field.TypeString, Default: ""},
+		{Name: "protocol", Type: field.TypeString, Default: ""},
+		{Name: "ip_address", Type: field.TypeString, Default: ""},
+		{Name: "actor_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// LoginEventsTable holds the schema information for the "login_events" table.
+	LoginEventsTable = &schema.Table{
+		Name:       "login_events",
+		Columns:    LoginEventsColumns,
+		PrimaryKey: []*schema.Column{LoginEventsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "loginevent_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{LoginEventsColumns[1], LoginEventsColumns[8]},
+			},
+		},
+	}
+	// OidcClientsColumns holds the columns for the "oidc_clients" table.
+	OidcClientsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Size: 128},
+		{Name: "redirect_uris", Type: field.TypeJSON},
+		{Name: "public", Type: field.TypeBool, Default: false},
+		{Name: "secret_hash", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// OidcClientsTable holds the schema information for the "oidc_clients" table.
+	OidcClientsTable = &schema.Table{
+		Name:       "oidc_clients",
+		Columns:    OidcClientsColumns,
+		PrimaryKey: []*schema.Column{OidcClientsColumns[0]},
+	}
+	// OidcConsentsColumns holds the columns for the "oidc_consents" table.
+	OidcConsentsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "scopes", Type: field.TypeJSON},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "oidc_client_consents", Type: field.TypeUUID},
+		{Name: "user_oidc_consents", Type: field.TypeUUID},
+	}
+	// OidcConsentsTable holds the schema information for the "oidc_consents" table.
+	OidcConsentsTable = &schema.Table{
+		Name:       "oidc_consents",
+		Columns:    OidcConsentsColumns,
+		PrimaryKey: []*schema.Column{OidcConsentsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "oidc_consents_oidc_clients_consents",
+				Columns:    []*schema.Column{OidcConsentsColumns[4]},
+				RefColumns: []*schema.Column{OidcClientsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "oidc_consents_users_oidc_consents",
+				Columns:    []*schema.Column{OidcConsentsColumns[5]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "oidcconsent_oidc_client_consents_user_oidc_consents",
+				Unique:  true,
+				Columns: []*schema.Column{OidcConsentsColumns[4], OidcConsentsColumns[5]},
+			},
+		},
+	}
+	// OutboxEventsColumns holds the columns for the "outbox_events" table.
+	OutboxEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "event_type", Type: field.TypeString},
+		{Name: "payload", Type: field.TypeString, Size: 2147483647},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "dispatched_at", Type: field.TypeTime, Nullable: true},
+	}
+	// OutboxEventsTable holds the schema information for the "outbox_events" table.
+	OutboxEventsTable = &schema.Table{
+		Name:       "outbox_events",
+		Columns:    OutboxEventsColumns,
+		PrimaryKey: []*schema.Column{OutboxEventsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "outboxevent_dispatched_at_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{OutboxEventsColumns[4], OutboxEventsColumns[3]},
+			},
+			{
+				Name:    "outboxevent_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{OutboxEventsColumns[3]},
+			},
+		},
+	}
+	// PasswordHistoriesColumns holds the columns for the "password_histories" table.
+	PasswordHistoriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "password_hash", Type: field.TypeString},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_password_history", Type: field.TypeUUID},
+	}
+	// PasswordHistoriesTable holds the schema information for the "password_histories" table.
+	PasswordHistoriesTable = &schema.Table{
+		Name:       "password_histories",
+		Columns:    PasswordHistoriesColumns,
+		PrimaryKey: []*schema.Column{PasswordHistoriesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "password_histories_users_password_history",
+				Columns:    []*schema.Column{PasswordHistoriesColumns[3]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// PasswordPoliciesColumns holds the columns for the "password_policies" table.
+	PasswordPoliciesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Unique: true, Size: 64},
+		{Name: "description", Type: field.TypeString, Nullable: true, Size: 255},
+		{Name: "builtin", Type: field.TypeBool, Default: false},
+		{Name: "min_length", Type: field.TypeInt, Default: 8},
+		{Name: "require_uppercase", Type: field.TypeBool, Default: false},
+		{Name: "require_lowercase", Type: field.TypeBool, Default: false},
+		{Name: "require_digit", Type: field.TypeBool, Default: false},
+		{Name: "require_symbol", Type: field.TypeBool, Default: false},
+		{Name: "check_dictionary", Type: field.TypeBool, Default: false},
+		{Name: "check_username", Type: field.TypeBool, Default: false},
+		{Name: "history_count", Type: field.TypeInt, Default: 0},
+		{Name: "min_age_hours", Type: field.TypeInt, Default: 0},
+		{Name: "max_age_days", Type: field.TypeInt, Default: 0},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// PasswordPoliciesTable holds the schema information for the "password_policies" table.
+	PasswordPoliciesTable = &schema.Table{
+		Name:       "password_policies",
+		Columns:    PasswordPoliciesColumns,
+		PrimaryKey: []*schema.Column{PasswordPoliciesColumns[0]},
+	}
+	// PasswordResetTokensColumns holds the columns for the "password_reset_tokens" table.
+	PasswordResetTokensColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "token_hash", Type: field.TypeString, Unique: true},
+		{Name: "ip_address", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "user_password_reset_tokens", Type: field.TypeUUID},
+	}
+	// PasswordResetTokensTable holds the schema information for the "password_reset_tokens" table.
+	PasswordResetTokensTable = &schema.Table{
+		Name:       "password_reset_tokens",
+		Columns:    PasswordResetTokensColumns,
+		PrimaryKey: []*schema.Column{PasswordResetTokensColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "password_reset_tokens_users_password_reset_tokens",
+				Columns:    []*schema.Column{PasswordResetTokensColumns[6]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// ProvisionedObjectsColumns holds the columns for the "provisioned_objects" table.
+	ProvisionedObjectsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "resource_type", Type: field.TypeEnum, Enums: []string{"user", "group"}},
+		{Name: "local_id", Type: field.TypeUUID},
+		{Name: "remote_id", Type: field.TypeString, Default: ""},
+		{Name: "hash", Type: field.TypeString, Default: ""},
+		{Name: "last_error", Type: field.TypeString, Default: ""},
+		{Name: "synced_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "provisioning_connector_objects", Type: field.TypeUUID},
+	}
+	// ProvisionedObjectsTable holds the schema information for the "provisioned_objects" table.
+	ProvisionedObjectsTable = &schema.Table{
+		Name:       "provisioned_objects",
+		Columns:    ProvisionedObjectsColumns,
+		PrimaryKey: []*schema.Column{ProvisionedObjectsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "provisioned_objects_provisioning_connectors_objects",
+				Columns:    []*schema.Column{ProvisionedObjectsColumns[9]},
+				RefColumns: []*schema.Column{ProvisioningConnectorsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "provisionedobject_resource_type_local_id_provisioning_connector_objects",
+				Unique:  true,
+				Columns: []*schema.Column{ProvisionedObjectsColumns[1], ProvisionedObjectsColumns[2], ProvisionedObjectsColumns[9]},
+			},
+		},
+	}
+	// ProvisioningConnectorsColumns holds the columns for the "provisioning_connectors" table.
+	ProvisioningConnectorsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Unique: true, Size: 64},
+		{Name: "url", Type: field.TypeString},
+		{Name: "token", Type: field.TypeString, Default: ""},
+		{Name: "scope_group_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "sync_groups", Type: field.TypeBool, Default: true},
+		{Name: "attribute_mapping", Type: field.TypeJSON, Nullable: true},
+		{Name: "enabled", Type: field.TypeBool, Default: true},
+		{Name: "event_cursor", Type: field.TypeTime, Nullable: true},
+		{Name: "last_sync_at", Type: field.TypeTime, Nullable: true},
+		{Name: "last_full_sync_at", Type: field.TypeTime, Nullable: true},
+		{Name: "last_error", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// ProvisioningConnectorsTable holds the schema information for the "provisioning_connectors" table.
+	ProvisioningConnectorsTable = &schema.Table{
+		Name:       "provisioning_connectors",
+		Columns:    ProvisioningConnectorsColumns,
+		PrimaryKey: []*schema.Column{ProvisioningConnectorsColumns[0]},
+	}
+	// RecoveryCodesColumns holds the columns for the "recovery_codes" table.
+	RecoveryCodesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "code_hash", Type: field.TypeString},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_recovery_codes", Type: field.TypeUUID},
+	}
+	// RecoveryCodesTable holds the schema information for the "recovery_codes" table.
+	RecoveryCodesTable = &schema.Table{
+		Name:       "recovery_codes",
+		Columns:    RecoveryCodesColumns,
+		PrimaryKey: []*schema.Column{RecoveryCodesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "recovery_codes_users_recovery_codes",
+				Columns:    []*schema.Column{RecoveryCodesColumns[3]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "recoverycode_code_hash_user_recovery_codes",
+				Unique:  true,
+				Columns: []*schema.Column{RecoveryCodesColumns[1], RecoveryCodesColumns[3]},
+			},
+		},
+	}
+	// RolesColumns holds the columns for the "roles" table.
+	RolesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Unique: true, Size: 64},
+		{Name: "description", Type: field.TypeString, Nullable: true, Size: 255},
+		{Name: "permissions", Type: field.TypeJSON, Nullable: true},
+		{Name: "builtin", Type: field.TypeBool, Default: false},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// RolesTable holds the schema information for the "roles" table.
+	RolesTable = &schema.Table{
+		Name:       "roles",
+		Columns:    RolesColumns,
+		PrimaryKey: []*schema.Column{RolesColumns[0]},
+	}
+	// SSHKeysColumns holds the columns for the "ssh_keys" table.
+	SSHKeysColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Size: 64},
+		{Name: "public_key", Type: field.TypeString, Size: 2147483647},
+		{Name: "fingerprint", Type: field.TypeString},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_ssh_keys", Type: field.TypeUUID},
+	}
+	// SSHKeysTable holds the schema information for the "ssh_keys" table.
+	SSHKeysTable = &schema.Table{
+		Name:       "ssh_keys",
+		Columns:    SSHKeysColumns,
+		PrimaryKey: []*schema.Column{SSHKeysColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "ssh_keys_users_ssh_keys",
+				Columns:    []*schema.Column{SSHKeysColumns[5]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "sshkey_fingerprint_user_ssh_keys",
+				Unique:  true,
+				Columns: []*schema.Column{SSHKeysColumns[3], SSHKeysColumns[5]},
+			},
+		},
+	}
+	// ServiceAccountsColumns holds the columns for the "service_accounts" table.
+	ServiceAccountsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Unique: true, Size: 64},
+		{Name: "description", Type: field.TypeString, Nullable: true, Size: 255},
+		{Name: "permissions", Type: field.TypeJSON, Nullable: true},
+		{Name: "disabled", Type: field.TypeBool, Default: false},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// ServiceAccountsTable holds the schema information for the "service_accounts" table.
+	ServiceAccountsTable = &schema.Table{
+		Name:       "service_accounts",
+		Columns:    ServiceAccountsColumns,
+		PrimaryKey: []*schema.Column{ServiceAccountsColumns[0]},
+	}
+	// SessionsColumns holds the columns for the "sessions" table.
+	SessionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "refresh_token_hash", Type: field.TypeString, Unique: true},
+		{Name: "previous_token_hash", Type: field.TypeString, Nullable: true},
+		{Name: "user_agent", Type: field.TypeString, Default: ""},
+		{Name: "ip_address", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "last_used_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "revoked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "user_sessions", Type: field.TypeUUID},
+	}
+	// SessionsTable holds the schema information for the "sessions" table.
+	SessionsTable = &schema.Table{
+		Name:       "sessions",
+		Columns:    SessionsColumns,
+		PrimaryKey: []*schema.Column{SessionsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "sessions_users_sessions",
+				Columns:    []*schema.Column{SessionsColumns[9]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "session_previous_token_hash",
+				Unique:  false,
+				Columns: []*schema.Column{SessionsColumns[2]},
+			},
+		},
+	}
+	// SigningKeysColumns holds the columns for the "signing_keys" table.
+	SigningKeysColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "algorithm", Type: field.TypeEnum, Enums: []string{"RS256", "ES256", "EdDSA"}},
+		{Name: "private_key", Type: field.TypeString, Size: 2147483647},
+		{Name: "public_key", Type: field.TypeString, Size: 2147483647},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "retired_at", Type: field.TypeTime, Nullable: true},
+	}
+	// SigningKeysTable holds the schema information for the "signing_keys" table.
+	SigningKeysTable = &schema.Table{
+		Name:       "signing_keys",
+		Columns:    SigningKeysColumns,
+		PrimaryKey: []*schema.Column{SigningKeysColumns[0]},
+	}
 	// UsersColumns holds the columns for the "users" table.
 	UsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
@@ -38,16 +688,135 @@ var (
 		{Name: "display_name", Type: field.TypeString, Size: 128},
 		{Name: "email", Type: field.TypeString, Unique: true, Size: 255},
 		{Name: "password_hash", Type: field.TypeString},
+		{Name: "password_changed_at", Type: field.TypeTime},
+		{Name: "must_change_password", Type: field.TypeBool, Default: false},
+		{Name: "account_expires_at", Type: field.TypeTime, Nullable: true},
 		{Name: "phone", Type: field.TypeString, Nullable: true, Size: 32},
-		{Name: "status", Type: field.TypeEnum, Enums: []string{"enabled", "disabled"}, Default: "enabled"},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"enabled", "disabled", "pending"}, Default: "enabled"},
+		{Name: "totp_secret", Type: field.TypeString, Nullable: true},
+		{Name: "mfa_enabled", Type: field.TypeBool, Default: false},
+		{Name: "totp_last_step", Type: field.TypeInt64, Default: 0},
+		{Name: "failed_login_count", Type: field.TypeInt, Default: 0},
+		{Name: "last_failed_login_at", Type: field.TypeTime, Nullable: true},
+		{Name: "lockout_count", Type: field.TypeInt, Default: 0},
+		{Name: "locked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "locked_until", Type: field.TypeTime, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "manager_id", Type: field.TypeUUID, Nullable: true},
 	}
 	// UsersTable holds the schema information for the "users" table.
 	UsersTable = &schema.Table{
 		Name:       "users",
 		Columns:    UsersColumns,
 		PrimaryKey: []*schema.Column{UsersColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "users_users_reports",
+				Columns:    []*schema.Column{UsersColumns[20]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+	}
+	// UserVersionsColumns holds the columns for the "user_versions" table.
+	UserVersionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "user_id", Type: field.TypeUUID},
+		{Name: "version", Type: field.TypeInt},
+		{Name: "operation", Type: field.TypeEnum, Enums: []string{"create", "update", "delete", "baseline"}},
+		{Name: "username", Type: field.TypeString},
+		{Name: "display_name", Type: field.TypeString},
+		{Name: "email", Type: field.TypeString},
+		{Name: "phone", Type: field.TypeString, Default: ""},
+		{Name: "status", Type: field.TypeString},
+		{Name: "manager_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "account_expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "attributes", Type: field.TypeJSON, Nullable: true},
+		{Name: "actor_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "actor_name", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// UserVersionsTable holds the schema information for the "user_versions" table.
+	UserVersionsTable = &schema.Table{
+		Name:       "user_versions",
+		Columns:    UserVersionsColumns,
+		PrimaryKey: []*schema.Column{UserVersionsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "userversion_user_id_version",
+				Unique:  true,
+				Columns: []*schema.Column{UserVersionsColumns[1], UserVersionsColumns[2]},
+			},
+			{
+				Name:    "userversion_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{UserVersionsColumns[1], UserVersionsColumns[14]},
+			},
+		},
+	}
+	// WebhookDeliveriesColumns holds the columns for the "webhook_deliveries" table.
+	WebhookDeliveriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"pending", "delivered", "dead"}, Default: "pending"},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "next_attempt_at", Type: field.TypeTime},
+		{Name: "last_status_code", Type: field.TypeInt, Default: 0},
+		{Name: "last_error", Type: field.TypeString, Default: ""},
+		{Name: "delivered_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "outbox_event_deliveries", Type: field.TypeUUID},
+		{Name: "webhook_subscription_deliveries", Type: field.TypeUUID},
+	}
+	// WebhookDeliveriesTable holds the schema information for the "webhook_deliveries" table.
+	WebhookDeliveriesTable = &schema.Table{
+		Name:       "webhook_deliveries",
+		Columns:    WebhookDeliveriesColumns,
+		PrimaryKey: []*schema.Column{WebhookDeliveriesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "webhook_deliveries_outbox_events_deliveries",
+				Columns:    []*schema.Column{WebhookDeliveriesColumns[9]},
+				RefColumns: []*schema.Column{OutboxEventsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "webhook_deliveries_webhook_subscriptions_deliveries",
+				Columns:    []*schema.Column{WebhookDeliveriesColumns[10]},
+				RefColumns: []*schema.Column{WebhookSubscriptionsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "webhookdelivery_status_next_attempt_at",
+				Unique:  false,
+				Columns: []*schema.Column{WebhookDeliveriesColumns[1], WebhookDeliveriesColumns[3]},
+			},
+			{
+				Name:    "webhookdelivery_status_updated_at",
+				Unique:  false,
+				Columns: []*schema.Column{WebhookDeliveriesColumns[1], WebhookDeliveriesColumns[8]},
+			},
+		},
+	}
+	// WebhookSubscriptionsColumns holds the columns for the "webhook_subscriptions" table.
+	WebhookSubscriptionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Unique: true, Size: 64},
+		{Name: "url", Type: field.TypeString},
+		{Name: "secret", Type: field.TypeString},
+		{Name: "event_types", Type: field.TypeJSON},
+		{Name: "enabled", Type: field.TypeBool, Default: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// WebhookSubscriptionsTable holds the schema information for the "webhook_subscriptions" table.
+	WebhookSubscriptionsTable = &schema.Table{
+		Name:       "webhook_subscriptions",
+		Columns:    WebhookSubscriptionsColumns,
+		PrimaryKey: []*schema.Column{WebhookSubscriptionsColumns[0]},
 	}
 	// GroupUsersColumns holds the columns for the "group_users" table.
 	GroupUsersColumns = []*schema.Column{
@@ -74,16 +843,200 @@ var (
 			},
 		},
 	}
+	// GroupOwnerUsersColumns holds the columns for the "group_owner_users" table.
+	GroupOwnerUsersColumns = []*schema.Column{
+		{Name: "group_id", Type: field.TypeUUID},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// GroupOwnerUsersTable holds the schema information for the "group_owner_users" table.
+	GroupOwnerUsersTable = &schema.Table{
+		Name:       "group_owner_users",
+		Columns:    GroupOwnerUsersColumns,
+		PrimaryKey: []*schema.Column{GroupOwnerUsersColumns[0], GroupOwnerUsersColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "group_owner_users_group_id",
+				Columns:    []*schema.Column{GroupOwnerUsersColumns[0]},
+				RefColumns: []*schema.Column{GroupsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "group_owner_users_user_id",
+				Columns:    []*schema.Column{GroupOwnerUsersColumns[1]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// GroupOwnerGroupsColumns holds the columns for the "group_owner_groups" table.
+	GroupOwnerGroupsColumns = []*schema.Column{
+		{Name: "group_id", Type: field.TypeUUID},
+		{Name: "owned_group_id", Type: field.TypeUUID},
+	}
+	// GroupOwnerGroupsTable holds the schema information for the "group_owner_groups" table.
+	GroupOwnerGroupsTable = &schema.Table{
+		Name:       "group_owner_groups",
+		Columns:    GroupOwnerGroupsColumns,
+		PrimaryKey: []*schema.Column{GroupOwnerGroupsColumns[0], GroupOwnerGroupsColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "group_owner_groups_group_id",
+				Columns:    []*schema.Column{GroupOwnerGroupsColumns[0]},
+				RefColumns: []*schema.Column{GroupsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "group_owner_groups_owned_group_id",
+				Columns:    []*schema.Column{GroupOwnerGroupsColumns[1]},
+				RefColumns: []*schema.Column{GroupsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// PasswordPolicyGroupsColumns holds the columns for the "password_policy_groups" table.
+	PasswordPolicyGroupsColumns = []*schema.Column{
+		{Name: "password_policy_id", Type: field.TypeUUID},
+		{Name: "group_id", Type: field.TypeUUID},
+	}
+	// PasswordPolicyGroupsTable holds the schema information for the "password_policy_groups" table.
+	PasswordPolicyGroupsTable = &schema.Table{
+		Name:       "password_policy_groups",
+		Columns:    PasswordPolicyGroupsColumns,
+		PrimaryKey: []*schema.Column{PasswordPolicyGroupsColumns[0], PasswordPolicyGroupsColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "password_policy_groups_password_policy_id",
+				Columns:    []*schema.Column{PasswordPolicyGroupsColumns[0]},
+				RefColumns: []*schema.Column{PasswordPoliciesColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "password_policy_groups_group_id",
+				Columns:    []*schema.Column{PasswordPolicyGroupsColumns[1]},
+				RefColumns: []*schema.Column{GroupsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// RoleUsersColumns holds the columns for the "role_users" table.
+	RoleUsersColumns = []*schema.Column{
+		{Name: "role_id", Type: field.TypeUUID},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// RoleUsersTable holds the schema information for the "role_users" table.
+	RoleUsersTable = &schema.Table{
+		Name:       "role_users",
+		Columns:    RoleUsersColumns,
+		PrimaryKey: []*schema.Column{RoleUsersColumns[0], RoleUsersColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "role_users_role_id",
+				Columns:    []*schema.Column{RoleUsersColumns[0]},
+				RefColumns: []*schema.Column{RolesColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "role_users_user_id",
+				Columns:    []*schema.Column{RoleUsersColumns[1]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// RoleGroupsColumns holds the columns for the "role_groups" table.
+	RoleGroupsColumns = []*schema.Column{
+		{Name: "role_id", Type: field.TypeUUID},
+		{Name: "group_id", Type: field.TypeUUID},
+	}
+	// RoleGroupsTable holds the schema information for the "role_groups" table.
+	RoleGroupsTable = &schema.Table{
+		Name:       "role_groups",
+		Columns:    RoleGroupsColumns,
+		PrimaryKey: []*schema.Column{RoleGroupsColumns[0], RoleGroupsColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "role_groups_role_id",
+				Columns:    []*schema.Column{RoleGroupsColumns[0]},
+				RefColumns: []*schema.Column{RolesColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "role_groups_group_id",
+				Columns:    []*schema.Column{RoleGroupsColumns[1]},
+				RefColumns: []*schema.Column{GroupsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		APIKeysTable,
+		AttributeDefinitionsTable,
+		AttributeValuesTable,
+		AuditEventsTable,
+		AuthorizationCodesTable,
+		DirectoryObjectsTable,
 		GroupsTable,
+		GroupMembershipChangesTable,
+		GroupVersionsTable,
+		InvitationsTable,
+		LoginEventsTable,
+		OidcClientsTable,
+		OidcConsentsTable,
+		OutboxEventsTable,
+		PasswordHistoriesTable,
+		PasswordPoliciesTable,
+		PasswordResetTokensTable,
+		ProvisionedObjectsTable,
+		ProvisioningConnectorsTable,
+		RecoveryCodesTable,
+		RolesTable,
+		SSHKeysTable,
+		ServiceAccountsTable,
+		SessionsTable,
+		SigningKeysTable,
 		UsersTable,
+		UserVersionsTable,
+		WebhookDeliveriesTable,
+		WebhookSubscriptionsTable,
 		GroupUsersTable,
+		GroupOwnerUsersTable,
+		GroupOwnerGroupsTable,
+		PasswordPolicyGroupsTable,
+		RoleUsersTable,
+		RoleGroupsTable,
 	}
 )
 
 func init() {
+	APIKeysTable.ForeignKeys[0].RefTable = ServiceAccountsTable
+	AttributeValuesTable.ForeignKeys[0].RefTable = GroupsTable
+	AttributeValuesTable.ForeignKeys[1].RefTable = UsersTable
+	AuthorizationCodesTable.ForeignKeys[0].RefTable = OidcClientsTable
+	AuthorizationCodesTable.ForeignKeys[1].RefTable = UsersTable
 	GroupsTable.ForeignKeys[0].RefTable = GroupsTable
+	InvitationsTable.ForeignKeys[0].RefTable = UsersTable
+	OidcConsentsTable.ForeignKeys[0].RefTable = OidcClientsTable
+	OidcConsentsTable.ForeignKeys[1].RefTable = UsersTable
+	PasswordHistoriesTable.ForeignKeys[0].RefTable = UsersTable
+	PasswordResetTokensTable.ForeignKeys[0].RefTable = UsersTable
+	ProvisionedObjectsTable.ForeignKeys[0].RefTable = ProvisioningConnectorsTable
+	RecoveryCodesTable.ForeignKeys[0].RefTable = UsersTable
+	SSHKeysTable.ForeignKeys[0].RefTable = UsersTable
+	SessionsTable.ForeignKeys[0].RefTable = UsersTable
+	UsersTable.ForeignKeys[0].RefTable = UsersTable
+	WebhookDeliveriesTable.ForeignKeys[0].RefTable = OutboxEventsTable
+	WebhookDeliveriesTable.ForeignKeys[1].RefTable = WebhookSubscriptionsTable
 	GroupUsersTable.ForeignKeys[0].RefTable = GroupsTable
 	GroupUsersTable.ForeignKeys[1].RefTable = UsersTable
+	GroupOwnerUsersTable.ForeignKeys[0].RefTable = GroupsTable
+	GroupOwnerUsersTable.ForeignKeys[1].RefTable = UsersTable
+	GroupOwnerGroupsTable.ForeignKeys[0].RefTable = GroupsTable
+	GroupOwnerGroupsTable.ForeignKeys[1].RefTable = GroupsTable
+	PasswordPolicyGroupsTable.ForeignKeys[0].RefTable = PasswordPoliciesTable
+	PasswordPolicyGroupsTable.ForeignKeys[1].RefTable = GroupsTable
+	RoleUsersTable.ForeignKeys[0].RefTable = RolesTable
+	RoleUsersTable.ForeignKeys[1].RefTable = UsersTable
+	RoleGroupsTable.ForeignKeys[0].RefTable = RolesTable
+	RoleGroupsTable.ForeignKeys[1].RefTable = GroupsTable
 }
@@ -0,0 +1,210 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/oidcclient"
+)
+
+// OIDCClient is the model entity for the OIDCClient schema.
+type OIDCClient struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// RedirectUris holds the value of the "redirect_uris" field.
+	RedirectUris []string `json:"redirect_uris,omitempty"`
+	// Public holds the value of the "public" field.
+	Public bool `json:"public,omitempty"`
+	// SecretHash holds the value of the "secret_hash" field.
+	SecretHash string `json:"-"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the OIDCClientQuery when eager-loading is set.
+	Edges        OIDCClientEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// OIDCClientEdges holds the relations/edges for other nodes in the graph.
+type OIDCClientEdges struct {
+	// Codes holds the value of the codes edge.
+	Codes []*AuthorizationCode `json:"codes,omitempty"`
+	// Consents holds the value of the consents edge.
+	Consents []*OIDCConsent `json:"consents,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// CodesOrErr returns the Codes value or an error if the edge
+// was not loaded in eager-loading.
+func (e OIDCClientEdges) CodesOrErr() ([]*AuthorizationCode, error) {
+	if e.loadedTypes[0] {
+		return e.Codes, nil
+	}
+	return nil, &NotLoadedError{edge: "codes"}
+}
+
+// ConsentsOrErr returns the Consents value or an error if the edge
+// was not loaded in eager-loading.
+func (e OIDCClientEdges) ConsentsOrErr() ([]*OIDCConsent, error) {
+	if e.loadedTypes[1] {
+		return e.Consents, nil
+	}
+	return nil, &NotLoadedError{edge: "consents"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*OIDCClient) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case oidcclient.FieldRedirectUris:
+			values[i] = new([]byte)
+		case oidcclient.FieldPublic:
+			values[i] = new(sql.NullBool)
+		case oidcclient.FieldName, oidcclient.FieldSecretHash:
+			values[i] = new(sql.NullString)
+		case oidcclient.FieldCreatedAt, oidcclient.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case oidcclient.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the OIDCClient fields.
+func (_m *OIDCClient) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case oidcclient.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case oidcclient.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case oidcclient.FieldRedirectUris:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field redirect_uris", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.RedirectUris); err != nil {
+					return fmt.Errorf("unmarshal field redirect_uris: %w", err)
+				}
+			}
+		case oidcclient.FieldPublic:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field public", values[i])
+			} else if value.Valid {
+				_m.Public = value.Bool
+			}
+		case oidcclient.FieldSecretHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field secret_hash", values[i])
+			} else if value.Valid {
+				_m.SecretHash = value.String
+			}
+		case oidcclient.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case oidcclient.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the OIDCClient.
+// This includes values selected through modifiers, order, etc.
+func (_m *OIDCClient) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryCodes queries the "codes" edge of the OIDCClient entity.
+func (_m *OIDCClient) QueryCodes() *AuthorizationCodeQuery {
+	return NewOIDCClientClient(_m.config).QueryCodes(_m)
+}
+
+// QueryConsents queries the "consents" edge of the OIDCClient entity.
+func (_m *OIDCClient) QueryConsents() *OIDCConsentQuery {
+	return NewOIDCClientClient(_m.config).QueryConsents(_m)
+}
+
+// Update returns a builder for updating this OIDCClient.
+// Note that you need to call OIDCClient.Unwrap() before calling this method if this OIDCClient
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *OIDCClient) Update() *OIDCClientUpdateOne {
+	return NewOIDCClientClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the OIDCClient entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *OIDCClient) Unwrap() *OIDCClient {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: OIDCClient is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *OIDCClient) String() string {
+	var builder strings.Builder
+	builder.WriteString("OIDCClient(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("redirect_uris=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RedirectUris))
+	builder.WriteString(", ")
+	builder.WriteString("public=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Public))
+	builder.WriteString(", ")
+	builder.WriteString("secret_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// OIDCClients is a parsable slice of OIDCClient.
+type OIDCClients []*OIDCClient
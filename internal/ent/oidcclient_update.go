@@ -0,0 +1,692 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/authorizationcode"
+	"github.com/qinzj/claude-demo/internal/ent/oidcclient"
+	"github.com/qinzj/claude-demo/internal/ent/oidcconsent"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// OIDCClientUpdate is the builder for updating OIDCClient entities.
+type OIDCClientUpdate struct {
+	config
+	hooks    []Hook
+	mutation *OIDCClientMutation
+}
+
+// Where appends a list predicates to the OIDCClientUpdate builder.
+func (_u *OIDCClientUpdate) Where(ps ...predicate.OIDCClient) *OIDCClientUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *OIDCClientUpdate) SetName(v string) *OIDCClientUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *OIDCClientUpdate) SetNillableName(v *string) *OIDCClientUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetRedirectUris sets the "redirect_uris" field.
+func (_u *OIDCClientUpdate) SetRedirectUris(v []string) *OIDCClientUpdate {
+	_u.mutation.SetRedirectUris(v)
+	return _u
+}
+
+// AppendRedirectUris appends value to the "redirect_uris" field.
+func (_u *OIDCClientUpdate) AppendRedirectUris(v []string) *OIDCClientUpdate {
+	_u.mutation.AppendRedirectUris(v)
+	return _u
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (_u *OIDCClientUpdate) SetSecretHash(v string) *OIDCClientUpdate {
+	_u.mutation.SetSecretHash(v)
+	return _u
+}
+
+// SetNillableSecretHash sets the "secret_hash" field if the given value is not nil.
+func (_u *OIDCClientUpdate) SetNillableSecretHash(v *string) *OIDCClientUpdate {
+	if v != nil {
+		_u.SetSecretHash(*v)
+	}
+	return _u
+}
+
+// ClearSecretHash clears the value of the "secret_hash" field.
+func (_u *OIDCClientUpdate) ClearSecretHash() *OIDCClientUpdate {
+	_u.mutation.ClearSecretHash()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *OIDCClientUpdate) SetUpdatedAt(v time.Time) *OIDCClientUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddCodeIDs adds the "codes" edge to the AuthorizationCode entity by IDs.
+func (_u *OIDCClientUpdate) AddCodeIDs(ids ...uuid.UUID) *OIDCClientUpdate {
+	_u.mutation.AddCodeIDs(ids...)
+	return _u
+}
+
+// AddCodes adds the "codes" edges to the AuthorizationCode entity.
+func (_u *OIDCClientUpdate) AddCodes(v ...*AuthorizationCode) *OIDCClientUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddCodeIDs(ids...)
+}
+
+// AddConsentIDs adds the "consents" edge to the OIDCConsent entity by IDs.
+func (_u *OIDCClientUpdate) AddConsentIDs(ids ...uuid.UUID) *OIDCClientUpdate {
+	_u.mutation.AddConsentIDs(ids...)
+	return _u
+}
+
+// AddConsents adds the "consents" edges to the OIDCConsent entity.
+func (_u *OIDCClientUpdate) AddConsents(v ...*OIDCConsent) *OIDCClientUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddConsentIDs(ids...)
+}
+
+// Mutation returns the OIDCClientMutation object of the builder.
+func (_u *OIDCClientUpdate) Mutation() *OIDCClientMutation {
+	return _u.mutation
+}
+
+// ClearCodes clears all "codes" edges to the AuthorizationCode entity.
+func (_u *OIDCClientUpdate) ClearCodes() *OIDCClientUpdate {
+	_u.mutation.ClearCodes()
+	return _u
+}
+
+// RemoveCodeIDs removes the "codes" edge to AuthorizationCode entities by IDs.
+func (_u *OIDCClientUpdate) RemoveCodeIDs(ids ...uuid.UUID) *OIDCClientUpdate {
+	_u.mutation.RemoveCodeIDs(ids...)
+	return _u
+}
+
+// RemoveCodes removes "codes" edges to AuthorizationCode entities.
+func (_u *OIDCClientUpdate) RemoveCodes(v ...*AuthorizationCode) *OIDCClientUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveCodeIDs(ids...)
+}
+
+// ClearConsents clears all "consents" edges to the OIDCConsent entity.
+func (_u *OIDCClientUpdate) ClearConsents() *OIDCClientUpdate {
+	_u.mutation.ClearConsents()
+	return _u
+}
+
+// RemoveConsentIDs removes the "consents" edge to OIDCConsent entities by IDs.
+func (_u *OIDCClientUpdate) RemoveConsentIDs(ids ...uuid.UUID) *OIDCClientUpdate {
+	_u.mutation.RemoveConsentIDs(ids...)
+	return _u
+}
+
+// RemoveConsents removes "consents" edges to OIDCConsent entities.
+func (_u *OIDCClientUpdate) RemoveConsents(v ...*OIDCConsent) *OIDCClientUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveConsentIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *OIDCClientUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *OIDCClientUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *OIDCClientUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *OIDCClientUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *OIDCClientUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := oidcclient.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *OIDCClientUpdate) check() error {
+	if v, ok := _u.mutation.Name(); ok {
+		if err := oidcclient.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "OIDCClient.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *OIDCClientUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(oidcclient.Table, oidcclient.Columns, sqlgraph.NewFieldSpec(oidcclient.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(oidcclient.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RedirectUris(); ok {
+		_spec.SetField(oidcclient.FieldRedirectUris, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRedirectUris(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, oidcclient.FieldRedirectUris, value)
+		})
+	}
+	if value, ok := _u.mutation.SecretHash(); ok {
+		_spec.SetField(oidcclient.FieldSecretHash, field.TypeString, value)
+	}
+	if _u.mutation.SecretHashCleared() {
+		_spec.ClearField(oidcclient.FieldSecretHash, field.TypeString)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(oidcclient.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.CodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.CodesTable,
+			Columns: []string{oidcclient.CodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(authorizationcode.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedCodesIDs(); len(nodes) > 0 && !_u.mutation.CodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.CodesTable,
+			Columns: []string{oidcclient.CodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(authorizationcode.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.CodesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.CodesTable,
+			Columns: []string{oidcclient.CodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(authorizationcode.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ConsentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.ConsentsTable,
+			Columns: []string{oidcclient.ConsentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(oidcconsent.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedConsentsIDs(); len(nodes) > 0 && !_u.mutation.ConsentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.ConsentsTable,
+			Columns: []string{oidcclient.ConsentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(oidcconsent.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ConsentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.ConsentsTable,
+			Columns: []string{oidcclient.ConsentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(oidcconsent.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{oidcclient.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// OIDCClientUpdateOne is the builder for updating a single OIDCClient entity.
+type OIDCClientUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *OIDCClientMutation
+}
+
+// SetName sets the "name" field.
+func (_u *OIDCClientUpdateOne) SetName(v string) *OIDCClientUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *OIDCClientUpdateOne) SetNillableName(v *string) *OIDCClientUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetRedirectUris sets the "redirect_uris" field.
+func (_u *OIDCClientUpdateOne) SetRedirectUris(v []string) *OIDCClientUpdateOne {
+	_u.mutation.SetRedirectUris(v)
+	return _u
+}
+
+// AppendRedirectUris appends value to the "redirect_uris" field.
+func (_u *OIDCClientUpdateOne) AppendRedirectUris(v []string) *OIDCClientUpdateOne {
+	_u.mutation.AppendRedirectUris(v)
+	return _u
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (_u *OIDCClientUpdateOne) SetSecretHash(v string) *OIDCClientUpdateOne {
+	_u.mutation.SetSecretHash(v)
+	return _u
+}
+
+// SetNillableSecretHash sets the "secret_hash" field if the given value is not nil.
+func (_u *OIDCClientUpdateOne) SetNillableSecretHash(v *string) *OIDCClientUpdateOne {
+	if v != nil {
+		_u.SetSecretHash(*v)
+	}
+	return _u
+}
+
+// ClearSecretHash clears the value of the "secret_hash" field.
+func (_u *OIDCClientUpdateOne) ClearSecretHash() *OIDCClientUpdateOne {
+	_u.mutation.ClearSecretHash()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *OIDCClientUpdateOne) SetUpdatedAt(v time.Time) *OIDCClientUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddCodeIDs adds the "codes" edge to the AuthorizationCode entity by IDs.
+func (_u *OIDCClientUpdateOne) AddCodeIDs(ids ...uuid.UUID) *OIDCClientUpdateOne {
+	_u.mutation.AddCodeIDs(ids...)
+	return _u
+}
+
+// AddCodes adds the "codes" edges to the AuthorizationCode entity.
+func (_u *OIDCClientUpdateOne) AddCodes(v ...*AuthorizationCode) *OIDCClientUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddCodeIDs(ids...)
+}
+
+// AddConsentIDs adds the "consents" edge to the OIDCConsent entity by IDs.
+func (_u *OIDCClientUpdateOne) AddConsentIDs(ids ...uuid.UUID) *OIDCClientUpdateOne {
+	_u.mutation.AddConsentIDs(ids...)
+	return _u
+}
+
+// AddConsents adds the "consents" edges to the OIDCConsent entity.
+func (_u *OIDCClientUpdateOne) AddConsents(v ...*OIDCConsent) *OIDCClientUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddConsentIDs(ids...)
+}
+
+// Mutation returns the OIDCClientMutation object of the builder.
+func (_u *OIDCClientUpdateOne) Mutation() *OIDCClientMutation {
+	return _u.mutation
+}
+
+// ClearCodes clears all "codes" edges to the AuthorizationCode entity.
+func (_u *OIDCClientUpdateOne) ClearCodes() *OIDCClientUpdateOne {
+	_u.mutation.ClearCodes()
+	return _u
+}
+
+// RemoveCodeIDs removes the "codes" edge to AuthorizationCode entities by IDs.
+func (_u *OIDCClientUpdateOne) RemoveCodeIDs(ids ...uuid.UUID) *OIDCClientUpdateOne {
+	_u.mutation.RemoveCodeIDs(ids...)
+	return _u
+}
+
+// RemoveCodes removes "codes" edges to AuthorizationCode entities.
+func (_u *OIDCClientUpdateOne) RemoveCodes(v ...*AuthorizationCode) *OIDCClientUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveCodeIDs(ids...)
+}
+
+// ClearConsents clears all "consents" edges to the OIDCConsent entity.
+func (_u *OIDCClientUpdateOne) ClearConsents() *OIDCClientUpdateOne {
+	_u.mutation.ClearConsents()
+	return _u
+}
+
+// RemoveConsentIDs removes the "consents" edge to OIDCConsent entities by IDs.
+func (_u *OIDCClientUpdateOne) RemoveConsentIDs(ids ...uuid.UUID) *OIDCClientUpdateOne {
+	_u.mutation.RemoveConsentIDs(ids...)
+	return _u
+}
+
+// RemoveConsents removes "consents" edges to OIDCConsent entities.
+func (_u *OIDCClientUpdateOne) RemoveConsents(v ...*OIDCConsent) *OIDCClientUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveConsentIDs(ids...)
+}
+
+// Where appends a list predicates to the OIDCClientUpdate builder.
+func (_u *OIDCClientUpdateOne) Where(ps ...predicate.OIDCClient) *OIDCClientUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *OIDCClientUpdateOne) Select(field string, fields ...string) *OIDCClientUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated OIDCClient entity.
+func (_u *OIDCClientUpdateOne) Save(ctx context.Context) (*OIDCClient, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *OIDCClientUpdateOne) SaveX(ctx context.Context) *OIDCClient {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *OIDCClientUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *OIDCClientUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *OIDCClientUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := oidcclient.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *OIDCClientUpdateOne) check() error {
+	if v, ok := _u.mutation.Name(); ok {
+		if err := oidcclient.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "OIDCClient.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *OIDCClientUpdateOne) sqlSave(ctx context.Context) (_node *OIDCClient, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(oidcclient.Table, oidcclient.Columns, sqlgraph.NewFieldSpec(oidcclient.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "OIDCClient.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, oidcclient.FieldID)
+		for _, f := range fields {
+			if !oidcclient.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != oidcclient.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(oidcclient.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RedirectUris(); ok {
+		_spec.SetField(oidcclient.FieldRedirectUris, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRedirectUris(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, oidcclient.FieldRedirectUris, value)
+		})
+	}
+	if value, ok := _u.mutation.SecretHash(); ok {
+		_spec.SetField(oidcclient.FieldSecretHash, field.TypeString, value)
+	}
+	if _u.mutation.SecretHashCleared() {
+		_spec.ClearField(oidcclient.FieldSecretHash, field.TypeString)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(oidcclient.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.CodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.CodesTable,
+			Columns: []string{oidcclient.CodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(authorizationcode.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedCodesIDs(); len(nodes) > 0 && !_u.mutation.CodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.CodesTable,
+			Columns: []string{oidcclient.CodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(authorizationcode.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.CodesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.CodesTable,
+			Columns: []string{oidcclient.CodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(authorizationcode.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ConsentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.ConsentsTable,
+			Columns: []string{oidcclient.ConsentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(oidcconsent.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedConsentsIDs(); len(nodes) > 0 && !_u.mutation.ConsentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.ConsentsTable,
+			Columns: []string{oidcclient.ConsentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(oidcconsent.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ConsentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   oidcclient.ConsentsTable,
+			Columns: []string{oidcclient.ConsentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(oidcconsent.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &OIDCClient{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{oidcclient.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
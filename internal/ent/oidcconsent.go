@@ -0,0 +1,200 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/oidcclient"
+	"github.com/qinzj/claude-demo/internal/ent/oidcconsent"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+)
+
+// OIDCConsent is the model entity for the OIDCConsent schema.
+type OIDCConsent struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Scopes holds the value of the "scopes" field.
+	Scopes []string `json:"scopes,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the OIDCConsentQuery when eager-loading is set.
+	Edges                OIDCConsentEdges `json:"edges"`
+	oidc_client_consents *uuid.UUID
+	user_oidc_consents   *uuid.UUID
+	selectValues         sql.SelectValues
+}
+
+// OIDCConsentEdges holds the relations/edges for other nodes in the graph.
+type OIDCConsentEdges struct {
+	// Client holds the value of the client edge.
+	Client *OIDCClient `json:"client,omitempty"`
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// ClientOrErr returns the Client value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e OIDCConsentEdges) ClientOrErr() (*OIDCClient, error) {
+	if e.Client != nil {
+		return e.Client, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: oidcclient.Label}
+	}
+	return nil, &NotLoadedError{edge: "client"}
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e OIDCConsentEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*OIDCConsent) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case oidcconsent.FieldScopes:
+			values[i] = new([]byte)
+		case oidcconsent.FieldCreatedAt, oidcconsent.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case oidcconsent.FieldID:
+			values[i] = new(uuid.UUID)
+		case oidcconsent.ForeignKeys[0]: // oidc_client_consents
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		case oidcconsent.ForeignKeys[1]: // user_oidc_consents
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the OIDCConsent fields.
+func (_m *OIDCConsent) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case oidcconsent.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case oidcconsent.FieldScopes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field scopes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Scopes); err != nil {
+					return fmt.Errorf("unmarshal field scopes: %w", err)
+				}
+			}
+		case oidcconsent.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case oidcconsent.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		case oidcconsent.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field oidc_client_consents", values[i])
+			} else if value.Valid {
+				_m.oidc_client_consents = new(uuid.UUID)
+				*_m.oidc_client_consents = *value.S.(*uuid.UUID)
+			}
+		case oidcconsent.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field user_oidc_consents", values[i])
+			} else if value.Valid {
+				_m.user_oidc_consents = new(uuid.UUID)
+				*_m.user_oidc_consents = *value.S.(*uuid.UUID)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the OIDCConsent.
+// This includes values selected through modifiers, order, etc.
+func (_m *OIDCConsent) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryClient queries the "client" edge of the OIDCConsent entity.
+func (_m *OIDCConsent) QueryClient() *OIDCClientQuery {
+	return NewOIDCConsentClient(_m.config).QueryClient(_m)
+}
+
+// QueryUser queries the "user" edge of the OIDCConsent entity.
+func (_m *OIDCConsent) QueryUser() *UserQuery {
+	return NewOIDCConsentClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this OIDCConsent.
+// Note that you need to call OIDCConsent.Unwrap() before calling this method if this OIDCConsent
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *OIDCConsent) Update() *OIDCConsentUpdateOne {
+	return NewOIDCConsentClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the OIDCConsent entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *OIDCConsent) Unwrap() *OIDCConsent {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: OIDCConsent is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *OIDCConsent) String() string {
+	var builder strings.Builder
+	builder.WriteString("OIDCConsent(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("scopes=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Scopes))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// OIDCConsents is a parsable slice of OIDCConsent.
+type OIDCConsents []*OIDCConsent
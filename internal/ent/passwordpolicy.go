@@ -0,0 +1,291 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/passwordpolicy"
+)
+
+// PasswordPolicy is the model entity for the PasswordPolicy schema.
+type PasswordPolicy struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Description holds the value of the "description" field.
+	Description string `json:"description,omitempty"`
+	// Builtin holds the value of the "builtin" field.
+	Builtin bool `json:"builtin,omitempty"`
+	// MinLength holds the value of the "min_length" field.
+	MinLength int `json:"min_length,omitempty"`
+	// RequireUppercase holds the value of the "require_uppercase" field.
+	RequireUppercase bool `json:"require_uppercase,omitempty"`
+	// RequireLowercase holds the value of the "require_lowercase" field.
+	RequireLowercase bool `json:"require_lowercase,omitempty"`
+	// RequireDigit holds the value of the "require_digit" field.
+	RequireDigit bool `json:"require_digit,omitempty"`
+	// RequireSymbol holds the value of the "require_symbol" field.
+	RequireSymbol bool `json:"require_symbol,omitempty"`
+	// CheckDictionary holds the value of the "check_dictionary" field.
+	CheckDictionary bool `json:"check_dictionary,omitempty"`
+	// CheckUsername holds the value of the "check_username" field.
+	CheckUsername bool `json:"check_username,omitempty"`
+	// HistoryCount holds the value of the "history_count" field.
+	HistoryCount int `json:"history_count,omitempty"`
+	// MinAgeHours holds the value of the "min_age_hours" field.
+	MinAgeHours int `json:"min_age_hours,omitempty"`
+	// MaxAgeDays holds the value of the "max_age_days" field.
+	MaxAgeDays int `json:"max_age_days,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PasswordPolicyQuery when eager-loading is set.
+	Edges        PasswordPolicyEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// PasswordPolicyEdges holds the relations/edges for other nodes in the graph.
+type PasswordPolicyEdges struct {
+	// Groups holds the value of the groups edge.
+	Groups []*Group `json:"groups,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// GroupsOrErr returns the Groups value or an error if the edge
+// was not loaded in eager-loading.
+func (e PasswordPolicyEdges) GroupsOrErr() ([]*Group, error) {
+	if e.loadedTypes[0] {
+		return e.Groups, nil
+	}
+	return nil, &NotLoadedError{edge: "groups"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*PasswordPolicy) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case passwordpolicy.FieldBuiltin, passwordpolicy.FieldRequireUppercase, passwordpolicy.FieldRequireLowercase, passwordpolicy.FieldRequireDigit, passwordpolicy.FieldRequireSymbol, passwordpolicy.FieldCheckDictionary, passwordpolicy.FieldCheckUsername:
+			values[i] = new(sql.NullBool)
+		case passwordpolicy.FieldMinLength, passwordpolicy.FieldHistoryCount, passwordpolicy.FieldMinAgeHours, passwordpolicy.FieldMaxAgeDays:
+			values[i] = new(sql.NullInt64)
+		case passwordpolicy.FieldName, passwordpolicy.FieldDescription:
+			values[i] = new(sql.NullString)
+		case passwordpolicy.FieldCreatedAt, passwordpolicy.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case passwordpolicy.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the PasswordPolicy fields.
+func (_m *PasswordPolicy) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case passwordpolicy.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case passwordpolicy.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case passwordpolicy.FieldDescription:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field description", values[i])
+			} else if value.Valid {
+				_m.Description = value.String
+			}
+		case passwordpolicy.FieldBuiltin:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field builtin", values[i])
+			} else if value.Valid {
+				_m.Builtin = value.Bool
+			}
+		case passwordpolicy.FieldMinLength:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field min_length", values[i])
+			} else if value.Valid {
+				_m.MinLength = int(value.Int64)
+			}
+		case passwordpolicy.FieldRequireUppercase:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field require_uppercase", values[i])
+			} else if value.Valid {
+				_m.RequireUppercase = value.Bool
+			}
+		case passwordpolicy.FieldRequireLowercase:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field require_lowercase", values[i])
+			} else if value.Valid {
+				_m.RequireLowercase = value.Bool
+			}
+		case passwordpolicy.FieldRequireDigit:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field require_digit", values[i])
+			} else if value.Valid {
+				_m.RequireDigit = value.Bool
+			}
+		case passwordpolicy.FieldRequireSymbol:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field require_symbol", values[i])
+			} else if value.Valid {
+				_m.RequireSymbol = value.Bool
+			}
+		case passwordpolicy.FieldCheckDictionary:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field check_dictionary", values[i])
+			} else if value.Valid {
+				_m.CheckDictionary = value.Bool
+			}
+		case passwordpolicy.FieldCheckUsername:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field check_username", values[i])
+			} else if value.Valid {
+				_m.CheckUsername = value.Bool
+			}
+		case passwordpolicy.FieldHistoryCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field history_count", values[i])
+			} else if value.Valid {
+				_m.HistoryCount = int(value.Int64)
+			}
+		case passwordpolicy.FieldMinAgeHours:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field min_age_hours", values[i])
+			} else if value.Valid {
+				_m.MinAgeHours = int(value.Int64)
+			}
+		case passwordpolicy.FieldMaxAgeDays:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_age_days", values[i])
+			} else if value.Valid {
+				_m.MaxAgeDays = int(value.Int64)
+			}
+		case passwordpolicy.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case passwordpolicy.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the PasswordPolicy.
+// This includes values selected through modifiers, order, etc.
+func (_m *PasswordPolicy) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryGroups queries the "groups" edge of the PasswordPolicy entity.
+func (_m *PasswordPolicy) QueryGroups() *GroupQuery {
+	return NewPasswordPolicyClient(_m.config).QueryGroups(_m)
+}
+
+// Update returns a builder for updating this PasswordPolicy.
+// Note that you need to call PasswordPolicy.Unwrap() before calling this method if this PasswordPolicy
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *PasswordPolicy) Update() *PasswordPolicyUpdateOne {
+	return NewPasswordPolicyClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the PasswordPolicy entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *PasswordPolicy) Unwrap() *PasswordPolicy {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: PasswordPolicy is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *PasswordPolicy) String() string {
+	var builder strings.Builder
+	builder.WriteString("PasswordPolicy(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("description=")
+	builder.WriteString(_m.Description)
+	builder.WriteString(", ")
+	builder.WriteString("builtin=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Builtin))
+	builder.WriteString(", ")
+	builder.WriteString("min_length=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MinLength))
+	builder.WriteString(", ")
+	builder.WriteString("require_uppercase=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RequireUppercase))
+	builder.WriteString(", ")
+	builder.WriteString("require_lowercase=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RequireLowercase))
+	builder.WriteString(", ")
+	builder.WriteString("require_digit=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RequireDigit))
+	builder.WriteString(", ")
+	builder.WriteString("require_symbol=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RequireSymbol))
+	builder.WriteString(", ")
+	builder.WriteString("check_dictionary=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CheckDictionary))
+	builder.WriteString(", ")
+	builder.WriteString("check_username=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CheckUsername))
+	builder.WriteString(", ")
+	builder.WriteString("history_count=")
+	builder.WriteString(fmt.Sprintf("%v", _m.HistoryCount))
+	builder.WriteString(", ")
+	builder.WriteString("min_age_hours=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MinAgeHours))
+	builder.WriteString(", ")
+	builder.WriteString("max_age_days=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MaxAgeDays))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// PasswordPolicies is a parsable slice of PasswordPolicy.
+type PasswordPolicies []*PasswordPolicy
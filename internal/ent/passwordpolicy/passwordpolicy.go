@@ -0,0 +1,242 @@
+// Code generated by ent, DO NOT EDIT.
+
+package passwordpolicy
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the passwordpolicy type in the database.
+	Label = "password_policy"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldDescription holds the string denoting the description field in the database.
+	FieldDescription = "description"
+	// FieldBuiltin holds the string denoting the builtin field in the database.
+	FieldBuiltin = "builtin"
+	// FieldMinLength holds the string denoting the min_length field in the database.
+	FieldMinLength = "min_length"
+	// FieldRequireUppercase holds the string denoting the require_uppercase field in the database.
+	FieldRequireUppercase = "require_uppercase"
+	// FieldRequireLowercase holds the string denoting the require_lowercase field in the database.
+	FieldRequireLowercase = "require_lowercase"
+	// FieldRequireDigit holds the string denoting the require_digit field in the database.
+	FieldRequireDigit = "require_digit"
+	// FieldRequireSymbol holds the string denoting the require_symbol field in the database.
+	FieldRequireSymbol = "require_symbol"
+	// FieldCheckDictionary holds the string denoting the check_dictionary field in the database.
+	FieldCheckDictionary = "check_dictionary"
+	// FieldCheckUsername holds the string denoting the check_username field in the database.
+	FieldCheckUsername = "check_username"
+	// FieldHistoryCount holds the string denoting the history_count field in the database.
+	FieldHistoryCount = "history_count"
+	// FieldMinAgeHours holds the string denoting the min_age_hours field in the database.
+	FieldMinAgeHours = "min_age_hours"
+	// FieldMaxAgeDays holds the string denoting the max_age_days field in the database.
+	FieldMaxAgeDays = "max_age_days"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// EdgeGroups holds the string denoting the groups edge name in mutations.
+	EdgeGroups = "groups"
+	// Table holds the table name of the passwordpolicy in the database.
+	Table = "password_policies"
+	// GroupsTable is the table that holds the groups relation/edge. The primary key declared below.
+	GroupsTable = "password_policy_groups"
+	// GroupsInverseTable is the table name for the Group entity.
+	// It exists in this package in order to avoid circular dependency with the "group" package.
+	GroupsInverseTable = "groups"
+)
+
+// Columns holds all SQL columns for passwordpolicy fields.
+var Columns = []string{
+	FieldID,
+	FieldName,
+	FieldDescription,
+	FieldBuiltin,
+	FieldMinLength,
+	FieldRequireUppercase,
+	FieldRequireLowercase,
+	FieldRequireDigit,
+	FieldRequireSymbol,
+	FieldCheckDictionary,
+	FieldCheckUsername,
+	FieldHistoryCount,
+	FieldMinAgeHours,
+	FieldMaxAgeDays,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+}
+
+var (
+	// GroupsPrimaryKey and GroupsColumn2 are the table columns denoting the
+	// primary key for the groups relation (M2M).
+	GroupsPrimaryKey = []string{"password_policy_id", "group_id"}
+)
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// NameValidator is a validator for the "name" field. It is called by the builders before save.
+	NameValidator func(string) error
+	// DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
+	DescriptionValidator func(string) error
+	// DefaultBuiltin holds the default value on creation for the "builtin" field.
+	DefaultBuiltin bool
+	// DefaultMinLength holds the default value on creation for the "min_length" field.
+	DefaultMinLength int
+	// MinLengthValidator is a validator for the "min_length" field. It is called by the builders before save.
+	MinLengthValidator func(int) error
+	// DefaultRequireUppercase holds the default value on creation for the "require_uppercase" field.
+	DefaultRequireUppercase bool
+	// DefaultRequireLowercase holds the default value on creation for the "require_lowercase" field.
+	DefaultRequireLowercase bool
+	// DefaultRequireDigit holds the default value on creation for the "require_digit" field.
+	DefaultRequireDigit bool
+	// DefaultRequireSymbol holds the default value on creation for the "require_symbol" field.
+	DefaultRequireSymbol bool
+	// DefaultCheckDictionary holds the default value on creation for the "check_dictionary" field.
+	DefaultCheckDictionary bool
+	// DefaultCheckUsername holds the default value on creation for the "check_username" field.
+	DefaultCheckUsername bool
+	// DefaultHistoryCount holds the default value on creation for the "history_count" field.
+	DefaultHistoryCount int
+	// HistoryCountValidator is a validator for the "history_count" field. It is called by the builders before save.
+	HistoryCountValidator func(int) error
+	// DefaultMinAgeHours holds the default value on creation for the "min_age_hours" field.
+	DefaultMinAgeHours int
+	// MinAgeHoursValidator is a validator for the "min_age_hours" field. It is called by the builders before save.
+	MinAgeHoursValidator func(int) error
+	// DefaultMaxAgeDays holds the default value on creation for the "max_age_days" field.
+	DefaultMaxAgeDays int
+	// MaxAgeDaysValidator is a validator for the "max_age_days" field. It is called by the builders before save.
+	MaxAgeDaysValidator func(int) error
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// OrderOption defines the ordering options for the PasswordPolicy queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByName orders the results by the name field.
+func ByName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldName, opts...).ToFunc()
+}
+
+// ByDescription orders the results by the description field.
+func ByDescription(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDescription, opts...).ToFunc()
+}
+
+// ByBuiltin orders the results by the builtin field.
+func ByBuiltin(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldBuiltin, opts...).ToFunc()
+}
+
+// ByMinLength orders the results by the min_length field.
+func ByMinLength(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMinLength, opts...).ToFunc()
+}
+
+// ByRequireUppercase orders the results by the require_uppercase field.
+func ByRequireUppercase(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRequireUppercase, opts...).ToFunc()
+}
+
+// ByRequireLowercase orders the results by the require_lowercase field.
+func ByRequireLowercase(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRequireLowercase, opts...).ToFunc()
+}
+
+// ByRequireDigit orders the results by the require_digit field.
+func ByRequireDigit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRequireDigit, opts...).ToFunc()
+}
+
+// ByRequireSymbol orders the results by the require_symbol field.
+func ByRequireSymbol(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRequireSymbol, opts...).ToFunc()
+}
+
+// ByCheckDictionary orders the results by the check_dictionary field.
+func ByCheckDictionary(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCheckDictionary, opts...).ToFunc()
+}
+
+// ByCheckUsername orders the results by the check_username field.
+func ByCheckUsername(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCheckUsername, opts...).ToFunc()
+}
+
+// ByHistoryCount orders the results by the history_count field.
+func ByHistoryCount(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldHistoryCount, opts...).ToFunc()
+}
+
+// ByMinAgeHours orders the results by the min_age_hours field.
+func ByMinAgeHours(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMinAgeHours, opts...).ToFunc()
+}
+
+// ByMaxAgeDays orders the results by the max_age_days field.
+func ByMaxAgeDays(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxAgeDays, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
+
+// ByGroupsCount orders the results by groups count.
+func ByGroupsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newGroupsStep(), opts...)
+	}
+}
+
+// ByGroups orders the results by groups terms.
+func ByGroups(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newGroupsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+func newGroupsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(GroupsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, false, GroupsTable, GroupsPrimaryKey...),
+	)
+}
@@ -0,0 +1,620 @@
+// Code generated by ent, DO NOT EDIT.
+
+package passwordpolicy
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLTE(FieldID, id))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldName, v))
+}
+
+// Description applies equality check predicate on the "description" field. It's identical to DescriptionEQ.
+func Description(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldDescription, v))
+}
+
+// Builtin applies equality check predicate on the "builtin" field. It's identical to BuiltinEQ.
+func Builtin(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldBuiltin, v))
+}
+
+// MinLength applies equality check predicate on the "min_length" field. It's identical to MinLengthEQ.
+func MinLength(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldMinLength, v))
+}
+
+// RequireUppercase applies equality check predicate on the "require_uppercase" field. It's identical to RequireUppercaseEQ.
+func RequireUppercase(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldRequireUppercase, v))
+}
+
+// RequireLowercase applies equality check predicate on the "require_lowercase" field. It's identical to RequireLowercaseEQ.
+func RequireLowercase(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldRequireLowercase, v))
+}
+
+// RequireDigit applies equality check predicate on the "require_digit" field. It's identical to RequireDigitEQ.
+func RequireDigit(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldRequireDigit, v))
+}
+
+// RequireSymbol applies equality check predicate on the "require_symbol" field. It's identical to RequireSymbolEQ.
+func RequireSymbol(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldRequireSymbol, v))
+}
+
+// CheckDictionary applies equality check predicate on the "check_dictionary" field. It's identical to CheckDictionaryEQ.
+func CheckDictionary(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldCheckDictionary, v))
+}
+
+// CheckUsername applies equality check predicate on the "check_username" field. It's identical to CheckUsernameEQ.
+func CheckUsername(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldCheckUsername, v))
+}
+
+// HistoryCount applies equality check predicate on the "history_count" field. It's identical to HistoryCountEQ.
+func HistoryCount(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldHistoryCount, v))
+}
+
+// MinAgeHours applies equality check predicate on the "min_age_hours" field. It's identical to MinAgeHoursEQ.
+func MinAgeHours(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldMinAgeHours, v))
+}
+
+// MaxAgeDays applies equality check predicate on the "max_age_days" field. It's identical to MaxAgeDaysEQ.
+func MaxAgeDays(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldMaxAgeDays, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldContainsFold(FieldName, v))
+}
+
+// DescriptionEQ applies the EQ predicate on the "description" field.
+func DescriptionEQ(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldDescription, v))
+}
+
+// DescriptionNEQ applies the NEQ predicate on the "description" field.
+func DescriptionNEQ(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldDescription, v))
+}
+
+// DescriptionIn applies the In predicate on the "description" field.
+func DescriptionIn(vs ...string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldIn(FieldDescription, vs...))
+}
+
+// DescriptionNotIn applies the NotIn predicate on the "description" field.
+func DescriptionNotIn(vs ...string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNotIn(FieldDescription, vs...))
+}
+
+// DescriptionGT applies the GT predicate on the "description" field.
+func DescriptionGT(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGT(FieldDescription, v))
+}
+
+// DescriptionGTE applies the GTE predicate on the "description" field.
+func DescriptionGTE(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGTE(FieldDescription, v))
+}
+
+// DescriptionLT applies the LT predicate on the "description" field.
+func DescriptionLT(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLT(FieldDescription, v))
+}
+
+// DescriptionLTE applies the LTE predicate on the "description" field.
+func DescriptionLTE(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLTE(FieldDescription, v))
+}
+
+// DescriptionContains applies the Contains predicate on the "description" field.
+func DescriptionContains(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldContains(FieldDescription, v))
+}
+
+// DescriptionHasPrefix applies the HasPrefix predicate on the "description" field.
+func DescriptionHasPrefix(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldHasPrefix(FieldDescription, v))
+}
+
+// DescriptionHasSuffix applies the HasSuffix predicate on the "description" field.
+func DescriptionHasSuffix(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldHasSuffix(FieldDescription, v))
+}
+
+// DescriptionIsNil applies the IsNil predicate on the "description" field.
+func DescriptionIsNil() predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldIsNull(FieldDescription))
+}
+
+// DescriptionNotNil applies the NotNil predicate on the "description" field.
+func DescriptionNotNil() predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNotNull(FieldDescription))
+}
+
+// DescriptionEqualFold applies the EqualFold predicate on the "description" field.
+func DescriptionEqualFold(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEqualFold(FieldDescription, v))
+}
+
+// DescriptionContainsFold applies the ContainsFold predicate on the "description" field.
+func DescriptionContainsFold(v string) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldContainsFold(FieldDescription, v))
+}
+
+// BuiltinEQ applies the EQ predicate on the "builtin" field.
+func BuiltinEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldBuiltin, v))
+}
+
+// BuiltinNEQ applies the NEQ predicate on the "builtin" field.
+func BuiltinNEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldBuiltin, v))
+}
+
+// MinLengthEQ applies the EQ predicate on the "min_length" field.
+func MinLengthEQ(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldMinLength, v))
+}
+
+// MinLengthNEQ applies the NEQ predicate on the "min_length" field.
+func MinLengthNEQ(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldMinLength, v))
+}
+
+// MinLengthIn applies the In predicate on the "min_length" field.
+func MinLengthIn(vs ...int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldIn(FieldMinLength, vs...))
+}
+
+// MinLengthNotIn applies the NotIn predicate on the "min_length" field.
+func MinLengthNotIn(vs ...int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNotIn(FieldMinLength, vs...))
+}
+
+// MinLengthGT applies the GT predicate on the "min_length" field.
+func MinLengthGT(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGT(FieldMinLength, v))
+}
+
+// MinLengthGTE applies the GTE predicate on the "min_length" field.
+func MinLengthGTE(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGTE(FieldMinLength, v))
+}
+
+// MinLengthLT applies the LT predicate on the "min_length" field.
+func MinLengthLT(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLT(FieldMinLength, v))
+}
+
+// MinLengthLTE applies the LTE predicate on the "min_length" field.
+func MinLengthLTE(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLTE(FieldMinLength, v))
+}
+
+// RequireUppercaseEQ applies the EQ predicate on the "require_uppercase" field.
+func RequireUppercaseEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldRequireUppercase, v))
+}
+
+// RequireUppercaseNEQ applies the NEQ predicate on the "require_uppercase" field.
+func RequireUppercaseNEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldRequireUppercase, v))
+}
+
+// RequireLowercaseEQ applies the EQ predicate on the "require_lowercase" field.
+func RequireLowercaseEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldRequireLowercase, v))
+}
+
+// RequireLowercaseNEQ applies the NEQ predicate on the "require_lowercase" field.
+func RequireLowercaseNEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldRequireLowercase, v))
+}
+
+// RequireDigitEQ applies the EQ predicate on the "require_digit" field.
+func RequireDigitEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldRequireDigit, v))
+}
+
+// RequireDigitNEQ applies the NEQ predicate on the "require_digit" field.
+func RequireDigitNEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldRequireDigit, v))
+}
+
+// RequireSymbolEQ applies the EQ predicate on the "require_symbol" field.
+func RequireSymbolEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldRequireSymbol, v))
+}
+
+// RequireSymbolNEQ applies the NEQ predicate on the "require_symbol" field.
+func RequireSymbolNEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldRequireSymbol, v))
+}
+
+// CheckDictionaryEQ applies the EQ predicate on the "check_dictionary" field.
+func CheckDictionaryEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldCheckDictionary, v))
+}
+
+// CheckDictionaryNEQ applies the NEQ predicate on the "check_dictionary" field.
+func CheckDictionaryNEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldCheckDictionary, v))
+}
+
+// CheckUsernameEQ applies the EQ predicate on the "check_username" field.
+func CheckUsernameEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldCheckUsername, v))
+}
+
+// CheckUsernameNEQ applies the NEQ predicate on the "check_username" field.
+func CheckUsernameNEQ(v bool) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldCheckUsername, v))
+}
+
+// HistoryCountEQ applies the EQ predicate on the "history_count" field.
+func HistoryCountEQ(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldHistoryCount, v))
+}
+
+// HistoryCountNEQ applies the NEQ predicate on the "history_count" field.
+func HistoryCountNEQ(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldHistoryCount, v))
+}
+
+// HistoryCountIn applies the In predicate on the "history_count" field.
+func HistoryCountIn(vs ...int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldIn(FieldHistoryCount, vs...))
+}
+
+// HistoryCountNotIn applies the NotIn predicate on the "history_count" field.
+func HistoryCountNotIn(vs ...int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNotIn(FieldHistoryCount, vs...))
+}
+
+// HistoryCountGT applies the GT predicate on the "history_count" field.
+func HistoryCountGT(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGT(FieldHistoryCount, v))
+}
+
+// HistoryCountGTE applies the GTE predicate on the "history_count" field.
+func HistoryCountGTE(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGTE(FieldHistoryCount, v))
+}
+
+// HistoryCountLT applies the LT predicate on the "history_count" field.
+func HistoryCountLT(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLT(FieldHistoryCount, v))
+}
+
+// HistoryCountLTE applies the LTE predicate on the "history_count" field.
+func HistoryCountLTE(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLTE(FieldHistoryCount, v))
+}
+
+// MinAgeHoursEQ applies the EQ predicate on the "min_age_hours" field.
+func MinAgeHoursEQ(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldMinAgeHours, v))
+}
+
+// MinAgeHoursNEQ applies the NEQ predicate on the "min_age_hours" field.
+func MinAgeHoursNEQ(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldMinAgeHours, v))
+}
+
+// MinAgeHoursIn applies the In predicate on the "min_age_hours" field.
+func MinAgeHoursIn(vs ...int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldIn(FieldMinAgeHours, vs...))
+}
+
+// MinAgeHoursNotIn applies the NotIn predicate on the "min_age_hours" field.
+func MinAgeHoursNotIn(vs ...int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNotIn(FieldMinAgeHours, vs...))
+}
+
+// MinAgeHoursGT applies the GT predicate on the "min_age_hours" field.
+func MinAgeHoursGT(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGT(FieldMinAgeHours, v))
+}
+
+// MinAgeHoursGTE applies the GTE predicate on the "min_age_hours" field.
+func MinAgeHoursGTE(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGTE(FieldMinAgeHours, v))
+}
+
+// MinAgeHoursLT applies the LT predicate on the "min_age_hours" field.
+func MinAgeHoursLT(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLT(FieldMinAgeHours, v))
+}
+
+// MinAgeHoursLTE applies the LTE predicate on the "min_age_hours" field.
+func MinAgeHoursLTE(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLTE(FieldMinAgeHours, v))
+}
+
+// MaxAgeDaysEQ applies the EQ predicate on the "max_age_days" field.
+func MaxAgeDaysEQ(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldMaxAgeDays, v))
+}
+
+// MaxAgeDaysNEQ applies the NEQ predicate on the "max_age_days" field.
+func MaxAgeDaysNEQ(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldMaxAgeDays, v))
+}
+
+// MaxAgeDaysIn applies the In predicate on the "max_age_days" field.
+func MaxAgeDaysIn(vs ...int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldIn(FieldMaxAgeDays, vs...))
+}
+
+// MaxAgeDaysNotIn applies the NotIn predicate on the "max_age_days" field.
+func MaxAgeDaysNotIn(vs ...int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNotIn(FieldMaxAgeDays, vs...))
+}
+
+// MaxAgeDaysGT applies the GT predicate on the "max_age_days" field.
+func MaxAgeDaysGT(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGT(FieldMaxAgeDays, v))
+}
+
+// MaxAgeDaysGTE applies the GTE predicate on the "max_age_days" field.
+func MaxAgeDaysGTE(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGTE(FieldMaxAgeDays, v))
+}
+
+// MaxAgeDaysLT applies the LT predicate on the "max_age_days" field.
+func MaxAgeDaysLT(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLT(FieldMaxAgeDays, v))
+}
+
+// MaxAgeDaysLTE applies the LTE predicate on the "max_age_days" field.
+func MaxAgeDaysLTE(v int) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLTE(FieldMaxAgeDays, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// HasGroups applies the HasEdge predicate on the "groups" edge.
+func HasGroups() predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, false, GroupsTable, GroupsPrimaryKey...),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasGroupsWith applies the HasEdge predicate on the "groups" edge with a given conditions (other predicates).
+func HasGroupsWith(preds ...predicate.Group) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(func(s *sql.Selector) {
+		step := newGroupsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.PasswordPolicy) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.PasswordPolicy) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.PasswordPolicy) predicate.PasswordPolicy {
+	return predicate.PasswordPolicy(sql.NotPredicates(p))
+}
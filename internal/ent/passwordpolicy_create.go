@@ -0,0 +1,619 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/passwordpolicy"
+)
+
+// PasswordPolicyCreate is the builder for creating a PasswordPolicy entity.
+type PasswordPolicyCreate struct {
+	config
+	mutation *PasswordPolicyMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (_c *PasswordPolicyCreate) SetName(v string) *PasswordPolicyCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetDescription sets the "description" field.
+func (_c *PasswordPolicyCreate) SetDescription(v string) *PasswordPolicyCreate {
+	_c.mutation.SetDescription(v)
+	return _c
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableDescription(v *string) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetDescription(*v)
+	}
+	return _c
+}
+
+// SetBuiltin sets the "builtin" field.
+func (_c *PasswordPolicyCreate) SetBuiltin(v bool) *PasswordPolicyCreate {
+	_c.mutation.SetBuiltin(v)
+	return _c
+}
+
+// SetNillableBuiltin sets the "builtin" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableBuiltin(v *bool) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetBuiltin(*v)
+	}
+	return _c
+}
+
+// SetMinLength sets the "min_length" field.
+func (_c *PasswordPolicyCreate) SetMinLength(v int) *PasswordPolicyCreate {
+	_c.mutation.SetMinLength(v)
+	return _c
+}
+
+// SetNillableMinLength sets the "min_length" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableMinLength(v *int) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetMinLength(*v)
+	}
+	return _c
+}
+
+// SetRequireUppercase sets the "require_uppercase" field.
+func (_c *PasswordPolicyCreate) SetRequireUppercase(v bool) *PasswordPolicyCreate {
+	_c.mutation.SetRequireUppercase(v)
+	return _c
+}
+
+// SetNillableRequireUppercase sets the "require_uppercase" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableRequireUppercase(v *bool) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetRequireUppercase(*v)
+	}
+	return _c
+}
+
+// SetRequireLowercase sets the "require_lowercase" field.
+func (_c *PasswordPolicyCreate) SetRequireLowercase(v bool) *PasswordPolicyCreate {
+	_c.mutation.SetRequireLowercase(v)
+	return _c
+}
+
+// SetNillableRequireLowercase sets the "require_lowercase" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableRequireLowercase(v *bool) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetRequireLowercase(*v)
+	}
+	return _c
+}
+
+// SetRequireDigit sets the "require_digit" field.
+func (_c *PasswordPolicyCreate) SetRequireDigit(v bool) *PasswordPolicyCreate {
+	_c.mutation.SetRequireDigit(v)
+	return _c
+}
+
+// SetNillableRequireDigit sets the "require_digit" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableRequireDigit(v *bool) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetRequireDigit(*v)
+	}
+	return _c
+}
+
+// SetRequireSymbol sets the "require_symbol" field.
+func (_c *PasswordPolicyCreate) SetRequireSymbol(v bool) *PasswordPolicyCreate {
+	_c.mutation.SetRequireSymbol(v)
+	return _c
+}
+
+// SetNillableRequireSymbol sets the "require_symbol" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableRequireSymbol(v *bool) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetRequireSymbol(*v)
+	}
+	return _c
+}
+
+// SetCheckDictionary sets the "check_dictionary" field.
+func (_c *PasswordPolicyCreate) SetCheckDictionary(v bool) *PasswordPolicyCreate {
+	_c.mutation.SetCheckDictionary(v)
+	return _c
+}
+
+// SetNillableCheckDictionary sets the "check_dictionary" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableCheckDictionary(v *bool) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetCheckDictionary(*v)
+	}
+	return _c
+}
+
+// SetCheckUsername sets the "check_username" field.
+func (_c *PasswordPolicyCreate) SetCheckUsername(v bool) *PasswordPolicyCreate {
+	_c.mutation.SetCheckUsername(v)
+	return _c
+}
+
+// SetNillableCheckUsername sets the "check_username" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableCheckUsername(v *bool) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetCheckUsername(*v)
+	}
+	return _c
+}
+
+// SetHistoryCount sets the "history_count" field.
+func (_c *PasswordPolicyCreate) SetHistoryCount(v int) *PasswordPolicyCreate {
+	_c.mutation.SetHistoryCount(v)
+	return _c
+}
+
+// SetNillableHistoryCount sets the "history_count" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableHistoryCount(v *int) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetHistoryCount(*v)
+	}
+	return _c
+}
+
+// SetMinAgeHours sets the "min_age_hours" field.
+func (_c *PasswordPolicyCreate) SetMinAgeHours(v int) *PasswordPolicyCreate {
+	_c.mutation.SetMinAgeHours(v)
+	return _c
+}
+
+// SetNillableMinAgeHours sets the "min_age_hours" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableMinAgeHours(v *int) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetMinAgeHours(*v)
+	}
+	return _c
+}
+
+// SetMaxAgeDays sets the "max_age_days" field.
+func (_c *PasswordPolicyCreate) SetMaxAgeDays(v int) *PasswordPolicyCreate {
+	_c.mutation.SetMaxAgeDays(v)
+	return _c
+}
+
+// SetNillableMaxAgeDays sets the "max_age_days" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableMaxAgeDays(v *int) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetMaxAgeDays(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *PasswordPolicyCreate) SetCreatedAt(v time.Time) *PasswordPolicyCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableCreatedAt(v *time.Time) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *PasswordPolicyCreate) SetUpdatedAt(v time.Time) *PasswordPolicyCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableUpdatedAt(v *time.Time) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *PasswordPolicyCreate) SetID(v uuid.UUID) *PasswordPolicyCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *PasswordPolicyCreate) SetNillableID(v *uuid.UUID) *PasswordPolicyCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// AddGroupIDs adds the "groups" edge to the Group entity by IDs.
+func (_c *PasswordPolicyCreate) AddGroupIDs(ids ...uuid.UUID) *PasswordPolicyCreate {
+	_c.mutation.AddGroupIDs(ids...)
+	return _c
+}
+
+// AddGroups adds the "groups" edges to the Group entity.
+func (_c *PasswordPolicyCreate) AddGroups(v ...*Group) *PasswordPolicyCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddGroupIDs(ids...)
+}
+
+// Mutation returns the PasswordPolicyMutation object of the builder.
+func (_c *PasswordPolicyCreate) Mutation() *PasswordPolicyMutation {
+	return _c.mutation
+}
+
+// Save creates the PasswordPolicy in the database.
+func (_c *PasswordPolicyCreate) Save(ctx context.Context) (*PasswordPolicy, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *PasswordPolicyCreate) SaveX(ctx context.Context) *PasswordPolicy {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PasswordPolicyCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PasswordPolicyCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *PasswordPolicyCreate) defaults() {
+	if _, ok := _c.mutation.Builtin(); !ok {
+		v := passwordpolicy.DefaultBuiltin
+		_c.mutation.SetBuiltin(v)
+	}
+	if _, ok := _c.mutation.MinLength(); !ok {
+		v := passwordpolicy.DefaultMinLength
+		_c.mutation.SetMinLength(v)
+	}
+	if _, ok := _c.mutation.RequireUppercase(); !ok {
+		v := passwordpolicy.DefaultRequireUppercase
+		_c.mutation.SetRequireUppercase(v)
+	}
+	if _, ok := _c.mutation.RequireLowercase(); !ok {
+		v := passwordpolicy.DefaultRequireLowercase
+		_c.mutation.SetRequireLowercase(v)
+	}
+	if _, ok := _c.mutation.RequireDigit(); !ok {
+		v := passwordpolicy.DefaultRequireDigit
+		_c.mutation.SetRequireDigit(v)
+	}
+	if _, ok := _c.mutation.RequireSymbol(); !ok {
+		v := passwordpolicy.DefaultRequireSymbol
+		_c.mutation.SetRequireSymbol(v)
+	}
+	if _, ok := _c.mutation.CheckDictionary(); !ok {
+		v := passwordpolicy.DefaultCheckDictionary
+		_c.mutation.SetCheckDictionary(v)
+	}
+	if _, ok := _c.mutation.CheckUsername(); !ok {
+		v := passwordpolicy.DefaultCheckUsername
+		_c.mutation.SetCheckUsername(v)
+	}
+	if _, ok := _c.mutation.HistoryCount(); !ok {
+		v := passwordpolicy.DefaultHistoryCount
+		_c.mutation.SetHistoryCount(v)
+	}
+	if _, ok := _c.mutation.MinAgeHours(); !ok {
+		v := passwordpolicy.DefaultMinAgeHours
+		_c.mutation.SetMinAgeHours(v)
+	}
+	if _, ok := _c.mutation.MaxAgeDays(); !ok {
+		v := passwordpolicy.DefaultMaxAgeDays
+		_c.mutation.SetMaxAgeDays(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := passwordpolicy.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := passwordpolicy.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := passwordpolicy.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *PasswordPolicyCreate) check() error {
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "PasswordPolicy.name"`)}
+	}
+	if v, ok := _c.mutation.Name(); ok {
+		if err := passwordpolicy.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.name": %w`, err)}
+		}
+	}
+	if v, ok := _c.mutation.Description(); ok {
+		if err := passwordpolicy.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.description": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Builtin(); !ok {
+		return &ValidationError{Name: "builtin", err: errors.New(`ent: missing required field "PasswordPolicy.builtin"`)}
+	}
+	if _, ok := _c.mutation.MinLength(); !ok {
+		return &ValidationError{Name: "min_length", err: errors.New(`ent: missing required field "PasswordPolicy.min_length"`)}
+	}
+	if v, ok := _c.mutation.MinLength(); ok {
+		if err := passwordpolicy.MinLengthValidator(v); err != nil {
+			return &ValidationError{Name: "min_length", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.min_length": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.RequireUppercase(); !ok {
+		return &ValidationError{Name: "require_uppercase", err: errors.New(`ent: missing required field "PasswordPolicy.require_uppercase"`)}
+	}
+	if _, ok := _c.mutation.RequireLowercase(); !ok {
+		return &ValidationError{Name: "require_lowercase", err: errors.New(`ent: missing required field "PasswordPolicy.require_lowercase"`)}
+	}
+	if _, ok := _c.mutation.RequireDigit(); !ok {
+		return &ValidationError{Name: "require_digit", err: errors.New(`ent: missing required field "PasswordPolicy.require_digit"`)}
+	}
+	if _, ok := _c.mutation.RequireSymbol(); !ok {
+		return &ValidationError{Name: "require_symbol", err: errors.New(`ent: missing required field "PasswordPolicy.require_symbol"`)}
+	}
+	if _, ok := _c.mutation.CheckDictionary(); !ok {
+		return &ValidationError{Name: "check_dictionary", err: errors.New(`ent: missing required field "PasswordPolicy.check_dictionary"`)}
+	}
+	if _, ok := _c.mutation.CheckUsername(); !ok {
+		return &ValidationError{Name: "check_username", err: errors.New(`ent: missing required field "PasswordPolicy.check_username"`)}
+	}
+	if _, ok := _c.mutation.HistoryCount(); !ok {
+		return &ValidationError{Name: "history_count", err: errors.New(`ent: missing required field "PasswordPolicy.history_count"`)}
+	}
+	if v, ok := _c.mutation.HistoryCount(); ok {
+		if err := passwordpolicy.HistoryCountValidator(v); err != nil {
+			return &ValidationError{Name: "history_count", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.history_count": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.MinAgeHours(); !ok {
+		return &ValidationError{Name: "min_age_hours", err: errors.New(`ent: missing required field "PasswordPolicy.min_age_hours"`)}
+	}
+	if v, ok := _c.mutation.MinAgeHours(); ok {
+		if err := passwordpolicy.MinAgeHoursValidator(v); err != nil {
+			return &ValidationError{Name: "min_age_hours", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.min_age_hours": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.MaxAgeDays(); !ok {
+		return &ValidationError{Name: "max_age_days", err: errors.New(`ent: missing required field "PasswordPolicy.max_age_days"`)}
+	}
+	if v, ok := _c.mutation.MaxAgeDays(); ok {
+		if err := passwordpolicy.MaxAgeDaysValidator(v); err != nil {
+			return &ValidationError{Name: "max_age_days", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.max_age_days": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "PasswordPolicy.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "PasswordPolicy.updated_at"`)}
+	}
+	return nil
+}
+
+func (_c *PasswordPolicyCreate) sqlSave(ctx context.Context) (*PasswordPolicy, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *PasswordPolicyCreate) createSpec() (*PasswordPolicy, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PasswordPolicy{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(passwordpolicy.Table, sqlgraph.NewFieldSpec(passwordpolicy.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(passwordpolicy.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.Description(); ok {
+		_spec.SetField(passwordpolicy.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
+	if value, ok := _c.mutation.Builtin(); ok {
+		_spec.SetField(passwordpolicy.FieldBuiltin, field.TypeBool, value)
+		_node.Builtin = value
+	}
+	if value, ok := _c.mutation.MinLength(); ok {
+		_spec.SetField(passwordpolicy.FieldMinLength, field.TypeInt, value)
+		_node.MinLength = value
+	}
+	if value, ok := _c.mutation.RequireUppercase(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireUppercase, field.TypeBool, value)
+		_node.RequireUppercase = value
+	}
+	if value, ok := _c.mutation.RequireLowercase(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireLowercase, field.TypeBool, value)
+		_node.RequireLowercase = value
+	}
+	if value, ok := _c.mutation.RequireDigit(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireDigit, field.TypeBool, value)
+		_node.RequireDigit = value
+	}
+	if value, ok := _c.mutation.RequireSymbol(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireSymbol, field.TypeBool, value)
+		_node.RequireSymbol = value
+	}
+	if value, ok := _c.mutation.CheckDictionary(); ok {
+		_spec.SetField(passwordpolicy.FieldCheckDictionary, field.TypeBool, value)
+		_node.CheckDictionary = value
+	}
+	if value, ok := _c.mutation.CheckUsername(); ok {
+		_spec.SetField(passwordpolicy.FieldCheckUsername, field.TypeBool, value)
+		_node.CheckUsername = value
+	}
+	if value, ok := _c.mutation.HistoryCount(); ok {
+		_spec.SetField(passwordpolicy.FieldHistoryCount, field.TypeInt, value)
+		_node.HistoryCount = value
+	}
+	if value, ok := _c.mutation.MinAgeHours(); ok {
+		_spec.SetField(passwordpolicy.FieldMinAgeHours, field.TypeInt, value)
+		_node.MinAgeHours = value
+	}
+	if value, ok := _c.mutation.MaxAgeDays(); ok {
+		_spec.SetField(passwordpolicy.FieldMaxAgeDays, field.TypeInt, value)
+		_node.MaxAgeDays = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(passwordpolicy.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(passwordpolicy.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := _c.mutation.GroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   passwordpolicy.GroupsTable,
+			Columns: passwordpolicy.GroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// PasswordPolicyCreateBulk is the builder for creating many PasswordPolicy entities in bulk.
+type PasswordPolicyCreateBulk struct {
+	config
+	err      error
+	builders []*PasswordPolicyCreate
+}
+
+// Save creates the PasswordPolicy entities in the database.
+func (_c *PasswordPolicyCreateBulk) Save(ctx context.Context) ([]*PasswordPolicy, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*PasswordPolicy, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PasswordPolicyMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *PasswordPolicyCreateBulk) SaveX(ctx context.Context) []*PasswordPolicy {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PasswordPolicyCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PasswordPolicyCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
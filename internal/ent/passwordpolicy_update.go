@@ -0,0 +1,958 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/passwordpolicy"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// PasswordPolicyUpdate is the builder for updating PasswordPolicy entities.
+type PasswordPolicyUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PasswordPolicyMutation
+}
+
+// Where appends a list predicates to the PasswordPolicyUpdate builder.
+func (_u *PasswordPolicyUpdate) Where(ps ...predicate.PasswordPolicy) *PasswordPolicyUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *PasswordPolicyUpdate) SetName(v string) *PasswordPolicyUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableName(v *string) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *PasswordPolicyUpdate) SetDescription(v string) *PasswordPolicyUpdate {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableDescription(v *string) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *PasswordPolicyUpdate) ClearDescription() *PasswordPolicyUpdate {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetMinLength sets the "min_length" field.
+func (_u *PasswordPolicyUpdate) SetMinLength(v int) *PasswordPolicyUpdate {
+	_u.mutation.ResetMinLength()
+	_u.mutation.SetMinLength(v)
+	return _u
+}
+
+// SetNillableMinLength sets the "min_length" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableMinLength(v *int) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetMinLength(*v)
+	}
+	return _u
+}
+
+// AddMinLength adds value to the "min_length" field.
+func (_u *PasswordPolicyUpdate) AddMinLength(v int) *PasswordPolicyUpdate {
+	_u.mutation.AddMinLength(v)
+	return _u
+}
+
+// SetRequireUppercase sets the "require_uppercase" field.
+func (_u *PasswordPolicyUpdate) SetRequireUppercase(v bool) *PasswordPolicyUpdate {
+	_u.mutation.SetRequireUppercase(v)
+	return _u
+}
+
+// SetNillableRequireUppercase sets the "require_uppercase" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableRequireUppercase(v *bool) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetRequireUppercase(*v)
+	}
+	return _u
+}
+
+// SetRequireLowercase sets the "require_lowercase" field.
+func (_u *PasswordPolicyUpdate) SetRequireLowercase(v bool) *PasswordPolicyUpdate {
+	_u.mutation.SetRequireLowercase(v)
+	return _u
+}
+
+// SetNillableRequireLowercase sets the "require_lowercase" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableRequireLowercase(v *bool) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetRequireLowercase(*v)
+	}
+	return _u
+}
+
+// SetRequireDigit sets the "require_digit" field.
+func (_u *PasswordPolicyUpdate) SetRequireDigit(v bool) *PasswordPolicyUpdate {
+	_u.mutation.SetRequireDigit(v)
+	return _u
+}
+
+// SetNillableRequireDigit sets the "require_digit" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableRequireDigit(v *bool) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetRequireDigit(*v)
+	}
+	return _u
+}
+
+// SetRequireSymbol sets the "require_symbol" field.
+func (_u *PasswordPolicyUpdate) SetRequireSymbol(v bool) *PasswordPolicyUpdate {
+	_u.mutation.SetRequireSymbol(v)
+	return _u
+}
+
+// SetNillableRequireSymbol sets the "require_symbol" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableRequireSymbol(v *bool) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetRequireSymbol(*v)
+	}
+	return _u
+}
+
+// SetCheckDictionary sets the "check_dictionary" field.
+func (_u *PasswordPolicyUpdate) SetCheckDictionary(v bool) *PasswordPolicyUpdate {
+	_u.mutation.SetCheckDictionary(v)
+	return _u
+}
+
+// SetNillableCheckDictionary sets the "check_dictionary" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableCheckDictionary(v *bool) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetCheckDictionary(*v)
+	}
+	return _u
+}
+
+// SetCheckUsername sets the "check_username" field.
+func (_u *PasswordPolicyUpdate) SetCheckUsername(v bool) *PasswordPolicyUpdate {
+	_u.mutation.SetCheckUsername(v)
+	return _u
+}
+
+// SetNillableCheckUsername sets the "check_username" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableCheckUsername(v *bool) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetCheckUsername(*v)
+	}
+	return _u
+}
+
+// SetHistoryCount sets the "history_count" field.
+func (_u *PasswordPolicyUpdate) SetHistoryCount(v int) *PasswordPolicyUpdate {
+	_u.mutation.ResetHistoryCount()
+	_u.mutation.SetHistoryCount(v)
+	return _u
+}
+
+// SetNillableHistoryCount sets the "history_count" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableHistoryCount(v *int) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetHistoryCount(*v)
+	}
+	return _u
+}
+
+// AddHistoryCount adds value to the "history_count" field.
+func (_u *PasswordPolicyUpdate) AddHistoryCount(v int) *PasswordPolicyUpdate {
+	_u.mutation.AddHistoryCount(v)
+	return _u
+}
+
+// SetMinAgeHours sets the "min_age_hours" field.
+func (_u *PasswordPolicyUpdate) SetMinAgeHours(v int) *PasswordPolicyUpdate {
+	_u.mutation.ResetMinAgeHours()
+	_u.mutation.SetMinAgeHours(v)
+	return _u
+}
+
+// SetNillableMinAgeHours sets the "min_age_hours" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableMinAgeHours(v *int) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetMinAgeHours(*v)
+	}
+	return _u
+}
+
+// AddMinAgeHours adds value to the "min_age_hours" field.
+func (_u *PasswordPolicyUpdate) AddMinAgeHours(v int) *PasswordPolicyUpdate {
+	_u.mutation.AddMinAgeHours(v)
+	return _u
+}
+
+// SetMaxAgeDays sets the "max_age_days" field.
+func (_u *PasswordPolicyUpdate) SetMaxAgeDays(v int) *PasswordPolicyUpdate {
+	_u.mutation.ResetMaxAgeDays()
+	_u.mutation.SetMaxAgeDays(v)
+	return _u
+}
+
+// SetNillableMaxAgeDays sets the "max_age_days" field if the given value is not nil.
+func (_u *PasswordPolicyUpdate) SetNillableMaxAgeDays(v *int) *PasswordPolicyUpdate {
+	if v != nil {
+		_u.SetMaxAgeDays(*v)
+	}
+	return _u
+}
+
+// AddMaxAgeDays adds value to the "max_age_days" field.
+func (_u *PasswordPolicyUpdate) AddMaxAgeDays(v int) *PasswordPolicyUpdate {
+	_u.mutation.AddMaxAgeDays(v)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *PasswordPolicyUpdate) SetUpdatedAt(v time.Time) *PasswordPolicyUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddGroupIDs adds the "groups" edge to the Group entity by IDs.
+func (_u *PasswordPolicyUpdate) AddGroupIDs(ids ...uuid.UUID) *PasswordPolicyUpdate {
+	_u.mutation.AddGroupIDs(ids...)
+	return _u
+}
+
+// AddGroups adds the "groups" edges to the Group entity.
+func (_u *PasswordPolicyUpdate) AddGroups(v ...*Group) *PasswordPolicyUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddGroupIDs(ids...)
+}
+
+// Mutation returns the PasswordPolicyMutation object of the builder.
+func (_u *PasswordPolicyUpdate) Mutation() *PasswordPolicyMutation {
+	return _u.mutation
+}
+
+// ClearGroups clears all "groups" edges to the Group entity.
+func (_u *PasswordPolicyUpdate) ClearGroups() *PasswordPolicyUpdate {
+	_u.mutation.ClearGroups()
+	return _u
+}
+
+// RemoveGroupIDs removes the "groups" edge to Group entities by IDs.
+func (_u *PasswordPolicyUpdate) RemoveGroupIDs(ids ...uuid.UUID) *PasswordPolicyUpdate {
+	_u.mutation.RemoveGroupIDs(ids...)
+	return _u
+}
+
+// RemoveGroups removes "groups" edges to Group entities.
+func (_u *PasswordPolicyUpdate) RemoveGroups(v ...*Group) *PasswordPolicyUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveGroupIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *PasswordPolicyUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PasswordPolicyUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *PasswordPolicyUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PasswordPolicyUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *PasswordPolicyUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := passwordpolicy.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PasswordPolicyUpdate) check() error {
+	if v, ok := _u.mutation.Name(); ok {
+		if err := passwordpolicy.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.name": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Description(); ok {
+		if err := passwordpolicy.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.description": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MinLength(); ok {
+		if err := passwordpolicy.MinLengthValidator(v); err != nil {
+			return &ValidationError{Name: "min_length", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.min_length": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.HistoryCount(); ok {
+		if err := passwordpolicy.HistoryCountValidator(v); err != nil {
+			return &ValidationError{Name: "history_count", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.history_count": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MinAgeHours(); ok {
+		if err := passwordpolicy.MinAgeHoursValidator(v); err != nil {
+			return &ValidationError{Name: "min_age_hours", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.min_age_hours": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MaxAgeDays(); ok {
+		if err := passwordpolicy.MaxAgeDaysValidator(v); err != nil {
+			return &ValidationError{Name: "max_age_days", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.max_age_days": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *PasswordPolicyUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(passwordpolicy.Table, passwordpolicy.Columns, sqlgraph.NewFieldSpec(passwordpolicy.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(passwordpolicy.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(passwordpolicy.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(passwordpolicy.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.MinLength(); ok {
+		_spec.SetField(passwordpolicy.FieldMinLength, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMinLength(); ok {
+		_spec.AddField(passwordpolicy.FieldMinLength, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.RequireUppercase(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireUppercase, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RequireLowercase(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireLowercase, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RequireDigit(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireDigit, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RequireSymbol(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireSymbol, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.CheckDictionary(); ok {
+		_spec.SetField(passwordpolicy.FieldCheckDictionary, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.CheckUsername(); ok {
+		_spec.SetField(passwordpolicy.FieldCheckUsername, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.HistoryCount(); ok {
+		_spec.SetField(passwordpolicy.FieldHistoryCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedHistoryCount(); ok {
+		_spec.AddField(passwordpolicy.FieldHistoryCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MinAgeHours(); ok {
+		_spec.SetField(passwordpolicy.FieldMinAgeHours, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMinAgeHours(); ok {
+		_spec.AddField(passwordpolicy.FieldMinAgeHours, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MaxAgeDays(); ok {
+		_spec.SetField(passwordpolicy.FieldMaxAgeDays, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxAgeDays(); ok {
+		_spec.AddField(passwordpolicy.FieldMaxAgeDays, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(passwordpolicy.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.GroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   passwordpolicy.GroupsTable,
+			Columns: passwordpolicy.GroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedGroupsIDs(); len(nodes) > 0 && !_u.mutation.GroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   passwordpolicy.GroupsTable,
+			Columns: passwordpolicy.GroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.GroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   passwordpolicy.GroupsTable,
+			Columns: passwordpolicy.GroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{passwordpolicy.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// PasswordPolicyUpdateOne is the builder for updating a single PasswordPolicy entity.
+type PasswordPolicyUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PasswordPolicyMutation
+}
+
+// SetName sets the "name" field.
+func (_u *PasswordPolicyUpdateOne) SetName(v string) *PasswordPolicyUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableName(v *string) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *PasswordPolicyUpdateOne) SetDescription(v string) *PasswordPolicyUpdateOne {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableDescription(v *string) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *PasswordPolicyUpdateOne) ClearDescription() *PasswordPolicyUpdateOne {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetMinLength sets the "min_length" field.
+func (_u *PasswordPolicyUpdateOne) SetMinLength(v int) *PasswordPolicyUpdateOne {
+	_u.mutation.ResetMinLength()
+	_u.mutation.SetMinLength(v)
+	return _u
+}
+
+// SetNillableMinLength sets the "min_length" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableMinLength(v *int) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetMinLength(*v)
+	}
+	return _u
+}
+
+// AddMinLength adds value to the "min_length" field.
+func (_u *PasswordPolicyUpdateOne) AddMinLength(v int) *PasswordPolicyUpdateOne {
+	_u.mutation.AddMinLength(v)
+	return _u
+}
+
+// SetRequireUppercase sets the "require_uppercase" field.
+func (_u *PasswordPolicyUpdateOne) SetRequireUppercase(v bool) *PasswordPolicyUpdateOne {
+	_u.mutation.SetRequireUppercase(v)
+	return _u
+}
+
+// SetNillableRequireUppercase sets the "require_uppercase" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableRequireUppercase(v *bool) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetRequireUppercase(*v)
+	}
+	return _u
+}
+
+// SetRequireLowercase sets the "require_lowercase" field.
+func (_u *PasswordPolicyUpdateOne) SetRequireLowercase(v bool) *PasswordPolicyUpdateOne {
+	_u.mutation.SetRequireLowercase(v)
+	return _u
+}
+
+// SetNillableRequireLowercase sets the "require_lowercase" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableRequireLowercase(v *bool) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetRequireLowercase(*v)
+	}
+	return _u
+}
+
+// SetRequireDigit sets the "require_digit" field.
+func (_u *PasswordPolicyUpdateOne) SetRequireDigit(v bool) *PasswordPolicyUpdateOne {
+	_u.mutation.SetRequireDigit(v)
+	return _u
+}
+
+// SetNillableRequireDigit sets the "require_digit" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableRequireDigit(v *bool) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetRequireDigit(*v)
+	}
+	return _u
+}
+
+// SetRequireSymbol sets the "require_symbol" field.
+func (_u *PasswordPolicyUpdateOne) SetRequireSymbol(v bool) *PasswordPolicyUpdateOne {
+	_u.mutation.SetRequireSymbol(v)
+	return _u
+}
+
+// SetNillableRequireSymbol sets the "require_symbol" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableRequireSymbol(v *bool) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetRequireSymbol(*v)
+	}
+	return _u
+}
+
+// SetCheckDictionary sets the "check_dictionary" field.
+func (_u *PasswordPolicyUpdateOne) SetCheckDictionary(v bool) *PasswordPolicyUpdateOne {
+	_u.mutation.SetCheckDictionary(v)
+	return _u
+}
+
+// SetNillableCheckDictionary sets the "check_dictionary" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableCheckDictionary(v *bool) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetCheckDictionary(*v)
+	}
+	return _u
+}
+
+// SetCheckUsername sets the "check_username" field.
+func (_u *PasswordPolicyUpdateOne) SetCheckUsername(v bool) *PasswordPolicyUpdateOne {
+	_u.mutation.SetCheckUsername(v)
+	return _u
+}
+
+// SetNillableCheckUsername sets the "check_username" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableCheckUsername(v *bool) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetCheckUsername(*v)
+	}
+	return _u
+}
+
+// SetHistoryCount sets the "history_count" field.
+func (_u *PasswordPolicyUpdateOne) SetHistoryCount(v int) *PasswordPolicyUpdateOne {
+	_u.mutation.ResetHistoryCount()
+	_u.mutation.SetHistoryCount(v)
+	return _u
+}
+
+// SetNillableHistoryCount sets the "history_count" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableHistoryCount(v *int) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetHistoryCount(*v)
+	}
+	return _u
+}
+
+// AddHistoryCount adds value to the "history_count" field.
+func (_u *PasswordPolicyUpdateOne) AddHistoryCount(v int) *PasswordPolicyUpdateOne {
+	_u.mutation.AddHistoryCount(v)
+	return _u
+}
+
+// SetMinAgeHours sets the "min_age_hours" field.
+func (_u *PasswordPolicyUpdateOne) SetMinAgeHours(v int) *PasswordPolicyUpdateOne {
+	_u.mutation.ResetMinAgeHours()
+	_u.mutation.SetMinAgeHours(v)
+	return _u
+}
+
+// SetNillableMinAgeHours sets the "min_age_hours" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableMinAgeHours(v *int) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetMinAgeHours(*v)
+	}
+	return _u
+}
+
+// AddMinAgeHours adds value to the "min_age_hours" field.
+func (_u *PasswordPolicyUpdateOne) AddMinAgeHours(v int) *PasswordPolicyUpdateOne {
+	_u.mutation.AddMinAgeHours(v)
+	return _u
+}
+
+// SetMaxAgeDays sets the "max_age_days" field.
+func (_u *PasswordPolicyUpdateOne) SetMaxAgeDays(v int) *PasswordPolicyUpdateOne {
+	_u.mutation.ResetMaxAgeDays()
+	_u.mutation.SetMaxAgeDays(v)
+	return _u
+}
+
+// SetNillableMaxAgeDays sets the "max_age_days" field if the given value is not nil.
+func (_u *PasswordPolicyUpdateOne) SetNillableMaxAgeDays(v *int) *PasswordPolicyUpdateOne {
+	if v != nil {
+		_u.SetMaxAgeDays(*v)
+	}
+	return _u
+}
+
+// AddMaxAgeDays adds value to the "max_age_days" field.
+func (_u *PasswordPolicyUpdateOne) AddMaxAgeDays(v int) *PasswordPolicyUpdateOne {
+	_u.mutation.AddMaxAgeDays(v)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *PasswordPolicyUpdateOne) SetUpdatedAt(v time.Time) *PasswordPolicyUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddGroupIDs adds the "groups" edge to the Group entity by IDs.
+func (_u *PasswordPolicyUpdateOne) AddGroupIDs(ids ...uuid.UUID) *PasswordPolicyUpdateOne {
+	_u.mutation.AddGroupIDs(ids...)
+	return _u
+}
+
+// AddGroups adds the "groups" edges to the Group entity.
+func (_u *PasswordPolicyUpdateOne) AddGroups(v ...*Group) *PasswordPolicyUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddGroupIDs(ids...)
+}
+
+// Mutation returns the PasswordPolicyMutation object of the builder.
+func (_u *PasswordPolicyUpdateOne) Mutation() *PasswordPolicyMutation {
+	return _u.mutation
+}
+
+// ClearGroups clears all "groups" edges to the Group entity.
+func (_u *PasswordPolicyUpdateOne) ClearGroups() *PasswordPolicyUpdateOne {
+	_u.mutation.ClearGroups()
+	return _u
+}
+
+// RemoveGroupIDs removes the "groups" edge to Group entities by IDs.
+func (_u *PasswordPolicyUpdateOne) RemoveGroupIDs(ids ...uuid.UUID) *PasswordPolicyUpdateOne {
+	_u.mutation.RemoveGroupIDs(ids...)
+	return _u
+}
+
+// RemoveGroups removes "groups" edges to Group entities.
+func (_u *PasswordPolicyUpdateOne) RemoveGroups(v ...*Group) *PasswordPolicyUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveGroupIDs(ids...)
+}
+
+// Where appends a list predicates to the PasswordPolicyUpdate builder.
+func (_u *PasswordPolicyUpdateOne) Where(ps ...predicate.PasswordPolicy) *PasswordPolicyUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *PasswordPolicyUpdateOne) Select(field string, fields ...string) *PasswordPolicyUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated PasswordPolicy entity.
+func (_u *PasswordPolicyUpdateOne) Save(ctx context.Context) (*PasswordPolicy, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PasswordPolicyUpdateOne) SaveX(ctx context.Context) *PasswordPolicy {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *PasswordPolicyUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PasswordPolicyUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *PasswordPolicyUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := passwordpolicy.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PasswordPolicyUpdateOne) check() error {
+	if v, ok := _u.mutation.Name(); ok {
+		if err := passwordpolicy.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.name": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Description(); ok {
+		if err := passwordpolicy.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.description": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MinLength(); ok {
+		if err := passwordpolicy.MinLengthValidator(v); err != nil {
+			return &ValidationError{Name: "min_length", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.min_length": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.HistoryCount(); ok {
+		if err := passwordpolicy.HistoryCountValidator(v); err != nil {
+			return &ValidationError{Name: "history_count", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.history_count": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MinAgeHours(); ok {
+		if err := passwordpolicy.MinAgeHoursValidator(v); err != nil {
+			return &ValidationError{Name: "min_age_hours", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.min_age_hours": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MaxAgeDays(); ok {
+		if err := passwordpolicy.MaxAgeDaysValidator(v); err != nil {
+			return &ValidationError{Name: "max_age_days", err: fmt.Errorf(`ent: validator failed for field "PasswordPolicy.max_age_days": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *PasswordPolicyUpdateOne) sqlSave(ctx context.Context) (_node *PasswordPolicy, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(passwordpolicy.Table, passwordpolicy.Columns, sqlgraph.NewFieldSpec(passwordpolicy.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "PasswordPolicy.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, passwordpolicy.FieldID)
+		for _, f := range fields {
+			if !passwordpolicy.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != passwordpolicy.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(passwordpolicy.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(passwordpolicy.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(passwordpolicy.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.MinLength(); ok {
+		_spec.SetField(passwordpolicy.FieldMinLength, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMinLength(); ok {
+		_spec.AddField(passwordpolicy.FieldMinLength, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.RequireUppercase(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireUppercase, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RequireLowercase(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireLowercase, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RequireDigit(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireDigit, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RequireSymbol(); ok {
+		_spec.SetField(passwordpolicy.FieldRequireSymbol, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.CheckDictionary(); ok {
+		_spec.SetField(passwordpolicy.FieldCheckDictionary, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.CheckUsername(); ok {
+		_spec.SetField(passwordpolicy.FieldCheckUsername, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.HistoryCount(); ok {
+		_spec.SetField(passwordpolicy.FieldHistoryCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedHistoryCount(); ok {
+		_spec.AddField(passwordpolicy.FieldHistoryCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MinAgeHours(); ok {
+		_spec.SetField(passwordpolicy.FieldMinAgeHours, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMinAgeHours(); ok {
+		_spec.AddField(passwordpolicy.FieldMinAgeHours, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MaxAgeDays(); ok {
+		_spec.SetField(passwordpolicy.FieldMaxAgeDays, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxAgeDays(); ok {
+		_spec.AddField(passwordpolicy.FieldMaxAgeDays, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(passwordpolicy.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.GroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   passwordpolicy.GroupsTable,
+			Columns: passwordpolicy.GroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedGroupsIDs(); len(nodes) > 0 && !_u.mutation.GroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   passwordpolicy.GroupsTable,
+			Columns: passwordpolicy.GroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.GroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   passwordpolicy.GroupsTable,
+			Columns: passwordpolicy.GroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &PasswordPolicy{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{passwordpolicy.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,226 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/provisionedobject"
+	"github.com/qinzj/claude-demo/internal/ent/provisioningconnector"
+)
+
+// ProvisionedObject is the model entity for the ProvisionedObject schema.
+type ProvisionedObject struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// ResourceType holds the value of the "resource_type" field.
+	ResourceType provisionedobject.ResourceType `json:"resource_type,omitempty"`
+	// LocalID holds the value of the "local_id" field.
+	LocalID uuid.UUID `json:"local_id,omitempty"`
+	// RemoteID holds the value of the "remote_id" field.
+	RemoteID string `json:"remote_id,omitempty"`
+	// Hash holds the value of the "hash" field.
+	Hash string `json:"hash,omitempty"`
+	// LastError holds the value of the "last_error" field.
+	LastError string `json:"last_error,omitempty"`
+	// SyncedAt holds the value of the "synced_at" field.
+	SyncedAt *time.Time `json:"synced_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ProvisionedObjectQuery when eager-loading is set.
+	Edges                          ProvisionedObjectEdges `json:"edges"`
+	provisioning_connector_objects *uuid.UUID
+	selectValues                   sql.SelectValues
+}
+
+// ProvisionedObjectEdges holds the relations/edges for other nodes in the graph.
+type ProvisionedObjectEdges struct {
+	// Connector holds the value of the connector edge.
+	Connector *ProvisioningConnector `json:"connector,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// ConnectorOrErr returns the Connector value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ProvisionedObjectEdges) ConnectorOrErr() (*ProvisioningConnector, error) {
+	if e.Connector != nil {
+		return e.Connector, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: provisioningconnector.Label}
+	}
+	return nil, &NotLoadedError{edge: "connector"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ProvisionedObject) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case provisionedobject.FieldResourceType, provisionedobject.FieldRemoteID, provisionedobject.FieldHash, provisionedobject.FieldLastError:
+			values[i] = new(sql.NullString)
+		case provisionedobject.FieldSyncedAt, provisionedobject.FieldCreatedAt, provisionedobject.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case provisionedobject.FieldID, provisionedobject.FieldLocalID:
+			values[i] = new(uuid.UUID)
+		case provisionedobject.ForeignKeys[0]: // provisioning_connector_objects
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ProvisionedObject fields.
+func (_m *ProvisionedObject) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case provisionedobject.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case provisionedobject.FieldResourceType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field resource_type", values[i])
+			} else if value.Valid {
+				_m.ResourceType = provisionedobject.ResourceType(value.String)
+			}
+		case provisionedobject.FieldLocalID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field local_id", values[i])
+			} else if value != nil {
+				_m.LocalID = *value
+			}
+		case provisionedobject.FieldRemoteID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field remote_id", values[i])
+			} else if value.Valid {
+				_m.RemoteID = value.String
+			}
+		case provisionedobject.FieldHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field hash", values[i])
+			} else if value.Valid {
+				_m.Hash = value.String
+			}
+		case provisionedobject.FieldLastError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field last_error", values[i])
+			} else if value.Valid {
+				_m.LastError = value.String
+			}
+		case provisionedobject.FieldSyncedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field synced_at", values[i])
+			} else if value.Valid {
+				_m.SyncedAt = new(time.Time)
+				*_m.SyncedAt = value.Time
+			}
+		case provisionedobject.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case provisionedobject.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		case provisionedobject.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field provisioning_connector_objects", values[i])
+			} else if value.Valid {
+				_m.provisioning_connector_objects = new(uuid.UUID)
+				*_m.provisioning_connector_objects = *value.S.(*uuid.UUID)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ProvisionedObject.
+// This includes values selected through modifiers, order, etc.
+func (_m *ProvisionedObject) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryConnector queries the "connector" edge of the ProvisionedObject entity.
+func (_m *ProvisionedObject) QueryConnector() *ProvisioningConnectorQuery {
+	return NewProvisionedObjectClient(_m.config).QueryConnector(_m)
+}
+
+// Update returns a builder for updating this ProvisionedObject.
+// Note that you need to call ProvisionedObject.Unwrap() before calling this method if this ProvisionedObject
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *ProvisionedObject) Update() *ProvisionedObjectUpdateOne {
+	return NewProvisionedObjectClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the ProvisionedObject entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *ProvisionedObject) Unwrap() *ProvisionedObject {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ProvisionedObject is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *ProvisionedObject) String() string {
+	var builder strings.Builder
+	builder.WriteString("ProvisionedObject(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("resource_type=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ResourceType))
+	builder.WriteString(", ")
+	builder.WriteString("local_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LocalID))
+	builder.WriteString(", ")
+	builder.WriteString("remote_id=")
+	builder.WriteString(_m.RemoteID)
+	builder.WriteString(", ")
+	builder.WriteString("hash=")
+	builder.WriteString(_m.Hash)
+	builder.WriteString(", ")
+	builder.WriteString("last_error=")
+	builder.WriteString(_m.LastError)
+	builder.WriteString(", ")
+	if v := _m.SyncedAt; v != nil {
+		builder.WriteString("synced_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ProvisionedObjects is a parsable slice of ProvisionedObject.
+type ProvisionedObjects []*ProvisionedObject
@@ -0,0 +1,182 @@
+// Code generated by ent, DO NOT EDIT.
+
+package provisionedobject
+
+import (
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the provisionedobject type in the database.
+	Label = "provisioned_object"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldResourceType holds the string denoting the resource_type field in the database.
+	FieldResourceType = "resource_type"
+	// FieldLocalID holds the string denoting the local_id field in the database.
+	FieldLocalID = "local_id"
+	// FieldRemoteID holds the string denoting the remote_id field in the database.
+	FieldRemoteID = "remote_id"
+	// FieldHash holds the string denoting the hash field in the database.
+	FieldHash = "hash"
+	// FieldLastError holds the string denoting the last_error field in the database.
+	FieldLastError = "last_error"
+	// FieldSyncedAt holds the string denoting the synced_at field in the database.
+	FieldSyncedAt = "synced_at"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// EdgeConnector holds the string denoting the connector edge name in mutations.
+	EdgeConnector = "connector"
+	// Table holds the table name of the provisionedobject in the database.
+	Table = "provisioned_objects"
+	// ConnectorTable is the table that holds the connector relation/edge.
+	ConnectorTable = "provisioned_objects"
+	// ConnectorInverseTable is the table name for the ProvisioningConnector entity.
+	// It exists in this package in order to avoid circular dependency with the "provisioningconnector" package.
+	ConnectorInverseTable = "provisioning_connectors"
+	// ConnectorColumn is the table column denoting the connector relation/edge.
+	ConnectorColumn = "provisioning_connector_objects"
+)
+
+// Columns holds all SQL columns for provisionedobject fields.
+var Columns = []string{
+	FieldID,
+	FieldResourceType,
+	FieldLocalID,
+	FieldRemoteID,
+	FieldHash,
+	FieldLastError,
+	FieldSyncedAt,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the "provisioned_objects"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"provisioning_connector_objects",
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultRemoteID holds the default value on creation for the "remote_id" field.
+	DefaultRemoteID string
+	// DefaultHash holds the default value on creation for the "hash" field.
+	DefaultHash string
+	// DefaultLastError holds the default value on creation for the "last_error" field.
+	DefaultLastError string
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// ResourceType defines the type for the "resource_type" enum field.
+type ResourceType string
+
+// ResourceType values.
+const (
+	ResourceTypeUser  ResourceType = "user"
+	ResourceTypeGroup ResourceType = "group"
+)
+
+func (rt ResourceType) String() string {
+	return string(rt)
+}
+
+// ResourceTypeValidator is a validator for the "resource_type" field enum values. It is called by the builders before save.
+func ResourceTypeValidator(rt ResourceType) error {
+	switch rt {
+	case ResourceTypeUser, ResourceTypeGroup:
+		return nil
+	default:
+		return fmt.Errorf("provisionedobject: invalid enum value for resource_type field: %q", rt)
+	}
+}
+
+// OrderOption defines the ordering options for the ProvisionedObject queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByResourceType orders the results by the resource_type field.
+func ByResourceType(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResourceType, opts...).ToFunc()
+}
+
+// ByLocalID orders the results by the local_id field.
+func ByLocalID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLocalID, opts...).ToFunc()
+}
+
+// ByRemoteID orders the results by the remote_id field.
+func ByRemoteID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRemoteID, opts...).ToFunc()
+}
+
+// ByHash orders the results by the hash field.
+func ByHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldHash, opts...).ToFunc()
+}
+
+// ByLastError orders the results by the last_error field.
+func ByLastError(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastError, opts...).ToFunc()
+}
+
+// BySyncedAt orders the results by the synced_at field.
+func BySyncedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSyncedAt, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
+
+// ByConnectorField orders the results by connector field.
+func ByConnectorField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newConnectorStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newConnectorStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ConnectorInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, ConnectorTable, ConnectorColumn),
+	)
+}
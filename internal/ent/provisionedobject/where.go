@@ -0,0 +1,515 @@
+// Code generated by ent, DO NOT EDIT.
+
+package provisionedobject
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLTE(FieldID, id))
+}
+
+// LocalID applies equality check predicate on the "local_id" field. It's identical to LocalIDEQ.
+func LocalID(v uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldLocalID, v))
+}
+
+// RemoteID applies equality check predicate on the "remote_id" field. It's identical to RemoteIDEQ.
+func RemoteID(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldRemoteID, v))
+}
+
+// Hash applies equality check predicate on the "hash" field. It's identical to HashEQ.
+func Hash(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldHash, v))
+}
+
+// LastError applies equality check predicate on the "last_error" field. It's identical to LastErrorEQ.
+func LastError(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldLastError, v))
+}
+
+// SyncedAt applies equality check predicate on the "synced_at" field. It's identical to SyncedAtEQ.
+func SyncedAt(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldSyncedAt, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// ResourceTypeEQ applies the EQ predicate on the "resource_type" field.
+func ResourceTypeEQ(v ResourceType) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldResourceType, v))
+}
+
+// ResourceTypeNEQ applies the NEQ predicate on the "resource_type" field.
+func ResourceTypeNEQ(v ResourceType) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNEQ(FieldResourceType, v))
+}
+
+// ResourceTypeIn applies the In predicate on the "resource_type" field.
+func ResourceTypeIn(vs ...ResourceType) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldIn(FieldResourceType, vs...))
+}
+
+// ResourceTypeNotIn applies the NotIn predicate on the "resource_type" field.
+func ResourceTypeNotIn(vs ...ResourceType) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNotIn(FieldResourceType, vs...))
+}
+
+// LocalIDEQ applies the EQ predicate on the "local_id" field.
+func LocalIDEQ(v uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldLocalID, v))
+}
+
+// LocalIDNEQ applies the NEQ predicate on the "local_id" field.
+func LocalIDNEQ(v uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNEQ(FieldLocalID, v))
+}
+
+// LocalIDIn applies the In predicate on the "local_id" field.
+func LocalIDIn(vs ...uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldIn(FieldLocalID, vs...))
+}
+
+// LocalIDNotIn applies the NotIn predicate on the "local_id" field.
+func LocalIDNotIn(vs ...uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNotIn(FieldLocalID, vs...))
+}
+
+// LocalIDGT applies the GT predicate on the "local_id" field.
+func LocalIDGT(v uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGT(FieldLocalID, v))
+}
+
+// LocalIDGTE applies the GTE predicate on the "local_id" field.
+func LocalIDGTE(v uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGTE(FieldLocalID, v))
+}
+
+// LocalIDLT applies the LT predicate on the "local_id" field.
+func LocalIDLT(v uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLT(FieldLocalID, v))
+}
+
+// LocalIDLTE applies the LTE predicate on the "local_id" field.
+func LocalIDLTE(v uuid.UUID) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLTE(FieldLocalID, v))
+}
+
+// RemoteIDEQ applies the EQ predicate on the "remote_id" field.
+func RemoteIDEQ(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldRemoteID, v))
+}
+
+// RemoteIDNEQ applies the NEQ predicate on the "remote_id" field.
+func RemoteIDNEQ(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNEQ(FieldRemoteID, v))
+}
+
+// RemoteIDIn applies the In predicate on the "remote_id" field.
+func RemoteIDIn(vs ...string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldIn(FieldRemoteID, vs...))
+}
+
+// RemoteIDNotIn applies the NotIn predicate on the "remote_id" field.
+func RemoteIDNotIn(vs ...string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNotIn(FieldRemoteID, vs...))
+}
+
+// RemoteIDGT applies the GT predicate on the "remote_id" field.
+func RemoteIDGT(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGT(FieldRemoteID, v))
+}
+
+// RemoteIDGTE applies the GTE predicate on the "remote_id" field.
+func RemoteIDGTE(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGTE(FieldRemoteID, v))
+}
+
+// RemoteIDLT applies the LT predicate on the "remote_id" field.
+func RemoteIDLT(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLT(FieldRemoteID, v))
+}
+
+// RemoteIDLTE applies the LTE predicate on the "remote_id" field.
+func RemoteIDLTE(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLTE(FieldRemoteID, v))
+}
+
+// RemoteIDContains applies the Contains predicate on the "remote_id" field.
+func RemoteIDContains(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldContains(FieldRemoteID, v))
+}
+
+// RemoteIDHasPrefix applies the HasPrefix predicate on the "remote_id" field.
+func RemoteIDHasPrefix(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldHasPrefix(FieldRemoteID, v))
+}
+
+// RemoteIDHasSuffix applies the HasSuffix predicate on the "remote_id" field.
+func RemoteIDHasSuffix(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldHasSuffix(FieldRemoteID, v))
+}
+
+// RemoteIDEqualFold applies the EqualFold predicate on the "remote_id" field.
+func RemoteIDEqualFold(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEqualFold(FieldRemoteID, v))
+}
+
+// RemoteIDContainsFold applies the ContainsFold predicate on the "remote_id" field.
+func RemoteIDContainsFold(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldContainsFold(FieldRemoteID, v))
+}
+
+// HashEQ applies the EQ predicate on the "hash" field.
+func HashEQ(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldHash, v))
+}
+
+// HashNEQ applies the NEQ predicate on the "hash" field.
+func HashNEQ(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNEQ(FieldHash, v))
+}
+
+// HashIn applies the In predicate on the "hash" field.
+func HashIn(vs ...string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldIn(FieldHash, vs...))
+}
+
+// HashNotIn applies the NotIn predicate on the "hash" field.
+func HashNotIn(vs ...string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNotIn(FieldHash, vs...))
+}
+
+// HashGT applies the GT predicate on the "hash" field.
+func HashGT(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGT(FieldHash, v))
+}
+
+// HashGTE applies the GTE predicate on the "hash" field.
+func HashGTE(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGTE(FieldHash, v))
+}
+
+// HashLT applies the LT predicate on the "hash" field.
+func HashLT(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLT(FieldHash, v))
+}
+
+// HashLTE applies the LTE predicate on the "hash" field.
+func HashLTE(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLTE(FieldHash, v))
+}
+
+// HashContains applies the Contains predicate on the "hash" field.
+func HashContains(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldContains(FieldHash, v))
+}
+
+// HashHasPrefix applies the HasPrefix predicate on the "hash" field.
+func HashHasPrefix(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldHasPrefix(FieldHash, v))
+}
+
+// HashHasSuffix applies the HasSuffix predicate on the "hash" field.
+func HashHasSuffix(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldHasSuffix(FieldHash, v))
+}
+
+// HashEqualFold applies the EqualFold predicate on the "hash" field.
+func HashEqualFold(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEqualFold(FieldHash, v))
+}
+
+// HashContainsFold applies the ContainsFold predicate on the "hash" field.
+func HashContainsFold(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldContainsFold(FieldHash, v))
+}
+
+// LastErrorEQ applies the EQ predicate on the "last_error" field.
+func LastErrorEQ(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldLastError, v))
+}
+
+// LastErrorNEQ applies the NEQ predicate on the "last_error" field.
+func LastErrorNEQ(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNEQ(FieldLastError, v))
+}
+
+// LastErrorIn applies the In predicate on the "last_error" field.
+func LastErrorIn(vs ...string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldIn(FieldLastError, vs...))
+}
+
+// LastErrorNotIn applies the NotIn predicate on the "last_error" field.
+func LastErrorNotIn(vs ...string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNotIn(FieldLastError, vs...))
+}
+
+// LastErrorGT applies the GT predicate on the "last_error" field.
+func LastErrorGT(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGT(FieldLastError, v))
+}
+
+// LastErrorGTE applies the GTE predicate on the "last_error" field.
+func LastErrorGTE(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGTE(FieldLastError, v))
+}
+
+// LastErrorLT applies the LT predicate on the "last_error" field.
+func LastErrorLT(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLT(FieldLastError, v))
+}
+
+// LastErrorLTE applies the LTE predicate on the "last_error" field.
+func LastErrorLTE(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLTE(FieldLastError, v))
+}
+
+// LastErrorContains applies the Contains predicate on the "last_error" field.
+func LastErrorContains(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldContains(FieldLastError, v))
+}
+
+// LastErrorHasPrefix applies the HasPrefix predicate on the "last_error" field.
+func LastErrorHasPrefix(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldHasPrefix(FieldLastError, v))
+}
+
+// LastErrorHasSuffix applies the HasSuffix predicate on the "last_error" field.
+func LastErrorHasSuffix(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldHasSuffix(FieldLastError, v))
+}
+
+// LastErrorEqualFold applies the EqualFold predicate on the "last_error" field.
+func LastErrorEqualFold(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEqualFold(FieldLastError, v))
+}
+
+// LastErrorContainsFold applies the ContainsFold predicate on the "last_error" field.
+func LastErrorContainsFold(v string) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldContainsFold(FieldLastError, v))
+}
+
+// SyncedAtEQ applies the EQ predicate on the "synced_at" field.
+func SyncedAtEQ(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldSyncedAt, v))
+}
+
+// SyncedAtNEQ applies the NEQ predicate on the "synced_at" field.
+func SyncedAtNEQ(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNEQ(FieldSyncedAt, v))
+}
+
+// SyncedAtIn applies the In predicate on the "synced_at" field.
+func SyncedAtIn(vs ...time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldIn(FieldSyncedAt, vs...))
+}
+
+// SyncedAtNotIn applies the NotIn predicate on the "synced_at" field.
+func SyncedAtNotIn(vs ...time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNotIn(FieldSyncedAt, vs...))
+}
+
+// SyncedAtGT applies the GT predicate on the "synced_at" field.
+func SyncedAtGT(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGT(FieldSyncedAt, v))
+}
+
+// SyncedAtGTE applies the GTE predicate on the "synced_at" field.
+func SyncedAtGTE(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGTE(FieldSyncedAt, v))
+}
+
+// SyncedAtLT applies the LT predicate on the "synced_at" field.
+func SyncedAtLT(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLT(FieldSyncedAt, v))
+}
+
+// SyncedAtLTE applies the LTE predicate on the "synced_at" field.
+func SyncedAtLTE(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLTE(FieldSyncedAt, v))
+}
+
+// SyncedAtIsNil applies the IsNil predicate on the "synced_at" field.
+func SyncedAtIsNil() predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldIsNull(FieldSyncedAt))
+}
+
+// SyncedAtNotNil applies the NotNil predicate on the "synced_at" field.
+func SyncedAtNotNil() predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNotNull(FieldSyncedAt))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// HasConnector applies the HasEdge predicate on the "connector" edge.
+func HasConnector() predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, ConnectorTable, ConnectorColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasConnectorWith applies the HasEdge predicate on the "connector" edge with a given conditions (other predicates).
+func HasConnectorWith(preds ...predicate.ProvisioningConnector) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(func(s *sql.Selector) {
+		step := newConnectorStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ProvisionedObject) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ProvisionedObject) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ProvisionedObject) predicate.ProvisionedObject {
+	return predicate.ProvisionedObject(sql.NotPredicates(p))
+}
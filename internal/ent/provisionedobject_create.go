@@ -0,0 +1,407 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/provisionedobject"
+	"github.com/qinzj/claude-demo/internal/ent/provisioningconnector"
+)
+
+// ProvisionedObjectCreate is the builder for creating a ProvisionedObject entity.
+type ProvisionedObjectCreate struct {
+	config
+	mutation *ProvisionedObjectMutation
+	hooks    []Hook
+}
+
+// SetResourceType sets the "resource_type" field.
+func (_c *ProvisionedObjectCreate) SetResourceType(v provisionedobject.ResourceType) *ProvisionedObjectCreate {
+	_c.mutation.SetResourceType(v)
+	return _c
+}
+
+// SetLocalID sets the "local_id" field.
+func (_c *ProvisionedObjectCreate) SetLocalID(v uuid.UUID) *ProvisionedObjectCreate {
+	_c.mutation.SetLocalID(v)
+	return _c
+}
+
+// SetRemoteID sets the "remote_id" field.
+func (_c *ProvisionedObjectCreate) SetRemoteID(v string) *ProvisionedObjectCreate {
+	_c.mutation.SetRemoteID(v)
+	return _c
+}
+
+// SetNillableRemoteID sets the "remote_id" field if the given value is not nil.
+func (_c *ProvisionedObjectCreate) SetNillableRemoteID(v *string) *ProvisionedObjectCreate {
+	if v != nil {
+		_c.SetRemoteID(*v)
+	}
+	return _c
+}
+
+// SetHash sets the "hash" field.
+func (_c *ProvisionedObjectCreate) SetHash(v string) *ProvisionedObjectCreate {
+	_c.mutation.SetHash(v)
+	return _c
+}
+
+// SetNillableHash sets the "hash" field if the given value is not nil.
+func (_c *ProvisionedObjectCreate) SetNillableHash(v *string) *ProvisionedObjectCreate {
+	if v != nil {
+		_c.SetHash(*v)
+	}
+	return _c
+}
+
+// SetLastError sets the "last_error" field.
+func (_c *ProvisionedObjectCreate) SetLastError(v string) *ProvisionedObjectCreate {
+	_c.mutation.SetLastError(v)
+	return _c
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (_c *ProvisionedObjectCreate) SetNillableLastError(v *string) *ProvisionedObjectCreate {
+	if v != nil {
+		_c.SetLastError(*v)
+	}
+	return _c
+}
+
+// SetSyncedAt sets the "synced_at" field.
+func (_c *ProvisionedObjectCreate) SetSyncedAt(v time.Time) *ProvisionedObjectCreate {
+	_c.mutation.SetSyncedAt(v)
+	return _c
+}
+
+// SetNillableSyncedAt sets the "synced_at" field if the given value is not nil.
+func (_c *ProvisionedObjectCreate) SetNillableSyncedAt(v *time.Time) *ProvisionedObjectCreate {
+	if v != nil {
+		_c.SetSyncedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *ProvisionedObjectCreate) SetCreatedAt(v time.Time) *ProvisionedObjectCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *ProvisionedObjectCreate) SetNillableCreatedAt(v *time.Time) *ProvisionedObjectCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *ProvisionedObjectCreate) SetUpdatedAt(v time.Time) *ProvisionedObjectCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *ProvisionedObjectCreate) SetNillableUpdatedAt(v *time.Time) *ProvisionedObjectCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *ProvisionedObjectCreate) SetID(v uuid.UUID) *ProvisionedObjectCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *ProvisionedObjectCreate) SetNillableID(v *uuid.UUID) *ProvisionedObjectCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetConnectorID sets the "connector" edge to the ProvisioningConnector entity by ID.
+func (_c *ProvisionedObjectCreate) SetConnectorID(id uuid.UUID) *ProvisionedObjectCreate {
+	_c.mutation.SetConnectorID(id)
+	return _c
+}
+
+// SetConnector sets the "connector" edge to the ProvisioningConnector entity.
+func (_c *ProvisionedObjectCreate) SetConnector(v *ProvisioningConnector) *ProvisionedObjectCreate {
+	return _c.SetConnectorID(v.ID)
+}
+
+// Mutation returns the ProvisionedObjectMutation object of the builder.
+func (_c *ProvisionedObjectCreate) Mutation() *ProvisionedObjectMutation {
+	return _c.mutation
+}
+
+// Save creates the ProvisionedObject in the database.
+func (_c *ProvisionedObjectCreate) Save(ctx context.Context) (*ProvisionedObject, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *ProvisionedObjectCreate) SaveX(ctx context.Context) *ProvisionedObject {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *ProvisionedObjectCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *ProvisionedObjectCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *ProvisionedObjectCreate) defaults() {
+	if _, ok := _c.mutation.RemoteID(); !ok {
+		v := provisionedobject.DefaultRemoteID
+		_c.mutation.SetRemoteID(v)
+	}
+	if _, ok := _c.mutation.Hash(); !ok {
+		v := provisionedobject.DefaultHash
+		_c.mutation.SetHash(v)
+	}
+	if _, ok := _c.mutation.LastError(); !ok {
+		v := provisionedobject.DefaultLastError
+		_c.mutation.SetLastError(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := provisionedobject.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := provisionedobject.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := provisionedobject.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *ProvisionedObjectCreate) check() error {
+	if _, ok := _c.mutation.ResourceType(); !ok {
+		return &ValidationError{Name: "resource_type", err: errors.New(`ent: missing required field "ProvisionedObject.resource_type"`)}
+	}
+	if v, ok := _c.mutation.ResourceType(); ok {
+		if err := provisionedobject.ResourceTypeValidator(v); err != nil {
+			return &ValidationError{Name: "resource_type", err: fmt.Errorf(`ent: validator failed for field "ProvisionedObject.resource_type": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.LocalID(); !ok {
+		return &ValidationError{Name: "local_id", err: errors.New(`ent: missing required field "ProvisionedObject.local_id"`)}
+	}
+	if _, ok := _c.mutation.RemoteID(); !ok {
+		return &ValidationError{Name: "remote_id", err: errors.New(`ent: missing required field "ProvisionedObject.remote_id"`)}
+	}
+	if _, ok := _c.mutation.Hash(); !ok {
+		return &ValidationError{Name: "hash", err: errors.New(`ent: missing required field "ProvisionedObject.hash"`)}
+	}
+	if _, ok := _c.mutation.LastError(); !ok {
+		return &ValidationError{Name: "last_error", err: errors.New(`ent: missing required field "ProvisionedObject.last_error"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ProvisionedObject.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "ProvisionedObject.updated_at"`)}
+	}
+	if len(_c.mutation.ConnectorIDs()) == 0 {
+		return &ValidationError{Name: "connector", err: errors.New(`ent: missing required edge "ProvisionedObject.connector"`)}
+	}
+	return nil
+}
+
+func (_c *ProvisionedObjectCreate) sqlSave(ctx context.Context) (*ProvisionedObject, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *ProvisionedObjectCreate) createSpec() (*ProvisionedObject, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ProvisionedObject{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(provisionedobject.Table, sqlgraph.NewFieldSpec(provisionedobject.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.ResourceType(); ok {
+		_spec.SetField(provisionedobject.FieldResourceType, field.TypeEnum, value)
+		_node.ResourceType = value
+	}
+	if value, ok := _c.mutation.LocalID(); ok {
+		_spec.SetField(provisionedobject.FieldLocalID, field.TypeUUID, value)
+		_node.LocalID = value
+	}
+	if value, ok := _c.mutation.RemoteID(); ok {
+		_spec.SetField(provisionedobject.FieldRemoteID, field.TypeString, value)
+		_node.RemoteID = value
+	}
+	if value, ok := _c.mutation.Hash(); ok {
+		_spec.SetField(provisionedobject.FieldHash, field.TypeString, value)
+		_node.Hash = value
+	}
+	if value, ok := _c.mutation.LastError(); ok {
+		_spec.SetField(provisionedobject.FieldLastError, field.TypeString, value)
+		_node.LastError = value
+	}
+	if value, ok := _c.mutation.SyncedAt(); ok {
+		_spec.SetField(provisionedobject.FieldSyncedAt, field.TypeTime, value)
+		_node.SyncedAt = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(provisionedobject.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(provisionedobject.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := _c.mutation.ConnectorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   provisionedobject.ConnectorTable,
+			Columns: []string{provisionedobject.ConnectorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisioningconnector.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.provisioning_connector_objects = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ProvisionedObjectCreateBulk is the builder for creating many ProvisionedObject entities in bulk.
+type ProvisionedObjectCreateBulk struct {
+	config
+	err      error
+	builders []*ProvisionedObjectCreate
+}
+
+// Save creates the ProvisionedObject entities in the database.
+func (_c *ProvisionedObjectCreateBulk) Save(ctx context.Context) ([]*ProvisionedObject, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*ProvisionedObject, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ProvisionedObjectMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *ProvisionedObjectCreateBulk) SaveX(ctx context.Context) []*ProvisionedObject {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *ProvisionedObjectCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *ProvisionedObjectCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,482 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+	"github.com/qinzj/claude-demo/internal/ent/provisionedobject"
+	"github.com/qinzj/claude-demo/internal/ent/provisioningconnector"
+)
+
+// ProvisionedObjectUpdate is the builder for updating ProvisionedObject entities.
+type ProvisionedObjectUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ProvisionedObjectMutation
+}
+
+// Where appends a list predicates to the ProvisionedObjectUpdate builder.
+func (_u *ProvisionedObjectUpdate) Where(ps ...predicate.ProvisionedObject) *ProvisionedObjectUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetRemoteID sets the "remote_id" field.
+func (_u *ProvisionedObjectUpdate) SetRemoteID(v string) *ProvisionedObjectUpdate {
+	_u.mutation.SetRemoteID(v)
+	return _u
+}
+
+// SetNillableRemoteID sets the "remote_id" field if the given value is not nil.
+func (_u *ProvisionedObjectUpdate) SetNillableRemoteID(v *string) *ProvisionedObjectUpdate {
+	if v != nil {
+		_u.SetRemoteID(*v)
+	}
+	return _u
+}
+
+// SetHash sets the "hash" field.
+func (_u *ProvisionedObjectUpdate) SetHash(v string) *ProvisionedObjectUpdate {
+	_u.mutation.SetHash(v)
+	return _u
+}
+
+// SetNillableHash sets the "hash" field if the given value is not nil.
+func (_u *ProvisionedObjectUpdate) SetNillableHash(v *string) *ProvisionedObjectUpdate {
+	if v != nil {
+		_u.SetHash(*v)
+	}
+	return _u
+}
+
+// SetLastError sets the "last_error" field.
+func (_u *ProvisionedObjectUpdate) SetLastError(v string) *ProvisionedObjectUpdate {
+	_u.mutation.SetLastError(v)
+	return _u
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (_u *ProvisionedObjectUpdate) SetNillableLastError(v *string) *ProvisionedObjectUpdate {
+	if v != nil {
+		_u.SetLastError(*v)
+	}
+	return _u
+}
+
+// SetSyncedAt sets the "synced_at" field.
+func (_u *ProvisionedObjectUpdate) SetSyncedAt(v time.Time) *ProvisionedObjectUpdate {
+	_u.mutation.SetSyncedAt(v)
+	return _u
+}
+
+// SetNillableSyncedAt sets the "synced_at" field if the given value is not nil.
+func (_u *ProvisionedObjectUpdate) SetNillableSyncedAt(v *time.Time) *ProvisionedObjectUpdate {
+	if v != nil {
+		_u.SetSyncedAt(*v)
+	}
+	return _u
+}
+
+// ClearSyncedAt clears the value of the "synced_at" field.
+func (_u *ProvisionedObjectUpdate) ClearSyncedAt() *ProvisionedObjectUpdate {
+	_u.mutation.ClearSyncedAt()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *ProvisionedObjectUpdate) SetUpdatedAt(v time.Time) *ProvisionedObjectUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetConnectorID sets the "connector" edge to the ProvisioningConnector entity by ID.
+func (_u *ProvisionedObjectUpdate) SetConnectorID(id uuid.UUID) *ProvisionedObjectUpdate {
+	_u.mutation.SetConnectorID(id)
+	return _u
+}
+
+// SetConnector sets the "connector" edge to the ProvisioningConnector entity.
+func (_u *ProvisionedObjectUpdate) SetConnector(v *ProvisioningConnector) *ProvisionedObjectUpdate {
+	return _u.SetConnectorID(v.ID)
+}
+
+// Mutation returns the ProvisionedObjectMutation object of the builder.
+func (_u *ProvisionedObjectUpdate) Mutation() *ProvisionedObjectMutation {
+	return _u.mutation
+}
+
+// ClearConnector clears the "connector" edge to the ProvisioningConnector entity.
+func (_u *ProvisionedObjectUpdate) ClearConnector() *ProvisionedObjectUpdate {
+	_u.mutation.ClearConnector()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *ProvisionedObjectUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ProvisionedObjectUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *ProvisionedObjectUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ProvisionedObjectUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *ProvisionedObjectUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := provisionedobject.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *ProvisionedObjectUpdate) check() error {
+	if _u.mutation.ConnectorCleared() && len(_u.mutation.ConnectorIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "ProvisionedObject.connector"`)
+	}
+	return nil
+}
+
+func (_u *ProvisionedObjectUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(provisionedobject.Table, provisionedobject.Columns, sqlgraph.NewFieldSpec(provisionedobject.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.RemoteID(); ok {
+		_spec.SetField(provisionedobject.FieldRemoteID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Hash(); ok {
+		_spec.SetField(provisionedobject.FieldHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.LastError(); ok {
+		_spec.SetField(provisionedobject.FieldLastError, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.SyncedAt(); ok {
+		_spec.SetField(provisionedobject.FieldSyncedAt, field.TypeTime, value)
+	}
+	if _u.mutation.SyncedAtCleared() {
+		_spec.ClearField(provisionedobject.FieldSyncedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(provisionedobject.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ConnectorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   provisionedobject.ConnectorTable,
+			Columns: []string{provisionedobject.ConnectorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisioningconnector.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ConnectorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   provisionedobject.ConnectorTable,
+			Columns: []string{provisionedobject.ConnectorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisioningconnector.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{provisionedobject.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// ProvisionedObjectUpdateOne is the builder for updating a single ProvisionedObject entity.
+type ProvisionedObjectUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ProvisionedObjectMutation
+}
+
+// SetRemoteID sets the "remote_id" field.
+func (_u *ProvisionedObjectUpdateOne) SetRemoteID(v string) *ProvisionedObjectUpdateOne {
+	_u.mutation.SetRemoteID(v)
+	return _u
+}
+
+// SetNillableRemoteID sets the "remote_id" field if the given value is not nil.
+func (_u *ProvisionedObjectUpdateOne) SetNillableRemoteID(v *string) *ProvisionedObjectUpdateOne {
+	if v != nil {
+		_u.SetRemoteID(*v)
+	}
+	return _u
+}
+
+// SetHash sets the "hash" field.
+func (_u *ProvisionedObjectUpdateOne) SetHash(v string) *ProvisionedObjectUpdateOne {
+	_u.mutation.SetHash(v)
+	return _u
+}
+
+// SetNillableHash sets the "hash" field if the given value is not nil.
+func (_u *ProvisionedObjectUpdateOne) SetNillableHash(v *string) *ProvisionedObjectUpdateOne {
+	if v != nil {
+		_u.SetHash(*v)
+	}
+	return _u
+}
+
+// SetLastError sets the "last_error" field.
+func (_u *ProvisionedObjectUpdateOne) SetLastError(v string) *ProvisionedObjectUpdateOne {
+	_u.mutation.SetLastError(v)
+	return _u
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (_u *ProvisionedObjectUpdateOne) SetNillableLastError(v *string) *ProvisionedObjectUpdateOne {
+	if v != nil {
+		_u.SetLastError(*v)
+	}
+	return _u
+}
+
+// SetSyncedAt sets the "synced_at" field.
+func (_u *ProvisionedObjectUpdateOne) SetSyncedAt(v time.Time) *ProvisionedObjectUpdateOne {
+	_u.mutation.SetSyncedAt(v)
+	return _u
+}
+
+// SetNillableSyncedAt sets the "synced_at" field if the given value is not nil.
+func (_u *ProvisionedObjectUpdateOne) SetNillableSyncedAt(v *time.Time) *ProvisionedObjectUpdateOne {
+	if v != nil {
+		_u.SetSyncedAt(*v)
+	}
+	return _u
+}
+
+// ClearSyncedAt clears the value of the "synced_at" field.
+func (_u *ProvisionedObjectUpdateOne) ClearSyncedAt() *ProvisionedObjectUpdateOne {
+	_u.mutation.ClearSyncedAt()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *ProvisionedObjectUpdateOne) SetUpdatedAt(v time.Time) *ProvisionedObjectUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetConnectorID sets the "connector" edge to the ProvisioningConnector entity by ID.
+func (_u *ProvisionedObjectUpdateOne) SetConnectorID(id uuid.UUID) *ProvisionedObjectUpdateOne {
+	_u.mutation.SetConnectorID(id)
+	return _u
+}
+
+// SetConnector sets the "connector" edge to the ProvisioningConnector entity.
+func (_u *ProvisionedObjectUpdateOne) SetConnector(v *ProvisioningConnector) *ProvisionedObjectUpdateOne {
+	return _u.SetConnectorID(v.ID)
+}
+
+// Mutation returns the ProvisionedObjectMutation object of the builder.
+func (_u *ProvisionedObjectUpdateOne) Mutation() *ProvisionedObjectMutation {
+	return _u.mutation
+}
+
+// ClearConnector clears the "connector" edge to the ProvisioningConnector entity.
+func (_u *ProvisionedObjectUpdateOne) ClearConnector() *ProvisionedObjectUpdateOne {
+	_u.mutation.ClearConnector()
+	return _u
+}
+
+// Where appends a list predicates to the ProvisionedObjectUpdate builder.
+func (_u *ProvisionedObjectUpdateOne) Where(ps ...predicate.ProvisionedObject) *ProvisionedObjectUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *ProvisionedObjectUpdateOne) Select(field string, fields ...string) *ProvisionedObjectUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated ProvisionedObject entity.
+func (_u *ProvisionedObjectUpdateOne) Save(ctx context.Context) (*ProvisionedObject, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ProvisionedObjectUpdateOne) SaveX(ctx context.Context) *ProvisionedObject {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *ProvisionedObjectUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ProvisionedObjectUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *ProvisionedObjectUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := provisionedobject.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *ProvisionedObjectUpdateOne) check() error {
+	if _u.mutation.ConnectorCleared() && len(_u.mutation.ConnectorIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "ProvisionedObject.connector"`)
+	}
+	return nil
+}
+
+func (_u *ProvisionedObjectUpdateOne) sqlSave(ctx context.Context) (_node *ProvisionedObject, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(provisionedobject.Table, provisionedobject.Columns, sqlgraph.NewFieldSpec(provisionedobject.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ProvisionedObject.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, provisionedobject.FieldID)
+		for _, f := range fields {
+			if !provisionedobject.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != provisionedobject.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.RemoteID(); ok {
+		_spec.SetField(provisionedobject.FieldRemoteID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Hash(); ok {
+		_spec.SetField(provisionedobject.FieldHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.LastError(); ok {
+		_spec.SetField(provisionedobject.FieldLastError, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.SyncedAt(); ok {
+		_spec.SetField(provisionedobject.FieldSyncedAt, field.TypeTime, value)
+	}
+	if _u.mutation.SyncedAtCleared() {
+		_spec.ClearField(provisionedobject.FieldSyncedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(provisionedobject.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ConnectorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   provisionedobject.ConnectorTable,
+			Columns: []string{provisionedobject.ConnectorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisioningconnector.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ConnectorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   provisionedobject.ConnectorTable,
+			Columns: []string{provisionedobject.ConnectorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisioningconnector.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &ProvisionedObject{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{provisionedobject.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
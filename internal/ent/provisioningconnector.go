@@ -0,0 +1,285 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/provisioningconnector"
+)
+
+// ProvisioningConnector is the model entity for the ProvisioningConnector schema.
+type ProvisioningConnector struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// URL holds the value of the "url" field.
+	URL string `json:"url,omitempty"`
+	// Token holds the value of the "token" field.
+	Token string `json:"-"`
+	// ScopeGroupID holds the value of the "scope_group_id" field.
+	ScopeGroupID *uuid.UUID `json:"scope_group_id,omitempty"`
+	// SyncGroups holds the value of the "sync_groups" field.
+	SyncGroups bool `json:"sync_groups,omitempty"`
+	// AttributeMapping holds the value of the "attribute_mapping" field.
+	AttributeMapping map[string]string `json:"attribute_mapping,omitempty"`
+	// Enabled holds the value of the "enabled" field.
+	Enabled bool `json:"enabled,omitempty"`
+	// EventCursor holds the value of the "event_cursor" field.
+	EventCursor *time.Time `json:"event_cursor,omitempty"`
+	// LastSyncAt holds the value of the "last_sync_at" field.
+	LastSyncAt *time.Time `json:"last_sync_at,omitempty"`
+	// LastFullSyncAt holds the value of the "last_full_sync_at" field.
+	LastFullSyncAt *time.Time `json:"last_full_sync_at,omitempty"`
+	// LastError holds the value of the "last_error" field.
+	LastError string `json:"last_error,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ProvisioningConnectorQuery when eager-loading is set.
+	Edges        ProvisioningConnectorEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// ProvisioningConnectorEdges holds the relations/edges for other nodes in the graph.
+type ProvisioningConnectorEdges struct {
+	// Objects holds the value of the objects edge.
+	Objects []*ProvisionedObject `json:"objects,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// ObjectsOrErr returns the Objects value or an error if the edge
+// was not loaded in eager-loading.
+func (e ProvisioningConnectorEdges) ObjectsOrErr() ([]*ProvisionedObject, error) {
+	if e.loadedTypes[0] {
+		return e.Objects, nil
+	}
+	return nil, &NotLoadedError{edge: "objects"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ProvisioningConnector) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case provisioningconnector.FieldScopeGroupID:
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		case provisioningconnector.FieldAttributeMapping:
+			values[i] = new([]byte)
+		case provisioningconnector.FieldSyncGroups, provisioningconnector.FieldEnabled:
+			values[i] = new(sql.NullBool)
+		case provisioningconnector.FieldName, provisioningconnector.FieldURL, provisioningconnector.FieldToken, provisioningconnector.FieldLastError:
+			values[i] = new(sql.NullString)
+		case provisioningconnector.FieldEventCursor, provisioningconnector.FieldLastSyncAt, provisioningconnector.FieldLastFullSyncAt, provisioningconnector.FieldCreatedAt, provisioningconnector.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case provisioningconnector.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ProvisioningConnector fields.
+func (_m *ProvisioningConnector) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case provisioningconnector.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case provisioningconnector.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case provisioningconnector.FieldURL:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field url", values[i])
+			} else if value.Valid {
+				_m.URL = value.String
+			}
+		case provisioningconnector.FieldToken:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field token", values[i])
+			} else if value.Valid {
+				_m.Token = value.String
+			}
+		case provisioningconnector.FieldScopeGroupID:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field scope_group_id", values[i])
+			} else if value.Valid {
+				_m.ScopeGroupID = new(uuid.UUID)
+				*_m.ScopeGroupID = *value.S.(*uuid.UUID)
+			}
+		case provisioningconnector.FieldSyncGroups:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field sync_groups", values[i])
+			} else if value.Valid {
+				_m.SyncGroups = value.Bool
+			}
+		case provisioningconnector.FieldAttributeMapping:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field attribute_mapping", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.AttributeMapping); err != nil {
+					return fmt.Errorf("unmarshal field attribute_mapping: %w", err)
+				}
+			}
+		case provisioningconnector.FieldEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field enabled", values[i])
+			} else if value.Valid {
+				_m.Enabled = value.Bool
+			}
+		case provisioningconnector.FieldEventCursor:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field event_cursor", values[i])
+			} else if value.Valid {
+				_m.EventCursor = new(time.Time)
+				*_m.EventCursor = value.Time
+			}
+		case provisioningconnector.FieldLastSyncAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_sync_at", values[i])
+			} else if value.Valid {
+				_m.LastSyncAt = new(time.Time)
+				*_m.LastSyncAt = value.Time
+			}
+		case provisioningconnector.FieldLastFullSyncAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_full_sync_at", values[i])
+			} else if value.Valid {
+				_m.LastFullSyncAt = new(time.Time)
+				*_m.LastFullSyncAt = value.Time
+			}
+		case provisioningconnector.FieldLastError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field last_error", values[i])
+			} else if value.Valid {
+				_m.LastError = value.String
+			}
+		case provisioningconnector.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case provisioningconnector.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ProvisioningConnector.
+// This includes values selected through modifiers, order, etc.
+func (_m *ProvisioningConnector) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryObjects queries the "objects" edge of the ProvisioningConnector entity.
+func (_m *ProvisioningConnector) QueryObjects() *ProvisionedObjectQuery {
+	return NewProvisioningConnectorClient(_m.config).QueryObjects(_m)
+}
+
+// Update returns a builder for updating this ProvisioningConnector.
+// Note that you need to call ProvisioningConnector.Unwrap() before calling this method if this ProvisioningConnector
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *ProvisioningConnector) Update() *ProvisioningConnectorUpdateOne {
+	return NewProvisioningConnectorClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the ProvisioningConnector entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *ProvisioningConnector) Unwrap() *ProvisioningConnector {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ProvisioningConnector is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *ProvisioningConnector) String() string {
+	var builder strings.Builder
+	builder.WriteString("ProvisioningConnector(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("url=")
+	builder.WriteString(_m.URL)
+	builder.WriteString(", ")
+	builder.WriteString("token=<sensitive>")
+	builder.WriteString(", ")
+	if v := _m.ScopeGroupID; v != nil {
+		builder.WriteString("scope_group_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("sync_groups=")
+	builder.WriteString(fmt.Sprintf("%v", _m.SyncGroups))
+	builder.WriteString(", ")
+	builder.WriteString("attribute_mapping=")
+	builder.WriteString(fmt.Sprintf("%v", _m.AttributeMapping))
+	builder.WriteString(", ")
+	builder.WriteString("enabled=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Enabled))
+	builder.WriteString(", ")
+	if v := _m.EventCursor; v != nil {
+		builder.WriteString("event_cursor=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.LastSyncAt; v != nil {
+		builder.WriteString("last_sync_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.LastFullSyncAt; v != nil {
+		builder.WriteString("last_full_sync_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("last_error=")
+	builder.WriteString(_m.LastError)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ProvisioningConnectors is a parsable slice of ProvisioningConnector.
+type ProvisioningConnectors []*ProvisioningConnector
@@ -0,0 +1,195 @@
+// Code generated by ent, DO NOT EDIT.
+
+package provisioningconnector
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the provisioningconnector type in the database.
+	Label = "provisioning_connector"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldURL holds the string denoting the url field in the database.
+	FieldURL = "url"
+	// FieldToken holds the string denoting the token field in the database.
+	FieldToken = "token"
+	// FieldScopeGroupID holds the string denoting the scope_group_id field in the database.
+	FieldScopeGroupID = "scope_group_id"
+	// FieldSyncGroups holds the string denoting the sync_groups field in the database.
+	FieldSyncGroups = "sync_groups"
+	// FieldAttributeMapping holds the string denoting the attribute_mapping field in the database.
+	FieldAttributeMapping = "attribute_mapping"
+	// FieldEnabled holds the string denoting the enabled field in the database.
+	FieldEnabled = "enabled"
+	// FieldEventCursor holds the string denoting the event_cursor field in the database.
+	FieldEventCursor = "event_cursor"
+	// FieldLastSyncAt holds the string denoting the last_sync_at field in the database.
+	FieldLastSyncAt = "last_sync_at"
+	// FieldLastFullSyncAt holds the string denoting the last_full_sync_at field in the database.
+	FieldLastFullSyncAt = "last_full_sync_at"
+	// FieldLastError holds the string denoting the last_error field in the database.
+	FieldLastError = "last_error"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// EdgeObjects holds the string denoting the objects edge name in mutations.
+	EdgeObjects = "objects"
+	// Table holds the table name of the provisioningconnector in the database.
+	Table = "provisioning_connectors"
+	// ObjectsTable is the table that holds the objects relation/edge.
+	ObjectsTable = "provisioned_objects"
+	// ObjectsInverseTable is the table name for the ProvisionedObject entity.
+	// It exists in this package in order to avoid circular dependency with the "provisionedobject" package.
+	ObjectsInverseTable = "provisioned_objects"
+	// ObjectsColumn is the table column denoting the objects relation/edge.
+	ObjectsColumn = "provisioning_connector_objects"
+)
+
+// Columns holds all SQL columns for provisioningconnector fields.
+var Columns = []string{
+	FieldID,
+	FieldName,
+	FieldURL,
+	FieldToken,
+	FieldScopeGroupID,
+	FieldSyncGroups,
+	FieldAttributeMapping,
+	FieldEnabled,
+	FieldEventCursor,
+	FieldLastSyncAt,
+	FieldLastFullSyncAt,
+	FieldLastError,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// NameValidator is a validator for the "name" field. It is called by the builders before save.
+	NameValidator func(string) error
+	// URLValidator is a validator for the "url" field. It is called by the builders before save.
+	URLValidator func(string) error
+	// DefaultToken holds the default value on creation for the "token" field.
+	DefaultToken string
+	// DefaultSyncGroups holds the default value on creation for the "sync_groups" field.
+	DefaultSyncGroups bool
+	// DefaultEnabled holds the default value on creation for the "enabled" field.
+	DefaultEnabled bool
+	// DefaultLastError holds the default value on creation for the "last_error" field.
+	DefaultLastError string
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// OrderOption defines the ordering options for the ProvisioningConnector queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByName orders the results by the name field.
+func ByName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldName, opts...).ToFunc()
+}
+
+// ByURL orders the results by the url field.
+func ByURL(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldURL, opts...).ToFunc()
+}
+
+// ByToken orders the results by the token field.
+func ByToken(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldToken, opts...).ToFunc()
+}
+
+// ByScopeGroupID orders the results by the scope_group_id field.
+func ByScopeGroupID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldScopeGroupID, opts...).ToFunc()
+}
+
+// BySyncGroups orders the results by the sync_groups field.
+func BySyncGroups(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSyncGroups, opts...).ToFunc()
+}
+
+// ByEnabled orders the results by the enabled field.
+func ByEnabled(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEnabled, opts...).ToFunc()
+}
+
+// ByEventCursor orders the results by the event_cursor field.
+func ByEventCursor(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEventCursor, opts...).ToFunc()
+}
+
+// ByLastSyncAt orders the results by the last_sync_at field.
+func ByLastSyncAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastSyncAt, opts...).ToFunc()
+}
+
+// ByLastFullSyncAt orders the results by the last_full_sync_at field.
+func ByLastFullSyncAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastFullSyncAt, opts...).ToFunc()
+}
+
+// ByLastError orders the results by the last_error field.
+func ByLastError(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastError, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
+
+// ByObjectsCount orders the results by objects count.
+func ByObjectsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newObjectsStep(), opts...)
+	}
+}
+
+// ByObjects orders the results by objects terms.
+func ByObjects(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newObjectsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+func newObjectsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ObjectsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, ObjectsTable, ObjectsColumn),
+	)
+}
@@ -0,0 +1,725 @@
+// Code generated by ent, DO NOT EDIT.
+
+package provisioningconnector
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLTE(FieldID, id))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldName, v))
+}
+
+// URL applies equality check predicate on the "url" field. It's identical to URLEQ.
+func URL(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldURL, v))
+}
+
+// Token applies equality check predicate on the "token" field. It's identical to TokenEQ.
+func Token(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldToken, v))
+}
+
+// ScopeGroupID applies equality check predicate on the "scope_group_id" field. It's identical to ScopeGroupIDEQ.
+func ScopeGroupID(v uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldScopeGroupID, v))
+}
+
+// SyncGroups applies equality check predicate on the "sync_groups" field. It's identical to SyncGroupsEQ.
+func SyncGroups(v bool) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldSyncGroups, v))
+}
+
+// Enabled applies equality check predicate on the "enabled" field. It's identical to EnabledEQ.
+func Enabled(v bool) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldEnabled, v))
+}
+
+// EventCursor applies equality check predicate on the "event_cursor" field. It's identical to EventCursorEQ.
+func EventCursor(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldEventCursor, v))
+}
+
+// LastSyncAt applies equality check predicate on the "last_sync_at" field. It's identical to LastSyncAtEQ.
+func LastSyncAt(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldLastSyncAt, v))
+}
+
+// LastFullSyncAt applies equality check predicate on the "last_full_sync_at" field. It's identical to LastFullSyncAtEQ.
+func LastFullSyncAt(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldLastFullSyncAt, v))
+}
+
+// LastError applies equality check predicate on the "last_error" field. It's identical to LastErrorEQ.
+func LastError(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldLastError, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldContainsFold(FieldName, v))
+}
+
+// URLEQ applies the EQ predicate on the "url" field.
+func URLEQ(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldURL, v))
+}
+
+// URLNEQ applies the NEQ predicate on the "url" field.
+func URLNEQ(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldURL, v))
+}
+
+// URLIn applies the In predicate on the "url" field.
+func URLIn(vs ...string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIn(FieldURL, vs...))
+}
+
+// URLNotIn applies the NotIn predicate on the "url" field.
+func URLNotIn(vs ...string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotIn(FieldURL, vs...))
+}
+
+// URLGT applies the GT predicate on the "url" field.
+func URLGT(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGT(FieldURL, v))
+}
+
+// URLGTE applies the GTE predicate on the "url" field.
+func URLGTE(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGTE(FieldURL, v))
+}
+
+// URLLT applies the LT predicate on the "url" field.
+func URLLT(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLT(FieldURL, v))
+}
+
+// URLLTE applies the LTE predicate on the "url" field.
+func URLLTE(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLTE(FieldURL, v))
+}
+
+// URLContains applies the Contains predicate on the "url" field.
+func URLContains(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldContains(FieldURL, v))
+}
+
+// URLHasPrefix applies the HasPrefix predicate on the "url" field.
+func URLHasPrefix(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldHasPrefix(FieldURL, v))
+}
+
+// URLHasSuffix applies the HasSuffix predicate on the "url" field.
+func URLHasSuffix(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldHasSuffix(FieldURL, v))
+}
+
+// URLEqualFold applies the EqualFold predicate on the "url" field.
+func URLEqualFold(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEqualFold(FieldURL, v))
+}
+
+// URLContainsFold applies the ContainsFold predicate on the "url" field.
+func URLContainsFold(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldContainsFold(FieldURL, v))
+}
+
+// TokenEQ applies the EQ predicate on the "token" field.
+func TokenEQ(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldToken, v))
+}
+
+// TokenNEQ applies the NEQ predicate on the "token" field.
+func TokenNEQ(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldToken, v))
+}
+
+// TokenIn applies the In predicate on the "token" field.
+func TokenIn(vs ...string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIn(FieldToken, vs...))
+}
+
+// TokenNotIn applies the NotIn predicate on the "token" field.
+func TokenNotIn(vs ...string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotIn(FieldToken, vs...))
+}
+
+// TokenGT applies the GT predicate on the "token" field.
+func TokenGT(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGT(FieldToken, v))
+}
+
+// TokenGTE applies the GTE predicate on the "token" field.
+func TokenGTE(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGTE(FieldToken, v))
+}
+
+// TokenLT applies the LT predicate on the "token" field.
+func TokenLT(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLT(FieldToken, v))
+}
+
+// TokenLTE applies the LTE predicate on the "token" field.
+func TokenLTE(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLTE(FieldToken, v))
+}
+
+// TokenContains applies the Contains predicate on the "token" field.
+func TokenContains(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldContains(FieldToken, v))
+}
+
+// TokenHasPrefix applies the HasPrefix predicate on the "token" field.
+func TokenHasPrefix(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldHasPrefix(FieldToken, v))
+}
+
+// TokenHasSuffix applies the HasSuffix predicate on the "token" field.
+func TokenHasSuffix(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldHasSuffix(FieldToken, v))
+}
+
+// TokenEqualFold applies the EqualFold predicate on the "token" field.
+func TokenEqualFold(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEqualFold(FieldToken, v))
+}
+
+// TokenContainsFold applies the ContainsFold predicate on the "token" field.
+func TokenContainsFold(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldContainsFold(FieldToken, v))
+}
+
+// ScopeGroupIDEQ applies the EQ predicate on the "scope_group_id" field.
+func ScopeGroupIDEQ(v uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldScopeGroupID, v))
+}
+
+// ScopeGroupIDNEQ applies the NEQ predicate on the "scope_group_id" field.
+func ScopeGroupIDNEQ(v uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldScopeGroupID, v))
+}
+
+// ScopeGroupIDIn applies the In predicate on the "scope_group_id" field.
+func ScopeGroupIDIn(vs ...uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIn(FieldScopeGroupID, vs...))
+}
+
+// ScopeGroupIDNotIn applies the NotIn predicate on the "scope_group_id" field.
+func ScopeGroupIDNotIn(vs ...uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotIn(FieldScopeGroupID, vs...))
+}
+
+// ScopeGroupIDGT applies the GT predicate on the "scope_group_id" field.
+func ScopeGroupIDGT(v uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGT(FieldScopeGroupID, v))
+}
+
+// ScopeGroupIDGTE applies the GTE predicate on the "scope_group_id" field.
+func ScopeGroupIDGTE(v uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGTE(FieldScopeGroupID, v))
+}
+
+// ScopeGroupIDLT applies the LT predicate on the "scope_group_id" field.
+func ScopeGroupIDLT(v uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLT(FieldScopeGroupID, v))
+}
+
+// ScopeGroupIDLTE applies the LTE predicate on the "scope_group_id" field.
+func ScopeGroupIDLTE(v uuid.UUID) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLTE(FieldScopeGroupID, v))
+}
+
+// ScopeGroupIDIsNil applies the IsNil predicate on the "scope_group_id" field.
+func ScopeGroupIDIsNil() predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIsNull(FieldScopeGroupID))
+}
+
+// ScopeGroupIDNotNil applies the NotNil predicate on the "scope_group_id" field.
+func ScopeGroupIDNotNil() predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotNull(FieldScopeGroupID))
+}
+
+// SyncGroupsEQ applies the EQ predicate on the "sync_groups" field.
+func SyncGroupsEQ(v bool) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldSyncGroups, v))
+}
+
+// SyncGroupsNEQ applies the NEQ predicate on the "sync_groups" field.
+func SyncGroupsNEQ(v bool) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldSyncGroups, v))
+}
+
+// AttributeMappingIsNil applies the IsNil predicate on the "attribute_mapping" field.
+func AttributeMappingIsNil() predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIsNull(FieldAttributeMapping))
+}
+
+// AttributeMappingNotNil applies the NotNil predicate on the "attribute_mapping" field.
+func AttributeMappingNotNil() predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotNull(FieldAttributeMapping))
+}
+
+// EnabledEQ applies the EQ predicate on the "enabled" field.
+func EnabledEQ(v bool) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldEnabled, v))
+}
+
+// EnabledNEQ applies the NEQ predicate on the "enabled" field.
+func EnabledNEQ(v bool) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldEnabled, v))
+}
+
+// EventCursorEQ applies the EQ predicate on the "event_cursor" field.
+func EventCursorEQ(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldEventCursor, v))
+}
+
+// EventCursorNEQ applies the NEQ predicate on the "event_cursor" field.
+func EventCursorNEQ(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldEventCursor, v))
+}
+
+// EventCursorIn applies the In predicate on the "event_cursor" field.
+func EventCursorIn(vs ...time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIn(FieldEventCursor, vs...))
+}
+
+// EventCursorNotIn applies the NotIn predicate on the "event_cursor" field.
+func EventCursorNotIn(vs ...time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotIn(FieldEventCursor, vs...))
+}
+
+// EventCursorGT applies the GT predicate on the "event_cursor" field.
+func EventCursorGT(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGT(FieldEventCursor, v))
+}
+
+// EventCursorGTE applies the GTE predicate on the "event_cursor" field.
+func EventCursorGTE(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGTE(FieldEventCursor, v))
+}
+
+// EventCursorLT applies the LT predicate on the "event_cursor" field.
+func EventCursorLT(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLT(FieldEventCursor, v))
+}
+
+// EventCursorLTE applies the LTE predicate on the "event_cursor" field.
+func EventCursorLTE(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLTE(FieldEventCursor, v))
+}
+
+// EventCursorIsNil applies the IsNil predicate on the "event_cursor" field.
+func EventCursorIsNil() predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIsNull(FieldEventCursor))
+}
+
+// EventCursorNotNil applies the NotNil predicate on the "event_cursor" field.
+func EventCursorNotNil() predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotNull(FieldEventCursor))
+}
+
+// LastSyncAtEQ applies the EQ predicate on the "last_sync_at" field.
+func LastSyncAtEQ(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldLastSyncAt, v))
+}
+
+// LastSyncAtNEQ applies the NEQ predicate on the "last_sync_at" field.
+func LastSyncAtNEQ(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldLastSyncAt, v))
+}
+
+// LastSyncAtIn applies the In predicate on the "last_sync_at" field.
+func LastSyncAtIn(vs ...time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIn(FieldLastSyncAt, vs...))
+}
+
+// LastSyncAtNotIn applies the NotIn predicate on the "last_sync_at" field.
+func LastSyncAtNotIn(vs ...time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotIn(FieldLastSyncAt, vs...))
+}
+
+// LastSyncAtGT applies the GT predicate on the "last_sync_at" field.
+func LastSyncAtGT(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGT(FieldLastSyncAt, v))
+}
+
+// LastSyncAtGTE applies the GTE predicate on the "last_sync_at" field.
+func LastSyncAtGTE(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGTE(FieldLastSyncAt, v))
+}
+
+// LastSyncAtLT applies the LT predicate on the "last_sync_at" field.
+func LastSyncAtLT(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLT(FieldLastSyncAt, v))
+}
+
+// LastSyncAtLTE applies the LTE predicate on the "last_sync_at" field.
+func LastSyncAtLTE(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLTE(FieldLastSyncAt, v))
+}
+
+// LastSyncAtIsNil applies the IsNil predicate on the "last_sync_at" field.
+func LastSyncAtIsNil() predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIsNull(FieldLastSyncAt))
+}
+
+// LastSyncAtNotNil applies the NotNil predicate on the "last_sync_at" field.
+func LastSyncAtNotNil() predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotNull(FieldLastSyncAt))
+}
+
+// LastFullSyncAtEQ applies the EQ predicate on the "last_full_sync_at" field.
+func LastFullSyncAtEQ(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldLastFullSyncAt, v))
+}
+
+// LastFullSyncAtNEQ applies the NEQ predicate on the "last_full_sync_at" field.
+func LastFullSyncAtNEQ(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldLastFullSyncAt, v))
+}
+
+// LastFullSyncAtIn applies the In predicate on the "last_full_sync_at" field.
+func LastFullSyncAtIn(vs ...time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIn(FieldLastFullSyncAt, vs...))
+}
+
+// LastFullSyncAtNotIn applies the NotIn predicate on the "last_full_sync_at" field.
+func LastFullSyncAtNotIn(vs ...time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotIn(FieldLastFullSyncAt, vs...))
+}
+
+// LastFullSyncAtGT applies the GT predicate on the "last_full_sync_at" field.
+func LastFullSyncAtGT(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGT(FieldLastFullSyncAt, v))
+}
+
+// LastFullSyncAtGTE applies the GTE predicate on the "last_full_sync_at" field.
+func LastFullSyncAtGTE(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGTE(FieldLastFullSyncAt, v))
+}
+
+// LastFullSyncAtLT applies the LT predicate on the "last_full_sync_at" field.
+func LastFullSyncAtLT(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLT(FieldLastFullSyncAt, v))
+}
+
+// LastFullSyncAtLTE applies the LTE predicate on the "last_full_sync_at" field.
+func LastFullSyncAtLTE(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLTE(FieldLastFullSyncAt, v))
+}
+
+// LastFullSyncAtIsNil applies the IsNil predicate on the "last_full_sync_at" field.
+func LastFullSyncAtIsNil() predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIsNull(FieldLastFullSyncAt))
+}
+
+// LastFullSyncAtNotNil applies the NotNil predicate on the "last_full_sync_at" field.
+func LastFullSyncAtNotNil() predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotNull(FieldLastFullSyncAt))
+}
+
+// LastErrorEQ applies the EQ predicate on the "last_error" field.
+func LastErrorEQ(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldLastError, v))
+}
+
+// LastErrorNEQ applies the NEQ predicate on the "last_error" field.
+func LastErrorNEQ(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldLastError, v))
+}
+
+// LastErrorIn applies the In predicate on the "last_error" field.
+func LastErrorIn(vs ...string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIn(FieldLastError, vs...))
+}
+
+// LastErrorNotIn applies the NotIn predicate on the "last_error" field.
+func LastErrorNotIn(vs ...string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotIn(FieldLastError, vs...))
+}
+
+// LastErrorGT applies the GT predicate on the "last_error" field.
+func LastErrorGT(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGT(FieldLastError, v))
+}
+
+// LastErrorGTE applies the GTE predicate on the "last_error" field.
+func LastErrorGTE(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGTE(FieldLastError, v))
+}
+
+// LastErrorLT applies the LT predicate on the "last_error" field.
+func LastErrorLT(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLT(FieldLastError, v))
+}
+
+// LastErrorLTE applies the LTE predicate on the "last_error" field.
+func LastErrorLTE(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLTE(FieldLastError, v))
+}
+
+// LastErrorContains applies the Contains predicate on the "last_error" field.
+func LastErrorContains(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldContains(FieldLastError, v))
+}
+
+// LastErrorHasPrefix applies the HasPrefix predicate on the "last_error" field.
+func LastErrorHasPrefix(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldHasPrefix(FieldLastError, v))
+}
+
+// LastErrorHasSuffix applies the HasSuffix predicate on the "last_error" field.
+func LastErrorHasSuffix(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldHasSuffix(FieldLastError, v))
+}
+
+// LastErrorEqualFold applies the EqualFold predicate on the "last_error" field.
+func LastErrorEqualFold(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEqualFold(FieldLastError, v))
+}
+
+// LastErrorContainsFold applies the ContainsFold predicate on the "last_error" field.
+func LastErrorContainsFold(v string) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldContainsFold(FieldLastError, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// HasObjects applies the HasEdge predicate on the "objects" edge.
+func HasObjects() predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, ObjectsTable, ObjectsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasObjectsWith applies the HasEdge predicate on the "objects" edge with a given conditions (other predicates).
+func HasObjectsWith(preds ...predicate.ProvisionedObject) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(func(s *sql.Selector) {
+		step := newObjectsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ProvisioningConnector) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ProvisioningConnector) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ProvisioningConnector) predicate.ProvisioningConnector {
+	return predicate.ProvisioningConnector(sql.NotPredicates(p))
+}
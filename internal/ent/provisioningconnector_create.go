@@ -0,0 +1,501 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/provisionedobject"
+	"github.com/qinzj/claude-demo/internal/ent/provisioningconnector"
+)
+
+// ProvisioningConnectorCreate is the builder for creating a ProvisioningConnector entity.
+type ProvisioningConnectorCreate struct {
+	config
+	mutation *ProvisioningConnectorMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (_c *ProvisioningConnectorCreate) SetName(v string) *ProvisioningConnectorCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetURL sets the "url" field.
+func (_c *ProvisioningConnectorCreate) SetURL(v string) *ProvisioningConnectorCreate {
+	_c.mutation.SetURL(v)
+	return _c
+}
+
+// SetToken sets the "token" field.
+func (_c *ProvisioningConnectorCreate) SetToken(v string) *ProvisioningConnectorCreate {
+	_c.mutation.SetToken(v)
+	return _c
+}
+
+// SetNillableToken sets the "token" field if the given value is not nil.
+func (_c *ProvisioningConnectorCreate) SetNillableToken(v *string) *ProvisioningConnectorCreate {
+	if v != nil {
+		_c.SetToken(*v)
+	}
+	return _c
+}
+
+// SetScopeGroupID sets the "scope_group_id" field.
+func (_c *ProvisioningConnectorCreate) SetScopeGroupID(v uuid.UUID) *ProvisioningConnectorCreate {
+	_c.mutation.SetScopeGroupID(v)
+	return _c
+}
+
+// SetNillableScopeGroupID sets the "scope_group_id" field if the given value is not nil.
+func (_c *ProvisioningConnectorCreate) SetNillableScopeGroupID(v *uuid.UUID) *ProvisioningConnectorCreate {
+	if v != nil {
+		_c.SetScopeGroupID(*v)
+	}
+	return _c
+}
+
+// SetSyncGroups sets the "sync_groups" field.
+func (_c *ProvisioningConnectorCreate) SetSyncGroups(v bool) *ProvisioningConnectorCreate {
+	_c.mutation.SetSyncGroups(v)
+	return _c
+}
+
+// SetNillableSyncGroups sets the "sync_groups" field if the given value is not nil.
+func (_c *ProvisioningConnectorCreate) SetNillableSyncGroups(v *bool) *ProvisioningConnectorCreate {
+	if v != nil {
+		_c.SetSyncGroups(*v)
+	}
+	return _c
+}
+
+// SetAttributeMapping sets the "attribute_mapping" field.
+func (_c *ProvisioningConnectorCreate) SetAttributeMapping(v map[string]string) *ProvisioningConnectorCreate {
+	_c.mutation.SetAttributeMapping(v)
+	return _c
+}
+
+// SetEnabled sets the "enabled" field.
+func (_c *ProvisioningConnectorCreate) SetEnabled(v bool) *ProvisioningConnectorCreate {
+	_c.mutation.SetEnabled(v)
+	return _c
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (_c *ProvisioningConnectorCreate) SetNillableEnabled(v *bool) *ProvisioningConnectorCreate {
+	if v != nil {
+		_c.SetEnabled(*v)
+	}
+	return _c
+}
+
+// SetEventCursor sets the "event_cursor" field.
+func (_c *ProvisioningConnectorCreate) SetEventCursor(v time.Time) *ProvisioningConnectorCreate {
+	_c.mutation.SetEventCursor(v)
+	return _c
+}
+
+// SetNillableEventCursor sets the "event_cursor" field if the given value is not nil.
+func (_c *ProvisioningConnectorCreate) SetNillableEventCursor(v *time.Time) *ProvisioningConnectorCreate {
+	if v != nil {
+		_c.SetEventCursor(*v)
+	}
+	return _c
+}
+
+// SetLastSyncAt sets the "last_sync_at" field.
+func (_c *ProvisioningConnectorCreate) SetLastSyncAt(v time.Time) *ProvisioningConnectorCreate {
+	_c.mutation.SetLastSyncAt(v)
+	return _c
+}
+
+// SetNillableLastSyncAt sets the "last_sync_at" field if the given value is not nil.
+func (_c *ProvisioningConnectorCreate) SetNillableLastSyncAt(v *time.Time) *ProvisioningConnectorCreate {
+	if v != nil {
+		_c.SetLastSyncAt(*v)
+	}
+	return _c
+}
+
+// SetLastFullSyncAt sets the "last_full_sync_at" field.
+func (_c *ProvisioningConnectorCreate) SetLastFullSyncAt(v time.Time) *ProvisioningConnectorCreate {
+	_c.mutation.SetLastFullSyncAt(v)
+	return _c
+}
+
+// SetNillableLastFullSyncAt sets the "last_full_sync_at" field if the given value is not nil.
+func (_c *ProvisioningConnectorCreate) SetNillableLastFullSyncAt(v *time.Time) *ProvisioningConnectorCreate {
+	if v != nil {
+		_c.SetLastFullSyncAt(*v)
+	}
+	return _c
+}
+
+// SetLastError sets the "last_error" field.
+func (_c *ProvisioningConnectorCreate) SetLastError(v string) *ProvisioningConnectorCreate {
+	_c.mutation.SetLastError(v)
+	return _c
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (_c *ProvisioningConnectorCreate) SetNillableLastError(v *string) *ProvisioningConnectorCreate {
+	if v != nil {
+		_c.SetLastError(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *ProvisioningConnectorCreate) SetCreatedAt(v time.Time) *ProvisioningConnectorCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *ProvisioningConnectorCreate) SetNillableCreatedAt(v *time.Time) *ProvisioningConnectorCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *ProvisioningConnectorCreate) SetUpdatedAt(v time.Time) *ProvisioningConnectorCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *ProvisioningConnectorCreate) SetNillableUpdatedAt(v *time.Time) *ProvisioningConnectorCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *ProvisioningConnectorCreate) SetID(v uuid.UUID) *ProvisioningConnectorCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *ProvisioningConnectorCreate) SetNillableID(v *uuid.UUID) *ProvisioningConnectorCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// AddObjectIDs adds the "objects" edge to the ProvisionedObject entity by IDs.
+func (_c *ProvisioningConnectorCreate) AddObjectIDs(ids ...uuid.UUID) *ProvisioningConnectorCreate {
+	_c.mutation.AddObjectIDs(ids...)
+	return _c
+}
+
+// AddObjects adds the "objects" edges to the ProvisionedObject entity.
+func (_c *ProvisioningConnectorCreate) AddObjects(v ...*ProvisionedObject) *ProvisioningConnectorCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddObjectIDs(ids...)
+}
+
+// Mutation returns the ProvisioningConnectorMutation object of the builder.
+func (_c *ProvisioningConnectorCreate) Mutation() *ProvisioningConnectorMutation {
+	return _c.mutation
+}
+
+// Save creates the ProvisioningConnector in the database.
+func (_c *ProvisioningConnectorCreate) Save(ctx context.Context) (*ProvisioningConnector, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *ProvisioningConnectorCreate) SaveX(ctx context.Context) *ProvisioningConnector {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *ProvisioningConnectorCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *ProvisioningConnectorCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *ProvisioningConnectorCreate) defaults() {
+	if _, ok := _c.mutation.Token(); !ok {
+		v := provisioningconnector.DefaultToken
+		_c.mutation.SetToken(v)
+	}
+	if _, ok := _c.mutation.SyncGroups(); !ok {
+		v := provisioningconnector.DefaultSyncGroups
+		_c.mutation.SetSyncGroups(v)
+	}
+	if _, ok := _c.mutation.Enabled(); !ok {
+		v := provisioningconnector.DefaultEnabled
+		_c.mutation.SetEnabled(v)
+	}
+	if _, ok := _c.mutation.LastError(); !ok {
+		v := provisioningconnector.DefaultLastError
+		_c.mutation.SetLastError(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := provisioningconnector.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := provisioningconnector.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := provisioningconnector.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *ProvisioningConnectorCreate) check() error {
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "ProvisioningConnector.name"`)}
+	}
+	if v, ok := _c.mutation.Name(); ok {
+		if err := provisioningconnector.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ProvisioningConnector.name": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.URL(); !ok {
+		return &ValidationError{Name: "url", err: errors.New(`ent: missing required field "ProvisioningConnector.url"`)}
+	}
+	if v, ok := _c.mutation.URL(); ok {
+		if err := provisioningconnector.URLValidator(v); err != nil {
+			return &ValidationError{Name: "url", err: fmt.Errorf(`ent: validator failed for field "ProvisioningConnector.url": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Token(); !ok {
+		return &ValidationError{Name: "token", err: errors.New(`ent: missing required field "ProvisioningConnector.token"`)}
+	}
+	if _, ok := _c.mutation.SyncGroups(); !ok {
+		return &ValidationError{Name: "sync_groups", err: errors.New(`ent: missing required field "ProvisioningConnector.sync_groups"`)}
+	}
+	if _, ok := _c.mutation.Enabled(); !ok {
+		return &ValidationError{Name: "enabled", err: errors.New(`ent: missing required field "ProvisioningConnector.enabled"`)}
+	}
+	if _, ok := _c.mutation.LastError(); !ok {
+		return &ValidationError{Name: "last_error", err: errors.New(`ent: missing required field "ProvisioningConnector.last_error"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ProvisioningConnector.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "ProvisioningConnector.updated_at"`)}
+	}
+	return nil
+}
+
+func (_c *ProvisioningConnectorCreate) sqlSave(ctx context.Context) (*ProvisioningConnector, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *ProvisioningConnectorCreate) createSpec() (*ProvisioningConnector, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ProvisioningConnector{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(provisioningconnector.Table, sqlgraph.NewFieldSpec(provisioningconnector.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(provisioningconnector.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.URL(); ok {
+		_spec.SetField(provisioningconnector.FieldURL, field.TypeString, value)
+		_node.URL = value
+	}
+	if value, ok := _c.mutation.Token(); ok {
+		_spec.SetField(provisioningconnector.FieldToken, field.TypeString, value)
+		_node.Token = value
+	}
+	if value, ok := _c.mutation.ScopeGroupID(); ok {
+		_spec.SetField(provisioningconnector.FieldScopeGroupID, field.TypeUUID, value)
+		_node.ScopeGroupID = &value
+	}
+	if value, ok := _c.mutation.SyncGroups(); ok {
+		_spec.SetField(provisioningconnector.FieldSyncGroups, field.TypeBool, value)
+		_node.SyncGroups = value
+	}
+	if value, ok := _c.mutation.AttributeMapping(); ok {
+		_spec.SetField(provisioningconnector.FieldAttributeMapping, field.TypeJSON, value)
+		_node.AttributeMapping = value
+	}
+	if value, ok := _c.mutation.Enabled(); ok {
+		_spec.SetField(provisioningconnector.FieldEnabled, field.TypeBool, value)
+		_node.Enabled = value
+	}
+	if value, ok := _c.mutation.EventCursor(); ok {
+		_spec.SetField(provisioningconnector.FieldEventCursor, field.TypeTime, value)
+		_node.EventCursor = &value
+	}
+	if value, ok := _c.mutation.LastSyncAt(); ok {
+		_spec.SetField(provisioningconnector.FieldLastSyncAt, field.TypeTime, value)
+		_node.LastSyncAt = &value
+	}
+	if value, ok := _c.mutation.LastFullSyncAt(); ok {
+		_spec.SetField(provisioningconnector.FieldLastFullSyncAt, field.TypeTime, value)
+		_node.LastFullSyncAt = &value
+	}
+	if value, ok := _c.mutation.LastError(); ok {
+		_spec.SetField(provisioningconnector.FieldLastError, field.TypeString, value)
+		_node.LastError = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(provisioningconnector.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(provisioningconnector.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := _c.mutation.ObjectsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   provisioningconnector.ObjectsTable,
+			Columns: []string{provisioningconnector.ObjectsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisionedobject.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ProvisioningConnectorCreateBulk is the builder for creating many ProvisioningConnector entities in bulk.
+type ProvisioningConnectorCreateBulk struct {
+	config
+	err      error
+	builders []*ProvisioningConnectorCreate
+}
+
+// Save creates the ProvisioningConnector entities in the database.
+func (_c *ProvisioningConnectorCreateBulk) Save(ctx context.Context) ([]*ProvisioningConnector, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*ProvisioningConnector, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ProvisioningConnectorMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *ProvisioningConnectorCreateBulk) SaveX(ctx context.Context) []*ProvisioningConnector {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *ProvisioningConnectorCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *ProvisioningConnectorCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,860 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+	"github.com/qinzj/claude-demo/internal/ent/provisionedobject"
+	"github.com/qinzj/claude-demo/internal/ent/provisioningconnector"
+)
+
+// ProvisioningConnectorUpdate is the builder for updating ProvisioningConnector entities.
+type ProvisioningConnectorUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ProvisioningConnectorMutation
+}
+
+// Where appends a list predicates to the ProvisioningConnectorUpdate builder.
+func (_u *ProvisioningConnectorUpdate) Where(ps ...predicate.ProvisioningConnector) *ProvisioningConnectorUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *ProvisioningConnectorUpdate) SetName(v string) *ProvisioningConnectorUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdate) SetNillableName(v *string) *ProvisioningConnectorUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetURL sets the "url" field.
+func (_u *ProvisioningConnectorUpdate) SetURL(v string) *ProvisioningConnectorUpdate {
+	_u.mutation.SetURL(v)
+	return _u
+}
+
+// SetNillableURL sets the "url" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdate) SetNillableURL(v *string) *ProvisioningConnectorUpdate {
+	if v != nil {
+		_u.SetURL(*v)
+	}
+	return _u
+}
+
+// SetToken sets the "token" field.
+func (_u *ProvisioningConnectorUpdate) SetToken(v string) *ProvisioningConnectorUpdate {
+	_u.mutation.SetToken(v)
+	return _u
+}
+
+// SetNillableToken sets the "token" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdate) SetNillableToken(v *string) *ProvisioningConnectorUpdate {
+	if v != nil {
+		_u.SetToken(*v)
+	}
+	return _u
+}
+
+// SetScopeGroupID sets the "scope_group_id" field.
+func (_u *ProvisioningConnectorUpdate) SetScopeGroupID(v uuid.UUID) *ProvisioningConnectorUpdate {
+	_u.mutation.SetScopeGroupID(v)
+	return _u
+}
+
+// SetNillableScopeGroupID sets the "scope_group_id" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdate) SetNillableScopeGroupID(v *uuid.UUID) *ProvisioningConnectorUpdate {
+	if v != nil {
+		_u.SetScopeGroupID(*v)
+	}
+	return _u
+}
+
+// ClearScopeGroupID clears the value of the "scope_group_id" field.
+func (_u *ProvisioningConnectorUpdate) ClearScopeGroupID() *ProvisioningConnectorUpdate {
+	_u.mutation.ClearScopeGroupID()
+	return _u
+}
+
+// SetSyncGroups sets the "sync_groups" field.
+func (_u *ProvisioningConnectorUpdate) SetSyncGroups(v bool) *ProvisioningConnectorUpdate {
+	_u.mutation.SetSyncGroups(v)
+	return _u
+}
+
+// SetNillableSyncGroups sets the "sync_groups" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdate) SetNillableSyncGroups(v *bool) *ProvisioningConnectorUpdate {
+	if v != nil {
+		_u.SetSyncGroups(*v)
+	}
+	return _u
+}
+
+// SetAttributeMapping sets the "attribute_mapping" field.
+func (_u *ProvisioningConnectorUpdate) SetAttributeMapping(v map[string]string) *ProvisioningConnectorUpdate {
+	_u.mutation.SetAttributeMapping(v)
+	return _u
+}
+
+// ClearAttributeMapping clears the value of the "attribute_mapping" field.
+func (_u *ProvisioningConnectorUpdate) ClearAttributeMapping() *ProvisioningConnectorUpdate {
+	_u.mutation.ClearAttributeMapping()
+	return _u
+}
+
+// SetEnabled sets the "enabled" field.
+func (_u *ProvisioningConnectorUpdate) SetEnabled(v bool) *ProvisioningConnectorUpdate {
+	_u.mutation.SetEnabled(v)
+	return _u
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdate) SetNillableEnabled(v *bool) *ProvisioningConnectorUpdate {
+	if v != nil {
+		_u.SetEnabled(*v)
+	}
+	return _u
+}
+
+// SetEventCursor sets the "event_cursor" field.
+func (_u *ProvisioningConnectorUpdate) SetEventCursor(v time.Time) *ProvisioningConnectorUpdate {
+	_u.mutation.SetEventCursor(v)
+	return _u
+}
+
+// SetNillableEventCursor sets the "event_cursor" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdate) SetNillableEventCursor(v *time.Time) *ProvisioningConnectorUpdate {
+	if v != nil {
+		_u.SetEventCursor(*v)
+	}
+	return _u
+}
+
+// ClearEventCursor clears the value of the "event_cursor" field.
+func (_u *ProvisioningConnectorUpdate) ClearEventCursor() *ProvisioningConnectorUpdate {
+	_u.mutation.ClearEventCursor()
+	return _u
+}
+
+// SetLastSyncAt sets the "last_sync_at" field.
+func (_u *ProvisioningConnectorUpdate) SetLastSyncAt(v time.Time) *ProvisioningConnectorUpdate {
+	_u.mutation.SetLastSyncAt(v)
+	return _u
+}
+
+// SetNillableLastSyncAt sets the "last_sync_at" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdate) SetNillableLastSyncAt(v *time.Time) *ProvisioningConnectorUpdate {
+	if v != nil {
+		_u.SetLastSyncAt(*v)
+	}
+	return _u
+}
+
+// ClearLastSyncAt clears the value of the "last_sync_at" field.
+func (_u *ProvisioningConnectorUpdate) ClearLastSyncAt() *ProvisioningConnectorUpdate {
+	_u.mutation.ClearLastSyncAt()
+	return _u
+}
+
+// SetLastFullSyncAt sets the "last_full_sync_at" field.
+func (_u *ProvisioningConnectorUpdate) SetLastFullSyncAt(v time.Time) *ProvisioningConnectorUpdate {
+	_u.mutation.SetLastFullSyncAt(v)
+	return _u
+}
+
+// SetNillableLastFullSyncAt sets the "last_full_sync_at" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdate) SetNillableLastFullSyncAt(v *time.Time) *ProvisioningConnectorUpdate {
+	if v != nil {
+		_u.SetLastFullSyncAt(*v)
+	}
+	return _u
+}
+
+// ClearLastFullSyncAt clears the value of the "last_full_sync_at" field.
+func (_u *ProvisioningConnectorUpdate) ClearLastFullSyncAt() *ProvisioningConnectorUpdate {
+	_u.mutation.ClearLastFullSyncAt()
+	return _u
+}
+
+// SetLastError sets the "last_error" field.
+func (_u *ProvisioningConnectorUpdate) SetLastError(v string) *ProvisioningConnectorUpdate {
+	_u.mutation.SetLastError(v)
+	return _u
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdate) SetNillableLastError(v *string) *ProvisioningConnectorUpdate {
+	if v != nil {
+		_u.SetLastError(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *ProvisioningConnectorUpdate) SetUpdatedAt(v time.Time) *ProvisioningConnectorUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddObjectIDs adds the "objects" edge to the ProvisionedObject entity by IDs.
+func (_u *ProvisioningConnectorUpdate) AddObjectIDs(ids ...uuid.UUID) *ProvisioningConnectorUpdate {
+	_u.mutation.AddObjectIDs(ids...)
+	return _u
+}
+
+// AddObjects adds the "objects" edges to the ProvisionedObject entity.
+func (_u *ProvisioningConnectorUpdate) AddObjects(v ...*ProvisionedObject) *ProvisioningConnectorUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddObjectIDs(ids...)
+}
+
+// Mutation returns the ProvisioningConnectorMutation object of the builder.
+func (_u *ProvisioningConnectorUpdate) Mutation() *ProvisioningConnectorMutation {
+	return _u.mutation
+}
+
+// ClearObjects clears all "objects" edges to the ProvisionedObject entity.
+func (_u *ProvisioningConnectorUpdate) ClearObjects() *ProvisioningConnectorUpdate {
+	_u.mutation.ClearObjects()
+	return _u
+}
+
+// RemoveObjectIDs removes the "objects" edge to ProvisionedObject entities by IDs.
+func (_u *ProvisioningConnectorUpdate) RemoveObjectIDs(ids ...uuid.UUID) *ProvisioningConnectorUpdate {
+	_u.mutation.RemoveObjectIDs(ids...)
+	return _u
+}
+
+// RemoveObjects removes "objects" edges to ProvisionedObject entities.
+func (_u *ProvisioningConnectorUpdate) RemoveObjects(v ...*ProvisionedObject) *ProvisioningConnectorUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveObjectIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *ProvisioningConnectorUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ProvisioningConnectorUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *ProvisioningConnectorUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ProvisioningConnectorUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *ProvisioningConnectorUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := provisioningconnector.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *ProvisioningConnectorUpdate) check() error {
+	if v, ok := _u.mutation.Name(); ok {
+		if err := provisioningconnector.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ProvisioningConnector.name": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.URL(); ok {
+		if err := provisioningconnector.URLValidator(v); err != nil {
+			return &ValidationError{Name: "url", err: fmt.Errorf(`ent: validator failed for field "ProvisioningConnector.url": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *ProvisioningConnectorUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(provisioningconnector.Table, provisioningconnector.Columns, sqlgraph.NewFieldSpec(provisioningconnector.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(provisioningconnector.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.URL(); ok {
+		_spec.SetField(provisioningconnector.FieldURL, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Token(); ok {
+		_spec.SetField(provisioningconnector.FieldToken, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ScopeGroupID(); ok {
+		_spec.SetField(provisioningconnector.FieldScopeGroupID, field.TypeUUID, value)
+	}
+	if _u.mutation.ScopeGroupIDCleared() {
+		_spec.ClearField(provisioningconnector.FieldScopeGroupID, field.TypeUUID)
+	}
+	if value, ok := _u.mutation.SyncGroups(); ok {
+		_spec.SetField(provisioningconnector.FieldSyncGroups, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.AttributeMapping(); ok {
+		_spec.SetField(provisioningconnector.FieldAttributeMapping, field.TypeJSON, value)
+	}
+	if _u.mutation.AttributeMappingCleared() {
+		_spec.ClearField(provisioningconnector.FieldAttributeMapping, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Enabled(); ok {
+		_spec.SetField(provisioningconnector.FieldEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.EventCursor(); ok {
+		_spec.SetField(provisioningconnector.FieldEventCursor, field.TypeTime, value)
+	}
+	if _u.mutation.EventCursorCleared() {
+		_spec.ClearField(provisioningconnector.FieldEventCursor, field.TypeTime)
+	}
+	if value, ok := _u.mutation.LastSyncAt(); ok {
+		_spec.SetField(provisioningconnector.FieldLastSyncAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastSyncAtCleared() {
+		_spec.ClearField(provisioningconnector.FieldLastSyncAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.LastFullSyncAt(); ok {
+		_spec.SetField(provisioningconnector.FieldLastFullSyncAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastFullSyncAtCleared() {
+		_spec.ClearField(provisioningconnector.FieldLastFullSyncAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.LastError(); ok {
+		_spec.SetField(provisioningconnector.FieldLastError, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(provisioningconnector.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ObjectsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   provisioningconnector.ObjectsTable,
+			Columns: []string{provisioningconnector.ObjectsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisionedobject.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedObjectsIDs(); len(nodes) > 0 && !_u.mutation.ObjectsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   provisioningconnector.ObjectsTable,
+			Columns: []string{provisioningconnector.ObjectsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisionedobject.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ObjectsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   provisioningconnector.ObjectsTable,
+			Columns: []string{provisioningconnector.ObjectsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisionedobject.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{provisioningconnector.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// ProvisioningConnectorUpdateOne is the builder for updating a single ProvisioningConnector entity.
+type ProvisioningConnectorUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ProvisioningConnectorMutation
+}
+
+// SetName sets the "name" field.
+func (_u *ProvisioningConnectorUpdateOne) SetName(v string) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdateOne) SetNillableName(v *string) *ProvisioningConnectorUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetURL sets the "url" field.
+func (_u *ProvisioningConnectorUpdateOne) SetURL(v string) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetURL(v)
+	return _u
+}
+
+// SetNillableURL sets the "url" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdateOne) SetNillableURL(v *string) *ProvisioningConnectorUpdateOne {
+	if v != nil {
+		_u.SetURL(*v)
+	}
+	return _u
+}
+
+// SetToken sets the "token" field.
+func (_u *ProvisioningConnectorUpdateOne) SetToken(v string) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetToken(v)
+	return _u
+}
+
+// SetNillableToken sets the "token" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdateOne) SetNillableToken(v *string) *ProvisioningConnectorUpdateOne {
+	if v != nil {
+		_u.SetToken(*v)
+	}
+	return _u
+}
+
+// SetScopeGroupID sets the "scope_group_id" field.
+func (_u *ProvisioningConnectorUpdateOne) SetScopeGroupID(v uuid.UUID) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetScopeGroupID(v)
+	return _u
+}
+
+// SetNillableScopeGroupID sets the "scope_group_id" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdateOne) SetNillableScopeGroupID(v *uuid.UUID) *ProvisioningConnectorUpdateOne {
+	if v != nil {
+		_u.SetScopeGroupID(*v)
+	}
+	return _u
+}
+
+// ClearScopeGroupID clears the value of the "scope_group_id" field.
+func (_u *ProvisioningConnectorUpdateOne) ClearScopeGroupID() *ProvisioningConnectorUpdateOne {
+	_u.mutation.ClearScopeGroupID()
+	return _u
+}
+
+// SetSyncGroups sets the "sync_groups" field.
+func (_u *ProvisioningConnectorUpdateOne) SetSyncGroups(v bool) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetSyncGroups(v)
+	return _u
+}
+
+// SetNillableSyncGroups sets the "sync_groups" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdateOne) SetNillableSyncGroups(v *bool) *ProvisioningConnectorUpdateOne {
+	if v != nil {
+		_u.SetSyncGroups(*v)
+	}
+	return _u
+}
+
+// SetAttributeMapping sets the "attribute_mapping" field.
+func (_u *ProvisioningConnectorUpdateOne) SetAttributeMapping(v map[string]string) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetAttributeMapping(v)
+	return _u
+}
+
+// ClearAttributeMapping clears the value of the "attribute_mapping" field.
+func (_u *ProvisioningConnectorUpdateOne) ClearAttributeMapping() *ProvisioningConnectorUpdateOne {
+	_u.mutation.ClearAttributeMapping()
+	return _u
+}
+
+// SetEnabled sets the "enabled" field.
+func (_u *ProvisioningConnectorUpdateOne) SetEnabled(v bool) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetEnabled(v)
+	return _u
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdateOne) SetNillableEnabled(v *bool) *ProvisioningConnectorUpdateOne {
+	if v != nil {
+		_u.SetEnabled(*v)
+	}
+	return _u
+}
+
+// SetEventCursor sets the "event_cursor" field.
+func (_u *ProvisioningConnectorUpdateOne) SetEventCursor(v time.Time) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetEventCursor(v)
+	return _u
+}
+
+// SetNillableEventCursor sets the "event_cursor" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdateOne) SetNillableEventCursor(v *time.Time) *ProvisioningConnectorUpdateOne {
+	if v != nil {
+		_u.SetEventCursor(*v)
+	}
+	return _u
+}
+
+// ClearEventCursor clears the value of the "event_cursor" field.
+func (_u *ProvisioningConnectorUpdateOne) ClearEventCursor() *ProvisioningConnectorUpdateOne {
+	_u.mutation.ClearEventCursor()
+	return _u
+}
+
+// SetLastSyncAt sets the "last_sync_at" field.
+func (_u *ProvisioningConnectorUpdateOne) SetLastSyncAt(v time.Time) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetLastSyncAt(v)
+	return _u
+}
+
+// SetNillableLastSyncAt sets the "last_sync_at" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdateOne) SetNillableLastSyncAt(v *time.Time) *ProvisioningConnectorUpdateOne {
+	if v != nil {
+		_u.SetLastSyncAt(*v)
+	}
+	return _u
+}
+
+// ClearLastSyncAt clears the value of the "last_sync_at" field.
+func (_u *ProvisioningConnectorUpdateOne) ClearLastSyncAt() *ProvisioningConnectorUpdateOne {
+	_u.mutation.ClearLastSyncAt()
+	return _u
+}
+
+// SetLastFullSyncAt sets the "last_full_sync_at" field.
+func (_u *ProvisioningConnectorUpdateOne) SetLastFullSyncAt(v time.Time) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetLastFullSyncAt(v)
+	return _u
+}
+
+// SetNillableLastFullSyncAt sets the "last_full_sync_at" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdateOne) SetNillableLastFullSyncAt(v *time.Time) *ProvisioningConnectorUpdateOne {
+	if v != nil {
+		_u.SetLastFullSyncAt(*v)
+	}
+	return _u
+}
+
+// ClearLastFullSyncAt clears the value of the "last_full_sync_at" field.
+func (_u *ProvisioningConnectorUpdateOne) ClearLastFullSyncAt() *ProvisioningConnectorUpdateOne {
+	_u.mutation.ClearLastFullSyncAt()
+	return _u
+}
+
+// SetLastError sets the "last_error" field.
+func (_u *ProvisioningConnectorUpdateOne) SetLastError(v string) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetLastError(v)
+	return _u
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (_u *ProvisioningConnectorUpdateOne) SetNillableLastError(v *string) *ProvisioningConnectorUpdateOne {
+	if v != nil {
+		_u.SetLastError(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *ProvisioningConnectorUpdateOne) SetUpdatedAt(v time.Time) *ProvisioningConnectorUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddObjectIDs adds the "objects" edge to the ProvisionedObject entity by IDs.
+func (_u *ProvisioningConnectorUpdateOne) AddObjectIDs(ids ...uuid.UUID) *ProvisioningConnectorUpdateOne {
+	_u.mutation.AddObjectIDs(ids...)
+	return _u
+}
+
+// AddObjects adds the "objects" edges to the ProvisionedObject entity.
+func (_u *ProvisioningConnectorUpdateOne) AddObjects(v ...*ProvisionedObject) *ProvisioningConnectorUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddObjectIDs(ids...)
+}
+
+// Mutation returns the ProvisioningConnectorMutation object of the builder.
+func (_u *ProvisioningConnectorUpdateOne) Mutation() *ProvisioningConnectorMutation {
+	return _u.mutation
+}
+
+// ClearObjects clears all "objects" edges to the ProvisionedObject entity.
+func (_u *ProvisioningConnectorUpdateOne) ClearObjects() *ProvisioningConnectorUpdateOne {
+	_u.mutation.ClearObjects()
+	return _u
+}
+
+// RemoveObjectIDs removes the "objects" edge to ProvisionedObject entities by IDs.
+func (_u *ProvisioningConnectorUpdateOne) RemoveObjectIDs(ids ...uuid.UUID) *ProvisioningConnectorUpdateOne {
+	_u.mutation.RemoveObjectIDs(ids...)
+	return _u
+}
+
+// RemoveObjects removes "objects" edges to ProvisionedObject entities.
+func (_u *ProvisioningConnectorUpdateOne) RemoveObjects(v ...*ProvisionedObject) *ProvisioningConnectorUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveObjectIDs(ids...)
+}
+
+// Where appends a list predicates to the ProvisioningConnectorUpdate builder.
+func (_u *ProvisioningConnectorUpdateOne) Where(ps ...predicate.ProvisioningConnector) *ProvisioningConnectorUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *ProvisioningConnectorUpdateOne) Select(field string, fields ...string) *ProvisioningConnectorUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated ProvisioningConnector entity.
+func (_u *ProvisioningConnectorUpdateOne) Save(ctx context.Context) (*ProvisioningConnector, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ProvisioningConnectorUpdateOne) SaveX(ctx context.Context) *ProvisioningConnector {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *ProvisioningConnectorUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ProvisioningConnectorUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *ProvisioningConnectorUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := provisioningconnector.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *ProvisioningConnectorUpdateOne) check() error {
+	if v, ok := _u.mutation.Name(); ok {
+		if err := provisioningconnector.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ProvisioningConnector.name": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.URL(); ok {
+		if err := provisioningconnector.URLValidator(v); err != nil {
+			return &ValidationError{Name: "url", err: fmt.Errorf(`ent: validator failed for field "ProvisioningConnector.url": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *ProvisioningConnectorUpdateOne) sqlSave(ctx context.Context) (_node *ProvisioningConnector, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(provisioningconnector.Table, provisioningconnector.Columns, sqlgraph.NewFieldSpec(provisioningconnector.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ProvisioningConnector.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, provisioningconnector.FieldID)
+		for _, f := range fields {
+			if !provisioningconnector.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != provisioningconnector.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(provisioningconnector.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.URL(); ok {
+		_spec.SetField(provisioningconnector.FieldURL, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Token(); ok {
+		_spec.SetField(provisioningconnector.FieldToken, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ScopeGroupID(); ok {
+		_spec.SetField(provisioningconnector.FieldScopeGroupID, field.TypeUUID, value)
+	}
+	if _u.mutation.ScopeGroupIDCleared() {
+		_spec.ClearField(provisioningconnector.FieldScopeGroupID, field.TypeUUID)
+	}
+	if value, ok := _u.mutation.SyncGroups(); ok {
+		_spec.SetField(provisioningconnector.FieldSyncGroups, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.AttributeMapping(); ok {
+		_spec.SetField(provisioningconnector.FieldAttributeMapping, field.TypeJSON, value)
+	}
+	if _u.mutation.AttributeMappingCleared() {
+		_spec.ClearField(provisioningconnector.FieldAttributeMapping, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Enabled(); ok {
+		_spec.SetField(provisioningconnector.FieldEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.EventCursor(); ok {
+		_spec.SetField(provisioningconnector.FieldEventCursor, field.TypeTime, value)
+	}
+	if _u.mutation.EventCursorCleared() {
+		_spec.ClearField(provisioningconnector.FieldEventCursor, field.TypeTime)
+	}
+	if value, ok := _u.mutation.LastSyncAt(); ok {
+		_spec.SetField(provisioningconnector.FieldLastSyncAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastSyncAtCleared() {
+		_spec.ClearField(provisioningconnector.FieldLastSyncAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.LastFullSyncAt(); ok {
+		_spec.SetField(provisioningconnector.FieldLastFullSyncAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastFullSyncAtCleared() {
+		_spec.ClearField(provisioningconnector.FieldLastFullSyncAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.LastError(); ok {
+		_spec.SetField(provisioningconnector.FieldLastError, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(provisioningconnector.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ObjectsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   provisioningconnector.ObjectsTable,
+			Columns: []string{provisioningconnector.ObjectsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisionedobject.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedObjectsIDs(); len(nodes) > 0 && !_u.mutation.ObjectsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   provisioningconnector.ObjectsTable,
+			Columns: []string{provisioningconnector.ObjectsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisionedobject.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ObjectsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   provisioningconnector.ObjectsTable,
+			Columns: []string{provisioningconnector.ObjectsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(provisionedobject.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &ProvisioningConnector{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{provisioningconnector.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -6,8 +6,35 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/apikey"
+	"github.com/qinzj/claude-demo/internal/ent/attributedefinition"
+	"github.com/qinzj/claude-demo/internal/ent/attributevalue"
+	"github.com/qinzj/claude-demo/internal/ent/auditevent"
+	"github.com/qinzj/claude-demo/internal/ent/authorizationcode"
+	"github.com/qinzj/claude-demo/internal/ent/directoryobject"
 	"github.com/qinzj/claude-demo/internal/ent/group"
+	"github.com/qinzj/claude-demo/internal/ent/groupmembershipchange"
+	"github.com/qinzj/claude-demo/internal/ent/groupversion"
+	"github.com/qinzj/claude-demo/internal/ent/invitation"
+	"github.com/qinzj/claude-demo/internal/ent/loginevent"
+	"github.com/qinzj/claude-demo/internal/ent/oidcclient"
+	"github.com/qinzj/claude-demo/internal/ent/oidcconsent"
+	"github.com/qinzj/claude-demo/internal/ent/outboxevent"
+	"github.com/qinzj/claude-demo/internal/ent/passwordhistory"
+	"github.com/qinzj/claude-demo/internal/ent/passwordpolicy"
+	"github.com/qinzj/claude-demo/internal/ent/passwordresettoken"
+	"github.com/qinzj/claude-demo/internal/ent/provisionedobject"
+	"github.com/qinzj/claude-demo/internal/ent/provisioningconnector"
+	"github.com/qinzj/claude-demo/internal/ent/recoverycode"
+	"github.com/qinzj/claude-demo/internal/ent/role"
+	"github.com/qinzj/claude-demo/internal/ent/serviceaccount"
+	"github.com/qinzj/claude-demo/internal/ent/session"
+	"github.com/qinzj/claude-demo/internal/ent/signingkey"
+	"github.com/qinzj/claude-demo/internal/ent/sshkey"
 	"github.com/qinzj/claude-demo/internal/ent/user"
+	"github.com/qinzj/claude-demo/internal/ent/userversion"
+	"github.com/qinzj/claude-demo/internal/ent/webhookdelivery"
+	"github.com/qinzj/claude-demo/internal/ent/webhooksubscription"
 	"github.com/qinzj/claude-demo/internal/schema"
 )
 
@@ -15,6 +42,242 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	apikeyFields := schema.APIKey{}.Fields()
+	_ = apikeyFields
+	// apikeyDescName is the schema descriptor for name field.
+	apikeyDescName := apikeyFields[1].Descriptor()
+	// apikey.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	apikey.NameValidator = func() func(string) error {
+		validators := apikeyDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// apikeyDescPrefix is the schema descriptor for prefix field.
+	apikeyDescPrefix := apikeyFields[2].Descriptor()
+	// apikey.PrefixValidator is a validator for the "prefix" field. It is called by the builders before save.
+	apikey.PrefixValidator = apikeyDescPrefix.Validators[0].(func(string) error)
+	// apikeyDescKeyHash is the schema descriptor for key_hash field.
+	apikeyDescKeyHash := apikeyFields[3].Descriptor()
+	// apikey.KeyHashValidator is a validator for the "key_hash" field. It is called by the builders before save.
+	apikey.KeyHashValidator = apikeyDescKeyHash.Validators[0].(func(string) error)
+	// apikeyDescCreatedAt is the schema descriptor for created_at field.
+	apikeyDescCreatedAt := apikeyFields[6].Descriptor()
+	// apikey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	apikey.DefaultCreatedAt = apikeyDescCreatedAt.Default.(func() time.Time)
+	// apikeyDescID is the schema descriptor for id field.
+	apikeyDescID := apikeyFields[0].Descriptor()
+	// apikey.DefaultID holds the default value on creation for the id field.
+	apikey.DefaultID = apikeyDescID.Default.(func() uuid.UUID)
+	attributedefinitionFields := schema.AttributeDefinition{}.Fields()
+	_ = attributedefinitionFields
+	// attributedefinitionDescName is the schema descriptor for name field.
+	attributedefinitionDescName := attributedefinitionFields[1].Descriptor()
+	// attributedefinition.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	attributedefinition.NameValidator = func() func(string) error {
+		validators := attributedefinitionDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// attributedefinitionDescMultiValued is the schema descriptor for multi_valued field.
+	attributedefinitionDescMultiValued := attributedefinitionFields[4].Descriptor()
+	// attributedefinition.DefaultMultiValued holds the default value on creation for the multi_valued field.
+	attributedefinition.DefaultMultiValued = attributedefinitionDescMultiValued.Default.(bool)
+	// attributedefinitionDescLdapName is the schema descriptor for ldap_name field.
+	attributedefinitionDescLdapName := attributedefinitionFields[5].Descriptor()
+	// attributedefinition.LdapNameValidator is a validator for the "ldap_name" field. It is called by the builders before save.
+	attributedefinition.LdapNameValidator = func() func(string) error {
+		validators := attributedefinitionDescLdapName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(ldap_name string) error {
+			for _, fn := range fns {
+				if err := fn(ldap_name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// attributedefinitionDescDescription is the schema descriptor for description field.
+	attributedefinitionDescDescription := attributedefinitionFields[6].Descriptor()
+	// attributedefinition.DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
+	attributedefinition.DescriptionValidator = attributedefinitionDescDescription.Validators[0].(func(string) error)
+	// attributedefinitionDescRequired is the schema descriptor for required field.
+	attributedefinitionDescRequired := attributedefinitionFields[7].Descriptor()
+	// attributedefinition.DefaultRequired holds the default value on creation for the required field.
+	attributedefinition.DefaultRequired = attributedefinitionDescRequired.Default.(bool)
+	// attributedefinitionDescIndexed is the schema descriptor for indexed field.
+	attributedefinitionDescIndexed := attributedefinitionFields[8].Descriptor()
+	// attributedefinition.DefaultIndexed holds the default value on creation for the indexed field.
+	attributedefinition.DefaultIndexed = attributedefinitionDescIndexed.Default.(bool)
+	// attributedefinitionDescCreatedAt is the schema descriptor for created_at field.
+	attributedefinitionDescCreatedAt := attributedefinitionFields[9].Descriptor()
+	// attributedefinition.DefaultCreatedAt holds the default value on creation for the created_at field.
+	attributedefinition.DefaultCreatedAt = attributedefinitionDescCreatedAt.Default.(func() time.Time)
+	// attributedefinitionDescUpdatedAt is the schema descriptor for updated_at field.
+	attributedefinitionDescUpdatedAt := attributedefinitionFields[10].Descriptor()
+	// attributedefinition.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	attributedefinition.DefaultUpdatedAt = attributedefinitionDescUpdatedAt.Default.(func() time.Time)
+	// attributedefinition.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	attributedefinition.UpdateDefaultUpdatedAt = attributedefinitionDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// attributedefinitionDescID is the schema descriptor for id field.
+	attributedefinitionDescID := attributedefinitionFields[0].Descriptor()
+	// attributedefinition.DefaultID holds the default value on creation for the id field.
+	attributedefinition.DefaultID = attributedefinitionDescID.Default.(func() uuid.UUID)
+	attributevalueFields := schema.AttributeValue{}.Fields()
+	_ = attributevalueFields
+	// attributevalueDescName is the schema descriptor for name field.
+	attributevalueDescName := attributevalueFields[1].Descriptor()
+	// attributevalue.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	attributevalue.NameValidator = func() func(string) error {
+		validators := attributevalueDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// attributevalueDescValue is the schema descriptor for value field.
+	attributevalueDescValue := attributevalueFields[2].Descriptor()
+	// attributevalue.ValueValidator is a validator for the "value" field. It is called by the builders before save.
+	attributevalue.ValueValidator = attributevalueDescValue.Validators[0].(func(string) error)
+	// attributevalueDescID is the schema descriptor for id field.
+	attributevalueDescID := attributevalueFields[0].Descriptor()
+	// attributevalue.DefaultID holds the default value on creation for the id field.
+	attributevalue.DefaultID = attributevalueDescID.Default.(func() uuid.UUID)
+	auditeventFields := schema.AuditEvent{}.Fields()
+	_ = auditeventFields
+	// auditeventDescCreatedAt is the schema descriptor for created_at field.
+	auditeventDescCreatedAt := auditeventFields[1].Descriptor()
+	// auditevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditevent.DefaultCreatedAt = auditeventDescCreatedAt.Default.(func() time.Time)
+	// auditeventDescReason is the schema descriptor for reason field.
+	auditeventDescReason := auditeventFields[4].Descriptor()
+	// auditevent.DefaultReason holds the default value on creation for the reason field.
+	auditevent.DefaultReason = auditeventDescReason.Default.(string)
+	// auditeventDescActorType is the schema descriptor for actor_type field.
+	auditeventDescActorType := auditeventFields[5].Descriptor()
+	// auditevent.DefaultActorType holds the default value on creation for the actor_type field.
+	auditevent.DefaultActorType = auditeventDescActorType.Default.(string)
+	// auditeventDescActorName is the schema descriptor for actor_name field.
+	auditeventDescActorName := auditeventFields[7].Descriptor()
+	// auditevent.DefaultActorName holds the default value on creation for the actor_name field.
+	auditevent.DefaultActorName = auditeventDescActorName.Default.(string)
+	// auditeventDescTargetType is the schema descriptor for target_type field.
+	auditeventDescTargetType := auditeventFields[8].Descriptor()
+	// auditevent.DefaultTargetType holds the default value on creation for the target_type field.
+	auditevent.DefaultTargetType = auditeventDescTargetType.Default.(string)
+	// auditeventDescTargetID is the schema descriptor for target_id field.
+	auditeventDescTargetID := auditeventFields[9].Descriptor()
+	// auditevent.DefaultTargetID holds the default value on creation for the target_id field.
+	auditevent.DefaultTargetID = auditeventDescTargetID.Default.(string)
+	// auditeventDescTargetName is the schema descriptor for target_name field.
+	auditeventDescTargetName := auditeventFields[10].Descriptor()
+	// auditevent.DefaultTargetName holds the default value on creation for the target_name field.
+	auditevent.DefaultTargetName = auditeventDescTargetName.Default.(string)
+	// auditeventDescProtocol is the schema descriptor for protocol field.
+	auditeventDescProtocol := auditeventFields[11].Descriptor()
+	// auditevent.DefaultProtocol holds the default value on creation for the protocol field.
+	auditevent.DefaultProtocol = auditeventDescProtocol.Default.(string)
+	// auditeventDescIPAddress is the schema descriptor for ip_address field.
+	auditeventDescIPAddress := auditeventFields[12].Descriptor()
+	// auditevent.DefaultIPAddress holds the default value on creation for the ip_address field.
+	auditevent.DefaultIPAddress = auditeventDescIPAddress.Default.(string)
+	// auditeventDescRequestID is the schema descriptor for request_id field.
+	auditeventDescRequestID := auditeventFields[13].Descriptor()
+	// auditevent.DefaultRequestID holds the default value on creation for the request_id field.
+	auditevent.DefaultRequestID = auditeventDescRequestID.Default.(string)
+	// auditeventDescChanges is the schema descriptor for changes field.
+	auditeventDescChanges := auditeventFields[14].Descriptor()
+	// auditevent.DefaultChanges holds the default value on creation for the changes field.
+	auditevent.DefaultChanges = auditeventDescChanges.Default.(string)
+	// auditeventDescID is the schema descriptor for id field.
+	auditeventDescID := auditeventFields[0].Descriptor()
+	// auditevent.DefaultID holds the default value on creation for the id field.
+	auditevent.DefaultID = auditeventDescID.Default.(func() uuid.UUID)
+	authorizationcodeFields := schema.AuthorizationCode{}.Fields()
+	_ = authorizationcodeFields
+	// authorizationcodeDescCodeHash is the schema descriptor for code_hash field.
+	authorizationcodeDescCodeHash := authorizationcodeFields[1].Descriptor()
+	// authorizationcode.CodeHashValidator is a validator for the "code_hash" field. It is called by the builders before save.
+	authorizationcode.CodeHashValidator = authorizationcodeDescCodeHash.Validators[0].(func(string) error)
+	// authorizationcodeDescRedirectURI is the schema descriptor for redirect_uri field.
+	authorizationcodeDescRedirectURI := authorizationcodeFields[2].Descriptor()
+	// authorizationcode.RedirectURIValidator is a validator for the "redirect_uri" field. It is called by the builders before save.
+	authorizationcode.RedirectURIValidator = authorizationcodeDescRedirectURI.Validators[0].(func(string) error)
+	// authorizationcodeDescNonce is the schema descriptor for nonce field.
+	authorizationcodeDescNonce := authorizationcodeFields[4].Descriptor()
+	// authorizationcode.DefaultNonce holds the default value on creation for the nonce field.
+	authorizationcode.DefaultNonce = authorizationcodeDescNonce.Default.(string)
+	// authorizationcodeDescCodeChallenge is the schema descriptor for code_challenge field.
+	authorizationcodeDescCodeChallenge := authorizationcodeFields[5].Descriptor()
+	// authorizationcode.CodeChallengeValidator is a validator for the "code_challenge" field. It is called by the builders before save.
+	authorizationcode.CodeChallengeValidator = authorizationcodeDescCodeChallenge.Validators[0].(func(string) error)
+	// authorizationcodeDescID is the schema descriptor for id field.
+	authorizationcodeDescID := authorizationcodeFields[0].Descriptor()
+	// authorizationcode.DefaultID holds the default value on creation for the id field.
+	authorizationcode.DefaultID = authorizationcodeDescID.Default.(func() uuid.UUID)
+	directoryobjectFields := schema.DirectoryObject{}.Fields()
+	_ = directoryobjectFields
+	// directoryobjectDescSource is the schema descriptor for source field.
+	directoryobjectDescSource := directoryobjectFields[1].Descriptor()
+	// directoryobject.SourceValidator is a validator for the "source" field. It is called by the builders before save.
+	directoryobject.SourceValidator = directoryobjectDescSource.Validators[0].(func(string) error)
+	// directoryobjectDescExternalID is the schema descriptor for external_id field.
+	directoryobjectDescExternalID := directoryobjectFields[3].Descriptor()
+	// directoryobject.ExternalIDValidator is a validator for the "external_id" field. It is called by the builders before save.
+	directoryobject.ExternalIDValidator = directoryobjectDescExternalID.Validators[0].(func(string) error)
+	// directoryobjectDescDn is the schema descriptor for dn field.
+	directoryobjectDescDn := directoryobjectFields[5].Descriptor()
+	// directoryobject.DefaultDn holds the default value on creation for the dn field.
+	directoryobject.DefaultDn = directoryobjectDescDn.Default.(string)
+	// directoryobjectDescPasswordDigest is the schema descriptor for password_digest field.
+	directoryobjectDescPasswordDigest := directoryobjectFields[6].Descriptor()
+	// directoryobject.DefaultPasswordDigest holds the default value on creation for the password_digest field.
+	directoryobject.DefaultPasswordDigest = directoryobjectDescPasswordDigest.Default.(string)
+	// directoryobjectDescCreatedAt is the schema descriptor for created_at field.
+	directoryobjectDescCreatedAt := directoryobjectFields[7].Descriptor()
+	// directoryobject.DefaultCreatedAt holds the default value on creation for the created_at field.
+	directoryobject.DefaultCreatedAt = directoryobjectDescCreatedAt.Default.(func() time.Time)
+	// directoryobjectDescUpdatedAt is the schema descriptor for updated_at field.
+	directoryobjectDescUpdatedAt := directoryobjectFields[8].Descriptor()
+	// directoryobject.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	directoryobject.DefaultUpdatedAt = directoryobjectDescUpdatedAt.Default.(func() time.Time)
+	// directoryobject.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	directoryobject.UpdateDefaultUpdatedAt = directoryobjectDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// directoryobjectDescID is the schema descriptor for id field.
+	directoryobjectDescID := directoryobjectFields[0].Descriptor()
+	// directoryobject.DefaultID holds the default value on creation for the id field.
+	directoryobject.DefaultID = directoryobjectDescID.Default.(func() uuid.UUID)
 	groupFields := schema.Group{}.Fields()
 	_ = groupFields
 	// groupDescName is the schema descriptor for name field.
@@ -39,12 +302,16 @@ func init() {
 	groupDescDescription := groupFields[2].Descriptor()
 	// group.DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
 	group.DescriptionValidator = groupDescDescription.Validators[0].(func(string) error)
+	// groupDescRequireMfa is the schema descriptor for require_mfa field.
+	groupDescRequireMfa := groupFields[4].Descriptor()
+	// group.DefaultRequireMfa holds the default value on creation for the require_mfa field.
+	group.DefaultRequireMfa = groupDescRequireMfa.Default.(bool)
 	// groupDescCreatedAt is the schema descriptor for created_at field.
-	groupDescCreatedAt := groupFields[4].Descriptor()
+	groupDescCreatedAt := groupFields[5].Descriptor()
 	// group.DefaultCreatedAt holds the default value on creation for the created_at field.
 	group.DefaultCreatedAt = groupDescCreatedAt.Default.(func() time.Time)
 	// groupDescUpdatedAt is the schema descriptor for updated_at field.
-	groupDescUpdatedAt := groupFields[5].Descriptor()
+	groupDescUpdatedAt := groupFields[6].Descriptor()
 	// group.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	group.DefaultUpdatedAt = groupDescUpdatedAt.Default.(func() time.Time)
 	// group.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -53,6 +320,532 @@ func init() {
 	groupDescID := groupFields[0].Descriptor()
 	// group.DefaultID holds the default value on creation for the id field.
 	group.DefaultID = groupDescID.Default.(func() uuid.UUID)
+	groupmembershipchangeFields := schema.GroupMembershipChange{}.Fields()
+	_ = groupmembershipchangeFields
+	// groupmembershipchangeDescActorName is the schema descriptor for actor_name field.
+	groupmembershipchangeDescActorName := groupmembershipchangeFields[5].Descriptor()
+	// groupmembershipchange.DefaultActorName holds the default value on creation for the actor_name field.
+	groupmembershipchange.DefaultActorName = groupmembershipchangeDescActorName.Default.(string)
+	// groupmembershipchangeDescCreatedAt is the schema descriptor for created_at field.
+	groupmembershipchangeDescCreatedAt := groupmembershipchangeFields[6].Descriptor()
+	// groupmembershipchange.DefaultCreatedAt holds the default value on creation for the created_at field.
+	groupmembershipchange.DefaultCreatedAt = groupmembershipchangeDescCreatedAt.Default.(func() time.Time)
+	// groupmembershipchangeDescID is the schema descriptor for id field.
+	groupmembershipchangeDescID := groupmembershipchangeFields[0].Descriptor()
+	// groupmembershipchange.DefaultID holds the default value on creation for the id field.
+	groupmembershipchange.DefaultID = groupmembershipchangeDescID.Default.(func() uuid.UUID)
+	groupversionFields := schema.GroupVersion{}.Fields()
+	_ = groupversionFields
+	// groupversionDescVersion is the schema descriptor for version field.
+	groupversionDescVersion := groupversionFields[2].Descriptor()
+	// groupversion.VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	groupversion.VersionValidator = groupversionDescVersion.Validators[0].(func(int) error)
+	// groupversionDescDescription is the schema descriptor for description field.
+	groupversionDescDescription := groupversionFields[5].Descriptor()
+	// groupversion.DefaultDescription holds the default value on creation for the description field.
+	groupversion.DefaultDescription = groupversionDescDescription.Default.(string)
+	// groupversionDescRequireMfa is the schema descriptor for require_mfa field.
+	groupversionDescRequireMfa := groupversionFields[7].Descriptor()
+	// groupversion.DefaultRequireMfa holds the default value on creation for the require_mfa field.
+	groupversion.DefaultRequireMfa = groupversionDescRequireMfa.Default.(bool)
+	// groupversionDescActorName is the schema descriptor for actor_name field.
+	groupversionDescActorName := groupversionFields[10].Descriptor()
+	// groupversion.DefaultActorName holds the default value on creation for the actor_name field.
+	groupversion.DefaultActorName = groupversionDescActorName.Default.(string)
+	// groupversionDescCreatedAt is the schema descriptor for created_at field.
+	groupversionDescCreatedAt := groupversionFields[11].Descriptor()
+	// groupversion.DefaultCreatedAt holds the default value on creation for the created_at field.
+	groupversion.DefaultCreatedAt = groupversionDescCreatedAt.Default.(func() time.Time)
+	// groupversionDescID is the schema descriptor for id field.
+	groupversionDescID := groupversionFields[0].Descriptor()
+	// groupversion.DefaultID holds the default value on creation for the id field.
+	groupversion.DefaultID = groupversionDescID.Default.(func() uuid.UUID)
+	invitationFields := schema.Invitation{}.Fields()
+	_ = invitationFields
+	// invitationDescTokenHash is the schema descriptor for token_hash field.
+	invitationDescTokenHash := invitationFields[1].Descriptor()
+	// invitation.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	invitation.TokenHashValidator = invitationDescTokenHash.Validators[0].(func(string) error)
+	// invitationDescCreatedAt is the schema descriptor for created_at field.
+	invitationDescCreatedAt := invitationFields[2].Descriptor()
+	// invitation.DefaultCreatedAt holds the default value on creation for the created_at field.
+	invitation.DefaultCreatedAt = invitationDescCreatedAt.Default.(func() time.Time)
+	// invitationDescID is the schema descriptor for id field.
+	invitationDescID := invitationFields[0].Descriptor()
+	// invitation.DefaultID holds the default value on creation for the id field.
+	invitation.DefaultID = invitationDescID.Default.(func() uuid.UUID)
+	logineventFields := schema.LoginEvent{}.Fields()
+	_ = logineventFields
+	// logineventDescReason is the schema descriptor for reason field.
+	logineventDescReason := logineventFields[4].Descriptor()
+	// loginevent.DefaultReason holds the default value on creation for the reason field.
+	loginevent.DefaultReason = logineventDescReason.Default.(string)
+	// logineventDescProtocol is the schema descriptor for protocol field.
+	logineventDescProtocol := logineventFields[5].Descriptor()
+	// loginevent.DefaultProtocol holds the default value on creation for the protocol field.
+	loginevent.DefaultProtocol = logineventDescProtocol.Default.(string)
+	// logineventDescIPAddress is the schema descriptor for ip_address field.
+	logineventDescIPAddress := logineventFields[6].Descriptor()
+	// loginevent.DefaultIPAddress holds the default value on creation for the ip_address field.
+	loginevent.DefaultIPAddress = logineventDescIPAddress.Default.(string)
+	// logineventDescCreatedAt is the schema descriptor for created_at field.
+	logineventDescCreatedAt := logineventFields[8].Descriptor()
+	// loginevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	loginevent.DefaultCreatedAt = logineventDescCreatedAt.Default.(func() time.Time)
+	// logineventDescID is the schema descriptor for id field.
+	logineventDescID := logineventFields[0].Descriptor()
+	// loginevent.DefaultID holds the default value on creation for the id field.
+	loginevent.DefaultID = logineventDescID.Default.(func() uuid.UUID)
+	oidcclientFields := schema.OIDCClient{}.Fields()
+	_ = oidcclientFields
+	// oidcclientDescName is the schema descriptor for name field.
+	oidcclientDescName := oidcclientFields[1].Descriptor()
+	// oidcclient.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	oidcclient.NameValidator = func() func(string) error {
+		validators := oidcclientDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// oidcclientDescPublic is the schema descriptor for public field.
+	oidcclientDescPublic := oidcclientFields[3].Descriptor()
+	// oidcclient.DefaultPublic holds the default value on creation for the public field.
+	oidcclient.DefaultPublic = oidcclientDescPublic.Default.(bool)
+	// oidcclientDescCreatedAt is the schema descriptor for created_at field.
+	oidcclientDescCreatedAt := oidcclientFields[5].Descriptor()
+	// oidcclient.DefaultCreatedAt holds the default value on creation for the created_at field.
+	oidcclient.DefaultCreatedAt = oidcclientDescCreatedAt.Default.(func() time.Time)
+	// oidcclientDescUpdatedAt is the schema descriptor for updated_at field.
+	oidcclientDescUpdatedAt := oidcclientFields[6].Descriptor()
+	// oidcclient.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	oidcclient.DefaultUpdatedAt = oidcclientDescUpdatedAt.Default.(func() time.Time)
+	// oidcclient.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	oidcclient.UpdateDefaultUpdatedAt = oidcclientDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// oidcclientDescID is the schema descriptor for id field.
+	oidcclientDescID := oidcclientFields[0].Descriptor()
+	// oidcclient.DefaultID holds the default value on creation for the id field.
+	oidcclient.DefaultID = oidcclientDescID.Default.(func() uuid.UUID)
+	oidcconsentFields := schema.OIDCConsent{}.Fields()
+	_ = oidcconsentFields
+	// oidcconsentDescCreatedAt is the schema descriptor for created_at field.
+	oidcconsentDescCreatedAt := oidcconsentFields[2].Descriptor()
+	// oidcconsent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	oidcconsent.DefaultCreatedAt = oidcconsentDescCreatedAt.Default.(func() time.Time)
+	// oidcconsentDescUpdatedAt is the schema descriptor for updated_at field.
+	oidcconsentDescUpdatedAt := oidcconsentFields[3].Descriptor()
+	// oidcconsent.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	oidcconsent.DefaultUpdatedAt = oidcconsentDescUpdatedAt.Default.(func() time.Time)
+	// oidcconsent.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	oidcconsent.UpdateDefaultUpdatedAt = oidcconsentDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// oidcconsentDescID is the schema descriptor for id field.
+	oidcconsentDescID := oidcconsentFields[0].Descriptor()
+	// oidcconsent.DefaultID holds the default value on creation for the id field.
+	oidcconsent.DefaultID = oidcconsentDescID.Default.(func() uuid.UUID)
+	outboxeventFields := schema.OutboxEvent{}.Fields()
+	_ = outboxeventFields
+	// outboxeventDescEventType is the schema descriptor for event_type field.
+	outboxeventDescEventType := outboxeventFields[1].Descriptor()
+	// outboxevent.EventTypeValidator is a validator for the "event_type" field. It is called by the builders before save.
+	outboxevent.EventTypeValidator = outboxeventDescEventType.Validators[0].(func(string) error)
+	// outboxeventDescCreatedAt is the schema descriptor for created_at field.
+	outboxeventDescCreatedAt := outboxeventFields[3].Descriptor()
+	// outboxevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	outboxevent.DefaultCreatedAt = outboxeventDescCreatedAt.Default.(func() time.Time)
+	// outboxeventDescID is the schema descriptor for id field.
+	outboxeventDescID := outboxeventFields[0].Descriptor()
+	// outboxevent.DefaultID holds the default value on creation for the id field.
+	outboxevent.DefaultID = outboxeventDescID.Default.(func() uuid.UUID)
+	passwordhistoryFields := schema.PasswordHistory{}.Fields()
+	_ = passwordhistoryFields
+	// passwordhistoryDescPasswordHash is the schema descriptor for password_hash field.
+	passwordhistoryDescPasswordHash := passwordhistoryFields[1].Descriptor()
+	// passwordhistory.PasswordHashValidator is a validator for the "password_hash" field. It is called by the builders before save.
+	passwordhistory.PasswordHashValidator = passwordhistoryDescPasswordHash.Validators[0].(func(string) error)
+	// passwordhistoryDescCreatedAt is the schema descriptor for created_at field.
+	passwordhistoryDescCreatedAt := passwordhistoryFields[2].Descriptor()
+	// passwordhistory.DefaultCreatedAt holds the default value on creation for the created_at field.
+	passwordhistory.DefaultCreatedAt = passwordhistoryDescCreatedAt.Default.(func() time.Time)
+	// passwordhistoryDescID is the schema descriptor for id field.
+	passwordhistoryDescID := passwordhistoryFields[0].Descriptor()
+	// passwordhistory.DefaultID holds the default value on creation for the id field.
+	passwordhistory.DefaultID = passwordhistoryDescID.Default.(func() uuid.UUID)
+	passwordpolicyFields := schema.PasswordPolicy{}.Fields()
+	_ = passwordpolicyFields
+	// passwordpolicyDescName is the schema descriptor for name field.
+	passwordpolicyDescName := passwordpolicyFields[1].Descriptor()
+	// passwordpolicy.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	passwordpolicy.NameValidator = func() func(string) error {
+		validators := passwordpolicyDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// passwordpolicyDescDescription is the schema descriptor for description field.
+	passwordpolicyDescDescription := passwordpolicyFields[2].Descriptor()
+	// passwordpolicy.DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
+	passwordpolicy.DescriptionValidator = passwordpolicyDescDescription.Validators[0].(func(string) error)
+	// passwordpolicyDescBuiltin is the schema descriptor for builtin field.
+	passwordpolicyDescBuiltin := passwordpolicyFields[3].Descriptor()
+	// passwordpolicy.DefaultBuiltin holds the default value on creation for the builtin field.
+	passwordpolicy.DefaultBuiltin = passwordpolicyDescBuiltin.Default.(bool)
+	// passwordpolicyDescMinLength is the schema descriptor for min_length field.
+	passwordpolicyDescMinLength := passwordpolicyFields[4].Descriptor()
+	// passwordpolicy.DefaultMinLength holds the default value on creation for the min_length field.
+	passwordpolicy.DefaultMinLength = passwordpolicyDescMinLength.Default.(int)
+	// passwordpolicy.MinLengthValidator is a validator for the "min_length" field. It is called by the builders before save.
+	passwordpolicy.MinLengthValidator = passwordpolicyDescMinLength.Validators[0].(func(int) error)
+	// passwordpolicyDescRequireUppercase is the schema descriptor for require_uppercase field.
+	passwordpolicyDescRequireUppercase := passwordpolicyFields[5].Descriptor()
+	// passwordpolicy.DefaultRequireUppercase holds the default value on creation for the require_uppercase field.
+	passwordpolicy.DefaultRequireUppercase = passwordpolicyDescRequireUppercase.Default.(bool)
+	// passwordpolicyDescRequireLowercase is the schema descriptor for require_lowercase field.
+	passwordpolicyDescRequireLowercase := passwordpolicyFields[6].Descriptor()
+	// passwordpolicy.DefaultRequireLowercase holds the default value on creation for the require_lowercase field.
+	passwordpolicy.DefaultRequireLowercase = passwordpolicyDescRequireLowercase.Default.(bool)
+	// passwordpolicyDescRequireDigit is the schema descriptor for require_digit field.
+	passwordpolicyDescRequireDigit := passwordpolicyFields[7].Descriptor()
+	// passwordpolicy.DefaultRequireDigit holds the default value on creation for the require_digit field.
+	passwordpolicy.DefaultRequireDigit = passwordpolicyDescRequireDigit.Default.(bool)
+	// passwordpolicyDescRequireSymbol is the schema descriptor for require_symbol field.
+	passwordpolicyDescRequireSymbol := passwordpolicyFields[8].Descriptor()
+	// passwordpolicy.DefaultRequireSymbol holds the default value on creation for the require_symbol field.
+	passwordpolicy.DefaultRequireSymbol = passwordpolicyDescRequireSymbol.Default.(bool)
+	// passwordpolicyDescCheckDictionary is the schema descriptor for check_dictionary field.
+	passwordpolicyDescCheckDictionary := passwordpolicyFields[9].Descriptor()
+	// passwordpolicy.DefaultCheckDictionary holds the default value on creation for the check_dictionary field.
+	passwordpolicy.DefaultCheckDictionary = passwordpolicyDescCheckDictionary.Default.(bool)
+	// passwordpolicyDescCheckUsername is the schema descriptor for check_username field.
+	passwordpolicyDescCheckUsername := passwordpolicyFields[10].Descriptor()
+	// passwordpolicy.DefaultCheckUsername holds the default value on creation for the check_username field.
+	passwordpolicy.DefaultCheckUsername = passwordpolicyDescCheckUsername.Default.(bool)
+	// passwordpolicyDescHistoryCount is the schema descriptor for history_count field.
+	passwordpolicyDescHistoryCount := passwordpolicyFields[11].Descriptor()
+	// passwordpolicy.DefaultHistoryCount holds the default value on creation for the history_count field.
+	passwordpolicy.DefaultHistoryCount = passwordpolicyDescHistoryCount.Default.(int)
+	// passwordpolicy.HistoryCountValidator is a validator for the "history_count" field. It is called by the builders before save.
+	passwordpolicy.HistoryCountValidator = passwordpolicyDescHistoryCount.Validators[0].(func(int) error)
+	// passwordpolicyDescMinAgeHours is the schema descriptor for min_age_hours field.
+	passwordpolicyDescMinAgeHours := passwordpolicyFields[12].Descriptor()
+	// passwordpolicy.DefaultMinAgeHours holds the default value on creation for the min_age_hours field.
+	passwordpolicy.DefaultMinAgeHours = passwordpolicyDescMinAgeHours.Default.(int)
+	// passwordpolicy.MinAgeHoursValidator is a validator for the "min_age_hours" field. It is called by the builders before save.
+	passwordpolicy.MinAgeHoursValidator = passwordpolicyDescMinAgeHours.Validators[0].(func(int) error)
+	// passwordpolicyDescMaxAgeDays is the schema descriptor for max_age_days field.
+	passwordpolicyDescMaxAgeDays := passwordpolicyFields[13].Descriptor()
+	// passwordpolicy.DefaultMaxAgeDays holds the default value on creation for the max_age_days field.
+	passwordpolicy.DefaultMaxAgeDays = passwordpolicyDescMaxAgeDays.Default.(int)
+	// passwordpolicy.MaxAgeDaysValidator is a validator for the "max_age_days" field. It is called by the builders before save.
+	passwordpolicy.MaxAgeDaysValidator = passwordpolicyDescMaxAgeDays.Validators[0].(func(int) error)
+	// passwordpolicyDescCreatedAt is the schema descriptor for created_at field.
+	passwordpolicyDescCreatedAt := passwordpolicyFields[14].Descriptor()
+	// passwordpolicy.DefaultCreatedAt holds the default value on creation for the created_at field.
+	passwordpolicy.DefaultCreatedAt = passwordpolicyDescCreatedAt.Default.(func() time.Time)
+	// passwordpolicyDescUpdatedAt is the schema descriptor for updated_at field.
+	passwordpolicyDescUpdatedAt := passwordpolicyFields[15].Descriptor()
+	// passwordpolicy.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	passwordpolicy.DefaultUpdatedAt = passwordpolicyDescUpdatedAt.Default.(func() time.Time)
+	// passwordpolicy.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	passwordpolicy.UpdateDefaultUpdatedAt = passwordpolicyDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// passwordpolicyDescID is the schema descriptor for id field.
+	passwordpolicyDescID := passwordpolicyFields[0].Descriptor()
+	// passwordpolicy.DefaultID holds the default value on creation for the id field.
+	passwordpolicy.DefaultID = passwordpolicyDescID.Default.(func() uuid.UUID)
+	passwordresettokenFields := schema.PasswordResetToken{}.Fields()
+	_ = passwordresettokenFields
+	// passwordresettokenDescTokenHash is the schema descriptor for token_hash field.
+	passwordresettokenDescTokenHash := passwordresettokenFields[1].Descriptor()
+	// passwordresettoken.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	passwordresettoken.TokenHashValidator = passwordresettokenDescTokenHash.Validators[0].(func(string) error)
+	// passwordresettokenDescIPAddress is the schema descriptor for ip_address field.
+	passwordresettokenDescIPAddress := passwordresettokenFields[2].Descriptor()
+	// passwordresettoken.DefaultIPAddress holds the default value on creation for the ip_address field.
+	passwordresettoken.DefaultIPAddress = passwordresettokenDescIPAddress.Default.(string)
+	// passwordresettokenDescCreatedAt is the schema descriptor for created_at field.
+	passwordresettokenDescCreatedAt := passwordresettokenFields[3].Descriptor()
+	// passwordresettoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	passwordresettoken.DefaultCreatedAt = passwordresettokenDescCreatedAt.Default.(func() time.Time)
+	// passwordresettokenDescID is the schema descriptor for id field.
+	passwordresettokenDescID := passwordresettokenFields[0].Descriptor()
+	// passwordresettoken.DefaultID holds the default value on creation for the id field.
+	passwordresettoken.DefaultID = passwordresettokenDescID.Default.(func() uuid.UUID)
+	provisionedobjectFields := schema.ProvisionedObject{}.Fields()
+	_ = provisionedobjectFields
+	// provisionedobjectDescRemoteID is the schema descriptor for remote_id field.
+	provisionedobjectDescRemoteID := provisionedobjectFields[3].Descriptor()
+	// provisionedobject.DefaultRemoteID holds the default value on creation for the remote_id field.
+	provisionedobject.DefaultRemoteID = provisionedobjectDescRemoteID.Default.(string)
+	// provisionedobjectDescHash is the schema descriptor for hash field.
+	provisionedobjectDescHash := provisionedobjectFields[4].Descriptor()
+	// provisionedobject.DefaultHash holds the default value on creation for the hash field.
+	provisionedobject.DefaultHash = provisionedobjectDescHash.Default.(string)
+	// provisionedobjectDescLastError is the schema descriptor for last_error field.
+	provisionedobjectDescLastError := provisionedobjectFields[5].Descriptor()
+	// provisionedobject.DefaultLastError holds the default value on creation for the last_error field.
+	provisionedobject.DefaultLastError = provisionedobjectDescLastError.Default.(string)
+	// provisionedobjectDescCreatedAt is the schema descriptor for created_at field.
+	provisionedobjectDescCreatedAt := provisionedobjectFields[7].Descriptor()
+	// provisionedobject.DefaultCreatedAt holds the default value on creation for the created_at field.
+	provisionedobject.DefaultCreatedAt = provisionedobjectDescCreatedAt.Default.(func() time.Time)
+	// provisionedobjectDescUpdatedAt is the schema descriptor for updated_at field.
+	provisionedobjectDescUpdatedAt := provisionedobjectFields[8].Descriptor()
+	// provisionedobject.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	provisionedobject.DefaultUpdatedAt = provisionedobjectDescUpdatedAt.Default.(func() time.Time)
+	// provisionedobject.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	provisionedobject.UpdateDefaultUpdatedAt = provisionedobjectDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// provisionedobjectDescID is the schema descriptor for id field.
+	provisionedobjectDescID := provisionedobjectFields[0].Descriptor()
+	// provisionedobject.DefaultID holds the default value on creation for the id field.
+	provisionedobject.DefaultID = provisionedobjectDescID.Default.(func() uuid.UUID)
+	provisioningconnectorFields := schema.ProvisioningConnector{}.Fields()
+	_ = provisioningconnectorFields
+	// provisioningconnectorDescName is the schema descriptor for name field.
+	provisioningconnectorDescName := provisioningconnectorFields[1].Descriptor()
+	// provisioningconnector.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	provisioningconnector.NameValidator = func() func(string) error {
+		validators := provisioningconnectorDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// provisioningconnectorDescURL is the schema descriptor for url field.
+	provisioningconnectorDescURL := provisioningconnectorFields[2].Descriptor()
+	// provisioningconnector.URLValidator is a validator for the "url" field. It is called by the builders before save.
+	provisioningconnector.URLValidator = provisioningconnectorDescURL.Validators[0].(func(string) error)
+	// provisioningconnectorDescToken is the schema descriptor for token field.
+	provisioningconnectorDescToken := provisioningconnectorFields[3].Descriptor()
+	// provisioningconnector.DefaultToken holds the default value on creation for the token field.
+	provisioningconnector.DefaultToken = provisioningconnectorDescToken.Default.(string)
+	// provisioningconnectorDescSyncGroups is the schema descriptor for sync_groups field.
+	provisioningconnectorDescSyncGroups := provisioningconnectorFields[5].Descriptor()
+	// provisioningconnector.DefaultSyncGroups holds the default value on creation for the sync_groups field.
+	provisioningconnector.DefaultSyncGroups = provisioningconnectorDescSyncGroups.Default.(bool)
+	// provisioningconnectorDescEnabled is the schema descriptor for enabled field.
+	provisioningconnectorDescEnabled := provisioningconnectorFields[7].Descriptor()
+	// provisioningconnector.DefaultEnabled holds the default value on creation for the enabled field.
+	provisioningconnector.DefaultEnabled = provisioningconnectorDescEnabled.Default.(bool)
+	// provisioningconnectorDescLastError is the schema descriptor for last_error field.
+	provisioningconnectorDescLastError := provisioningconnectorFields[11].Descriptor()
+	// provisioningconnector.DefaultLastError holds the default value on creation for the last_error field.
+	provisioningconnector.DefaultLastError = provisioningconnectorDescLastError.Default.(string)
+	// provisioningconnectorDescCreatedAt is the schema descriptor for created_at field.
+	provisioningconnectorDescCreatedAt := provisioningconnectorFields[12].Descriptor()
+	// provisioningconnector.DefaultCreatedAt holds the default value on creation for the created_at field.
+	provisioningconnector.DefaultCreatedAt = provisioningconnectorDescCreatedAt.Default.(func() time.Time)
+	// provisioningconnectorDescUpdatedAt is the schema descriptor for updated_at field.
+	provisioningconnectorDescUpdatedAt := provisioningconnectorFields[13].Descriptor()
+	// provisioningconnector.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	provisioningconnector.DefaultUpdatedAt = provisioningconnectorDescUpdatedAt.Default.(func() time.Time)
+	// provisioningconnector.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	provisioningconnector.UpdateDefaultUpdatedAt = provisioningconnectorDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// provisioningconnectorDescID is the schema descriptor for id field.
+	provisioningconnectorDescID := provisioningconnectorFields[0].Descriptor()
+	// provisioningconnector.DefaultID holds the default value on creation for the id field.
+	provisioningconnector.DefaultID = provisioningconnectorDescID.Default.(func() uuid.UUID)
+	recoverycodeFields := schema.RecoveryCode{}.Fields()
+	_ = recoverycodeFields
+	// recoverycodeDescCodeHash is the schema descriptor for code_hash field.
+	recoverycodeDescCodeHash := recoverycodeFields[1].Descriptor()
+	// recoverycode.CodeHashValidator is a validator for the "code_hash" field. It is called by the builders before save.
+	recoverycode.CodeHashValidator = recoverycodeDescCodeHash.Validators[0].(func(string) error)
+	// recoverycodeDescCreatedAt is the schema descriptor for created_at field.
+	recoverycodeDescCreatedAt := recoverycodeFields[2].Descriptor()
+	// recoverycode.DefaultCreatedAt holds the default value on creation for the created_at field.
+	recoverycode.DefaultCreatedAt = recoverycodeDescCreatedAt.Default.(func() time.Time)
+	// recoverycodeDescID is the schema descriptor for id field.
+	recoverycodeDescID := recoverycodeFields[0].Descriptor()
+	// recoverycode.DefaultID holds the default value on creation for the id field.
+	recoverycode.DefaultID = recoverycodeDescID.Default.(func() uuid.UUID)
+	roleFields := schema.Role{}.Fields()
+	_ = roleFields
+	// roleDescName is the schema descriptor for name field.
+	roleDescName := roleFields[1].Descriptor()
+	// role.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	role.NameValidator = func() func(string) error {
+		validators := roleDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// roleDescDescription is the schema descriptor for description field.
+	roleDescDescription := roleFields[2].Descriptor()
+	// role.DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
+	role.DescriptionValidator = roleDescDescription.Validators[0].(func(string) error)
+	// roleDescBuiltin is the schema descriptor for builtin field.
+	roleDescBuiltin := roleFields[4].Descriptor()
+	// role.DefaultBuiltin holds the default value on creation for the builtin field.
+	role.DefaultBuiltin = roleDescBuiltin.Default.(bool)
+	// roleDescCreatedAt is the schema descriptor for created_at field.
+	roleDescCreatedAt := roleFields[5].Descriptor()
+	// role.DefaultCreatedAt holds the default value on creation for the created_at field.
+	role.DefaultCreatedAt = roleDescCreatedAt.Default.(func() time.Time)
+	// roleDescUpdatedAt is the schema descriptor for updated_at field.
+	roleDescUpdatedAt := roleFields[6].Descriptor()
+	// role.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	role.DefaultUpdatedAt = roleDescUpdatedAt.Default.(func() time.Time)
+	// role.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	role.UpdateDefaultUpdatedAt = roleDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// roleDescID is the schema descriptor for id field.
+	roleDescID := roleFields[0].Descriptor()
+	// role.DefaultID holds the default value on creation for the id field.
+	role.DefaultID = roleDescID.Default.(func() uuid.UUID)
+	sshkeyFields := schema.SSHKey{}.Fields()
+	_ = sshkeyFields
+	// sshkeyDescName is the schema descriptor for name field.
+	sshkeyDescName := sshkeyFields[1].Descriptor()
+	// sshkey.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	sshkey.NameValidator = func() func(string) error {
+		validators := sshkeyDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// sshkeyDescPublicKey is the schema descriptor for public_key field.
+	sshkeyDescPublicKey := sshkeyFields[2].Descriptor()
+	// sshkey.PublicKeyValidator is a validator for the "public_key" field. It is called by the builders before save.
+	sshkey.PublicKeyValidator = sshkeyDescPublicKey.Validators[0].(func(string) error)
+	// sshkeyDescFingerprint is the schema descriptor for fingerprint field.
+	sshkeyDescFingerprint := sshkeyFields[3].Descriptor()
+	// sshkey.FingerprintValidator is a validator for the "fingerprint" field. It is called by the builders before save.
+	sshkey.FingerprintValidator = sshkeyDescFingerprint.Validators[0].(func(string) error)
+	// sshkeyDescCreatedAt is the schema descriptor for created_at field.
+	sshkeyDescCreatedAt := sshkeyFields[4].Descriptor()
+	// sshkey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	sshkey.DefaultCreatedAt = sshkeyDescCreatedAt.Default.(func() time.Time)
+	// sshkeyDescID is the schema descriptor for id field.
+	sshkeyDescID := sshkeyFields[0].Descriptor()
+	// sshkey.DefaultID holds the default value on creation for the id field.
+	sshkey.DefaultID = sshkeyDescID.Default.(func() uuid.UUID)
+	serviceaccountFields := schema.ServiceAccount{}.Fields()
+	_ = serviceaccountFields
+	// serviceaccountDescName is the schema descriptor for name field.
+	serviceaccountDescName := serviceaccountFields[1].Descriptor()
+	// serviceaccount.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	serviceaccount.NameValidator = func() func(string) error {
+		validators := serviceaccountDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// serviceaccountDescDescription is the schema descriptor for description field.
+	serviceaccountDescDescription := serviceaccountFields[2].Descriptor()
+	// serviceaccount.DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
+	serviceaccount.DescriptionValidator = serviceaccountDescDescription.Validators[0].(func(string) error)
+	// serviceaccountDescDisabled is the schema descriptor for disabled field.
+	serviceaccountDescDisabled := serviceaccountFields[4].Descriptor()
+	// serviceaccount.DefaultDisabled holds the default value on creation for the disabled field.
+	serviceaccount.DefaultDisabled = serviceaccountDescDisabled.Default.(bool)
+	// serviceaccountDescCreatedAt is the schema descriptor for created_at field.
+	serviceaccountDescCreatedAt := serviceaccountFields[5].Descriptor()
+	// serviceaccount.DefaultCreatedAt holds the default value on creation for the created_at field.
+	serviceaccount.DefaultCreatedAt = serviceaccountDescCreatedAt.Default.(func() time.Time)
+	// serviceaccountDescUpdatedAt is the schema descriptor for updated_at field.
+	serviceaccountDescUpdatedAt := serviceaccountFields[6].Descriptor()
+	// serviceaccount.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	serviceaccount.DefaultUpdatedAt = serviceaccountDescUpdatedAt.Default.(func() time.Time)
+	// serviceaccount.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	serviceaccount.UpdateDefaultUpdatedAt = serviceaccountDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// serviceaccountDescID is the schema descriptor for id field.
+	serviceaccountDescID := serviceaccountFields[0].Descriptor()
+	// serviceaccount.DefaultID holds the default value on creation for the id field.
+	serviceaccount.DefaultID = serviceaccountDescID.Default.(func() uuid.UUID)
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescRefreshTokenHash is the schema descriptor for refresh_token_hash field.
+	sessionDescRefreshTokenHash := sessionFields[1].Descriptor()
+	// session.RefreshTokenHashValidator is a validator for the "refresh_token_hash" field. It is called by the builders before save.
+	session.RefreshTokenHashValidator = sessionDescRefreshTokenHash.Validators[0].(func(string) error)
+	// sessionDescUserAgent is the schema descriptor for user_agent field.
+	sessionDescUserAgent := sessionFields[3].Descriptor()
+	// session.DefaultUserAgent holds the default value on creation for the user_agent field.
+	session.DefaultUserAgent = sessionDescUserAgent.Default.(string)
+	// sessionDescIPAddress is the schema descriptor for ip_address field.
+	sessionDescIPAddress := sessionFields[4].Descriptor()
+	// session.DefaultIPAddress holds the default value on creation for the ip_address field.
+	session.DefaultIPAddress = sessionDescIPAddress.Default.(string)
+	// sessionDescCreatedAt is the schema descriptor for created_at field.
+	sessionDescCreatedAt := sessionFields[5].Descriptor()
+	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
+	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() time.Time)
+	// sessionDescLastUsedAt is the schema descriptor for last_used_at field.
+	sessionDescLastUsedAt := sessionFields[6].Descriptor()
+	// session.DefaultLastUsedAt holds the default value on creation for the last_used_at field.
+	session.DefaultLastUsedAt = sessionDescLastUsedAt.Default.(func() time.Time)
+	// sessionDescID is the schema descriptor for id field.
+	sessionDescID := sessionFields[0].Descriptor()
+	// session.DefaultID holds the default value on creation for the id field.
+	session.DefaultID = sessionDescID.Default.(func() uuid.UUID)
+	signingkeyFields := schema.SigningKey{}.Fields()
+	_ = signingkeyFields
+	// signingkeyDescPrivateKey is the schema descriptor for private_key field.
+	signingkeyDescPrivateKey := signingkeyFields[2].Descriptor()
+	// signingkey.PrivateKeyValidator is a validator for the "private_key" field. It is called by the builders before save.
+	signingkey.PrivateKeyValidator = signingkeyDescPrivateKey.Validators[0].(func(string) error)
+	// signingkeyDescPublicKey is the schema descriptor for public_key field.
+	signingkeyDescPublicKey := signingkeyFields[3].Descriptor()
+	// signingkey.PublicKeyValidator is a validator for the "public_key" field. It is called by the builders before save.
+	signingkey.PublicKeyValidator = signingkeyDescPublicKey.Validators[0].(func(string) error)
+	// signingkeyDescCreatedAt is the schema descriptor for created_at field.
+	signingkeyDescCreatedAt := signingkeyFields[4].Descriptor()
+	// signingkey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	signingkey.DefaultCreatedAt = signingkeyDescCreatedAt.Default.(func() time.Time)
+	// signingkeyDescID is the schema descriptor for id field.
+	signingkeyDescID := signingkeyFields[0].Descriptor()
+	// signingkey.DefaultID holds the default value on creation for the id field.
+	signingkey.DefaultID = signingkeyDescID.Default.(func() uuid.UUID)
 	userFields := schema.User{}.Fields()
 	_ = userFields
 	// userDescUsername is the schema descriptor for username field.
@@ -109,16 +902,40 @@ func init() {
 			return nil
 		}
 	}()
+	// userDescPasswordChangedAt is the schema descriptor for password_changed_at field.
+	userDescPasswordChangedAt := userFields[5].Descriptor()
+	// user.DefaultPasswordChangedAt holds the default value on creation for the password_changed_at field.
+	user.DefaultPasswordChangedAt = userDescPasswordChangedAt.Default.(func() time.Time)
+	// userDescMustChangePassword is the schema descriptor for must_change_password field.
+	userDescMustChangePassword := userFields[6].Descriptor()
+	// user.DefaultMustChangePassword holds the default value on creation for the must_change_password field.
+	user.DefaultMustChangePassword = userDescMustChangePassword.Default.(bool)
 	// userDescPhone is the schema descriptor for phone field.
-	userDescPhone := userFields[5].Descriptor()
+	userDescPhone := userFields[8].Descriptor()
 	// user.PhoneValidator is a validator for the "phone" field. It is called by the builders before save.
 	user.PhoneValidator = userDescPhone.Validators[0].(func(string) error)
+	// userDescMfaEnabled is the schema descriptor for mfa_enabled field.
+	userDescMfaEnabled := userFields[12].Descriptor()
+	// user.DefaultMfaEnabled holds the default value on creation for the mfa_enabled field.
+	user.DefaultMfaEnabled = userDescMfaEnabled.Default.(bool)
+	// userDescTotpLastStep is the schema descriptor for totp_last_step field.
+	userDescTotpLastStep := userFields[13].Descriptor()
+	// user.DefaultTotpLastStep holds the default value on creation for the totp_last_step field.
+	user.DefaultTotpLastStep = userDescTotpLastStep.Default.(int64)
+	// userDescFailedLoginCount is the schema descriptor for failed_login_count field.
+	userDescFailedLoginCount := userFields[14].Descriptor()
+	// user.DefaultFailedLoginCount holds the default value on creation for the failed_login_count field.
+	user.DefaultFailedLoginCount = userDescFailedLoginCount.Default.(int)
+	// userDescLockoutCount is the schema descriptor for lockout_count field.
+	userDescLockoutCount := userFields[16].Descriptor()
+	// user.DefaultLockoutCount holds the default value on creation for the lockout_count field.
+	user.DefaultLockoutCount = userDescLockoutCount.Default.(int)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[7].Descriptor()
+	userDescCreatedAt := userFields[19].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
 	// userDescUpdatedAt is the schema descriptor for updated_at field.
-	userDescUpdatedAt := userFields[8].Descriptor()
+	userDescUpdatedAt := userFields[20].Descriptor()
 	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
 	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -127,4 +944,104 @@ func init() {
 	userDescID := userFields[0].Descriptor()
 	// user.DefaultID holds the default value on creation for the id field.
 	user.DefaultID = userDescID.Default.(func() uuid.UUID)
+	userversionFields := schema.UserVersion{}.Fields()
+	_ = userversionFields
+	// userversionDescVersion is the schema descriptor for version field.
+	userversionDescVersion := userversionFields[2].Descriptor()
+	// userversion.VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	userversion.VersionValidator = userversionDescVersion.Validators[0].(func(int) error)
+	// userversionDescPhone is the schema descriptor for phone field.
+	userversionDescPhone := userversionFields[7].Descriptor()
+	// userversion.DefaultPhone holds the default value on creation for the phone field.
+	userversion.DefaultPhone = userversionDescPhone.Default.(string)
+	// userversionDescActorName is the schema descriptor for actor_name field.
+	userversionDescActorName := userversionFields[13].Descriptor()
+	// userversion.DefaultActorName holds the default value on creation for the actor_name field.
+	userversion.DefaultActorName = userversionDescActorName.Default.(string)
+	// userversionDescCreatedAt is the schema descriptor for created_at field.
+	userversionDescCreatedAt := userversionFields[14].Descriptor()
+	// userversion.DefaultCreatedAt holds the default value on creation for the created_at field.
+	userversion.DefaultCreatedAt = userversionDescCreatedAt.Default.(func() time.Time)
+	// userversionDescID is the schema descriptor for id field.
+	userversionDescID := userversionFields[0].Descriptor()
+	// userversion.DefaultID holds the default value on creation for the id field.
+	userversion.DefaultID = userversionDescID.Default.(func() uuid.UUID)
+	webhookdeliveryFields := schema.WebhookDelivery{}.Fields()
+	_ = webhookdeliveryFields
+	// webhookdeliveryDescAttempts is the schema descriptor for attempts field.
+	webhookdeliveryDescAttempts := webhookdeliveryFields[2].Descriptor()
+	// webhookdelivery.DefaultAttempts holds the default value on creation for the attempts field.
+	webhookdelivery.DefaultAttempts = webhookdeliveryDescAttempts.Default.(int)
+	// webhookdeliveryDescNextAttemptAt is the schema descriptor for next_attempt_at field.
+	webhookdeliveryDescNextAttemptAt := webhookdeliveryFields[3].Descriptor()
+	// webhookdelivery.DefaultNextAttemptAt holds the default value on creation for the next_attempt_at field.
+	webhookdelivery.DefaultNextAttemptAt = webhookdeliveryDescNextAttemptAt.Default.(func() time.Time)
+	// webhookdeliveryDescLastStatusCode is the schema descriptor for last_status_code field.
+	webhookdeliveryDescLastStatusCode := webhookdeliveryFields[4].Descriptor()
+	// webhookdelivery.DefaultLastStatusCode holds the default value on creation for the last_status_code field.
+	webhookdelivery.DefaultLastStatusCode = webhookdeliveryDescLastStatusCode.Default.(int)
+	// webhookdeliveryDescLastError is the schema descriptor for last_error field.
+	webhookdeliveryDescLastError := webhookdeliveryFields[5].Descriptor()
+	// webhookdelivery.DefaultLastError holds the default value on creation for the last_error field.
+	webhookdelivery.DefaultLastError = webhookdeliveryDescLastError.Default.(string)
+	// webhookdeliveryDescCreatedAt is the schema descriptor for created_at field.
+	webhookdeliveryDescCreatedAt := webhookdeliveryFields[7].Descriptor()
+	// webhookdelivery.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhookdelivery.DefaultCreatedAt = webhookdeliveryDescCreatedAt.Default.(func() time.Time)
+	// webhookdeliveryDescUpdatedAt is the schema descriptor for updated_at field.
+	webhookdeliveryDescUpdatedAt := webhookdeliveryFields[8].Descriptor()
+	// webhookdelivery.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	webhookdelivery.DefaultUpdatedAt = webhookdeliveryDescUpdatedAt.Default.(func() time.Time)
+	// webhookdelivery.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	webhookdelivery.UpdateDefaultUpdatedAt = webhookdeliveryDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// webhookdeliveryDescID is the schema descriptor for id field.
+	webhookdeliveryDescID := webhookdeliveryFields[0].Descriptor()
+	// webhookdelivery.DefaultID holds the default value on creation for the id field.
+	webhookdelivery.DefaultID = webhookdeliveryDescID.Default.(func() uuid.UUID)
+	webhooksubscriptionFields := schema.WebhookSubscription{}.Fields()
+	_ = webhooksubscriptionFields
+	// webhooksubscriptionDescName is the schema descriptor for name field.
+	webhooksubscriptionDescName := webhooksubscriptionFields[1].Descriptor()
+	// webhooksubscription.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	webhooksubscription.NameValidator = func() func(string) error {
+		validators := webhooksubscriptionDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// webhooksubscriptionDescURL is the schema descriptor for url field.
+	webhooksubscriptionDescURL := webhooksubscriptionFields[2].Descriptor()
+	// webhooksubscription.URLValidator is a validator for the "url" field. It is called by the builders before save.
+	webhooksubscription.URLValidator = webhooksubscriptionDescURL.Validators[0].(func(string) error)
+	// webhooksubscriptionDescSecret is the schema descriptor for secret field.
+	webhooksubscriptionDescSecret := webhooksubscriptionFields[3].Descriptor()
+	// webhooksubscription.SecretValidator is a validator for the "secret" field. It is called by the builders before save.
+	webhooksubscription.SecretValidator = webhooksubscriptionDescSecret.Validators[0].(func(string) error)
+	// webhooksubscriptionDescEnabled is the schema descriptor for enabled field.
+	webhooksubscriptionDescEnabled := webhooksubscriptionFields[5].Descriptor()
+	// webhooksubscription.DefaultEnabled holds the default value on creation for the enabled field.
+	webhooksubscription.DefaultEnabled = webhooksubscriptionDescEnabled.Default.(bool)
+	// webhooksubscriptionDescCreatedAt is the schema descriptor for created_at field.
+	webhooksubscriptionDescCreatedAt := webhooksubscriptionFields[6].Descriptor()
+	// webhooksubscription.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhooksubscription.DefaultCreatedAt = webhooksubscriptionDescCreatedAt.Default.(func() time.Time)
+	// webhooksubscriptionDescUpdatedAt is the schema descriptor for updated_at field.
+	webhooksubscriptionDescUpdatedAt := webhooksubscriptionFields[7].Descriptor()
+	// webhooksubscription.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	webhooksubscription.DefaultUpdatedAt = webhooksubscriptionDescUpdatedAt.Default.(func() time.Time)
+	// webhooksubscription.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	webhooksubscription.UpdateDefaultUpdatedAt = webhooksubscriptionDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// webhooksubscriptionDescID is the schema descriptor for id field.
+	webhooksubscriptionDescID := webhooksubscriptionFields[0].Descriptor()
+	// webhooksubscription.DefaultID holds the default value on creation for the id field.
+	webhooksubscription.DefaultID = webhooksubscriptionDescID.Default.(func() uuid.UUID)
 }
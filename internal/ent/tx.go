@@ -12,10 +12,64 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// APIKey is the client for interacting with the APIKey builders.
+	APIKey *APIKeyClient
+	// AttributeDefinition is the client for interacting with the AttributeDefinition builders.
+	AttributeDefinition *AttributeDefinitionClient
+	// AttributeValue is the client for interacting with the AttributeValue builders.
+	AttributeValue *AttributeValueClient
+	// AuditEvent is the client for interacting with the AuditEvent builders.
+	AuditEvent *AuditEventClient
+	// AuthorizationCode is the client for interacting with the AuthorizationCode builders.
+	AuthorizationCode *AuthorizationCodeClient
+	// DirectoryObject is the client for interacting with the DirectoryObject builders.
+	DirectoryObject *DirectoryObjectClient
 	// Group is the client for interacting with the Group builders.
 	Group *GroupClient
+	// GroupMembershipChange is the client for interacting with the GroupMembershipChange builders.
+	GroupMembershipChange *GroupMembershipChangeClient
+	// GroupVersion is the client for interacting with the GroupVersion builders.
+	GroupVersion *GroupVersionClient
+	// Invitation is the client for interacting with the Invitation builders.
+	Invitation *InvitationClient
+	// LoginEvent is the client for interacting with the LoginEvent builders.
+	LoginEvent *LoginEventClient
+	// OIDCClient is the client for interacting with the OIDCClient builders.
+	OIDCClient *OIDCClientClient
+	// OIDCConsent is the client for interacting with the OIDCConsent builders.
+	OIDCConsent *OIDCConsentClient
+	// OutboxEvent is the client for interacting with the OutboxEvent builders.
+	OutboxEvent *OutboxEventClient
+	// PasswordHistory is the client for interacting with the PasswordHistory builders.
+	PasswordHistory *PasswordHistoryClient
+	// PasswordPolicy is the client for interacting with the PasswordPolicy builders.
+	PasswordPolicy *PasswordPolicyClient
+	// PasswordResetToken is the client for interacting with the PasswordResetToken builders.
+	PasswordResetToken *PasswordResetTokenClient
+	// ProvisionedObject is the client for interacting with the ProvisionedObject builders.
+	ProvisionedObject *ProvisionedObjectClient
+	// ProvisioningConnector is the client for interacting with the ProvisioningConnector builders.
+	ProvisioningConnector *ProvisioningConnectorClient
+	// RecoveryCode is the client for interacting with the RecoveryCode builders.
+	RecoveryCode *RecoveryCodeClient
+	// Role is the client for interacting with the Role builders.
+	Role *RoleClient
+	// SSHKey is the client for interacting with the SSHKey builders.
+	SSHKey *SSHKeyClient
+	// ServiceAccount is the client for interacting with the ServiceAccount builders.
+	ServiceAccount *ServiceAccountClient
+	// Session is the client for interacting with the Session builders.
+	Session *SessionClient
+	// SigningKey is the client for interacting with the SigningKey builders.
+	SigningKey *SigningKeyClient
 	// User is the client for interacting with the User builders.
 	User *UserClient
+	// UserVersion is the client for interacting with the UserVersion builders.
+	UserVersion *UserVersionClient
+	// WebhookDelivery is the client for interacting with the WebhookDelivery builders.
+	WebhookDelivery *WebhookDeliveryClient
+	// WebhookSubscription is the client for interacting with the WebhookSubscription builders.
+	WebhookSubscription *WebhookSubscriptionClient
 
 	// lazily loaded.
 	client     *Client
@@ -147,8 +201,35 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.APIKey = NewAPIKeyClient(tx.config)
+	tx.AttributeDefinition = NewAttributeDefinitionClient(tx.config)
+	tx.AttributeValue = NewAttributeValueClient(tx.config)
+	tx.AuditEvent = NewAuditEventClient(tx.config)
+	tx.AuthorizationCode = NewAuthorizationCodeClient(tx.config)
+	tx.DirectoryObject = NewDirectoryObjectClient(tx.config)
 	tx.Group = NewGroupClient(tx.config)
+	tx.GroupMembershipChange = NewGroupMembershipChangeClient(tx.config)
+	tx.GroupVersion = NewGroupVersionClient(tx.config)
+	tx.Invitation = NewInvitationClient(tx.config)
+	tx.LoginEvent = NewLoginEventClient(tx.config)
+	tx.OIDCClient = NewOIDCClientClient(tx.config)
+	tx.OIDCConsent = NewOIDCConsentClient(tx.config)
+	tx.OutboxEvent = NewOutboxEventClient(tx.config)
+	tx.PasswordHistory = NewPasswordHistoryClient(tx.config)
+	tx.PasswordPolicy = NewPasswordPolicyClient(tx.config)
+	tx.PasswordResetToken = NewPasswordResetTokenClient(tx.config)
+	tx.ProvisionedObject = NewProvisionedObjectClient(tx.config)
+	tx.ProvisioningConnector = NewProvisioningConnectorClient(tx.config)
+	tx.RecoveryCode = NewRecoveryCodeClient(tx.config)
+	tx.Role = NewRoleClient(tx.config)
+	tx.SSHKey = NewSSHKeyClient(tx.config)
+	tx.ServiceAccount = NewServiceAccountClient(tx.config)
+	tx.Session = NewSessionClient(tx.config)
+	tx.SigningKey = NewSigningKeyClient(tx.config)
 	tx.User = NewUserClient(tx.config)
+	tx.UserVersion = NewUserVersionClient(tx.config)
+	tx.WebhookDelivery = NewWebhookDeliveryClient(tx.config)
+	tx.WebhookSubscription = NewWebhookSubscriptionClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -158,7 +239,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: Group.QueryXXX(), the query will be executed
+// applies a query, for example: APIKey.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
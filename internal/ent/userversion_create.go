@@ -0,0 +1,441 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/qinzj/claude-demo/internal/ent/userversion"
+)
+
+// UserVersionCreate is the builder for creating a UserVersion entity.
+type UserVersionCreate struct {
+	config
+	mutation *UserVersionMutation
+	hooks    []Hook
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *UserVersionCreate) SetUserID(v uuid.UUID) *UserVersionCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetVersion sets the "version" field.
+func (_c *UserVersionCreate) SetVersion(v int) *UserVersionCreate {
+	_c.mutation.SetVersion(v)
+	return _c
+}
+
+// SetOperation sets the "operation" field.
+func (_c *UserVersionCreate) SetOperation(v userversion.Operation) *UserVersionCreate {
+	_c.mutation.SetOperation(v)
+	return _c
+}
+
+// SetUsername sets the "username" field.
+func (_c *UserVersionCreate) SetUsername(v string) *UserVersionCreate {
+	_c.mutation.SetUsername(v)
+	return _c
+}
+
+// SetDisplayName sets the "display_name" field.
+func (_c *UserVersionCreate) SetDisplayName(v string) *UserVersionCreate {
+	_c.mutation.SetDisplayName(v)
+	return _c
+}
+
+// SetEmail sets the "email" field.
+func (_c *UserVersionCreate) SetEmail(v string) *UserVersionCreate {
+	_c.mutation.SetEmail(v)
+	return _c
+}
+
+// SetPhone sets the "phone" field.
+func (_c *UserVersionCreate) SetPhone(v string) *UserVersionCreate {
+	_c.mutation.SetPhone(v)
+	return _c
+}
+
+// SetNillablePhone sets the "phone" field if the given value is not nil.
+func (_c *UserVersionCreate) SetNillablePhone(v *string) *UserVersionCreate {
+	if v != nil {
+		_c.SetPhone(*v)
+	}
+	return _c
+}
+
+// SetStatus sets the "status" field.
+func (_c *UserVersionCreate) SetStatus(v string) *UserVersionCreate {
+	_c.mutation.SetStatus(v)
+	return _c
+}
+
+// SetManagerID sets the "manager_id" field.
+func (_c *UserVersionCreate) SetManagerID(v uuid.UUID) *UserVersionCreate {
+	_c.mutation.SetManagerID(v)
+	return _c
+}
+
+// SetNillableManagerID sets the "manager_id" field if the given value is not nil.
+func (_c *UserVersionCreate) SetNillableManagerID(v *uuid.UUID) *UserVersionCreate {
+	if v != nil {
+		_c.SetManagerID(*v)
+	}
+	return _c
+}
+
+// SetAccountExpiresAt sets the "account_expires_at" field.
+func (_c *UserVersionCreate) SetAccountExpiresAt(v time.Time) *UserVersionCreate {
+	_c.mutation.SetAccountExpiresAt(v)
+	return _c
+}
+
+// SetNillableAccountExpiresAt sets the "account_expires_at" field if the given value is not nil.
+func (_c *UserVersionCreate) SetNillableAccountExpiresAt(v *time.Time) *UserVersionCreate {
+	if v != nil {
+		_c.SetAccountExpiresAt(*v)
+	}
+	return _c
+}
+
+// SetAttributes sets the "attributes" field.
+func (_c *UserVersionCreate) SetAttributes(v map[string][]string) *UserVersionCreate {
+	_c.mutation.SetAttributes(v)
+	return _c
+}
+
+// SetActorID sets the "actor_id" field.
+func (_c *UserVersionCreate) SetActorID(v uuid.UUID) *UserVersionCreate {
+	_c.mutation.SetActorID(v)
+	return _c
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (_c *UserVersionCreate) SetNillableActorID(v *uuid.UUID) *UserVersionCreate {
+	if v != nil {
+		_c.SetActorID(*v)
+	}
+	return _c
+}
+
+// SetActorName sets the "actor_name" field.
+func (_c *UserVersionCreate) SetActorName(v string) *UserVersionCreate {
+	_c.mutation.SetActorName(v)
+	return _c
+}
+
+// SetNillableActorName sets the "actor_name" field if the given value is not nil.
+func (_c *UserVersionCreate) SetNillableActorName(v *string) *UserVersionCreate {
+	if v != nil {
+		_c.SetActorName(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *UserVersionCreate) SetCreatedAt(v time.Time) *UserVersionCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *UserVersionCreate) SetNillableCreatedAt(v *time.Time) *UserVersionCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *UserVersionCreate) SetID(v uuid.UUID) *UserVersionCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *UserVersionCreate) SetNillableID(v *uuid.UUID) *UserVersionCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the UserVersionMutation object of the builder.
+func (_c *UserVersionCreate) Mutation() *UserVersionMutation {
+	return _c.mutation
+}
+
+// Save creates the UserVersion in the database.
+func (_c *UserVersionCreate) Save(ctx context.Context) (*UserVersion, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *UserVersionCreate) SaveX(ctx context.Context) *UserVersion {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *UserVersionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *UserVersionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *UserVersionCreate) defaults() {
+	if _, ok := _c.mutation.Phone(); !ok {
+		v := userversion.DefaultPhone
+		_c.mutation.SetPhone(v)
+	}
+	if _, ok := _c.mutation.ActorName(); !ok {
+		v := userversion.DefaultActorName
+		_c.mutation.SetActorName(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := userversion.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := userversion.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *UserVersionCreate) check() error {
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "UserVersion.user_id"`)}
+	}
+	if _, ok := _c.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "UserVersion.version"`)}
+	}
+	if v, ok := _c.mutation.Version(); ok {
+		if err := userversion.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "UserVersion.version": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Operation(); !ok {
+		return &ValidationError{Name: "operation", err: errors.New(`ent: missing required field "UserVersion.operation"`)}
+	}
+	if v, ok := _c.mutation.Operation(); ok {
+		if err := userversion.OperationValidator(v); err != nil {
+			return &ValidationError{Name: "operation", err: fmt.Errorf(`ent: validator failed for field "UserVersion.operation": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Username(); !ok {
+		return &ValidationError{Name: "username", err: errors.New(`ent: missing required field "UserVersion.username"`)}
+	}
+	if _, ok := _c.mutation.DisplayName(); !ok {
+		return &ValidationError{Name: "display_name", err: errors.New(`ent: missing required field "UserVersion.display_name"`)}
+	}
+	if _, ok := _c.mutation.Email(); !ok {
+		return &ValidationError{Name: "email", err: errors.New(`ent: missing required field "UserVersion.email"`)}
+	}
+	if _, ok := _c.mutation.Phone(); !ok {
+		return &ValidationError{Name: "phone", err: errors.New(`ent: missing required field "UserVersion.phone"`)}
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "UserVersion.status"`)}
+	}
+	if _, ok := _c.mutation.ActorName(); !ok {
+		return &ValidationError{Name: "actor_name", err: errors.New(`ent: missing required field "UserVersion.actor_name"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "UserVersion.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *UserVersionCreate) sqlSave(ctx context.Context) (*UserVersion, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *UserVersionCreate) createSpec() (*UserVersion, *sqlgraph.CreateSpec) {
+	var (
+		_node = &UserVersion{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(userversion.Table, sqlgraph.NewFieldSpec(userversion.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.UserID(); ok {
+		_spec.SetField(userversion.FieldUserID, field.TypeUUID, value)
+		_node.UserID = value
+	}
+	if value, ok := _c.mutation.Version(); ok {
+		_spec.SetField(userversion.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if value, ok := _c.mutation.Operation(); ok {
+		_spec.SetField(userversion.FieldOperation, field.TypeEnum, value)
+		_node.Operation = value
+	}
+	if value, ok := _c.mutation.Username(); ok {
+		_spec.SetField(userversion.FieldUsername, field.TypeString, value)
+		_node.Username = value
+	}
+	if value, ok := _c.mutation.DisplayName(); ok {
+		_spec.SetField(userversion.FieldDisplayName, field.TypeString, value)
+		_node.DisplayName = value
+	}
+	if value, ok := _c.mutation.Email(); ok {
+		_spec.SetField(userversion.FieldEmail, field.TypeString, value)
+		_node.Email = value
+	}
+	if value, ok := _c.mutation.Phone(); ok {
+		_spec.SetField(userversion.FieldPhone, field.TypeString, value)
+		_node.Phone = value
+	}
+	if value, ok := _c.mutation.Status(); ok {
+		_spec.SetField(userversion.FieldStatus, field.TypeString, value)
+		_node.Status = value
+	}
+	if value, ok := _c.mutation.ManagerID(); ok {
+		_spec.SetField(userversion.FieldManagerID, field.TypeUUID, value)
+		_node.ManagerID = &value
+	}
+	if value, ok := _c.mutation.AccountExpiresAt(); ok {
+		_spec.SetField(userversion.FieldAccountExpiresAt, field.TypeTime, value)
+		_node.AccountExpiresAt = &value
+	}
+	if value, ok := _c.mutation.Attributes(); ok {
+		_spec.SetField(userversion.FieldAttributes, field.TypeJSON, value)
+		_node.Attributes = value
+	}
+	if value, ok := _c.mutation.ActorID(); ok {
+		_spec.SetField(userversion.FieldActorID, field.TypeUUID, value)
+		_node.ActorID = &value
+	}
+	if value, ok := _c.mutation.ActorName(); ok {
+		_spec.SetField(userversion.FieldActorName, field.TypeString, value)
+		_node.ActorName = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(userversion.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// UserVersionCreateBulk is the builder for creating many UserVersion entities in bulk.
+type UserVersionCreateBulk struct {
+	config
+	err      error
+	builders []*UserVersionCreate
+}
+
+// Save creates the UserVersion entities in the database.
+func (_c *UserVersionCreateBulk) Save(ctx context.Context) ([]*UserVersion, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*UserVersion, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*UserVersionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *UserVersionCreateBulk) SaveX(ctx context.Context) []*UserVersion {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *UserVersionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *UserVersionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
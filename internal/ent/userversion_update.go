@@ -0,0 +1,199 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/qinzj/claude-demo/internal/ent/predicate"
+	"github.com/qinzj/claude-demo/internal/ent/userversion"
+)
+
+// UserVersionUpdate is the builder for updating UserVersion entities.
+type UserVersionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *UserVersionMutation
+}
+
+// Where appends a list predicates to the UserVersionUpdate builder.
+func (_u *UserVersionUpdate) Where(ps ...predicate.UserVersion) *UserVersionUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Mutation returns the UserVersionMutation object of the builder.
+func (_u *UserVersionUpdate) Mutation() *UserVersionMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *UserVersionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *UserVersionUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *UserVersionUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *UserVersionUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *UserVersionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(userversion.Table, userversion.Columns, sqlgraph.NewFieldSpec(userversion.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.ManagerIDCleared() {
+		_spec.ClearField(userversion.FieldManagerID, field.TypeUUID)
+	}
+	if _u.mutation.AccountExpiresAtCleared() {
+		_spec.ClearField(userversion.FieldAccountExpiresAt, field.TypeTime)
+	}
+	if _u.mutation.AttributesCleared() {
+		_spec.ClearField(userversion.FieldAttributes, field.TypeJSON)
+	}
+	if _u.mutation.ActorIDCleared() {
+		_spec.ClearField(userversion.FieldActorID, field.TypeUUID)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{userversion.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// UserVersionUpdateOne is the builder for updating a single UserVersion entity.
+type UserVersionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *UserVersionMutation
+}
+
+// Mutation returns the UserVersionMutation object of the builder.
+func (_u *UserVersionUpdateOne) Mutation() *UserVersionMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the UserVersionUpdate builder.
+func (_u *UserVersionUpdateOne) Where(ps ...predicate.UserVersion) *UserVersionUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *UserVersionUpdateOne) Select(field string, fields ...string) *UserVersionUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated UserVersion entity.
+func (_u *UserVersionUpdateOne) Save(ctx context.Context) (*UserVersion, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *UserVersionUpdateOne) SaveX(ctx context.Context) *UserVersion {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *UserVersionUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *UserVersionUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *UserVersionUpdateOne) sqlSave(ctx context.Context) (_node *UserVersion, err error) {
+	_spec := sqlgraph.NewUpdateSpec(userversion.Table, userversion.Columns, sqlgraph.NewFieldSpec(userversion.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "UserVersion.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, userversion.FieldID)
+		for _, f := range fields {
+			if !userversion.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != userversion.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.ManagerIDCleared() {
+		_spec.ClearField(userversion.FieldManagerID, field.TypeUUID)
+	}
+	if _u.mutation.AccountExpiresAtCleared() {
+		_spec.ClearField(userversion.FieldAccountExpiresAt, field.TypeTime)
+	}
+	if _u.mutation.AttributesCleared() {
+		_spec.ClearField(userversion.FieldAttributes, field.TypeJSON)
+	}
+	if _u.mutation.ActorIDCleared() {
+		_spec.ClearField(userversion.FieldActorID, field.TypeUUID)
+	}
+	_node = &UserVersion{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{userversion.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// AttributeHandler handles extension attribute schema endpoints.
+type AttributeHandler struct {
+	attributeService *service.AttributeService
+}
+
+// NewAttributeHandler creates a new AttributeHandler.
+func NewAttributeHandler(attrSvc *service.AttributeService) *AttributeHandler {
+	return &AttributeHandler{attributeService: attrSvc}
+}
+
+// CreateAttributeReq is the request DTO for declaring an extension attribute.
+type CreateAttributeReq struct {
+	Name        string `json:"name" binding:"required,max=64"`
+	Target      string `json:"target" binding:"required,oneof=user group"`
+	Type        string `json:"type,omitempty" binding:"omitempty,oneof=string integer boolean"`
+	MultiValued bool   `json:"multi_valued,omitempty"`
+	LDAPName    string `json:"ldap_name,omitempty" binding:"omitempty,max=64"`
+	Description string `json:"description,omitempty" binding:"omitempty,max=255"`
+	Required    bool   `json:"required,omitempty"`
+	Indexed     bool   `json:"indexed,omitempty"`
+}
+
+// UpdateAttributeReq is the request DTO for updating an extension attribute.
+type UpdateAttributeReq struct {
+	LDAPName    *string `json:"ldap_name,omitempty" binding:"omitempty,max=64"`
+	Description *string `json:"description,omitempty" binding:"omitempty,max=255"`
+	Required    *bool   `json:"required,omitempty"`
+	Indexed     *bool   `json:"indexed,omitempty"`
+}
+
+// Create godoc
+// @Summary      Create attribute definition
+// @Description  Declare a custom attribute for users or groups
+// @Tags         Attribute
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string              true  "Bearer token"
+// @Param        request        body      CreateAttributeReq  true  "Attribute definition"
+// @Success      200            {object}  Response{data=domain.AttributeDefinition}
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/attributes [post]
+func (h *AttributeHandler) Create(c *gin.Context) {
+	var req CreateAttributeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	def, err := h.attributeService.CreateDefinition(c.Request.Context(), domain.CreateAttributeDefinitionInput{
+		Name:        req.Name,
+		Target:      domain.AttributeTarget(req.Target),
+		Type:        domain.AttributeType(req.Type),
+		MultiValued: req.MultiValued,
+		LDAPName:    req.LDAPName,
+		Description: req.Description,
+		Required:    req.Required,
+		Indexed:     req.Indexed,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAttributes) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to create attribute: "+err.Error())
+		return
+	}
+	OK(c, def)
+}
+
+// List godoc
+// @Summary      List attribute definitions
+// @Description  List custom attribute definitions, optionally filtered by target
+// @Tags         Attribute
+// @Produce      json
+// @Param        Authorization  header    string  true   "Bearer token"
+// @Param        target         query     string  false  "Target: user | group"
+// @Success      200            {object}  Response{data=[]domain.AttributeDefinition}
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/attributes [get]
+func (h *AttributeHandler) List(c *gin.Context) {
+	target := domain.AttributeTarget(c.Query("target"))
+	switch target {
+	case "", domain.AttributeTargetUser, domain.AttributeTargetGroup:
+	default:
+		Error(c, http.StatusBadRequest, "invalid target")
+		return
+	}
+
+	defs, err := h.attributeService.ListDefinitions(c.Request.Context(), target)
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to list attributes")
+		return
+	}
+	OK(c, defs)
+}
+
+// Get godoc
+// @Summary      Get attribute definition
+// @Description  Retrieve a custom attribute definition by ID
+// @Tags         Attribute
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Attribute definition ID (UUID)"
+// @Success      200            {object}  Response{data=domain.AttributeDefinition}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/attributes/{id} [get]
+func (h *AttributeHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid attribute id")
+		return
+	}
+
+	def, err := h.attributeService.GetDefinition(c.Request.Context(), id)
+	if err != nil {
+		Error(c, http.StatusNotFound, "attribute not found")
+		return
+	}
+	OK(c, def)
+}
+
+// Update godoc
+// @Summary      Update attribute definition
+// @Description  Update ldap_name, description, required or indexed of a custom attribute
+// @Tags         Attribute
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string              true  "Bearer token"
+// @Param        id             path      string              true  "Attribute definition ID (UUID)"
+// @Param        request        body      UpdateAttributeReq  true  "Fields to update"
+// @Success      200            {object}  Response{data=domain.AttributeDefinition}
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/attributes/{id} [put]
+func (h *AttributeHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid attribute id")
+		return
+	}
+
+	var req UpdateAttributeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	def, err := h.attributeService.UpdateDefinition(c.Request.Context(), id, domain.UpdateAttributeDefinitionInput{
+		LDAPName:    req.LDAPName,
+		Description: req.Description,
+		Required:    req.Required,
+		Indexed:     req.Indexed,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAttributes) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to update attribute: "+err.Error())
+		return
+	}
+	OK(c, def)
+}
+
+// Delete godoc
+// @Summary      Delete attribute definition
+// @Description  Delete a custom attribute definition and all of its stored values
+// @Tags         Attribute
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Attribute definition ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/attributes/{id} [delete]
+func (h *AttributeHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid attribute id")
+		return
+	}
+
+	if err := h.attributeService.DeleteDefinition(c.Request.Context(), id); err != nil {
+		Error(c, http.StatusInternalServerError, "failed to delete attribute")
+		return
+	}
+	OK(c, nil)
+}
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// AuditHandler handles querying and exporting the audit trail.
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler.
+func NewAuditHandler(auditSvc *service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditSvc}
+}
+
+// List godoc
+// @Summary      List audit events
+// @Description  List audit events, newest first, with optional filters. Every change to users, groups, memberships, roles, password policies, attributes, OIDC clients, service accounts, webhooks and the LDAP configuration is recorded, as is every login and bind, successful or not, with the actor, client IP, protocol, request ID and the changed fields. Secrets are recorded as redacted, without their values.
+// @Tags         Audit
+// @Produce      json
+// @Param        Authorization  header    string  true   "Bearer token"
+// @Param        page           query     int     false  "Page number (default 1)"
+// @Param        page_size      query     int     false  "Page size (default 20, max 100)"
+// @Param        actor          query     string  false  "Actor name or ID"
+// @Param        action         query     string  false  "Action, e.g. user.update; a trailing * matches a prefix, e.g. group.*"
+// @Param        target_type    query     string  false  "Target type, e.g. user or group"
+// @Param        target_id      query     string  false  "Target ID"
+// @Param        outcome        query     string  false  "success or failure"
+// @Param        protocol       query     string  false  "http or ldap"
+// @Param        ip             query     string  false  "Client IP address"
+// @Param        from           query     string  false  "Earliest time, RFC 3339"
+// @Param        to             query     string  false  "Latest time, exclusive, RFC 3339"
+// @Success      200            {object}  Response{data=domain.ListResult[domain.AuditEvent]}
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/audit [get]
+func (h *AuditHandler) List(c *gin.Context) {
+	filter, ok := auditFilter(c)
+	if !ok {
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.auditService.List(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to list audit events")
+		return
+	}
+	OK(c, result)
+}
+
+// Export godoc
+// @Summary      Export audit events
+// @Description  Download the audit events matching the filters as JSON lines, one event per line, oldest first. The filters are those of the list endpoint.
+// @Tags         Audit
+// @Produce      json
+// @Param        Authorization  header    string  true   "Bearer token"
+// @Param        actor          query     string  false  "Actor name or ID"
+// @Param        action         query     string  false  "Action; a trailing * matches a prefix"
+// @Param        target_type    query     string  false  "Target type"
+// @Param        target_id      query     string  false  "Target ID"
+// @Param        outcome        query     string  false  "success or failure"
+// @Param        protocol       query     string  false  "http or ldap"
+// @Param        ip             query     string  false  "Client IP address"
+// @Param        from           query     string  false  "Earliest time, RFC 3339"
+// @Param        to             query     string  false  "Latest time, exclusive, RFC 3339"
+// @Success      200            {object}  domain.AuditEvent  "One event per line (application/x-ndjson)"
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/audit/export [get]
+func (h *AuditHandler) Export(c *gin.Context) {
+	filter, ok := auditFilter(c)
+	if !ok {
+		return
+	}
+
+	// The status is sent with the first event, so a failure before it can
+	// still be reported; later ones can only cut the download short.
+	enc := json.NewEncoder(c.Writer)
+	started := false
+	err := h.auditService.Export(c.Request.Context(), filter, func(e *domain.AuditEvent) error {
+		if !started {
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Header("Content-Disposition", `attachment; filename="audit.jsonl"`)
+			c.Status(http.StatusOK)
+			started = true
+		}
+		return enc.Encode(e)
+	})
+	switch {
+	case err != nil && !started:
+		Error(c, http.StatusInternalServerError, "failed to export audit events")
+	case err != nil:
+		_ = c.Error(err)
+	case !started:
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+	}
+}
+
+// auditFilter reads the audit event filters from the query string. It
+// writes a 400 response and returns false if a filter is malformed.
+func auditFilter(c *gin.Context) (domain.AuditFilter, bool) {
+	filter := domain.AuditFilter{
+		Actor:      c.Query("actor"),
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+		Outcome:    domain.AuditOutcome(c.Query("outcome")),
+		Protocol:   c.Query("protocol"),
+		IPAddress:  c.Query("ip"),
+	}
+	switch filter.Outcome {
+	case "", domain.AuditSuccess, domain.AuditFailure:
+	default:
+		Error(c, http.StatusBadRequest, "outcome must be success or failure")
+		return filter, false
+	}
+	for name, bound := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		v := c.Query(name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			Error(c, http.StatusBadRequest, name+" must be an RFC 3339 time")
+			return filter, false
+		}
+		*bound = &t
+	}
+	return filter, true
+}
@@ -1,10 +1,13 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
+	"github.com/qinzj/claude-demo/internal/domain"
 	"github.com/qinzj/claude-demo/internal/service"
 )
 
@@ -24,16 +27,33 @@ type LoginReq struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// MFAVerifyReq completes a login challenged for a second factor.
+type MFAVerifyReq struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// MFAEnrollReq starts TOTP enrollment during a challenged login.
+type MFAEnrollReq struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+}
+
+// RefreshReq is the token refresh request DTO.
+type RefreshReq struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
 // Login godoc
 // @Summary      User login
-// @Description  Authenticate with username and password, returns JWT token
+// @Description  Authenticate with username and password; opens a session and returns a short-lived access token plus a refresh token. If the user has MFA enabled, or a group requires it, no session is opened yet: the response has mfa_required set and an mfa_token for /auth/mfa/verify, or for /auth/mfa/enroll when enrollment_required is set. If password_change_required is set, the password has expired or was reset and the token only allows changing it via /me/password. Too many failed logins lock the account, or block the client address, for a while: the response is 429 with a Retry-After header.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        request  body      LoginReq  true  "Login credentials"
-// @Success      200      {object}  Response{data=object{token=string,user=object{id=string,username=string,display_name=string}}}
+// @Success      200      {object}  Response{data=object{token=string,refresh_token=string,expires_at=string,password_change_required=bool,user=object{id=string,username=string,display_name=string},mfa_required=bool,mfa_token=string,enrollment_required=bool}}
 // @Failure      400      {object}  Response
 // @Failure      401      {object}  Response
+// @Failure      429      {object}  Response
 // @Router       /api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginReq
@@ -42,29 +62,191 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, u, err := h.authService.Login(c.Request.Context(), req.Username, req.Password)
+	tokens, u, err := h.authService.Login(c.Request.Context(), req.Username, req.Password)
+	var challenge *service.MFAChallenge
+	if errors.As(err, &challenge) {
+		OK(c, gin.H{
+			"mfa_required":        true,
+			"mfa_token":           challenge.Token,
+			"expires_at":          challenge.ExpiresAt,
+			"enrollment_required": challenge.EnrollmentRequired,
+		})
+		return
+	}
+	if writeLockoutError(c, err) {
+		return
+	}
 	if err != nil {
 		Error(c, http.StatusUnauthorized, "authentication failed")
 		return
 	}
 
-	OK(c, gin.H{
-		"token": token,
-		"user": gin.H{
-			"id":           u.ID,
-			"username":     u.Username,
-			"display_name": u.DisplayName,
-		},
-	})
+	OK(c, loginResponse(tokens, u))
+}
+
+// VerifyMFA godoc
+// @Summary      Verify second factor
+// @Description  Complete an MFA-challenged login with a TOTP code or a recovery code. Each challenge allows a few attempts.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      MFAVerifyReq  true  "Challenge token and code"
+// @Success      200      {object}  Response{data=object{token=string,refresh_token=string,expires_at=string,password_change_required=bool,user=object{id=string,username=string,display_name=string}}}
+// @Failure      400      {object}  Response
+// @Failure      401      {object}  Response
+// @Router       /api/v1/auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req MFAVerifyReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	tokens, u, err := h.authService.VerifyMFA(c.Request.Context(), req.MFAToken, req.Code)
+	if err != nil {
+		Error(c, http.StatusUnauthorized, "mfa verification failed")
+		return
+	}
+	OK(c, loginResponse(tokens, u))
+}
+
+// BeginMFAEnrollment godoc
+// @Summary      Start enrollment during login
+// @Description  For a login challenged with enrollment_required, generate a TOTP secret and its otpauth:// provisioning URI
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      MFAEnrollReq  true  "Challenge token"
+// @Success      200      {object}  Response{data=domain.TOTPEnrollment}
+// @Failure      400      {object}  Response
+// @Failure      401      {object}  Response
+// @Failure      409      {object}  Response
+// @Router       /api/v1/auth/mfa/enroll [post]
+func (h *AuthHandler) BeginMFAEnrollment(c *gin.Context) {
+	var req MFAEnrollReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	enrollment, err := h.authService.BeginMFAEnrollment(c.Request.Context(), req.MFAToken)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidMFAChallenge) {
+			Error(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		writeMFAError(c, err)
+		return
+	}
+	OK(c, enrollment)
+}
+
+// ConfirmMFAEnrollment godoc
+// @Summary      Confirm enrollment during login
+// @Description  Enable MFA with a code from the authenticator app and open the session. The recovery codes are shown only once.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      MFAVerifyReq  true  "Challenge token and TOTP code"
+// @Success      200      {object}  Response{data=object{token=string,refresh_token=string,expires_at=string,user=object{id=string,username=string,display_name=string},recovery_codes=[]string}}
+// @Failure      400      {object}  Response
+// @Failure      401      {object}  Response
+// @Router       /api/v1/auth/mfa/enroll/confirm [post]
+func (h *AuthHandler) ConfirmMFAEnrollment(c *gin.Context) {
+	var req MFAVerifyReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	tokens, u, codes, err := h.authService.ConfirmMFAEnrollment(c.Request.Context(), req.MFAToken, req.Code)
+	if err != nil {
+		Error(c, http.StatusUnauthorized, "mfa verification failed")
+		return
+	}
+	resp := loginResponse(tokens, u)
+	resp["recovery_codes"] = codes
+	OK(c, resp)
+}
+
+// Refresh godoc
+// @Summary      Refresh tokens
+// @Description  Exchange a refresh token for a new access token; the refresh token is rotated and the old one becomes invalid
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      RefreshReq  true  "Refresh token"
+// @Success      200      {object}  Response{data=service.TokenPair}
+// @Failure      400      {object}  Response
+// @Failure      401      {object}  Response
+// @Router       /api/v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	tokens, err := h.authService.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		Error(c, http.StatusUnauthorized, "invalid or expired refresh token")
+		return
+	}
+	OK(c, tokens)
 }
 
 // Logout godoc
 // @Summary      User logout
-// @Description  Stateless logout (JWT is not invalidated server-side)
+// @Description  Revoke the current session; its access and refresh tokens stop working immediately
 // @Tags         Auth
 // @Produce      json
-// @Success      200  {object}  Response
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response
+// @Failure      401            {object}  Response
 // @Router       /api/v1/auth/logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
+	if err := h.authService.RevokeSession(c.Request.Context(), currentUserID(c), currentSessionID(c)); err != nil {
+		Error(c, http.StatusInternalServerError, "failed to revoke session")
+		return
+	}
 	OK(c, nil)
 }
+
+// JWKS godoc
+// @Summary      JSON Web Key Set
+// @Description  Public keys for verifying access tokens, selected by the token's "kid" header. Returned as a bare JWK Set, not wrapped in Response.
+// @Tags         Auth
+// @Produce      json
+// @Success      200  {object}  domain.JWKSet
+// @Failure      500  {object}  Response
+// @Router       /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	set, err := h.authService.JWKS(c.Request.Context())
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to load signing keys")
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, set)
+}
+
+// loginResponse is the body returned when a login opens a session.
+func loginResponse(tokens *service.TokenPair, u *domain.User) gin.H {
+	return gin.H{
+		"token":                    tokens.AccessToken,
+		"refresh_token":            tokens.RefreshToken,
+		"expires_at":               tokens.ExpiresAt,
+		"password_change_required": tokens.PasswordChangeRequired,
+		"user": gin.H{
+			"id":           u.ID,
+			"username":     u.Username,
+			"display_name": u.DisplayName,
+		},
+	}
+}
+
+// currentSessionID returns the session ID of the access token set by Authenticate.
+func currentSessionID(c *gin.Context) uuid.UUID {
+	id, _ := uuid.Parse(c.GetString("session_id"))
+	return id
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// CSVHandler handles CSV import and export of users.
+type CSVHandler struct {
+	csvService *service.CSVService
+}
+
+// NewCSVHandler creates a new CSVHandler.
+func NewCSVHandler(csvSvc *service.CSVService) *CSVHandler {
+	return &CSVHandler{csvService: csvSvc}
+}
+
+// Export godoc
+// @Summary      Export users as CSV
+// @Description  Download the users matching the filters of the user list as a UTF-8 CSV file with a byte order mark: one column per field, then one column attr.<name> per custom attribute. Groups and multiple attribute values are separated by ";", and managers are given by username. The file can be imported back.
+// @Tags         User
+// @Produce      text/csv
+// @Param        Authorization  header    string  true   "Bearer token"
+// @Param        search         query     string  false  "Search by username, display_name, or email"
+// @Param        attr.{name}    query     string  false  "Exact match on an indexed custom attribute, e.g. attr.department=Sales"
+// @Success      200            {string}  string  "CSV file"
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/users/export.csv [get]
+func (h *CSVHandler) Export(c *gin.Context) {
+	var buf bytes.Buffer
+	if err := h.csvService.ExportUsers(c.Request.Context(), &buf, c.Query("search"), attributeQuery(c)); err != nil {
+		if errors.Is(err, service.ErrInvalidAttributes) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to export users: "+err.Error())
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="users.csv"`)
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// Import godoc
+// @Summary      Import users from CSV
+// @Description  Create and update users, matched by username, and their group memberships from the CSV file in the request body, and return the outcome of each row, or with dry_run=true only what would be done. The first row is the header; columns named after a field (username, display_name, email, phone, status, manager, account_expires_at, groups, password) or a custom attribute are imported, and others can be mapped with column.<field>=<header>. Empty cells leave fields unchanged. Every row is validated first: with mode=all_or_nothing (default) nothing is applied if any row is invalid, and the rows are applied in one transaction rolled back if any fails; with mode=best_effort the valid rows are applied. New users without a password get a generated one, returned once in the report (credentials=generate, default), an invitation email (credentials=invite) or none (credentials=none). With format=csv the report is returned as the imported file with result and errors columns, and with errors_only=true only its failed rows. Returns 400, importing nothing, if the file or the options are invalid.
+// @Tags         User
+// @Accept       text/csv
+// @Produce      json
+// @Param        Authorization   header    string  true   "Bearer token"
+// @Param        mode            query     string  false  "all_or_nothing (default) or best_effort"
+// @Param        credentials     query     string  false  "generate (default), invite or none"
+// @Param        replace_groups  query     bool    false  "Also remove users from the groups their row does not list"
+// @Param        dry_run         query     bool    false  "Only report the changes"
+// @Param        column.{field}  query     string  false  "Header of the column holding a field, e.g. column.username=Employee ID"
+// @Param        format          query     string  false  "json (default) or csv"
+// @Param        errors_only     query     bool    false  "With format=csv, only return the failed rows"
+// @Param        body            body      string  true   "CSV file"
+// @Success      200             {object}  Response{data=domain.CSVImportReport}
+// @Failure      400             {object}  Response
+// @Failure      500             {object}  Response
+// @Failure      503             {object}  Response
+// @Router       /api/v1/users/import.csv [post]
+func (h *CSVHandler) Import(c *gin.Context) {
+	opts := domain.CSVImportOptions{
+		Mode:        domain.CSVImportMode(c.Query("mode")),
+		Credentials: domain.CSVCredentials(c.Query("credentials")),
+	}
+	opts.ReplaceGroups, _ = strconv.ParseBool(c.DefaultQuery("replace_groups", "false"))
+	opts.DryRun, _ = strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	for key, vals := range c.Request.URL.Query() {
+		field, ok := strings.CutPrefix(key, "column.")
+		if !ok || field == "" || len(vals) == 0 {
+			continue
+		}
+		if opts.Columns == nil {
+			opts.Columns = make(map[string]string)
+		}
+		opts.Columns[field] = vals[0]
+	}
+
+	report, err := h.csvService.ImportUsers(c.Request.Context(), c.Request.Body, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCSV):
+			Error(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrInvitationUnavailable):
+			Error(c, http.StatusServiceUnavailable, err.Error())
+		default:
+			Error(c, http.StatusInternalServerError, "failed to import users: "+err.Error())
+		}
+		return
+	}
+	if c.Query("format") != "csv" {
+		OK(c, report)
+		return
+	}
+	errorsOnly, _ := strconv.ParseBool(c.DefaultQuery("errors_only", "false"))
+	var buf bytes.Buffer
+	if err := service.WriteCSVImportReport(&buf, report, errorsOnly); err != nil {
+		Error(c, http.StatusInternalServerError, "failed to write report: "+err.Error())
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="import-report.csv"`)
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// DirectorySyncHandler handles upstream directory synchronization endpoints.
+type DirectorySyncHandler struct {
+	directorySyncService *service.DirectorySyncService
+}
+
+// NewDirectorySyncHandler creates a new DirectorySyncHandler.
+func NewDirectorySyncHandler(directorySyncSvc *service.DirectorySyncService) *DirectorySyncHandler {
+	return &DirectorySyncHandler{directorySyncService: directorySyncSvc}
+}
+
+// Sources godoc
+// @Summary      List directory sources
+// @Description  List the upstream LDAP directories configured under directory_sync, with the report of their last synchronization since the server started. Bind passwords are never returned.
+// @Tags         DirectorySync
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=[]domain.DirectorySourceStatus}
+// @Router       /api/v1/directory-sync/sources [get]
+func (h *DirectorySyncHandler) Sources(c *gin.Context) {
+	OK(c, h.directorySyncService.Sources())
+}
+
+// Sync godoc
+// @Summary      Synchronize directory source
+// @Description  Synchronize users and groups from an upstream LDAP directory now and return what was done, or with dry_run=true only what would be done. Returns 502, changing nothing, if the directory cannot be read or returns no users or groups where some were synchronized before.
+// @Tags         DirectorySync
+// @Produce      json
+// @Param        Authorization  header    string  true   "Bearer token"
+// @Param        name           path      string  true   "Source name"
+// @Param        dry_run        query     bool    false  "Only report the changes"
+// @Success      200            {object}  Response{data=domain.DirectorySyncReport}
+// @Failure      404            {object}  Response
+// @Failure      502            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/directory-sync/sources/{name}/sync [post]
+func (h *DirectorySyncHandler) Sync(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+
+	report, err := h.directorySyncService.Sync(c.Request.Context(), c.Param("name"), dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrDirectorySourceNotFound):
+			Error(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrDirectoryUnreachable), errors.Is(err, service.ErrDirectoryEmpty):
+			Error(c, http.StatusBadGateway, err.Error())
+		default:
+			Error(c, http.StatusInternalServerError, "failed to synchronize directory: "+err.Error())
+		}
+		return
+	}
+	OK(c, report)
+}
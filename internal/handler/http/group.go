@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -22,16 +23,21 @@ func NewGroupHandler(groupSvc *service.GroupService) *GroupHandler {
 
 // CreateGroupReq is the request DTO for creating a group.
 type CreateGroupReq struct {
-	Name        string  `json:"name" binding:"required,max=64"`
-	Description string  `json:"description,omitempty" binding:"omitempty,max=255"`
-	ParentID    *string `json:"parent_id,omitempty"`
+	Name        string              `json:"name" binding:"required,max=64"`
+	Description string              `json:"description,omitempty" binding:"omitempty,max=255"`
+	ParentID    *string             `json:"parent_id,omitempty"`
+	OwnerUsers  []string            `json:"owner_user_ids,omitempty"`
+	OwnerGroups []string            `json:"owner_group_ids,omitempty"`
+	Attributes  map[string][]string `json:"attributes,omitempty"`
 }
 
 // UpdateGroupReq is the request DTO for updating a group.
 type UpdateGroupReq struct {
-	Name        *string `json:"name,omitempty" binding:"omitempty,max=64"`
-	Description *string `json:"description,omitempty" binding:"omitempty,max=255"`
-	ParentID    *string `json:"parent_id,omitempty"`
+	Name        *string             `json:"name,omitempty" binding:"omitempty,max=64"`
+	Description *string             `json:"description,omitempty" binding:"omitempty,max=255"`
+	ParentID    *string             `json:"parent_id,omitempty"`
+	RequireMFA  *bool               `json:"require_mfa,omitempty"`
+	Attributes  map[string][]string `json:"attributes,omitempty"`
 }
 
 // AddMembersReq is the request DTO for adding members to a group.
@@ -39,9 +45,15 @@ type AddMembersReq struct {
 	UserIDs []string `json:"user_ids" binding:"required,min=1"`
 }
 
+// AddOwnersReq is the request DTO for adding owners to a group.
+type AddOwnersReq struct {
+	UserIDs  []string `json:"user_ids,omitempty"`
+	GroupIDs []string `json:"group_ids,omitempty"`
+}
+
 // Create godoc
 // @Summary      Create group
-// @Description  Create a new user group, optionally with a parent group
+// @Description  Create a new user group, optionally with a parent group and owners (defaults to the caller)
 // @Tags         Group
 // @Accept       json
 // @Produce      json
@@ -61,6 +73,7 @@ func (h *GroupHandler) Create(c *gin.Context) {
 	input := domain.CreateGroupInput{
 		Name:        req.Name,
 		Description: req.Description,
+		Attributes:  req.Attributes,
 	}
 	if req.ParentID != nil {
 		pid, err := uuid.Parse(*req.ParentID)
@@ -70,9 +83,25 @@ func (h *GroupHandler) Create(c *gin.Context) {
 		}
 		input.ParentID = &pid
 	}
+	ownerUsers, err := parseUUIDs(req.OwnerUsers)
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid owner_user_ids: "+err.Error())
+		return
+	}
+	ownerGroups, err := parseUUIDs(req.OwnerGroups)
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid owner_group_ids: "+err.Error())
+		return
+	}
+	input.OwnerUserIDs = ownerUsers
+	input.OwnerGroupIDs = ownerGroups
 
 	g, err := h.groupService.CreateGroup(c.Request.Context(), input)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidAttributes) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		Error(c, http.StatusInternalServerError, "failed to create group: "+err.Error())
 		return
 	}
@@ -125,7 +154,7 @@ func (h *GroupHandler) List(c *gin.Context) {
 
 // Update godoc
 // @Summary      Update group
-// @Description  Update group fields (name, description, parent_id)
+// @Description  Update group fields (name, description, parent_id, attributes)
 // @Tags         Group
 // @Accept       json
 // @Produce      json
@@ -152,6 +181,8 @@ func (h *GroupHandler) Update(c *gin.Context) {
 	input := domain.UpdateGroupInput{
 		Name:        req.Name,
 		Description: req.Description,
+		RequireMFA:  req.RequireMFA,
+		Attributes:  req.Attributes,
 	}
 	if req.ParentID != nil {
 		pid, err := uuid.Parse(*req.ParentID)
@@ -164,6 +195,10 @@ func (h *GroupHandler) Update(c *gin.Context) {
 
 	g, err := h.groupService.UpdateGroup(c.Request.Context(), id, input)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidAttributes) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		Error(c, http.StatusInternalServerError, "failed to update group: "+err.Error())
 		return
 	}
@@ -207,6 +242,7 @@ func (h *GroupHandler) Delete(c *gin.Context) {
 // @Success      200            {object}  Response
 // @Failure      400            {object}  Response
 // @Failure      500            {object}  Response
+// @Failure      403            {object}  Response
 // @Router       /api/v1/groups/{id}/members [post]
 func (h *GroupHandler) AddMembers(c *gin.Context) {
 	groupID, err := uuid.Parse(c.Param("id"))
@@ -232,6 +268,10 @@ func (h *GroupHandler) AddMembers(c *gin.Context) {
 	}
 
 	if err := h.groupService.AddMembers(c.Request.Context(), groupID, userIDs); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		Error(c, http.StatusInternalServerError, "failed to add members")
 		return
 	}
@@ -249,6 +289,7 @@ func (h *GroupHandler) AddMembers(c *gin.Context) {
 // @Success      200            {object}  Response
 // @Failure      400            {object}  Response
 // @Failure      500            {object}  Response
+// @Failure      403            {object}  Response
 // @Router       /api/v1/groups/{id}/members/{uid} [delete]
 func (h *GroupHandler) RemoveMember(c *gin.Context) {
 	groupID, err := uuid.Parse(c.Param("id"))
@@ -264,6 +305,10 @@ func (h *GroupHandler) RemoveMember(c *gin.Context) {
 	}
 
 	if err := h.groupService.RemoveMember(c.Request.Context(), groupID, userID); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		Error(c, http.StatusInternalServerError, "failed to remove member")
 		return
 	}
@@ -295,3 +340,143 @@ func (h *GroupHandler) GetMembers(c *gin.Context) {
 	}
 	OK(c, users)
 }
+
+// AddOwners godoc
+// @Summary      Add owners
+// @Description  Add owning users and groups; owners may manage the group's members and owners
+// @Tags         Group
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string        true  "Bearer token"
+// @Param        id             path      string        true  "Group ID (UUID)"
+// @Param        request        body      AddOwnersReq  true  "User and group IDs to add as owners"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/groups/{id}/owners [post]
+func (h *GroupHandler) AddOwners(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid group id")
+		return
+	}
+
+	var req AddOwnersReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+	if len(req.UserIDs) == 0 && len(req.GroupIDs) == 0 {
+		Error(c, http.StatusBadRequest, "user_ids or group_ids is required")
+		return
+	}
+
+	userIDs, err := parseUUIDs(req.UserIDs)
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user_id: "+err.Error())
+		return
+	}
+	groupIDs, err := parseUUIDs(req.GroupIDs)
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid group_id: "+err.Error())
+		return
+	}
+
+	if err := h.groupService.AddOwners(c.Request.Context(), groupID, userIDs, groupIDs); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to add owners")
+		return
+	}
+	OK(c, nil)
+}
+
+// RemoveOwnerUser godoc
+// @Summary      Remove owner user
+// @Description  Remove an owning user from a group
+// @Tags         Group
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Group ID (UUID)"
+// @Param        uid            path      string  true  "User ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/groups/{id}/owners/users/{uid} [delete]
+func (h *GroupHandler) RemoveOwnerUser(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid group id")
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("uid"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if err := h.groupService.RemoveOwnerUser(c.Request.Context(), groupID, userID); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to remove owner")
+		return
+	}
+	OK(c, nil)
+}
+
+// RemoveOwnerGroup godoc
+// @Summary      Remove owner group
+// @Description  Remove an owning group from a group
+// @Tags         Group
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Group ID (UUID)"
+// @Param        gid            path      string  true  "Owner group ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/groups/{id}/owners/groups/{gid} [delete]
+func (h *GroupHandler) RemoveOwnerGroup(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid group id")
+		return
+	}
+
+	ownerID, err := uuid.Parse(c.Param("gid"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid owner group id")
+		return
+	}
+
+	if err := h.groupService.RemoveOwnerGroup(c.Request.Context(), groupID, ownerID); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to remove owner")
+		return
+	}
+	OK(c, nil)
+}
+
+// parseUUIDs parses a list of UUID strings, reporting the first invalid one.
+func parseUUIDs(ids []string) ([]uuid.UUID, error) {
+	out := make([]uuid.UUID, len(ids))
+	for i, idStr := range ids {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, errors.New(idStr)
+		}
+		out[i] = id
+	}
+	return out, nil
+}
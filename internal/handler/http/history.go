@@ -0,0 +1,189 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// History godoc
+// @Summary      Get user history
+// @Description  Get every version of a user's profile and every change to their group memberships, oldest first, with who made each change. Versions are recorded in the same transaction as the change; deleted users keep their history.
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Success      200            {object}  Response{data=domain.UserHistory}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/users/{id}/history [get]
+func (h *UserHandler) History(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	hist, err := h.userService.History(c.Request.Context(), id)
+	if err != nil {
+		writeHistoryError(c, err, "failed to get user history")
+		return
+	}
+	OK(c, hist)
+}
+
+// Snapshot godoc
+// @Summary      Get user at a point in time
+// @Description  Reconstruct a user's profile and group memberships as they were at the given time
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Param        at             query     string  true  "Point in time, RFC 3339"
+// @Success      200            {object}  Response{data=domain.UserSnapshot}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/users/{id}/snapshot [get]
+func (h *UserHandler) Snapshot(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	at, ok := historyTime(c)
+	if !ok {
+		return
+	}
+
+	snap, err := h.userService.SnapshotAt(c.Request.Context(), id, at)
+	if err != nil {
+		writeHistoryError(c, err, "failed to reconstruct user")
+		return
+	}
+	OK(c, snap)
+}
+
+// Revert godoc
+// @Summary      Revert user to a version
+// @Description  Restore a user's display name, email, phone, manager, account expiry, status and extension attributes to those of an earlier version, recording a new version. Passwords, MFA and group memberships are not changed, nor is the status of pending users. Attributes no longer defined are not restored; required attributes without a value in the version keep their current values. Returns 400 if a restored value no longer passes its attribute's definition.
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Param        version        path      int     true  "Version number"
+// @Success      200            {object}  Response{data=domain.User}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/users/{id}/history/{version}/revert [post]
+func (h *UserHandler) Revert(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		Error(c, http.StatusBadRequest, "invalid version")
+		return
+	}
+
+	u, err := h.userService.Revert(c.Request.Context(), id, version)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidManager) || errors.Is(err, service.ErrInvalidAttributes) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		writeHistoryError(c, err, "failed to revert user")
+		return
+	}
+	OK(c, u)
+}
+
+// History godoc
+// @Summary      Get group history
+// @Description  Get every version of a group's settings and every change to its members, oldest first, with who made each change. Deleted groups keep their history.
+// @Tags         Group
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Group ID (UUID)"
+// @Success      200            {object}  Response{data=domain.GroupHistory}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/groups/{id}/history [get]
+func (h *GroupHandler) History(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid group id")
+		return
+	}
+
+	hist, err := h.groupService.History(c.Request.Context(), id)
+	if err != nil {
+		writeHistoryError(c, err, "failed to get group history")
+		return
+	}
+	OK(c, hist)
+}
+
+// Snapshot godoc
+// @Summary      Get group at a point in time
+// @Description  Reconstruct a group and its members as they were at the given time, e.g. who was in a group on a given day
+// @Tags         Group
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Group ID (UUID)"
+// @Param        at             query     string  true  "Point in time, RFC 3339"
+// @Success      200            {object}  Response{data=domain.GroupSnapshot}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/groups/{id}/snapshot [get]
+func (h *GroupHandler) Snapshot(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid group id")
+		return
+	}
+	at, ok := historyTime(c)
+	if !ok {
+		return
+	}
+
+	snap, err := h.groupService.SnapshotAt(c.Request.Context(), id, at)
+	if err != nil {
+		writeHistoryError(c, err, "failed to reconstruct group")
+		return
+	}
+	OK(c, snap)
+}
+
+// historyTime reads the required point in time of a snapshot. It writes a
+// 400 response and returns false if it is missing or malformed.
+func historyTime(c *gin.Context) (time.Time, bool) {
+	at, err := time.Parse(time.RFC3339, c.Query("at"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "at must be an RFC 3339 time")
+		return at, false
+	}
+	return at, true
+}
+
+// writeHistoryError writes a 404 response for a missing version and a 500
+// response with msg otherwise.
+func writeHistoryError(c *gin.Context, err error, msg string) {
+	if errors.Is(err, service.ErrVersionNotFound) {
+		Error(c, http.StatusNotFound, err.Error())
+		return
+	}
+	Error(c, http.StatusInternalServerError, msg)
+}
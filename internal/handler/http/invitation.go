@@ -0,0 +1,246 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// InvitationHandler handles inviting users and the emailed invitations
+// with which they activate their accounts.
+type InvitationHandler struct {
+	invitationService *service.InvitationService
+}
+
+// NewInvitationHandler creates a new InvitationHandler.
+func NewInvitationHandler(invitationSvc *service.InvitationService) *InvitationHandler {
+	return &InvitationHandler{invitationService: invitationSvc}
+}
+
+// InviteUserReq is the request DTO for inviting a user. The invited user
+// chooses their own password.
+type InviteUserReq struct {
+	Username         string              `json:"username" binding:"required,max=64"`
+	DisplayName      string              `json:"display_name" binding:"required,max=128"`
+	Email            string              `json:"email" binding:"required,email,max=255"`
+	Phone            string              `json:"phone,omitempty" binding:"omitempty,max=32"`
+	Attributes       map[string][]string `json:"attributes,omitempty"`
+	AccountExpiresAt *time.Time          `json:"account_expires_at,omitempty"`
+}
+
+// InvitationTokenReq carries the token of an invitation link.
+type InvitationTokenReq struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// AcceptInvitationReq accepts an invitation with the password the user
+// chose and, if they enrolled MFA, a code from their authenticator app.
+type AcceptInvitationReq struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	TOTPCode string `json:"totp_code,omitempty"`
+}
+
+// InvitationResp describes an open invitation.
+type InvitationResp struct {
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	Email       string    `json:"email"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	MFARequired bool      `json:"mfa_required"`
+}
+
+// AcceptInvitationResp holds the recovery codes of a user who enrolled MFA
+// while accepting the invitation. They are shown only once.
+type AcceptInvitationResp struct {
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+// Invite godoc
+// @Summary      Invite user
+// @Description  Create a pending user (requires user:write) and email them an invitation link, with which they choose their password, optionally enroll MFA and activate the account. Pending users cannot log in, including over LDAP. If the email cannot be sent, the user is still created and 502 is returned; the invitation can then be resent. Returns 503 if no mail server is configured.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string         true  "Bearer token"
+// @Param        request        body      InviteUserReq  true  "User info"
+// @Success      200            {object}  Response{data=domain.User}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      502            {object}  Response{data=domain.User}
+// @Failure      503            {object}  Response
+// @Router       /api/v1/users/invitations [post]
+func (h *InvitationHandler) Invite(c *gin.Context) {
+	var req InviteUserReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	u, err := h.invitationService.Invite(c.Request.Context(), domain.InviteUserInput{
+		Username:         req.Username,
+		DisplayName:      req.DisplayName,
+		Email:            req.Email,
+		Phone:            req.Phone,
+		Attributes:       req.Attributes,
+		AccountExpiresAt: req.AccountExpiresAt,
+	})
+	if err != nil {
+		switch {
+		case u != nil && errors.Is(err, service.ErrMailDelivery):
+			ErrorWithData(c, http.StatusBadGateway, "user created, but failed to send invitation email", u)
+		case errors.Is(err, service.ErrInvalidAttributes):
+			Error(c, http.StatusBadRequest, err.Error())
+		case !writeInvitationError(c, err):
+			Error(c, http.StatusInternalServerError, "failed to invite user: "+err.Error())
+		}
+		return
+	}
+	OK(c, u)
+}
+
+// Resend godoc
+// @Summary      Resend invitation
+// @Description  Email a new invitation to a pending user (requires user:write); earlier invitation links stop working. Returns 400 if the user is not pending, 503 if no mail server is configured.
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Failure      502            {object}  Response
+// @Failure      503            {object}  Response
+// @Router       /api/v1/users/{id}/invitation [post]
+func (h *InvitationHandler) Resend(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if err := h.invitationService.Resend(c.Request.Context(), id); err != nil {
+		if !writeInvitationError(c, err) {
+			Error(c, http.StatusNotFound, "user not found")
+		}
+		return
+	}
+	OK(c, nil)
+}
+
+// Check godoc
+// @Summary      Check invitation
+// @Description  Return the invited user, when the invitation expires and whether the user must enroll MFA to accept it, or 400 if it is unknown, expired or already accepted
+// @Tags         Auth
+// @Produce      json
+// @Param        token  query     string  true  "Token from the invitation link"
+// @Success      200    {object}  Response{data=InvitationResp}
+// @Failure      400    {object}  Response
+// @Router       /api/v1/auth/invitation [get]
+func (h *InvitationHandler) Check(c *gin.Context) {
+	ctx := c.Request.Context()
+	u, inv, err := h.invitationService.Check(ctx, c.Query("token"))
+	if err != nil {
+		if !writeInvitationError(c, err) {
+			Error(c, http.StatusInternalServerError, "failed to check invitation")
+		}
+		return
+	}
+	required, err := h.invitationService.MFARequired(ctx, u.ID)
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to check invitation")
+		return
+	}
+	OK(c, InvitationResp{
+		Username:    u.Username,
+		DisplayName: u.DisplayName,
+		Email:       u.Email,
+		ExpiresAt:   inv.ExpiresAt,
+		MFARequired: required,
+	})
+}
+
+// BeginMFAEnrollment godoc
+// @Summary      Start TOTP enrollment for an invitation
+// @Description  Generate a TOTP secret for the invited user and its otpauth:// provisioning URI, to be shown as a QR code. It is enabled by accepting the invitation with a code from the authenticator app.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      InvitationTokenReq  true  "Invitation token"
+// @Success      200      {object}  Response{data=domain.TOTPEnrollment}
+// @Failure      400      {object}  Response
+// @Router       /api/v1/auth/invitation/mfa [post]
+func (h *InvitationHandler) BeginMFAEnrollment(c *gin.Context) {
+	var req InvitationTokenReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	enrollment, err := h.invitationService.BeginMFAEnrollment(c.Request.Context(), req.Token)
+	if err != nil {
+		if !writeInvitationError(c, err) {
+			writeMFAError(c, err)
+		}
+		return
+	}
+	OK(c, enrollment)
+}
+
+// Accept godoc
+// @Summary      Accept invitation
+// @Description  Set the invited user's password and enable the account. The password must satisfy the user's password policies. A totp_code for the secret from the enrollment endpoint enables MFA too, and is required if a group of the user demands MFA; the recovery codes are then returned, shown only once. If the password or code is refused, the invitation can be used again.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      AcceptInvitationReq  true  "Token, password and optional TOTP code"
+// @Success      200      {object}  Response{data=AcceptInvitationResp}
+// @Failure      400      {object}  Response{data=PasswordViolationsResp}
+// @Router       /api/v1/auth/invitation/accept [post]
+func (h *InvitationHandler) Accept(c *gin.Context) {
+	var req AcceptInvitationReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	codes, err := h.invitationService.Accept(c.Request.Context(), req.Token, domain.AcceptInvitationInput{
+		Password: req.Password,
+		TOTPCode: req.TOTPCode,
+	})
+	if err != nil {
+		if writePasswordPolicyError(c, err) || writeInvitationError(c, err) {
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to accept invitation")
+		return
+	}
+	OK(c, AcceptInvitationResp{RecoveryCodes: codes})
+}
+
+// writeInvitationError writes the response for an invitation error the
+// service reports with a sentinel, and reports whether it did.
+func writeInvitationError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrInvalidInvitation),
+		errors.Is(err, service.ErrUserNotPending),
+		errors.Is(err, service.ErrMFARequired),
+		errors.Is(err, service.ErrMFANotEnabled),
+		errors.Is(err, service.ErrInvalidMFACode),
+		errors.Is(err, service.ErrPasswordManagedUpstream):
+		Error(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrInvitationUnavailable):
+		Error(c, http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, service.ErrMailDelivery):
+		Error(c, http.StatusBadGateway, "failed to send invitation email")
+	default:
+		return false
+	}
+	return true
+}
@@ -6,16 +6,20 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/qinzj/claude-demo/internal/config"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
 )
 
-// LDAPConfigHandler handles LDAP configuration endpoints.
+// LDAPConfigHandler handles LDAP configuration endpoints. Changes are
+// recorded in the audit trail.
 type LDAPConfigHandler struct {
-	cfg *config.LDAPConfig
+	cfg          *config.LDAPConfig
+	auditService *service.AuditService
 }
 
 // NewLDAPConfigHandler creates a new LDAPConfigHandler.
-func NewLDAPConfigHandler(cfg *config.LDAPConfig) *LDAPConfigHandler {
-	return &LDAPConfigHandler{cfg: cfg}
+func NewLDAPConfigHandler(cfg *config.LDAPConfig, auditSvc *service.AuditService) *LDAPConfigHandler {
+	return &LDAPConfigHandler{cfg: cfg, auditService: auditSvc}
 }
 
 // GetConfig godoc
@@ -24,14 +28,20 @@ func NewLDAPConfigHandler(cfg *config.LDAPConfig) *LDAPConfigHandler {
 // @Tags         LDAP
 // @Produce      json
 // @Param        Authorization  header    string  true  "Bearer token"
-// @Success      200            {object}  Response{data=object{base_dn=string,mode=string,port=int}}
+// @Success      200            {object}  Response{data=object{base_dn=string,mode=string,port=int,otp=bool}}
 // @Router       /api/v1/ldap/config [get]
 func (h *LDAPConfigHandler) GetConfig(c *gin.Context) {
-	OK(c, gin.H{
+	OK(c, h.current())
+}
+
+// current returns the LDAP configuration as shown by GetConfig.
+func (h *LDAPConfigHandler) current() gin.H {
+	return gin.H{
 		"base_dn": h.cfg.BaseDN,
 		"mode":    h.cfg.Mode,
 		"port":    h.cfg.Port,
-	})
+		"otp":     h.cfg.OTP,
+	}
 }
 
 // UpdateConfigReq is the request DTO for updating LDAP config.
@@ -39,11 +49,12 @@ type UpdateConfigReq struct {
 	BaseDN string `json:"base_dn" binding:"required"`
 	Mode   string `json:"mode" binding:"required,oneof=openldap activedirectory"`
 	Port   int    `json:"port" binding:"required,min=1,max=65535"`
+	OTP    bool   `json:"otp"`
 }
 
 // UpdateConfig godoc
 // @Summary      Update LDAP config
-// @Description  Update the LDAP server configuration (base_dn, mode, port)
+// @Description  Update the LDAP server configuration (base_dn, mode, port, otp). With otp enabled, users who have MFA enabled bind with their password followed by a current TOTP code.
 // @Tags         LDAP
 // @Accept       json
 // @Produce      json
@@ -59,9 +70,24 @@ func (h *LDAPConfigHandler) UpdateConfig(c *gin.Context) {
 		return
 	}
 
+	before := h.current()
 	h.cfg.BaseDN = req.BaseDN
 	h.cfg.Mode = req.Mode
 	h.cfg.Port = req.Port
+	h.cfg.OTP = req.OTP
+
+	changes := service.AuditChanges(before, h.current())
+	if len(changes) > 0 {
+		if err := h.auditService.Record(c.Request.Context(), &domain.AuditEvent{
+			Action:     domain.AuditLDAPConfigUpdate,
+			TargetType: domain.AuditTargetLDAPConfig,
+			TargetName: "ldap",
+			Changes:    changes,
+		}); err != nil {
+			Error(c, http.StatusInternalServerError, "failed to record audit event")
+			return
+		}
+	}
 	OK(c, nil)
 }
 
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/qinzj/claude-demo/internal/config"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// LDIFExporter writes all users and groups as an LDIF file, with the
+// entries the LDAP server publishes.
+type LDIFExporter interface {
+	ExportLDIF(ctx context.Context, w io.Writer) error
+}
+
+// LDIFHandler handles LDIF import and export endpoints.
+type LDIFHandler struct {
+	exporter    LDIFExporter
+	ldifService *service.LDIFService
+	cfg         *config.LDAPConfig
+}
+
+// NewLDIFHandler creates a new LDIFHandler. Imported entries are named as
+// the LDAP server configured by cfg names them.
+func NewLDIFHandler(exporter LDIFExporter, ldifSvc *service.LDIFService, cfg *config.LDAPConfig) *LDIFHandler {
+	return &LDIFHandler{exporter: exporter, ldifService: ldifSvc, cfg: cfg}
+}
+
+// Export godoc
+// @Summary      Export LDIF
+// @Description  Download all users and then all groups as an LDIF file (RFC 2849), with the DNs and attributes the LDAP server returns for them in its current mode.
+// @Tags         LDIF
+// @Produce      text/x-ldif
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {string}  string  "LDIF file"
+// @Failure      500            {object}  Response
+// @Router       /api/v1/export.ldif [get]
+func (h *LDIFHandler) Export(c *gin.Context) {
+	var buf bytes.Buffer
+	if err := h.exporter.ExportLDIF(c.Request.Context(), &buf); err != nil {
+		Error(c, http.StatusInternalServerError, "failed to export LDIF: "+err.Error())
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="export.ldif"`)
+	c.Data(http.StatusOK, "text/x-ldif; charset=utf-8", buf.Bytes())
+}
+
+// Import godoc
+// @Summary      Import LDIF
+// @Description  Apply the content and change records (add, delete, modify, modrdn) of an LDIF file in the request body to users and groups, and return the outcome of each record, or with dry_run=true only what would be done. Entries are named as the LDAP server names them in its current mode. A record that fails does not stop the import. Returns 400, importing nothing, if the file cannot be parsed.
+// @Tags         LDIF
+// @Accept       text/x-ldif
+// @Produce      json
+// @Param        Authorization  header    string  true   "Bearer token"
+// @Param        dry_run        query     bool    false  "Only report the changes"
+// @Param        body           body      string  true   "LDIF file"
+// @Success      200            {object}  Response{data=domain.LDIFImportReport}
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/import.ldif [post]
+func (h *LDIFHandler) Import(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+
+	report, err := h.ldifService.Import(c.Request.Context(), c.Request.Body, h.cfg.Mode, h.cfg.BaseDN, dryRun)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidLDIF) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to import LDIF: "+err.Error())
+		return
+	}
+	OK(c, report)
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// GetLockout godoc
+// @Summary      Get user lockout
+// @Description  Get a user's failed login count and lockout, with the most recent failed logins, refused logins, lockouts and unlocks
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Success      200            {object}  Response{data=domain.LockoutStatus}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/users/{id}/lockout [get]
+func (h *UserHandler) GetLockout(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	st, err := h.userService.LockoutStatus(c.Request.Context(), id)
+	if err != nil {
+		Error(c, http.StatusNotFound, "user not found")
+		return
+	}
+	OK(c, st)
+}
+
+// Unlock godoc
+// @Summary      Unlock user
+// @Description  Lift a user's lockout and clear their failed logins; the next lockout starts again at the shortest duration
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/users/{id}/lockout [delete]
+func (h *UserHandler) Unlock(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if err := h.userService.Unlock(c.Request.Context(), id); err != nil {
+		Error(c, http.StatusNotFound, "user not found")
+		return
+	}
+	OK(c, nil)
+}
+
+// writeLockoutError writes a 429 response with a Retry-After header if err
+// refused a login because the account is locked or the client is blocked,
+// and reports whether it did.
+func writeLockoutError(c *gin.Context, err error) bool {
+	var lerr *service.LockoutError
+	if !errors.As(err, &lerr) {
+		return false
+	}
+	retry := max(int(math.Ceil(time.Until(lerr.Until).Seconds())), 1)
+	c.Header("Retry-After", strconv.Itoa(retry))
+	msg := "account locked after too many failed logins, try again later"
+	if errors.Is(err, service.ErrTooManyAttempts) {
+		msg = "too many failed logins from this address, try again later"
+	}
+	Error(c, http.StatusTooManyRequests, msg)
+	return true
+}
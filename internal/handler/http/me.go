@@ -0,0 +1,252 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/config"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// MeHandler handles self-service endpoints for the authenticated user.
+type MeHandler struct {
+	userService  *service.UserService
+	groupService *service.GroupService
+	cfg          *config.SelfServiceConfig
+}
+
+// NewMeHandler creates a new MeHandler.
+func NewMeHandler(userSvc *service.UserService, groupSvc *service.GroupService, cfg *config.SelfServiceConfig) *MeHandler {
+	return &MeHandler{userService: userSvc, groupService: groupSvc, cfg: cfg}
+}
+
+// UpdateMeReq is the request DTO for updating the caller's own profile.
+// Only fields listed in self_service.editable_fields are accepted.
+type UpdateMeReq struct {
+	DisplayName *string `json:"display_name,omitempty" binding:"omitempty,max=128"`
+	Email       *string `json:"email,omitempty" binding:"omitempty,email,max=255"`
+	Phone       *string `json:"phone,omitempty" binding:"omitempty,max=32"`
+}
+
+// AddSSHKeyReq is the request DTO for registering an SSH public key.
+type AddSSHKeyReq struct {
+	Name      string `json:"name,omitempty" binding:"omitempty,max=64"`
+	PublicKey string `json:"public_key" binding:"required"`
+}
+
+// Get godoc
+// @Summary      Get my profile
+// @Description  Return the authenticated user's profile
+// @Tags         Me
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=domain.User}
+// @Failure      404            {object}  Response
+// @Router       /api/v1/me [get]
+func (h *MeHandler) Get(c *gin.Context) {
+	u, err := h.userService.GetUser(c.Request.Context(), currentUserID(c))
+	if err != nil {
+		Error(c, http.StatusNotFound, "user not found")
+		return
+	}
+	OK(c, u)
+}
+
+// Update godoc
+// @Summary      Update my profile
+// @Description  Update the authenticated user's profile; only configured editable fields are accepted
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string       true  "Bearer token"
+// @Param        request        body      UpdateMeReq  true  "Fields to update"
+// @Success      200            {object}  Response{data=domain.User}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/me [put]
+func (h *MeHandler) Update(c *gin.Context) {
+	var req UpdateMeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	for field, set := range map[string]bool{
+		"display_name": req.DisplayName != nil,
+		"email":        req.Email != nil,
+		"phone":        req.Phone != nil,
+	} {
+		if set && !h.cfg.IsEditable(field) {
+			Error(c, http.StatusForbidden, "field "+field+" is not editable")
+			return
+		}
+	}
+
+	u, err := h.userService.UpdateUser(c.Request.Context(), currentUserID(c), domain.UpdateUserInput{
+		DisplayName: req.DisplayName,
+		Email:       req.Email,
+		Phone:       req.Phone,
+	})
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to update profile")
+		return
+	}
+	OK(c, u)
+}
+
+// GetPasswordStatus godoc
+// @Summary      Get my password status
+// @Description  Return the password rules that apply to the authenticated user and when their password expires
+// @Tags         Me
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=domain.PasswordStatus}
+// @Failure      500            {object}  Response
+// @Router       /api/v1/me/password [get]
+func (h *MeHandler) GetPasswordStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	u, err := h.userService.GetUser(ctx, currentUserID(c))
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to get user")
+		return
+	}
+	st, err := h.userService.PasswordStatus(ctx, u)
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to get password status")
+		return
+	}
+	OK(c, st)
+}
+
+// ChangePassword godoc
+// @Summary      Change my password
+// @Description  Change the authenticated user's password (requires old password verification). The new password must satisfy the user's password policies. Wrong old passwords count as failed logins towards the account lockout.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string             true  "Bearer token"
+// @Param        request        body      ChangePasswordReq  true  "Old and new password"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response{data=PasswordViolationsResp}
+// @Failure      429            {object}  Response
+// @Router       /api/v1/me/password [put]
+func (h *MeHandler) ChangePassword(c *gin.Context) {
+	var req ChangePasswordReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.userService.ChangePassword(c.Request.Context(), currentUserID(c), req.OldPassword, req.NewPassword); err != nil {
+		if writePasswordPolicyError(c, err) || writeLockoutError(c, err) {
+			return
+		}
+		Error(c, http.StatusBadRequest, "failed to change password: "+err.Error())
+		return
+	}
+	OK(c, nil)
+}
+
+// GetGroups godoc
+// @Summary      Get my groups
+// @Description  Return the groups the authenticated user belongs to (read-only)
+// @Tags         Me
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=[]domain.Group}
+// @Failure      500            {object}  Response
+// @Router       /api/v1/me/groups [get]
+func (h *MeHandler) GetGroups(c *gin.Context) {
+	groups, err := h.groupService.GetUserGroups(c.Request.Context(), currentUserID(c))
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to get groups")
+		return
+	}
+	OK(c, groups)
+}
+
+// ListSSHKeys godoc
+// @Summary      List my SSH keys
+// @Description  Return the SSH public keys registered by the authenticated user
+// @Tags         Me
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=[]domain.SSHKey}
+// @Failure      500            {object}  Response
+// @Router       /api/v1/me/ssh-keys [get]
+func (h *MeHandler) ListSSHKeys(c *gin.Context) {
+	keys, err := h.userService.ListSSHKeys(c.Request.Context(), currentUserID(c))
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to list ssh keys")
+		return
+	}
+	OK(c, keys)
+}
+
+// AddSSHKey godoc
+// @Summary      Add SSH key
+// @Description  Register an SSH public key in authorized_keys format
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string        true  "Bearer token"
+// @Param        request        body      AddSSHKeyReq  true  "SSH public key"
+// @Success      200            {object}  Response{data=domain.SSHKey}
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/me/ssh-keys [post]
+func (h *MeHandler) AddSSHKey(c *gin.Context) {
+	var req AddSSHKeyReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	k, err := h.userService.AddSSHKey(c.Request.Context(), currentUserID(c), req.Name, req.PublicKey)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSSHKey) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to add ssh key")
+		return
+	}
+	OK(c, k)
+}
+
+// DeleteSSHKey godoc
+// @Summary      Delete SSH key
+// @Description  Remove one of the authenticated user's SSH public keys
+// @Tags         Me
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "SSH key ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/me/ssh-keys/{id} [delete]
+func (h *MeHandler) DeleteSSHKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid ssh key id")
+		return
+	}
+
+	if err := h.userService.DeleteSSHKey(c.Request.Context(), currentUserID(c), id); err != nil {
+		Error(c, http.StatusNotFound, "ssh key not found")
+		return
+	}
+	OK(c, nil)
+}
+
+// currentUserID returns the ID of the authenticated user set by Authenticate.
+// Authenticate rejects tokens whose user_id is not a valid UUID.
+func currentUserID(c *gin.Context) uuid.UUID {
+	id, _ := uuid.Parse(c.GetString("user_id"))
+	return id
+}
@@ -0,0 +1,191 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// MFAHandler handles TOTP enrollment for the authenticated user and MFA
+// resets by administrators.
+type MFAHandler struct {
+	mfaService *service.MFAService
+}
+
+// NewMFAHandler creates a new MFAHandler.
+func NewMFAHandler(mfaSvc *service.MFAService) *MFAHandler {
+	return &MFAHandler{mfaService: mfaSvc}
+}
+
+// MFACodeReq carries a TOTP code or, where accepted, a recovery code.
+type MFACodeReq struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// RecoveryCodesResp holds freshly issued recovery codes. They are shown only once.
+type RecoveryCodesResp struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Status godoc
+// @Summary      Get my MFA status
+// @Description  Return whether the authenticated user has TOTP enabled, whether a group requires it, and how many recovery codes are left
+// @Tags         Me
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=domain.MFAStatus}
+// @Failure      500            {object}  Response
+// @Router       /api/v1/me/mfa [get]
+func (h *MFAHandler) Status(c *gin.Context) {
+	status, err := h.mfaService.Status(c.Request.Context(), currentUserID(c))
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to load mfa status")
+		return
+	}
+	OK(c, status)
+}
+
+// BeginTOTP godoc
+// @Summary      Start TOTP enrollment
+// @Description  Generate a new TOTP secret and its otpauth:// provisioning URI, to be shown as a QR code. The secret is inactive until confirmed.
+// @Tags         Me
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=domain.TOTPEnrollment}
+// @Failure      409            {object}  Response
+// @Router       /api/v1/me/mfa/totp [post]
+func (h *MFAHandler) BeginTOTP(c *gin.Context) {
+	enrollment, err := h.mfaService.BeginEnrollment(c.Request.Context(), currentUserID(c))
+	if err != nil {
+		writeMFAError(c, err)
+		return
+	}
+	OK(c, enrollment)
+}
+
+// ConfirmTOTP godoc
+// @Summary      Confirm TOTP enrollment
+// @Description  Enable MFA by submitting a code from the authenticator app. Returns the recovery codes, which are shown only once.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string      true  "Bearer token"
+// @Param        request        body      MFACodeReq  true  "TOTP code"
+// @Success      200            {object}  Response{data=RecoveryCodesResp}
+// @Failure      400            {object}  Response
+// @Failure      409            {object}  Response
+// @Router       /api/v1/me/mfa/totp/confirm [post]
+func (h *MFAHandler) ConfirmTOTP(c *gin.Context) {
+	var req MFACodeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	codes, err := h.mfaService.ConfirmEnrollment(c.Request.Context(), currentUserID(c), req.Code)
+	if err != nil {
+		writeMFAError(c, err)
+		return
+	}
+	OK(c, RecoveryCodesResp{RecoveryCodes: codes})
+}
+
+// Disable godoc
+// @Summary      Disable my MFA
+// @Description  Turn off TOTP after confirming with a TOTP or recovery code. Refused while a group requires MFA.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string      true  "Bearer token"
+// @Param        request        body      MFACodeReq  true  "TOTP or recovery code"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Router       /api/v1/me/mfa [delete]
+func (h *MFAHandler) Disable(c *gin.Context) {
+	var req MFACodeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.mfaService.Disable(c.Request.Context(), currentUserID(c), req.Code); err != nil {
+		writeMFAError(c, err)
+		return
+	}
+	OK(c, nil)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      Regenerate my recovery codes
+// @Description  Replace all recovery codes after confirming with a TOTP or recovery code. The new codes are shown only once.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string      true  "Bearer token"
+// @Param        request        body      MFACodeReq  true  "TOTP or recovery code"
+// @Success      200            {object}  Response{data=RecoveryCodesResp}
+// @Failure      400            {object}  Response
+// @Router       /api/v1/me/mfa/recovery-codes [post]
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req MFACodeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(c.Request.Context(), currentUserID(c), req.Code)
+	if err != nil {
+		writeMFAError(c, err)
+		return
+	}
+	OK(c, RecoveryCodesResp{RecoveryCodes: codes})
+}
+
+// Reset godoc
+// @Summary      Reset user MFA
+// @Description  Remove a user's TOTP enrollment and recovery codes, e.g. after a lost device. If a group requires MFA, the user enrolls again on the next login.
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/users/{id}/mfa [delete]
+func (h *MFAHandler) Reset(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if err := h.mfaService.Reset(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		Error(c, http.StatusNotFound, "user not found")
+		return
+	}
+	OK(c, nil)
+}
+
+// writeMFAError maps MFA errors to HTTP status codes.
+func writeMFAError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidMFACode), errors.Is(err, service.ErrMFANotEnabled):
+		Error(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrMFAEnabled):
+		Error(c, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrMFARequired):
+		Error(c, http.StatusForbidden, err.Error())
+	default:
+		Error(c, http.StatusInternalServerError, "mfa operation failed")
+	}
+}
@@ -0,0 +1,446 @@
+package http
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+var oidcTemplates = template.Must(template.ParseFS(templateFS, "templates/*.html"))
+
+// ssoCookieName is the cookie holding the browser's OIDC login session.
+const ssoCookieName = "sso_session"
+
+// loginCookieName is the cookie from which the login form token is derived,
+// set when the login page is shown.
+const loginCookieName = "sso_login"
+
+// scopeDescriptions are shown on the consent page.
+var scopeDescriptions = map[string]string{
+	domain.ScopeOpenID:  "账号标识",
+	domain.ScopeProfile: "姓名和用户名",
+	domain.ScopeEmail:   "邮箱地址",
+	domain.ScopePhone:   "手机号码",
+	domain.ScopeGroups:  "所属用户组",
+}
+
+// OIDCHandler serves the OpenID Connect provider endpoints, including the
+// login and consent pages of the authorization code flow. The token endpoint
+// also serves the client credentials grant for service accounts.
+type OIDCHandler struct {
+	oidcService           *service.OIDCService
+	authService           *service.AuthService
+	serviceAccountService *service.ServiceAccountService
+}
+
+// NewOIDCHandler creates a new OIDCHandler.
+func NewOIDCHandler(oidcSvc *service.OIDCService, authSvc *service.AuthService, saSvc *service.ServiceAccountService) *OIDCHandler {
+	return &OIDCHandler{oidcService: oidcSvc, authService: authSvc, serviceAccountService: saSvc}
+}
+
+// Discovery godoc
+// @Summary      OpenID Provider metadata
+// @Description  OpenID Connect discovery document
+// @Tags         OIDC
+// @Produce      json
+// @Success      200  {object}  domain.OIDCDiscovery
+// @Router       /.well-known/openid-configuration [get]
+func (h *OIDCHandler) Discovery(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.oidcService.Discovery())
+}
+
+// Authorize godoc
+// @Summary      Authorization endpoint
+// @Description  Start the authorization code flow (PKCE with S256 required). Shows the login and consent pages as needed, then redirects to redirect_uri with code and state.
+// @Tags         OIDC
+// @Produce      html
+// @Param        response_type          query  string  true   "Must be code"
+// @Param        client_id              query  string  true   "Client ID"
+// @Param        redirect_uri           query  string  true   "Registered redirect URI"
+// @Param        scope                  query  string  true   "Space-separated scopes, must include openid"
+// @Param        state                  query  string  false  "Opaque value returned to the client"
+// @Param        nonce                  query  string  false  "Value copied into the ID token"
+// @Param        code_challenge         query  string  true   "PKCE code challenge"
+// @Param        code_challenge_method  query  string  true   "Must be S256"
+// @Param        prompt                 query  string  false  "none | login | consent"
+// @Success      200  "Login or consent page"
+// @Success      302  "Redirect to the client"
+// @Failure      400  "Error page for an invalid client or redirect URI"
+// @Router       /oauth2/authorize [get]
+func (h *OIDCHandler) Authorize(c *gin.Context) {
+	h.authorize(c, c.Request.URL.Query())
+}
+
+// Login handles the login form of the authorization flow. On success it
+// stores the SSO session cookie and resumes the authorization request.
+func (h *OIDCHandler) Login(c *gin.Context) {
+	query, err := url.ParseQuery(c.PostForm("request"))
+	if err != nil {
+		h.renderError(c, http.StatusBadRequest, "无效的授权请求")
+		return
+	}
+	// Without the token, a cross-site form could log the browser in to an
+	// account of the attacker's.
+	loginCookie, err := c.Cookie(loginCookieName)
+	if err != nil || loginCookie == "" ||
+		subtle.ConstantTimeCompare([]byte(c.PostForm("csrf_token")), []byte(csrfToken("login", loginCookie))) != 1 {
+		h.renderError(c, http.StatusForbidden, "请求已失效，请重新发起登录")
+		return
+	}
+
+	username := c.PostForm("username")
+	token, expiresAt, err := h.authService.StartSSOSession(c.Request.Context(), username, c.PostForm("password"), c.PostForm("code"))
+	if err != nil {
+		msg := "用户名、密码或验证码错误"
+		switch {
+		case errors.Is(err, service.ErrMFARequired):
+			msg = "所在用户组要求启用两步验证，请先登录管理后台完成绑定"
+		case errors.Is(err, service.ErrAccountLocked), errors.Is(err, service.ErrTooManyAttempts):
+			msg = "登录失败次数过多，请稍后再试"
+		}
+		h.render(c, http.StatusUnauthorized, "login.html", gin.H{
+			"Title":     "登录",
+			"Request":   query.Encode(),
+			"Username":  username,
+			"Error":     msg,
+			"CSRFToken": csrfToken("login", loginCookie),
+		})
+		return
+	}
+
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     ssoCookieName,
+		Value:    token,
+		Path:     "/oauth2",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(h.oidcService.Issuer(), "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+	c.Redirect(http.StatusSeeOther, "/oauth2/authorize?"+query.Encode())
+}
+
+// Consent handles the consent form of the authorization flow.
+func (h *OIDCHandler) Consent(c *gin.Context) {
+	query, err := url.ParseQuery(c.PostForm("request"))
+	if err != nil {
+		h.renderError(c, http.StatusBadRequest, "无效的授权请求")
+		return
+	}
+
+	claims, cookie, ok := h.ssoSession(c)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/oauth2/authorize?"+query.Encode())
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(c.PostForm("csrf_token")), []byte(csrfToken("consent", cookie))) != 1 {
+		h.renderError(c, http.StatusForbidden, "请求已失效，请重新发起登录")
+		return
+	}
+
+	req := authorizeRequestFromQuery(query)
+	client, scopes, ok := h.validate(c, req)
+	if !ok {
+		return
+	}
+	if c.PostForm("decision") != "allow" {
+		redirectOAuthError(c, req, &service.OAuthError{Code: "access_denied", Description: "the user denied the request"})
+		return
+	}
+
+	userID, _ := uuid.Parse(claims.UserID)
+	if err := h.oidcService.SaveConsent(c.Request.Context(), userID, client.ID, scopes); err != nil {
+		h.renderError(c, http.StatusInternalServerError, "保存授权失败")
+		return
+	}
+	h.issueCode(c, client, userID, claims.IssuedAt.Time, req, scopes)
+}
+
+// Token godoc
+// @Summary      Token endpoint
+// @Description  Exchange an authorization code for an access token and ID token. Clients authenticate with client_secret_basic or client_secret_post; public clients send only client_id.
+// @Description  With grant_type=client_credentials, a service account authenticates with its ID or name as client_id and an API key as client_secret; scope optionally narrows the token to a subset of its permissions.
+// @Tags         OIDC
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        grant_type     formData  string  true   "authorization_code | client_credentials"
+// @Param        code           formData  string  false  "Authorization code (authorization_code)"
+// @Param        redirect_uri   formData  string  false  "Redirect URI used in the authorization request (authorization_code)"
+// @Param        code_verifier  formData  string  false  "PKCE code verifier (authorization_code)"
+// @Param        scope          formData  string  false  "Space-separated permissions (client_credentials)"
+// @Param        client_id      formData  string  false  "Client ID when not using HTTP Basic authentication"
+// @Param        client_secret  formData  string  false  "Client secret when not using HTTP Basic authentication"
+// @Success      200  {object}  domain.TokenResponse
+// @Failure      400  {object}  service.OAuthError
+// @Failure      401  {object}  service.OAuthError
+// @Router       /oauth2/token [post]
+func (h *OIDCHandler) Token(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	req := domain.TokenRequest{
+		GrantType:    c.PostForm("grant_type"),
+		Code:         c.PostForm("code"),
+		RedirectURI:  c.PostForm("redirect_uri"),
+		ClientID:     c.PostForm("client_id"),
+		ClientSecret: c.PostForm("client_secret"),
+		CodeVerifier: c.PostForm("code_verifier"),
+		Scope:        c.PostForm("scope"),
+	}
+	// RFC 6749 section 2.3.1: Basic credentials are form-urlencoded.
+	if id, secret, ok := c.Request.BasicAuth(); ok {
+		req.ClientID, _ = url.QueryUnescape(id)
+		req.ClientSecret, _ = url.QueryUnescape(secret)
+	}
+
+	var tokens *domain.TokenResponse
+	var err error
+	if req.GrantType == "client_credentials" {
+		tokens, err = h.serviceAccountService.ClientCredentials(c.Request.Context(), req.ClientID, req.ClientSecret, req.Scope)
+	} else {
+		tokens, err = h.oidcService.Exchange(c.Request.Context(), req)
+	}
+	if err != nil {
+		var oerr *service.OAuthError
+		if !errors.As(err, &oerr) {
+			c.JSON(http.StatusInternalServerError, &service.OAuthError{Code: "server_error"})
+			return
+		}
+		status := http.StatusBadRequest
+		if oerr.Code == "invalid_client" {
+			status = http.StatusUnauthorized
+			c.Header("WWW-Authenticate", `Basic realm="oauth2"`)
+		}
+		c.JSON(status, oerr)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// UserInfo godoc
+// @Summary      UserInfo endpoint
+// @Description  Return claims about the user an OIDC access token was issued for, limited to its scopes
+// @Tags         OIDC
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer access token from the token endpoint"
+// @Success      200            {object}  object
+// @Failure      401            {object}  service.OAuthError
+// @Router       /oauth2/userinfo [get]
+func (h *OIDCHandler) UserInfo(c *gin.Context) {
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	claims, err := h.oidcService.UserInfo(c.Request.Context(), token)
+	if err != nil {
+		var oerr *service.OAuthError
+		if !errors.As(err, &oerr) {
+			c.JSON(http.StatusInternalServerError, &service.OAuthError{Code: "server_error"})
+			return
+		}
+		c.Header("WWW-Authenticate", `Bearer error="`+oerr.Code+`"`)
+		c.JSON(http.StatusUnauthorized, oerr)
+		return
+	}
+	c.JSON(http.StatusOK, claims)
+}
+
+// authorize validates the request and continues with the login page, the
+// consent page or the redirect carrying the code, whichever applies.
+func (h *OIDCHandler) authorize(c *gin.Context, query url.Values) {
+	req := authorizeRequestFromQuery(query)
+	client, scopes, ok := h.validate(c, req)
+	if !ok {
+		return
+	}
+
+	claims, cookie, loggedIn := h.ssoSession(c)
+	if !loggedIn || req.Prompt == "login" {
+		if req.Prompt == "none" {
+			redirectOAuthError(c, req, &service.OAuthError{Code: "login_required"})
+			return
+		}
+		// Drop prompt=login so the request does not loop back to this page.
+		resume := cloneValues(query)
+		if resume.Get("prompt") == "login" {
+			resume.Del("prompt")
+		}
+		h.render(c, http.StatusOK, "login.html", gin.H{
+			"Title":     "登录",
+			"Request":   resume.Encode(),
+			"CSRFToken": csrfToken("login", h.loginCookie(c)),
+		})
+		return
+	}
+
+	userID, _ := uuid.Parse(claims.UserID)
+	consented, err := h.oidcService.HasConsent(c.Request.Context(), userID, client.ID, scopes)
+	if err != nil {
+		h.renderError(c, http.StatusInternalServerError, "读取授权记录失败")
+		return
+	}
+	if !consented || req.Prompt == "consent" {
+		if req.Prompt == "none" {
+			redirectOAuthError(c, req, &service.OAuthError{Code: "consent_required"})
+			return
+		}
+		descriptions := make([]string, len(scopes))
+		for i, scope := range scopes {
+			descriptions[i] = scopeDescriptions[scope]
+		}
+		h.render(c, http.StatusOK, "consent.html", gin.H{
+			"Title":      "授权",
+			"ClientName": client.Name,
+			"Username":   claims.Username,
+			"Scopes":     descriptions,
+			"Request":    query.Encode(),
+			"CSRFToken":  csrfToken("consent", cookie),
+		})
+		return
+	}
+
+	h.issueCode(c, client, userID, claims.IssuedAt.Time, req, scopes)
+}
+
+// validate checks the authorization request. Errors about the client itself
+// are shown to the user; all others are sent to the client's redirect URI.
+func (h *OIDCHandler) validate(c *gin.Context, req domain.AuthorizeRequest) (*domain.OIDCClient, []string, bool) {
+	client, scopes, err := h.oidcService.Authorize(c.Request.Context(), req)
+	if err == nil {
+		return client, scopes, true
+	}
+
+	var oerr *service.OAuthError
+	switch {
+	case errors.Is(err, service.ErrInvalidClient):
+		h.renderError(c, http.StatusBadRequest, err.Error())
+	case errors.As(err, &oerr):
+		redirectOAuthError(c, req, oerr)
+	default:
+		h.renderError(c, http.StatusInternalServerError, "处理授权请求失败")
+	}
+	return nil, nil, false
+}
+
+func (h *OIDCHandler) issueCode(c *gin.Context, client *domain.OIDCClient, userID uuid.UUID, authTime time.Time, req domain.AuthorizeRequest, scopes []string) {
+	code, err := h.oidcService.IssueCode(c.Request.Context(), client, userID, authTime, req, scopes)
+	if err != nil {
+		redirectOAuthError(c, req, &service.OAuthError{Code: "server_error"})
+		return
+	}
+	redirectToClient(c, req, url.Values{"code": {code}})
+}
+
+// loginCookie returns the browser's login form cookie, setting a new one if
+// it has none.
+func (h *OIDCHandler) loginCookie(c *gin.Context) string {
+	if cookie, err := c.Cookie(loginCookieName); err == nil && cookie != "" {
+		return cookie
+	}
+	cookie := rand.Text()
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     loginCookieName,
+		Value:    cookie,
+		Path:     "/oauth2",
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(h.oidcService.Issuer(), "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+	return cookie
+}
+
+// ssoSession returns the browser's login session, if it has a valid one.
+func (h *OIDCHandler) ssoSession(c *gin.Context) (*service.Claims, string, bool) {
+	cookie, err := c.Cookie(ssoCookieName)
+	if err != nil || cookie == "" {
+		return nil, "", false
+	}
+	claims, err := h.authService.ValidateSSOSession(c.Request.Context(), cookie)
+	if err != nil {
+		return nil, "", false
+	}
+	return claims, cookie, true
+}
+
+func (h *OIDCHandler) render(c *gin.Context, status int, name string, data gin.H) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Frame-Options", "DENY")
+	c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+	c.Status(status)
+	if err := oidcTemplates.ExecuteTemplate(c.Writer, name, data); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+func (h *OIDCHandler) renderError(c *gin.Context, status int, msg string) {
+	h.render(c, status, "error.html", gin.H{"Title": "错误", "Error": msg})
+}
+
+func authorizeRequestFromQuery(q url.Values) domain.AuthorizeRequest {
+	return domain.AuthorizeRequest{
+		ResponseType:        q.Get("response_type"),
+		ClientID:            q.Get("client_id"),
+		RedirectURI:         q.Get("redirect_uri"),
+		Scope:               q.Get("scope"),
+		State:               q.Get("state"),
+		Nonce:               q.Get("nonce"),
+		CodeChallenge:       q.Get("code_challenge"),
+		CodeChallengeMethod: q.Get("code_challenge_method"),
+		Prompt:              q.Get("prompt"),
+	}
+}
+
+func redirectOAuthError(c *gin.Context, req domain.AuthorizeRequest, oerr *service.OAuthError) {
+	params := url.Values{"error": {oerr.Code}}
+	if oerr.Description != "" {
+		params.Set("error_description", oerr.Description)
+	}
+	redirectToClient(c, req, params)
+}
+
+// redirectToClient redirects to the (already validated) redirect URI with
+// the given parameters and the request's state.
+func redirectToClient(c *gin.Context, req domain.AuthorizeRequest, params url.Values) {
+	target, _ := url.Parse(req.RedirectURI)
+	q := target.Query()
+	for k, v := range params {
+		q[k] = v
+	}
+	if req.State != "" {
+		q.Set("state", req.State)
+	}
+	target.RawQuery = q.Encode()
+	c.Redirect(http.StatusFound, target.String())
+}
+
+// csrfToken derives the token of a form from a cookie, which a cross-site
+// attacker cannot read: the session cookie for the consent form, and the
+// login cookie for the login form.
+func csrfToken(form, cookie string) string {
+	sum := sha256.Sum256([]byte(form + ":" + cookie))
+	return hex.EncodeToString(sum[:])
+}
+
+func cloneValues(v url.Values) url.Values {
+	out := make(url.Values, len(v))
+	for k, vals := range v {
+		out[k] = append([]string(nil), vals...)
+	}
+	return out
+}
@@ -0,0 +1,228 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// OIDCClientHandler handles OIDC client registration endpoints.
+type OIDCClientHandler struct {
+	oidcService *service.OIDCService
+}
+
+// NewOIDCClientHandler creates a new OIDCClientHandler.
+func NewOIDCClientHandler(oidcSvc *service.OIDCService) *OIDCClientHandler {
+	return &OIDCClientHandler{oidcService: oidcSvc}
+}
+
+// CreateOIDCClientReq is the request DTO for registering an OIDC client.
+type CreateOIDCClientReq struct {
+	Name         string   `json:"name" binding:"required,max=128"`
+	RedirectURIs []string `json:"redirect_uris" binding:"required,min=1"`
+	Public       bool     `json:"public,omitempty"`
+}
+
+// UpdateOIDCClientReq is the request DTO for updating an OIDC client.
+type UpdateOIDCClientReq struct {
+	Name         *string  `json:"name,omitempty" binding:"omitempty,max=128"`
+	RedirectURIs []string `json:"redirect_uris,omitempty"`
+}
+
+// OIDCClientSecretResp carries a client and its newly generated secret.
+// The secret is only returned once.
+type OIDCClientSecretResp struct {
+	Client       *domain.OIDCClient `json:"client"`
+	ClientSecret string             `json:"client_secret,omitempty"`
+}
+
+// Create godoc
+// @Summary      Register OIDC client
+// @Description  Register a relying party. Confidential clients receive a client_secret that is shown only once; public clients (SPAs, native apps) get none and must use PKCE.
+// @Tags         OIDC
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string               true  "Bearer token"
+// @Param        request        body      CreateOIDCClientReq  true  "Client info"
+// @Success      200            {object}  Response{data=OIDCClientSecretResp}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/oidc/clients [post]
+func (h *OIDCClientHandler) Create(c *gin.Context) {
+	var req CreateOIDCClientReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	client, secret, err := h.oidcService.CreateClient(c.Request.Context(), domain.CreateOIDCClientInput{
+		Name:         req.Name,
+		RedirectURIs: req.RedirectURIs,
+		Public:       req.Public,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidClient) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to create client: "+err.Error())
+		return
+	}
+	OK(c, OIDCClientSecretResp{Client: client, ClientSecret: secret})
+}
+
+// List godoc
+// @Summary      List OIDC clients
+// @Description  List all registered OIDC clients
+// @Tags         OIDC
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=[]domain.OIDCClient}
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/oidc/clients [get]
+func (h *OIDCClientHandler) List(c *gin.Context) {
+	clients, err := h.oidcService.ListClients(c.Request.Context())
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to list clients")
+		return
+	}
+	OK(c, clients)
+}
+
+// Get godoc
+// @Summary      Get OIDC client
+// @Description  Retrieve an OIDC client by client ID
+// @Tags         OIDC
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Client ID (UUID)"
+// @Success      200            {object}  Response{data=domain.OIDCClient}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/oidc/clients/{id} [get]
+func (h *OIDCClientHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	client, err := h.oidcService.GetClient(c.Request.Context(), id)
+	if err != nil {
+		Error(c, http.StatusNotFound, "client not found")
+		return
+	}
+	OK(c, client)
+}
+
+// Update godoc
+// @Summary      Update OIDC client
+// @Description  Update an OIDC client's name or redirect URIs
+// @Tags         OIDC
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string               true  "Bearer token"
+// @Param        id             path      string               true  "Client ID (UUID)"
+// @Param        request        body      UpdateOIDCClientReq  true  "Fields to update"
+// @Success      200            {object}  Response{data=domain.OIDCClient}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/oidc/clients/{id} [put]
+func (h *OIDCClientHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	var req UpdateOIDCClientReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	client, err := h.oidcService.UpdateClient(c.Request.Context(), id, domain.UpdateOIDCClientInput{
+		Name:         req.Name,
+		RedirectURIs: req.RedirectURIs,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidClient) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to update client: "+err.Error())
+		return
+	}
+	OK(c, client)
+}
+
+// Delete godoc
+// @Summary      Delete OIDC client
+// @Description  Delete an OIDC client together with its pending authorization codes and user consents
+// @Tags         OIDC
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Client ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/oidc/clients/{id} [delete]
+func (h *OIDCClientHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	if err := h.oidcService.DeleteClient(c.Request.Context(), id); err != nil {
+		Error(c, http.StatusInternalServerError, "failed to delete client")
+		return
+	}
+	OK(c, nil)
+}
+
+// RotateSecret godoc
+// @Summary      Rotate OIDC client secret
+// @Description  Generate a new secret for a confidential client; the old secret stops working immediately
+// @Tags         OIDC
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Client ID (UUID)"
+// @Success      200            {object}  Response{data=OIDCClientSecretResp}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/oidc/clients/{id}/secret [post]
+func (h *OIDCClientHandler) RotateSecret(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	secret, err := h.oidcService.RotateClientSecret(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidClient) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to rotate secret: "+err.Error())
+		return
+	}
+	client, err := h.oidcService.GetClient(c.Request.Context(), id)
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to load client")
+		return
+	}
+	OK(c, OIDCClientSecretResp{Client: client, ClientSecret: secret})
+}
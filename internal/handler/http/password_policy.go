@@ -0,0 +1,310 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// PasswordPolicyHandler handles password policy and policy assignment endpoints.
+type PasswordPolicyHandler struct {
+	policyService *service.PasswordPolicyService
+}
+
+// NewPasswordPolicyHandler creates a new PasswordPolicyHandler.
+func NewPasswordPolicyHandler(policySvc *service.PasswordPolicyService) *PasswordPolicyHandler {
+	return &PasswordPolicyHandler{policyService: policySvc}
+}
+
+// CreatePasswordPolicyReq is the request DTO for creating a password policy.
+type CreatePasswordPolicyReq struct {
+	Name             string `json:"name" binding:"required,max=64"`
+	Description      string `json:"description,omitempty" binding:"omitempty,max=255"`
+	MinLength        int    `json:"min_length" binding:"required"`
+	RequireUppercase bool   `json:"require_uppercase,omitempty"`
+	RequireLowercase bool   `json:"require_lowercase,omitempty"`
+	RequireDigit     bool   `json:"require_digit,omitempty"`
+	RequireSymbol    bool   `json:"require_symbol,omitempty"`
+	CheckDictionary  bool   `json:"check_dictionary,omitempty"`
+	CheckUsername    bool   `json:"check_username,omitempty"`
+	HistoryCount     int    `json:"history_count,omitempty"`
+	MinAgeHours      int    `json:"min_age_hours,omitempty"`
+	MaxAgeDays       int    `json:"max_age_days,omitempty"`
+}
+
+// UpdatePasswordPolicyReq is the request DTO for updating a password policy.
+// Omitted fields are left unchanged.
+type UpdatePasswordPolicyReq struct {
+	Name             *string `json:"name,omitempty" binding:"omitempty,max=64"`
+	Description      *string `json:"description,omitempty" binding:"omitempty,max=255"`
+	MinLength        *int    `json:"min_length,omitempty"`
+	RequireUppercase *bool   `json:"require_uppercase,omitempty"`
+	RequireLowercase *bool   `json:"require_lowercase,omitempty"`
+	RequireDigit     *bool   `json:"require_digit,omitempty"`
+	RequireSymbol    *bool   `json:"require_symbol,omitempty"`
+	CheckDictionary  *bool   `json:"check_dictionary,omitempty"`
+	CheckUsername    *bool   `json:"check_username,omitempty"`
+	HistoryCount     *int    `json:"history_count,omitempty"`
+	MinAgeHours      *int    `json:"min_age_hours,omitempty"`
+	MaxAgeDays       *int    `json:"max_age_days,omitempty"`
+}
+
+// AssignPolicyGroupsReq is the request DTO for applying a password policy to groups.
+type AssignPolicyGroupsReq struct {
+	GroupIDs []string `json:"group_ids" binding:"required,min=1"`
+}
+
+// Create godoc
+// @Summary      Create password policy
+// @Description  Create a password policy; it applies to the members of the groups it is assigned to
+// @Tags         PasswordPolicy
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string                   true  "Bearer token"
+// @Param        request        body      CreatePasswordPolicyReq  true  "Policy"
+// @Success      200            {object}  Response{data=domain.PasswordPolicy}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/password-policies [post]
+func (h *PasswordPolicyHandler) Create(c *gin.Context) {
+	var req CreatePasswordPolicyReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	p, err := h.policyService.CreatePolicy(c.Request.Context(), domain.CreatePasswordPolicyInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Rules: domain.PasswordRules{
+			MinLength:        req.MinLength,
+			RequireUppercase: req.RequireUppercase,
+			RequireLowercase: req.RequireLowercase,
+			RequireDigit:     req.RequireDigit,
+			RequireSymbol:    req.RequireSymbol,
+			CheckDictionary:  req.CheckDictionary,
+			CheckUsername:    req.CheckUsername,
+			HistoryCount:     req.HistoryCount,
+			MinAgeHours:      req.MinAgeHours,
+			MaxAgeDays:       req.MaxAgeDays,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPasswordPolicy) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to create password policy: "+err.Error())
+		return
+	}
+	OK(c, p)
+}
+
+// List godoc
+// @Summary      List password policies
+// @Description  List all password policies, including the built-in default policy
+// @Tags         PasswordPolicy
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=[]domain.PasswordPolicy}
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/password-policies [get]
+func (h *PasswordPolicyHandler) List(c *gin.Context) {
+	policies, err := h.policyService.ListPolicies(c.Request.Context())
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to list password policies")
+		return
+	}
+	OK(c, policies)
+}
+
+// Get godoc
+// @Summary      Get password policy
+// @Description  Retrieve a password policy by ID with the groups it applies to
+// @Tags         PasswordPolicy
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Policy ID (UUID)"
+// @Success      200            {object}  Response{data=domain.PasswordPolicy}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/password-policies/{id} [get]
+func (h *PasswordPolicyHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid policy id")
+		return
+	}
+
+	p, err := h.policyService.GetPolicy(c.Request.Context(), id)
+	if err != nil {
+		Error(c, http.StatusNotFound, "password policy not found")
+		return
+	}
+	OK(c, p)
+}
+
+// Update godoc
+// @Summary      Update password policy
+// @Description  Update a password policy. The built-in default policy can be changed but not renamed.
+// @Tags         PasswordPolicy
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string                   true  "Bearer token"
+// @Param        id             path      string                   true  "Policy ID (UUID)"
+// @Param        request        body      UpdatePasswordPolicyReq  true  "Fields to update"
+// @Success      200            {object}  Response{data=domain.PasswordPolicy}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/password-policies/{id} [put]
+func (h *PasswordPolicyHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid policy id")
+		return
+	}
+
+	var req UpdatePasswordPolicyReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	p, err := h.policyService.UpdatePolicy(c.Request.Context(), id, domain.UpdatePasswordPolicyInput{
+		Name:             req.Name,
+		Description:      req.Description,
+		MinLength:        req.MinLength,
+		RequireUppercase: req.RequireUppercase,
+		RequireLowercase: req.RequireLowercase,
+		RequireDigit:     req.RequireDigit,
+		RequireSymbol:    req.RequireSymbol,
+		CheckDictionary:  req.CheckDictionary,
+		CheckUsername:    req.CheckUsername,
+		HistoryCount:     req.HistoryCount,
+		MinAgeHours:      req.MinAgeHours,
+		MaxAgeDays:       req.MaxAgeDays,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPasswordPolicy) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to update password policy: "+err.Error())
+		return
+	}
+	OK(c, p)
+}
+
+// Delete godoc
+// @Summary      Delete password policy
+// @Description  Delete a password policy. The built-in default policy cannot be deleted.
+// @Tags         PasswordPolicy
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Policy ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/password-policies/{id} [delete]
+func (h *PasswordPolicyHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid policy id")
+		return
+	}
+
+	if err := h.policyService.DeletePolicy(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrInvalidPasswordPolicy) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to delete password policy")
+		return
+	}
+	OK(c, nil)
+}
+
+// AssignGroups godoc
+// @Summary      Apply password policy to groups
+// @Description  Apply a password policy to the direct members of groups
+// @Tags         PasswordPolicy
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string                 true  "Bearer token"
+// @Param        id             path      string                 true  "Policy ID (UUID)"
+// @Param        request        body      AssignPolicyGroupsReq  true  "Group IDs"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/password-policies/{id}/groups [post]
+func (h *PasswordPolicyHandler) AssignGroups(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid policy id")
+		return
+	}
+
+	var req AssignPolicyGroupsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+	groupIDs, err := parseUUIDs(req.GroupIDs)
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid group_id: "+err.Error())
+		return
+	}
+
+	if err := h.policyService.AssignGroups(c.Request.Context(), id, groupIDs); err != nil {
+		if errors.Is(err, service.ErrInvalidPasswordPolicy) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to assign password policy")
+		return
+	}
+	OK(c, nil)
+}
+
+// UnassignGroup godoc
+// @Summary      Remove password policy from group
+// @Description  Stop applying a password policy to a group
+// @Tags         PasswordPolicy
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Policy ID (UUID)"
+// @Param        gid            path      string  true  "Group ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/password-policies/{id}/groups/{gid} [delete]
+func (h *PasswordPolicyHandler) UnassignGroup(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid policy id")
+		return
+	}
+	groupID, err := uuid.Parse(c.Param("gid"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid group id")
+		return
+	}
+
+	if err := h.policyService.UnassignGroup(c.Request.Context(), id, groupID); err != nil {
+		Error(c, http.StatusInternalServerError, "failed to unassign password policy")
+		return
+	}
+	OK(c, nil)
+}
@@ -0,0 +1,166 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// PasswordResetHandler handles forgot-password requests and the emailed
+// reset links.
+type PasswordResetHandler struct {
+	resetService *service.PasswordResetService
+}
+
+// NewPasswordResetHandler creates a new PasswordResetHandler.
+func NewPasswordResetHandler(resetSvc *service.PasswordResetService) *PasswordResetHandler {
+	return &PasswordResetHandler{resetService: resetSvc}
+}
+
+// ForgotPasswordReq requests a reset link for a username or email address.
+type ForgotPasswordReq struct {
+	Login string `json:"login" binding:"required"`
+}
+
+// ResetWithTokenReq sets a new password with the token of a reset link.
+type ResetWithTokenReq struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// ResetTokenResp describes a valid reset link.
+type ResetTokenResp struct {
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// Forgot godoc
+// @Summary      Forgot password
+// @Description  Email a single-use password reset link to the user with the given username or email address. The response is the same whether or not such a user exists, and whether or not the email could be sent, since it is sent in the background; nothing is sent to disabled or expired users, to users whose password is managed by an upstream directory, or to users who were sent too many links recently. Too many requests from one address, and requests while too many links wait to be sent, are refused with 429. Returns 503 if no mail server is configured.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      ForgotPasswordReq  true  "Username or email"
+// @Success      200      {object}  Response
+// @Failure      400      {object}  Response
+// @Failure      429      {object}  Response
+// @Failure      503      {object}  Response
+// @Router       /api/v1/auth/password/forgot [post]
+func (h *PasswordResetHandler) Forgot(c *gin.Context) {
+	var req ForgotPasswordReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.resetService.RequestReset(c.Request.Context(), req.Login); err != nil {
+		if !writePasswordResetError(c, err) {
+			Error(c, http.StatusInternalServerError, "failed to request password reset")
+		}
+		return
+	}
+	OK(c, nil)
+}
+
+// Check godoc
+// @Summary      Check password reset link
+// @Description  Return the user a reset link belongs to and when it expires, or 400 if it is unknown, expired or already used
+// @Tags         Auth
+// @Produce      json
+// @Param        token  query     string  true  "Token from the reset link"
+// @Success      200    {object}  Response{data=ResetTokenResp}
+// @Failure      400    {object}  Response
+// @Router       /api/v1/auth/password/reset [get]
+func (h *PasswordResetHandler) Check(c *gin.Context) {
+	u, t, err := h.resetService.CheckToken(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		if !writePasswordResetError(c, err) {
+			Error(c, http.StatusInternalServerError, "failed to check password reset link")
+		}
+		return
+	}
+	OK(c, ResetTokenResp{Username: u.Username, DisplayName: u.DisplayName, ExpiresAt: t.ExpiresAt})
+}
+
+// Reset godoc
+// @Summary      Reset password with link
+// @Description  Set a new password with the token of an emailed reset link. The password must satisfy the user's password policies; if it does not, the link can be used again. A successful reset uses up the link and every other link sent to the user, lifts any lockout and signs the user out of all sessions.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      ResetWithTokenReq  true  "Token and new password"
+// @Success      200      {object}  Response
+// @Failure      400      {object}  Response{data=PasswordViolationsResp}
+// @Router       /api/v1/auth/password/reset [post]
+func (h *PasswordResetHandler) Reset(c *gin.Context) {
+	var req ResetWithTokenReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.resetService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		if writePasswordPolicyError(c, err) || writePasswordResetError(c, err) {
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to reset password")
+		return
+	}
+	OK(c, nil)
+}
+
+// SendLink godoc
+// @Summary      Send password reset link
+// @Description  Email a password reset link to a user (requires user:write), regardless of how many links they were sent recently. Returns 400 for users whose password is managed by an upstream directory, and 503 if no mail server is configured.
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Failure      502            {object}  Response
+// @Failure      503            {object}  Response
+// @Router       /api/v1/users/{id}/password/reset-link [post]
+func (h *PasswordResetHandler) SendLink(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if err := h.resetService.SendResetLink(c.Request.Context(), id); err != nil {
+		if !writePasswordResetError(c, err) {
+			Error(c, http.StatusNotFound, "user not found")
+		}
+		return
+	}
+	OK(c, nil)
+}
+
+// writePasswordResetError writes the response for a password reset error
+// the service reports with a sentinel, and reports whether it did.
+func writePasswordResetError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrInvalidResetToken),
+		errors.Is(err, service.ErrUserDisabled),
+		errors.Is(err, service.ErrAccountExpired),
+		errors.Is(err, service.ErrPasswordManagedUpstream):
+		Error(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrTooManyResetRequests):
+		Error(c, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, service.ErrPasswordResetUnavailable):
+		Error(c, http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, service.ErrMailDelivery):
+		Error(c, http.StatusBadGateway, "failed to send password reset email")
+	default:
+		return false
+	}
+	return true
+}
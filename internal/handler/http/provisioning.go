@@ -0,0 +1,296 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// ProvisioningHandler handles provisioning connector endpoints.
+type ProvisioningHandler struct {
+	provisioningService *service.ProvisioningService
+}
+
+// NewProvisioningHandler creates a new ProvisioningHandler.
+func NewProvisioningHandler(provisioningSvc *service.ProvisioningService) *ProvisioningHandler {
+	return &ProvisioningHandler{provisioningService: provisioningSvc}
+}
+
+// CreateConnectorReq is the request DTO for creating a provisioning
+// connector.
+type CreateConnectorReq struct {
+	Name             string            `json:"name" binding:"required,max=64"`
+	URL              string            `json:"url" binding:"required"`
+	Token            string            `json:"token,omitempty"`
+	ScopeGroupID     *string           `json:"scope_group_id,omitempty"`
+	SyncGroups       *bool             `json:"sync_groups,omitempty"`
+	AttributeMapping map[string]string `json:"attribute_mapping,omitempty"`
+}
+
+// UpdateConnectorReq is the request DTO for updating a provisioning
+// connector. An empty scope_group_id provisions every user.
+type UpdateConnectorReq struct {
+	Name             *string           `json:"name,omitempty" binding:"omitempty,min=1,max=64"`
+	URL              *string           `json:"url,omitempty"`
+	Token            *string           `json:"token,omitempty"`
+	ScopeGroupID     *string           `json:"scope_group_id,omitempty"`
+	SyncGroups       *bool             `json:"sync_groups,omitempty"`
+	AttributeMapping map[string]string `json:"attribute_mapping,omitempty"`
+	Enabled          *bool             `json:"enabled,omitempty"`
+}
+
+// Create godoc
+// @Summary      Create provisioning connector
+// @Description  Provision users, and unless sync_groups is false their groups, to an application's SCIM 2.0 endpoint, authenticating with the bearer token. Only direct members of scope_group_id are provisioned if given. attribute_mapping maps user extension attributes to SCIM attribute paths, such as urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department. The connector is fully synchronized shortly after; the token is never returned.
+// @Tags         Provisioning
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string              true  "Bearer token"
+// @Param        request        body      CreateConnectorReq  true  "Connector info"
+// @Success      200            {object}  Response{data=domain.ProvisioningConnector}
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/provisioning/connectors [post]
+func (h *ProvisioningHandler) Create(c *gin.Context) {
+	var req CreateConnectorReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	input := domain.CreateConnectorInput{
+		Name:             req.Name,
+		URL:              req.URL,
+		Token:            req.Token,
+		SyncGroups:       req.SyncGroups == nil || *req.SyncGroups,
+		AttributeMapping: req.AttributeMapping,
+	}
+	if req.ScopeGroupID != nil && *req.ScopeGroupID != "" {
+		groupID, err := uuid.Parse(*req.ScopeGroupID)
+		if err != nil {
+			Error(c, http.StatusBadRequest, "invalid scope_group_id")
+			return
+		}
+		input.ScopeGroupID = &groupID
+	}
+
+	conn, err := h.provisioningService.CreateConnector(c.Request.Context(), input)
+	if err != nil {
+		writeProvisioningError(c, err, "failed to create connector")
+		return
+	}
+	OK(c, conn)
+}
+
+// List godoc
+// @Summary      List provisioning connectors
+// @Description  List all provisioning connectors with the status of their synchronization
+// @Tags         Provisioning
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=[]domain.ProvisioningConnector}
+// @Failure      500            {object}  Response
+// @Router       /api/v1/provisioning/connectors [get]
+func (h *ProvisioningHandler) List(c *gin.Context) {
+	conns, err := h.provisioningService.ListConnectors(c.Request.Context())
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to list connectors")
+		return
+	}
+	OK(c, conns)
+}
+
+// Get godoc
+// @Summary      Get provisioning connector
+// @Description  Retrieve a provisioning connector with the status of its synchronization: when it last ran and last fully ran, the error that stopped it or how many objects failed, and how many users and groups are provisioned
+// @Tags         Provisioning
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Connector ID (UUID)"
+// @Success      200            {object}  Response{data=domain.ProvisioningConnector}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/provisioning/connectors/{id} [get]
+func (h *ProvisioningHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid connector id")
+		return
+	}
+
+	conn, err := h.provisioningService.GetConnector(c.Request.Context(), id)
+	if err != nil {
+		writeProvisioningError(c, err, "failed to get connector")
+		return
+	}
+	OK(c, conn)
+}
+
+// Update godoc
+// @Summary      Update provisioning connector
+// @Description  Update a provisioning connector. Any change but of the name fully synchronizes it again; a new URL also looks every resource up again in the new application. Disabling it stops its synchronization until it is enabled again.
+// @Tags         Provisioning
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string              true  "Bearer token"
+// @Param        id             path      string              true  "Connector ID (UUID)"
+// @Param        request        body      UpdateConnectorReq  true  "Fields to update"
+// @Success      200            {object}  Response{data=domain.ProvisioningConnector}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/provisioning/connectors/{id} [put]
+func (h *ProvisioningHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid connector id")
+		return
+	}
+	var req UpdateConnectorReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	input := domain.UpdateConnectorInput{
+		Name:             req.Name,
+		URL:              req.URL,
+		Token:            req.Token,
+		SyncGroups:       req.SyncGroups,
+		AttributeMapping: req.AttributeMapping,
+		Enabled:          req.Enabled,
+	}
+	if req.ScopeGroupID != nil {
+		if *req.ScopeGroupID == "" {
+			input.ClearScope = true
+		} else {
+			groupID, err := uuid.Parse(*req.ScopeGroupID)
+			if err != nil {
+				Error(c, http.StatusBadRequest, "invalid scope_group_id")
+				return
+			}
+			input.ScopeGroupID = &groupID
+		}
+	}
+
+	conn, err := h.provisioningService.UpdateConnector(c.Request.Context(), id, input)
+	if err != nil {
+		writeProvisioningError(c, err, "failed to update connector")
+		return
+	}
+	OK(c, conn)
+}
+
+// Delete godoc
+// @Summary      Delete provisioning connector
+// @Description  Delete a provisioning connector. The users and groups it provisioned are left in the application.
+// @Tags         Provisioning
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Connector ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/provisioning/connectors/{id} [delete]
+func (h *ProvisioningHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid connector id")
+		return
+	}
+
+	if err := h.provisioningService.DeleteConnector(c.Request.Context(), id); err != nil {
+		writeProvisioningError(c, err, "failed to delete connector")
+		return
+	}
+	OK(c, nil)
+}
+
+// Objects godoc
+// @Summary      List provisioned objects
+// @Description  List the users and groups of a provisioning connector with the ID of their resource in the application and, for those failing to synchronize, the error; failing objects come first. Failures are retried when the object changes and at every full synchronization.
+// @Tags         Provisioning
+// @Produce      json
+// @Param        Authorization  header    string  true   "Bearer token"
+// @Param        id             path      string  true   "Connector ID (UUID)"
+// @Param        type           query     string  false  "user or group"
+// @Param        failed         query     bool    false  "Only objects failing to synchronize"
+// @Param        page           query     int     false  "Page number (default 1)"
+// @Param        page_size      query     int     false  "Page size (default 20, max 100)"
+// @Success      200            {object}  Response{data=domain.ListResult[domain.ProvisionedObject]}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/provisioning/connectors/{id}/objects [get]
+func (h *ProvisioningHandler) Objects(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid connector id")
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	failed, _ := strconv.ParseBool(c.DefaultQuery("failed", "false"))
+
+	result, err := h.provisioningService.ListObjects(c.Request.Context(), id, domain.ListProvisionedObjectsInput{
+		ResourceType: domain.ProvisionedResourceType(c.Query("type")),
+		FailedOnly:   failed,
+		Page:         page,
+		PageSize:     pageSize,
+	})
+	if err != nil {
+		writeProvisioningError(c, err, "failed to list provisioned objects")
+		return
+	}
+	OK(c, result)
+}
+
+// Sync godoc
+// @Summary      Synchronize provisioning connector
+// @Description  Synchronize an enabled connector now and return what was done. By default only the users and groups changed since it was last synchronized are sent; with full=true every resource is compared with the application's, correcting changes made there and retrying failures. Returns 502 if the application does not respond.
+// @Tags         Provisioning
+// @Produce      json
+// @Param        Authorization  header    string  true   "Bearer token"
+// @Param        id             path      string  true   "Connector ID (UUID)"
+// @Param        full           query     bool    false  "Fully synchronize"
+// @Success      200            {object}  Response{data=domain.ProvisioningSyncResult}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Failure      502            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/provisioning/connectors/{id}/sync [post]
+func (h *ProvisioningHandler) Sync(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid connector id")
+		return
+	}
+	full, _ := strconv.ParseBool(c.DefaultQuery("full", "false"))
+
+	result, err := h.provisioningService.SyncConnector(c.Request.Context(), id, full)
+	if err != nil {
+		writeProvisioningError(c, err, "failed to synchronize connector")
+		return
+	}
+	OK(c, result)
+}
+
+func writeProvisioningError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidConnector):
+		Error(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrConnectorNotFound):
+		Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrConnectorUnreachable):
+		Error(c, http.StatusBadGateway, err.Error())
+	default:
+		Error(c, http.StatusInternalServerError, msg+": "+err.Error())
+	}
+}
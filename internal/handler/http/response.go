@@ -22,3 +22,8 @@ func OK(c *gin.Context, data interface{}) {
 func Error(c *gin.Context, httpStatus int, msg string) {
 	c.JSON(httpStatus, Response{Code: -1, Message: msg})
 }
+
+// ErrorWithData sends an error response carrying structured details.
+func ErrorWithData(c *gin.Context, httpStatus int, msg string, data interface{}) {
+	c.JSON(httpStatus, Response{Code: -1, Message: msg, Data: data})
+}
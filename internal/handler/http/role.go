@@ -0,0 +1,343 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// RoleHandler handles role and role assignment endpoints.
+type RoleHandler struct {
+	roleService *service.RoleService
+}
+
+// NewRoleHandler creates a new RoleHandler.
+func NewRoleHandler(roleSvc *service.RoleService) *RoleHandler {
+	return &RoleHandler{roleService: roleSvc}
+}
+
+// CreateRoleReq is the request DTO for creating a role.
+type CreateRoleReq struct {
+	Name        string   `json:"name" binding:"required,max=64"`
+	Description string   `json:"description,omitempty" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// UpdateRoleReq is the request DTO for updating a role.
+type UpdateRoleReq struct {
+	Name        *string  `json:"name,omitempty" binding:"omitempty,max=64"`
+	Description *string  `json:"description,omitempty" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// AssignRoleUsersReq is the request DTO for assigning a role to users.
+type AssignRoleUsersReq struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1"`
+}
+
+// AssignRoleGroupsReq is the request DTO for assigning a role to groups.
+type AssignRoleGroupsReq struct {
+	GroupIDs []string `json:"group_ids" binding:"required,min=1"`
+}
+
+// Create godoc
+// @Summary      Create role
+// @Description  Create a role with a set of permissions
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string         true  "Bearer token"
+// @Param        request        body      CreateRoleReq  true  "Role info"
+// @Success      200            {object}  Response{data=domain.Role}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/roles [post]
+func (h *RoleHandler) Create(c *gin.Context) {
+	var req CreateRoleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	r, err := h.roleService.CreateRole(c.Request.Context(), domain.CreateRoleInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRole) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to create role: "+err.Error())
+		return
+	}
+	OK(c, r)
+}
+
+// List godoc
+// @Summary      List roles
+// @Description  List all roles
+// @Tags         Role
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=[]domain.Role}
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/roles [get]
+func (h *RoleHandler) List(c *gin.Context) {
+	roles, err := h.roleService.ListRoles(c.Request.Context())
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to list roles")
+		return
+	}
+	OK(c, roles)
+}
+
+// Get godoc
+// @Summary      Get role
+// @Description  Retrieve a role by ID with its assigned users and groups
+// @Tags         Role
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Role ID (UUID)"
+// @Success      200            {object}  Response{data=domain.Role}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/roles/{id} [get]
+func (h *RoleHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid role id")
+		return
+	}
+
+	r, err := h.roleService.GetRole(c.Request.Context(), id)
+	if err != nil {
+		Error(c, http.StatusNotFound, "role not found")
+		return
+	}
+	OK(c, r)
+}
+
+// Update godoc
+// @Summary      Update role
+// @Description  Update a custom role's name, description or permissions
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string         true  "Bearer token"
+// @Param        id             path      string         true  "Role ID (UUID)"
+// @Param        request        body      UpdateRoleReq  true  "Fields to update"
+// @Success      200            {object}  Response{data=domain.Role}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/roles/{id} [put]
+func (h *RoleHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid role id")
+		return
+	}
+
+	var req UpdateRoleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	r, err := h.roleService.UpdateRole(c.Request.Context(), id, domain.UpdateRoleInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRole) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to update role: "+err.Error())
+		return
+	}
+	OK(c, r)
+}
+
+// Delete godoc
+// @Summary      Delete role
+// @Description  Delete a custom role
+// @Tags         Role
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Role ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/roles/{id} [delete]
+func (h *RoleHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid role id")
+		return
+	}
+
+	if err := h.roleService.DeleteRole(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrInvalidRole) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to delete role")
+		return
+	}
+	OK(c, nil)
+}
+
+// AssignUsers godoc
+// @Summary      Assign role to users
+// @Description  Grant a role to users
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string              true  "Bearer token"
+// @Param        id             path      string              true  "Role ID (UUID)"
+// @Param        request        body      AssignRoleUsersReq  true  "User IDs"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/roles/{id}/users [post]
+func (h *RoleHandler) AssignUsers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid role id")
+		return
+	}
+
+	var req AssignRoleUsersReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+	userIDs, err := parseUUIDs(req.UserIDs)
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user_id: "+err.Error())
+		return
+	}
+
+	if err := h.roleService.AssignUsers(c.Request.Context(), id, userIDs); err != nil {
+		Error(c, http.StatusInternalServerError, "failed to assign role")
+		return
+	}
+	OK(c, nil)
+}
+
+// UnassignUser godoc
+// @Summary      Unassign role from user
+// @Description  Revoke a role from a user
+// @Tags         Role
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Role ID (UUID)"
+// @Param        uid            path      string  true  "User ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/roles/{id}/users/{uid} [delete]
+func (h *RoleHandler) UnassignUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid role id")
+		return
+	}
+	userID, err := uuid.Parse(c.Param("uid"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if err := h.roleService.UnassignUser(c.Request.Context(), id, userID); err != nil {
+		Error(c, http.StatusInternalServerError, "failed to unassign role")
+		return
+	}
+	OK(c, nil)
+}
+
+// AssignGroups godoc
+// @Summary      Assign role to groups
+// @Description  Grant a role to groups; all members of the groups inherit it
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string               true  "Bearer token"
+// @Param        id             path      string               true  "Role ID (UUID)"
+// @Param        request        body      AssignRoleGroupsReq  true  "Group IDs"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/roles/{id}/groups [post]
+func (h *RoleHandler) AssignGroups(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid role id")
+		return
+	}
+
+	var req AssignRoleGroupsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+	groupIDs, err := parseUUIDs(req.GroupIDs)
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid group_id: "+err.Error())
+		return
+	}
+
+	if err := h.roleService.AssignGroups(c.Request.Context(), id, groupIDs); err != nil {
+		Error(c, http.StatusInternalServerError, "failed to assign role")
+		return
+	}
+	OK(c, nil)
+}
+
+// UnassignGroup godoc
+// @Summary      Unassign role from group
+// @Description  Revoke a role from a group
+// @Tags         Role
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Role ID (UUID)"
+// @Param        gid            path      string  true  "Group ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/roles/{id}/groups/{gid} [delete]
+func (h *RoleHandler) UnassignGroup(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid role id")
+		return
+	}
+	groupID, err := uuid.Parse(c.Param("gid"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid group id")
+		return
+	}
+
+	if err := h.roleService.UnassignGroup(c.Request.Context(), id, groupID); err != nil {
+		Error(c, http.StatusInternalServerError, "failed to unassign role")
+		return
+	}
+	OK(c, nil)
+}
@@ -7,60 +7,256 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/qinzj/claude-demo/internal/config"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/handler/scim"
 	"github.com/qinzj/claude-demo/internal/middleware"
 	"github.com/qinzj/claude-demo/internal/service"
 )
 
 // SetupRouter creates and configures the Gin router with all routes.
+// Every route except login, its MFA steps, token refresh, password reset and accepting an invitation requires a valid JWT; most routes also
+// require a permission granted through the caller's roles.
 func SetupRouter(
 	userSvc *service.UserService,
 	groupSvc *service.GroupService,
 	authSvc *service.AuthService,
+	attrSvc *service.AttributeService,
+	roleSvc *service.RoleService,
+	oidcSvc *service.OIDCService,
+	saSvc *service.ServiceAccountService,
+	mfaSvc *service.MFAService,
+	policySvc *service.PasswordPolicyService,
+	resetSvc *service.PasswordResetService,
+	invitationSvc *service.InvitationService,
+	auditSvc *service.AuditService,
+	webhookSvc *service.WebhookService,
+	provisioningSvc *service.ProvisioningService,
+	directorySyncSvc *service.DirectorySyncService,
+	ldifSvc *service.LDIFService,
+	ldifExporter LDIFExporter,
+	csvSvc *service.CSVService,
+	serverCfg *config.ServerConfig,
 	ldapCfg *config.LDAPConfig,
+	selfCfg *config.SelfServiceConfig,
 	logger *zap.Logger,
 ) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
+	// X-Forwarded-For is only honoured from the configured proxies; otherwise
+	// any client could choose the IP used for rate limiting and auditing.
+	if err := r.SetTrustedProxies(serverCfg.TrustedProxies); err != nil {
+		logger.Fatal("invalid server.trusted_proxies", zap.Error(err))
+	}
 	r.Use(gin.Recovery())
 	r.Use(middleware.Logger(logger))
+	r.Use(middleware.RequestInfo())
 
 	authHandler := NewAuthHandler(authSvc)
 	userHandler := NewUserHandler(userSvc, groupSvc)
 	groupHandler := NewGroupHandler(groupSvc)
-	ldapHandler := NewLDAPConfigHandler(ldapCfg)
+	ldapHandler := NewLDAPConfigHandler(ldapCfg, auditSvc)
+	attrHandler := NewAttributeHandler(attrSvc)
+	roleHandler := NewRoleHandler(roleSvc)
+	meHandler := NewMeHandler(userSvc, groupSvc, selfCfg)
+	sessionHandler := NewSessionHandler(authSvc)
+	mfaHandler := NewMFAHandler(mfaSvc)
+	oidcHandler := NewOIDCHandler(oidcSvc, authSvc, saSvc)
+	oidcClientHandler := NewOIDCClientHandler(oidcSvc)
+	serviceAccountHandler := NewServiceAccountHandler(saSvc)
+	policyHandler := NewPasswordPolicyHandler(policySvc)
+	resetHandler := NewPasswordResetHandler(resetSvc)
+	invitationHandler := NewInvitationHandler(invitationSvc)
+	auditHandler := NewAuditHandler(auditSvc)
+	webhookHandler := NewWebhookHandler(webhookSvc)
+	provisioningHandler := NewProvisioningHandler(provisioningSvc)
+	directorySyncHandler := NewDirectorySyncHandler(directorySyncSvc)
+	ldifHandler := NewLDIFHandler(ldifExporter, ldifSvc, ldapCfg)
+	csvHandler := NewCSVHandler(csvSvc)
+	scimHandler := scim.New(userSvc, groupSvc, attrSvc)
 
 	// Swagger UI: GET /swagger/index.html
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Public keys for verifying access tokens.
+	r.GET("/.well-known/jwks.json", authHandler.JWKS)
+
+	// OpenID Connect provider.
+	r.GET("/.well-known/openid-configuration", oidcHandler.Discovery)
+	oauth := r.Group("/oauth2")
+	{
+		oauth.GET("/authorize", oidcHandler.Authorize)
+		oauth.POST("/login", oidcHandler.Login)
+		oauth.POST("/consent", oidcHandler.Consent)
+		oauth.POST("/token", oidcHandler.Token)
+		oauth.GET("/userinfo", oidcHandler.UserInfo)
+		oauth.POST("/userinfo", oidcHandler.UserInfo)
+	}
+
+	// SCIM 2.0 provisioning, with SCIM errors instead of the usual envelope.
+	scimHandler.RegisterRoutes(r.Group(scim.BasePath, middleware.AuthenticateWith(authSvc, saSvc, scim.Abort)))
+
+	perm := middleware.RequirePermission
+
 	api := r.Group("/api/v1")
 	{
 		api.POST("/auth/login", authHandler.Login)
-		api.POST("/auth/logout", authHandler.Logout)
-		api.POST("/users", userHandler.Create)
+		api.POST("/auth/mfa/verify", authHandler.VerifyMFA)
+		api.POST("/auth/mfa/enroll", authHandler.BeginMFAEnrollment)
+		api.POST("/auth/mfa/enroll/confirm", authHandler.ConfirmMFAEnrollment)
+		api.POST("/auth/refresh", authHandler.Refresh)
+		api.POST("/auth/password/forgot", resetHandler.Forgot)
+		api.GET("/auth/password/reset", resetHandler.Check)
+		api.POST("/auth/password/reset", resetHandler.Reset)
+		api.GET("/auth/invitation", invitationHandler.Check)
+		api.POST("/auth/invitation/mfa", invitationHandler.BeginMFAEnrollment)
+		api.POST("/auth/invitation/accept", invitationHandler.Accept)
 
 		protected := api.Group("")
-		protected.Use(middleware.JWTAuth(authSvc))
+		protected.Use(middleware.Authenticate(authSvc, saSvc))
+		// Users whose password expired or was reset may only change it.
+		protected.Use(middleware.RequireCurrentPassword(
+			"/api/v1/auth/logout",
+			"/api/v1/me",
+			"/api/v1/me/password",
+		))
 		{
-			protected.GET("/users", userHandler.List)
-			protected.GET("/users/:id", userHandler.Get)
-			protected.PUT("/users/:id", userHandler.Update)
-			protected.DELETE("/users/:id", userHandler.Delete)
-			protected.PUT("/users/:id/password", userHandler.ChangePassword)
-			protected.PUT("/users/:id/status", userHandler.SetStatus)
-			protected.GET("/users/:id/groups", userHandler.GetGroups)
-
-			protected.POST("/groups", groupHandler.Create)
-			protected.GET("/groups", groupHandler.List)
-			protected.GET("/groups/:id", groupHandler.Get)
-			protected.PUT("/groups/:id", groupHandler.Update)
-			protected.DELETE("/groups/:id", groupHandler.Delete)
+			protected.POST("/auth/logout", middleware.RequireUser(), authHandler.Logout)
+
+			// Self-service: always scoped to the authenticated user.
+			me := protected.Group("/me", middleware.RequireUser())
+			me.GET("", meHandler.Get)
+			me.PUT("", meHandler.Update)
+			me.GET("/password", meHandler.GetPasswordStatus)
+			me.PUT("/password", meHandler.ChangePassword)
+			me.GET("/groups", meHandler.GetGroups)
+			me.GET("/ssh-keys", meHandler.ListSSHKeys)
+			me.POST("/ssh-keys", meHandler.AddSSHKey)
+			me.DELETE("/ssh-keys/:id", meHandler.DeleteSSHKey)
+			me.GET("/sessions", sessionHandler.ListMine)
+			me.DELETE("/sessions/:id", sessionHandler.RevokeMine)
+			me.GET("/mfa", mfaHandler.Status)
+			me.DELETE("/mfa", mfaHandler.Disable)
+			me.POST("/mfa/totp", mfaHandler.BeginTOTP)
+			me.POST("/mfa/totp/confirm", mfaHandler.ConfirmTOTP)
+			me.POST("/mfa/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+
+			protected.POST("/users", perm(domain.PermissionUserWrite), userHandler.Create)
+			protected.POST("/users/invitations", perm(domain.PermissionUserWrite), invitationHandler.Invite)
+			protected.GET("/users", perm(domain.PermissionUserRead), userHandler.List)
+			protected.GET("/users/export.csv", perm(domain.PermissionUserRead), csvHandler.Export)
+			protected.POST("/users/import.csv", perm(domain.PermissionUserWrite), csvHandler.Import)
+			protected.GET("/users/:id", perm(domain.PermissionUserRead), userHandler.Get)
+			protected.PUT("/users/:id", perm(domain.PermissionUserWrite), userHandler.Update)
+			protected.DELETE("/users/:id", perm(domain.PermissionUserWrite), userHandler.Delete)
+			protected.PUT("/users/:id/password", middleware.RequireSelfOrPermission("id", domain.PermissionUserWrite), userHandler.ChangePassword)
+			protected.POST("/users/:id/password/reset", perm(domain.PermissionUserWrite), userHandler.ResetPassword)
+			protected.POST("/users/:id/password/reset-link", perm(domain.PermissionUserWrite), resetHandler.SendLink)
+			protected.PUT("/users/:id/status", perm(domain.PermissionUserWrite), userHandler.SetStatus)
+			protected.POST("/users/:id/invitation", perm(domain.PermissionUserWrite), invitationHandler.Resend)
+			protected.GET("/users/:id/groups", perm(domain.PermissionUserRead), userHandler.GetGroups)
+			protected.GET("/users/:id/org", perm(domain.PermissionUserRead), userHandler.GetOrgChart)
+			protected.GET("/users/:id/sessions", perm(domain.PermissionUserRead), sessionHandler.ListUser)
+			protected.DELETE("/users/:id/sessions", perm(domain.PermissionUserWrite), sessionHandler.RevokeAllUser)
+			protected.DELETE("/users/:id/sessions/:sid", perm(domain.PermissionUserWrite), sessionHandler.RevokeUser)
+			protected.DELETE("/users/:id/mfa", perm(domain.PermissionUserWrite), mfaHandler.Reset)
+			protected.GET("/users/:id/lockout", perm(domain.PermissionUserRead), userHandler.GetLockout)
+			protected.DELETE("/users/:id/lockout", perm(domain.PermissionUserWrite), userHandler.Unlock)
+			protected.GET("/users/:id/history", perm(domain.PermissionUserRead), userHandler.History)
+			protected.GET("/users/:id/snapshot", perm(domain.PermissionUserRead), userHandler.Snapshot)
+			protected.POST("/users/:id/history/:version/revert", perm(domain.PermissionUserWrite), userHandler.Revert)
+
+			protected.POST("/groups", perm(domain.PermissionGroupAdmin), groupHandler.Create)
+			protected.GET("/groups", perm(domain.PermissionGroupRead), groupHandler.List)
+			protected.GET("/groups/:id", perm(domain.PermissionGroupRead), groupHandler.Get)
+			protected.PUT("/groups/:id", perm(domain.PermissionGroupAdmin), groupHandler.Update)
+			protected.DELETE("/groups/:id", perm(domain.PermissionGroupAdmin), groupHandler.Delete)
+			protected.GET("/groups/:id/members", perm(domain.PermissionGroupRead), groupHandler.GetMembers)
+			protected.GET("/groups/:id/history", perm(domain.PermissionGroupRead), groupHandler.History)
+			protected.GET("/groups/:id/snapshot", perm(domain.PermissionGroupRead), groupHandler.Snapshot)
+			// Membership and ownership changes are authorized by GroupService:
+			// group owners may manage their groups without group:admin.
 			protected.POST("/groups/:id/members", groupHandler.AddMembers)
 			protected.DELETE("/groups/:id/members/:uid", groupHandler.RemoveMember)
-			protected.GET("/groups/:id/members", groupHandler.GetMembers)
+			protected.POST("/groups/:id/owners", groupHandler.AddOwners)
+			protected.DELETE("/groups/:id/owners/users/:uid", groupHandler.RemoveOwnerUser)
+			protected.DELETE("/groups/:id/owners/groups/:gid", groupHandler.RemoveOwnerGroup)
 
-			protected.GET("/ldap/config", ldapHandler.GetConfig)
-			protected.PUT("/ldap/config", ldapHandler.UpdateConfig)
+			protected.POST("/attributes", perm(domain.PermissionAttributeAdmin), attrHandler.Create)
+			protected.GET("/attributes", attrHandler.List)
+			protected.GET("/attributes/:id", attrHandler.Get)
+			protected.PUT("/attributes/:id", perm(domain.PermissionAttributeAdmin), attrHandler.Update)
+			protected.DELETE("/attributes/:id", perm(domain.PermissionAttributeAdmin), attrHandler.Delete)
+
+			roles := protected.Group("/roles", perm(domain.PermissionRoleAdmin))
+			roles.POST("", roleHandler.Create)
+			roles.GET("", roleHandler.List)
+			roles.GET("/:id", roleHandler.Get)
+			roles.PUT("/:id", roleHandler.Update)
+			roles.DELETE("/:id", roleHandler.Delete)
+			roles.POST("/:id/users", roleHandler.AssignUsers)
+			roles.DELETE("/:id/users/:uid", roleHandler.UnassignUser)
+			roles.POST("/:id/groups", roleHandler.AssignGroups)
+			roles.DELETE("/:id/groups/:gid", roleHandler.UnassignGroup)
+
+			policies := protected.Group("/password-policies", perm(domain.PermissionPasswordPolicy))
+			policies.POST("", policyHandler.Create)
+			policies.GET("", policyHandler.List)
+			policies.GET("/:id", policyHandler.Get)
+			policies.PUT("/:id", policyHandler.Update)
+			policies.DELETE("/:id", policyHandler.Delete)
+			policies.POST("/:id/groups", policyHandler.AssignGroups)
+			policies.DELETE("/:id/groups/:gid", policyHandler.UnassignGroup)
+
+			oidcClients := protected.Group("/oidc/clients", perm(domain.PermissionOIDCAdmin))
+			oidcClients.POST("", oidcClientHandler.Create)
+			oidcClients.GET("", oidcClientHandler.List)
+			oidcClients.GET("/:id", oidcClientHandler.Get)
+			oidcClients.PUT("/:id", oidcClientHandler.Update)
+			oidcClients.DELETE("/:id", oidcClientHandler.Delete)
+			oidcClients.POST("/:id/secret", oidcClientHandler.RotateSecret)
+
+			serviceAccounts := protected.Group("/service-accounts", perm(domain.PermissionServiceAccount))
+			serviceAccounts.POST("", serviceAccountHandler.Create)
+			serviceAccounts.GET("", serviceAccountHandler.List)
+			serviceAccounts.GET("/:id", serviceAccountHandler.Get)
+			serviceAccounts.PUT("/:id", serviceAccountHandler.Update)
+			serviceAccounts.DELETE("/:id", serviceAccountHandler.Delete)
+			serviceAccounts.POST("/:id/keys", serviceAccountHandler.CreateKey)
+			serviceAccounts.POST("/:id/keys/:kid/rotate", serviceAccountHandler.RotateKey)
+			serviceAccounts.DELETE("/:id/keys/:kid", serviceAccountHandler.DeleteKey)
+
+			protected.GET("/ldap/config", perm(domain.PermissionLDAPConfig), ldapHandler.GetConfig)
+			protected.PUT("/ldap/config", perm(domain.PermissionLDAPConfig), ldapHandler.UpdateConfig)
 			protected.GET("/ldap/status", ldapHandler.GetStatus)
+
+			audit := protected.Group("/audit", perm(domain.PermissionAuditRead))
+			audit.GET("", auditHandler.List)
+			audit.GET("/export", auditHandler.Export)
+
+			webhooks := protected.Group("/webhooks", perm(domain.PermissionWebhookAdmin))
+			webhooks.POST("", webhookHandler.Create)
+			webhooks.GET("", webhookHandler.List)
+			webhooks.GET("/:id", webhookHandler.Get)
+			webhooks.PUT("/:id", webhookHandler.Update)
+			webhooks.DELETE("/:id", webhookHandler.Delete)
+			webhooks.GET("/:id/deliveries", webhookHandler.Deliveries)
+			webhooks.POST("/:id/deliveries/:did/retry", webhookHandler.RetryDelivery)
+
+			connectors := protected.Group("/provisioning/connectors", perm(domain.PermissionProvisioning))
+			connectors.POST("", provisioningHandler.Create)
+			connectors.GET("", provisioningHandler.List)
+			connectors.GET("/:id", provisioningHandler.Get)
+			connectors.PUT("/:id", provisioningHandler.Update)
+			connectors.DELETE("/:id", provisioningHandler.Delete)
+			connectors.GET("/:id/objects", provisioningHandler.Objects)
+			connectors.POST("/:id/sync", provisioningHandler.Sync)
+
+			directories := protected.Group("/directory-sync/sources", perm(domain.PermissionDirectorySync))
+			directories.GET("", directorySyncHandler.Sources)
+			directories.POST("/:name/sync", directorySyncHandler.Sync)
+
+			protected.GET("/export.ldif", perm(domain.PermissionUserRead), perm(domain.PermissionGroupRead), ldifHandler.Export)
+			protected.POST("/import.ldif", perm(domain.PermissionUserWrite), perm(domain.PermissionGroupAdmin), ldifHandler.Import)
 		}
 	}
 
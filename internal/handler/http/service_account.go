@@ -0,0 +1,321 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// ServiceAccountHandler handles service account and API key endpoints.
+type ServiceAccountHandler struct {
+	serviceAccountService *service.ServiceAccountService
+}
+
+// NewServiceAccountHandler creates a new ServiceAccountHandler.
+func NewServiceAccountHandler(saSvc *service.ServiceAccountService) *ServiceAccountHandler {
+	return &ServiceAccountHandler{serviceAccountService: saSvc}
+}
+
+// CreateServiceAccountReq is the request DTO for creating a service account.
+type CreateServiceAccountReq struct {
+	Name        string   `json:"name" binding:"required,max=64"`
+	Description string   `json:"description,omitempty" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// UpdateServiceAccountReq is the request DTO for updating a service account.
+type UpdateServiceAccountReq struct {
+	Description *string  `json:"description,omitempty" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions,omitempty"`
+	Disabled    *bool    `json:"disabled,omitempty"`
+}
+
+// CreateAPIKeyReq is the request DTO for creating an API key.
+type CreateAPIKeyReq struct {
+	Name      string     `json:"name" binding:"required,max=64"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeyResp carries an API key together with its value, which is only
+// returned when the key is created or rotated.
+type APIKeyResp struct {
+	APIKey *domain.APIKey `json:"api_key"`
+	Key    string         `json:"key"`
+}
+
+// Create godoc
+// @Summary      Create service account
+// @Description  Create a machine identity with a set of permissions; the caller can only grant permissions they hold
+// @Tags         ServiceAccount
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string                   true  "Bearer token"
+// @Param        request        body      CreateServiceAccountReq  true  "Service account info"
+// @Success      200            {object}  Response{data=domain.ServiceAccount}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/service-accounts [post]
+func (h *ServiceAccountHandler) Create(c *gin.Context) {
+	var req CreateServiceAccountReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	sa, err := h.serviceAccountService.CreateServiceAccount(c.Request.Context(), domain.CreateServiceAccountInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		writeServiceAccountError(c, err, "failed to create service account")
+		return
+	}
+	OK(c, sa)
+}
+
+// List godoc
+// @Summary      List service accounts
+// @Description  List all service accounts
+// @Tags         ServiceAccount
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=[]domain.ServiceAccount}
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/service-accounts [get]
+func (h *ServiceAccountHandler) List(c *gin.Context) {
+	accounts, err := h.serviceAccountService.ListServiceAccounts(c.Request.Context())
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to list service accounts")
+		return
+	}
+	OK(c, accounts)
+}
+
+// Get godoc
+// @Summary      Get service account
+// @Description  Retrieve a service account with its API keys (without their values)
+// @Tags         ServiceAccount
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Service account ID (UUID)"
+// @Success      200            {object}  Response{data=domain.ServiceAccount}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/service-accounts/{id} [get]
+func (h *ServiceAccountHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid service account id")
+		return
+	}
+
+	sa, err := h.serviceAccountService.GetServiceAccount(c.Request.Context(), id)
+	if err != nil {
+		Error(c, http.StatusNotFound, "service account not found")
+		return
+	}
+	OK(c, sa)
+}
+
+// Update godoc
+// @Summary      Update service account
+// @Description  Update a service account's description or permissions, or disable it; disabling rejects its keys and tokens immediately
+// @Tags         ServiceAccount
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string                   true  "Bearer token"
+// @Param        id             path      string                   true  "Service account ID (UUID)"
+// @Param        request        body      UpdateServiceAccountReq  true  "Fields to update"
+// @Success      200            {object}  Response{data=domain.ServiceAccount}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/service-accounts/{id} [put]
+func (h *ServiceAccountHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid service account id")
+		return
+	}
+
+	var req UpdateServiceAccountReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	sa, err := h.serviceAccountService.UpdateServiceAccount(c.Request.Context(), id, domain.UpdateServiceAccountInput{
+		Description: req.Description,
+		Permissions: req.Permissions,
+		Disabled:    req.Disabled,
+	})
+	if err != nil {
+		writeServiceAccountError(c, err, "failed to update service account")
+		return
+	}
+	OK(c, sa)
+}
+
+// Delete godoc
+// @Summary      Delete service account
+// @Description  Delete a service account and its API keys
+// @Tags         ServiceAccount
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Service account ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/service-accounts/{id} [delete]
+func (h *ServiceAccountHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid service account id")
+		return
+	}
+
+	if err := h.serviceAccountService.DeleteServiceAccount(c.Request.Context(), id); err != nil {
+		Error(c, http.StatusInternalServerError, "failed to delete service account")
+		return
+	}
+	OK(c, nil)
+}
+
+// CreateKey godoc
+// @Summary      Create API key
+// @Description  Create an API key for a service account. The key is returned only once; use it as a Bearer token, as client_secret for the client credentials grant, or as LDAP bind password.
+// @Tags         ServiceAccount
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string           true  "Bearer token"
+// @Param        id             path      string           true  "Service account ID (UUID)"
+// @Param        request        body      CreateAPIKeyReq  true  "Key name and optional expiry"
+// @Success      200            {object}  Response{data=APIKeyResp}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/service-accounts/{id}/keys [post]
+func (h *ServiceAccountHandler) CreateKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid service account id")
+		return
+	}
+
+	var req CreateAPIKeyReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	k, key, err := h.serviceAccountService.CreateAPIKey(c.Request.Context(), id, domain.CreateAPIKeyInput{
+		Name:      req.Name,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidServiceAccount) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		Error(c, http.StatusNotFound, "service account not found")
+		return
+	}
+	OK(c, APIKeyResp{APIKey: k, Key: key})
+}
+
+// RotateKey godoc
+// @Summary      Rotate API key
+// @Description  Replace an API key with one of a new ID and value, keeping its name and expiry; the old value and access tokens issued with it stop working immediately
+// @Tags         ServiceAccount
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Service account ID (UUID)"
+// @Param        kid            path      string  true  "API key ID (UUID)"
+// @Success      200            {object}  Response{data=APIKeyResp}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/service-accounts/{id}/keys/{kid}/rotate [post]
+func (h *ServiceAccountHandler) RotateKey(c *gin.Context) {
+	id, keyID, ok := parseServiceAccountKeyIDs(c)
+	if !ok {
+		return
+	}
+
+	k, key, err := h.serviceAccountService.RotateAPIKey(c.Request.Context(), id, keyID)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		Error(c, http.StatusNotFound, "api key not found")
+		return
+	}
+	OK(c, APIKeyResp{APIKey: k, Key: key})
+}
+
+// DeleteKey godoc
+// @Summary      Delete API key
+// @Description  Revoke an API key together with the access tokens issued with it
+// @Tags         ServiceAccount
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Service account ID (UUID)"
+// @Param        kid            path      string  true  "API key ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/service-accounts/{id}/keys/{kid} [delete]
+func (h *ServiceAccountHandler) DeleteKey(c *gin.Context) {
+	id, keyID, ok := parseServiceAccountKeyIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.serviceAccountService.DeleteAPIKey(c.Request.Context(), id, keyID); err != nil {
+		Error(c, http.StatusNotFound, "api key not found")
+		return
+	}
+	OK(c, nil)
+}
+
+func parseServiceAccountKeyIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid service account id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	keyID, err := uuid.Parse(c.Param("kid"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid api key id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, keyID, true
+}
+
+func writeServiceAccountError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidServiceAccount):
+		Error(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrForbidden):
+		Error(c, http.StatusForbidden, err.Error())
+	default:
+		Error(c, http.StatusInternalServerError, msg+": "+err.Error())
+	}
+}
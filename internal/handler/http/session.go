@@ -0,0 +1,150 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// SessionHandler handles login session endpoints, both for the authenticated
+// user and for administrators managing other users' sessions.
+type SessionHandler struct {
+	authService *service.AuthService
+}
+
+// NewSessionHandler creates a new SessionHandler.
+func NewSessionHandler(authSvc *service.AuthService) *SessionHandler {
+	return &SessionHandler{authService: authSvc}
+}
+
+// ListMine godoc
+// @Summary      List my sessions
+// @Description  List the authenticated user's active login sessions
+// @Tags         Me
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=[]domain.Session}
+// @Failure      500            {object}  Response
+// @Router       /api/v1/me/sessions [get]
+func (h *SessionHandler) ListMine(c *gin.Context) {
+	h.list(c, currentUserID(c))
+}
+
+// RevokeMine godoc
+// @Summary      Revoke my session
+// @Description  Sign out one of the authenticated user's sessions, e.g. a lost device
+// @Tags         Me
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Session ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/me/sessions/{id} [delete]
+func (h *SessionHandler) RevokeMine(c *gin.Context) {
+	h.revoke(c, currentUserID(c), c.Param("id"))
+}
+
+// ListUser godoc
+// @Summary      List user sessions
+// @Description  List a user's active login sessions
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Success      200            {object}  Response{data=[]domain.Session}
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/users/{id}/sessions [get]
+func (h *SessionHandler) ListUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	h.list(c, userID)
+}
+
+// RevokeUser godoc
+// @Summary      Revoke user session
+// @Description  Revoke one of a user's login sessions
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Param        sid            path      string  true  "Session ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/users/{id}/sessions/{sid} [delete]
+func (h *SessionHandler) RevokeUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	h.revoke(c, userID, c.Param("sid"))
+}
+
+// RevokeAllUser godoc
+// @Summary      Revoke all user sessions
+// @Description  Sign a user out everywhere by revoking all of their sessions
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Success      200            {object}  Response{data=object{revoked=int}}
+// @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/users/{id}/sessions [delete]
+func (h *SessionHandler) RevokeAllUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	n, err := h.authService.RevokeSessions(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to revoke sessions")
+		return
+	}
+	OK(c, gin.H{"revoked": n})
+}
+
+func (h *SessionHandler) list(c *gin.Context, userID uuid.UUID) {
+	sessions, err := h.authService.ListSessions(c.Request.Context(), userID)
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to list sessions")
+		return
+	}
+	OK(c, sessions)
+}
+
+func (h *SessionHandler) revoke(c *gin.Context, userID uuid.UUID, rawSessionID string) {
+	sessionID, err := uuid.Parse(rawSessionID)
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid session id")
+		return
+	}
+
+	if err := h.authService.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		Error(c, http.StatusNotFound, "session not found")
+		return
+	}
+	OK(c, nil)
+}
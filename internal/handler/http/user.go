@@ -1,8 +1,11 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -22,26 +25,50 @@ func NewUserHandler(userSvc *service.UserService, groupSvc *service.GroupService
 	return &UserHandler{userService: userSvc, groupService: groupSvc}
 }
 
-// CreateUserReq is the request DTO for creating a user.
+// CreateUserReq is the request DTO for creating a user. The password is
+// checked against the default password policy. Instead of a password, a
+// password_hash migrated from another system may be given, in one of the
+// supported schemes such as {SSHA}, {CRYPT}$6$, PBKDF2 or argon2id.
 type CreateUserReq struct {
-	Username    string `json:"username" binding:"required,max=64"`
-	DisplayName string `json:"display_name" binding:"required,max=128"`
-	Email       string `json:"email" binding:"required,email,max=255"`
-	Password    string `json:"password" binding:"required,min=8"`
-	Phone       string `json:"phone,omitempty" binding:"omitempty,max=32"`
+	Username           string              `json:"username" binding:"required,max=64"`
+	DisplayName        string              `json:"display_name" binding:"required,max=128"`
+	Email              string              `json:"email" binding:"required,email,max=255"`
+	Password           string              `json:"password" binding:"required_without=PasswordHash,excluded_with=PasswordHash"`
+	PasswordHash       string              `json:"password_hash,omitempty"`
+	Phone              string              `json:"phone,omitempty" binding:"omitempty,max=32"`
+	Attributes         map[string][]string `json:"attributes,omitempty"`
+	MustChangePassword bool                `json:"must_change_password,omitempty"`
+	AccountExpiresAt   *time.Time          `json:"account_expires_at,omitempty"`
 }
 
 // UpdateUserReq is the request DTO for updating a user.
+// An empty manager_id removes the user's manager, and an empty
+// account_expires_at makes the account never expire.
 type UpdateUserReq struct {
-	DisplayName *string `json:"display_name,omitempty" binding:"omitempty,max=128"`
-	Email       *string `json:"email,omitempty" binding:"omitempty,email,max=255"`
-	Phone       *string `json:"phone,omitempty" binding:"omitempty,max=32"`
+	DisplayName      *string             `json:"display_name,omitempty" binding:"omitempty,max=128"`
+	Email            *string             `json:"email,omitempty" binding:"omitempty,email,max=255"`
+	Phone            *string             `json:"phone,omitempty" binding:"omitempty,max=32"`
+	ManagerID        *string             `json:"manager_id,omitempty"`
+	AccountExpiresAt *string             `json:"account_expires_at,omitempty"`
+	Attributes       map[string][]string `json:"attributes,omitempty"`
 }
 
 // ChangePasswordReq is the request DTO for changing password.
 type ChangePasswordReq struct {
 	OldPassword string `json:"old_password" binding:"required"`
-	NewPassword string `json:"new_password" binding:"required,min=8"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// ResetPasswordReq is the request DTO for an administrator resetting a
+// user's password. must_change_password defaults to true.
+type ResetPasswordReq struct {
+	NewPassword        string `json:"new_password" binding:"required"`
+	MustChangePassword *bool  `json:"must_change_password,omitempty"`
+}
+
+// PasswordViolationsResp carries the policy violations of a rejected password.
+type PasswordViolationsResp struct {
+	Violations []domain.PasswordViolation `json:"violations"`
 }
 
 // SetStatusReq is the request DTO for setting user status.
@@ -51,14 +78,16 @@ type SetStatusReq struct {
 
 // Create godoc
 // @Summary      Create user
-// @Description  Create a new user (no authentication required)
+// @Description  Create a new user (requires user:write). After account_expires_at the user can no longer log in. Users migrated from another directory can be created with their existing password_hash instead of a password; it is rehashed with the configured algorithm at their first login.
 // @Tags         User
 // @Accept       json
 // @Produce      json
-// @Param        request  body      CreateUserReq  true  "User info"
-// @Success      200      {object}  Response{data=domain.User}
-// @Failure      400      {object}  Response
-// @Failure      500      {object}  Response
+// @Param        Authorization  header    string         true  "Bearer token"
+// @Param        request        body      CreateUserReq  true  "User info"
+// @Success      200            {object}  Response{data=domain.User}
+// @Failure      400            {object}  Response{data=PasswordViolationsResp}
+// @Failure      403            {object}  Response
+// @Failure      500            {object}  Response
 // @Router       /api/v1/users [post]
 func (h *UserHandler) Create(c *gin.Context) {
 	var req CreateUserReq
@@ -68,13 +97,24 @@ func (h *UserHandler) Create(c *gin.Context) {
 	}
 
 	u, err := h.userService.CreateUser(c.Request.Context(), domain.CreateUserInput{
-		Username:    req.Username,
-		DisplayName: req.DisplayName,
-		Email:       req.Email,
-		Password:    req.Password,
-		Phone:       req.Phone,
+		Username:           req.Username,
+		DisplayName:        req.DisplayName,
+		Email:              req.Email,
+		Password:           req.Password,
+		PasswordHash:       req.PasswordHash,
+		Phone:              req.Phone,
+		Attributes:         req.Attributes,
+		MustChangePassword: req.MustChangePassword,
+		AccountExpiresAt:   req.AccountExpiresAt,
 	})
 	if err != nil {
+		if writePasswordPolicyError(c, err) {
+			return
+		}
+		if errors.Is(err, service.ErrInvalidAttributes) || errors.Is(err, service.ErrUnsupportedPasswordHash) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		Error(c, http.StatusInternalServerError, "failed to create user: "+err.Error())
 		return
 	}
@@ -116,7 +156,9 @@ func (h *UserHandler) Get(c *gin.Context) {
 // @Param        page           query     int     false  "Page number (default 1)"
 // @Param        page_size      query     int     false  "Page size (default 20, max 100)"
 // @Param        search         query     string  false  "Search by username, display_name, or email"
+// @Param        attr.{name}    query     string  false  "Exact match on an indexed custom attribute, e.g. attr.department=Sales"
 // @Success      200            {object}  Response{data=domain.ListResult[domain.User]}
+// @Failure      400            {object}  Response
 // @Failure      500            {object}  Response
 // @Router       /api/v1/users [get]
 func (h *UserHandler) List(c *gin.Context) {
@@ -125,20 +167,56 @@ func (h *UserHandler) List(c *gin.Context) {
 	search := c.Query("search")
 
 	result, err := h.userService.ListUsers(c.Request.Context(), domain.ListUsersInput{
-		Page:     page,
-		PageSize: pageSize,
-		Search:   search,
+		Page:       page,
+		PageSize:   pageSize,
+		Search:     search,
+		Attributes: attributeQuery(c),
 	})
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidAttributes) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		Error(c, http.StatusInternalServerError, "failed to list users")
 		return
 	}
 	OK(c, result)
 }
 
+// GetOrgChart godoc
+// @Summary      Get user org chart
+// @Description  Return the user's management chain and the tree of users reporting to them
+// @Tags         User
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Success      200            {object}  Response{data=domain.OrgChart}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/users/{id}/org [get]
+func (h *UserHandler) GetOrgChart(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	chart, err := h.userService.GetOrgChart(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			Error(c, http.StatusNotFound, "user not found")
+			return
+		}
+		Error(c, http.StatusInternalServerError, "failed to get org chart")
+		return
+	}
+	OK(c, chart)
+}
+
 // Update godoc
 // @Summary      Update user
-// @Description  Update user fields (display_name, email, phone)
+// @Description  Update user fields (display_name, email, phone, manager_id, account_expires_at, attributes)
 // @Tags         User
 // @Accept       json
 // @Produce      json
@@ -147,6 +225,7 @@ func (h *UserHandler) List(c *gin.Context) {
 // @Param        request        body      UpdateUserReq  true  "Fields to update"
 // @Success      200            {object}  Response{data=domain.User}
 // @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
 // @Failure      500            {object}  Response
 // @Router       /api/v1/users/{id} [put]
 func (h *UserHandler) Update(c *gin.Context) {
@@ -162,12 +241,47 @@ func (h *UserHandler) Update(c *gin.Context) {
 		return
 	}
 
-	u, err := h.userService.UpdateUser(c.Request.Context(), id, domain.UpdateUserInput{
+	input := domain.UpdateUserInput{
 		DisplayName: req.DisplayName,
 		Email:       req.Email,
 		Phone:       req.Phone,
-	})
+		Attributes:  req.Attributes,
+	}
+	if req.ManagerID != nil {
+		if *req.ManagerID == "" {
+			input.ClearManager = true
+		} else {
+			managerID, err := uuid.Parse(*req.ManagerID)
+			if err != nil {
+				Error(c, http.StatusBadRequest, "invalid manager id")
+				return
+			}
+			input.ManagerID = &managerID
+		}
+	}
+	if req.AccountExpiresAt != nil {
+		if *req.AccountExpiresAt == "" {
+			input.ClearAccountExpiry = true
+		} else {
+			expiresAt, err := time.Parse(time.RFC3339, *req.AccountExpiresAt)
+			if err != nil {
+				Error(c, http.StatusBadRequest, "invalid account_expires_at, expected RFC 3339")
+				return
+			}
+			input.AccountExpiresAt = &expiresAt
+		}
+	}
+
+	u, err := h.userService.UpdateUser(c.Request.Context(), id, input)
 	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrInvalidAttributes) || errors.Is(err, service.ErrInvalidManager) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		Error(c, http.StatusInternalServerError, "failed to update user")
 		return
 	}
@@ -183,6 +297,7 @@ func (h *UserHandler) Update(c *gin.Context) {
 // @Param        id             path      string  true  "User ID (UUID)"
 // @Success      200            {object}  Response
 // @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
 // @Failure      500            {object}  Response
 // @Router       /api/v1/users/{id} [delete]
 func (h *UserHandler) Delete(c *gin.Context) {
@@ -193,6 +308,10 @@ func (h *UserHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.userService.DeleteUser(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		Error(c, http.StatusInternalServerError, "failed to delete user")
 		return
 	}
@@ -201,7 +320,7 @@ func (h *UserHandler) Delete(c *gin.Context) {
 
 // ChangePassword godoc
 // @Summary      Change password
-// @Description  Change a user's password (requires old password verification)
+// @Description  Change a user's password (requires old password verification). The new password must satisfy the user's password policies. Wrong old passwords count as failed logins towards the account lockout.
 // @Tags         User
 // @Accept       json
 // @Produce      json
@@ -209,7 +328,8 @@ func (h *UserHandler) Delete(c *gin.Context) {
 // @Param        id             path      string             true  "User ID (UUID)"
 // @Param        request        body      ChangePasswordReq  true  "Old and new password"
 // @Success      200            {object}  Response
-// @Failure      400            {object}  Response
+// @Failure      400            {object}  Response{data=PasswordViolationsResp}
+// @Failure      429            {object}  Response
 // @Router       /api/v1/users/{id}/password [put]
 func (h *UserHandler) ChangePassword(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -225,15 +345,70 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 	}
 
 	if err := h.userService.ChangePassword(c.Request.Context(), id, req.OldPassword, req.NewPassword); err != nil {
+		if writePasswordPolicyError(c, err) || writeLockoutError(c, err) {
+			return
+		}
 		Error(c, http.StatusBadRequest, "failed to change password: "+err.Error())
 		return
 	}
 	OK(c, nil)
 }
 
+// ResetPassword godoc
+// @Summary      Reset password
+// @Description  Set a user's password without the old one (requires user:write). The new password must satisfy the user's password policies, except the minimum age. By default the user must change it at the next login.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string            true  "Bearer token"
+// @Param        id             path      string            true  "User ID (UUID)"
+// @Param        request        body      ResetPasswordReq  true  "New password"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response{data=PasswordViolationsResp}
+// @Failure      403            {object}  Response
+// @Router       /api/v1/users/{id}/password/reset [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req ResetPasswordReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+	mustChange := req.MustChangePassword == nil || *req.MustChangePassword
+
+	if err := h.userService.ResetPassword(c.Request.Context(), id, req.NewPassword, mustChange); err != nil {
+		if writePasswordPolicyError(c, err) {
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		Error(c, http.StatusBadRequest, "failed to reset password: "+err.Error())
+		return
+	}
+	OK(c, nil)
+}
+
+// writePasswordPolicyError writes a 400 response listing the violations if
+// err is a password policy error, and reports whether it did.
+func writePasswordPolicyError(c *gin.Context, err error) bool {
+	var perr *service.PasswordPolicyError
+	if !errors.As(err, &perr) {
+		return false
+	}
+	ErrorWithData(c, http.StatusBadRequest, service.ErrPasswordPolicy.Error(), PasswordViolationsResp{Violations: perr.Violations})
+	return true
+}
+
 // SetStatus godoc
 // @Summary      Set user status
-// @Description  Enable or disable a user account
+// @Description  Enable or disable a user account. Pending users cannot be enabled; they become enabled by accepting their invitation.
 // @Tags         User
 // @Accept       json
 // @Produce      json
@@ -242,6 +417,7 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 // @Param        request        body      SetStatusReq  true  "Status: enabled | disabled"
 // @Success      200            {object}  Response
 // @Failure      400            {object}  Response
+// @Failure      403            {object}  Response
 // @Failure      500            {object}  Response
 // @Router       /api/v1/users/{id}/status [put]
 func (h *UserHandler) SetStatus(c *gin.Context) {
@@ -258,6 +434,14 @@ func (h *UserHandler) SetStatus(c *gin.Context) {
 	}
 
 	if err := h.userService.SetUserStatus(c.Request.Context(), id, domain.UserStatus(req.Status)); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrUserPending) {
+			Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		Error(c, http.StatusInternalServerError, "failed to set status")
 		return
 	}
@@ -289,3 +473,20 @@ func (h *UserHandler) GetGroups(c *gin.Context) {
 	}
 	OK(c, groups)
 }
+
+// attributeQuery collects "attr.<name>=<value>" query parameters into an
+// extension attribute filter.
+func attributeQuery(c *gin.Context) map[string]string {
+	var attrs map[string]string
+	for key, vals := range c.Request.URL.Query() {
+		name, ok := strings.CutPrefix(key, "attr.")
+		if !ok || name == "" || len(vals) == 0 {
+			continue
+		}
+		if attrs == nil {
+			attrs = make(map[string]string)
+		}
+		attrs[name] = vals[0]
+	}
+	return attrs
+}
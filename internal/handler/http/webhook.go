@@ -0,0 +1,258 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// WebhookHandler handles webhook subscription endpoints.
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler.
+func NewWebhookHandler(webhookSvc *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookSvc}
+}
+
+// CreateWebhookReq is the request DTO for creating a webhook subscription.
+type CreateWebhookReq struct {
+	Name       string   `json:"name" binding:"required,max=64"`
+	URL        string   `json:"url" binding:"required"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types" binding:"required"`
+}
+
+// UpdateWebhookReq is the request DTO for updating a webhook subscription.
+type UpdateWebhookReq struct {
+	Name       *string  `json:"name,omitempty" binding:"omitempty,min=1,max=64"`
+	URL        *string  `json:"url,omitempty"`
+	Secret     *string  `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	Enabled    *bool    `json:"enabled,omitempty"`
+}
+
+// Create godoc
+// @Summary      Create webhook
+// @Description  Subscribe a URL to directory change events. Event types are exact types such as user.disabled, prefixes such as group.* or * for every event. Requests are signed with the secret, which is generated unless given and only returned here.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string            true  "Bearer token"
+// @Param        request        body      CreateWebhookReq  true  "Webhook info"
+// @Success      200            {object}  Response{data=domain.WebhookSubscription}
+// @Failure      400            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/webhooks [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var req CreateWebhookReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	sub, err := h.webhookService.CreateWebhook(c.Request.Context(), domain.CreateWebhookInput{
+		Name:       req.Name,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		writeWebhookError(c, err, "failed to create webhook")
+		return
+	}
+	OK(c, sub)
+}
+
+// List godoc
+// @Summary      List webhooks
+// @Description  List all webhook subscriptions, without their secrets
+// @Tags         Webhook
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  Response{data=[]domain.WebhookSubscription}
+// @Failure      500            {object}  Response
+// @Router       /api/v1/webhooks [get]
+func (h *WebhookHandler) List(c *gin.Context) {
+	subs, err := h.webhookService.ListWebhooks(c.Request.Context())
+	if err != nil {
+		Error(c, http.StatusInternalServerError, "failed to list webhooks")
+		return
+	}
+	OK(c, subs)
+}
+
+// Get godoc
+// @Summary      Get webhook
+// @Description  Retrieve a webhook subscription, without its secret
+// @Tags         Webhook
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Webhook ID (UUID)"
+// @Success      200            {object}  Response{data=domain.WebhookSubscription}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Router       /api/v1/webhooks/{id} [get]
+func (h *WebhookHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid webhook id")
+		return
+	}
+
+	sub, err := h.webhookService.GetWebhook(c.Request.Context(), id)
+	if err != nil {
+		writeWebhookError(c, err, "failed to get webhook")
+		return
+	}
+	OK(c, sub)
+}
+
+// Update godoc
+// @Summary      Update webhook
+// @Description  Update a webhook subscription. Disabling it holds its pending deliveries, and events are not delivered to it until it is enabled again.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string            true  "Bearer token"
+// @Param        id             path      string            true  "Webhook ID (UUID)"
+// @Param        request        body      UpdateWebhookReq  true  "Fields to update"
+// @Success      200            {object}  Response{data=domain.WebhookSubscription}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/webhooks/{id} [put]
+func (h *WebhookHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid webhook id")
+		return
+	}
+	var req UpdateWebhookReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	sub, err := h.webhookService.UpdateWebhook(c.Request.Context(), id, domain.UpdateWebhookInput{
+		Name:       req.Name,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		Enabled:    req.Enabled,
+	})
+	if err != nil {
+		writeWebhookError(c, err, "failed to update webhook")
+		return
+	}
+	OK(c, sub)
+}
+
+// Delete godoc
+// @Summary      Delete webhook
+// @Description  Delete a webhook subscription and its deliveries
+// @Tags         Webhook
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Webhook ID (UUID)"
+// @Success      200            {object}  Response
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid webhook id")
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), id); err != nil {
+		writeWebhookError(c, err, "failed to delete webhook")
+		return
+	}
+	OK(c, nil)
+}
+
+// Deliveries godoc
+// @Summary      List webhook deliveries
+// @Description  List the deliveries of a webhook subscription, newest first, with the outcome of their last attempt. Failed deliveries are retried with exponential backoff; dead deliveries ran out of attempts.
+// @Tags         Webhook
+// @Produce      json
+// @Param        Authorization  header    string  true   "Bearer token"
+// @Param        id             path      string  true   "Webhook ID (UUID)"
+// @Param        status         query     string  false  "pending, delivered or dead"
+// @Param        page           query     int     false  "Page number (default 1)"
+// @Param        page_size      query     int     false  "Page size (default 20, max 100)"
+// @Success      200            {object}  Response{data=domain.ListResult[domain.WebhookDelivery]}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) Deliveries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid webhook id")
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.webhookService.ListDeliveries(c.Request.Context(), id, domain.WebhookDeliveryStatus(c.Query("status")), page, pageSize)
+	if err != nil {
+		writeWebhookError(c, err, "failed to list webhook deliveries")
+		return
+	}
+	OK(c, result)
+}
+
+// RetryDelivery godoc
+// @Summary      Retry dead webhook delivery
+// @Description  Make a dead delivery pending again with a full set of attempts, e.g. once the receiver is fixed
+// @Tags         Webhook
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      string  true  "Webhook ID (UUID)"
+// @Param        did            path      string  true  "Delivery ID (UUID)"
+// @Success      200            {object}  Response{data=domain.WebhookDelivery}
+// @Failure      400            {object}  Response
+// @Failure      404            {object}  Response
+// @Failure      500            {object}  Response
+// @Router       /api/v1/webhooks/{id}/deliveries/{did}/retry [post]
+func (h *WebhookHandler) RetryDelivery(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid webhook id")
+		return
+	}
+	deliveryID, err := uuid.Parse(c.Param("did"))
+	if err != nil {
+		Error(c, http.StatusBadRequest, "invalid delivery id")
+		return
+	}
+
+	dl, err := h.webhookService.RetryDelivery(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		writeWebhookError(c, err, "failed to retry webhook delivery")
+		return
+	}
+	OK(c, dl)
+}
+
+func writeWebhookError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidWebhook):
+		Error(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrWebhookNotFound):
+		Error(c, http.StatusNotFound, err.Error())
+	default:
+		Error(c, http.StatusInternalServerError, msg+": "+err.Error())
+	}
+}
@@ -2,11 +2,16 @@ package ldap
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/jimlambrt/gldap"
 	"go.uber.org/zap"
 
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ldap/attrs"
 	"github.com/qinzj/claude-demo/internal/ldap/dn"
+	"github.com/qinzj/claude-demo/internal/service"
 )
 
 func (h *Handler) handleBind(w *gldap.ResponseWriter, r *gldap.Request) {
@@ -24,8 +29,19 @@ func (h *Handler) handleBind(w *gldap.ResponseWriter, r *gldap.Request) {
 	bindDN := msg.UserName
 	password := string(msg.Password)
 
+	if bindDN == clientAddrDN {
+		h.bindClientAddr(resp, r.ConnectionID(), password)
+		return
+	}
+
 	h.logger.Info("LDAP bind attempt", zap.String("dn", bindDN))
 
+	// Service accounts bind with an API key for read-only app access.
+	if dn.IsServiceAccountDN(bindDN, h.cfg.BaseDN, h.cfg.Mode) {
+		h.bindServiceAccount(h.requestContext(r), resp, bindDN, password)
+		return
+	}
+
 	// Extract username from DN
 	username, err := dn.ExtractUsername(bindDN, h.cfg.BaseDN, h.cfg.Mode)
 	if err != nil {
@@ -36,17 +52,146 @@ func (h *Handler) handleBind(w *gldap.ResponseWriter, r *gldap.Request) {
 		return
 	}
 
-	// Authenticate via service layer
-	ctx := context.Background()
-	_, err = h.userService.Authenticate(ctx, username, password)
+	// Authenticate via service layer. In OTP mode, users with MFA enabled
+	// append a TOTP code to their password.
+	ctx := h.requestContext(r)
+	policy := hasControl(msg.Controls, gldap.ControlTypeBeheraPasswordPolicy)
+	var u *domain.User
+	if h.cfg.OTP {
+		u, err = h.mfaService.AuthenticateWithOTP(ctx, username, password)
+	} else {
+		u, err = h.userService.Authenticate(ctx, username, password)
+	}
 	if err != nil {
 		h.logger.Warn("LDAP bind failed",
 			zap.String("username", username),
 			zap.Error(err),
 		)
+		h.setCredentialsError(resp, err)
+		if policy && errors.Is(err, service.ErrAccountLocked) {
+			setPasswordPolicyControl(resp, gldap.WithErrorCode(gldap.BeheraAccountLocked))
+		}
+		return
+	}
+
+	st, err := h.userService.PasswordStatus(ctx, u)
+	if err != nil {
+		h.logger.Error("failed to get password status", zap.String("username", username), zap.Error(err))
+		resp.SetResultCode(gldap.ResultOperationsError)
+		return
+	}
+
+	// Expired passwords must be changed over HTTP; there is no grace login.
+	if st.Expired {
+		h.logger.Warn("LDAP bind failed: password expired", zap.String("username", username))
+		h.setADError(resp, adPasswordExpired)
+		if policy {
+			setPasswordPolicyControl(resp, gldap.WithErrorCode(gldap.BeheraPasswordExpired))
+		}
 		return
 	}
 
 	h.logger.Info("LDAP bind success", zap.String("username", username))
 	resp.SetResultCode(gldap.ResultSuccess)
+	if !policy {
+		return
+	}
+	switch {
+	case st.MustChange:
+		setPasswordPolicyControl(resp, gldap.WithErrorCode(gldap.BeheraChangeAfterReset))
+	case st.ExpiresAt != nil && time.Until(*st.ExpiresAt) < passwordExpiryWarning:
+		setPasswordPolicyControl(resp, gldap.WithSecondsBeforeExpiration(uint(time.Until(*st.ExpiresAt).Seconds())))
+	}
+}
+
+// Active Directory sub-codes for failed binds, reported as "data <code>" in
+// the diagnostic message.
+const (
+	adInvalidCredentials = "52e"
+	adNotPermittedNow    = "530"
+	adPasswordExpired    = "532"
+	adAccountDisabled    = "533"
+	adAccountExpired     = "701"
+	adAccountLocked      = "775"
+)
+
+// resultResponse is implemented by the gldap responses to operations that
+// authenticate a user.
+type resultResponse interface {
+	SetResultCode(code int)
+	SetDiagnosticMessage(msg string)
+}
+
+// setCredentialsError reports a failed authentication as invalidCredentials.
+// In Active Directory mode the diagnostic message carries the sub-code that
+// Windows clients use to tell the user why, e.g. that the account is locked.
+func (h *Handler) setCredentialsError(resp resultResponse, err error) {
+	resp.SetResultCode(gldap.ResultInvalidCredentials)
+	switch {
+	case errors.Is(err, service.ErrAccountLocked):
+		h.setADError(resp, adAccountLocked)
+	case errors.Is(err, service.ErrTooManyAttempts):
+		h.setADError(resp, adNotPermittedNow)
+	case errors.Is(err, service.ErrUserDisabled):
+		h.setADError(resp, adAccountDisabled)
+	case errors.Is(err, service.ErrAccountExpired):
+		h.setADError(resp, adAccountExpired)
+	default:
+		h.setADError(resp, adInvalidCredentials)
+	}
+}
+
+// setADError sets the diagnostic message Active Directory returns for a
+// failed bind with the given sub-code. Other modes leave it empty.
+func (h *Handler) setADError(resp resultResponse, code string) {
+	if h.cfg.Mode != attrs.ModeActiveDirectory {
+		return
+	}
+	resp.SetDiagnosticMessage("80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data " + code + ", v4563")
+}
+
+// passwordExpiryWarning is how long before a password expires binds start
+// to report the time left in the password policy response control.
+const passwordExpiryWarning = 14 * 24 * time.Hour
+
+// setPasswordPolicyControl attaches a draft-behera password policy response
+// control to a bind response.
+func setPasswordPolicyControl(resp *gldap.BindResponse, opt ...gldap.Option) {
+	ctrl, err := gldap.NewControlBeheraPasswordPolicy(opt...)
+	if err != nil {
+		return
+	}
+	resp.SetControls(ctrl)
+}
+
+// hasControl reports whether the request carried a control of the given type.
+func hasControl(controls []gldap.Control, controlType string) bool {
+	for _, c := range controls {
+		if c.GetControlType() == controlType {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) bindServiceAccount(ctx context.Context, resp *gldap.BindResponse, bindDN, key string) {
+	name, err := dn.ExtractServiceAccountName(bindDN, h.cfg.BaseDN, h.cfg.Mode)
+	if err != nil {
+		h.logger.Warn("failed to extract service account from DN",
+			zap.String("dn", bindDN),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if _, err := h.serviceAccountService.AuthenticateBind(ctx, name, key); err != nil {
+		h.logger.Warn("LDAP service account bind failed",
+			zap.String("service_account", name),
+			zap.Error(err),
+		)
+		return
+	}
+
+	h.logger.Info("LDAP bind success", zap.String("service_account", name))
+	resp.SetResultCode(gldap.ResultSuccess)
 }
@@ -0,0 +1,61 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ldap/ldif"
+)
+
+// ExportLDIF writes all users and then all groups to w as an LDIF file,
+// with the DNs and attributes a search for them returns.
+func (h *Handler) ExportLDIF(ctx context.Context, w io.Writer) error {
+	users, err := h.userService.AllUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("querying users: %w", err)
+	}
+	userExt, err := h.extensionNamesFor(ctx, domain.AttributeTargetUser)
+	if err != nil {
+		return fmt.Errorf("querying attribute definitions: %w", err)
+	}
+	groups, err := h.groupService.AllGroups(ctx)
+	if err != nil {
+		return fmt.Errorf("querying groups: %w", err)
+	}
+	groupExt, err := h.extensionNamesFor(ctx, domain.AttributeTargetGroup)
+	if err != nil {
+		return fmt.Errorf("querying attribute definitions: %w", err)
+	}
+
+	lw := ldif.NewWriter(w)
+	org := newOrgIndex(users)
+	for _, u := range users {
+		if err := lw.Write(h.userToEntry(u, userExt, org).record()); err != nil {
+			return err
+		}
+	}
+	for _, g := range groups {
+		if err := lw.Write(h.groupToEntry(g, groupExt).record()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// record converts an entry to an LDIF content record, with objectClass
+// first and the other attributes sorted by name.
+func (e *ldapEntry) record() *ldif.Record {
+	r := &ldif.Record{DN: e.dn}
+	r.Attributes = append(r.Attributes, ldif.Attribute{Name: "objectClass", Values: e.attrs["objectClass"]})
+	for _, name := range slices.Sorted(maps.Keys(e.attrs)) {
+		if name == "objectClass" || name == "dn" {
+			continue
+		}
+		r.Attributes = append(r.Attributes, ldif.Attribute{Name: name, Values: e.attrs[name]})
+	}
+	return r
+}
@@ -2,6 +2,10 @@ package ldap
 
 import (
 	"context"
+	"crypto/rand"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jimlambrt/gldap"
@@ -11,12 +15,16 @@ import (
 	"github.com/qinzj/claude-demo/internal/domain"
 	"github.com/qinzj/claude-demo/internal/ldap/attrs"
 	"github.com/qinzj/claude-demo/internal/ldap/dn"
+	"github.com/qinzj/claude-demo/internal/service"
 )
 
 // UserService defines the user operations needed by LDAP handler.
 type UserService interface {
 	Authenticate(ctx context.Context, username, password string) (*domain.User, error)
 	AllUsers(ctx context.Context) ([]*domain.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*domain.User, error)
+	ChangePassword(ctx context.Context, id uuid.UUID, oldPassword, newPassword string) error
+	PasswordStatus(ctx context.Context, u *domain.User) (*domain.PasswordStatus, error)
 }
 
 // GroupService defines the group operations needed by LDAP handler.
@@ -24,28 +32,69 @@ type GroupService interface {
 	AllGroups(ctx context.Context) ([]*domain.Group, error)
 }
 
+// AttributeService defines the extension attribute operations needed by LDAP handler.
+type AttributeService interface {
+	ListDefinitions(ctx context.Context, target domain.AttributeTarget) ([]*domain.AttributeDefinition, error)
+}
+
+// ServiceAccountService defines the service account operations needed by LDAP handler.
+type ServiceAccountService interface {
+	AuthenticateBind(ctx context.Context, name, key string) (*domain.ServiceAccount, error)
+}
+
+// MFAService defines the multi-factor operations needed by LDAP handler.
+type MFAService interface {
+	AuthenticateWithOTP(ctx context.Context, username, credential string) (*domain.User, error)
+}
+
 // Handler handles LDAP protocol operations.
 type Handler struct {
-	userService  UserService
-	groupService GroupService
-	cfg          *config.LDAPConfig
-	logger       *zap.Logger
+	userService           UserService
+	groupService          GroupService
+	attributeService      AttributeService
+	serviceAccountService ServiceAccountService
+	mfaService            MFAService
+	cfg                   *config.LDAPConfig
+	logger                *zap.Logger
+
+	// clientToken authenticates the binds with which Serve introduces
+	// clients, whose IP addresses clients holds by connection ID.
+	clientToken string
+	clientsMu   sync.Mutex
+	clients     map[int]string
 }
 
 // New creates a new LDAP Handler.
-func New(userSvc UserService, groupSvc GroupService, cfg *config.LDAPConfig, logger *zap.Logger) *Handler {
+func New(userSvc UserService, groupSvc GroupService, attrSvc AttributeService, saSvc ServiceAccountService, mfaSvc MFAService, cfg *config.LDAPConfig, logger *zap.Logger) *Handler {
 	return &Handler{
-		userService:  userSvc,
-		groupService: groupSvc,
-		cfg:          cfg,
-		logger:       logger,
+		userService:           userSvc,
+		groupService:          groupSvc,
+		attributeService:      attrSvc,
+		serviceAccountService: saSvc,
+		mfaService:            mfaSvc,
+		cfg:                   cfg,
+		logger:                logger,
+		clientToken:           rand.Text(),
+		clients:               make(map[int]string),
 	}
 }
 
-// RegisterRoutes registers LDAP Bind and Search handlers on the mux.
+// RegisterRoutes registers LDAP Bind, Search and Modify handlers on the mux.
 func (h *Handler) RegisterRoutes(mux *gldap.Mux) {
 	mux.Bind(h.handleBind)
 	mux.Search(h.handleSearch)
+	mux.Modify(h.handleModify)
+}
+
+// requestContext returns the context for the service calls of a request,
+// with a new request ID for the audit trail and the client's IP address if
+// its connection was forwarded by Serve.
+func (h *Handler) requestContext(r *gldap.Request) context.Context {
+	return service.WithRequestInfo(context.Background(), domain.RequestInfo{
+		Protocol:  domain.ProtocolLDAP,
+		IPAddress: h.clientIP(r.ConnectionID()),
+		RequestID: uuid.New().String(),
+	})
 }
 
 func (h *Handler) buildUserDN(u *domain.User) string {
@@ -56,13 +105,70 @@ func (h *Handler) buildGroupDN(g *domain.Group) string {
 	return dn.BuildGroupDN(g.Name, h.cfg.BaseDN, h.cfg.Mode)
 }
 
-func (h *Handler) userToEntry(u *domain.User) *ldapEntry {
+func (h *Handler) userToEntry(u *domain.User, ext extensionNames, org *orgIndex) *ldapEntry {
 	userDN := h.buildUserDN(u)
 	mapper := attrs.NewMapper(h.cfg.Mode)
 	attrsMap := mapper.UserToLDAPAttrs(u.Username, u.DisplayName, u.Email, u.Phone, string(u.Status))
+	ext.apply(attrsMap, u.Attributes)
+
+	if u.ManagerID != nil {
+		if m, ok := org.byID[*u.ManagerID]; ok {
+			attrsMap["manager"] = []string{h.buildUserDN(m)}
+		}
+	}
+	// directReports is a constructed back-link attribute in Active Directory only.
+	if h.cfg.Mode == attrs.ModeActiveDirectory {
+		var reportDNs []string
+		for _, r := range org.reports[u.ID] {
+			reportDNs = append(reportDNs, h.buildUserDN(r))
+		}
+		if len(reportDNs) > 0 {
+			attrsMap["directReports"] = reportDNs
+		}
+	}
+
+	// Lockout state: Active Directory always publishes lockoutTime, 0 when
+	// the account is not locked; OpenLDAP only while it is locked.
+	locked := u.Locked(time.Now()) && u.LockedAt != nil
+	if h.cfg.Mode == attrs.ModeActiveDirectory {
+		lockoutTime := "0"
+		if locked {
+			lockoutTime = attrs.FileTime(*u.LockedAt)
+		}
+		attrsMap["lockoutTime"] = []string{lockoutTime}
+		attrsMap["badPwdCount"] = []string{strconv.Itoa(u.FailedLoginCount)}
+	} else if locked {
+		attrsMap["pwdAccountLockedTime"] = []string{attrs.GeneralizedTime(*u.LockedAt)}
+	}
+
+	// Password age and account expiry. A pwdLastSet or shadowLastChange of
+	// 0 means the user must change their password at next logon.
+	if h.cfg.Mode == attrs.ModeActiveDirectory {
+		pwdLastSet := attrs.FileTime(u.PasswordChangedAt)
+		if u.MustChangePassword {
+			pwdLastSet = "0"
+		}
+		accountExpires := attrs.FileTimeNever
+		if u.AccountExpiresAt != nil {
+			accountExpires = attrs.FileTime(*u.AccountExpiresAt)
+		}
+		attrsMap["pwdLastSet"] = []string{pwdLastSet}
+		attrsMap["accountExpires"] = []string{accountExpires}
+	} else {
+		attrsMap["pwdChangedTime"] = []string{attrs.GeneralizedTime(u.PasswordChangedAt)}
+		shadowLastChange := attrs.EpochDays(u.PasswordChangedAt)
+		if u.MustChangePassword {
+			shadowLastChange = "0"
+		}
+		attrsMap["shadowLastChange"] = []string{shadowLastChange}
+		if u.AccountExpiresAt != nil {
+			attrsMap["shadowExpire"] = []string{attrs.EpochDays(*u.AccountExpiresAt)}
+		}
+	}
 
 	// Add objectClass
 	attrsMap["objectClass"] = mapper.UserObjectClasses()
+	h.setEntryID(attrsMap, u.ID)
 
 	// Add dn as attribute
 	attrsMap["dn"] = []string{userDN}
@@ -73,7 +179,7 @@ func (h *Handler) userToEntry(u *domain.User) *ldapEntry {
 	}
 }
 
-func (h *Handler) groupToEntry(g *domain.Group) *ldapEntry {
+func (h *Handler) groupToEntry(g *domain.Group, ext extensionNames) *ldapEntry {
 	groupDN := h.buildGroupDN(g)
 	mapper := attrs.NewMapper(h.cfg.Mode)
 
@@ -85,7 +191,20 @@ func (h *Handler) groupToEntry(g *domain.Group) *ldapEntry {
 	}
 
 	attrsMap := mapper.GroupToLDAPAttrs(g.Name, g.Description, memberDNs)
+	ext.apply(attrsMap, g.Attributes)
+
+	var ownerDNs []string
+	for _, u := range g.OwnerUsers {
+		ownerDNs = append(ownerDNs, h.buildUserDN(u))
+	}
+	for _, o := range g.OwnerGroups {
+		ownerDNs = append(ownerDNs, h.buildGroupDN(o))
+	}
+	if len(ownerDNs) > 0 {
+		attrsMap[mapper.GroupOwnerAttribute()] = ownerDNs
+	}
 	attrsMap["objectClass"] = mapper.GroupObjectClasses()
+	h.setEntryID(attrsMap, g.ID)
 	attrsMap["dn"] = []string{groupDN}
 
 	return &ldapEntry{
@@ -94,6 +213,64 @@ func (h *Handler) groupToEntry(g *domain.Group) *ldapEntry {
 	}
 }
 
+// setEntryID publishes the immutable identifier of an entry, by which
+// directories synchronizing from this one track it across renames: the
+// objectGUID in Active Directory, the entryUUID otherwise.
+func (h *Handler) setEntryID(attrsMap map[string][]string, id uuid.UUID) {
+	if h.cfg.Mode == attrs.ModeActiveDirectory {
+		attrsMap["objectGUID"] = []string{attrs.ObjectGUID(id)}
+	} else {
+		attrsMap["entryUUID"] = []string{id.String()}
+	}
+}
+
+// orgIndex resolves manager references between users of a single search.
+type orgIndex struct {
+	byID    map[uuid.UUID]*domain.User
+	reports map[uuid.UUID][]*domain.User
+}
+
+func newOrgIndex(users []*domain.User) *orgIndex {
+	idx := &orgIndex{
+		byID:    make(map[uuid.UUID]*domain.User, len(users)),
+		reports: make(map[uuid.UUID][]*domain.User),
+	}
+	for _, u := range users {
+		idx.byID[u.ID] = u
+		if u.ManagerID != nil {
+			idx.reports[*u.ManagerID] = append(idx.reports[*u.ManagerID], u)
+		}
+	}
+	return idx
+}
+
+// extensionNames maps extension attribute names to their LDAP attribute names.
+type extensionNames map[string]string
+
+// extensionNamesFor loads the LDAP names of the declared extension attributes for a target.
+func (h *Handler) extensionNamesFor(ctx context.Context, target domain.AttributeTarget) (extensionNames, error) {
+	defs, err := h.attributeService.ListDefinitions(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	names := make(extensionNames, len(defs))
+	for _, def := range defs {
+		names[def.Name] = def.LDAPName
+	}
+	return names, nil
+}
+
+// apply copies extension attribute values into an entry under their LDAP names.
+func (n extensionNames) apply(attrsMap map[string][]string, values map[string][]string) {
+	for name, vals := range values {
+		ldapName, ok := n[name]
+		if !ok || len(vals) == 0 {
+			continue
+		}
+		attrsMap[ldapName] = vals
+	}
+}
+
 type ldapEntry struct {
 	dn    string
 	attrs map[string][]string
@@ -0,0 +1,231 @@
+package ldap
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/google/uuid"
+	"github.com/jimlambrt/gldap"
+
+	"github.com/qinzj/claude-demo/internal/config"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ldap/filter"
+)
+
+func TestUserToEntryManager(t *testing.T) {
+	boss := &domain.User{ID: uuid.New(), Username: "boss", DisplayName: "Boss"}
+	report := &domain.User{ID: uuid.New(), Username: "dev", DisplayName: "Dev", ManagerID: &boss.ID}
+	org := newOrgIndex([]*domain.User{boss, report})
+
+	tests := []struct {
+		mode              string
+		wantManager       string
+		wantDirectReports []string
+	}{
+		{"openldap", "uid=boss,ou=users,dc=example,dc=com", nil},
+		{"activedirectory", "cn=Boss,cn=Users,dc=example,dc=com", []string{"cn=Dev,cn=Users,dc=example,dc=com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			h := &Handler{cfg: &config.LDAPConfig{BaseDN: "dc=example,dc=com", Mode: tt.mode}}
+
+			entry := h.userToEntry(report, nil, org)
+			if got := entry.attrs["manager"]; len(got) != 1 || got[0] != tt.wantManager {
+				t.Errorf("manager = %v, want [%s]", got, tt.wantManager)
+			}
+
+			entry = h.userToEntry(boss, nil, org)
+			if _, ok := entry.attrs["manager"]; ok {
+				t.Error("unexpected manager on top-level user")
+			}
+			got := entry.attrs["directReports"]
+			if len(got) != len(tt.wantDirectReports) {
+				t.Fatalf("directReports = %v, want %v", got, tt.wantDirectReports)
+			}
+			for i := range got {
+				if got[i] != tt.wantDirectReports[i] {
+					t.Errorf("directReports[%d] = %q, want %q", i, got[i], tt.wantDirectReports[i])
+				}
+			}
+		})
+	}
+}
+
+func encodeUnicodePwd(p string) string {
+	u := utf16.Encode([]rune(`"` + p + `"`))
+	b := make([]byte, 2*len(u))
+	for i, c := range u {
+		binary.LittleEndian.PutUint16(b[2*i:], c)
+	}
+	return string(b)
+}
+
+func TestPasswordChange(t *testing.T) {
+	change := func(op int64, attr string, vals ...string) gldap.Change {
+		// Encode the values the way gldap passes them on: as the raw
+		// contents of the value set.
+		var raw []byte
+		for _, v := range vals {
+			raw = append(raw, ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "").Bytes()...)
+		}
+		return gldap.Change{Operation: op, Modification: gldap.PartialAttribute{Type: attr, Vals: []string{string(raw)}}}
+	}
+
+	tests := []struct {
+		name    string
+		changes []gldap.Change
+		wantOld string
+		wantNew string
+		wantOK  bool
+		wantErr bool
+	}{
+		{
+			name:    "userPassword delete and add",
+			changes: []gldap.Change{change(gldap.DeleteAttribute, "userPassword", "old"), change(gldap.AddAttribute, "userPassword", "new")},
+			wantOld: "old", wantNew: "new", wantOK: true,
+		},
+		{
+			name:    "unicodePwd delete and add",
+			changes: []gldap.Change{change(gldap.DeleteAttribute, "unicodePwd", encodeUnicodePwd("old")), change(gldap.AddAttribute, "unicodePwd", encodeUnicodePwd("nëw"))},
+			wantOld: "old", wantNew: "nëw", wantOK: true,
+		},
+		{
+			name:    "replace without old password",
+			changes: []gldap.Change{change(gldap.ReplaceAttribute, "userPassword", "new")},
+		},
+		{
+			name:    "other attribute",
+			changes: []gldap.Change{change(gldap.ReplaceAttribute, "mail", "x@example.com")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldPw, newPw, ok, err := passwordChange(tt.changes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK || oldPw != tt.wantOld || newPw != tt.wantNew {
+				t.Errorf("passwordChange = %q, %q, %v; want %q, %q, %v", oldPw, newPw, ok, tt.wantOld, tt.wantNew, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEntryID(t *testing.T) {
+	id := uuid.MustParse("01020304-0506-0708-090a-0b0c0d0e0f10")
+	u := &domain.User{ID: id, Username: "dev", DisplayName: "Dev"}
+	g := &domain.Group{ID: id, Name: "devs"}
+	org := newOrgIndex([]*domain.User{u})
+
+	h := &Handler{cfg: &config.LDAPConfig{BaseDN: "dc=example,dc=com", Mode: "openldap"}}
+	for _, e := range []*ldapEntry{h.userToEntry(u, nil, org), h.groupToEntry(g, nil)} {
+		if got := e.attrs["entryUUID"]; len(got) != 1 || got[0] != id.String() {
+			t.Errorf("openldap %s: entryUUID = %q, want %q", e.dn, got, id)
+		}
+	}
+
+	// The first three fields of an objectGUID are little-endian.
+	h.cfg.Mode = "activedirectory"
+	want := "\x04\x03\x02\x01\x06\x05\x08\x07\x09\x0a\x0b\x0c\x0d\x0e\x0f\x10"
+	for _, e := range []*ldapEntry{h.userToEntry(u, nil, org), h.groupToEntry(g, nil)} {
+		if got := e.attrs["objectGUID"]; len(got) != 1 || got[0] != want {
+			t.Errorf("activedirectory %s: objectGUID = %x, want %x", e.dn, got, want)
+		}
+	}
+}
+
+func TestUserToEntryPasswordAttributes(t *testing.T) {
+	changed := time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC)
+	expires := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	u := &domain.User{ID: uuid.New(), Username: "dev", DisplayName: "Dev", PasswordChangedAt: changed}
+	org := newOrgIndex([]*domain.User{u})
+
+	tests := []struct {
+		mode       string
+		mustChange bool
+		expires    *time.Time
+		want       map[string]string
+	}{
+		{"openldap", false, nil, map[string]string{
+			"pwdChangedTime": "20240301003000Z", "shadowLastChange": "19783", "shadowExpire": "",
+		}},
+		{"openldap", true, &expires, map[string]string{
+			"pwdChangedTime": "20240301003000Z", "shadowLastChange": "0", "shadowExpire": "20089",
+		}},
+		{"activedirectory", false, nil, map[string]string{
+			"pwdLastSet": "133537266000000000", "accountExpires": "9223372036854775807",
+		}},
+		{"activedirectory", true, &expires, map[string]string{
+			"pwdLastSet": "0", "accountExpires": "133801632000000000",
+		}},
+	}
+
+	for _, tt := range tests {
+		h := &Handler{cfg: &config.LDAPConfig{BaseDN: "dc=example,dc=com", Mode: tt.mode}}
+		u.MustChangePassword, u.AccountExpiresAt = tt.mustChange, tt.expires
+		entry := h.userToEntry(u, nil, org)
+		for attr, want := range tt.want {
+			var got string
+			if vals := entry.attrs[attr]; len(vals) > 0 {
+				got = vals[0]
+			}
+			if got != want {
+				t.Errorf("%s (must change %v, expires %v): %s = %q, want %q", tt.mode, tt.mustChange, tt.expires, attr, got, want)
+			}
+		}
+	}
+}
+
+func TestMatchEntryOrdering(t *testing.T) {
+	entry := &ldapEntry{attrs: map[string][]string{
+		"accountExpires": {"9223372036854775807"},
+		"pwdLastSet":     {"133537266000000000"},
+		"pwdChangedTime": {"20240301003000Z"},
+	}}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"(accountExpires>=133801632000000000)", true},
+		{"(accountExpires<=133801632000000000)", false},
+		{"(pwdLastSet<=133801632000000000)", true},
+		{"(pwdLastSet>=9)", true},
+		{"(pwdChangedTime<=20250101000000Z)", true},
+		{"(pwdChangedTime>=20250101000000Z)", false},
+	}
+	for _, tt := range tests {
+		f, err := filter.Parse(tt.filter)
+		if err != nil {
+			t.Fatalf("Parse(%s): %v", tt.filter, err)
+		}
+		if got := matchEntry(f, entry); got != tt.want {
+			t.Errorf("%s: match = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestEntryRecord(t *testing.T) {
+	h := &Handler{cfg: &config.LDAPConfig{BaseDN: "dc=example,dc=com", Mode: "openldap"}}
+	u := &domain.User{ID: uuid.New(), Username: "jdoe", DisplayName: "John Doe", Email: "jdoe@example.com", Status: domain.UserStatusEnabled}
+	r := h.userToEntry(u, nil, newOrgIndex([]*domain.User{u})).record()
+
+	if r.DN != "uid=jdoe,ou=users,dc=example,dc=com" {
+		t.Errorf("DN = %q", r.DN)
+	}
+	var names []string
+	for _, a := range r.Attributes {
+		names = append(names, a.Name)
+	}
+	want := []string{"objectClass", "cn", "displayName", "entryUUID", "mail", "pwdChangedTime", "shadowLastChange", "sn", "status", "uid"}
+	if !slices.Equal(names, want) {
+		t.Errorf("attributes = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,155 @@
+package ldap
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/jimlambrt/gldap"
+	"go.uber.org/zap"
+)
+
+// clientAddrDN is the bind DN with which Serve introduces the client of a
+// forwarded connection to the handler. The password is the handler's
+// client token followed by a space and the client's IP address.
+const clientAddrDN = "cn=client-address"
+
+// Serve accepts LDAP clients on ln and forwards their connections to
+// server, which it runs on a loopback address, until server is stopped or
+// ln is closed. gldap does not tell handlers the address of the client
+// behind a connection, so each forwarded connection starts with a bind as
+// clientAddrDN that records it by connection ID, for the client IP
+// blocking of logins and the audit trail. The bind response is not
+// forwarded. server must route to h and be created with
+// gldap.WithOnClose(h.ConnectionClosed).
+func (h *Handler) Serve(server *gldap.Server, ln net.Listener) error {
+	addr, err := loopbackAddr()
+	if err != nil {
+		return err
+	}
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- server.Run(addr)
+		_ = ln.Close()
+	}()
+	for !server.Ready() {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for {
+		c, err := ln.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			select {
+			case err := <-runErr:
+				return err
+			default:
+				return nil
+			}
+		}
+		if err != nil {
+			return err
+		}
+		go h.forward(c, addr)
+	}
+}
+
+// loopbackAddr returns a free address on the loopback interface.
+func loopbackAddr() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("finding a loopback address for the LDAP server: %w", err)
+	}
+	defer ln.Close()
+	return ln.Addr().String(), nil
+}
+
+// forward copies the traffic between a client and the LDAP server at
+// addr, once it has introduced the client to the server.
+func (h *Handler) forward(client net.Conn, addr string) {
+	defer client.Close()
+	server, err := net.Dial("tcp", addr)
+	if err != nil {
+		h.logger.Error("failed to connect to the LDAP server", zap.Error(err))
+		return
+	}
+	defer server.Close()
+	ip, _, _ := net.SplitHostPort(client.RemoteAddr().String())
+	if err := h.introduce(server, ip); err != nil {
+		h.logger.Error("failed to pass the client address to the LDAP server", zap.String("ip", ip), zap.Error(err))
+		return
+	}
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(server, client)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(client, server)
+		done <- struct{}{}
+	}()
+	// Closing both connections ends the other copy.
+	<-done
+}
+
+// introduce binds a new connection to the LDAP server as clientAddrDN, and
+// reads the response.
+func (h *Handler) introduce(server net.Conn, ip string) error {
+	req := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Request")
+	req.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, 1, "Message ID"))
+	bind := ber.Encode(ber.ClassApplication, ber.TypeConstructed, gldap.ApplicationBindRequest, nil, "Bind Request")
+	bind.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, 3, "Version"))
+	bind.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, clientAddrDN, "User Name"))
+	bind.AppendChild(ber.NewString(ber.ClassContext, ber.TypePrimitive, 0, h.clientToken+" "+ip, "Password"))
+	req.AppendChild(bind)
+	if _, err := server.Write(req.Bytes()); err != nil {
+		return err
+	}
+
+	resp, err := ber.ReadPacket(server)
+	if err != nil {
+		return err
+	}
+	if len(resp.Children) < 2 || len(resp.Children[1].Children) < 1 {
+		return errors.New("malformed bind response")
+	}
+	if code, _ := resp.Children[1].Children[0].Value.(int64); code != gldap.ResultSuccess {
+		return fmt.Errorf("bind result %d", code)
+	}
+	return nil
+}
+
+// bindClientAddr handles the bind of Serve that introduces a client,
+// recording their address for the connection.
+func (h *Handler) bindClientAddr(resp *gldap.BindResponse, connID int, password string) {
+	token, ip, ok := strings.Cut(password, " ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.clientToken)) != 1 {
+		h.logger.Warn("LDAP bind as the client address with a wrong token", zap.Int("conn", connID))
+		return
+	}
+	h.clientsMu.Lock()
+	h.clients[connID] = ip
+	h.clientsMu.Unlock()
+	resp.SetResultCode(gldap.ResultSuccess)
+}
+
+// clientIP returns the IP address of the client behind a connection, or
+// "" if it was not forwarded by Serve.
+func (h *Handler) clientIP(connID int) string {
+	h.clientsMu.Lock()
+	defer h.clientsMu.Unlock()
+	return h.clients[connID]
+}
+
+// ConnectionClosed forgets the address of the client behind a connection
+// once it is closed.
+func (h *Handler) ConnectionClosed(connID int) {
+	h.clientsMu.Lock()
+	delete(h.clients, connID)
+	h.clientsMu.Unlock()
+}
@@ -0,0 +1,147 @@
+package ldap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"unicode/utf16"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/jimlambrt/gldap"
+	"go.uber.org/zap"
+
+	"github.com/qinzj/claude-demo/internal/ldap/dn"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// handleModify lets users change their own password. The directory is
+// otherwise read-only over LDAP. Since binds are not tracked per
+// connection, the request must prove knowledge of the old password the way
+// Active Directory requires for unicodePwd: by deleting the old value and
+// adding the new one in the same request. userPassword is accepted in the
+// same form.
+func (h *Handler) handleModify(w *gldap.ResponseWriter, r *gldap.Request) {
+	resp := r.NewModifyResponse(gldap.WithResponseCode(gldap.ResultUnwillingToPerform))
+	defer func() {
+		_ = w.Write(resp)
+	}()
+
+	msg, err := r.GetModifyMessage()
+	if err != nil {
+		h.logger.Error("failed to get modify message", zap.Error(err))
+		resp.SetResultCode(gldap.ResultProtocolError)
+		return
+	}
+
+	username, err := dn.ExtractUsername(msg.DN, h.cfg.BaseDN, h.cfg.Mode)
+	if err != nil {
+		resp.SetDiagnosticMessage("only user passwords can be modified")
+		return
+	}
+
+	oldPassword, newPassword, ok, err := passwordChange(msg.Changes)
+	if err != nil {
+		resp.SetDiagnosticMessage(err.Error())
+		return
+	}
+	if !ok {
+		resp.SetResultCode(gldap.ResultInsufficientAccessRights)
+		resp.SetDiagnosticMessage("the old password is required: delete the old value and add the new one")
+		return
+	}
+
+	ctx := h.requestContext(r)
+	u, err := h.userService.GetUserByUsername(ctx, username)
+	if err != nil {
+		resp.SetResultCode(gldap.ResultNoSuchObject)
+		return
+	}
+
+	err = h.userService.ChangePassword(ctx, u.ID, oldPassword, newPassword)
+	switch {
+	case err == nil:
+		h.logger.Info("LDAP password changed", zap.String("username", username))
+		resp.SetResultCode(gldap.ResultSuccess)
+	case errors.Is(err, service.ErrInvalidOldPassword), errors.Is(err, service.ErrAccountLocked):
+		h.logger.Warn("LDAP password change failed", zap.String("username", username), zap.Error(err))
+		h.setCredentialsError(resp, err)
+	case errors.Is(err, service.ErrPasswordManagedUpstream):
+		resp.SetResultCode(gldap.ResultUnwillingToPerform)
+		resp.SetDiagnosticMessage(err.Error())
+	case errors.Is(err, service.ErrPasswordPolicy):
+		// Unlike bind responses, gldap's modify responses cannot carry
+		// controls, so clients that asked for the password policy control
+		// get no error code in one; the diagnostic message names the
+		// violated rules instead.
+		resp.SetResultCode(gldap.ResultConstraintViolation)
+		resp.SetDiagnosticMessage(err.Error())
+	default:
+		h.logger.Error("LDAP password change failed", zap.String("username", username), zap.Error(err))
+		resp.SetResultCode(gldap.ResultOperationsError)
+	}
+}
+
+// passwordChange extracts the old and new password from a modify request
+// that deletes one password value and adds another. ok is false for
+// password changes without the old value, such as a replace; err is set if
+// the request modifies anything other than the password.
+func passwordChange(changes []gldap.Change) (oldPassword, newPassword string, ok bool, err error) {
+	var hasOld, hasNew bool
+	for _, c := range changes {
+		attr := c.Modification.Type
+		if !strings.EqualFold(attr, "userPassword") && !strings.EqualFold(attr, "unicodePwd") {
+			return "", "", false, errors.New("only password changes are supported")
+		}
+		vals := modificationValues(c)
+		if len(vals) != 1 {
+			return "", "", false, nil
+		}
+		val := vals[0]
+		if strings.EqualFold(attr, "unicodePwd") {
+			val = decodeUnicodePwd(val)
+		}
+		switch c.Operation {
+		case gldap.DeleteAttribute:
+			oldPassword, hasOld = val, true
+		case gldap.AddAttribute:
+			newPassword, hasNew = val, true
+		default:
+			return "", "", false, nil
+		}
+	}
+	return oldPassword, newPassword, hasOld && hasNew, nil
+}
+
+// modificationValues returns the values of a change. gldap hands over the
+// undecoded contents of the value set, so the octet strings are read here;
+// values that are not BER-encoded are returned unchanged.
+func modificationValues(c gldap.Change) []string {
+	var vals []string
+	for _, raw := range c.Modification.Vals {
+		r := bytes.NewReader([]byte(raw))
+		for r.Len() > 0 {
+			p, err := ber.ReadPacket(r)
+			if err != nil || p.ClassType != ber.ClassUniversal || p.Tag != ber.TagOctetString {
+				return c.Modification.Vals
+			}
+			vals = append(vals, p.Data.String())
+		}
+	}
+	return vals
+}
+
+// decodeUnicodePwd decodes an Active Directory unicodePwd value: the
+// password enclosed in double quotes, encoded as UTF-16LE.
+func decodeUnicodePwd(v string) string {
+	b := []byte(v)
+	if len(b)%2 != 0 {
+		return v
+	}
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	s := string(utf16.Decode(u))
+	return strings.TrimSuffix(strings.TrimPrefix(s, `"`), `"`)
+}
@@ -1,12 +1,14 @@
 package ldap
 
 import (
-	"context"
+	"cmp"
+	"strconv"
 	"strings"
 
 	"github.com/jimlambrt/gldap"
 	"go.uber.org/zap"
 
+	"github.com/qinzj/claude-demo/internal/domain"
 	"github.com/qinzj/claude-demo/internal/ldap/filter"
 )
 
@@ -30,7 +32,7 @@ func (h *Handler) handleSearch(w *gldap.ResponseWriter, r *gldap.Request) {
 		zap.Int64("sizeLimit", int64(msg.SizeLimit)),
 	)
 
-	ctx := context.Background()
+	ctx := h.requestContext(r)
 
 	// Parse filter to determine what to search for
 	var f *filter.Filter
@@ -66,8 +68,15 @@ func (h *Handler) handleSearch(w *gldap.ResponseWriter, r *gldap.Request) {
 			resp.SetResultCode(gldap.ResultOther)
 			return
 		}
+		ext, err := h.extensionNamesFor(ctx, domain.AttributeTargetUser)
+		if err != nil {
+			h.logger.Error("failed to query attribute definitions", zap.Error(err))
+			resp.SetResultCode(gldap.ResultOther)
+			return
+		}
+		org := newOrgIndex(users)
 		for _, u := range users {
-			entry := h.userToEntry(u)
+			entry := h.userToEntry(u, ext, org)
 			if f == nil || matchEntry(f, entry) {
 				entries = append(entries, entry)
 				count++
@@ -85,8 +94,14 @@ func (h *Handler) handleSearch(w *gldap.ResponseWriter, r *gldap.Request) {
 			resp.SetResultCode(gldap.ResultOther)
 			return
 		}
+		ext, err := h.extensionNamesFor(ctx, domain.AttributeTargetGroup)
+		if err != nil {
+			h.logger.Error("failed to query attribute definitions", zap.Error(err))
+			resp.SetResultCode(gldap.ResultOther)
+			return
+		}
 		for _, g := range groups {
-			entry := h.groupToEntry(g)
+			entry := h.groupToEntry(g, ext)
 			if f == nil || matchEntry(f, entry) {
 				entries = append(entries, entry)
 				count++
@@ -121,7 +136,7 @@ func matchEntry(f *filter.Filter, entry *ldapEntry) bool {
 	case filter.FilterGreaterOrEqual:
 		vals := entry.attrs[f.Attr]
 		for _, v := range vals {
-			if v >= f.Value {
+			if compareValues(v, f.Value) >= 0 {
 				return true
 			}
 		}
@@ -129,7 +144,7 @@ func matchEntry(f *filter.Filter, entry *ldapEntry) bool {
 	case filter.FilterLessOrEqual:
 		vals := entry.attrs[f.Attr]
 		for _, v := range vals {
-			if v <= f.Value {
+			if compareValues(v, f.Value) <= 0 {
 				return true
 			}
 		}
@@ -160,6 +175,18 @@ func matchEntry(f *filter.Filter, entry *ldapEntry) bool {
 	}
 }
 
+// compareValues orders two attribute values for >= and <= filters. Integers,
+// such as FILETIME timestamps and day counts, compare numerically; other
+// values, including GeneralizedTime timestamps, compare as strings.
+func compareValues(a, b string) int {
+	x, errA := strconv.ParseInt(a, 10, 64)
+	y, errB := strconv.ParseInt(b, 10, 64)
+	if errA == nil && errB == nil {
+		return cmp.Compare(x, y)
+	}
+	return strings.Compare(a, b)
+}
+
 func matchEqual(attr, value string, entry *ldapEntry) bool {
 	vals, ok := entry.attrs[attr]
 	if !ok {
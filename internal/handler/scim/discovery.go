@@ -0,0 +1,257 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// schemaAttribute describes an attribute in a schema definition
+// (RFC 7643, section 7).
+type schemaAttribute struct {
+	Name           string            `json:"name"`
+	Type           string            `json:"type"`
+	MultiValued    bool              `json:"multiValued"`
+	Required       bool              `json:"required"`
+	CaseExact      bool              `json:"caseExact"`
+	Mutability     string            `json:"mutability"`
+	Returned       string            `json:"returned"`
+	Uniqueness     string            `json:"uniqueness"`
+	ReferenceTypes []string          `json:"referenceTypes,omitempty"`
+	SubAttributes  []schemaAttribute `json:"subAttributes,omitempty"`
+}
+
+func attribute(name, typ string) schemaAttribute {
+	return schemaAttribute{Name: name, Type: typ, Mutability: "readWrite", Returned: "default", Uniqueness: "none"}
+}
+
+func complexAttribute(name string, subs ...schemaAttribute) schemaAttribute {
+	a := attribute(name, "complex")
+	a.SubAttributes = subs
+	return a
+}
+
+func reference(name string, types ...string) schemaAttribute {
+	a := attribute(name, "reference")
+	a.ReferenceTypes = types
+	return a
+}
+
+func (a schemaAttribute) multiValued() schemaAttribute {
+	a.MultiValued = true
+	return a
+}
+
+func (a schemaAttribute) required() schemaAttribute {
+	a.Required = true
+	return a
+}
+
+func (a schemaAttribute) unique() schemaAttribute {
+	a.Uniqueness = "server"
+	return a
+}
+
+func (a schemaAttribute) mutability(m string) schemaAttribute {
+	a.Mutability = m
+	for i := range a.SubAttributes {
+		a.SubAttributes[i].Mutability = m
+	}
+	return a
+}
+
+func (a schemaAttribute) neverReturned() schemaAttribute {
+	a.Returned = "never"
+	return a
+}
+
+type schemaDefinition struct {
+	Schemas     []string          `json:"schemas"`
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Attributes  []schemaAttribute `json:"attributes"`
+	Meta        map[string]string `json:"meta"`
+}
+
+type schemaExtension struct {
+	Schema   string `json:"schema"`
+	Required bool   `json:"required"`
+}
+
+type resourceType struct {
+	Schemas          []string          `json:"schemas"`
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Endpoint         string            `json:"endpoint"`
+	Description      string            `json:"description"`
+	Schema           string            `json:"schema"`
+	SchemaExtensions []schemaExtension `json:"schemaExtensions,omitempty"`
+	Meta             map[string]string `json:"meta"`
+}
+
+func typedValues(name string) schemaAttribute {
+	return complexAttribute(name,
+		attribute("value", "string"),
+		attribute("type", "string"),
+		attribute("primary", "boolean"),
+	).multiValued()
+}
+
+func schemaDefinitions(base string) []schemaDefinition {
+	defs := []schemaDefinition{
+		{
+			ID:          SchemaUser,
+			Name:        "User",
+			Description: "User Account",
+			Attributes: []schemaAttribute{
+				attribute("id", "string").mutability("readOnly").unique(),
+				attribute("userName", "string").required().unique(),
+				complexAttribute("name",
+					attribute("formatted", "string"),
+					attribute("givenName", "string"),
+					attribute("familyName", "string"),
+				),
+				attribute("displayName", "string"),
+				attribute("active", "boolean"),
+				attribute("password", "string").mutability("writeOnly").neverReturned(),
+				typedValues("emails").required(),
+				typedValues("phoneNumbers"),
+				complexAttribute("groups",
+					attribute("value", "string"),
+					reference("$ref", "Group"),
+					attribute("display", "string"),
+				).multiValued().mutability("readOnly"),
+			},
+		},
+		{
+			ID:          SchemaEnterpriseUser,
+			Name:        "EnterpriseUser",
+			Description: "Enterprise User; employeeNumber, costCenter, organization, division and department are stored only if a user extension attribute of that name is defined",
+			Attributes: []schemaAttribute{
+				attribute("employeeNumber", "string"),
+				attribute("costCenter", "string"),
+				attribute("organization", "string"),
+				attribute("division", "string"),
+				attribute("department", "string"),
+				complexAttribute("manager",
+					attribute("value", "string"),
+					reference("$ref", "User").mutability("readOnly"),
+				),
+			},
+		},
+		{
+			ID:          SchemaGroup,
+			Name:        "Group",
+			Description: "Group",
+			Attributes: []schemaAttribute{
+				attribute("id", "string").mutability("readOnly").unique(),
+				attribute("displayName", "string").required().unique(),
+				complexAttribute("members",
+					attribute("value", "string").mutability("immutable"),
+					reference("$ref", "User").mutability("immutable"),
+					attribute("display", "string").mutability("readOnly"),
+					attribute("type", "string").mutability("immutable"),
+				).multiValued(),
+			},
+		},
+	}
+	for i := range defs {
+		defs[i].Schemas = []string{SchemaSchema}
+		defs[i].Meta = map[string]string{"resourceType": "Schema", "location": base + "/Schemas/" + defs[i].ID}
+	}
+	return defs
+}
+
+func resourceTypes(base string) []resourceType {
+	types := []resourceType{
+		{
+			ID:               "User",
+			Name:             "User",
+			Endpoint:         "/Users",
+			Description:      "User Account",
+			Schema:           SchemaUser,
+			SchemaExtensions: []schemaExtension{{Schema: SchemaEnterpriseUser}},
+		},
+		{
+			ID:          "Group",
+			Name:        "Group",
+			Endpoint:    "/Groups",
+			Description: "Group",
+			Schema:      SchemaGroup,
+		},
+	}
+	for i := range types {
+		types[i].Schemas = []string{SchemaResourceType}
+		types[i].Meta = map[string]string{"resourceType": "ResourceType", "location": base + "/ResourceTypes/" + types[i].ID}
+	}
+	return types
+}
+
+// discoveryList writes a list response of all items, as the discovery
+// endpoints take no filter or pagination.
+func discoveryList[T any](c *gin.Context, items []T) {
+	write(c, http.StatusOK, listResponse[T]{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: len(items),
+		StartIndex:   1,
+		ItemsPerPage: len(items),
+		Resources:    items,
+	})
+}
+
+// ServiceProviderConfig describes the supported SCIM features.
+func (h *Handler) ServiceProviderConfig(c *gin.Context) {
+	supported := func(ok bool) map[string]any { return map[string]any{"supported": ok} }
+	write(c, http.StatusOK, map[string]any{
+		"schemas":        []string{SchemaServiceProviderConfig},
+		"patch":          supported(true),
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": maxResults},
+		"changePassword": supported(true),
+		"sort":           supported(false),
+		"etag":           supported(true),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "A service account API key or access token, or a user's access token, as a bearer token",
+			"primary":     true,
+		}},
+		"meta": map[string]string{
+			"resourceType": "ServiceProviderConfig",
+			"location":     baseURL(c) + "/ServiceProviderConfig",
+		},
+	})
+}
+
+// ResourceTypes lists the resource types.
+func (h *Handler) ResourceTypes(c *gin.Context) {
+	discoveryList(c, resourceTypes(baseURL(c)))
+}
+
+// ResourceType returns a resource type.
+func (h *Handler) ResourceType(c *gin.Context) {
+	for _, t := range resourceTypes(baseURL(c)) {
+		if t.ID == c.Param("id") {
+			write(c, http.StatusOK, t)
+			return
+		}
+	}
+	fail(c, notFound("ResourceType"))
+}
+
+// Schemas lists the schemas of the resources.
+func (h *Handler) Schemas(c *gin.Context) {
+	discoveryList(c, schemaDefinitions(baseURL(c)))
+}
+
+// Schema returns a schema.
+func (h *Handler) Schema(c *gin.Context) {
+	for _, s := range schemaDefinitions(baseURL(c)) {
+		if s.ID == c.Param("id") {
+			write(c, http.StatusOK, s)
+			return
+		}
+	}
+	fail(c, notFound("Schema"))
+}
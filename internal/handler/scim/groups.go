@@ -0,0 +1,312 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/scim/filter"
+	"github.com/qinzj/claude-demo/internal/scim/patch"
+)
+
+var groupPatchSchema = patch.Schema{
+	ID:          SchemaGroup,
+	MultiValued: []string{"members"},
+}
+
+// groupResource converts a group to a SCIM Group. Its members are users;
+// child groups are not members in SCIM terms.
+func groupResource(base string, g *domain.Group) map[string]any {
+	id := g.ID.String()
+	res := map[string]any{
+		"schemas":     []any{SchemaGroup},
+		"id":          id,
+		"displayName": g.Name,
+		"meta": map[string]any{
+			"resourceType": "Group",
+			"created":      timestamp(g.CreatedAt),
+			"lastModified": timestamp(g.UpdatedAt),
+			"location":     base + "/Groups/" + id,
+		},
+	}
+	if len(g.Users) > 0 {
+		users := slices.Clone(g.Users)
+		slices.SortFunc(users, func(a, b *domain.User) int { return strings.Compare(a.Username, b.Username) })
+		members := make([]any, len(users))
+		for i, u := range users {
+			members[i] = map[string]any{
+				"value":   u.ID.String(),
+				"display": u.DisplayName,
+				"type":    "User",
+				"$ref":    base + "/Users/" + u.ID.String(),
+			}
+		}
+		res["members"] = members
+	}
+	setVersion(res)
+	return res
+}
+
+// parseGroup reads the display name and members of a SCIM Group. Members
+// must be existing users.
+func (h *Handler) parseGroup(ctx context.Context, res map[string]any) (string, []uuid.UUID, error) {
+	name, err := str(res, "displayName")
+	if err != nil {
+		return "", nil, err
+	}
+	if name == "" {
+		return "", nil, badRequest("invalidValue", "displayName is required")
+	}
+
+	_, v, _ := filter.Lookup(res, "members")
+	if v == nil {
+		return name, nil, nil
+	}
+	elems, ok := v.([]any)
+	if !ok {
+		return "", nil, badRequest("invalidValue", "members must be an array")
+	}
+	if len(elems) == 0 {
+		return name, nil, nil
+	}
+	var members []uuid.UUID
+	for _, e := range elems {
+		m, ok := e.(map[string]any)
+		if !ok {
+			return "", nil, badRequest("invalidValue", "members must be objects")
+		}
+		if typ, err := str(m, "type"); err != nil || (typ != "" && !strings.EqualFold(typ, "User")) {
+			return "", nil, badRequest("invalidValue", "only users can be members of a group")
+		}
+		value, err := str(m, "value")
+		if err != nil {
+			return "", nil, err
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return "", nil, badRequest("invalidValue", "member %q is not a user", value)
+		}
+		if !slices.Contains(members, id) {
+			members = append(members, id)
+		}
+	}
+
+	users, _, err := h.userService.FindUsers(ctx, domain.UserFilter{IDs: members}, 0, -1)
+	if err != nil {
+		return "", nil, err
+	}
+	known := make(map[uuid.UUID]bool, len(users))
+	for _, u := range users {
+		known[u.ID] = true
+	}
+	for _, id := range members {
+		if !known[id] {
+			return "", nil, badRequest("invalidValue", "member %q is not a user", id.String())
+		}
+	}
+	return name, members, nil
+}
+
+// checkGroupUnique fails with 409 if a group other than the given one has
+// the name.
+func (h *Handler) checkGroupUnique(ctx context.Context, id uuid.UUID, name string) error {
+	groups, err := h.groupService.AllGroups(ctx)
+	if err != nil {
+		return err
+	}
+	for _, g := range groups {
+		if g.ID != id && strings.EqualFold(g.Name, name) {
+			return &Error{Status: http.StatusConflict, ScimType: "uniqueness", Detail: "displayName " + name + " is already taken"}
+		}
+	}
+	return nil
+}
+
+// applyGroup renames a group and adds and removes members to match the
+// given ones.
+func (h *Handler) applyGroup(ctx context.Context, g *domain.Group, name string, members []uuid.UUID) error {
+	if name != g.Name {
+		if err := h.checkGroupUnique(ctx, g.ID, name); err != nil {
+			return err
+		}
+		if _, err := h.groupService.UpdateGroup(ctx, g.ID, domain.UpdateGroupInput{Name: &name}); err != nil {
+			return err
+		}
+	}
+
+	current := make(map[uuid.UUID]bool, len(g.Users))
+	for _, u := range g.Users {
+		current[u.ID] = true
+	}
+	var added []uuid.UUID
+	for _, id := range members {
+		if !current[id] {
+			added = append(added, id)
+		}
+		delete(current, id)
+	}
+	if len(added) > 0 {
+		if err := h.groupService.AddMembers(ctx, g.ID, added); err != nil {
+			return err
+		}
+	}
+	for id := range current {
+		if err := h.groupService.RemoveMember(ctx, g.ID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadGroup returns a group with its current SCIM resource.
+func (h *Handler) loadGroup(c *gin.Context) (*domain.Group, map[string]any, error) {
+	id, err := parseID(c, "Group")
+	if err != nil {
+		return nil, nil, err
+	}
+	g, err := h.groupService.GetGroup(c.Request.Context(), id)
+	if err != nil {
+		return nil, nil, notFound("Group")
+	}
+	return g, groupResource(baseURL(c), g), nil
+}
+
+// respondGroup reloads a group after a change and writes its resource.
+func (h *Handler) respondGroup(c *gin.Context, status int, id uuid.UUID) {
+	g, err := h.groupService.GetGroup(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	respond(c, status, groupResource(baseURL(c), g))
+}
+
+// ListGroups lists groups, optionally filtered, a page at a time.
+func (h *Handler) ListGroups(c *gin.Context) {
+	groups, err := h.groupService.AllGroups(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	slices.SortFunc(groups, func(a, b *domain.Group) int { return strings.Compare(a.Name, b.Name) })
+	base := baseURL(c)
+	resources := make([]map[string]any, len(groups))
+	for i, g := range groups {
+		resources[i] = groupResource(base, g)
+	}
+	list(c, resources)
+}
+
+// GetGroup returns a group.
+func (h *Handler) GetGroup(c *gin.Context) {
+	_, res, err := h.loadGroup(c)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	respondGet(c, res)
+}
+
+// CreateGroup creates a group with the given members.
+func (h *Handler) CreateGroup(c *gin.Context) {
+	ctx := c.Request.Context()
+	var body map[string]any
+	if err := decode(c, &body); err != nil {
+		fail(c, err)
+		return
+	}
+	name, members, err := h.parseGroup(ctx, body)
+	if err == nil {
+		err = h.checkGroupUnique(ctx, uuid.Nil, name)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	g, err := h.groupService.CreateGroup(ctx, domain.CreateGroupInput{Name: name})
+	if err == nil && len(members) > 0 {
+		err = h.groupService.AddMembers(ctx, g.ID, members)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	h.respondGroup(c, http.StatusCreated, g.ID)
+}
+
+// ReplaceGroup replaces the display name and members of a group.
+func (h *Handler) ReplaceGroup(c *gin.Context) {
+	g, res, err := h.loadGroup(c)
+	if err == nil {
+		err = checkPrecondition(c, res)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	var body map[string]any
+	if err := decode(c, &body); err != nil {
+		fail(c, err)
+		return
+	}
+	name, members, err := h.parseGroup(c.Request.Context(), body)
+	if err == nil {
+		err = h.applyGroup(c.Request.Context(), g, name, members)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	h.respondGroup(c, http.StatusOK, g.ID)
+}
+
+// PatchGroup applies PATCH operations to a group, such as adding or
+// removing members.
+func (h *Handler) PatchGroup(c *gin.Context) {
+	g, res, err := h.loadGroup(c)
+	if err == nil {
+		err = checkPrecondition(c, res)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	ops, err := decodePatch(c)
+	if err == nil {
+		err = patch.Apply(res, ops, groupPatchSchema)
+	}
+	var name string
+	var members []uuid.UUID
+	if err == nil {
+		name, members, err = h.parseGroup(c.Request.Context(), res)
+	}
+	if err == nil {
+		err = h.applyGroup(c.Request.Context(), g, name, members)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	h.respondGroup(c, http.StatusOK, g.ID)
+}
+
+// DeleteGroup deletes a group.
+func (h *Handler) DeleteGroup(c *gin.Context) {
+	g, res, err := h.loadGroup(c)
+	if err == nil {
+		err = checkPrecondition(c, res)
+	}
+	if err == nil {
+		err = h.groupService.DeleteGroup(c.Request.Context(), g.ID)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,483 @@
+// Package scim serves the SCIM 2.0 protocol (RFC 7643, RFC 7644), through
+// which identity providers and HR systems provision users and groups.
+package scim
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/scim/filter"
+	"github.com/qinzj/claude-demo/internal/scim/patch"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// BasePath is the path under which the SCIM endpoints are served.
+const BasePath = "/scim/v2"
+
+// ContentType is the media type of SCIM requests and responses.
+const ContentType = "application/scim+json"
+
+// Schema URNs.
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaEnterpriseUser        = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+const (
+	// defaultCount is the page size of list responses without a count.
+	defaultCount = 100
+	// maxResults is the largest page size of list responses.
+	maxResults = 200
+)
+
+// UserService defines the user operations needed by SCIM handler.
+type UserService interface {
+	CreateUser(ctx context.Context, input domain.CreateUserInput) (*domain.User, error)
+	GetUser(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	FindUsers(ctx context.Context, f domain.UserFilter, offset, limit int) ([]*domain.User, int, error)
+	UpdateUser(ctx context.Context, id uuid.UUID, input domain.UpdateUserInput) (*domain.User, error)
+	DeleteUser(ctx context.Context, id uuid.UUID) error
+	ResetPassword(ctx context.Context, id uuid.UUID, newPassword string, mustChange bool) error
+	SetUserStatus(ctx context.Context, id uuid.UUID, status domain.UserStatus) error
+}
+
+// GroupService defines the group operations needed by SCIM handler.
+type GroupService interface {
+	CreateGroup(ctx context.Context, input domain.CreateGroupInput) (*domain.Group, error)
+	GetGroup(ctx context.Context, id uuid.UUID) (*domain.Group, error)
+	AllGroups(ctx context.Context) ([]*domain.Group, error)
+	UpdateGroup(ctx context.Context, id uuid.UUID, input domain.UpdateGroupInput) (*domain.Group, error)
+	DeleteGroup(ctx context.Context, id uuid.UUID) error
+	AddMembers(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID) error
+	RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error
+}
+
+// AttributeService defines the extension attribute operations needed by SCIM handler.
+type AttributeService interface {
+	ListDefinitions(ctx context.Context, target domain.AttributeTarget) ([]*domain.AttributeDefinition, error)
+}
+
+// Handler handles SCIM requests.
+type Handler struct {
+	userService      UserService
+	groupService     GroupService
+	attributeService AttributeService
+}
+
+// New creates a new SCIM Handler.
+func New(userSvc UserService, groupSvc GroupService, attrSvc AttributeService) *Handler {
+	return &Handler{userService: userSvc, groupService: groupSvc, attributeService: attrSvc}
+}
+
+// RegisterRoutes registers the SCIM endpoints on r, which must authenticate
+// requests. Users need user:read or user:write, groups group:read or
+// group:admin; the discovery endpoints only need authentication.
+func (h *Handler) RegisterRoutes(r gin.IRoutes) {
+	r.GET("/ServiceProviderConfig", h.ServiceProviderConfig)
+	r.GET("/ResourceTypes", h.ResourceTypes)
+	r.GET("/ResourceTypes/:id", h.ResourceType)
+	r.GET("/Schemas", h.Schemas)
+	r.GET("/Schemas/:id", h.Schema)
+
+	r.GET("/Users", require(domain.PermissionUserRead), h.ListUsers)
+	r.POST("/Users", require(domain.PermissionUserWrite), h.CreateUser)
+	r.GET("/Users/:id", require(domain.PermissionUserRead), h.GetUser)
+	r.PUT("/Users/:id", require(domain.PermissionUserWrite), h.ReplaceUser)
+	r.PATCH("/Users/:id", require(domain.PermissionUserWrite), h.PatchUser)
+	r.DELETE("/Users/:id", require(domain.PermissionUserWrite), h.DeleteUser)
+
+	r.GET("/Groups", require(domain.PermissionGroupRead), h.ListGroups)
+	r.POST("/Groups", require(domain.PermissionGroupAdmin), h.CreateGroup)
+	r.GET("/Groups/:id", require(domain.PermissionGroupRead), h.GetGroup)
+	r.PUT("/Groups/:id", require(domain.PermissionGroupAdmin), h.ReplaceGroup)
+	r.PATCH("/Groups/:id", require(domain.PermissionGroupAdmin), h.PatchGroup)
+	r.DELETE("/Groups/:id", require(domain.PermissionGroupAdmin), h.DeleteGroup)
+}
+
+// Abort rejects a request with a SCIM error, such as when authentication
+// fails.
+func Abort(c *gin.Context, status int, message string) {
+	c.Abort()
+	writeError(c, &Error{Status: status, Detail: message})
+}
+
+// require returns a middleware that rejects requests whose actor lacks the
+// given permission, or must change their password first.
+func require(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("password_change_required") {
+			Abort(c, http.StatusForbidden, "password change required")
+			return
+		}
+		actor, ok := service.ActorFromContext(c.Request.Context())
+		if !ok || !actor.Can(permission) {
+			Abort(c, http.StatusForbidden, "permission denied: requires "+permission)
+			return
+		}
+		c.Next()
+	}
+}
+
+// Error is a SCIM error response. ScimType further describes errors with
+// status 400 and 409, such as invalidFilter or uniqueness.
+type Error struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+func badRequest(scimType, format string, args ...any) *Error {
+	return &Error{Status: http.StatusBadRequest, ScimType: scimType, Detail: fmt.Sprintf(format, args...)}
+}
+
+func notFound(resourceType string) *Error {
+	return &Error{Status: http.StatusNotFound, Detail: resourceType + " not found"}
+}
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+	Status   string   `json:"status"`
+}
+
+func writeError(c *gin.Context, e *Error) {
+	write(c, e.Status, errorResponse{
+		Schemas:  []string{SchemaError},
+		ScimType: e.ScimType,
+		Detail:   e.Detail,
+		Status:   strconv.Itoa(e.Status),
+	})
+}
+
+// fail writes the SCIM error for err, mapping service errors to their
+// status codes.
+func fail(c *gin.Context, err error) {
+	var serr *Error
+	var perr *patch.Error
+	var pwerr *service.PasswordPolicyError
+	switch {
+	case errors.As(err, &serr):
+		writeError(c, serr)
+	case errors.As(err, &perr):
+		writeError(c, badRequest(perr.ScimType, "%s", perr.Detail))
+	case errors.As(err, &pwerr):
+		writeError(c, badRequest("invalidValue", "%s", pwerr.Error()))
+	case errors.Is(err, service.ErrInvalidAttributes), errors.Is(err, service.ErrInvalidManager),
+		errors.Is(err, service.ErrUserPending):
+		writeError(c, badRequest("invalidValue", "%s", err.Error()))
+	case errors.Is(err, service.ErrPasswordManagedUpstream):
+		writeError(c, badRequest("mutability", "%s", err.Error()))
+	case errors.Is(err, service.ErrForbidden):
+		writeError(c, &Error{Status: http.StatusForbidden, Detail: err.Error()})
+	default:
+		writeError(c, &Error{Status: http.StatusInternalServerError, Detail: err.Error()})
+	}
+}
+
+func write(c *gin.Context, status int, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.Data(status, ContentType, b)
+}
+
+// decode reads a JSON request body.
+func decode(c *gin.Context, v any) error {
+	if err := json.NewDecoder(c.Request.Body).Decode(v); err != nil {
+		return badRequest("invalidSyntax", "invalid request body: %v", err)
+	}
+	return nil
+}
+
+// baseURL returns the absolute URL of the SCIM endpoints, as reached by the
+// client, for the location of resources.
+func baseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + BasePath
+}
+
+// parseID parses the id of a resource from the path. As ids are opaque to
+// clients, one that is not a UUID is simply not found.
+func parseID(c *gin.Context, resourceType string) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, notFound(resourceType)
+	}
+	return id, nil
+}
+
+// setVersion sets the version of a resource in its meta attribute: a weak
+// ETag hashed from its content, so that it changes with anything shown,
+// such as group memberships.
+func setVersion(res map[string]any) string {
+	meta := res["meta"].(map[string]any)
+	delete(meta, "version")
+	b, _ := json.Marshal(res)
+	sum := sha256.Sum256(b)
+	etag := `W/"` + hex.EncodeToString(sum[:8]) + `"`
+	meta["version"] = etag
+	return etag
+}
+
+func version(res map[string]any) string {
+	return res["meta"].(map[string]any)["version"].(string)
+}
+
+// etagMatches reports whether an If-Match or If-None-Match header lists
+// the ETag, using the weak comparison.
+func etagMatches(header, etag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPrecondition fails with 412 if the request has an If-Match header
+// that does not match the current version of the resource.
+func checkPrecondition(c *gin.Context, res map[string]any) error {
+	if m := c.GetHeader("If-Match"); m != "" && !etagMatches(m, version(res)) {
+		return &Error{Status: http.StatusPreconditionFailed, Detail: "resource has changed: version " + version(res)}
+	}
+	return nil
+}
+
+// respond writes a resource with its ETag, after applying the attributes
+// and excludedAttributes query parameters.
+func respond(c *gin.Context, status int, res map[string]any) {
+	q, err := parseProjection(c)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.Header("ETag", version(res))
+	if status == http.StatusCreated {
+		c.Header("Location", res["meta"].(map[string]any)["location"].(string))
+	}
+	write(c, status, q.project(res))
+}
+
+// respondGet writes a resource like respond, or 304 Not Modified if the
+// client already has its current version.
+func respondGet(c *gin.Context, res map[string]any) {
+	if m := c.GetHeader("If-None-Match"); m != "" && etagMatches(m, version(res)) {
+		c.Header("ETag", version(res))
+		c.Status(http.StatusNotModified)
+		return
+	}
+	respond(c, http.StatusOK, res)
+}
+
+type listResponse[T any] struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []T      `json:"Resources"`
+}
+
+// projection holds the attributes and excludedAttributes query parameters.
+type projection struct {
+	attributes []filter.AttrPath
+	excluded   []filter.AttrPath
+}
+
+func parseProjection(c *gin.Context) (*projection, error) {
+	var q projection
+	for param, paths := range map[string]*[]filter.AttrPath{"attributes": &q.attributes, "excludedAttributes": &q.excluded} {
+		v := c.Query(param)
+		if v == "" {
+			continue
+		}
+		for _, s := range strings.Split(v, ",") {
+			p, err := filter.ParsePath(s)
+			if err != nil || p.Filter != nil {
+				return nil, badRequest("invalidValue", "invalid %s %q", param, s)
+			}
+			*paths = append(*paths, p.AttrPath)
+		}
+	}
+	return &q, nil
+}
+
+// project returns the resource with only the requested attributes, or
+// without the excluded ones. Sub-attributes select their whole attribute.
+// The schemas and id are always returned.
+func (q *projection) project(res map[string]any) map[string]any {
+	if len(q.attributes) > 0 {
+		out := map[string]any{"schemas": res["schemas"], "id": res["id"]}
+		for _, p := range q.attributes {
+			from := filter.Container(res, p)
+			key, v, ok := filter.Lookup(from, p.Name)
+			if !ok {
+				continue
+			}
+			to := out
+			if p.URN != "" {
+				if urn, _, ok := filter.Lookup(res, p.URN); ok {
+					ext, _ := out[urn].(map[string]any)
+					if ext == nil {
+						ext = make(map[string]any)
+						out[urn] = ext
+					}
+					to = ext
+				}
+			}
+			to[key] = v
+		}
+		res = out
+	}
+	for _, p := range q.excluded {
+		c := filter.Container(res, p)
+		if key, _, ok := filter.Lookup(c, p.Name); ok && key != "id" && key != "schemas" {
+			delete(c, key)
+		}
+	}
+	return res
+}
+
+// listQuery holds the query parameters of a list request.
+type listQuery struct {
+	start, count int
+	filter       *filter.Filter
+	projection   *projection
+}
+
+func parseListQuery(c *gin.Context) (*listQuery, error) {
+	q := &listQuery{start: 1, count: defaultCount}
+	var err error
+	if v := c.Query("startIndex"); v != "" {
+		if q.start, err = strconv.Atoi(v); err != nil {
+			return nil, badRequest("invalidValue", "invalid startIndex %q", v)
+		}
+		q.start = max(q.start, 1)
+	}
+	if v := c.Query("count"); v != "" {
+		if q.count, err = strconv.Atoi(v); err != nil {
+			return nil, badRequest("invalidValue", "invalid count %q", v)
+		}
+		q.count = min(max(q.count, 0), maxResults)
+	}
+	if q.projection, err = parseProjection(c); err != nil {
+		return nil, err
+	}
+	if v := c.Query("filter"); v != "" {
+		if q.filter, err = filter.Parse(v); err != nil {
+			return nil, badRequest("invalidFilter", "%v", err)
+		}
+	}
+	return q, nil
+}
+
+// list writes the resources that match the filter query parameter, a page
+// at a time as set by startIndex and count.
+func list(c *gin.Context, resources []map[string]any) {
+	q, err := parseListQuery(c)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	if q.filter != nil {
+		var matched []map[string]any
+		for _, res := range resources {
+			if q.filter.Match(res) {
+				matched = append(matched, res)
+			}
+		}
+		resources = matched
+	}
+	var page []map[string]any
+	if q.start <= len(resources) {
+		page = resources[q.start-1 : min(q.start-1+q.count, len(resources))]
+	}
+	q.write(c, len(resources), page)
+}
+
+// write writes a page of the total matching resources.
+func (q *listQuery) write(c *gin.Context, total int, page []map[string]any) {
+	projected := make([]map[string]any, len(page))
+	for i, res := range page {
+		projected[i] = q.projection.project(res)
+	}
+	write(c, http.StatusOK, listResponse[map[string]any]{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   q.start,
+		ItemsPerPage: len(projected),
+		Resources:    projected,
+	})
+}
+
+// str returns a string attribute, or "" if it is not set.
+func str(m map[string]any, name string) (string, error) {
+	_, v, ok := filter.Lookup(m, name)
+	if !ok || v == nil {
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", badRequest("invalidValue", "%s must be a string", name)
+	}
+	return s, nil
+}
+
+// primaryValue returns the value of the primary value of a multi-valued
+// attribute such as emails, or else of its first value.
+func primaryValue(m map[string]any, name string) (string, error) {
+	_, v, ok := filter.Lookup(m, name)
+	if !ok || v == nil {
+		return "", nil
+	}
+	elems, ok := v.([]any)
+	if !ok {
+		return "", badRequest("invalidValue", "%s must be an array", name)
+	}
+	var chosen map[string]any
+	for _, e := range elems {
+		em, ok := e.(map[string]any)
+		if !ok {
+			return "", badRequest("invalidValue", "values of %s must be objects", name)
+		}
+		if chosen == nil {
+			chosen = em
+		}
+		if _, primary, _ := filter.Lookup(em, "primary"); primary == true {
+			chosen = em
+			break
+		}
+	}
+	if chosen == nil {
+		return "", nil
+	}
+	return str(chosen, "value")
+}
@@ -0,0 +1,582 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/scim/filter"
+	"github.com/qinzj/claude-demo/internal/scim/patch"
+)
+
+// enterpriseAttributes are the simple attributes of the enterprise user
+// extension. Each is stored in the user extension attribute of the same
+// name, if one is defined, and ignored otherwise.
+var enterpriseAttributes = []string{"employeeNumber", "costCenter", "organization", "division", "department"}
+
+var userPatchSchema = patch.Schema{
+	ID:          SchemaUser,
+	Extensions:  []string{SchemaEnterpriseUser},
+	MultiValued: []string{"emails", "phoneNumbers", "groups"},
+}
+
+// enterpriseDefinitions maps the enterprise attributes that have a user
+// extension attribute to its name.
+func (h *Handler) enterpriseDefinitions(ctx context.Context) (map[string]string, error) {
+	defs, err := h.attributeService.ListDefinitions(ctx, domain.AttributeTargetUser)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string)
+	for _, attr := range enterpriseAttributes {
+		for _, def := range defs {
+			if strings.EqualFold(def.Name, attr) {
+				names[attr] = def.Name
+			}
+		}
+	}
+	return names, nil
+}
+
+func timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// userResource converts a user to a SCIM User with the groups they are a
+// member of. The user is active only if enabled; pending users are not.
+func userResource(base string, u *domain.User, groups []*domain.Group, defs map[string]string) map[string]any {
+	id := u.ID.String()
+	res := map[string]any{
+		"schemas":     []any{SchemaUser},
+		"id":          id,
+		"userName":    u.Username,
+		"displayName": u.DisplayName,
+		"name":        map[string]any{"formatted": u.DisplayName},
+		"active":      u.Status == domain.UserStatusEnabled,
+		"emails":      []any{map[string]any{"value": u.Email, "type": "work", "primary": true}},
+		"meta": map[string]any{
+			"resourceType": "User",
+			"created":      timestamp(u.CreatedAt),
+			"lastModified": timestamp(u.UpdatedAt),
+			"location":     base + "/Users/" + id,
+		},
+	}
+	if u.Phone != "" {
+		res["phoneNumbers"] = []any{map[string]any{"value": u.Phone, "type": "work", "primary": true}}
+	}
+	if len(groups) > 0 {
+		values := make([]any, len(groups))
+		for i, g := range groups {
+			values[i] = map[string]any{"value": g.ID.String(), "display": g.Name, "$ref": base + "/Groups/" + g.ID.String()}
+		}
+		res["groups"] = values
+	}
+
+	ext := make(map[string]any)
+	if u.ManagerID != nil {
+		ext["manager"] = map[string]any{"value": u.ManagerID.String(), "$ref": base + "/Users/" + u.ManagerID.String()}
+	}
+	for _, attr := range enterpriseAttributes {
+		if name, ok := defs[attr]; ok && len(u.Attributes[name]) > 0 {
+			ext[attr] = u.Attributes[name][0]
+		}
+	}
+	if len(ext) > 0 {
+		res["schemas"] = []any{SchemaUser, SchemaEnterpriseUser}
+		res[SchemaEnterpriseUser] = ext
+	}
+	setVersion(res)
+	return res
+}
+
+// userFields are the attributes of a SCIM User that map to a user.
+type userFields struct {
+	username    string
+	displayName string
+	email       string
+	phone       string
+	password    string
+	active      *bool
+	managerID   *uuid.UUID
+	attributes  map[string][]string
+}
+
+// parseUser reads the attributes of a SCIM User. The display name falls
+// back to the formatted or given and family names, then to the user name.
+// Read-only attributes such as groups are ignored.
+func parseUser(res map[string]any, defs map[string]string) (*userFields, error) {
+	var f userFields
+	var err error
+	if f.username, err = str(res, "userName"); err != nil {
+		return nil, err
+	}
+	if f.username == "" {
+		return nil, badRequest("invalidValue", "userName is required")
+	}
+	if f.displayName, err = str(res, "displayName"); err != nil {
+		return nil, err
+	}
+	if _, v, _ := filter.Lookup(res, "name"); f.displayName == "" && v != nil {
+		name, ok := v.(map[string]any)
+		if !ok {
+			return nil, badRequest("invalidValue", "name must be an object")
+		}
+		formatted, _ := str(name, "formatted")
+		given, _ := str(name, "givenName")
+		family, _ := str(name, "familyName")
+		if f.displayName = formatted; f.displayName == "" {
+			f.displayName = strings.TrimSpace(given + " " + family)
+		}
+	}
+	if f.displayName == "" {
+		f.displayName = f.username
+	}
+	if f.email, err = primaryValue(res, "emails"); err != nil {
+		return nil, err
+	}
+	if f.email == "" {
+		return nil, badRequest("invalidValue", "an email address is required")
+	}
+	if f.phone, err = primaryValue(res, "phoneNumbers"); err != nil {
+		return nil, err
+	}
+	if f.password, err = str(res, "password"); err != nil {
+		return nil, err
+	}
+
+	// Some clients send active as a string.
+	switch _, v, _ := filter.Lookup(res, "active"); v := v.(type) {
+	case nil:
+	case bool:
+		f.active = &v
+	case string:
+		if !strings.EqualFold(v, "true") && !strings.EqualFold(v, "false") {
+			return nil, badRequest("invalidValue", "active must be a boolean")
+		}
+		active := strings.EqualFold(v, "true")
+		f.active = &active
+	default:
+		return nil, badRequest("invalidValue", "active must be a boolean")
+	}
+
+	ext := map[string]any{}
+	if _, v, ok := filter.Lookup(res, SchemaEnterpriseUser); ok && v != nil {
+		if ext, ok = v.(map[string]any); !ok {
+			return nil, badRequest("invalidValue", "%s must be an object", SchemaEnterpriseUser)
+		}
+	}
+	manager, err := managerValue(ext)
+	if err != nil {
+		return nil, err
+	}
+	if manager != "" {
+		id, err := uuid.Parse(manager)
+		if err != nil {
+			return nil, badRequest("invalidValue", "manager %q is not a user id", manager)
+		}
+		f.managerID = &id
+	}
+	f.attributes = make(map[string][]string)
+	for attr, name := range defs {
+		v, err := str(ext, attr)
+		if err != nil {
+			return nil, err
+		}
+		f.attributes[name] = []string{}
+		if v != "" {
+			f.attributes[name] = []string{v}
+		}
+	}
+	return &f, nil
+}
+
+// managerValue returns the id of the manager of an enterprise user, which
+// clients send either as an object or as the id itself.
+func managerValue(ext map[string]any) (string, error) {
+	_, v, _ := filter.Lookup(ext, "manager")
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case map[string]any:
+		return str(v, "value")
+	default:
+		return "", badRequest("invalidValue", "manager must be an object")
+	}
+}
+
+// checkUserUnique fails with 409 if a user other than the given one has the
+// user name or email address.
+func (h *Handler) checkUserUnique(ctx context.Context, id uuid.UUID, username, email string) error {
+	for _, taken := range []struct {
+		filter domain.UserFilter
+		detail string
+	}{
+		{domain.UserFilter{Username: username}, "userName " + username},
+		{domain.UserFilter{Email: email}, "email " + email},
+	} {
+		users, _, err := h.userService.FindUsers(ctx, taken.filter, 0, 2)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			if u.ID != id {
+				return &Error{Status: http.StatusConflict, ScimType: "uniqueness", Detail: taken.detail + " is already taken"}
+			}
+		}
+	}
+	return nil
+}
+
+// applyUser updates a user to the given attributes, changing only what
+// differs. The user name cannot be changed. A password is set as a reset by
+// an administrator; becoming inactive disables the user, while pending
+// users stay pending until they accept their invitation.
+func (h *Handler) applyUser(ctx context.Context, u *domain.User, f *userFields) error {
+	if f.username != u.Username {
+		return badRequest("mutability", "userName cannot be changed")
+	}
+	if !strings.EqualFold(f.email, u.Email) {
+		if err := h.checkUserUnique(ctx, u.ID, f.username, f.email); err != nil {
+			return err
+		}
+	}
+
+	var input domain.UpdateUserInput
+	changed := false
+	if f.displayName != u.DisplayName {
+		input.DisplayName, changed = &f.displayName, true
+	}
+	if f.email != u.Email {
+		input.Email, changed = &f.email, true
+	}
+	if f.phone != u.Phone {
+		input.Phone, changed = &f.phone, true
+	}
+	switch {
+	case f.managerID == nil && u.ManagerID != nil:
+		input.ClearManager, changed = true, true
+	case f.managerID != nil && (u.ManagerID == nil || *u.ManagerID != *f.managerID):
+		input.ManagerID, changed = f.managerID, true
+	}
+	for name, values := range f.attributes {
+		if !slices.Equal(values, u.Attributes[name]) {
+			if input.Attributes == nil {
+				input.Attributes = make(map[string][]string)
+			}
+			input.Attributes[name], changed = values, true
+		}
+	}
+	if changed {
+		if _, err := h.userService.UpdateUser(ctx, u.ID, input); err != nil {
+			return err
+		}
+	}
+
+	if f.password != "" {
+		if err := h.userService.ResetPassword(ctx, u.ID, f.password, false); err != nil {
+			return err
+		}
+	}
+	if f.active != nil && *f.active != (u.Status == domain.UserStatusEnabled) {
+		status := domain.UserStatusDisabled
+		if *f.active {
+			status = domain.UserStatusEnabled
+		}
+		if status == domain.UserStatusEnabled || u.Status == domain.UserStatusEnabled {
+			if err := h.userService.SetUserStatus(ctx, u.ID, status); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadUser returns a user with their current SCIM resource.
+func (h *Handler) loadUser(c *gin.Context) (*domain.User, map[string]any, map[string]string, error) {
+	id, err := parseID(c, "User")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	u, err := h.userService.GetUser(c.Request.Context(), id)
+	if err != nil {
+		return nil, nil, nil, notFound("User")
+	}
+	defs, err := h.enterpriseDefinitions(c.Request.Context())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return u, userResource(baseURL(c), u, u.Groups, defs), defs, nil
+}
+
+// respondUser reloads a user after a change and writes their resource.
+func (h *Handler) respondUser(c *gin.Context, status int, id uuid.UUID, defs map[string]string) {
+	u, err := h.userService.GetUser(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	respond(c, status, userResource(baseURL(c), u, u.Groups, defs))
+}
+
+// ListUsers lists users, optionally filtered, a page at a time. Filters
+// that userFilter translates are answered by the database; others are
+// matched against every user.
+func (h *Handler) ListUsers(c *gin.Context) {
+	ctx := c.Request.Context()
+	q, err := parseListQuery(c)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	defs, err := h.enterpriseDefinitions(ctx)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	var f domain.UserFilter
+	offset, limit := q.start-1, q.count
+	translated := q.filter == nil
+	if !translated {
+		f, translated = userFilter(q.filter)
+	}
+	if !translated {
+		offset, limit = 0, -1
+	}
+	users, total, err := h.userService.FindUsers(ctx, f, offset, limit)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	base := baseURL(c)
+	resources := make([]map[string]any, len(users))
+	for i, u := range users {
+		resources[i] = userResource(base, u, u.Groups, defs)
+	}
+	if translated {
+		q.write(c, total, resources)
+		return
+	}
+	list(c, resources)
+}
+
+// userFilter translates a filter made only of eq comparisons of the id,
+// userName, displayName or email address joined with and, such as
+// userName eq "bjensen", to a UserFilter. For any other filter ok is false.
+func userFilter(flt *filter.Filter) (f domain.UserFilter, ok bool) {
+	var collect func(flt *filter.Filter) bool
+	collect = func(flt *filter.Filter) bool {
+		switch flt.Type {
+		case filter.FilterAnd:
+			for _, c := range flt.Children {
+				if !collect(c) {
+					return false
+				}
+			}
+			return true
+		case filter.FilterCompare:
+			v, isString := flt.Value.(string)
+			if flt.Op != filter.OpEqual || !isString || v == "" ||
+				(flt.Attr.URN != "" && !strings.EqualFold(flt.Attr.URN, SchemaUser)) {
+				return false
+			}
+			var field *string
+			switch name, sub := strings.ToLower(flt.Attr.Name), strings.ToLower(flt.Attr.Sub); {
+			case name == "id" && sub == "":
+				id, err := uuid.Parse(v)
+				if err != nil || id.String() != strings.ToLower(v) || f.IDs != nil {
+					return false
+				}
+				f.IDs = []uuid.UUID{id}
+				return true
+			case name == "username" && sub == "":
+				field = &f.Username
+			case name == "displayname" && sub == "":
+				field = &f.DisplayName
+			case name == "emails" && (sub == "" || sub == "value"):
+				field = &f.Email
+			default:
+				return false
+			}
+			if *field != "" {
+				return false
+			}
+			*field = v
+			return true
+		default:
+			return false
+		}
+	}
+	if !collect(flt) {
+		return domain.UserFilter{}, false
+	}
+	return f, true
+}
+
+// GetUser returns a user.
+func (h *Handler) GetUser(c *gin.Context) {
+	_, res, _, err := h.loadUser(c)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	respondGet(c, res)
+}
+
+// CreateUser creates a user. Without a password, the user can log in only
+// after a password reset.
+func (h *Handler) CreateUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	var body map[string]any
+	if err := decode(c, &body); err != nil {
+		fail(c, err)
+		return
+	}
+	defs, err := h.enterpriseDefinitions(ctx)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	f, err := parseUser(body, defs)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	if err := h.checkUserUnique(ctx, uuid.Nil, f.username, f.email); err != nil {
+		fail(c, err)
+		return
+	}
+	// Check the manager before creating the user, who is set up in steps.
+	if f.managerID != nil {
+		if _, err := h.userService.GetUser(ctx, *f.managerID); err != nil {
+			fail(c, badRequest("invalidValue", "manager %s is not a user", *f.managerID))
+			return
+		}
+	}
+
+	attrs := make(map[string][]string)
+	for name, values := range f.attributes {
+		if len(values) > 0 {
+			attrs[name] = values
+		}
+	}
+	u, err := h.userService.CreateUser(ctx, domain.CreateUserInput{
+		Username:    f.username,
+		DisplayName: f.displayName,
+		Email:       f.email,
+		Password:    f.password,
+		NoPassword:  f.password == "",
+		Phone:       f.phone,
+		Attributes:  attrs,
+	})
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	f.password = ""
+	if err := h.applyUser(ctx, u, f); err != nil {
+		// Do not leave a user the client was told was not created.
+		if derr := h.userService.DeleteUser(ctx, u.ID); derr != nil {
+			err = errors.Join(err, derr)
+		}
+		fail(c, err)
+		return
+	}
+	h.respondUser(c, http.StatusCreated, u.ID, defs)
+}
+
+// ReplaceUser replaces the attributes of a user.
+func (h *Handler) ReplaceUser(c *gin.Context) {
+	u, res, defs, err := h.loadUser(c)
+	if err == nil {
+		err = checkPrecondition(c, res)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	var body map[string]any
+	if err := decode(c, &body); err != nil {
+		fail(c, err)
+		return
+	}
+	f, err := parseUser(body, defs)
+	if err == nil {
+		err = h.applyUser(c.Request.Context(), u, f)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	h.respondUser(c, http.StatusOK, u.ID, defs)
+}
+
+type patchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []patch.Operation `json:"Operations"`
+}
+
+// decodePatch reads a PATCH request.
+func decodePatch(c *gin.Context) ([]patch.Operation, error) {
+	var req patchRequest
+	if err := decode(c, &req); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(req.Schemas, SchemaPatchOp) {
+		return nil, badRequest("invalidSyntax", "schemas must contain %s", SchemaPatchOp)
+	}
+	return req.Operations, nil
+}
+
+// PatchUser applies PATCH operations to a user.
+func (h *Handler) PatchUser(c *gin.Context) {
+	u, res, defs, err := h.loadUser(c)
+	if err == nil {
+		err = checkPrecondition(c, res)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	ops, err := decodePatch(c)
+	if err == nil {
+		err = patch.Apply(res, ops, userPatchSchema)
+	}
+	var f *userFields
+	if err == nil {
+		f, err = parseUser(res, defs)
+	}
+	if err == nil {
+		err = h.applyUser(c.Request.Context(), u, f)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	h.respondUser(c, http.StatusOK, u.ID, defs)
+}
+
+// DeleteUser deletes a user.
+func (h *Handler) DeleteUser(c *gin.Context) {
+	u, res, _, err := h.loadUser(c)
+	if err == nil {
+		err = checkPrecondition(c, res)
+	}
+	if err == nil {
+		err = h.userService.DeleteUser(c.Request.Context(), u.ID)
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -2,6 +2,14 @@
 // Active Directory modes.
 package attrs
 
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
 const (
 	// ModeOpenLDAP indicates OpenLDAP attribute mapping.
 	ModeOpenLDAP = "openldap"
@@ -96,8 +104,94 @@ func (m *Mapper) GroupToLDAPAttrs(name, description string, memberDNs []string)
 	return attrs
 }
 
+// GroupOwnerAttribute returns the attribute that lists the DNs of a
+// group's owners: "managedBy" in Active Directory, "owner" otherwise.
+func (m *Mapper) GroupOwnerAttribute() string {
+	if m.mode == ModeActiveDirectory {
+		return "managedBy"
+	}
+	return "owner"
+}
+
+// IsReservedAttribute reports whether name is an attribute produced by the
+// built-in mapping in either mode. Extension attributes must not shadow these.
+func IsReservedAttribute(name string) bool {
+	for _, r := range reservedAttrs {
+		if strings.EqualFold(r, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// GeneralizedTime formats t as an LDAP GeneralizedTime in UTC, the syntax
+// of OpenLDAP operational timestamps such as pwdAccountLockedTime.
+func GeneralizedTime(t time.Time) string {
+	return t.UTC().Format("20060102150405Z")
+}
+
+// FileTime formats t as a Windows FILETIME, the number of 100-nanosecond
+// intervals since January 1, 1601 UTC, used by Active Directory timestamps
+// such as lockoutTime.
+func FileTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano()/100+fileTimeUnixEpoch, 10)
+}
+
+// FileTimeNever is the Active Directory accountExpires value of an account
+// that never expires.
+const FileTimeNever = "9223372036854775807"
+
+// ParseFileTime parses a Windows FILETIME, as formatted by FileTime.
+func ParseFileTime(s string) (time.Time, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, (n-fileTimeUnixEpoch)*100).UTC(), nil
+}
+
+// EpochDays formats t as the number of whole days since January 1, 1970
+// UTC, the syntax of shadowAccount attributes such as shadowLastChange.
+func EpochDays(t time.Time) string {
+	return strconv.FormatInt(t.Unix()/(24*60*60), 10)
+}
+
+// ParseEpochDays parses a number of days since January 1, 1970 UTC, as
+// formatted by EpochDays.
+func ParseEpochDays(s string) (time.Time, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(n*24*60*60, 0).UTC(), nil
+}
+
+// ObjectGUID returns the binary Active Directory objectGUID of an entry
+// with the given ID. The first three fields of a GUID are little-endian.
+func ObjectGUID(id uuid.UUID) string {
+	b := make([]byte, 16)
+	b[0], b[1], b[2], b[3] = id[3], id[2], id[1], id[0]
+	b[4], b[5] = id[5], id[4]
+	b[6], b[7] = id[7], id[6]
+	copy(b[8:], id[8:])
+	return string(b)
+}
+
 // --- internal helpers ---
 
+// fileTimeUnixEpoch is the Unix epoch as a Windows FILETIME.
+const fileTimeUnixEpoch = 116444736000000000
+
+// reservedAttrs lists attribute names emitted by the mapper and LDAP handler.
+var reservedAttrs = []string{
+	"dn", "objectClass", "cn", "sn", "uid", "displayName", "mail",
+	"telephoneNumber", "status", "sAMAccountName", "userAccountControl",
+	"description", "member", "memberOf", "manager", "directReports",
+	"owner", "managedBy", "pwdAccountLockedTime", "lockoutTime", "badPwdCount",
+	"pwdChangedTime", "shadowLastChange", "shadowExpire", "pwdLastSet",
+	"accountExpires", "entryUUID", "objectGUID",
+}
+
 // openLDAPAttrMap maps OpenLDAP attribute names to DB column names.
 var openLDAPAttrMap = map[string]string{
 	"uid":             "username",
@@ -119,10 +213,10 @@ var adAttrMap = map[string]string{
 }
 
 // adAccountControl converts a simple status string to an AD
-// userAccountControl value. "active" yields 512 (normal account);
+// userAccountControl value. "enabled" yields 512 (normal account);
 // anything else yields 514 (disabled).
 func adAccountControl(status string) string {
-	if status == "active" {
+	if status == "enabled" {
 		return "512"
 	}
 	return "514"
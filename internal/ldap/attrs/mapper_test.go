@@ -2,6 +2,7 @@ package attrs
 
 import (
 	"testing"
+	"time"
 )
 
 func TestMapAttribute(t *testing.T) {
@@ -99,6 +100,24 @@ func TestGroupObjectClasses(t *testing.T) {
 	}
 }
 
+func TestGroupOwnerAttribute(t *testing.T) {
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{mode: ModeOpenLDAP, want: "owner"},
+		{mode: ModeActiveDirectory, want: "managedBy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			if got := NewMapper(tt.mode).GroupOwnerAttribute(); got != tt.want {
+				t.Errorf("GroupOwnerAttribute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestUserToLDAPAttrs(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -117,7 +136,7 @@ func TestUserToLDAPAttrs(t *testing.T) {
 			displayName: "John Doe",
 			email:       "jdoe@example.com",
 			phone:       "+1-555-0100",
-			status:      "active",
+			status:      "enabled",
 			wantKeys:    []string{"objectClass", "cn", "displayName", "uid", "sn", "status", "mail", "telephoneNumber"},
 		},
 		{
@@ -127,7 +146,7 @@ func TestUserToLDAPAttrs(t *testing.T) {
 			displayName: "John Doe",
 			email:       "jdoe@example.com",
 			phone:       "+1-555-0100",
-			status:      "active",
+			status:      "enabled",
 			wantKeys:    []string{"objectClass", "cn", "displayName", "sAMAccountName", "userAccountControl", "mail", "telephoneNumber"},
 		},
 		{
@@ -137,7 +156,7 @@ func TestUserToLDAPAttrs(t *testing.T) {
 			displayName: "John Doe",
 			email:       "",
 			phone:       "",
-			status:      "active",
+			status:      "enabled",
 			wantKeys:    []string{"objectClass", "cn", "displayName", "uid", "sn", "status"},
 		},
 		{
@@ -182,7 +201,7 @@ func TestUserToLDAPAttrs(t *testing.T) {
 				if got["sAMAccountName"][0] != tt.username {
 					t.Errorf("sAMAccountName = %q, want %q", got["sAMAccountName"][0], tt.username)
 				}
-				if tt.status == "active" && got["userAccountControl"][0] != "512" {
+				if tt.status == "enabled" && got["userAccountControl"][0] != "512" {
 					t.Errorf("userAccountControl = %q, want %q", got["userAccountControl"][0], "512")
 				}
 				if tt.status == "disabled" && got["userAccountControl"][0] != "514" {
@@ -286,3 +305,47 @@ func assertStringSliceEqual(t *testing.T, got, want []string) {
 		}
 	}
 }
+
+func TestTimestamps(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 8, 30, 0, 0, time.FixedZone("CST", 8*3600))
+
+	if got, want := GeneralizedTime(ts), "20240301003000Z"; got != want {
+		t.Errorf("GeneralizedTime = %q, want %q", got, want)
+	}
+	if got, want := FileTime(ts), "133537266000000000"; got != want {
+		t.Errorf("FileTime = %q, want %q", got, want)
+	}
+	if got, want := FileTime(time.Unix(0, 0)), "116444736000000000"; got != want {
+		t.Errorf("FileTime(Unix epoch) = %q, want %q", got, want)
+	}
+	if got, err := ParseFileTime(FileTime(ts)); err != nil || !got.Equal(ts) {
+		t.Errorf("ParseFileTime = %v, %v, want %v", got, err, ts)
+	}
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if got, err := ParseEpochDays(EpochDays(ts)); err != nil || !got.Equal(day) {
+		t.Errorf("ParseEpochDays = %v, %v, want %v", got, err, day)
+	}
+}
+
+func TestIsReservedAttribute(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "uid", want: true},
+		{name: "MAIL", want: true},
+		{name: "sAMAccountName", want: true},
+		{name: "memberOf", want: true},
+		{name: "manager", want: true},
+		{name: "department", want: false},
+		{name: "employeeNumber", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsReservedAttribute(tt.name); got != tt.want {
+				t.Errorf("IsReservedAttribute(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
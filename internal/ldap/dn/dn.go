@@ -42,6 +42,13 @@ func BuildGroupDN(groupName, baseDN, mode string) string {
 	return "cn=" + escapeRDNValue(groupName) + "," + groupContainer(mode) + "," + baseDN
 }
 
+// BuildServiceAccountDN builds a service account DN based on LDAP mode.
+// OpenLDAP: cn=<name>,ou=services,<baseDN>
+// AD: cn=<name>,cn=Services,<baseDN>
+func BuildServiceAccountDN(name, baseDN, mode string) string {
+	return "cn=" + escapeRDNValue(name) + "," + serviceAccountContainer(mode) + "," + baseDN
+}
+
 // ParseDN parses a DN string into RDN components.
 func ParseDN(dn string) ([]RDN, error) {
 	if dn == "" {
@@ -106,6 +113,23 @@ func ExtractUsername(dn, baseDN, mode string) (string, error) {
 	return first.Value, nil
 }
 
+// ExtractServiceAccountName extracts the service account name from a
+// service account DN.
+func ExtractServiceAccountName(dn, baseDN, mode string) (string, error) {
+	if !IsServiceAccountDN(dn, baseDN, mode) {
+		return "", fmt.Errorf("dn: %q is not a valid service account DN for mode %q: %w", dn, mode, errNotServiceAccountDN)
+	}
+
+	rdns, err := ParseDN(dn)
+	if err != nil {
+		return "", fmt.Errorf("dn: extracting service account name: %w", err)
+	}
+	if !strings.EqualFold(rdns[0].Type, "cn") {
+		return "", fmt.Errorf("dn: expected cn= in service account DN, got %q: %w", rdns[0].Type, errNotServiceAccountDN)
+	}
+	return rdns[0].Value, nil
+}
+
 // IsUserDN checks if a DN is a user DN.
 func IsUserDN(dn, baseDN, mode string) bool {
 	suffix := "," + UserBaseDN(baseDN, mode)
@@ -118,6 +142,12 @@ func IsGroupDN(dn, baseDN, mode string) bool {
 	return strings.HasSuffix(strings.ToLower(dn), strings.ToLower(suffix))
 }
 
+// IsServiceAccountDN checks if a DN is a service account DN.
+func IsServiceAccountDN(dn, baseDN, mode string) bool {
+	suffix := "," + ServiceAccountBaseDN(baseDN, mode)
+	return strings.HasSuffix(strings.ToLower(dn), strings.ToLower(suffix))
+}
+
 // UserBaseDN returns the base DN for users.
 // OpenLDAP: ou=users,<baseDN>
 // AD: cn=Users,<baseDN>
@@ -132,12 +162,21 @@ func GroupBaseDN(baseDN, mode string) string {
 	return groupContainer(mode) + "," + baseDN
 }
 
+// ServiceAccountBaseDN returns the base DN for service accounts.
+// OpenLDAP: ou=services,<baseDN>
+// AD: cn=Services,<baseDN>
+func ServiceAccountBaseDN(baseDN, mode string) string {
+	return serviceAccountContainer(mode) + "," + baseDN
+}
+
 // --- internal helpers ---
 
 var (
 	errEmptyDN      = fmt.Errorf("empty DN")
 	errMalformedRDN = fmt.Errorf("malformed RDN")
 	errNotUserDN    = fmt.Errorf("not a user DN")
+
+	errNotServiceAccountDN = fmt.Errorf("not a service account DN")
 )
 
 func userContainer(mode string) string {
@@ -154,6 +193,13 @@ func groupContainer(mode string) string {
 	return "ou=groups"
 }
 
+func serviceAccountContainer(mode string) string {
+	if mode == ModeActiveDirectory {
+		return "cn=Services"
+	}
+	return "ou=services"
+}
+
 // splitDN splits a DN string on commas while respecting escaped commas.
 func splitDN(dn string) []string {
 	var parts []string
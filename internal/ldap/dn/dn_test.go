@@ -375,3 +375,33 @@ func TestGroupBaseDN(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceAccountDN(t *testing.T) {
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{ModeOpenLDAP, "cn=hr-sync,ou=services,dc=example,dc=com"},
+		{ModeActiveDirectory, "cn=hr-sync,cn=Services,dc=example,dc=com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			got := BuildServiceAccountDN("hr-sync", testBaseDN, tt.mode)
+			if got != tt.want {
+				t.Fatalf("BuildServiceAccountDN() = %q, want %q", got, tt.want)
+			}
+			if IsUserDN(got, testBaseDN, tt.mode) {
+				t.Error("service account DN must not be a user DN")
+			}
+			name, err := ExtractServiceAccountName(got, testBaseDN, tt.mode)
+			if err != nil || name != "hr-sync" {
+				t.Errorf("ExtractServiceAccountName() = %q, %v, want %q", name, err, "hr-sync")
+			}
+		})
+	}
+
+	if _, err := ExtractServiceAccountName("uid=jdoe,ou=users,"+testBaseDN, testBaseDN, ModeOpenLDAP); err == nil {
+		t.Error("expected error for a user DN")
+	}
+}
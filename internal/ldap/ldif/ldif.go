@@ -0,0 +1,76 @@
+// Package ldif reads and writes the LDAP Data Interchange Format (RFC 2849),
+// both content records, which describe entries, and change records, which
+// add, delete, modify and rename them.
+package ldif
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Change types of change records.
+const (
+	ChangeAdd    = "add"
+	ChangeDelete = "delete"
+	ChangeModify = "modify"
+	ChangeModRDN = "modrdn"
+)
+
+// Operations of the modifications of a modify change record.
+const (
+	ModAdd     = "add"
+	ModDelete  = "delete"
+	ModReplace = "replace"
+)
+
+// Attribute is an attribute of an entry with its values. Name is the
+// attribute description as written, options included.
+type Attribute struct {
+	Name   string
+	Values []string
+}
+
+// Modification is a change to one attribute of a modify change record.
+// Deleting an attribute without values deletes all of its values, and
+// replacing it without values deletes it.
+type Modification struct {
+	Op string
+	Attribute
+}
+
+// Record is a content record, which has no ChangeType and lists the
+// attributes of an entry, or a change record. Attributes is set for content
+// records and add change records, Modifications for modify change records,
+// and NewRDN, DeleteOldRDN and NewSuperior for modrdn change records. Line
+// is the number of the line the record starts on.
+type Record struct {
+	Line          int
+	DN            string
+	ChangeType    string
+	Attributes    []Attribute
+	Modifications []Modification
+	NewRDN        string
+	DeleteOldRDN  bool
+	NewSuperior   string
+}
+
+// Values returns the values of an attribute of a content or add record.
+// Attribute names are case-insensitive.
+func (r *Record) Values(name string) []string {
+	for _, a := range r.Attributes {
+		if strings.EqualFold(a.Name, name) {
+			return a.Values
+		}
+	}
+	return nil
+}
+
+// Error is a syntax error in LDIF input.
+type Error struct {
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("ldif: line %d: %s", e.Line, e.Msg)
+}
@@ -0,0 +1,290 @@
+package ldif
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// line is a logical line of LDIF input, with continuation lines unfolded.
+type line struct {
+	num  int
+	text string
+}
+
+// Parse reads all records of an LDIF file. The version line is optional;
+// URL values (attr:< url) and controls are not supported.
+func Parse(r io.Reader) ([]*Record, error) {
+	blocks, err := readBlocks(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) > 0 && hasName(blocks[0][0].text, "version") {
+		first := blocks[0][0]
+		name, value, err := parseLine(first)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(name, "version") || value != "1" {
+			return nil, &Error{Line: first.num, Msg: fmt.Sprintf("unsupported version %q", value)}
+		}
+		if blocks[0] = blocks[0][1:]; len(blocks[0]) == 0 {
+			blocks = blocks[1:]
+		}
+	}
+
+	records := make([]*Record, 0, len(blocks))
+	for _, b := range blocks {
+		rec, err := parseRecord(b)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// readBlocks splits LDIF input into the logical lines of its records, which
+// are separated by empty lines. Comments are dropped.
+func readBlocks(r io.Reader) ([][]line, error) {
+	br := bufio.NewReader(r)
+	var blocks [][]line
+	var block []line
+	comment := false
+	for num := 1; ; num++ {
+		text, err := br.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if text == "" && err != nil {
+			break
+		}
+		text = strings.TrimSuffix(strings.TrimSuffix(text, "\n"), "\r")
+		switch {
+		case strings.HasPrefix(text, " "):
+			if comment {
+				break
+			}
+			if len(block) == 0 {
+				return nil, &Error{Line: num, Msg: "continuation line without a line to continue"}
+			}
+			block[len(block)-1].text += text[1:]
+		case strings.HasPrefix(text, "#"):
+			comment = true
+		case text == "":
+			comment = false
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+		default:
+			comment = false
+			block = append(block, line{num: num, text: text})
+		}
+		if err != nil {
+			break
+		}
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// parseRecord parses the logical lines of a record.
+func parseRecord(lines []line) (*Record, error) {
+	name, dn, err := parseLine(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(name, "dn") {
+		return nil, &Error{Line: lines[0].num, Msg: fmt.Sprintf("record starts with %s instead of dn", name)}
+	}
+	rec := &Record{Line: lines[0].num, DN: dn}
+	lines = lines[1:]
+
+	if len(lines) > 0 && hasName(lines[0].text, "control") {
+		return nil, &Error{Line: lines[0].num, Msg: "controls are not supported"}
+	}
+	if len(lines) == 0 || !hasName(lines[0].text, "changetype") {
+		if len(lines) == 0 {
+			return nil, &Error{Line: rec.Line, Msg: "content record without attributes"}
+		}
+		rec.Attributes, err = parseAttributes(lines)
+		return rec, err
+	}
+
+	_, changeType, err := parseLine(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	start := lines[0].num
+	lines = lines[1:]
+	switch rec.ChangeType = strings.ToLower(changeType); rec.ChangeType {
+	case ChangeAdd:
+		if len(lines) == 0 {
+			return nil, &Error{Line: start, Msg: "add record without attributes"}
+		}
+		rec.Attributes, err = parseAttributes(lines)
+	case ChangeDelete:
+		if len(lines) > 0 {
+			return nil, &Error{Line: lines[0].num, Msg: "delete record with attributes"}
+		}
+	case ChangeModify:
+		rec.Modifications, err = parseModifications(start, lines)
+	case ChangeModRDN, "moddn":
+		rec.ChangeType = ChangeModRDN
+		err = parseModRDN(rec, start, lines)
+	default:
+		return nil, &Error{Line: start, Msg: fmt.Sprintf("unknown changetype %q", changeType)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// parseAttributes parses the attribute lines of a content or add record.
+// Values of the same attribute are collected in the order they appear.
+func parseAttributes(lines []line) ([]Attribute, error) {
+	var attrs []Attribute
+	for _, l := range lines {
+		name, value, err := parseLine(l)
+		if err != nil {
+			return nil, err
+		}
+		attrs = appendValue(attrs, name, value)
+	}
+	return attrs, nil
+}
+
+func appendValue(attrs []Attribute, name, value string) []Attribute {
+	for i := range attrs {
+		if strings.EqualFold(attrs[i].Name, name) {
+			attrs[i].Values = append(attrs[i].Values, value)
+			return attrs
+		}
+	}
+	return append(attrs, Attribute{Name: name, Values: []string{value}})
+}
+
+// parseModifications parses the modifications of a modify record, each an
+// operation line naming the attribute, its values and a "-" line.
+func parseModifications(start int, lines []line) ([]Modification, error) {
+	var mods []Modification
+	for len(lines) > 0 {
+		opLine := lines[0].num
+		op, attr, err := parseLine(lines[0])
+		if err != nil {
+			return nil, err
+		}
+		op = strings.ToLower(op)
+		if op != ModAdd && op != ModDelete && op != ModReplace {
+			return nil, &Error{Line: opLine, Msg: fmt.Sprintf("unsupported modification %q", op)}
+		}
+		mod := Modification{Op: op, Attribute: Attribute{Name: attr}}
+		lines = lines[1:]
+		for len(lines) > 0 && lines[0].text != "-" {
+			name, value, err := parseLine(lines[0])
+			if err != nil {
+				return nil, err
+			}
+			if !strings.EqualFold(name, attr) {
+				return nil, &Error{Line: lines[0].num, Msg: fmt.Sprintf("value of %s in a modification of %s", name, attr)}
+			}
+			mod.Values = append(mod.Values, value)
+			lines = lines[1:]
+		}
+		if len(lines) > 0 {
+			lines = lines[1:] // "-"
+		}
+		if op == ModAdd && len(mod.Values) == 0 {
+			return nil, &Error{Line: opLine, Msg: fmt.Sprintf("add of %s without values", attr)}
+		}
+		mods = append(mods, mod)
+	}
+	if len(mods) == 0 {
+		return nil, &Error{Line: start, Msg: "modify record without modifications"}
+	}
+	return mods, nil
+}
+
+// parseModRDN parses the lines of a modrdn record.
+func parseModRDN(rec *Record, start int, lines []line) error {
+	seen := make(map[string]bool)
+	for _, l := range lines {
+		name, value, err := parseLine(l)
+		if err != nil {
+			return err
+		}
+		name = strings.ToLower(name)
+		if seen[name] {
+			return &Error{Line: l.num, Msg: "duplicate " + name}
+		}
+		seen[name] = true
+		switch name {
+		case "newrdn":
+			rec.NewRDN = value
+		case "deleteoldrdn":
+			if value != "0" && value != "1" {
+				return &Error{Line: l.num, Msg: fmt.Sprintf("deleteoldrdn is %q instead of 0 or 1", value)}
+			}
+			rec.DeleteOldRDN = value == "1"
+		case "newsuperior":
+			rec.NewSuperior = value
+		default:
+			return &Error{Line: l.num, Msg: fmt.Sprintf("unexpected %s in a modrdn record", name)}
+		}
+	}
+	if !seen["newrdn"] || !seen["deleteoldrdn"] {
+		return &Error{Line: start, Msg: "modrdn record without newrdn and deleteoldrdn"}
+	}
+	return nil
+}
+
+// parseLine splits a logical line into an attribute description and its
+// value, decoding base64 values.
+func parseLine(l line) (name, value string, err error) {
+	name, value, ok := strings.Cut(l.text, ":")
+	if !ok {
+		return "", "", &Error{Line: l.num, Msg: fmt.Sprintf("missing colon in %q", l.text)}
+	}
+	if !validName(name) {
+		return "", "", &Error{Line: l.num, Msg: fmt.Sprintf("invalid attribute description %q", name)}
+	}
+	switch {
+	case strings.HasPrefix(value, ":"):
+		b, err := base64.StdEncoding.DecodeString(strings.TrimLeft(value[1:], " "))
+		if err != nil {
+			return "", "", &Error{Line: l.num, Msg: fmt.Sprintf("invalid base64 value of %s", name)}
+		}
+		return name, string(b), nil
+	case strings.HasPrefix(value, "<"):
+		return "", "", &Error{Line: l.num, Msg: fmt.Sprintf("URL value of %s is not supported", name)}
+	default:
+		return name, strings.TrimLeft(value, " "), nil
+	}
+}
+
+// validName reports whether s is an attribute description: letters, digits
+// and hyphens, with options separated by semicolons, or a numeric OID.
+func validName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == ';' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// hasName reports whether a logical line is a value of the named attribute.
+func hasName(text, name string) bool {
+	n, _, ok := strings.Cut(text, ":")
+	return ok && strings.EqualFold(n, name)
+}
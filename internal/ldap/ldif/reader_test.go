@@ -0,0 +1,119 @@
+package ldif
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	input := "version: 1\r\n" +
+		"# a comment\r\n" +
+		"#  continued\r\n" +
+		"dn: uid=jdoe,ou=users,dc=example,dc=com\r\n" +
+		"objectClass: top\r\n" +
+		"objectClass: inetOrgPerson\r\n" +
+		"cn: John\r\n" +
+		"  Doe\r\n" +
+		"description:: w6lsw6h2ZQ==\r\n" +
+		"mail:jdoe@example.com\r\n" +
+		"\r\n" +
+		"\r\n" +
+		"dn: cn=admins,ou=groups,dc=example,dc=com\n" +
+		"changetype: modify\n" +
+		"add: member\n" +
+		"member: uid=jdoe,ou=users,dc=example,dc=com\n" +
+		"-\n" +
+		"delete: description\n" +
+		"-\n" +
+		"replace: owner\n" +
+		"\n" +
+		"dn: uid=old,ou=users,dc=example,dc=com\n" +
+		"changetype: delete\n" +
+		"\n" +
+		"dn: cn=admins,ou=groups,dc=example,dc=com\n" +
+		"changetype: moddn\n" +
+		"newrdn: cn=operators\n" +
+		"deleteoldrdn: 1\n"
+
+	got, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := []*Record{
+		{
+			Line: 4,
+			DN:   "uid=jdoe,ou=users,dc=example,dc=com",
+			Attributes: []Attribute{
+				{Name: "objectClass", Values: []string{"top", "inetOrgPerson"}},
+				{Name: "cn", Values: []string{"John Doe"}},
+				{Name: "description", Values: []string{"élève"}},
+				{Name: "mail", Values: []string{"jdoe@example.com"}},
+			},
+		},
+		{
+			Line:       13,
+			DN:         "cn=admins,ou=groups,dc=example,dc=com",
+			ChangeType: ChangeModify,
+			Modifications: []Modification{
+				{Op: ModAdd, Attribute: Attribute{Name: "member", Values: []string{"uid=jdoe,ou=users,dc=example,dc=com"}}},
+				{Op: ModDelete, Attribute: Attribute{Name: "description"}},
+				{Op: ModReplace, Attribute: Attribute{Name: "owner"}},
+			},
+		},
+		{Line: 22, DN: "uid=old,ou=users,dc=example,dc=com", ChangeType: ChangeDelete},
+		{
+			Line:         25,
+			DN:           "cn=admins,ou=groups,dc=example,dc=com",
+			ChangeType:   ChangeModRDN,
+			NewRDN:       "cn=operators",
+			DeleteOldRDN: true,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		for i := range got {
+			t.Logf("record %d: %+v", i, got[i])
+		}
+		t.Fatal("records differ")
+	}
+	if v := got[0].Values("MAIL"); len(v) != 1 || v[0] != "jdoe@example.com" {
+		t.Errorf("Values(MAIL) = %v", v)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int
+	}{
+		{"unsupported version", "version: 2\n\ndn: cn=a\ncn: a\n", 1},
+		{"no dn", "cn: a\n", 1},
+		{"missing colon", "dn: cn=a\ncn a\n", 2},
+		{"content without attributes", "dn: cn=a\n", 1},
+		{"invalid base64", "dn: cn=a\ncn:: !!\n", 2},
+		{"url value", "dn: cn=a\njpegPhoto:< file:///etc/passwd\n", 2},
+		{"control", "dn: cn=a\ncontrol: 1.2.840.113556.1.4.805 true\nchangetype: delete\n", 2},
+		{"unknown changetype", "dn: cn=a\nchangetype: move\n", 2},
+		{"delete with attributes", "dn: cn=a\nchangetype: delete\ncn: a\n", 3},
+		{"increment", "dn: cn=a\nchangetype: modify\nincrement: uidNumber\nuidNumber: 1\n-\n", 3},
+		{"foreign value", "dn: cn=a\nchangetype: modify\nreplace: cn\nsn: b\n-\n", 4},
+		{"add without values", "dn: cn=a\nchangetype: modify\nadd: member\n-\n", 3},
+		{"modify without modifications", "dn: cn=a\nchangetype: modify\n", 2},
+		{"modrdn without deleteoldrdn", "dn: cn=a\nchangetype: modrdn\nnewrdn: cn=b\n", 2},
+		{"leading continuation", " cn: a\n", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("err = %v, want *Error", err)
+			}
+			if e.Line != tt.line {
+				t.Errorf("line = %d, want %d (%v)", e.Line, tt.line, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package ldif
+
+import (
+	"encoding/base64"
+	"io"
+	"strings"
+)
+
+// lineWidth is the width at which lines are folded.
+const lineWidth = 76
+
+// Writer writes records as an LDIF file, starting with the version line.
+// Values that are not safe strings, such as binary values, are base64
+// encoded, and long lines are folded.
+type Writer struct {
+	w       io.Writer
+	started bool
+}
+
+// NewWriter returns a Writer that writes to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write writes a record.
+func (w *Writer) Write(r *Record) error {
+	var b strings.Builder
+	if !w.started {
+		b.WriteString("version: 1\n")
+		w.started = true
+	}
+	b.WriteString("\n")
+	writeLine(&b, "dn", r.DN)
+	if r.ChangeType != "" {
+		writeLine(&b, "changetype", r.ChangeType)
+	}
+	switch r.ChangeType {
+	case "", ChangeAdd:
+		for _, a := range r.Attributes {
+			for _, v := range a.Values {
+				writeLine(&b, a.Name, v)
+			}
+		}
+	case ChangeModify:
+		for _, m := range r.Modifications {
+			writeLine(&b, m.Op, m.Name)
+			for _, v := range m.Values {
+				writeLine(&b, m.Name, v)
+			}
+			b.WriteString("-\n")
+		}
+	case ChangeModRDN:
+		writeLine(&b, "newrdn", r.NewRDN)
+		deleteOldRDN := "0"
+		if r.DeleteOldRDN {
+			deleteOldRDN = "1"
+		}
+		writeLine(&b, "deleteoldrdn", deleteOldRDN)
+		if r.NewSuperior != "" {
+			writeLine(&b, "newsuperior", r.NewSuperior)
+		}
+	}
+	_, err := io.WriteString(w.w, b.String())
+	return err
+}
+
+// writeLine writes an attribute value line, folded.
+func writeLine(b *strings.Builder, name, value string) {
+	l := name + ": " + value
+	if !safeString(value) {
+		l = name + ":: " + base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	for len(l) > lineWidth {
+		b.WriteString(l[:lineWidth])
+		b.WriteString("\n ")
+		l = l[lineWidth:]
+	}
+	b.WriteString(l)
+	b.WriteString("\n")
+}
+
+// safeString reports whether a value can be written as is: it is ASCII
+// without NUL, CR and LF, does not start with a space, colon or "<", and
+// does not end with a space.
+func safeString(s string) bool {
+	if s == "" {
+		return true
+	}
+	if s[0] == ' ' || s[0] == ':' || s[0] == '<' || s[len(s)-1] == ' ' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c == 0 || c == '\n' || c == '\r' || c > 127 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package ldif
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	long := strings.Repeat("x", 100)
+	records := []*Record{
+		{
+			DN: "cn=Zoë,cn=Users,dc=example,dc=com",
+			Attributes: []Attribute{
+				{Name: "objectClass", Values: []string{"top", "user"}},
+				{Name: "objectGUID", Values: []string{"\x00\x01\xff"}},
+				{Name: "description", Values: []string{long, " leading space", ":colon", "<angle"}},
+			},
+		},
+		{
+			DN:         "cn=admins,cn=Groups,dc=example,dc=com",
+			ChangeType: ChangeModify,
+			Modifications: []Modification{
+				{Op: ModReplace, Attribute: Attribute{Name: "member", Values: []string{"cn=a", "cn=b"}}},
+				{Op: ModDelete, Attribute: Attribute{Name: "description"}},
+			},
+		},
+		{DN: "cn=old,cn=Groups,dc=example,dc=com", ChangeType: ChangeDelete},
+		{DN: "cn=x,cn=Groups,dc=example,dc=com", ChangeType: ChangeModRDN, NewRDN: "cn=y", NewSuperior: "cn=Groups,dc=example,dc=com"},
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, r := range records {
+		if err := w.Write(r); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "version: 1\n\ndn:: ") {
+		t.Errorf("output starts with %q", out[:20])
+	}
+	for _, l := range strings.Split(out, "\n") {
+		if len(l) > lineWidth {
+			t.Errorf("line longer than %d: %q", lineWidth, l)
+		}
+	}
+	if !strings.Contains(out, "\ndescription: "+long[:lineWidth-len("description: ")]+"\n ") {
+		t.Errorf("long value not folded:\n%s", out)
+	}
+
+	got, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	for i, r := range got {
+		r.Line = 0
+		if !reflect.DeepEqual(r, records[i]) {
+			t.Errorf("record %d = %+v, want %+v", i, r, records[i])
+		}
+	}
+}
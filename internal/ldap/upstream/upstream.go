@@ -0,0 +1,160 @@
+// Package upstream reads from upstream LDAP directories, such as the
+// OpenLDAP servers and Active Directory forests users are migrated from.
+package upstream
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+)
+
+// pageSize is the number of entries requested per page of a search.
+// Active Directory returns at most 1000 entries without paging.
+const pageSize = 500
+
+// Config is how to connect to an upstream directory.
+type Config struct {
+	URL                string // ldap:// or ldaps://
+	BindDN             string // anonymous if empty
+	BindPassword       string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+}
+
+// Validate checks that the URL is an ldap:// or ldaps:// URL.
+func (c Config) Validate() error {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" || u.Host == "" {
+		return fmt.Errorf("invalid url %q: must be ldap://host or ldaps://host", c.URL)
+	}
+	if c.StartTLS && u.Scheme == "ldaps" {
+		return errors.New("start_tls cannot be used with ldaps")
+	}
+	return nil
+}
+
+// ErrInvalidCredentials is returned by Authenticate when the directory
+// rejects the DN or password.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Conn is a bound connection to an upstream directory.
+type Conn struct {
+	conn *ldap.Conn
+}
+
+// Dial connects to the directory and binds as the configured DN.
+func Dial(c Config) (*Conn, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.InsecureSkipVerify}
+	if u, err := url.Parse(c.URL); err == nil {
+		tlsConfig.ServerName = u.Hostname()
+	}
+	conn, err := ldap.DialURL(c.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: c.Timeout}),
+		ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", c.URL, err)
+	}
+	if c.Timeout > 0 {
+		conn.SetTimeout(c.Timeout)
+	}
+	if c.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("starting TLS: %w", err)
+		}
+	}
+	if c.BindDN != "" {
+		if err := conn.Bind(c.BindDN, c.BindPassword); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("binding as %s: %w", c.BindDN, err)
+		}
+	}
+	return &Conn{conn: conn}, nil
+}
+
+// Authenticate verifies the password of a user of the directory by binding
+// as dn with it. Empty passwords are refused, since binding with one is an
+// unauthenticated bind, which directories accept for any DN.
+func Authenticate(c Config, dn, password string) error {
+	if password == "" {
+		return ErrInvalidCredentials
+	}
+	c.BindDN, c.BindPassword = dn, password
+	conn, err := Dial(c)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return ErrInvalidCredentials
+		}
+		return err
+	}
+	return conn.Close()
+}
+
+// Search returns the entries under baseDN matching filter, with the given
+// attributes, reading as many pages as the directory returns.
+func (c *Conn) Search(baseDN, filter string, attributes []string) ([]*ldap.Entry, error) {
+	req := ldap.NewSearchRequest(baseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0, 0, false, filter, attributes, nil)
+	res, err := c.conn.SearchWithPaging(req, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("searching %s for %s: %w", baseDN, filter, err)
+	}
+	return res.Entries, nil
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// Value returns the first value of an entry's attribute, matched
+// case-insensitively. Active Directory's binary objectGUID is formatted as
+// a GUID string, so that it can serve as an entry's identifier.
+func Value(e *ldap.Entry, attr string) string {
+	if strings.EqualFold(attr, "objectGUID") {
+		return GUID(e.GetEqualFoldRawAttributeValue(attr))
+	}
+	return e.GetEqualFoldAttributeValue(attr)
+}
+
+// Values returns all values of an entry's attribute, matched
+// case-insensitively.
+func Values(e *ldap.Entry, attr string) []string {
+	return e.GetEqualFoldAttributeValues(attr)
+}
+
+// GUID formats a binary Active Directory GUID, whose first three fields
+// are little-endian, in its usual string form; see attrs.ObjectGUID.
+// Values that are not 16 bytes long are returned as they are.
+func GUID(b []byte) string {
+	if len(b) != 16 {
+		return string(b)
+	}
+	var u uuid.UUID
+	u[0], u[1], u[2], u[3] = b[3], b[2], b[1], b[0]
+	u[4], u[5] = b[5], b[4]
+	u[6], u[7] = b[7], b[6]
+	copy(u[8:], b[8:])
+	return u.String()
+}
+
+// NormalizeDN returns a form of dn for comparing DNs case-insensitively,
+// or dn in lower case if it cannot be parsed.
+func NormalizeDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(dn)
+	}
+	return strings.ToLower(parsed.String())
+}
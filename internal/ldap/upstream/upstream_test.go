@@ -0,0 +1,62 @@
+package upstream
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/ldap/attrs"
+)
+
+func TestValue(t *testing.T) {
+	id := uuid.MustParse("01020304-0506-0708-090a-0b0c0d0e0f10")
+	e := ldap.NewEntry("cn=dev,dc=example,dc=com", map[string][]string{
+		"objectGUID": {attrs.ObjectGUID(id)},
+		"entryUUID":  {id.String()},
+		"mail":       {"dev@example.com", "dev2@example.com"},
+	})
+
+	if got := Value(e, "objectguid"); got != id.String() {
+		t.Errorf("objectGUID = %q, want %q", got, id)
+	}
+	if got := Value(e, "entryUUID"); got != id.String() {
+		t.Errorf("entryUUID = %q, want %q", got, id)
+	}
+	if got := Value(e, "MAIL"); got != "dev@example.com" {
+		t.Errorf("mail = %q, want the first value", got)
+	}
+	if got := Values(e, "mail"); len(got) != 2 {
+		t.Errorf("mail values = %q, want both", got)
+	}
+	if got := Value(e, "uid"); got != "" {
+		t.Errorf("uid = %q, want empty", got)
+	}
+}
+
+func TestNormalizeDN(t *testing.T) {
+	a := NormalizeDN("CN=Dev User,OU=People, DC=Example,DC=com")
+	b := NormalizeDN("cn=dev user,ou=people,dc=example,dc=com")
+	if a != b {
+		t.Errorf("NormalizeDN = %q and %q, want equal", a, b)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		cfg Config
+		ok  bool
+	}{
+		{Config{URL: "ldap://dc1.example.com:389"}, true},
+		{Config{URL: "ldaps://dc1.example.com"}, true},
+		{Config{URL: "ldap://dc1.example.com", StartTLS: true}, true},
+		{Config{URL: "ldaps://dc1.example.com", StartTLS: true}, false},
+		{Config{URL: "http://dc1.example.com"}, false},
+		{Config{URL: "dc1.example.com"}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err == nil) != tt.ok {
+			t.Errorf("Validate(%+v) = %v, want ok %v", tt.cfg, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Package mail sends notification emails through an SMTP server. Messages
+// are rendered from text templates that deployments can replace.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Sender delivers messages.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Ways of securing the connection to an SMTP server.
+const (
+	// TLSStartTLS upgrades a plain connection with STARTTLS, and fails if
+	// the server does not offer it.
+	TLSStartTLS = "starttls"
+	// TLSImplicit connects with TLS from the start ("SMTPS").
+	TLSImplicit = "tls"
+	// TLSNone sends in the clear, for relays on a trusted network.
+	TLSNone = "none"
+)
+
+// DefaultTimeout bounds a delivery when the SMTP sender sets no timeout.
+const DefaultTimeout = 30 * time.Second
+
+// SMTP sends messages through an SMTP server. Messages are sent with PLAIN
+// authentication when Username is set.
+type SMTP struct {
+	Host     string
+	Port     int // defaults to 587, 465 or 25 by TLS mode
+	Username string
+	Password string
+	From     string // sender address, e.g. "User Manager <noreply@example.com>"
+	TLS      string // TLSStartTLS (default), TLSImplicit or TLSNone
+	Timeout  time.Duration
+}
+
+// Validate checks that the sender is fully configured.
+func (s *SMTP) Validate() error {
+	if s.Host == "" {
+		return errors.New("smtp host is required")
+	}
+	if _, err := netmail.ParseAddress(s.From); err != nil {
+		return fmt.Errorf("invalid from address %q: %w", s.From, err)
+	}
+	switch s.tlsMode() {
+	case TLSStartTLS, TLSImplicit, TLSNone:
+	default:
+		return fmt.Errorf("unsupported smtp tls mode %q", s.TLS)
+	}
+	return nil
+}
+
+// Send delivers msg in a single SMTP session.
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	from, err := netmail.ParseAddress(s.From)
+	if err != nil {
+		return fmt.Errorf("invalid from address %q: %w", s.From, err)
+	}
+	to := make([]*netmail.Address, len(msg.To))
+	for i, addr := range msg.To {
+		if to[i], err = netmail.ParseAddress(addr); err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", addr, err)
+		}
+	}
+	if len(to) == 0 {
+		return errors.New("message has no recipients")
+	}
+	data, err := compose(from, to, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	c, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	if err := c.Mail(from.Address); err != nil {
+		return fmt.Errorf("smtp MAIL FROM: %w", err)
+	}
+	for _, addr := range to {
+		if err := c.Rcpt(addr.Address); err != nil {
+			return fmt.Errorf("smtp RCPT TO %s: %w", addr.Address, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("writing message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+	return c.Quit()
+}
+
+// dial connects and authenticates to the server, with every network
+// operation bounded by the context's deadline.
+func (s *SMTP) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.port()))
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to smtp server %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	tlsConfig := &tls.Config{ServerName: s.Host}
+	if s.tlsMode() == TLSImplicit {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	c, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("connecting to smtp server %s: %w", addr, err)
+	}
+
+	if s.tlsMode() == TLSStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			_ = c.Close()
+			return nil, fmt.Errorf("smtp server %s does not support STARTTLS", addr)
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("smtp STARTTLS: %w", err)
+		}
+	}
+	if s.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("smtp authentication: %w", err)
+		}
+	}
+	return c, nil
+}
+
+func (s *SMTP) tlsMode() string {
+	if s.TLS == "" {
+		return TLSStartTLS
+	}
+	return strings.ToLower(s.TLS)
+}
+
+func (s *SMTP) port() int {
+	if s.Port > 0 {
+		return s.Port
+	}
+	switch s.tlsMode() {
+	case TLSImplicit:
+		return 465
+	case TLSNone:
+		return 25
+	}
+	return 587
+}
+
+// compose formats msg as a MIME message with a quoted-printable UTF-8 body.
+// Header values are encoded, so a subject cannot inject headers.
+func compose(from *netmail.Address, to []*netmail.Address, msg Message, now time.Time) ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("generating message id: %w", err)
+	}
+	domain := from.Address[strings.LastIndexByte(from.Address, '@')+1:]
+
+	recipients := make([]string, len(to))
+	for i, addr := range to {
+		recipients[i] = addr.String()
+	}
+
+	var buf bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&buf, "%s: %s\r\n", k, v) }
+	header("From", from.String())
+	header("To", strings.Join(recipients, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("Message-ID", "<"+hex.EncodeToString(id)+"@"+domain+">")
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(msg.Body)); err != nil {
+		return nil, fmt.Errorf("encoding message body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("encoding message body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
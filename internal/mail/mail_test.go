@@ -0,0 +1,208 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// received is a message accepted by the SMTP stand-in.
+type received struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// startSMTP runs a minimal SMTP server on the loopback interface that
+// accepts every message and reports it on the returned channel. It offers
+// PLAIN authentication but no STARTTLS.
+func startSMTP(t *testing.T) (host string, port int, msgs <-chan received) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	ch := make(chan received, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, ch)
+		}
+	}()
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, ch
+}
+
+func serveSMTP(conn net.Conn, ch chan<- received) {
+	defer func() { _ = conn.Close() }()
+	tc := textproto.NewConn(conn)
+	var msg received
+	_ = tc.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := tc.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			_ = tc.PrintfLine("250-localhost")
+			_ = tc.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			msg.auth = strings.TrimPrefix(arg, "PLAIN ")
+			_ = tc.PrintfLine("235 authenticated")
+		case "MAIL":
+			msg.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			_ = tc.PrintfLine("250 ok")
+		case "RCPT":
+			msg.to = append(msg.to, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			_ = tc.PrintfLine("250 ok")
+		case "DATA":
+			_ = tc.PrintfLine("354 go ahead")
+			data, err := io.ReadAll(tc.DotReader())
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			ch <- msg
+			_ = tc.PrintfLine("250 queued")
+		case "QUIT":
+			_ = tc.PrintfLine("221 bye")
+			return
+		default:
+			_ = tc.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func TestSMTPSend(t *testing.T) {
+	host, port, msgs := startSMTP(t)
+	s := &SMTP{
+		Host:     host,
+		Port:     port,
+		Username: "mailer",
+		Password: "secret",
+		From:     "User Manager <noreply@example.com>",
+		TLS:      TLSNone,
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	body := "您好，\n\nhttps://example.com/reset-password?token=abc\n"
+	err := s.Send(context.Background(), Message{
+		To:      []string{"alice@example.com"},
+		Subject: "重置密码\r\nBcc: eve@example.com",
+		Body:    body,
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	msg := <-msgs
+	if msg.from != "noreply@example.com" || len(msg.to) != 1 || msg.to[0] != "alice@example.com" {
+		t.Errorf("envelope = %s -> %v", msg.from, msg.to)
+	}
+	if auth, _ := base64.StdEncoding.DecodeString(msg.auth); string(auth) != "\x00mailer\x00secret" {
+		t.Errorf("auth = %q", auth)
+	}
+
+	m, err := netmail.ReadMessage(bufio.NewReader(strings.NewReader(msg.data)))
+	if err != nil {
+		t.Fatalf("parsing message: %v", err)
+	}
+	if m.Header.Get("Bcc") != "" {
+		t.Error("subject injected a Bcc header")
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if err != nil || subject != "重置密码\r\nBcc: eve@example.com" {
+		t.Errorf("subject = %q, %v", subject, err)
+	}
+	if got := m.Header.Get("To"); got != "<alice@example.com>" {
+		t.Errorf("To = %q", got)
+	}
+	decoded, err := io.ReadAll(quotedprintable.NewReader(m.Body))
+	if err != nil {
+		t.Fatalf("decoding body: %v", err)
+	}
+	if got := strings.ReplaceAll(string(decoded), "\r\n", "\n"); got != body {
+		t.Errorf("body = %q, want %q", got, body)
+	}
+}
+
+func TestSMTPRequiresStartTLS(t *testing.T) {
+	host, port, _ := startSMTP(t)
+	s := &SMTP{Host: host, Port: port, From: "noreply@example.com"}
+	err := s.Send(context.Background(), Message{To: []string{"alice@example.com"}, Subject: "s", Body: "b"})
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Errorf("Send without STARTTLS: err = %v", err)
+	}
+}
+
+func TestSMTPValidate(t *testing.T) {
+	for _, s := range []*SMTP{
+		{From: "noreply@example.com"},
+		{Host: "smtp.example.com"},
+		{Host: "smtp.example.com", From: "noreply@example.com", TLS: "ssl"},
+	} {
+		if err := s.Validate(); err == nil {
+			t.Errorf("%+v: expected error", s)
+		}
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	data := struct {
+		DisplayName, Username, URL string
+		ExpiresInMinutes           int
+	}{"Alice", "alice", "https://example.com/reset-password?token=abc", 30}
+
+	builtin, err := LoadTemplates("")
+	if err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	msg, err := builtin.Render(TemplatePasswordReset, "alice@example.com", data)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if msg.Subject == "" || !strings.Contains(msg.Body, data.URL) || !strings.Contains(msg.Body, "30") {
+		t.Errorf("built-in message = %+v", msg)
+	}
+
+	dir := t.TempDir()
+	custom := `{{define "subject"}}Reset for {{.Username}}{{end}}{{define "body"}}Go to {{.URL}}{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "password_reset.tmpl"), []byte(custom), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	overridden, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatalf("LoadTemplates(%s): %v", dir, err)
+	}
+	msg, err = overridden.Render(TemplatePasswordReset, "alice@example.com", data)
+	if err != nil || msg.Subject != "Reset for alice" || msg.Body != "Go to "+data.URL+"\n" {
+		t.Errorf("custom message = %+v, %v", msg, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "password_reset.tmpl"), []byte(`{{define "subject"}}x{{end}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTemplates(dir); err == nil {
+		t.Error("template without body: expected error")
+	}
+}
@@ -0,0 +1,94 @@
+package mail
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Names of the built-in templates.
+const (
+	// TemplatePasswordReset is the email carrying a password reset link.
+	TemplatePasswordReset = "password_reset"
+	// TemplateInvitation is the email inviting a new user to activate their
+	// account.
+	TemplateInvitation = "invitation"
+)
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// Templates renders messages from named text templates. Each template file
+// defines a "subject" and a "body" template.
+type Templates struct {
+	sets map[string]*template.Template
+}
+
+// LoadTemplates loads the built-in templates. If dir is not empty, a file
+// <name>.tmpl in it replaces the built-in template of the same name.
+func LoadTemplates(dir string) (*Templates, error) {
+	t := &Templates{sets: make(map[string]*template.Template)}
+	entries, err := fs.ReadDir(builtinTemplates, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("reading built-in templates: %w", err)
+	}
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".tmpl")
+		text, err := fs.ReadFile(builtinTemplates, "templates/"+e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("reading built-in template %s: %w", name, err)
+		}
+		if dir != "" {
+			custom, err := os.ReadFile(filepath.Join(dir, e.Name()))
+			switch {
+			case err == nil:
+				text = custom
+			case !errors.Is(err, fs.ErrNotExist):
+				return nil, fmt.Errorf("reading template %s: %w", name, err)
+			}
+		}
+		if t.sets[name], err = parseTemplate(name, string(text)); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %w", name, err)
+	}
+	for _, part := range []string{"subject", "body"} {
+		if tmpl.Lookup(part) == nil {
+			return nil, fmt.Errorf("template %s does not define %q", name, part)
+		}
+	}
+	return tmpl, nil
+}
+
+// Render renders the named template with data into a message to the given
+// recipient. Surrounding whitespace is trimmed from subject and body.
+func (t *Templates) Render(name, to string, data any) (Message, error) {
+	tmpl, ok := t.sets[name]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown mail template %q", name)
+	}
+	var subject, body strings.Builder
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, fmt.Errorf("rendering %s subject: %w", name, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Message{}, fmt.Errorf("rendering %s body: %w", name, err)
+	}
+	return Message{
+		To:      []string{to},
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+	}, nil
+}
@@ -2,45 +2,142 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
+	"github.com/qinzj/claude-demo/internal/domain"
 	"github.com/qinzj/claude-demo/internal/service"
 )
 
-// JWTAuth returns a middleware that validates JWT tokens.
-func JWTAuth(authService *service.AuthService) gin.HandlerFunc {
+// Authenticate returns a middleware that accepts either a user's JWT access
+// token, whose session must still be active and user still enabled, or a
+// service account credential: an API key or an access token from the client
+// credentials grant.
+func Authenticate(authService *service.AuthService, saService *service.ServiceAccountService) gin.HandlerFunc {
+	return AuthenticateWith(authService, saService, abort)
+}
+
+// AuthenticateWith is like Authenticate but rejects requests through fail,
+// for APIs such as SCIM that have their own error format.
+func AuthenticateWith(authService *service.AuthService, saService *service.ServiceAccountService, fail func(c *gin.Context, status int, message string)) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"code":    -1,
-				"message": "missing authorization header",
-			})
+			fail(c, http.StatusUnauthorized, "missing authorization header")
 			return
 		}
 
 		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenStr == authHeader {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			fail(c, http.StatusUnauthorized, "invalid authorization format")
+			return
+		}
+
+		var actor *domain.Actor
+		if claims, err := authService.ValidateToken(c.Request.Context(), tokenStr); err == nil {
+			if userID, err := uuid.Parse(claims.UserID); err == nil {
+				c.Set("user_id", claims.UserID)
+				c.Set("username", claims.Username)
+				c.Set("session_id", claims.SessionID)
+				c.Set("permissions", claims.Permissions)
+				c.Set("password_change_required", claims.PasswordChangeRequired)
+				actor = &domain.Actor{UserID: userID, Name: claims.Username, Permissions: claims.Permissions}
+			}
+		} else if sa, err := saService.Authenticate(c.Request.Context(), tokenStr); err == nil {
+			c.Set("service_account_id", sa.ID.String())
+			c.Set("username", sa.Name)
+			c.Set("permissions", sa.Permissions)
+			actor = &domain.Actor{ServiceAccountID: sa.ID, Name: sa.Name, Permissions: sa.Permissions}
+		}
+		if actor == nil {
+			fail(c, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
+
+		c.Request = c.Request.WithContext(service.WithActor(c.Request.Context(), actor))
+		c.Next()
+	}
+}
+
+// RequireCurrentPassword returns a middleware that rejects requests from
+// users whose password has expired or was reset with a forced change,
+// except for the given route paths, such as the one for changing the
+// password. It must run after Authenticate.
+func RequireCurrentPassword(allowedPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("password_change_required") && !slices.Contains(allowedPaths, c.FullPath()) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
 				"code":    -1,
-				"message": "invalid authorization format",
+				"message": "password change required",
 			})
 			return
 		}
+		c.Next()
+	}
+}
 
-		claims, err := authService.ValidateToken(c.Request.Context(), tokenStr)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+// RequireUser returns a middleware that rejects service accounts, for
+// endpoints that only make sense for a human user such as /me. It must run
+// after Authenticate.
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor, ok := service.ActorFromContext(c.Request.Context())
+		if !ok || actor.IsServiceAccount() {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
 				"code":    -1,
-				"message": "invalid or expired token",
+				"message": "not available to service accounts",
 			})
 			return
 		}
+		c.Next()
+	}
+}
 
-		c.Set("user_id", claims.UserID)
-		c.Set("username", claims.Username)
+// RequirePermission returns a middleware that rejects requests whose actor
+// lacks the given permission. It must run after Authenticate.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor, ok := service.ActorFromContext(c.Request.Context())
+		if !ok || !actor.Can(permission) {
+			forbidden(c, permission)
+			return
+		}
 		c.Next()
 	}
 }
+
+// RequireSelfOrPermission is like RequirePermission but also admits the user
+// identified by the named path parameter, e.g. for changing one's own password.
+func RequireSelfOrPermission(param, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor, ok := service.ActorFromContext(c.Request.Context())
+		if !ok {
+			forbidden(c, permission)
+			return
+		}
+		id, err := uuid.Parse(c.Param(param))
+		self := err == nil && !actor.IsServiceAccount() && id == actor.UserID
+		if !self && !actor.Can(permission) {
+			forbidden(c, permission)
+			return
+		}
+		c.Next()
+	}
+}
+
+func forbidden(c *gin.Context, permission string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"code":    -1,
+		"message": "permission denied: requires " + permission,
+	})
+}
+
+func abort(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"code":    -1,
+		"message": message,
+	})
+}
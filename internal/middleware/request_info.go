@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+// RequestInfo returns a middleware that records the client IP address, user
+// agent and request ID in the request context for services, e.g. for session
+// metadata and the audit trail. It must run after Logger, which assigns the
+// request ID.
+func RequestInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		info := domain.RequestInfo{
+			Protocol:  domain.ProtocolHTTP,
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			RequestID: c.GetString("request_id"),
+		}
+		c.Request = c.Request.WithContext(service.WithRequestInfo(c.Request.Context(), info))
+		c.Next()
+	}
+}
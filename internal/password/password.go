@@ -0,0 +1,310 @@
+// Package password hashes passwords and verifies password hashes. Besides
+// the hashes it produces, it understands the schemes found in OpenLDAP
+// directories and other identity systems, so users can be imported with
+// their existing hashes and moved to the configured scheme as they log in.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrUnknownScheme is returned for a hash in a scheme no verifier is
+	// registered for.
+	ErrUnknownScheme = errors.New("unknown password hash scheme")
+	// ErrMalformedHash is returned for a hash that cannot be parsed.
+	ErrMalformedHash = errors.New("malformed password hash")
+)
+
+// Verifier reports whether password matches hash. The hash is passed with
+// the prefix the verifier was registered for.
+type Verifier func(hash, password string) (bool, error)
+
+var (
+	mu        sync.RWMutex
+	verifiers = map[string]Verifier{}
+)
+
+// Register makes v verify hashes that start with prefix, such as "{SSHA}"
+// or "$6$". Prefixes in braces are matched case-insensitively, as OpenLDAP
+// does. Registering a prefix again replaces its verifier.
+func Register(prefix string, v Verifier) {
+	mu.Lock()
+	defer mu.Unlock()
+	verifiers[normalizePrefix(prefix)] = v
+}
+
+// Supported reports whether a verifier is registered for the scheme of hash.
+func Supported(hash string) bool {
+	if inner, ok := unwrap(hash); ok {
+		return strings.HasPrefix(inner, "$") && Supported(inner)
+	}
+	_, ok := lookup(hash)
+	return ok
+}
+
+// Verify reports whether password matches hash, in any registered scheme.
+func Verify(hash, password string) (bool, error) {
+	v, ok := lookup(hash)
+	if !ok {
+		return false, ErrUnknownScheme
+	}
+	return v(hash, password)
+}
+
+// lookup returns the verifier with the longest prefix of hash.
+func lookup(hash string) (Verifier, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	var best Verifier
+	n := 0
+	for prefix, v := range verifiers {
+		if len(prefix) > n && len(hash) >= len(prefix) && normalizePrefix(hash[:len(prefix)]) == prefix {
+			best, n = v, len(prefix)
+		}
+	}
+	return best, best != nil
+}
+
+func normalizePrefix(p string) string {
+	if strings.HasPrefix(p, "{") {
+		return strings.ToUpper(p)
+	}
+	return p
+}
+
+// Algorithms a Hasher can hash new passwords with.
+const (
+	Bcrypt   = "bcrypt"
+	Argon2id = "argon2id"
+)
+
+// Default parameters of a Hasher.
+const (
+	DefaultAlgorithm         = Bcrypt
+	DefaultBcryptCost        = bcrypt.DefaultCost
+	DefaultArgon2Memory      = 64 * 1024 // KiB
+	DefaultArgon2Iterations  = 3
+	DefaultArgon2Parallelism = 2
+)
+
+const (
+	argon2SaltSize = 16
+	argon2KeySize  = 32
+)
+
+// Hasher hashes new passwords. The zero value hashes with bcrypt at the
+// default cost; unset parameters take the defaults above.
+type Hasher struct {
+	Algorithm         string
+	BcryptCost        int
+	Argon2Memory      uint32 // KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+}
+
+// Validate checks that the hasher's algorithm and parameters are usable.
+func (h Hasher) Validate() error {
+	switch h.algorithm() {
+	case Bcrypt:
+		if cost := h.bcryptCost(); cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+			return fmt.Errorf("bcrypt cost %d out of range [%d, %d]", cost, bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case Argon2id:
+	default:
+		return fmt.Errorf("unsupported password hash algorithm %q", h.Algorithm)
+	}
+	return nil
+}
+
+// Hash hashes a password with the configured algorithm.
+func (h Hasher) Hash(password string) (string, error) {
+	switch h.algorithm() {
+	case Bcrypt:
+		b, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost())
+		if err != nil {
+			return "", fmt.Errorf("hashing password: %w", err)
+		}
+		return string(b), nil
+	case Argon2id:
+		salt := make([]byte, argon2SaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return "", fmt.Errorf("generating salt: %w", err)
+		}
+		p := h.argon2Params()
+		key := argon2.IDKey([]byte(password), salt, p.iterations, p.memory, p.parallelism, argon2KeySize)
+		return p.format(salt, key), nil
+	}
+	return "", fmt.Errorf("unsupported password hash algorithm %q", h.Algorithm)
+}
+
+// NeedsRehash reports whether hash should be replaced by a new hash of the
+// same password: it is in another scheme than the configured one, or was
+// made with other parameters.
+func (h Hasher) NeedsRehash(hash string) bool {
+	switch h.algorithm() {
+	case Bcrypt:
+		if !isBcrypt(hash) {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || cost != h.bcryptCost()
+	case Argon2id:
+		p, _, _, err := parseArgon2(hash)
+		return err != nil || p.variant != Argon2id || p != h.argon2Params()
+	}
+	return false
+}
+
+func (h Hasher) algorithm() string {
+	if h.Algorithm == "" {
+		return DefaultAlgorithm
+	}
+	return strings.ToLower(h.Algorithm)
+}
+
+func (h Hasher) bcryptCost() int {
+	if h.BcryptCost == 0 {
+		return DefaultBcryptCost
+	}
+	return h.BcryptCost
+}
+
+func (h Hasher) argon2Params() argon2Params {
+	p := argon2Params{
+		variant:     Argon2id,
+		memory:      h.Argon2Memory,
+		iterations:  h.Argon2Iterations,
+		parallelism: h.Argon2Parallelism,
+	}
+	if p.memory == 0 {
+		p.memory = DefaultArgon2Memory
+	}
+	if p.iterations == 0 {
+		p.iterations = DefaultArgon2Iterations
+	}
+	if p.parallelism == 0 {
+		p.parallelism = DefaultArgon2Parallelism
+	}
+	return p
+}
+
+// --- bcrypt ---
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func verifyBcrypt(hash, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return false, nil
+	}
+	return false, fmt.Errorf("%w: %v", ErrMalformedHash, err)
+}
+
+// --- argon2 ---
+
+// argon2Params are the parameters of a hash in the PHC string format,
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>.
+type argon2Params struct {
+	variant     string
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+func (p argon2Params) format(salt, key []byte) string {
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", p.variant, argon2.Version,
+		p.memory, p.iterations, p.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func parseArgon2(hash string) (p argon2Params, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" {
+		return p, nil, nil, ErrMalformedHash
+	}
+	p.variant = parts[1]
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrMalformedHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism); err != nil {
+		return p, nil, nil, ErrMalformedHash
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return p, nil, nil, ErrMalformedHash
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return p, nil, nil, ErrMalformedHash
+	}
+	return p, salt, key, nil
+}
+
+func verifyArgon2(hash, password string) (bool, error) {
+	p, salt, key, err := parseArgon2(hash)
+	if err != nil {
+		return false, err
+	}
+	var got []byte
+	switch p.variant {
+	case Argon2id:
+		got = argon2.IDKey([]byte(password), salt, p.iterations, p.memory, p.parallelism, uint32(len(key)))
+	case "argon2i":
+		got = argon2.Key([]byte(password), salt, p.iterations, p.memory, p.parallelism, uint32(len(key)))
+	default:
+		return false, ErrMalformedHash
+	}
+	return subtle.ConstantTimeCompare(got, key) == 1, nil
+}
+
+// --- wrapped schemes ---
+
+// wrappers are the schemes OpenLDAP uses to store hashes of other schemes,
+// such as {CRYPT}$6$... or {ARGON2}$argon2id$....
+var wrappers = []string{"{CRYPT}", "{ARGON2}"}
+
+// unwrap returns the inner hash of a hash in one of the wrapper schemes.
+func unwrap(hash string) (string, bool) {
+	for _, w := range wrappers {
+		if len(hash) >= len(w) && strings.EqualFold(hash[:len(w)], w) {
+			return hash[len(w):], true
+		}
+	}
+	return "", false
+}
+
+// verifyWrapped verifies a hash in one of the wrapper schemes by verifying
+// the inner hash. Only crypt(3)-style inner hashes are accepted; traditional
+// DES crypt is not supported.
+func verifyWrapped(hash, password string) (bool, error) {
+	inner, _ := unwrap(hash)
+	if !strings.HasPrefix(inner, "$") {
+		return false, ErrUnknownScheme
+	}
+	return Verify(inner, password)
+}
+
+func init() {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		Register(prefix, verifyBcrypt)
+	}
+	Register("$argon2id$", verifyArgon2)
+	Register("$argon2i$", verifyArgon2)
+	for _, w := range wrappers {
+		Register(w, verifyWrapped)
+	}
+}
@@ -0,0 +1,125 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestVerify(t *testing.T) {
+	const pw = "Secret#Pass1"
+	hashes := []string{
+		"$6$saltsalt$JsPIc4j4KNk2qTMmND5ebjyEQeD98VaoguQYvtrew4EG6ruEYunewxFYhvpp6pqrdm8.GBxlDRKYz2ipP8q2F1",
+		"$6$rounds=10000$saltsaltsaltsalt$fguYGnbZEJk3vq2iQbm7JzSiEtqtfCuPholT97vqsxViWlLRpQ7t98iMxMRa/S3WvXmni0R/a1sjRD7DOLDSa1",
+		"{CRYPT}$6$saltsalt$JsPIc4j4KNk2qTMmND5ebjyEQeD98VaoguQYvtrew4EG6ruEYunewxFYhvpp6pqrdm8.GBxlDRKYz2ipP8q2F1",
+		"$5$saltsalt$1VdIBImFhp62PMsi.x4ef07XzE6et3eAVYEsKYsUzxB",
+		"{SSHA}iCtdldPg/c58ZJoQvbiJWBq2QQsBAgMEBQYHCA==",
+		"{ssha}iCtdldPg/c58ZJoQvbiJWBq2QQsBAgMEBQYHCA==",
+		"{SSHA512}xHdKf29art6BoOSwtWP0cNd96j2Jg6FYcfVmMDS3H6GcebZRgizw2wsTqZ2FnWjR3ZlXT26SVeWJHqnnsYK3BAECAwQFBgcI",
+		"{SHA}AR8AeNuA8iJ9eieXJpdJZklOUFc=",
+		"{PBKDF2}60000$MDEyMzQ1Njc4OWFiY2RlZg$H2ZiY8xJCeOcxjW8tRBvLWGVunE",
+		"{PBKDF2-SHA512}10000$MDEyMzQ1Njc4OWFiY2RlZg$eJxqCW9bSy0E4jYAtMa5zP/KTr8aYIi5sgSw.b7aE/qnQywharehxyBfOAGlBXxahPKEiV2sdG.TaGqvlZiDqg",
+		"$pbkdf2-sha256$29000$MDEyMzQ1Njc4OWFiY2RlZg$C20AO99aYk8I4XwTE/6rnKpAiQ7uA3Oig6.Ja/IePNg",
+	}
+	for _, h := range hashes {
+		if ok, err := Verify(h, pw); !ok || err != nil {
+			t.Errorf("Verify(%s) = %v, %v; want true", h, ok, err)
+		}
+		if ok, err := Verify(h, pw+"x"); ok || err != nil {
+			t.Errorf("Verify(%s) with wrong password = %v, %v; want false", h, ok, err)
+		}
+	}
+
+	// Test vector from the SHA-crypt specification.
+	if ok, _ := Verify("$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1", "Hello world!"); !ok {
+		t.Error("SHA-512 crypt test vector does not verify")
+	}
+	// Example from the Argon2 reference implementation, also as stored by
+	// OpenLDAP's argon2 module.
+	for _, h := range []string{
+		"$argon2i$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG",
+		"{ARGON2}$argon2i$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG",
+	} {
+		if ok, _ := Verify(h, "password"); !ok {
+			t.Errorf("Argon2 test vector %s does not verify", h)
+		}
+	}
+}
+
+func TestVerifyErrors(t *testing.T) {
+	tests := []struct {
+		hash string
+		want error
+	}{
+		{"plaintext", ErrUnknownScheme},
+		{"{MD5}X03MO1qnZdYdgyfeuILPmQ==", ErrUnknownScheme},
+		{"{CRYPT}abJnggxhB/yWI", ErrUnknownScheme},
+		{"{SSHA}not base64", ErrMalformedHash},
+		{"{PBKDF2-SHA256}abc$def", ErrMalformedHash},
+		{"$argon2id$v=19$m=65536$c29tZXNhbHQ$abc", ErrMalformedHash},
+	}
+	for _, tt := range tests {
+		if _, err := Verify(tt.hash, "pw"); !errors.Is(err, tt.want) {
+			t.Errorf("Verify(%s): err = %v, want %v", tt.hash, err, tt.want)
+		}
+		if Supported(tt.hash) != (tt.want != ErrUnknownScheme) {
+			t.Errorf("Supported(%s) = %v", tt.hash, Supported(tt.hash))
+		}
+	}
+}
+
+func TestHasher(t *testing.T) {
+	bcrypt4 := Hasher{BcryptCost: bcrypt.MinCost}
+	argon := Hasher{Algorithm: Argon2id, Argon2Memory: 1024, Argon2Iterations: 1, Argon2Parallelism: 1}
+
+	for _, h := range []Hasher{bcrypt4, argon} {
+		hash, err := h.Hash("Secret#Pass1")
+		if err != nil {
+			t.Fatalf("%s: Hash: %v", h.Algorithm, err)
+		}
+		if ok, err := Verify(hash, "Secret#Pass1"); !ok || err != nil {
+			t.Errorf("%s: Verify(%s) = %v, %v", h.Algorithm, hash, ok, err)
+		}
+		if h.NeedsRehash(hash) {
+			t.Errorf("%s: fresh hash %s needs rehash", h.Algorithm, hash)
+		}
+	}
+
+	bcryptHash, _ := bcrypt4.Hash("pw")
+	argonHash, _ := argon.Hash("pw")
+	if !strings.HasPrefix(argonHash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("argon2id hash = %s", argonHash)
+	}
+	tests := []struct {
+		h    Hasher
+		hash string
+		want bool
+	}{
+		{bcrypt4, argonHash, true},
+		{bcrypt4, "{SSHA}iCtdldPg/c58ZJoQvbiJWBq2QQsBAgMEBQYHCA==", true},
+		{Hasher{BcryptCost: bcrypt.MinCost + 1}, bcryptHash, true},
+		{argon, bcryptHash, true},
+		{Hasher{Algorithm: Argon2id, Argon2Memory: 2048, Argon2Iterations: 1, Argon2Parallelism: 1}, argonHash, true},
+		{argon, argonHash, false},
+	}
+	for _, tt := range tests {
+		if got := tt.h.NeedsRehash(tt.hash); got != tt.want {
+			t.Errorf("%+v: NeedsRehash(%s) = %v, want %v", tt.h, tt.hash, got, tt.want)
+		}
+	}
+}
+
+func TestHasherValidate(t *testing.T) {
+	for _, h := range []Hasher{{}, {Algorithm: "ARGON2ID"}, {BcryptCost: 12}} {
+		if err := h.Validate(); err != nil {
+			t.Errorf("%+v: %v", h, err)
+		}
+	}
+	for _, h := range []Hasher{{Algorithm: "md5"}, {BcryptCost: 40}} {
+		if err := h.Validate(); err == nil {
+			t.Errorf("%+v: expected error", h)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package password
+
+import (
+	"bytes"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+// --- salted SHA: {SHA}, {SSHA}, {SSHA256}, {SSHA512}, ... ---
+
+// verifySHA returns a verifier for OpenLDAP's {SHA*} and {SSHA*} schemes:
+// base64 of the digest of password+salt, followed by the salt.
+func verifySHA(newHash func() hash.Hash, salted bool) Verifier {
+	return func(h, password string) (bool, error) {
+		i := strings.IndexByte(h, '}')
+		b, err := base64.StdEncoding.DecodeString(h[i+1:])
+		size := newHash().Size()
+		if err != nil || len(b) < size || (!salted && len(b) != size) {
+			return false, ErrMalformedHash
+		}
+		digest, salt := b[:size], b[size:]
+		d := newHash()
+		d.Write([]byte(password))
+		d.Write(salt)
+		return subtle.ConstantTimeCompare(d.Sum(nil), digest) == 1, nil
+	}
+}
+
+// --- PBKDF2 ---
+
+// ab64 is the "adapted base64" of passlib and OpenLDAP's pw-pbkdf2 module:
+// standard base64 without padding, with '.' in place of '+'.
+func ab64Decode(s string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.ReplaceAll(s, ".", "+"))
+}
+
+// verifyPBKDF2 returns a verifier for PBKDF2 hashes in the OpenLDAP
+// pw-pbkdf2 format, {PBKDF2-SHA512}<rounds>$<salt>$<key>, and the passlib
+// format, $pbkdf2-sha512$<rounds>$<salt>$<key>.
+func verifyPBKDF2(newHash func() hash.Hash) Verifier {
+	return func(h, password string) (bool, error) {
+		var rest string
+		if strings.HasPrefix(h, "{") {
+			rest = h[strings.IndexByte(h, '}')+1:]
+		} else {
+			rest = h[strings.IndexByte(h[1:], '$')+2:]
+		}
+		parts := strings.Split(rest, "$")
+		if len(parts) != 3 {
+			return false, ErrMalformedHash
+		}
+		rounds, err := strconv.Atoi(parts[0])
+		if err != nil || rounds < 1 {
+			return false, ErrMalformedHash
+		}
+		salt, err := ab64Decode(parts[1])
+		if err != nil {
+			return false, ErrMalformedHash
+		}
+		key, err := ab64Decode(parts[2])
+		if err != nil || len(key) == 0 {
+			return false, ErrMalformedHash
+		}
+		got, err := pbkdf2.Key(newHash, password, salt, rounds, len(key))
+		if err != nil {
+			return false, ErrMalformedHash
+		}
+		return subtle.ConstantTimeCompare(got, key) == 1, nil
+	}
+}
+
+// --- SHA-crypt: $5$ and $6$ ---
+
+const (
+	shaCryptAlphabet      = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	shaCryptDefaultRounds = 5000
+	shaCryptMinRounds     = 1000
+	shaCryptMaxRounds     = 999999999
+	shaCryptMaxSalt       = 16
+)
+
+// Byte orders in which SHA-crypt encodes the final digest, three bytes at
+// a time; the trailing one or two bytes are encoded separately.
+var (
+	sha256CryptOrder = [][3]int{
+		{0, 10, 20}, {21, 1, 11}, {12, 22, 2}, {3, 13, 23}, {24, 4, 14},
+		{15, 25, 5}, {6, 16, 26}, {27, 7, 17}, {18, 28, 8}, {9, 19, 29},
+	}
+	sha512CryptOrder = [][3]int{
+		{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45}, {25, 46, 4},
+		{47, 5, 26}, {6, 27, 48}, {28, 49, 7}, {50, 8, 29}, {9, 30, 51},
+		{31, 52, 10}, {53, 11, 32}, {12, 33, 54}, {34, 55, 13}, {56, 14, 35},
+		{15, 36, 57}, {37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19},
+		{62, 20, 41},
+	}
+)
+
+// verifySHACrypt returns a verifier for the SHA-crypt schemes of glibc's
+// crypt(3), $5$[rounds=<n>$]<salt>$<hash> and its $6$ SHA-512 variant.
+func verifySHACrypt(magic string, newHash func() hash.Hash) Verifier {
+	return func(h, password string) (bool, error) {
+		rest := h[len(magic):]
+		rounds, custom := shaCryptDefaultRounds, false
+		if r, ok := strings.CutPrefix(rest, "rounds="); ok {
+			n, after, found := strings.Cut(r, "$")
+			v, err := strconv.Atoi(n)
+			if !found || err != nil {
+				return false, ErrMalformedHash
+			}
+			rounds, custom, rest = min(max(v, shaCryptMinRounds), shaCryptMaxRounds), true, after
+		}
+		i := strings.LastIndexByte(rest, '$')
+		if i < 0 {
+			return false, ErrMalformedHash
+		}
+		salt := rest[:i]
+		if len(salt) > shaCryptMaxSalt {
+			salt = salt[:shaCryptMaxSalt]
+		}
+		got := shaCrypt(magic, newHash, []byte(password), []byte(salt), rounds, custom)
+		return subtle.ConstantTimeCompare([]byte(got), []byte(h)) == 1, nil
+	}
+}
+
+// shaCrypt implements the SHA-crypt algorithm as specified by Ulrich
+// Drepper in "Unix crypt using SHA-256 and SHA-512".
+func shaCrypt(magic string, newHash func() hash.Hash, password, salt []byte, rounds int, customRounds bool) string {
+	d := newHash()
+	size := d.Size()
+
+	// Digest B: password, salt, password.
+	d.Write(password)
+	d.Write(salt)
+	d.Write(password)
+	b := d.Sum(nil)
+
+	// Digest A.
+	d.Reset()
+	d.Write(password)
+	d.Write(salt)
+	n := len(password)
+	for ; n > size; n -= size {
+		d.Write(b)
+	}
+	d.Write(b[:n])
+	for n = len(password); n > 0; n >>= 1 {
+		if n&1 != 0 {
+			d.Write(b)
+		} else {
+			d.Write(password)
+		}
+	}
+	a := d.Sum(nil)
+
+	// Byte sequence P: digest DP of the password repeated once per byte.
+	d.Reset()
+	for range password {
+		d.Write(password)
+	}
+	p := repeatTo(d.Sum(nil), len(password))
+
+	// Byte sequence S: digest DS of the salt repeated 16+A[0] times.
+	d.Reset()
+	for range 16 + int(a[0]) {
+		d.Write(salt)
+	}
+	s := repeatTo(d.Sum(nil), len(salt))
+
+	c := a
+	for i := range rounds {
+		d.Reset()
+		if i%2 != 0 {
+			d.Write(p)
+		} else {
+			d.Write(c)
+		}
+		if i%3 != 0 {
+			d.Write(s)
+		}
+		if i%7 != 0 {
+			d.Write(p)
+		}
+		if i%2 != 0 {
+			d.Write(c)
+		} else {
+			d.Write(p)
+		}
+		c = d.Sum(nil)
+	}
+
+	var out strings.Builder
+	out.WriteString(magic)
+	if customRounds {
+		out.WriteString("rounds=" + strconv.Itoa(rounds) + "$")
+	}
+	out.Write(salt)
+	out.WriteByte('$')
+	if size == sha256.Size {
+		for _, g := range sha256CryptOrder {
+			encode24(&out, c[g[0]], c[g[1]], c[g[2]], 4)
+		}
+		encode24(&out, 0, c[31], c[30], 3)
+	} else {
+		for _, g := range sha512CryptOrder {
+			encode24(&out, c[g[0]], c[g[1]], c[g[2]], 4)
+		}
+		encode24(&out, 0, 0, c[63], 2)
+	}
+	return out.String()
+}
+
+func repeatTo(b []byte, n int) []byte {
+	return bytes.Repeat(b, n/len(b)+1)[:n]
+}
+
+// encode24 writes n characters of the crypt(3) base64 encoding of three
+// bytes, least significant six bits first.
+func encode24(out *strings.Builder, b2, b1, b0 byte, n int) {
+	w := uint(b2)<<16 | uint(b1)<<8 | uint(b0)
+	for range n {
+		out.WriteByte(shaCryptAlphabet[w&0x3f])
+		w >>= 6
+	}
+}
+
+func init() {
+	Register("{SHA}", verifySHA(sha1.New, false))
+	Register("{SSHA}", verifySHA(sha1.New, true))
+	Register("{SHA256}", verifySHA(sha256.New, false))
+	Register("{SSHA256}", verifySHA(sha256.New, true))
+	Register("{SHA384}", verifySHA(sha512.New384, false))
+	Register("{SSHA384}", verifySHA(sha512.New384, true))
+	Register("{SHA512}", verifySHA(sha512.New, false))
+	Register("{SSHA512}", verifySHA(sha512.New, true))
+
+	Register("{PBKDF2}", verifyPBKDF2(sha1.New))
+	Register("{PBKDF2-SHA1}", verifyPBKDF2(sha1.New))
+	Register("{PBKDF2-SHA256}", verifyPBKDF2(sha256.New))
+	Register("{PBKDF2-SHA512}", verifyPBKDF2(sha512.New))
+	Register("$pbkdf2$", verifyPBKDF2(sha1.New))
+	Register("$pbkdf2-sha256$", verifyPBKDF2(sha256.New))
+	Register("$pbkdf2-sha512$", verifyPBKDF2(sha512.New))
+
+	Register("$5$", verifySHACrypt("$5$", sha256.New))
+	Register("$6$", verifySHACrypt("$6$", sha512.New))
+}
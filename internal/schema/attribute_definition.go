@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// AttributeDefinition holds the schema definition for the AttributeDefinition entity.
+// It declares an admin-defined extension attribute for users or groups.
+type AttributeDefinition struct {
+	ent.Schema
+}
+
+// Fields of the AttributeDefinition.
+func (AttributeDefinition) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("name").NotEmpty().MaxLen(64).Immutable(),
+		field.Enum("target").Values("user", "group").Immutable(),
+		field.Enum("type").Values("string", "integer", "boolean").Default("string").Immutable(),
+		field.Bool("multi_valued").Default(false).Immutable(),
+		field.String("ldap_name").NotEmpty().MaxLen(64),
+		field.String("description").Optional().MaxLen(255),
+		field.Bool("required").Default(false),
+		field.Bool("indexed").Default(false),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Indexes of the AttributeDefinition.
+func (AttributeDefinition) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("target", "name").Unique(),
+		index.Fields("target", "ldap_name").Unique(),
+	}
+}
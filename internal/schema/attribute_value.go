@@ -0,0 +1,39 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// AttributeValue holds the schema definition for the AttributeValue entity.
+// Each row stores one value of an extension attribute for a user or a group.
+type AttributeValue struct {
+	ent.Schema
+}
+
+// Fields of the AttributeValue.
+func (AttributeValue) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("name").NotEmpty().MaxLen(64),
+		field.String("value").MaxLen(1024),
+	}
+}
+
+// Edges of the AttributeValue.
+func (AttributeValue) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("attribute_values").Unique(),
+		edge.From("group", Group.Type).Ref("attribute_values").Unique(),
+	}
+}
+
+// Indexes of the AttributeValue.
+func (AttributeValue) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("name", "value"),
+	}
+}
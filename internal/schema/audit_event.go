@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// AuditEvent holds the schema definition for the AuditEvent entity.
+// Audit events record who changed what over HTTP or LDAP, and every login
+// and bind. They are append-only: every field is immutable and nothing
+// deletes them. Actors and targets are referenced by ID and name rather
+// than by edge so that events outlive what they describe.
+type AuditEvent struct {
+	ent.Schema
+}
+
+// Fields of the AuditEvent.
+func (AuditEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.String("action").Immutable(),
+		field.Enum("outcome").Values("success", "failure").Immutable(),
+		field.String("reason").Default("").Immutable(),
+		field.String("actor_type").Default("").Immutable(),
+		field.UUID("actor_id", uuid.UUID{}).Optional().Nillable().Immutable(),
+		field.String("actor_name").Default("").Immutable(),
+		field.String("target_type").Default("").Immutable(),
+		field.String("target_id").Default("").Immutable(),
+		field.String("target_name").Default("").Immutable(),
+		field.String("protocol").Default("").Immutable(),
+		field.String("ip_address").Default("").Immutable(),
+		field.String("request_id").Default("").Immutable(),
+		// Changed fields with their old and new values, JSON encoded.
+		field.Text("changes").Default("").Immutable(),
+	}
+}
+
+// Indexes of the AuditEvent.
+func (AuditEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at"),
+		index.Fields("target_type", "target_id", "created_at"),
+		index.Fields("actor_id", "created_at"),
+	}
+}
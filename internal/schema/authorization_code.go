@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// AuthorizationCode holds the schema definition for the AuthorizationCode
+// entity: a single-use OAuth authorization code awaiting exchange.
+type AuthorizationCode struct {
+	ent.Schema
+}
+
+// Fields of the AuthorizationCode.
+func (AuthorizationCode) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("code_hash").Unique().NotEmpty().Sensitive().Immutable(),
+		field.String("redirect_uri").NotEmpty().Immutable(),
+		field.Strings("scopes").Immutable(),
+		field.String("nonce").Default("").Immutable(),
+		field.String("code_challenge").NotEmpty().Immutable(),
+		field.Time("auth_time").Immutable(),
+		field.Time("expires_at").Immutable(),
+	}
+}
+
+// Edges of the AuthorizationCode.
+func (AuthorizationCode) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("client", OIDCClient.Type).Ref("codes").Unique().Required().Immutable(),
+		edge.From("user", User.Type).Ref("authorization_codes").Unique().Required().Immutable(),
+	}
+}
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// DirectoryObject holds the schema definition for the DirectoryObject
+// entity. It links an entry of an upstream directory to the user or group
+// synchronized from it.
+type DirectoryObject struct {
+	ent.Schema
+}
+
+// Fields of the DirectoryObject.
+func (DirectoryObject) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		// Name of the configured source directory.
+		field.String("source").NotEmpty().Immutable(),
+		field.Enum("resource_type").Values("user", "group").Immutable(),
+		// Immutable identifier of the entry, such as its entryUUID.
+		field.String("external_id").NotEmpty().Immutable(),
+		// ID of the user or group; not an edge, so that a user deleted
+		// locally is created again rather than the link lost.
+		field.UUID("local_id", uuid.UUID{}),
+		field.String("dn").Default(""),
+		// Hash of the password last imported from the entry, to import it
+		// again only when it changes upstream.
+		field.String("password_digest").Default("").Sensitive(),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Indexes of the DirectoryObject.
+func (DirectoryObject) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("source", "resource_type", "external_id").Unique(),
+		// A user or group is synchronized from one entry at most.
+		index.Fields("resource_type", "local_id").Unique(),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
@@ -21,6 +22,7 @@ func (Group) Fields() []ent.Field {
 		field.String("name").Unique().NotEmpty().MaxLen(64),
 		field.String("description").Optional().MaxLen(255),
 		field.UUID("parent_id", uuid.UUID{}).Optional().Nillable(),
+		field.Bool("require_mfa").Default(false),
 		field.Time("created_at").Immutable().Default(time.Now),
 		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
 	}
@@ -31,5 +33,11 @@ func (Group) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("users", User.Type),
 		edge.To("children", Group.Type).From("parent").Field("parent_id").Unique(),
+		edge.To("owner_users", User.Type),
+		edge.To("owner_groups", Group.Type).From("owned_groups"),
+		edge.From("roles", Role.Type).Ref("groups"),
+		edge.From("password_policies", PasswordPolicy.Type).Ref("groups"),
+		edge.To("attribute_values", AttributeValue.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
 	}
 }
@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// GroupMembershipChange holds the schema definition for the
+// GroupMembershipChange entity. It records a user joining or leaving a
+// group, written by a hook in the transaction that changed the membership,
+// so that the members of a group can be reconstructed for any point in
+// time.
+type GroupMembershipChange struct {
+	ent.Schema
+}
+
+// Fields of the GroupMembershipChange.
+func (GroupMembershipChange) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.UUID("group_id", uuid.UUID{}).Immutable(),
+		field.UUID("user_id", uuid.UUID{}).Immutable(),
+		field.Enum("operation").Values("add", "remove").Immutable(),
+		field.UUID("actor_id", uuid.UUID{}).Optional().Nillable().Immutable(),
+		field.String("actor_name").Default("").Immutable(),
+		field.Time("created_at").Immutable().Default(time.Now),
+	}
+}
+
+// Indexes of the GroupMembershipChange.
+func (GroupMembershipChange) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("group_id", "created_at"),
+		index.Fields("user_id", "created_at"),
+	}
+}
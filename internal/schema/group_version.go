@@ -0,0 +1,44 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// GroupVersion holds the schema definition for the GroupVersion entity.
+// Like UserVersion, it is a snapshot of a group written in the transaction
+// that changed it. Membership is versioned separately, by
+// GroupMembershipChange.
+type GroupVersion struct {
+	ent.Schema
+}
+
+// Fields of the GroupVersion.
+func (GroupVersion) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.UUID("group_id", uuid.UUID{}).Immutable(),
+		field.Int("version").Positive().Immutable(),
+		field.Enum("operation").Values("create", "update", "delete", "baseline").Immutable(),
+		field.String("name").Immutable(),
+		field.String("description").Default("").Immutable(),
+		field.UUID("parent_id", uuid.UUID{}).Optional().Nillable().Immutable(),
+		field.Bool("require_mfa").Default(false).Immutable(),
+		field.JSON("attributes", map[string][]string{}).Optional().Immutable(),
+		field.UUID("actor_id", uuid.UUID{}).Optional().Nillable().Immutable(),
+		field.String("actor_name").Default("").Immutable(),
+		field.Time("created_at").Immutable().Default(time.Now),
+	}
+}
+
+// Indexes of the GroupVersion.
+func (GroupVersion) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("group_id", "version").Unique(),
+		index.Fields("group_id", "created_at"),
+	}
+}
@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Invitation holds the schema definition for the Invitation entity. An
+// invitation is emailed to a pending user as a link with which they set
+// their own password and activate the account; only the hash of its token
+// is stored, and it can be accepted once.
+type Invitation struct {
+	ent.Schema
+}
+
+// Fields of the Invitation.
+func (Invitation) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("token_hash").Unique().NotEmpty().Immutable().Sensitive(),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.Time("expires_at").Immutable(),
+		field.Time("accepted_at").Optional().Nillable(),
+	}
+}
+
+// Edges of the Invitation.
+func (Invitation) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("invitations").Unique().Required(),
+	}
+}
@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// LoginEvent holds the schema definition for the LoginEvent entity.
+// Login events are the audit trail of failed logins and account lockouts.
+// They reference users by ID rather than by edge so that they outlive the
+// accounts they describe, and attempts on unknown usernames are kept too.
+type LoginEvent struct {
+	ent.Schema
+}
+
+// Fields of the LoginEvent.
+func (LoginEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.UUID("user_id", uuid.UUID{}).Optional().Nillable().Immutable(),
+		field.String("username").Immutable(),
+		field.Enum("type").Values("failure", "rejected", "locked", "unlocked").Immutable(),
+		field.String("reason").Default("").Immutable(),
+		field.String("protocol").Default("").Immutable(),
+		field.String("ip_address").Default("").Immutable(),
+		field.UUID("actor_id", uuid.UUID{}).Optional().Nillable().Immutable(),
+		field.Time("created_at").Immutable().Default(time.Now),
+	}
+}
+
+// Indexes of the LoginEvent.
+func (LoginEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "created_at"),
+	}
+}
@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// OIDCClient holds the schema definition for the OIDCClient entity.
+// Clients are relying parties allowed to sign users in over OpenID Connect;
+// the ID is the OAuth client_id.
+type OIDCClient struct {
+	ent.Schema
+}
+
+// Fields of the OIDCClient.
+func (OIDCClient) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("name").NotEmpty().MaxLen(128),
+		field.Strings("redirect_uris"),
+		field.Bool("public").Default(false).Immutable(),
+		field.String("secret_hash").Optional().Sensitive(),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the OIDCClient.
+func (OIDCClient) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("codes", AuthorizationCode.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("consents", OIDCConsent.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
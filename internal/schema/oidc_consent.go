@@ -0,0 +1,42 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// OIDCConsent holds the schema definition for the OIDCConsent entity:
+// the scopes a user has agreed to share with a client.
+type OIDCConsent struct {
+	ent.Schema
+}
+
+// Fields of the OIDCConsent.
+func (OIDCConsent) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.Strings("scopes"),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the OIDCConsent.
+func (OIDCConsent) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("client", OIDCClient.Type).Ref("consents").Unique().Required().Immutable(),
+		edge.From("user", User.Type).Ref("oidc_consents").Unique().Required().Immutable(),
+	}
+}
+
+// Indexes of the OIDCConsent.
+func (OIDCConsent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Edges("client", "user").Unique(),
+	}
+}
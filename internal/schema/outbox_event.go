@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// OutboxEvent holds the schema definition for the OutboxEvent entity.
+// Outbox events describe changes to users, groups and memberships for
+// webhook subscribers. They are written by the history hooks in the
+// transaction that makes the change, so an event exists if and only if the
+// change was committed, and are later fanned out to one delivery per
+// matching subscription. Dispatched events whose deliveries were all purged
+// are purged too.
+type OutboxEvent struct {
+	ent.Schema
+}
+
+// Fields of the OutboxEvent.
+func (OutboxEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("event_type").NotEmpty().Immutable(),
+		// Event data, JSON encoded.
+		field.Text("payload").Immutable(),
+		field.Time("created_at").Immutable().Default(time.Now),
+		// When the event was fanned out to the subscriptions; nil until then.
+		field.Time("dispatched_at").Optional().Nillable(),
+	}
+}
+
+// Edges of the OutboxEvent.
+func (OutboxEvent) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("deliveries", WebhookDelivery.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the OutboxEvent.
+func (OutboxEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("dispatched_at", "created_at"),
+		// For the newest event, the provisioning cursor.
+		index.Fields("created_at"),
+	}
+}
@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// PasswordHistory holds the schema definition for the PasswordHistory entity.
+// It keeps the hashes of a user's previous passwords so they cannot be
+// reused.
+type PasswordHistory struct {
+	ent.Schema
+}
+
+// Fields of the PasswordHistory.
+func (PasswordHistory) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("password_hash").NotEmpty().Immutable().Sensitive(),
+		field.Time("created_at").Immutable().Default(time.Now),
+	}
+}
+
+// Edges of the PasswordHistory.
+func (PasswordHistory) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("password_history").Unique().Required(),
+	}
+}
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// PasswordPolicy holds the schema definition for the PasswordPolicy entity.
+// The built-in default policy applies to every user; other policies apply to
+// the members of the groups they are assigned to. A user is bound by the
+// strictest combination of the policies that apply to them.
+type PasswordPolicy struct {
+	ent.Schema
+}
+
+// Fields of the PasswordPolicy.
+func (PasswordPolicy) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("name").Unique().NotEmpty().MaxLen(64),
+		field.String("description").Optional().MaxLen(255),
+		field.Bool("builtin").Default(false).Immutable(),
+		field.Int("min_length").Default(8).NonNegative(),
+		field.Bool("require_uppercase").Default(false),
+		field.Bool("require_lowercase").Default(false),
+		field.Bool("require_digit").Default(false),
+		field.Bool("require_symbol").Default(false),
+		field.Bool("check_dictionary").Default(false),
+		field.Bool("check_username").Default(false),
+		field.Int("history_count").Default(0).NonNegative(),
+		field.Int("min_age_hours").Default(0).NonNegative(),
+		field.Int("max_age_days").Default(0).NonNegative(),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the PasswordPolicy.
+func (PasswordPolicy) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("groups", Group.Type),
+	}
+}
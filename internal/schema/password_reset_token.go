@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// PasswordResetToken holds the schema definition for the PasswordResetToken
+// entity. A token is emailed as a link that lets a user set a new password
+// without the old one; only its hash is stored, and it can be used once.
+type PasswordResetToken struct {
+	ent.Schema
+}
+
+// Fields of the PasswordResetToken.
+func (PasswordResetToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("token_hash").Unique().NotEmpty().Immutable().Sensitive(),
+		field.String("ip_address").Default("").Immutable(),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.Time("expires_at").Immutable(),
+		field.Time("used_at").Optional().Nillable(),
+	}
+}
+
+// Edges of the PasswordResetToken.
+func (PasswordResetToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("password_reset_tokens").Unique().Required(),
+	}
+}
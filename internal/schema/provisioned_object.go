@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ProvisionedObject holds the schema definition for the ProvisionedObject
+// entity. It links a user or group to the resource a connector provisioned
+// for it, and records the outcome of the last attempt to synchronize it.
+type ProvisionedObject struct {
+	ent.Schema
+}
+
+// Fields of the ProvisionedObject.
+func (ProvisionedObject) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.Enum("resource_type").Values("user", "group").Immutable(),
+		// ID of the user or group; not an edge, as the object outlives it
+		// until the resource is deprovisioned.
+		field.UUID("local_id", uuid.UUID{}).Immutable(),
+		// ID of the resource in the application; empty until created.
+		field.String("remote_id").Default(""),
+		// Hash of the resource last sent, to skip unchanged resources.
+		field.String("hash").Default(""),
+		field.String("last_error").Default(""),
+		field.Time("synced_at").Optional().Nillable(),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the ProvisionedObject.
+func (ProvisionedObject) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("connector", ProvisioningConnector.Type).Ref("objects").Unique().Required(),
+	}
+}
+
+// Indexes of the ProvisionedObject.
+func (ProvisionedObject) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("resource_type", "local_id").Edges("connector").Unique(),
+	}
+}
@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// ProvisioningConnector holds the schema definition for the
+// ProvisioningConnector entity. A connector provisions users, and
+// optionally groups, to an application's SCIM endpoint, and records how
+// its synchronization stands.
+type ProvisioningConnector struct {
+	ent.Schema
+}
+
+// Fields of the ProvisioningConnector.
+func (ProvisioningConnector) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("name").Unique().NotEmpty().MaxLen(64),
+		// SCIM base URL of the application, such as https://app/scim/v2.
+		field.String("url").NotEmpty(),
+		// Bearer token sent to the application; empty sends none.
+		field.String("token").Default("").Sensitive(),
+		// Only direct members of this group are provisioned; nil provisions
+		// every user.
+		field.UUID("scope_group_id", uuid.UUID{}).Optional().Nillable(),
+		field.Bool("sync_groups").Default(true),
+		// Target SCIM attribute path of each user extension attribute.
+		field.JSON("attribute_mapping", map[string]string{}).Optional(),
+		field.Bool("enabled").Default(true),
+		// Time of the latest outbox event taken into account; changes are
+		// synchronized when newer events are written.
+		field.Time("event_cursor").Optional().Nillable(),
+		field.Time("last_sync_at").Optional().Nillable(),
+		// Cleared to have the next synchronization reconcile everything.
+		field.Time("last_full_sync_at").Optional().Nillable(),
+		field.String("last_error").Default(""),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the ProvisioningConnector.
+func (ProvisioningConnector) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("objects", ProvisionedObject.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
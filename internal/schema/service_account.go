@@ -0,0 +1,39 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// ServiceAccount holds the schema definition for the ServiceAccount entity.
+// A service account is a machine identity for automation. It authenticates
+// with API keys and holds its own set of permissions instead of roles.
+type ServiceAccount struct {
+	ent.Schema
+}
+
+// Fields of the ServiceAccount.
+func (ServiceAccount) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("name").Unique().NotEmpty().MaxLen(64).Immutable(),
+		field.String("description").Optional().MaxLen(255),
+		field.Strings("permissions").Optional(),
+		field.Bool("disabled").Default(false),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the ServiceAccount.
+func (ServiceAccount) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("api_keys", APIKey.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
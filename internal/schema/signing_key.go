@@ -0,0 +1,27 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// SigningKey holds the schema definition for the SigningKey entity.
+// Keys sign JWTs and are published via JWKS; the ID doubles as the "kid".
+type SigningKey struct {
+	ent.Schema
+}
+
+// Fields of the SigningKey.
+func (SigningKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.Enum("algorithm").Values("RS256", "ES256", "EdDSA").Immutable(),
+		field.Text("private_key").NotEmpty().Sensitive().Immutable(),
+		field.Text("public_key").NotEmpty().Immutable(),
+		field.Time("created_at").Immutable().Default(time.Now),
+		field.Time("retired_at").Optional().Nillable(),
+	}
+}
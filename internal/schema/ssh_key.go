@@ -0,0 +1,42 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// SSHKey holds the schema definition for the SSHKey entity.
+// Users manage their own SSH public keys through the self-service API.
+type SSHKey struct {
+	ent.Schema
+}
+
+// Fields of the SSHKey.
+func (SSHKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.String("name").NotEmpty().MaxLen(64),
+		field.Text("public_key").NotEmpty().Immutable(),
+		field.String("fingerprint").NotEmpty().Immutable(),
+		field.Time("created_at").Immutable().Default(time.Now),
+	}
+}
+
+// Edges of the SSHKey.
+func (SSHKey) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("ssh_keys").Unique().Required(),
+	}
+}
+
+// Indexes of the SSHKey.
+func (SSHKey) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("fingerprint").Edges("user").Unique(),
+	}
+}
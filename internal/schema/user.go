@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
@@ -22,8 +23,20 @@ func (User) Fields() []ent.Field {
 		field.String("display_name").NotEmpty().MaxLen(128),
 		field.String("email").Unique().NotEmpty().MaxLen(255),
 		field.String("password_hash").Sensitive(),
+		field.Time("password_changed_at").Default(time.Now),
+		field.Bool("must_change_password").Default(false),
+		field.Time("account_expires_at").Optional().Nillable(),
 		field.String("phone").Optional().MaxLen(32),
-		field.Enum("status").Values("enabled", "disabled").Default("enabled"),
+		field.Enum("status").Values("enabled", "disabled", "pending").Default("enabled"),
+		field.UUID("manager_id", uuid.UUID{}).Optional().Nillable(),
+		field.String("totp_secret").Optional().Sensitive(),
+		field.Bool("mfa_enabled").Default(false),
+		field.Int64("totp_last_step").Default(0),
+		field.Int("failed_login_count").Default(0),
+		field.Time("last_failed_login_at").Optional().Nillable(),
+		field.Int("lockout_count").Default(0),
+		field.Time("locked_at").Optional().Nillable(),
+		field.Time("locked_until").Optional().Nillable(),
 		field.Time("created_at").Immutable().Default(time.Now),
 		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
 	}
@@ -33,5 +46,26 @@ func (User) Fields() []ent.Field {
 func (User) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.From("groups", Group.Type).Ref("users"),
+		edge.From("owned_groups", Group.Type).Ref("owner_users"),
+		edge.From("roles", Role.Type).Ref("users"),
+		edge.To("reports", User.Type).From("manager").Field("manager_id").Unique(),
+		edge.To("attribute_values", AttributeValue.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("ssh_keys", SSHKey.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("sessions", Session.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("authorization_codes", AuthorizationCode.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("oidc_consents", OIDCConsent.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("recovery_codes", RecoveryCode.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("password_history", PasswordHistory.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("password_reset_tokens", PasswordResetToken.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("invitations", Invitation.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
 	}
 }
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// UserVersion holds the schema definition for the UserVersion entity.
+// A version is a snapshot of a user's profile, written by a hook in the
+// transaction that created, changed or deleted the user. Versions are
+// append-only and reference the user by ID rather than by edge so that
+// they outlive it.
+type UserVersion struct {
+	ent.Schema
+}
+
+// Fields of the UserVersion.
+func (UserVersion) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New).Immutable(),
+		field.UUID("user_id", uuid.UUID{}).Immutable(),
+		field.Int("version").Positive().Immutable(),
+		field.Enum("operation").Values("create", "update", "delete", "baseline").Immutable(),
+		field.String("username").Immutable(),
+		field.String("display_name").Immutable(),
+		field.String("email").Immutable(),
+		field.String("phone").Default("").Immutable(),
+		field.String("status").Immutable(),
+		field.UUID("manager_id", uuid.UUID{}).Optional().Nillable().Immutable(),
+		field.Time("account_expires_at").Optional().Nillable().Immutable(),
+		field.JSON("attributes", map[string][]string{}).Optional().Immutable(),
+		field.UUID("actor_id", uuid.UUID{}).Optional().Nillable().Immutable(),
+		field.String("actor_name").Default("").Immutable(),
+		field.Time("created_at").Immutable().Default(time.Now),
+	}
+}
+
+// Indexes of the UserVersion.
+func (UserVersion) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "version").Unique(),
+		index.Fields("user_id", "created_at"),
+	}
+}
@@ -0,0 +1,268 @@
+// Package client calls the resource endpoints of a SCIM 2.0 service
+// provider (RFC 7644), for provisioning users and groups to other
+// applications.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/qinzj/claude-demo/internal/scim/filter"
+)
+
+// Schema URNs of the resources the client provisions.
+const (
+	SchemaUser           = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaEnterpriseUser = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SchemaGroup          = "urn:ietf:params:scim:schemas:core:2.0:Group"
+)
+
+// Resource endpoints, relative to the base URL.
+const (
+	Users  = "Users"
+	Groups = "Groups"
+)
+
+// ContentType is the media type of SCIM requests and responses.
+const ContentType = "application/scim+json"
+
+// maxResponseSize bounds the responses read, so that a misbehaving service
+// provider cannot exhaust memory.
+const maxResponseSize = 4 << 20
+
+// Error is an error response of the service provider. Requests that fail
+// without a response, for example because the service provider cannot be
+// reached, return other errors.
+type Error struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("scim: status %d", e.Status)
+	if e.ScimType != "" {
+		msg += " (" + e.ScimType + ")"
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+// IsNotFound reports whether err is a 404 response.
+func IsNotFound(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Status == http.StatusNotFound
+}
+
+// Client calls a service provider with a bearer token.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// New creates a Client of the service provider at baseURL, such as
+// https://app.example.com/scim/v2. An empty token sends no Authorization
+// header.
+func New(baseURL, token string, hc *http.Client) *Client {
+	return &Client{baseURL: strings.TrimSuffix(baseURL, "/"), token: token, http: hc}
+}
+
+// Create creates a resource and returns it as created.
+func (c *Client) Create(ctx context.Context, endpoint string, res map[string]any) (map[string]any, error) {
+	return c.do(ctx, http.MethodPost, endpoint, res)
+}
+
+// Get retrieves a resource by ID.
+func (c *Client) Get(ctx context.Context, endpoint, id string) (map[string]any, error) {
+	return c.do(ctx, http.MethodGet, endpoint+"/"+url.PathEscape(id), nil)
+}
+
+// Replace replaces a resource and returns it as replaced.
+func (c *Client) Replace(ctx context.Context, endpoint, id string, res map[string]any) (map[string]any, error) {
+	return c.do(ctx, http.MethodPut, endpoint+"/"+url.PathEscape(id), res)
+}
+
+// Delete deletes a resource.
+func (c *Client) Delete(ctx context.Context, endpoint, id string) error {
+	_, err := c.do(ctx, http.MethodDelete, endpoint+"/"+url.PathEscape(id), nil)
+	return err
+}
+
+// Find returns the resources matching a filter, of the first page of
+// results; it is meant for filters on unique attributes.
+func (c *Client) Find(ctx context.Context, endpoint, filter string) ([]map[string]any, error) {
+	list, err := c.do(ctx, http.MethodGet, endpoint+"?filter="+url.QueryEscape(filter), nil)
+	if err != nil {
+		return nil, err
+	}
+	var body struct {
+		Resources []map[string]any `json:"Resources"`
+	}
+	if err := remarshal(list, &body); err != nil {
+		return nil, fmt.Errorf("decoding list response: %w", err)
+	}
+	return body.Resources, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body map[string]any) (map[string]any, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encoding request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+"/"+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", ContentType)
+	if body != nil {
+		req.Header.Set("Content-Type", ContentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		e := &Error{Status: resp.StatusCode}
+		var scimErr struct {
+			ScimType string `json:"scimType"`
+			Detail   string `json:"detail"`
+		}
+		if json.Unmarshal(data, &scimErr) == nil {
+			e.ScimType, e.Detail = scimErr.ScimType, scimErr.Detail
+		}
+		return nil, e
+	}
+	if resp.StatusCode == http.StatusNoContent || len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	var res map[string]any
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return res, nil
+}
+
+// ID returns the id of a resource returned by the service provider.
+func ID(res map[string]any) string {
+	_, v, _ := filter.Lookup(res, "id")
+	id, _ := v.(string)
+	return id
+}
+
+// Eq returns a filter for resources whose attribute equals a string.
+func Eq(attr, value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return attr + ` eq "` + value + `"`
+}
+
+// Normalize returns a resource as it is encoded in JSON and decoded again,
+// with strings, float64 numbers, []any and map[string]any only, as
+// resources returned by the service provider are.
+func Normalize(res map[string]any) (map[string]any, error) {
+	var out map[string]any
+	if err := remarshal(res, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Covers reports whether a resource returned by the service provider has
+// the values of a normalized resource sent to it. Attribute names compare
+// case-insensitively. Attributes the service provider adds, such as meta
+// or the display of group members, are ignored, as are sub-attributes it
+// adds to complex values; the values of multi-valued attributes may be
+// reordered but no more may be added. Attributes missing from have match
+// only empty values in want.
+func Covers(have, want any) bool {
+	switch w := want.(type) {
+	case map[string]any:
+		h, ok := have.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, wv := range w {
+			_, hv, found := filter.Lookup(h, k)
+			if !found {
+				if !empty(wv) {
+					return false
+				}
+				continue
+			}
+			if !Covers(hv, wv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		if have == nil {
+			return len(w) == 0
+		}
+		h, ok := have.([]any)
+		if !ok || len(h) != len(w) {
+			return false
+		}
+		for _, wv := range w {
+			found := false
+			for _, hv := range h {
+				if Covers(hv, wv) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(have, want)
+	}
+}
+
+func empty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+func remarshal(in, out any) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
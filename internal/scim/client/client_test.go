@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCovers(t *testing.T) {
+	want := map[string]any{
+		"userName": "alice",
+		"active":   true,
+		"emails":   []any{map[string]any{"value": "alice@example.com", "primary": true}},
+		"members":  []any{},
+		"nickName": "",
+	}
+	tests := []struct {
+		name string
+		have map[string]any
+		ok   bool
+	}{
+		{"equal", map[string]any{
+			"userName": "alice", "active": true,
+			"emails": []any{map[string]any{"value": "alice@example.com", "primary": true}},
+		}, true},
+		{"added attributes", map[string]any{
+			"id": "1", "USERNAME": "alice", "active": true, "meta": map[string]any{},
+			"emails": []any{map[string]any{"value": "alice@example.com", "primary": true, "display": "Alice"}},
+		}, true},
+		{"changed value", map[string]any{
+			"userName": "alice", "active": false,
+			"emails": []any{map[string]any{"value": "alice@example.com", "primary": true}},
+		}, false},
+		{"missing attribute", map[string]any{
+			"userName": "alice",
+			"emails":   []any{map[string]any{"value": "alice@example.com", "primary": true}},
+		}, false},
+		{"added value", map[string]any{
+			"userName": "alice", "active": true,
+			"emails": []any{
+				map[string]any{"value": "alice@example.com", "primary": true},
+				map[string]any{"value": "other@example.com"},
+			},
+		}, false},
+		{"added member", map[string]any{
+			"userName": "alice", "active": true,
+			"emails":  []any{map[string]any{"value": "alice@example.com", "primary": true}},
+			"members": []any{map[string]any{"value": "2"}},
+		}, false},
+	}
+	for _, tt := range tests {
+		if got := Covers(tt.have, want); got != tt.ok {
+			t.Errorf("%s: Covers = %v, want %v", tt.name, got, tt.ok)
+		}
+	}
+}
+
+func TestEq(t *testing.T) {
+	if got, want := Eq("userName", `a"b\c`), `userName eq "a\"b\\c"`; got != want {
+		t.Errorf("Eq = %s, want %s", got, want)
+	}
+}
+
+func TestClientError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.URL.EscapedPath() != "/scim/Users/a%2Fb" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", ContentType)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status":"404","detail":"no such user"}`))
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL+"/scim/", "token", srv.Client()).Get(context.Background(), Users, "a/b")
+	var e *Error
+	if !errors.As(err, &e) || e.Status != http.StatusNotFound || e.Detail != "no such user" {
+		t.Fatalf("Get = %v, want the 404 error", err)
+	}
+	if !IsNotFound(err) {
+		t.Error("IsNotFound = false")
+	}
+}
@@ -0,0 +1,107 @@
+// Package filter implements the SCIM filter grammar (RFC 7644, section
+// 3.4.2.2) and attribute paths, and evaluates filters against resources
+// decoded from JSON.
+package filter
+
+import "fmt"
+
+// FilterType represents the type of a SCIM filter node.
+type FilterType int
+
+const (
+	// FilterAnd represents a logical "and" of its children.
+	FilterAnd FilterType = iota
+	// FilterOr represents a logical "or" of its children.
+	FilterOr
+	// FilterNot represents the negation of its only child.
+	FilterNot
+	// FilterCompare represents an attribute compared with a value.
+	FilterCompare
+	// FilterPresent represents a presence test (pr).
+	FilterPresent
+	// FilterValuePath represents a filter on the values of a complex
+	// multi-valued attribute, such as emails[type eq "work"].
+	FilterValuePath
+)
+
+// String returns a human-readable name for the filter type.
+func (ft FilterType) String() string {
+	switch ft {
+	case FilterAnd:
+		return "and"
+	case FilterOr:
+		return "or"
+	case FilterNot:
+		return "not"
+	case FilterCompare:
+		return "compare"
+	case FilterPresent:
+		return "pr"
+	case FilterValuePath:
+		return "valuePath"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(ft))
+	}
+}
+
+// Comparison operators, in lower case.
+const (
+	OpEqual          = "eq"
+	OpNotEqual       = "ne"
+	OpContains       = "co"
+	OpStartsWith     = "sw"
+	OpEndsWith       = "ew"
+	OpGreater        = "gt"
+	OpLess           = "lt"
+	OpGreaterOrEqual = "ge"
+	OpLessOrEqual    = "le"
+)
+
+// AttrPath names an attribute, optionally qualified with the URN of its
+// schema and followed by a sub-attribute, as in
+// urn:ietf:params:scim:schemas:core:2.0:User:name.givenName.
+type AttrPath struct {
+	// URN is the schema URN, or empty for an unqualified attribute.
+	URN string
+	// Name is the attribute name.
+	Name string
+	// Sub is the sub-attribute name, or empty.
+	Sub string
+}
+
+// String returns the path in SCIM notation.
+func (p AttrPath) String() string {
+	s := p.Name
+	if p.URN != "" {
+		s = p.URN + ":" + s
+	}
+	if p.Sub != "" {
+		s += "." + p.Sub
+	}
+	return s
+}
+
+// Filter represents a parsed SCIM filter as an abstract syntax tree node.
+type Filter struct {
+	// Type is the kind of filter.
+	Type FilterType
+	// Attr is the attribute of compare, presence and value path filters.
+	Attr AttrPath
+	// Op is the comparison operator of a compare filter.
+	Op string
+	// Value is the comparison value: a string, float64, bool or nil.
+	Value any
+	// Children holds the operands of and, or and not filters, and the
+	// filter on the values of a value path filter.
+	Children []*Filter
+}
+
+// Path is the target of a PATCH operation (RFC 7644, section 3.5.2): an
+// attribute path, optionally with a filter selecting values of a
+// multi-valued attribute and a sub-attribute of the selected values, as in
+// emails[type eq "work"].value.
+type Path struct {
+	AttrPath
+	// Filter selects values of a multi-valued attribute, or is nil.
+	Filter *Filter
+}
@@ -0,0 +1,220 @@
+package filter
+
+import (
+	"strings"
+	"time"
+)
+
+// Lookup returns the value of the named attribute of a resource or complex
+// value, matching the name case-insensitively as SCIM attribute names are,
+// together with the key it is stored under.
+func Lookup(m map[string]any, name string) (key string, v any, ok bool) {
+	if v, ok := m[name]; ok {
+		return name, v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, name) {
+			return k, v, true
+		}
+	}
+	return "", nil, false
+}
+
+// Container returns the map holding the attributes of a path's schema: the
+// extension object under the URN if the resource has one, or else the
+// resource itself, for attributes of the core schema.
+func Container(res map[string]any, p AttrPath) map[string]any {
+	if p.URN != "" {
+		if _, v, ok := Lookup(res, p.URN); ok {
+			if ext, ok := v.(map[string]any); ok {
+				return ext
+			}
+		}
+	}
+	return res
+}
+
+// Values returns the values of an attribute path in a resource. The values
+// of a multi-valued attribute are returned one by one. A complex value
+// without a sub-attribute in the path stands for its "value" sub-attribute,
+// so that emails co "example.com" tests the addresses.
+func Values(res map[string]any, p AttrPath) []any {
+	_, v, ok := Lookup(Container(res, p), p.Name)
+	if !ok || v == nil {
+		return nil
+	}
+	elems, ok := v.([]any)
+	if !ok {
+		elems = []any{v}
+	}
+
+	var values []any
+	for _, e := range elems {
+		sub := p.Sub
+		if m, ok := e.(map[string]any); ok {
+			if sub == "" {
+				sub = "value"
+			}
+			_, e, _ = Lookup(m, sub)
+		} else if sub != "" {
+			continue
+		}
+		if e != nil {
+			values = append(values, e)
+		}
+	}
+	return values
+}
+
+// Match reports whether a resource, or a value of a complex attribute,
+// matches the filter. Strings compare case-insensitively, and strings that
+// are both timestamps compare chronologically. A multi-valued attribute
+// matches if any of its values does.
+func (f *Filter) Match(res map[string]any) bool {
+	switch f.Type {
+	case FilterAnd:
+		for _, c := range f.Children {
+			if !c.Match(res) {
+				return false
+			}
+		}
+		return true
+	case FilterOr:
+		for _, c := range f.Children {
+			if c.Match(res) {
+				return true
+			}
+		}
+		return false
+	case FilterNot:
+		return !f.Children[0].Match(res)
+	case FilterPresent:
+		for _, v := range Values(res, f.Attr) {
+			if s, ok := v.(string); !ok || s != "" {
+				return true
+			}
+		}
+		return false
+	case FilterValuePath:
+		_, v, _ := Lookup(Container(res, f.Attr), f.Attr.Name)
+		elems, ok := v.([]any)
+		if !ok {
+			elems = []any{v}
+		}
+		for _, e := range elems {
+			if m, ok := e.(map[string]any); ok && f.Children[0].Match(m) {
+				return true
+			}
+		}
+		return false
+	case FilterCompare:
+		values := Values(res, f.Attr)
+		if f.Value == nil {
+			// eq null tests for absence, ne null for presence.
+			return (len(values) == 0) == (f.Op == OpEqual)
+		}
+		for _, v := range values {
+			if compare(v, f.Op, f.Value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// Equalities returns the attribute values required by a filter made only of
+// eq comparisons of simple attributes joined with and, such as
+// type eq "work" and primary eq true. For any other filter ok is false.
+// PATCH uses them to add a value that a filter found missing.
+func (f *Filter) Equalities() (values map[string]any, ok bool) {
+	values = make(map[string]any)
+	var collect func(f *Filter) bool
+	collect = func(f *Filter) bool {
+		switch f.Type {
+		case FilterAnd:
+			for _, c := range f.Children {
+				if !collect(c) {
+					return false
+				}
+			}
+			return true
+		case FilterCompare:
+			if f.Op != OpEqual || f.Value == nil || f.Attr.URN != "" || f.Attr.Sub != "" {
+				return false
+			}
+			values[f.Attr.Name] = f.Value
+			return true
+		default:
+			return false
+		}
+	}
+	if !collect(f) {
+		return nil, false
+	}
+	return values, true
+}
+
+// compare applies a comparison operator to an attribute value and the
+// value of a filter.
+func compare(actual any, op string, want any) bool {
+	switch w := want.(type) {
+	case string:
+		a, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		return compareStrings(a, op, w)
+	case float64:
+		a, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		return compareOrdered(a, op, w)
+	case bool:
+		a, ok := actual.(bool)
+		if !ok {
+			return false
+		}
+		return (a == w) == (op == OpEqual)
+	default:
+		return false
+	}
+}
+
+func compareStrings(a, op, w string) bool {
+	switch op {
+	case OpContains:
+		return strings.Contains(strings.ToLower(a), strings.ToLower(w))
+	case OpStartsWith:
+		return strings.HasPrefix(strings.ToLower(a), strings.ToLower(w))
+	case OpEndsWith:
+		return strings.HasSuffix(strings.ToLower(a), strings.ToLower(w))
+	}
+	if at, err := time.Parse(time.RFC3339Nano, a); err == nil {
+		if wt, err := time.Parse(time.RFC3339Nano, w); err == nil {
+			return compareOrdered(at.UnixNano(), op, wt.UnixNano())
+		}
+	}
+	return compareOrdered(strings.ToLower(a), op, strings.ToLower(w))
+}
+
+func compareOrdered[T int64 | float64 | string](a T, op string, w T) bool {
+	switch op {
+	case OpEqual:
+		return a == w
+	case OpNotEqual:
+		return a != w
+	case OpGreater:
+		return a > w
+	case OpLess:
+		return a < w
+	case OpGreaterOrEqual:
+		return a >= w
+	case OpLessOrEqual:
+		return a <= w
+	default:
+		return false
+	}
+}
@@ -0,0 +1,82 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testUser = `{
+	"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+	"id": "2819c223",
+	"userName": "bjensen",
+	"displayName": "Babs Jensen",
+	"active": true,
+	"emails": [
+		{"value": "bjensen@example.com", "type": "work", "primary": true},
+		{"value": "babs@jensen.org", "type": "home"}
+	],
+	"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {
+		"employeeNumber": "701984",
+		"manager": {"value": "26118915"}
+	},
+	"meta": {"lastModified": "2011-05-13T04:42:34.5Z"}
+}`
+
+func TestMatch(t *testing.T) {
+	var res map[string]any
+	if err := json.Unmarshal([]byte(testUser), &res); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{`userName eq "BJensen"`, true},
+		{`username eq "bjensen"`, true},
+		{`userName ne "bjensen"`, false},
+		{`displayName co "jens"`, true},
+		{`displayName sw "Babs"`, true},
+		{`displayName ew "Babs"`, false},
+		{`title pr`, false},
+		{`title eq null`, true},
+		{`displayName ne null`, true},
+		{`active eq true`, true},
+		{`active eq false`, false},
+		{`emails co "jensen.org"`, true},
+		{`emails.type eq "home"`, true},
+		{`emails[type eq "work" and value co "jensen.org"]`, false},
+		{`emails[type eq "home" and value co "jensen.org"]`, true},
+		{`emails[primary eq true]`, true},
+		{`meta.lastModified gt "2011-05-13T04:42:34Z"`, true},
+		{`meta.lastModified lt "2011-05-13T04:42:34Z"`, false},
+		{`urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:employeeNumber eq "701984"`, true},
+		{`urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager eq "26118915"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "bjensen"`, true},
+		{`userName eq "x" or not (active eq false)`, true},
+		{`userName eq "bjensen" and (emails.type eq "other" or title pr)`, false},
+	}
+	for _, tt := range tests {
+		f, err := Parse(tt.filter)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.filter, err)
+		}
+		if got := f.Match(res); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestEqualities(t *testing.T) {
+	f, _ := Parse(`type eq "work" and primary eq true`)
+	values, ok := f.Equalities()
+	if !ok || values["type"] != "work" || values["primary"] != true {
+		t.Errorf("Equalities() = %v, %v", values, ok)
+	}
+	for _, s := range []string{`type ne "work"`, `type eq "work" or type eq "home"`, `type pr`} {
+		f, _ := Parse(s)
+		if values, ok := f.Equalities(); ok {
+			t.Errorf("%s: Equalities() = %v, want none", s, values)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tokenKind classifies the tokens of a filter.
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpenParen
+	tokenCloseParen
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// Parse parses a SCIM filter such as
+//
+//	userName eq "bjensen" and (emails co "example.com" or not (title pr))
+//
+// into a Filter AST. Operators and keywords are case-insensitive. It returns
+// a descriptive error for malformed filters.
+func Parse(s string) (*Filter, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, fmt.Errorf("scim filter: %w", err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("scim filter: empty filter")
+	}
+	p := &parser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("scim filter: %w", err)
+	}
+	if t, ok := p.peek(); ok {
+		return nil, fmt.Errorf("scim filter: unexpected %q", t.text)
+	}
+	return f, nil
+}
+
+// ParsePath parses the path of a PATCH operation, such as members,
+// name.givenName, emails[type eq "work"].value or an attribute qualified
+// with its schema URN.
+func ParsePath(s string) (*Path, error) {
+	s = strings.TrimSpace(s)
+	open := strings.IndexByte(s, '[')
+	if open < 0 {
+		attr, err := parseAttrPath(s)
+		if err != nil {
+			return nil, fmt.Errorf("scim path: %w", err)
+		}
+		return &Path{AttrPath: attr}, nil
+	}
+
+	closing := strings.LastIndexByte(s, ']')
+	if closing < open {
+		return nil, fmt.Errorf("scim path: missing ] in %q", s)
+	}
+	attr, err := parseAttrPath(s[:open])
+	if err != nil {
+		return nil, fmt.Errorf("scim path: %w", err)
+	}
+	if attr.Sub != "" {
+		return nil, fmt.Errorf("scim path: filter on sub-attribute %q", attr)
+	}
+	f, err := Parse(s[open+1 : closing])
+	if err != nil {
+		return nil, err
+	}
+	rest := s[closing+1:]
+	if rest != "" {
+		if !strings.HasPrefix(rest, ".") || !validName(rest[1:]) {
+			return nil, fmt.Errorf("scim path: invalid sub-attribute %q", rest)
+		}
+		attr.Sub = rest[1:]
+	}
+	return &Path{AttrPath: attr, Filter: f}, nil
+}
+
+// tokenize splits a filter into words, quoted strings, parentheses and
+// brackets.
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		switch c := s[i]; c {
+		case ' ', '\t', '\n', '\r':
+			i++
+		case '(':
+			tokens = append(tokens, token{tokenOpenParen, "("})
+			i++
+		case ')':
+			tokens = append(tokens, token{tokenCloseParen, ")"})
+			i++
+		case '[':
+			tokens = append(tokens, token{tokenOpenBracket, "["})
+			i++
+		case ']':
+			tokens = append(tokens, token{tokenCloseBracket, "]"})
+			i++
+		case '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			var str string
+			if err := json.Unmarshal([]byte(s[i:end+1]), &str); err != nil {
+				return nil, fmt.Errorf("invalid string at offset %d: %w", i, err)
+			}
+			tokens = append(tokens, token{tokenString, str})
+			i = end + 1
+		default:
+			end := i
+			for end < len(s) && !strings.ContainsRune(" \t\n\r()[]\"", rune(s[end])) {
+				end++
+			}
+			tokens = append(tokens, token{tokenWord, s[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// parser is a recursive descent parser over the tokens of a filter:
+//
+//	or      = and *("or" and)
+//	and     = not *("and" not)
+//	not     = "not" "(" or ")" / primary
+//	primary = "(" or ")" / attrPath "[" or "]" / attrPath "pr" / attrPath op value
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) next() (token, bool) {
+	t, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return t, ok
+}
+
+// keyword consumes the next token if it is the given keyword.
+func (p *parser) keyword(kw string) bool {
+	t, ok := p.peek()
+	if ok && t.kind == tokenWord && strings.EqualFold(t.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	t, ok := p.next()
+	if !ok {
+		return fmt.Errorf("expected %q at end of filter", text)
+	}
+	if t.kind != kind {
+		return fmt.Errorf("expected %q, got %q", text, t.text)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (*Filter, error) {
+	return p.parseLogical(FilterOr, "or", p.parseAnd)
+}
+
+func (p *parser) parseAnd() (*Filter, error) {
+	return p.parseLogical(FilterAnd, "and", p.parseNot)
+}
+
+// parseLogical parses operands separated by the keyword of an and or or
+// filter, flattening them into a single node.
+func (p *parser) parseLogical(typ FilterType, kw string, operand func() (*Filter, error)) (*Filter, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	children := []*Filter{first}
+	for p.keyword(kw) {
+		f, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, f)
+	}
+	if len(children) == 1 {
+		return first, nil
+	}
+	return &Filter{Type: typ, Children: children}, nil
+}
+
+func (p *parser) parseNot() (*Filter, error) {
+	if !p.keyword("not") {
+		return p.parsePrimary()
+	}
+	if err := p.expect(tokenOpenParen, "("); err != nil {
+		return nil, err
+	}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(tokenCloseParen, ")"); err != nil {
+		return nil, err
+	}
+	return &Filter{Type: FilterNot, Children: []*Filter{f}}, nil
+}
+
+func (p *parser) parsePrimary() (*Filter, error) {
+	t, ok := p.next()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+	if t.kind == tokenOpenParen {
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseParen, ")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+	if t.kind != tokenWord {
+		return nil, fmt.Errorf("expected an attribute, got %q", t.text)
+	}
+	attr, err := parseAttrPath(t.text)
+	if err != nil {
+		return nil, err
+	}
+
+	if next, ok := p.peek(); ok && next.kind == tokenOpenBracket {
+		p.pos++
+		if attr.Sub != "" {
+			return nil, fmt.Errorf("filter on sub-attribute %q", attr)
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseBracket, "]"); err != nil {
+			return nil, err
+		}
+		return &Filter{Type: FilterValuePath, Attr: attr, Children: []*Filter{f}}, nil
+	}
+
+	op, ok := p.next()
+	if !ok || op.kind != tokenWord {
+		return nil, fmt.Errorf("expected an operator after %q", t.text)
+	}
+	switch o := strings.ToLower(op.text); o {
+	case "pr":
+		return &Filter{Type: FilterPresent, Attr: attr}, nil
+	case OpEqual, OpNotEqual, OpContains, OpStartsWith, OpEndsWith, OpGreater, OpLess, OpGreaterOrEqual, OpLessOrEqual:
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkOperand(o, v); err != nil {
+			return nil, err
+		}
+		return &Filter{Type: FilterCompare, Attr: attr, Op: o, Value: v}, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %q", op.text)
+	}
+}
+
+// parseValue parses a comparison value: a string, number, true, false or
+// null.
+func (p *parser) parseValue() (any, error) {
+	t, ok := p.next()
+	if !ok {
+		return nil, fmt.Errorf("expected a value at end of filter")
+	}
+	if t.kind == tokenString {
+		return t.text, nil
+	}
+	if t.kind != tokenWord {
+		return nil, fmt.Errorf("expected a value, got %q", t.text)
+	}
+	switch strings.ToLower(t.text) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	n, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q", t.text)
+	}
+	return n, nil
+}
+
+// checkOperand rejects operators that are undefined for a value's type,
+// such as ordering booleans.
+func checkOperand(op string, v any) error {
+	switch v.(type) {
+	case string:
+		return nil
+	case float64:
+		if op == OpContains || op == OpStartsWith || op == OpEndsWith {
+			return fmt.Errorf("operator %q needs a string", op)
+		}
+		return nil
+	default:
+		if op != OpEqual && op != OpNotEqual {
+			return fmt.Errorf("operator %q needs a string or number", op)
+		}
+		return nil
+	}
+}
+
+// parseAttrPath parses an attribute path. A URN prefix ends at the last
+// colon; the rest is the attribute name and an optional sub-attribute.
+func parseAttrPath(s string) (AttrPath, error) {
+	var attr AttrPath
+	name := s
+	if len(s) > 4 && strings.EqualFold(s[:4], "urn:") {
+		i := strings.LastIndexByte(s, ':')
+		attr.URN, name = s[:i], s[i+1:]
+	}
+	attr.Name, attr.Sub, _ = strings.Cut(name, ".")
+	if !validName(attr.Name) || (attr.Sub != "" && !validName(attr.Sub)) || strings.HasSuffix(name, ".") {
+		return AttrPath{}, fmt.Errorf("invalid attribute %q", s)
+	}
+	return attr, nil
+}
+
+// validName reports whether s is an attribute name: a letter followed by
+// letters, digits, hyphens, underscores and, for $ref, a leading dollar.
+func validName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i == 0 && c == '$':
+		case i > 0 && (c >= '0' && c <= '9' || c == '-' || c == '_'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package filter
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(t *testing.T, f *Filter)
+	}{
+		{
+			name:  "Equal",
+			input: `userName eq "bjensen"`,
+			check: func(t *testing.T, f *Filter) {
+				t.Helper()
+				if f.Type != FilterCompare || f.Attr.Name != "userName" || f.Op != OpEqual || f.Value != "bjensen" {
+					t.Errorf("got %+v", f)
+				}
+			},
+		},
+		{
+			name:  "Operators are case-insensitive",
+			input: `userName SW "J" AND title PR`,
+			check: func(t *testing.T, f *Filter) {
+				t.Helper()
+				if f.Type != FilterAnd || len(f.Children) != 2 || f.Children[0].Op != OpStartsWith || f.Children[1].Type != FilterPresent {
+					t.Errorf("got %+v", f)
+				}
+			},
+		},
+		{
+			name:  "And binds tighter than or",
+			input: `a eq 1 or b eq 2 and c eq 3`,
+			check: func(t *testing.T, f *Filter) {
+				t.Helper()
+				if f.Type != FilterOr || len(f.Children) != 2 || f.Children[1].Type != FilterAnd {
+					t.Errorf("got %+v", f)
+				}
+			},
+		},
+		{
+			name:  "Parentheses and not",
+			input: `not (active eq true or meta.lastModified gt "2011-05-13T04:42:34Z")`,
+			check: func(t *testing.T, f *Filter) {
+				t.Helper()
+				if f.Type != FilterNot || f.Children[0].Type != FilterOr {
+					t.Fatalf("got %+v", f)
+				}
+				lm := f.Children[0].Children[1]
+				if lm.Attr.Name != "meta" || lm.Attr.Sub != "lastModified" || lm.Op != OpGreater {
+					t.Errorf("got %+v", lm)
+				}
+			},
+		},
+		{
+			name:  "Value path",
+			input: `emails[type eq "work" and value co "@example.com"]`,
+			check: func(t *testing.T, f *Filter) {
+				t.Helper()
+				if f.Type != FilterValuePath || f.Attr.Name != "emails" || f.Children[0].Type != FilterAnd {
+					t.Errorf("got %+v", f)
+				}
+			},
+		},
+		{
+			name:  "Schema URN",
+			input: `urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager.value eq "26118915"`,
+			check: func(t *testing.T, f *Filter) {
+				t.Helper()
+				want := AttrPath{URN: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User", Name: "manager", Sub: "value"}
+				if f.Attr != want {
+					t.Errorf("attr = %+v, want %+v", f.Attr, want)
+				}
+			},
+		},
+		{
+			name:  "Values",
+			input: `a eq 1.5 and b eq false and c eq null and d eq "say \"hi\""`,
+			check: func(t *testing.T, f *Filter) {
+				t.Helper()
+				want := []any{1.5, false, nil, `say "hi"`}
+				for i, c := range f.Children {
+					if c.Value != want[i] {
+						t.Errorf("value %d = %#v, want %#v", i, c.Value, want[i])
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			tt.check(t, f)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName like "x"`,
+		`userName eq "x" and`,
+		`(userName eq "x"`,
+		`userName eq "x")`,
+		`userName eq "unterminated`,
+		`not userName eq "x"`,
+		`emails[type eq "work"`,
+		`active gt true`,
+		`age co 3`,
+		`1abc eq "x"`,
+		`name. eq "x"`,
+	} {
+		if f, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) = %+v, want an error", input, f)
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	p, err := ParsePath(`emails[type eq "work"].value`)
+	if err != nil {
+		t.Fatalf("ParsePath: %v", err)
+	}
+	if p.Name != "emails" || p.Sub != "value" || p.Filter == nil || p.Filter.Attr.Name != "type" {
+		t.Errorf("got %+v", p)
+	}
+
+	p, err = ParsePath("urn:ietf:params:scim:schemas:core:2.0:User:name.givenName")
+	if err != nil {
+		t.Fatalf("ParsePath: %v", err)
+	}
+	if p.URN != "urn:ietf:params:scim:schemas:core:2.0:User" || p.Name != "name" || p.Sub != "givenName" || p.Filter != nil {
+		t.Errorf("got %+v", p)
+	}
+
+	for _, input := range []string{``, `emails[type eq "work"`, `emails[type eq "work"]value`, `name.givenName[value eq "x"]`, `a b`} {
+		if p, err := ParsePath(input); err == nil {
+			t.Errorf("ParsePath(%q) = %+v, want an error", input, p)
+		}
+	}
+}
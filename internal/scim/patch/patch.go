@@ -0,0 +1,349 @@
+// Package patch applies SCIM PATCH operations (RFC 7644, section 3.5.2) to
+// resources decoded from JSON.
+package patch
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/qinzj/claude-demo/internal/scim/filter"
+)
+
+// Operation is one operation of a PATCH request.
+type Operation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// Error is a PATCH request that cannot be applied. ScimType is the SCIM
+// error type, such as invalidPath or noTarget.
+type Error struct {
+	ScimType string
+	Detail   string
+}
+
+func (e *Error) Error() string {
+	return "scim patch: " + e.Detail
+}
+
+func errorf(scimType, format string, args ...any) *Error {
+	return &Error{ScimType: scimType, Detail: fmt.Sprintf(format, args...)}
+}
+
+// Schema describes the resource type that operations apply to.
+type Schema struct {
+	// ID is the URN of the core schema. Attributes qualified with it are
+	// top-level attributes of the resource.
+	ID string
+	// Extensions are the URNs of schema extensions, whose attributes are
+	// held in an object under the URN.
+	Extensions []string
+	// MultiValued lists the multi-valued top-level attributes, to which add
+	// appends values.
+	MultiValued []string
+}
+
+func (s Schema) multiValued(p filter.AttrPath) bool {
+	if p.URN != "" && !strings.EqualFold(p.URN, s.ID) {
+		return false
+	}
+	return slices.ContainsFunc(s.MultiValued, func(name string) bool { return strings.EqualFold(name, p.Name) })
+}
+
+func (s Schema) isSchema(urn string) bool {
+	return strings.EqualFold(urn, s.ID) || slices.ContainsFunc(s.Extensions, func(e string) bool { return strings.EqualFold(e, urn) })
+}
+
+// Apply applies operations to a resource in order. Operation names and
+// attribute names are case-insensitive. Without a path, the value of add and
+// replace is an object whose members are applied as paths, which may also
+// name a schema whose attributes are to be set. The resource may be
+// partially modified when an error is returned.
+func Apply(res map[string]any, ops []Operation, s Schema) error {
+	for _, op := range ops {
+		if err := apply(res, op, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func apply(res map[string]any, op Operation, s Schema) error {
+	name := strings.ToLower(op.Op)
+	switch name {
+	case "add", "replace":
+	case "remove":
+		if op.Path == "" {
+			return errorf("noTarget", "remove needs a path")
+		}
+	default:
+		return errorf("invalidSyntax", "unknown operation %q", op.Op)
+	}
+
+	if op.Path == "" {
+		values, ok := op.Value.(map[string]any)
+		if !ok {
+			return errorf("invalidValue", "%s without a path needs an object value", name)
+		}
+		for k, v := range values {
+			if sub, ok := v.(map[string]any); ok && s.isSchema(k) {
+				for sk, sv := range sub {
+					if err := applyPath(res, name, k+":"+sk, sv, s); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err := applyPath(res, name, k, v, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return applyPath(res, name, op.Path, op.Value, s)
+}
+
+func applyPath(res map[string]any, op, path string, value any, s Schema) error {
+	p, err := filter.ParsePath(path)
+	if err != nil {
+		return errorf("invalidPath", "%v", err)
+	}
+	if op == "remove" {
+		return remove(res, p, value, s)
+	}
+	if value == nil {
+		return errorf("invalidValue", "%s %q needs a value", op, path)
+	}
+	if p.Filter != nil {
+		return setFiltered(res, p, value, s)
+	}
+	return set(res, p.AttrPath, value, op == "replace", s)
+}
+
+// container returns the map holding a path's attribute, creating the object
+// of a schema extension if needed.
+func container(res map[string]any, p filter.AttrPath, s Schema, create bool) map[string]any {
+	if p.URN == "" || strings.EqualFold(p.URN, s.ID) {
+		return res
+	}
+	key, v, ok := filter.Lookup(res, p.URN)
+	if ext, isMap := v.(map[string]any); ok && isMap {
+		return ext
+	}
+	if !create {
+		return nil
+	}
+	if !ok {
+		key = p.URN
+	}
+	ext := make(map[string]any)
+	res[key] = ext
+	return ext
+}
+
+// put sets an attribute, keeping the spelling of an existing key.
+func put(m map[string]any, name string, v any) {
+	if key, _, ok := filter.Lookup(m, name); ok {
+		name = key
+	}
+	m[name] = v
+}
+
+func del(m map[string]any, name string) {
+	if key, _, ok := filter.Lookup(m, name); ok {
+		delete(m, key)
+	}
+}
+
+// set adds or replaces the value of an attribute path without a filter.
+// Adding to a multi-valued attribute appends the values that are not there
+// yet; replacing it replaces all values. Complex values are merged, so that
+// sub-attributes that are not given are left unchanged.
+func set(res map[string]any, p filter.AttrPath, value any, replace bool, s Schema) error {
+	c := container(res, p, s, true)
+	_, cur, _ := filter.Lookup(c, p.Name)
+
+	if p.Sub != "" {
+		switch parent := cur.(type) {
+		case nil:
+			put(c, p.Name, map[string]any{p.Sub: value})
+		case map[string]any:
+			put(parent, p.Sub, value)
+		case []any:
+			for _, e := range parent {
+				if m, ok := e.(map[string]any); ok {
+					put(m, p.Sub, value)
+				}
+			}
+		default:
+			return errorf("invalidPath", "%q has no sub-attributes", p.Name)
+		}
+		return nil
+	}
+
+	if s.multiValued(p) {
+		values, ok := value.([]any)
+		if !ok {
+			values = []any{value}
+		}
+		if replace {
+			put(c, p.Name, values)
+			return nil
+		}
+		existing, _ := cur.([]any)
+		for _, v := range values {
+			if !slices.ContainsFunc(existing, func(e any) bool { return sameValue(e, v) }) {
+				existing = append(existing, v)
+			}
+		}
+		put(c, p.Name, existing)
+		return nil
+	}
+
+	if m, ok := cur.(map[string]any); ok {
+		if v, ok := value.(map[string]any); ok {
+			for k, sv := range v {
+				put(m, k, sv)
+			}
+			return nil
+		}
+	}
+	put(c, p.Name, value)
+	return nil
+}
+
+// setFiltered adds or replaces the values of a multi-valued attribute that
+// match the path's filter, or their sub-attribute. If none match and the
+// filter only requires sub-attributes to equal some values, such as
+// emails[type eq "work"], a value with them is added.
+func setFiltered(res map[string]any, p *filter.Path, value any, s Schema) error {
+	c := container(res, p.AttrPath, s, true)
+	_, cur, _ := filter.Lookup(c, p.Name)
+	elems, _ := cur.([]any)
+
+	update := func(m map[string]any) error {
+		if p.Sub != "" {
+			put(m, p.Sub, value)
+			return nil
+		}
+		v, ok := value.(map[string]any)
+		if !ok {
+			return errorf("invalidValue", "value of %q must be an object", p.Name)
+		}
+		for k, sv := range v {
+			put(m, k, sv)
+		}
+		return nil
+	}
+
+	matched := false
+	for _, e := range elems {
+		if m, ok := e.(map[string]any); ok && p.Filter.Match(m) {
+			matched = true
+			if err := update(m); err != nil {
+				return err
+			}
+		}
+	}
+	if matched {
+		return nil
+	}
+
+	m, ok := p.Filter.Equalities()
+	if !ok {
+		return errorf("noTarget", "no value of %q matches the filter", p.Name)
+	}
+	if err := update(m); err != nil {
+		return err
+	}
+	put(c, p.Name, append(elems, m))
+	return nil
+}
+
+// remove removes the value of an attribute path. A value list given with a
+// multi-valued attribute and no filter, as some clients send to remove
+// group members, removes only those values. Removing what is not there is
+// not an error.
+func remove(res map[string]any, p *filter.Path, value any, s Schema) error {
+	c := container(res, p.AttrPath, s, false)
+	if c == nil {
+		return nil
+	}
+	_, cur, ok := filter.Lookup(c, p.Name)
+	if !ok {
+		return nil
+	}
+	elems, multi := cur.([]any)
+
+	if p.Filter == nil {
+		switch {
+		case p.Sub != "" && multi:
+			for _, e := range elems {
+				if m, ok := e.(map[string]any); ok {
+					del(m, p.Sub)
+				}
+			}
+		case p.Sub != "":
+			if m, ok := cur.(map[string]any); ok {
+				del(m, p.Sub)
+			}
+		case multi && value != nil:
+			values, ok := value.([]any)
+			if !ok {
+				values = []any{value}
+			}
+			kept := slices.DeleteFunc(slices.Clone(elems), func(e any) bool {
+				return slices.ContainsFunc(values, func(v any) bool { return sameValue(e, v) })
+			})
+			putOrDelete(c, p.Name, kept)
+		default:
+			del(c, p.Name)
+		}
+		return nil
+	}
+
+	if !multi {
+		return nil
+	}
+	kept := elems[:0:0]
+	for _, e := range elems {
+		m, ok := e.(map[string]any)
+		if !ok || !p.Filter.Match(m) {
+			kept = append(kept, e)
+			continue
+		}
+		if p.Sub != "" {
+			del(m, p.Sub)
+			kept = append(kept, m)
+		}
+	}
+	putOrDelete(c, p.Name, kept)
+	return nil
+}
+
+func putOrDelete(m map[string]any, name string, values []any) {
+	if len(values) == 0 {
+		del(m, name)
+		return
+	}
+	put(m, name, values)
+}
+
+// sameValue reports whether two values of a multi-valued attribute are the
+// same: complex values with a "value" sub-attribute, such as group members,
+// are identified by it.
+func sameValue(a, b any) bool {
+	am, aok := a.(map[string]any)
+	bm, bok := b.(map[string]any)
+	if aok && bok {
+		_, av, aok := filter.Lookup(am, "value")
+		_, bv, bok := filter.Lookup(bm, "value")
+		if aok && bok {
+			return reflect.DeepEqual(av, bv)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,151 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const enterprise = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+
+var userSchema = Schema{
+	ID:          "urn:ietf:params:scim:schemas:core:2.0:User",
+	Extensions:  []string{enterprise},
+	MultiValued: []string{"emails", "members"},
+}
+
+func decode(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatalf("decode %s: %v", s, err)
+	}
+	return m
+}
+
+func decodeOps(t *testing.T, s string) []Operation {
+	t.Helper()
+	var ops []Operation
+	if err := json.Unmarshal([]byte(s), &ops); err != nil {
+		t.Fatalf("decode %s: %v", s, err)
+	}
+	return ops
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name string
+		res  string
+		ops  string
+		want string
+	}{
+		{
+			name: "Replace simple attribute",
+			res:  `{"displayName": "Babs"}`,
+			ops:  `[{"op": "Replace", "path": "displayname", "value": "Barbara"}]`,
+			want: `{"displayName": "Barbara"}`,
+		},
+		{
+			name: "Without a path",
+			res:  `{"displayName": "Babs", "active": true}`,
+			ops:  `[{"op": "replace", "value": {"active": false, "name.givenName": "Barbara"}}]`,
+			want: `{"displayName": "Babs", "active": false, "name": {"givenName": "Barbara"}}`,
+		},
+		{
+			name: "Merge complex attribute",
+			res:  `{"name": {"givenName": "Babs", "familyName": "Jensen"}}`,
+			ops:  `[{"op": "add", "path": "name", "value": {"givenName": "Barbara"}}]`,
+			want: `{"name": {"givenName": "Barbara", "familyName": "Jensen"}}`,
+		},
+		{
+			name: "Add appends new values",
+			res:  `{"members": [{"value": "1"}]}`,
+			ops:  `[{"op": "add", "path": "members", "value": [{"value": "1"}, {"value": "2"}]}]`,
+			want: `{"members": [{"value": "1"}, {"value": "2"}]}`,
+		},
+		{
+			name: "Replace replaces all values",
+			res:  `{"members": [{"value": "1"}]}`,
+			ops:  `[{"op": "replace", "path": "members", "value": [{"value": "2"}]}]`,
+			want: `{"members": [{"value": "2"}]}`,
+		},
+		{
+			name: "Replace filtered sub-attribute",
+			res:  `{"emails": [{"value": "a@x.com", "type": "work"}, {"value": "b@y.com", "type": "home"}]}`,
+			ops:  `[{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "c@x.com"}]`,
+			want: `{"emails": [{"value": "c@x.com", "type": "work"}, {"value": "b@y.com", "type": "home"}]}`,
+		},
+		{
+			name: "Filter without a match adds a value",
+			res:  `{}`,
+			ops:  `[{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "a@x.com"}]`,
+			want: `{"emails": [{"type": "work", "value": "a@x.com"}]}`,
+		},
+		{
+			name: "Extension attribute",
+			res:  `{}`,
+			ops: `[{"op": "add", "path": "` + enterprise + `:manager", "value": {"value": "42"}},
+				{"op": "add", "value": {"` + enterprise + `": {"employeeNumber": "7"}}}]`,
+			want: `{"` + enterprise + `": {"manager": {"value": "42"}, "employeeNumber": "7"}}`,
+		},
+		{
+			name: "Core schema URN",
+			res:  `{"userName": "babs"}`,
+			ops:  `[{"op": "replace", "path": "urn:ietf:params:scim:schemas:core:2.0:User:userName", "value": "bjensen"}]`,
+			want: `{"userName": "bjensen"}`,
+		},
+		{
+			name: "Remove filtered values",
+			res:  `{"members": [{"value": "1"}, {"value": "2"}]}`,
+			ops:  `[{"op": "remove", "path": "members[value eq \"1\"]"}]`,
+			want: `{"members": [{"value": "2"}]}`,
+		},
+		{
+			name: "Remove listed values",
+			res:  `{"members": [{"value": "1"}, {"value": "2"}]}`,
+			ops:  `[{"op": "remove", "path": "members", "value": [{"value": "2"}, {"value": "1"}]}]`,
+			want: `{}`,
+		},
+		{
+			name: "Remove attribute and sub-attribute",
+			res:  `{"title": "Boss", "name": {"givenName": "Babs", "familyName": "Jensen"}}`,
+			ops:  `[{"op": "remove", "path": "title"}, {"op": "remove", "path": "name.givenName"}, {"op": "remove", "path": "nickName"}]`,
+			want: `{"name": {"familyName": "Jensen"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := decode(t, tt.res)
+			if err := Apply(res, decodeOps(t, tt.ops), userSchema); err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if want := decode(t, tt.want); !reflect.DeepEqual(res, want) {
+				t.Errorf("got %v, want %v", res, want)
+			}
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		ops      string
+		scimType string
+	}{
+		{`[{"op": "move", "path": "title", "value": "x"}]`, "invalidSyntax"},
+		{`[{"op": "remove"}]`, "noTarget"},
+		{`[{"op": "add", "value": "x"}]`, "invalidValue"},
+		{`[{"op": "add", "path": "title"}]`, "invalidValue"},
+		{`[{"op": "add", "path": "emails[type eq", "value": "x"}]`, "invalidPath"},
+		{`[{"op": "replace", "path": "emails[type ne \"work\"].value", "value": "x"}]`, "noTarget"},
+		{`[{"op": "replace", "path": "userName.first", "value": "x"}]`, "invalidPath"},
+	}
+	for _, tt := range tests {
+		err := Apply(map[string]any{"userName": "babs"}, decodeOps(t, tt.ops), userSchema)
+		var perr *Error
+		if !errors.As(err, &perr) || perr.ScimType != tt.scimType {
+			t.Errorf("%s: err = %v, want %s", tt.ops, err, tt.scimType)
+		}
+	}
+}
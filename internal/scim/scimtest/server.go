@@ -0,0 +1,237 @@
+// Package scimtest provides an in-memory SCIM service provider for testing
+// provisioning, in the manner of net/http/httptest.
+package scimtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/qinzj/claude-demo/internal/scim/filter"
+)
+
+// uniqueAttributes are the attributes that must be unique among the
+// resources of each endpoint, compared case-insensitively.
+var uniqueAttributes = map[string]string{
+	"Users":  "userName",
+	"Groups": "displayName",
+}
+
+// Server is a SCIM service provider serving the Users and Groups endpoints
+// from memory. It accepts requests with the bearer token it was created
+// with, and stores resources as given, adding an id and meta. List
+// requests support filters but not pagination.
+type Server struct {
+	*httptest.Server
+	token string
+
+	mu        sync.Mutex
+	resources map[string]map[string]map[string]any
+	requests  []string
+	failures  int
+	nextID    int
+}
+
+// NewServer starts a Server accepting token, closed when the test ends.
+// Its base URL is the URL of the httptest server.
+func NewServer(t testing.TB, token string) *Server {
+	s := &Server{
+		token:     token,
+		resources: map[string]map[string]map[string]any{"Users": {}, "Groups": {}},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Resources returns copies of the resources of an endpoint.
+func (s *Server) Resources(endpoint string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []map[string]any
+	for _, res := range s.resources[endpoint] {
+		items = append(items, clone(res))
+	}
+	return items
+}
+
+// Find returns a copy of the resource of an endpoint whose attribute
+// equals a string, or nil.
+func (s *Server) Find(endpoint, attr, value string) map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, res := range s.resources[endpoint] {
+		if _, v, _ := filter.Lookup(res, attr); v == value {
+			return clone(res)
+		}
+	}
+	return nil
+}
+
+// Set stores a resource under its id, as a change made by someone else.
+func (s *Server) Set(endpoint string, res map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resources[endpoint][res["id"].(string)] = clone(res)
+}
+
+// Remove deletes a resource, as a change made by someone else.
+func (s *Server) Remove(endpoint, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.resources[endpoint], id)
+}
+
+// Requests returns the method and path of the requests served since the
+// last call, such as "PUT /Users/3".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.requests
+	s.requests = nil
+	return r
+}
+
+// Fail makes the next n requests fail with 500.
+func (s *Server) Fail(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = n
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+		writeError(w, http.StatusUnauthorized, "", "invalid token")
+		return
+	}
+	if s.failures > 0 {
+		s.failures--
+		writeError(w, http.StatusInternalServerError, "", "injected failure")
+		return
+	}
+
+	endpoint, id, _ := strings.Cut(strings.Trim(r.URL.Path, "/"), "/")
+	resources, ok := s.resources[endpoint]
+	if !ok {
+		writeError(w, http.StatusNotFound, "", "unknown endpoint")
+		return
+	}
+
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		s.list(w, r, resources)
+	case id == "" && r.Method == http.MethodPost:
+		res, ok := decode(w, r)
+		if !ok {
+			return
+		}
+		if s.taken(endpoint, "", res) {
+			writeError(w, http.StatusConflict, "uniqueness", "resource exists")
+			return
+		}
+		s.nextID++
+		res["id"] = strconv.Itoa(s.nextID)
+		res["meta"] = map[string]any{"resourceType": strings.TrimSuffix(endpoint, "s")}
+		resources[res["id"].(string)] = res
+		write(w, http.StatusCreated, res)
+	case resources[id] == nil:
+		writeError(w, http.StatusNotFound, "", "resource not found")
+	case r.Method == http.MethodGet:
+		write(w, http.StatusOK, resources[id])
+	case r.Method == http.MethodPut:
+		res, ok := decode(w, r)
+		if !ok {
+			return
+		}
+		if s.taken(endpoint, id, res) {
+			writeError(w, http.StatusConflict, "uniqueness", "resource exists")
+			return
+		}
+		res["id"] = id
+		res["meta"] = resources[id]["meta"]
+		resources[id] = res
+		write(w, http.StatusOK, res)
+	case r.Method == http.MethodDelete:
+		delete(resources, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "", "method not allowed")
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, resources map[string]map[string]any) {
+	var f *filter.Filter
+	if q := r.URL.Query().Get("filter"); q != "" {
+		var err error
+		if f, err = filter.Parse(q); err != nil {
+			writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+			return
+		}
+	}
+	items := []map[string]any{}
+	for _, res := range resources {
+		if f == nil || f.Match(res) {
+			items = append(items, res)
+		}
+	}
+	write(w, http.StatusOK, map[string]any{
+		"schemas":      []string{"urn:ietf:params:scim:api:messages:2.0:ListResponse"},
+		"totalResults": len(items),
+		"Resources":    items,
+	})
+}
+
+// taken reports whether a resource other than the one with the given id
+// has the unique attribute of res.
+func (s *Server) taken(endpoint, id string, res map[string]any) bool {
+	attr := uniqueAttributes[endpoint]
+	_, v, _ := filter.Lookup(res, attr)
+	value, _ := v.(string)
+	for other, existing := range s.resources[endpoint] {
+		_, ev, _ := filter.Lookup(existing, attr)
+		if s, ok := ev.(string); ok && other != id && strings.EqualFold(s, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func decode(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	var res map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return nil, false
+	}
+	return res, true
+}
+
+func write(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, scimType, detail string) {
+	write(w, status, map[string]any{
+		"schemas":  []string{"urn:ietf:params:scim:api:messages:2.0:Error"},
+		"status":   strconv.Itoa(status),
+		"scimType": scimType,
+		"detail":   detail,
+	})
+}
+
+// clone deep-copies a resource decoded from JSON.
+func clone(res map[string]any) map[string]any {
+	b, _ := json.Marshal(res)
+	var out map[string]any
+	_ = json.Unmarshal(b, &out)
+	return out
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+// ErrForbidden is returned when the acting user is not allowed to perform an operation.
+var ErrForbidden = errors.New("forbidden")
+
+// WithActor returns a context that records the user performing the request.
+// Services use it to enforce per-object permissions such as group ownership.
+func WithActor(ctx context.Context, actor *domain.Actor) context.Context {
+	return context.WithValue(ctx, domain.ActorContextKey{}, actor)
+}
+
+// ActorFromContext returns the acting user recorded by WithActor.
+// The second result is false for internal calls made without an actor.
+func ActorFromContext(ctx context.Context) (*domain.Actor, bool) {
+	actor, ok := ctx.Value(domain.ActorContextKey{}).(*domain.Actor)
+	return actor, ok && actor != nil
+}
+
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a context that records the client issuing the request.
+func WithRequestInfo(ctx context.Context, info domain.RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the client recorded by WithRequestInfo, or
+// the zero value for internal calls.
+func RequestInfoFromContext(ctx context.Context) domain.RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(domain.RequestInfo)
+	return info
+}
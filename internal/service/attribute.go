@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ldap/attrs"
+)
+
+// ErrInvalidAttributes is returned when extension attribute values or
+// definitions fail validation.
+var ErrInvalidAttributes = errors.New("invalid attributes")
+
+var (
+	attributeNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,63}$`)
+	ldapNamePattern      = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]{0,63}$`)
+)
+
+// AttributeService manages the extension attribute schema.
+type AttributeService struct {
+	dao *dao.DAO
+}
+
+// NewAttributeService creates a new AttributeService.
+func NewAttributeService(d *dao.DAO) *AttributeService {
+	return &AttributeService{dao: d}
+}
+
+// CreateDefinition declares a new extension attribute after validating its names.
+func (s *AttributeService) CreateDefinition(ctx context.Context, input domain.CreateAttributeDefinitionInput) (*domain.AttributeDefinition, error) {
+	if !attributeNamePattern.MatchString(input.Name) {
+		return nil, fmt.Errorf("%w: name %q must start with a letter and contain only letters, digits and underscores", ErrInvalidAttributes, input.Name)
+	}
+	switch input.Target {
+	case domain.AttributeTargetUser, domain.AttributeTargetGroup:
+	default:
+		return nil, fmt.Errorf("%w: unknown target %q", ErrInvalidAttributes, input.Target)
+	}
+	switch input.Type {
+	case "":
+		input.Type = domain.AttributeTypeString
+	case domain.AttributeTypeString, domain.AttributeTypeInteger, domain.AttributeTypeBoolean:
+	default:
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidAttributes, input.Type)
+	}
+	if input.LDAPName == "" {
+		input.LDAPName = input.Name
+	}
+	if err := validateLDAPName(input.LDAPName); err != nil {
+		return nil, err
+	}
+	def, err := s.dao.CreateAttributeDefinition(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditAttributeCreate, def, AuditChanges(nil, def)); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// GetDefinition retrieves an extension attribute definition by ID.
+func (s *AttributeService) GetDefinition(ctx context.Context, id uuid.UUID) (*domain.AttributeDefinition, error) {
+	return s.dao.GetAttributeDefinition(ctx, id)
+}
+
+// ListDefinitions returns extension attribute definitions, optionally for one target.
+func (s *AttributeService) ListDefinitions(ctx context.Context, target domain.AttributeTarget) ([]*domain.AttributeDefinition, error) {
+	return s.dao.ListAttributeDefinitions(ctx, target)
+}
+
+// UpdateDefinition updates the mutable fields of an extension attribute definition.
+func (s *AttributeService) UpdateDefinition(ctx context.Context, id uuid.UUID, input domain.UpdateAttributeDefinitionInput) (*domain.AttributeDefinition, error) {
+	if input.LDAPName != nil {
+		if err := validateLDAPName(*input.LDAPName); err != nil {
+			return nil, err
+		}
+	}
+	before, err := s.dao.GetAttributeDefinition(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	def, err := s.dao.UpdateAttributeDefinition(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditAttributeUpdate, def, AuditChanges(before, def)); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// DeleteDefinition deletes an extension attribute definition and all its values.
+func (s *AttributeService) DeleteDefinition(ctx context.Context, id uuid.UUID) error {
+	def, err := s.dao.GetAttributeDefinition(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.DeleteAttributeDefinition(ctx, id); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditAttributeDelete, def, AuditChanges(def, nil))
+}
+
+// audit records an operation on an extension attribute definition in the
+// audit trail.
+func (s *AttributeService) audit(ctx context.Context, action string, def *domain.AttributeDefinition, changes []domain.AuditChange) error {
+	return recordAudit(ctx, s.dao, &domain.AuditEvent{
+		Action:     action,
+		TargetType: domain.AuditTargetAttribute,
+		TargetID:   def.ID.String(),
+		TargetName: def.Name,
+		Changes:    changes,
+	})
+}
+
+func validateLDAPName(name string) error {
+	if !ldapNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: ldap_name %q must start with a letter and contain only letters, digits and hyphens", ErrInvalidAttributes, name)
+	}
+	if attrs.IsReservedAttribute(name) {
+		return fmt.Errorf("%w: ldap_name %q is a built-in LDAP attribute", ErrInvalidAttributes, name)
+	}
+	return nil
+}
+
+// validateAttributeValues checks values against the declared schema and returns
+// them normalized. When creating, every required attribute must be present.
+func validateAttributeValues(defs []*domain.AttributeDefinition, values map[string][]string, creating bool) (map[string][]string, error) {
+	byName := make(map[string]*domain.AttributeDefinition, len(defs))
+	for _, def := range defs {
+		byName[def.Name] = def
+	}
+
+	normalized := make(map[string][]string, len(values))
+	for name, vals := range values {
+		def, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown attribute %q", ErrInvalidAttributes, name)
+		}
+		if len(vals) > 1 && !def.MultiValued {
+			return nil, fmt.Errorf("%w: attribute %q is single-valued", ErrInvalidAttributes, name)
+		}
+		if len(vals) == 0 && def.Required {
+			return nil, fmt.Errorf("%w: attribute %q is required", ErrInvalidAttributes, name)
+		}
+
+		out := make([]string, 0, len(vals))
+		seen := make(map[string]bool, len(vals))
+		for _, v := range vals {
+			nv, err := normalizeAttributeValue(def, v)
+			if err != nil {
+				return nil, err
+			}
+			if seen[nv] {
+				continue
+			}
+			seen[nv] = true
+			out = append(out, nv)
+		}
+		normalized[name] = out
+	}
+
+	if creating {
+		for _, def := range defs {
+			if def.Required && len(normalized[def.Name]) == 0 {
+				return nil, fmt.Errorf("%w: attribute %q is required", ErrInvalidAttributes, def.Name)
+			}
+		}
+	}
+	return normalized, nil
+}
+
+// validateAttributeFilter checks that every filtered attribute is declared and
+// indexed, and normalizes the filter values so they match stored values.
+func validateAttributeFilter(defs []*domain.AttributeDefinition, filter map[string]string) (map[string]string, error) {
+	byName := make(map[string]*domain.AttributeDefinition, len(defs))
+	for _, def := range defs {
+		byName[def.Name] = def
+	}
+
+	normalized := make(map[string]string, len(filter))
+	for name, v := range filter {
+		def, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown attribute %q", ErrInvalidAttributes, name)
+		}
+		if !def.Indexed {
+			return nil, fmt.Errorf("%w: attribute %q is not indexed for search", ErrInvalidAttributes, name)
+		}
+		nv, err := normalizeAttributeValue(def, v)
+		if err != nil {
+			return nil, err
+		}
+		normalized[name] = nv
+	}
+	return normalized, nil
+}
+
+// normalizeAttributeValue validates a single value against the attribute type.
+// Integers are stored in canonical decimal form and booleans use the LDAP
+// Boolean syntax (TRUE/FALSE).
+func normalizeAttributeValue(def *domain.AttributeDefinition, v string) (string, error) {
+	switch def.Type {
+	case domain.AttributeTypeInteger:
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%w: attribute %q expects an integer, got %q", ErrInvalidAttributes, def.Name, v)
+		}
+		return strconv.FormatInt(n, 10), nil
+	case domain.AttributeTypeBoolean:
+		b, err := strconv.ParseBool(strings.ToLower(strings.TrimSpace(v)))
+		if err != nil {
+			return "", fmt.Errorf("%w: attribute %q expects a boolean, got %q", ErrInvalidAttributes, def.Name, v)
+		}
+		if b {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	default:
+		if v == "" {
+			return "", fmt.Errorf("%w: attribute %q must not contain empty values", ErrInvalidAttributes, def.Name)
+		}
+		if len(v) > 1024 {
+			return "", fmt.Errorf("%w: attribute %q value exceeds 1024 characters", ErrInvalidAttributes, def.Name)
+		}
+		return v, nil
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent/enttest"
+	"github.com/qinzj/claude-demo/internal/password"
+)
+
+func setupAttributeService(t *testing.T) (*AttributeService, *UserService, context.Context) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	d := dao.New(client)
+	ctx := context.Background()
+	if err := d.AutoMigrate(ctx); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	return NewAttributeService(d), NewUserService(d, domain.LockoutPolicy{}, password.Hasher{}), ctx
+}
+
+func TestAttributeServiceCreateDefinition(t *testing.T) {
+	svc, _, ctx := setupAttributeService(t)
+
+	tests := []struct {
+		name    string
+		input   domain.CreateAttributeDefinitionInput
+		wantErr bool
+	}{
+		{"valid", domain.CreateAttributeDefinitionInput{Name: "department", Target: domain.AttributeTargetUser}, false},
+		{"invalid name", domain.CreateAttributeDefinitionInput{Name: "1dept", Target: domain.AttributeTargetUser}, true},
+		{"unknown target", domain.CreateAttributeDefinitionInput{Name: "title", Target: "device"}, true},
+		{"unknown type", domain.CreateAttributeDefinitionInput{Name: "title", Target: domain.AttributeTargetUser, Type: "float"}, true},
+		{"reserved ldap name", domain.CreateAttributeDefinitionInput{Name: "email2", Target: domain.AttributeTargetUser, LDAPName: "mail"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateDefinition(ctx, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateDefinition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	def, err := svc.CreateDefinition(ctx, domain.CreateAttributeDefinitionInput{Name: "title", Target: domain.AttributeTargetUser})
+	if err != nil {
+		t.Fatalf("CreateDefinition: %v", err)
+	}
+	if def.LDAPName != "title" || def.Type != domain.AttributeTypeString {
+		t.Errorf("defaults = (%q, %q), want (title, string)", def.LDAPName, def.Type)
+	}
+}
+
+func TestUserServiceAttributes(t *testing.T) {
+	attrSvc, svc, ctx := setupAttributeService(t)
+
+	for _, in := range []domain.CreateAttributeDefinitionInput{
+		{Name: "department", Target: domain.AttributeTargetUser, Required: true, Indexed: true},
+		{Name: "employeeNumber", Target: domain.AttributeTargetUser, Type: domain.AttributeTypeInteger},
+		{Name: "skills", Target: domain.AttributeTargetUser, MultiValued: true},
+		{Name: "contractor", Target: domain.AttributeTargetUser, Type: domain.AttributeTypeBoolean},
+	} {
+		if _, err := attrSvc.CreateDefinition(ctx, in); err != nil {
+			t.Fatalf("CreateDefinition(%s): %v", in.Name, err)
+		}
+	}
+
+	base := domain.CreateUserInput{
+		Username:    "john",
+		DisplayName: "John Doe",
+		Email:       "john@example.com",
+		Password:    "password123",
+	}
+
+	t.Run("missing required attribute", func(t *testing.T) {
+		_, err := svc.CreateUser(ctx, base)
+		if !errors.Is(err, ErrInvalidAttributes) {
+			t.Errorf("CreateUser() error = %v, want ErrInvalidAttributes", err)
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		cases := []map[string][]string{
+			{"department": {"Eng"}, "employeeNumber": {"abc"}},
+			{"department": {"Eng", "Ops"}},
+			{"department": {"Eng"}, "unknown": {"x"}},
+			{"department": {"Eng"}, "contractor": {"maybe"}},
+		}
+		for _, attrs := range cases {
+			in := base
+			in.Attributes = attrs
+			if _, err := svc.CreateUser(ctx, in); !errors.Is(err, ErrInvalidAttributes) {
+				t.Errorf("CreateUser(%v) error = %v, want ErrInvalidAttributes", attrs, err)
+			}
+		}
+	})
+
+	in := base
+	in.Attributes = map[string][]string{
+		"department":     {"Engineering"},
+		"employeeNumber": {" 0042 "},
+		"skills":         {"go", "ldap", "go"},
+		"contractor":     {"true"},
+	}
+	u, err := svc.CreateUser(ctx, in)
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if got := u.Attributes["employeeNumber"]; len(got) != 1 || got[0] != "42" {
+		t.Errorf("employeeNumber = %v, want [42]", got)
+	}
+	if got := u.Attributes["skills"]; len(got) != 2 {
+		t.Errorf("skills = %v, want 2 deduplicated values", got)
+	}
+	if got := u.Attributes["contractor"]; len(got) != 1 || got[0] != "TRUE" {
+		t.Errorf("contractor = %v, want [TRUE]", got)
+	}
+
+	t.Run("update replaces listed attributes only", func(t *testing.T) {
+		updated, err := svc.UpdateUser(ctx, u.ID, domain.UpdateUserInput{
+			Attributes: map[string][]string{"skills": {}, "department": {"Sales"}},
+		})
+		if err != nil {
+			t.Fatalf("UpdateUser: %v", err)
+		}
+		if _, ok := updated.Attributes["skills"]; ok {
+			t.Error("skills should be removed")
+		}
+		if got := updated.Attributes["department"]; len(got) != 1 || got[0] != "Sales" {
+			t.Errorf("department = %v, want [Sales]", got)
+		}
+		if got := updated.Attributes["employeeNumber"]; len(got) != 1 {
+			t.Errorf("employeeNumber = %v, should be untouched", got)
+		}
+	})
+
+	t.Run("cannot clear required attribute", func(t *testing.T) {
+		_, err := svc.UpdateUser(ctx, u.ID, domain.UpdateUserInput{
+			Attributes: map[string][]string{"department": {}},
+		})
+		if !errors.Is(err, ErrInvalidAttributes) {
+			t.Errorf("UpdateUser() error = %v, want ErrInvalidAttributes", err)
+		}
+	})
+
+	t.Run("list by indexed attribute", func(t *testing.T) {
+		result, err := svc.ListUsers(ctx, domain.ListUsersInput{Attributes: map[string]string{"department": "Sales"}})
+		if err != nil {
+			t.Fatalf("ListUsers: %v", err)
+		}
+		if result.Total != 1 {
+			t.Errorf("Total = %d, want 1", result.Total)
+		}
+	})
+
+	t.Run("list by non-indexed attribute", func(t *testing.T) {
+		_, err := svc.ListUsers(ctx, domain.ListUsersInput{Attributes: map[string]string{"employeeNumber": "42"}})
+		if !errors.Is(err, ErrInvalidAttributes) {
+			t.Errorf("ListUsers() error = %v, want ErrInvalidAttributes", err)
+		}
+	})
+}
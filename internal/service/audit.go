@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+// AuditService queries the audit trail. The other services append to it
+// as they make changes and authenticate users; Record is for changes made
+// outside them, such as to the LDAP configuration.
+type AuditService struct {
+	dao *dao.DAO
+}
+
+// NewAuditService creates a new AuditService.
+func NewAuditService(d *dao.DAO) *AuditService {
+	return &AuditService{dao: d}
+}
+
+// List returns a page of the audit events matching the filter, newest first.
+func (s *AuditService) List(ctx context.Context, filter domain.AuditFilter, page, pageSize int) (*domain.ListResult[domain.AuditEvent], error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	return s.dao.ListAuditEvents(ctx, filter, page, pageSize)
+}
+
+// Export calls fn for every audit event matching the filter, oldest first.
+func (s *AuditService) Export(ctx context.Context, filter domain.AuditFilter, fn func(*domain.AuditEvent) error) error {
+	return s.dao.EachAuditEvent(ctx, filter, fn)
+}
+
+// Record appends an event to the audit trail, with the actor and client
+// taken from the context.
+func (s *AuditService) Record(ctx context.Context, e *domain.AuditEvent) error {
+	return recordAudit(ctx, s.dao, e)
+}
+
+// recordAudit appends an event to the audit trail. The client and request
+// ID are taken from the context, as is the actor unless the event names
+// one, as login events do. The outcome defaults to success.
+func recordAudit(ctx context.Context, d *dao.DAO, e *domain.AuditEvent) error {
+	info := RequestInfoFromContext(ctx)
+	e.Protocol = info.Protocol
+	e.IPAddress = info.IPAddress
+	e.RequestID = info.RequestID
+	if e.Outcome == "" {
+		e.Outcome = domain.AuditSuccess
+	}
+	if actor, ok := ActorFromContext(ctx); ok && e.ActorType == "" {
+		e.ActorType = domain.AuditActorUser
+		id := actor.UserID
+		if actor.IsServiceAccount() {
+			e.ActorType = domain.AuditActorServiceAccount
+			id = actor.ServiceAccountID
+		}
+		e.ActorID = &id
+		e.ActorName = actor.Name
+	}
+	return d.CreateAuditEvent(ctx, e)
+}
+
+// recordLoginAudit records a login, or a bind over LDAP, by a user. u is
+// nil for unknown usernames.
+func recordLoginAudit(ctx context.Context, d *dao.DAO, u *domain.User, username string, outcome domain.AuditOutcome, reason string) error {
+	e := &domain.AuditEvent{
+		Action:     loginAction(ctx),
+		Outcome:    outcome,
+		Reason:     reason,
+		ActorType:  domain.AuditActorUser,
+		ActorName:  username,
+		TargetType: domain.AuditTargetUser,
+		TargetName: username,
+	}
+	if u != nil {
+		e.ActorID = &u.ID
+		e.TargetID = u.ID.String()
+	}
+	return recordAudit(ctx, d, e)
+}
+
+// recordServiceAccountLoginAudit records a login or bind with a service
+// account's API key. sa is nil if the key is unknown.
+func recordServiceAccountLoginAudit(ctx context.Context, d *dao.DAO, sa *domain.ServiceAccount, name string, outcome domain.AuditOutcome, reason string) error {
+	e := &domain.AuditEvent{
+		Action:     loginAction(ctx),
+		Outcome:    outcome,
+		Reason:     reason,
+		ActorType:  domain.AuditActorServiceAccount,
+		ActorName:  name,
+		TargetType: domain.AuditTargetServiceAccount,
+		TargetName: name,
+	}
+	if sa != nil {
+		e.ActorID = &sa.ID
+		e.ActorName = sa.Name
+		e.TargetID = sa.ID.String()
+		e.TargetName = sa.Name
+	}
+	return recordAudit(ctx, d, e)
+}
+
+// loginAction returns the audit action for a login over the request's
+// protocol.
+func loginAction(ctx context.Context) string {
+	if RequestInfoFromContext(ctx).Protocol == domain.ProtocolLDAP {
+		return domain.AuditBind
+	}
+	return domain.AuditLogin
+}
+
+// auditIgnoredFields are left out of audited changes: identifiers and
+// timestamps that change with every write, and related objects, whose
+// changes are audited as operations of their own.
+var auditIgnoredFields = map[string]bool{
+	"id":           true,
+	"client_id":    true,
+	"created_at":   true,
+	"updated_at":   true,
+	"last_used_at": true,
+	"groups":       true,
+	"children":     true,
+	"users":        true,
+	"members":      true,
+	"owner_users":  true,
+	"owner_groups": true,
+	"api_keys":     true,
+}
+
+// auditSecretFields are recorded as changed without their values.
+var auditSecretFields = map[string]bool{
+	"password":      true,
+	"password_hash": true,
+	"secret":        true,
+	"client_secret": true,
+	"key":           true,
+	"token":         true,
+	"totp_secret":   true,
+}
+
+// AuditChanges returns the fields that differ between the JSON encodings of
+// two objects, ordered by name. before is nil for creations and after for
+// deletions. Secrets are redacted, and fields that change on every write
+// or hold related objects are left out.
+func AuditChanges(before, after any) []domain.AuditChange {
+	old, cur := auditFields(before), auditFields(after)
+	names := make([]string, 0, len(old)+len(cur))
+	for name := range old {
+		names = append(names, name)
+	}
+	for name := range cur {
+		if _, ok := old[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var changes []domain.AuditChange
+	for _, name := range names {
+		if auditIgnoredFields[name] || reflect.DeepEqual(old[name], cur[name]) || auditEmpty(old[name]) && auditEmpty(cur[name]) {
+			continue
+		}
+		c := domain.AuditChange{Field: name, Old: old[name], New: cur[name]}
+		if auditSecretFields[name] {
+			c = domain.AuditChange{Field: name, Redacted: true}
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// auditFields decodes the JSON encoding of an object into its fields, none
+// for nil.
+func auditFields(v any) map[string]any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// auditEmpty reports whether a decoded JSON value is empty, so that it is
+// not recorded as changed from or to a missing field.
+func auditEmpty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// auditPasswordChange is the audited change of a password, whose values
+// are never recorded.
+var auditPasswordChange = []domain.AuditChange{{Field: "password", Redacted: true}}
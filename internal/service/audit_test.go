@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent/enttest"
+	"github.com/qinzj/claude-demo/internal/password"
+)
+
+func setupAuditService(t *testing.T) (*AuditService, *UserService, *dao.DAO, context.Context) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	d := dao.New(client)
+	ctx := context.Background()
+	if err := d.AutoMigrate(ctx); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	return NewAuditService(d), NewUserService(d, domain.LockoutPolicy{}, password.Hasher{}), d, ctx
+}
+
+// auditActions returns the actions of the audit events matching the filter,
+// oldest first.
+func auditActions(t *testing.T, s *AuditService, ctx context.Context, filter domain.AuditFilter) []string {
+	t.Helper()
+	var actions []string
+	if err := s.Export(ctx, filter, func(e *domain.AuditEvent) error {
+		actions = append(actions, e.Action)
+		return nil
+	}); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	return actions
+}
+
+func TestAuditChanges(t *testing.T) {
+	before := &domain.User{ID: uuid.New(), Username: "bob", DisplayName: "Bob", Email: "bob@example.com", PasswordHash: "old", UpdatedAt: time.Now()}
+	after := *before
+	after.DisplayName = "Robert"
+	after.Phone = "555"
+	after.PasswordHash = "new"
+	after.UpdatedAt = time.Now().Add(time.Second)
+
+	changes := AuditChanges(before, &after)
+	want := []domain.AuditChange{
+		{Field: "display_name", Old: "Bob", New: "Robert"},
+		{Field: "phone", Old: "", New: "555"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("changes[%d] = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	secret := AuditChanges(map[string]any{"client_secret": "a"}, map[string]any{"client_secret": "b"})
+	if len(secret) != 1 || !secret[0].Redacted || secret[0].Old != nil || secret[0].New != nil {
+		t.Errorf("secret change = %+v, want redacted without values", secret)
+	}
+
+	created := AuditChanges(nil, before)
+	var fields []string
+	for _, c := range created {
+		if c.Old != nil {
+			t.Errorf("created field %s has old value %v", c.Field, c.Old)
+		}
+		fields = append(fields, c.Field)
+	}
+	if !slices.Contains(fields, "username") || slices.Contains(fields, "id") || slices.Contains(fields, "phone") {
+		t.Errorf("created fields = %v, want username but neither id nor the empty phone", fields)
+	}
+}
+
+func TestAuditUserChanges(t *testing.T) {
+	s, userSvc, _, ctx := setupAuditService(t)
+	actorID := uuid.New()
+	ctx = WithRequestInfo(ctx, domain.RequestInfo{Protocol: domain.ProtocolHTTP, IPAddress: "192.0.2.7", RequestID: "req-1"})
+	ctx = WithActor(ctx, &domain.Actor{UserID: actorID, Name: "admin", Permissions: []string{domain.PermissionAll}})
+
+	u, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "carol", DisplayName: "Carol", Email: "carol@example.com", Password: "Secret-pass-1",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	name := "Caroline"
+	if _, err := userSvc.UpdateUser(ctx, u.ID, domain.UpdateUserInput{DisplayName: &name}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if err := userSvc.ResetPassword(ctx, u.ID, "Other-pass-2", false); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if err := userSvc.SetUserStatus(ctx, u.ID, domain.UserStatusDisabled); err != nil {
+		t.Fatalf("SetUserStatus: %v", err)
+	}
+	if err := userSvc.DeleteUser(ctx, u.ID); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	filter := domain.AuditFilter{TargetType: domain.AuditTargetUser, TargetID: u.ID.String()}
+	want := []string{domain.AuditUserCreate, domain.AuditUserUpdate, domain.AuditUserPasswordReset, domain.AuditUserStatus, domain.AuditUserDelete}
+	if got := auditActions(t, s, ctx, filter); !slices.Equal(got, want) {
+		t.Fatalf("actions = %v, want %v", got, want)
+	}
+
+	page, err := s.List(ctx, filter, 1, 2)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if page.Total != 5 || len(page.Items) != 2 || page.Items[0].Action != domain.AuditUserDelete {
+		t.Fatalf("List = %d of %d starting with %q, want newest first", len(page.Items), page.Total, page.Items[0].Action)
+	}
+	e := page.Items[0]
+	if e.ActorType != domain.AuditActorUser || e.ActorID == nil || *e.ActorID != actorID || e.ActorName != "admin" {
+		t.Errorf("actor = %s %v %q, want the admin user", e.ActorType, e.ActorID, e.ActorName)
+	}
+	if e.Protocol != domain.ProtocolHTTP || e.IPAddress != "192.0.2.7" || e.RequestID != "req-1" || e.TargetName != "carol" {
+		t.Errorf("event = %+v, want client, request ID and target recorded", e)
+	}
+
+	updates, err := s.List(ctx, domain.AuditFilter{Action: domain.AuditUserUpdate, Actor: "admin"}, 1, 10)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(updates.Items) != 1 {
+		t.Fatalf("got %d update events, want 1", len(updates.Items))
+	}
+	changes := updates.Items[0].Changes
+	if len(changes) != 1 || changes[0].Field != "display_name" || changes[0].Old != "Carol" || changes[0].New != "Caroline" {
+		t.Errorf("update changes = %+v, want display_name Carol -> Caroline", changes)
+	}
+
+	resets, err := s.List(ctx, domain.AuditFilter{Action: domain.AuditUserPasswordReset}, 1, 10)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if c := resets.Items[0].Changes; len(c) != 1 || !c[0].Redacted || c[0].New != nil {
+		t.Errorf("password reset changes = %+v, want the password redacted", c)
+	}
+}
+
+func TestAuditLogins(t *testing.T) {
+	s, userSvc, _, ctx := setupAuditService(t)
+	if _, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "dave", DisplayName: "Dave", Email: "dave@example.com", Password: "Secret-pass-1",
+	}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	httpCtx := WithRequestInfo(ctx, domain.RequestInfo{Protocol: domain.ProtocolHTTP, IPAddress: "192.0.2.8"})
+	if _, err := userSvc.Authenticate(httpCtx, "dave", "wrong"); err == nil {
+		t.Fatal("expected wrong password to fail")
+	}
+	if _, err := userSvc.Authenticate(httpCtx, "nobody", "wrong"); err == nil {
+		t.Fatal("expected unknown user to fail")
+	}
+	ldapCtx := WithRequestInfo(ctx, domain.RequestInfo{Protocol: domain.ProtocolLDAP})
+	if _, err := userSvc.Authenticate(ldapCtx, "dave", "Secret-pass-1"); err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+
+	want := []string{domain.AuditLogin, domain.AuditLogin, domain.AuditBind}
+	if got := auditActions(t, s, ctx, domain.AuditFilter{Action: "auth.*"}); !slices.Equal(got, want) {
+		t.Fatalf("actions = %v, want %v", got, want)
+	}
+
+	failures, err := s.List(ctx, domain.AuditFilter{Outcome: domain.AuditFailure, IPAddress: "192.0.2.8"}, 1, 10)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if failures.Total != 2 {
+		t.Fatalf("got %d failures, want 2", failures.Total)
+	}
+	unknown := failures.Items[0]
+	if unknown.ActorName != "nobody" || unknown.ActorID != nil || unknown.Reason != domain.LoginReasonUnknownUser {
+		t.Errorf("unknown user failure = %+v", unknown)
+	}
+
+	binds, err := s.List(ctx, domain.AuditFilter{Protocol: domain.ProtocolLDAP, Outcome: domain.AuditSuccess}, 1, 10)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if binds.Total != 1 || binds.Items[0].ActorName != "dave" || binds.Items[0].ActorID == nil {
+		t.Errorf("binds = %+v, want dave's successful bind", binds.Items)
+	}
+
+	future := time.Now().Add(time.Hour)
+	if got := auditActions(t, s, ctx, domain.AuditFilter{From: &future}); len(got) != 0 {
+		t.Errorf("events after now = %v, want none", got)
+	}
+	for _, e := range auditActions(t, s, ctx, domain.AuditFilter{}) {
+		if strings.HasPrefix(e, "user.password") {
+			t.Errorf("unexpected %s event for a login", e)
+		}
+	}
+}
@@ -2,70 +2,347 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 
+	"github.com/qinzj/claude-demo/internal/dao"
 	"github.com/qinzj/claude-demo/internal/domain"
 )
 
-// AuthService handles authentication and JWT tokens.
+// ErrInvalidSession is returned when a token refers to a session that is
+// unknown, expired or revoked, or whose user is disabled.
+var ErrInvalidSession = errors.New("invalid or expired session")
+
+// ErrInvalidMFAChallenge is returned when an MFA challenge token is unknown,
+// expired, already used or has seen too many wrong codes.
+var ErrInvalidMFAChallenge = errors.New("invalid or expired mfa challenge")
+
+// ssoAudience marks tokens that identify a browser's OpenID Connect login
+// session rather than granting API access.
+const ssoAudience = "sso"
+
+// mfaAudience marks challenge tokens that stand for a correct password
+// while the second factor is still outstanding.
+const mfaAudience = "mfa"
+
+const (
+	mfaChallengeTTL         = 5 * time.Minute
+	mfaChallengeMaxAttempts = 5
+)
+
+// AuthService handles authentication, JWT access tokens and login sessions.
 type AuthService struct {
+	dao         *dao.DAO
 	userService *UserService
-	jwtSecret   []byte
-	expireHours int
+	mfa         *MFAService
+	keys        *KeyService
+	accessTTL   time.Duration
+	sessionTTL  time.Duration
+
+	mu         sync.Mutex
+	challenges map[string]*challengeState
+}
+
+// challengeState counts the codes tried against one MFA challenge.
+type challengeState struct {
+	attempts  int
+	expiresAt time.Time
 }
 
-// NewAuthService creates a new AuthService.
-func NewAuthService(userSvc *UserService, jwtSecret string, expireHours int) *AuthService {
+// NewAuthService creates a new AuthService. Tokens are signed with the
+// current key of keySvc. Access tokens live for accessTTL; sessions, and
+// therefore refresh tokens, live for sessionTTL. Second factors are checked
+// with mfaSvc.
+func NewAuthService(d *dao.DAO, userSvc *UserService, mfaSvc *MFAService, keySvc *KeyService, accessTTL, sessionTTL time.Duration) *AuthService {
 	return &AuthService{
+		dao:         d,
 		userService: userSvc,
-		jwtSecret:   []byte(jwtSecret),
-		expireHours: expireHours,
+		mfa:         mfaSvc,
+		keys:        keySvc,
+		accessTTL:   accessTTL,
+		sessionTTL:  sessionTTL,
+		challenges:  make(map[string]*challengeState),
 	}
 }
 
-// Claims represents JWT token claims.
+// Claims represents JWT token claims. Permissions are resolved from the
+// user's roles when the token is issued. PasswordChangeRequired is not part
+// of the token; it is filled in on validation from the user's current
+// password status.
 type Claims struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
+	UserID                 string   `json:"user_id"`
+	Username               string   `json:"username"`
+	SessionID              string   `json:"sid"`
+	Permissions            []string `json:"permissions,omitempty"`
+	PasswordChangeRequired bool     `json:"-"`
 	jwt.RegisteredClaims
 }
 
-// Login authenticates a user and returns a JWT token.
-func (s *AuthService) Login(ctx context.Context, username, password string) (string, *domain.User, error) {
-	u, err := s.userService.Authenticate(ctx, username, password)
+// TokenPair is a short-lived access token together with the refresh token
+// used to obtain the next one. PasswordChangeRequired is set when the user's
+// password has expired or was reset; the token then only allows changing it.
+type TokenPair struct {
+	AccessToken            string    `json:"token"`
+	RefreshToken           string    `json:"refresh_token"`
+	ExpiresAt              time.Time `json:"expires_at"`
+	PasswordChangeRequired bool      `json:"password_change_required,omitempty"`
+}
+
+// MFAChallenge is returned as the error of Login when the password was
+// correct but a second factor is still needed. Token is passed to
+// VerifyMFA, or, if EnrollmentRequired is set because the user's group
+// requires MFA they have not set up yet, to BeginMFAEnrollment and
+// ConfirmMFAEnrollment.
+type MFAChallenge struct {
+	Token              string    `json:"mfa_token"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	EnrollmentRequired bool      `json:"enrollment_required"`
+}
+
+func (c *MFAChallenge) Error() string {
+	if c.EnrollmentRequired {
+		return "mfa enrollment required"
+	}
+	return "mfa verification required"
+}
+
+// Login authenticates a user, opens a new session and returns its tokens.
+// Client metadata is taken from the context, see WithRequestInfo. When the
+// user has MFA enabled or is required to, the error is an *MFAChallenge
+// and no session is opened yet.
+func (s *AuthService) Login(ctx context.Context, username, password string) (*TokenPair, *domain.User, error) {
+	u, err := s.userService.authenticate(ctx, username, password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	required, err := s.mfa.Required(ctx, u.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if u.MFAEnabled || required {
+		challenge, err := s.newMFAChallenge(ctx, u, !u.MFAEnabled)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, u, challenge
+	}
+	if err := s.userService.loginSucceeded(ctx, u); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.openSession(ctx, u)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, u, nil
+}
+
+// VerifyMFA completes a login challenged by Login with a TOTP or recovery
+// code, and opens the session.
+func (s *AuthService) VerifyMFA(ctx context.Context, challengeToken, code string) (*TokenPair, *domain.User, error) {
+	u, jti, err := s.parseMFAChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !s.attemptMFAChallenge(jti) {
+		return nil, nil, ErrInvalidMFAChallenge
+	}
+	if err := s.verifySecondFactor(ctx, u, code); err != nil {
+		return nil, nil, err
+	}
+	s.finishMFAChallenge(jti)
+
+	tokens, err := s.openSession(ctx, u)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, u, nil
+}
+
+// BeginMFAEnrollment starts TOTP enrollment for a user whose login was
+// challenged because their group requires MFA.
+func (s *AuthService) BeginMFAEnrollment(ctx context.Context, challengeToken string) (*domain.TOTPEnrollment, error) {
+	u, _, err := s.parseMFAChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.mfa.BeginEnrollment(ctx, u.ID)
+}
+
+// ConfirmMFAEnrollment confirms the enrollment started by BeginMFAEnrollment
+// and opens the session. The recovery codes are returned only this once.
+func (s *AuthService) ConfirmMFAEnrollment(ctx context.Context, challengeToken, code string) (*TokenPair, *domain.User, []string, error) {
+	u, jti, err := s.parseMFAChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !s.attemptMFAChallenge(jti) {
+		return nil, nil, nil, ErrInvalidMFAChallenge
+	}
+	codes, err := s.mfa.ConfirmEnrollment(ctx, u.ID, code)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	s.finishMFAChallenge(jti)
+	if err := s.userService.loginSucceeded(ctx, u); err != nil {
+		return nil, nil, nil, err
+	}
+
+	tokens, err := s.openSession(ctx, u)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return tokens, u, codes, nil
+}
+
+// verifySecondFactor checks the TOTP or recovery code completing a login.
+// A wrong code counts as a failed login, like a wrong password, and a right
+// one clears earlier failures.
+func (s *AuthService) verifySecondFactor(ctx context.Context, u *domain.User, code string) error {
+	if err := s.mfa.Verify(ctx, u, code); err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			if err := s.userService.recordFailedLogin(ctx, u, domain.LoginReasonInvalidOTP); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+	return s.userService.loginSucceeded(ctx, u)
+}
+
+// openSession opens a new session for an authenticated user and returns its tokens.
+func (s *AuthService) openSession(ctx context.Context, u *domain.User) (*TokenPair, error) {
+	refresh, hash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	sess, err := s.dao.CreateSession(ctx, u.ID, hash, RequestInfoFromContext(ctx), time.Now().Add(s.sessionTTL))
+	if err != nil {
+		return nil, err
+	}
+	return s.issue(ctx, u, sess.ID, refresh)
+}
+
+// Refresh exchanges a refresh token for a new token pair. The refresh token
+// is rotated on every use; presenting an already rotated token revokes the
+// whole session, since it indicates the token was stolen.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	hash := hashToken(refreshToken)
+	sess, reused, err := s.dao.GetSessionByTokenHash(ctx, hash)
+	if err != nil {
+		return nil, ErrInvalidSession
+	}
+	if reused {
+		if err := s.dao.RevokeSession(ctx, sess.UserID, sess.ID); err != nil && sess.RevokedAt == nil {
+			return nil, err
+		}
+		return nil, ErrInvalidSession
+	}
+	if !sess.Active(time.Now()) {
+		return nil, ErrInvalidSession
+	}
+
+	u, err := s.activeUser(ctx, sess.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, newHash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.dao.RotateSessionToken(ctx, sess.ID, hash, newHash, RequestInfoFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidSession
+	}
+	return s.issue(ctx, u, sess.ID, refresh)
+}
+
+// ValidateToken validates a JWT access token, using the key named by its
+// "kid" header, and returns its claims. The token is rejected if its session
+// has been revoked or has expired, or if the user has been disabled or
+// deleted since it was issued.
+func (s *AuthService) ValidateToken(ctx context.Context, tokenStr string) (*Claims, error) {
+	return s.validate(ctx, tokenStr, "")
+}
+
+// StartSSOSession authenticates a user on the OpenID Connect login page and
+// opens a session for the browser. The returned token is meant for an
+// HTTP-only cookie: it carries the "sso" audience, so it is not accepted as
+// an API access token. Users with MFA enabled must also give a TOTP or
+// recovery code; users required to enroll first get ErrMFARequired.
+func (s *AuthService) StartSSOSession(ctx context.Context, username, password, code string) (string, time.Time, error) {
+	u, err := s.userService.authenticate(ctx, username, password)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("authentication failed: %w", err)
+	}
+	if u.MFAEnabled {
+		if err := s.verifySecondFactor(ctx, u, code); err != nil {
+			return "", time.Time{}, err
+		}
+	} else if required, err := s.mfa.Required(ctx, u.ID); err != nil {
+		return "", time.Time{}, err
+	} else if required {
+		return "", time.Time{}, ErrMFARequired
+	} else if err := s.userService.loginSucceeded(ctx, u); err != nil {
+		return "", time.Time{}, err
+	}
+
+	// The browser never refreshes, so the refresh token is discarded.
+	_, hash, err := newOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(s.sessionTTL)
+	sess, err := s.dao.CreateSession(ctx, u.ID, hash, RequestInfoFromContext(ctx), expiresAt)
 	if err != nil {
-		return "", nil, fmt.Errorf("authentication failed: %w", err)
+		return "", time.Time{}, err
 	}
 
 	claims := &Claims{
-		UserID:   u.ID.String(),
-		Username: u.Username,
+		UserID:    u.ID.String(),
+		Username:  u.Username,
+		SessionID: sess.ID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(s.expireHours) * time.Hour)),
+			Audience:  jwt.ClaimStrings{ssoAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenStr, err := token.SignedString(s.jwtSecret)
+	token, err := s.keys.sign(ctx, claims, "")
 	if err != nil {
-		return "", nil, fmt.Errorf("signing token: %w", err)
+		return "", time.Time{}, err
 	}
+	return token, expiresAt, nil
+}
 
-	return tokenStr, u, nil
+// ValidateSSOSession validates a token issued by StartSSOSession. The claims'
+// IssuedAt is the time the user authenticated.
+func (s *AuthService) ValidateSSOSession(ctx context.Context, tokenStr string) (*Claims, error) {
+	return s.validate(ctx, tokenStr, ssoAudience)
 }
 
-// ValidateToken validates a JWT token and returns the claims.
-func (s *AuthService) ValidateToken(_ context.Context, tokenStr string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.jwtSecret, nil
-	})
+// validate parses a session-bound token. Access tokens have no audience;
+// SSO session tokens must carry the given one.
+func (s *AuthService) validate(ctx context.Context, tokenStr, audience string) (*Claims, error) {
+	opts := []jwt.ParserOption{jwt.WithValidMethods(validMethods())}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, s.keys.keyfunc(ctx), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("parsing token: %w", err)
 	}
@@ -74,6 +351,190 @@ func (s *AuthService) ValidateToken(_ context.Context, tokenStr string) (*Claims
 	if !ok || !token.Valid {
 		return nil, fmt.Errorf("invalid token")
 	}
+	if audience == "" && len(claims.Audience) > 0 {
+		return nil, fmt.Errorf("invalid token: unexpected audience %v", claims.Audience)
+	}
+
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, ErrInvalidSession
+	}
+	sess, err := s.dao.GetSession(ctx, sessionID)
+	if err != nil || !sess.Active(time.Now()) || sess.UserID.String() != claims.UserID {
+		return nil, ErrInvalidSession
+	}
+	u, err := s.activeUser(ctx, sess.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if claims.PasswordChangeRequired, err = s.userService.PasswordChangeRequired(ctx, u); err != nil {
+		return nil, err
+	}
 
 	return claims, nil
 }
+
+// ListSessions returns a user's active sessions.
+func (s *AuthService) ListSessions(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	return s.dao.ListActiveSessions(ctx, userID)
+}
+
+// RevokeSession revokes one of a user's sessions.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := s.userService.authorizeUserManagementByID(ctx, userID); err != nil {
+		return err
+	}
+	return s.dao.RevokeSession(ctx, userID, sessionID)
+}
+
+// JWKS returns the public keys that verify access tokens.
+func (s *AuthService) JWKS(ctx context.Context) (*domain.JWKSet, error) {
+	return s.keys.JWKS(ctx)
+}
+
+// RevokeSessions revokes all of a user's sessions and returns how many were active.
+func (s *AuthService) RevokeSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	if err := s.userService.authorizeUserManagementByID(ctx, userID); err != nil {
+		return 0, err
+	}
+	return s.dao.RevokeUserSessions(ctx, userID)
+}
+
+// issue signs an access token for the session. Permissions are resolved
+// afresh so role changes take effect on the next refresh.
+func (s *AuthService) issue(ctx context.Context, u *domain.User, sessionID uuid.UUID, refreshToken string) (*TokenPair, error) {
+	perms, err := s.userService.GetUserPermissions(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("resolving permissions: %w", err)
+	}
+	changeRequired, err := s.userService.PasswordChangeRequired(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.accessTTL)
+	claims := &Claims{
+		UserID:      u.ID.String(),
+		Username:    u.Username,
+		SessionID:   sessionID.String(),
+		Permissions: perms,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	tokenStr, err := s.keys.sign(ctx, claims, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:            tokenStr,
+		RefreshToken:           refreshToken,
+		ExpiresAt:              expiresAt,
+		PasswordChangeRequired: changeRequired,
+	}, nil
+}
+
+// newMFAChallenge signs a short-lived challenge token for a user who passed
+// the password check.
+func (s *AuthService) newMFAChallenge(ctx context.Context, u *domain.User, enroll bool) (*MFAChallenge, error) {
+	now := time.Now()
+	expiresAt := now.Add(mfaChallengeTTL)
+	claims := &Claims{
+		UserID:   u.ID.String(),
+		Username: u.Username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Audience:  jwt.ClaimStrings{mfaAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	token, err := s.keys.sign(ctx, claims, "")
+	if err != nil {
+		return nil, err
+	}
+	return &MFAChallenge{Token: token, ExpiresAt: expiresAt, EnrollmentRequired: enroll}, nil
+}
+
+// parseMFAChallenge validates a challenge token and returns its user and ID.
+func (s *AuthService) parseMFAChallenge(ctx context.Context, tokenStr string) (*domain.User, string, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, s.keys.keyfunc(ctx),
+		jwt.WithValidMethods(validMethods()), jwt.WithAudience(mfaAudience))
+	if err != nil {
+		return nil, "", ErrInvalidMFAChallenge
+	}
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid || claims.ID == "" {
+		return nil, "", ErrInvalidMFAChallenge
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, "", ErrInvalidMFAChallenge
+	}
+	u, err := s.activeUser(ctx, userID)
+	if err != nil {
+		return nil, "", ErrInvalidMFAChallenge
+	}
+	return u, claims.ID, nil
+}
+
+// attemptMFAChallenge counts a code tried against a challenge and reports
+// whether the challenge may still be used. Each challenge allows a few
+// attempts, so guessing codes requires the password again and again.
+func (s *AuthService) attemptMFAChallenge(jti string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, st := range s.challenges {
+		if now.After(st.expiresAt) {
+			delete(s.challenges, id)
+		}
+	}
+	st, ok := s.challenges[jti]
+	if !ok {
+		st = &challengeState{expiresAt: now.Add(mfaChallengeTTL)}
+		s.challenges[jti] = st
+	}
+	st.attempts++
+	return st.attempts <= mfaChallengeMaxAttempts
+}
+
+// finishMFAChallenge prevents a challenge from being used again once it
+// has opened a session.
+func (s *AuthService) finishMFAChallenge(jti string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if st, ok := s.challenges[jti]; ok {
+		st.attempts = mfaChallengeMaxAttempts
+	}
+}
+
+func (s *AuthService) activeUser(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	u, err := s.userService.GetUser(ctx, id)
+	if err != nil || u.Status != domain.UserStatusEnabled || u.Expired(time.Now()) {
+		return nil, ErrInvalidSession
+	}
+	return u, nil
+}
+
+// newOpaqueToken returns a random opaque token, such as a refresh token or an
+// authorization code, and the hash under which it is stored. Only the hash is
+// persisted.
+func newOpaqueToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("generating token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,13 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/qinzj/claude-demo/internal/dao"
 	"github.com/qinzj/claude-demo/internal/domain"
 	"github.com/qinzj/claude-demo/internal/ent/enttest"
+	"github.com/qinzj/claude-demo/internal/password"
 )
 
 func setupAuthService(t *testing.T) (*AuthService, *UserService, context.Context) {
@@ -20,8 +24,12 @@ func setupAuthService(t *testing.T) (*AuthService, *UserService, context.Context
 	if err := d.AutoMigrate(ctx); err != nil {
 		t.Fatalf("auto migrate: %v", err)
 	}
-	userSvc := NewUserService(d)
-	authSvc := NewAuthService(userSvc, "test-secret", 24)
+	userSvc := NewUserService(d, domain.LockoutPolicy{}, password.Hasher{})
+	keySvc := NewKeyService(d, "ES256", 30*24*time.Hour, time.Hour)
+	if _, err := keySvc.RotateIfDue(ctx); err != nil {
+		t.Fatalf("init signing key: %v", err)
+	}
+	authSvc := NewAuthService(d, userSvc, NewMFAService(d, userSvc, "Test"), keySvc, 15*time.Minute, 24*time.Hour)
 	return authSvc, userSvc, ctx
 }
 
@@ -35,12 +43,12 @@ func TestAuthServiceLogin(t *testing.T) {
 		Password:    "adminpass",
 	})
 
-	token, u, err := authSvc.Login(ctx, "admin", "adminpass")
+	tokens, u, err := authSvc.Login(ctx, "admin", "adminpass")
 	if err != nil {
 		t.Fatalf("Login: %v", err)
 	}
-	if token == "" {
-		t.Error("expected non-empty token")
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+		t.Error("expected non-empty access and refresh tokens")
 	}
 	if u.Username != "admin" {
 		t.Errorf("Username = %q, want %q", u.Username, "admin")
@@ -73,9 +81,9 @@ func TestAuthServiceValidateToken(t *testing.T) {
 		Password:    "adminpass",
 	})
 
-	token, _, _ := authSvc.Login(ctx, "admin", "adminpass")
+	tokens, _, _ := authSvc.Login(ctx, "admin", "adminpass")
 
-	claims, err := authSvc.ValidateToken(ctx, token)
+	claims, err := authSvc.ValidateToken(ctx, tokens.AccessToken)
 	if err != nil {
 		t.Fatalf("ValidateToken: %v", err)
 	}
@@ -92,3 +100,133 @@ func TestAuthServiceValidateInvalidToken(t *testing.T) {
 		t.Error("expected error for invalid token")
 	}
 }
+
+func TestAuthServiceRefreshRotation(t *testing.T) {
+	authSvc, userSvc, ctx := setupAuthService(t)
+
+	userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username:    "alice",
+		DisplayName: "Alice",
+		Email:       "alice@example.com",
+		Password:    "alicepass",
+	})
+	first, _, err := authSvc.Login(ctx, "alice", "alicepass")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	second, err := authSvc.Refresh(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Error("expected refresh token to be rotated")
+	}
+	if _, err := authSvc.ValidateToken(ctx, second.AccessToken); err != nil {
+		t.Errorf("ValidateToken(refreshed): %v", err)
+	}
+
+	// Replaying the rotated-out token revokes the session entirely.
+	if _, err := authSvc.Refresh(ctx, first.RefreshToken); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("Refresh(reused) error = %v, want ErrInvalidSession", err)
+	}
+	if _, err := authSvc.Refresh(ctx, second.RefreshToken); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("Refresh(after reuse) error = %v, want ErrInvalidSession", err)
+	}
+	if _, err := authSvc.ValidateToken(ctx, second.AccessToken); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("ValidateToken(after reuse) error = %v, want ErrInvalidSession", err)
+	}
+}
+
+func TestAuthServiceRevokeSession(t *testing.T) {
+	authSvc, userSvc, ctx := setupAuthService(t)
+
+	u, _ := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username:    "bob",
+		DisplayName: "Bob",
+		Email:       "bob@example.com",
+		Password:    "bobpass",
+	})
+	laptop, _, _ := authSvc.Login(ctx, "bob", "bobpass")
+	phone, _, _ := authSvc.Login(ctx, "bob", "bobpass")
+
+	sessions, err := authSvc.ListSessions(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("len(sessions) = %d, want 2", len(sessions))
+	}
+
+	claims, _ := authSvc.ValidateToken(ctx, laptop.AccessToken)
+	sid, _ := uuid.Parse(claims.SessionID)
+	if err := authSvc.RevokeSession(ctx, u.ID, sid); err != nil {
+		t.Fatalf("RevokeSession: %v", err)
+	}
+	if _, err := authSvc.ValidateToken(ctx, laptop.AccessToken); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("ValidateToken(revoked) error = %v, want ErrInvalidSession", err)
+	}
+	if _, err := authSvc.Refresh(ctx, laptop.RefreshToken); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("Refresh(revoked) error = %v, want ErrInvalidSession", err)
+	}
+	if _, err := authSvc.ValidateToken(ctx, phone.AccessToken); err != nil {
+		t.Errorf("ValidateToken(other session): %v", err)
+	}
+}
+
+func TestAuthServiceDisabledUser(t *testing.T) {
+	authSvc, userSvc, ctx := setupAuthService(t)
+
+	u, _ := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username:    "carol",
+		DisplayName: "Carol",
+		Email:       "carol@example.com",
+		Password:    "carolpass",
+	})
+	tokens, _, _ := authSvc.Login(ctx, "carol", "carolpass")
+
+	if err := userSvc.SetUserStatus(ctx, u.ID, domain.UserStatusDisabled); err != nil {
+		t.Fatalf("SetUserStatus: %v", err)
+	}
+	if _, err := authSvc.ValidateToken(ctx, tokens.AccessToken); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("ValidateToken(disabled) error = %v, want ErrInvalidSession", err)
+	}
+
+	// Re-enabling the user does not revive sessions revoked on disable.
+	userSvc.SetUserStatus(ctx, u.ID, domain.UserStatusEnabled)
+	if _, err := authSvc.Refresh(ctx, tokens.RefreshToken); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("Refresh(re-enabled) error = %v, want ErrInvalidSession", err)
+	}
+}
+
+func TestAuthServiceKeyRotation(t *testing.T) {
+	authSvc, userSvc, ctx := setupAuthService(t)
+
+	userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username:    "dave",
+		DisplayName: "Dave",
+		Email:       "dave@example.com",
+		Password:    "davepass",
+	})
+	before, _, _ := authSvc.Login(ctx, "dave", "davepass")
+
+	if _, err := authSvc.keys.Rotate(ctx); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	after, _, _ := authSvc.Login(ctx, "dave", "davepass")
+
+	// Tokens signed with the retired key stay valid during the grace period.
+	for name, tok := range map[string]string{"before": before.AccessToken, "after": after.AccessToken} {
+		if _, err := authSvc.ValidateToken(ctx, tok); err != nil {
+			t.Errorf("ValidateToken(%s rotation): %v", name, err)
+		}
+	}
+
+	set, err := authSvc.JWKS(ctx)
+	if err != nil {
+		t.Fatalf("JWKS: %v", err)
+	}
+	if len(set.Keys) != 2 {
+		t.Errorf("len(JWKS keys) = %d, want 2", len(set.Keys))
+	}
+}
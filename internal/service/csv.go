@@ -0,0 +1,1021 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	netmail "net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/password"
+)
+
+// ErrInvalidCSV is returned for a CSV file that cannot be read, such as one
+// without a username column, or for invalid import options. Nothing is
+// imported.
+var ErrInvalidCSV = errors.New("invalid CSV import")
+
+// csvListSeparator separates the groups, and the values of multi-valued
+// extension attributes, in a cell.
+const csvListSeparator = ";"
+
+// utf8BOM is written at the start of exported files so that spreadsheets
+// read them as UTF-8, and skipped when importing.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// CSVService exports users to and imports them from CSV files, such as
+// spreadsheets kept by HR, through the user, group and invitation
+// services. Users are identified by username, which cannot be changed.
+type CSVService struct {
+	dao         *dao.DAO
+	users       *UserService
+	groups      *GroupService
+	invitations *InvitationService
+}
+
+// NewCSVService creates a new CSVService.
+func NewCSVService(d *dao.DAO, userSvc *UserService, groupSvc *GroupService, invitationSvc *InvitationService) *CSVService {
+	return &CSVService{dao: d, users: userSvc, groups: groupSvc, invitations: invitationSvc}
+}
+
+// withDAO returns a copy of the service whose users and groups are changed
+// through d. Invitations are not.
+func (s *CSVService) withDAO(d *dao.DAO) *CSVService {
+	return &CSVService{dao: d, users: s.users.withDAO(d), groups: s.groups.withDAO(d), invitations: s.invitations}
+}
+
+// ExportUsers writes the users matching the search and attribute filters
+// of ListUsers to w as CSV, with a column for each field but the password
+// and for each extension attribute of users, named as the import expects.
+func (s *CSVService) ExportUsers(ctx context.Context, w io.Writer, search string, attrs map[string]string) error {
+	defs, err := s.dao.ListAttributeDefinitions(ctx, domain.AttributeTargetUser)
+	if err != nil {
+		return fmt.Errorf("loading attribute definitions: %w", err)
+	}
+	if len(attrs) > 0 {
+		if attrs, err = validateAttributeFilter(defs, attrs); err != nil {
+			return err
+		}
+	}
+	users, err := s.dao.SearchUsers(ctx, search, attrs)
+	if err != nil {
+		return err
+	}
+	all, err := s.dao.AllUsers(ctx)
+	if err != nil {
+		return err
+	}
+	usernames := make(map[uuid.UUID]string, len(all))
+	for _, u := range all {
+		usernames[u.ID] = u.Username
+	}
+
+	header := slices.DeleteFunc(slices.Clone(domain.CSVUserFields), func(f string) bool { return f == "password" })
+	for _, def := range defs {
+		header = append(header, "attr."+def.Name)
+	}
+	if _, err := w.Write(utf8BOM); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, u := range users {
+		var manager, expires string
+		if u.ManagerID != nil {
+			manager = usernames[*u.ManagerID]
+		}
+		if u.AccountExpiresAt != nil {
+			expires = u.AccountExpiresAt.UTC().Format(time.RFC3339)
+		}
+		groups := make([]string, len(u.Groups))
+		for i, g := range u.Groups {
+			groups[i] = g.Name
+		}
+		slices.Sort(groups)
+		row := []string{
+			u.Username, u.DisplayName, u.Email, u.Phone, string(u.Status), manager, expires,
+			strings.Join(groups, csvListSeparator),
+		}
+		for _, def := range defs {
+			row = append(row, strings.Join(u.Attributes[def.Name], csvListSeparator))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ImportUsers creates and updates users, and their group memberships, from
+// the rows of a CSV file whose first row names the columns. Columns are
+// named after the fields they hold unless opts.Columns says otherwise, and
+// a column named after an extension attribute holds that attribute. Empty
+// cells leave fields unchanged.
+//
+// Every row is validated before any is applied. In an all-or-nothing
+// import nothing is applied if a row is invalid or fails to apply;
+// otherwise the valid rows are applied in order. With opts.DryRun nothing is applied and the report
+// says what importing would do.
+func (s *CSVService) ImportUsers(ctx context.Context, r io.Reader, opts domain.CSVImportOptions) (*domain.CSVImportReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = domain.CSVImportAllOrNothing
+	}
+	if opts.Credentials == "" {
+		opts.Credentials = domain.CSVCredentialsGenerate
+	}
+	switch opts.Mode {
+	case domain.CSVImportAllOrNothing, domain.CSVImportBestEffort:
+	default:
+		return nil, fmt.Errorf("%w: unknown mode %q", ErrInvalidCSV, opts.Mode)
+	}
+	switch opts.Credentials {
+	case domain.CSVCredentialsGenerate, domain.CSVCredentialsNone:
+	case domain.CSVCredentialsInvite:
+		if s.invitations == nil || s.invitations.sender == nil {
+			return nil, ErrInvitationUnavailable
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown credentials %q", ErrInvalidCSV, opts.Credentials)
+	}
+
+	header, records, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.newImport(ctx, opts, header)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		run.plan(ctx, rec)
+	}
+	run.planManagers()
+	if err := run.authorizeUpdates(ctx); err != nil {
+		return nil, err
+	}
+
+	invalid := slices.ContainsFunc(run.plans, (*csvPlan).failed)
+	if !opts.DryRun && !(invalid && opts.Mode == domain.CSVImportAllOrNothing) {
+		run.report.Applied = true
+		run.apply(ctx)
+	}
+	for _, p := range run.plans {
+		switch p.res.Action {
+		case domain.CSVImportCreate:
+			run.report.Created++
+		case domain.CSVImportUpdate:
+			run.report.Updated++
+		case domain.CSVImportUnchanged:
+			run.report.Unchanged++
+		case domain.CSVImportFail:
+			run.report.Failed++
+		case domain.CSVImportSkip:
+			run.report.Skipped++
+		}
+	}
+	return run.report, nil
+}
+
+// csvRecord is a row of a CSV file and the line it starts on.
+type csvRecord struct {
+	line   int
+	values []string
+}
+
+// readCSV reads the header and the rows of a CSV file, skipping a leading
+// byte order mark.
+func readCSV(r io.Reader) ([]string, []csvRecord, error) {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(len(utf8BOM)); err == nil && bytes.Equal(b, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil, fmt.Errorf("%w: the file is empty", ErrInvalidCSV)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+	var records []csvRecord
+	for {
+		values, err := cr.Read()
+		if err == io.EOF {
+			return header, records, nil
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+		}
+		line, _ := cr.FieldPos(0)
+		records = append(records, csvRecord{line: line, values: values})
+	}
+}
+
+// csvImport is the state of a CSV import: the users and groups, and the
+// changes planned for each row.
+type csvImport struct {
+	s      *CSVService
+	opts   domain.CSVImportOptions
+	report *domain.CSVImportReport
+
+	columns map[string]int // by field
+	defs    map[string]*domain.AttributeDefinition
+	rules   domain.PasswordRules // of new users
+
+	users      map[string]*domain.User // by lower-cased username
+	emails     map[string]*domain.User // by lower-cased email
+	usersByID  map[uuid.UUID]*domain.User
+	groups     map[string]*domain.Group // by name
+	memberOf   map[uuid.UUID][]uuid.UUID
+	authorized map[uuid.UUID]error
+
+	plans     []*csvPlan
+	rows      map[string]*csvPlan // by lower-cased username
+	rowEmails map[string]*csvPlan // by lower-cased email
+}
+
+// csvPlan is the change a row makes to its user.
+type csvPlan struct {
+	res  *domain.CSVImportRow
+	key  string       // lower-cased username
+	user *domain.User // nil for new users
+
+	create      *domain.CreateUserInput
+	invite      bool
+	update      domain.UpdateUserInput
+	status      domain.UserStatus
+	password    string
+	hash        string
+	manager     string // lower-cased username of the new manager
+	addGroups   []*domain.Group
+	leaveGroups []*domain.Group
+}
+
+func (p *csvPlan) failed() bool {
+	return len(p.res.Errors) > 0
+}
+
+func (p *csvPlan) fail(format string, args ...any) {
+	p.res.Action = domain.CSVImportFail
+	p.res.Errors = append(p.res.Errors, fmt.Sprintf(format, args...))
+}
+
+func (s *CSVService) newImport(ctx context.Context, opts domain.CSVImportOptions, header []string) (*csvImport, error) {
+	defs, err := s.dao.ListAttributeDefinitions(ctx, domain.AttributeTargetUser)
+	if err != nil {
+		return nil, fmt.Errorf("loading attribute definitions: %w", err)
+	}
+	r := &csvImport{
+		s:    s,
+		opts: opts,
+		report: &domain.CSVImportReport{
+			DryRun:  opts.DryRun,
+			Mode:    opts.Mode,
+			Header:  header,
+			Columns: make(map[string]string),
+			Rows:    []*domain.CSVImportRow{},
+		},
+		columns:    make(map[string]int),
+		defs:       make(map[string]*domain.AttributeDefinition, len(defs)),
+		users:      make(map[string]*domain.User),
+		emails:     make(map[string]*domain.User),
+		usersByID:  make(map[uuid.UUID]*domain.User),
+		groups:     make(map[string]*domain.Group),
+		memberOf:   make(map[uuid.UUID][]uuid.UUID),
+		authorized: make(map[uuid.UUID]error),
+		rows:       make(map[string]*csvPlan),
+		rowEmails:  make(map[string]*csvPlan),
+	}
+	for _, def := range defs {
+		r.defs[def.Name] = def
+	}
+	if err := r.mapColumns(header); err != nil {
+		return nil, err
+	}
+
+	if r.rules, err = s.users.passwordRules(ctx, uuid.Nil); err != nil {
+		return nil, err
+	}
+	users, err := s.dao.AllUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		r.addUser(u)
+	}
+	groups, err := s.dao.AllGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		r.groups[g.Name] = g
+		for _, u := range g.Users {
+			r.memberOf[u.ID] = append(r.memberOf[u.ID], g.ID)
+		}
+	}
+	return r, nil
+}
+
+// mapColumns finds the column of each field: the one opts.Columns names,
+// or else the one named after the field or, for extension attributes,
+// after the attribute. Column names are compared case-insensitively.
+func (r *csvImport) mapColumns(header []string) error {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		key := strings.ToLower(strings.TrimSpace(h))
+		if _, ok := index[key]; ok {
+			return fmt.Errorf("%w: column %q appears twice", ErrInvalidCSV, h)
+		}
+		index[key] = i
+	}
+
+	mapped := make(map[int]bool)
+	for field, column := range r.opts.Columns {
+		if !r.isField(field) {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidCSV, field)
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			return fmt.Errorf("%w: no column %q for %s", ErrInvalidCSV, column, field)
+		}
+		if mapped[i] {
+			return fmt.Errorf("%w: column %q is mapped twice", ErrInvalidCSV, column)
+		}
+		r.columns[field] = i
+		mapped[i] = true
+	}
+	for i, h := range header {
+		if mapped[i] {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(h))
+		field := key
+		if !r.isField(field) {
+			if _, ok := r.defs[key]; ok {
+				field = "attr." + key
+			}
+		}
+		if _, ok := r.columns[field]; ok || !r.isField(field) {
+			r.report.Ignored = append(r.report.Ignored, h)
+			continue
+		}
+		r.columns[field] = i
+	}
+	if _, ok := r.columns["username"]; !ok {
+		return fmt.Errorf("%w: no username column", ErrInvalidCSV)
+	}
+	for field, i := range r.columns {
+		r.report.Columns[field] = header[i]
+	}
+	return nil
+}
+
+// isField reports whether a field can be imported.
+func (r *csvImport) isField(field string) bool {
+	if name, ok := strings.CutPrefix(field, "attr."); ok {
+		_, ok := r.defs[name]
+		return ok
+	}
+	return slices.Contains(domain.CSVUserFields, field)
+}
+
+func (r *csvImport) addUser(u *domain.User) {
+	r.users[strings.ToLower(u.Username)] = u
+	r.emails[strings.ToLower(u.Email)] = u
+	r.usersByID[u.ID] = u
+}
+
+// cell returns the trimmed cell of a field in a row, and whether it is
+// set.
+func (r *csvImport) cell(p *csvPlan, field string) (string, bool) {
+	i, ok := r.columns[field]
+	if !ok || i >= len(p.res.Values) {
+		return "", false
+	}
+	v := strings.TrimSpace(p.res.Values[i])
+	return v, v != ""
+}
+
+// plan validates a row and plans its changes, except for the manager.
+func (r *csvImport) plan(ctx context.Context, rec csvRecord) {
+	p := &csvPlan{res: &domain.CSVImportRow{Row: rec.line, Values: rec.values}}
+	r.plans = append(r.plans, p)
+	r.report.Rows = append(r.report.Rows, p.res)
+	if len(rec.values) != len(r.report.Header) {
+		p.fail("row has %d cells, the header %d", len(rec.values), len(r.report.Header))
+		return
+	}
+
+	username, ok := r.cell(p, "username")
+	p.res.Username = username
+	if !ok {
+		p.fail("username is empty")
+		return
+	}
+	if len([]rune(username)) > 64 {
+		p.fail("username is longer than 64 characters")
+		return
+	}
+	p.key = strings.ToLower(username)
+	if other := r.rows[p.key]; other != nil {
+		p.fail("user is also in row %d", other.res.Row)
+		return
+	}
+	r.rows[p.key] = p
+	p.user = r.users[p.key]
+
+	displayName, _ := r.cell(p, "display_name")
+	if len([]rune(displayName)) > 128 {
+		p.fail("display_name is longer than 128 characters")
+	}
+	email, hasEmail := r.cell(p, "email")
+	if hasEmail {
+		r.checkEmail(p, email)
+	}
+	phone, _ := r.cell(p, "phone")
+	if len([]rune(phone)) > 32 {
+		p.fail("phone is longer than 32 characters")
+	}
+	var expires *time.Time
+	if v, ok := r.cell(p, "account_expires_at"); ok {
+		if t, err := parseCSVTime(v); err != nil {
+			p.fail("invalid account_expires_at %q: use RFC 3339 or YYYY-MM-DD", v)
+		} else {
+			expires = &t
+		}
+	}
+	attrs := make(map[string][]string)
+	for field := range r.columns {
+		name, ok := strings.CutPrefix(field, "attr.")
+		if v, set := r.cell(p, field); ok && set {
+			attrs[name] = []string{v}
+			if r.defs[name].MultiValued {
+				attrs[name] = splitCSVList(v)
+			}
+		}
+	}
+	var err error
+	if attrs, err = validateAttributeValues(slices.Collect(maps.Values(r.defs)), attrs, p.user == nil); err != nil {
+		p.fail("%v", err)
+	}
+
+	if p.user == nil {
+		r.planCreate(p, username, displayName, email, phone, expires, attrs)
+	} else {
+		r.planUpdate(ctx, p, displayName, email, phone, expires, attrs)
+	}
+	r.planGroups(ctx, p)
+	switch {
+	case p.failed():
+	case p.user == nil:
+		p.res.Action = domain.CSVImportCreate
+	case len(p.res.Fields) > 0:
+		p.res.Action = domain.CSVImportUpdate
+	default:
+		p.res.Action = domain.CSVImportUnchanged
+	}
+}
+
+// checkEmail checks that an email address is valid and belongs to no other
+// user.
+func (r *csvImport) checkEmail(p *csvPlan, email string) {
+	if a, err := netmail.ParseAddress(email); err != nil || a.Address != email || a.Name != "" || len(email) > 255 {
+		p.fail("invalid email %q", email)
+		return
+	}
+	key := strings.ToLower(email)
+	if other := r.rowEmails[key]; other != nil {
+		p.fail("email is also in row %d", other.res.Row)
+		return
+	}
+	r.rowEmails[key] = p
+	if u := r.emails[key]; u != nil && u != p.user {
+		p.fail("email is used by user %s", u.Username)
+	}
+}
+
+// planCreate plans the creation of a user.
+func (r *csvImport) planCreate(p *csvPlan, username, displayName, email, phone string, expires *time.Time, attrs map[string][]string) {
+	if email == "" {
+		p.fail("email is empty")
+	}
+	p.create = &domain.CreateUserInput{
+		Username:           username,
+		DisplayName:        displayName,
+		Email:              email,
+		Phone:              phone,
+		Attributes:         attrs,
+		AccountExpiresAt:   expires,
+		MustChangePassword: true,
+	}
+	if p.create.DisplayName == "" {
+		p.create.DisplayName = username
+	}
+
+	p.status = domain.UserStatusEnabled
+	if v, ok := r.cell(p, "status"); ok {
+		switch status := domain.UserStatus(strings.ToLower(v)); status {
+		case domain.UserStatusEnabled, domain.UserStatusDisabled:
+			p.status = status
+		case domain.UserStatusPending:
+			if r.opts.Credentials != domain.CSVCredentialsInvite {
+				p.fail("pending users are created by invitation")
+			}
+		default:
+			p.fail("invalid status %q", v)
+		}
+	}
+
+	switch pw, ok := r.cell(p, "password"); {
+	case ok && password.Supported(pw):
+		p.create.PasswordHash = pw
+		p.create.MustChangePassword = false
+	case ok:
+		if v := checkPasswordQuality(r.rules, pw, username, p.create.DisplayName, email); len(v) > 0 {
+			p.fail("%v", &PasswordPolicyError{Violations: v})
+		}
+		p.create.Password = pw
+	case r.opts.Credentials == domain.CSVCredentialsInvite:
+		p.invite = true
+	case r.opts.Credentials == domain.CSVCredentialsNone:
+		p.create.NoPassword = true
+		p.create.MustChangePassword = false
+	}
+}
+
+// planUpdate plans the changes to an existing user, recording the fields
+// changed.
+func (r *csvImport) planUpdate(ctx context.Context, p *csvPlan, displayName, email, phone string, expires *time.Time, attrs map[string][]string) {
+	u := p.user
+	if displayName != "" && displayName != u.DisplayName {
+		p.update.DisplayName = &displayName
+		p.res.Fields = append(p.res.Fields, "display_name")
+	}
+	if email != "" && email != u.Email {
+		p.update.Email = &email
+		p.res.Fields = append(p.res.Fields, "email")
+	}
+	if phone != "" && phone != u.Phone {
+		p.update.Phone = &phone
+		p.res.Fields = append(p.res.Fields, "phone")
+	}
+	if expires != nil && (u.AccountExpiresAt == nil || !expires.Equal(*u.AccountExpiresAt)) {
+		p.update.AccountExpiresAt = expires
+		p.res.Fields = append(p.res.Fields, "account_expires_at")
+	}
+	for _, name := range slices.Sorted(maps.Keys(attrs)) {
+		if !slices.Equal(attrs[name], u.Attributes[name]) {
+			if p.update.Attributes == nil {
+				p.update.Attributes = make(map[string][]string)
+			}
+			p.update.Attributes[name] = attrs[name]
+			p.res.Fields = append(p.res.Fields, "attr."+name)
+		}
+	}
+
+	p.status = u.Status
+	if v, ok := r.cell(p, "status"); ok {
+		switch status := domain.UserStatus(strings.ToLower(v)); status {
+		case domain.UserStatusEnabled, domain.UserStatusDisabled:
+			if u.Status == domain.UserStatusPending && status == domain.UserStatusEnabled {
+				p.fail("%v", ErrUserPending)
+			}
+			p.status = status
+		case domain.UserStatusPending:
+			if u.Status != domain.UserStatusPending {
+				p.fail("only invited users are pending")
+			}
+		default:
+			p.fail("invalid status %q", v)
+		}
+		if p.status != u.Status {
+			p.res.Fields = append(p.res.Fields, "status")
+		}
+	}
+
+	if pw, ok := r.cell(p, "password"); ok {
+		switch {
+		case password.Supported(pw):
+			if pw != u.PasswordHash {
+				p.hash = pw
+			}
+		case !passwordMatches(u.PasswordHash, pw):
+			_, v, err := r.s.users.passwordViolations(ctx, u, pw, false)
+			if err != nil {
+				p.fail("%v", err)
+			} else if len(v) > 0 {
+				p.fail("%v", &PasswordPolicyError{Violations: v})
+			}
+			p.password = pw
+		}
+		if p.hash != "" || p.password != "" {
+			p.res.Fields = append(p.res.Fields, "password")
+		}
+	}
+}
+
+// planGroups plans the group memberships of a row, checking that the acting
+// user may manage the groups joined or left.
+func (r *csvImport) planGroups(ctx context.Context, p *csvPlan) {
+	v, ok := r.cell(p, "groups")
+	if !ok {
+		return
+	}
+	var current []uuid.UUID
+	if p.user != nil {
+		current = r.memberOf[p.user.ID]
+	}
+	var listed []uuid.UUID
+	for _, name := range splitCSVList(v) {
+		g := r.groups[name]
+		if g == nil {
+			p.fail("group %q does not exist", name)
+			continue
+		}
+		listed = append(listed, g.ID)
+		if !slices.Contains(current, g.ID) && !slices.Contains(p.addGroups, g) {
+			p.addGroups = append(p.addGroups, g)
+		}
+	}
+	if r.opts.ReplaceGroups {
+		for _, id := range current {
+			if !slices.Contains(listed, id) {
+				p.leaveGroups = append(p.leaveGroups, r.groupByID(id))
+			}
+		}
+	}
+	for _, g := range append(slices.Clone(p.addGroups), p.leaveGroups...) {
+		err, ok := r.authorized[g.ID]
+		if !ok {
+			err = r.s.groups.authorizeMembership(ctx, g.ID)
+			r.authorized[g.ID] = err
+		}
+		if err != nil {
+			p.fail("cannot manage the members of group %q", g.Name)
+		}
+	}
+	if p.user != nil && len(p.addGroups)+len(p.leaveGroups) > 0 {
+		p.res.Fields = append(p.res.Fields, "groups")
+	}
+}
+
+func (r *csvImport) groupByID(id uuid.UUID) *domain.Group {
+	for _, g := range r.groups {
+		if g.ID == id {
+			return g
+		}
+	}
+	return nil
+}
+
+// planManagers resolves the managers of the valid rows, which may be users
+// of other rows, and checks that they do not form a reporting cycle. A row
+// whose manager is the new user of an invalid row is invalid too, so the
+// managers are resolved until no more rows fail.
+func (r *csvImport) planManagers() {
+	for {
+		managers := make(map[string]string) // by lower-cased username
+		for key, u := range r.users {
+			if u.ManagerID != nil {
+				if m := r.usersByID[*u.ManagerID]; m != nil {
+					managers[key] = strings.ToLower(m.Username)
+				}
+			}
+		}
+		for _, p := range r.plans {
+			if p.manager != "" && !p.failed() {
+				managers[p.key] = p.manager
+			}
+		}
+
+		failed := false
+		for _, p := range r.plans {
+			if p.failed() {
+				continue
+			}
+			v, ok := r.cell(p, "manager")
+			if !ok {
+				continue
+			}
+			key := strings.ToLower(v)
+			switch row := r.rows[key]; {
+			case key == p.key:
+				p.fail("a user cannot be their own manager")
+			case r.users[key] == nil && row == nil:
+				p.fail("manager %q does not exist", v)
+			case r.users[key] == nil && row.failed():
+				p.fail("manager %q is in invalid row %d", v, row.res.Row)
+			case p.user != nil && p.user.ManagerID != nil && r.usersByID[*p.user.ManagerID] == r.users[key]:
+				// unchanged
+			case reachesManager(managers, key, p.key):
+				p.fail("manager %q would create a reporting cycle", v)
+			default:
+				p.manager = key
+				managers[p.key] = key
+			}
+			failed = failed || p.failed()
+		}
+		if !failed {
+			break
+		}
+	}
+	for _, p := range r.plans {
+		if p.manager != "" && p.user != nil && !p.failed() {
+			p.res.Fields = append(p.res.Fields, "manager")
+			p.res.Action = domain.CSVImportUpdate
+		}
+	}
+}
+
+// authorizeUpdates fails the rows that change existing users the actor may
+// not manage. Changing only their group memberships is authorized per group.
+func (r *csvImport) authorizeUpdates(ctx context.Context) error {
+	for _, p := range r.plans {
+		if p.user == nil || p.failed() || !slices.ContainsFunc(p.res.Fields, func(f string) bool { return f != "groups" }) {
+			continue
+		}
+		if err := r.s.users.authorizeUserManagement(ctx, p.user); errors.Is(err, ErrForbidden) {
+			p.fail("%v", err)
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reachesManager reports whether the management chain from a user reaches
+// another.
+func reachesManager(managers map[string]string, from, to string) bool {
+	seen := make(map[string]bool)
+	for key := from; key != "" && !seen[key]; key = managers[key] {
+		if key == to {
+			return true
+		}
+		seen[key] = true
+	}
+	return false
+}
+
+// errImportRolledBack rolls back an all-or-nothing import in which a row
+// failed to apply.
+var errImportRolledBack = errors.New("import rolled back")
+
+// apply applies the valid rows. An all-or-nothing import applies them in
+// one transaction, which is rolled back if any row fails. Invitations are
+// sent once the users they are for are saved.
+func (r *csvImport) apply(ctx context.Context) {
+	if r.opts.Mode != domain.CSVImportAllOrNothing {
+		r.applyRows(ctx)
+		r.sendInvitations(ctx)
+		return
+	}
+	s := r.s
+	var failed *csvPlan
+	err := s.dao.WithTx(ctx, func(d *dao.DAO) error {
+		r.s = s.withDAO(d)
+		defer func() { r.s = s }()
+		if failed = r.applyRows(ctx); failed != nil {
+			return errImportRolledBack
+		}
+		return nil
+	})
+	if err != nil {
+		r.rollBack(failed, err)
+		return
+	}
+	r.sendInvitations(ctx)
+}
+
+// applyRows applies the valid rows in order, and then sets the managers,
+// which may be users created by later rows. In an all-or-nothing import
+// it stops at the first row that fails and returns it.
+func (r *csvImport) applyRows(ctx context.Context) *csvPlan {
+	allOrNothing := r.opts.Mode == domain.CSVImportAllOrNothing
+	for _, p := range r.plans {
+		if p.failed() {
+			continue
+		}
+		if err := r.applyRow(ctx, p); err != nil {
+			p.fail("%v", err)
+			if allOrNothing {
+				return p
+			}
+		}
+	}
+	for _, p := range r.plans {
+		if p.manager == "" || p.res.UserID == nil {
+			continue
+		}
+		m := r.users[p.manager]
+		if m == nil {
+			p.fail("manager was not created")
+		} else if _, err := r.s.users.UpdateUser(ctx, *p.res.UserID, domain.UpdateUserInput{ManagerID: &m.ID}); err != nil {
+			p.fail("setting manager: %v", err)
+		}
+		if p.failed() && allOrNothing {
+			return p
+		}
+	}
+	return nil
+}
+
+// rollBack reports that a rolled back import applied no row, because the
+// failed one did or because of err.
+func (r *csvImport) rollBack(failed *csvPlan, err error) {
+	r.report.Applied = false
+	reason := fmt.Sprintf("not applied: %v", err)
+	if failed != nil {
+		reason = fmt.Sprintf("not applied: row %d failed", failed.res.Row)
+	}
+	for _, p := range r.plans {
+		p.res.UserID = nil
+		p.res.Password = ""
+		if p == failed {
+			continue
+		}
+		if p.res.Action == domain.CSVImportCreate || p.res.Action == domain.CSVImportUpdate {
+			p.res.Action = domain.CSVImportSkip
+			p.res.Errors = append(p.res.Errors, reason)
+		}
+	}
+}
+
+// sendInvitations emails the users created pending their invitations.
+func (r *csvImport) sendInvitations(ctx context.Context) {
+	for _, p := range r.plans {
+		if !p.invite || p.res.UserID == nil {
+			continue
+		}
+		err := r.s.invitations.send(ctx, r.usersByID[*p.res.UserID])
+		switch {
+		case errors.Is(err, ErrMailDelivery):
+			p.res.Errors = append(p.res.Errors, "the invitation email could not be sent; resend it")
+		case err != nil:
+			p.res.Errors = append(p.res.Errors, fmt.Sprintf("the invitation could not be sent: %v; resend it", err))
+		default:
+			p.res.Invited = true
+		}
+	}
+}
+
+// applyRow creates or updates the user of a row and their memberships.
+func (r *csvImport) applyRow(ctx context.Context, p *csvPlan) error {
+	s := r.s
+	u := p.user
+	if u == nil {
+		var err error
+		if u, err = r.createUser(ctx, p); err != nil {
+			return err
+		}
+		r.addUser(u)
+	} else {
+		in := p.update
+		if in.DisplayName != nil || in.Email != nil || in.Phone != nil || in.AccountExpiresAt != nil || len(in.Attributes) > 0 {
+			if _, err := s.users.UpdateUser(ctx, u.ID, in); err != nil {
+				return err
+			}
+		}
+		if p.status != u.Status {
+			if err := s.users.SetUserStatus(ctx, u.ID, p.status); err != nil {
+				return err
+			}
+		}
+		if p.hash != "" {
+			if err := s.users.ImportPasswordHash(ctx, u.ID, p.hash); err != nil {
+				return err
+			}
+		}
+		if p.password != "" {
+			if err := s.users.ResetPassword(ctx, u.ID, p.password, true); err != nil {
+				return err
+			}
+		}
+	}
+	p.res.UserID = &u.ID
+
+	for _, g := range p.addGroups {
+		if err := s.groups.AddMembers(ctx, g.ID, []uuid.UUID{u.ID}); err != nil {
+			return fmt.Errorf("joining group %q: %w", g.Name, err)
+		}
+	}
+	for _, g := range p.leaveGroups {
+		if err := s.groups.RemoveMember(ctx, g.ID, u.ID); err != nil {
+			return fmt.Errorf("leaving group %q: %w", g.Name, err)
+		}
+	}
+	return nil
+}
+
+// createUser creates the user of a row with the password of the row, a
+// generated one, an invitation or no password.
+func (r *csvImport) createUser(ctx context.Context, p *csvPlan) (*domain.User, error) {
+	s := r.s
+	in := p.create
+	if p.invite {
+		u, err := s.users.createPendingUser(ctx, domain.InviteUserInput{
+			Username:         in.Username,
+			DisplayName:      in.DisplayName,
+			Email:            in.Email,
+			Phone:            in.Phone,
+			Attributes:       in.Attributes,
+			AccountExpiresAt: in.AccountExpiresAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if p.status == domain.UserStatusDisabled {
+			if err := s.users.SetUserStatus(ctx, u.ID, p.status); err != nil {
+				return nil, err
+			}
+		}
+		return u, nil
+	}
+
+	if in.Password == "" && in.PasswordHash == "" && !in.NoPassword {
+		pw, err := generatePassword(r.rules, in.Username, in.DisplayName, in.Email)
+		if err != nil {
+			return nil, err
+		}
+		in.Password = pw
+		p.res.Password = pw
+	}
+	u, err := s.users.CreateUser(ctx, *in)
+	if err != nil {
+		return nil, err
+	}
+	if p.status != domain.UserStatusEnabled {
+		if err := s.users.SetUserStatus(ctx, u.ID, p.status); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+// WriteCSVImportReport writes the rows of a CSV import report to w as CSV:
+// the cells of each row, followed by its result and errors and any
+// generated password. With failedOnly only the rows that failed or were
+// skipped are written, so that they can be corrected and imported again.
+func WriteCSVImportReport(w io.Writer, report *domain.CSVImportReport, failedOnly bool) error {
+	cw := csv.NewWriter(w)
+	header := append(slices.Clone(report.Header), "result", "errors")
+	passwords := slices.ContainsFunc(report.Rows, func(row *domain.CSVImportRow) bool { return row.Password != "" })
+	if passwords {
+		header = append(header, "generated_password")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, row := range report.Rows {
+		if failedOnly && row.Action != domain.CSVImportFail && row.Action != domain.CSVImportSkip {
+			continue
+		}
+		values := slices.Clone(row.Values)
+		for len(values) < len(report.Header) {
+			values = append(values, "")
+		}
+		values = append(values, string(row.Action), strings.Join(row.Errors, "; "))
+		if passwords {
+			values = append(values, row.Password)
+		}
+		if err := cw.Write(values); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// parseCSVTime parses an RFC 3339 time or a date, which is taken as
+// midnight UTC.
+func parseCSVTime(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, v)
+}
+
+// splitCSVList splits a cell into its non-empty, trimmed values.
+func splitCSVList(v string) []string {
+	var values []string
+	for _, s := range strings.Split(v, csvListSeparator) {
+		if s = strings.TrimSpace(s); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/hook"
+)
+
+func setupCSVService(t *testing.T) (*CSVService, *UserService, *GroupService, context.Context) {
+	t.Helper()
+	invitationSvc, _, userSvc, d, ctx := setupInvitationService(t)
+	groupSvc := NewGroupService(d)
+	if _, err := NewAttributeService(d).CreateDefinition(ctx, domain.CreateAttributeDefinitionInput{
+		Name: "department", Target: domain.AttributeTargetUser, Type: domain.AttributeTypeString, Indexed: true,
+	}); err != nil {
+		t.Fatalf("CreateDefinition: %v", err)
+	}
+	for _, name := range []string{"staff", "sales"} {
+		if _, err := groupSvc.CreateGroup(ctx, domain.CreateGroupInput{Name: name}); err != nil {
+			t.Fatalf("CreateGroup: %v", err)
+		}
+	}
+	return NewCSVService(d, userSvc, groupSvc, invitationSvc), userSvc, groupSvc, ctx
+}
+
+func csvActions(report *domain.CSVImportReport) []string {
+	var actions []string
+	for _, row := range report.Rows {
+		actions = append(actions, string(row.Action)+" "+row.Username)
+	}
+	return actions
+}
+
+func userGroupNames(t *testing.T, groupSvc *GroupService, ctx context.Context, id uuid.UUID) []string {
+	t.Helper()
+	groups, err := groupSvc.GetUserGroups(ctx, id)
+	if err != nil {
+		t.Fatalf("GetUserGroups: %v", err)
+	}
+	var names []string
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestCSVImport(t *testing.T) {
+	svc, userSvc, groupSvc, ctx := setupCSVService(t)
+	boss, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "boss", DisplayName: "Boss", Email: "boss@example.com", NoPassword: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	// Employee IDs are mapped to usernames; the notes column is ignored.
+	const content = "\xEF\xBB\xBFEmployee ID,Name,E-mail,Department,Groups,Manager,Notes\n" +
+		"amy,Amy,amy@example.com,Sales,staff;sales,ben,\n" +
+		"ben,Ben,ben@example.com,Sales,staff,boss,new hire\n" +
+		"boss,The Boss,,,staff,,\n"
+	opts := domain.CSVImportOptions{
+		Columns: map[string]string{"username": "employee id", "display_name": "Name", "email": "E-mail"},
+		DryRun:  true,
+	}
+	report, err := svc.ImportUsers(ctx, strings.NewReader(content), opts)
+	if err != nil {
+		t.Fatalf("ImportUsers dry run: %v", err)
+	}
+	want := []string{"create amy", "create ben", "update boss"}
+	if got := csvActions(report); !slices.Equal(got, want) {
+		t.Errorf("dry run actions = %v, want %v", got, want)
+	}
+	if report.Applied || !slices.Equal(report.Ignored, []string{"Notes"}) || report.Columns["attr.department"] != "Department" {
+		t.Errorf("dry run report = %+v", report)
+	}
+	if got := report.Rows[2].Fields; !slices.Equal(got, []string{"display_name", "groups"}) {
+		t.Errorf("fields = %v", got)
+	}
+	if _, err := userSvc.GetUserByUsername(ctx, "amy"); err == nil {
+		t.Fatal("dry run created a user")
+	}
+
+	opts.DryRun = false
+	if report, err = svc.ImportUsers(ctx, strings.NewReader(content), opts); err != nil {
+		t.Fatalf("ImportUsers: %v", err)
+	}
+	if !report.Applied || report.Created != 2 || report.Updated != 1 {
+		t.Fatalf("report = %v: %+v", csvActions(report), report.Rows)
+	}
+	amy, err := userSvc.GetUserByUsername(ctx, "amy")
+	if err != nil {
+		t.Fatalf("imported user: %v", err)
+	}
+	ben, _ := userSvc.GetUserByUsername(ctx, "ben")
+	if amy.ManagerID == nil || *amy.ManagerID != ben.ID || ben.ManagerID == nil || *ben.ManagerID != boss.ID {
+		t.Errorf("managers: amy %v, ben %v", amy.ManagerID, ben.ManagerID)
+	}
+	if !slices.Equal(amy.Attributes["department"], []string{"Sales"}) || !amy.MustChangePassword {
+		t.Errorf("amy = %+v", amy)
+	}
+	if got := userGroupNames(t, groupSvc, ctx, amy.ID); !slices.Equal(got, []string{"sales", "staff"}) {
+		t.Errorf("amy's groups = %v", got)
+	}
+	// Generated passwords are returned once, and work.
+	if pw := report.Rows[0].Password; pw == "" {
+		t.Error("no password was generated")
+	} else if _, err := userSvc.Authenticate(ctx, "amy", pw); err != nil {
+		t.Errorf("Authenticate with generated password: %v", err)
+	}
+
+	// Importing the file again changes nothing.
+	if report, err = svc.ImportUsers(ctx, strings.NewReader(content), opts); err != nil {
+		t.Fatalf("ImportUsers again: %v", err)
+	}
+	if report.Unchanged != 3 {
+		t.Errorf("second import = %v: %+v", csvActions(report), report.Rows)
+	}
+
+	// A file with an invalid row changes nothing unless best effort.
+	const invalid = "username,email,status,groups,manager\n" +
+		"amy,,disabled,,\n" +
+		"cat,not-an-email,,nobody,\n" +
+		"dan,,,,\n" +
+		"eve,eve@example.com,,,dan\n" +
+		"boss,,,,amy\n"
+	opts = domain.CSVImportOptions{}
+	if report, err = svc.ImportUsers(ctx, strings.NewReader(invalid), opts); err != nil {
+		t.Fatalf("ImportUsers invalid: %v", err)
+	}
+	want = []string{"update amy", "fail cat", "fail dan", "fail eve", "fail boss"}
+	if got := csvActions(report); !slices.Equal(got, want) {
+		t.Errorf("invalid actions = %v, want %v", got, want)
+	}
+	wantErrors := [][]string{
+		nil,
+		{`invalid email "not-an-email"`, `group "nobody" does not exist`},
+		{"email is empty"},
+		{`manager "dan" is in invalid row 4`},
+		{`manager "amy" would create a reporting cycle`},
+	}
+	for i, row := range report.Rows {
+		if !slices.Equal(row.Errors, wantErrors[i]) {
+			t.Errorf("row %d errors = %q, want %q", row.Row, row.Errors, wantErrors[i])
+		}
+	}
+	if report.Applied {
+		t.Error("an all-or-nothing import with invalid rows was applied")
+	}
+	if amy, _ = userSvc.GetUser(ctx, amy.ID); amy.Status != domain.UserStatusEnabled {
+		t.Error("amy was disabled")
+	}
+
+	opts.Mode = domain.CSVImportBestEffort
+	if report, err = svc.ImportUsers(ctx, strings.NewReader(invalid), opts); err != nil {
+		t.Fatalf("ImportUsers best effort: %v", err)
+	}
+	if !report.Applied || report.Updated != 1 || report.Failed != 4 {
+		t.Errorf("best effort = %v", csvActions(report))
+	}
+	if amy, _ = userSvc.GetUser(ctx, amy.ID); amy.Status != domain.UserStatusDisabled {
+		t.Error("amy was not disabled")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSVImportReport(&buf, report, true); err != nil {
+		t.Fatalf("WriteCSVImportReport: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading report: %v", err)
+	}
+	if len(records) != 5 || !slices.Equal(records[0], []string{"username", "email", "status", "groups", "manager", "result", "errors"}) ||
+		!slices.Equal(records[2], []string{"dan", "", "", "", "", "fail", "email is empty"}) {
+		t.Errorf("error report = %q", records)
+	}
+
+	// Group memberships are replaced on request.
+	opts = domain.CSVImportOptions{ReplaceGroups: true}
+	if _, err = svc.ImportUsers(ctx, strings.NewReader("username,groups\namy,staff\n"), opts); err != nil {
+		t.Fatalf("ImportUsers replace groups: %v", err)
+	}
+	if got := userGroupNames(t, groupSvc, ctx, amy.ID); !slices.Equal(got, []string{"staff"}) {
+		t.Errorf("amy's groups = %v, want [staff]", got)
+	}
+
+	// Only members of groups the actor may manage are changed.
+	actor := WithActor(ctx, &domain.Actor{UserID: boss.ID, Permissions: []string{domain.PermissionUserWrite}})
+	if report, err = svc.ImportUsers(actor, strings.NewReader("username,groups\nben,sales\n"), opts); err != nil {
+		t.Fatalf("ImportUsers as group member: %v", err)
+	}
+	if got := report.Rows[0].Errors; !slices.Equal(got, []string{`cannot manage the members of group "sales"`, `cannot manage the members of group "staff"`}) {
+		t.Errorf("errors = %q", got)
+	}
+
+	for _, tt := range []struct {
+		content string
+		opts    domain.CSVImportOptions
+	}{
+		{"", domain.CSVImportOptions{}},
+		{"email\nx@example.com\n", domain.CSVImportOptions{}},
+		{"user,user\n", domain.CSVImportOptions{}},
+		{"username\n", domain.CSVImportOptions{Columns: map[string]string{"email": "mail"}}},
+		{"username\n", domain.CSVImportOptions{Mode: "sometimes"}},
+		{"username\n\"amy\n", domain.CSVImportOptions{}},
+	} {
+		if _, err := svc.ImportUsers(ctx, strings.NewReader(tt.content), tt.opts); !errors.Is(err, ErrInvalidCSV) {
+			t.Errorf("ImportUsers(%q, %+v): err = %v, want ErrInvalidCSV", tt.content, tt.opts, err)
+		}
+	}
+}
+
+func TestCSVImportRollsBack(t *testing.T) {
+	svc, userSvc, groupSvc, ctx := setupCSVService(t)
+	amy, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "amy", DisplayName: "Amy", Email: "amy@example.com", NoPassword: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	// cal is valid but fails to be saved, as if their email had been taken
+	// since the file was validated.
+	svc.dao.Client().User.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+			if name, _ := m.Username(); name == "cal" {
+				return nil, errors.New("email taken")
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+	const content = "username,display_name,email,groups,manager\n" +
+		"abe,Abe,abe@example.com,staff,amy\n" +
+		"amy,Amy Lee,,sales,\n" +
+		"cal,Cal,cal@example.com,,\n" +
+		"dee,Dee,dee@example.com,,\n"
+
+	report, err := svc.ImportUsers(ctx, strings.NewReader(content), domain.CSVImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportUsers: %v", err)
+	}
+	want := []string{"skip abe", "skip amy", "fail cal", "skip dee"}
+	if got := csvActions(report); !slices.Equal(got, want) || report.Applied {
+		t.Errorf("actions = %v, applied = %v, want %v and not applied", got, report.Applied, want)
+	}
+	if row := report.Rows[0]; row.UserID != nil || row.Password != "" {
+		t.Errorf("rolled back row = %+v, want no user or password", row)
+	}
+	if _, err := userSvc.GetUserByUsername(ctx, "abe"); err == nil {
+		t.Error("abe was created")
+	}
+	if amy, _ = userSvc.GetUser(ctx, amy.ID); amy.DisplayName != "Amy" {
+		t.Errorf("amy's display name = %q, want it unchanged", amy.DisplayName)
+	}
+	if got := userGroupNames(t, groupSvc, ctx, amy.ID); len(got) != 0 {
+		t.Errorf("amy's groups = %v, want none", got)
+	}
+
+	report, err = svc.ImportUsers(ctx, strings.NewReader(content), domain.CSVImportOptions{Mode: domain.CSVImportBestEffort})
+	if err != nil {
+		t.Fatalf("ImportUsers best effort: %v", err)
+	}
+	want = []string{"create abe", "update amy", "fail cal", "create dee"}
+	if got := csvActions(report); !slices.Equal(got, want) {
+		t.Errorf("best effort actions = %v, want %v", got, want)
+	}
+	if abe, err := userSvc.GetUserByUsername(ctx, "abe"); err != nil || abe.ManagerID == nil || *abe.ManagerID != amy.ID {
+		t.Errorf("abe = %+v, %v, want amy as manager", abe, err)
+	}
+}
+
+func TestCSVImportCredentials(t *testing.T) {
+	svc, userSvc, _, ctx := setupCSVService(t)
+	const content = "username,email,password\n" +
+		"fay,fay@example.com,\n" +
+		"gus,gus@example.com,Initial-pass-1\n"
+
+	report, err := svc.ImportUsers(ctx, strings.NewReader(content), domain.CSVImportOptions{Credentials: domain.CSVCredentialsInvite})
+	if err != nil {
+		t.Fatalf("ImportUsers: %v", err)
+	}
+	if report.Created != 2 || !report.Rows[0].Invited || report.Rows[1].Invited {
+		t.Fatalf("report = %+v", report.Rows)
+	}
+	fay, _ := userSvc.GetUserByUsername(ctx, "fay")
+	if fay.Status != domain.UserStatusPending {
+		t.Errorf("fay's status = %s, want pending", fay.Status)
+	}
+	if _, err := userSvc.Authenticate(ctx, "gus", "Initial-pass-1"); err != nil {
+		t.Errorf("Authenticate with imported password: %v", err)
+	}
+
+	report, err = svc.ImportUsers(ctx, strings.NewReader("username,email\nhal,hal@example.com\n"), domain.CSVImportOptions{Credentials: domain.CSVCredentialsNone})
+	if err != nil {
+		t.Fatalf("ImportUsers: %v", err)
+	}
+	if report.Rows[0].Password != "" {
+		t.Error("a password was generated")
+	}
+	if hal, _ := userSvc.GetUserByUsername(ctx, "hal"); hal.PasswordHash != "" || hal.MustChangePassword {
+		t.Errorf("hal = %+v", hal)
+	}
+}
+
+func TestCSVExport(t *testing.T) {
+	svc, _, _, ctx := setupCSVService(t)
+	content := "username,display_name,email,department,groups,manager,account_expires_at\n" +
+		"ivy,Ivy,ivy@example.com,Sales,sales;staff,,2030-01-02\n" +
+		"jon,Jon,jon@example.com,Support,staff,ivy,\n"
+	if _, err := svc.ImportUsers(ctx, strings.NewReader(content), domain.CSVImportOptions{}); err != nil {
+		t.Fatalf("ImportUsers: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := svc.ExportUsers(ctx, &buf, "", map[string]string{"department": "Support"}); err != nil {
+		t.Fatalf("ExportUsers: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), utf8BOM) {
+		t.Error("the export does not start with a byte order mark")
+	}
+	records, err := csv.NewReader(bytes.NewReader(buf.Bytes()[len(utf8BOM):])).ReadAll()
+	if err != nil {
+		t.Fatalf("reading export: %v", err)
+	}
+	want := [][]string{
+		{"username", "display_name", "email", "phone", "status", "manager", "account_expires_at", "groups", "attr.department"},
+		{"jon", "Jon", "jon@example.com", "", "enabled", "ivy", "", "staff", "Support"},
+	}
+	if len(records) != len(want) || !slices.Equal(records[0], want[0]) || !slices.Equal(records[1], want[1]) {
+		t.Errorf("export = %q, want %q", records, want)
+	}
+
+	buf.Reset()
+	if err := svc.ExportUsers(ctx, &buf, "ivy", nil); err != nil {
+		t.Fatalf("ExportUsers: %v", err)
+	}
+	if !strings.Contains(buf.String(), "ivy,Ivy,ivy@example.com,,enabled,,2030-01-02T00:00:00Z,sales;staff,Sales\n") {
+		t.Errorf("export = %q", buf.String())
+	}
+
+	// The export imports back without changes.
+	report, err := svc.ImportUsers(ctx, &buf, domain.CSVImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportUsers export: %v", err)
+	}
+	if report.Unchanged != 1 || len(report.Ignored) != 0 {
+		t.Errorf("re-import = %v, ignored %v", csvActions(report), report.Ignored)
+	}
+
+	if err := svc.ExportUsers(ctx, &buf, "", map[string]string{"nope": "x"}); !errors.Is(err, ErrInvalidAttributes) {
+		t.Errorf("ExportUsers with unknown attribute: err = %v, want ErrInvalidAttributes", err)
+	}
+}
@@ -0,0 +1,941 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ldap/upstream"
+	"github.com/qinzj/claude-demo/internal/password"
+)
+
+var (
+	// ErrDirectorySourceNotFound is returned for an unknown upstream
+	// directory.
+	ErrDirectorySourceNotFound = errors.New("directory source not found")
+	// ErrDirectoryUnreachable is returned when an upstream directory
+	// cannot be read. Nothing is changed.
+	ErrDirectoryUnreachable = errors.New("directory unreachable")
+	// ErrDirectoryEmpty is returned when an upstream directory returns no
+	// users or groups where some were synchronized before, which more
+	// likely means a broken search than that they were all deleted.
+	// Nothing is changed.
+	ErrDirectoryEmpty = errors.New("directory search returned no entries")
+)
+
+// directory is a connection to an upstream directory.
+type directory interface {
+	Search(baseDN, filter string, attributes []string) ([]*ldap.Entry, error)
+	Close() error
+}
+
+// dialDirectory connects to a source's directory.
+func dialDirectory(src *domain.DirectorySource) (directory, error) {
+	return upstream.Dial(upstream.Config{
+		URL:                src.URL,
+		BindDN:             src.BindDN,
+		BindPassword:       src.BindPassword,
+		StartTLS:           src.StartTLS,
+		InsecureSkipVerify: src.InsecureSkipVerify,
+		Timeout:            src.Timeout,
+	})
+}
+
+// DirectorySyncService synchronizes users and groups from upstream LDAP
+// directories, such as those being migrated from. Each synchronization
+// reads all of a source's users and groups, and then creates, updates and
+// removes local ones to match, through the user and group services. The
+// users and groups synchronized from an entry are linked to it by the
+// entry's immutable identifier, so they follow it across renames.
+type DirectorySyncService struct {
+	dao     *dao.DAO
+	users   *UserService
+	groups  *GroupService
+	sources []domain.DirectorySource
+	dial    func(*domain.DirectorySource) (directory, error)
+
+	// mu serializes synchronizations, so that two never change the same
+	// users at once.
+	mu sync.Mutex
+
+	reportsMu sync.Mutex
+	reports   map[string]*domain.DirectorySyncReport
+}
+
+// NewDirectorySyncService creates a new DirectorySyncService for the given
+// sources.
+func NewDirectorySyncService(d *dao.DAO, userSvc *UserService, groupSvc *GroupService, sources []domain.DirectorySource) *DirectorySyncService {
+	return &DirectorySyncService{
+		dao:     d,
+		users:   userSvc,
+		groups:  groupSvc,
+		sources: sources,
+		dial:    dialDirectory,
+		reports: make(map[string]*domain.DirectorySyncReport),
+	}
+}
+
+// Sources returns the configured sources, with the report of their last
+// synchronization since the server started.
+func (s *DirectorySyncService) Sources() []*domain.DirectorySourceStatus {
+	s.reportsMu.Lock()
+	defer s.reportsMu.Unlock()
+	statuses := make([]*domain.DirectorySourceStatus, len(s.sources))
+	for i := range s.sources {
+		statuses[i] = &domain.DirectorySourceStatus{Source: &s.sources[i], LastReport: s.reports[s.sources[i].Name]}
+	}
+	return statuses
+}
+
+// Sync synchronizes a source now and returns the report, or in a dry run
+// only reports what synchronizing it would do. The report is returned
+// along with ErrDirectoryUnreachable or ErrDirectoryEmpty, in which case
+// nothing was changed.
+func (s *DirectorySyncService) Sync(ctx context.Context, name string, dryRun bool) (*domain.DirectorySyncReport, error) {
+	i := slices.IndexFunc(s.sources, func(src domain.DirectorySource) bool { return src.Name == name })
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrDirectorySourceNotFound, name)
+	}
+	src := &s.sources[i]
+	if !dryRun {
+		if err := recordAudit(ctx, s.dao, &domain.AuditEvent{
+			Action:     domain.AuditDirectorySync,
+			TargetType: domain.AuditTargetDirectory,
+			TargetID:   src.Name,
+			TargetName: src.Name,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return s.sync(ctx, src, dryRun)
+}
+
+// Run synchronizes each source with an interval every interval, the first
+// time right away, until ctx is canceled. Errors, and users and groups that
+// failed to synchronize, are passed to onError.
+func (s *DirectorySyncService) Run(ctx context.Context, onError func(error)) {
+	// Intervals are measured in minutes, so a check every minute is
+	// precise enough.
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	next := make(map[string]time.Time)
+	for {
+		now := time.Now()
+		for i := range s.sources {
+			src := &s.sources[i]
+			if src.Interval <= 0 || now.Before(next[src.Name]) {
+				continue
+			}
+			next[src.Name] = now.Add(src.Interval)
+			report, err := s.sync(ctx, src, false)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				onError(fmt.Errorf("source %s: %w", src.Name, err))
+			} else if report.Users.Failed > 0 || report.Groups.Failed > 0 {
+				onError(fmt.Errorf("source %s: %d users and %d groups failed to synchronize",
+					src.Name, report.Users.Failed, report.Groups.Failed))
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync synchronizes a source and, unless in a dry run, keeps the report.
+func (s *DirectorySyncService) sync(ctx context.Context, src *domain.DirectorySource, dryRun bool) (*domain.DirectorySyncReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Changes are made by the synchronization, not on behalf of whoever
+	// asked for it, who would otherwise become the owner of new groups.
+	ctx = WithActor(ctx, nil)
+	r := &directorySyncRun{
+		s:      s,
+		src:    src,
+		dryRun: dryRun,
+		report: &domain.DirectorySyncReport{
+			Source:    src.Name,
+			DryRun:    dryRun,
+			StartedAt: time.Now(),
+			Changes:   []*domain.DirectorySyncChange{},
+		},
+	}
+	err := r.execute(ctx)
+	r.report.FinishedAt = time.Now()
+	if err != nil {
+		r.report.Error = err.Error()
+	}
+	if !dryRun {
+		s.reportsMu.Lock()
+		s.reports[src.Name] = r.report
+		s.reportsMu.Unlock()
+	}
+	return r.report, err
+}
+
+// upstreamUser is a user read from an upstream directory. Attributes holds
+// the values of every mapped extension attribute, nil if it has none.
+type upstreamUser struct {
+	id, dn                                      string
+	username, displayName, email, phone, passwd string
+	attributes                                  map[string][]string
+	disabled                                    bool
+}
+
+// upstreamGroup is a group read from an upstream directory.
+type upstreamGroup struct {
+	id, dn            string
+	name, description string
+	attributes        map[string][]string
+	members           []string
+}
+
+// directorySyncRun is a single synchronization of a source.
+type directorySyncRun struct {
+	s      *DirectorySyncService
+	src    *domain.DirectorySource
+	dryRun bool
+	report *domain.DirectorySyncReport
+
+	// links of this source by external ID, and of all sources by local ID.
+	links  map[domain.DirectoryResourceType]map[string]*domain.DirectoryObject
+	linked map[domain.DirectoryResourceType]map[uuid.UUID]*domain.DirectoryObject
+	seen   map[uuid.UUID]bool
+
+	users       map[uuid.UUID]*domain.User
+	usersByName map[string]*domain.User
+	groups      map[uuid.UUID]*domain.Group
+
+	// The users synchronized in this run, by the normalized DN and the
+	// lower-case username of their entries, mapped to the lower-case
+	// username of the local user; and their IDs by lower-case username,
+	// nil for users only created in a dry run.
+	memberDNs   map[string]string
+	memberNames map[string]string
+	userIDs     map[string]uuid.UUID
+}
+
+func (r *directorySyncRun) execute(ctx context.Context) error {
+	users, groups, err := r.read()
+	if err != nil {
+		return err
+	}
+
+	objects, err := r.s.dao.DirectoryObjects(ctx)
+	if err != nil {
+		return err
+	}
+	localUsers, err := r.s.users.AllUsers(ctx)
+	if err != nil {
+		return err
+	}
+	localGroups, err := r.s.groups.AllGroups(ctx)
+	if err != nil {
+		return err
+	}
+	r.index(objects, localUsers, localGroups)
+
+	if len(users) == 0 {
+		if err := r.checkEmpty(domain.DirectoryUser); err != nil {
+			return err
+		}
+	}
+	if len(groups) == 0 && r.src.SyncGroups {
+		if err := r.checkEmpty(domain.DirectoryGroup); err != nil {
+			return err
+		}
+	}
+
+	for _, u := range users {
+		r.syncUser(ctx, u)
+	}
+	if r.src.SyncGroups {
+		for _, g := range groups {
+			r.syncGroup(ctx, g)
+		}
+	}
+	r.removeStale(ctx)
+	return nil
+}
+
+// read reads the users and, if synchronized, groups of the source, sorted
+// by name. Entries that cannot be synchronized are reported as failed.
+func (r *directorySyncRun) read() ([]*upstreamUser, []*upstreamGroup, error) {
+	conn, err := r.s.dial(r.src)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrDirectoryUnreachable, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	um := r.src.Users
+	attributes := []string{um.IDAttribute, um.Username, um.DisplayName, um.Email, um.Phone, um.Password}
+	attributes = append(attributes, slices.Collect(maps.Values(um.Attributes))...)
+	entries, err := conn.Search(um.BaseDN, um.Filter, nonEmpty(attributes))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrDirectoryUnreachable, err)
+	}
+	disabled := make(map[string]bool)
+	if um.DisabledFilter != "" {
+		filter := "(&" + parenthesize(um.Filter) + parenthesize(um.DisabledFilter) + ")"
+		matches, err := conn.Search(um.BaseDN, filter, []string{um.IDAttribute})
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrDirectoryUnreachable, err)
+		}
+		for _, e := range matches {
+			disabled[upstream.Value(e, um.IDAttribute)] = true
+		}
+	}
+
+	var users []*upstreamUser
+	ids := make(map[string]bool)
+	for _, e := range entries {
+		u := &upstreamUser{
+			id:          upstream.Value(e, um.IDAttribute),
+			dn:          e.DN,
+			username:    value(e, um.Username),
+			displayName: value(e, um.DisplayName),
+			email:       value(e, um.Email),
+			phone:       value(e, um.Phone),
+			passwd:      value(e, um.Password),
+			attributes:  mappedValues(e, um.Attributes),
+		}
+		u.disabled = disabled[u.id]
+		if u.displayName == "" {
+			u.displayName = u.username
+		}
+		switch {
+		case u.id == "":
+			r.fail(domain.DirectoryUser, u.username, e.DN, "entry has no "+um.IDAttribute)
+		case ids[u.id]:
+			r.fail(domain.DirectoryUser, u.username, e.DN, "duplicate "+um.IDAttribute+" "+u.id)
+		case u.username == "":
+			r.fail(domain.DirectoryUser, "", e.DN, "entry has no "+um.Username)
+		default:
+			ids[u.id] = true
+			users = append(users, u)
+		}
+	}
+	slices.SortFunc(users, func(a, b *upstreamUser) int { return cmp.Compare(a.username, b.username) })
+
+	if !r.src.SyncGroups {
+		return users, nil, nil
+	}
+	gm := r.src.Groups
+	attributes = []string{gm.IDAttribute, gm.Name, gm.Description, gm.Member}
+	attributes = append(attributes, slices.Collect(maps.Values(gm.Attributes))...)
+	if entries, err = conn.Search(gm.BaseDN, gm.Filter, nonEmpty(attributes)); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrDirectoryUnreachable, err)
+	}
+	var groups []*upstreamGroup
+	clear(ids)
+	for _, e := range entries {
+		g := &upstreamGroup{
+			id:          upstream.Value(e, gm.IDAttribute),
+			dn:          e.DN,
+			name:        value(e, gm.Name),
+			description: value(e, gm.Description),
+			attributes:  mappedValues(e, gm.Attributes),
+		}
+		if gm.Member != "" {
+			g.members = upstream.Values(e, gm.Member)
+		}
+		switch {
+		case g.id == "":
+			r.fail(domain.DirectoryGroup, g.name, e.DN, "entry has no "+gm.IDAttribute)
+		case ids[g.id]:
+			r.fail(domain.DirectoryGroup, g.name, e.DN, "duplicate "+gm.IDAttribute+" "+g.id)
+		case g.name == "":
+			r.fail(domain.DirectoryGroup, "", e.DN, "entry has no "+gm.Name)
+		default:
+			ids[g.id] = true
+			groups = append(groups, g)
+		}
+	}
+	slices.SortFunc(groups, func(a, b *upstreamGroup) int { return cmp.Compare(a.name, b.name) })
+	return users, groups, nil
+}
+
+func (r *directorySyncRun) index(objects []*domain.DirectoryObject, users []*domain.User, groups []*domain.Group) {
+	r.links = map[domain.DirectoryResourceType]map[string]*domain.DirectoryObject{
+		domain.DirectoryUser:  {},
+		domain.DirectoryGroup: {},
+	}
+	r.linked = map[domain.DirectoryResourceType]map[uuid.UUID]*domain.DirectoryObject{
+		domain.DirectoryUser:  {},
+		domain.DirectoryGroup: {},
+	}
+	for _, o := range objects {
+		r.linked[o.ResourceType][o.LocalID] = o
+		if o.Source == r.src.Name {
+			r.links[o.ResourceType][o.ExternalID] = o
+		}
+	}
+	r.seen = make(map[uuid.UUID]bool)
+
+	r.users = make(map[uuid.UUID]*domain.User, len(users))
+	r.usersByName = make(map[string]*domain.User, len(users))
+	for _, u := range users {
+		r.users[u.ID] = u
+		r.usersByName[strings.ToLower(u.Username)] = u
+	}
+	r.groups = make(map[uuid.UUID]*domain.Group, len(groups))
+	for _, g := range groups {
+		r.groups[g.ID] = g
+	}
+
+	r.memberDNs = make(map[string]string)
+	r.memberNames = make(map[string]string)
+	r.userIDs = make(map[string]uuid.UUID)
+}
+
+// checkEmpty refuses to remove every user or group synchronized from the
+// source because its search returned none.
+func (r *directorySyncRun) checkEmpty(t domain.DirectoryResourceType) error {
+	if r.src.Delete == domain.DirectoryDeleteKeep {
+		return nil
+	}
+	n := 0
+	for _, o := range r.links[t] {
+		if _, ok := r.users[o.LocalID]; ok && t == domain.DirectoryUser {
+			n++
+		} else if _, ok := r.groups[o.LocalID]; ok && t == domain.DirectoryGroup {
+			n++
+		}
+	}
+	if n > 0 {
+		return fmt.Errorf("%w: %d %ss synchronized before would be removed", ErrDirectoryEmpty, n, t)
+	}
+	return nil
+}
+
+// syncUser creates or updates the local user of an upstream user.
+func (r *directorySyncRun) syncUser(ctx context.Context, e *upstreamUser) {
+	change := &domain.DirectorySyncChange{ResourceType: domain.DirectoryUser, Name: e.username, DN: e.dn}
+	link := r.links[domain.DirectoryUser][e.id]
+	var u *domain.User
+	if link != nil {
+		r.seen[link.ID] = true
+		u = r.users[link.LocalID]
+	}
+	if u == nil {
+		if existing := r.usersByName[strings.ToLower(e.username)]; existing != nil {
+			if reason := r.conflict(domain.DirectoryUser, existing.ID, "username"); reason != "" {
+				r.conflictWith(change, &r.report.Users, reason)
+				return
+			}
+			u = existing
+			change.Action = domain.DirectorySyncAdopt
+		}
+	}
+	if u != nil && !strings.EqualFold(u.Username, e.username) {
+		r.conflictWith(change, &r.report.Users, fmt.Sprintf("renamed upstream from %s, but usernames cannot be changed", u.Username))
+		r.addMember(e, u.Username, u.ID)
+		return
+	}
+
+	status := domain.UserStatusEnabled
+	if e.disabled {
+		status = domain.UserStatusDisabled
+	}
+	digest := ""
+	if e.passwd != "" && password.Supported(e.passwd) {
+		digest = hashToken(e.passwd)
+	}
+
+	if u == nil {
+		change.Action = domain.DirectorySyncCreate
+		id := uuid.Nil
+		if !r.dryRun {
+			var err error
+			if id, err = r.createUser(ctx, e, status, digest, link); err != nil {
+				r.failWith(change, &r.report.Users, err)
+				return
+			}
+			change.LocalID = &id
+		}
+		r.report.Users.Created++
+		r.report.Changes = append(r.report.Changes, change)
+		r.addMember(e, e.username, id)
+		return
+	}
+
+	change.LocalID = &u.ID
+	um := r.src.Users
+	var input domain.UpdateUserInput
+	if um.DisplayName != "" && u.DisplayName != e.displayName {
+		input.DisplayName = &e.displayName
+		change.Fields = append(change.Fields, "display_name")
+	}
+	if um.Email != "" && u.Email != e.email {
+		if e.email == "" {
+			r.failWith(change, &r.report.Users, fmt.Errorf("entry has no %s", um.Email))
+			return
+		}
+		input.Email = &e.email
+		change.Fields = append(change.Fields, "email")
+	}
+	if um.Phone != "" && u.Phone != e.phone {
+		input.Phone = &e.phone
+		change.Fields = append(change.Fields, "phone")
+	}
+	input.Attributes = changedAttributes(um.Attributes, e.attributes, u.Attributes)
+	for _, name := range slices.Sorted(maps.Keys(input.Attributes)) {
+		change.Fields = append(change.Fields, "attributes."+name)
+	}
+	setStatus := u.Status != domain.UserStatusPending && u.Status != status
+	if setStatus {
+		change.Fields = append(change.Fields, "status")
+	}
+	setPassword := digest != "" && (link == nil || link.PasswordDigest != digest)
+	if setPassword {
+		change.Fields = append(change.Fields, "password")
+	}
+
+	if !r.dryRun {
+		err := r.updateUser(ctx, u, input, setStatus, status, setPassword, e.passwd)
+		if err == nil && (link == nil || link.LocalID != u.ID || link.DN != e.dn || setPassword) {
+			err = r.saveLink(ctx, link, domain.DirectoryUser, e.id, u.ID, e.dn, digest)
+		}
+		if err != nil {
+			r.failWith(change, &r.report.Users, err)
+			return
+		}
+	}
+	r.addMember(e, u.Username, u.ID)
+	switch {
+	case change.Action == domain.DirectorySyncAdopt:
+		r.report.Users.Updated++
+	case len(change.Fields) > 0:
+		change.Action = domain.DirectorySyncUpdate
+		r.report.Users.Updated++
+	default:
+		r.report.Users.Unchanged++
+		return
+	}
+	r.report.Changes = append(r.report.Changes, change)
+}
+
+// createUser creates the local user of an upstream user, with its password
+// hash if imported and otherwise without a password, and links it to the
+// entry.
+func (r *directorySyncRun) createUser(ctx context.Context, e *upstreamUser, status domain.UserStatus, digest string, link *domain.DirectoryObject) (uuid.UUID, error) {
+	if e.email == "" {
+		return uuid.Nil, fmt.Errorf("entry has no %s", cmp.Or(r.src.Users.Email, "email"))
+	}
+	input := domain.CreateUserInput{
+		Username:    e.username,
+		DisplayName: e.displayName,
+		Email:       e.email,
+		Phone:       e.phone,
+		NoPassword:  digest == "",
+		Attributes:  make(map[string][]string),
+	}
+	if digest != "" {
+		input.PasswordHash = e.passwd
+	}
+	for name, values := range e.attributes {
+		if len(values) > 0 {
+			input.Attributes[name] = values
+		}
+	}
+	u, err := r.s.users.CreateUser(ctx, input)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if status != domain.UserStatusEnabled {
+		if err := r.s.users.SetUserStatus(ctx, u.ID, status); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	return u.ID, r.saveLink(ctx, link, domain.DirectoryUser, e.id, u.ID, e.dn, digest)
+}
+
+// updateUser applies the changes found to a user.
+func (r *directorySyncRun) updateUser(ctx context.Context, u *domain.User, input domain.UpdateUserInput, setStatus bool, status domain.UserStatus, setPassword bool, hash string) error {
+	if input.DisplayName != nil || input.Email != nil || input.Phone != nil || len(input.Attributes) > 0 {
+		if _, err := r.s.users.UpdateUser(ctx, u.ID, input); err != nil {
+			return err
+		}
+	}
+	if setStatus {
+		if err := r.s.users.SetUserStatus(ctx, u.ID, status); err != nil {
+			return err
+		}
+	}
+	if setPassword {
+		if err := r.s.users.ImportPasswordHash(ctx, u.ID, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addMember records a user synchronized in this run, for the groups it is a
+// member of.
+func (r *directorySyncRun) addMember(e *upstreamUser, username string, id uuid.UUID) {
+	name := strings.ToLower(username)
+	r.memberDNs[upstream.NormalizeDN(e.dn)] = name
+	r.memberNames[strings.ToLower(e.username)] = name
+	r.userIDs[name] = id
+}
+
+// syncGroup creates or updates the local group of an upstream group, with
+// the users synchronized from its members as members. Members that are not
+// synchronized from the source are left alone.
+func (r *directorySyncRun) syncGroup(ctx context.Context, e *upstreamGroup) {
+	change := &domain.DirectorySyncChange{ResourceType: domain.DirectoryGroup, Name: e.name, DN: e.dn}
+	link := r.links[domain.DirectoryGroup][e.id]
+	var g *domain.Group
+	if link != nil {
+		r.seen[link.ID] = true
+		g = r.groups[link.LocalID]
+	}
+	if g == nil {
+		for _, existing := range r.groups {
+			if !strings.EqualFold(existing.Name, e.name) {
+				continue
+			}
+			if reason := r.conflict(domain.DirectoryGroup, existing.ID, "name"); reason != "" {
+				r.conflictWith(change, &r.report.Groups, reason)
+				return
+			}
+			g = existing
+			change.Action = domain.DirectorySyncAdopt
+		}
+	}
+
+	want := make(map[string]bool)
+	for _, m := range e.members {
+		if name, ok := r.memberDNs[upstream.NormalizeDN(m)]; ok {
+			want[name] = true
+		} else if name, ok := r.memberNames[strings.ToLower(m)]; ok {
+			want[name] = true
+		}
+	}
+	have := make(map[string]bool)
+	if g != nil {
+		for _, u := range g.Users {
+			if o := r.linked[domain.DirectoryUser][u.ID]; o != nil && o.Source == r.src.Name {
+				have[strings.ToLower(u.Username)] = true
+			}
+		}
+	}
+	var added, removed []uuid.UUID
+	for _, name := range slices.Sorted(maps.Keys(want)) {
+		if !have[name] {
+			added = append(added, r.userIDs[name])
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(have)) {
+		if !want[name] {
+			removed = append(removed, r.usersByName[name].ID)
+		}
+	}
+
+	if g == nil {
+		change.Action = domain.DirectorySyncCreate
+		if !r.dryRun {
+			id, err := r.createGroup(ctx, e, added, link)
+			if err != nil {
+				r.failWith(change, &r.report.Groups, err)
+				return
+			}
+			change.LocalID = &id
+		}
+		r.report.Groups.Created++
+		r.report.Changes = append(r.report.Changes, change)
+		return
+	}
+
+	change.LocalID = &g.ID
+	gm := r.src.Groups
+	var input domain.UpdateGroupInput
+	if g.Name != e.name {
+		input.Name = &e.name
+		change.Fields = append(change.Fields, "name")
+	}
+	if gm.Description != "" && g.Description != e.description {
+		input.Description = &e.description
+		change.Fields = append(change.Fields, "description")
+	}
+	input.Attributes = changedAttributes(gm.Attributes, e.attributes, g.Attributes)
+	for _, name := range slices.Sorted(maps.Keys(input.Attributes)) {
+		change.Fields = append(change.Fields, "attributes."+name)
+	}
+	if len(added) > 0 || len(removed) > 0 {
+		change.Fields = append(change.Fields, "members")
+	}
+
+	if !r.dryRun {
+		err := r.updateGroup(ctx, g, input, added, removed)
+		if err == nil && (link == nil || link.LocalID != g.ID || link.DN != e.dn) {
+			err = r.saveLink(ctx, link, domain.DirectoryGroup, e.id, g.ID, e.dn, "")
+		}
+		if err != nil {
+			r.failWith(change, &r.report.Groups, err)
+			return
+		}
+	}
+	switch {
+	case change.Action == domain.DirectorySyncAdopt:
+		r.report.Groups.Updated++
+	case len(change.Fields) > 0:
+		change.Action = domain.DirectorySyncUpdate
+		r.report.Groups.Updated++
+	default:
+		r.report.Groups.Unchanged++
+		return
+	}
+	r.report.Changes = append(r.report.Changes, change)
+}
+
+// createGroup creates the local group of an upstream group with its
+// members, and links it to the entry.
+func (r *directorySyncRun) createGroup(ctx context.Context, e *upstreamGroup, members []uuid.UUID, link *domain.DirectoryObject) (uuid.UUID, error) {
+	input := domain.CreateGroupInput{Name: e.name, Description: e.description, Attributes: make(map[string][]string)}
+	for name, values := range e.attributes {
+		if len(values) > 0 {
+			input.Attributes[name] = values
+		}
+	}
+	g, err := r.s.groups.CreateGroup(ctx, input)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(members) > 0 {
+		if err := r.s.groups.AddMembers(ctx, g.ID, members); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	return g.ID, r.saveLink(ctx, link, domain.DirectoryGroup, e.id, g.ID, e.dn, "")
+}
+
+// updateGroup applies the changes found to a group.
+func (r *directorySyncRun) updateGroup(ctx context.Context, g *domain.Group, input domain.UpdateGroupInput, added, removed []uuid.UUID) error {
+	if input.Name != nil || input.Description != nil || len(input.Attributes) > 0 {
+		if _, err := r.s.groups.UpdateGroup(ctx, g.ID, input); err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		if err := r.s.groups.AddMembers(ctx, g.ID, added); err != nil {
+			return err
+		}
+	}
+	for _, id := range removed {
+		if err := r.s.groups.RemoveMember(ctx, g.ID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeStale disables or deletes the users, and deletes the groups, whose
+// entries are gone from the source, as its delete policy says. Links to
+// users and groups deleted locally are dropped.
+func (r *directorySyncRun) removeStale(ctx context.Context) {
+	for _, t := range []domain.DirectoryResourceType{domain.DirectoryGroup, domain.DirectoryUser} {
+		if t == domain.DirectoryGroup && !r.src.SyncGroups {
+			continue
+		}
+		stale := slices.DeleteFunc(slices.Collect(maps.Values(r.links[t])), func(o *domain.DirectoryObject) bool { return r.seen[o.ID] })
+		slices.SortFunc(stale, func(a, b *domain.DirectoryObject) int { return cmp.Compare(a.DN, b.DN) })
+		for _, o := range stale {
+			var err error
+			switch t {
+			case domain.DirectoryUser:
+				err = r.removeUser(ctx, o)
+			case domain.DirectoryGroup:
+				err = r.removeGroup(ctx, o)
+			}
+			if err != nil && ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
+
+func (r *directorySyncRun) removeUser(ctx context.Context, o *domain.DirectoryObject) error {
+	u := r.users[o.LocalID]
+	if u == nil {
+		return r.deleteLink(ctx, o)
+	}
+	change := &domain.DirectorySyncChange{ResourceType: domain.DirectoryUser, Name: u.Username, DN: o.DN, LocalID: &u.ID}
+	switch r.src.Delete {
+	case domain.DirectoryDeleteDisable:
+		if u.Status == domain.UserStatusDisabled {
+			return nil
+		}
+		change.Action = domain.DirectorySyncDisable
+		if !r.dryRun {
+			if err := r.s.users.SetUserStatus(ctx, u.ID, domain.UserStatusDisabled); err != nil {
+				r.failWith(change, &r.report.Users, err)
+				return err
+			}
+		}
+		r.report.Users.Disabled++
+	case domain.DirectoryDeleteDelete:
+		change.Action = domain.DirectorySyncDelete
+		if !r.dryRun {
+			err := r.s.users.DeleteUser(ctx, u.ID)
+			if err == nil {
+				err = r.deleteLink(ctx, o)
+			}
+			if err != nil {
+				r.failWith(change, &r.report.Users, err)
+				return err
+			}
+		}
+		r.report.Users.Deleted++
+	default:
+		return nil
+	}
+	r.report.Changes = append(r.report.Changes, change)
+	return nil
+}
+
+func (r *directorySyncRun) removeGroup(ctx context.Context, o *domain.DirectoryObject) error {
+	g := r.groups[o.LocalID]
+	if g == nil {
+		return r.deleteLink(ctx, o)
+	}
+	if r.src.Delete == domain.DirectoryDeleteKeep {
+		return nil
+	}
+	change := &domain.DirectorySyncChange{
+		ResourceType: domain.DirectoryGroup,
+		Action:       domain.DirectorySyncDelete,
+		Name:         g.Name,
+		DN:           o.DN,
+		LocalID:      &g.ID,
+	}
+	if !r.dryRun {
+		err := r.s.groups.DeleteGroup(ctx, g.ID)
+		if err == nil {
+			err = r.deleteLink(ctx, o)
+		}
+		if err != nil {
+			r.failWith(change, &r.report.Groups, err)
+			return err
+		}
+	}
+	r.report.Groups.Deleted++
+	r.report.Changes = append(r.report.Changes, change)
+	return nil
+}
+
+// conflict returns why an entry cannot be synchronized to the local user
+// or group whose name it has, or "" if, by the conflict policy, it adopts
+// it.
+func (r *directorySyncRun) conflict(t domain.DirectoryResourceType, localID uuid.UUID, field string) string {
+	if o := r.linked[t][localID]; o != nil {
+		if o.Source == r.src.Name {
+			return fmt.Sprintf("%s is taken by the %s synchronized from %s", field, t, o.DN)
+		}
+		return fmt.Sprintf("%s is taken by a %s synchronized from source %s", field, t, o.Source)
+	}
+	if r.src.Conflict != domain.DirectoryConflictAdopt {
+		return fmt.Sprintf("%s is taken by a local %s", field, t)
+	}
+	return ""
+}
+
+func (r *directorySyncRun) saveLink(ctx context.Context, link *domain.DirectoryObject, t domain.DirectoryResourceType, externalID string, localID uuid.UUID, dn, digest string) error {
+	o := &domain.DirectoryObject{Source: r.src.Name, ResourceType: t, ExternalID: externalID}
+	if link != nil {
+		o = link
+	}
+	o.LocalID, o.DN, o.PasswordDigest = localID, dn, digest
+	_, err := r.s.dao.SaveDirectoryObject(ctx, o)
+	return err
+}
+
+func (r *directorySyncRun) deleteLink(ctx context.Context, o *domain.DirectoryObject) error {
+	if r.dryRun {
+		return nil
+	}
+	return r.s.dao.DeleteDirectoryObject(ctx, o.ID)
+}
+
+func (r *directorySyncRun) conflictWith(change *domain.DirectorySyncChange, counts *domain.DirectorySyncCounts, reason string) {
+	change.Action = domain.DirectorySyncConflict
+	change.Error = reason
+	counts.Conflicts++
+	r.report.Changes = append(r.report.Changes, change)
+}
+
+func (r *directorySyncRun) failWith(change *domain.DirectorySyncChange, counts *domain.DirectorySyncCounts, err error) {
+	change.Action = domain.DirectorySyncFail
+	change.Error = err.Error()
+	counts.Failed++
+	r.report.Changes = append(r.report.Changes, change)
+}
+
+func (r *directorySyncRun) fail(t domain.DirectoryResourceType, name, dn, reason string) {
+	counts := &r.report.Users
+	if t == domain.DirectoryGroup {
+		counts = &r.report.Groups
+	}
+	r.failWith(&domain.DirectorySyncChange{ResourceType: t, Name: name, DN: dn}, counts, errors.New(reason))
+}
+
+// changedAttributes returns the mapped extension attributes whose upstream
+// values differ from the local ones, with the upstream values; an empty
+// slice removes an attribute. Values are compared regardless of order.
+func changedAttributes(mapping map[string]string, want, have map[string][]string) map[string][]string {
+	changed := make(map[string][]string)
+	for name := range mapping {
+		w, h := slices.Sorted(slices.Values(want[name])), slices.Sorted(slices.Values(have[name]))
+		if !slices.Equal(w, h) {
+			changed[name] = append([]string{}, want[name]...)
+		}
+	}
+	return changed
+}
+
+// mappedValues reads the values of the upstream attributes mapped to
+// extension attributes.
+func mappedValues(e *ldap.Entry, mapping map[string]string) map[string][]string {
+	values := make(map[string][]string, len(mapping))
+	for name, attr := range mapping {
+		values[name] = upstream.Values(e, attr)
+	}
+	return values
+}
+
+// value is upstream.Value for attributes that may not be mapped.
+func value(e *ldap.Entry, attr string) string {
+	if attr == "" {
+		return ""
+	}
+	return upstream.Value(e, attr)
+}
+
+// parenthesize wraps an LDAP filter in parentheses unless it already is.
+func parenthesize(filter string) string {
+	if strings.HasPrefix(filter, "(") {
+		return filter
+	}
+	return "(" + filter + ")"
+}
+
+// nonEmpty returns the non-empty strings of s.
+func nonEmpty(s []string) []string {
+	return slices.DeleteFunc(s, func(v string) bool { return v == "" })
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+// fakeDirectory is a stand-in upstream directory. Searches return the
+// entries whose objectClass the filter names, only those listed in disabled
+// for filters asking for disabled users, and only the one with the uid for
+// filters naming one.
+type fakeDirectory struct {
+	entries  []*ldap.Entry
+	disabled []string
+	err      error
+}
+
+func (f *fakeDirectory) Search(_, filter string, _ []string) ([]*ldap.Entry, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var found []*ldap.Entry
+	for _, e := range f.entries {
+		class := e.GetAttributeValue("objectClass")
+		if !strings.Contains(filter, "(objectClass="+class+")") {
+			continue
+		}
+		if strings.Contains(filter, "(disabled=TRUE)") && !slices.Contains(f.disabled, e.DN) {
+			continue
+		}
+		if strings.Contains(filter, "(uid=") && !strings.Contains(filter, "(uid="+e.GetAttributeValue("uid")+")") {
+			continue
+		}
+		found = append(found, e)
+	}
+	return found, nil
+}
+
+func (f *fakeDirectory) Close() error { return nil }
+
+func (f *fakeDirectory) addUser(id, uid, mail string, extra map[string][]string) {
+	attrs := map[string][]string{
+		"objectClass": {"person"},
+		"entryUUID":   {id},
+		"uid":         {uid},
+		"cn":          {strings.ToUpper(uid)},
+		"mail":        {mail},
+	}
+	for k, v := range extra {
+		attrs[k] = v
+	}
+	f.entries = append(f.entries, ldap.NewEntry("uid="+uid+",ou=people,dc=example,dc=com", attrs))
+}
+
+func (f *fakeDirectory) addGroup(id, cn string, members ...string) {
+	f.entries = append(f.entries, ldap.NewEntry("cn="+cn+",ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"group"},
+		"entryUUID":   {id},
+		"cn":          {cn},
+		"member":      members,
+	}))
+}
+
+func (f *fakeDirectory) set(dn, attr string, values ...string) {
+	for _, e := range f.entries {
+		if e.DN == dn {
+			for _, a := range e.Attributes {
+				if a.Name == attr {
+					a.Values = values
+				}
+			}
+		}
+	}
+}
+
+func (f *fakeDirectory) remove(dn string) {
+	f.entries = slices.DeleteFunc(f.entries, func(e *ldap.Entry) bool { return e.DN == dn })
+}
+
+func testDirectorySource() domain.DirectorySource {
+	return domain.DirectorySource{
+		Name:     "corp",
+		URL:      "ldap://corp.example.com",
+		Conflict: domain.DirectoryConflictSkip,
+		Delete:   domain.DirectoryDeleteDisable,
+		Users: domain.DirectoryUserMapping{
+			BaseDN:         "ou=people,dc=example,dc=com",
+			Filter:         "(objectClass=person)",
+			DisabledFilter: "(disabled=TRUE)",
+			IDAttribute:    "entryUUID",
+			Username:       "uid",
+			DisplayName:    "cn",
+			Email:          "mail",
+			Password:       "userPassword",
+			Attributes:     map[string]string{"department": "departmentNumber"},
+		},
+		SyncGroups: true,
+		Groups: domain.DirectoryGroupMapping{
+			BaseDN:      "ou=groups,dc=example,dc=com",
+			Filter:      "(objectClass=group)",
+			IDAttribute: "entryUUID",
+			Name:        "cn",
+			Member:      "member",
+		},
+	}
+}
+
+func setupDirectorySyncService(t *testing.T, src domain.DirectorySource) (*DirectorySyncService, *fakeDirectory, *UserService, *GroupService) {
+	t.Helper()
+	_, userSvc, d, _ := setupAuditService(t)
+	groupSvc := NewGroupService(d)
+	svc := NewDirectorySyncService(d, userSvc, groupSvc, []domain.DirectorySource{src})
+	dir := &fakeDirectory{}
+	svc.dial = func(*domain.DirectorySource) (directory, error) { return dir, nil }
+	return svc, dir, userSvc, groupSvc
+}
+
+func syncActions(report *domain.DirectorySyncReport) []string {
+	var actions []string
+	for _, c := range report.Changes {
+		actions = append(actions, string(c.ResourceType)+" "+string(c.Action)+" "+c.Name)
+	}
+	return actions
+}
+
+func TestDirectorySync(t *testing.T) {
+	svc, dir, userSvc, groupSvc := setupDirectorySyncService(t, testDirectorySource())
+	ctx := context.Background()
+	attrSvc := NewAttributeService(svc.dao)
+	if _, err := attrSvc.CreateDefinition(ctx, domain.CreateAttributeDefinitionInput{
+		Name: "department", Target: domain.AttributeTargetUser, Type: domain.AttributeTypeString,
+	}); err != nil {
+		t.Fatalf("CreateDefinition: %v", err)
+	}
+
+	const ssha = "{SSHA}iCtdldPg/c58ZJoQvbiJWBq2QQsBAgMEBQYHCA=="
+	dir.addUser("id-alice", "alice", "alice@example.com", map[string][]string{
+		"userPassword": {ssha}, "departmentNumber": {"sales"},
+	})
+	dir.addUser("id-bob", "bob", "bob@example.com", nil)
+	dir.addGroup("id-staff", "staff", "uid=alice,ou=people,dc=example,dc=com", "UID=Bob, OU=People,DC=example,DC=com")
+	dir.disabled = []string{"uid=bob,ou=people,dc=example,dc=com"}
+
+	report, err := svc.Sync(ctx, "corp", true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if want := []string{"user create alice", "user create bob", "group create staff"}; !slices.Equal(syncActions(report), want) {
+		t.Errorf("dry run changes = %v, want %v", syncActions(report), want)
+	}
+	if users, _ := userSvc.AllUsers(ctx); len(users) != 0 {
+		t.Errorf("dry run created %d users", len(users))
+	}
+
+	if report, err = svc.Sync(ctx, "corp", false); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if report.Users.Created != 2 || report.Groups.Created != 1 {
+		t.Errorf("counts = %+v, %+v", report.Users, report.Groups)
+	}
+	alice, err := userSvc.Authenticate(ctx, "alice", "Secret#Pass1")
+	if err != nil {
+		t.Fatalf("imported password: %v", err)
+	}
+	if alice.DisplayName != "ALICE" || !slices.Equal(alice.Attributes["department"], []string{"sales"}) {
+		t.Errorf("alice = %+v", alice)
+	}
+	bob, err := userSvc.GetUserByUsername(ctx, "bob")
+	if err != nil {
+		t.Fatalf("GetUserByUsername: %v", err)
+	}
+	if bob.Status != domain.UserStatusDisabled {
+		t.Errorf("bob status = %s, want disabled", bob.Status)
+	}
+	groups, _ := groupSvc.AllGroups(ctx)
+	if len(groups) != 1 || len(groups[0].Users) != 2 {
+		t.Fatalf("groups = %+v", groups)
+	}
+	if statuses := svc.Sources(); statuses[0].LastReport != report {
+		t.Error("last report not kept")
+	}
+
+	// Unchanged entries are not reported.
+	if report, _ = svc.Sync(ctx, "corp", false); len(report.Changes) != 0 || report.Users.Unchanged != 2 || report.Groups.Unchanged != 1 {
+		t.Errorf("second sync = %v, %+v", syncActions(report), report.Users)
+	}
+
+	// Changes follow the entry across renames of the group, and members
+	// added locally are left alone.
+	carol, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "carol", DisplayName: "Carol", Email: "carol@example.com", Password: "Secret-pass-1",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if err := groupSvc.AddMembers(ctx, groups[0].ID, []uuid.UUID{carol.ID}); err != nil {
+		t.Fatalf("AddMembers: %v", err)
+	}
+	dir.set("uid=alice,ou=people,dc=example,dc=com", "cn", "Alice Smith")
+	dir.remove("cn=staff,ou=groups,dc=example,dc=com")
+	dir.addGroup("id-staff", "employees", "uid=alice,ou=people,dc=example,dc=com")
+	dir.disabled = nil
+	if report, err = svc.Sync(ctx, "corp", false); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if want := []string{"user update alice", "user update bob", "group update employees"}; !slices.Equal(syncActions(report), want) {
+		t.Errorf("changes = %v, want %v", syncActions(report), want)
+	}
+	if got := report.Changes[2].Fields; !slices.Equal(got, []string{"name", "members"}) {
+		t.Errorf("group fields = %v", got)
+	}
+	members, _ := groupSvc.GetGroupMembers(ctx, groups[0].ID)
+	var names []string
+	for _, m := range members {
+		names = append(names, m.Username)
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"alice", "carol"}) {
+		t.Errorf("members = %v", names)
+	}
+
+	// Users gone upstream are disabled and groups deleted.
+	dir.remove("uid=bob,ou=people,dc=example,dc=com")
+	dir.remove("cn=employees,ou=groups,dc=example,dc=com")
+	dir.addGroup("id-other", "other")
+	if report, err = svc.Sync(ctx, "corp", false); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if want := []string{"group create other", "group delete employees", "user disable bob"}; !slices.Equal(syncActions(report), want) {
+		t.Errorf("changes = %v, want %v", syncActions(report), want)
+	}
+
+	// An empty search does not remove everything.
+	dir.entries = nil
+	if _, err := svc.Sync(ctx, "corp", false); !errors.Is(err, ErrDirectoryEmpty) {
+		t.Errorf("empty directory: err = %v, want ErrDirectoryEmpty", err)
+	}
+	dir.err = errors.New("connection reset")
+	if _, err := svc.Sync(ctx, "corp", false); !errors.Is(err, ErrDirectoryUnreachable) {
+		t.Errorf("failing directory: err = %v, want ErrDirectoryUnreachable", err)
+	}
+	if _, err := svc.Sync(ctx, "missing", true); !errors.Is(err, ErrDirectorySourceNotFound) {
+		t.Errorf("unknown source: err = %v", err)
+	}
+}
+
+func TestDirectorySyncConflicts(t *testing.T) {
+	src := testDirectorySource()
+	svc, dir, userSvc, _ := setupDirectorySyncService(t, src)
+	ctx := context.Background()
+	local, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "alice", DisplayName: "Local Alice", Email: "alice@local", Password: "Secret-pass-1",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	dir.addUser("id-alice", "Alice", "alice@example.com", nil)
+	dir.addUser("id-nomail", "nomail", "", nil)
+	dir.entries = append(dir.entries, ldap.NewEntry("uid=noid,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass": {"person"}, "uid": {"noid"},
+	}))
+
+	report, err := svc.Sync(ctx, "corp", false)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if want := []string{"user fail noid", "user conflict Alice", "user fail nomail"}; !slices.Equal(syncActions(report), want) {
+		t.Errorf("changes = %v, want %v", syncActions(report), want)
+	}
+	if report.Users.Conflicts != 1 || report.Users.Failed != 2 {
+		t.Errorf("counts = %+v", report.Users)
+	}
+
+	svc.sources[0].Conflict = domain.DirectoryConflictAdopt
+	if report, err = svc.Sync(ctx, "corp", false); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if report.Users.Updated != 1 || report.Changes[1].Action != domain.DirectorySyncAdopt {
+		t.Errorf("adopt: %v, %+v", syncActions(report), report.Users)
+	}
+	u, _ := userSvc.GetUser(ctx, local.ID)
+	if u.DisplayName != "ALICE" || u.Email != "alice@example.com" {
+		t.Errorf("adopted user = %+v", u)
+	}
+
+	// A second source cannot take over users of the first.
+	other := src
+	other.Name, other.Conflict = "other", domain.DirectoryConflictAdopt
+	svc.sources = append(svc.sources, other)
+	report, err = svc.Sync(ctx, "other", true)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if c := report.Changes[1]; c.Action != domain.DirectorySyncConflict || !strings.Contains(c.Error, "source corp") {
+		t.Errorf("other source: %+v", c)
+	}
+}
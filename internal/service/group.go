@@ -20,14 +20,57 @@ func NewGroupService(d *dao.DAO) *GroupService {
 	return &GroupService{dao: d}
 }
 
+// withDAO returns a copy of the service that works through d.
+func (s *GroupService) withDAO(d *dao.DAO) *GroupService {
+	return NewGroupService(d)
+}
+
 // CreateGroup creates a new group, validating parent exists if specified.
+// Extension attributes are validated against the declared group schema.
+// Without explicit owners, the acting user becomes the group's owner.
 func (s *GroupService) CreateGroup(ctx context.Context, input domain.CreateGroupInput) (*domain.Group, error) {
 	if input.ParentID != nil {
 		if _, err := s.dao.GetGroupByID(ctx, *input.ParentID); err != nil {
 			return nil, fmt.Errorf("parent group not found: %w", err)
 		}
 	}
-	return s.dao.CreateGroup(ctx, input.Name, input.Description, input.ParentID)
+
+	defs, err := s.dao.ListAttributeDefinitions(ctx, domain.AttributeTargetGroup)
+	if err != nil {
+		return nil, fmt.Errorf("loading attribute definitions: %w", err)
+	}
+	attrs, err := validateAttributeValues(defs, input.Attributes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(input.OwnerUserIDs) == 0 && len(input.OwnerGroupIDs) == 0 {
+		if actor, ok := ActorFromContext(ctx); ok && !actor.IsServiceAccount() {
+			input.OwnerUserIDs = []uuid.UUID{actor.UserID}
+		}
+	}
+
+	g, err := s.dao.CreateGroup(ctx, input.Name, input.Description, input.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(input.OwnerUserIDs) > 0 || len(input.OwnerGroupIDs) > 0 {
+		if err := s.dao.AddGroupOwners(ctx, g.ID, input.OwnerUserIDs, input.OwnerGroupIDs); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.dao.SetGroupAttributes(ctx, g.ID, attrs); err != nil {
+		return nil, err
+	}
+	if g, err = s.dao.GetGroupByID(ctx, g.ID); err != nil {
+		return nil, err
+	}
+	changes := AuditChanges(nil, g)
+	changes = append(changes, ownerChanges(input.OwnerUserIDs, input.OwnerGroupIDs)...)
+	if err := s.audit(ctx, domain.AuditGroupCreate, g, changes); err != nil {
+		return nil, err
+	}
+	return g, nil
 }
 
 // GetGroup retrieves a group by ID with users and children.
@@ -45,22 +88,177 @@ func (s *GroupService) UpdateGroup(ctx context.Context, id uuid.UUID, input doma
 	if input.ParentID != nil && *input.ParentID == id {
 		return nil, fmt.Errorf("group cannot be its own parent")
 	}
-	return s.dao.UpdateGroup(ctx, id, input)
+	if len(input.Attributes) > 0 {
+		defs, err := s.dao.ListAttributeDefinitions(ctx, domain.AttributeTargetGroup)
+		if err != nil {
+			return nil, fmt.Errorf("loading attribute definitions: %w", err)
+		}
+		if input.Attributes, err = validateAttributeValues(defs, input.Attributes, false); err != nil {
+			return nil, err
+		}
+	}
+
+	before, err := s.dao.GetGroupByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.dao.UpdateGroup(ctx, id, input); err != nil {
+		return nil, err
+	}
+	g, err := s.dao.GetGroupByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditGroupUpdate, g, AuditChanges(before, g)); err != nil {
+		return nil, err
+	}
+	return g, nil
 }
 
 // DeleteGroup deletes a group.
 func (s *GroupService) DeleteGroup(ctx context.Context, id uuid.UUID) error {
-	return s.dao.DeleteGroup(ctx, id)
+	g, err := s.dao.GetGroupByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.DeleteGroup(ctx, id); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditGroupDelete, g, AuditChanges(g, nil))
 }
 
-// AddMembers adds users to a group.
+// AddMembers adds users to a group. The actor must be allowed to change the
+// group's members.
 func (s *GroupService) AddMembers(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID) error {
-	return s.dao.AddMembers(ctx, groupID, userIDs)
+	if err := s.authorizeMembership(ctx, groupID); err != nil {
+		return err
+	}
+	if err := s.dao.AddMembers(ctx, groupID, userIDs); err != nil {
+		return err
+	}
+	return s.auditByID(ctx, domain.AuditGroupMemberAdd, groupID, []domain.AuditChange{{Field: "members", New: userIDs}})
 }
 
-// RemoveMember removes a user from a group.
+// RemoveMember removes a user from a group. The actor must be allowed to change
+// the group's members.
 func (s *GroupService) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
-	return s.dao.RemoveMember(ctx, groupID, userID)
+	if err := s.authorizeMembership(ctx, groupID); err != nil {
+		return err
+	}
+	if err := s.dao.RemoveMember(ctx, groupID, userID); err != nil {
+		return err
+	}
+	return s.auditByID(ctx, domain.AuditGroupMemberRemove, groupID, []domain.AuditChange{{Field: "members", Old: []uuid.UUID{userID}}})
+}
+
+// AddOwners adds owning users and groups to a group. The actor must be allowed
+// to manage the group.
+func (s *GroupService) AddOwners(ctx context.Context, groupID uuid.UUID, userIDs, groupIDs []uuid.UUID) error {
+	if err := s.authorizeGroupManagement(ctx, groupID); err != nil {
+		return err
+	}
+	if err := s.dao.AddGroupOwners(ctx, groupID, userIDs, groupIDs); err != nil {
+		return err
+	}
+	return s.auditByID(ctx, domain.AuditGroupOwnerAdd, groupID, ownerChanges(userIDs, groupIDs))
+}
+
+// RemoveOwnerUser removes an owning user from a group. The actor must be allowed
+// to manage the group.
+func (s *GroupService) RemoveOwnerUser(ctx context.Context, groupID, userID uuid.UUID) error {
+	if err := s.authorizeGroupManagement(ctx, groupID); err != nil {
+		return err
+	}
+	if err := s.dao.RemoveGroupOwnerUser(ctx, groupID, userID); err != nil {
+		return err
+	}
+	return s.auditByID(ctx, domain.AuditGroupOwnerRemove, groupID, []domain.AuditChange{{Field: "owner_users", Old: []uuid.UUID{userID}}})
+}
+
+// RemoveOwnerGroup removes an owning group from a group. The actor must be allowed
+// to manage the group.
+func (s *GroupService) RemoveOwnerGroup(ctx context.Context, groupID, ownerGroupID uuid.UUID) error {
+	if err := s.authorizeGroupManagement(ctx, groupID); err != nil {
+		return err
+	}
+	if err := s.dao.RemoveGroupOwnerGroup(ctx, groupID, ownerGroupID); err != nil {
+		return err
+	}
+	return s.auditByID(ctx, domain.AuditGroupOwnerRemove, groupID, []domain.AuditChange{{Field: "owner_groups", Old: []uuid.UUID{ownerGroupID}}})
+}
+
+// audit records an operation on a group in the audit trail.
+func (s *GroupService) audit(ctx context.Context, action string, g *domain.Group, changes []domain.AuditChange) error {
+	return recordAudit(ctx, s.dao, &domain.AuditEvent{
+		Action:     action,
+		TargetType: domain.AuditTargetGroup,
+		TargetID:   g.ID.String(),
+		TargetName: g.Name,
+		Changes:    changes,
+	})
+}
+
+// auditByID is audit for a group that is not loaded yet.
+func (s *GroupService) auditByID(ctx context.Context, action string, groupID uuid.UUID, changes []domain.AuditChange) error {
+	g, err := s.dao.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	return s.audit(ctx, action, g, changes)
+}
+
+// ownerChanges returns the audited changes for owning users and groups
+// being added to a group.
+func ownerChanges(userIDs, groupIDs []uuid.UUID) []domain.AuditChange {
+	var changes []domain.AuditChange
+	if len(userIDs) > 0 {
+		changes = append(changes, domain.AuditChange{Field: "owner_users", New: userIDs})
+	}
+	if len(groupIDs) > 0 {
+		changes = append(changes, domain.AuditChange{Field: "owner_groups", New: groupIDs})
+	}
+	return changes
+}
+
+// authorizeGroupManagement checks whether the actor may change a group's members
+// or owners. Calls without an actor are internal and always allowed; holders of
+// the group:admin permission may manage any group; everyone else must own it.
+func (s *GroupService) authorizeGroupManagement(ctx context.Context, groupID uuid.UUID) error {
+	actor, ok := ActorFromContext(ctx)
+	if !ok || actor.Can(domain.PermissionGroupAdmin) {
+		return nil
+	}
+
+	owner, err := s.dao.IsGroupOwner(ctx, groupID, actor.UserID)
+	if err != nil {
+		return err
+	}
+	if !owner {
+		return fmt.Errorf("%w: not an owner of group %s", ErrForbidden, groupID)
+	}
+	return nil
+}
+
+// authorizeMembership checks whether the actor may change a group's members.
+// Members inherit the roles assigned to the group, so on top of managing the
+// group this requires the role:admin permission when the group has roles;
+// otherwise owners could grant themselves or others those roles.
+func (s *GroupService) authorizeMembership(ctx context.Context, groupID uuid.UUID) error {
+	if err := s.authorizeGroupManagement(ctx, groupID); err != nil {
+		return err
+	}
+	actor, ok := ActorFromContext(ctx)
+	if !ok || actor.Can(domain.PermissionRoleAdmin) {
+		return nil
+	}
+	hasRoles, err := s.dao.GroupHasRoles(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if hasRoles {
+		return fmt.Errorf("%w: group %s has roles, changing its members requires %s", ErrForbidden, groupID, domain.PermissionRoleAdmin)
+	}
+	return nil
 }
 
 // GetGroupMembers returns all users in a group.
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent/enttest"
+)
+
+func setupGroupService(t *testing.T) (*GroupService, *dao.DAO, context.Context) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	d := dao.New(client)
+	ctx := context.Background()
+	if err := d.AutoMigrate(ctx); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	return NewGroupService(d), d, ctx
+}
+
+func TestGroupServiceCreateGroupDefaultsOwner(t *testing.T) {
+	svc, d, ctx := setupGroupService(t)
+
+	creator, _ := d.CreateUser(ctx, "creator", "Creator", "creator@example.com", "hash", "")
+	g, err := svc.CreateGroup(WithActor(ctx, &domain.Actor{UserID: creator.ID}), domain.CreateGroupInput{Name: "devs"})
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if len(g.OwnerUsers) != 1 || g.OwnerUsers[0].ID != creator.ID {
+		t.Errorf("OwnerUsers = %v, want [%s]", g.OwnerUsers, creator.ID)
+	}
+
+	g, err = svc.CreateGroup(ctx, domain.CreateGroupInput{Name: "ops"})
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if len(g.OwnerUsers) != 0 {
+		t.Errorf("OwnerUsers = %v, want none without an actor", g.OwnerUsers)
+	}
+}
+
+func TestGroupServiceMembershipAuthorization(t *testing.T) {
+	svc, d, ctx := setupGroupService(t)
+
+	owner, _ := d.CreateUser(ctx, "owner", "Owner", "owner@example.com", "hash", "")
+	lead, _ := d.CreateUser(ctx, "lead", "Lead", "lead@example.com", "hash", "")
+	other, _ := d.CreateUser(ctx, "other", "Other", "other@example.com", "hash", "")
+
+	leads, _ := svc.CreateGroup(ctx, domain.CreateGroupInput{Name: "leads"})
+	d.AddMembers(ctx, leads.ID, []uuid.UUID{lead.ID})
+
+	g, err := svc.CreateGroup(ctx, domain.CreateGroupInput{
+		Name:          "devs",
+		OwnerUserIDs:  []uuid.UUID{owner.ID},
+		OwnerGroupIDs: []uuid.UUID{leads.ID},
+	})
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr bool
+	}{
+		{"internal call", ctx, false},
+		{"direct owner", WithActor(ctx, &domain.Actor{UserID: owner.ID}), false},
+		{"owner via group", WithActor(ctx, &domain.Actor{UserID: lead.ID}), false},
+		{"group admin", WithActor(ctx, &domain.Actor{UserID: uuid.New(), Permissions: []string{domain.PermissionGroupAdmin}}), false},
+		{"non-owner", WithActor(ctx, &domain.Actor{UserID: other.ID, Permissions: []string{domain.PermissionGroupRead}}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.AddMembers(tt.ctx, g.ID, []uuid.UUID{other.ID})
+			if tt.wantErr {
+				if !errors.Is(err, ErrForbidden) {
+					t.Errorf("AddMembers() error = %v, want ErrForbidden", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("AddMembers() error = %v", err)
+			}
+		})
+	}
+
+	if err := svc.AddOwners(WithActor(ctx, &domain.Actor{UserID: other.ID}), g.ID, []uuid.UUID{other.ID}, nil); !errors.Is(err, ErrForbidden) {
+		t.Errorf("AddOwners() by non-owner error = %v, want ErrForbidden", err)
+	}
+}
+
+func TestGroupServiceMembershipOfGroupWithRoles(t *testing.T) {
+	svc, d, ctx := setupGroupService(t)
+
+	owner, _ := d.CreateUser(ctx, "owner", "Owner", "owner@example.com", "hash", "")
+	g, err := svc.CreateGroup(ctx, domain.CreateGroupInput{Name: "admins", OwnerUserIDs: []uuid.UUID{owner.ID}})
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	admin, err := d.CreateRole(ctx, domain.CreateRoleInput{Name: "admin", Permissions: []string{domain.PermissionAll}}, false)
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	if err := d.AddRoleGroups(ctx, admin.ID, []uuid.UUID{g.ID}); err != nil {
+		t.Fatalf("AddRoleGroups: %v", err)
+	}
+
+	// Owners and group admins could otherwise grant themselves the group's roles.
+	for _, actor := range []*domain.Actor{
+		{UserID: owner.ID},
+		{UserID: uuid.New(), Permissions: []string{domain.PermissionGroupAdmin}},
+	} {
+		if err := svc.AddMembers(WithActor(ctx, actor), g.ID, []uuid.UUID{owner.ID}); !errors.Is(err, ErrForbidden) {
+			t.Errorf("AddMembers() by %v error = %v, want ErrForbidden", actor.Permissions, err)
+		}
+		if err := svc.RemoveMember(WithActor(ctx, actor), g.ID, owner.ID); !errors.Is(err, ErrForbidden) {
+			t.Errorf("RemoveMember() by %v error = %v, want ErrForbidden", actor.Permissions, err)
+		}
+	}
+
+	roleAdmin := WithActor(ctx, &domain.Actor{UserID: uuid.New(), Permissions: []string{domain.PermissionGroupAdmin, domain.PermissionRoleAdmin}})
+	if err := svc.AddMembers(roleAdmin, g.ID, []uuid.UUID{owner.ID}); err != nil {
+		t.Errorf("AddMembers() by role admin error = %v", err)
+	}
+	// Owners may still manage the group's owners.
+	if err := svc.AddOwners(WithActor(ctx, &domain.Actor{UserID: owner.ID}), g.ID, nil, nil); err != nil {
+		t.Errorf("AddOwners() by owner error = %v", err)
+	}
+}
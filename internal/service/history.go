@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+)
+
+// ErrVersionNotFound is returned when a user or group has no history, did
+// not exist at the requested time, or has no version of the requested
+// number.
+var ErrVersionNotFound = errors.New("version not found")
+
+// History returns every version of a user and every change to their group
+// memberships, oldest first. It works for deleted users too.
+func (s *UserService) History(ctx context.Context, id uuid.UUID) (*domain.UserHistory, error) {
+	h, err := s.dao.UserHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(h.Versions) == 0 {
+		return nil, ErrVersionNotFound
+	}
+	return h, nil
+}
+
+// SnapshotAt reconstructs a user as of a point in time, with the groups
+// they belonged to then.
+func (s *UserService) SnapshotAt(ctx context.Context, id uuid.UUID, at time.Time) (*domain.UserSnapshot, error) {
+	v, err := s.dao.UserVersionAt(ctx, id, at)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrVersionNotFound
+	}
+	groupIDs, err := s.dao.UserGroupsAt(ctx, id, at)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &domain.UserSnapshot{At: at, User: v, Groups: []*domain.GroupVersion{}}
+	for _, gid := range groupIDs {
+		g, err := s.dao.GroupVersionAt(ctx, gid, at)
+		if err != nil {
+			return nil, err
+		}
+		if g != nil {
+			snap.Groups = append(snap.Groups, g)
+		}
+	}
+	return snap, nil
+}
+
+// Revert restores a user's profile to an earlier version: the display
+// name, email, phone, manager, account expiry, status and extension
+// attributes. Passwords, MFA and group memberships are left as they are, as
+// is the status of users who are or were pending, which only accepting an
+// invitation changes. Attributes that are no longer defined are not
+// restored, and required attributes the version has no value for keep
+// their current values.
+func (s *UserService) Revert(ctx context.Context, id uuid.UUID, version int) (*domain.User, error) {
+	v, err := s.dao.GetUserVersion(ctx, id, version)
+	if ent.IsNotFound(err) {
+		return nil, ErrVersionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if v.Operation == domain.VersionDelete {
+		return nil, fmt.Errorf("%w: version %d is the deletion", ErrVersionNotFound, version)
+	}
+	before, err := s.dao.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeUserManagement(ctx, before); err != nil {
+		return nil, err
+	}
+	if v.ManagerID != nil {
+		if err := s.checkManager(ctx, id, *v.ManagerID); err != nil {
+			return nil, err
+		}
+	}
+	restored := *v
+	if before.Status == domain.UserStatusPending || v.Status == domain.UserStatusPending {
+		restored.Status = before.Status
+	}
+	if restored.Attributes, err = s.revertedAttributes(ctx, v); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.dao.RestoreUserVersion(ctx, id, &restored); err != nil {
+		return nil, err
+	}
+	if restored.Status == domain.UserStatusDisabled && before.Status != domain.UserStatusDisabled {
+		if _, err := s.dao.RevokeUserSessions(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	u, err := s.dao.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	changes := append(AuditChanges(before, u), domain.AuditChange{Field: "version", New: version})
+	if err := s.audit(ctx, domain.AuditUserRevert, u, changes); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// revertedAttributes returns the values every defined user attribute takes
+// when reverting to v, validated against the current definitions.
+func (s *UserService) revertedAttributes(ctx context.Context, v *domain.UserVersion) (map[string][]string, error) {
+	defs, err := s.dao.ListAttributeDefinitions(ctx, domain.AttributeTargetUser)
+	if err != nil {
+		return nil, fmt.Errorf("loading attribute definitions: %w", err)
+	}
+	attrs := make(map[string][]string, len(defs))
+	for _, def := range defs {
+		if vals := v.Attributes[def.Name]; len(vals) > 0 || !def.Required {
+			attrs[def.Name] = vals
+		}
+	}
+	return validateAttributeValues(defs, attrs, false)
+}
+
+// History returns every version of a group and every change to its
+// members, oldest first. It works for deleted groups too.
+func (s *GroupService) History(ctx context.Context, id uuid.UUID) (*domain.GroupHistory, error) {
+	h, err := s.dao.GroupHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(h.Versions) == 0 {
+		return nil, ErrVersionNotFound
+	}
+	return h, nil
+}
+
+// SnapshotAt reconstructs a group as of a point in time, with the users who
+// belonged to it then, as they were then.
+func (s *GroupService) SnapshotAt(ctx context.Context, id uuid.UUID, at time.Time) (*domain.GroupSnapshot, error) {
+	g, err := s.dao.GroupVersionAt(ctx, id, at)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, ErrVersionNotFound
+	}
+	userIDs, err := s.dao.GroupMembersAt(ctx, id, at)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &domain.GroupSnapshot{At: at, Group: g, Members: []*domain.UserVersion{}}
+	for _, uid := range userIDs {
+		u, err := s.dao.UserVersionAt(ctx, uid, at)
+		if err != nil {
+			return nil, err
+		}
+		if u != nil {
+			snap.Members = append(snap.Members, u)
+		}
+	}
+	return snap, nil
+}
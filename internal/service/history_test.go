@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+func TestRevertUser(t *testing.T) {
+	_, userSvc, d, ctx := setupAuditService(t)
+	ctx = WithActor(ctx, &domain.Actor{UserID: uuid.New(), Name: "admin", Permissions: []string{domain.PermissionAll}})
+
+	u, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "erin", DisplayName: "Erin", Email: "erin@example.com", Password: "Secret-pass-1",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	name, email := "Erin B", "erin.b@example.com"
+	if _, err := userSvc.UpdateUser(ctx, u.ID, domain.UpdateUserInput{DisplayName: &name, Email: &email}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if err := userSvc.SetUserStatus(ctx, u.ID, domain.UserStatusDisabled); err != nil {
+		t.Fatalf("SetUserStatus: %v", err)
+	}
+
+	reverted, err := userSvc.Revert(ctx, u.ID, 1)
+	if err != nil {
+		t.Fatalf("Revert: %v", err)
+	}
+	if reverted.DisplayName != "Erin" || reverted.Email != "erin@example.com" || reverted.Status != domain.UserStatusEnabled {
+		t.Errorf("reverted user = %+v, want version 1", reverted)
+	}
+
+	h, err := userSvc.History(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(h.Versions) != 4 || h.Versions[3].Version != 4 || h.Versions[3].ActorName != "admin" {
+		t.Errorf("versions = %+v, want the revert as version 4 by admin", h.Versions)
+	}
+	events, err := d.ListAuditEvents(ctx, domain.AuditFilter{Action: domain.AuditUserRevert}, 1, 10)
+	if err != nil || events.Total != 1 {
+		t.Errorf("revert audit events = %v, %v, want 1", events, err)
+	}
+
+	if _, err := userSvc.Revert(ctx, u.ID, 9); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("Revert(unknown version) err = %v, want ErrVersionNotFound", err)
+	}
+	if _, err := userSvc.SnapshotAt(ctx, u.ID, time.Now().Add(-time.Hour)); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("SnapshotAt(before creation) err = %v, want ErrVersionNotFound", err)
+	}
+}
+
+func TestRevertUserAttributes(t *testing.T) {
+	_, userSvc, d, ctx := setupAuditService(t)
+	for _, input := range []domain.CreateAttributeDefinitionInput{
+		{Name: "employeeNumber", Target: domain.AttributeTargetUser, Type: domain.AttributeTypeString, LDAPName: "employeeNumber"},
+		{Name: "badge", Target: domain.AttributeTargetUser, Type: domain.AttributeTypeString, LDAPName: "badge"},
+	} {
+		if _, err := d.CreateAttributeDefinition(ctx, input); err != nil {
+			t.Fatalf("CreateAttributeDefinition: %v", err)
+		}
+	}
+	u, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "erin", DisplayName: "Erin", Email: "erin@example.com", Password: "Secret-pass-1",
+		Attributes: map[string][]string{"employeeNumber": {"1001"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	created := time.Now()
+	h, _ := userSvc.History(ctx, u.ID)
+	version := h.Versions[len(h.Versions)-1].Version
+	if _, err := userSvc.UpdateUser(ctx, u.ID, domain.UpdateUserInput{
+		Attributes: map[string][]string{"employeeNumber": {"2002"}, "badge": {"B-7"}},
+	}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+
+	snap, err := userSvc.SnapshotAt(ctx, u.ID, created)
+	if err != nil || snap.User.Attributes["employeeNumber"][0] != "1001" {
+		t.Fatalf("SnapshotAt = %+v, %v, want employee number 1001", snap, err)
+	}
+	reverted, err := userSvc.Revert(ctx, u.ID, version)
+	if err != nil {
+		t.Fatalf("Revert: %v", err)
+	}
+	if got := reverted.Attributes; len(got) != 1 || got["employeeNumber"][0] != "1001" {
+		t.Errorf("reverted attributes = %v, want only employee number 1001", got)
+	}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ent/user"
+	"github.com/qinzj/claude-demo/internal/mail"
+	"github.com/qinzj/claude-demo/internal/totp"
+)
+
+var (
+	// ErrInvitationUnavailable is returned when invitations cannot be sent
+	// because no mail server is configured.
+	ErrInvitationUnavailable = errors.New("invitations by email are not configured")
+	// ErrInvalidInvitation is returned for an invitation that is unknown,
+	// expired or already accepted, or whose user is no longer pending.
+	ErrInvalidInvitation = errors.New("invalid or expired invitation")
+	// ErrUserNotPending is returned when resending the invitation of a user
+	// who already accepted one.
+	ErrUserNotPending = errors.New("user is not pending")
+	// ErrUserPending is returned when enabling a pending user, who becomes
+	// enabled only by accepting the invitation.
+	ErrUserPending = errors.New("user has not accepted the invitation")
+)
+
+// invitationMail is the data of the invitation email template.
+type invitationMail struct {
+	DisplayName    string
+	Username       string
+	URL            string
+	ExpiresAt      time.Time
+	ExpiresInHours int
+}
+
+// InvitationService onboards users by invitation: an administrator creates
+// a pending user, who is emailed a single-use link with which they choose
+// their password, optionally enroll MFA, and activate the account.
+type InvitationService struct {
+	dao         *dao.DAO
+	userService *UserService
+	mfaService  *MFAService
+	sender      mail.Sender
+	templates   *mail.Templates
+	policy      domain.InvitationPolicy
+}
+
+// NewInvitationService creates a new InvitationService. Invitations are
+// rendered with templates and sent through sender; a nil sender disables
+// them.
+func NewInvitationService(d *dao.DAO, userSvc *UserService, mfaSvc *MFAService, sender mail.Sender, templates *mail.Templates, policy domain.InvitationPolicy) *InvitationService {
+	return &InvitationService{
+		dao:         d,
+		userService: userSvc,
+		mfaService:  mfaSvc,
+		sender:      sender,
+		templates:   templates,
+		policy:      policy,
+	}
+}
+
+// Invite creates a pending user and emails them an invitation. If the
+// email cannot be sent, the user is still created and returned with the
+// error, and the invitation can be resent.
+func (s *InvitationService) Invite(ctx context.Context, input domain.InviteUserInput) (*domain.User, error) {
+	if s.sender == nil {
+		return nil, ErrInvitationUnavailable
+	}
+	u, err := s.userService.createPendingUser(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return u, s.send(ctx, u)
+}
+
+// Resend emails a new invitation to a pending user. Earlier invitations
+// stop working.
+func (s *InvitationService) Resend(ctx context.Context, userID uuid.UUID) error {
+	if s.sender == nil {
+		return ErrInvitationUnavailable
+	}
+	u, err := s.dao.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("getting user: %w", err)
+	}
+	if u.Status != domain.UserStatusPending {
+		return fmt.Errorf("%w: %q", ErrUserNotPending, u.Username)
+	}
+	return s.send(ctx, u)
+}
+
+// Check returns the user an invitation belongs to, so the invitation page
+// can greet them before they choose a password.
+func (s *InvitationService) Check(ctx context.Context, token string) (*domain.User, *domain.Invitation, error) {
+	return s.lookup(ctx, token)
+}
+
+// MFARequired reports whether a group the invited user was added to
+// demands MFA, in which case they must enroll it to accept the invitation.
+func (s *InvitationService) MFARequired(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return s.dao.UserRequiresMFA(ctx, userID)
+}
+
+// BeginMFAEnrollment generates a TOTP secret for the invited user, to be
+// confirmed with a code when accepting the invitation.
+func (s *InvitationService) BeginMFAEnrollment(ctx context.Context, token string) (*domain.TOTPEnrollment, error) {
+	u, _, err := s.lookup(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.mfaService.BeginEnrollment(ctx, u.ID)
+}
+
+// Accept sets the invited user's password, which must satisfy their
+// password policies, and enables the account. With a TOTP code for the
+// secret from BeginMFAEnrollment, MFA is enabled as well and the recovery
+// codes are returned; the code is required if a group demands MFA. If the
+// password or code is refused, the invitation stays valid for another try.
+func (s *InvitationService) Accept(ctx context.Context, token string, input domain.AcceptInvitationInput) ([]string, error) {
+	u, inv, err := s.lookup(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	required, err := s.dao.UserRequiresMFA(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	code := strings.TrimSpace(input.TOTPCode)
+	switch {
+	case code == "" && required:
+		return nil, ErrMFARequired
+	case code != "" && u.TOTPSecret == "":
+		return nil, ErrMFANotEnabled
+	case code != "":
+		// Check the code before using up the invitation; it is recorded as
+		// used only when MFA is enabled below.
+		if _, ok := totp.Validate(u.TOTPSecret, code, time.Now()); !ok {
+			return nil, ErrInvalidMFACode
+		}
+	}
+
+	ok, err := s.dao.AcceptInvitation(ctx, inv.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidInvitation
+	}
+	var codes []string
+	err = s.userService.ResetPassword(ctx, u.ID, input.Password, false)
+	if err == nil && code != "" {
+		codes, err = s.mfaService.ConfirmEnrollment(ctx, u.ID, code)
+	}
+	if err != nil {
+		if rerr := s.dao.ReleaseInvitation(ctx, inv.ID); rerr != nil {
+			return nil, fmt.Errorf("%w; %v", err, rerr)
+		}
+		return nil, err
+	}
+	if err := s.dao.UpdateUserStatus(ctx, u.ID, user.StatusEnabled); err != nil {
+		return nil, err
+	}
+	if err := s.userService.auditStatus(ctx, u, domain.UserStatusEnabled); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// lookup finds an open invitation and its pending user.
+func (s *InvitationService) lookup(ctx context.Context, token string) (*domain.User, *domain.Invitation, error) {
+	inv, err := s.dao.GetInvitation(ctx, hashToken(token))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil, ErrInvalidInvitation
+		}
+		return nil, nil, err
+	}
+	now := time.Now()
+	if !inv.Valid(now) {
+		return nil, nil, ErrInvalidInvitation
+	}
+	u, err := s.dao.GetUserByID(ctx, inv.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting user: %w", err)
+	}
+	if u.Status != domain.UserStatusPending || u.Expired(now) {
+		return nil, nil, ErrInvalidInvitation
+	}
+	return u, inv, nil
+}
+
+// send creates an invitation for the user and emails it.
+func (s *InvitationService) send(ctx context.Context, u *domain.User) error {
+	link, err := url.Parse(s.policy.URL)
+	if err != nil {
+		return fmt.Errorf("parsing invitation url: %w", err)
+	}
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(s.policy.TokenTTL)
+	if err := s.dao.CreateInvitation(ctx, u.ID, hash, expiresAt); err != nil {
+		return err
+	}
+
+	q := link.Query()
+	q.Set("token", token)
+	link.RawQuery = q.Encode()
+	msg, err := s.templates.Render(mail.TemplateInvitation, u.Email, invitationMail{
+		DisplayName:    u.DisplayName,
+		Username:       u.Username,
+		URL:            link.String(),
+		ExpiresAt:      expiresAt,
+		ExpiresInHours: int(s.policy.TokenTTL.Hours()),
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.sender.Send(ctx, msg); err != nil {
+		return fmt.Errorf("%w: %v", ErrMailDelivery, err)
+	}
+	return nil
+}
+
+// createPendingUser creates an invited user without a password. Extension
+// attributes are validated as in CreateUser.
+func (s *UserService) createPendingUser(ctx context.Context, input domain.InviteUserInput) (*domain.User, error) {
+	defs, err := s.dao.ListAttributeDefinitions(ctx, domain.AttributeTargetUser)
+	if err != nil {
+		return nil, fmt.Errorf("loading attribute definitions: %w", err)
+	}
+	attrs, err := validateAttributeValues(defs, input.Attributes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.dao.CreatePendingUser(ctx, input.Username, input.DisplayName, input.Email, input.Phone)
+	if err != nil {
+		return nil, err
+	}
+	if input.AccountExpiresAt != nil {
+		if _, err := s.dao.UpdateUser(ctx, u.ID, domain.UpdateUserInput{AccountExpiresAt: input.AccountExpiresAt}); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.dao.SetUserAttributes(ctx, u.ID, attrs); err != nil {
+		return nil, err
+	}
+	if u, err = s.dao.GetUserByID(ctx, u.ID); err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditUserInvite, u, AuditChanges(nil, u)); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
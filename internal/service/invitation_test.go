@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/mail"
+	"github.com/qinzj/claude-demo/internal/totp"
+)
+
+var invitationLinkPattern = regexp.MustCompile(`https://sso\.example\.com/accept-invitation\?token=\S+`)
+
+var testInvitationPolicy = domain.InvitationPolicy{
+	URL:      "https://sso.example.com/accept-invitation",
+	TokenTTL: 72 * time.Hour,
+}
+
+func setupInvitationService(t *testing.T) (*InvitationService, *fakeSender, *UserService, *dao.DAO, context.Context) {
+	t.Helper()
+	userSvc, mfaSvc, d, _, ctx := setupLockoutService(t, domain.LockoutPolicy{})
+	templates, err := mail.LoadTemplates("")
+	if err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	sender := &fakeSender{}
+	return NewInvitationService(d, userSvc, mfaSvc, sender, templates, testInvitationPolicy), sender, userSvc, d, ctx
+}
+
+var carol = domain.InviteUserInput{Username: "carol", DisplayName: "Carol", Email: "carol@example.com"}
+
+func TestInvitation(t *testing.T) {
+	svc, sender, userSvc, _, ctx := setupInvitationService(t)
+
+	u, err := svc.Invite(ctx, carol)
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	if u.Status != domain.UserStatusPending {
+		t.Errorf("status = %s, want pending", u.Status)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].To[0] != "carol@example.com" {
+		t.Fatalf("sent = %+v", sender.sent)
+	}
+	token := sender.linkToken(t, invitationLinkPattern)
+
+	// Pending users can neither log in nor be enabled by an administrator.
+	if _, err := userSvc.Authenticate(ctx, "carol", ""); !errors.Is(err, ErrUserDisabled) {
+		t.Errorf("Authenticate pending user: err = %v, want ErrUserDisabled", err)
+	}
+	if err := userSvc.SetUserStatus(ctx, u.ID, domain.UserStatusEnabled); !errors.Is(err, ErrUserPending) {
+		t.Errorf("SetUserStatus: err = %v, want ErrUserPending", err)
+	}
+
+	got, _, err := svc.Check(ctx, token)
+	if err != nil || got.ID != u.ID {
+		t.Fatalf("Check = %v, %v", got, err)
+	}
+	codes, err := svc.Accept(ctx, token, domain.AcceptInvitationInput{Password: "carol-secret-1"})
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if len(codes) != 0 {
+		t.Errorf("recovery codes without MFA enrollment: %v", codes)
+	}
+	if _, err := userSvc.Authenticate(ctx, "carol", "carol-secret-1"); err != nil {
+		t.Errorf("login after accepting: %v", err)
+	}
+	if _, err := svc.Accept(ctx, token, domain.AcceptInvitationInput{Password: "carol-secret-2"}); !errors.Is(err, ErrInvalidInvitation) {
+		t.Errorf("reusing invitation: err = %v, want ErrInvalidInvitation", err)
+	}
+	if err := svc.Resend(ctx, u.ID); !errors.Is(err, ErrUserNotPending) {
+		t.Errorf("Resend to enabled user: err = %v, want ErrUserNotPending", err)
+	}
+}
+
+func TestInvitationResend(t *testing.T) {
+	svc, sender, _, _, ctx := setupInvitationService(t)
+	u, err := svc.Invite(ctx, carol)
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	first := sender.linkToken(t, invitationLinkPattern)
+	if err := svc.Resend(ctx, u.ID); err != nil {
+		t.Fatalf("Resend: %v", err)
+	}
+	if _, _, err := svc.Check(ctx, first); !errors.Is(err, ErrInvalidInvitation) {
+		t.Errorf("replaced invitation: err = %v, want ErrInvalidInvitation", err)
+	}
+	if _, _, err := svc.Check(ctx, sender.linkToken(t, invitationLinkPattern)); err != nil {
+		t.Errorf("new invitation: %v", err)
+	}
+}
+
+func TestInvitationPolicyViolation(t *testing.T) {
+	svc, sender, _, _, ctx := setupInvitationService(t)
+	if _, err := NewPasswordPolicyService(svc.dao).EnsureDefaultPolicy(ctx); err != nil {
+		t.Fatalf("EnsureDefaultPolicy: %v", err)
+	}
+	if _, err := svc.Invite(ctx, carol); err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	token := sender.linkToken(t, invitationLinkPattern)
+
+	var perr *PasswordPolicyError
+	if _, err := svc.Accept(ctx, token, domain.AcceptInvitationInput{Password: "short"}); !errors.As(err, &perr) {
+		t.Fatalf("weak password: err = %v, want policy error", err)
+	}
+	if _, err := svc.Accept(ctx, token, domain.AcceptInvitationInput{Password: "long-enough-1"}); err != nil {
+		t.Errorf("retry after policy violation: %v", err)
+	}
+}
+
+func TestInvitationMFA(t *testing.T) {
+	svc, sender, _, d, ctx := setupInvitationService(t)
+	u, err := svc.Invite(ctx, carol)
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	token := sender.linkToken(t, invitationLinkPattern)
+
+	g, err := d.CreateGroup(ctx, "admins", "", nil)
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if err := d.AddMembers(ctx, g.ID, []uuid.UUID{u.ID}); err != nil {
+		t.Fatalf("AddMembers: %v", err)
+	}
+	required := true
+	if _, err := d.UpdateGroup(ctx, g.ID, domain.UpdateGroupInput{RequireMFA: &required}); err != nil {
+		t.Fatalf("UpdateGroup: %v", err)
+	}
+
+	input := domain.AcceptInvitationInput{Password: "carol-secret-1"}
+	if _, err := svc.Accept(ctx, token, input); !errors.Is(err, ErrMFARequired) {
+		t.Fatalf("without MFA: err = %v, want ErrMFARequired", err)
+	}
+	enrollment, err := svc.BeginMFAEnrollment(ctx, token)
+	if err != nil {
+		t.Fatalf("BeginMFAEnrollment: %v", err)
+	}
+	input.TOTPCode = "000000"
+	if _, err := svc.Accept(ctx, token, input); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("wrong code: err = %v, want ErrInvalidMFACode", err)
+	}
+	input.TOTPCode, _ = totp.Code(enrollment.Secret, time.Now())
+	codes, err := svc.Accept(ctx, token, input)
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if len(codes) != recoveryCodeCount {
+		t.Errorf("got %d recovery codes, want %d", len(codes), recoveryCodeCount)
+	}
+	got, _ := d.GetUserByID(ctx, u.ID)
+	if got.Status != domain.UserStatusEnabled || !got.MFAEnabled {
+		t.Errorf("after accepting: status = %s, mfa = %v", got.Status, got.MFAEnabled)
+	}
+}
+
+func TestInvitationUnavailable(t *testing.T) {
+	svc, _, _, _, ctx := setupInvitationService(t)
+	svc.sender = nil
+	if _, err := svc.Invite(ctx, carol); !errors.Is(err, ErrInvitationUnavailable) {
+		t.Errorf("Invite: err = %v", err)
+	}
+	if _, err := svc.dao.GetUserByUsername(ctx, "carol"); err == nil {
+		t.Error("user created although invitations are unavailable")
+	}
+}
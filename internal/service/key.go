@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+// ErrUnknownSigningKey is returned when a token names a key ID that is not
+// (or no longer) published.
+var ErrUnknownSigningKey = errors.New("unknown signing key")
+
+// keyCacheTTL bounds how long keys loaded from the database are trusted, so
+// rotations performed by other instances are picked up.
+const keyCacheTTL = time.Minute
+
+// KeyService manages the asymmetric keys used to sign JWTs. The newest key
+// signs; keys retired by a rotation keep verifying tokens for a grace period
+// and stay published in the JWKS until then.
+type KeyService struct {
+	dao         *dao.DAO
+	algorithm   string
+	rotateEvery time.Duration
+	grace       time.Duration
+
+	mu       sync.RWMutex
+	keys     []*signingKey // newest first; keys[0] signs when active
+	loadedAt time.Time
+}
+
+// signingKey is a parsed SigningKey ready for use with golang-jwt.
+type signingKey struct {
+	kid     string
+	alg     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	retired bool
+}
+
+// NewKeyService creates a new KeyService. New keys use the given algorithm
+// (RS256, ES256 or EdDSA); the signing key is replaced every rotateEvery and
+// retired keys are accepted for grace afterwards.
+func NewKeyService(d *dao.DAO, algorithm string, rotateEvery, grace time.Duration) *KeyService {
+	return &KeyService{
+		dao:         d,
+		algorithm:   algorithm,
+		rotateEvery: rotateEvery,
+		grace:       grace,
+	}
+}
+
+// Rotate generates a new signing key and retires the current one.
+func (s *KeyService) Rotate(ctx context.Context) (*domain.SigningKey, error) {
+	privPEM, pubPEM, err := generateKeyPair(s.algorithm)
+	if err != nil {
+		return nil, err
+	}
+	k, err := s.dao.CreateSigningKey(ctx, s.algorithm, privPEM, pubPEM)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reload(ctx); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// RotateIfDue creates a signing key if there is none, rotates it once it is
+// older than the rotation interval, and deletes keys whose grace period has
+// passed. It reports whether a new key was created.
+func (s *KeyService) RotateIfDue(ctx context.Context) (bool, error) {
+	now := time.Now()
+	if _, err := s.dao.DeleteSigningKeysRetiredBefore(ctx, now.Add(-s.grace)); err != nil {
+		return false, err
+	}
+
+	keys, err := s.dao.ListSigningKeys(ctx, now.Add(-s.grace))
+	if err != nil {
+		return false, err
+	}
+	if len(keys) > 0 && keys[0].RetiredAt == nil && now.Sub(keys[0].CreatedAt) < s.rotateEvery {
+		return false, s.reload(ctx)
+	}
+
+	if _, err := s.Rotate(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// JWKS returns the public keys that currently verify tokens.
+func (s *KeyService) JWKS(ctx context.Context) (*domain.JWKSet, error) {
+	if err := s.reload(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	set := &domain.JWKSet{Keys: make([]domain.JWK, 0, len(s.keys))}
+	for _, k := range s.keys {
+		jwk, err := publicJWK(k)
+		if err != nil {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set, nil
+}
+
+// sign signs the claims with the current signing key, setting the "kid"
+// header. A non-empty typ overrides the default "JWT" type header.
+func (s *KeyService) sign(ctx context.Context, claims jwt.Claims, typ string) (string, error) {
+	k, err := s.current(ctx)
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(k.method, claims)
+	token.Header["kid"] = k.kid
+	if typ != "" {
+		token.Header["typ"] = typ
+	}
+	signed, err := token.SignedString(k.private)
+	if err != nil {
+		return "", fmt.Errorf("signing token: %w", err)
+	}
+	return signed, nil
+}
+
+// keyfunc resolves the verification key for a token from its "kid" header.
+// It is meant for jwt.Parse.
+func (s *KeyService) keyfunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		k, err := s.lookup(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != k.alg {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return k.private.Public(), nil
+	}
+}
+
+// validMethods lists the algorithms accepted when parsing tokens.
+func validMethods() []string {
+	return []string{domain.SigningAlgorithmRS256, domain.SigningAlgorithmES256, domain.SigningAlgorithmEdDSA}
+}
+
+func (s *KeyService) current(ctx context.Context) (*signingKey, error) {
+	if err := s.reloadIfStale(ctx); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.keys) == 0 || s.keys[0].retired {
+		return nil, errors.New("no active signing key")
+	}
+	return s.keys[0], nil
+}
+
+// lookup finds a published key by ID, reloading once on a miss since another
+// instance may have rotated.
+func (s *KeyService) lookup(ctx context.Context, kid string) (*signingKey, error) {
+	if err := s.reloadIfStale(ctx); err != nil {
+		return nil, err
+	}
+	if k := s.find(kid); k != nil {
+		return k, nil
+	}
+	if err := s.reload(ctx); err != nil {
+		return nil, err
+	}
+	if k := s.find(kid); k != nil {
+		return k, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownSigningKey, kid)
+}
+
+func (s *KeyService) find(kid string) *signingKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, k := range s.keys {
+		if k.kid == kid {
+			return k
+		}
+	}
+	return nil
+}
+
+func (s *KeyService) reloadIfStale(ctx context.Context) error {
+	s.mu.RLock()
+	fresh := time.Since(s.loadedAt) < keyCacheTTL
+	s.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+	return s.reload(ctx)
+}
+
+func (s *KeyService) reload(ctx context.Context) error {
+	now := time.Now()
+	stored, err := s.dao.ListSigningKeys(ctx, now.Add(-s.grace))
+	if err != nil {
+		return err
+	}
+
+	keys := make([]*signingKey, 0, len(stored))
+	for _, sk := range stored {
+		k, err := parseSigningKey(sk)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, k)
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.loadedAt = now
+	s.mu.Unlock()
+	return nil
+}
+
+func parseSigningKey(sk *domain.SigningKey) (*signingKey, error) {
+	block, _ := pem.Decode([]byte(sk.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s: invalid PEM", sk.ID)
+	}
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("signing key %s: %w", sk.ID, err)
+	}
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s: unsupported key type %T", sk.ID, priv)
+	}
+	method := jwt.GetSigningMethod(sk.Algorithm)
+	if method == nil {
+		return nil, fmt.Errorf("signing key %s: unsupported algorithm %q", sk.ID, sk.Algorithm)
+	}
+	return &signingKey{
+		kid:     sk.ID.String(),
+		alg:     sk.Algorithm,
+		method:  method,
+		private: signer,
+		retired: sk.RetiredAt != nil,
+	}, nil
+}
+
+// generateKeyPair creates a key pair for the algorithm and returns it PEM
+// encoded as PKCS#8 private key and PKIX public key.
+func generateKeyPair(algorithm string) (privPEM, pubPEM string, err error) {
+	var priv crypto.Signer
+	switch algorithm {
+	case domain.SigningAlgorithmRS256:
+		priv, err = rsa.GenerateKey(rand.Reader, 2048)
+	case domain.SigningAlgorithmES256:
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case domain.SigningAlgorithmEdDSA:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return "", "", fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("generating %s key: %w", algorithm, err)
+	}
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return "", "", fmt.Errorf("encoding private key: %w", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		return "", "", fmt.Errorf("encoding public key: %w", err)
+	}
+	privPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}))
+	pubPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+	return privPEM, pubPEM, nil
+}
+
+func publicJWK(k *signingKey) (domain.JWK, error) {
+	jwk := domain.JWK{Use: "sig", KeyID: k.kid, Algorithm: k.alg}
+	b64 := base64.RawURLEncoding.EncodeToString
+
+	switch pub := k.private.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = b64(pub.N.Bytes())
+		jwk.E = b64(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		ecdhKey, err := pub.ECDH()
+		if err != nil {
+			return domain.JWK{}, fmt.Errorf("signing key %s: %w", k.kid, err)
+		}
+		// Uncompressed point: 0x04 || X || Y.
+		point := ecdhKey.Bytes()
+		size := (len(point) - 1) / 2
+		jwk.KeyType = "EC"
+		jwk.Curve = pub.Curve.Params().Name
+		jwk.X = b64(point[1 : 1+size])
+		jwk.Y = b64(point[1+size:])
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = b64(pub)
+	default:
+		return domain.JWK{}, fmt.Errorf("signing key %s: unsupported public key type %T", k.kid, pub)
+	}
+	return jwk, nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/ent/enttest"
+)
+
+func setupKeyService(t *testing.T, algorithm string, grace time.Duration) (*KeyService, context.Context) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	d := dao.New(client)
+	ctx := context.Background()
+	if err := d.AutoMigrate(ctx); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	return NewKeyService(d, algorithm, 24*time.Hour, grace), ctx
+}
+
+func TestKeyServiceSignAndVerify(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		kty       string
+	}{
+		{"RS256", "RSA"},
+		{"ES256", "EC"},
+		{"EdDSA", "OKP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			keySvc, ctx := setupKeyService(t, tt.algorithm, time.Hour)
+			rotated, err := keySvc.RotateIfDue(ctx)
+			if err != nil {
+				t.Fatalf("RotateIfDue: %v", err)
+			}
+			if !rotated {
+				t.Error("expected a key to be created")
+			}
+
+			signed, err := keySvc.sign(ctx, jwt.RegisteredClaims{Subject: "alice"}, "")
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+			var claims jwt.RegisteredClaims
+			if _, err := jwt.ParseWithClaims(signed, &claims, keySvc.keyfunc(ctx), jwt.WithValidMethods(validMethods())); err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if claims.Subject != "alice" {
+				t.Errorf("Subject = %q, want alice", claims.Subject)
+			}
+
+			set, err := keySvc.JWKS(ctx)
+			if err != nil {
+				t.Fatalf("JWKS: %v", err)
+			}
+			if len(set.Keys) != 1 {
+				t.Fatalf("len(keys) = %d, want 1", len(set.Keys))
+			}
+			if k := set.Keys[0]; k.KeyType != tt.kty || k.Algorithm != tt.algorithm || k.Use != "sig" {
+				t.Errorf("JWK = %+v, want kty %s alg %s", k, tt.kty, tt.algorithm)
+			}
+		})
+	}
+}
+
+func TestKeyServiceRotateIfDue(t *testing.T) {
+	keySvc, ctx := setupKeyService(t, "ES256", time.Hour)
+	if _, err := keySvc.RotateIfDue(ctx); err != nil {
+		t.Fatalf("RotateIfDue: %v", err)
+	}
+
+	rotated, err := keySvc.RotateIfDue(ctx)
+	if err != nil {
+		t.Fatalf("RotateIfDue: %v", err)
+	}
+	if rotated {
+		t.Error("fresh key should not be rotated")
+	}
+
+	keySvc.rotateEvery = 0
+	if rotated, _ := keySvc.RotateIfDue(ctx); !rotated {
+		t.Error("expected rotation once the interval has passed")
+	}
+}
+
+func TestKeyServiceGracePeriod(t *testing.T) {
+	keySvc, ctx := setupKeyService(t, "ES256", time.Hour)
+	keySvc.RotateIfDue(ctx)
+	old, _ := keySvc.sign(ctx, jwt.RegisteredClaims{Subject: "alice"}, "")
+
+	keySvc.Rotate(ctx)
+	parse := func(tok string) error {
+		_, err := jwt.Parse(tok, keySvc.keyfunc(ctx), jwt.WithValidMethods(validMethods()))
+		return err
+	}
+	if err := parse(old); err != nil {
+		t.Errorf("retired key within grace: %v", err)
+	}
+
+	// Once the grace period is over the retired key is pruned and rejected.
+	keySvc.grace = 0
+	if _, err := keySvc.RotateIfDue(ctx); err != nil {
+		t.Fatalf("RotateIfDue: %v", err)
+	}
+	if err := parse(old); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Errorf("retired key after grace: error = %v, want ErrUnknownSigningKey", err)
+	}
+	set, _ := keySvc.JWKS(ctx)
+	if len(set.Keys) != 1 {
+		t.Errorf("len(keys) = %d, want 1", len(set.Keys))
+	}
+}
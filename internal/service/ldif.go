@@ -0,0 +1,1238 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ldap/attrs"
+	"github.com/qinzj/claude-demo/internal/ldap/dn"
+	"github.com/qinzj/claude-demo/internal/ldap/ldif"
+	"github.com/qinzj/claude-demo/internal/ldap/upstream"
+	"github.com/qinzj/claude-demo/internal/password"
+)
+
+// ErrInvalidLDIF is returned for input that is not a valid LDIF file.
+// Nothing is imported.
+var ErrInvalidLDIF = errors.New("invalid LDIF")
+
+// adAccountDisable is the ACCOUNTDISABLE flag of userAccountControl.
+const adAccountDisable = 0x2
+
+// LDIFService imports LDIF files into users and groups, through the user
+// and group services. Entries are named as the LDAP server names them:
+// users are identified by username, which cannot be changed, and groups by
+// name.
+type LDIFService struct {
+	dao    *dao.DAO
+	users  *UserService
+	groups *GroupService
+}
+
+// NewLDIFService creates a new LDIFService.
+func NewLDIFService(d *dao.DAO, userSvc *UserService, groupSvc *GroupService) *LDIFService {
+	return &LDIFService{dao: d, users: userSvc, groups: groupSvc}
+}
+
+// Import applies the records of an LDIF file, whose entries are named as
+// the LDAP server names them in the given mode under baseDN, in order and
+// reports what was done with each, or in a dry run only reports what
+// importing them would do. Content records create an entry or set the attributes they list;
+// change records add, delete, modify and rename entries. Members, owners
+// and managers are set after all records, so they may refer to entries
+// further down the file. A record that fails does not stop the import.
+func (s *LDIFService) Import(ctx context.Context, r io.Reader, mode, baseDN string, dryRun bool) (*domain.LDIFImportReport, error) {
+	records, err := ldif.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLDIF, err)
+	}
+	run, err := s.newImport(ctx, mode, baseDN, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		run.apply(ctx, rec)
+	}
+	for _, refs := range run.refs {
+		run.applyRefs(ctx, refs)
+	}
+	for _, e := range run.report.Entries {
+		switch e.Action {
+		case domain.LDIFImportCreate:
+			run.report.Created++
+		case domain.LDIFImportUpdate:
+			run.report.Updated++
+		case domain.LDIFImportRename:
+			run.report.Renamed++
+		case domain.LDIFImportDelete:
+			run.report.Deleted++
+		case domain.LDIFImportUnchanged:
+			run.report.Unchanged++
+		case domain.LDIFImportSkip:
+			run.report.Skipped++
+		case domain.LDIFImportFail:
+			run.report.Failed++
+		}
+	}
+	return run.report, nil
+}
+
+// ldifImport is the state of an import: the users and groups as the
+// records imported so far left them, which in a dry run exist only here.
+type ldifImport struct {
+	s      *LDIFService
+	mode   string
+	baseDN string
+	dryRun bool
+	report *domain.LDIFImportReport
+
+	users      map[uuid.UUID]*domain.User
+	usersByKey map[string]*domain.User // by lower-cased username
+	userDNs    map[string]*domain.User // by normalized DN
+	groups     map[uuid.UUID]*domain.Group
+	groupDNs   map[string]*domain.Group
+	members    map[uuid.UUID][]uuid.UUID
+	ownerUsers map[uuid.UUID][]uuid.UUID
+	ownerGroup map[uuid.UUID][]uuid.UUID
+
+	userFields  map[string]string
+	groupFields map[string]string
+	containers  map[string]bool
+	refs        []*ldifRefs
+}
+
+// ldifRefs are the changes of a record to the members and owners of a
+// group, or to the manager of a user, applied after all records.
+type ldifRefs struct {
+	result *domain.LDIFImportResult
+	user   *domain.User
+	group  *domain.Group
+	mods   []ldif.Modification
+}
+
+func (s *LDIFService) newImport(ctx context.Context, mode, baseDN string, dryRun bool) (*ldifImport, error) {
+	users, err := s.dao.AllUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.dao.AllGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r := &ldifImport{
+		s:          s,
+		mode:       mode,
+		baseDN:     baseDN,
+		dryRun:     dryRun,
+		report:     &domain.LDIFImportReport{DryRun: dryRun, Entries: []*domain.LDIFImportResult{}},
+		users:      make(map[uuid.UUID]*domain.User),
+		usersByKey: make(map[string]*domain.User),
+		userDNs:    make(map[string]*domain.User),
+		groups:     make(map[uuid.UUID]*domain.Group),
+		groupDNs:   make(map[string]*domain.Group),
+		members:    make(map[uuid.UUID][]uuid.UUID),
+		ownerUsers: make(map[uuid.UUID][]uuid.UUID),
+		ownerGroup: make(map[uuid.UUID][]uuid.UUID),
+		containers: map[string]bool{
+			upstream.NormalizeDN(baseDN):                                true,
+			upstream.NormalizeDN(dn.UserBaseDN(baseDN, mode)):           true,
+			upstream.NormalizeDN(dn.GroupBaseDN(baseDN, mode)):          true,
+			upstream.NormalizeDN(dn.ServiceAccountBaseDN(baseDN, mode)): true,
+		},
+	}
+	for _, u := range users {
+		r.addUser(u)
+	}
+	for _, g := range groups {
+		r.addGroup(g)
+		for _, u := range g.Users {
+			r.members[g.ID] = append(r.members[g.ID], u.ID)
+		}
+		for _, u := range g.OwnerUsers {
+			r.ownerUsers[g.ID] = append(r.ownerUsers[g.ID], u.ID)
+		}
+		for _, o := range g.OwnerGroups {
+			r.ownerGroup[g.ID] = append(r.ownerGroup[g.ID], o.ID)
+		}
+	}
+
+	if r.userFields, err = s.fields(ctx, domain.AttributeTargetUser, ldifUserFields[mode == attrs.ModeActiveDirectory]); err != nil {
+		return nil, err
+	}
+	groupFields := map[string]string{"cn": "name", "description": "description", "member": "members"}
+	groupFields[strings.ToLower(attrs.NewMapper(mode).GroupOwnerAttribute())] = "owners"
+	if r.groupFields, err = s.fields(ctx, domain.AttributeTargetGroup, groupFields); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// fields returns the fields the attributes of users or groups set, by
+// lower-cased attribute name: the given built-in ones, the extension
+// attributes, and "" for attributes derived from other fields or
+// maintained by the server, which are not imported.
+func (s *LDIFService) fields(ctx context.Context, target domain.AttributeTarget, builtin map[string]string) (map[string]string, error) {
+	defs, err := s.dao.ListAttributeDefinitions(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("loading attribute definitions: %w", err)
+	}
+	fields := make(map[string]string, len(ldifDerivedAttributes)+len(builtin)+len(defs))
+	for _, name := range ldifDerivedAttributes {
+		fields[strings.ToLower(name)] = ""
+	}
+	maps.Copy(fields, builtin)
+	for _, def := range defs {
+		fields[strings.ToLower(def.LDAPName)] = "attributes." + def.Name
+	}
+	return fields, nil
+}
+
+// ldifUserFields maps the lower-cased names of the attributes of user
+// entries to the fields they set, in Active Directory mode and otherwise.
+var ldifUserFields = map[bool]map[string]string{
+	false: {
+		"uid":             "username",
+		"cn":              "cn",
+		"displayname":     "display_name",
+		"mail":            "email",
+		"telephonenumber": "phone",
+		"status":          "status",
+		"userpassword":    "password",
+		"shadowexpire":    "account_expires_at",
+		"manager":         "manager",
+		"sn":              "",
+	},
+	true: {
+		"samaccountname":     "username",
+		"cn":                 "cn",
+		"displayname":        "display_name",
+		"mail":               "email",
+		"telephonenumber":    "phone",
+		"useraccountcontrol": "status",
+		"userpassword":       "password",
+		"accountexpires":     "account_expires_at",
+		"manager":            "manager",
+	},
+}
+
+// ldifDerivedAttributes lists attributes that are derived from other
+// fields, such as the lockout state and back-links the LDAP handler
+// publishes, or that directories maintain themselves.
+var ldifDerivedAttributes = []string{
+	"objectClass", "entryUUID", "objectGUID", "memberOf", "directReports",
+	"pwdAccountLockedTime", "lockoutTime", "badPwdCount", "pwdChangedTime",
+	"shadowLastChange", "pwdLastSet", "createTimestamp", "modifyTimestamp",
+	"creatorsName", "modifiersName", "entryCSN", "entryDN",
+	"structuralObjectClass", "hasSubordinates", "subschemaSubentry",
+	"whenCreated", "whenChanged", "uSNCreated", "uSNChanged", "objectSid",
+	"objectCategory", "distinguishedName", "name", "instanceType",
+	"dSCorePropagationData",
+}
+
+// isRef reports whether a field refers to other entries by DN.
+func isRef(field string) bool {
+	return field == "manager" || field == "members" || field == "owners"
+}
+
+// isMultiValued reports whether a field can have more than one value.
+func isMultiValued(field string) bool {
+	return field == "members" || field == "owners" || strings.HasPrefix(field, "attributes.")
+}
+
+func (r *ldifImport) addUser(u *domain.User) {
+	r.users[u.ID] = u
+	r.usersByKey[strings.ToLower(u.Username)] = u
+	r.userDNs[r.userDN(u)] = u
+}
+
+func (r *ldifImport) addGroup(g *domain.Group) {
+	r.groups[g.ID] = g
+	r.groupDNs[r.groupDN(g)] = g
+}
+
+func (r *ldifImport) userDN(u *domain.User) string {
+	return upstream.NormalizeDN(dn.BuildUserDN(u.Username, u.DisplayName, r.baseDN, r.mode))
+}
+
+func (r *ldifImport) groupDN(g *domain.Group) string {
+	return upstream.NormalizeDN(dn.BuildGroupDN(g.Name, r.baseDN, r.mode))
+}
+
+// apply applies a record, except for its references.
+func (r *ldifImport) apply(ctx context.Context, rec *ldif.Record) {
+	res := &domain.LDIFImportResult{Line: rec.Line, DN: rec.DN, ChangeType: rec.ChangeType}
+	r.report.Entries = append(r.report.Entries, res)
+	var err error
+	switch rec.ChangeType {
+	case "", ldif.ChangeAdd:
+		err = r.applyEntry(ctx, rec, res)
+	case ldif.ChangeDelete:
+		err = r.applyDelete(ctx, rec, res)
+	case ldif.ChangeModify:
+		err = r.applyModify(ctx, rec, res)
+	case ldif.ChangeModRDN:
+		err = r.applyModRDN(ctx, rec, res)
+	}
+	if err != nil {
+		res.Action = domain.LDIFImportFail
+		res.Error = err.Error()
+	}
+}
+
+// target returns the user or group an existing entry is, and an error if
+// there is none.
+func (r *ldifImport) target(entryDN string, res *domain.LDIFImportResult) (*domain.User, *domain.Group, error) {
+	key := upstream.NormalizeDN(entryDN)
+	if u := r.userDNs[key]; u != nil {
+		res.Type = domain.AttributeTargetUser
+		res.LocalID = r.localID(u.ID)
+		return u, nil, nil
+	}
+	if g := r.groupDNs[key]; g != nil {
+		res.Type = domain.AttributeTargetGroup
+		res.LocalID = r.localID(g.ID)
+		return nil, g, nil
+	}
+	return nil, nil, errors.New("no such entry")
+}
+
+// localID returns the ID to report for a user or group, which in a dry run
+// is not that of a real one.
+func (r *ldifImport) localID(id uuid.UUID) *uuid.UUID {
+	if r.dryRun {
+		return nil
+	}
+	return &id
+}
+
+// fieldValues sorts the attributes of a content or add record by the
+// field they set, reporting those that are not imported.
+func (r *ldifImport) fieldValues(fields map[string]string, attributes []ldif.Attribute, res *domain.LDIFImportResult) (map[string][]string, error) {
+	values := make(map[string][]string)
+	for _, a := range attributes {
+		field, ok := r.field(fields, a.Name)
+		if !ok {
+			res.Ignored = append(res.Ignored, a.Name)
+			continue
+		}
+		if field == "" {
+			continue
+		}
+		if len(a.Values) > 1 && !isMultiValued(field) {
+			return nil, fmt.Errorf("%s has more than one value", a.Name)
+		}
+		values[field] = append(values[field], a.Values...)
+	}
+	return values, nil
+}
+
+// field returns the field an attribute sets, "" if it is not imported and
+// false if it is unknown. Attribute options are disregarded.
+func (r *ldifImport) field(fields map[string]string, name string) (string, bool) {
+	name, _, _ = strings.Cut(name, ";")
+	field, ok := fields[strings.ToLower(name)]
+	return field, ok
+}
+
+// applyEntry applies a content or add record: it creates the entry or, for
+// content records of existing entries, sets the attributes listed.
+func (r *ldifImport) applyEntry(ctx context.Context, rec *ldif.Record, res *domain.LDIFImportResult) error {
+	key := upstream.NormalizeDN(rec.DN)
+	if r.containers[key] {
+		res.Action = domain.LDIFImportSkip
+		return nil
+	}
+	switch {
+	case dn.IsUserDN(rec.DN, r.baseDN, r.mode):
+		res.Type = domain.AttributeTargetUser
+		values, err := r.fieldValues(r.userFields, rec.Attributes, res)
+		if err != nil {
+			return err
+		}
+		return r.applyUserEntry(ctx, rec, values, res)
+	case dn.IsGroupDN(rec.DN, r.baseDN, r.mode):
+		res.Type = domain.AttributeTargetGroup
+		values, err := r.fieldValues(r.groupFields, rec.Attributes, res)
+		if err != nil {
+			return err
+		}
+		return r.applyGroupEntry(ctx, rec, values, res)
+	case dn.IsServiceAccountDN(rec.DN, r.baseDN, r.mode):
+		return errors.New("service accounts cannot be imported")
+	default:
+		return fmt.Errorf("entry is not under %s or %s",
+			dn.UserBaseDN(r.baseDN, r.mode), dn.GroupBaseDN(r.baseDN, r.mode))
+	}
+}
+
+// applyUserEntry creates or updates the user of a content or add record.
+func (r *ldifImport) applyUserEntry(ctx context.Context, rec *ldif.Record, values map[string][]string, res *domain.LDIFImportResult) error {
+	username := first(values["username"])
+	if username == "" && r.mode != attrs.ModeActiveDirectory {
+		username, _ = dn.ExtractUsername(rec.DN, r.baseDN, r.mode)
+	}
+	if username == "" {
+		return fmt.Errorf("entry has no %s", r.usernameAttribute())
+	}
+	displayName := cmp.Or(first(values["display_name"]), first(values["cn"]), username)
+
+	u := r.usersByKey[strings.ToLower(username)]
+	if u != nil && rec.ChangeType == ldif.ChangeAdd {
+		return errors.New("entry already exists")
+	}
+	if u != nil {
+		displayName = cmp.Or(first(values["display_name"]), first(values["cn"]), u.DisplayName)
+	}
+	if want := dn.BuildUserDN(username, displayName, r.baseDN, r.mode); upstream.NormalizeDN(want) != upstream.NormalizeDN(rec.DN) {
+		return fmt.Errorf("entry with these attributes is named %s", want)
+	}
+
+	if u == nil {
+		var err error
+		if u, err = r.createUser(ctx, username, displayName, values, res); err != nil {
+			return err
+		}
+		res.Action = domain.LDIFImportCreate
+	} else {
+		res.LocalID = r.localID(u.ID)
+		if err := r.updateUser(ctx, u, values, res); err != nil {
+			return err
+		}
+		res.Action = domain.LDIFImportUnchanged
+		if len(res.Fields) > 0 {
+			res.Action = domain.LDIFImportUpdate
+		}
+	}
+	if v, ok := values["manager"]; ok {
+		r.refs = append(r.refs, &ldifRefs{result: res, user: u, mods: []ldif.Modification{
+			{Op: ldif.ModReplace, Attribute: ldif.Attribute{Name: "manager", Values: v}},
+		}})
+	}
+	return nil
+}
+
+// usernameAttribute returns the attribute usernames are published as.
+func (r *ldifImport) usernameAttribute() string {
+	if r.mode == attrs.ModeActiveDirectory {
+		return "sAMAccountName"
+	}
+	return "uid"
+}
+
+// createUser creates a user with the values of a record. A userPassword in
+// a supported hash scheme is imported as is; any other is a password,
+// checked against the password policy. Users without one have no password.
+func (r *ldifImport) createUser(ctx context.Context, username, displayName string, values map[string][]string, res *domain.LDIFImportResult) (*domain.User, error) {
+	input := domain.CreateUserInput{
+		Username:    username,
+		DisplayName: displayName,
+		Email:       first(values["email"]),
+		Phone:       first(values["phone"]),
+		Attributes:  make(map[string][]string),
+	}
+	if input.Email == "" {
+		return nil, errors.New("entry has no mail")
+	}
+	switch pw := first(values["password"]); {
+	case pw == "":
+		input.NoPassword = true
+	case password.Supported(pw):
+		input.PasswordHash = pw
+	default:
+		input.Password = pw
+	}
+	if v, ok := values["account_expires_at"]; ok {
+		var err error
+		if input.AccountExpiresAt, err = r.accountExpiry(first(v)); err != nil {
+			return nil, err
+		}
+	}
+	status := domain.UserStatusEnabled
+	if v, ok := values["status"]; ok {
+		var err error
+		if status, err = r.status(first(v)); err != nil {
+			return nil, err
+		}
+	}
+	for field, v := range values {
+		if name, ok := strings.CutPrefix(field, "attributes."); ok {
+			input.Attributes[name] = v
+		}
+	}
+
+	u := &domain.User{
+		ID:               uuid.New(),
+		Username:         input.Username,
+		DisplayName:      input.DisplayName,
+		Email:            input.Email,
+		Phone:            input.Phone,
+		Status:           status,
+		AccountExpiresAt: input.AccountExpiresAt,
+		Attributes:       input.Attributes,
+	}
+	if !r.dryRun {
+		var err error
+		if u, err = r.s.users.CreateUser(ctx, input); err != nil {
+			return nil, err
+		}
+		if status != domain.UserStatusEnabled {
+			if err := r.s.users.SetUserStatus(ctx, u.ID, status); err != nil {
+				return nil, err
+			}
+			u.Status = status
+		}
+		res.LocalID = &u.ID
+	}
+	r.addUser(u)
+	return u, nil
+}
+
+// updateUser sets the fields of a user to values, an empty slice clearing a
+// field, and records the fields changed.
+func (r *ldifImport) updateUser(ctx context.Context, u *domain.User, values map[string][]string, res *domain.LDIFImportResult) error {
+	var input domain.UpdateUserInput
+	if v, ok := values["username"]; ok && !strings.EqualFold(first(v), u.Username) {
+		return errors.New("usernames cannot be changed")
+	}
+	displayName, ok := values["display_name"]
+	if !ok {
+		displayName, ok = values["cn"]
+	}
+	if ok && first(displayName) != u.DisplayName {
+		if first(displayName) == "" {
+			return errors.New("display name cannot be removed")
+		}
+		input.DisplayName = &displayName[0]
+		res.Fields = append(res.Fields, "display_name")
+	}
+	if v, ok := values["email"]; ok && first(v) != u.Email {
+		if first(v) == "" {
+			return errors.New("email cannot be removed")
+		}
+		input.Email = &v[0]
+		res.Fields = append(res.Fields, "email")
+	}
+	if v, ok := values["phone"]; ok && first(v) != u.Phone {
+		phone := first(v)
+		input.Phone = &phone
+		res.Fields = append(res.Fields, "phone")
+	}
+	if v, ok := values["account_expires_at"]; ok && !slices.Equal(v, r.userValues(u, "account_expires_at")) {
+		t, err := r.accountExpiry(first(v))
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			input.ClearAccountExpiry = u.AccountExpiresAt != nil
+		} else {
+			input.AccountExpiresAt = t
+		}
+		if input.ClearAccountExpiry || input.AccountExpiresAt != nil {
+			res.Fields = append(res.Fields, "account_expires_at")
+		}
+	}
+	for _, field := range slices.Sorted(maps.Keys(values)) {
+		name, ok := strings.CutPrefix(field, "attributes.")
+		if !ok || slices.Equal(values[field], u.Attributes[name]) {
+			continue
+		}
+		if input.Attributes == nil {
+			input.Attributes = make(map[string][]string)
+		}
+		input.Attributes[name] = append([]string{}, values[field]...)
+		res.Fields = append(res.Fields, field)
+	}
+
+	status := u.Status
+	if v, ok := values["status"]; ok {
+		var err error
+		if status, err = r.status(first(v)); err != nil {
+			return err
+		}
+		// Pending users become enabled only by accepting their invitation.
+		if u.Status == domain.UserStatusPending {
+			status = u.Status
+		}
+	}
+	if status != u.Status {
+		res.Fields = append(res.Fields, "status")
+	}
+
+	var hash, newPassword string
+	if v, ok := values["password"]; ok {
+		switch pw := first(v); {
+		case pw == "":
+			return errors.New("password cannot be removed")
+		case password.Supported(pw):
+			if pw != u.PasswordHash {
+				hash = pw
+			}
+		case !passwordMatches(u.PasswordHash, pw):
+			newPassword = pw
+		}
+		if hash != "" || newPassword != "" {
+			res.Fields = append(res.Fields, "password")
+		}
+	}
+
+	if !r.dryRun {
+		if input.DisplayName != nil || input.Email != nil || input.Phone != nil ||
+			input.AccountExpiresAt != nil || input.ClearAccountExpiry || len(input.Attributes) > 0 {
+			if _, err := r.s.users.UpdateUser(ctx, u.ID, input); err != nil {
+				return err
+			}
+		}
+		if status != u.Status {
+			if err := r.s.users.SetUserStatus(ctx, u.ID, status); err != nil {
+				return err
+			}
+		}
+		if hash != "" {
+			if err := r.s.users.ImportPasswordHash(ctx, u.ID, hash); err != nil {
+				return err
+			}
+		}
+		if newPassword != "" {
+			if err := r.s.users.ResetPassword(ctx, u.ID, newPassword, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	delete(r.userDNs, r.userDN(u))
+	if input.DisplayName != nil {
+		u.DisplayName = *input.DisplayName
+	}
+	if input.Email != nil {
+		u.Email = *input.Email
+	}
+	if input.Phone != nil {
+		u.Phone = *input.Phone
+	}
+	if input.ClearAccountExpiry {
+		u.AccountExpiresAt = nil
+	} else if input.AccountExpiresAt != nil {
+		u.AccountExpiresAt = input.AccountExpiresAt
+	}
+	for name, v := range input.Attributes {
+		if u.Attributes == nil {
+			u.Attributes = make(map[string][]string)
+		}
+		u.Attributes[name] = v
+	}
+	if hash != "" {
+		u.PasswordHash = hash
+	}
+	u.Status = status
+	r.userDNs[r.userDN(u)] = u
+	return nil
+}
+
+// userValues returns the values of a field of a user as the LDAP handler
+// publishes them.
+func (r *ldifImport) userValues(u *domain.User, field string) []string {
+	ad := r.mode == attrs.ModeActiveDirectory
+	switch field {
+	case "username":
+		return []string{u.Username}
+	case "display_name", "cn":
+		return []string{u.DisplayName}
+	case "email":
+		return nonEmpty([]string{u.Email})
+	case "phone":
+		return nonEmpty([]string{u.Phone})
+	case "status":
+		ldapAttrs := attrs.NewMapper(r.mode).UserToLDAPAttrs(u.Username, u.DisplayName, u.Email, u.Phone, string(u.Status))
+		if ad {
+			return ldapAttrs["userAccountControl"]
+		}
+		return ldapAttrs["status"]
+	case "account_expires_at":
+		switch {
+		case u.AccountExpiresAt == nil && ad:
+			return []string{attrs.FileTimeNever}
+		case u.AccountExpiresAt == nil:
+			return nil
+		case ad:
+			return []string{attrs.FileTime(*u.AccountExpiresAt)}
+		default:
+			return []string{attrs.EpochDays(*u.AccountExpiresAt)}
+		}
+	case "manager":
+		if u.ManagerID != nil {
+			if m := r.users[*u.ManagerID]; m != nil {
+				return []string{r.userDN(m)}
+			}
+		}
+		return nil
+	}
+	if name, ok := strings.CutPrefix(field, "attributes."); ok {
+		return u.Attributes[name]
+	}
+	return nil
+}
+
+// status parses the status or userAccountControl of a user entry. Users
+// that are not enabled are disabled.
+func (r *ldifImport) status(v string) (domain.UserStatus, error) {
+	if r.mode == attrs.ModeActiveDirectory {
+		flags, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid userAccountControl %q", v)
+		}
+		if flags&adAccountDisable != 0 {
+			return domain.UserStatusDisabled, nil
+		}
+		return domain.UserStatusEnabled, nil
+	}
+	switch domain.UserStatus(v) {
+	case domain.UserStatusEnabled:
+		return domain.UserStatusEnabled, nil
+	case domain.UserStatusDisabled, domain.UserStatusPending:
+		return domain.UserStatusDisabled, nil
+	default:
+		return "", fmt.Errorf("invalid status %q", v)
+	}
+}
+
+// accountExpiry parses the accountExpires or shadowExpire of a user entry.
+// It returns nil for accounts that never expire.
+func (r *ldifImport) accountExpiry(v string) (*time.Time, error) {
+	var t time.Time
+	var err error
+	if r.mode == attrs.ModeActiveDirectory {
+		if v == "" || v == "0" || v == attrs.FileTimeNever {
+			return nil, nil
+		}
+		t, err = attrs.ParseFileTime(v)
+	} else {
+		if v == "" {
+			return nil, nil
+		}
+		t, err = attrs.ParseEpochDays(v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid account expiry %q", v)
+	}
+	return &t, nil
+}
+
+// applyGroupEntry creates or updates the group of a content or add record.
+func (r *ldifImport) applyGroupEntry(ctx context.Context, rec *ldif.Record, values map[string][]string, res *domain.LDIFImportResult) error {
+	rdns, err := dn.ParseDN(rec.DN)
+	if err != nil {
+		return err
+	}
+	name := rdns[0].Value
+	if want := dn.BuildGroupDN(name, r.baseDN, r.mode); !strings.EqualFold(rdns[0].Type, "cn") || upstream.NormalizeDN(want) != upstream.NormalizeDN(rec.DN) {
+		return fmt.Errorf("groups are named cn=<name>,%s", dn.GroupBaseDN(r.baseDN, r.mode))
+	}
+	if v, ok := values["name"]; ok && !slices.ContainsFunc(v, func(cn string) bool { return strings.EqualFold(cn, name) }) {
+		return errors.New("cn does not match the DN")
+	}
+
+	g := r.groupDNs[upstream.NormalizeDN(rec.DN)]
+	if g != nil && rec.ChangeType == ldif.ChangeAdd {
+		return errors.New("entry already exists")
+	}
+	if g == nil {
+		if g, err = r.createGroup(ctx, name, values, res); err != nil {
+			return err
+		}
+		res.Action = domain.LDIFImportCreate
+	} else {
+		res.LocalID = r.localID(g.ID)
+		if err := r.updateGroup(ctx, g, values, res); err != nil {
+			return err
+		}
+		res.Action = domain.LDIFImportUnchanged
+		if len(res.Fields) > 0 {
+			res.Action = domain.LDIFImportUpdate
+		}
+	}
+
+	refs := &ldifRefs{result: res, group: g}
+	for _, field := range []string{"members", "owners"} {
+		if v, ok := values[field]; ok {
+			refs.mods = append(refs.mods, ldif.Modification{Op: ldif.ModReplace, Attribute: ldif.Attribute{Name: field, Values: v}})
+		}
+	}
+	if len(refs.mods) > 0 {
+		r.refs = append(r.refs, refs)
+	}
+	return nil
+}
+
+// createGroup creates a group with the values of a record. Like groups
+// created otherwise, it is owned by the acting user until the owners of
+// the record are set.
+func (r *ldifImport) createGroup(ctx context.Context, name string, values map[string][]string, res *domain.LDIFImportResult) (*domain.Group, error) {
+	input := domain.CreateGroupInput{
+		Name:        name,
+		Description: first(values["description"]),
+		Attributes:  make(map[string][]string),
+	}
+	for field, v := range values {
+		if attr, ok := strings.CutPrefix(field, "attributes."); ok {
+			input.Attributes[attr] = v
+		}
+	}
+
+	g := &domain.Group{ID: uuid.New(), Name: input.Name, Description: input.Description, Attributes: input.Attributes}
+	if r.dryRun {
+		if actor, ok := ActorFromContext(ctx); ok && !actor.IsServiceAccount() {
+			r.ownerUsers[g.ID] = []uuid.UUID{actor.UserID}
+		}
+	} else {
+		var err error
+		if g, err = r.s.groups.CreateGroup(ctx, input); err != nil {
+			return nil, err
+		}
+		for _, u := range g.OwnerUsers {
+			r.ownerUsers[g.ID] = append(r.ownerUsers[g.ID], u.ID)
+		}
+		res.LocalID = &g.ID
+	}
+	r.addGroup(g)
+	return g, nil
+}
+
+// updateGroup sets the fields of a group to values, an empty slice
+// clearing a field, and records the fields changed. Groups are renamed by
+// modrdn records.
+func (r *ldifImport) updateGroup(ctx context.Context, g *domain.Group, values map[string][]string, res *domain.LDIFImportResult) error {
+	var input domain.UpdateGroupInput
+	if v, ok := values["name"]; ok && !slices.ContainsFunc(v, func(cn string) bool { return strings.EqualFold(cn, g.Name) }) {
+		return errors.New("groups are renamed by modrdn")
+	}
+	if v, ok := values["description"]; ok && first(v) != g.Description {
+		description := first(v)
+		input.Description = &description
+		res.Fields = append(res.Fields, "description")
+	}
+	for _, field := range slices.Sorted(maps.Keys(values)) {
+		name, ok := strings.CutPrefix(field, "attributes.")
+		if !ok || slices.Equal(values[field], g.Attributes[name]) {
+			continue
+		}
+		if input.Attributes == nil {
+			input.Attributes = make(map[string][]string)
+		}
+		input.Attributes[name] = append([]string{}, values[field]...)
+		res.Fields = append(res.Fields, field)
+	}
+	if input.Description == nil && len(input.Attributes) == 0 {
+		return nil
+	}
+	if !r.dryRun {
+		if _, err := r.s.groups.UpdateGroup(ctx, g.ID, input); err != nil {
+			return err
+		}
+	}
+	if input.Description != nil {
+		g.Description = *input.Description
+	}
+	for name, v := range input.Attributes {
+		if g.Attributes == nil {
+			g.Attributes = make(map[string][]string)
+		}
+		g.Attributes[name] = v
+	}
+	return nil
+}
+
+// groupValues returns the values of a field of a group as the LDAP handler
+// publishes them.
+func (r *ldifImport) groupValues(g *domain.Group, field string) []string {
+	switch field {
+	case "name":
+		return []string{g.Name}
+	case "description":
+		return nonEmpty([]string{g.Description})
+	case "members":
+		var dns []string
+		for _, id := range r.members[g.ID] {
+			if u := r.users[id]; u != nil {
+				dns = append(dns, r.userDN(u))
+			}
+		}
+		return dns
+	case "owners":
+		var dns []string
+		for _, id := range r.ownerUsers[g.ID] {
+			if u := r.users[id]; u != nil {
+				dns = append(dns, r.userDN(u))
+			}
+		}
+		for _, id := range r.ownerGroup[g.ID] {
+			if o := r.groups[id]; o != nil {
+				dns = append(dns, r.groupDN(o))
+			}
+		}
+		return dns
+	}
+	if name, ok := strings.CutPrefix(field, "attributes."); ok {
+		return g.Attributes[name]
+	}
+	return nil
+}
+
+// applyModify applies a modify record: the modifications are applied to
+// the values of the entry one after the other, and the fields they leave
+// changed are set.
+func (r *ldifImport) applyModify(ctx context.Context, rec *ldif.Record, res *domain.LDIFImportResult) error {
+	u, g, err := r.target(rec.DN, res)
+	if err != nil {
+		return err
+	}
+	fields, current := r.groupFields, func(field string) []string { return r.groupValues(g, field) }
+	if u != nil {
+		fields, current = r.userFields, func(field string) []string { return r.userValues(u, field) }
+	}
+
+	values := make(map[string][]string)
+	refs := &ldifRefs{result: res, user: u, group: g}
+	for _, m := range rec.Modifications {
+		field, ok := r.field(fields, m.Name)
+		if !ok {
+			res.Ignored = append(res.Ignored, m.Name)
+			continue
+		}
+		if field == "" {
+			continue
+		}
+		if isRef(field) {
+			m.Name = field
+			refs.mods = append(refs.mods, m)
+			continue
+		}
+		v, ok := values[field]
+		if !ok {
+			v = current(field)
+		}
+		v = modifyValues(v, m, func(s string) string { return s })
+		if len(v) > 1 && !isMultiValued(field) {
+			return fmt.Errorf("%s has more than one value", m.Name)
+		}
+		values[field] = v
+	}
+
+	if u != nil {
+		err = r.updateUser(ctx, u, values, res)
+	} else {
+		err = r.updateGroup(ctx, g, values, res)
+	}
+	if err != nil {
+		return err
+	}
+	res.Action = domain.LDIFImportUnchanged
+	if len(res.Fields) > 0 {
+		res.Action = domain.LDIFImportUpdate
+	}
+	if len(refs.mods) > 0 {
+		r.refs = append(r.refs, refs)
+	}
+	return nil
+}
+
+// modifyValues applies a modification to the values of an attribute,
+// comparing values by key.
+func modifyValues(values []string, m ldif.Modification, key func(string) string) []string {
+	has := func(vs []string, v string) bool {
+		return slices.ContainsFunc(vs, func(x string) bool { return key(x) == key(v) })
+	}
+	switch m.Op {
+	case ldif.ModAdd:
+		values = slices.Clone(values)
+		for _, v := range m.Values {
+			if !has(values, v) {
+				values = append(values, v)
+			}
+		}
+		return values
+	case ldif.ModDelete:
+		if len(m.Values) == 0 {
+			return []string{}
+		}
+		return slices.DeleteFunc(slices.Clone(values), func(v string) bool { return has(m.Values, v) })
+	default:
+		return append([]string{}, m.Values...)
+	}
+}
+
+// applyDelete deletes the user or group of a delete record.
+func (r *ldifImport) applyDelete(ctx context.Context, rec *ldif.Record, res *domain.LDIFImportResult) error {
+	u, g, err := r.target(rec.DN, res)
+	if err != nil {
+		return err
+	}
+	if u != nil {
+		if !r.dryRun {
+			if err := r.s.users.DeleteUser(ctx, u.ID); err != nil {
+				return err
+			}
+		}
+		delete(r.users, u.ID)
+		delete(r.usersByKey, strings.ToLower(u.Username))
+		delete(r.userDNs, r.userDN(u))
+		for id := range r.groups {
+			r.members[id] = slices.DeleteFunc(r.members[id], func(m uuid.UUID) bool { return m == u.ID })
+			r.ownerUsers[id] = slices.DeleteFunc(r.ownerUsers[id], func(m uuid.UUID) bool { return m == u.ID })
+		}
+	} else {
+		if !r.dryRun {
+			if err := r.s.groups.DeleteGroup(ctx, g.ID); err != nil {
+				return err
+			}
+		}
+		delete(r.groups, g.ID)
+		delete(r.groupDNs, r.groupDN(g))
+		for id := range r.groups {
+			r.ownerGroup[id] = slices.DeleteFunc(r.ownerGroup[id], func(o uuid.UUID) bool { return o == g.ID })
+		}
+	}
+	res.Action = domain.LDIFImportDelete
+	return nil
+}
+
+// applyModRDN applies a modrdn record. Groups are renamed; users are named
+// by username, which cannot be changed, except in Active Directory, where
+// they are named by display name. Entries cannot be moved.
+func (r *ldifImport) applyModRDN(ctx context.Context, rec *ldif.Record, res *domain.LDIFImportResult) error {
+	u, g, err := r.target(rec.DN, res)
+	if err != nil {
+		return err
+	}
+	rdns, err := dn.ParseDN(rec.NewRDN)
+	if err != nil || len(rdns) != 1 {
+		return fmt.Errorf("invalid newrdn %q", rec.NewRDN)
+	}
+	parent := dn.GroupBaseDN(r.baseDN, r.mode)
+	if u != nil {
+		parent = dn.UserBaseDN(r.baseDN, r.mode)
+	}
+	if rec.NewSuperior != "" && upstream.NormalizeDN(rec.NewSuperior) != upstream.NormalizeDN(parent) {
+		return errors.New("entries cannot be moved")
+	}
+	rdn := rdns[0]
+
+	if u != nil {
+		if r.mode != attrs.ModeActiveDirectory {
+			if !strings.EqualFold(rdn.Type, "uid") || !strings.EqualFold(rdn.Value, u.Username) {
+				return errors.New("usernames cannot be changed")
+			}
+			res.Action = domain.LDIFImportUnchanged
+			return nil
+		}
+		if !strings.EqualFold(rdn.Type, "cn") {
+			return fmt.Errorf("users are named by cn")
+		}
+		if other := r.userDNs[upstream.NormalizeDN(rdn.String()+","+parent)]; other != nil && other != u {
+			return errors.New("entry already exists")
+		}
+		if err := r.updateUser(ctx, u, map[string][]string{"display_name": {rdn.Value}}, res); err != nil {
+			return err
+		}
+	} else {
+		if !strings.EqualFold(rdn.Type, "cn") {
+			return fmt.Errorf("groups are named by cn")
+		}
+		if other := r.groupDNs[upstream.NormalizeDN(rdn.String()+","+parent)]; other != nil && other != g {
+			return errors.New("entry already exists")
+		}
+		if rdn.Value != g.Name {
+			if !r.dryRun {
+				if _, err := r.s.groups.UpdateGroup(ctx, g.ID, domain.UpdateGroupInput{Name: &rdn.Value}); err != nil {
+					return err
+				}
+			}
+			delete(r.groupDNs, r.groupDN(g))
+			g.Name = rdn.Value
+			r.groupDNs[r.groupDN(g)] = g
+			res.Fields = append(res.Fields, "name")
+		}
+	}
+	res.Action = domain.LDIFImportUnchanged
+	if len(res.Fields) > 0 {
+		res.Action = domain.LDIFImportRename
+	}
+	return nil
+}
+
+// applyRefs sets the manager of a user, or the members and owners of a
+// group, as a record says.
+func (r *ldifImport) applyRefs(ctx context.Context, refs *ldifRefs) {
+	res := refs.result
+	if res.Action == domain.LDIFImportFail {
+		return
+	}
+	var err error
+	switch {
+	case refs.user != nil && r.users[refs.user.ID] == refs.user:
+		err = r.applyManager(ctx, refs)
+	case refs.group != nil && r.groups[refs.group.ID] == refs.group:
+		err = r.applyGroupRefs(ctx, refs)
+	default:
+		return // deleted by a later record
+	}
+	if err != nil {
+		res.Action = domain.LDIFImportFail
+		res.Error = err.Error()
+		return
+	}
+	if res.Action == domain.LDIFImportUnchanged && len(res.Fields) > 0 {
+		res.Action = domain.LDIFImportUpdate
+	}
+}
+
+// applyManager sets the manager of a user.
+func (r *ldifImport) applyManager(ctx context.Context, refs *ldifRefs) error {
+	u := refs.user
+	dns := r.userValues(u, "manager")
+	for _, m := range refs.mods {
+		dns = modifyValues(dns, m, upstream.NormalizeDN)
+	}
+	if len(dns) > 1 {
+		return errors.New("manager has more than one value")
+	}
+	var manager *domain.User
+	if len(dns) == 1 {
+		if manager = r.userDNs[upstream.NormalizeDN(dns[0])]; manager == nil {
+			return fmt.Errorf("manager %s is not a user", dns[0])
+		}
+	}
+	switch {
+	case manager == nil && u.ManagerID == nil,
+		manager != nil && u.ManagerID != nil && *u.ManagerID == manager.ID:
+		return nil
+	}
+
+	input := domain.UpdateUserInput{ClearManager: manager == nil}
+	if manager != nil {
+		input.ManagerID = &manager.ID
+	}
+	if !r.dryRun {
+		if _, err := r.s.users.UpdateUser(ctx, u.ID, input); err != nil {
+			return err
+		}
+	}
+	u.ManagerID = input.ManagerID
+	refs.result.Fields = append(refs.result.Fields, "manager")
+	return nil
+}
+
+// applyGroupRefs sets the members and owners of a group. Members must be
+// users; owners may be users or groups.
+func (r *ldifImport) applyGroupRefs(ctx context.Context, refs *ldifRefs) error {
+	g := refs.group
+	values := make(map[string][]string)
+	for _, m := range refs.mods {
+		v, ok := values[m.Name]
+		if !ok {
+			v = r.groupValues(g, m.Name)
+		}
+		values[m.Name] = modifyValues(v, m, upstream.NormalizeDN)
+	}
+
+	if dns, ok := values["members"]; ok {
+		var want []uuid.UUID
+		for _, d := range dns {
+			u := r.userDNs[upstream.NormalizeDN(d)]
+			if u == nil {
+				return fmt.Errorf("member %s is not a user", d)
+			}
+			want = append(want, u.ID)
+		}
+		added, removed := diffIDs(r.members[g.ID], want)
+		if len(added) > 0 || len(removed) > 0 {
+			if !r.dryRun {
+				if len(added) > 0 {
+					if err := r.s.groups.AddMembers(ctx, g.ID, added); err != nil {
+						return err
+					}
+				}
+				for _, id := range removed {
+					if err := r.s.groups.RemoveMember(ctx, g.ID, id); err != nil {
+						return err
+					}
+				}
+			}
+			r.members[g.ID] = want
+			refs.result.Fields = append(refs.result.Fields, "members")
+		}
+	}
+
+	if dns, ok := values["owners"]; ok {
+		var wantUsers, wantGroups []uuid.UUID
+		for _, d := range dns {
+			key := upstream.NormalizeDN(d)
+			if u := r.userDNs[key]; u != nil {
+				wantUsers = append(wantUsers, u.ID)
+			} else if o := r.groupDNs[key]; o != nil {
+				wantGroups = append(wantGroups, o.ID)
+			} else {
+				return fmt.Errorf("owner %s is not a user or group", d)
+			}
+		}
+		addedUsers, removedUsers := diffIDs(r.ownerUsers[g.ID], wantUsers)
+		addedGroups, removedGroups := diffIDs(r.ownerGroup[g.ID], wantGroups)
+		if len(addedUsers)+len(removedUsers)+len(addedGroups)+len(removedGroups) > 0 {
+			if !r.dryRun {
+				if len(addedUsers) > 0 || len(addedGroups) > 0 {
+					if err := r.s.groups.AddOwners(ctx, g.ID, addedUsers, addedGroups); err != nil {
+						return err
+					}
+				}
+				for _, id := range removedUsers {
+					if err := r.s.groups.RemoveOwnerUser(ctx, g.ID, id); err != nil {
+						return err
+					}
+				}
+				for _, id := range removedGroups {
+					if err := r.s.groups.RemoveOwnerGroup(ctx, g.ID, id); err != nil {
+						return err
+					}
+				}
+			}
+			r.ownerUsers[g.ID] = wantUsers
+			r.ownerGroup[g.ID] = wantGroups
+			refs.result.Fields = append(refs.result.Fields, "owners")
+		}
+	}
+	return nil
+}
+
+// diffIDs returns the IDs in want but not in have, and those in have but
+// not in want.
+func diffIDs(have, want []uuid.UUID) (added, removed []uuid.UUID) {
+	for _, id := range want {
+		if !slices.Contains(have, id) && !slices.Contains(added, id) {
+			added = append(added, id)
+		}
+	}
+	for _, id := range have {
+		if !slices.Contains(want, id) {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
+
+// first returns the first of values, or "".
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
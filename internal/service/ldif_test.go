@@ -0,0 +1,235 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+const ldifTestBaseDN = "dc=example,dc=com"
+
+func ldifActions(report *domain.LDIFImportReport) []string {
+	var actions []string
+	for _, e := range report.Entries {
+		actions = append(actions, string(e.Action)+" "+e.DN)
+	}
+	return actions
+}
+
+func TestLDIFImport(t *testing.T) {
+	_, userSvc, d, ctx := setupAuditService(t)
+	groupSvc := NewGroupService(d)
+	svc := NewLDIFService(d, userSvc, groupSvc)
+
+	const content = `version: 1
+
+dn: dc=example,dc=com
+objectClass: domain
+dc: example
+
+dn: cn=team,ou=groups,dc=example,dc=com
+objectClass: groupOfNames
+cn: team
+description: The team
+member: uid=ann,ou=users,dc=example,dc=com
+member: uid=ben,ou=users,dc=example,dc=com
+
+dn: uid=ann,ou=users,dc=example,dc=com
+objectClass: inetOrgPerson
+uid: ann
+cn: Ann
+displayName: Ann
+mail: ann@example.com
+status: enabled
+entryUUID: 5f0c4c6e-0000-0000-0000-000000000000
+roomNumber: 12
+
+dn: uid=ben,ou=users,dc=example,dc=com
+objectClass: inetOrgPerson
+uid: ben
+cn: Ben
+mail: ben@example.com
+status: disabled
+manager: uid=ann,ou=users,dc=example,dc=com
+
+dn: uid=cat,ou=users,dc=example,dc=com
+objectClass: inetOrgPerson
+uid: cat
+cn: Cat
+`
+
+	report, err := svc.Import(ctx, strings.NewReader(content), "openldap", ldifTestBaseDN, true)
+	if err != nil {
+		t.Fatalf("Import dry run: %v", err)
+	}
+	want := []string{
+		"skip dc=example,dc=com",
+		"create cn=team,ou=groups,dc=example,dc=com",
+		"create uid=ann,ou=users,dc=example,dc=com",
+		"create uid=ben,ou=users,dc=example,dc=com",
+		"fail uid=cat,ou=users,dc=example,dc=com",
+	}
+	if got := ldifActions(report); !slices.Equal(got, want) {
+		t.Errorf("dry run actions = %v, want %v", got, want)
+	}
+	if !report.DryRun || report.Created != 3 || report.Skipped != 1 || report.Failed != 1 {
+		t.Errorf("dry run report = %+v", report)
+	}
+	if got := report.Entries[2].Ignored; !slices.Equal(got, []string{"roomNumber"}) {
+		t.Errorf("ignored = %v, want [roomNumber]", got)
+	}
+	if got := report.Entries[4].Error; got != "entry has no mail" {
+		t.Errorf("error = %q", got)
+	}
+	if users, _ := userSvc.AllUsers(ctx); len(users) != 0 {
+		t.Fatalf("dry run created %d users", len(users))
+	}
+
+	report, err = svc.Import(ctx, strings.NewReader(content), "openldap", ldifTestBaseDN, false)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if got := ldifActions(report); !slices.Equal(got, want) {
+		t.Errorf("actions = %v, want %v", got, want)
+	}
+	ann, err := userSvc.GetUserByUsername(ctx, "ann")
+	if err != nil {
+		t.Fatalf("imported user: %v", err)
+	}
+	ben, err := userSvc.GetUserByUsername(ctx, "ben")
+	if err != nil {
+		t.Fatalf("imported user: %v", err)
+	}
+	if ben.Status != domain.UserStatusDisabled || ben.ManagerID == nil || *ben.ManagerID != ann.ID {
+		t.Errorf("ben = %+v", ben)
+	}
+	groups, err := groupSvc.AllGroups(ctx)
+	if err != nil || len(groups) != 1 {
+		t.Fatalf("groups = %v, %v", groups, err)
+	}
+	team := groups[0]
+	if team.Description != "The team" || len(team.Users) != 2 {
+		t.Errorf("team = %+v", team)
+	}
+
+	// Importing the same file again changes nothing.
+	report, err = svc.Import(ctx, strings.NewReader(content), "openldap", ldifTestBaseDN, false)
+	if err != nil {
+		t.Fatalf("Import again: %v", err)
+	}
+	if report.Unchanged != 3 || report.Created+report.Updated != 0 {
+		t.Errorf("second import = %v", ldifActions(report))
+	}
+
+	const changes = `version: 1
+
+dn: uid=ann,ou=users,dc=example,dc=com
+changetype: modify
+replace: telephoneNumber
+telephoneNumber: 555-0100
+-
+replace: status
+status: disabled
+-
+
+dn: cn=team,ou=groups,dc=example,dc=com
+changetype: modify
+delete: member
+member: uid=ann,ou=users,dc=example,dc=com
+-
+
+dn: cn=team,ou=groups,dc=example,dc=com
+changetype: modrdn
+newrdn: cn=crew
+deleteoldrdn: 1
+
+dn: uid=ben,ou=users,dc=example,dc=com
+changetype: delete
+
+dn: uid=ann,ou=users,dc=example,dc=com
+changetype: modrdn
+newrdn: uid=anna
+deleteoldrdn: 1
+
+dn: uid=nobody,ou=users,dc=example,dc=com
+changetype: delete
+`
+	report, err = svc.Import(ctx, strings.NewReader(changes), "openldap", ldifTestBaseDN, false)
+	if err != nil {
+		t.Fatalf("Import changes: %v", err)
+	}
+	want = []string{
+		"update uid=ann,ou=users,dc=example,dc=com",
+		"update cn=team,ou=groups,dc=example,dc=com",
+		"rename cn=team,ou=groups,dc=example,dc=com",
+		"delete uid=ben,ou=users,dc=example,dc=com",
+		"fail uid=ann,ou=users,dc=example,dc=com",
+		"fail uid=nobody,ou=users,dc=example,dc=com",
+	}
+	if got := ldifActions(report); !slices.Equal(got, want) {
+		t.Errorf("change actions = %v, want %v", got, want)
+	}
+	if got := report.Entries[0].Fields; !slices.Equal(got, []string{"phone", "status"}) {
+		t.Errorf("fields = %v, want [phone status]", got)
+	}
+	if ann, _ = userSvc.GetUser(ctx, ann.ID); ann.Phone != "555-0100" || ann.Status != domain.UserStatusDisabled {
+		t.Errorf("ann = %+v", ann)
+	}
+	if _, err := userSvc.GetUser(ctx, ben.ID); err == nil {
+		t.Error("ben was not deleted")
+	}
+	if team, err = groupSvc.GetGroup(ctx, team.ID); err != nil || team.Name != "crew" || len(team.Users) != 0 {
+		t.Errorf("team = %+v, %v", team, err)
+	}
+
+	if _, err := svc.Import(ctx, strings.NewReader("dn: uid=x\nchangetype: rename\n"), "openldap", ldifTestBaseDN, false); !errors.Is(err, ErrInvalidLDIF) {
+		t.Errorf("invalid LDIF: err = %v, want ErrInvalidLDIF", err)
+	}
+}
+
+func TestLDIFImportActiveDirectory(t *testing.T) {
+	_, userSvc, d, ctx := setupAuditService(t)
+	svc := NewLDIFService(d, userSvc, NewGroupService(d))
+
+	const content = `dn: cn=Dan Doe,cn=Users,dc=example,dc=com
+objectClass: user
+sAMAccountName: dan
+cn: Dan Doe
+mail: dan@example.com
+userAccountControl: 514
+accountExpires: 9223372036854775807
+`
+	report, err := svc.Import(ctx, strings.NewReader(content), "activedirectory", ldifTestBaseDN, false)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if report.Created != 1 {
+		t.Fatalf("report = %v: %+v", ldifActions(report), report.Entries[0])
+	}
+	dan, err := userSvc.GetUserByUsername(ctx, "dan")
+	if err != nil {
+		t.Fatalf("imported user: %v", err)
+	}
+	if dan.DisplayName != "Dan Doe" || dan.Status != domain.UserStatusDisabled || dan.AccountExpiresAt != nil {
+		t.Errorf("dan = %+v", dan)
+	}
+
+	// Users are named by display name in Active Directory.
+	const rename = `dn: cn=Dan Doe,cn=Users,dc=example,dc=com
+changetype: modrdn
+newrdn: cn=Daniel Doe
+deleteoldrdn: 1
+`
+	if report, err = svc.Import(ctx, strings.NewReader(rename), "activedirectory", ldifTestBaseDN, false); err != nil {
+		t.Fatalf("Import rename: %v", err)
+	}
+	if report.Renamed != 1 {
+		t.Errorf("rename = %v: %+v", ldifActions(report), report.Entries[0])
+	}
+	if dan, _ = userSvc.GetUser(ctx, dan.ID); dan.DisplayName != "Daniel Doe" {
+		t.Errorf("display name = %q, want Daniel Doe", dan.DisplayName)
+	}
+}
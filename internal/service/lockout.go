@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/password"
+)
+
+var (
+	// ErrInvalidCredentials is returned by Authenticate for an unknown
+	// username or a wrong password.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrUserDisabled is returned by Authenticate for a disabled user.
+	ErrUserDisabled = errors.New("user is disabled")
+	// ErrAccountExpired is returned by Authenticate for a user whose
+	// account expiry date has passed.
+	ErrAccountExpired = errors.New("account expired")
+	// ErrAccountLocked is wrapped by a *LockoutError when an account is
+	// locked out after too many failed logins.
+	ErrAccountLocked = errors.New("account locked")
+	// ErrTooManyAttempts is wrapped by a *LockoutError when the client's IP
+	// address is blocked after too many failed logins.
+	ErrTooManyAttempts = errors.New("too many failed login attempts")
+)
+
+// LockoutError is returned when a login is refused without checking the
+// password. It wraps ErrAccountLocked or ErrTooManyAttempts; Until is when
+// logins are accepted again.
+type LockoutError struct {
+	Err   error
+	Until time.Time
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("%v until %s", e.Err, e.Until.Format(time.RFC3339))
+}
+
+func (e *LockoutError) Unwrap() error {
+	return e.Err
+}
+
+// loginEventLimit is how many recent login events LockoutStatus returns.
+const loginEventLimit = 50
+
+// ipFailures counts the failed logins from one IP address in the window
+// that started at since.
+type ipFailures struct {
+	count int
+	since time.Time
+}
+
+// LockoutStatus returns a user's failed login count, lockout and recent
+// login events.
+func (s *UserService) LockoutStatus(ctx context.Context, id uuid.UUID) (*domain.LockoutStatus, error) {
+	u, err := s.dao.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting user: %w", err)
+	}
+	events, err := s.dao.ListLoginEvents(ctx, id, loginEventLimit)
+	if err != nil {
+		return nil, err
+	}
+	st := &domain.LockoutStatus{
+		Locked:           u.Locked(time.Now()),
+		FailedLoginCount: u.FailedLoginCount,
+		Events:           events,
+	}
+	if st.Locked {
+		st.LockedAt, st.LockedUntil = u.LockedAt, u.LockedUntil
+	}
+	return st, nil
+}
+
+// Unlock lifts a user's lockout and clears their failed logins, so the next
+// lockout starts again at the shortest duration.
+func (s *UserService) Unlock(ctx context.Context, id uuid.UUID) error {
+	u, err := s.dao.GetUserByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting user: %w", err)
+	}
+	if err := s.dao.ResetLockout(ctx, id); err != nil {
+		return err
+	}
+	return s.recordLoginEvent(ctx, u, u.Username, domain.LoginEventUnlocked, "")
+}
+
+// recordFailedLogin counts a failed login for a user and locks the account
+// once the lockout threshold is reached. Besides wrong passwords, it counts
+// wrong second factors, which could otherwise be guessed without limit by
+// anyone who knows the password.
+func (s *UserService) recordFailedLogin(ctx context.Context, u *domain.User, reason string) error {
+	now := time.Now()
+	s.countIPFailure(RequestInfoFromContext(ctx).IPAddress, now)
+	if err := s.recordLoginEvent(ctx, u, u.Username, domain.LoginEventFailure, reason); err != nil {
+		return err
+	}
+	if s.lockout.Threshold <= 0 {
+		return nil
+	}
+
+	var windowStart time.Time
+	if s.lockout.Window > 0 {
+		windowStart = now.Add(-s.lockout.Window)
+	}
+	n, err := s.dao.RecordFailedLogin(ctx, u.ID, windowStart)
+	if err != nil {
+		return err
+	}
+	if n < s.lockout.Threshold {
+		return nil
+	}
+	if err := s.dao.LockUser(ctx, u.ID, now.Add(s.lockout.LockDuration(u.LockoutCount+1))); err != nil {
+		return err
+	}
+	return s.recordLoginEvent(ctx, u, u.Username, domain.LoginEventLocked, "")
+}
+
+// verifyPassword checks a user's password under the lockout policy: locked
+// accounts are refused without looking at the password, and failures count
+// towards locking the account and blocking the client. Earlier failures are
+// only cleared by loginSucceeded, once every factor has been checked. A
+// correct password stored in another scheme than the configured one is
+// rehashed. Users that authenticate against an upstream directory are
+// verified by it, see checkPassword.
+func (s *UserService) verifyPassword(ctx context.Context, u *domain.User, pw string) error {
+	if u.Locked(time.Now()) {
+		if err := s.recordLoginEvent(ctx, u, u.Username, domain.LoginEventRejected, domain.LoginReasonAccountLocked); err != nil {
+			return err
+		}
+		return &LockoutError{Err: ErrAccountLocked, Until: *u.LockedUntil}
+	}
+	ok, delegated, err := s.checkPassword(ctx, u, pw)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if err := s.recordFailedLogin(ctx, u, domain.LoginReasonInvalidPassword); err != nil {
+			return err
+		}
+		return ErrInvalidCredentials
+	}
+	if delegated {
+		return nil
+	}
+	return s.upgradeHash(ctx, u, pw)
+}
+
+// passwordMatches reports whether pw matches a stored hash in any supported
+// scheme. Hashes that cannot be verified never match.
+func passwordMatches(hash, pw string) bool {
+	ok, err := password.Verify(hash, pw)
+	return ok && err == nil
+}
+
+// upgradeHash replaces the stored hash of a verified password if it is not
+// in the configured scheme, such as an imported {SSHA} hash or a bcrypt hash
+// of a lower cost. The password does not count as changed.
+func (s *UserService) upgradeHash(ctx context.Context, u *domain.User, pw string) error {
+	if !s.hasher.NeedsRehash(u.PasswordHash) {
+		return nil
+	}
+	hash, err := s.hasher.Hash(pw)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.UpdatePasswordHash(ctx, u.ID, hash); err != nil {
+		return err
+	}
+	u.PasswordHash = hash
+	return nil
+}
+
+// loginSucceeded clears a user's failed logins after a complete login and
+// records it in the audit trail.
+func (s *UserService) loginSucceeded(ctx context.Context, u *domain.User) error {
+	if err := s.clearFailedLogins(ctx, u); err != nil {
+		return err
+	}
+	return recordLoginAudit(ctx, s.dao, u, u.Username, domain.AuditSuccess, "")
+}
+
+// clearFailedLogins clears a user's failed logins once they proved to know
+// their password.
+func (s *UserService) clearFailedLogins(ctx context.Context, u *domain.User) error {
+	if u.FailedLoginCount == 0 && u.LockoutCount == 0 && u.LockedAt == nil {
+		return nil
+	}
+	return s.dao.ResetLockout(ctx, u.ID)
+}
+
+// checkClient refuses logins from an IP address that is blocked after too
+// many failed logins.
+func (s *UserService) checkClient(ctx context.Context, username string) error {
+	ip := RequestInfoFromContext(ctx).IPAddress
+	if ip == "" || s.lockout.IPThreshold <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	f, ok := s.ipFailures[ip]
+	blocked := ok && f.count >= s.lockout.IPThreshold && time.Since(f.since) < s.lockout.Window
+	var until time.Time
+	if blocked {
+		until = f.since.Add(s.lockout.Window)
+	}
+	s.mu.Unlock()
+
+	if !blocked {
+		return nil
+	}
+	if err := s.recordLoginEvent(ctx, nil, username, domain.LoginEventRejected, domain.LoginReasonIPBlocked); err != nil {
+		return err
+	}
+	return &LockoutError{Err: ErrTooManyAttempts, Until: until}
+}
+
+// countIPFailure counts a failed login from an IP address. Counts are kept
+// in memory for one window, which starts at the first failure.
+func (s *UserService) countIPFailure(ip string, now time.Time) {
+	if ip == "" || s.lockout.IPThreshold <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for addr, f := range s.ipFailures {
+		if now.Sub(f.since) >= s.lockout.Window {
+			delete(s.ipFailures, addr)
+		}
+	}
+	f, ok := s.ipFailures[ip]
+	if !ok {
+		f = &ipFailures{since: now}
+		s.ipFailures[ip] = f
+	}
+	f.count++
+}
+
+// recordLoginEvent appends an event to the login audit trail, with the
+// client and, for unlocks, the acting administrator taken from the context.
+// u is nil for unknown usernames. The event is recorded in the audit trail
+// too: failed and refused logins as such, lockouts and unlocks as
+// operations on the user.
+func (s *UserService) recordLoginEvent(ctx context.Context, u *domain.User, username string, typ domain.LoginEventType, reason string) error {
+	info := RequestInfoFromContext(ctx)
+	e := &domain.LoginEvent{
+		Username:  username,
+		Type:      typ,
+		Reason:    reason,
+		Protocol:  info.Protocol,
+		IPAddress: info.IPAddress,
+	}
+	if u != nil {
+		e.UserID = &u.ID
+	}
+	if actor, ok := ActorFromContext(ctx); ok && typ == domain.LoginEventUnlocked {
+		id := actor.UserID
+		if actor.IsServiceAccount() {
+			id = actor.ServiceAccountID
+		}
+		e.ActorID = &id
+	}
+	if err := s.dao.CreateLoginEvent(ctx, e); err != nil {
+		return err
+	}
+
+	switch typ {
+	case domain.LoginEventLocked:
+		return s.audit(ctx, domain.AuditUserLock, u, nil)
+	case domain.LoginEventUnlocked:
+		return s.audit(ctx, domain.AuditUserUnlock, u, nil)
+	default:
+		return recordLoginAudit(ctx, s.dao, u, username, domain.AuditFailure, reason)
+	}
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent/enttest"
+	"github.com/qinzj/claude-demo/internal/password"
+	"github.com/qinzj/claude-demo/internal/totp"
+)
+
+func setupLockoutService(t *testing.T, policy domain.LockoutPolicy) (*UserService, *MFAService, *dao.DAO, *domain.User, context.Context) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	d := dao.New(client)
+	ctx := WithRequestInfo(context.Background(), domain.RequestInfo{Protocol: domain.ProtocolHTTP, IPAddress: "192.0.2.1"})
+	if err := d.AutoMigrate(ctx); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	userSvc := NewUserService(d, policy, password.Hasher{})
+	u, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username:    "bob",
+		DisplayName: "Bob",
+		Email:       "bob@example.com",
+		Password:    "bobpass",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	return userSvc, NewMFAService(d, userSvc, "Test"), d, u, ctx
+}
+
+// assertLockedFor checks that err reports an account lockout ending about d from now.
+func assertLockedFor(t *testing.T, err error, d time.Duration) {
+	t.Helper()
+	var lockErr *LockoutError
+	if !errors.As(err, &lockErr) || !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("expected account lockout, got %v", err)
+	}
+	if left := time.Until(lockErr.Until); left > d || left < d-time.Minute {
+		t.Errorf("locked for %v, want %v", left, d)
+	}
+}
+
+func TestLockoutPolicyLockDuration(t *testing.T) {
+	p := domain.LockoutPolicy{Duration: 15 * time.Minute, MaxDuration: time.Hour}
+	for n, want := range []time.Duration{15 * time.Minute, 30 * time.Minute, time.Hour, time.Hour} {
+		if got := p.LockDuration(n + 1); got != want {
+			t.Errorf("LockDuration(%d) = %v, want %v", n+1, got, want)
+		}
+	}
+
+	p.MaxDuration = 0
+	if got := p.LockDuration(3); got != 15*time.Minute {
+		t.Errorf("without MaxDuration: LockDuration(3) = %v, want %v", got, 15*time.Minute)
+	}
+}
+
+func TestUserServiceLockout(t *testing.T) {
+	policy := domain.LockoutPolicy{Threshold: 3, Duration: 15 * time.Minute, MaxDuration: time.Hour, Window: time.Hour}
+	s, _, d, u, ctx := setupLockoutService(t, policy)
+
+	fail := func(n int) {
+		t.Helper()
+		for range n {
+			if _, err := s.Authenticate(ctx, "bob", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+				t.Fatalf("wrong password: expected ErrInvalidCredentials, got %v", err)
+			}
+		}
+	}
+
+	// A successful login clears earlier failures.
+	fail(2)
+	if _, err := s.Authenticate(ctx, "bob", "bobpass"); err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	fail(2)
+	if _, err := s.Authenticate(ctx, "bob", "bobpass"); err != nil {
+		t.Fatalf("failures were not cleared by the successful login: %v", err)
+	}
+
+	fail(3)
+	_, err := s.Authenticate(ctx, "bob", "bobpass")
+	assertLockedFor(t, err, 15*time.Minute)
+
+	st, err := s.LockoutStatus(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("LockoutStatus: %v", err)
+	}
+	if !st.Locked || st.LockedAt == nil || st.LockedUntil == nil {
+		t.Errorf("status = %+v, want locked", st)
+	}
+	var types []domain.LoginEventType
+	for _, e := range st.Events[:3] {
+		types = append(types, e.Type)
+		if e.Protocol != domain.ProtocolHTTP || e.IPAddress != "192.0.2.1" {
+			t.Errorf("event client = %q %q, want http 192.0.2.1", e.Protocol, e.IPAddress)
+		}
+	}
+	if want := []domain.LoginEventType{domain.LoginEventRejected, domain.LoginEventLocked, domain.LoginEventFailure}; !slices.Equal(types, want) {
+		t.Errorf("latest events = %v, want %v", types, want)
+	}
+
+	// A locked account cannot change its password either.
+	if err := s.ChangePassword(ctx, u.ID, "bobpass", "Another#Pass1"); !errors.Is(err, ErrAccountLocked) {
+		t.Errorf("ChangePassword while locked: expected ErrAccountLocked, got %v", err)
+	}
+
+	actorID := u.ID
+	adminCtx := WithActor(ctx, &domain.Actor{UserID: actorID})
+	if err := s.Unlock(adminCtx, u.ID); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	if _, err := s.Authenticate(ctx, "bob", "bobpass"); err != nil {
+		t.Fatalf("Authenticate after unlock: %v", err)
+	}
+	st, _ = s.LockoutStatus(ctx, u.ID)
+	if st.Locked || st.Events[0].Type != domain.LoginEventUnlocked || st.Events[0].ActorID == nil || *st.Events[0].ActorID != actorID {
+		t.Errorf("after unlock: status = %+v, latest event = %+v", st, st.Events[0])
+	}
+
+	// Each lockout without a successful login in between lasts twice as
+	// long. Let the first one expire early instead of waiting for it.
+	fail(3)
+	if err := d.LockUser(ctx, u.ID, time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("LockUser: %v", err)
+	}
+	fail(3)
+	_, err = s.Authenticate(ctx, "bob", "bobpass")
+	assertLockedFor(t, err, time.Hour)
+}
+
+func TestUserServiceIPBlocking(t *testing.T) {
+	policy := domain.LockoutPolicy{IPThreshold: 3, Window: time.Hour}
+	s, _, _, _, ctx := setupLockoutService(t, policy)
+
+	for _, username := range []string{"bob", "nobody", "someone"} {
+		if _, err := s.Authenticate(ctx, username, "guess"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("%s: expected ErrInvalidCredentials, got %v", username, err)
+		}
+	}
+	if _, err := s.Authenticate(ctx, "bob", "bobpass"); !errors.Is(err, ErrTooManyAttempts) {
+		t.Errorf("blocked client: expected ErrTooManyAttempts, got %v", err)
+	}
+
+	// Other clients are not affected, and without an account threshold the
+	// account itself is not locked.
+	other := WithRequestInfo(ctx, domain.RequestInfo{IPAddress: "192.0.2.2"})
+	if _, err := s.Authenticate(other, "bob", "bobpass"); err != nil {
+		t.Errorf("other client: %v", err)
+	}
+}
+
+func TestMFAServiceOTPFailuresLockAccount(t *testing.T) {
+	policy := domain.LockoutPolicy{Threshold: 3, Duration: 15 * time.Minute, Window: time.Hour}
+	_, s, _, u, ctx := setupLockoutService(t, policy)
+	secret, _ := enrollTOTP(t, s, ctx, u)
+
+	// The password is right every time, so only the codes count.
+	stale, _ := totp.Code(secret, time.Now().Add(-10*totp.Period*time.Second))
+	for range 3 {
+		if _, err := s.AuthenticateWithOTP(ctx, "bob", "bobpass"+stale); !errors.Is(err, ErrInvalidMFACode) {
+			t.Fatalf("stale code: expected ErrInvalidMFACode, got %v", err)
+		}
+	}
+	code, _ := totp.Code(secret, time.Now())
+	_, err := s.AuthenticateWithOTP(ctx, "bob", "bobpass"+code)
+	assertLockedFor(t, err, 15*time.Minute)
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/totp"
+)
+
+var (
+	// ErrInvalidMFACode is returned when a TOTP or recovery code is wrong,
+	// expired or already used.
+	ErrInvalidMFACode = errors.New("invalid mfa code")
+	// ErrMFAEnabled is returned when enrolling a user who already has MFA enabled.
+	ErrMFAEnabled = errors.New("mfa already enabled")
+	// ErrMFANotEnabled is returned when an operation needs an enrolled second factor.
+	ErrMFANotEnabled = errors.New("mfa not enabled")
+	// ErrMFARequired is returned when MFA is mandatory for the user, either
+	// when disabling it or when logging in before enrolling.
+	ErrMFARequired = errors.New("mfa required by group policy")
+)
+
+// recoveryCodeCount is the number of recovery codes issued at a time.
+const recoveryCodeCount = 10
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFAService manages TOTP enrollment and verifies second factors.
+type MFAService struct {
+	dao         *dao.DAO
+	userService *UserService
+	issuer      string
+}
+
+// NewMFAService creates a new MFAService. The issuer names the account in
+// authenticator apps.
+func NewMFAService(d *dao.DAO, userSvc *UserService, issuer string) *MFAService {
+	return &MFAService{dao: d, userService: userSvc, issuer: issuer}
+}
+
+// Status returns the user's MFA setup.
+func (s *MFAService) Status(ctx context.Context, userID uuid.UUID) (*domain.MFAStatus, error) {
+	u, err := s.dao.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	required, err := s.dao.UserRequiresMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	status := &domain.MFAStatus{Enabled: u.MFAEnabled, Required: required}
+	if u.MFAEnabled {
+		if status.RecoveryCodesRemaining, err = s.dao.CountRecoveryCodes(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// Required reports whether a group the user belongs to demands MFA.
+func (s *MFAService) Required(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return s.dao.UserRequiresMFA(ctx, userID)
+}
+
+// BeginEnrollment generates a new TOTP secret for the user. The secret is
+// inactive until confirmed with ConfirmEnrollment; calling BeginEnrollment
+// again replaces it.
+func (s *MFAService) BeginEnrollment(ctx context.Context, userID uuid.UUID) (*domain.TOTPEnrollment, error) {
+	u, err := s.dao.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.MFAEnabled {
+		return nil, ErrMFAEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.dao.SetPendingTOTPSecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+	return &domain.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.issuer, u.Username, secret),
+	}, nil
+}
+
+// ConfirmEnrollment enables MFA once the user proves their authenticator
+// produces valid codes for the pending secret. It returns the recovery
+// codes, which are shown only this once.
+func (s *MFAService) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	u, err := s.dao.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.MFAEnabled {
+		return nil, ErrMFAEnabled
+	}
+	if u.TOTPSecret == "" {
+		return nil, ErrMFANotEnabled
+	}
+	step, ok := totp.Validate(u.TOTPSecret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.dao.EnableMFA(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+	if err := s.userService.audit(ctx, domain.AuditUserMFAEnable, u, mfaEnabledChange(false, true)); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Verify checks a second factor for an enrolled user. The code is either a
+// current TOTP code or an unused recovery code; both are single-use.
+func (s *MFAService) Verify(ctx context.Context, u *domain.User, code string) error {
+	if !u.MFAEnabled {
+		return ErrMFANotEnabled
+	}
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) == totp.Digits {
+		return s.verifyTOTP(ctx, u, code)
+	}
+
+	ok, err := s.dao.UseRecoveryCode(ctx, u.ID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking
+// a current second factor.
+func (s *MFAService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	u, err := s.dao.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Verify(ctx, u, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.dao.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Disable turns off MFA after checking a current second factor. It is
+// refused while a group the user belongs to requires MFA.
+func (s *MFAService) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	u, err := s.dao.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	required, err := s.dao.UserRequiresMFA(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrMFARequired
+	}
+	if err := s.Verify(ctx, u, code); err != nil {
+		return err
+	}
+	if err := s.dao.DisableMFA(ctx, userID); err != nil {
+		return err
+	}
+	return s.userService.audit(ctx, domain.AuditUserMFADisable, u, mfaEnabledChange(true, false))
+}
+
+// Reset removes a user's MFA enrollment, for example after a lost device.
+// If MFA is required, the user enrolls again on their next login.
+func (s *MFAService) Reset(ctx context.Context, userID uuid.UUID) error {
+	u, err := s.dao.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.userService.authorizeUserManagement(ctx, u); err != nil {
+		return err
+	}
+	if err := s.dao.DisableMFA(ctx, userID); err != nil {
+		return err
+	}
+	return s.userService.audit(ctx, domain.AuditUserMFAReset, u, mfaEnabledChange(u.MFAEnabled, false))
+}
+
+// mfaEnabledChange is the audited change of whether a user has MFA enabled.
+// The TOTP secret is never recorded.
+func mfaEnabledChange(old, cur bool) []domain.AuditChange {
+	if old == cur {
+		return nil
+	}
+	return []domain.AuditChange{{Field: "mfa_enabled", Old: old, New: cur}}
+}
+
+// AuthenticateWithOTP verifies a password for clients that cannot prompt
+// for a second factor, such as VPN gateways binding over LDAP. Users with
+// MFA enabled append their current TOTP code to the password; other users
+// send the password alone.
+func (s *MFAService) AuthenticateWithOTP(ctx context.Context, username, credential string) (*domain.User, error) {
+	u, err := s.dao.GetUserByUsername(ctx, username)
+	if err != nil || !u.MFAEnabled {
+		return s.userService.Authenticate(ctx, username, credential)
+	}
+	if len(credential) <= totp.Digits {
+		if err := recordLoginAudit(ctx, s.dao, u, username, domain.AuditFailure, domain.LoginReasonInvalidOTP); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidMFACode
+	}
+
+	split := len(credential) - totp.Digits
+	if u, err = s.userService.authenticate(ctx, username, credential[:split]); err != nil {
+		return nil, err
+	}
+	if err := s.verifyTOTP(ctx, u, credential[split:]); err != nil {
+		if err := s.userService.recordFailedLogin(ctx, u, domain.LoginReasonInvalidOTP); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+	if err := s.userService.loginSucceeded(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (s *MFAService) verifyTOTP(ctx context.Context, u *domain.User, code string) error {
+	step, ok := totp.Validate(u.TOTPSecret, code, time.Now())
+	if !ok {
+		return ErrInvalidMFACode
+	}
+	fresh, err := s.dao.UseTOTPStep(ctx, u.ID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// newRecoveryCodes returns recovery codes formatted as "xxxxx-xxxxx" and
+// the hashes under which they are stored.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	codes = make([]string, recoveryCodeCount)
+	hashes = make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("generating recovery code: %w", err)
+		}
+		c := strings.ToLower(recoveryEncoding.EncodeToString(b))[:10]
+		codes[i] = c[:5] + "-" + c[5:]
+		hashes[i] = hashToken(c)
+	}
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(code, "-", ""))
+}
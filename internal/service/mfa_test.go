@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent/enttest"
+	"github.com/qinzj/claude-demo/internal/password"
+	"github.com/qinzj/claude-demo/internal/totp"
+)
+
+func setupMFAService(t *testing.T) (*MFAService, *AuthService, *dao.DAO, *domain.User, context.Context) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	d := dao.New(client)
+	ctx := context.Background()
+	if err := d.AutoMigrate(ctx); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	userSvc := NewUserService(d, domain.LockoutPolicy{}, password.Hasher{})
+	keySvc := NewKeyService(d, "ES256", 30*24*time.Hour, time.Hour)
+	if _, err := keySvc.RotateIfDue(ctx); err != nil {
+		t.Fatalf("init signing key: %v", err)
+	}
+	mfaSvc := NewMFAService(d, userSvc, "Test")
+	authSvc := NewAuthService(d, userSvc, mfaSvc, keySvc, 15*time.Minute, 24*time.Hour)
+
+	u, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username:    "alice",
+		DisplayName: "Alice",
+		Email:       "alice@example.com",
+		Password:    "alicepass",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	return mfaSvc, authSvc, d, u, ctx
+}
+
+// enrollTOTP enables TOTP for the user and returns its secret and recovery codes.
+func enrollTOTP(t *testing.T, s *MFAService, ctx context.Context, u *domain.User) (string, []string) {
+	t.Helper()
+	enrollment, err := s.BeginEnrollment(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("BeginEnrollment: %v", err)
+	}
+	code, _ := totp.Code(enrollment.Secret, time.Now())
+	codes, err := s.ConfirmEnrollment(ctx, u.ID, code)
+	if err != nil {
+		t.Fatalf("ConfirmEnrollment: %v", err)
+	}
+	return enrollment.Secret, codes
+}
+
+func TestMFAServiceEnrollment(t *testing.T) {
+	s, _, _, u, ctx := setupMFAService(t)
+
+	enrollment, err := s.BeginEnrollment(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("BeginEnrollment: %v", err)
+	}
+	if enrollment.ProvisioningURI == "" || enrollment.Secret == "" {
+		t.Fatalf("unexpected enrollment %+v", enrollment)
+	}
+
+	if _, err := s.ConfirmEnrollment(ctx, u.ID, "000000"); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("wrong code: err = %v, want ErrInvalidMFACode", err)
+	}
+	code, _ := totp.Code(enrollment.Secret, time.Now())
+	codes, err := s.ConfirmEnrollment(ctx, u.ID, code)
+	if err != nil {
+		t.Fatalf("ConfirmEnrollment: %v", err)
+	}
+	if len(codes) != recoveryCodeCount {
+		t.Errorf("got %d recovery codes, want %d", len(codes), recoveryCodeCount)
+	}
+
+	status, err := s.Status(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if !status.Enabled || status.Required || status.RecoveryCodesRemaining != recoveryCodeCount {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	if _, err := s.BeginEnrollment(ctx, u.ID); !errors.Is(err, ErrMFAEnabled) {
+		t.Errorf("re-enroll: err = %v, want ErrMFAEnabled", err)
+	}
+}
+
+func TestMFAServiceVerify(t *testing.T) {
+	s, _, d, u, ctx := setupMFAService(t)
+	secret, codes := enrollTOTP(t, s, ctx, u)
+	u, _ = d.GetUserByID(ctx, u.ID)
+
+	// The code used for enrollment cannot be replayed.
+	code, _ := totp.Code(secret, time.Now())
+	if err := s.Verify(ctx, u, code); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("replayed code: err = %v, want ErrInvalidMFACode", err)
+	}
+	next, _ := totp.Code(secret, time.Now().Add(totp.Period*time.Second))
+	if err := s.Verify(ctx, u, next); err != nil {
+		t.Errorf("next code: %v", err)
+	}
+
+	// Recovery codes work once, with or without the dash.
+	if err := s.Verify(ctx, u, codes[0]); err != nil {
+		t.Errorf("recovery code: %v", err)
+	}
+	if err := s.Verify(ctx, u, codes[0]); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("reused recovery code: err = %v, want ErrInvalidMFACode", err)
+	}
+	if err := s.Verify(ctx, u, "  "+codes[1][:5]+codes[1][6:]); err != nil {
+		t.Errorf("recovery code without dash: %v", err)
+	}
+	if status, _ := s.Status(ctx, u.ID); status.RecoveryCodesRemaining != recoveryCodeCount-2 {
+		t.Errorf("got %d recovery codes left, want %d", status.RecoveryCodesRemaining, recoveryCodeCount-2)
+	}
+
+	fresh, err := s.RegenerateRecoveryCodes(ctx, u.ID, codes[2])
+	if err != nil {
+		t.Fatalf("RegenerateRecoveryCodes: %v", err)
+	}
+	if err := s.Verify(ctx, u, codes[3]); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("old recovery code after regeneration: err = %v, want ErrInvalidMFACode", err)
+	}
+	if err := s.Disable(ctx, u.ID, fresh[0]); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	if status, _ := s.Status(ctx, u.ID); status.Enabled || status.RecoveryCodesRemaining != 0 {
+		t.Errorf("unexpected status after disable %+v", status)
+	}
+}
+
+func TestMFAServiceRequired(t *testing.T) {
+	s, _, d, u, ctx := setupMFAService(t)
+
+	g, err := d.CreateGroup(ctx, "admins", "", nil)
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if err := d.AddMembers(ctx, g.ID, []uuid.UUID{u.ID}); err != nil {
+		t.Fatalf("AddMembers: %v", err)
+	}
+	required := true
+	if _, err := d.UpdateGroup(ctx, g.ID, domain.UpdateGroupInput{RequireMFA: &required}); err != nil {
+		t.Fatalf("UpdateGroup: %v", err)
+	}
+
+	_, codes := enrollTOTP(t, s, ctx, u)
+	if err := s.Disable(ctx, u.ID, codes[0]); !errors.Is(err, ErrMFARequired) {
+		t.Errorf("Disable: err = %v, want ErrMFARequired", err)
+	}
+	// An administrator can still reset it.
+	if err := s.Reset(ctx, u.ID); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	status, _ := s.Status(ctx, u.ID)
+	if status.Enabled || !status.Required {
+		t.Errorf("unexpected status after reset %+v", status)
+	}
+}
+
+func TestAuthServiceLoginMFA(t *testing.T) {
+	s, authSvc, _, u, ctx := setupMFAService(t)
+	secret, _ := enrollTOTP(t, s, ctx, u)
+
+	_, _, err := authSvc.Login(ctx, "alice", "alicepass")
+	var challenge *MFAChallenge
+	if !errors.As(err, &challenge) || challenge.EnrollmentRequired {
+		t.Fatalf("Login: err = %v, want MFA challenge", err)
+	}
+
+	// The challenge token is not an access token.
+	if _, err := authSvc.ValidateToken(ctx, challenge.Token); err == nil {
+		t.Error("challenge token accepted as access token")
+	}
+
+	if _, _, err := authSvc.VerifyMFA(ctx, challenge.Token, "000000"); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("wrong code: err = %v, want ErrInvalidMFACode", err)
+	}
+	code, _ := totp.Code(secret, time.Now().Add(totp.Period*time.Second))
+	tokens, _, err := authSvc.VerifyMFA(ctx, challenge.Token, code)
+	if err != nil {
+		t.Fatalf("VerifyMFA: %v", err)
+	}
+	if _, err := authSvc.ValidateToken(ctx, tokens.AccessToken); err != nil {
+		t.Errorf("access token invalid: %v", err)
+	}
+
+	// A challenge opens at most one session.
+	if _, _, err := authSvc.VerifyMFA(ctx, challenge.Token, code); !errors.Is(err, ErrInvalidMFAChallenge) {
+		t.Errorf("reused challenge: err = %v, want ErrInvalidMFAChallenge", err)
+	}
+
+	// SSO logins need the code too.
+	if _, _, err := authSvc.StartSSOSession(ctx, "alice", "alicepass", ""); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("SSO without code: err = %v, want ErrInvalidMFACode", err)
+	}
+}
+
+func TestAuthServiceMFAChallengeAttempts(t *testing.T) {
+	s, authSvc, _, u, ctx := setupMFAService(t)
+	secret, _ := enrollTOTP(t, s, ctx, u)
+
+	_, _, err := authSvc.Login(ctx, "alice", "alicepass")
+	var challenge *MFAChallenge
+	if !errors.As(err, &challenge) {
+		t.Fatalf("Login: err = %v, want MFA challenge", err)
+	}
+	for i := 0; i < mfaChallengeMaxAttempts; i++ {
+		authSvc.VerifyMFA(ctx, challenge.Token, "000000")
+	}
+	code, _ := totp.Code(secret, time.Now().Add(totp.Period*time.Second))
+	if _, _, err := authSvc.VerifyMFA(ctx, challenge.Token, code); !errors.Is(err, ErrInvalidMFAChallenge) {
+		t.Errorf("err = %v, want ErrInvalidMFAChallenge", err)
+	}
+}
+
+func TestAuthServiceLoginMFAEnrollment(t *testing.T) {
+	_, authSvc, d, u, ctx := setupMFAService(t)
+
+	g, _ := d.CreateGroup(ctx, "admins", "", nil)
+	d.AddMembers(ctx, g.ID, []uuid.UUID{u.ID})
+	required := true
+	d.UpdateGroup(ctx, g.ID, domain.UpdateGroupInput{RequireMFA: &required})
+
+	_, _, err := authSvc.Login(ctx, "alice", "alicepass")
+	var challenge *MFAChallenge
+	if !errors.As(err, &challenge) || !challenge.EnrollmentRequired {
+		t.Fatalf("Login: err = %v, want enrollment challenge", err)
+	}
+	if _, _, err := authSvc.StartSSOSession(ctx, "alice", "alicepass", ""); !errors.Is(err, ErrMFARequired) {
+		t.Errorf("SSO before enrollment: err = %v, want ErrMFARequired", err)
+	}
+
+	enrollment, err := authSvc.BeginMFAEnrollment(ctx, challenge.Token)
+	if err != nil {
+		t.Fatalf("BeginMFAEnrollment: %v", err)
+	}
+	code, _ := totp.Code(enrollment.Secret, time.Now())
+	tokens, _, codes, err := authSvc.ConfirmMFAEnrollment(ctx, challenge.Token, code)
+	if err != nil {
+		t.Fatalf("ConfirmMFAEnrollment: %v", err)
+	}
+	if tokens.AccessToken == "" || len(codes) != recoveryCodeCount {
+		t.Errorf("unexpected result: tokens %+v, %d recovery codes", tokens, len(codes))
+	}
+}
+
+func TestMFAServiceAuthenticateWithOTP(t *testing.T) {
+	s, _, _, u, ctx := setupMFAService(t)
+
+	// Without MFA the password is used as is.
+	if _, err := s.AuthenticateWithOTP(ctx, "alice", "alicepass"); err != nil {
+		t.Fatalf("password only: %v", err)
+	}
+
+	secret, _ := enrollTOTP(t, s, ctx, u)
+	if _, err := s.AuthenticateWithOTP(ctx, "alice", "alicepass"); err == nil {
+		t.Error("password without code accepted")
+	}
+	code, _ := totp.Code(secret, time.Now().Add(totp.Period*time.Second))
+	if _, err := s.AuthenticateWithOTP(ctx, "alice", "wrongpass"+code); err == nil {
+		t.Error("wrong password accepted")
+	}
+	if _, err := s.AuthenticateWithOTP(ctx, "alice", "alicepass"+code); err != nil {
+		t.Errorf("password+code: %v", err)
+	}
+	if _, err := s.AuthenticateWithOTP(ctx, "alice", "alicepass"+code); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("replayed code: err = %v, want ErrInvalidMFACode", err)
+	}
+}
@@ -0,0 +1,470 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+)
+
+// ErrInvalidClient is returned when an OIDC client registration is invalid,
+// or when an authorization request names an unknown client or an
+// unregistered redirect URI. Such requests must not be redirected.
+var ErrInvalidClient = errors.New("invalid oidc client")
+
+// authorizationCodeTTL is how long an authorization code may be exchanged.
+const authorizationCodeTTL = 5 * time.Minute
+
+// accessTokenType is the JWT "typ" of OIDC access tokens (RFC 9068).
+const accessTokenType = "at+jwt"
+
+// OAuthError is an OAuth 2.0 error response (RFC 6749 section 4.1.2.1 and 5.2).
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+func oauthError(code, format string, args ...any) *OAuthError {
+	return &OAuthError{Code: code, Description: fmt.Sprintf(format, args...)}
+}
+
+// OIDCService implements an OpenID Connect provider: the authorization code
+// flow with PKCE, ID tokens, userinfo, and client registration.
+type OIDCService struct {
+	dao    *dao.DAO
+	auth   *AuthService
+	issuer string
+}
+
+// NewOIDCService creates a new OIDCService. The issuer is the provider's
+// external base URL, e.g. "https://id.example.com"; endpoint URLs are
+// derived from it.
+func NewOIDCService(d *dao.DAO, authSvc *AuthService, issuer string) *OIDCService {
+	return &OIDCService{dao: d, auth: authSvc, issuer: strings.TrimSuffix(issuer, "/")}
+}
+
+// Issuer returns the provider's issuer identifier.
+func (s *OIDCService) Issuer() string {
+	return s.issuer
+}
+
+// Discovery returns the OpenID Provider metadata.
+func (s *OIDCService) Discovery() *domain.OIDCDiscovery {
+	return &domain.OIDCDiscovery{
+		Issuer:                            s.issuer,
+		AuthorizationEndpoint:             s.issuer + "/oauth2/authorize",
+		TokenEndpoint:                     s.issuer + "/oauth2/token",
+		UserinfoEndpoint:                  s.issuer + "/oauth2/userinfo",
+		JWKSURI:                           s.issuer + "/.well-known/jwks.json",
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code", "client_credentials"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{s.auth.keys.algorithm},
+		ScopesSupported:                   domain.SupportedScopes,
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported: []string{
+			"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce",
+			"name", "preferred_username", "email", "phone_number", "groups",
+		},
+	}
+}
+
+// CreateClient registers a client. For confidential clients the generated
+// secret is returned; it is stored hashed and cannot be retrieved later.
+func (s *OIDCService) CreateClient(ctx context.Context, input domain.CreateOIDCClientInput) (*domain.OIDCClient, string, error) {
+	if err := validateRedirectURIs(input.RedirectURIs); err != nil {
+		return nil, "", err
+	}
+
+	var secret, secretHash string
+	if !input.Public {
+		var err error
+		if secret, secretHash, err = newOpaqueToken(); err != nil {
+			return nil, "", err
+		}
+	}
+	c, err := s.dao.CreateOIDCClient(ctx, input, secretHash)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.audit(ctx, domain.AuditOIDCClientCreate, c, AuditChanges(nil, c)); err != nil {
+		return nil, "", err
+	}
+	return c, secret, nil
+}
+
+// GetClient retrieves a client by ID.
+func (s *OIDCService) GetClient(ctx context.Context, id uuid.UUID) (*domain.OIDCClient, error) {
+	return s.dao.GetOIDCClient(ctx, id)
+}
+
+// ListClients returns all registered clients.
+func (s *OIDCService) ListClients(ctx context.Context) ([]*domain.OIDCClient, error) {
+	return s.dao.ListOIDCClients(ctx)
+}
+
+// UpdateClient updates a client's name and redirect URIs.
+func (s *OIDCService) UpdateClient(ctx context.Context, id uuid.UUID, input domain.UpdateOIDCClientInput) (*domain.OIDCClient, error) {
+	if input.RedirectURIs != nil {
+		if err := validateRedirectURIs(input.RedirectURIs); err != nil {
+			return nil, err
+		}
+	}
+	before, err := s.dao.GetOIDCClient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.dao.UpdateOIDCClient(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditOIDCClientUpdate, c, AuditChanges(before, c)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DeleteClient deletes a client together with its pending codes and consents.
+func (s *OIDCService) DeleteClient(ctx context.Context, id uuid.UUID) error {
+	c, err := s.dao.GetOIDCClient(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.DeleteOIDCClient(ctx, id); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditOIDCClientDelete, c, AuditChanges(c, nil))
+}
+
+// RotateClientSecret replaces a confidential client's secret and returns the
+// new one.
+func (s *OIDCService) RotateClientSecret(ctx context.Context, id uuid.UUID) (string, error) {
+	c, err := s.dao.GetOIDCClient(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if c.Public {
+		return "", fmt.Errorf("%w: public clients have no secret", ErrInvalidClient)
+	}
+	secret, hash, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.dao.UpdateOIDCClientSecret(ctx, id, hash); err != nil {
+		return "", err
+	}
+	if err := s.audit(ctx, domain.AuditOIDCClientSecret, c, []domain.AuditChange{{Field: "client_secret", Redacted: true}}); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// audit records an operation on a client in the audit trail.
+func (s *OIDCService) audit(ctx context.Context, action string, c *domain.OIDCClient, changes []domain.AuditChange) error {
+	return recordAudit(ctx, s.dao, &domain.AuditEvent{
+		Action:     action,
+		TargetType: domain.AuditTargetOIDCClient,
+		TargetID:   c.ID.String(),
+		TargetName: c.Name,
+		Changes:    changes,
+	})
+}
+
+// Authorize validates an authorization request and returns the client and
+// the granted scopes. Errors wrapping ErrInvalidClient must be shown to the
+// user; an *OAuthError is returned to the client via its redirect URI.
+func (s *OIDCService) Authorize(ctx context.Context, req domain.AuthorizeRequest) (*domain.OIDCClient, []string, error) {
+	clientID, err := uuid.Parse(req.ClientID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: unknown client_id", ErrInvalidClient)
+	}
+	client, err := s.dao.GetOIDCClient(ctx, clientID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("%w: unknown client_id", ErrInvalidClient)
+		}
+		return nil, nil, err
+	}
+	if !slices.Contains(client.RedirectURIs, req.RedirectURI) {
+		return nil, nil, fmt.Errorf("%w: redirect_uri is not registered for this client", ErrInvalidClient)
+	}
+
+	if req.ResponseType != "code" {
+		return client, nil, oauthError("unsupported_response_type", "only response_type=code is supported")
+	}
+	if req.CodeChallenge == "" || req.CodeChallengeMethod != "S256" {
+		return client, nil, oauthError("invalid_request", "PKCE with code_challenge_method=S256 is required")
+	}
+	scopes := parseScopes(req.Scope)
+	if !slices.Contains(scopes, domain.ScopeOpenID) {
+		return client, nil, oauthError("invalid_scope", "the openid scope is required")
+	}
+	return client, scopes, nil
+}
+
+// HasConsent reports whether the user has already granted all scopes to the client.
+func (s *OIDCService) HasConsent(ctx context.Context, userID, clientID uuid.UUID, scopes []string) (bool, error) {
+	granted, err := s.dao.GetOIDCConsent(ctx, userID, clientID)
+	if err != nil {
+		return false, err
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(granted, scope) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// SaveConsent records that the user granted the scopes to the client, in
+// addition to any scopes granted before.
+func (s *OIDCService) SaveConsent(ctx context.Context, userID, clientID uuid.UUID, scopes []string) error {
+	granted, err := s.dao.GetOIDCConsent(ctx, userID, clientID)
+	if err != nil {
+		return err
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(granted, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	return s.dao.SaveOIDCConsent(ctx, userID, clientID, granted)
+}
+
+// IssueCode creates an authorization code for a validated request.
+func (s *OIDCService) IssueCode(ctx context.Context, client *domain.OIDCClient, userID uuid.UUID, authTime time.Time, req domain.AuthorizeRequest, scopes []string) (string, error) {
+	code, hash, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	err = s.dao.CreateAuthorizationCode(ctx, hash, domain.AuthorizationCode{
+		ClientID:      client.ID,
+		UserID:        userID,
+		RedirectURI:   req.RedirectURI,
+		Scopes:        scopes,
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      authTime,
+		ExpiresAt:     time.Now().Add(authorizationCodeTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// Exchange redeems an authorization code at the token endpoint. Errors are
+// *OAuthError values except for internal failures.
+func (s *OIDCService) Exchange(ctx context.Context, req domain.TokenRequest) (*domain.TokenResponse, error) {
+	if req.GrantType != "authorization_code" {
+		return nil, oauthError("unsupported_grant_type", "only authorization_code is supported")
+	}
+	client, err := s.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := s.dao.ConsumeAuthorizationCode(ctx, hashToken(req.Code))
+	if err != nil {
+		return nil, oauthError("invalid_grant", "invalid or already used authorization code")
+	}
+	switch {
+	case code.ClientID != client.ID:
+		return nil, oauthError("invalid_grant", "authorization code was issued to another client")
+	case time.Now().After(code.ExpiresAt):
+		return nil, oauthError("invalid_grant", "authorization code has expired")
+	case code.RedirectURI != req.RedirectURI:
+		return nil, oauthError("invalid_grant", "redirect_uri does not match the authorization request")
+	case !verifyPKCE(req.CodeVerifier, code.CodeChallenge):
+		return nil, oauthError("invalid_grant", "code_verifier does not match the code challenge")
+	}
+
+	u, err := s.auth.activeUser(ctx, code.UserID)
+	if err != nil {
+		return nil, oauthError("invalid_grant", "the user is no longer active")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.auth.accessTTL)
+	accessToken, err := s.auth.keys.sign(ctx, &oidcAccessClaims{
+		ClientID: client.ID.String(),
+		Scope:    strings.Join(code.Scopes, " "),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   u.ID.String(),
+			Audience:  jwt.ClaimStrings{s.issuer},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+	}, accessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	idClaims, err := s.userClaims(ctx, u, code.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	idClaims["iss"] = s.issuer
+	idClaims["aud"] = client.ID.String()
+	idClaims["exp"] = expiresAt.Unix()
+	idClaims["iat"] = now.Unix()
+	idClaims["auth_time"] = code.AuthTime.Unix()
+	if code.Nonce != "" {
+		idClaims["nonce"] = code.Nonce
+	}
+	idToken, err := s.auth.keys.sign(ctx, idClaims, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.auth.accessTTL.Seconds()),
+		IDToken:     idToken,
+		Scope:       strings.Join(code.Scopes, " "),
+	}, nil
+}
+
+// UserInfo returns the claims of the user an OIDC access token was issued
+// for, limited to the token's scopes.
+func (s *OIDCService) UserInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	var claims oidcAccessClaims
+	token, err := jwt.ParseWithClaims(accessToken, &claims, s.auth.keys.keyfunc(ctx),
+		jwt.WithValidMethods(validMethods()),
+		jwt.WithIssuer(s.issuer),
+		jwt.WithAudience(s.issuer),
+	)
+	if err != nil || !token.Valid || token.Header["typ"] != accessTokenType {
+		return nil, oauthError("invalid_token", "invalid or expired access token")
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, oauthError("invalid_token", "invalid subject")
+	}
+	u, err := s.auth.activeUser(ctx, userID)
+	if err != nil {
+		return nil, oauthError("invalid_token", "the user is no longer active")
+	}
+	return s.userClaims(ctx, u, parseScopes(claims.Scope))
+}
+
+// userClaims returns the standard claims about a user released for the scopes.
+func (s *OIDCService) userClaims(ctx context.Context, u *domain.User, scopes []string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{"sub": u.ID.String()}
+	if slices.Contains(scopes, domain.ScopeProfile) {
+		claims["name"] = u.DisplayName
+		claims["preferred_username"] = u.Username
+		claims["updated_at"] = u.UpdatedAt.Unix()
+	}
+	if slices.Contains(scopes, domain.ScopeEmail) {
+		claims["email"] = u.Email
+	}
+	if slices.Contains(scopes, domain.ScopePhone) && u.Phone != "" {
+		claims["phone_number"] = u.Phone
+	}
+	if slices.Contains(scopes, domain.ScopeGroups) {
+		groups, err := s.dao.GetUserGroups(ctx, u.ID)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(groups))
+		for i, g := range groups {
+			names[i] = g.Name
+		}
+		sort.Strings(names)
+		claims["groups"] = names
+	}
+	return claims, nil
+}
+
+// authenticateClient checks the client credentials presented at the token
+// endpoint. Public clients must not send a secret.
+func (s *OIDCService) authenticateClient(ctx context.Context, clientID, secret string) (*domain.OIDCClient, error) {
+	id, err := uuid.Parse(clientID)
+	if err != nil {
+		return nil, oauthError("invalid_client", "unknown client")
+	}
+	client, err := s.dao.GetOIDCClient(ctx, id)
+	if err != nil {
+		return nil, oauthError("invalid_client", "unknown client")
+	}
+	if client.Public {
+		if secret != "" {
+			return nil, oauthError("invalid_client", "public clients must not use a client secret")
+		}
+		return client, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(hashToken(secret)), []byte(client.SecretHash)) != 1 {
+		return nil, oauthError("invalid_client", "invalid client credentials")
+	}
+	return client, nil
+}
+
+// oidcAccessClaims are the claims of an OIDC access token. Its audience is
+// the issuer, so it is accepted by userinfo but not by the management API.
+type oidcAccessClaims struct {
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope"`
+	jwt.RegisteredClaims
+}
+
+// parseScopes splits a space-separated scope string, dropping unsupported
+// and duplicate scopes.
+func parseScopes(scope string) []string {
+	var scopes []string
+	for _, sc := range strings.Fields(scope) {
+		if slices.Contains(domain.SupportedScopes, sc) && !slices.Contains(scopes, sc) {
+			scopes = append(scopes, sc)
+		}
+	}
+	return scopes
+}
+
+// verifyPKCE checks an S256 code verifier against the stored challenge.
+func verifyPKCE(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+func validateRedirectURIs(uris []string) error {
+	if len(uris) == 0 {
+		return fmt.Errorf("%w: at least one redirect URI is required", ErrInvalidClient)
+	}
+	for _, raw := range uris {
+		u, err := url.Parse(raw)
+		if err != nil || !u.IsAbs() || u.Fragment != "" {
+			return fmt.Errorf("%w: redirect URI %q must be an absolute URI without fragment", ErrInvalidClient, raw)
+		}
+	}
+	return nil
+}
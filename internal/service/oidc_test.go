@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent/enttest"
+	"github.com/qinzj/claude-demo/internal/password"
+)
+
+const (
+	testIssuer      = "https://sso.example.com"
+	testRedirectURI = "https://app.example.com/callback"
+	testVerifier    = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+func setupOIDCService(t *testing.T) (*OIDCService, *dao.DAO, *domain.User, context.Context) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	d := dao.New(client)
+	ctx := context.Background()
+	if err := d.AutoMigrate(ctx); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	userSvc := NewUserService(d, domain.LockoutPolicy{}, password.Hasher{})
+	keySvc := NewKeyService(d, "ES256", 30*24*time.Hour, time.Hour)
+	if _, err := keySvc.RotateIfDue(ctx); err != nil {
+		t.Fatalf("init signing key: %v", err)
+	}
+	authSvc := NewAuthService(d, userSvc, NewMFAService(d, userSvc, "Test"), keySvc, 15*time.Minute, 24*time.Hour)
+
+	u, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username:    "alice",
+		DisplayName: "Alice",
+		Email:       "alice@example.com",
+		Password:    "alicepass",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	return NewOIDCService(d, authSvc, testIssuer), d, u, ctx
+}
+
+func testChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// authorizeCode runs Authorize and IssueCode for the client and returns the code.
+func authorizeCode(t *testing.T, s *OIDCService, ctx context.Context, clientID uuid.UUID, userID uuid.UUID, scope string) string {
+	t.Helper()
+	req := domain.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            clientID.String(),
+		RedirectURI:         testRedirectURI,
+		Scope:               scope,
+		Nonce:               "n-0S6_WzA2Mj",
+		CodeChallenge:       testChallenge(testVerifier),
+		CodeChallengeMethod: "S256",
+	}
+	client, scopes, err := s.Authorize(ctx, req)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	code, err := s.IssueCode(ctx, client, userID, time.Now(), req, scopes)
+	if err != nil {
+		t.Fatalf("IssueCode: %v", err)
+	}
+	return code
+}
+
+func TestOIDCServiceAuthorizeValidation(t *testing.T) {
+	s, _, _, ctx := setupOIDCService(t)
+
+	client, _, err := s.CreateClient(ctx, domain.CreateOIDCClientInput{Name: "app", RedirectURIs: []string{testRedirectURI}})
+	if err != nil {
+		t.Fatalf("CreateClient: %v", err)
+	}
+
+	valid := domain.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            client.ID.String(),
+		RedirectURI:         testRedirectURI,
+		Scope:               "openid profile",
+		CodeChallenge:       testChallenge(testVerifier),
+		CodeChallengeMethod: "S256",
+	}
+	if _, scopes, err := s.Authorize(ctx, valid); err != nil || len(scopes) != 2 {
+		t.Fatalf("Authorize(valid) = %v, %v", scopes, err)
+	}
+
+	// Errors about the client or redirect URI must not be redirected.
+	bad := valid
+	bad.RedirectURI = "https://evil.example.com/callback"
+	if _, _, err := s.Authorize(ctx, bad); !errors.Is(err, ErrInvalidClient) {
+		t.Errorf("unregistered redirect_uri: err = %v, want ErrInvalidClient", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*domain.AuthorizeRequest)
+		code   string
+	}{
+		{"missing openid", func(r *domain.AuthorizeRequest) { r.Scope = "profile" }, "invalid_scope"},
+		{"missing PKCE", func(r *domain.AuthorizeRequest) { r.CodeChallenge = "" }, "invalid_request"},
+		{"plain PKCE", func(r *domain.AuthorizeRequest) { r.CodeChallengeMethod = "plain" }, "invalid_request"},
+		{"implicit flow", func(r *domain.AuthorizeRequest) { r.ResponseType = "token" }, "unsupported_response_type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			_, _, err := s.Authorize(ctx, req)
+			var oerr *OAuthError
+			if !errors.As(err, &oerr) || oerr.Code != tt.code {
+				t.Errorf("err = %v, want OAuth error %q", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestOIDCServiceExchange(t *testing.T) {
+	s, d, u, ctx := setupOIDCService(t)
+
+	g, err := d.CreateGroup(ctx, "engineering", "", nil)
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if err := d.AddMembers(ctx, g.ID, []uuid.UUID{u.ID}); err != nil {
+		t.Fatalf("AddMembers: %v", err)
+	}
+
+	client, secret, err := s.CreateClient(ctx, domain.CreateOIDCClientInput{Name: "app", RedirectURIs: []string{testRedirectURI}})
+	if err != nil {
+		t.Fatalf("CreateClient: %v", err)
+	}
+	if secret == "" {
+		t.Fatal("expected a secret for a confidential client")
+	}
+
+	code := authorizeCode(t, s, ctx, client.ID, u.ID, "openid profile email groups")
+	req := domain.TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		ClientID:     client.ID.String(),
+		ClientSecret: "wrong",
+		CodeVerifier: testVerifier,
+	}
+
+	var oerr *OAuthError
+	if _, err := s.Exchange(ctx, req); !errors.As(err, &oerr) || oerr.Code != "invalid_client" {
+		t.Fatalf("wrong secret: err = %v, want invalid_client", err)
+	}
+
+	req.ClientSecret = secret
+	tokens, err := s.Exchange(ctx, req)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	idClaims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(tokens.IDToken, idClaims, s.auth.keys.keyfunc(ctx),
+		jwt.WithIssuer(testIssuer), jwt.WithAudience(client.ID.String())); err != nil {
+		t.Fatalf("parse ID token: %v", err)
+	}
+	if idClaims["sub"] != u.ID.String() || idClaims["nonce"] != "n-0S6_WzA2Mj" || idClaims["email"] != "alice@example.com" {
+		t.Errorf("unexpected ID token claims: %v", idClaims)
+	}
+	if groups, _ := idClaims["groups"].([]any); len(groups) != 1 || groups[0] != "engineering" {
+		t.Errorf("groups = %v, want [engineering]", idClaims["groups"])
+	}
+
+	info, err := s.UserInfo(ctx, tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("UserInfo: %v", err)
+	}
+	if info["preferred_username"] != "alice" {
+		t.Errorf("preferred_username = %v, want alice", info["preferred_username"])
+	}
+
+	// The OIDC access token must not work against the management API.
+	if _, err := s.auth.ValidateToken(ctx, tokens.AccessToken); err == nil {
+		t.Error("expected OIDC access token to be rejected by ValidateToken")
+	}
+
+	// Codes are single use.
+	if _, err := s.Exchange(ctx, req); !errors.As(err, &oerr) || oerr.Code != "invalid_grant" {
+		t.Errorf("reused code: err = %v, want invalid_grant", err)
+	}
+}
+
+func TestOIDCServiceExchangePKCE(t *testing.T) {
+	s, _, u, ctx := setupOIDCService(t)
+
+	client, secret, err := s.CreateClient(ctx, domain.CreateOIDCClientInput{Name: "spa", RedirectURIs: []string{testRedirectURI}, Public: true})
+	if err != nil {
+		t.Fatalf("CreateClient: %v", err)
+	}
+	if secret != "" {
+		t.Error("public clients must not receive a secret")
+	}
+
+	code := authorizeCode(t, s, ctx, client.ID, u.ID, "openid")
+	req := domain.TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		ClientID:     client.ID.String(),
+		CodeVerifier: "wrong-verifier-wrong-verifier-wrong-verifier",
+	}
+	var oerr *OAuthError
+	if _, err := s.Exchange(ctx, req); !errors.As(err, &oerr) || oerr.Code != "invalid_grant" {
+		t.Errorf("wrong verifier: err = %v, want invalid_grant", err)
+	}
+
+	// A failed exchange still consumes the code.
+	req.CodeVerifier = testVerifier
+	if _, err := s.Exchange(ctx, req); !errors.As(err, &oerr) || oerr.Code != "invalid_grant" {
+		t.Errorf("consumed code: err = %v, want invalid_grant", err)
+	}
+
+	code = authorizeCode(t, s, ctx, client.ID, u.ID, "openid")
+	req.Code = code
+	if _, err := s.Exchange(ctx, req); err != nil {
+		t.Errorf("Exchange: %v", err)
+	}
+}
+
+func TestOIDCServiceConsent(t *testing.T) {
+	s, _, u, ctx := setupOIDCService(t)
+
+	client, _, err := s.CreateClient(ctx, domain.CreateOIDCClientInput{Name: "app", RedirectURIs: []string{testRedirectURI}})
+	if err != nil {
+		t.Fatalf("CreateClient: %v", err)
+	}
+
+	if ok, err := s.HasConsent(ctx, u.ID, client.ID, []string{"openid"}); err != nil || ok {
+		t.Fatalf("HasConsent before consent = %v, %v", ok, err)
+	}
+	if err := s.SaveConsent(ctx, u.ID, client.ID, []string{"openid", "profile"}); err != nil {
+		t.Fatalf("SaveConsent: %v", err)
+	}
+	if ok, _ := s.HasConsent(ctx, u.ID, client.ID, []string{"openid"}); !ok {
+		t.Error("expected consent for a subset of granted scopes")
+	}
+	if ok, _ := s.HasConsent(ctx, u.ID, client.ID, []string{"openid", "email"}); ok {
+		t.Error("expected no consent for a new scope")
+	}
+
+	// Granting a new scope keeps the earlier grants.
+	if err := s.SaveConsent(ctx, u.ID, client.ID, []string{"openid", "email"}); err != nil {
+		t.Fatalf("SaveConsent: %v", err)
+	}
+	if ok, _ := s.HasConsent(ctx, u.ID, client.ID, []string{"profile", "email"}); !ok {
+		t.Error("expected consent to accumulate scopes")
+	}
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/ldap/upstream"
+)
+
+// ErrPasswordManagedUpstream is returned when a user whose password is
+// verified by an upstream directory tries to change it here.
+var ErrPasswordManagedUpstream = errors.New("password is managed by an upstream directory")
+
+// PassThroughService verifies the passwords of the users that, by the
+// pass_through settings of the directory sources, authenticate against an
+// upstream directory, by binding to it as them. The user's DN is the one
+// recorded by synchronization, or else found by searching the directory for
+// the username.
+type PassThroughService struct {
+	dao     *dao.DAO
+	sources []domain.DirectorySource
+	dial    func(*domain.DirectorySource) (directory, error)
+	bind    func(src *domain.DirectorySource, dn, password string) error
+}
+
+// NewPassThroughService creates a new PassThroughService for the given
+// sources.
+func NewPassThroughService(d *dao.DAO, sources []domain.DirectorySource) *PassThroughService {
+	return &PassThroughService{dao: d, sources: sources, dial: dialDirectory, bind: bindDirectory}
+}
+
+// bindDirectory verifies a password by binding to a source's directory.
+func bindDirectory(src *domain.DirectorySource, dn, password string) error {
+	return upstream.Authenticate(upstream.Config{
+		URL:                src.URL,
+		StartTLS:           src.StartTLS,
+		InsecureSkipVerify: src.InsecureSkipVerify,
+		Timeout:            src.Timeout,
+	}, dn, password)
+}
+
+// Source returns the source a user authenticates against, or nil if the
+// user's password is verified against the stored hash. The first source
+// whose pass_through settings include the user wins.
+func (s *PassThroughService) Source(ctx context.Context, u *domain.User) (*domain.DirectorySource, error) {
+	var groups []*domain.Group
+	var link *domain.DirectoryObject
+	var loaded bool
+	for i := range s.sources {
+		src := &s.sources[i]
+		pt := src.PassThrough
+		if !pt.Enabled() {
+			continue
+		}
+		if slices.ContainsFunc(pt.Users, func(name string) bool { return strings.EqualFold(name, u.Username) }) {
+			return src, nil
+		}
+		if !loaded {
+			var err error
+			if groups, err = s.dao.GetUserGroups(ctx, u.ID); err != nil {
+				return nil, err
+			}
+			if link, err = s.dao.DirectoryObjectByLocalID(ctx, domain.DirectoryUser, u.ID); err != nil && !ent.IsNotFound(err) {
+				return nil, err
+			}
+			loaded = true
+		}
+		for _, g := range groups {
+			if slices.ContainsFunc(pt.Groups, func(name string) bool { return strings.EqualFold(name, g.Name) }) {
+				return src, nil
+			}
+		}
+		if pt.SynchronizedUsers && link != nil && link.Source == src.Name {
+			return src, nil
+		}
+	}
+	return nil, nil
+}
+
+// verify reports whether the upstream directory accepts a user's password.
+// It returns ErrDirectoryUnreachable if the directory cannot be asked.
+func (s *PassThroughService) verify(ctx context.Context, src *domain.DirectorySource, u *domain.User, password string) (bool, error) {
+	dn, err := s.userDN(ctx, src, u)
+	if err != nil {
+		return false, err
+	}
+	if dn == "" {
+		return false, nil
+	}
+	err = s.bind(src, dn, password)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, upstream.ErrInvalidCredentials):
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: %v", ErrDirectoryUnreachable, err)
+	}
+}
+
+// userDN returns the DN of a user's entry in the directory: the one
+// recorded when the user was last synchronized from it, or else the DN of
+// the only user entry with the username. It returns "" if there is none.
+func (s *PassThroughService) userDN(ctx context.Context, src *domain.DirectorySource, u *domain.User) (string, error) {
+	link, err := s.dao.DirectoryObjectByLocalID(ctx, domain.DirectoryUser, u.ID)
+	if err != nil && !ent.IsNotFound(err) {
+		return "", err
+	}
+	if link != nil && link.Source == src.Name && link.DN != "" {
+		return link.DN, nil
+	}
+
+	conn, err := s.dial(src)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDirectoryUnreachable, err)
+	}
+	defer func() { _ = conn.Close() }()
+	um := src.Users
+	filter := "(&" + parenthesize(um.Filter) + "(" + um.Username + "=" + ldap.EscapeFilter(u.Username) + "))"
+	entries, err := conn.Search(um.BaseDN, filter, []string{um.Username})
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDirectoryUnreachable, err)
+	}
+	switch len(entries) {
+	case 0:
+		return "", nil
+	case 1:
+		return entries[0].DN, nil
+	default:
+		return "", fmt.Errorf("%d entries of source %s have username %s", len(entries), src.Name, u.Username)
+	}
+}
+
+// UsePassThrough makes the service verify the passwords of the users that
+// authenticate against an upstream directory with p.
+func (s *UserService) UsePassThrough(p *PassThroughService) {
+	s.passThrough = p
+}
+
+// passThroughSource returns the source a user authenticates against, or
+// nil.
+func (s *UserService) passThroughSource(ctx context.Context, u *domain.User) (*domain.DirectorySource, error) {
+	if s.passThrough == nil {
+		return nil, nil
+	}
+	return s.passThrough.Source(ctx, u)
+}
+
+// checkPassword reports whether a password is the user's: by asking the
+// upstream directory the user authenticates against, if any, and otherwise
+// by comparing it with the stored hash. Passwords verified upstream are
+// captured if the source says so. delegated reports the former.
+func (s *UserService) checkPassword(ctx context.Context, u *domain.User, pw string) (ok, delegated bool, err error) {
+	src, err := s.passThroughSource(ctx, u)
+	if err != nil {
+		return false, false, err
+	}
+	if src == nil {
+		return passwordMatches(u.PasswordHash, pw), false, nil
+	}
+	if ok, err = s.passThrough.verify(ctx, src, u, pw); !ok || err != nil {
+		return ok, true, err
+	}
+	if src.PassThrough.CapturePassword {
+		err = s.capturePassword(ctx, u, pw)
+	}
+	return true, true, err
+}
+
+// capturePassword stores a password verified upstream, hashed, unless the
+// stored hash already matches it. Like upgradeHash, the password does not
+// count as changed.
+func (s *UserService) capturePassword(ctx context.Context, u *domain.User, pw string) error {
+	if passwordMatches(u.PasswordHash, pw) {
+		return s.upgradeHash(ctx, u, pw)
+	}
+	hash, err := s.hasher.Hash(pw)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.UpdatePasswordHash(ctx, u.ID, hash); err != nil {
+		return err
+	}
+	u.PasswordHash = hash
+	return nil
+}
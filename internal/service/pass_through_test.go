@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ldap/upstream"
+	"github.com/qinzj/claude-demo/internal/password"
+)
+
+// fakeBinds is a stand-in for binding to an upstream directory: binds
+// succeed with the password given for the DN.
+type fakeBinds struct {
+	passwords map[string]string
+	err       error
+	dns       []string
+}
+
+func (f *fakeBinds) bind(_ *domain.DirectorySource, dn, pw string) error {
+	f.dns = append(f.dns, dn)
+	if f.err != nil {
+		return f.err
+	}
+	if pw == "" || f.passwords[dn] != pw {
+		return upstream.ErrInvalidCredentials
+	}
+	return nil
+}
+
+func TestPassThrough(t *testing.T) {
+	src := testDirectorySource()
+	src.PassThrough = domain.DirectoryPassThrough{
+		Users:             []string{"Listed"},
+		Groups:            []string{"legacy"},
+		SynchronizedUsers: true,
+	}
+	sync, dir, userSvc, groupSvc := setupDirectorySyncService(t, src)
+	ctx := context.Background()
+	pt := NewPassThroughService(sync.dao, []domain.DirectorySource{src})
+	binds := &fakeBinds{passwords: map[string]string{
+		"uid=synced,ou=people,dc=example,dc=com": "upstream-synced",
+		"uid=listed,ou=people,dc=example,dc=com": "upstream-listed",
+		"uid=member,ou=people,dc=example,dc=com": "upstream-member",
+	}}
+	pt.dial = func(*domain.DirectorySource) (directory, error) { return dir, nil }
+	pt.bind = binds.bind
+	userSvc.UsePassThrough(pt)
+
+	dir.addUser("id-synced", "synced", "synced@example.com", nil)
+	if _, err := sync.Sync(ctx, "corp", false); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	// Entries of users that are not synchronized are found by username.
+	dir.addUser("id-listed", "listed", "listed@example.com", nil)
+	dir.addUser("id-member", "member", "member@example.com", nil)
+	create := func(username string) *domain.User {
+		u, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+			Username: username, DisplayName: username, Email: username + "@local", Password: "Local-pass-1",
+		})
+		if err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+		return u
+	}
+	create("listed")
+	member := create("member")
+	create("local")
+	g, err := groupSvc.CreateGroup(ctx, domain.CreateGroupInput{Name: "legacy"})
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if err := groupSvc.AddMembers(ctx, g.ID, []uuid.UUID{member.ID}); err != nil {
+		t.Fatalf("AddMembers: %v", err)
+	}
+
+	for _, tc := range []struct{ username, password string }{
+		{"synced", "upstream-synced"},
+		{"listed", "upstream-listed"},
+		{"member", "upstream-member"},
+		{"local", "Local-pass-1"},
+	} {
+		if _, err := userSvc.Authenticate(ctx, tc.username, tc.password); err != nil {
+			t.Errorf("Authenticate(%s): %v", tc.username, err)
+		}
+	}
+	if len(binds.dns) != 3 || binds.dns[0] != "uid=synced,ou=people,dc=example,dc=com" {
+		t.Errorf("binds = %v", binds.dns)
+	}
+	if _, err := userSvc.Authenticate(ctx, "listed", "Local-pass-1"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("local password of a pass-through user: err = %v", err)
+	}
+	if _, err := userSvc.Authenticate(ctx, "listed", ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("empty password: err = %v", err)
+	}
+
+	// An unreachable directory is not a wrong password.
+	binds.err = errors.New("connection refused")
+	if _, err := userSvc.Authenticate(ctx, "synced", "upstream-synced"); !errors.Is(err, ErrDirectoryUnreachable) {
+		t.Errorf("unreachable directory: err = %v", err)
+	}
+	binds.err = nil
+
+	listed, _ := userSvc.GetUserByUsername(ctx, "listed")
+	if err := userSvc.ChangePassword(ctx, listed.ID, "upstream-listed", "New-pass-123"); !errors.Is(err, ErrPasswordManagedUpstream) {
+		t.Errorf("ChangePassword: err = %v", err)
+	}
+	if err := userSvc.ResetPassword(ctx, listed.ID, "New-pass-123", false); !errors.Is(err, ErrPasswordManagedUpstream) {
+		t.Errorf("ResetPassword: err = %v", err)
+	}
+	sender := &fakeSender{}
+	resets := NewPasswordResetService(sync.dao, userSvc, sender, nil, testResetPolicy)
+	go resets.Run(t.Context())
+	if err := resets.SendResetLink(ctx, listed.ID); !errors.Is(err, ErrPasswordManagedUpstream) {
+		t.Errorf("SendResetLink: err = %v", err)
+	}
+	requestReset(t, resets, ctx, "listed")
+	if len(sender.sent) != 0 {
+		t.Errorf("sent = %+v", sender.sent)
+	}
+	st, err := userSvc.PasswordStatus(ctx, listed)
+	if err != nil || st.PassThrough != "corp" || st.ExpiresAt != nil {
+		t.Errorf("PasswordStatus = %+v, %v", st, err)
+	}
+
+	// Captured passwords keep working once pass-through is turned off.
+	pt.sources[0].PassThrough.CapturePassword = true
+	if _, err := userSvc.Authenticate(ctx, "synced", "upstream-synced"); err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	synced, _ := userSvc.GetUserByUsername(ctx, "synced")
+	if ok, _ := password.Verify(synced.PasswordHash, "upstream-synced"); !ok {
+		t.Fatal("password not captured")
+	}
+	pt.sources[0].PassThrough = domain.DirectoryPassThrough{}
+	if _, err := userSvc.Authenticate(ctx, "synced", "upstream-synced"); err != nil {
+		t.Errorf("captured password: %v", err)
+	}
+}
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+)
+
+// ErrInvalidPasswordPolicy is returned when a password policy definition
+// fails validation or the built-in policy would be deleted or renamed.
+var ErrInvalidPasswordPolicy = errors.New("invalid password policy")
+
+// ErrPasswordPolicy is wrapped by every *PasswordPolicyError.
+var ErrPasswordPolicy = errors.New("password does not meet policy")
+
+// PasswordPolicyError lists the ways a new password fails the policies
+// that apply to its user.
+type PasswordPolicyError struct {
+	Violations []domain.PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Message
+	}
+	return ErrPasswordPolicy.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrPasswordPolicy
+}
+
+// Has reports whether the error contains a violation with the given code.
+func (e *PasswordPolicyError) Has(code string) bool {
+	for _, v := range e.Violations {
+		if v.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultPasswordRules seed the built-in policy.
+var defaultPasswordRules = domain.PasswordRules{MinLength: 8}
+
+// Limits on policy settings, to keep history checks and dates sane.
+const (
+	maxPasswordMinLength    = 128
+	maxPasswordHistoryCount = 24
+)
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+// commonPasswords holds well-known passwords rejected by the dictionary check.
+var commonPasswords = func() map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(commonPasswordList) {
+		m[w] = true
+	}
+	return m
+}()
+
+// PasswordPolicyService manages password policies and their group assignments.
+type PasswordPolicyService struct {
+	dao *dao.DAO
+}
+
+// NewPasswordPolicyService creates a new PasswordPolicyService.
+func NewPasswordPolicyService(d *dao.DAO) *PasswordPolicyService {
+	return &PasswordPolicyService{dao: d}
+}
+
+// EnsureDefaultPolicy creates the built-in default policy if it does not exist yet.
+func (s *PasswordPolicyService) EnsureDefaultPolicy(ctx context.Context) (*domain.PasswordPolicy, error) {
+	p, err := s.dao.GetPasswordPolicyByName(ctx, domain.DefaultPasswordPolicyName)
+	if err == nil {
+		return p, nil
+	}
+	if !ent.IsNotFound(err) {
+		return nil, err
+	}
+	return s.dao.CreatePasswordPolicy(ctx, domain.CreatePasswordPolicyInput{
+		Name:        domain.DefaultPasswordPolicyName,
+		Description: "Applies to every user",
+		Rules:       defaultPasswordRules,
+	}, true)
+}
+
+// CreatePolicy creates a password policy after validating its rules.
+func (s *PasswordPolicyService) CreatePolicy(ctx context.Context, input domain.CreatePasswordPolicyInput) (*domain.PasswordPolicy, error) {
+	if err := validatePasswordRules(input.Rules); err != nil {
+		return nil, err
+	}
+	p, err := s.dao.CreatePasswordPolicy(ctx, input, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditPasswordPolicyCreate, p, AuditChanges(nil, p)); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GetPolicy retrieves a password policy by ID with its groups.
+func (s *PasswordPolicyService) GetPolicy(ctx context.Context, id uuid.UUID) (*domain.PasswordPolicy, error) {
+	return s.dao.GetPasswordPolicyByID(ctx, id)
+}
+
+// ListPolicies returns all password policies.
+func (s *PasswordPolicyService) ListPolicies(ctx context.Context) ([]*domain.PasswordPolicy, error) {
+	return s.dao.ListPasswordPolicies(ctx)
+}
+
+// UpdatePolicy updates a password policy. The rules of the built-in policy
+// may be changed, but not its name.
+func (s *PasswordPolicyService) UpdatePolicy(ctx context.Context, id uuid.UUID, input domain.UpdatePasswordPolicyInput) (*domain.PasswordPolicy, error) {
+	p, err := s.dao.GetPasswordPolicyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Builtin && input.Name != nil && *input.Name != p.Name {
+		return nil, fmt.Errorf("%w: built-in policy %q cannot be renamed", ErrInvalidPasswordPolicy, p.Name)
+	}
+	if err := validatePasswordRules(input.Apply(p.PasswordRules)); err != nil {
+		return nil, err
+	}
+	updated, err := s.dao.UpdatePasswordPolicy(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditPasswordPolicyUpdate, updated, AuditChanges(p, updated)); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeletePolicy deletes a password policy. The built-in policy cannot be deleted.
+func (s *PasswordPolicyService) DeletePolicy(ctx context.Context, id uuid.UUID) error {
+	p, err := s.dao.GetPasswordPolicyByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if p.Builtin {
+		return fmt.Errorf("%w: built-in policy %q cannot be deleted", ErrInvalidPasswordPolicy, p.Name)
+	}
+	if err := s.dao.DeletePasswordPolicy(ctx, id); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditPasswordPolicyDelete, p, AuditChanges(p, nil))
+}
+
+// AssignGroups applies a password policy to the direct members of groups.
+func (s *PasswordPolicyService) AssignGroups(ctx context.Context, policyID uuid.UUID, groupIDs []uuid.UUID) error {
+	p, err := s.dao.GetPasswordPolicyByID(ctx, policyID)
+	if err != nil {
+		return err
+	}
+	if p.Builtin {
+		return fmt.Errorf("%w: built-in policy %q already applies to every user", ErrInvalidPasswordPolicy, p.Name)
+	}
+	if err := s.dao.AddPasswordPolicyGroups(ctx, policyID, groupIDs); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditPasswordPolicyAssign, p, []domain.AuditChange{{Field: "groups", New: groupIDs}})
+}
+
+// UnassignGroup stops applying a password policy to a group.
+func (s *PasswordPolicyService) UnassignGroup(ctx context.Context, policyID, groupID uuid.UUID) error {
+	if err := s.dao.RemovePasswordPolicyGroup(ctx, policyID, groupID); err != nil {
+		return err
+	}
+	p, err := s.dao.GetPasswordPolicyByID(ctx, policyID)
+	if err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditPasswordPolicyUnassign, p, []domain.AuditChange{{Field: "groups", Old: []uuid.UUID{groupID}}})
+}
+
+// audit records an operation on a password policy in the audit trail.
+func (s *PasswordPolicyService) audit(ctx context.Context, action string, p *domain.PasswordPolicy, changes []domain.AuditChange) error {
+	return recordAudit(ctx, s.dao, &domain.AuditEvent{
+		Action:     action,
+		TargetType: domain.AuditTargetPasswordPolicy,
+		TargetID:   p.ID.String(),
+		TargetName: p.Name,
+		Changes:    changes,
+	})
+}
+
+// validatePasswordRules checks that policy settings are within bounds.
+func validatePasswordRules(r domain.PasswordRules) error {
+	switch {
+	case r.MinLength < 1 || r.MinLength > maxPasswordMinLength:
+		return fmt.Errorf("%w: min_length must be between 1 and %d", ErrInvalidPasswordPolicy, maxPasswordMinLength)
+	case r.HistoryCount < 0 || r.HistoryCount > maxPasswordHistoryCount:
+		return fmt.Errorf("%w: history_count must be between 0 and %d", ErrInvalidPasswordPolicy, maxPasswordHistoryCount)
+	case r.MinAgeHours < 0 || r.MaxAgeDays < 0:
+		return fmt.Errorf("%w: ages must not be negative", ErrInvalidPasswordPolicy)
+	case r.MaxAgeDays > 0 && r.MinAgeHours >= r.MaxAgeDays*24:
+		return fmt.Errorf("%w: min_age_hours must be less than max_age_days", ErrInvalidPasswordPolicy)
+	}
+	return nil
+}
+
+// checkPasswordQuality returns the rules a candidate password breaks,
+// without regard to the user's password history or age. identity holds the
+// username and other personal details the password must not contain.
+func checkPasswordQuality(r domain.PasswordRules, password string, identity ...string) []domain.PasswordViolation {
+	var violations []domain.PasswordViolation
+	add := func(code, msg string) {
+		violations = append(violations, domain.PasswordViolation{Code: code, Message: msg})
+	}
+
+	if n := len([]rune(password)); n < r.MinLength {
+		add(domain.PasswordTooShort, fmt.Sprintf("password must be at least %d characters long", r.MinLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, c := range password {
+		switch {
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsLower(c):
+			lower = true
+		case unicode.IsDigit(c):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	if r.RequireUppercase && !upper {
+		add(domain.PasswordMissingUppercase, "password must contain an uppercase letter")
+	}
+	if r.RequireLowercase && !lower {
+		add(domain.PasswordMissingLowercase, "password must contain a lowercase letter")
+	}
+	if r.RequireDigit && !digit {
+		add(domain.PasswordMissingDigit, "password must contain a digit")
+	}
+	if r.RequireSymbol && !symbol {
+		add(domain.PasswordMissingSymbol, "password must contain a symbol")
+	}
+
+	if r.CheckDictionary && isCommonPassword(password) {
+		add(domain.PasswordInDictionary, "password is too common")
+	}
+	if r.CheckUsername && containsIdentity(password, identity) {
+		add(domain.PasswordContainsUsername, "password must not contain the username or name")
+	}
+	return violations
+}
+
+// isCommonPassword reports whether a password is a well-known one, possibly
+// decorated with leading or trailing digits and symbols or common letter
+// substitutions.
+func isCommonPassword(password string) bool {
+	p := strings.ToLower(password)
+	if commonPasswords[p] {
+		return true
+	}
+	core := strings.TrimFunc(p, func(c rune) bool { return !unicode.IsLetter(c) })
+	if core == "" {
+		return false
+	}
+	return commonPasswords[core] || commonPasswords[unleet.Replace(core)]
+}
+
+var unleet = strings.NewReplacer("@", "a", "4", "a", "3", "e", "1", "i", "0", "o", "$", "s", "5", "s", "7", "t")
+
+// containsIdentity reports whether the password contains, forwards or
+// backwards, any of the identity strings or the words they consist of.
+// Fragments shorter than three characters are ignored.
+func containsIdentity(password string, identity []string) bool {
+	p := strings.ToLower(password)
+	rev := reverse(p)
+	for _, id := range identity {
+		id = strings.ToLower(id)
+		if local, _, ok := strings.Cut(id, "@"); ok {
+			id = local
+		}
+		parts := strings.FieldsFunc(id, func(c rune) bool {
+			return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+		})
+		for _, part := range append(parts, id) {
+			if len([]rune(part)) < 3 {
+				continue
+			}
+			if strings.Contains(p, part) || strings.Contains(rev, part) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// generatedPasswordLength is the length of generated passwords, unless a
+// policy demands longer ones.
+const generatedPasswordLength = 16
+
+// passwordAlphabets are the character classes of generated passwords,
+// without characters that are easily mistaken for one another.
+var passwordAlphabets = []string{
+	"ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"abcdefghijkmnopqrstuvwxyz",
+	"23456789",
+	"!#$%&*+-=?@",
+}
+
+// generatePassword returns a random password with characters of every
+// class that satisfies the rules for the given identity.
+func generatePassword(r domain.PasswordRules, identity ...string) (string, error) {
+	all := strings.Join(passwordAlphabets, "")
+	pw := make([]byte, max(r.MinLength, generatedPasswordLength))
+	for {
+		for i := range pw {
+			alphabet := all
+			if i < len(passwordAlphabets) {
+				alphabet = passwordAlphabets[i]
+			}
+			k, err := randIndex(len(alphabet))
+			if err != nil {
+				return "", err
+			}
+			pw[i] = alphabet[k]
+		}
+		for i := len(pw) - 1; i > 0; i-- {
+			j, err := randIndex(i + 1)
+			if err != nil {
+				return "", err
+			}
+			pw[i], pw[j] = pw[j], pw[i]
+		}
+		if len(checkPasswordQuality(r, string(pw), identity...)) == 0 {
+			return string(pw), nil
+		}
+	}
+}
+
+// randIndex returns a uniformly random integer in [0, n).
+func randIndex(n int) (int, error) {
+	k, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("generating password: %w", err)
+	}
+	return int(k.Int64()), nil
+}
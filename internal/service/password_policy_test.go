@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent/enttest"
+	"github.com/qinzj/claude-demo/internal/password"
+)
+
+func setupPasswordPolicyService(t *testing.T) (*PasswordPolicyService, *UserService, *dao.DAO, context.Context) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	d := dao.New(client)
+	ctx := context.Background()
+	if err := d.AutoMigrate(ctx); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	policySvc := NewPasswordPolicyService(d)
+	if _, err := policySvc.EnsureDefaultPolicy(ctx); err != nil {
+		t.Fatalf("EnsureDefaultPolicy: %v", err)
+	}
+	return policySvc, NewUserService(d, domain.LockoutPolicy{}, password.Hasher{}), d, ctx
+}
+
+func violationCodes(err error) []string {
+	var perr *PasswordPolicyError
+	if !errors.As(err, &perr) {
+		return nil
+	}
+	codes := make([]string, len(perr.Violations))
+	for i, v := range perr.Violations {
+		codes[i] = v.Code
+	}
+	return codes
+}
+
+func TestCheckPasswordQuality(t *testing.T) {
+	strict := domain.PasswordRules{
+		MinLength:        10,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		CheckDictionary:  true,
+		CheckUsername:    true,
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		want     []string
+	}{
+		{"strong", "Tr0ub4dor&3x", nil},
+		{"short", "Ab1!xyzq", []string{domain.PasswordTooShort}},
+		{"no classes", "abcdefghijkl", []string{domain.PasswordMissingUppercase, domain.PasswordMissingDigit, domain.PasswordMissingSymbol}},
+		{"decorated dictionary word", "Password123!", []string{domain.PasswordInDictionary}},
+		{"substituted dictionary word", "!!P@ssw0rd99", []string{domain.PasswordInDictionary}},
+		{"username", "xJohnDoe!2024", []string{domain.PasswordContainsUsername}},
+		{"reversed username", "Eodnhoj#2024x", []string{domain.PasswordContainsUsername}},
+		{"email local part", "Jdoe.Secure#9", []string{domain.PasswordContainsUsername}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := checkPasswordQuality(strict, tt.password, "johndoe", "John Doe", "jdoe@example.com")
+			if len(violations) != len(tt.want) {
+				t.Fatalf("violations = %v, want codes %v", violations, tt.want)
+			}
+			for i, v := range violations {
+				if v.Code != tt.want[i] {
+					t.Errorf("violation[%d] = %q, want %q", i, v.Code, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPasswordRulesMerge(t *testing.T) {
+	a := domain.PasswordRules{MinLength: 8, RequireDigit: true, HistoryCount: 3, MaxAgeDays: 90}
+	b := domain.PasswordRules{MinLength: 12, RequireSymbol: true, MinAgeHours: 24, MaxAgeDays: 30}
+
+	got := a.Merge(b)
+	want := domain.PasswordRules{MinLength: 12, RequireDigit: true, RequireSymbol: true, HistoryCount: 3, MinAgeHours: 24, MaxAgeDays: 30}
+	if got != want {
+		t.Errorf("Merge = %+v, want %+v", got, want)
+	}
+	if got := (domain.PasswordRules{MaxAgeDays: 30}).Merge(domain.PasswordRules{}); got.MaxAgeDays != 30 {
+		t.Errorf("MaxAgeDays = %d, want 30: an unset maximum must not lift another policy's", got.MaxAgeDays)
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	rules := domain.PasswordRules{
+		MinLength: 20, RequireUppercase: true, RequireLowercase: true, RequireDigit: true, RequireSymbol: true,
+		CheckDictionary: true, CheckUsername: true,
+	}
+	seen := make(map[string]bool)
+	for range 50 {
+		pw, err := generatePassword(rules, "alice", "Alice Smith")
+		if err != nil {
+			t.Fatalf("generatePassword: %v", err)
+		}
+		if len(pw) != 20 {
+			t.Errorf("len(%q) = %d, want 20", pw, len(pw))
+		}
+		if v := checkPasswordQuality(rules, pw, "alice", "Alice Smith"); len(v) > 0 {
+			t.Errorf("%q violates the rules: %+v", pw, v)
+		}
+		if seen[pw] {
+			t.Errorf("%q was generated twice", pw)
+		}
+		seen[pw] = true
+	}
+	if pw, _ := generatePassword(domain.PasswordRules{}); len(pw) != generatedPasswordLength {
+		t.Errorf("len(%q) = %d, want %d", pw, len(pw), generatedPasswordLength)
+	}
+}
+
+func TestPasswordPolicyServiceBuiltin(t *testing.T) {
+	policySvc, _, _, ctx := setupPasswordPolicyService(t)
+
+	def, err := policySvc.EnsureDefaultPolicy(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultPolicy: %v", err)
+	}
+	if !def.Builtin || def.MinLength != 8 {
+		t.Errorf("default policy = %+v", def)
+	}
+
+	name := "renamed"
+	if _, err := policySvc.UpdatePolicy(ctx, def.ID, domain.UpdatePasswordPolicyInput{Name: &name}); !errors.Is(err, ErrInvalidPasswordPolicy) {
+		t.Errorf("rename err = %v, want ErrInvalidPasswordPolicy", err)
+	}
+	minLength := 10
+	if p, err := policySvc.UpdatePolicy(ctx, def.ID, domain.UpdatePasswordPolicyInput{MinLength: &minLength}); err != nil || p.MinLength != 10 {
+		t.Errorf("UpdatePolicy = %+v, %v", p, err)
+	}
+	if err := policySvc.DeletePolicy(ctx, def.ID); !errors.Is(err, ErrInvalidPasswordPolicy) {
+		t.Errorf("delete err = %v, want ErrInvalidPasswordPolicy", err)
+	}
+
+	if _, err := policySvc.CreatePolicy(ctx, domain.CreatePasswordPolicyInput{
+		Name:  "bad",
+		Rules: domain.PasswordRules{MinLength: 8, MinAgeHours: 48, MaxAgeDays: 1},
+	}); !errors.Is(err, ErrInvalidPasswordPolicy) {
+		t.Errorf("create err = %v, want ErrInvalidPasswordPolicy", err)
+	}
+}
+
+func TestUserServicePasswordPolicy(t *testing.T) {
+	policySvc, userSvc, d, ctx := setupPasswordPolicyService(t)
+
+	if _, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "alice", DisplayName: "Alice", Email: "alice@example.com", Password: "short",
+	}); !errors.Is(err, ErrPasswordPolicy) {
+		t.Fatalf("CreateUser err = %v, want ErrPasswordPolicy", err)
+	}
+	u, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "alice", DisplayName: "Alice", Email: "alice@example.com", Password: "first-password",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	// A group policy tightens the default for its members only.
+	g, _ := d.CreateGroup(ctx, "admins", "", nil)
+	_ = d.AddMembers(ctx, g.ID, []uuid.UUID{u.ID})
+	p, err := policySvc.CreatePolicy(ctx, domain.CreatePasswordPolicyInput{
+		Name:  "admins",
+		Rules: domain.PasswordRules{MinLength: 12, RequireDigit: true, HistoryCount: 2, MinAgeHours: 1, MaxAgeDays: 30},
+	})
+	if err != nil {
+		t.Fatalf("CreatePolicy: %v", err)
+	}
+	if err := policySvc.AssignGroups(ctx, p.ID, []uuid.UUID{g.ID}); err != nil {
+		t.Fatalf("AssignGroups: %v", err)
+	}
+
+	err = userSvc.ChangePassword(ctx, u.ID, "first-password", "too-weak")
+	if codes := violationCodes(err); len(codes) != 3 || codes[0] != domain.PasswordTooShort ||
+		codes[1] != domain.PasswordMissingDigit || codes[2] != domain.PasswordTooRecent {
+		t.Fatalf("ChangePassword codes = %v (err %v)", codes, err)
+	}
+
+	// An administrator reset skips the minimum age and forces a change.
+	if err := userSvc.ResetPassword(ctx, u.ID, "second-password-2", true); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	u, _ = userSvc.GetUser(ctx, u.ID)
+	if required, _ := userSvc.PasswordChangeRequired(ctx, u); !required {
+		t.Error("expected password change to be required after reset")
+	}
+
+	// The forced change is allowed despite the minimum age, but not with a
+	// password from the history.
+	if err := userSvc.ChangePassword(ctx, u.ID, "second-password-2", "first-password-1"); err != nil {
+		t.Fatalf("forced ChangePassword: %v", err)
+	}
+	u, _ = userSvc.GetUser(ctx, u.ID)
+	st, err := userSvc.PasswordStatus(ctx, u)
+	if err != nil {
+		t.Fatalf("PasswordStatus: %v", err)
+	}
+	if st.MustChange || st.Expired || st.ExpiresAt == nil || st.ExpiresAt.Sub(u.PasswordChangedAt) != 30*24*time.Hour {
+		t.Errorf("PasswordStatus = %+v", st)
+	}
+
+	if err := userSvc.ResetPassword(ctx, u.ID, "second-password-2", false); !errors.Is(err, ErrPasswordPolicy) {
+		t.Errorf("reused password err = %v, want ErrPasswordPolicy", err)
+	} else if codes := violationCodes(err); len(codes) != 1 || codes[0] != domain.PasswordReused {
+		t.Errorf("reused password codes = %v", codes)
+	}
+	if err := userSvc.ResetPassword(ctx, u.ID, "third-password-3", false); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if err := userSvc.ResetPassword(ctx, u.ID, "fourth-password-4", false); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	// Only the last two previous passwords are remembered.
+	if err := userSvc.ResetPassword(ctx, u.ID, "second-password-2", false); err != nil {
+		t.Errorf("password beyond history should be accepted: %v", err)
+	}
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/mail"
+)
+
+var (
+	// ErrPasswordResetUnavailable is returned when reset links cannot be
+	// sent because no mail server is configured.
+	ErrPasswordResetUnavailable = errors.New("password reset by email is not configured")
+	// ErrInvalidResetToken is returned for a reset link that is unknown,
+	// expired or already used, or whose user can no longer log in.
+	ErrInvalidResetToken = errors.New("invalid or expired password reset link")
+	// ErrTooManyResetRequests is returned when the client's IP address has
+	// requested too many reset links, or too many links wait to be sent.
+	ErrTooManyResetRequests = errors.New("too many password reset requests")
+	// ErrMailDelivery is returned when the mail server does not accept a
+	// reset email.
+	ErrMailDelivery = errors.New("email delivery failed")
+)
+
+// ipRequests counts the forgot-password requests from one IP address in
+// the window that started at since.
+type ipRequests struct {
+	count int
+	since time.Time
+}
+
+// passwordResetMail is the data of the password reset email template.
+type passwordResetMail struct {
+	DisplayName      string
+	Username         string
+	URL              string
+	ExpiresAt        time.Time
+	ExpiresInMinutes int
+}
+
+// PasswordResetService lets users who forgot their password set a new one
+// through a single-use link sent to their email address.
+type PasswordResetService struct {
+	dao         *dao.DAO
+	userService *UserService
+	sender      mail.Sender
+	templates   *mail.Templates
+	policy      domain.PasswordResetPolicy
+	onError     func(error)
+
+	mu         sync.Mutex
+	ipRequests map[string]*ipRequests
+
+	// requests queues the links requested through RequestReset for Run to
+	// send; pending counts those not yet sent.
+	requests chan resetRequest
+	pending  sync.WaitGroup
+}
+
+// resetQueueSize is how many requested links can wait to be sent; further
+// requests are refused until Run catches up.
+const resetQueueSize = 100
+
+// resetRequest is a link requested through RequestReset.
+type resetRequest struct {
+	ctx   context.Context
+	login string
+}
+
+// NewPasswordResetService creates a new PasswordResetService. Links are
+// rendered with templates and sent through sender; a nil sender disables
+// reset links. New passwords are checked and stored by userSvc.
+func NewPasswordResetService(d *dao.DAO, userSvc *UserService, sender mail.Sender, templates *mail.Templates, policy domain.PasswordResetPolicy) *PasswordResetService {
+	return &PasswordResetService{
+		dao:         d,
+		userService: userSvc,
+		sender:      sender,
+		templates:   templates,
+		policy:      policy,
+		onError:     func(error) {},
+		ipRequests:  make(map[string]*ipRequests),
+		requests:    make(chan resetRequest, resetQueueSize),
+	}
+}
+
+// OnRequestError sets the function told about failures to send a link
+// requested through RequestReset, which does not report them itself.
+func (s *PasswordResetService) OnRequestError(fn func(error)) {
+	s.onError = fn
+}
+
+// RequestReset emails a reset link to the user with the given username or
+// email address. To not reveal which accounts exist, the link is queued for
+// Run to send, so that the answer and its timing are the same for every
+// login: nothing is sent to unknown, disabled or expired users, to users
+// whose password is managed by an upstream directory, or to users who were
+// already sent the most links the policy allows, and failures are only
+// reported to the function set with OnRequestError. Requests are refused
+// with ErrTooManyResetRequests while the queue is full.
+func (s *PasswordResetService) RequestReset(ctx context.Context, login string) error {
+	if s.sender == nil {
+		return ErrPasswordResetUnavailable
+	}
+	if !s.allowClient(RequestInfoFromContext(ctx).IPAddress, time.Now()) {
+		return ErrTooManyResetRequests
+	}
+
+	// Keep the request information, but not the cancellation, of ctx.
+	s.pending.Add(1)
+	select {
+	case s.requests <- resetRequest{ctx: context.WithoutCancel(ctx), login: login}:
+		return nil
+	default:
+		s.pending.Done()
+		return ErrTooManyResetRequests
+	}
+}
+
+// Run sends the links requested through RequestReset, one at a time, until
+// ctx is done. It then sends the links still queued and returns, so stop
+// accepting requests first.
+func (s *PasswordResetService) Run(ctx context.Context) {
+	for {
+		select {
+		case r := <-s.requests:
+			s.sendQueued(r)
+		case <-ctx.Done():
+			for {
+				select {
+				case r := <-s.requests:
+					s.sendQueued(r)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// sendQueued sends a link requested through RequestReset.
+func (s *PasswordResetService) sendQueued(r resetRequest) {
+	defer s.pending.Done()
+	if err := s.sendRequested(r.ctx, r.login); err != nil {
+		s.onError(fmt.Errorf("sending password reset link: %w", err))
+	}
+}
+
+// sendRequested emails a reset link to the user with the given username or
+// email address, if they may be sent one.
+func (s *PasswordResetService) sendRequested(ctx context.Context, login string) error {
+	u, err := s.dao.GetUserByUsername(ctx, login)
+	if ent.IsNotFound(err) && strings.Contains(login, "@") {
+		u, err = s.dao.GetUserByEmail(ctx, login)
+	}
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if u.Status != domain.UserStatusEnabled || u.Expired(time.Now()) {
+		return nil
+	}
+	if src, err := s.userService.passThroughSource(ctx, u); err != nil || src != nil {
+		return err
+	}
+	return s.send(ctx, u, s.policy.UserLimit)
+}
+
+// SendResetLink emails a reset link to a user on an administrator's behalf.
+// The per-user limit does not apply.
+func (s *PasswordResetService) SendResetLink(ctx context.Context, userID uuid.UUID) error {
+	if s.sender == nil {
+		return ErrPasswordResetUnavailable
+	}
+	u, err := s.dao.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("getting user: %w", err)
+	}
+	if u.Status != domain.UserStatusEnabled {
+		return fmt.Errorf("%w: %q", ErrUserDisabled, u.Username)
+	}
+	if u.Expired(time.Now()) {
+		return fmt.Errorf("%w: %q", ErrAccountExpired, u.Username)
+	}
+	src, err := s.userService.passThroughSource(ctx, u)
+	if err != nil {
+		return err
+	}
+	if src != nil {
+		return fmt.Errorf("%w: reset it in %s", ErrPasswordManagedUpstream, src.Name)
+	}
+	return s.send(ctx, u, 0)
+}
+
+// CheckToken returns the user a reset link belongs to, so the reset page
+// can greet them before they choose a password.
+func (s *PasswordResetService) CheckToken(ctx context.Context, token string) (*domain.User, *domain.PasswordResetToken, error) {
+	return s.lookup(ctx, token)
+}
+
+// ResetPassword sets a new password with a reset link, which is used up.
+// The password must satisfy the user's policies; if it does not, the link
+// stays valid for another try. A successful reset lifts any lockout and
+// ends all of the user's sessions.
+func (s *PasswordResetService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	u, t, err := s.lookup(ctx, token)
+	if err != nil {
+		return err
+	}
+	ok, err := s.dao.UsePasswordResetToken(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidResetToken
+	}
+
+	if err := s.userService.ResetPassword(ctx, u.ID, newPassword, false); err != nil {
+		if rerr := s.dao.ReleasePasswordResetToken(ctx, t.ID); rerr != nil {
+			return fmt.Errorf("%w; %v", err, rerr)
+		}
+		return err
+	}
+	if err := s.userService.clearFailedLogins(ctx, u); err != nil {
+		return err
+	}
+	_, err = s.dao.RevokeUserSessions(ctx, u.ID)
+	return err
+}
+
+// lookup finds a usable reset link and its user.
+func (s *PasswordResetService) lookup(ctx context.Context, token string) (*domain.User, *domain.PasswordResetToken, error) {
+	t, err := s.dao.GetPasswordResetToken(ctx, hashToken(token))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil, ErrInvalidResetToken
+		}
+		return nil, nil, err
+	}
+	now := time.Now()
+	if !t.Valid(now) {
+		return nil, nil, ErrInvalidResetToken
+	}
+	u, err := s.dao.GetUserByID(ctx, t.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting user: %w", err)
+	}
+	if u.Status != domain.UserStatusEnabled || u.Expired(now) {
+		return nil, nil, ErrInvalidResetToken
+	}
+	return u, t, nil
+}
+
+// send creates a reset link for the user and emails it. With a positive
+// limit, nothing is sent to a user who was already sent that many links in
+// the policy's window.
+func (s *PasswordResetService) send(ctx context.Context, u *domain.User, limit int) error {
+	link, err := url.Parse(s.policy.URL)
+	if err != nil {
+		return fmt.Errorf("parsing password reset url: %w", err)
+	}
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	expiresAt := now.Add(s.policy.TokenTTL)
+	ip := RequestInfoFromContext(ctx).IPAddress
+	if limit > 0 {
+		created, err := s.dao.CreateLimitedPasswordResetToken(ctx, u.ID, hash, ip, expiresAt, now.Add(-s.policy.Window), limit)
+		if err != nil || !created {
+			return err
+		}
+	} else if err := s.dao.CreatePasswordResetToken(ctx, u.ID, hash, ip, expiresAt); err != nil {
+		return err
+	}
+
+	q := link.Query()
+	q.Set("token", token)
+	link.RawQuery = q.Encode()
+	data := passwordResetMail{
+		DisplayName:      u.DisplayName,
+		Username:         u.Username,
+		URL:              link.String(),
+		ExpiresAt:        expiresAt,
+		ExpiresInMinutes: int(s.policy.TokenTTL.Minutes()),
+	}
+	if data.DisplayName == "" {
+		data.DisplayName = u.Username
+	}
+	msg, err := s.templates.Render(mail.TemplatePasswordReset, u.Email, data)
+	if err != nil {
+		return err
+	}
+	if err := s.sender.Send(ctx, msg); err != nil {
+		return fmt.Errorf("%w: %v", ErrMailDelivery, err)
+	}
+	return nil
+}
+
+// allowClient counts a forgot-password request from an IP address and
+// reports whether it is within the policy's limit. Counts are kept in
+// memory for one window, which starts at the first request.
+func (s *PasswordResetService) allowClient(ip string, now time.Time) bool {
+	if ip == "" || s.policy.IPLimit <= 0 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for addr, r := range s.ipRequests {
+		if now.Sub(r.since) >= s.policy.Window {
+			delete(s.ipRequests, addr)
+		}
+	}
+	r, ok := s.ipRequests[ip]
+	if !ok {
+		r = &ipRequests{since: now}
+		s.ipRequests[ip] = r
+	}
+	if r.count >= s.policy.IPLimit {
+		return false
+	}
+	r.count++
+	return true
+}
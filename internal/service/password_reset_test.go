@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/mail"
+)
+
+// fakeSender records the messages it is asked to send, or fails them with
+// err.
+type fakeSender struct {
+	sent []mail.Message
+	err  error
+}
+
+func (f *fakeSender) Send(_ context.Context, msg mail.Message) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+var resetLinkPattern = regexp.MustCompile(`https://sso\.example\.com/reset-password\?token=\S+`)
+
+// resetToken returns the token of the reset link in the last sent message.
+func (f *fakeSender) resetToken(t *testing.T) string {
+	t.Helper()
+	return f.linkToken(t, resetLinkPattern)
+}
+
+// linkToken returns the token of the link matching pattern in the last sent
+// message.
+func (f *fakeSender) linkToken(t *testing.T, pattern *regexp.Regexp) string {
+	t.Helper()
+	if len(f.sent) == 0 {
+		t.Fatal("no message sent")
+	}
+	link := pattern.FindString(f.sent[len(f.sent)-1].Body)
+	u, err := url.Parse(link)
+	if err != nil || u.Query().Get("token") == "" {
+		t.Fatalf("no link in %q", f.sent[len(f.sent)-1].Body)
+	}
+	return u.Query().Get("token")
+}
+
+var testResetPolicy = domain.PasswordResetPolicy{
+	URL:       "https://sso.example.com/reset-password",
+	TokenTTL:  30 * time.Minute,
+	UserLimit: 3,
+	IPLimit:   10,
+	Window:    time.Hour,
+}
+
+func setupPasswordResetService(t *testing.T, policy domain.PasswordResetPolicy) (*PasswordResetService, *fakeSender, *UserService, *domain.User, context.Context) {
+	t.Helper()
+	userSvc, _, d, u, ctx := setupLockoutService(t, domain.LockoutPolicy{Threshold: 2, Duration: time.Hour, Window: time.Hour})
+	templates, err := mail.LoadTemplates("")
+	if err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	sender := &fakeSender{}
+	svc := NewPasswordResetService(d, userSvc, sender, templates, policy)
+	go svc.Run(t.Context())
+	return svc, sender, userSvc, u, ctx
+}
+
+// requestReset calls RequestReset and waits for the link to be sent.
+func requestReset(t *testing.T, svc *PasswordResetService, ctx context.Context, login string) {
+	t.Helper()
+	if err := svc.RequestReset(ctx, login); err != nil {
+		t.Fatalf("RequestReset(%q): %v", login, err)
+	}
+	svc.pending.Wait()
+}
+
+func TestPasswordReset(t *testing.T) {
+	svc, sender, userSvc, u, ctx := setupPasswordResetService(t, testResetPolicy)
+
+	// Lock the account; a successful reset lifts the lockout.
+	for range 2 {
+		_, _ = userSvc.Authenticate(ctx, "bob", "wrong")
+	}
+
+	requestReset(t, svc, ctx, "bob")
+	if len(sender.sent) != 1 || sender.sent[0].To[0] != "bob@example.com" {
+		t.Fatalf("sent = %+v", sender.sent)
+	}
+	token := sender.resetToken(t)
+
+	got, _, err := svc.CheckToken(ctx, token)
+	if err != nil || got.ID != u.ID {
+		t.Fatalf("CheckToken = %v, %v", got, err)
+	}
+	if err := svc.ResetPassword(ctx, token, "new-secret-1"); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if _, err := userSvc.Authenticate(ctx, "bob", "new-secret-1"); err != nil {
+		t.Errorf("login with new password: %v", err)
+	}
+	if err := svc.ResetPassword(ctx, token, "new-secret-2"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("reusing link: err = %v, want ErrInvalidResetToken", err)
+	}
+}
+
+func TestPasswordResetByEmail(t *testing.T) {
+	svc, sender, _, _, ctx := setupPasswordResetService(t, testResetPolicy)
+	requestReset(t, svc, ctx, "BOB@example.com")
+	if len(sender.sent) != 1 {
+		t.Errorf("sent %d messages, want 1", len(sender.sent))
+	}
+}
+
+func TestPasswordResetSilentlyIgnored(t *testing.T) {
+	policy := testResetPolicy
+	policy.UserLimit = 2
+	svc, sender, userSvc, u, ctx := setupPasswordResetService(t, policy)
+
+	// Unknown users get the same answer as known ones.
+	requestReset(t, svc, ctx, "nobody")
+	for range 3 {
+		if err := svc.RequestReset(ctx, "bob"); err != nil {
+			t.Fatalf("RequestReset: %v", err)
+		}
+	}
+	svc.pending.Wait()
+	if len(sender.sent) != 2 {
+		t.Errorf("sent %d messages, want the user limit of 2", len(sender.sent))
+	}
+
+	if err := userSvc.SetUserStatus(ctx, u.ID, domain.UserStatusDisabled); err != nil {
+		t.Fatalf("SetUserStatus: %v", err)
+	}
+	if err := svc.SendResetLink(ctx, u.ID); !errors.Is(err, ErrUserDisabled) {
+		t.Errorf("SendResetLink to disabled user: err = %v", err)
+	}
+	if _, _, err := svc.CheckToken(ctx, sender.resetToken(t)); !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("link of disabled user: err = %v, want ErrInvalidResetToken", err)
+	}
+}
+
+func TestPasswordResetDeliveryFailure(t *testing.T) {
+	svc, sender, _, _, ctx := setupPasswordResetService(t, testResetPolicy)
+	var reported []error
+	svc.OnRequestError(func(err error) { reported = append(reported, err) })
+	sender.err = errors.New("connection refused")
+
+	// The failure is not revealed to the client, which would tell them the
+	// account exists.
+	requestReset(t, svc, ctx, "bob")
+	requestReset(t, svc, ctx, "nobody")
+	if len(reported) != 1 || !errors.Is(reported[0], ErrMailDelivery) {
+		t.Errorf("reported = %v, want one ErrMailDelivery", reported)
+	}
+}
+
+func TestPasswordResetClientLimit(t *testing.T) {
+	policy := testResetPolicy
+	policy.IPLimit = 3
+	svc, _, _, _, ctx := setupPasswordResetService(t, policy)
+	for range 3 {
+		if err := svc.RequestReset(ctx, "nobody"); err != nil {
+			t.Fatalf("RequestReset: %v", err)
+		}
+	}
+	if err := svc.RequestReset(ctx, "bob"); !errors.Is(err, ErrTooManyResetRequests) {
+		t.Errorf("err = %v, want ErrTooManyResetRequests", err)
+	}
+	other := WithRequestInfo(context.Background(), domain.RequestInfo{IPAddress: "192.0.2.2"})
+	if err := svc.RequestReset(other, "bob"); err != nil {
+		t.Errorf("other client: %v", err)
+	}
+	svc.pending.Wait()
+}
+
+func TestPasswordResetQueue(t *testing.T) {
+	policy := testResetPolicy
+	policy.IPLimit = 0
+	userSvc, _, d, _, ctx := setupLockoutService(t, domain.LockoutPolicy{})
+	templates, err := mail.LoadTemplates("")
+	if err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	sender := &fakeSender{}
+	svc := NewPasswordResetService(d, userSvc, sender, templates, policy)
+
+	// Without Run, requests wait in the queue until it is full.
+	for range resetQueueSize - 1 {
+		if err := svc.RequestReset(ctx, "nobody"); err != nil {
+			t.Fatalf("RequestReset: %v", err)
+		}
+	}
+	if err := svc.RequestReset(ctx, "bob"); err != nil {
+		t.Fatalf("RequestReset: %v", err)
+	}
+	if err := svc.RequestReset(ctx, "bob"); !errors.Is(err, ErrTooManyResetRequests) {
+		t.Errorf("full queue: err = %v, want ErrTooManyResetRequests", err)
+	}
+
+	// Once stopped, Run sends the queued links before returning.
+	stopped, stop := context.WithCancel(ctx)
+	stop()
+	svc.Run(stopped)
+	if len(sender.sent) != 1 || sender.sent[0].To[0] != "bob@example.com" {
+		t.Errorf("sent = %+v, want the queued link", sender.sent)
+	}
+}
+
+func TestPasswordResetPolicyViolation(t *testing.T) {
+	svc, sender, _, u, ctx := setupPasswordResetService(t, testResetPolicy)
+	if _, err := NewPasswordPolicyService(svc.dao).EnsureDefaultPolicy(ctx); err != nil {
+		t.Fatalf("EnsureDefaultPolicy: %v", err)
+	}
+
+	if err := svc.SendResetLink(ctx, u.ID); err != nil {
+		t.Fatalf("SendResetLink: %v", err)
+	}
+	first := sender.resetToken(t)
+	if err := svc.SendResetLink(ctx, u.ID); err != nil {
+		t.Fatalf("SendResetLink: %v", err)
+	}
+	second := sender.resetToken(t)
+
+	var perr *PasswordPolicyError
+	if err := svc.ResetPassword(ctx, second, "short"); !errors.As(err, &perr) {
+		t.Fatalf("weak password: err = %v, want policy error", err)
+	}
+	if err := svc.ResetPassword(ctx, second, "long-enough-1"); err != nil {
+		t.Fatalf("retry after policy violation: %v", err)
+	}
+	// The password change invalidates every other link.
+	if _, _, err := svc.CheckToken(ctx, first); !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("older link: err = %v, want ErrInvalidResetToken", err)
+	}
+}
+
+func TestPasswordResetExpiredLink(t *testing.T) {
+	policy := testResetPolicy
+	policy.TokenTTL = -time.Minute
+	svc, sender, _, _, ctx := setupPasswordResetService(t, policy)
+	requestReset(t, svc, ctx, "bob")
+	if err := svc.ResetPassword(ctx, sender.resetToken(t), "new-secret-1"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("err = %v, want ErrInvalidResetToken", err)
+	}
+}
+
+func TestPasswordResetUnavailable(t *testing.T) {
+	svc, _, _, u, ctx := setupPasswordResetService(t, testResetPolicy)
+	svc.sender = nil
+	if err := svc.RequestReset(ctx, "bob"); !errors.Is(err, ErrPasswordResetUnavailable) {
+		t.Errorf("RequestReset: err = %v", err)
+	}
+	if err := svc.SendResetLink(ctx, u.ID); !errors.Is(err, ErrPasswordResetUnavailable) {
+		t.Errorf("SendResetLink: err = %v", err)
+	}
+}
@@ -0,0 +1,694 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	"github.com/qinzj/claude-demo/internal/scim/client"
+	"github.com/qinzj/claude-demo/internal/scim/filter"
+	"github.com/qinzj/claude-demo/internal/scim/patch"
+)
+
+var (
+	// ErrInvalidConnector is returned when a provisioning connector fails
+	// validation or cannot be synchronized.
+	ErrInvalidConnector = errors.New("invalid connector")
+	// ErrConnectorNotFound is returned for an unknown provisioning
+	// connector.
+	ErrConnectorNotFound = errors.New("connector not found")
+	// ErrConnectorUnreachable is returned when a synchronization stops
+	// because the application did not respond.
+	ErrConnectorUnreachable = errors.New("connector unreachable")
+)
+
+// reservedSCIMAttributes are the core User attributes a connector sets
+// itself, to which extension attributes cannot be mapped.
+var reservedSCIMAttributes = []string{"id", "externalId", "userName", "active", "schemas", "meta", "groups"}
+
+// scimUserSchema describes provisioned users to the patch package, with
+// which mapped attributes are set.
+var scimUserSchema = patch.Schema{
+	ID:          client.SchemaUser,
+	Extensions:  []string{client.SchemaEnterpriseUser},
+	MultiValued: []string{"emails", "phoneNumbers", "ims", "photos", "addresses", "entitlements", "roles", "x509Certificates"},
+}
+
+// ProvisioningService manages provisioning connectors and synchronizes
+// users and groups to their applications. A connector is fully
+// synchronized when created and then every reconcile interval, comparing
+// each resource with the application's; in between, the changes the DAO
+// writes to the outbox trigger an incremental synchronization, which sends
+// only the resources that changed since they were last sent.
+type ProvisioningService struct {
+	dao    *dao.DAO
+	policy domain.ProvisioningPolicy
+	client *http.Client
+
+	// mu serializes synchronizations, so that no connector is synchronized
+	// twice at once.
+	mu sync.Mutex
+}
+
+// NewProvisioningService creates a new ProvisioningService synchronizing
+// according to policy.
+func NewProvisioningService(d *dao.DAO, policy domain.ProvisioningPolicy) *ProvisioningService {
+	return &ProvisioningService{dao: d, policy: policy, client: &http.Client{Timeout: policy.Timeout}}
+}
+
+// audit records an operation on a connector in the audit trail. The status
+// changes with every synchronization and is left out of the changes.
+func (s *ProvisioningService) audit(ctx context.Context, action string, conn *domain.ProvisioningConnector, changes []domain.AuditChange) error {
+	return recordAudit(ctx, s.dao, &domain.AuditEvent{
+		Action:     action,
+		TargetType: domain.AuditTargetConnector,
+		TargetID:   conn.ID.String(),
+		TargetName: conn.Name,
+		Changes:    changes,
+	})
+}
+
+// connectorChanges returns the audited changes between two versions of a
+// connector.
+func connectorChanges(before, after *domain.ProvisioningConnector) []domain.AuditChange {
+	strip := func(c *domain.ProvisioningConnector) *domain.ProvisioningConnector {
+		if c == nil {
+			return nil
+		}
+		copied := *c
+		copied.Status = domain.ProvisioningStatus{}
+		return &copied
+	}
+	return AuditChanges(strip(before), strip(after))
+}
+
+// CreateConnector creates a provisioning connector, which is fully
+// synchronized when the synchronizer next runs.
+func (s *ProvisioningService) CreateConnector(ctx context.Context, input domain.CreateConnectorInput) (*domain.ProvisioningConnector, error) {
+	if err := validateConnectorURL(input.URL); err != nil {
+		return nil, err
+	}
+	if err := s.validateScope(ctx, input.ScopeGroupID); err != nil {
+		return nil, err
+	}
+	if err := s.validateMapping(ctx, input.AttributeMapping); err != nil {
+		return nil, err
+	}
+
+	conn, err := s.dao.CreateConnector(ctx, input)
+	if ent.IsConstraintError(err) {
+		return nil, fmt.Errorf("%w: name %q is taken", ErrInvalidConnector, input.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	changes := connectorChanges(nil, conn)
+	if input.Token != "" {
+		changes = append(changes, domain.AuditChange{Field: "token", Redacted: true})
+	}
+	if err := s.audit(ctx, domain.AuditConnectorCreate, conn, changes); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// GetConnector retrieves a provisioning connector with its status.
+func (s *ProvisioningService) GetConnector(ctx context.Context, id uuid.UUID) (*domain.ProvisioningConnector, error) {
+	conn, err := s.dao.GetConnector(ctx, id)
+	return conn, connectorError(err)
+}
+
+// ListConnectors returns all provisioning connectors with their status.
+func (s *ProvisioningService) ListConnectors(ctx context.Context) ([]*domain.ProvisioningConnector, error) {
+	return s.dao.ListConnectors(ctx)
+}
+
+// UpdateConnector updates a provisioning connector. Any change but of the
+// name makes the next synchronization a full one; a change of URL also
+// forgets the resources provisioned, which are then looked up in the new
+// application by userName and displayName. Disabling a connector stops its
+// synchronization; changes made meanwhile are sent once it is enabled.
+func (s *ProvisioningService) UpdateConnector(ctx context.Context, id uuid.UUID, input domain.UpdateConnectorInput) (*domain.ProvisioningConnector, error) {
+	if input.URL != nil {
+		if err := validateConnectorURL(*input.URL); err != nil {
+			return nil, err
+		}
+	}
+	if !input.ClearScope {
+		if err := s.validateScope(ctx, input.ScopeGroupID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.validateMapping(ctx, input.AttributeMapping); err != nil {
+		return nil, err
+	}
+	before, err := s.GetConnector(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize with synchronizations, which must not record the result of
+	// a synchronization to the old application after the reset.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.dao.UpdateConnector(ctx, id, input); err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, fmt.Errorf("%w: name %q is taken", ErrInvalidConnector, *input.Name)
+		}
+		return nil, err
+	}
+	urlChanged := input.URL != nil && *input.URL != before.URL
+	if urlChanged || input.Token != nil || input.ScopeGroupID != nil || input.ClearScope ||
+		input.SyncGroups != nil || input.AttributeMapping != nil || input.Enabled != nil {
+		if err := s.dao.ResetConnectorSync(ctx, id, urlChanged); err != nil {
+			return nil, err
+		}
+	}
+	conn, err := s.GetConnector(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := connectorChanges(before, conn)
+	if input.Token != nil {
+		changes = append(changes, domain.AuditChange{Field: "token", Redacted: true})
+	}
+	if err := s.audit(ctx, domain.AuditConnectorUpdate, conn, changes); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// DeleteConnector deletes a provisioning connector. The resources it
+// provisioned are left in the application.
+func (s *ProvisioningService) DeleteConnector(ctx context.Context, id uuid.UUID) error {
+	conn, err := s.GetConnector(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.dao.DeleteConnector(ctx, id); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditConnectorDelete, conn, connectorChanges(conn, nil))
+}
+
+// ListObjects returns the objects of a connector, failing ones first.
+func (s *ProvisioningService) ListObjects(ctx context.Context, id uuid.UUID, input domain.ListProvisionedObjectsInput) (*domain.ListResult[domain.ProvisionedObject], error) {
+	switch input.ResourceType {
+	case "", domain.ProvisionedUser, domain.ProvisionedGroup:
+	default:
+		return nil, fmt.Errorf("%w: unknown resource type %q", ErrInvalidConnector, input.ResourceType)
+	}
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+	if _, err := s.GetConnector(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.dao.ListProvisionedObjects(ctx, id, input)
+}
+
+// SyncConnector synchronizes an enabled connector now, fully or only the
+// changes since it was last synchronized, and returns what it did.
+func (s *ProvisioningService) SyncConnector(ctx context.Context, id uuid.UUID, full bool) (*domain.ProvisioningSyncResult, error) {
+	conn, err := s.GetConnector(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !conn.Enabled {
+		return nil, fmt.Errorf("%w: connector is disabled", ErrInvalidConnector)
+	}
+	if err := s.audit(ctx, domain.AuditConnectorSync, conn, nil); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Reload the connector, which a synchronization may have advanced
+	// while this one waited.
+	if conn, err = s.GetConnector(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.sync(ctx, conn, full || conn.Status.LastFullSyncAt == nil)
+}
+
+// Run synchronizes the enabled connectors every poll interval until ctx
+// is done, passing errors to onError.
+func (s *ProvisioningService) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(s.policy.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.SyncAll(ctx); err != nil && ctx.Err() == nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// SyncAll synchronizes every enabled connector: fully if it never was or
+// its reconcile interval has passed since it last was, and otherwise the
+// changes since it was last synchronized, if any.
+func (s *ProvisioningService) SyncAll(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conns, err := s.dao.EnabledConnectors(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, conn := range conns {
+		last := conn.Status.LastFullSyncAt
+		full := last == nil || time.Since(*last) >= s.policy.ReconcileInterval
+		if _, err := s.sync(ctx, conn, full); err != nil {
+			errs = append(errs, fmt.Errorf("connector %s: %w", conn.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sync synchronizes a connector and records the outcome in its status.
+// s.mu must be held.
+func (s *ProvisioningService) sync(ctx context.Context, conn *domain.ProvisioningConnector, full bool) (*domain.ProvisioningSyncResult, error) {
+	// Take the cursor before reading users and groups, so that changes made
+	// while synchronizing are synchronized next time.
+	cursor, err := s.dao.LatestEventTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.ProvisioningSyncResult{Full: full}
+	if !full && (cursor == nil || conn.EventCursor != nil && !cursor.After(*conn.EventCursor)) {
+		return result, nil
+	}
+
+	run := &provisioningRun{
+		dao:    s.dao,
+		conn:   conn,
+		client: client.New(conn.URL, conn.Token, s.client),
+		full:   full,
+		result: result,
+	}
+	err = run.execute(ctx)
+	if err != nil {
+		cursor = nil
+		full = false
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+
+	var lastError string
+	switch {
+	case err != nil:
+		lastError = err.Error()
+	case result.Failed > 0:
+		lastError = fmt.Sprintf("%d objects failed to synchronize", result.Failed)
+	}
+	if recordErr := s.dao.RecordConnectorSync(ctx, conn.ID, full, cursor, lastError); recordErr != nil {
+		return nil, recordErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// objectKey identifies the object of a user or group.
+type objectKey struct {
+	resourceType domain.ProvisionedResourceType
+	localID      uuid.UUID
+}
+
+// provisioningRun is one synchronization of a connector.
+type provisioningRun struct {
+	dao    *dao.DAO
+	conn   *domain.ProvisioningConnector
+	client *client.Client
+	full   bool
+	result *domain.ProvisioningSyncResult
+
+	objects map[objectKey]*domain.ProvisionedObject
+	seen    map[objectKey]bool
+}
+
+// execute sends the users in scope, then the groups with those among their
+// members that were provisioned, and then deprovisions the groups and
+// users that are not to be provisioned any more. Objects that the
+// application rejects are recorded as failing; other errors, such as the
+// application not responding, stop the synchronization.
+func (r *provisioningRun) execute(ctx context.Context) error {
+	users, err := r.dao.AllUsers(ctx)
+	if err != nil {
+		return err
+	}
+	groups, err := r.dao.AllGroups(ctx)
+	if err != nil {
+		return err
+	}
+	objects, err := r.dao.ProvisionedObjects(ctx, r.conn.ID)
+	if err != nil {
+		return err
+	}
+	r.objects = make(map[objectKey]*domain.ProvisionedObject, len(objects))
+	r.seen = make(map[objectKey]bool, len(objects))
+	for _, o := range objects {
+		r.objects[objectKey{o.ResourceType, o.LocalID}] = o
+	}
+
+	var scope map[uuid.UUID]bool
+	if id := r.conn.ScopeGroupID; id != nil {
+		i := slices.IndexFunc(groups, func(g *domain.Group) bool { return g.ID == *id })
+		if i < 0 {
+			// Deprovisioning everyone because the group is gone would be
+			// drastic; stop until the scope is changed.
+			return fmt.Errorf("%w: scope group %s does not exist", ErrInvalidConnector, id)
+		}
+		scope = make(map[uuid.UUID]bool, len(groups[i].Users))
+		for _, u := range groups[i].Users {
+			scope[u.ID] = true
+		}
+	}
+
+	slices.SortFunc(users, func(a, b *domain.User) int { return strings.Compare(a.Username, b.Username) })
+	for _, u := range users {
+		if u.Status == domain.UserStatusPending || scope != nil && !scope[u.ID] {
+			continue
+		}
+		res, err := provisionedUser(u, r.conn.AttributeMapping)
+		if err != nil {
+			return err
+		}
+		if err := r.sync(ctx, domain.ProvisionedUser, u.ID, res, "userName", u.Username); err != nil {
+			return err
+		}
+	}
+
+	if r.conn.SyncGroups {
+		slices.SortFunc(groups, func(a, b *domain.Group) int { return strings.Compare(a.Name, b.Name) })
+		for _, g := range groups {
+			var members []string
+			for _, u := range g.Users {
+				if o := r.objects[objectKey{domain.ProvisionedUser, u.ID}]; o != nil && o.RemoteID != "" && r.seen[objectKey{domain.ProvisionedUser, u.ID}] {
+					members = append(members, o.RemoteID)
+				}
+			}
+			if err := r.sync(ctx, domain.ProvisionedGroup, g.ID, provisionedGroup(g, members), "displayName", g.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	stale := slices.Collect(maps.Values(r.objects))
+	slices.SortFunc(stale, func(a, b *domain.ProvisionedObject) int {
+		// Groups first, so that their members are not deleted under them.
+		return cmp.Compare(a.ResourceType, b.ResourceType)
+	})
+	for _, o := range stale {
+		if r.seen[objectKey{o.ResourceType, o.LocalID}] {
+			continue
+		}
+		if err := r.deprovision(ctx, o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sync sends the resource of a user or group to the application, unless
+// it is unchanged since it was last sent. Full synchronizations compare it
+// with the application's resource instead. A resource not provisioned yet
+// is looked up by a unique attribute first, so that one the application
+// already has is taken over rather than duplicated.
+func (r *provisioningRun) sync(ctx context.Context, typ domain.ProvisionedResourceType, localID uuid.UUID, res map[string]any, attr, value string) error {
+	res, err := client.Normalize(res)
+	if err != nil {
+		return fmt.Errorf("encoding resource: %w", err)
+	}
+	hash := resourceHash(res)
+	key := objectKey{typ, localID}
+	r.seen[key] = true
+	o := r.objects[key]
+	if o == nil {
+		o = &domain.ProvisionedObject{ConnectorID: r.conn.ID, ResourceType: typ, LocalID: localID}
+	}
+	if !r.full && o.RemoteID != "" && o.Hash == hash {
+		r.result.Unchanged++
+		return nil
+	}
+
+	endpoint := client.Users
+	if typ == domain.ProvisionedGroup {
+		endpoint = client.Groups
+	}
+	changed, err := r.push(ctx, endpoint, o, res, attr, value)
+	if err != nil {
+		if !rejected(err) {
+			return fmt.Errorf("%w: %v", ErrConnectorUnreachable, err)
+		}
+		r.result.Failed++
+		o.LastError = err.Error()
+	} else {
+		now := time.Now()
+		o.Hash, o.LastError, o.SyncedAt = hash, "", &now
+		switch changed {
+		case http.MethodPost:
+			r.result.Created++
+		case http.MethodPut:
+			r.result.Updated++
+		default:
+			r.result.Unchanged++
+		}
+	}
+	saved, err := r.dao.SaveProvisionedObject(ctx, o)
+	if err != nil {
+		return err
+	}
+	r.objects[key] = saved
+	return nil
+}
+
+// push makes the application's resource of an object match res. It
+// returns the method of the request that changed it, or "" if it was
+// unchanged, and sets the object's remote ID.
+func (r *provisioningRun) push(ctx context.Context, endpoint string, o *domain.ProvisionedObject, res map[string]any, attr, value string) (string, error) {
+	if o.RemoteID == "" {
+		found, err := r.client.Find(ctx, endpoint, client.Eq(attr, value))
+		if err != nil {
+			return "", err
+		}
+		if len(found) > 0 {
+			o.RemoteID = client.ID(found[0])
+			if client.Covers(found[0], res) {
+				return "", nil
+			}
+		}
+	} else if r.full {
+		remote, err := r.client.Get(ctx, endpoint, o.RemoteID)
+		switch {
+		case client.IsNotFound(err):
+			o.RemoteID = ""
+		case err != nil:
+			return "", err
+		case client.Covers(remote, res):
+			return "", nil
+		}
+	}
+
+	if o.RemoteID != "" {
+		_, err := r.client.Replace(ctx, endpoint, o.RemoteID, res)
+		if !client.IsNotFound(err) {
+			return http.MethodPut, err
+		}
+	}
+	created, err := r.client.Create(ctx, endpoint, res)
+	if err != nil {
+		return "", err
+	}
+	if o.RemoteID = client.ID(created); o.RemoteID == "" {
+		return "", &client.Error{Status: http.StatusCreated, Detail: "created resource has no id"}
+	}
+	return http.MethodPost, nil
+}
+
+// deprovision deletes the application's resource of an object that is not
+// to be provisioned any more, and the object.
+func (r *provisioningRun) deprovision(ctx context.Context, o *domain.ProvisionedObject) error {
+	if o.RemoteID != "" {
+		endpoint := client.Users
+		if o.ResourceType == domain.ProvisionedGroup {
+			endpoint = client.Groups
+		}
+		err := r.client.Delete(ctx, endpoint, o.RemoteID)
+		if err != nil && !client.IsNotFound(err) {
+			if !rejected(err) {
+				return fmt.Errorf("%w: %v", ErrConnectorUnreachable, err)
+			}
+			r.result.Failed++
+			o.LastError = err.Error()
+			_, err := r.dao.SaveProvisionedObject(ctx, o)
+			return err
+		}
+		r.result.Deleted++
+	}
+	return r.dao.DeleteProvisionedObject(ctx, o.ID)
+}
+
+// rejected reports whether an error is a response of the application, as
+// opposed to the application not responding, which stops the
+// synchronization.
+func rejected(err error) bool {
+	var e *client.Error
+	return errors.As(err, &e)
+}
+
+// provisionedUser returns the SCIM User resource provisioned for a user.
+// Extension attributes are set as their mapped paths; multiple values are
+// sent as an array.
+func provisionedUser(u *domain.User, mapping map[string]string) (map[string]any, error) {
+	res := map[string]any{
+		"schemas":     []any{client.SchemaUser},
+		"externalId":  u.ID.String(),
+		"userName":    u.Username,
+		"displayName": u.DisplayName,
+		"name":        map[string]any{"formatted": u.DisplayName},
+		"active":      u.Status == domain.UserStatusEnabled,
+	}
+	if u.Email != "" {
+		res["emails"] = []any{map[string]any{"value": u.Email, "type": "work", "primary": true}}
+	}
+	if u.Phone != "" {
+		res["phoneNumbers"] = []any{map[string]any{"value": u.Phone, "type": "work"}}
+	}
+
+	for _, source := range slices.Sorted(maps.Keys(mapping)) {
+		values := u.Attributes[source]
+		if len(values) == 0 {
+			continue
+		}
+		var v any = values[0]
+		if len(values) > 1 {
+			v = toAnySlice(values)
+		}
+		if err := patch.Apply(res, []patch.Operation{{Op: "replace", Path: mapping[source], Value: v}}, scimUserSchema); err != nil {
+			return nil, fmt.Errorf("%w: mapping attribute %s: %v", ErrInvalidConnector, source, err)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(res)) {
+		if _, ok := res[key].(map[string]any); ok && strings.HasPrefix(strings.ToLower(key), "urn:") {
+			res["schemas"] = append(res["schemas"].([]any), key)
+		}
+	}
+	return res, nil
+}
+
+// provisionedGroup returns the SCIM Group resource provisioned for a group
+// whose provisioned members have the given remote IDs.
+func provisionedGroup(g *domain.Group, memberIDs []string) map[string]any {
+	slices.Sort(memberIDs)
+	members := make([]any, len(memberIDs))
+	for i, id := range memberIDs {
+		members[i] = map[string]any{"value": id}
+	}
+	return map[string]any{
+		"schemas":     []any{client.SchemaGroup},
+		"externalId":  g.ID.String(),
+		"displayName": g.Name,
+		"members":     members,
+	}
+}
+
+func resourceHash(res map[string]any) string {
+	b, _ := json.Marshal(res)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func toAnySlice(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+// validateScope checks that the scope group, if any, exists.
+func (s *ProvisioningService) validateScope(ctx context.Context, groupID *uuid.UUID) error {
+	if groupID == nil {
+		return nil
+	}
+	if _, err := s.dao.GetGroupByID(ctx, *groupID); err != nil {
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("%w: scope group %s does not exist", ErrInvalidConnector, groupID)
+		}
+		return err
+	}
+	return nil
+}
+
+// validateMapping checks that every source is a user extension attribute
+// and every target a SCIM attribute path that the connector does not set
+// itself.
+func (s *ProvisioningService) validateMapping(ctx context.Context, mapping map[string]string) error {
+	if len(mapping) == 0 {
+		return nil
+	}
+	defs, err := s.dao.ListAttributeDefinitions(ctx, domain.AttributeTargetUser)
+	if err != nil {
+		return err
+	}
+	for source, target := range mapping {
+		if !slices.ContainsFunc(defs, func(d *domain.AttributeDefinition) bool { return d.Name == source }) {
+			return fmt.Errorf("%w: %q is not a user attribute", ErrInvalidConnector, source)
+		}
+		p, err := filter.ParsePath(target)
+		if err != nil {
+			return fmt.Errorf("%w: mapping of %s: %v", ErrInvalidConnector, source, err)
+		}
+		core := p.URN == "" || strings.EqualFold(p.URN, client.SchemaUser)
+		if core && slices.ContainsFunc(reservedSCIMAttributes, func(a string) bool { return strings.EqualFold(a, p.Name) }) {
+			return fmt.Errorf("%w: mapping of %s: %s is set by the connector", ErrInvalidConnector, source, p.Name)
+		}
+	}
+	return nil
+}
+
+func validateConnectorURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidConnector)
+	}
+	return nil
+}
+
+// connectorError returns ErrConnectorNotFound for a not found error and
+// err otherwise.
+func connectorError(err error) error {
+	if ent.IsNotFound(err) {
+		return ErrConnectorNotFound
+	}
+	return err
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/scim/client"
+	"github.com/qinzj/claude-demo/internal/scim/scimtest"
+)
+
+func setupProvisioningService(t *testing.T) (*ProvisioningService, *UserService, *GroupService, *AttributeService, context.Context) {
+	t.Helper()
+	_, userSvc, d, ctx := setupAuditService(t)
+	svc := NewProvisioningService(d, domain.ProvisioningPolicy{Timeout: 5 * time.Second, ReconcileInterval: time.Hour})
+	return svc, userSvc, NewGroupService(d), NewAttributeService(d), ctx
+}
+
+func syncAll(t *testing.T, svc *ProvisioningService, ctx context.Context) {
+	t.Helper()
+	if err := svc.SyncAll(ctx); err != nil {
+		t.Fatalf("SyncAll: %v", err)
+	}
+}
+
+func createProvisionedUser(t *testing.T, userSvc *UserService, ctx context.Context, username string, attrs map[string][]string) *domain.User {
+	t.Helper()
+	u, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: username, DisplayName: username, Email: username + "@example.com", Password: "Secret-pass-1", Attributes: attrs,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	return u
+}
+
+// remoteValue returns the value of an attribute of a stand-in resource.
+func remoteValue(res map[string]any, names ...string) any {
+	var v any = res
+	for _, name := range names {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[name]
+	}
+	return v
+}
+
+func TestProvisioningSync(t *testing.T) {
+	svc, userSvc, groupSvc, attrSvc, ctx := setupProvisioningService(t)
+	srv := scimtest.NewServer(t, "app-token")
+
+	if _, err := attrSvc.CreateDefinition(ctx, domain.CreateAttributeDefinitionInput{
+		Name: "department", Target: domain.AttributeTargetUser, Type: domain.AttributeTypeString,
+	}); err != nil {
+		t.Fatalf("CreateDefinition: %v", err)
+	}
+	alice := createProvisionedUser(t, userSvc, ctx, "alice", map[string][]string{"department": {"Engineering"}})
+	bob := createProvisionedUser(t, userSvc, ctx, "bob", nil)
+	eng, err := groupSvc.CreateGroup(ctx, domain.CreateGroupInput{Name: "eng"})
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if err := groupSvc.AddMembers(ctx, eng.ID, []uuid.UUID{alice.ID, bob.ID}); err != nil {
+		t.Fatalf("AddMembers: %v", err)
+	}
+
+	conn, err := svc.CreateConnector(ctx, domain.CreateConnectorInput{
+		Name:             "app",
+		URL:              srv.URL,
+		Token:            "app-token",
+		SyncGroups:       true,
+		AttributeMapping: map[string]string{"department": client.SchemaEnterpriseUser + ":department"},
+	})
+	if err != nil {
+		t.Fatalf("CreateConnector: %v", err)
+	}
+
+	// The first synchronization is a full one.
+	syncAll(t, svc, ctx)
+	remoteAlice := srv.Find(client.Users, "userName", "alice")
+	if remoteAlice == nil {
+		t.Fatal("alice was not provisioned")
+	}
+	if got := remoteValue(remoteAlice, "externalId"); got != alice.ID.String() {
+		t.Errorf("externalId = %v, want %s", got, alice.ID)
+	}
+	if got := remoteValue(remoteAlice, client.SchemaEnterpriseUser, "department"); got != "Engineering" {
+		t.Errorf("department = %v, want Engineering", got)
+	}
+	if !slices.Contains(remoteAlice["schemas"].([]any), any(client.SchemaEnterpriseUser)) {
+		t.Errorf("schemas = %v, want the enterprise extension", remoteAlice["schemas"])
+	}
+	remoteBob := srv.Find(client.Users, "userName", "bob")
+	group := srv.Find(client.Groups, "displayName", "eng")
+	if remoteBob == nil || group == nil {
+		t.Fatal("bob or eng was not provisioned")
+	}
+	if members := group["members"].([]any); len(members) != 2 {
+		t.Errorf("members = %v, want alice and bob", members)
+	}
+
+	conn, err = svc.GetConnector(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("GetConnector: %v", err)
+	}
+	if st := conn.Status; st.LastFullSyncAt == nil || st.LastError != "" || st.Users != 2 || st.Groups != 1 || st.Failed != 0 {
+		t.Errorf("status = %+v, want 2 users and 1 group fully synchronized", st)
+	}
+
+	// Without changes, nothing is sent.
+	srv.Requests()
+	syncAll(t, svc, ctx)
+	if got := srv.Requests(); len(got) != 0 {
+		t.Errorf("requests without changes = %v, want none", got)
+	}
+
+	// Changes send only the resources that changed.
+	name := "Robert"
+	if _, err := userSvc.UpdateUser(ctx, bob.ID, domain.UpdateUserInput{DisplayName: &name}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if err := userSvc.SetUserStatus(ctx, alice.ID, domain.UserStatusDisabled); err != nil {
+		t.Fatalf("SetUserStatus: %v", err)
+	}
+	syncAll(t, svc, ctx)
+	want := []string{"PUT /Users/" + client.ID(remoteAlice), "PUT /Users/" + client.ID(remoteBob)}
+	if got := srv.Requests(); !slices.Equal(got, want) {
+		t.Errorf("requests = %v, want %v", got, want)
+	}
+	if got := remoteValue(srv.Find(client.Users, "userName", "alice"), "active"); got != false {
+		t.Errorf("active = %v, want false", got)
+	}
+	if got := remoteValue(srv.Find(client.Users, "userName", "bob"), "displayName"); got != "Robert" {
+		t.Errorf("displayName = %v, want Robert", got)
+	}
+
+	if err := groupSvc.RemoveMember(ctx, eng.ID, bob.ID); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+	syncAll(t, svc, ctx)
+	if got := srv.Requests(); !slices.Equal(got, []string{"PUT /Groups/" + client.ID(group)}) {
+		t.Errorf("requests = %v, want the group replaced", got)
+	}
+	if members := srv.Find(client.Groups, "displayName", "eng")["members"].([]any); len(members) != 1 {
+		t.Errorf("members = %v, want alice", members)
+	}
+
+	// Deleted users are deprovisioned.
+	if err := userSvc.DeleteUser(ctx, bob.ID); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	syncAll(t, svc, ctx)
+	if got := srv.Requests(); !slices.Equal(got, []string{"DELETE /Users/" + client.ID(remoteBob)}) {
+		t.Errorf("requests = %v, want bob deleted", got)
+	}
+
+	// Changes made in the application are corrected by a full
+	// synchronization.
+	remoteAlice = srv.Find(client.Users, "userName", "alice")
+	remoteAlice["displayName"] = "Mallory"
+	srv.Set(client.Users, remoteAlice)
+	srv.Remove(client.Groups, client.ID(group))
+	syncAll(t, svc, ctx)
+	if got := srv.Requests(); len(got) != 0 {
+		t.Errorf("requests without changes = %v, want none", got)
+	}
+	result, err := svc.SyncConnector(ctx, conn.ID, true)
+	if err != nil {
+		t.Fatalf("SyncConnector: %v", err)
+	}
+	if *result != (domain.ProvisioningSyncResult{Full: true, Created: 1, Updated: 1}) {
+		t.Errorf("result = %+v, want alice updated and eng created", result)
+	}
+	if got := remoteValue(srv.Find(client.Users, "userName", "alice"), "displayName"); got != "alice" {
+		t.Errorf("displayName = %v, want alice", got)
+	}
+	if srv.Find(client.Groups, "displayName", "eng") == nil {
+		t.Error("eng was not provisioned again")
+	}
+}
+
+func TestProvisioningScope(t *testing.T) {
+	svc, userSvc, groupSvc, _, ctx := setupProvisioningService(t)
+	srv := scimtest.NewServer(t, "")
+
+	alice := createProvisionedUser(t, userSvc, ctx, "alice", nil)
+	createProvisionedUser(t, userSvc, ctx, "bob", nil)
+	app, err := groupSvc.CreateGroup(ctx, domain.CreateGroupInput{Name: "app-users"})
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if err := groupSvc.AddMembers(ctx, app.ID, []uuid.UUID{alice.ID}); err != nil {
+		t.Fatalf("AddMembers: %v", err)
+	}
+	if _, err := svc.CreateConnector(ctx, domain.CreateConnectorInput{Name: "app", URL: srv.URL, ScopeGroupID: &app.ID}); err != nil {
+		t.Fatalf("CreateConnector: %v", err)
+	}
+
+	syncAll(t, svc, ctx)
+	if srv.Find(client.Users, "userName", "alice") == nil || srv.Find(client.Users, "userName", "bob") != nil {
+		t.Fatalf("users = %v, want only alice", srv.Resources(client.Users))
+	}
+	if got := srv.Resources(client.Groups); len(got) != 0 {
+		t.Errorf("groups = %v, want none without sync_groups", got)
+	}
+
+	// Leaving the scope group deprovisions.
+	if err := groupSvc.RemoveMember(ctx, app.ID, alice.ID); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+	syncAll(t, svc, ctx)
+	if got := srv.Resources(client.Users); len(got) != 0 {
+		t.Errorf("users = %v, want none", got)
+	}
+}
+
+func TestProvisioningFailures(t *testing.T) {
+	svc, userSvc, _, _, ctx := setupProvisioningService(t)
+	srv := scimtest.NewServer(t, "")
+
+	// A resource the application already has is taken over.
+	srv.Set(client.Users, map[string]any{"id": "existing", "userName": "carol"})
+	createProvisionedUser(t, userSvc, ctx, "carol", nil)
+	createProvisionedUser(t, userSvc, ctx, "dave", nil)
+	conn, err := svc.CreateConnector(ctx, domain.CreateConnectorInput{Name: "app", URL: srv.URL})
+	if err != nil {
+		t.Fatalf("CreateConnector: %v", err)
+	}
+
+	// The lookup of carol fails.
+	srv.Fail(1)
+	result, err := svc.SyncConnector(ctx, conn.ID, true)
+	if err != nil {
+		t.Fatalf("SyncConnector: %v", err)
+	}
+	if result.Created != 1 || result.Failed != 1 {
+		t.Errorf("result = %+v, want dave created and carol failed", result)
+	}
+	conn, _ = svc.GetConnector(ctx, conn.ID)
+	if conn.Status.Failed != 1 || conn.Status.LastError == "" {
+		t.Errorf("status = %+v, want 1 failed object", conn.Status)
+	}
+	failed, err := svc.ListObjects(ctx, conn.ID, domain.ListProvisionedObjectsInput{FailedOnly: true})
+	if err != nil {
+		t.Fatalf("ListObjects: %v", err)
+	}
+	if failed.Total != 1 || failed.Items[0].LastError == "" {
+		t.Fatalf("failed objects = %+v, want carol", failed.Items)
+	}
+
+	// Failures are retried by full synchronizations.
+	result, err = svc.SyncConnector(ctx, conn.ID, true)
+	if err != nil {
+		t.Fatalf("SyncConnector: %v", err)
+	}
+	if result.Created != 0 || result.Updated != 1 || result.Failed != 0 {
+		t.Errorf("result = %+v, want carol taken over", result)
+	}
+	if got := len(srv.Resources(client.Users)); got != 2 {
+		t.Errorf("remote users = %d, want 2", got)
+	}
+	objects, _ := svc.ListObjects(ctx, conn.ID, domain.ListProvisionedObjectsInput{ResourceType: domain.ProvisionedUser})
+	if !slices.ContainsFunc(objects.Items, func(o *domain.ProvisionedObject) bool { return o.RemoteID == "existing" }) {
+		t.Errorf("objects = %+v, want carol linked to the existing resource", objects.Items)
+	}
+
+	// An application that does not respond stops the synchronization.
+	srv.Close()
+	if _, err := svc.SyncConnector(ctx, conn.ID, true); !errors.Is(err, ErrConnectorUnreachable) {
+		t.Fatalf("SyncConnector with the application down = %v, want ErrConnectorUnreachable", err)
+	}
+	conn, _ = svc.GetConnector(ctx, conn.ID)
+	if conn.Status.LastError == "" {
+		t.Error("last error was not recorded")
+	}
+}
+
+func TestProvisioningConnectorValidation(t *testing.T) {
+	svc, _, _, attrSvc, ctx := setupProvisioningService(t)
+	if _, err := attrSvc.CreateDefinition(ctx, domain.CreateAttributeDefinitionInput{
+		Name: "title", Target: domain.AttributeTargetUser, Type: domain.AttributeTypeString,
+	}); err != nil {
+		t.Fatalf("CreateDefinition: %v", err)
+	}
+	missing := uuid.New()
+
+	for name, input := range map[string]domain.CreateConnectorInput{
+		"relative url":    {Name: "a", URL: "/scim/v2"},
+		"unknown source":  {Name: "a", URL: "https://app/scim", AttributeMapping: map[string]string{"nickname": "nickName"}},
+		"reserved target": {Name: "a", URL: "https://app/scim", AttributeMapping: map[string]string{"title": "userName"}},
+		"invalid target":  {Name: "a", URL: "https://app/scim", AttributeMapping: map[string]string{"title": "title["}},
+		"unknown scope":   {Name: "a", URL: "https://app/scim", ScopeGroupID: &missing},
+	} {
+		if _, err := svc.CreateConnector(ctx, input); !errors.Is(err, ErrInvalidConnector) {
+			t.Errorf("%s: CreateConnector = %v, want ErrInvalidConnector", name, err)
+		}
+	}
+
+	conn, err := svc.CreateConnector(ctx, domain.CreateConnectorInput{
+		Name: "a", URL: "https://app/scim", AttributeMapping: map[string]string{"title": "title"},
+	})
+	if err != nil {
+		t.Fatalf("CreateConnector: %v", err)
+	}
+	if _, err := svc.CreateConnector(ctx, domain.CreateConnectorInput{Name: "a", URL: "https://other/scim"}); !errors.Is(err, ErrInvalidConnector) {
+		t.Errorf("CreateConnector with a taken name = %v, want ErrInvalidConnector", err)
+	}
+	enabled := false
+	if _, err := svc.UpdateConnector(ctx, conn.ID, domain.UpdateConnectorInput{Enabled: &enabled}); err != nil {
+		t.Fatalf("UpdateConnector: %v", err)
+	}
+	if _, err := svc.SyncConnector(ctx, conn.ID, false); !errors.Is(err, ErrInvalidConnector) {
+		t.Errorf("SyncConnector of a disabled connector = %v, want ErrInvalidConnector", err)
+	}
+	if _, err := svc.GetConnector(ctx, uuid.New()); !errors.Is(err, ErrConnectorNotFound) {
+		t.Errorf("GetConnector of an unknown connector = %v, want ErrConnectorNotFound", err)
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+)
+
+// ErrInvalidRole is returned when a role definition fails validation or a
+// built-in role would be modified.
+var ErrInvalidRole = errors.New("invalid role")
+
+// RoleService manages roles and their assignments.
+type RoleService struct {
+	dao *dao.DAO
+}
+
+// NewRoleService creates a new RoleService.
+func NewRoleService(d *dao.DAO) *RoleService {
+	return &RoleService{dao: d}
+}
+
+// EnsureBuiltinRoles creates the built-in admin role if it does not exist yet.
+func (s *RoleService) EnsureBuiltinRoles(ctx context.Context) (*domain.Role, error) {
+	r, err := s.dao.GetRoleByName(ctx, domain.AdminRoleName)
+	if err == nil {
+		return r, nil
+	}
+	if !ent.IsNotFound(err) {
+		return nil, err
+	}
+	return s.dao.CreateRole(ctx, domain.CreateRoleInput{
+		Name:        domain.AdminRoleName,
+		Description: "Full access to every API",
+		Permissions: []string{domain.PermissionAll},
+	}, true)
+}
+
+// GrantAdmin assigns the built-in admin role to a user, creating the role if needed.
+func (s *RoleService) GrantAdmin(ctx context.Context, userID uuid.UUID) error {
+	admin, err := s.EnsureBuiltinRoles(ctx)
+	if err != nil {
+		return fmt.Errorf("ensuring admin role: %w", err)
+	}
+	return s.dao.AddRoleUsers(ctx, admin.ID, []uuid.UUID{userID})
+}
+
+// CreateRole creates a custom role after validating its permissions.
+func (s *RoleService) CreateRole(ctx context.Context, input domain.CreateRoleInput) (*domain.Role, error) {
+	if err := validatePermissions(input.Permissions, ErrInvalidRole); err != nil {
+		return nil, err
+	}
+	r, err := s.dao.CreateRole(ctx, input, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditRoleCreate, r, AuditChanges(nil, r)); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetRole retrieves a role by ID with its assignments.
+func (s *RoleService) GetRole(ctx context.Context, id uuid.UUID) (*domain.Role, error) {
+	return s.dao.GetRoleByID(ctx, id)
+}
+
+// ListRoles returns all roles.
+func (s *RoleService) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	return s.dao.ListRoles(ctx)
+}
+
+// UpdateRole updates a custom role. Built-in roles cannot be changed.
+func (s *RoleService) UpdateRole(ctx context.Context, id uuid.UUID, input domain.UpdateRoleInput) (*domain.Role, error) {
+	r, err := s.dao.GetRoleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Builtin {
+		return nil, fmt.Errorf("%w: built-in role %q cannot be modified", ErrInvalidRole, r.Name)
+	}
+	if input.Permissions != nil {
+		if err := validatePermissions(input.Permissions, ErrInvalidRole); err != nil {
+			return nil, err
+		}
+	}
+	updated, err := s.dao.UpdateRole(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditRoleUpdate, updated, AuditChanges(r, updated)); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteRole deletes a custom role. Built-in roles cannot be deleted.
+func (s *RoleService) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	r, err := s.dao.GetRoleByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if r.Builtin {
+		return fmt.Errorf("%w: built-in role %q cannot be deleted", ErrInvalidRole, r.Name)
+	}
+	if err := s.dao.DeleteRole(ctx, id); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditRoleDelete, r, AuditChanges(r, nil))
+}
+
+// AssignUsers assigns a role to users.
+func (s *RoleService) AssignUsers(ctx context.Context, roleID uuid.UUID, userIDs []uuid.UUID) error {
+	if err := s.dao.AddRoleUsers(ctx, roleID, userIDs); err != nil {
+		return err
+	}
+	return s.auditByID(ctx, domain.AuditRoleAssign, roleID, []domain.AuditChange{{Field: "users", New: userIDs}})
+}
+
+// UnassignUser removes a role from a user.
+func (s *RoleService) UnassignUser(ctx context.Context, roleID, userID uuid.UUID) error {
+	if err := s.dao.RemoveRoleUser(ctx, roleID, userID); err != nil {
+		return err
+	}
+	return s.auditByID(ctx, domain.AuditRoleUnassign, roleID, []domain.AuditChange{{Field: "users", Old: []uuid.UUID{userID}}})
+}
+
+// AssignGroups assigns a role to groups.
+func (s *RoleService) AssignGroups(ctx context.Context, roleID uuid.UUID, groupIDs []uuid.UUID) error {
+	if err := s.dao.AddRoleGroups(ctx, roleID, groupIDs); err != nil {
+		return err
+	}
+	return s.auditByID(ctx, domain.AuditRoleAssign, roleID, []domain.AuditChange{{Field: "groups", New: groupIDs}})
+}
+
+// UnassignGroup removes a role from a group.
+func (s *RoleService) UnassignGroup(ctx context.Context, roleID, groupID uuid.UUID) error {
+	if err := s.dao.RemoveRoleGroup(ctx, roleID, groupID); err != nil {
+		return err
+	}
+	return s.auditByID(ctx, domain.AuditRoleUnassign, roleID, []domain.AuditChange{{Field: "groups", Old: []uuid.UUID{groupID}}})
+}
+
+// audit records an operation on a role in the audit trail.
+func (s *RoleService) audit(ctx context.Context, action string, r *domain.Role, changes []domain.AuditChange) error {
+	return recordAudit(ctx, s.dao, &domain.AuditEvent{
+		Action:     action,
+		TargetType: domain.AuditTargetRole,
+		TargetID:   r.ID.String(),
+		TargetName: r.Name,
+		Changes:    changes,
+	})
+}
+
+// auditByID is audit for a role that is not loaded yet.
+func (s *RoleService) auditByID(ctx context.Context, action string, roleID uuid.UUID, changes []domain.AuditChange) error {
+	r, err := s.dao.GetRoleByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	return s.audit(ctx, action, r, changes)
+}
+
+// validatePermissions checks that every permission is known, wrapping
+// invalid in the error otherwise.
+func validatePermissions(perms []string, invalid error) error {
+	for _, p := range perms {
+		if !slices.Contains(domain.KnownPermissions, p) {
+			return fmt.Errorf("%w: unknown permission %q", invalid, p)
+		}
+	}
+	return nil
+}
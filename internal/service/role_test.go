@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent/enttest"
+)
+
+func setupRoleService(t *testing.T) (*RoleService, *dao.DAO, context.Context) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	d := dao.New(client)
+	ctx := context.Background()
+	if err := d.AutoMigrate(ctx); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	return NewRoleService(d), d, ctx
+}
+
+func TestRoleServiceCreateRoleValidation(t *testing.T) {
+	svc, _, ctx := setupRoleService(t)
+
+	if _, err := svc.CreateRole(ctx, domain.CreateRoleInput{
+		Name:        "bogus",
+		Permissions: []string{"user:fly"},
+	}); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("CreateRole() error = %v, want ErrInvalidRole", err)
+	}
+
+	r, err := svc.CreateRole(ctx, domain.CreateRoleInput{
+		Name:        "helpdesk",
+		Permissions: []string{domain.PermissionUserRead, domain.PermissionUserWrite},
+	})
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	if r.Builtin {
+		t.Error("custom role should not be built-in")
+	}
+}
+
+func TestRoleServiceBuiltinAdmin(t *testing.T) {
+	svc, d, ctx := setupRoleService(t)
+
+	admin, err := svc.EnsureBuiltinRoles(ctx)
+	if err != nil {
+		t.Fatalf("EnsureBuiltinRoles: %v", err)
+	}
+	again, err := svc.EnsureBuiltinRoles(ctx)
+	if err != nil {
+		t.Fatalf("EnsureBuiltinRoles: %v", err)
+	}
+	if again.ID != admin.ID {
+		t.Error("EnsureBuiltinRoles should be idempotent")
+	}
+
+	name := "root"
+	if _, err := svc.UpdateRole(ctx, admin.ID, domain.UpdateRoleInput{Name: &name}); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("UpdateRole(builtin) error = %v, want ErrInvalidRole", err)
+	}
+	if err := svc.DeleteRole(ctx, admin.ID); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("DeleteRole(builtin) error = %v, want ErrInvalidRole", err)
+	}
+
+	u, _ := d.CreateUser(ctx, "boss", "Boss", "boss@example.com", "hash", "")
+	for range 2 {
+		if err := svc.GrantAdmin(ctx, u.ID); err != nil {
+			t.Fatalf("GrantAdmin: %v", err)
+		}
+	}
+	perms, err := d.UserPermissions(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("UserPermissions: %v", err)
+	}
+	if !domain.HasPermission(perms, domain.PermissionLDAPConfig) {
+		t.Errorf("admin permissions %v should grant %s", perms, domain.PermissionLDAPConfig)
+	}
+}
+
+func TestRoleServicePermissionsViaGroup(t *testing.T) {
+	svc, d, ctx := setupRoleService(t)
+
+	u, _ := d.CreateUser(ctx, "reader", "Reader", "reader@example.com", "hash", "")
+	g, _ := d.CreateGroup(ctx, "readers", "", nil)
+	d.AddMembers(ctx, g.ID, []uuid.UUID{u.ID})
+
+	r, err := svc.CreateRole(ctx, domain.CreateRoleInput{
+		Name:        "viewer",
+		Permissions: []string{domain.PermissionUserRead, domain.PermissionGroupRead},
+	})
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	if err := svc.AssignGroups(ctx, r.ID, []uuid.UUID{g.ID}); err != nil {
+		t.Fatalf("AssignGroups: %v", err)
+	}
+
+	perms, err := d.UserPermissions(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("UserPermissions: %v", err)
+	}
+	want := []string{domain.PermissionGroupRead, domain.PermissionUserRead}
+	if !slices.Equal(perms, want) {
+		t.Errorf("permissions = %v, want %v", perms, want)
+	}
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+)
+
+var (
+	// ErrInvalidServiceAccount is returned when a service account or API key
+	// definition fails validation.
+	ErrInvalidServiceAccount = errors.New("invalid service account")
+	// ErrInvalidAPIKey is returned when a credential does not identify an
+	// enabled service account through an unexpired API key.
+	ErrInvalidAPIKey = errors.New("invalid or expired api key")
+)
+
+// apiKeyPrefix starts every API key so they are recognizable, e.g. by
+// secret scanners, and cannot be mistaken for a JWT.
+const apiKeyPrefix = "sak_"
+
+// serviceAccountAudience marks access tokens issued to service accounts
+// through the client credentials grant.
+const serviceAccountAudience = "service"
+
+// apiKeyTouchInterval limits how often last use of an API key is written.
+const apiKeyTouchInterval = time.Minute
+
+var serviceAccountNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// ServiceAccountService manages service accounts and authenticates them by
+// API key or by access tokens from the client credentials grant.
+type ServiceAccountService struct {
+	dao      *dao.DAO
+	keys     *KeyService
+	tokenTTL time.Duration
+}
+
+// NewServiceAccountService creates a new ServiceAccountService. Access tokens
+// from the client credentials grant are signed with keySvc and live for tokenTTL.
+func NewServiceAccountService(d *dao.DAO, keySvc *KeyService, tokenTTL time.Duration) *ServiceAccountService {
+	return &ServiceAccountService{dao: d, keys: keySvc, tokenTTL: tokenTTL}
+}
+
+// serviceAccountClaims are the claims of a service account access token.
+// Permissions may be narrower than the account's, see ClientCredentials.
+type serviceAccountClaims struct {
+	Name        string   `json:"name"`
+	KeyID       string   `json:"key_id"`
+	Permissions []string `json:"permissions,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// CreateServiceAccount creates a service account. The acting user can only
+// grant permissions they hold themselves.
+func (s *ServiceAccountService) CreateServiceAccount(ctx context.Context, input domain.CreateServiceAccountInput) (*domain.ServiceAccount, error) {
+	if !serviceAccountNamePattern.MatchString(input.Name) {
+		return nil, fmt.Errorf("%w: name must be lowercase letters, digits, '.', '_' or '-'", ErrInvalidServiceAccount)
+	}
+	if err := s.checkPermissions(ctx, input.Permissions); err != nil {
+		return nil, err
+	}
+	sa, err := s.dao.CreateServiceAccount(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditServiceAccountCreate, sa, AuditChanges(nil, sa)); err != nil {
+		return nil, err
+	}
+	return sa, nil
+}
+
+// GetServiceAccount retrieves a service account with its API keys.
+func (s *ServiceAccountService) GetServiceAccount(ctx context.Context, id uuid.UUID) (*domain.ServiceAccount, error) {
+	return s.dao.GetServiceAccount(ctx, id)
+}
+
+// ListServiceAccounts returns all service accounts.
+func (s *ServiceAccountService) ListServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error) {
+	return s.dao.ListServiceAccounts(ctx)
+}
+
+// UpdateServiceAccount updates a service account's description, permissions
+// or disabled flag. Disabling it immediately rejects its keys and tokens.
+func (s *ServiceAccountService) UpdateServiceAccount(ctx context.Context, id uuid.UUID, input domain.UpdateServiceAccountInput) (*domain.ServiceAccount, error) {
+	if input.Permissions != nil {
+		if err := s.checkPermissions(ctx, input.Permissions); err != nil {
+			return nil, err
+		}
+	}
+	before, err := s.dao.GetServiceAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sa, err := s.dao.UpdateServiceAccount(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditServiceAccountUpdate, sa, AuditChanges(before, sa)); err != nil {
+		return nil, err
+	}
+	return sa, nil
+}
+
+// DeleteServiceAccount deletes a service account and its API keys.
+func (s *ServiceAccountService) DeleteServiceAccount(ctx context.Context, id uuid.UUID) error {
+	sa, err := s.dao.GetServiceAccount(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.DeleteServiceAccount(ctx, id); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditServiceAccountDelete, sa, AuditChanges(sa, nil))
+}
+
+// CreateAPIKey creates an API key for a service account and returns it
+// together with the key value, which is not stored and cannot be retrieved later.
+// As the key acts with the account's permissions, the acting user must hold
+// them all.
+func (s *ServiceAccountService) CreateAPIKey(ctx context.Context, serviceAccountID uuid.UUID, input domain.CreateAPIKeyInput) (*domain.APIKey, string, error) {
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, "", fmt.Errorf("%w: expires_at must be in the future", ErrInvalidServiceAccount)
+	}
+	sa, err := s.dao.GetServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := checkActorHolds(ctx, sa.Permissions); err != nil {
+		return nil, "", err
+	}
+	key, prefix, hash, err := newAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	k, err := s.dao.CreateAPIKey(ctx, serviceAccountID, input, prefix, hash)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.audit(ctx, domain.AuditAPIKeyCreate, sa, AuditChanges(nil, k)); err != nil {
+		return nil, "", err
+	}
+	return k, key, nil
+}
+
+// RotateAPIKey replaces an API key with one of a new ID and value, keeping
+// its name and expiry. The old value, and access tokens issued with it,
+// stop working immediately; to rotate without downtime, create a second
+// key and delete the first once clients have switched. Like creating
+// a key, it requires holding all of the account's permissions.
+func (s *ServiceAccountService) RotateAPIKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) (*domain.APIKey, string, error) {
+	sa, err := s.dao.GetServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := checkActorHolds(ctx, sa.Permissions); err != nil {
+		return nil, "", err
+	}
+	key, prefix, hash, err := newAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	k, err := s.dao.RotateAPIKey(ctx, serviceAccountID, keyID, prefix, hash)
+	if err != nil {
+		return nil, "", err
+	}
+	var before *domain.APIKey
+	for _, old := range sa.APIKeys {
+		if old.ID == keyID {
+			before = old
+		}
+	}
+	changes := append(AuditChanges(before, k), domain.AuditChange{Field: "key", Redacted: true})
+	if err := s.audit(ctx, domain.AuditAPIKeyRotate, sa, changes); err != nil {
+		return nil, "", err
+	}
+	return k, key, nil
+}
+
+// DeleteAPIKey revokes an API key, including access tokens issued with it.
+func (s *ServiceAccountService) DeleteAPIKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error {
+	sa, err := s.dao.GetServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.DeleteAPIKey(ctx, serviceAccountID, keyID); err != nil {
+		return err
+	}
+	for _, k := range sa.APIKeys {
+		if k.ID == keyID {
+			return s.audit(ctx, domain.AuditAPIKeyDelete, sa, AuditChanges(k, nil))
+		}
+	}
+	return nil
+}
+
+// audit records an operation on a service account in the audit trail.
+func (s *ServiceAccountService) audit(ctx context.Context, action string, sa *domain.ServiceAccount, changes []domain.AuditChange) error {
+	return recordAudit(ctx, s.dao, &domain.AuditEvent{
+		Action:     action,
+		TargetType: domain.AuditTargetServiceAccount,
+		TargetID:   sa.ID.String(),
+		TargetName: sa.Name,
+		Changes:    changes,
+	})
+}
+
+// ClientCredentials implements the OAuth2 client credentials grant. The
+// client ID is the service account's ID or name and the client secret one of
+// its API keys. The optional scope is a space-separated list of permissions
+// narrowing those of the token. Errors are *OAuthError values except for
+// internal failures.
+func (s *ServiceAccountService) ClientCredentials(ctx context.Context, clientID, secret, scope string) (*domain.TokenResponse, error) {
+	k, sa, err := s.authenticateKey(ctx, secret)
+	if err != nil || (clientID != sa.ID.String() && clientID != sa.Name) {
+		if err := recordServiceAccountLoginAudit(ctx, s.dao, nil, clientID, domain.AuditFailure, domain.LoginReasonInvalidAPIKey); err != nil {
+			return nil, err
+		}
+		return nil, oauthError("invalid_client", "invalid client credentials")
+	}
+
+	perms := sa.Permissions
+	if scope != "" {
+		perms = strings.Fields(scope)
+		for _, p := range perms {
+			if !domain.HasPermission(sa.Permissions, p) {
+				return nil, oauthError("invalid_scope", "the service account does not hold %q", p)
+			}
+		}
+	}
+
+	now := time.Now()
+	token, err := s.keys.sign(ctx, &serviceAccountClaims{
+		Name:        sa.Name,
+		KeyID:       k.ID.String(),
+		Permissions: perms,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   sa.ID.String(),
+			Audience:  jwt.ClaimStrings{serviceAccountAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.tokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+	}, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := recordServiceAccountLoginAudit(ctx, s.dao, sa, sa.Name, domain.AuditSuccess, ""); err != nil {
+		return nil, err
+	}
+	return &domain.TokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.tokenTTL.Seconds()),
+		Scope:       strings.Join(perms, " "),
+	}, nil
+}
+
+// Authenticate resolves a bearer credential, either an API key or an access
+// token from ClientCredentials, to its service account. The returned
+// account's Permissions are those in effect for the credential: a token's
+// permissions are limited to those the account still holds.
+func (s *ServiceAccountService) Authenticate(ctx context.Context, credential string) (*domain.ServiceAccount, error) {
+	if strings.HasPrefix(credential, apiKeyPrefix) {
+		_, sa, err := s.authenticateKey(ctx, credential)
+		return sa, err
+	}
+
+	var claims serviceAccountClaims
+	token, err := jwt.ParseWithClaims(credential, &claims, s.keys.keyfunc(ctx),
+		jwt.WithValidMethods(validMethods()),
+		jwt.WithAudience(serviceAccountAudience),
+	)
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidAPIKey
+	}
+	saID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+	keyID, err := uuid.Parse(claims.KeyID)
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// Tokens die with the key they were issued for.
+	k, err := s.dao.GetAPIKey(ctx, keyID)
+	if err != nil || k.Expired(time.Now()) {
+		return nil, ErrInvalidAPIKey
+	}
+	sa, err := s.dao.GetServiceAccount(ctx, saID)
+	if err != nil || sa.Disabled || !hasKey(sa, keyID) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	var perms []string
+	for _, p := range claims.Permissions {
+		if domain.HasPermission(sa.Permissions, p) {
+			perms = append(perms, p)
+		}
+	}
+	sa.Permissions = perms
+	return sa, nil
+}
+
+// AuthenticateBind authenticates an LDAP simple bind as the named service
+// account with one of its API keys as the password. The account must hold
+// the ldap:bind permission.
+func (s *ServiceAccountService) AuthenticateBind(ctx context.Context, name, key string) (*domain.ServiceAccount, error) {
+	_, sa, err := s.authenticateKey(ctx, key)
+	if err == nil && sa.Name != name {
+		err = ErrInvalidAPIKey
+	}
+	if err != nil {
+		if rerr := recordServiceAccountLoginAudit(ctx, s.dao, nil, name, domain.AuditFailure, domain.LoginReasonInvalidAPIKey); rerr != nil {
+			return nil, rerr
+		}
+		return nil, err
+	}
+	if !domain.HasPermission(sa.Permissions, domain.PermissionLDAPBind) {
+		if err := recordServiceAccountLoginAudit(ctx, s.dao, sa, name, domain.AuditFailure, domain.LoginReasonNotPermitted); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidAPIKey
+	}
+	if err := recordServiceAccountLoginAudit(ctx, s.dao, sa, name, domain.AuditSuccess, ""); err != nil {
+		return nil, err
+	}
+	return sa, nil
+}
+
+// authenticateKey looks up an API key and its enabled service account and
+// records the key's use.
+func (s *ServiceAccountService) authenticateKey(ctx context.Context, key string) (*domain.APIKey, *domain.ServiceAccount, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	k, sa, err := s.dao.GetAPIKeyByHash(ctx, hashToken(key))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil, ErrInvalidAPIKey
+		}
+		return nil, nil, err
+	}
+	now := time.Now()
+	if sa.Disabled || k.Expired(now) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.dao.TouchAPIKey(ctx, k.ID, now); err != nil {
+			return nil, nil, err
+		}
+	}
+	return k, sa, nil
+}
+
+// checkPermissions validates permissions to be granted to a service account.
+func (s *ServiceAccountService) checkPermissions(ctx context.Context, perms []string) error {
+	if err := validatePermissions(perms, ErrInvalidServiceAccount); err != nil {
+		return err
+	}
+	return checkActorHolds(ctx, perms)
+}
+
+// checkActorHolds checks that the acting user, if any, holds all of perms.
+func checkActorHolds(ctx context.Context, perms []string) error {
+	if actor, ok := ActorFromContext(ctx); ok {
+		for _, p := range perms {
+			if !actor.Can(p) {
+				return fmt.Errorf("%w: cannot grant %q without holding it", ErrForbidden, p)
+			}
+		}
+	}
+	return nil
+}
+
+func hasKey(sa *domain.ServiceAccount, keyID uuid.UUID) bool {
+	for _, k := range sa.APIKeys {
+		if k.ID == keyID {
+			return true
+		}
+	}
+	return false
+}
+
+// newAPIKey generates an API key and returns it with its display prefix and
+// the hash under which it is stored.
+func newAPIKey() (key, prefix, hash string, err error) {
+	secret, _, err := newOpaqueToken()
+	if err != nil {
+		return "", "", "", err
+	}
+	key = apiKeyPrefix + secret
+	return key, key[:len(apiKeyPrefix)+8], hashToken(key), nil
+}
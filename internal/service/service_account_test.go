@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent/enttest"
+)
+
+func setupServiceAccountService(t *testing.T) (*ServiceAccountService, context.Context) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	d := dao.New(client)
+	ctx := context.Background()
+	if err := d.AutoMigrate(ctx); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	keySvc := NewKeyService(d, "ES256", 30*24*time.Hour, time.Hour)
+	if _, err := keySvc.RotateIfDue(ctx); err != nil {
+		t.Fatalf("init signing key: %v", err)
+	}
+	return NewServiceAccountService(d, keySvc, 15*time.Minute), ctx
+}
+
+func TestServiceAccountServiceCreateValidation(t *testing.T) {
+	s, ctx := setupServiceAccountService(t)
+
+	tests := []struct {
+		name  string
+		input domain.CreateServiceAccountInput
+		want  error
+	}{
+		{"invalid name", domain.CreateServiceAccountInput{Name: "HR Sync"}, ErrInvalidServiceAccount},
+		{"unknown permission", domain.CreateServiceAccountInput{Name: "hr-sync", Permissions: []string{"user:delete"}}, ErrInvalidServiceAccount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.CreateServiceAccount(ctx, tt.input); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// The acting user cannot grant permissions they do not hold.
+	actorCtx := WithActor(ctx, &domain.Actor{UserID: uuid.New(), Permissions: []string{domain.PermissionServiceAccount, domain.PermissionUserRead}})
+	if _, err := s.CreateServiceAccount(actorCtx, domain.CreateServiceAccountInput{
+		Name: "ci", Permissions: []string{domain.PermissionAll},
+	}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("escalation: err = %v, want ErrForbidden", err)
+	}
+	if _, err := s.CreateServiceAccount(actorCtx, domain.CreateServiceAccountInput{
+		Name: "ci", Permissions: []string{domain.PermissionUserRead},
+	}); err != nil {
+		t.Errorf("CreateServiceAccount: %v", err)
+	}
+}
+
+func TestServiceAccountServiceAPIKeys(t *testing.T) {
+	s, ctx := setupServiceAccountService(t)
+
+	sa, err := s.CreateServiceAccount(ctx, domain.CreateServiceAccountInput{
+		Name: "hr-sync", Permissions: []string{domain.PermissionUserRead},
+	})
+	if err != nil {
+		t.Fatalf("CreateServiceAccount: %v", err)
+	}
+	k, key, err := s.CreateAPIKey(ctx, sa.ID, domain.CreateAPIKeyInput{Name: "prod"})
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if key[:len(k.Prefix)] != k.Prefix {
+		t.Errorf("prefix %q does not start key", k.Prefix)
+	}
+
+	got, err := s.Authenticate(ctx, key)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if got.ID != sa.ID || !domain.HasPermission(got.Permissions, domain.PermissionUserRead) {
+		t.Errorf("unexpected service account %+v", got)
+	}
+	loaded, _ := s.GetServiceAccount(ctx, sa.ID)
+	if len(loaded.APIKeys) != 1 || loaded.APIKeys[0].LastUsedAt == nil {
+		t.Errorf("expected last use to be recorded, got %+v", loaded.APIKeys)
+	}
+
+	if _, err := s.Authenticate(ctx, key+"x"); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("wrong key: err = %v, want ErrInvalidAPIKey", err)
+	}
+
+	// Rotation invalidates the old value.
+	_, rotated, err := s.RotateAPIKey(ctx, sa.ID, k.ID)
+	if err != nil {
+		t.Fatalf("RotateAPIKey: %v", err)
+	}
+	if _, err := s.Authenticate(ctx, key); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("rotated key: err = %v, want ErrInvalidAPIKey", err)
+	}
+	if _, err := s.Authenticate(ctx, rotated); err != nil {
+		t.Errorf("new key: %v", err)
+	}
+
+	disabled := true
+	if _, err := s.UpdateServiceAccount(ctx, sa.ID, domain.UpdateServiceAccountInput{Disabled: &disabled}); err != nil {
+		t.Fatalf("UpdateServiceAccount: %v", err)
+	}
+	if _, err := s.Authenticate(ctx, rotated); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("disabled account: err = %v, want ErrInvalidAPIKey", err)
+	}
+}
+
+func TestServiceAccountServiceAPIKeyEscalation(t *testing.T) {
+	s, ctx := setupServiceAccountService(t)
+
+	sa, err := s.CreateServiceAccount(ctx, domain.CreateServiceAccountInput{
+		Name: "root-sync", Permissions: []string{domain.PermissionAll},
+	})
+	if err != nil {
+		t.Fatalf("CreateServiceAccount: %v", err)
+	}
+	k, _, err := s.CreateAPIKey(ctx, sa.ID, domain.CreateAPIKeyInput{Name: "prod"})
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+
+	// A key acts with the account's permissions, so the acting user must hold
+	// them all to create or rotate one.
+	actorCtx := WithActor(ctx, &domain.Actor{UserID: uuid.New(), Permissions: []string{domain.PermissionServiceAccount}})
+	if _, _, err := s.CreateAPIKey(actorCtx, sa.ID, domain.CreateAPIKeyInput{Name: "mine"}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("CreateAPIKey: err = %v, want ErrForbidden", err)
+	}
+	if _, _, err := s.RotateAPIKey(actorCtx, sa.ID, k.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("RotateAPIKey: err = %v, want ErrForbidden", err)
+	}
+	if loaded, _ := s.GetServiceAccount(ctx, sa.ID); len(loaded.APIKeys) != 1 || loaded.APIKeys[0].Prefix != k.Prefix {
+		t.Errorf("keys changed: %+v", loaded.APIKeys)
+	}
+
+	adminCtx := WithActor(ctx, &domain.Actor{UserID: uuid.New(), Permissions: []string{domain.PermissionAll}})
+	if _, _, err := s.CreateAPIKey(adminCtx, sa.ID, domain.CreateAPIKeyInput{Name: "admin"}); err != nil {
+		t.Errorf("CreateAPIKey by admin: %v", err)
+	}
+}
+
+func TestServiceAccountServiceExpiredKey(t *testing.T) {
+	s, ctx := setupServiceAccountService(t)
+
+	sa, _ := s.CreateServiceAccount(ctx, domain.CreateServiceAccountInput{Name: "ci"})
+	past := time.Now().Add(-time.Hour)
+	if _, _, err := s.CreateAPIKey(ctx, sa.ID, domain.CreateAPIKeyInput{Name: "old", ExpiresAt: &past}); !errors.Is(err, ErrInvalidServiceAccount) {
+		t.Errorf("past expiry: err = %v, want ErrInvalidServiceAccount", err)
+	}
+
+	soon := time.Now().Add(50 * time.Millisecond)
+	_, key, err := s.CreateAPIKey(ctx, sa.ID, domain.CreateAPIKeyInput{Name: "short", ExpiresAt: &soon})
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := s.Authenticate(ctx, key); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("expired key: err = %v, want ErrInvalidAPIKey", err)
+	}
+}
+
+func TestServiceAccountServiceClientCredentials(t *testing.T) {
+	s, ctx := setupServiceAccountService(t)
+
+	sa, _ := s.CreateServiceAccount(ctx, domain.CreateServiceAccountInput{
+		Name: "hr-sync", Permissions: []string{domain.PermissionUserRead, domain.PermissionUserWrite},
+	})
+	k, key, _ := s.CreateAPIKey(ctx, sa.ID, domain.CreateAPIKeyInput{Name: "prod"})
+
+	var oerr *OAuthError
+	if _, err := s.ClientCredentials(ctx, "other", key, ""); !errors.As(err, &oerr) || oerr.Code != "invalid_client" {
+		t.Errorf("wrong client_id: err = %v, want invalid_client", err)
+	}
+	if _, err := s.ClientCredentials(ctx, sa.Name, key, domain.PermissionGroupAdmin); !errors.As(err, &oerr) || oerr.Code != "invalid_scope" {
+		t.Errorf("excess scope: err = %v, want invalid_scope", err)
+	}
+
+	tokens, err := s.ClientCredentials(ctx, sa.ID.String(), key, domain.PermissionUserRead)
+	if err != nil {
+		t.Fatalf("ClientCredentials: %v", err)
+	}
+	got, err := s.Authenticate(ctx, tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate token: %v", err)
+	}
+	if len(got.Permissions) != 1 || got.Permissions[0] != domain.PermissionUserRead {
+		t.Errorf("Permissions = %v, want [%s]", got.Permissions, domain.PermissionUserRead)
+	}
+
+	// Removing a permission from the account takes effect on existing tokens.
+	if _, err := s.UpdateServiceAccount(ctx, sa.ID, domain.UpdateServiceAccountInput{Permissions: []string{domain.PermissionUserWrite}}); err != nil {
+		t.Fatalf("UpdateServiceAccount: %v", err)
+	}
+	got, err = s.Authenticate(ctx, tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate token: %v", err)
+	}
+	if len(got.Permissions) != 0 {
+		t.Errorf("Permissions = %v, want none", got.Permissions)
+	}
+
+	// Rotating the key revokes tokens issued with it.
+	rotated, key, err := s.RotateAPIKey(ctx, sa.ID, k.ID)
+	if err != nil {
+		t.Fatalf("RotateAPIKey: %v", err)
+	}
+	if rotated.ID == k.ID || rotated.Name != k.Name {
+		t.Errorf("rotated key = %+v, want a new ID and the name %q", rotated, k.Name)
+	}
+	if _, err := s.Authenticate(ctx, tokens.AccessToken); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("token of rotated key: err = %v, want ErrInvalidAPIKey", err)
+	}
+
+	// Deleting the key revokes tokens issued with it.
+	if tokens, err = s.ClientCredentials(ctx, sa.Name, key, ""); err != nil {
+		t.Fatalf("ClientCredentials: %v", err)
+	}
+	if err := s.DeleteAPIKey(ctx, sa.ID, rotated.ID); err != nil {
+		t.Fatalf("DeleteAPIKey: %v", err)
+	}
+	if _, err := s.Authenticate(ctx, tokens.AccessToken); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("token of deleted key: err = %v, want ErrInvalidAPIKey", err)
+	}
+}
+
+func TestServiceAccountServiceAuthenticateBind(t *testing.T) {
+	s, ctx := setupServiceAccountService(t)
+
+	reader, _ := s.CreateServiceAccount(ctx, domain.CreateServiceAccountInput{
+		Name: "wiki", Permissions: []string{domain.PermissionLDAPBind},
+	})
+	_, readerKey, _ := s.CreateAPIKey(ctx, reader.ID, domain.CreateAPIKeyInput{Name: "ldap"})
+	apiOnly, _ := s.CreateServiceAccount(ctx, domain.CreateServiceAccountInput{
+		Name: "ci", Permissions: []string{domain.PermissionUserRead},
+	})
+	_, apiKey, _ := s.CreateAPIKey(ctx, apiOnly.ID, domain.CreateAPIKeyInput{Name: "ci"})
+
+	if _, err := s.AuthenticateBind(ctx, "wiki", readerKey); err != nil {
+		t.Errorf("AuthenticateBind: %v", err)
+	}
+	if _, err := s.AuthenticateBind(ctx, "ci", readerKey); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("key of another account: err = %v, want ErrInvalidAPIKey", err)
+	}
+	if _, err := s.AuthenticateBind(ctx, "ci", apiKey); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("without ldap:bind: err = %v, want ErrInvalidAPIKey", err)
+	}
+}
@@ -2,34 +2,138 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/ssh"
 
 	"github.com/qinzj/claude-demo/internal/dao"
 	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
 	"github.com/qinzj/claude-demo/internal/ent/user"
+	"github.com/qinzj/claude-demo/internal/password"
 )
 
+// ErrInvalidSSHKey is returned when an SSH public key cannot be parsed or is
+// already registered for the user.
+var ErrInvalidSSHKey = errors.New("invalid ssh key")
+
+// ErrInvalidOldPassword is returned when a password change gives the wrong
+// current password.
+var ErrInvalidOldPassword = errors.New("invalid old password")
+
+// ErrInvalidManager is returned when a manager assignment would reference a
+// missing user or create a cycle in the reporting hierarchy.
+var ErrInvalidManager = errors.New("invalid manager")
+
+// ErrUserNotFound is returned for a user ID that matches no user.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrUnsupportedPasswordHash is returned when a user is imported with a
+// password hash in a scheme that cannot be verified.
+var ErrUnsupportedPasswordHash = errors.New("unsupported password hash")
+
 // UserService handles user business logic.
 type UserService struct {
-	dao *dao.DAO
+	dao     *dao.DAO
+	lockout domain.LockoutPolicy
+	hasher  password.Hasher
+
+	// passThrough verifies the passwords of users that authenticate
+	// against an upstream directory, if set by UsePassThrough.
+	passThrough *PassThroughService
+
+	mu         sync.Mutex
+	ipFailures map[string]*ipFailures
+}
+
+// NewUserService creates a new UserService. Failed logins lock accounts and
+// block client IP addresses as set by the lockout policy. New passwords are
+// hashed with hasher, and other hashes are upgraded to it at login.
+func NewUserService(d *dao.DAO, lockout domain.LockoutPolicy, hasher password.Hasher) *UserService {
+	return &UserService{dao: d, lockout: lockout, hasher: hasher, ipFailures: make(map[string]*ipFailures)}
 }
 
-// NewUserService creates a new UserService.
-func NewUserService(d *dao.DAO) *UserService {
-	return &UserService{dao: d}
+// withDAO returns a copy of the service that works through d, such as a
+// DAO in a transaction. The copy counts failed logins on its own.
+func (s *UserService) withDAO(d *dao.DAO) *UserService {
+	c := NewUserService(d, s.lockout, s.hasher)
+	if s.passThrough != nil {
+		pt := *s.passThrough
+		pt.dao = d
+		c.passThrough = &pt
+	}
+	return c
 }
 
-// CreateUser creates a new user with bcrypt-hashed password.
+// CreateUser creates a new user with a hashed password.
+// Extension attributes are validated against the declared user schema, and
+// the password against the default password policy, since a new user does
+// not belong to any group yet. An imported PasswordHash is stored as is,
+// without a policy check, and is rehashed at the user's first login. With
+// NoPassword the stored hash is empty, which never matches any password.
 func (s *UserService) CreateUser(ctx context.Context, input domain.CreateUserInput) (*domain.User, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if input.PasswordHash != "" {
+		if !password.Supported(input.PasswordHash) {
+			return nil, ErrUnsupportedPasswordHash
+		}
+	} else if !input.NoPassword {
+		rules, err := s.passwordRules(ctx, uuid.Nil)
+		if err != nil {
+			return nil, err
+		}
+		if v := checkPasswordQuality(rules, input.Password, input.Username, input.DisplayName, input.Email); len(v) > 0 {
+			return nil, &PasswordPolicyError{Violations: v}
+		}
+	}
+
+	defs, err := s.dao.ListAttributeDefinitions(ctx, domain.AttributeTargetUser)
 	if err != nil {
-		return nil, fmt.Errorf("hashing password: %w", err)
+		return nil, fmt.Errorf("loading attribute definitions: %w", err)
+	}
+	attrs, err := validateAttributeValues(defs, input.Attributes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := input.PasswordHash
+	if hash == "" && !input.NoPassword {
+		if hash, err = s.hasher.Hash(input.Password); err != nil {
+			return nil, err
+		}
 	}
 
-	return s.dao.CreateUser(ctx, input.Username, input.DisplayName, input.Email, string(hash), input.Phone)
+	u, err := s.dao.CreateUser(ctx, input.Username, input.DisplayName, input.Email, hash, input.Phone)
+	if err != nil {
+		return nil, err
+	}
+	if input.MustChangePassword {
+		if err := s.dao.SetMustChangePassword(ctx, u.ID, true); err != nil {
+			return nil, err
+		}
+		u.MustChangePassword = true
+	}
+	if input.AccountExpiresAt != nil {
+		if u, err = s.dao.UpdateUser(ctx, u.ID, domain.UpdateUserInput{AccountExpiresAt: input.AccountExpiresAt}); err != nil {
+			return nil, err
+		}
+	}
+	if len(attrs) > 0 {
+		if err := s.dao.SetUserAttributes(ctx, u.ID, attrs); err != nil {
+			return nil, err
+		}
+		if u, err = s.dao.GetUserByID(ctx, u.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.audit(ctx, domain.AuditUserCreate, u, AuditChanges(nil, u)); err != nil {
+		return nil, err
+	}
+	return u, nil
 }
 
 // GetUser retrieves a user by ID with groups.
@@ -50,62 +154,562 @@ func (s *UserService) ListUsers(ctx context.Context, input domain.ListUsersInput
 	if input.PageSize < 1 || input.PageSize > 100 {
 		input.PageSize = 20
 	}
-	return s.dao.ListUsers(ctx, input.Page, input.PageSize, input.Search)
+
+	var attrs map[string]string
+	if len(input.Attributes) > 0 {
+		defs, err := s.dao.ListAttributeDefinitions(ctx, domain.AttributeTargetUser)
+		if err != nil {
+			return nil, fmt.Errorf("loading attribute definitions: %w", err)
+		}
+		if attrs, err = validateAttributeFilter(defs, input.Attributes); err != nil {
+			return nil, err
+		}
+	}
+	return s.dao.ListUsers(ctx, input.Page, input.PageSize, input.Search, attrs)
 }
 
 // UpdateUser partially updates a user.
 func (s *UserService) UpdateUser(ctx context.Context, id uuid.UUID, input domain.UpdateUserInput) (*domain.User, error) {
-	return s.dao.UpdateUser(ctx, id, input)
+	if len(input.Attributes) > 0 {
+		defs, err := s.dao.ListAttributeDefinitions(ctx, domain.AttributeTargetUser)
+		if err != nil {
+			return nil, fmt.Errorf("loading attribute definitions: %w", err)
+		}
+		if input.Attributes, err = validateAttributeValues(defs, input.Attributes, false); err != nil {
+			return nil, err
+		}
+	}
+
+	before, err := s.dao.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeUserManagement(ctx, before); err != nil {
+		return nil, err
+	}
+	if input.ManagerID != nil && !input.ClearManager {
+		if err := s.checkManager(ctx, id, *input.ManagerID); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := s.dao.UpdateUser(ctx, id, input); err != nil {
+		return nil, err
+	}
+	u, err := s.dao.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditUserUpdate, u, AuditChanges(before, u)); err != nil {
+		return nil, err
+	}
+	return u, nil
 }
 
 // DeleteUser deletes a user.
 func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	return s.dao.DeleteUser(ctx, id)
+	u, err := s.dao.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeUserManagement(ctx, u); err != nil {
+		return err
+	}
+	if err := s.dao.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditUserDelete, u, AuditChanges(u, nil))
 }
 
-// ChangePassword verifies the old password and sets the new one.
+// ChangePassword verifies the old password and sets the new one, which
+// must satisfy the user's password policies. Unless the user is being forced
+// to change it, the old password must also have reached its minimum age.
 func (s *UserService) ChangePassword(ctx context.Context, id uuid.UUID, oldPassword, newPassword string) error {
 	u, err := s.dao.GetUserByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("getting user: %w", err)
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(oldPassword)); err != nil {
-		return fmt.Errorf("invalid old password: %w", err)
+	if err := s.checkClient(ctx, u.Username); err != nil {
+		return err
+	}
+	src, err := s.passThroughSource(ctx, u)
+	if err != nil {
+		return err
+	}
+	if src != nil {
+		return fmt.Errorf("%w: change it in %s", ErrPasswordManagedUpstream, src.Name)
+	}
+	if err := s.verifyPassword(ctx, u, oldPassword); err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			return ErrInvalidOldPassword
+		}
+		return err
+	}
+	if err := s.clearFailedLogins(ctx, u); err != nil {
+		return err
+	}
+
+	if err := s.setPassword(ctx, u, newPassword, true, false); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditUserPasswordChange, u, auditPasswordChange)
+}
+
+// ResetPassword sets a user's password on an administrator's behalf,
+// without the old password and regardless of its age. The new password must
+// still satisfy the user's password policies. With mustChange set, the user
+// has to choose a new password at the next login. The passwords of users
+// who authenticate against an upstream directory cannot be reset here.
+func (s *UserService) ResetPassword(ctx context.Context, id uuid.UUID, newPassword string, mustChange bool) error {
+	u, err := s.dao.GetUserByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting user: %w", err)
+	}
+	if err := s.authorizeUserManagement(ctx, u); err != nil {
+		return err
+	}
+	src, err := s.passThroughSource(ctx, u)
+	if err != nil {
+		return err
+	}
+	if src != nil {
+		return fmt.Errorf("%w: reset it in %s", ErrPasswordManagedUpstream, src.Name)
+	}
+	if err := s.setPassword(ctx, u, newPassword, false, mustChange); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditUserPasswordReset, u, auditPasswordChange)
+}
+
+// ImportPasswordHash replaces a user's password with an existing hash, such
+// as one synchronized from another directory. Like an imported hash of a
+// new user, it is not checked against the password policies, and is
+// rehashed at the user's next login.
+func (s *UserService) ImportPasswordHash(ctx context.Context, id uuid.UUID, hash string) error {
+	if !password.Supported(hash) {
+		return ErrUnsupportedPasswordHash
+	}
+	u, err := s.dao.GetUserByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting user: %w", err)
+	}
+	if err := s.authorizeUserManagement(ctx, u); err != nil {
+		return err
+	}
+	rules, err := s.passwordRules(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.UpdateUserPassword(ctx, id, hash, false, rules.HistoryCount); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditUserPasswordReset, u, auditPasswordChange)
+}
+
+// PasswordStatus returns the rules that apply to a user's password and
+// whether it has expired or must be changed.
+func (s *UserService) PasswordStatus(ctx context.Context, u *domain.User) (*domain.PasswordStatus, error) {
+	rules, err := s.passwordRules(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	st := &domain.PasswordStatus{
+		Rules:      rules,
+		ChangedAt:  u.PasswordChangedAt,
+		ExpiresAt:  rules.ExpiresAt(u.PasswordChangedAt),
+		MustChange: u.MustChangePassword,
+	}
+	// Passwords verified upstream expire there, if at all.
+	src, err := s.passThroughSource(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	if src != nil {
+		st.PassThrough = src.Name
+		st.ExpiresAt, st.MustChange = nil, false
+	}
+	st.Expired = st.ExpiresAt != nil && !time.Now().Before(*st.ExpiresAt)
+	return st, nil
+}
+
+// PasswordChangeRequired reports whether the user's password has expired
+// or was reset with a forced change, so the user may do nothing but change it.
+func (s *UserService) PasswordChangeRequired(ctx context.Context, u *domain.User) (bool, error) {
+	st, err := s.PasswordStatus(ctx, u)
+	if err != nil {
+		return false, err
+	}
+	return st.MustChange || st.Expired, nil
+}
+
+// passwordRules merges the default policy with the policies of the groups
+// the user directly belongs to. For uuid.Nil only the default applies.
+func (s *UserService) passwordRules(ctx context.Context, userID uuid.UUID) (domain.PasswordRules, error) {
+	policies, err := s.dao.UserPasswordPolicies(ctx, userID)
+	if err != nil {
+		return domain.PasswordRules{}, err
+	}
+	var rules domain.PasswordRules
+	for _, p := range policies {
+		rules = rules.Merge(p.PasswordRules)
+	}
+	return rules, nil
+}
+
+// setPassword checks a new password against the user's policies and stores
+// it. selfService enforces the minimum password age.
+func (s *UserService) setPassword(ctx context.Context, u *domain.User, password string, selfService, mustChange bool) error {
+	rules, violations, err := s.passwordViolations(ctx, u, password, selfService)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return err
 	}
+	return s.dao.UpdateUserPassword(ctx, u.ID, hash, mustChange, rules.HistoryCount)
+}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+// passwordViolations checks a new password of a user against their
+// policies and returns the rules applied. selfService enforces the minimum
+// password age.
+func (s *UserService) passwordViolations(ctx context.Context, u *domain.User, password string, selfService bool) (domain.PasswordRules, []domain.PasswordViolation, error) {
+	rules, err := s.passwordRules(ctx, u.ID)
 	if err != nil {
-		return fmt.Errorf("hashing new password: %w", err)
+		return rules, nil, err
+	}
+
+	violations := checkPasswordQuality(rules, password, u.Username, u.DisplayName, u.Email)
+	minAge := time.Duration(rules.MinAgeHours) * time.Hour
+	if selfService && minAge > 0 && !u.MustChangePassword && time.Since(u.PasswordChangedAt) < minAge {
+		violations = append(violations, domain.PasswordViolation{
+			Code:    domain.PasswordTooRecent,
+			Message: fmt.Sprintf("password was changed less than %d hours ago", rules.MinAgeHours),
+		})
 	}
+	if rules.HistoryCount > 0 {
+		reused, err := s.passwordReused(ctx, u, password, rules.HistoryCount)
+		if err != nil {
+			return rules, nil, err
+		}
+		if reused {
+			violations = append(violations, domain.PasswordViolation{
+				Code:    domain.PasswordReused,
+				Message: fmt.Sprintf("password must differ from the last %d passwords", rules.HistoryCount),
+			})
+		}
+	}
+	return rules, violations, nil
+}
 
-	return s.dao.UpdateUserPassword(ctx, id, string(hash))
+// passwordReused reports whether the password matches the current one or
+// one of the last n previous passwords.
+func (s *UserService) passwordReused(ctx context.Context, u *domain.User, password string, n int) (bool, error) {
+	history, err := s.dao.PasswordHistory(ctx, u.ID, n)
+	if err != nil {
+		return false, err
+	}
+	for _, h := range append([]string{u.PasswordHash}, history...) {
+		if passwordMatches(h, password) {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
-// SetUserStatus enables or disables a user.
+// SetUserStatus enables or disables a user. Disabling a user also revokes all
+// of their sessions, so re-enabling the account does not revive old tokens.
+// Pending users can be disabled but become enabled only by accepting their
+// invitation.
 func (s *UserService) SetUserStatus(ctx context.Context, id uuid.UUID, status domain.UserStatus) error {
-	return s.dao.UpdateUserStatus(ctx, id, user.Status(status))
+	u, err := s.dao.GetUserByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting user: %w", err)
+	}
+	if err := s.authorizeUserManagement(ctx, u); err != nil {
+		return err
+	}
+	if status == domain.UserStatusEnabled && u.Status == domain.UserStatusPending {
+		return fmt.Errorf("%w: %q", ErrUserPending, u.Username)
+	}
+	if err := s.dao.UpdateUserStatus(ctx, id, user.Status(status)); err != nil {
+		return err
+	}
+	if status == domain.UserStatusDisabled {
+		if _, err := s.dao.RevokeUserSessions(ctx, id); err != nil {
+			return err
+		}
+	}
+	return s.auditStatus(ctx, u, status)
+}
+
+// auditStatus records a change of a user's status.
+func (s *UserService) auditStatus(ctx context.Context, u *domain.User, status domain.UserStatus) error {
+	if u.Status == status {
+		return nil
+	}
+	return s.audit(ctx, domain.AuditUserStatus, u, []domain.AuditChange{{Field: "status", Old: u.Status, New: status}})
 }
 
-// Authenticate verifies a username/password combination. Used by both HTTP login and LDAP Bind.
+// Authenticate verifies a username/password combination. Used by both HTTP
+// login and LDAP Bind. Failed attempts are recorded and, under the lockout
+// policy, lock the account or block the client's IP address; refused
+// attempts return a *LockoutError.
 func (s *UserService) Authenticate(ctx context.Context, username, password string) (*domain.User, error) {
+	u, err := s.authenticate(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loginSucceeded(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// authenticate is Authenticate for logins that go on to check a second
+// factor: earlier failed logins are kept until loginSucceeded.
+func (s *UserService) authenticate(ctx context.Context, username, password string) (*domain.User, error) {
+	if err := s.checkClient(ctx, username); err != nil {
+		return nil, err
+	}
+
 	u, err := s.dao.GetUserByUsername(ctx, username)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		if !ent.IsNotFound(err) {
+			return nil, err
+		}
+		s.countIPFailure(RequestInfoFromContext(ctx).IPAddress, time.Now())
+		if err := s.recordLoginEvent(ctx, nil, username, domain.LoginEventFailure, domain.LoginReasonUnknownUser); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: user not found", ErrInvalidCredentials)
 	}
 
 	if u.Status != domain.UserStatusEnabled {
-		return nil, fmt.Errorf("user %q is disabled", username)
+		if err := s.recordLoginEvent(ctx, u, username, domain.LoginEventRejected, domain.LoginReasonDisabled); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %q", ErrUserDisabled, username)
 	}
-
-	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)); err != nil {
-		return nil, fmt.Errorf("invalid credentials: %w", err)
+	if u.Expired(time.Now()) {
+		if err := s.recordLoginEvent(ctx, u, username, domain.LoginEventRejected, domain.LoginReasonExpired); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %q", ErrAccountExpired, username)
 	}
 
+	if err := s.verifyPassword(ctx, u, password); err != nil {
+		return nil, err
+	}
 	return u, nil
 }
 
+// AddSSHKey parses an authorized_keys formatted public key and registers it
+// for the user. The key's comment is used as its name when none is given.
+func (s *UserService) AddSSHKey(ctx context.Context, userID uuid.UUID, name, publicKey string) (*domain.SSHKey, error) {
+	pk, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSSHKey, err)
+	}
+	if name == "" {
+		name = comment
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidSSHKey)
+	}
+
+	normalized := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pk)))
+	if comment != "" {
+		normalized += " " + comment
+	}
+
+	k, err := s.dao.CreateSSHKey(ctx, userID, name, normalized, ssh.FingerprintSHA256(pk))
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, fmt.Errorf("%w: key is already registered", ErrInvalidSSHKey)
+		}
+		return nil, err
+	}
+	if err := s.auditSSHKey(ctx, domain.AuditUserSSHKeyAdd, userID, AuditChanges(nil, k)); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// ListSSHKeys returns the SSH public keys registered by a user.
+func (s *UserService) ListSSHKeys(ctx context.Context, userID uuid.UUID) ([]*domain.SSHKey, error) {
+	return s.dao.ListSSHKeys(ctx, userID)
+}
+
+// DeleteSSHKey removes one of the user's SSH public keys.
+func (s *UserService) DeleteSSHKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	keys, err := s.dao.ListSSHKeys(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.DeleteSSHKey(ctx, userID, keyID); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if k.ID == keyID {
+			return s.auditSSHKey(ctx, domain.AuditUserSSHKeyDelete, userID, AuditChanges(k, nil))
+		}
+	}
+	return nil
+}
+
+// auditSSHKey records a change to a user's SSH keys.
+func (s *UserService) auditSSHKey(ctx context.Context, action string, userID uuid.UUID, changes []domain.AuditChange) error {
+	u, err := s.dao.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("getting user: %w", err)
+	}
+	return s.audit(ctx, action, u, changes)
+}
+
+// GetUserPermissions returns the permissions granted to a user through roles
+// assigned directly or via group membership.
+func (s *UserService) GetUserPermissions(ctx context.Context, id uuid.UUID) ([]string, error) {
+	return s.dao.UserPermissions(ctx, id)
+}
+
 // AllUsers returns all users (for LDAP search).
 func (s *UserService) AllUsers(ctx context.Context) ([]*domain.User, error) {
 	return s.dao.AllUsers(ctx)
 }
+
+// FindUsers returns a page of the users matching f, ordered by username,
+// with their groups, and the number of matching users. A negative limit
+// returns all of them.
+func (s *UserService) FindUsers(ctx context.Context, f domain.UserFilter, offset, limit int) ([]*domain.User, int, error) {
+	return s.dao.FindUsers(ctx, f, offset, limit)
+}
+
+// authorizeUserManagement checks whether the actor may take over or disrupt
+// a user's account: change it, reset its password or MFA, change its status,
+// revoke its sessions or delete it. Calls without an actor are internal and
+// always allowed, as are actors managing themselves and holders of the
+// role:admin permission; everyone else must hold every permission the user
+// has, so that user:write does not let them take over a more privileged
+// account.
+func (s *UserService) authorizeUserManagement(ctx context.Context, u *domain.User) error {
+	actor, ok := ActorFromContext(ctx)
+	if !ok || actor.UserID == u.ID || actor.Can(domain.PermissionRoleAdmin) {
+		return nil
+	}
+	perms, err := s.dao.UserPermissions(ctx, u.ID)
+	if err != nil {
+		return err
+	}
+	for _, p := range perms {
+		if !actor.Can(p) {
+			return fmt.Errorf("%w: %q has the %s permission", ErrForbidden, u.Username, p)
+		}
+	}
+	return nil
+}
+
+// authorizeUserManagementByID is authorizeUserManagement for a user that is
+// not loaded yet.
+func (s *UserService) authorizeUserManagementByID(ctx context.Context, id uuid.UUID) error {
+	if _, ok := ActorFromContext(ctx); !ok {
+		return nil
+	}
+	u, err := s.dao.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.authorizeUserManagement(ctx, u)
+}
+
+// audit records an operation on a user in the audit trail.
+func (s *UserService) audit(ctx context.Context, action string, u *domain.User, changes []domain.AuditChange) error {
+	return recordAudit(ctx, s.dao, &domain.AuditEvent{
+		Action:     action,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   u.ID.String(),
+		TargetName: u.Username,
+		Changes:    changes,
+	})
+}
+
+// checkManager verifies that managerID exists and that assigning it to the
+// user does not make the user their own (indirect) manager.
+func (s *UserService) checkManager(ctx context.Context, id, managerID uuid.UUID) error {
+	if managerID == id {
+		return fmt.Errorf("%w: a user cannot be their own manager", ErrInvalidManager)
+	}
+
+	visited := map[uuid.UUID]bool{id: true}
+	next := &managerID
+	for next != nil {
+		if visited[*next] {
+			return fmt.Errorf("%w: assignment would create a reporting cycle", ErrInvalidManager)
+		}
+		visited[*next] = true
+
+		m, err := s.dao.GetUserByID(ctx, *next)
+		if err != nil {
+			if *next == managerID {
+				return fmt.Errorf("%w: manager %s not found", ErrInvalidManager, managerID)
+			}
+			return fmt.Errorf("walking management chain: %w", err)
+		}
+		next = m.ManagerID
+	}
+	return nil
+}
+
+// GetOrgChart returns the user's management chain and the tree of everyone
+// reporting to them, directly or indirectly.
+func (s *UserService) GetOrgChart(ctx context.Context, id uuid.UUID) (*domain.OrgChart, error) {
+	users, err := s.dao.AllUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*domain.User, len(users))
+	reports := make(map[uuid.UUID][]*domain.User)
+	for _, u := range users {
+		byID[u.ID] = u
+		if u.ManagerID != nil {
+			reports[*u.ManagerID] = append(reports[*u.ManagerID], u)
+		}
+	}
+
+	root, ok := byID[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, id)
+	}
+
+	chart := &domain.OrgChart{User: root, Chain: []*domain.User{}}
+	seen := map[uuid.UUID]bool{id: true}
+	for m := root.ManagerID; m != nil; {
+		mgr, ok := byID[*m]
+		if !ok || seen[mgr.ID] {
+			break
+		}
+		seen[mgr.ID] = true
+		chart.Chain = append(chart.Chain, mgr)
+		m = mgr.ManagerID
+	}
+
+	var build func(uuid.UUID) []*domain.OrgNode
+	build = func(managerID uuid.UUID) []*domain.OrgNode {
+		nodes := make([]*domain.OrgNode, 0, len(reports[managerID]))
+		for _, r := range reports[managerID] {
+			if seen[r.ID] {
+				continue
+			}
+			seen[r.ID] = true
+			nodes = append(nodes, &domain.OrgNode{User: r, Reports: build(r.ID)})
+		}
+		return nodes
+	}
+	chart.Reports = build(id)
+	return chart, nil
+}
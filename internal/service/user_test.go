@@ -2,13 +2,21 @@ package service
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	_ "github.com/mattn/go-sqlite3"
+	"golang.org/x/crypto/ssh"
 
 	"github.com/qinzj/claude-demo/internal/dao"
 	"github.com/qinzj/claude-demo/internal/domain"
 	"github.com/qinzj/claude-demo/internal/ent/enttest"
+	"github.com/qinzj/claude-demo/internal/password"
 )
 
 func setupUserService(t *testing.T) (*UserService, context.Context) {
@@ -20,7 +28,7 @@ func setupUserService(t *testing.T) (*UserService, context.Context) {
 	if err := d.AutoMigrate(ctx); err != nil {
 		t.Fatalf("auto migrate: %v", err)
 	}
-	return NewUserService(d), ctx
+	return NewUserService(d, domain.LockoutPolicy{}, password.Hasher{}), ctx
 }
 
 func TestUserServiceCreateUser(t *testing.T) {
@@ -92,6 +100,130 @@ func TestUserServiceAuthenticateDisabled(t *testing.T) {
 	}
 }
 
+func TestUserServiceAuthenticateExpired(t *testing.T) {
+	svc, ctx := setupUserService(t)
+
+	past := time.Now().Add(-time.Hour)
+	u, err := svc.CreateUser(ctx, domain.CreateUserInput{
+		Username:         "john",
+		DisplayName:      "John Doe",
+		Email:            "john@example.com",
+		Password:         "password123",
+		AccountExpiresAt: &past,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if u.AccountExpiresAt == nil || !u.AccountExpiresAt.Equal(past) {
+		t.Fatalf("AccountExpiresAt = %v, want %v", u.AccountExpiresAt, past)
+	}
+
+	if _, err := svc.Authenticate(ctx, "john", "password123"); !errors.Is(err, ErrAccountExpired) {
+		t.Errorf("expected ErrAccountExpired, got %v", err)
+	}
+
+	future := time.Now().Add(time.Hour)
+	if _, err := svc.UpdateUser(ctx, u.ID, domain.UpdateUserInput{AccountExpiresAt: &future}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if _, err := svc.Authenticate(ctx, "john", "password123"); err != nil {
+		t.Errorf("Authenticate before expiry: %v", err)
+	}
+
+	u, err = svc.UpdateUser(ctx, u.ID, domain.UpdateUserInput{ClearAccountExpiry: true})
+	if err != nil || u.AccountExpiresAt != nil {
+		t.Errorf("clearing expiry: AccountExpiresAt = %v, err = %v", u.AccountExpiresAt, err)
+	}
+}
+
+func TestUserServiceImportedPasswordHash(t *testing.T) {
+	svc, ctx := setupUserService(t)
+
+	if _, err := svc.CreateUser(ctx, domain.CreateUserInput{
+		Username:     "legacy",
+		DisplayName:  "Legacy",
+		Email:        "legacy@example.com",
+		PasswordHash: "{MD5}X03MO1qnZdYdgyfeuILPmQ==",
+	}); !errors.Is(err, ErrUnsupportedPasswordHash) {
+		t.Errorf("expected ErrUnsupportedPasswordHash, got %v", err)
+	}
+
+	// Imported hashes skip the password policy: this one is of "Secret#Pass1".
+	const ssha = "{SSHA}iCtdldPg/c58ZJoQvbiJWBq2QQsBAgMEBQYHCA=="
+	u, err := svc.CreateUser(ctx, domain.CreateUserInput{
+		Username:     "john",
+		DisplayName:  "John Doe",
+		Email:        "john@example.com",
+		PasswordHash: ssha,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if u.PasswordHash != ssha {
+		t.Errorf("PasswordHash = %q, want the imported hash", u.PasswordHash)
+	}
+
+	if _, err := svc.Authenticate(ctx, "john", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("wrong password: expected ErrInvalidCredentials, got %v", err)
+	}
+	if u, _ = svc.GetUser(ctx, u.ID); u.PasswordHash != ssha {
+		t.Error("hash changed after a failed login")
+	}
+
+	if _, err := svc.Authenticate(ctx, "john", "Secret#Pass1"); err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	upgraded, _ := svc.GetUser(ctx, u.ID)
+	if !strings.HasPrefix(upgraded.PasswordHash, "$2a$") {
+		t.Errorf("hash after login = %q, want bcrypt", upgraded.PasswordHash)
+	}
+	if !upgraded.PasswordChangedAt.Equal(u.PasswordChangedAt) {
+		t.Error("rehashing counted as a password change")
+	}
+
+	// A service configured for argon2id moves bcrypt hashes over too.
+	argon := NewUserService(svc.dao, domain.LockoutPolicy{}, password.Hasher{
+		Algorithm: password.Argon2id, Argon2Memory: 1024, Argon2Iterations: 1, Argon2Parallelism: 1,
+	})
+	if _, err := argon.Authenticate(ctx, "john", "Secret#Pass1"); err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if u, _ = svc.GetUser(ctx, u.ID); !strings.HasPrefix(u.PasswordHash, "$argon2id$") {
+		t.Errorf("hash after login = %q, want argon2id", u.PasswordHash)
+	}
+	if _, err := argon.Authenticate(ctx, "john", "Secret#Pass1"); err != nil {
+		t.Errorf("Authenticate with argon2id hash: %v", err)
+	}
+}
+
+func TestUserServiceCreateUserWithoutPassword(t *testing.T) {
+	svc, ctx := setupUserService(t)
+
+	u, err := svc.CreateUser(ctx, domain.CreateUserInput{
+		Username:    "provisioned",
+		DisplayName: "Provisioned",
+		Email:       "provisioned@example.com",
+		NoPassword:  true,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if u.PasswordHash != "" {
+		t.Errorf("PasswordHash = %q, want none", u.PasswordHash)
+	}
+	for _, pw := range []string{"", "password123"} {
+		if _, err := svc.Authenticate(ctx, "provisioned", pw); !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("Authenticate(%q): expected ErrInvalidCredentials, got %v", pw, err)
+		}
+	}
+	if err := svc.ResetPassword(ctx, u.ID, "password123", false); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if _, err := svc.Authenticate(ctx, "provisioned", "password123"); err != nil {
+		t.Errorf("Authenticate after reset: %v", err)
+	}
+}
+
 func TestUserServiceChangePassword(t *testing.T) {
 	svc, ctx := setupUserService(t)
 
@@ -159,3 +291,226 @@ func TestUserServiceListUsers(t *testing.T) {
 		t.Errorf("Items = %d, want 3", len(result.Items))
 	}
 }
+
+func TestUserServiceUpdateManagerCycle(t *testing.T) {
+	svc, ctx := setupUserService(t)
+
+	var ids []uuid.UUID
+	for _, name := range []string{"alice", "bob", "carol"} {
+		u, err := svc.CreateUser(ctx, domain.CreateUserInput{
+			Username:    name,
+			DisplayName: name,
+			Email:       name + "@example.com",
+			Password:    "password",
+		})
+		if err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+		ids = append(ids, u.ID)
+	}
+	alice, bob, carol := ids[0], ids[1], ids[2]
+
+	// carol -> bob -> alice
+	if _, err := svc.UpdateUser(ctx, bob, domain.UpdateUserInput{ManagerID: &alice}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if _, err := svc.UpdateUser(ctx, carol, domain.UpdateUserInput{ManagerID: &bob}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+
+	missing := uuid.New()
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		manager uuid.UUID
+	}{
+		{"self", alice, alice},
+		{"direct cycle", alice, bob},
+		{"indirect cycle", alice, carol},
+		{"missing manager", alice, missing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.UpdateUser(ctx, tt.id, domain.UpdateUserInput{ManagerID: &tt.manager})
+			if !errors.Is(err, ErrInvalidManager) {
+				t.Errorf("UpdateUser() error = %v, want ErrInvalidManager", err)
+			}
+		})
+	}
+}
+
+func TestUserServiceGetOrgChart(t *testing.T) {
+	svc, ctx := setupUserService(t)
+
+	users := make(map[string]*domain.User)
+	for _, name := range []string{"ceo", "cto", "dev1", "dev2"} {
+		u, err := svc.CreateUser(ctx, domain.CreateUserInput{
+			Username:    name,
+			DisplayName: name,
+			Email:       name + "@example.com",
+			Password:    "password",
+		})
+		if err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+		users[name] = u
+	}
+	for report, manager := range map[string]string{"cto": "ceo", "dev1": "cto", "dev2": "cto"} {
+		if _, err := svc.UpdateUser(ctx, users[report].ID, domain.UpdateUserInput{ManagerID: &users[manager].ID}); err != nil {
+			t.Fatalf("UpdateUser: %v", err)
+		}
+	}
+
+	chart, err := svc.GetOrgChart(ctx, users["cto"].ID)
+	if err != nil {
+		t.Fatalf("GetOrgChart: %v", err)
+	}
+	if len(chart.Chain) != 1 || chart.Chain[0].Username != "ceo" {
+		t.Errorf("Chain = %v, want [ceo]", chart.Chain)
+	}
+	if len(chart.Reports) != 2 {
+		t.Errorf("Reports = %d, want 2", len(chart.Reports))
+	}
+
+	chart, err = svc.GetOrgChart(ctx, users["ceo"].ID)
+	if err != nil {
+		t.Fatalf("GetOrgChart: %v", err)
+	}
+	if len(chart.Chain) != 0 {
+		t.Errorf("Chain = %d, want 0", len(chart.Chain))
+	}
+	if len(chart.Reports) != 1 || len(chart.Reports[0].Reports) != 2 {
+		t.Errorf("unexpected subordinate tree for ceo")
+	}
+
+	if _, err := svc.GetOrgChart(ctx, uuid.New()); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("GetOrgChart(unknown user) err = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestUserServiceSSHKeys(t *testing.T) {
+	svc, ctx := setupUserService(t)
+
+	alice, _ := svc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "alice", DisplayName: "Alice", Email: "alice@example.com", Password: "password",
+	})
+	bob, _ := svc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "bob", DisplayName: "Bob", Email: "bob@example.com", Password: "password",
+	})
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("ssh public key: %v", err)
+	}
+	authorized := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPub))) + " alice@laptop"
+
+	if _, err := svc.AddSSHKey(ctx, alice.ID, "", "not a key"); !errors.Is(err, ErrInvalidSSHKey) {
+		t.Errorf("AddSSHKey(garbage) error = %v, want ErrInvalidSSHKey", err)
+	}
+
+	k, err := svc.AddSSHKey(ctx, alice.ID, "", authorized)
+	if err != nil {
+		t.Fatalf("AddSSHKey: %v", err)
+	}
+	if k.Name != "alice@laptop" {
+		t.Errorf("Name = %q, want comment as default name", k.Name)
+	}
+	if k.Fingerprint != ssh.FingerprintSHA256(sshPub) {
+		t.Errorf("Fingerprint = %q, want %q", k.Fingerprint, ssh.FingerprintSHA256(sshPub))
+	}
+
+	if _, err := svc.AddSSHKey(ctx, alice.ID, "again", authorized); !errors.Is(err, ErrInvalidSSHKey) {
+		t.Errorf("AddSSHKey(duplicate) error = %v, want ErrInvalidSSHKey", err)
+	}
+	if _, err := svc.AddSSHKey(ctx, bob.ID, "shared", authorized); err != nil {
+		t.Errorf("AddSSHKey(same key, other user): %v", err)
+	}
+
+	if err := svc.DeleteSSHKey(ctx, bob.ID, k.ID); err == nil {
+		t.Error("expected error deleting another user's key")
+	}
+	if err := svc.DeleteSSHKey(ctx, alice.ID, k.ID); err != nil {
+		t.Fatalf("DeleteSSHKey: %v", err)
+	}
+	keys, _ := svc.ListSSHKeys(ctx, alice.ID)
+	if len(keys) != 0 {
+		t.Errorf("keys = %d, want 0", len(keys))
+	}
+}
+
+func TestUserServiceManagePrivilegedUser(t *testing.T) {
+	mfaSvc, authSvc, d, admin, ctx := setupMFAService(t)
+	userSvc := mfaSvc.userService
+	adminRole, err := d.CreateRole(ctx, domain.CreateRoleInput{Name: "admin", Permissions: []string{domain.PermissionAll}}, false)
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	if err := d.AddRoleUsers(ctx, adminRole.ID, []uuid.UUID{admin.ID}); err != nil {
+		t.Fatalf("AddRoleUsers: %v", err)
+	}
+	staff, err := userSvc.CreateUser(ctx, domain.CreateUserInput{Username: "staff", DisplayName: "Staff", Email: "staff@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	// A helpdesk actor with user:write cannot take over the administrator.
+	helpdesk := WithActor(ctx, &domain.Actor{UserID: uuid.New(), Permissions: []string{domain.PermissionUserRead, domain.PermissionUserWrite}})
+	// DeleteUser goes last, as it deletes the staff user.
+	for _, tt := range []struct {
+		name string
+		call func(context.Context, uuid.UUID) error
+	}{
+		{"ResetPassword", func(ctx context.Context, id uuid.UUID) error {
+			return userSvc.ResetPassword(ctx, id, "Newpass-123", true)
+		}},
+		{"SetUserStatus", func(ctx context.Context, id uuid.UUID) error {
+			return userSvc.SetUserStatus(ctx, id, domain.UserStatusDisabled)
+		}},
+		{"UpdateUser", func(ctx context.Context, id uuid.UUID) error {
+			email := "taken-over@example.com"
+			_, err := userSvc.UpdateUser(ctx, id, domain.UpdateUserInput{Email: &email})
+			return err
+		}},
+		{"Revert", func(ctx context.Context, id uuid.UUID) error {
+			_, err := userSvc.Revert(ctx, id, 1)
+			return err
+		}},
+		{"ResetMFA", mfaSvc.Reset},
+		{"RevokeSessions", func(ctx context.Context, id uuid.UUID) error {
+			_, err := authSvc.RevokeSessions(ctx, id)
+			return err
+		}},
+		{"DeleteUser", userSvc.DeleteUser},
+	} {
+		if err := tt.call(helpdesk, admin.ID); !errors.Is(err, ErrForbidden) {
+			t.Errorf("%s(admin) by user:write actor: err = %v, want ErrForbidden", tt.name, err)
+		}
+		// Users holding no more permissions than the actor can be managed.
+		if err := tt.call(helpdesk, staff.ID); err != nil {
+			t.Errorf("%s(staff) by user:write actor: %v", tt.name, err)
+		}
+	}
+	if _, err := userSvc.GetUser(ctx, admin.ID); err != nil {
+		t.Fatalf("admin was deleted: %v", err)
+	}
+	// Authorization comes before the manager is looked up, so the error
+	// does not tell whether a user ID exists.
+	missing := uuid.New()
+	if _, err := userSvc.UpdateUser(helpdesk, admin.ID, domain.UpdateUserInput{ManagerID: &missing}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("UpdateUser(admin) with a missing manager by user:write actor: err = %v, want ErrForbidden", err)
+	}
+
+	// Role administrators and the user themselves can.
+	roleAdmin := WithActor(ctx, &domain.Actor{UserID: uuid.New(), Permissions: []string{domain.PermissionUserWrite, domain.PermissionRoleAdmin}})
+	if err := userSvc.ResetPassword(roleAdmin, admin.ID, "Newpass-123", true); err != nil {
+		t.Errorf("ResetPassword by role admin: %v", err)
+	}
+	self := WithActor(ctx, &domain.Actor{UserID: admin.ID})
+	if _, err := authSvc.RevokeSessions(self, admin.ID); err != nil {
+		t.Errorf("RevokeSessions by self: %v", err)
+	}
+}
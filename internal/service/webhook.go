@@ -0,0 +1,389 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+)
+
+var (
+	// ErrInvalidWebhook is returned when a webhook subscription fails
+	// validation or a delivery cannot be retried.
+	ErrInvalidWebhook = errors.New("invalid webhook")
+	// ErrWebhookNotFound is returned for an unknown webhook subscription or
+	// delivery.
+	ErrWebhookNotFound = errors.New("webhook not found")
+)
+
+// webhookSecretPrefix starts every generated webhook secret.
+const webhookSecretPrefix = "whsec_"
+
+// webhookBatchSize is how many events are fanned out, and how many
+// deliveries attempted concurrently, at a time.
+const webhookBatchSize = 50
+
+// webhookPurgeInterval is how often events and deliveries older than the
+// retention period are purged.
+const webhookPurgeInterval = time.Hour
+
+// Headers of webhook requests. The signature is "sha256=" followed by the
+// hex HMAC-SHA256, keyed with the subscription's secret, of the timestamp,
+// a '.' and the body; see WebhookSignature.
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookIDHeader        = "X-Webhook-ID"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// WebhookService manages webhook subscriptions and delivers the outbox
+// events the DAO writes with every change to users, groups and
+// memberships.
+type WebhookService struct {
+	dao    *dao.DAO
+	policy domain.WebhookPolicy
+	client *http.Client
+}
+
+// NewWebhookService creates a new WebhookService delivering according to
+// policy.
+func NewWebhookService(d *dao.DAO, policy domain.WebhookPolicy) *WebhookService {
+	return &WebhookService{dao: d, policy: policy, client: &http.Client{Timeout: policy.Timeout}}
+}
+
+// audit records an operation on a webhook subscription in the audit trail.
+func (s *WebhookService) audit(ctx context.Context, action string, sub *domain.WebhookSubscription, changes []domain.AuditChange) error {
+	return recordAudit(ctx, s.dao, &domain.AuditEvent{
+		Action:     action,
+		TargetType: domain.AuditTargetWebhook,
+		TargetID:   sub.ID.String(),
+		TargetName: sub.Name,
+		Changes:    changes,
+	})
+}
+
+// CreateWebhook creates a webhook subscription, generating its secret if
+// none is given. The returned subscription carries the secret, which
+// cannot be retrieved later.
+func (s *WebhookService) CreateWebhook(ctx context.Context, input domain.CreateWebhookInput) (*domain.WebhookSubscription, error) {
+	if err := validateWebhookURL(input.URL); err != nil {
+		return nil, err
+	}
+	if err := validateEventTypes(input.EventTypes); err != nil {
+		return nil, err
+	}
+	if input.Secret == "" {
+		secret, _, err := newOpaqueToken()
+		if err != nil {
+			return nil, err
+		}
+		input.Secret = webhookSecretPrefix + secret
+	}
+
+	sub, err := s.dao.CreateWebhook(ctx, input)
+	if ent.IsConstraintError(err) {
+		return nil, fmt.Errorf("%w: name %q is taken", ErrInvalidWebhook, input.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, domain.AuditWebhookCreate, sub, AuditChanges(nil, sub)); err != nil {
+		return nil, err
+	}
+	sub.Secret = input.Secret
+	return sub, nil
+}
+
+// GetWebhook retrieves a webhook subscription, without its secret.
+func (s *WebhookService) GetWebhook(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	sub, err := s.dao.GetWebhook(ctx, id)
+	return sub, webhookError(err)
+}
+
+// ListWebhooks returns all webhook subscriptions.
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	return s.dao.ListWebhooks(ctx)
+}
+
+// UpdateWebhook updates a webhook subscription. Disabling it holds its
+// deliveries until it is enabled again; events are not delivered to it
+// while it is disabled.
+func (s *WebhookService) UpdateWebhook(ctx context.Context, id uuid.UUID, input domain.UpdateWebhookInput) (*domain.WebhookSubscription, error) {
+	if input.URL != nil {
+		if err := validateWebhookURL(*input.URL); err != nil {
+			return nil, err
+		}
+	}
+	if input.EventTypes != nil {
+		if err := validateEventTypes(input.EventTypes); err != nil {
+			return nil, err
+		}
+	}
+	if input.Secret != nil && *input.Secret == "" {
+		return nil, fmt.Errorf("%w: secret must not be empty", ErrInvalidWebhook)
+	}
+	before, err := s.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := s.dao.UpdateWebhook(ctx, id, input)
+	if ent.IsConstraintError(err) {
+		return nil, fmt.Errorf("%w: name %q is taken", ErrInvalidWebhook, *input.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	changes := AuditChanges(before, sub)
+	if input.Secret != nil {
+		changes = append(changes, domain.AuditChange{Field: "secret", Redacted: true})
+	}
+	if err := s.audit(ctx, domain.AuditWebhookUpdate, sub, changes); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// DeleteWebhook deletes a webhook subscription and its deliveries.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	sub, err := s.GetWebhook(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.DeleteWebhook(ctx, id); err != nil {
+		return err
+	}
+	return s.audit(ctx, domain.AuditWebhookDelete, sub, AuditChanges(sub, nil))
+}
+
+// ListDeliveries returns the deliveries of a subscription, newest first,
+// optionally only those with the given status.
+func (s *WebhookService) ListDeliveries(ctx context.Context, id uuid.UUID, status domain.WebhookDeliveryStatus, page, pageSize int) (*domain.ListResult[domain.WebhookDelivery], error) {
+	switch status {
+	case "", domain.WebhookPending, domain.WebhookDelivered, domain.WebhookDead:
+	default:
+		return nil, fmt.Errorf("%w: unknown delivery status %q", ErrInvalidWebhook, status)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	if _, err := s.GetWebhook(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.dao.ListWebhookDeliveries(ctx, id, status, page, pageSize)
+}
+
+// RetryDelivery makes a dead-lettered delivery pending again, with a full
+// set of attempts, e.g. once the receiver is fixed.
+func (s *WebhookService) RetryDelivery(ctx context.Context, id, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	dl, err := s.dao.GetWebhookDelivery(ctx, id, deliveryID)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+	if dl.Status != domain.WebhookDead {
+		return nil, fmt.Errorf("%w: only dead deliveries can be retried", ErrInvalidWebhook)
+	}
+	if err := s.dao.RequeueWebhookDelivery(ctx, deliveryID); err != nil {
+		return nil, err
+	}
+	return s.dao.GetWebhookDelivery(ctx, id, deliveryID)
+}
+
+// Run dispatches webhooks every poll interval, and purges those older than
+// the retention period every purge interval, until ctx is done, passing
+// errors to onError.
+func (s *WebhookService) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(s.policy.PollInterval)
+	defer ticker.Stop()
+	var purged time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Dispatch(ctx); err != nil && ctx.Err() == nil {
+				onError(err)
+			}
+			if time.Since(purged) >= webhookPurgeInterval {
+				purged = time.Now()
+				if err := s.Purge(ctx); err != nil && ctx.Err() == nil {
+					onError(err)
+				}
+			}
+		}
+	}
+}
+
+// Purge deletes the deliveries that were delivered or dead-lettered, and
+// the dispatched events without pending deliveries, longer ago than the
+// retention period.
+func (s *WebhookService) Purge(ctx context.Context) error {
+	_, _, err := s.dao.PurgeWebhookHistory(ctx, time.Now().Add(-s.policy.Retention))
+	return err
+}
+
+// Dispatch fans new outbox events out to the subscriptions matching them
+// and attempts the deliveries that are due, until none are left.
+func (s *WebhookService) Dispatch(ctx context.Context) error {
+	for {
+		n, err := s.dao.FanOutEvents(ctx, webhookBatchSize)
+		if err != nil {
+			return err
+		}
+		if n < webhookBatchSize {
+			break
+		}
+	}
+	for {
+		// Claim the deliveries for longer than an attempt may take, so
+		// that they are retried should this dispatcher stop meanwhile.
+		now := time.Now()
+		due, err := s.dao.ClaimWebhookDeliveries(ctx, now, now.Add(2*s.policy.Timeout), webhookBatchSize)
+		if err != nil {
+			return err
+		}
+
+		attempts := make([]domain.WebhookAttempt, len(due))
+		var wg sync.WaitGroup
+		for i, w := range due {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				attempts[i] = s.attempt(ctx, w)
+			}()
+		}
+		wg.Wait()
+		for i, w := range due {
+			if err := s.dao.RecordWebhookAttempt(ctx, w.Delivery.ID, attempts[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(due) < webhookBatchSize {
+			return nil
+		}
+	}
+}
+
+// attempt sends a delivery and returns its outcome. A response status
+// other than 2xx is a failure, retried after a backoff until the attempts
+// run out.
+func (s *WebhookService) attempt(ctx context.Context, w *domain.DueWebhook) domain.WebhookAttempt {
+	code, err := s.send(ctx, w)
+	if err == nil {
+		return domain.WebhookAttempt{Status: domain.WebhookDelivered, StatusCode: code, NextAttemptAt: time.Now()}
+	}
+
+	a := domain.WebhookAttempt{Status: domain.WebhookPending, StatusCode: code, Error: err.Error()}
+	attempts := w.Delivery.Attempts + 1
+	if attempts >= s.policy.MaxAttempts {
+		a.Status = domain.WebhookDead
+		a.NextAttemptAt = time.Now()
+	} else {
+		a.NextAttemptAt = time.Now().Add(s.policy.Backoff(attempts))
+	}
+	return a
+}
+
+// send posts a delivery's event to its subscription and returns the
+// response status code.
+func (s *WebhookService) send(ctx context.Context, w *domain.DueWebhook) (int, error) {
+	body, err := json.Marshal(w.Event)
+	if err != nil {
+		return 0, fmt.Errorf("encoding event: %w", err)
+	}
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.Subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, w.Event.Type)
+	req.Header.Set(WebhookIDHeader, w.Event.ID.String())
+	req.Header.Set(WebhookDeliveryHeader, w.Delivery.ID.String())
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, WebhookSignature(w.Subscription.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// WebhookSignature returns the signature header of a webhook request with
+// the given timestamp and body. Receivers compute it with the secret
+// and compare it to the header, and should reject old timestamps to
+// prevent replays.
+func WebhookSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookError returns ErrWebhookNotFound for a not found error and err
+// otherwise.
+func webhookError(err error) error {
+	if ent.IsNotFound(err) {
+		return ErrWebhookNotFound
+	}
+	return err
+}
+
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	return nil
+}
+
+// validateEventTypes checks that every filter is "*", a known event type or
+// a prefix of known event types ending in ".*".
+func validateEventTypes(types []string) error {
+	if len(types) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", ErrInvalidWebhook)
+	}
+	for _, t := range types {
+		if t == "*" || slices.Contains(domain.KnownEventTypes, t) {
+			continue
+		}
+		prefix, ok := strings.CutSuffix(t, "*")
+		if ok && strings.HasSuffix(prefix, ".") && slices.ContainsFunc(domain.KnownEventTypes, func(k string) bool {
+			return strings.HasPrefix(k, prefix)
+		}) {
+			continue
+		}
+		return fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, t)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+// webhookReceiver is an httptest receiver recording the events it accepts.
+// It fails the first failures requests.
+type webhookReceiver struct {
+	*httptest.Server
+	failures atomic.Int32
+	mu       sync.Mutex
+	events   []domain.WebhookEvent
+	headers  []http.Header
+	bodies   [][]byte
+}
+
+func newWebhookReceiver(t *testing.T) *webhookReceiver {
+	r := &webhookReceiver{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(req.Body)
+		var e domain.WebhookEvent
+		if err := json.Unmarshal(body, &e); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.mu.Lock()
+		r.events = append(r.events, e)
+		r.headers = append(r.headers, req.Header.Clone())
+		r.bodies = append(r.bodies, body)
+		r.mu.Unlock()
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+// types returns the types of the events received, sorted, since
+// deliveries may arrive in any order.
+func (r *webhookReceiver) types() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var types []string
+	for _, e := range r.events {
+		types = append(types, e.Type)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// event returns the received event of a type.
+func (r *webhookReceiver) event(eventType string) (domain.WebhookEvent, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.events {
+		if e.Type == eventType {
+			return e, true
+		}
+	}
+	return domain.WebhookEvent{}, false
+}
+
+func setupWebhookService(t *testing.T) (*WebhookService, *UserService, *GroupService, context.Context) {
+	t.Helper()
+	_, userSvc, d, ctx := setupAuditService(t)
+	svc := NewWebhookService(d, domain.WebhookPolicy{
+		MaxAttempts:  3,
+		Timeout:      5 * time.Second,
+		RetryBackoff: time.Millisecond,
+		MaxBackoff:   time.Millisecond,
+	})
+	return svc, userSvc, NewGroupService(d), ctx
+}
+
+// dispatch dispatches webhooks until retries due within a few milliseconds
+// have been attempted.
+func dispatch(t *testing.T, svc *WebhookService, ctx context.Context) {
+	t.Helper()
+	for range 5 {
+		if err := svc.Dispatch(ctx); err != nil {
+			t.Fatalf("Dispatch: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	svc, userSvc, groupSvc, ctx := setupWebhookService(t)
+	recv := newWebhookReceiver(t)
+
+	sub, err := svc.CreateWebhook(ctx, domain.CreateWebhookInput{Name: "slack", URL: recv.URL, EventTypes: []string{"user.*"}})
+	if err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	if sub.Secret == "" {
+		t.Fatal("CreateWebhook did not return a generated secret")
+	}
+
+	u, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+		Username: "frank", DisplayName: "Frank", Email: "frank@example.com", Password: "Secret-pass-1",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if err := userSvc.SetUserStatus(ctx, u.ID, domain.UserStatusDisabled); err != nil {
+		t.Fatalf("SetUserStatus: %v", err)
+	}
+	if _, err := groupSvc.CreateGroup(ctx, domain.CreateGroupInput{Name: "ops"}); err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	dispatch(t, svc, ctx)
+
+	want := []string{domain.EventUserCreated, domain.EventUserDisabled, domain.EventUserUpdated}
+	if got := recv.types(); !slices.Equal(got, want) {
+		t.Fatalf("received %v, want %v", got, want)
+	}
+	for i, h := range recv.headers {
+		ts, _ := strconv.ParseInt(h.Get(WebhookTimestampHeader), 10, 64)
+		if got := h.Get(WebhookSignatureHeader); got != WebhookSignature(sub.Secret, ts, recv.bodies[i]) {
+			t.Errorf("signature %q does not verify", got)
+		}
+		if h.Get(WebhookEventHeader) != recv.events[i].Type || h.Get(WebhookIDHeader) != recv.events[i].ID.String() {
+			t.Errorf("headers = %v, want the event type and ID", h)
+		}
+	}
+	disabled, _ := recv.event(domain.EventUserDisabled)
+	var data domain.UserEventData
+	if err := json.Unmarshal(disabled.Data, &data); err != nil || data.User.Username != "frank" || data.Previous == nil {
+		t.Errorf("user.disabled data = %s, want frank before and after", disabled.Data)
+	}
+
+	deliveries, err := svc.ListDeliveries(ctx, sub.ID, domain.WebhookDelivered, 1, 20)
+	if err != nil || deliveries.Total != 3 {
+		t.Errorf("delivered = %+v, %v, want 3", deliveries, err)
+	}
+}
+
+func TestWebhookRetryAndDeadLetter(t *testing.T) {
+	svc, userSvc, _, ctx := setupWebhookService(t)
+	recv := newWebhookReceiver(t)
+
+	sub, err := svc.CreateWebhook(ctx, domain.CreateWebhookInput{Name: "hr", URL: recv.URL, EventTypes: []string{domain.EventUserCreated}})
+	if err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	create := func(name string) {
+		t.Helper()
+		if _, err := userSvc.CreateUser(ctx, domain.CreateUserInput{
+			Username: name, DisplayName: name, Email: name + "@example.com", Password: "Secret-pass-1",
+		}); err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+	}
+
+	t.Run("failed attempts are retried", func(t *testing.T) {
+		recv.failures.Store(2)
+		create("grace")
+		dispatch(t, svc, ctx)
+		result, _ := svc.ListDeliveries(ctx, sub.ID, "", 1, 20)
+		if result.Total != 1 || result.Items[0].Status != domain.WebhookDelivered || result.Items[0].Attempts != 3 {
+			t.Errorf("deliveries = %+v, want one delivered on the third attempt", result.Items)
+		}
+	})
+
+	t.Run("deliveries out of attempts are dead", func(t *testing.T) {
+		recv.failures.Store(10)
+		create("heidi")
+		dispatch(t, svc, ctx)
+		dead, _ := svc.ListDeliveries(ctx, sub.ID, domain.WebhookDead, 1, 20)
+		if dead.Total != 1 || dead.Items[0].Attempts != 3 || dead.Items[0].LastStatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("dead deliveries = %+v, want one after 3 attempts", dead.Items)
+		}
+
+		recv.failures.Store(0)
+		dl, err := svc.RetryDelivery(ctx, sub.ID, dead.Items[0].ID)
+		if err != nil || dl.Status != domain.WebhookPending || dl.Attempts != 0 {
+			t.Fatalf("RetryDelivery = %+v, %v, want pending", dl, err)
+		}
+		dispatch(t, svc, ctx)
+		if got := recv.types(); len(got) != 2 {
+			t.Errorf("received %v, want both users after the retry", got)
+		}
+		if _, err := svc.RetryDelivery(ctx, sub.ID, dead.Items[0].ID); !errors.Is(err, ErrInvalidWebhook) {
+			t.Errorf("RetryDelivery(delivered) err = %v, want ErrInvalidWebhook", err)
+		}
+	})
+}
+
+func TestWebhookValidation(t *testing.T) {
+	svc, _, _, ctx := setupWebhookService(t)
+
+	for _, input := range []domain.CreateWebhookInput{
+		{Name: "a", URL: "ftp://example.com", EventTypes: []string{"*"}},
+		{Name: "b", URL: "/relative", EventTypes: []string{"*"}},
+		{Name: "c", URL: "https://example.com", EventTypes: nil},
+		{Name: "d", URL: "https://example.com", EventTypes: []string{"user.renamed"}},
+		{Name: "e", URL: "https://example.com", EventTypes: []string{"role.*"}},
+	} {
+		if _, err := svc.CreateWebhook(ctx, input); !errors.Is(err, ErrInvalidWebhook) {
+			t.Errorf("CreateWebhook(%+v) err = %v, want ErrInvalidWebhook", input, err)
+		}
+	}
+
+	sub, err := svc.CreateWebhook(ctx, domain.CreateWebhookInput{Name: "ok", URL: "https://example.com", Secret: "given", EventTypes: []string{"group.*", domain.EventUserDeleted}})
+	if err != nil || sub.Secret != "given" {
+		t.Fatalf("CreateWebhook = %+v, %v, want the given secret", sub, err)
+	}
+	if _, err := svc.CreateWebhook(ctx, domain.CreateWebhookInput{Name: "ok", URL: "https://example.com", EventTypes: []string{"*"}}); !errors.Is(err, ErrInvalidWebhook) {
+		t.Errorf("CreateWebhook(taken name) err = %v, want ErrInvalidWebhook", err)
+	}
+	got, err := svc.GetWebhook(ctx, sub.ID)
+	if err != nil || got.Secret != "" {
+		t.Errorf("GetWebhook = %+v, %v, want it without the secret", got, err)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	p := domain.WebhookPolicy{RetryBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+	for attempts, want := range map[int]time.Duration{
+		1: 30 * time.Second,
+		2: time.Minute,
+		4: 4 * time.Minute,
+		5: 5 * time.Minute,
+		9: 5 * time.Minute,
+	} {
+		if got := p.Backoff(attempts); got != want {
+			t.Errorf("Backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 seed from RFC 6238 Appendix B.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestHOTPVectors(t *testing.T) {
+	// RFC 4226 Appendix D.
+	want := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+	for i, w := range want {
+		if got := hotp([]byte("12345678901234567890"), uint64(i), 6); got != w {
+			t.Errorf("counter %d: got %s, want %s", i, got, w)
+		}
+	}
+}
+
+func TestTOTPVectors(t *testing.T) {
+	// RFC 6238 Appendix B, SHA-1, 8 digits.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	key := []byte("12345678901234567890")
+	for _, tt := range tests {
+		if got := hotp(key, uint64(Step(time.Unix(tt.unix, 0))), 8); got != tt.want {
+			t.Errorf("t=%d: got %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, err := Code(rfcSecret, now)
+	if err != nil {
+		t.Fatalf("Code: %v", err)
+	}
+	if code != "005924" {
+		t.Fatalf("got code %s, want 005924", code)
+	}
+
+	step, ok := Validate(rfcSecret, code, now)
+	if !ok || step != Step(now) {
+		t.Fatalf("Validate current step: got (%d, %v)", step, ok)
+	}
+	if _, ok := Validate(rfcSecret, code, now.Add(Period*time.Second)); !ok {
+		t.Error("code from the previous step should be accepted")
+	}
+	if _, ok := Validate(rfcSecret, code, now.Add(2*Period*time.Second)); ok {
+		t.Error("code two steps old should be rejected")
+	}
+	if _, ok := Validate(rfcSecret, "000000", now); ok {
+		t.Error("wrong code should be rejected")
+	}
+	if _, ok := Validate(rfcSecret, "5924", now); ok {
+		t.Error("short code should be rejected")
+	}
+	if _, ok := Validate("not base32!", code, now); ok {
+		t.Error("invalid secret should be rejected")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	b, _ := GenerateSecret()
+	if a == b {
+		t.Error("secrets should differ")
+	}
+	if len(a) != 32 {
+		t.Errorf("got secret length %d, want 32", len(a))
+	}
+	if _, err := Code(a, time.Now()); err != nil {
+		t.Errorf("generated secret should decode: %v", err)
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	u, err := url.Parse(ProvisioningURI("Claude Demo", "alice", "JBSWY3DPEHPK3PXP"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/Claude Demo:alice" {
+		t.Errorf("unexpected URI %s", u)
+	}
+	q := u.Query()
+	if q.Get("secret") != "JBSWY3DPEHPK3PXP" || q.Get("issuer") != "Claude Demo" || q.Get("digits") != "6" {
+		t.Errorf("unexpected query %v", q)
+	}
+}
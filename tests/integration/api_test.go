@@ -1,10 +1,20 @@
 package integration
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
 	"github.com/qinzj/claude-demo/internal/domain"
 )
 
@@ -77,6 +87,7 @@ func TestUserLifecycle(t *testing.T) {
 		Email:       "lcadmin@test.com",
 		Password:    "password123",
 	})
+	grantAdmin(t, "lifecycleadmin")
 	token := loginAndGetToken(t, "lifecycleadmin", "password123")
 
 	// Create user
@@ -210,6 +221,7 @@ func TestGroupLifecycle(t *testing.T) {
 		Email:       "gadmin@test.com",
 		Password:    "password123",
 	})
+	grantAdmin(t, "groupadmin")
 	token := loginAndGetToken(t, "groupadmin", "password123")
 
 	// Create member user
@@ -326,6 +338,7 @@ func TestValidationErrors(t *testing.T) {
 		Email:       "valadmin@test.com",
 		Password:    "password123",
 	})
+	grantAdmin(t, "valadmin")
 	token := loginAndGetToken(t, "valadmin", "password123")
 
 	t.Run("create user missing required fields", func(t *testing.T) {
@@ -369,3 +382,765 @@ func TestValidationErrors(t *testing.T) {
 		}
 	})
 }
+
+func TestCustomAttributes(t *testing.T) {
+	userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "attradmin",
+		DisplayName: "Attr Admin",
+		Email:       "attradmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "attradmin")
+	token := loginAndGetToken(t, "attradmin", "password123")
+
+	t.Run("declare attribute", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/attributes", map[string]interface{}{
+			"name":      "costCenter",
+			"target":    "user",
+			"type":      "integer",
+			"ldap_name": "costCenter",
+			"indexed":   true,
+		}, token)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("create attribute failed: %s", r.Message)
+		}
+	})
+
+	t.Run("reject invalid value", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/users", map[string]interface{}{
+			"username":     "attruser-bad",
+			"display_name": "Attr User Bad",
+			"email":        "attrbad@test.com",
+			"password":     "password123",
+			"attributes":   map[string][]string{"costCenter": {"not-a-number"}},
+		}, token)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	var userID string
+	t.Run("create user with attribute", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/users", map[string]interface{}{
+			"username":     "attruser",
+			"display_name": "Attr User",
+			"email":        "attruser@test.com",
+			"password":     "password123",
+			"attributes":   map[string][]string{"costCenter": {"4711"}},
+		}, token)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("create user failed: %s", r.Message)
+		}
+		var data struct {
+			ID         string              `json:"id"`
+			Attributes map[string][]string `json:"attributes"`
+		}
+		json.Unmarshal(r.Data, &data)
+		userID = data.ID
+		if got := data.Attributes["costCenter"]; len(got) != 1 || got[0] != "4711" {
+			t.Errorf("costCenter = %v, want [4711]", got)
+		}
+	})
+
+	t.Run("list by attribute", func(t *testing.T) {
+		resp := doAPI(t, "GET", "/api/v1/users?attr.costCenter=4711", nil, token)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("list users failed: %s", r.Message)
+		}
+		var data struct {
+			Items []struct {
+				ID string `json:"id"`
+			} `json:"items"`
+			Total int `json:"total"`
+		}
+		json.Unmarshal(r.Data, &data)
+		if data.Total != 1 || data.Items[0].ID != userID {
+			t.Errorf("expected only attruser, got %+v", data)
+		}
+	})
+}
+
+func TestManagerHierarchy(t *testing.T) {
+	userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "orgadmin",
+		DisplayName: "Org Admin",
+		Email:       "orgadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "orgadmin")
+	token := loginAndGetToken(t, "orgadmin", "password123")
+
+	headID := createUserViaAPI(t, token, map[string]string{
+		"username": "orghead", "display_name": "Org Head", "email": "orghead@test.com", "password": "password123",
+	})
+	leadID := createUserViaAPI(t, token, map[string]string{
+		"username": "orglead", "display_name": "Org Lead", "email": "orglead@test.com", "password": "password123",
+	})
+	devID := createUserViaAPI(t, token, map[string]string{
+		"username": "orgdev", "display_name": "Org Dev", "email": "orgdev@test.com", "password": "password123",
+	})
+
+	t.Run("assign managers", func(t *testing.T) {
+		for id, manager := range map[string]string{leadID: headID, devID: leadID} {
+			resp := doAPI(t, "PUT", "/api/v1/users/"+id, map[string]string{"manager_id": manager}, token)
+			r := parseResponse(t, resp)
+			if r.Code != 0 {
+				t.Fatalf("assign manager failed: %s", r.Message)
+			}
+		}
+	})
+
+	t.Run("reject cycle", func(t *testing.T) {
+		resp := doAPI(t, "PUT", "/api/v1/users/"+headID, map[string]string{"manager_id": devID}, token)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("org chart", func(t *testing.T) {
+		resp := doAPI(t, "GET", "/api/v1/users/"+leadID+"/org", nil, token)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("get org chart failed: %s", r.Message)
+		}
+		var data struct {
+			Chain []struct {
+				ID string `json:"id"`
+			} `json:"chain"`
+			Reports []struct {
+				User struct {
+					ID string `json:"id"`
+				} `json:"user"`
+			} `json:"reports"`
+		}
+		json.Unmarshal(r.Data, &data)
+		if len(data.Chain) != 1 || data.Chain[0].ID != headID {
+			t.Errorf("chain = %v, want [%s]", data.Chain, headID)
+		}
+		if len(data.Reports) != 1 || data.Reports[0].User.ID != devID {
+			t.Errorf("reports = %v, want [%s]", data.Reports, devID)
+		}
+	})
+
+	t.Run("clear manager", func(t *testing.T) {
+		resp := doAPI(t, "PUT", "/api/v1/users/"+devID, map[string]string{"manager_id": ""}, token)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("clear manager failed: %s", r.Message)
+		}
+		var data struct {
+			ManagerID *string `json:"manager_id"`
+		}
+		json.Unmarshal(r.Data, &data)
+		if data.ManagerID != nil {
+			t.Errorf("manager_id = %q, want unset", *data.ManagerID)
+		}
+	})
+}
+
+func TestGroupOwnership(t *testing.T) {
+	for _, name := range []string{"ownerone", "ownertwo"} {
+		userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+			Username:    name,
+			DisplayName: name,
+			Email:       name + "@test.com",
+			Password:    "password123",
+		})
+	}
+	grantAdmin(t, "ownerone")
+	ownerToken := loginAndGetToken(t, "ownerone", "password123")
+	otherToken := loginAndGetToken(t, "ownertwo", "password123")
+
+	memberID := createUserViaAPI(t, ownerToken, map[string]string{
+		"username": "ownedmember", "display_name": "Owned Member", "email": "ownedmember@test.com", "password": "password123",
+	})
+	groupID := createGroupViaAPI(t, ownerToken, map[string]interface{}{"name": "owned-group"})
+
+	t.Run("creator owns group", func(t *testing.T) {
+		resp := doAPI(t, "GET", "/api/v1/groups/"+groupID, nil, ownerToken)
+		r := parseResponse(t, resp)
+		var data struct {
+			OwnerUsers []struct {
+				Username string `json:"username"`
+			} `json:"owner_users"`
+		}
+		json.Unmarshal(r.Data, &data)
+		if len(data.OwnerUsers) != 1 || data.OwnerUsers[0].Username != "ownerone" {
+			t.Errorf("owner_users = %v, want [ownerone]", data.OwnerUsers)
+		}
+	})
+
+	t.Run("non-owner cannot add members", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/groups/"+groupID+"/members", map[string]interface{}{
+			"user_ids": []string{memberID},
+		}, otherToken)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("owner adds members", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/groups/"+groupID+"/members", map[string]interface{}{
+			"user_ids": []string{memberID},
+		}, ownerToken)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("add members failed: %s", r.Message)
+		}
+	})
+
+	t.Run("owner delegates ownership", func(t *testing.T) {
+		other, err := userSvc.GetUserByUsername(t.Context(), "ownertwo")
+		if err != nil {
+			t.Fatalf("get user: %v", err)
+		}
+		resp := doAPI(t, "POST", "/api/v1/groups/"+groupID+"/owners", map[string]interface{}{
+			"user_ids": []string{other.ID.String()},
+		}, ownerToken)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("add owners failed: %s", r.Message)
+		}
+
+		resp = doAPI(t, "DELETE", "/api/v1/groups/"+groupID+"/members/"+memberID, nil, otherToken)
+		r = parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("remove member as new owner failed: %s", r.Message)
+		}
+	})
+}
+
+func TestRBAC(t *testing.T) {
+	for _, name := range []string{"rbacadmin", "rbacplain"} {
+		userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+			Username:    name,
+			DisplayName: name,
+			Email:       name + "@test.com",
+			Password:    "password123",
+		})
+	}
+	grantAdmin(t, "rbacadmin")
+	adminToken := loginAndGetToken(t, "rbacadmin", "password123")
+	plainToken := loginAndGetToken(t, "rbacplain", "password123")
+	plain, _ := userSvc.GetUserByUsername(t.Context(), "rbacplain")
+	admin, _ := userSvc.GetUserByUsername(t.Context(), "rbacadmin")
+
+	t.Run("create user requires token", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/users", map[string]string{
+			"username": "rbacanon", "display_name": "Anon", "email": "rbacanon@test.com", "password": "password123",
+		}, "")
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("user without roles is forbidden", func(t *testing.T) {
+		for _, req := range []struct{ method, path string }{
+			{"GET", "/api/v1/users"},
+			{"DELETE", "/api/v1/users/" + admin.ID.String()},
+			{"GET", "/api/v1/roles"},
+			{"PUT", "/api/v1/ldap/config"},
+		} {
+			resp := doAPI(t, req.method, req.path, map[string]string{}, plainToken)
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("%s %s: expected 403, got %d", req.method, req.path, resp.StatusCode)
+			}
+			resp.Body.Close()
+		}
+	})
+
+	t.Run("user may change own password", func(t *testing.T) {
+		resp := doAPI(t, "PUT", "/api/v1/users/"+plain.ID.String()+"/password", map[string]string{
+			"old_password": "password123",
+			"new_password": "password123",
+		}, plainToken)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("change own password failed: %s", r.Message)
+		}
+
+		resp = doAPI(t, "PUT", "/api/v1/users/"+admin.ID.String()+"/password", map[string]string{
+			"old_password": "password123",
+			"new_password": "password456",
+		}, plainToken)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("role granted through group", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/roles", map[string]interface{}{
+			"name":        "rbac-viewer",
+			"permissions": []string{"user:read"},
+		}, adminToken)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("create role failed: %s", r.Message)
+		}
+		var role struct {
+			ID string `json:"id"`
+		}
+		json.Unmarshal(r.Data, &role)
+
+		groupID := createGroupViaAPI(t, adminToken, map[string]interface{}{"name": "rbac-viewers"})
+		resp = doAPI(t, "POST", "/api/v1/groups/"+groupID+"/members", map[string]interface{}{
+			"user_ids": []string{plain.ID.String()},
+		}, adminToken)
+		parseResponse(t, resp)
+		resp = doAPI(t, "POST", "/api/v1/roles/"+role.ID+"/groups", map[string]interface{}{
+			"group_ids": []string{groupID},
+		}, adminToken)
+		if r := parseResponse(t, resp); r.Code != 0 {
+			t.Fatalf("assign role failed: %s", r.Message)
+		}
+
+		token := loginAndGetToken(t, "rbacplain", "password123")
+		resp = doAPI(t, "GET", "/api/v1/users", nil, token)
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("list users: expected 200, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+		resp = doAPI(t, "DELETE", "/api/v1/users/"+admin.ID.String(), nil, token)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("delete user: expected 403, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("user:write cannot take over an administrator", func(t *testing.T) {
+		ensureUser(t, domain.CreateUserInput{
+			Username: "rbachelpdesk", DisplayName: "Helpdesk", Email: "rbachelpdesk@test.com", Password: "password123",
+		})
+		helpdesk, _ := userSvc.GetUserByUsername(t.Context(), "rbachelpdesk")
+		resp := doAPI(t, "POST", "/api/v1/roles", map[string]interface{}{
+			"name":        "rbac-helpdesk",
+			"permissions": []string{"user:read", "user:write"},
+		}, adminToken)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("create role failed: %s", r.Message)
+		}
+		var role struct {
+			ID string `json:"id"`
+		}
+		json.Unmarshal(r.Data, &role)
+		resp = doAPI(t, "POST", "/api/v1/roles/"+role.ID+"/users", map[string]interface{}{
+			"user_ids": []string{helpdesk.ID.String()},
+		}, adminToken)
+		if r := parseResponse(t, resp); r.Code != 0 {
+			t.Fatalf("assign role failed: %s", r.Message)
+		}
+
+		token := loginAndGetToken(t, "rbachelpdesk", "password123")
+		for _, req := range []struct {
+			method, path string
+			body         interface{}
+		}{
+			{"POST", "/api/v1/users/" + admin.ID.String() + "/password/reset", map[string]string{"new_password": "password456"}},
+			{"DELETE", "/api/v1/users/" + admin.ID.String() + "/mfa", nil},
+			{"PUT", "/api/v1/users/" + admin.ID.String() + "/status", map[string]string{"status": "disabled"}},
+			{"DELETE", "/api/v1/users/" + admin.ID.String() + "/sessions", nil},
+			{"DELETE", "/api/v1/users/" + admin.ID.String(), nil},
+		} {
+			resp := doAPI(t, req.method, req.path, req.body, token)
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("%s %s: expected 403, got %d", req.method, req.path, resp.StatusCode)
+			}
+			resp.Body.Close()
+		}
+		resp = doAPI(t, "POST", "/api/v1/users/"+plain.ID.String()+"/password/reset", map[string]string{"new_password": "password123"}, token)
+		if r := parseResponse(t, resp); r.Code != 0 {
+			t.Errorf("reset password of plain user failed: %s", r.Message)
+		}
+		loginAndGetToken(t, "rbacadmin", "password123")
+	})
+
+	t.Run("built-in role is read-only", func(t *testing.T) {
+		adminRole, err := roleSvc.EnsureBuiltinRoles(t.Context())
+		if err != nil {
+			t.Fatalf("ensure roles: %v", err)
+		}
+		resp := doAPI(t, "DELETE", "/api/v1/roles/"+adminRole.ID.String(), nil, adminToken)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+}
+
+func TestSelfService(t *testing.T) {
+	me, err := userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "selfuser",
+		DisplayName: "Self User",
+		Email:       "selfuser@test.com",
+		Password:    "password123",
+	})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	groupSvc.CreateGroup(t.Context(), domain.CreateGroupInput{Name: "self-group"})
+	for _, g := range mustListGroups(t) {
+		if g.Name == "self-group" {
+			groupSvc.AddMembers(t.Context(), g.ID, []uuid.UUID{me.ID})
+		}
+	}
+	token := loginAndGetToken(t, "selfuser", "password123")
+
+	t.Run("get profile", func(t *testing.T) {
+		resp := doAPI(t, "GET", "/api/v1/me", nil, token)
+		r := parseResponse(t, resp)
+		var data struct {
+			Username string `json:"username"`
+		}
+		json.Unmarshal(r.Data, &data)
+		if data.Username != "selfuser" {
+			t.Errorf("username = %q, want selfuser", data.Username)
+		}
+	})
+
+	t.Run("update editable field", func(t *testing.T) {
+		resp := doAPI(t, "PUT", "/api/v1/me", map[string]string{"display_name": "Self Renamed"}, token)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("update profile failed: %s", r.Message)
+		}
+	})
+
+	t.Run("reject non-editable field", func(t *testing.T) {
+		resp := doAPI(t, "PUT", "/api/v1/me", map[string]string{"email": "other@test.com"}, token)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("groups are visible", func(t *testing.T) {
+		resp := doAPI(t, "GET", "/api/v1/me/groups", nil, token)
+		r := parseResponse(t, resp)
+		var groups []struct {
+			Name string `json:"name"`
+		}
+		json.Unmarshal(r.Data, &groups)
+		if len(groups) != 1 || groups[0].Name != "self-group" {
+			t.Errorf("groups = %v, want [self-group]", groups)
+		}
+	})
+
+	t.Run("ssh keys", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/me/ssh-keys", map[string]string{
+			"name":       "laptop",
+			"public_key": "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGtQUBcXCLbUSiSUz9hpXUFkGHIhKtjBh0Uf0kPqJ8dF self@test",
+		}, token)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("add ssh key failed: %s", r.Message)
+		}
+		var key struct {
+			ID string `json:"id"`
+		}
+		json.Unmarshal(r.Data, &key)
+
+		resp = doAPI(t, "GET", "/api/v1/me/ssh-keys", nil, token)
+		r = parseResponse(t, resp)
+		var keys []struct {
+			Name string `json:"name"`
+		}
+		json.Unmarshal(r.Data, &keys)
+		if len(keys) != 1 || keys[0].Name != "laptop" {
+			t.Errorf("keys = %v, want [laptop]", keys)
+		}
+
+		resp = doAPI(t, "DELETE", "/api/v1/me/ssh-keys/"+key.ID, nil, token)
+		if r := parseResponse(t, resp); r.Code != 0 {
+			t.Errorf("delete ssh key failed: %s", r.Message)
+		}
+	})
+
+	t.Run("change password", func(t *testing.T) {
+		resp := doAPI(t, "PUT", "/api/v1/me/password", map[string]string{
+			"old_password": "password123",
+			"new_password": "newpassword123",
+		}, token)
+		if r := parseResponse(t, resp); r.Code != 0 {
+			t.Fatalf("change password failed: %s", r.Message)
+		}
+		loginAndGetToken(t, "selfuser", "newpassword123")
+	})
+}
+
+func mustListGroups(t *testing.T) []*domain.Group {
+	t.Helper()
+	groups, err := groupSvc.ListGroups(t.Context())
+	if err != nil {
+		t.Fatalf("list groups: %v", err)
+	}
+	return groups
+}
+
+func TestSessions(t *testing.T) {
+	userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "sessionadmin",
+		DisplayName: "Session Admin",
+		Email:       "sessionadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "sessionadmin")
+	adminToken := loginAndGetToken(t, "sessionadmin", "password123")
+
+	u, err := userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "sessionuser",
+		DisplayName: "Session User",
+		Email:       "sessionuser@test.com",
+		Password:    "password123",
+	})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	resp := doAPI(t, "POST", "/api/v1/auth/login", map[string]string{
+		"username": "sessionuser",
+		"password": "password123",
+	}, "")
+	r := parseResponse(t, resp)
+	var login struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	json.Unmarshal(r.Data, &login)
+	if login.RefreshToken == "" {
+		t.Fatal("expected refresh token in login response")
+	}
+
+	t.Run("refresh rotates tokens", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/auth/refresh", map[string]string{"refresh_token": login.RefreshToken}, "")
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("refresh failed: %s", r.Message)
+		}
+		var tokens struct {
+			Token        string `json:"token"`
+			RefreshToken string `json:"refresh_token"`
+		}
+		json.Unmarshal(r.Data, &tokens)
+		if tokens.RefreshToken == login.RefreshToken {
+			t.Error("expected a new refresh token")
+		}
+		login.Token, login.RefreshToken = tokens.Token, tokens.RefreshToken
+
+		resp = doAPI(t, "GET", "/api/v1/me", nil, login.Token)
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("refreshed token: expected 200, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("admin lists and revokes sessions", func(t *testing.T) {
+		resp := doAPI(t, "GET", "/api/v1/users/"+u.ID.String()+"/sessions", nil, adminToken)
+		r := parseResponse(t, resp)
+		var sessions []struct {
+			ID string `json:"id"`
+		}
+		json.Unmarshal(r.Data, &sessions)
+		if len(sessions) != 1 {
+			t.Fatalf("len(sessions) = %d, want 1", len(sessions))
+		}
+
+		resp = doAPI(t, "DELETE", "/api/v1/users/"+u.ID.String()+"/sessions/"+sessions[0].ID, nil, adminToken)
+		if r := parseResponse(t, resp); r.Code != 0 {
+			t.Fatalf("revoke session failed: %s", r.Message)
+		}
+
+		resp = doAPI(t, "GET", "/api/v1/me", nil, login.Token)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("revoked token: expected 401, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("logout revokes session", func(t *testing.T) {
+		token := loginAndGetToken(t, "sessionuser", "password123")
+		resp := doAPI(t, "POST", "/api/v1/auth/logout", nil, token)
+		if r := parseResponse(t, resp); r.Code != 0 {
+			t.Fatalf("logout failed: %s", r.Message)
+		}
+
+		resp = doAPI(t, "GET", "/api/v1/me", nil, token)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("logged out token: expected 401, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("disabled user is rejected", func(t *testing.T) {
+		token := loginAndGetToken(t, "sessionuser", "password123")
+		resp := doAPI(t, "PUT", "/api/v1/users/"+u.ID.String()+"/status", map[string]string{"status": "disabled"}, adminToken)
+		if r := parseResponse(t, resp); r.Code != 0 {
+			t.Fatalf("disable user failed: %s", r.Message)
+		}
+
+		resp = doAPI(t, "GET", "/api/v1/me", nil, token)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("disabled user token: expected 401, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+}
+
+func TestJWKS(t *testing.T) {
+	userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "jwksuser",
+		DisplayName: "JWKS User",
+		Email:       "jwksuser@test.com",
+		Password:    "password123",
+	})
+	token := loginAndGetToken(t, "jwksuser", "password123")
+
+	// A downstream service verifies the token with the published key alone.
+	if _, err := jwt.Parse(token, jwksKeyfunc(t), jwt.WithValidMethods([]string{"ES256"})); err != nil {
+		t.Errorf("verify token with JWKS: %v", err)
+	}
+}
+
+func TestServiceAccounts(t *testing.T) {
+	userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "saadmin",
+		DisplayName: "SA Admin",
+		Email:       "saadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "saadmin")
+	adminToken := loginAndGetToken(t, "saadmin", "password123")
+
+	r := parseResponse(t, doAPI(t, "POST", "/api/v1/service-accounts", map[string]interface{}{
+		"name":        "hr-sync",
+		"description": "HR system sync job",
+		"permissions": []string{domain.PermissionUserRead},
+	}, adminToken))
+	if r.Code != 0 {
+		t.Fatalf("create service account: %s", r.Message)
+	}
+	var sa domain.ServiceAccount
+	if err := json.Unmarshal(r.Data, &sa); err != nil {
+		t.Fatalf("unmarshal service account: %v", err)
+	}
+
+	r = parseResponse(t, doAPI(t, "POST", "/api/v1/service-accounts/"+sa.ID.String()+"/keys", map[string]interface{}{
+		"name": "prod",
+	}, adminToken))
+	if r.Code != 0 {
+		t.Fatalf("create api key: %s", r.Message)
+	}
+	var created struct {
+		APIKey domain.APIKey `json:"api_key"`
+		Key    string        `json:"key"`
+	}
+	if err := json.Unmarshal(r.Data, &created); err != nil {
+		t.Fatalf("unmarshal api key: %v", err)
+	}
+
+	t.Run("api key", func(t *testing.T) {
+		resp := doAPI(t, "GET", "/api/v1/users", nil, created.Key)
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("list users with api key: expected 200, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+
+		resp = doAPI(t, "POST", "/api/v1/users", map[string]string{
+			"username": "fromsa", "display_name": "From SA", "email": "fromsa@test.com", "password": "password123",
+		}, created.Key)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("create user without user:write: expected 403, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+
+		resp = doAPI(t, "GET", "/api/v1/me", nil, created.Key)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("/me as service account: expected 403, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("client credentials", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", httpServer.URL+"/oauth2/token", strings.NewReader(url.Values{
+			"grant_type": {"client_credentials"},
+		}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth("hr-sync", created.Key)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("token: %v", err)
+		}
+		var tokens domain.TokenResponse
+		if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+			t.Fatalf("decode tokens: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || tokens.AccessToken == "" {
+			t.Fatalf("client credentials: expected token, got %d", resp.StatusCode)
+		}
+
+		resp = doAPI(t, "GET", "/api/v1/users", nil, tokens.AccessToken)
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("list users with access token: expected 200, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("last used is recorded", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "GET", "/api/v1/service-accounts/"+sa.ID.String(), nil, adminToken))
+		var got domain.ServiceAccount
+		if err := json.Unmarshal(r.Data, &got); err != nil {
+			t.Fatalf("unmarshal service account: %v", err)
+		}
+		if len(got.APIKeys) != 1 || got.APIKeys[0].LastUsedAt == nil || got.APIKeys[0].Prefix != created.APIKey.Prefix {
+			t.Errorf("unexpected api keys: %+v", got.APIKeys)
+		}
+	})
+
+	t.Run("deleted key is rejected", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "DELETE", "/api/v1/service-accounts/"+sa.ID.String()+"/keys/"+created.APIKey.ID.String(), nil, adminToken))
+		if r.Code != 0 {
+			t.Fatalf("delete api key: %s", r.Message)
+		}
+		resp := doAPI(t, "GET", "/api/v1/users", nil, created.Key)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("deleted api key: expected 401, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+}
+
+// jwksKeyfunc fetches the published JWKS and returns a keyfunc that looks up
+// the token's kid in it.
+func jwksKeyfunc(t *testing.T) jwt.Keyfunc {
+	t.Helper()
+	resp := doAPI(t, "GET", "/.well-known/jwks.json", nil, "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("jwks: expected 200, got %d", resp.StatusCode)
+	}
+	var set domain.JWKSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		t.Fatalf("decode jwks: %v", err)
+	}
+
+	return func(tok *jwt.Token) (interface{}, error) {
+		for _, k := range set.Keys {
+			if k.KeyID != tok.Header["kid"] {
+				continue
+			}
+			x, _ := base64.RawURLEncoding.DecodeString(k.X)
+			y, _ := base64.RawURLEncoding.DecodeString(k.Y)
+			return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+		}
+		return nil, fmt.Errorf("kid %v not in JWKS", tok.Header["kid"])
+	}
+}
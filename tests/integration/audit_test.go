@@ -0,0 +1,139 @@
+package integration
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+// listAudit fetches the audit events matching a query, newest first.
+func listAudit(t *testing.T, token string, query url.Values) []*domain.AuditEvent {
+	t.Helper()
+	r := parseResponse(t, doAPI(t, "GET", "/api/v1/audit?"+query.Encode(), nil, token))
+	if r.Code != 0 {
+		t.Fatalf("list audit: %s", r.Message)
+	}
+	var data domain.ListResult[domain.AuditEvent]
+	if err := json.Unmarshal(r.Data, &data); err != nil {
+		t.Fatalf("unmarshal audit events: %v", err)
+	}
+	return data.Items
+}
+
+func TestAuditLog(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "auditadmin",
+		DisplayName: "Audit Admin",
+		Email:       "auditadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "auditadmin")
+	adminToken := loginAndGetToken(t, "auditadmin", "password123")
+	u := ensureUser(t, domain.CreateUserInput{
+		Username:    "audituser",
+		DisplayName: "Audit User",
+		Email:       "audituser@test.com",
+		Password:    "password123",
+	})
+	const client = "198.51.100.42"
+
+	t.Run("logins are recorded with the client and request ID", func(t *testing.T) {
+		resp := loginFrom(t, client, "audituser", "wrongpassword")
+		resp.Body.Close()
+		resp = loginFrom(t, client, "audituser", "password123")
+		requestID := resp.Header.Get("X-Request-ID")
+		resp.Body.Close()
+
+		events := listAudit(t, adminToken, url.Values{"actor": {"audituser"}, "action": {"auth.*"}, "ip": {client}})
+		if len(events) != 2 {
+			t.Fatalf("got %d login events, want 2", len(events))
+		}
+		ok, failed := events[0], events[1]
+		if ok.Outcome != domain.AuditSuccess || ok.Action != domain.AuditLogin || ok.RequestID != requestID || ok.Protocol != domain.ProtocolHTTP {
+			t.Errorf("successful login = %+v, want request ID %s", ok, requestID)
+		}
+		if failed.Outcome != domain.AuditFailure || failed.Reason != domain.LoginReasonInvalidPassword {
+			t.Errorf("failed login = %+v", failed)
+		}
+	})
+
+	t.Run("ldap binds are recorded", func(t *testing.T) {
+		conn := ldapDial(t)
+		if err := conn.Bind("uid=audituser,ou=users,"+testBaseDN, "password123"); err != nil {
+			t.Fatalf("bind: %v", err)
+		}
+		events := listAudit(t, adminToken, url.Values{"actor": {u.ID.String()}, "action": {domain.AuditBind}})
+		if len(events) != 1 || events[0].Protocol != domain.ProtocolLDAP || events[0].RequestID == "" {
+			t.Fatalf("bind events = %+v, want one over LDAP", events)
+		}
+	})
+
+	t.Run("changes are recorded with their fields", func(t *testing.T) {
+		resp := doAPI(t, "PUT", "/api/v1/users/"+u.ID.String(), map[string]string{"display_name": "Audited User"}, adminToken)
+		if r := parseResponse(t, resp); r.Code != 0 {
+			t.Fatalf("update user: %s", r.Message)
+		}
+
+		events := listAudit(t, adminToken, url.Values{"target_type": {domain.AuditTargetUser}, "target_id": {u.ID.String()}, "action": {domain.AuditUserUpdate}})
+		if len(events) != 1 {
+			t.Fatalf("got %d update events, want 1", len(events))
+		}
+		e := events[0]
+		if e.ActorName != "auditadmin" || e.TargetName != "audituser" {
+			t.Errorf("update event = %+v, want auditadmin updating audituser", e)
+		}
+		if len(e.Changes) != 1 || e.Changes[0].Field != "display_name" || e.Changes[0].Old != "Audit User" || e.Changes[0].New != "Audited User" {
+			t.Errorf("changes = %+v, want display_name", e.Changes)
+		}
+	})
+
+	t.Run("export streams json lines", func(t *testing.T) {
+		resp := doAPI(t, "GET", "/api/v1/audit/export?actor=audituser&action=auth.*", nil, adminToken)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, want 200", resp.StatusCode)
+		}
+		var actions []string
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			var e domain.AuditEvent
+			if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+				t.Fatalf("decode line %q: %v", sc.Text(), err)
+			}
+			actions = append(actions, e.Action)
+		}
+		want := []string{domain.AuditLogin, domain.AuditLogin, domain.AuditBind}
+		if len(actions) != len(want) {
+			t.Fatalf("exported %v, want %v", actions, want)
+		}
+		for i := range want {
+			if actions[i] != want[i] {
+				t.Errorf("exported %v, want %v oldest first", actions, want)
+				break
+			}
+		}
+	})
+
+	t.Run("invalid filters are rejected", func(t *testing.T) {
+		for _, q := range []string{"outcome=maybe", "from=yesterday"} {
+			resp := doAPI(t, "GET", "/api/v1/audit?"+q, nil, adminToken)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("%s: status = %d, want 400", q, resp.StatusCode)
+			}
+			resp.Body.Close()
+		}
+	})
+
+	t.Run("reading requires audit:read", func(t *testing.T) {
+		token := loginAndGetToken(t, "audituser", "password123")
+		resp := doAPI(t, "GET", "/api/v1/audit", nil, token)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("status = %d, want 403", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+}
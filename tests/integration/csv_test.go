@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+// importCSV posts a CSV file to the import endpoint with the given query.
+func importCSV(t *testing.T, token, content, query string) (int, apiResponse) {
+	t.Helper()
+	req, err := http.NewRequest("POST", httpServer.URL+"/api/v1/users/import.csv?"+query, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", "text/csv")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	return resp.StatusCode, parseResponse(t, resp)
+}
+
+func csvReport(t *testing.T, r apiResponse) *domain.CSVImportReport {
+	t.Helper()
+	if r.Code != 0 {
+		t.Fatalf("import CSV: %s", r.Message)
+	}
+	var report domain.CSVImportReport
+	if err := json.Unmarshal(r.Data, &report); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	return &report
+}
+
+func TestCSV(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "csvadmin",
+		DisplayName: "CSV Admin",
+		Email:       "csvadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "csvadmin")
+	token := loginAndGetToken(t, "csvadmin", "password123")
+	ctx := t.Context()
+	createGroupViaAPI(t, token, map[string]interface{}{"name": "csv-team"})
+
+	content := "Login,Name,Mail,Groups\n" +
+		"csv-amy,Amy,csv-amy@test.com,csv-team\n" +
+		"csv-ben,Ben,csv-ben@test.com,csv-team\n"
+	query := "column.username=Login&column.display_name=Name&column.email=Mail"
+	status, r := importCSV(t, token, content, query+"&dry_run=true")
+	if report := csvReport(t, r); status != http.StatusOK || report.Created != 2 || report.Applied {
+		t.Fatalf("dry run = %d %+v", status, report)
+	}
+	if _, err := userSvc.GetUserByUsername(ctx, "csv-amy"); err == nil {
+		t.Fatal("dry run created a user")
+	}
+	_, r = importCSV(t, token, content, query)
+	report := csvReport(t, r)
+	if report.Created != 2 || report.Rows[0].Password == "" {
+		t.Fatalf("import = %+v", report)
+	}
+	loginAndGetToken(t, "csv-amy", report.Rows[0].Password)
+
+	resp := doAPI(t, "GET", "/api/v1/users/export.csv?search=csv-", nil, token)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/csv") {
+		t.Fatalf("export = %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if export := string(body); !strings.Contains(export, "\ncsv-ben,Ben,csv-ben@test.com,,enabled,,,csv-team") {
+		t.Errorf("export = %q", export)
+	}
+
+	// An invalid row stops the whole import; the failed rows can be
+	// downloaded as CSV.
+	invalid := "username,email,status\ncsv-amy,,disabled\ncsv-cat,,\n"
+	_, r = importCSV(t, token, invalid, "")
+	if report = csvReport(t, r); report.Applied || report.Failed != 1 {
+		t.Errorf("invalid import = %+v", report)
+	}
+	req, _ := http.NewRequest("POST", httpServer.URL+"/api/v1/users/import.csv?format=csv&errors_only=true&dry_run=true", strings.NewReader(invalid))
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if want := "username,email,status,result,errors\ncsv-cat,,,fail,email is empty\n"; string(body) != want {
+		t.Errorf("error report = %q, want %q", body, want)
+	}
+
+	if status, r = importCSV(t, token, "email\nx@test.com\n", ""); status != http.StatusBadRequest {
+		t.Errorf("import without usernames = %d %s", status, r.Message)
+	}
+
+	// Importing requires user:write.
+	ensureUser(t, domain.CreateUserInput{
+		Username: "csvplain", DisplayName: "Plain", Email: "csvplain@test.com", Password: "password123",
+	})
+	plain := loginAndGetToken(t, "csvplain", "password123")
+	if status, _ = importCSV(t, plain, content, "dry_run=true"); status != http.StatusForbidden {
+		t.Errorf("import without permission = %d", status)
+	}
+}
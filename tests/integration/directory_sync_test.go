@@ -0,0 +1,335 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jimlambrt/gldap"
+	"go.uber.org/zap"
+
+	"github.com/qinzj/claude-demo/internal/config"
+	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ent"
+	ldaphandler "github.com/qinzj/claude-demo/internal/handler/ldap"
+	"github.com/qinzj/claude-demo/internal/password"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+const (
+	upstreamBaseDN = "dc=upstream,dc=com"
+	upstreamBindDN = "uid=upstream-reader,ou=users," + upstreamBaseDN
+)
+
+// upstream is a second instance of the server, with its own database,
+// serving as the LDAP directory users and groups are synchronized from.
+var upstream struct {
+	users  *service.UserService
+	groups *service.GroupService
+	server *gldap.Server
+}
+
+// startUpstream starts the upstream directory and returns the source
+// synchronizing from it.
+func startUpstream(logger *zap.Logger) (domain.DirectorySource, error) {
+	var src domain.DirectorySource
+	client, err := ent.Open("sqlite3", "file:upstream?mode=memory&_fk=1")
+	if err != nil {
+		return src, err
+	}
+	d := dao.New(client)
+	ctx := context.Background()
+	if err := d.AutoMigrate(ctx); err != nil {
+		return src, err
+	}
+	upstream.users = service.NewUserService(d, domain.LockoutPolicy{}, password.Hasher{})
+	upstream.groups = service.NewGroupService(d)
+	keySvc := service.NewKeyService(d, signingAlg, 30*24*time.Hour, time.Hour)
+	if _, err := keySvc.RotateIfDue(ctx); err != nil {
+		return src, err
+	}
+	if _, err := service.NewPasswordPolicyService(d).EnsureDefaultPolicy(ctx); err != nil {
+		return src, err
+	}
+	if _, err := upstream.users.CreateUser(ctx, domain.CreateUserInput{
+		Username: "upstream-reader", DisplayName: "Reader", Email: "reader@upstream.com", Password: "password123",
+	}); err != nil {
+		return src, err
+	}
+
+	cfg := &config.LDAPConfig{BaseDN: upstreamBaseDN, Mode: "openldap"}
+	h := ldaphandler.New(upstream.users, upstream.groups, service.NewAttributeService(d),
+		service.NewServiceAccountService(d, keySvc, accessTTL), service.NewMFAService(d, upstream.users, "Upstream"), cfg, logger)
+	if upstream.server, err = gldap.NewServer(); err != nil {
+		return src, err
+	}
+	mux, err := gldap.NewMux()
+	if err != nil {
+		return src, err
+	}
+	h.RegisterRoutes(mux)
+	upstream.server.Router(mux)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return src, err
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+	go func() { _ = upstream.server.Run(addr) }()
+
+	return domain.DirectorySource{
+		Name:         "upstream",
+		URL:          "ldap://" + addr,
+		BindDN:       upstreamBindDN,
+		BindPassword: "password123",
+		Timeout:      5 * time.Second,
+		Conflict:     domain.DirectoryConflictSkip,
+		Delete:       domain.DirectoryDeleteDisable,
+		Users: domain.DirectoryUserMapping{
+			BaseDN:      "ou=users," + upstreamBaseDN,
+			Filter:      "(objectClass=inetOrgPerson)",
+			IDAttribute: "entryUUID",
+			Username:    "uid",
+			DisplayName: "cn",
+			Email:       "mail",
+			Phone:       "telephoneNumber",
+		},
+		SyncGroups: true,
+		Groups: domain.DirectoryGroupMapping{
+			BaseDN:      "ou=groups," + upstreamBaseDN,
+			Filter:      "(objectClass=groupOfNames)",
+			IDAttribute: "entryUUID",
+			Name:        "cn",
+			Description: "description",
+			Member:      "member",
+		},
+		PassThrough: domain.DirectoryPassThrough{
+			Users:             []string{"passthrough-local"},
+			SynchronizedUsers: true,
+			CapturePassword:   true,
+		},
+	}, nil
+}
+
+func syncUpstream(t *testing.T, token string, dryRun bool) *domain.DirectorySyncReport {
+	t.Helper()
+	resp := doAPI(t, "POST", fmt.Sprintf("/api/v1/directory-sync/sources/upstream/sync?dry_run=%t", dryRun), nil, token)
+	r := parseResponse(t, resp)
+	if r.Code != 0 {
+		t.Fatalf("sync upstream: %s", r.Message)
+	}
+	var report domain.DirectorySyncReport
+	if err := json.Unmarshal(r.Data, &report); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	return &report
+}
+
+func createUpstreamUser(t *testing.T, username string) *domain.User {
+	t.Helper()
+	u, err := upstream.users.CreateUser(t.Context(), domain.CreateUserInput{
+		Username: username, DisplayName: username, Email: username + "@upstream.com", Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("create upstream user: %v", err)
+	}
+	return u
+}
+
+func TestDirectorySync(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "directoryadmin",
+		DisplayName: "Directory Admin",
+		Email:       "directoryadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "directoryadmin")
+	token := loginAndGetToken(t, "directoryadmin", "password123")
+	ctx := t.Context()
+
+	ann := createUpstreamUser(t, "upstream-ann")
+	ben := createUpstreamUser(t, "upstream-ben")
+	g, err := upstream.groups.CreateGroup(ctx, domain.CreateGroupInput{Name: "upstream-team", Description: "Team"})
+	if err != nil {
+		t.Fatalf("create upstream group: %v", err)
+	}
+	if err := upstream.groups.AddMembers(ctx, g.ID, []uuid.UUID{ann.ID, ben.ID}); err != nil {
+		t.Fatalf("add upstream members: %v", err)
+	}
+	// A local user with an upstream username is left alone.
+	ensureUser(t, domain.CreateUserInput{
+		Username: "upstream-reader", DisplayName: "Local Reader", Email: "reader@test.com", Password: "password123",
+	})
+
+	resp := doAPI(t, "GET", "/api/v1/directory-sync/sources", nil, token)
+	r := parseResponse(t, resp)
+	var sources []map[string]any
+	if err := json.Unmarshal(r.Data, &sources); err != nil || len(sources) != 1 {
+		t.Fatalf("sources = %s, %v", r.Data, err)
+	}
+	if _, ok := sources[0]["source"].(map[string]any)["bind_password"]; ok {
+		t.Error("the bind password was returned")
+	}
+
+	report := syncUpstream(t, token, true)
+	if report.Users.Created != 2 || report.Users.Conflicts != 1 || report.Groups.Created != 1 {
+		t.Errorf("dry run = %+v, %+v", report.Users, report.Groups)
+	}
+	if _, err := userSvc.GetUserByUsername(ctx, "upstream-ann"); err == nil {
+		t.Error("dry run created a user")
+	}
+
+	report = syncUpstream(t, token, false)
+	if report.Users.Created != 2 || report.Groups.Created != 1 || report.Users.Failed+report.Groups.Failed != 0 {
+		t.Fatalf("sync = %+v, %+v: %+v", report.Users, report.Groups, report.Changes)
+	}
+	local, err := userSvc.GetUserByUsername(ctx, "upstream-ann")
+	if err != nil {
+		t.Fatalf("synchronized user: %v", err)
+	}
+	if local.Email != "upstream-ann@upstream.com" {
+		t.Errorf("email = %s", local.Email)
+	}
+	if reader, _ := userSvc.GetUserByUsername(ctx, "upstream-reader"); reader.DisplayName != "Local Reader" {
+		t.Error("conflicting local user changed")
+	}
+	groups, err := groupSvc.AllGroups(ctx)
+	if err != nil {
+		t.Fatalf("AllGroups: %v", err)
+	}
+	i := slices.IndexFunc(groups, func(g *domain.Group) bool { return g.Name == "upstream-team" })
+	if i < 0 || len(groups[i].Users) != 2 {
+		t.Fatalf("synchronized group not found with its members")
+	}
+
+	// Changes upstream are followed, and users gone are disabled.
+	name := "Ann Upstream"
+	if _, err := upstream.users.UpdateUser(ctx, ann.ID, domain.UpdateUserInput{DisplayName: &name}); err != nil {
+		t.Fatalf("update upstream user: %v", err)
+	}
+	if err := upstream.groups.RemoveMember(ctx, g.ID, ben.ID); err != nil {
+		t.Fatalf("remove upstream member: %v", err)
+	}
+	if err := upstream.users.DeleteUser(ctx, ben.ID); err != nil {
+		t.Fatalf("delete upstream user: %v", err)
+	}
+	report = syncUpstream(t, token, false)
+	if report.Users.Updated != 1 || report.Users.Disabled != 1 || report.Groups.Updated != 1 {
+		t.Errorf("second sync = %+v, %+v: %+v", report.Users, report.Groups, report.Changes)
+	}
+	if u, _ := userSvc.GetUserByUsername(ctx, "upstream-ann"); u.DisplayName != name {
+		t.Errorf("display name = %s", u.DisplayName)
+	}
+	if u, _ := userSvc.GetUserByUsername(ctx, "upstream-ben"); u.Status != domain.UserStatusDisabled {
+		t.Errorf("removed user status = %s", u.Status)
+	}
+
+	resp = doAPI(t, "POST", "/api/v1/directory-sync/sources/missing/sync", nil, token)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown source: status %d, want 404", resp.StatusCode)
+	}
+	resp.Body.Close()
+
+	// Users and groups synchronized are not left for other tests.
+	if err := upstream.users.DeleteUser(ctx, ann.ID); err != nil {
+		t.Fatalf("delete upstream user: %v", err)
+	}
+	if err := upstream.groups.DeleteGroup(ctx, g.ID); err != nil {
+		t.Fatalf("delete upstream group: %v", err)
+	}
+	for _, name := range []string{"upstream-ann", "upstream-ben"} {
+		if u, err := userSvc.GetUserByUsername(ctx, name); err == nil {
+			_ = userSvc.DeleteUser(ctx, u.ID)
+		}
+	}
+	_ = groupSvc.DeleteGroup(ctx, groups[i].ID)
+}
+
+func TestPassThrough(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "directoryadmin",
+		DisplayName: "Directory Admin",
+		Email:       "directoryadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "directoryadmin")
+	token := loginAndGetToken(t, "directoryadmin", "password123")
+	ctx := t.Context()
+
+	// A user synchronized without a password, and a local user listed in
+	// the source's pass_through settings, whose password only the upstream
+	// directory knows.
+	synced, err := upstream.users.CreateUser(ctx, domain.CreateUserInput{
+		Username: "passthrough-synced", DisplayName: "Synced", Email: "synced@upstream.com", Password: "upstream-pass1",
+	})
+	if err != nil {
+		t.Fatalf("create upstream user: %v", err)
+	}
+	listed, err := upstream.users.CreateUser(ctx, domain.CreateUserInput{
+		Username: "passthrough-local", DisplayName: "Listed", Email: "listed@upstream.com", Password: "upstream-pass2",
+	})
+	if err != nil {
+		t.Fatalf("create upstream user: %v", err)
+	}
+	local := ensureUser(t, domain.CreateUserInput{
+		Username: "passthrough-local", DisplayName: "Listed", Email: "listed@test.com", Password: "local-pass1",
+	})
+	if report := syncUpstream(t, token, false); report.Users.Created != 1 {
+		t.Fatalf("sync = %+v: %+v", report.Users, report.Changes)
+	}
+	t.Cleanup(func() {
+		ctx := context.Background()
+		_ = upstream.users.DeleteUser(ctx, synced.ID)
+		_ = upstream.users.DeleteUser(ctx, listed.ID)
+		if u, err := userSvc.GetUserByUsername(ctx, "passthrough-synced"); err == nil {
+			_ = userSvc.DeleteUser(ctx, u.ID)
+		}
+	})
+
+	loginAndGetToken(t, "passthrough-synced", "upstream-pass1")
+	loginAndGetToken(t, "passthrough-local", "upstream-pass2")
+	resp := doAPI(t, "POST", "/api/v1/auth/login", map[string]string{
+		"username": "passthrough-local",
+		"password": "local-pass1",
+	}, "")
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("login with the local password: status %d, want 401", resp.StatusCode)
+	}
+	resp.Body.Close()
+
+	conn := ldapDial(t)
+	if err := conn.Bind("uid=passthrough-synced,ou=users,"+testBaseDN, "upstream-pass1"); err != nil {
+		t.Errorf("LDAP bind with the upstream password: %v", err)
+	}
+	if err := conn.Bind("uid=passthrough-synced,ou=users,"+testBaseDN, "wrong-pass1"); err == nil {
+		t.Error("LDAP bind with a wrong password succeeded")
+	}
+
+	// The password was captured, and cannot be changed here.
+	u, err := userSvc.GetUserByUsername(ctx, "passthrough-synced")
+	if err != nil {
+		t.Fatalf("GetUserByUsername: %v", err)
+	}
+	if ok, _ := password.Verify(u.PasswordHash, "upstream-pass1"); !ok {
+		t.Error("the upstream password was not captured")
+	}
+	userToken := loginAndGetToken(t, "passthrough-local", "upstream-pass2")
+	resp = doAPI(t, "PUT", "/api/v1/me/password", map[string]string{
+		"old_password": "upstream-pass2",
+		"new_password": "new-pass123",
+	}, userToken)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("change password: status %d, want 400", resp.StatusCode)
+	}
+	resp.Body.Close()
+	if err := userSvc.DeleteUser(ctx, local.ID); err != nil {
+		t.Errorf("DeleteUser: %v", err)
+	}
+}
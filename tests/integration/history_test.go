@@ -0,0 +1,122 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+func TestHistory(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "historyadmin",
+		DisplayName: "History Admin",
+		Email:       "historyadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "historyadmin")
+	token := loginAndGetToken(t, "historyadmin", "password123")
+
+	userID := createUserViaAPI(t, token, map[string]string{
+		"username":     "historyuser",
+		"display_name": "History User",
+		"email":        "historyuser@test.com",
+		"password":     "password123",
+	})
+	groupID := createGroupViaAPI(t, token, map[string]interface{}{"name": "finance-admins"})
+	resp := doAPI(t, "POST", "/api/v1/groups/"+groupID+"/members", map[string]interface{}{"user_ids": []string{userID}}, token)
+	if r := parseResponse(t, resp); r.Code != 0 {
+		t.Fatalf("add member: %s", r.Message)
+	}
+	time.Sleep(10 * time.Millisecond)
+	member := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	resp = doAPI(t, "PUT", "/api/v1/users/"+userID, map[string]string{"display_name": "Renamed User"}, token)
+	if r := parseResponse(t, resp); r.Code != 0 {
+		t.Fatalf("update user: %s", r.Message)
+	}
+	resp = doAPI(t, "DELETE", "/api/v1/groups/"+groupID+"/members/"+userID, nil, token)
+	if r := parseResponse(t, resp); r.Code != 0 {
+		t.Fatalf("remove member: %s", r.Message)
+	}
+
+	t.Run("group snapshot lists the members at the time", func(t *testing.T) {
+		q := url.Values{"at": {member.Format(time.RFC3339Nano)}}
+		r := parseResponse(t, doAPI(t, "GET", "/api/v1/groups/"+groupID+"/snapshot?"+q.Encode(), nil, token))
+		if r.Code != 0 {
+			t.Fatalf("snapshot: %s", r.Message)
+		}
+		var snap domain.GroupSnapshot
+		if err := json.Unmarshal(r.Data, &snap); err != nil {
+			t.Fatalf("unmarshal snapshot: %v", err)
+		}
+		if snap.Group.Name != "finance-admins" || len(snap.Members) != 1 || snap.Members[0].DisplayName != "History User" {
+			t.Errorf("snapshot = %+v, want historyuser as they were then", snap)
+		}
+
+		q = url.Values{"at": {time.Now().Format(time.RFC3339Nano)}}
+		r = parseResponse(t, doAPI(t, "GET", "/api/v1/groups/"+groupID+"/snapshot?"+q.Encode(), nil, token))
+		if err := json.Unmarshal(r.Data, &snap); err != nil {
+			t.Fatalf("unmarshal snapshot: %v", err)
+		}
+		if len(snap.Members) != 0 {
+			t.Errorf("members now = %+v, want none", snap.Members)
+		}
+	})
+
+	t.Run("group history lists membership changes", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "GET", "/api/v1/groups/"+groupID+"/history", nil, token))
+		var h domain.GroupHistory
+		if err := json.Unmarshal(r.Data, &h); err != nil {
+			t.Fatalf("unmarshal history: %v", err)
+		}
+		if len(h.Memberships) != 2 || h.Memberships[0].ActorName != "historyadmin" || h.Memberships[1].Operation != domain.MembershipRemove {
+			t.Errorf("memberships = %+v, want an add and a remove by historyadmin", h.Memberships)
+		}
+	})
+
+	t.Run("user can be reverted", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/users/"+userID+"/history/1/revert", nil, token)
+		r := parseResponse(t, resp)
+		if r.Code != 0 {
+			t.Fatalf("revert: %s", r.Message)
+		}
+		var u domain.User
+		if err := json.Unmarshal(r.Data, &u); err != nil {
+			t.Fatalf("unmarshal user: %v", err)
+		}
+		if u.DisplayName != "History User" {
+			t.Errorf("display name = %q, want the original", u.DisplayName)
+		}
+
+		r = parseResponse(t, doAPI(t, "GET", "/api/v1/users/"+userID+"/history", nil, token))
+		var h domain.UserHistory
+		if err := json.Unmarshal(r.Data, &h); err != nil {
+			t.Fatalf("unmarshal history: %v", err)
+		}
+		if len(h.Versions) != 3 {
+			t.Errorf("got %d versions, want create, update and revert", len(h.Versions))
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for path, want := range map[string]int{
+			"/api/v1/users/" + userID + "/snapshot":                           http.StatusBadRequest,
+			"/api/v1/users/" + userID + "/snapshot?at=2000-01-01T00:00:00Z":   http.StatusNotFound,
+			"/api/v1/users/" + uuid.NewString() + "/history":                  http.StatusNotFound,
+			"/api/v1/groups/" + groupID + "/snapshot?at=2000-01-01T00:00:00Z": http.StatusNotFound,
+		} {
+			resp := doAPI(t, "GET", path, nil, token)
+			if resp.StatusCode != want {
+				t.Errorf("GET %s: status = %d, want %d", path, resp.StatusCode, want)
+			}
+			resp.Body.Close()
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+var invitationLinkPattern = regexp.MustCompile(`http://\S+/accept-invitation\?token=\S+`)
+
+func TestInvitation(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "inviteadmin",
+		DisplayName: "Invite Admin",
+		Email:       "inviteadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "inviteadmin")
+	adminToken := loginAndGetToken(t, "inviteadmin", "password123")
+	bindDN := "uid=invitee,ou=users," + testBaseDN
+
+	var user domain.User
+	var token string
+	t.Run("invite user", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "POST", "/api/v1/users/invitations", map[string]string{
+			"username":     "invitee",
+			"display_name": "Invited User",
+			"email":        "invitee@test.com",
+		}, adminToken))
+		if r.Code != 0 {
+			t.Fatalf("invite: %s", r.Message)
+		}
+		json.Unmarshal(r.Data, &user)
+		if user.Status != domain.UserStatusPending {
+			t.Errorf("status = %s, want pending", user.Status)
+		}
+		m := receiveMail(t)
+		if m.to != "invitee@test.com" {
+			t.Errorf("mail sent to %s", m.to)
+		}
+		token = linkTokenFrom(t, m, invitationLinkPattern)
+	})
+
+	t.Run("pending user cannot bind or be enabled", func(t *testing.T) {
+		if err := ldapDial(t).Bind(bindDN, "anything"); err == nil {
+			t.Error("LDAP bind of pending user should fail")
+		}
+		resp := doAPI(t, "PUT", "/api/v1/users/"+user.ID.String()+"/status", map[string]string{"status": "enabled"}, adminToken)
+		r := parseResponse(t, resp)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("enable pending user: status = %d (%s), want 400", resp.StatusCode, r.Message)
+		}
+	})
+
+	t.Run("resend replaces the link", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "POST", "/api/v1/users/"+user.ID.String()+"/invitation", nil, adminToken))
+		if r.Code != 0 {
+			t.Fatalf("resend: %s", r.Message)
+		}
+		old := token
+		token = linkTokenFrom(t, receiveMail(t), invitationLinkPattern)
+		resp := doAPI(t, "GET", "/api/v1/auth/invitation?token="+url.QueryEscape(old), nil, "")
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("old link: status = %d, want 400", resp.StatusCode)
+		}
+	})
+
+	t.Run("accept invitation", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "GET", "/api/v1/auth/invitation?token="+url.QueryEscape(token), nil, ""))
+		var inv struct {
+			Username    string `json:"username"`
+			MFARequired bool   `json:"mfa_required"`
+		}
+		json.Unmarshal(r.Data, &inv)
+		if r.Code != 0 || inv.Username != "invitee" || inv.MFARequired {
+			t.Fatalf("check invitation = %s (%s)", r.Data, r.Message)
+		}
+
+		resp := doAPI(t, "POST", "/api/v1/auth/invitation/accept", map[string]string{"token": token, "password": "short"}, "")
+		r = parseResponse(t, resp)
+		if resp.StatusCode != http.StatusBadRequest || len(policyViolations(t, r)) == 0 {
+			t.Fatalf("weak password: status = %d (%s), want policy violations", resp.StatusCode, r.Message)
+		}
+
+		r = parseResponse(t, doAPI(t, "POST", "/api/v1/auth/invitation/accept", map[string]string{"token": token, "password": "invitee-pass-1"}, ""))
+		if r.Code != 0 {
+			t.Fatalf("accept: %s", r.Message)
+		}
+		loginAndGetToken(t, "invitee", "invitee-pass-1")
+		if err := ldapDial(t).Bind(bindDN, "invitee-pass-1"); err != nil {
+			t.Errorf("LDAP bind after accepting: %v", err)
+		}
+
+		resp = doAPI(t, "POST", "/api/v1/users/"+user.ID.String()+"/invitation", nil, adminToken)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("resend to enabled user: status = %d, want 400", resp.StatusCode)
+		}
+	})
+}
@@ -1,12 +1,17 @@
 package integration
 
 import (
+	"encoding/json"
+	"net"
+	"net/http"
 	"testing"
+	"time"
 
 	goldap "github.com/go-ldap/ldap/v3"
 	"github.com/google/uuid"
 
 	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/ldap/attrs"
 )
 
 func ensureUser(t *testing.T, input domain.CreateUserInput) *domain.User {
@@ -39,11 +44,24 @@ func ensureGroup(t *testing.T, name, desc string, memberIDs []uuid.UUID) *domain
 	return g
 }
 
+// ldapClientIP is the address LDAP clients connect from, so that the
+// failed binds of the tests do not block the HTTP clients, which connect
+// from 127.0.0.1.
+const ldapClientIP = "127.0.0.3"
+
 func ldapDial(t *testing.T) *goldap.Conn {
 	t.Helper()
-	conn, err := goldap.Dial("tcp", ldapAddr)
+	return ldapDialFrom(t, ldapClientIP)
+}
+
+// ldapDialFrom connects to the LDAP server from the loopback address ip,
+// such as 127.0.0.2, so that the server sees a different client.
+func ldapDialFrom(t *testing.T, ip string) *goldap.Conn {
+	t.Helper()
+	dialer := &net.Dialer{LocalAddr: &net.TCPAddr{IP: net.ParseIP(ip)}}
+	conn, err := goldap.DialURL("ldap://"+ldapAddr, goldap.DialWithDialer(dialer))
 	if err != nil {
-		t.Fatalf("LDAP dial: %v", err)
+		t.Fatalf("LDAP dial from %s: %v", ip, err)
 	}
 	t.Cleanup(func() { conn.Close() })
 	return conn
@@ -90,6 +108,50 @@ func TestLDAPBind(t *testing.T) {
 	})
 }
 
+func TestLDAPServiceAccountBind(t *testing.T) {
+	ctx := t.Context()
+	sa, err := saSvc.CreateServiceAccount(ctx, domain.CreateServiceAccountInput{
+		Name:        "wiki",
+		Permissions: []string{domain.PermissionLDAPBind},
+	})
+	if err != nil {
+		t.Fatalf("create service account: %v", err)
+	}
+	_, key, err := saSvc.CreateAPIKey(ctx, sa.ID, domain.CreateAPIKeyInput{Name: "ldap"})
+	if err != nil {
+		t.Fatalf("create api key: %v", err)
+	}
+	bindDN := "cn=wiki,ou=services," + testBaseDN
+
+	t.Run("successful bind", func(t *testing.T) {
+		conn := ldapDial(t)
+		if err := conn.Bind(bindDN, key); err != nil {
+			t.Fatalf("bind should succeed: %v", err)
+		}
+		res, err := conn.Search(goldap.NewSearchRequest(
+			testBaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+			"(objectClass=inetOrgPerson)", []string{"uid"}, nil,
+		))
+		if err != nil || len(res.Entries) == 0 {
+			t.Errorf("search after bind: %d entries, err %v", len(res.Entries), err)
+		}
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		conn := ldapDial(t)
+		if err := conn.Bind(bindDN, key+"x"); err == nil {
+			t.Error("bind with wrong key should fail")
+		}
+	})
+
+	t.Run("user DN with api key", func(t *testing.T) {
+		conn := ldapDial(t)
+		if err := conn.Bind("uid=wiki,ou=users,"+testBaseDN, key); err == nil {
+			t.Error("api key must not authenticate a user DN")
+		}
+	})
+}
+
 func TestLDAPSearchFilters(t *testing.T) {
 	// Create test users
 	u1 := ensureUser(t, domain.CreateUserInput{
@@ -337,6 +399,114 @@ func TestLDAPSearchFilters(t *testing.T) {
 	})
 }
 
+func TestLDAPCustomAttributes(t *testing.T) {
+	ctx := t.Context()
+	if _, err := attrSvc.CreateDefinition(ctx, domain.CreateAttributeDefinitionInput{
+		Name:     "department",
+		Target:   domain.AttributeTargetUser,
+		LDAPName: "departmentNumber",
+	}); err != nil {
+		t.Fatalf("create attribute: %v", err)
+	}
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "attrldap",
+		DisplayName: "Attr LDAP",
+		Email:       "attrldap@test.com",
+		Password:    "password123",
+		Attributes:  map[string][]string{"department": {"Finance"}},
+	})
+
+	conn := ldapDial(t)
+	result, err := conn.Search(&goldap.SearchRequest{
+		BaseDN:     testBaseDN,
+		Scope:      goldap.ScopeWholeSubtree,
+		Filter:     "(&(objectClass=inetOrgPerson)(departmentNumber=Fin*))",
+		Attributes: []string{"uid", "departmentNumber"},
+	})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(result.Entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(result.Entries))
+	}
+	if got := result.Entries[0].GetAttributeValue("departmentNumber"); got != "Finance" {
+		t.Errorf("departmentNumber = %q, want Finance", got)
+	}
+}
+
+func TestLDAPManager(t *testing.T) {
+	ctx := t.Context()
+	boss := ensureUser(t, domain.CreateUserInput{
+		Username:    "ldapboss",
+		DisplayName: "LDAP Boss",
+		Email:       "ldapboss@test.com",
+		Password:    "password123",
+	})
+	report := ensureUser(t, domain.CreateUserInput{
+		Username:    "ldapreport",
+		DisplayName: "LDAP Report",
+		Email:       "ldapreport@test.com",
+		Password:    "password123",
+	})
+	if _, err := userSvc.UpdateUser(ctx, report.ID, domain.UpdateUserInput{ManagerID: &boss.ID}); err != nil {
+		t.Fatalf("set manager: %v", err)
+	}
+
+	conn := ldapDial(t)
+	bossDN := "uid=ldapboss,ou=users," + testBaseDN
+	result, err := conn.Search(&goldap.SearchRequest{
+		BaseDN:     testBaseDN,
+		Scope:      goldap.ScopeWholeSubtree,
+		Filter:     "(&(objectClass=inetOrgPerson)(manager=" + bossDN + "))",
+		Attributes: []string{"uid", "manager"},
+	})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(result.Entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(result.Entries))
+	}
+	if got := result.Entries[0].GetAttributeValue("uid"); got != "ldapreport" {
+		t.Errorf("uid = %q, want ldapreport", got)
+	}
+	if got := result.Entries[0].GetAttributeValue("manager"); got != bossDN {
+		t.Errorf("manager = %q, want %q", got, bossDN)
+	}
+}
+
+func TestLDAPGroupOwner(t *testing.T) {
+	owner := ensureUser(t, domain.CreateUserInput{
+		Username:    "ldapowner",
+		DisplayName: "LDAP Owner",
+		Email:       "ldapowner@test.com",
+		Password:    "password123",
+	})
+	if _, err := groupSvc.CreateGroup(t.Context(), domain.CreateGroupInput{
+		Name:         "ldap-owned",
+		OwnerUserIDs: []uuid.UUID{owner.ID},
+	}); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+
+	conn := ldapDial(t)
+	result, err := conn.Search(&goldap.SearchRequest{
+		BaseDN:     testBaseDN,
+		Scope:      goldap.ScopeWholeSubtree,
+		Filter:     "(&(objectClass=groupOfNames)(cn=ldap-owned))",
+		Attributes: []string{"cn", "owner"},
+	})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(result.Entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(result.Entries))
+	}
+	want := "uid=ldapowner,ou=users," + testBaseDN
+	if got := result.Entries[0].GetAttributeValue("owner"); got != want {
+		t.Errorf("owner = %q, want %q", got, want)
+	}
+}
+
 func TestLDAPGroupMembers(t *testing.T) {
 	u1 := ensureUser(t, domain.CreateUserInput{
 		Username:    "gmember1",
@@ -373,3 +543,120 @@ func TestLDAPGroupMembers(t *testing.T) {
 		}
 	})
 }
+
+func TestLDAPAccountExpiry(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "expadmin",
+		DisplayName: "Expiry Admin",
+		Email:       "expadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "expadmin")
+	adminToken := loginAndGetToken(t, "expadmin", "password123")
+
+	r := parseResponse(t, doAPI(t, "POST", "/api/v1/users", map[string]interface{}{
+		"username":           "expuser",
+		"display_name":       "Expired User",
+		"email":              "expuser@test.com",
+		"password":           "password123",
+		"account_expires_at": time.Now().Add(-time.Hour).Format(time.RFC3339),
+	}, adminToken))
+	var user domain.User
+	json.Unmarshal(r.Data, &user)
+	if r.Code != 0 || user.AccountExpiresAt == nil {
+		t.Fatalf("create user: %s", r.Message)
+	}
+	userDN := "uid=expuser,ou=users," + testBaseDN
+
+	t.Run("expired account cannot log in", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/auth/login", map[string]string{
+			"username": "expuser",
+			"password": "password123",
+		}, "")
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("login: status = %d, want 401", resp.StatusCode)
+		}
+		if err := ldapDial(t).Bind(userDN, "password123"); err == nil {
+			t.Error("bind for expired account should fail")
+		}
+	})
+
+	t.Run("search by expiry", func(t *testing.T) {
+		conn := ldapDial(t)
+		res, err := conn.Search(&goldap.SearchRequest{
+			BaseDN:     testBaseDN,
+			Scope:      goldap.ScopeWholeSubtree,
+			Filter:     "(&(objectClass=inetOrgPerson)(shadowExpire<=" + attrs.EpochDays(time.Now()) + "))",
+			Attributes: []string{"uid", "shadowExpire", "pwdChangedTime"},
+		})
+		if err != nil {
+			t.Fatalf("search: %v", err)
+		}
+		if len(res.Entries) != 1 || res.Entries[0].GetAttributeValue("uid") != "expuser" {
+			t.Fatalf("expired users = %d entries, want only expuser", len(res.Entries))
+		}
+		if res.Entries[0].GetAttributeValue("pwdChangedTime") == "" {
+			t.Error("missing pwdChangedTime")
+		}
+	})
+
+	t.Run("clearing expiry", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "PUT", "/api/v1/users/"+user.ID.String(),
+			map[string]string{"account_expires_at": ""}, adminToken))
+		var updated domain.User
+		json.Unmarshal(r.Data, &updated)
+		if r.Code != 0 || updated.AccountExpiresAt != nil {
+			t.Fatalf("update: %s, account_expires_at = %v", r.Message, updated.AccountExpiresAt)
+		}
+		if err := ldapDial(t).Bind(userDN, "password123"); err != nil {
+			t.Errorf("bind after clearing expiry: %v", err)
+		}
+	})
+}
+
+func TestLDAPBindImportedPasswordHash(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "hashadmin",
+		DisplayName: "Hash Admin",
+		Email:       "hashadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "hashadmin")
+	adminToken := loginAndGetToken(t, "hashadmin", "password123")
+
+	// A sha512-crypt hash of "Secret#Pass1", as exported from OpenLDAP.
+	const imported = "{CRYPT}$6$saltsalt$JsPIc4j4KNk2qTMmND5ebjyEQeD98VaoguQYvtrew4EG6ruEYunewxFYhvpp6pqrdm8.GBxlDRKYz2ipP8q2F1"
+	r := parseResponse(t, doAPI(t, "POST", "/api/v1/users", map[string]string{
+		"username":      "hashuser",
+		"display_name":  "Hash User",
+		"email":         "hashuser@test.com",
+		"password_hash": imported,
+	}, adminToken))
+	if r.Code != 0 {
+		t.Fatalf("create user: %s", r.Message)
+	}
+
+	r = parseResponse(t, doAPI(t, "POST", "/api/v1/users", map[string]string{
+		"username":      "badhash",
+		"display_name":  "Bad Hash",
+		"email":         "badhash@test.com",
+		"password_hash": "{MD5}X03MO1qnZdYdgyfeuILPmQ==",
+	}, adminToken))
+	if r.Code == 0 {
+		t.Error("creating a user with an unsupported hash should fail")
+	}
+
+	conn := ldapDial(t)
+	if err := conn.Bind("uid=hashuser,ou=users,"+testBaseDN, "wrongpassword"); err == nil {
+		t.Error("bind with wrong password should fail")
+	}
+	if err := conn.Bind("uid=hashuser,ou=users,"+testBaseDN, "Secret#Pass1"); err != nil {
+		t.Fatalf("bind with imported hash: %v", err)
+	}
+	u, err := userSvc.GetUserByUsername(t.Context(), "hashuser")
+	if err != nil || u.PasswordHash == imported {
+		t.Errorf("hash was not upgraded after bind: %v", err)
+	}
+	loginAndGetToken(t, "hashuser", "Secret#Pass1")
+}
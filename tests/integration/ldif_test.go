@@ -0,0 +1,143 @@
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+// importLDIF posts an LDIF file to the import endpoint.
+func importLDIF(t *testing.T, token, content string, dryRun bool) (int, apiResponse) {
+	t.Helper()
+	path := "/api/v1/import.ldif"
+	if dryRun {
+		path += "?dry_run=true"
+	}
+	req, err := http.NewRequest("POST", httpServer.URL+path, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", "text/x-ldif")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	return resp.StatusCode, parseResponse(t, resp)
+}
+
+func ldifReport(t *testing.T, r apiResponse) *domain.LDIFImportReport {
+	t.Helper()
+	if r.Code != 0 {
+		t.Fatalf("import LDIF: %s", r.Message)
+	}
+	var report domain.LDIFImportReport
+	if err := json.Unmarshal(r.Data, &report); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	return &report
+}
+
+func TestLDIF(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "ldifadmin",
+		DisplayName: "LDIF Admin",
+		Email:       "ldifadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "ldifadmin")
+	token := loginAndGetToken(t, "ldifadmin", "password123")
+	ctx := t.Context()
+
+	resp := doAPI(t, "GET", "/api/v1/export.ldif", nil, token)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/x-ldif") {
+		t.Fatalf("export = %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	export := string(body)
+	if !strings.Contains(export, "dn: uid=ldifadmin,ou=users,"+testBaseDN+"\n") {
+		t.Fatalf("export has no entry for ldifadmin:\n%s", export)
+	}
+
+	// The export imports back without changes.
+	status, r := importLDIF(t, token, export, true)
+	report := ldifReport(t, r)
+	if status != http.StatusOK || !report.DryRun || report.Created+report.Updated+report.Renamed+report.Deleted+report.Failed != 0 {
+		for _, e := range report.Entries {
+			if e.Action != domain.LDIFImportUnchanged {
+				t.Logf("%+v", e)
+			}
+		}
+		t.Fatalf("re-import = %d %+v", status, report)
+	}
+
+	content := `version: 1
+
+dn: uid=ldif-amy,ou=users,` + testBaseDN + `
+objectClass: inetOrgPerson
+uid: ldif-amy
+cn: Amy
+mail: ldif-amy@test.com
+userPassword: password123
+
+dn: cn=ldif-team,ou=groups,` + testBaseDN + `
+objectClass: groupOfNames
+cn: ldif-team
+member: uid=ldif-amy,ou=users,` + testBaseDN + `
+owner: uid=ldifadmin,ou=users,` + testBaseDN + `
+`
+	status, r = importLDIF(t, token, content, true)
+	if report = ldifReport(t, r); report.Created != 2 {
+		t.Fatalf("dry run = %d %+v", status, report)
+	}
+	if _, err := userSvc.GetUserByUsername(ctx, "ldif-amy"); err == nil {
+		t.Fatal("dry run created a user")
+	}
+	_, r = importLDIF(t, token, content, false)
+	if report = ldifReport(t, r); report.Created != 2 || report.Failed != 0 {
+		t.Fatalf("import = %+v", report)
+	}
+	loginAndGetToken(t, "ldif-amy", "password123")
+
+	changes := `dn: cn=ldif-team,ou=groups,` + testBaseDN + `
+changetype: modrdn
+newrdn: cn=ldif-crew
+deleteoldrdn: 1
+
+dn: uid=ldif-amy,ou=users,` + testBaseDN + `
+changetype: delete
+`
+	_, r = importLDIF(t, token, changes, false)
+	if report = ldifReport(t, r); report.Renamed != 1 || report.Deleted != 1 {
+		t.Fatalf("changes = %+v", report)
+	}
+	if _, err := userSvc.GetUserByUsername(ctx, "ldif-amy"); err == nil {
+		t.Error("ldif-amy was not deleted")
+	}
+	found := false
+	for _, g := range mustListGroups(t) {
+		found = found || g.Name == "ldif-crew"
+	}
+	if !found {
+		t.Error("ldif-team was not renamed")
+	}
+
+	status, r = importLDIF(t, token, "dn: uid=x\nchangetype: rename\n", false)
+	if status != http.StatusBadRequest {
+		t.Errorf("invalid LDIF = %d %s", status, r.Message)
+	}
+
+	// Importing requires user:write and group:admin.
+	ensureUser(t, domain.CreateUserInput{
+		Username: "ldifplain", DisplayName: "Plain", Email: "ldifplain@test.com", Password: "password123",
+	})
+	plain := loginAndGetToken(t, "ldifplain", "password123")
+	if status, _ = importLDIF(t, plain, content, true); status != http.StatusForbidden {
+		t.Errorf("import without permission = %d", status)
+	}
+}
@@ -0,0 +1,174 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+// loginFrom posts a login as if sent by the client at ip, which the router
+// takes from X-Forwarded-For because the test server trusts loopback as a
+// proxy.
+func loginFrom(t *testing.T, ip, username, password string) *http.Response {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"username": username, "password": password})
+	req, err := http.NewRequest("POST", httpServer.URL+"/api/v1/auth/login", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Forwarded-For", ip)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	return resp
+}
+
+func TestAccountLockout(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "lockadmin",
+		DisplayName: "Lockout Admin",
+		Email:       "lockadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "lockadmin")
+	adminToken := loginAndGetToken(t, "lockadmin", "password123")
+	u := ensureUser(t, domain.CreateUserInput{
+		Username:    "lockuser",
+		DisplayName: "Lockout User",
+		Email:       "lockuser@test.com",
+		Password:    "password123",
+	})
+	userDN := "uid=lockuser,ou=users," + testBaseDN
+	lockoutPath := "/api/v1/users/" + u.ID.String() + "/lockout"
+	const client = "198.51.100.7"
+
+	t.Run("failed logins lock the account", func(t *testing.T) {
+		for range lockoutPolicy.Threshold {
+			resp := loginFrom(t, client, "lockuser", "wrongpassword")
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Fatalf("wrong password: status = %d, want 401", resp.StatusCode)
+			}
+			resp.Body.Close()
+		}
+
+		resp := loginFrom(t, client, "lockuser", "password123")
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("locked account: status = %d, want 429", resp.StatusCode)
+		}
+		if resp.Header.Get("Retry-After") == "" {
+			t.Error("locked account: missing Retry-After header")
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("ldap bind reports the lockout", func(t *testing.T) {
+		ctrl, err := bindWithPolicy(t, userDN, "password123")
+		var lerr *goldap.Error
+		if !errors.As(err, &lerr) || lerr.ResultCode != goldap.LDAPResultInvalidCredentials {
+			t.Fatalf("bind: expected invalid credentials, got %v", err)
+		}
+		if ctrl == nil || ctrl.Error != 1 {
+			t.Errorf("policy control = %+v, want error accountLocked", ctrl)
+		}
+
+		conn := ldapDial(t)
+		res, err := conn.Search(&goldap.SearchRequest{
+			BaseDN:     testBaseDN,
+			Scope:      goldap.ScopeWholeSubtree,
+			Filter:     "(uid=lockuser)",
+			Attributes: []string{"pwdAccountLockedTime"},
+		})
+		if err != nil || len(res.Entries) != 1 {
+			t.Fatalf("search: %v", err)
+		}
+		if res.Entries[0].GetAttributeValue("pwdAccountLockedTime") == "" {
+			t.Error("locked user has no pwdAccountLockedTime")
+		}
+	})
+
+	t.Run("lockout status lists the events", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "GET", lockoutPath, nil, adminToken))
+		var st domain.LockoutStatus
+		json.Unmarshal(r.Data, &st)
+		if r.Code != 0 || !st.Locked || st.LockedUntil == nil {
+			t.Fatalf("status = %+v (%s), want locked", st, r.Message)
+		}
+
+		seen := map[string]bool{}
+		for _, e := range st.Events {
+			seen[string(e.Type)+" "+e.Protocol+" "+e.IPAddress] = true
+		}
+		for _, want := range []string{
+			"failure http " + client,
+			"locked http " + client,
+			"rejected http " + client,
+			"rejected ldap " + ldapClientIP,
+		} {
+			if !seen[want] {
+				t.Errorf("missing event %q in %v", want, seen)
+			}
+		}
+	})
+
+	t.Run("unlock", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "DELETE", lockoutPath, nil, adminToken))
+		if r.Code != 0 {
+			t.Fatalf("unlock: %s", r.Message)
+		}
+		resp := loginFrom(t, client, "lockuser", "password123")
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("login after unlock: status = %d, want 200", resp.StatusCode)
+		}
+		resp.Body.Close()
+		if err := ldapDial(t).Bind(userDN, "password123"); err != nil {
+			t.Errorf("bind after unlock: %v", err)
+		}
+	})
+
+	t.Run("failed logins block the client address", func(t *testing.T) {
+		// Failures on unknown usernames count too, so spraying guesses
+		// across accounts cannot avoid the block.
+		const attacker = "203.0.113.9"
+		for range lockoutPolicy.IPThreshold {
+			resp := loginFrom(t, attacker, "nosuchuser", "guess")
+			resp.Body.Close()
+		}
+
+		resp := loginFrom(t, attacker, "lockuser", "password123")
+		r := parseResponse(t, resp)
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("blocked client: status = %d (%s), want 429", resp.StatusCode, r.Message)
+		}
+		resp = loginFrom(t, client, "lockuser", "password123")
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("other client: status = %d, want 200", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("failed binds block the client address", func(t *testing.T) {
+		const attacker = "127.0.0.2"
+		conn := ldapDialFrom(t, attacker)
+		for range lockoutPolicy.IPThreshold {
+			_ = conn.Bind("uid=nosuchuser,ou=users,"+testBaseDN, "guess")
+		}
+
+		err := ldapDialFrom(t, attacker).Bind(userDN, "password123")
+		var lerr *goldap.Error
+		if !errors.As(err, &lerr) || lerr.ResultCode != goldap.LDAPResultInvalidCredentials {
+			t.Fatalf("bind from blocked client: expected invalid credentials, got %v", err)
+		}
+		if err := ldapDial(t).Bind(userDN, "password123"); err != nil {
+			t.Errorf("bind from other client: %v", err)
+		}
+
+	})
+}
@@ -0,0 +1,193 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/totp"
+)
+
+// mfaLogin is the login response, either tokens or an MFA challenge.
+type mfaLogin struct {
+	Token              string   `json:"token"`
+	MFARequired        bool     `json:"mfa_required"`
+	MFAToken           string   `json:"mfa_token"`
+	EnrollmentRequired bool     `json:"enrollment_required"`
+	RecoveryCodes      []string `json:"recovery_codes"`
+}
+
+func postLogin(t *testing.T, path string, body map[string]string) (apiResponse, mfaLogin) {
+	t.Helper()
+	r := parseResponse(t, doAPI(t, "POST", path, body, ""))
+	var data mfaLogin
+	json.Unmarshal(r.Data, &data)
+	return r, data
+}
+
+// codeAt returns the TOTP code for secret, offset by whole time steps so
+// tests do not reuse a code that was already accepted.
+func codeAt(t *testing.T, secret string, steps int) string {
+	t.Helper()
+	code, err := totp.Code(secret, time.Now().Add(time.Duration(steps)*totp.Period*time.Second))
+	if err != nil {
+		t.Fatalf("totp code: %v", err)
+	}
+	return code
+}
+
+func TestMFA(t *testing.T) {
+	userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "mfaadmin",
+		DisplayName: "MFA Admin",
+		Email:       "mfaadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "mfaadmin")
+	adminToken := loginAndGetToken(t, "mfaadmin", "password123")
+
+	u, err := userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "mfauser",
+		DisplayName: "MFA User",
+		Email:       "mfauser@test.com",
+		Password:    "password123",
+	})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	userToken := loginAndGetToken(t, "mfauser", "password123")
+
+	// Each accepted TOTP code must come from a newer time step than the last
+	// one, so the subtests below use steps -1, 0 and +1 in turn.
+	var secret string
+	var recoveryCodes []string
+
+	t.Run("enroll totp", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "POST", "/api/v1/me/mfa/totp", nil, userToken))
+		var enrollment domain.TOTPEnrollment
+		json.Unmarshal(r.Data, &enrollment)
+		if r.Code != 0 || enrollment.Secret == "" {
+			t.Fatalf("begin enrollment: %s", r.Message)
+		}
+		secret = enrollment.Secret
+
+		resp := doAPI(t, "POST", "/api/v1/me/mfa/totp/confirm", map[string]string{"code": "000000"}, userToken)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("wrong code: expected 400, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+
+		r = parseResponse(t, doAPI(t, "POST", "/api/v1/me/mfa/totp/confirm", map[string]string{"code": codeAt(t, secret, -1)}, userToken))
+		var codes struct {
+			RecoveryCodes []string `json:"recovery_codes"`
+		}
+		json.Unmarshal(r.Data, &codes)
+		if r.Code != 0 || len(codes.RecoveryCodes) == 0 {
+			t.Fatalf("confirm enrollment: %s", r.Message)
+		}
+		recoveryCodes = codes.RecoveryCodes
+
+		r = parseResponse(t, doAPI(t, "GET", "/api/v1/me/mfa", nil, userToken))
+		var status domain.MFAStatus
+		json.Unmarshal(r.Data, &status)
+		if !status.Enabled || status.RecoveryCodesRemaining != len(recoveryCodes) {
+			t.Errorf("unexpected status %+v", status)
+		}
+	})
+
+	t.Run("login requires second factor", func(t *testing.T) {
+		_, login := postLogin(t, "/api/v1/auth/login", map[string]string{"username": "mfauser", "password": "password123"})
+		if !login.MFARequired || login.MFAToken == "" || login.Token != "" {
+			t.Fatalf("expected MFA challenge, got %+v", login)
+		}
+
+		resp := doAPI(t, "GET", "/api/v1/me", nil, login.MFAToken)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("challenge token as bearer: expected 401, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+
+		resp = doAPI(t, "POST", "/api/v1/auth/mfa/verify", map[string]string{"mfa_token": login.MFAToken, "code": "000000"}, "")
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("wrong code: expected 401, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+
+		r, verified := postLogin(t, "/api/v1/auth/mfa/verify", map[string]string{"mfa_token": login.MFAToken, "code": codeAt(t, secret, 0)})
+		if r.Code != 0 || verified.Token == "" {
+			t.Fatalf("verify: %s", r.Message)
+		}
+		resp = doAPI(t, "GET", "/api/v1/me", nil, verified.Token)
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("access token: expected 200, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("login with recovery code", func(t *testing.T) {
+		_, login := postLogin(t, "/api/v1/auth/login", map[string]string{"username": "mfauser", "password": "password123"})
+		r, verified := postLogin(t, "/api/v1/auth/mfa/verify", map[string]string{"mfa_token": login.MFAToken, "code": recoveryCodes[0]})
+		if r.Code != 0 || verified.Token == "" {
+			t.Fatalf("verify with recovery code: %s", r.Message)
+		}
+	})
+
+	t.Run("ldap bind with password and otp", func(t *testing.T) {
+		ldapCfg.OTP = true
+		t.Cleanup(func() { ldapCfg.OTP = false })
+
+		bindDN := "uid=mfauser,ou=users," + testBaseDN
+		if err := ldapDial(t).Bind(bindDN, "password123"); err == nil {
+			t.Error("bind without otp should fail")
+		}
+		if err := ldapDial(t).Bind(bindDN, "password123"+codeAt(t, secret, 1)); err != nil {
+			t.Errorf("bind with password+otp: %v", err)
+		}
+	})
+
+	t.Run("admin resets mfa", func(t *testing.T) {
+		resp := doAPI(t, "DELETE", "/api/v1/users/"+u.ID.String()+"/mfa", nil, adminToken)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("reset: expected 200, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+
+		_, login := postLogin(t, "/api/v1/auth/login", map[string]string{"username": "mfauser", "password": "password123"})
+		if login.MFARequired || login.Token == "" {
+			t.Errorf("expected plain login after reset, got %+v", login)
+		}
+	})
+
+	t.Run("group requires enrollment", func(t *testing.T) {
+		gid := createGroupViaAPI(t, adminToken, map[string]interface{}{"name": "mfa-required"})
+		resp := doAPI(t, "PUT", "/api/v1/groups/"+gid, map[string]interface{}{"require_mfa": true}, adminToken)
+		resp.Body.Close()
+		resp = doAPI(t, "POST", "/api/v1/groups/"+gid+"/members", map[string]interface{}{"user_ids": []string{u.ID.String()}}, adminToken)
+		resp.Body.Close()
+
+		_, login := postLogin(t, "/api/v1/auth/login", map[string]string{"username": "mfauser", "password": "password123"})
+		if !login.MFARequired || !login.EnrollmentRequired {
+			t.Fatalf("expected enrollment challenge, got %+v", login)
+		}
+
+		r := parseResponse(t, doAPI(t, "POST", "/api/v1/auth/mfa/enroll", map[string]string{"mfa_token": login.MFAToken}, ""))
+		var enrollment domain.TOTPEnrollment
+		json.Unmarshal(r.Data, &enrollment)
+		if r.Code != 0 || enrollment.ProvisioningURI == "" {
+			t.Fatalf("enroll: %s", r.Message)
+		}
+
+		r, confirmed := postLogin(t, "/api/v1/auth/mfa/enroll/confirm", map[string]string{"mfa_token": login.MFAToken, "code": codeAt(t, enrollment.Secret, 0)})
+		if r.Code != 0 || confirmed.Token == "" || len(confirmed.RecoveryCodes) == 0 {
+			t.Fatalf("confirm: %s", r.Message)
+		}
+
+		resp = doAPI(t, "DELETE", "/api/v1/me/mfa", map[string]string{"code": confirmed.RecoveryCodes[0]}, confirmed.Token)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("disable while required: expected 403, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+}
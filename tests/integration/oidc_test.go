@@ -0,0 +1,302 @@
+package integration
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"html"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+var (
+	csrfTokenPattern = regexp.MustCompile(`name="csrf_token" value="([^"]+)"`)
+	requestPattern   = regexp.MustCompile(`name="request" value="([^"]*)"`)
+)
+
+// newBrowser returns an HTTP client that keeps cookies and stops at
+// redirects leaving the server, like a browser handing off to a client app.
+func newBrowser(t *testing.T) *http.Client {
+	t.Helper()
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("cookie jar: %v", err)
+	}
+	server, _ := url.Parse(httpServer.URL)
+	return &http.Client{
+		Jar: jar,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Host != server.Host {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+}
+
+// readPage reads an HTML response body and checks its status.
+func readPage(t *testing.T, resp *http.Response, want int) string {
+	t.Helper()
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != want {
+		t.Fatalf("expected %d, got %d: %s", want, resp.StatusCode, body)
+	}
+	return string(body)
+}
+
+func formValue(t *testing.T, page string, pattern *regexp.Regexp) string {
+	t.Helper()
+	m := pattern.FindStringSubmatch(page)
+	if m == nil {
+		t.Fatalf("%s not found in page: %s", pattern, page)
+	}
+	return html.UnescapeString(m[1])
+}
+
+func TestOIDCFlow(t *testing.T) {
+	userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "oidcadmin",
+		DisplayName: "OIDC Admin",
+		Email:       "oidcadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "oidcadmin")
+	adminToken := loginAndGetToken(t, "oidcadmin", "password123")
+
+	u, _ := userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "oidcuser",
+		DisplayName: "OIDC User",
+		Email:       "oidcuser@test.com",
+		Password:    "password123",
+	})
+	groupID := createGroupViaAPI(t, adminToken, map[string]interface{}{"name": "oidc-readers"})
+	r := parseResponse(t, doAPI(t, "POST", "/api/v1/groups/"+groupID+"/members", map[string]interface{}{
+		"user_ids": []string{u.ID.String()},
+	}, adminToken))
+	if r.Code != 0 {
+		t.Fatalf("add member: %s", r.Message)
+	}
+
+	// Discovery advertises endpoints under the issuer.
+	resp := doAPI(t, "GET", "/.well-known/openid-configuration", nil, "")
+	var discovery domain.OIDCDiscovery
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		t.Fatalf("decode discovery: %v", err)
+	}
+	resp.Body.Close()
+	if discovery.Issuer != httpServer.URL || discovery.TokenEndpoint != httpServer.URL+"/oauth2/token" {
+		t.Errorf("unexpected discovery document: %+v", discovery)
+	}
+
+	// Registering clients requires oidc:admin.
+	userToken := loginAndGetToken(t, "oidcuser", "password123")
+	resp = doAPI(t, "POST", "/api/v1/oidc/clients", map[string]interface{}{
+		"name": "forbidden", "redirect_uris": []string{"https://app.test/cb"},
+	}, userToken)
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("register client without permission: expected 403, got %d", resp.StatusCode)
+	}
+	resp.Body.Close()
+
+	redirectURI := "https://app.test/callback"
+	r = parseResponse(t, doAPI(t, "POST", "/api/v1/oidc/clients", map[string]interface{}{
+		"name": "Test App", "redirect_uris": []string{redirectURI},
+	}, adminToken))
+	if r.Code != 0 {
+		t.Fatalf("register client: %s", r.Message)
+	}
+	var registered struct {
+		Client       domain.OIDCClient `json:"client"`
+		ClientSecret string            `json:"client_secret"`
+	}
+	if err := json.Unmarshal(r.Data, &registered); err != nil {
+		t.Fatalf("unmarshal client: %v", err)
+	}
+	clientID := registered.Client.ID.String()
+
+	verifier := "test-verifier-0123456789-abcdefghijklmnopqrstuvwxyz"
+	sum := sha256.Sum256([]byte(verifier))
+	authorizeURL := httpServer.URL + "/oauth2/authorize?" + url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {"openid profile email groups"},
+		"state":                 {"xyz"},
+		"nonce":                 {"nonce-123"},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+	browser := newBrowser(t)
+
+	t.Run("unregistered redirect shows error", func(t *testing.T) {
+		resp, err := browser.Get(strings.Replace(authorizeURL, url.QueryEscape(redirectURI), url.QueryEscape("https://evil.test/cb"), 1))
+		if err != nil {
+			t.Fatalf("authorize: %v", err)
+		}
+		readPage(t, resp, http.StatusBadRequest)
+	})
+
+	t.Run("prompt none without session", func(t *testing.T) {
+		resp, err := browser.Get(authorizeURL + "&prompt=none")
+		if err != nil {
+			t.Fatalf("authorize: %v", err)
+		}
+		resp.Body.Close()
+		loc, _ := url.Parse(resp.Header.Get("Location"))
+		if resp.StatusCode != http.StatusFound || loc.Query().Get("error") != "login_required" || loc.Query().Get("state") != "xyz" {
+			t.Errorf("expected login_required redirect, got %d %s", resp.StatusCode, loc)
+		}
+	})
+
+	var code string
+	t.Run("login and consent", func(t *testing.T) {
+		resp, err := browser.Get(authorizeURL)
+		if err != nil {
+			t.Fatalf("authorize: %v", err)
+		}
+		page := readPage(t, resp, http.StatusOK)
+		request := formValue(t, page, requestPattern)
+		loginCSRF := formValue(t, page, csrfTokenPattern)
+
+		// A login form posted from another site lacks the token.
+		resp, err = browser.PostForm(httpServer.URL+"/oauth2/login", url.Values{
+			"request": {request}, "username": {"oidcuser"}, "password": {"password123"},
+		})
+		if err != nil {
+			t.Fatalf("login: %v", err)
+		}
+		readPage(t, resp, http.StatusForbidden)
+
+		resp, err = browser.PostForm(httpServer.URL+"/oauth2/login", url.Values{
+			"request": {request}, "csrf_token": {loginCSRF}, "username": {"oidcuser"}, "password": {"wrong"},
+		})
+		if err != nil {
+			t.Fatalf("login: %v", err)
+		}
+		if formValue(t, readPage(t, resp, http.StatusUnauthorized), csrfTokenPattern) != loginCSRF {
+			t.Error("the login form token changed after a failed login")
+		}
+
+		// A successful login continues to the consent page.
+		resp, err = browser.PostForm(httpServer.URL+"/oauth2/login", url.Values{
+			"request": {request}, "csrf_token": {loginCSRF}, "username": {"oidcuser"}, "password": {"password123"},
+		})
+		if err != nil {
+			t.Fatalf("login: %v", err)
+		}
+		page = readPage(t, resp, http.StatusOK)
+		if !strings.Contains(page, "Test App") {
+			t.Fatalf("expected consent page, got: %s", page)
+		}
+		csrf := formValue(t, page, csrfTokenPattern)
+
+		resp, err = browser.PostForm(httpServer.URL+"/oauth2/consent", url.Values{
+			"request": {request}, "csrf_token": {"forged"}, "decision": {"allow"},
+		})
+		if err != nil {
+			t.Fatalf("consent: %v", err)
+		}
+		readPage(t, resp, http.StatusForbidden)
+
+		resp, err = browser.PostForm(httpServer.URL+"/oauth2/consent", url.Values{
+			"request": {request}, "csrf_token": {csrf}, "decision": {"allow"},
+		})
+		if err != nil {
+			t.Fatalf("consent: %v", err)
+		}
+		resp.Body.Close()
+		loc, _ := url.Parse(resp.Header.Get("Location"))
+		if resp.StatusCode != http.StatusFound || !strings.HasPrefix(loc.String(), redirectURI) || loc.Query().Get("state") != "xyz" {
+			t.Fatalf("expected redirect to client, got %d %s", resp.StatusCode, loc)
+		}
+		code = loc.Query().Get("code")
+	})
+	if code == "" {
+		t.Fatal("no authorization code issued")
+	}
+
+	var tokens domain.TokenResponse
+	t.Run("token exchange", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", httpServer.URL+"/oauth2/token", strings.NewReader(url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {code},
+			"redirect_uri":  {redirectURI},
+			"code_verifier": {verifier},
+		}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(clientID, registered.ClientSecret)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("token: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+			t.Fatalf("decode tokens: %v", err)
+		}
+
+		claims := jwt.MapClaims{}
+		if _, err := jwt.ParseWithClaims(tokens.IDToken, claims, jwksKeyfunc(t),
+			jwt.WithIssuer(httpServer.URL), jwt.WithAudience(clientID)); err != nil {
+			t.Fatalf("verify ID token: %v", err)
+		}
+		if claims["sub"] != u.ID.String() || claims["nonce"] != "nonce-123" || claims["preferred_username"] != "oidcuser" {
+			t.Errorf("unexpected ID token claims: %v", claims)
+		}
+		if groups, _ := claims["groups"].([]any); len(groups) != 1 || groups[0] != "oidc-readers" {
+			t.Errorf("groups = %v, want [oidc-readers]", claims["groups"])
+		}
+	})
+
+	t.Run("userinfo", func(t *testing.T) {
+		resp := doAPI(t, "GET", "/oauth2/userinfo", nil, tokens.AccessToken)
+		defer resp.Body.Close()
+		var info map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+			t.Fatalf("decode userinfo: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK || info["email"] != "oidcuser@test.com" {
+			t.Errorf("unexpected userinfo %d: %v", resp.StatusCode, info)
+		}
+
+		resp = doAPI(t, "GET", "/oauth2/userinfo", nil, "invalid")
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("invalid token: expected 401, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("access token rejected by API", func(t *testing.T) {
+		resp := doAPI(t, "GET", "/api/v1/me", nil, tokens.AccessToken)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("silent reauthorize", func(t *testing.T) {
+		// With a session and stored consent, prompt=none returns a code directly.
+		resp, err := browser.Get(authorizeURL + "&prompt=none")
+		if err != nil {
+			t.Fatalf("authorize: %v", err)
+		}
+		resp.Body.Close()
+		loc, _ := url.Parse(resp.Header.Get("Location"))
+		if resp.StatusCode != http.StatusFound || loc.Query().Get("code") == "" {
+			t.Errorf("expected code redirect, got %d %s", resp.StatusCode, loc)
+		}
+	})
+}
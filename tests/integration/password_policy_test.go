@@ -0,0 +1,239 @@
+package integration
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+// policyViolations returns the violation codes of a rejected password.
+func policyViolations(t *testing.T, r apiResponse) []string {
+	t.Helper()
+	var data struct {
+		Violations []domain.PasswordViolation `json:"violations"`
+	}
+	json.Unmarshal(r.Data, &data)
+	codes := make([]string, len(data.Violations))
+	for i, v := range data.Violations {
+		codes[i] = v.Code
+	}
+	return codes
+}
+
+// bindWithPolicy binds with the password policy request control and
+// returns the policy response control, if any.
+func bindWithPolicy(t *testing.T, dn, password string) (*goldap.ControlBeheraPasswordPolicy, error) {
+	t.Helper()
+	conn := ldapDial(t)
+	res, err := conn.SimpleBind(&goldap.SimpleBindRequest{
+		Username: dn,
+		Password: password,
+		Controls: []goldap.Control{goldap.NewControlBeheraPasswordPolicy()},
+	})
+	if res == nil {
+		return nil, err
+	}
+	ctrl, _ := goldap.FindControl(res.Controls, goldap.ControlTypeBeheraPasswordPolicy).(*goldap.ControlBeheraPasswordPolicy)
+	return ctrl, err
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	userSvc.CreateUser(t.Context(), domain.CreateUserInput{
+		Username:    "ppadmin",
+		DisplayName: "Policy Admin",
+		Email:       "ppadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "ppadmin")
+	adminToken := loginAndGetToken(t, "ppadmin", "password123")
+	userDN := "uid=ppuser,ou=users," + testBaseDN
+
+	t.Run("create user checks default policy", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "POST", "/api/v1/users", map[string]interface{}{
+			"username":     "ppuser",
+			"display_name": "Policy User",
+			"email":        "ppuser@test.com",
+			"password":     "short",
+		}, adminToken))
+		if codes := policyViolations(t, r); !slices.Equal(codes, []string{domain.PasswordTooShort}) {
+			t.Errorf("violations = %v, want [too_short] (%s)", codes, r.Message)
+		}
+	})
+
+	r := parseResponse(t, doAPI(t, "POST", "/api/v1/users", map[string]interface{}{
+		"username":             "ppuser",
+		"display_name":         "Policy User",
+		"email":                "ppuser@test.com",
+		"password":             "password123",
+		"must_change_password": true,
+	}, adminToken))
+	var user domain.User
+	json.Unmarshal(r.Data, &user)
+	if r.Code != 0 || !user.MustChangePassword {
+		t.Fatalf("create user: %s", r.Message)
+	}
+
+	r = parseResponse(t, doAPI(t, "POST", "/api/v1/groups", map[string]string{"name": "pp-staff"}, adminToken))
+	var group domain.Group
+	json.Unmarshal(r.Data, &group)
+	parseResponse(t, doAPI(t, "POST", "/api/v1/groups/"+group.ID.String()+"/members",
+		map[string][]string{"user_ids": {user.ID.String()}}, adminToken))
+
+	r = parseResponse(t, doAPI(t, "POST", "/api/v1/password-policies", map[string]interface{}{
+		"name":             "pp-strict",
+		"min_length":       12,
+		"require_digit":    true,
+		"require_symbol":   true,
+		"check_dictionary": true,
+		"check_username":   true,
+		"history_count":    2,
+		"max_age_days":     30,
+	}, adminToken))
+	var policy domain.PasswordPolicy
+	json.Unmarshal(r.Data, &policy)
+	if r.Code != 0 {
+		t.Fatalf("create policy: %s", r.Message)
+	}
+	r = parseResponse(t, doAPI(t, "POST", "/api/v1/password-policies/"+policy.ID.String()+"/groups",
+		map[string][]string{"group_ids": {group.ID.String()}}, adminToken))
+	if r.Code != 0 {
+		t.Fatalf("assign policy: %s", r.Message)
+	}
+
+	t.Run("forced change limits api access", func(t *testing.T) {
+		var data struct {
+			Token                  string `json:"token"`
+			PasswordChangeRequired bool   `json:"password_change_required"`
+		}
+		r := parseResponse(t, doAPI(t, "POST", "/api/v1/auth/login", map[string]string{"username": "ppuser", "password": "password123"}, ""))
+		json.Unmarshal(r.Data, &data)
+		if !data.PasswordChangeRequired || data.Token == "" {
+			t.Fatalf("expected password_change_required on login: %s", r.Data)
+		}
+
+		resp := doAPI(t, "GET", "/api/v1/me/groups", nil, data.Token)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("GET /me/groups: expected 403, got %d", resp.StatusCode)
+		}
+
+		r = parseResponse(t, doAPI(t, "GET", "/api/v1/me/password", nil, data.Token))
+		var st domain.PasswordStatus
+		json.Unmarshal(r.Data, &st)
+		if !st.MustChange || st.Rules.MinLength != 12 || st.Rules.HistoryCount != 2 {
+			t.Errorf("password status = %+v", st)
+		}
+
+		for _, tt := range []struct {
+			password string
+			want     string
+		}{
+			{"P@ssword20240", domain.PasswordInDictionary},
+			{"Ppuser-Secret9", domain.PasswordContainsUsername},
+		} {
+			r = parseResponse(t, doAPI(t, "PUT", "/api/v1/me/password", map[string]string{
+				"old_password": "password123",
+				"new_password": tt.password,
+			}, data.Token))
+			if codes := policyViolations(t, r); !slices.Equal(codes, []string{tt.want}) {
+				t.Errorf("%s: violations = %v, want [%s]", tt.password, codes, tt.want)
+			}
+		}
+	})
+
+	t.Run("ldap bind reports change after reset", func(t *testing.T) {
+		ctrl, err := bindWithPolicy(t, userDN, "password123")
+		if err != nil {
+			t.Fatalf("bind: %v", err)
+		}
+		if ctrl == nil || ctrl.Error != 2 {
+			t.Errorf("policy control = %+v, want error changeAfterReset", ctrl)
+		}
+	})
+
+	t.Run("ldap password change", func(t *testing.T) {
+		conn := ldapDial(t)
+
+		req := goldap.NewModifyRequest(userDN, nil)
+		req.Replace("userPassword", []string{"Sturdy#Horse42"})
+		var lerr *goldap.Error
+		if err := conn.Modify(req); !errors.As(err, &lerr) || lerr.ResultCode != goldap.LDAPResultInsufficientAccessRights {
+			t.Errorf("replace: expected insufficient access rights, got %v", err)
+		}
+
+		req = goldap.NewModifyRequest(userDN, nil)
+		req.Delete("userPassword", []string{"password123"})
+		req.Add("userPassword", []string{"weak"})
+		if err := conn.Modify(req); !errors.As(err, &lerr) || lerr.ResultCode != goldap.LDAPResultConstraintViolation {
+			t.Errorf("weak password: expected constraint violation, got %v", err)
+		}
+
+		req = goldap.NewModifyRequest(userDN, nil)
+		req.Delete("userPassword", []string{"wrong-password"})
+		req.Add("userPassword", []string{"Sturdy#Horse42"})
+		if err := conn.Modify(req); !errors.As(err, &lerr) || lerr.ResultCode != goldap.LDAPResultInvalidCredentials {
+			t.Errorf("wrong old password: expected invalid credentials, got %v", err)
+		}
+
+		req = goldap.NewModifyRequest(userDN, nil)
+		req.Delete("userPassword", []string{"password123"})
+		req.Add("userPassword", []string{"Sturdy#Horse42"})
+		if err := conn.Modify(req); err != nil {
+			t.Fatalf("change password: %v", err)
+		}
+
+		ctrl, err := bindWithPolicy(t, userDN, "Sturdy#Horse42")
+		if err != nil {
+			t.Fatalf("bind with new password: %v", err)
+		}
+		if ctrl != nil {
+			t.Errorf("unexpected policy control %+v", ctrl)
+		}
+
+		token := loginAndGetToken(t, "ppuser", "Sturdy#Horse42")
+		resp := doAPI(t, "GET", "/api/v1/me/groups", nil, token)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GET /me/groups after change: expected 200, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("admin reset respects history", func(t *testing.T) {
+		path := "/api/v1/users/" + user.ID.String() + "/password/reset"
+		r := parseResponse(t, doAPI(t, "POST", path, map[string]string{"new_password": "Sturdy#Horse42"}, adminToken))
+		if codes := policyViolations(t, r); !slices.Equal(codes, []string{domain.PasswordReused}) {
+			t.Errorf("violations = %v, want [reused]", codes)
+		}
+
+		r = parseResponse(t, doAPI(t, "POST", path, map[string]string{"new_password": "Brave#Falcon77"}, adminToken))
+		if r.Code != 0 {
+			t.Fatalf("reset: %s", r.Message)
+		}
+		u, _ := userSvc.GetUser(t.Context(), user.ID)
+		if !u.MustChangePassword {
+			t.Error("expected reset to require a password change")
+		}
+	})
+
+	t.Run("builtin policy cannot be deleted", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "GET", "/api/v1/password-policies", nil, adminToken))
+		var policies []domain.PasswordPolicy
+		json.Unmarshal(r.Data, &policies)
+		for _, p := range policies {
+			if !p.Builtin {
+				continue
+			}
+			resp := doAPI(t, "DELETE", "/api/v1/password-policies/"+p.ID.String(), nil, adminToken)
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("delete built-in policy: expected 400, got %d", resp.StatusCode)
+			}
+		}
+	})
+}
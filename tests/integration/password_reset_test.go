@@ -0,0 +1,126 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+)
+
+var resetLinkPattern = regexp.MustCompile(`http://\S+/reset-password\?token=\S+`)
+
+// resetTokenFrom returns the token of the reset link in a mail.
+func resetTokenFrom(t *testing.T, m sentMail) string {
+	t.Helper()
+	return linkTokenFrom(t, m, resetLinkPattern)
+}
+
+// linkTokenFrom returns the token of the link matching pattern in a mail.
+func linkTokenFrom(t *testing.T, m sentMail, pattern *regexp.Regexp) string {
+	t.Helper()
+	u, err := url.Parse(pattern.FindString(m.body))
+	if err != nil || u.Query().Get("token") == "" {
+		t.Fatalf("no link in mail: %q", m.body)
+	}
+	return u.Query().Get("token")
+}
+
+func TestPasswordReset(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "resetadmin",
+		DisplayName: "Reset Admin",
+		Email:       "resetadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "resetadmin")
+	adminToken := loginAndGetToken(t, "resetadmin", "password123")
+	u := ensureUser(t, domain.CreateUserInput{
+		Username:    "forgetful",
+		DisplayName: "Forgetful User",
+		Email:       "forgetful@test.com",
+		Password:    "password123",
+	})
+	userToken := loginAndGetToken(t, "forgetful", "password123")
+
+	t.Run("unknown users get the same answer", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "POST", "/api/v1/auth/password/forgot", map[string]string{"login": "nobody@test.com"}, ""))
+		if r.Code != 0 {
+			t.Fatalf("forgot: %s", r.Message)
+		}
+		assertNoMail(t)
+	})
+
+	var token string
+	t.Run("forgot password mails a link", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "POST", "/api/v1/auth/password/forgot", map[string]string{"login": "forgetful@test.com"}, ""))
+		if r.Code != 0 {
+			t.Fatalf("forgot: %s", r.Message)
+		}
+		m := receiveMail(t)
+		if m.to != "forgetful@test.com" {
+			t.Errorf("mail sent to %s", m.to)
+		}
+		token = resetTokenFrom(t, m)
+
+		r = parseResponse(t, doAPI(t, "GET", "/api/v1/auth/password/reset?token="+url.QueryEscape(token), nil, ""))
+		var link struct {
+			Username string `json:"username"`
+		}
+		json.Unmarshal(r.Data, &link)
+		if r.Code != 0 || link.Username != "forgetful" {
+			t.Errorf("check link = %s (%s)", r.Data, r.Message)
+		}
+	})
+
+	t.Run("weak password keeps the link", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/auth/password/reset", map[string]string{"token": token, "new_password": "short"}, "")
+		r := parseResponse(t, resp)
+		if resp.StatusCode != http.StatusBadRequest || len(policyViolations(t, r)) == 0 {
+			t.Fatalf("weak password: status = %d (%s), want policy violations", resp.StatusCode, r.Message)
+		}
+	})
+
+	t.Run("reset password", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "POST", "/api/v1/auth/password/reset", map[string]string{"token": token, "new_password": "newpassword456"}, ""))
+		if r.Code != 0 {
+			t.Fatalf("reset: %s", r.Message)
+		}
+		loginAndGetToken(t, "forgetful", "newpassword456")
+
+		// Resetting the password signs the user out everywhere.
+		resp := doAPI(t, "GET", "/api/v1/me", nil, userToken)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("old session: status = %d, want 401", resp.StatusCode)
+		}
+
+		resp = doAPI(t, "POST", "/api/v1/auth/password/reset", map[string]string{"token": token, "new_password": "another789"}, "")
+		r = parseResponse(t, resp)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("reused link: status = %d (%s), want 400", resp.StatusCode, r.Message)
+		}
+	})
+
+	t.Run("administrator sends a link", func(t *testing.T) {
+		path := "/api/v1/users/" + u.ID.String() + "/password/reset-link"
+		resp := doAPI(t, "POST", path, nil, loginAndGetToken(t, "forgetful", "newpassword456"))
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("without user:write: status = %d, want 403", resp.StatusCode)
+		}
+
+		r := parseResponse(t, doAPI(t, "POST", path, nil, adminToken))
+		if r.Code != 0 {
+			t.Fatalf("send link: %s", r.Message)
+		}
+		m := receiveMail(t)
+		r = parseResponse(t, doAPI(t, "POST", "/api/v1/auth/password/reset", map[string]string{"token": resetTokenFrom(t, m), "new_password": "password123"}, ""))
+		if r.Code != 0 {
+			t.Fatalf("reset: %s", r.Message)
+		}
+		loginAndGetToken(t, "forgetful", "password123")
+	})
+}
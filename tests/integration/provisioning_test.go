@@ -0,0 +1,145 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/scim/client"
+	"github.com/qinzj/claude-demo/internal/scim/scimtest"
+)
+
+func TestProvisioning(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "provisioningadmin",
+		DisplayName: "Provisioning Admin",
+		Email:       "provisioningadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "provisioningadmin")
+	token := loginAndGetToken(t, "provisioningadmin", "password123")
+	app := scimtest.NewServer(t, "app-token")
+
+	// Only members of the scope group are provisioned, so that users of
+	// other tests are not.
+	userID := createUserViaAPI(t, token, map[string]string{
+		"username":     "provisioneduser",
+		"display_name": "Provisioned User",
+		"email":        "provisioneduser@test.com",
+		"password":     "password123",
+	})
+	groupID := createGroupViaAPI(t, token, map[string]interface{}{"name": "provisioned-app"})
+	resp := doAPI(t, "POST", "/api/v1/groups/"+groupID+"/members", map[string]interface{}{"user_ids": []string{userID}}, token)
+	if r := parseResponse(t, resp); r.Code != 0 {
+		t.Fatalf("add member: %s", r.Message)
+	}
+
+	resp = doAPI(t, "POST", "/api/v1/provisioning/connectors", map[string]interface{}{
+		"name":           "app",
+		"url":            "ftp://app",
+		"scope_group_id": groupID,
+	}, token)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("create connector with an ftp url: status %d, want 400", resp.StatusCode)
+	}
+	resp.Body.Close()
+
+	resp = doAPI(t, "POST", "/api/v1/provisioning/connectors", map[string]interface{}{
+		"name":           "app",
+		"url":            app.URL,
+		"token":          "app-token",
+		"scope_group_id": groupID,
+		"sync_groups":    false,
+	}, token)
+	r := parseResponse(t, resp)
+	if r.Code != 0 {
+		t.Fatalf("create connector: %s", r.Message)
+	}
+	var conn map[string]any
+	if err := json.Unmarshal(r.Data, &conn); err != nil {
+		t.Fatalf("unmarshal connector: %v", err)
+	}
+	if _, ok := conn["token"]; ok {
+		t.Error("the connector's token was returned")
+	}
+	id := conn["id"].(string)
+
+	resp = doAPI(t, "POST", "/api/v1/provisioning/connectors/"+id+"/sync?full=true", nil, token)
+	r = parseResponse(t, resp)
+	if r.Code != 0 {
+		t.Fatalf("sync connector: %s", r.Message)
+	}
+	var result domain.ProvisioningSyncResult
+	if err := json.Unmarshal(r.Data, &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if result != (domain.ProvisioningSyncResult{Full: true, Created: 1}) {
+		t.Errorf("result = %+v, want the user created", result)
+	}
+	remote := app.Find(client.Users, "userName", "provisioneduser")
+	if remote == nil {
+		t.Fatal("the user was not provisioned")
+	}
+	if len(app.Resources(client.Users)) != 1 || len(app.Resources(client.Groups)) != 0 {
+		t.Errorf("provisioned %d users and %d groups, want only the user", len(app.Resources(client.Users)), len(app.Resources(client.Groups)))
+	}
+
+	resp = doAPI(t, "GET", "/api/v1/provisioning/connectors/"+id, nil, token)
+	r = parseResponse(t, resp)
+	var got domain.ProvisioningConnector
+	if err := json.Unmarshal(r.Data, &got); err != nil {
+		t.Fatalf("unmarshal connector: %v", err)
+	}
+	if got.Status.Users != 1 || got.Status.LastFullSyncAt == nil || got.Status.LastError != "" {
+		t.Errorf("status = %+v, want 1 user fully synchronized", got.Status)
+	}
+
+	resp = doAPI(t, "GET", "/api/v1/provisioning/connectors/"+id+"/objects?type=user", nil, token)
+	r = parseResponse(t, resp)
+	var objects domain.ListResult[domain.ProvisionedObject]
+	if err := json.Unmarshal(r.Data, &objects); err != nil {
+		t.Fatalf("unmarshal objects: %v", err)
+	}
+	if objects.Total != 1 || objects.Items[0].LocalID.String() != userID || objects.Items[0].RemoteID != client.ID(remote) {
+		t.Errorf("objects = %+v, want the user linked to its resource", objects.Items)
+	}
+
+	// A changed token is rejected by the application, and the failure
+	// reported in the status.
+	resp = doAPI(t, "PUT", "/api/v1/provisioning/connectors/"+id, map[string]interface{}{"token": "wrong"}, token)
+	if r := parseResponse(t, resp); r.Code != 0 {
+		t.Fatalf("update connector: %s", r.Message)
+	}
+	resp = doAPI(t, "POST", "/api/v1/provisioning/connectors/"+id+"/sync", nil, token)
+	r = parseResponse(t, resp)
+	if r.Code != 0 {
+		t.Fatalf("sync connector: %s", r.Message)
+	}
+	resp = doAPI(t, "GET", "/api/v1/provisioning/connectors/"+id+"/objects?failed=true", nil, token)
+	r = parseResponse(t, resp)
+	if err := json.Unmarshal(r.Data, &objects); err != nil {
+		t.Fatalf("unmarshal objects: %v", err)
+	}
+	if objects.Total != 1 || objects.Items[0].LastError == "" {
+		t.Errorf("failed objects = %+v, want the user", objects.Items)
+	}
+
+	// An application that does not respond is a bad gateway.
+	app.Close()
+	resp = doAPI(t, "POST", "/api/v1/provisioning/connectors/"+id+"/sync?full=true", nil, token)
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("sync with the application down: status %d, want 502", resp.StatusCode)
+	}
+	resp.Body.Close()
+
+	resp = doAPI(t, "DELETE", "/api/v1/provisioning/connectors/"+id, nil, token)
+	if r := parseResponse(t, resp); r.Code != 0 {
+		t.Fatalf("delete connector: %s", r.Message)
+	}
+	resp = doAPI(t, "GET", "/api/v1/provisioning/connectors/"+id, nil, token)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("get deleted connector: status %d, want 404", resp.StatusCode)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,372 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	scimhandler "github.com/qinzj/claude-demo/internal/handler/scim"
+)
+
+// scimResponse is a decoded SCIM response.
+type scimResponse struct {
+	status int
+	header http.Header
+	body   map[string]any
+}
+
+// doSCIM sends a SCIM request with optional headers, such as If-Match.
+func doSCIM(t *testing.T, method, path string, body any, token string, headers ...string) scimResponse {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("encode body: %v", err)
+		}
+	}
+	req, err := http.NewRequest(method, httpServer.URL+scimhandler.BasePath+path, &buf)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", scimhandler.ContentType)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	r := scimResponse{status: resp.StatusCode, header: resp.Header}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotModified {
+		if err := json.NewDecoder(resp.Body).Decode(&r.body); err != nil {
+			t.Fatalf("%s %s: decode response: %v", method, path, err)
+		}
+	}
+	return r
+}
+
+// scimAPIKey creates a service account with the given permissions and
+// returns an API key for it.
+func scimAPIKey(t *testing.T, adminToken, name string, permissions ...string) string {
+	t.Helper()
+	r := parseResponse(t, doAPI(t, "POST", "/api/v1/service-accounts", map[string]interface{}{
+		"name":        name,
+		"permissions": permissions,
+	}, adminToken))
+	if r.Code != 0 {
+		t.Fatalf("create service account: %s", r.Message)
+	}
+	var sa domain.ServiceAccount
+	if err := json.Unmarshal(r.Data, &sa); err != nil {
+		t.Fatalf("unmarshal service account: %v", err)
+	}
+	r = parseResponse(t, doAPI(t, "POST", "/api/v1/service-accounts/"+sa.ID.String()+"/keys", map[string]interface{}{"name": "scim"}, adminToken))
+	if r.Code != 0 {
+		t.Fatalf("create api key: %s", r.Message)
+	}
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(r.Data, &created); err != nil {
+		t.Fatalf("unmarshal api key: %v", err)
+	}
+	return created.Key
+}
+
+func TestSCIM(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "scimadmin",
+		DisplayName: "SCIM Admin",
+		Email:       "scimadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "scimadmin")
+	adminToken := loginAndGetToken(t, "scimadmin", "password123")
+	token := scimAPIKey(t, adminToken, "scim-idp",
+		domain.PermissionUserRead, domain.PermissionUserWrite, domain.PermissionGroupRead, domain.PermissionGroupAdmin)
+	if _, err := attrSvc.CreateDefinition(t.Context(), domain.CreateAttributeDefinitionInput{
+		Name:   "employeeNumber",
+		Target: domain.AttributeTargetUser,
+	}); err != nil {
+		t.Fatalf("create attribute: %v", err)
+	}
+
+	t.Run("errors use the SCIM format", func(t *testing.T) {
+		r := doSCIM(t, "GET", "/Users", nil, "")
+		if r.status != http.StatusUnauthorized || r.header.Get("Content-Type") != scimhandler.ContentType {
+			t.Errorf("status = %d, content type = %q", r.status, r.header.Get("Content-Type"))
+		}
+		if schemas, _ := r.body["schemas"].([]any); len(schemas) != 1 || schemas[0] != scimhandler.SchemaError || r.body["status"] != "401" {
+			t.Errorf("body = %v, want a SCIM error", r.body)
+		}
+
+		readOnly := scimAPIKey(t, adminToken, "scim-reader", domain.PermissionUserRead)
+		if r := doSCIM(t, "POST", "/Users", map[string]any{"userName": "x"}, readOnly); r.status != http.StatusForbidden {
+			t.Errorf("create without user:write: status = %d, want 403", r.status)
+		}
+	})
+
+	t.Run("discovery", func(t *testing.T) {
+		r := doSCIM(t, "GET", "/ServiceProviderConfig", nil, token)
+		if patch, _ := r.body["patch"].(map[string]any); patch["supported"] != true {
+			t.Errorf("service provider config = %v", r.body)
+		}
+		r = doSCIM(t, "GET", "/Schemas", nil, token)
+		if r.body["totalResults"] != 3.0 {
+			t.Errorf("schemas = %v, want 3", r.body["totalResults"])
+		}
+		r = doSCIM(t, "GET", "/ResourceTypes/User", nil, token)
+		if r.body["schema"] != scimhandler.SchemaUser {
+			t.Errorf("user resource type = %v", r.body)
+		}
+	})
+
+	manager := doSCIM(t, "POST", "/Users", map[string]any{
+		"schemas":  []string{scimhandler.SchemaUser},
+		"userName": "scim.manager",
+		"name":     map[string]any{"givenName": "Maria", "familyName": "Manager"},
+		"emails":   []any{map[string]any{"value": "maria@scim.test", "type": "work"}},
+	}, token)
+	if manager.status != http.StatusCreated {
+		t.Fatalf("create manager: %d %v", manager.status, manager.body)
+	}
+	managerID := manager.body["id"].(string)
+	if manager.body["displayName"] != "Maria Manager" {
+		t.Errorf("display name = %v, want it from the name", manager.body["displayName"])
+	}
+
+	created := doSCIM(t, "POST", "/Users", map[string]any{
+		"schemas":     []string{scimhandler.SchemaUser, scimhandler.SchemaEnterpriseUser},
+		"userName":    "scim.bjensen",
+		"displayName": "Babs Jensen",
+		"password":    "Password-123",
+		"emails": []any{
+			map[string]any{"value": "babs@home.test", "type": "home"},
+			map[string]any{"value": "bjensen@scim.test", "type": "work", "primary": true},
+		},
+		scimhandler.SchemaEnterpriseUser: map[string]any{
+			"employeeNumber": "701984",
+			"manager":        map[string]any{"value": managerID},
+		},
+	}, token)
+	if created.status != http.StatusCreated {
+		t.Fatalf("create user: %d %v", created.status, created.body)
+	}
+	userID := created.body["id"].(string)
+	userPath := "/Users/" + userID
+
+	t.Run("created user", func(t *testing.T) {
+		if created.header.Get("Location") == "" || created.header.Get("ETag") == "" {
+			t.Errorf("headers = %v, want Location and ETag", created.header)
+		}
+		if emails := created.body["emails"].([]any); emails[0].(map[string]any)["value"] != "bjensen@scim.test" {
+			t.Errorf("emails = %v, want the primary address", emails)
+		}
+		ext, _ := created.body[scimhandler.SchemaEnterpriseUser].(map[string]any)
+		if ext["employeeNumber"] != "701984" || ext["manager"].(map[string]any)["value"] != managerID {
+			t.Errorf("enterprise extension = %v", ext)
+		}
+		if _, ok := created.body["password"]; ok {
+			t.Error("password was returned")
+		}
+		loginAndGetToken(t, "scim.bjensen", "Password-123")
+
+		r := doSCIM(t, "POST", "/Users", map[string]any{"userName": "scim.bjensen", "emails": []any{map[string]any{"value": "other@scim.test"}}}, token)
+		if r.status != http.StatusConflict || r.body["scimType"] != "uniqueness" {
+			t.Errorf("duplicate: %d %v, want 409 uniqueness", r.status, r.body)
+		}
+
+		r = doSCIM(t, "POST", "/Users", map[string]any{
+			"userName":                       "scim.orphan",
+			"emails":                         []any{map[string]any{"value": "orphan@scim.test"}},
+			scimhandler.SchemaEnterpriseUser: map[string]any{"manager": "00000000-0000-0000-0000-000000000001"},
+		}, token)
+		if r.status != http.StatusBadRequest || r.body["scimType"] != "invalidValue" {
+			t.Errorf("unknown manager: %d %v, want 400 invalidValue", r.status, r.body)
+		}
+		if _, err := userSvc.GetUserByUsername(t.Context(), "scim.orphan"); err == nil {
+			t.Error("user with an unknown manager was created")
+		}
+	})
+
+	t.Run("filter and pagination", func(t *testing.T) {
+		q := url.Values{"filter": {`userName eq "SCIM.BJENSEN"`}}
+		r := doSCIM(t, "GET", "/Users?"+q.Encode(), nil, token)
+		if r.body["totalResults"] != 1.0 {
+			t.Errorf("userName filter: %v", r.body)
+		}
+
+		q = url.Values{"filter": {`emails.value eq "MARIA@scim.test" and displayName eq "Maria Manager"`}}
+		r = doSCIM(t, "GET", "/Users?"+q.Encode(), nil, token)
+		if resources, _ := r.body["Resources"].([]any); len(resources) != 1 || resources[0].(map[string]any)["id"] != managerID {
+			t.Errorf("email filter: %v", r.body)
+		}
+		q = url.Values{"filter": {`id eq "` + userID + `" and userName eq "scim.manager"`}}
+		if r := doSCIM(t, "GET", "/Users?"+q.Encode(), nil, token); r.body["totalResults"] != 0.0 {
+			t.Errorf("contradictory filter: %v", r.body)
+		}
+
+		q = url.Values{"startIndex": {"2"}, "count": {"1"}}
+		r = doSCIM(t, "GET", "/Users?"+q.Encode(), nil, token)
+		all := doSCIM(t, "GET", "/Users", nil, token)
+		resources, _ := r.body["Resources"].([]any)
+		allResources, _ := all.body["Resources"].([]any)
+		if r.body["totalResults"] != all.body["totalResults"] || len(resources) != 1 || len(allResources) < 2 ||
+			resources[0].(map[string]any)["id"] != allResources[1].(map[string]any)["id"] {
+			t.Errorf("unfiltered page: %v", r.body)
+		}
+
+		q = url.Values{
+			"filter":     {`emails co "@scim.test" and urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager pr or userName sw "scim.m"`},
+			"startIndex": {"2"},
+			"count":      {"1"},
+			"attributes": {"userName"},
+		}
+		r = doSCIM(t, "GET", "/Users?"+q.Encode(), nil, token)
+		resources, _ = r.body["Resources"].([]any)
+		if r.body["totalResults"] != 2.0 || r.body["itemsPerPage"] != 1.0 || len(resources) != 1 {
+			t.Fatalf("second page: %v", r.body)
+		}
+		page := resources[0].(map[string]any)
+		if page["userName"] != "scim.manager" || page["emails"] != nil || page["id"] == nil {
+			t.Errorf("projected resource = %v, want only id and userName", page)
+		}
+
+		q = url.Values{"filter": {`userName eq`}}
+		if r := doSCIM(t, "GET", "/Users?"+q.Encode(), nil, token); r.status != http.StatusBadRequest || r.body["scimType"] != "invalidFilter" {
+			t.Errorf("invalid filter: %d %v", r.status, r.body)
+		}
+	})
+
+	t.Run("etags", func(t *testing.T) {
+		r := doSCIM(t, "GET", userPath, nil, token)
+		etag := r.header.Get("ETag")
+		if r := doSCIM(t, "GET", userPath, nil, token, "If-None-Match", etag); r.status != http.StatusNotModified {
+			t.Errorf("If-None-Match: status = %d, want 304", r.status)
+		}
+		r = doSCIM(t, "PATCH", userPath, map[string]any{
+			"schemas":    []string{scimhandler.SchemaPatchOp},
+			"Operations": []any{map[string]any{"op": "replace", "path": "displayName", "value": "Barbara Jensen"}},
+		}, token, "If-Match", etag)
+		if r.status != http.StatusOK || r.header.Get("ETag") == etag {
+			t.Fatalf("patch: %d %v, want a new version", r.status, r.body)
+		}
+		r = doSCIM(t, "PATCH", userPath, map[string]any{
+			"schemas":    []string{scimhandler.SchemaPatchOp},
+			"Operations": []any{map[string]any{"op": "replace", "path": "displayName", "value": "Babs"}},
+		}, token, "If-Match", etag)
+		if r.status != http.StatusPreconditionFailed {
+			t.Errorf("stale If-Match: status = %d, want 412", r.status)
+		}
+	})
+
+	t.Run("patch user", func(t *testing.T) {
+		r := doSCIM(t, "PATCH", userPath, map[string]any{
+			"schemas": []string{scimhandler.SchemaPatchOp},
+			"Operations": []any{
+				map[string]any{"op": "Replace", "path": "active", "value": "False"},
+				map[string]any{"op": "replace", "path": `emails[type eq "work"].value`, "value": "babs@scim.test"},
+				map[string]any{"op": "remove", "path": scimhandler.SchemaEnterpriseUser + ":manager"},
+			},
+		}, token)
+		if r.status != http.StatusOK {
+			t.Fatalf("patch: %d %v", r.status, r.body)
+		}
+		u, err := userSvc.GetUserByUsername(t.Context(), "scim.bjensen")
+		if err != nil {
+			t.Fatalf("get user: %v", err)
+		}
+		if u.Status != domain.UserStatusDisabled || u.Email != "babs@scim.test" || u.ManagerID != nil || u.Attributes["employeeNumber"][0] != "701984" {
+			t.Errorf("user = %+v", u)
+		}
+
+		r = doSCIM(t, "PATCH", userPath, map[string]any{
+			"schemas":    []string{scimhandler.SchemaPatchOp},
+			"Operations": []any{map[string]any{"op": "replace", "path": "userName", "value": "someone.else"}},
+		}, token)
+		if r.status != http.StatusBadRequest || r.body["scimType"] != "mutability" {
+			t.Errorf("rename: %d %v, want 400 mutability", r.status, r.body)
+		}
+	})
+
+	t.Run("put user", func(t *testing.T) {
+		r := doSCIM(t, "PUT", userPath, map[string]any{
+			"schemas":     []string{scimhandler.SchemaUser},
+			"userName":    "scim.bjensen",
+			"displayName": "Babs Jensen",
+			"active":      true,
+			"emails":      []any{map[string]any{"value": "bjensen@scim.test"}},
+		}, token)
+		if r.status != http.StatusOK || r.body["active"] != true {
+			t.Fatalf("put: %d %v", r.status, r.body)
+		}
+		if _, ok := r.body[scimhandler.SchemaEnterpriseUser]; ok {
+			t.Errorf("enterprise extension = %v, want it cleared", r.body[scimhandler.SchemaEnterpriseUser])
+		}
+	})
+
+	var groupID string
+	t.Run("groups", func(t *testing.T) {
+		r := doSCIM(t, "POST", "/Groups", map[string]any{
+			"schemas":     []string{scimhandler.SchemaGroup},
+			"displayName": "scim-engineering",
+			"members":     []any{map[string]any{"value": userID}},
+		}, token)
+		if r.status != http.StatusCreated {
+			t.Fatalf("create group: %d %v", r.status, r.body)
+		}
+		groupID = r.body["id"].(string)
+
+		r = doSCIM(t, "PATCH", "/Groups/"+groupID, map[string]any{
+			"schemas": []string{scimhandler.SchemaPatchOp},
+			"Operations": []any{
+				map[string]any{"op": "add", "path": "members", "value": []any{map[string]any{"value": managerID}}},
+				map[string]any{"op": "remove", "path": `members[value eq "` + userID + `"]`},
+				map[string]any{"op": "replace", "value": map[string]any{"displayName": "scim-eng"}},
+			},
+		}, token)
+		members, _ := r.body["members"].([]any)
+		if r.status != http.StatusOK || r.body["displayName"] != "scim-eng" || len(members) != 1 || members[0].(map[string]any)["value"] != managerID {
+			t.Fatalf("patch group: %d %v", r.status, r.body)
+		}
+
+		r = doSCIM(t, "GET", "/Users/"+managerID, nil, token)
+		if groups, _ := r.body["groups"].([]any); len(groups) != 1 || groups[0].(map[string]any)["display"] != "scim-eng" {
+			t.Errorf("manager groups = %v", r.body["groups"])
+		}
+
+		q := url.Values{"filter": {`displayName eq "scim-eng"`}, "excludedAttributes": {"members"}}
+		r = doSCIM(t, "GET", "/Groups?"+q.Encode(), nil, token)
+		resources, _ := r.body["Resources"].([]any)
+		if len(resources) != 1 || resources[0].(map[string]any)["members"] != nil {
+			t.Errorf("filtered groups = %v", r.body)
+		}
+
+		r = doSCIM(t, "POST", "/Groups", map[string]any{
+			"displayName": "scim-nested",
+			"members":     []any{map[string]any{"value": groupID, "type": "Group"}},
+		}, token)
+		if r.status != http.StatusBadRequest {
+			t.Errorf("nested group: %d %v, want 400", r.status, r.body)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		for _, path := range []string{"/Groups/" + groupID, userPath} {
+			if r := doSCIM(t, "DELETE", path, nil, token); r.status != http.StatusNoContent {
+				t.Errorf("DELETE %s: status = %d, want 204", path, r.status)
+			}
+			if r := doSCIM(t, "GET", path, nil, token); r.status != http.StatusNotFound {
+				t.Errorf("GET %s after delete: status = %d, want 404", path, r.status)
+			}
+		}
+	})
+}
@@ -18,23 +18,48 @@ import (
 
 	"github.com/qinzj/claude-demo/internal/config"
 	"github.com/qinzj/claude-demo/internal/dao"
+	"github.com/qinzj/claude-demo/internal/domain"
 	"github.com/qinzj/claude-demo/internal/ent"
 	httphandler "github.com/qinzj/claude-demo/internal/handler/http"
 	ldaphandler "github.com/qinzj/claude-demo/internal/handler/ldap"
+	"github.com/qinzj/claude-demo/internal/mail"
+	"github.com/qinzj/claude-demo/internal/password"
 	"github.com/qinzj/claude-demo/internal/service"
 )
 
 var (
-	httpServer  *httptest.Server
-	ldapAddr    string
-	ldapServer  *gldap.Server
-	userSvc     *service.UserService
-	groupSvc    *service.GroupService
-	authSvc     *service.AuthService
-	testBaseDN  = "dc=example,dc=com"
-	testMode    = "openldap"
-	jwtSecret   = "test-secret-key"
-	expireHours = 24
+	httpServer       *httptest.Server
+	ldapAddr         string
+	ldapServer       *gldap.Server
+	userSvc          *service.UserService
+	groupSvc         *service.GroupService
+	authSvc          *service.AuthService
+	attrSvc          *service.AttributeService
+	roleSvc          *service.RoleService
+	oidcSvc          *service.OIDCService
+	saSvc            *service.ServiceAccountService
+	policySvc        *service.PasswordPolicyService
+	resetSvc         *service.PasswordResetService
+	invitationSvc    *service.InvitationService
+	auditSvc         *service.AuditService
+	webhookSvc       *service.WebhookService
+	provisioningSvc  *service.ProvisioningService
+	directorySyncSvc *service.DirectorySyncService
+	ldapCfg          *config.LDAPConfig
+	testBaseDN       = "dc=example,dc=com"
+	testMode         = "openldap"
+	signingAlg       = "ES256"
+	accessTTL        = 15 * time.Minute
+	sessionTTL       = 24 * time.Hour
+	// Client IP blocking is tested from a separate address, since all
+	// other tests share the loopback address.
+	lockoutPolicy = domain.LockoutPolicy{
+		Threshold:   5,
+		Duration:    15 * time.Minute,
+		MaxDuration: time.Hour,
+		IPThreshold: 10,
+		Window:      15 * time.Minute,
+	}
 )
 
 func TestMain(m *testing.M) {
@@ -57,23 +82,94 @@ func TestMain(m *testing.M) {
 	}
 
 	// Init services
-	userSvc = service.NewUserService(d)
+	userSvc = service.NewUserService(d, lockoutPolicy, password.Hasher{})
 	groupSvc = service.NewGroupService(d)
-	authSvc = service.NewAuthService(userSvc, jwtSecret, expireHours)
+	attrSvc = service.NewAttributeService(d)
+	roleSvc = service.NewRoleService(d)
+	keySvc := service.NewKeyService(d, signingAlg, 30*24*time.Hour, time.Hour)
+	if _, err := keySvc.RotateIfDue(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "init signing key: %v\n", err)
+		os.Exit(1)
+	}
+	mfaSvc := service.NewMFAService(d, userSvc, "Test")
+	authSvc = service.NewAuthService(d, userSvc, mfaSvc, keySvc, accessTTL, sessionTTL)
+	saSvc = service.NewServiceAccountService(d, keySvc, accessTTL)
+	policySvc = service.NewPasswordPolicyService(d)
+	auditSvc = service.NewAuditService(d)
+	// Webhooks are dispatched by the tests, and retried without delay.
+	webhookSvc = service.NewWebhookService(d, domain.WebhookPolicy{
+		MaxAttempts:  3,
+		Timeout:      5 * time.Second,
+		RetryBackoff: time.Millisecond,
+		MaxBackoff:   time.Millisecond,
+	})
+	// Connectors are synchronized by the tests.
+	provisioningSvc = service.NewProvisioningService(d, domain.ProvisioningPolicy{
+		Timeout:           5 * time.Second,
+		ReconcileInterval: time.Hour,
+	})
+	if _, err := policySvc.EnsureDefaultPolicy(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "init password policy: %v\n", err)
+		os.Exit(1)
+	}
+	// Users and groups are synchronized by the tests from a second
+	// instance of the server.
+	upstreamSource, err := startUpstream(logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "start upstream directory: %v\n", err)
+		os.Exit(1)
+	}
+	directorySyncSvc = service.NewDirectorySyncService(d, userSvc, groupSvc, []domain.DirectorySource{upstreamSource})
+	userSvc.UsePassThrough(service.NewPassThroughService(d, []domain.DirectorySource{upstreamSource}))
+
+	// Setup HTTP server. The listener is created first because the OIDC
+	// issuer must match the server's URL.
+	httpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "listen: %v\n", err)
+		os.Exit(1)
+	}
+	oidcSvc = service.NewOIDCService(d, authSvc, "http://"+httpListener.Addr().String())
 
-	// Setup HTTP server
-	ldapCfg := &config.LDAPConfig{
+	// Password reset links and invitations are mailed through a local SMTP
+	// stand-in.
+	smtpAddr, err := startSMTP()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "start smtp: %v\n", err)
+		os.Exit(1)
+	}
+	templates, err := mail.LoadTemplates("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load mail templates: %v\n", err)
+		os.Exit(1)
+	}
+	sender := &mail.SMTP{Host: smtpAddr.IP.String(), Port: smtpAddr.Port, From: "noreply@example.com", TLS: mail.TLSNone}
+	resetSvc = service.NewPasswordResetService(d, userSvc, sender, templates, domain.PasswordResetPolicy{
+		URL:       "http://" + httpListener.Addr().String() + "/reset-password",
+		TokenTTL:  30 * time.Minute,
+		UserLimit: 3,
+		IPLimit:   20,
+		Window:    time.Hour,
+	})
+	go resetSvc.Run(ctx)
+	invitationSvc = service.NewInvitationService(d, userSvc, mfaSvc, sender, templates, domain.InvitationPolicy{
+		URL:      "http://" + httpListener.Addr().String() + "/accept-invitation",
+		TokenTTL: 72 * time.Hour,
+	})
+
+	ldapCfg = &config.LDAPConfig{
 		Port:   0,
 		BaseDN: testBaseDN,
 		Mode:   testMode,
 	}
-	router := httphandler.SetupRouter(userSvc, groupSvc, authSvc, ldapCfg, logger)
-	httpServer = httptest.NewServer(router)
+	ldapHandler := ldaphandler.New(userSvc, groupSvc, attrSvc, saSvc, mfaSvc, ldapCfg, logger)
+	router := httphandler.SetupRouter(userSvc, groupSvc, authSvc, attrSvc, roleSvc, oidcSvc, saSvc, mfaSvc, policySvc, resetSvc, invitationSvc, auditSvc, webhookSvc, provisioningSvc, directorySyncSvc, service.NewLDIFService(d, userSvc, groupSvc), ldapHandler, service.NewCSVService(d, userSvc, groupSvc, invitationSvc), &config.ServerConfig{TrustedProxies: []string{"127.0.0.1"}}, ldapCfg, &config.SelfServiceConfig{}, logger)
+	httpServer = &httptest.Server{Listener: httpListener, Config: &http.Server{Handler: router}}
+	httpServer.Start()
 	defer httpServer.Close()
 
 	// Setup LDAP server
-	ldapHandler := ldaphandler.New(userSvc, groupSvc, ldapCfg, logger)
-	ldapServer, err = gldap.NewServer()
+	ldapServer, err = gldap.NewServer(gldap.WithOnClose(ldapHandler.ConnectionClosed))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "create LDAP server: %v\n", err)
 		os.Exit(1)
@@ -87,17 +183,15 @@ func TestMain(m *testing.M) {
 	ldapHandler.RegisterRoutes(mux)
 	ldapServer.Router(mux)
 
-	// Find free port for LDAP
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "listen: %v\n", err)
 		os.Exit(1)
 	}
 	ldapAddr = listener.Addr().String()
-	_ = listener.Close()
 
 	go func() {
-		if err := ldapServer.Run(ldapAddr); err != nil {
+		if err := ldapHandler.Serve(ldapServer, listener); err != nil {
 			fmt.Fprintf(os.Stderr, "LDAP server: %v\n", err)
 		}
 	}()
@@ -108,6 +202,7 @@ func TestMain(m *testing.M) {
 	code := m.Run()
 
 	_ = ldapServer.Stop()
+	_ = upstream.server.Stop()
 	os.Exit(code)
 }
 
@@ -157,6 +252,18 @@ func parseResponse(t *testing.T, resp *http.Response) apiResponse {
 	return r
 }
 
+// grantAdmin assigns the built-in admin role to an existing user.
+func grantAdmin(t *testing.T, username string) {
+	t.Helper()
+	u, err := userSvc.GetUserByUsername(t.Context(), username)
+	if err != nil {
+		t.Fatalf("get user %s: %v", username, err)
+	}
+	if err := roleSvc.GrantAdmin(t.Context(), u.ID); err != nil {
+		t.Fatalf("grant admin to %s: %v", username, err)
+	}
+}
+
 // loginAndGetToken logs in and returns the JWT token.
 func loginAndGetToken(t *testing.T, username, password string) string {
 	t.Helper()
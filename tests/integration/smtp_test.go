@@ -0,0 +1,100 @@
+package integration
+
+import (
+	"bufio"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sentMail is a message accepted by the SMTP stand-in, with its body
+// decoded.
+type sentMail struct {
+	to      string
+	subject string
+	body    string
+}
+
+// mailbox receives the messages accepted by the SMTP stand-in.
+var mailbox = make(chan sentMail, 20)
+
+// startSMTP runs a minimal SMTP server on the loopback interface that
+// accepts every message into mailbox, and returns its address.
+func startSMTP() (*net.TCPAddr, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn)
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr), nil
+}
+
+func serveSMTP(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	tc := textproto.NewConn(conn)
+	var to string
+	_ = tc.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := tc.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO", "MAIL":
+			_ = tc.PrintfLine("250 ok")
+		case "RCPT":
+			to = strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			_ = tc.PrintfLine("250 ok")
+		case "DATA":
+			_ = tc.PrintfLine("354 go ahead")
+			m, err := netmail.ReadMessage(bufio.NewReader(tc.DotReader()))
+			if err != nil {
+				return
+			}
+			body, _ := io.ReadAll(quotedprintable.NewReader(m.Body))
+			mailbox <- sentMail{to: to, subject: m.Header.Get("Subject"), body: string(body)}
+			_ = tc.PrintfLine("250 queued")
+		case "QUIT":
+			_ = tc.PrintfLine("221 bye")
+			return
+		default:
+			_ = tc.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+// receiveMail waits for the next message the SMTP stand-in accepts.
+func receiveMail(t *testing.T) sentMail {
+	t.Helper()
+	select {
+	case m := <-mailbox:
+		return m
+	case <-time.After(5 * time.Second):
+		t.Fatal("no mail received")
+		return sentMail{}
+	}
+}
+
+// assertNoMail checks that the SMTP stand-in accepted no message.
+func assertNoMail(t *testing.T) {
+	t.Helper()
+	select {
+	case m := <-mailbox:
+		t.Errorf("unexpected mail to %s", m.to)
+	default:
+	}
+}
@@ -0,0 +1,154 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/qinzj/claude-demo/internal/domain"
+	"github.com/qinzj/claude-demo/internal/service"
+)
+
+func TestWebhooks(t *testing.T) {
+	ensureUser(t, domain.CreateUserInput{
+		Username:    "webhookadmin",
+		DisplayName: "Webhook Admin",
+		Email:       "webhookadmin@test.com",
+		Password:    "password123",
+	})
+	grantAdmin(t, "webhookadmin")
+	token := loginAndGetToken(t, "webhookadmin", "password123")
+	// Deliver what other tests changed before subscribing.
+	if err := webhookSvc.Dispatch(context.Background()); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+
+	var (
+		mu       sync.Mutex
+		received []*http.Request
+		bodies   [][]byte
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, r)
+		bodies = append(bodies, body)
+		mu.Unlock()
+	}))
+	defer receiver.Close()
+
+	resp := doAPI(t, "POST", "/api/v1/webhooks", map[string]interface{}{
+		"name":        "provisioning",
+		"url":         receiver.URL,
+		"event_types": []string{domain.EventUserDisabled, "group.member.*"},
+	}, token)
+	r := parseResponse(t, resp)
+	if r.Code != 0 {
+		t.Fatalf("create webhook: %s", r.Message)
+	}
+	var sub domain.WebhookSubscription
+	if err := json.Unmarshal(r.Data, &sub); err != nil {
+		t.Fatalf("unmarshal webhook: %v", err)
+	}
+	if sub.Secret == "" {
+		t.Fatal("create webhook did not return the secret")
+	}
+
+	userID := createUserViaAPI(t, token, map[string]string{
+		"username":     "webhookuser",
+		"display_name": "Webhook User",
+		"email":        "webhookuser@test.com",
+		"password":     "password123",
+	})
+	groupID := createGroupViaAPI(t, token, map[string]interface{}{"name": "webhook-team"})
+	resp = doAPI(t, "POST", "/api/v1/groups/"+groupID+"/members", map[string]interface{}{"user_ids": []string{userID}}, token)
+	if r := parseResponse(t, resp); r.Code != 0 {
+		t.Fatalf("add member: %s", r.Message)
+	}
+	resp = doAPI(t, "PUT", "/api/v1/users/"+userID+"/status", map[string]string{"status": "disabled"}, token)
+	if r := parseResponse(t, resp); r.Code != 0 {
+		t.Fatalf("disable user: %s", r.Message)
+	}
+	if err := webhookSvc.Dispatch(context.Background()); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+
+	t.Run("matching events are delivered signed", func(t *testing.T) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(received) != 2 {
+			t.Fatalf("received %d webhooks, want the member added and the user disabled", len(received))
+		}
+		// Deliveries may arrive in any order.
+		events := map[string]domain.WebhookEvent{}
+		for i, req := range received {
+			ts, _ := strconv.ParseInt(req.Header.Get(service.WebhookTimestampHeader), 10, 64)
+			if req.Header.Get(service.WebhookSignatureHeader) != service.WebhookSignature(sub.Secret, ts, bodies[i]) {
+				t.Errorf("webhook %d: signature does not verify", i)
+			}
+			var e domain.WebhookEvent
+			if err := json.Unmarshal(bodies[i], &e); err != nil {
+				t.Fatalf("unmarshal event: %v", err)
+			}
+			events[e.Type] = e
+		}
+		added, ok := events[domain.EventGroupMemberAdded]
+		if _, disabled := events[domain.EventUserDisabled]; !ok || !disabled {
+			t.Fatalf("events = %v, want the member added and the user disabled", events)
+		}
+		var data domain.MembershipEventData
+		if err := json.Unmarshal(added.Data, &data); err != nil {
+			t.Fatalf("unmarshal data: %v", err)
+		}
+		if data.Username != "webhookuser" || data.GroupName != "webhook-team" || data.ActorName != "webhookadmin" {
+			t.Errorf("member added data = %+v", data)
+		}
+	})
+
+	t.Run("deliveries are listed", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "GET", "/api/v1/webhooks/"+sub.ID.String()+"/deliveries?status=delivered", nil, token))
+		var result domain.ListResult[domain.WebhookDelivery]
+		if err := json.Unmarshal(r.Data, &result); err != nil {
+			t.Fatalf("unmarshal deliveries: %v", err)
+		}
+		if result.Total != 2 || result.Items[0].EventType != domain.EventUserDisabled {
+			t.Errorf("deliveries = %+v, want both, newest first", result.Items)
+		}
+	})
+
+	t.Run("secret is not listed", func(t *testing.T) {
+		r := parseResponse(t, doAPI(t, "GET", "/api/v1/webhooks/"+sub.ID.String(), nil, token))
+		var got domain.WebhookSubscription
+		if err := json.Unmarshal(r.Data, &got); err != nil {
+			t.Fatalf("unmarshal webhook: %v", err)
+		}
+		if got.Secret != "" || got.Name != "provisioning" {
+			t.Errorf("webhook = %+v, want it without the secret", got)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		resp := doAPI(t, "POST", "/api/v1/webhooks", map[string]interface{}{
+			"name": "bad", "url": receiver.URL, "event_types": []string{"user.renamed"},
+		}, token)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("unknown event type: status = %d, want 400", resp.StatusCode)
+		}
+		resp.Body.Close()
+		resp = doAPI(t, "GET", "/api/v1/webhooks/"+userID, nil, token)
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("unknown webhook: status = %d, want 404", resp.StatusCode)
+		}
+		resp.Body.Close()
+	})
+
+	resp = doAPI(t, "DELETE", "/api/v1/webhooks/"+sub.ID.String(), nil, token)
+	if r := parseResponse(t, resp); r.Code != 0 {
+		t.Fatalf("delete webhook: %s", r.Message)
+	}
+}
@@ -1,11 +1,30 @@
 import client from './client';
 
+export interface LoginData {
+  token?: string;
+  refresh_token?: string;
+  mfa_required?: boolean;
+  mfa_token?: string;
+  enrollment_required?: boolean;
+  recovery_codes?: string[];
+  password_change_required?: boolean;
+}
+
 interface LoginResponse {
   code: number;
   message: string;
-  data: {
-    token: string;
-  };
+  data: LoginData;
+}
+
+export interface TOTPEnrollment {
+  secret: string;
+  provisioning_uri: string;
+}
+
+interface EnrollmentResponse {
+  code: number;
+  message: string;
+  data: TOTPEnrollment;
 }
 
 export async function login(username: string, password: string): Promise<LoginResponse> {
@@ -13,6 +32,114 @@ export async function login(username: string, password: string): Promise<LoginRe
   return resp.data;
 }
 
+export async function verifyMFA(mfaToken: string, code: string): Promise<LoginResponse> {
+  const resp = await client.post<LoginResponse>('/auth/mfa/verify', { mfa_token: mfaToken, code });
+  return resp.data;
+}
+
+export async function beginMFAEnrollment(mfaToken: string): Promise<EnrollmentResponse> {
+  const resp = await client.post<EnrollmentResponse>('/auth/mfa/enroll', { mfa_token: mfaToken });
+  return resp.data;
+}
+
+export async function confirmMFAEnrollment(mfaToken: string, code: string): Promise<LoginResponse> {
+  const resp = await client.post<LoginResponse>('/auth/mfa/enroll/confirm', { mfa_token: mfaToken, code });
+  return resp.data;
+}
+
+export interface PasswordViolation {
+  code: string;
+  message: string;
+}
+
+interface ChangePasswordResponse {
+  code: number;
+  message: string;
+  data?: { violations?: PasswordViolation[] };
+}
+
+export async function changeOwnPassword(oldPassword: string, newPassword: string): Promise<ChangePasswordResponse> {
+  const resp = await client.put<ChangePasswordResponse>('/me/password', {
+    old_password: oldPassword,
+    new_password: newPassword,
+  }, { validateStatus: (status) => status < 500 });
+  return resp.data;
+}
+
+export async function forgotPassword(login: string): Promise<void> {
+  await client.post('/auth/password/forgot', { login });
+}
+
+export interface ResetLink {
+  username: string;
+  display_name: string;
+  expires_at: string;
+}
+
+interface ResetLinkResponse {
+  code: number;
+  message: string;
+  data: ResetLink;
+}
+
+export async function checkResetLink(token: string): Promise<ResetLinkResponse> {
+  const resp = await client.get<ResetLinkResponse>('/auth/password/reset', {
+    params: { token },
+    validateStatus: (status) => status < 500,
+  });
+  return resp.data;
+}
+
+export async function resetPasswordWithLink(token: string, newPassword: string): Promise<ChangePasswordResponse> {
+  const resp = await client.post<ChangePasswordResponse>('/auth/password/reset', {
+    token,
+    new_password: newPassword,
+  }, { validateStatus: (status) => status < 500 });
+  return resp.data;
+}
+
+export interface Invitation {
+  username: string;
+  display_name: string;
+  email: string;
+  expires_at: string;
+  mfa_required: boolean;
+}
+
+interface InvitationResponse {
+  code: number;
+  message: string;
+  data: Invitation;
+}
+
+interface AcceptInvitationResponse {
+  code: number;
+  message: string;
+  data?: { recovery_codes?: string[]; violations?: PasswordViolation[] };
+}
+
+export async function checkInvitation(token: string): Promise<InvitationResponse> {
+  const resp = await client.get<InvitationResponse>('/auth/invitation', {
+    params: { token },
+    validateStatus: (status) => status < 500,
+  });
+  return resp.data;
+}
+
+export async function beginInvitationMFA(token: string): Promise<EnrollmentResponse> {
+  const resp = await client.post<EnrollmentResponse>('/auth/invitation/mfa', { token });
+  return resp.data;
+}
+
+export async function acceptInvitation(token: string, password: string, totpCode?: string): Promise<AcceptInvitationResponse> {
+  const resp = await client.post<AcceptInvitationResponse>('/auth/invitation/accept', {
+    token,
+    password,
+    totp_code: totpCode,
+  }, { validateStatus: (status) => status < 500 });
+  return resp.data;
+}
+
 export async function logout(): Promise<void> {
   await client.post('/auth/logout');
 }
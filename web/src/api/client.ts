@@ -1,5 +1,5 @@
-import axios from 'axios';
-import { getToken, clearToken } from '../store/auth';
+import axios, { type AxiosRequestConfig } from 'axios';
+import { getToken, clearToken, getRefreshToken, setRefreshToken, setToken } from '../store/auth';
 
 const client = axios.create({
   baseURL: '/api/v1',
@@ -14,10 +14,41 @@ client.interceptors.request.use((config) => {
   return config;
 });
 
+// Refresh tokens are single-use, so concurrent 401s share one refresh call.
+let refreshing: Promise<boolean> | null = null;
+
+function refreshTokens(): Promise<boolean> {
+  const refreshToken = getRefreshToken();
+  if (!refreshToken) {
+    return Promise.resolve(false);
+  }
+  if (!refreshing) {
+    refreshing = axios
+      .post('/api/v1/auth/refresh', { refresh_token: refreshToken })
+      .then((resp) => {
+        setToken(resp.data.data.token);
+        setRefreshToken(resp.data.data.refresh_token);
+        return true;
+      })
+      .catch(() => false)
+      .finally(() => {
+        refreshing = null;
+      });
+  }
+  return refreshing;
+}
+
 client.interceptors.response.use(
   (response) => response,
-  (error) => {
-    if (error.response?.status === 401) {
+  async (error) => {
+    const config = error.config as (AxiosRequestConfig & { _retried?: boolean }) | undefined;
+    // Failed login steps are reported by the login page, which must keep its state.
+    const loginStep = config?.url?.startsWith('/auth/login') || config?.url?.startsWith('/auth/mfa/');
+    if (error.response?.status === 401 && !loginStep) {
+      if (config && !config._retried && !config.url?.startsWith('/auth/') && (await refreshTokens())) {
+        config._retried = true;
+        return client(config);
+      }
       clearToken();
       window.location.href = '/login';
     }
@@ -4,6 +4,7 @@ export interface LDAPConfig {
   base_dn: string;
   mode: string;
   port: number;
+  otp: boolean;
 }
 
 export interface LDAPStatus {
@@ -13,6 +14,6 @@ export interface LDAPStatus {
 }
 
 export const getLDAPConfig = () => client.get('/ldap/config');
-export const updateLDAPConfig = (data: { base_dn: string; mode: string; port: number }) =>
+export const updateLDAPConfig = (data: { base_dn: string; mode: string; port: number; otp: boolean }) =>
   client.put('/ldap/config', data);
 export const getLDAPStatus = () => client.get('/ldap/status');
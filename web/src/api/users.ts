@@ -6,7 +6,9 @@ export interface User {
   display_name: string;
   email: string;
   phone: string;
-  status: 'enabled' | 'disabled';
+  status: 'enabled' | 'disabled' | 'pending';
+  failed_login_count: number;
+  locked_until?: string;
   created_at: string;
   updated_at: string;
 }
@@ -19,6 +21,8 @@ export interface CreateUserReq {
   phone?: string;
 }
 
+export type InviteUserReq = Omit<CreateUserReq, 'password'>;
+
 export interface UpdateUserReq {
   display_name?: string;
   email?: string;
@@ -30,10 +34,59 @@ export const listUsers = (params: { page: number; page_size: number; search?: st
 
 export const getUser = (id: string) => client.get(`/users/${id}`);
 export const createUser = (data: CreateUserReq) => client.post('/users', data);
+export const inviteUser = (data: InviteUserReq) => client.post('/users/invitations', data);
+export const resendInvitation = (id: string) => client.post(`/users/${id}/invitation`);
 export const updateUser = (id: string, data: UpdateUserReq) => client.put(`/users/${id}`, data);
 export const deleteUser = (id: string) => client.delete(`/users/${id}`);
 export const changePassword = (id: string, data: { old_password: string; new_password: string }) =>
   client.put(`/users/${id}/password`, data);
+export const sendPasswordResetLink = (id: string) => client.post(`/users/${id}/password/reset-link`);
 export const setUserStatus = (id: string, status: 'enabled' | 'disabled') =>
   client.put(`/users/${id}/status`, { status });
+export const unlockUser = (id: string) => client.delete(`/users/${id}/lockout`);
 export const getUserGroups = (id: string) => client.get(`/users/${id}/groups`);
+
+export interface CSVImportOptions {
+  mode?: 'all_or_nothing' | 'best_effort';
+  credentials?: 'generate' | 'invite' | 'none';
+  replace_groups?: boolean;
+  dry_run?: boolean;
+}
+
+export interface CSVImportRow {
+  row: number;
+  username: string;
+  action: 'create' | 'update' | 'unchanged' | 'fail' | 'skip';
+  user_id?: string;
+  fields?: string[];
+  invited?: boolean;
+  password?: string;
+  errors?: string[];
+  values: string[];
+}
+
+export interface CSVImportReport {
+  dry_run: boolean;
+  mode: 'all_or_nothing' | 'best_effort';
+  applied: boolean;
+  header: string[];
+  columns: Record<string, string>;
+  ignored?: string[];
+  created: number;
+  updated: number;
+  unchanged: number;
+  failed: number;
+  skipped: number;
+  rows: CSVImportRow[];
+}
+
+export const exportUsersCSV = (params: { search?: string }) =>
+  client.get<Blob>('/users/export.csv', { params, responseType: 'blob' });
+
+// Imports can take a while, so they are not bound by the client timeout.
+export const importUsersCSV = (file: File, params: CSVImportOptions) =>
+  client.post('/users/import.csv', file, {
+    params,
+    headers: { 'Content-Type': 'text/csv' },
+    timeout: 0,
+  });
@@ -0,0 +1,205 @@
+import { useState } from 'react';
+import {
+  Alert,
+  Button,
+  Checkbox,
+  Form,
+  Modal,
+  Radio,
+  Space,
+  Table,
+  Tag,
+  Upload,
+  message,
+} from 'antd';
+import { UploadOutlined } from '@ant-design/icons';
+import { isAxiosError } from 'axios';
+import type { ColumnsType } from 'antd/es/table';
+import { importUsersCSV } from '../api/users';
+import type { CSVImportOptions, CSVImportReport, CSVImportRow } from '../api/users';
+
+const actionTags: Record<CSVImportRow['action'], { color: string; label: string }> = {
+  create: { color: 'green', label: '新建' },
+  update: { color: 'blue', label: '更新' },
+  unchanged: { color: 'default', label: '无变化' },
+  fail: { color: 'red', label: '失败' },
+  skip: { color: 'orange', label: '跳过' },
+};
+
+const csvCell = (v: string) => (/[",\r\n]/.test(v) ? `"${v.replace(/"/g, '""')}"` : v);
+
+// The error report is the imported file, restricted to the rows that failed
+// or were skipped, with their errors, so that it can be corrected and
+// imported again.
+function downloadErrorReport(report: CSVImportReport) {
+  const lines = [[...report.header, 'result', 'errors']];
+  for (const row of report.rows) {
+    if (row.action !== 'fail' && row.action !== 'skip') continue;
+    const values = report.header.map((_, i) => row.values[i] ?? '');
+    lines.push([...values, row.action, (row.errors ?? []).join('; ')]);
+  }
+  const content = lines.map((l) => l.map(csvCell).join(',')).join('\r\n') + '\r\n';
+  const url = URL.createObjectURL(new Blob(['\uFEFF' + content], { type: 'text/csv' }));
+  const a = document.createElement('a');
+  a.href = url;
+  a.download = 'import-errors.csv';
+  a.click();
+  URL.revokeObjectURL(url);
+}
+
+interface Props {
+  open: boolean;
+  onClose: () => void;
+  onImported: () => void;
+}
+
+export default function UserImportModal({ open, onClose, onImported }: Props) {
+  const [form] = Form.useForm<CSVImportOptions>();
+  const [file, setFile] = useState<File | null>(null);
+  const [importing, setImporting] = useState(false);
+  const [report, setReport] = useState<CSVImportReport | null>(null);
+
+  const close = () => {
+    setFile(null);
+    setReport(null);
+    form.resetFields();
+    onClose();
+  };
+
+  const handleImport = async (dryRun: boolean) => {
+    if (!file) {
+      message.warning('请选择 CSV 文件');
+      return;
+    }
+    setImporting(true);
+    try {
+      const resp = await importUsersCSV(file, { ...form.getFieldsValue(), dry_run: dryRun });
+      const result = resp.data?.data as CSVImportReport;
+      setReport(result);
+      if (result.applied) {
+        message.success('导入完成');
+        onImported();
+      }
+    } catch (err) {
+      const status = isAxiosError(err) ? err.response?.status : undefined;
+      const msg = isAxiosError(err) ? err.response?.data?.message : undefined;
+      message.error(status === 503 ? '未配置邮件服务器，无法邀请用户' : msg || '导入失败');
+    } finally {
+      setImporting(false);
+    }
+  };
+
+  const columns: ColumnsType<CSVImportRow> = [
+    { title: '行', dataIndex: 'row', key: 'row', width: 60 },
+    { title: '用户名', dataIndex: 'username', key: 'username' },
+    {
+      title: '结果',
+      dataIndex: 'action',
+      key: 'action',
+      render: (action: CSVImportRow['action'], row) => (
+        <Space size={4}>
+          <Tag color={actionTags[action].color}>{actionTags[action].label}</Tag>
+          {row.invited && <Tag>已邀请</Tag>}
+        </Space>
+      ),
+    },
+    {
+      title: '说明',
+      key: 'detail',
+      render: (_, row) =>
+        row.errors?.length ? row.errors.join('；') : row.fields?.join(', '),
+    },
+    {
+      title: '初始密码',
+      dataIndex: 'password',
+      key: 'password',
+      render: (password?: string) => password && <code>{password}</code>,
+    },
+  ];
+
+  return (
+    <Modal title="导入 CSV" open={open} onCancel={close} footer={null} width={800}>
+      <Form
+        form={form}
+        layout="vertical"
+        initialValues={{ mode: 'all_or_nothing', credentials: 'generate', replace_groups: false }}
+      >
+        <Form.Item label="CSV 文件" extra="第一行为表头，按用户名匹配已有用户；空单元格不修改对应字段">
+          <Upload
+            accept=".csv,text/csv"
+            maxCount={1}
+            beforeUpload={(f) => {
+              setFile(f);
+              setReport(null);
+              return false;
+            }}
+            onRemove={() => {
+              setFile(null);
+              setReport(null);
+            }}
+          >
+            <Button icon={<UploadOutlined />}>选择文件</Button>
+          </Upload>
+        </Form.Item>
+        <Form.Item name="mode" label="导入方式">
+          <Radio.Group>
+            <Radio value="all_or_nothing">全部成功才导入</Radio>
+            <Radio value="best_effort">导入有效的行</Radio>
+          </Radio.Group>
+        </Form.Item>
+        <Form.Item name="credentials" label="新用户的初始密码（文件中未提供时）">
+          <Radio.Group>
+            <Radio value="generate">自动生成</Radio>
+            <Radio value="invite">发送邀请邮件</Radio>
+            <Radio value="none">不设置</Radio>
+          </Radio.Group>
+        </Form.Item>
+        <Form.Item name="replace_groups" valuePropName="checked">
+          <Checkbox>将用户移出文件中未列出的组</Checkbox>
+        </Form.Item>
+        <Space>
+          <Button onClick={() => handleImport(true)} loading={importing} disabled={!file}>
+            预览
+          </Button>
+          <Button type="primary" onClick={() => handleImport(false)} loading={importing} disabled={!file}>
+            导入
+          </Button>
+        </Space>
+      </Form>
+
+      {report && (
+        <div style={{ marginTop: 24 }}>
+          <Alert
+            type={report.failed + report.skipped > 0 ? 'warning' : 'success'}
+            showIcon
+            style={{ marginBottom: 16 }}
+            message={
+              (report.dry_run ? '预览：' : report.applied ? '已导入：' : '未导入任何用户：') +
+              `新建 ${report.created}，更新 ${report.updated}，无变化 ${report.unchanged}，失败 ${report.failed}，跳过 ${report.skipped}`
+            }
+            description={
+              <>
+                {report.ignored?.length ? <div>未导入的列：{report.ignored.join(', ')}</div> : null}
+                {report.rows.some((r) => r.password) && <div>自动生成的初始密码仅显示一次，请妥善保存</div>}
+              </>
+            }
+            action={
+              report.failed + report.skipped > 0 && (
+                <Button size="small" onClick={() => downloadErrorReport(report)}>
+                  下载错误报告
+                </Button>
+              )
+            }
+          />
+          <Table
+            rowKey="row"
+            size="small"
+            columns={columns}
+            dataSource={report.rows}
+            pagination={{ pageSize: 10 }}
+          />
+        </div>
+      )}
+    </Modal>
+  );
+}
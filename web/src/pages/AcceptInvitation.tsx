@@ -0,0 +1,185 @@
+import { useState, useEffect } from 'react';
+import { useNavigate, useSearchParams } from 'react-router-dom';
+import { Card, Form, Input, Button, Checkbox, message, Typography, Result, Spin, QRCode, Modal } from 'antd';
+import { LockOutlined, SafetyOutlined } from '@ant-design/icons';
+import {
+  checkInvitation,
+  beginInvitationMFA,
+  acceptInvitation,
+  type Invitation,
+  type TOTPEnrollment,
+} from '../api/auth';
+
+const { Title, Paragraph, Text } = Typography;
+
+interface AcceptForm {
+  password: string;
+  confirm_password: string;
+  totp_code?: string;
+}
+
+// AcceptInvitation is the page emailed invitations point to. The invited
+// user chooses a password and, optionally or when a group requires it,
+// enrolls TOTP before the account is enabled.
+export default function AcceptInvitation() {
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get('token') ?? '';
+  const [invitation, setInvitation] = useState<Invitation | null>(null);
+  const [checking, setChecking] = useState(true);
+  const [loading, setLoading] = useState(false);
+  const [enableMFA, setEnableMFA] = useState(false);
+  const [enrollment, setEnrollment] = useState<TOTPEnrollment | null>(null);
+  const navigate = useNavigate();
+
+  useEffect(() => {
+    if (!token) {
+      setChecking(false);
+      return;
+    }
+    checkInvitation(token)
+      .then((resp) => {
+        if (resp.code !== 0) {
+          setInvitation(null);
+          return;
+        }
+        setInvitation(resp.data);
+        setEnableMFA(resp.data.mfa_required);
+      })
+      .catch(() => setInvitation(null))
+      .finally(() => setChecking(false));
+  }, [token]);
+
+  useEffect(() => {
+    if (!enableMFA || enrollment) return;
+    // Each call replaces the secret, so only the latest response counts.
+    let current = true;
+    beginInvitationMFA(token)
+      .then((resp) => {
+        if (!current) return;
+        if (resp.code === 0) {
+          setEnrollment(resp.data);
+        } else {
+          message.error(resp.message || '无法开始绑定两步验证');
+        }
+      })
+      .catch(() => current && message.error('无法开始绑定两步验证'));
+    return () => {
+      current = false;
+    };
+  }, [enableMFA, enrollment, token]);
+
+  const onFinish = async (values: AcceptForm) => {
+    setLoading(true);
+    try {
+      const resp = await acceptInvitation(token, values.password, enableMFA ? values.totp_code : undefined);
+      if (resp.code !== 0) {
+        const violations = resp.data?.violations ?? [];
+        message.error(violations.length ? violations.map((v) => v.message).join('；') : resp.message || '激活账号失败');
+        return;
+      }
+      const codes = resp.data?.recovery_codes ?? [];
+      if (codes.length) {
+        Modal.info({
+          title: '请保存恢复码',
+          content: (
+            <>
+              <Paragraph>手机丢失时可使用恢复码登录，每个恢复码只能使用一次，且只显示这一次。</Paragraph>
+              <Paragraph copyable={{ text: codes.join('\n') }}>
+                {codes.map((c) => <div key={c}><Text code>{c}</Text></div>)}
+              </Paragraph>
+            </>
+          ),
+          onOk: () => navigate('/login'),
+        });
+        return;
+      }
+      message.success('账号已激活，请登录');
+      navigate('/login');
+    } catch {
+      message.error('激活账号失败，请重试');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div style={{
+      display: 'flex',
+      justifyContent: 'center',
+      alignItems: 'center',
+      minHeight: '100vh',
+      background: '#f0f2f5',
+    }}>
+      <Card style={{ width: 400 }}>
+        <Title level={3} style={{ textAlign: 'center', marginBottom: 32 }}>
+          激活账号
+        </Title>
+        {checking ? (
+          <div style={{ textAlign: 'center' }}><Spin /></div>
+        ) : !invitation ? (
+          <Result
+            status="warning"
+            title="邀请无效或已过期"
+            subTitle="请联系管理员重新发送邀请。"
+            extra={<Button type="primary" onClick={() => navigate('/login')}>返回登录</Button>}
+          />
+        ) : (
+          <Form onFinish={onFinish} size="large">
+            <Paragraph>
+              {invitation.display_name || invitation.username}，欢迎！请为账号 {invitation.username} 设置密码。
+              邀请有效期至 {new Date(invitation.expires_at).toLocaleString()}。
+            </Paragraph>
+            <Form.Item name="password" rules={[{ required: true, message: '请输入密码' }]}>
+              <Input.Password prefix={<LockOutlined />} placeholder="密码" autoComplete="new-password" autoFocus />
+            </Form.Item>
+            <Form.Item
+              name="confirm_password"
+              dependencies={['password']}
+              rules={[
+                { required: true, message: '请确认密码' },
+                ({ getFieldValue }) => ({
+                  validator(_, value) {
+                    if (!value || getFieldValue('password') === value) {
+                      return Promise.resolve();
+                    }
+                    return Promise.reject(new Error('两次输入的密码不一致'));
+                  },
+                }),
+              ]}
+            >
+              <Input.Password prefix={<LockOutlined />} placeholder="确认密码" autoComplete="new-password" />
+            </Form.Item>
+            <Form.Item>
+              <Checkbox
+                checked={enableMFA}
+                disabled={invitation.mfa_required}
+                onChange={(e) => setEnableMFA(e.target.checked)}
+              >
+                {invitation.mfa_required ? '所在用户组要求启用两步验证' : '同时启用两步验证'}
+              </Checkbox>
+            </Form.Item>
+            {enableMFA && enrollment && (
+              <>
+                <Paragraph>请使用身份验证器 App 扫描二维码，然后输入显示的 6 位验证码。</Paragraph>
+                <div style={{ display: 'flex', justifyContent: 'center', marginBottom: 16 }}>
+                  <QRCode value={enrollment.provisioning_uri} />
+                </div>
+                <Paragraph style={{ textAlign: 'center' }}>
+                  无法扫码时手动输入密钥：<Text code copyable>{enrollment.secret}</Text>
+                </Paragraph>
+                <Form.Item name="totp_code" rules={[{ required: true, message: '请输入验证码' }]}>
+                  <Input prefix={<SafetyOutlined />} placeholder="验证码" autoComplete="one-time-code" />
+                </Form.Item>
+              </>
+            )}
+            <Form.Item>
+              <Button type="primary" htmlType="submit" loading={loading} block>
+                激活账号
+              </Button>
+            </Form.Item>
+          </Form>
+        )}
+      </Card>
+    </div>
+  );
+}
@@ -11,6 +11,7 @@ import {
   message,
   Typography,
   Space,
+  Switch,
 } from 'antd';
 import { getLDAPConfig, updateLDAPConfig, getLDAPStatus } from '../api/ldap';
 import type { LDAPConfig as LDAPConfigType, LDAPStatus } from '../api/ldap';
@@ -126,6 +127,14 @@ export default function LDAPConfig() {
             >
               <InputNumber min={1} max={65535} style={{ width: '100%' }} />
             </Form.Item>
+            <Form.Item
+              name="otp"
+              label="密码 + 动态验证码"
+              valuePropName="checked"
+              extra="开启后，已启用两步验证的用户绑定时需在密码后追加 6 位动态验证码（适用于 VPN 等客户端）"
+            >
+              <Switch />
+            </Form.Item>
             <Form.Item>
               <Button type="primary" htmlType="submit" loading={saving}>
                 保存
@@ -1,38 +1,176 @@
 import { useState } from 'react';
+import { isAxiosError } from 'axios';
 import { useNavigate } from 'react-router-dom';
-import { Card, Form, Input, Button, message, Typography } from 'antd';
-import { UserOutlined, LockOutlined } from '@ant-design/icons';
-import { login } from '../api/auth';
-import { setToken } from '../store/auth';
+import { Card, Form, Input, Button, message, Typography, QRCode, Modal } from 'antd';
+import { UserOutlined, LockOutlined, SafetyOutlined, MailOutlined } from '@ant-design/icons';
+import {
+  login,
+  verifyMFA,
+  beginMFAEnrollment,
+  confirmMFAEnrollment,
+  changeOwnPassword,
+  forgotPassword,
+  type LoginData,
+  type TOTPEnrollment,
+} from '../api/auth';
+import { setRefreshToken, setToken } from '../store/auth';
 
-const { Title } = Typography;
+const { Title, Paragraph, Text } = Typography;
+
+// tooManyAttempts reports whether a login step was refused because the
+// account is locked or this address is blocked after failed logins.
+const tooManyAttempts = (err: unknown) => isAxiosError(err) && err.response?.status === 429;
 
 interface LoginForm {
   username: string;
   password: string;
 }
 
+interface ForgotForm {
+  login: string;
+}
+
+interface CodeForm {
+  code: string;
+}
+
+interface ChangePasswordForm {
+  new_password: string;
+  confirm_password: string;
+}
+
+// The login page has three steps: password, then either a TOTP code or,
+// when a group requires MFA the user has not set up, TOTP enrollment.
+// Users whose password expired or was reset finish with a password change.
+// Users who forgot their password can ask for a reset link instead.
+type Step = 'password' | 'verify' | 'enroll' | 'change' | 'forgot';
+
 export default function Login() {
   const [loading, setLoading] = useState(false);
+  const [step, setStep] = useState<Step>('password');
+  const [mfaToken, setMFAToken] = useState('');
+  const [enrollment, setEnrollment] = useState<TOTPEnrollment | null>(null);
+  const [password, setPassword] = useState('');
   const navigate = useNavigate();
 
+  const finish = (data: LoginData) => {
+    setToken(data.token!);
+    setRefreshToken(data.refresh_token!);
+    if (data.password_change_required) {
+      setStep('change');
+      return;
+    }
+    if (data.recovery_codes?.length) {
+      Modal.info({
+        title: '请保存恢复码',
+        content: (
+          <>
+            <Paragraph>手机丢失时可使用恢复码登录，每个恢复码只能使用一次，且只显示这一次。</Paragraph>
+            <Paragraph copyable={{ text: data.recovery_codes.join('\n') }}>
+              {data.recovery_codes.map((c) => <div key={c}><Text code>{c}</Text></div>)}
+            </Paragraph>
+          </>
+        ),
+        onOk: () => navigate('/users'),
+      });
+      return;
+    }
+    navigate('/users');
+  };
+
   const onFinish = async (values: LoginForm) => {
     setLoading(true);
     try {
       const resp = await login(values.username, values.password);
+      if (resp.code !== 0) {
+        message.error(resp.message || '登录失败');
+        return;
+      }
+      setPassword(values.password);
+      if (!resp.data.mfa_required) {
+        finish(resp.data);
+        return;
+      }
+      setMFAToken(resp.data.mfa_token!);
+      if (resp.data.enrollment_required) {
+        const enroll = await beginMFAEnrollment(resp.data.mfa_token!);
+        if (enroll.code !== 0) {
+          message.error(enroll.message || '无法开始绑定两步验证');
+          return;
+        }
+        setEnrollment(enroll.data);
+        setStep('enroll');
+      } else {
+        setStep('verify');
+      }
+    } catch (err) {
+      message.error(tooManyAttempts(err) ? '登录失败次数过多，请稍后再试' : '登录失败，请检查用户名和密码');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const onCode = async (values: CodeForm) => {
+    setLoading(true);
+    try {
+      const resp = step === 'enroll'
+        ? await confirmMFAEnrollment(mfaToken, values.code)
+        : await verifyMFA(mfaToken, values.code);
       if (resp.code === 0) {
-        setToken(resp.data.token);
-        navigate('/users');
+        finish(resp.data);
       } else {
-        message.error(resp.message || '登录失败');
+        message.error(resp.message || '验证失败');
+      }
+    } catch (err) {
+      message.error(tooManyAttempts(err) ? '登录失败次数过多，请稍后再试' : '验证码错误或已过期，请重试');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const onChangePassword = async (values: ChangePasswordForm) => {
+    setLoading(true);
+    try {
+      const resp = await changeOwnPassword(password, values.new_password);
+      if (resp.code !== 0) {
+        const violations = resp.data?.violations ?? [];
+        message.error(violations.length ? violations.map((v) => v.message).join('；') : resp.message || '修改密码失败');
+        return;
       }
+      message.success('密码已修改');
+      navigate('/users');
     } catch {
-      message.error('登录失败，请检查用户名和密码');
+      message.error('修改密码失败，请重试');
     } finally {
       setLoading(false);
     }
   };
 
+  const onForgot = async (values: ForgotForm) => {
+    setLoading(true);
+    try {
+      await forgotPassword(values.login);
+      message.success('如果账号存在，重置密码链接已发送到其注册邮箱');
+      setStep('password');
+    } catch (err) {
+      const status = isAxiosError(err) ? err.response?.status : undefined;
+      message.error(
+        status === 429 ? '请求过于频繁，请稍后再试'
+          : status === 503 ? '未配置邮件服务，请联系管理员重置密码'
+            : '发送重置链接失败，请重试',
+      );
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const restart = () => {
+    setStep('password');
+    setMFAToken('');
+    setEnrollment(null);
+    setPassword('');
+  };
+
   return (
     <div style={{
       display: 'flex',
@@ -45,19 +183,95 @@ export default function Login() {
         <Title level={3} style={{ textAlign: 'center', marginBottom: 32 }}>
           用户管理系统
         </Title>
-        <Form onFinish={onFinish} size="large">
-          <Form.Item name="username" rules={[{ required: true, message: '请输入用户名' }]}>
-            <Input prefix={<UserOutlined />} placeholder="用户名" />
-          </Form.Item>
-          <Form.Item name="password" rules={[{ required: true, message: '请输入密码' }]}>
-            <Input.Password prefix={<LockOutlined />} placeholder="密码" />
-          </Form.Item>
-          <Form.Item>
-            <Button type="primary" htmlType="submit" loading={loading} block>
-              登录
+        {step === 'password' ? (
+          <Form onFinish={onFinish} size="large">
+            <Form.Item name="username" rules={[{ required: true, message: '请输入用户名' }]}>
+              <Input prefix={<UserOutlined />} placeholder="用户名" />
+            </Form.Item>
+            <Form.Item name="password" rules={[{ required: true, message: '请输入密码' }]}>
+              <Input.Password prefix={<LockOutlined />} placeholder="密码" />
+            </Form.Item>
+            <Form.Item>
+              <Button type="primary" htmlType="submit" loading={loading} block>
+                登录
+              </Button>
+            </Form.Item>
+            <Button type="link" onClick={() => setStep('forgot')} block>
+              忘记密码？
+            </Button>
+          </Form>
+        ) : step === 'forgot' ? (
+          <Form onFinish={onForgot} size="large">
+            <Paragraph>输入用户名或注册邮箱，我们会向账号的注册邮箱发送重置密码链接。</Paragraph>
+            <Form.Item name="login" rules={[{ required: true, message: '请输入用户名或邮箱' }]}>
+              <Input prefix={<MailOutlined />} placeholder="用户名或邮箱" autoFocus />
+            </Form.Item>
+            <Form.Item>
+              <Button type="primary" htmlType="submit" loading={loading} block>
+                发送重置链接
+              </Button>
+            </Form.Item>
+            <Button type="link" onClick={restart} block>
+              返回登录
+            </Button>
+          </Form>
+        ) : step === 'change' ? (
+          <Form onFinish={onChangePassword} size="large">
+            <Paragraph>密码已过期或已被管理员重置，请设置新密码后继续。</Paragraph>
+            <Form.Item name="new_password" rules={[{ required: true, message: '请输入新密码' }]}>
+              <Input.Password prefix={<LockOutlined />} placeholder="新密码" autoComplete="new-password" autoFocus />
+            </Form.Item>
+            <Form.Item
+              name="confirm_password"
+              dependencies={['new_password']}
+              rules={[
+                { required: true, message: '请确认新密码' },
+                ({ getFieldValue }) => ({
+                  validator(_, value) {
+                    if (!value || getFieldValue('new_password') === value) {
+                      return Promise.resolve();
+                    }
+                    return Promise.reject(new Error('两次输入的密码不一致'));
+                  },
+                }),
+              ]}
+            >
+              <Input.Password prefix={<LockOutlined />} placeholder="确认新密码" autoComplete="new-password" />
+            </Form.Item>
+            <Form.Item>
+              <Button type="primary" htmlType="submit" loading={loading} block>
+                修改密码
+              </Button>
+            </Form.Item>
+          </Form>
+        ) : (
+          <Form onFinish={onCode} size="large">
+            {step === 'enroll' && enrollment ? (
+              <>
+                <Paragraph>所在用户组要求启用两步验证。请使用身份验证器 App 扫描二维码，然后输入显示的 6 位验证码。</Paragraph>
+                <div style={{ display: 'flex', justifyContent: 'center', marginBottom: 16 }}>
+                  <QRCode value={enrollment.provisioning_uri} />
+                </div>
+                <Paragraph style={{ textAlign: 'center' }}>
+                  无法扫码时手动输入密钥：<Text code copyable>{enrollment.secret}</Text>
+                </Paragraph>
+              </>
+            ) : (
+              <Paragraph>请输入身份验证器 App 中的 6 位验证码，或一个恢复码。</Paragraph>
+            )}
+            <Form.Item name="code" rules={[{ required: true, message: '请输入验证码' }]}>
+              <Input prefix={<SafetyOutlined />} placeholder="验证码" autoComplete="one-time-code" autoFocus />
+            </Form.Item>
+            <Form.Item>
+              <Button type="primary" htmlType="submit" loading={loading} block>
+                验证
+              </Button>
+            </Form.Item>
+            <Button type="link" onClick={restart} block>
+              返回重新登录
             </Button>
-          </Form.Item>
-        </Form>
+          </Form>
+        )}
       </Card>
     </div>
   );
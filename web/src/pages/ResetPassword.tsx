@@ -0,0 +1,109 @@
+import { useState, useEffect } from 'react';
+import { useNavigate, useSearchParams } from 'react-router-dom';
+import { Card, Form, Input, Button, message, Typography, Result, Spin } from 'antd';
+import { LockOutlined } from '@ant-design/icons';
+import { checkResetLink, resetPasswordWithLink, type ResetLink } from '../api/auth';
+
+const { Title, Paragraph } = Typography;
+
+interface ResetForm {
+  new_password: string;
+  confirm_password: string;
+}
+
+// ResetPassword is the page emailed password reset links point to.
+export default function ResetPassword() {
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get('token') ?? '';
+  const [link, setLink] = useState<ResetLink | null>(null);
+  const [checking, setChecking] = useState(true);
+  const [loading, setLoading] = useState(false);
+  const navigate = useNavigate();
+
+  useEffect(() => {
+    if (!token) {
+      setChecking(false);
+      return;
+    }
+    checkResetLink(token)
+      .then((resp) => setLink(resp.code === 0 ? resp.data : null))
+      .catch(() => setLink(null))
+      .finally(() => setChecking(false));
+  }, [token]);
+
+  const onFinish = async (values: ResetForm) => {
+    setLoading(true);
+    try {
+      const resp = await resetPasswordWithLink(token, values.new_password);
+      if (resp.code !== 0) {
+        const violations = resp.data?.violations ?? [];
+        message.error(violations.length ? violations.map((v) => v.message).join('；') : resp.message || '重置密码失败');
+        return;
+      }
+      message.success('密码已重置，请使用新密码登录');
+      navigate('/login');
+    } catch {
+      message.error('重置密码失败，请重试');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div style={{
+      display: 'flex',
+      justifyContent: 'center',
+      alignItems: 'center',
+      minHeight: '100vh',
+      background: '#f0f2f5',
+    }}>
+      <Card style={{ width: 400 }}>
+        <Title level={3} style={{ textAlign: 'center', marginBottom: 32 }}>
+          重置密码
+        </Title>
+        {checking ? (
+          <div style={{ textAlign: 'center' }}><Spin /></div>
+        ) : !link ? (
+          <Result
+            status="warning"
+            title="链接无效或已过期"
+            subTitle="请重新申请重置密码链接。"
+            extra={<Button type="primary" onClick={() => navigate('/login')}>返回登录</Button>}
+          />
+        ) : (
+          <Form onFinish={onFinish} size="large">
+            <Paragraph>
+              {link.display_name || link.username}，请为账号 {link.username} 设置新密码。
+              链接有效期至 {new Date(link.expires_at).toLocaleString()}。
+            </Paragraph>
+            <Form.Item name="new_password" rules={[{ required: true, message: '请输入新密码' }]}>
+              <Input.Password prefix={<LockOutlined />} placeholder="新密码" autoComplete="new-password" autoFocus />
+            </Form.Item>
+            <Form.Item
+              name="confirm_password"
+              dependencies={['new_password']}
+              rules={[
+                { required: true, message: '请确认新密码' },
+                ({ getFieldValue }) => ({
+                  validator(_, value) {
+                    if (!value || getFieldValue('new_password') === value) {
+                      return Promise.resolve();
+                    }
+                    return Promise.reject(new Error('两次输入的密码不一致'));
+                  },
+                }),
+              ]}
+            >
+              <Input.Password prefix={<LockOutlined />} placeholder="确认新密码" autoComplete="new-password" />
+            </Form.Item>
+            <Form.Item>
+              <Button type="primary" htmlType="submit" loading={loading} block>
+                重置密码
+              </Button>
+            </Form.Item>
+          </Form>
+        )}
+      </Card>
+    </div>
+  );
+}
@@ -1,4 +1,5 @@
 import { useState, useEffect, useCallback } from 'react';
+import { isAxiosError } from 'axios';
 import { useParams, useNavigate } from 'react-router-dom';
 import {
   Card,
@@ -10,10 +11,11 @@ import {
   Table,
   Typography,
   Divider,
+  Alert,
 } from 'antd';
 import { ArrowLeftOutlined } from '@ant-design/icons';
 import type { ColumnsType } from 'antd/es/table';
-import { getUser, updateUser, changePassword, getUserGroups } from '../api/users';
+import { getUser, updateUser, changePassword, getUserGroups, unlockUser, sendPasswordResetLink, resendInvitation } from '../api/users';
 import type { User, UpdateUserReq } from '../api/users';
 import type { Group } from '../api/groups';
 
@@ -105,6 +107,39 @@ export default function UserDetail() {
     }
   };
 
+  const handleUnlock = async () => {
+    if (!id) return;
+    try {
+      await unlockUser(id);
+      message.success('已解除锁定');
+      void fetchUser();
+    } catch {
+      message.error('解除锁定失败');
+    }
+  };
+
+  const handleSendResetLink = async () => {
+    if (!id) return;
+    try {
+      await sendPasswordResetLink(id);
+      message.success('重置密码链接已发送到用户邮箱');
+    } catch (err) {
+      message.error(isAxiosError(err) && err.response?.status === 503 ? '未配置邮件服务器，无法发送' : '发送重置链接失败');
+    }
+  };
+
+  const handleResendInvitation = async () => {
+    if (!id) return;
+    try {
+      await resendInvitation(id);
+      message.success('已重新发送邀请邮件');
+    } catch (err) {
+      message.error(isAxiosError(err) && err.response?.status === 503 ? '未配置邮件服务器，无法发送' : '发送邀请失败');
+    }
+  };
+
+  const locked = !!user?.locked_until && new Date(user.locked_until) > new Date();
+
   const groupColumns: ColumnsType<Group> = [
     { title: '组名称', dataIndex: 'name', key: 'name' },
     { title: '描述', dataIndex: 'description', key: 'description' },
@@ -124,6 +159,26 @@ export default function UserDetail() {
         <Title level={4} style={{ margin: 0 }}>用户详情</Title>
       </Space>
 
+      {locked && (
+        <Alert
+          type="warning"
+          showIcon
+          style={{ marginBottom: 16 }}
+          message={`登录失败次数过多，账号已锁定至 ${new Date(user!.locked_until!).toLocaleString()}`}
+          action={<Button size="small" onClick={handleUnlock}>解除锁定</Button>}
+        />
+      )}
+
+      {user?.status === 'pending' && (
+        <Alert
+          type="info"
+          showIcon
+          style={{ marginBottom: 16 }}
+          message="用户尚未接受邀请，设置密码后账号才会启用"
+          action={<Button size="small" onClick={handleResendInvitation}>重新发送邀请</Button>}
+        />
+      )}
+
       <Card title="基本信息" loading={loading} style={{ marginBottom: 16 }}>
         <Form form={infoForm} layout="vertical" onFinish={handleSave} style={{ maxWidth: 500 }}>
           <Form.Item label="用户名">
@@ -191,9 +246,12 @@ export default function UserDetail() {
             <Input.Password />
           </Form.Item>
           <Form.Item>
-            <Button type="primary" htmlType="submit" loading={changingPwd}>
-              修改密码
-            </Button>
+            <Space>
+              <Button type="primary" htmlType="submit" loading={changingPwd}>
+                修改密码
+              </Button>
+              <Button onClick={handleSendResetLink}>发送重置链接</Button>
+            </Space>
           </Form.Item>
         </Form>
       </Card>
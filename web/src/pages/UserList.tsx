@@ -11,20 +11,36 @@ import {
   message,
   Popconfirm,
   Switch,
+  Checkbox,
   Typography,
 } from 'antd';
-import { PlusOutlined, SearchOutlined } from '@ant-design/icons';
+import { isAxiosError } from 'axios';
+import { DownloadOutlined, PlusOutlined, SearchOutlined, UploadOutlined } from '@ant-design/icons';
 import type { ColumnsType } from 'antd/es/table';
 import {
   listUsers,
   createUser,
+  inviteUser,
   deleteUser,
   setUserStatus,
+  exportUsersCSV,
 } from '../api/users';
 import type { User, CreateUserReq } from '../api/users';
+import UserImportModal from '../components/UserImportModal';
 
 const { Title } = Typography;
 
+// Pending users were invited and have not set their password yet.
+const statusTags: Record<User['status'], { color: string; label: string }> = {
+  enabled: { color: 'green', label: '启用' },
+  disabled: { color: 'red', label: '禁用' },
+  pending: { color: 'orange', label: '待激活' },
+};
+
+interface CreateUserForm extends CreateUserReq {
+  invite: boolean;
+}
+
 export default function UserList() {
   const navigate = useNavigate();
   const [loading, setLoading] = useState(false);
@@ -35,7 +51,9 @@ export default function UserList() {
   const [search, setSearch] = useState('');
   const [modalOpen, setModalOpen] = useState(false);
   const [creating, setCreating] = useState(false);
-  const [form] = Form.useForm<CreateUserReq>();
+  const [importOpen, setImportOpen] = useState(false);
+  const [form] = Form.useForm<CreateUserForm>();
+  const invite = Form.useWatch('invite', form);
 
   const fetchUsers = useCallback(async () => {
     setLoading(true);
@@ -55,21 +73,49 @@ export default function UserList() {
     void fetchUsers();
   }, [fetchUsers]);
 
-  const handleCreate = async (values: CreateUserReq) => {
+  const handleCreate = async ({ invite, password, ...values }: CreateUserForm) => {
     setCreating(true);
     try {
-      await createUser(values);
-      message.success('创建用户成功');
+      if (invite) {
+        await inviteUser(values);
+        message.success('已发送邀请邮件');
+      } else {
+        await createUser({ ...values, password });
+        message.success('创建用户成功');
+      }
       setModalOpen(false);
       form.resetFields();
       void fetchUsers();
-    } catch {
-      message.error('创建用户失败');
+    } catch (err) {
+      const status = isAxiosError(err) ? err.response?.status : undefined;
+      if (invite && status === 502) {
+        message.warning('用户已创建，但邀请邮件发送失败，请稍后在用户详情中重新发送');
+        setModalOpen(false);
+        form.resetFields();
+        void fetchUsers();
+      } else {
+        message.error(invite && status === 503 ? '未配置邮件服务器，无法邀请用户' : '创建用户失败');
+      }
     } finally {
       setCreating(false);
     }
   };
 
+  // Exports the users matching the current search.
+  const handleExport = async () => {
+    try {
+      const resp = await exportUsersCSV({ search: search || undefined });
+      const url = URL.createObjectURL(resp.data);
+      const a = document.createElement('a');
+      a.href = url;
+      a.download = 'users.csv';
+      a.click();
+      URL.revokeObjectURL(url);
+    } catch {
+      message.error('导出用户失败');
+    }
+  };
+
   const handleDelete = async (id: string) => {
     try {
       await deleteUser(id);
@@ -111,9 +157,7 @@ export default function UserList() {
       dataIndex: 'status',
       key: 'status',
       render: (status: User['status']) => (
-        <Tag color={status === 'enabled' ? 'green' : 'red'}>
-          {status === 'enabled' ? '启用' : '禁用'}
-        </Tag>
+        <Tag color={statusTags[status].color}>{statusTags[status].label}</Tag>
       ),
     },
     {
@@ -132,6 +176,7 @@ export default function UserList() {
           <Switch
             size="small"
             checked={record.status === 'enabled'}
+            disabled={record.status === 'pending'}
             onChange={(checked) => handleStatusChange(record.id, checked)}
           />
           <Popconfirm
@@ -166,6 +211,12 @@ export default function UserList() {
             allowClear
             style={{ width: 240 }}
           />
+          <Button icon={<DownloadOutlined />} onClick={handleExport}>
+            导出 CSV
+          </Button>
+          <Button icon={<UploadOutlined />} onClick={() => setImportOpen(true)}>
+            导入 CSV
+          </Button>
           <Button type="primary" icon={<PlusOutlined />} onClick={() => setModalOpen(true)}>
             创建用户
           </Button>
@@ -224,13 +275,18 @@ export default function UserList() {
           >
             <Input />
           </Form.Item>
-          <Form.Item
-            name="password"
-            label="密码"
-            rules={[{ required: true, message: '请输入密码' }]}
-          >
-            <Input.Password />
+          <Form.Item name="invite" valuePropName="checked">
+            <Checkbox>发送邀请邮件，由用户自行设置密码</Checkbox>
           </Form.Item>
+          {!invite && (
+            <Form.Item
+              name="password"
+              label="密码"
+              rules={[{ required: true, message: '请输入密码' }]}
+            >
+              <Input.Password />
+            </Form.Item>
+          )}
           <Form.Item name="phone" label="手机号">
             <Input />
           </Form.Item>
@@ -240,12 +296,18 @@ export default function UserList() {
                 取消
               </Button>
               <Button type="primary" htmlType="submit" loading={creating}>
-                创建
+                {invite ? '邀请' : '创建'}
               </Button>
             </Space>
           </Form.Item>
         </Form>
       </Modal>
+
+      <UserImportModal
+        open={importOpen}
+        onClose={() => setImportOpen(false)}
+        onImported={() => void fetchUsers()}
+      />
     </div>
   );
 }